@@ -0,0 +1,275 @@
+package autopilot
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BetweennessCentrality is a metric which measures how central each node is
+// within the channel graph. The betweenness centrality of a node is the sum,
+// over all pairs of other nodes, of the fraction of shortest paths between
+// them which pass through the node. Nodes with a high centrality are well
+// positioned to forward payments, as many routes go through them.
+//
+// The centrality is computed using Brandes' algorithm, which runs a breadth
+// first search from each node of the graph. As the searches are independent
+// of each other, they're spread across a configurable number of workers.
+type BetweennessCentrality struct {
+	// workers is the number of goroutines the searches are spread across.
+	workers int
+
+	// centrality is the betweenness centrality of each node of the graph
+	// the metric was last refreshed with.
+	centrality map[NodeID]float64
+
+	// min and max are the smallest and largest centrality of any node,
+	// which are used to normalize the centrality of each node.
+	min, max float64
+}
+
+// NewBetweennessCentralityMetric creates a new betweenness centrality metric
+// which spreads its computation across the passed number of workers.
+func NewBetweennessCentralityMetric(workers int) (*BetweennessCentrality,
+	error) {
+
+	if workers <= 0 {
+		return nil, fmt.Errorf("number of workers must be positive, "+
+			"got %v", workers)
+	}
+
+	return &BetweennessCentrality{
+		workers: workers,
+	}, nil
+}
+
+// Refresh computes the betweenness centrality of every node within the passed
+// channel graph, replacing the result of any earlier refresh. The graph is
+// treated as undirected and unweighted: each channel connects its two nodes
+// in both directions, and parallel channels between the same nodes count
+// only once.
+func (bc *BetweennessCentrality) Refresh(graph ChannelGraph) error {
+	g, err := newCentralityGraph(graph)
+	if err != nil {
+		return err
+	}
+
+	numNodes := len(g.nodes)
+	centrality := make([]float64, numNodes)
+
+	// Each worker accumulates the dependencies of the searches it runs
+	// into its own slice, which are summed up once all searches are done.
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		sources = make(chan int)
+	)
+	for i := 0; i < bc.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s := newCentralitySearch(numNodes)
+			partial := make([]float64, numNodes)
+			for source := range sources {
+				s.accumulate(g, source, partial)
+			}
+
+			mu.Lock()
+			for i, c := range partial {
+				centrality[i] += c
+			}
+			mu.Unlock()
+		}()
+	}
+	for source := 0; source < numNodes; source++ {
+		sources <- source
+	}
+	close(sources)
+	wg.Wait()
+
+	// As the graph is undirected, each shortest path was found once from
+	// either of its ends, so we'll halve the centrality of each node.
+	bc.centrality = make(map[NodeID]float64, numNodes)
+	bc.min, bc.max = 0, 0
+	for i, c := range centrality {
+		c /= 2
+		bc.centrality[g.nodes[i]] = c
+
+		if i == 0 || c < bc.min {
+			bc.min = c
+		}
+		if i == 0 || c > bc.max {
+			bc.max = c
+		}
+	}
+
+	return nil
+}
+
+// GetMetric returns the betweenness centrality of each node of the graph the
+// metric was last refreshed with. If normalize is true, then the centrality of
+// each node is scaled to the range [0, 1], relative to the nodes with the
+// smallest and largest centrality.
+func (bc *BetweennessCentrality) GetMetric(normalize bool) map[NodeID]float64 {
+	metric := make(map[NodeID]float64, len(bc.centrality))
+	for node, c := range bc.centrality {
+		if normalize {
+			c = bc.normalize(c)
+		}
+		metric[node] = c
+	}
+
+	return metric
+}
+
+// normalize scales the passed centrality to the range [0, 1]. If all nodes
+// have the same centrality, then it's normalized to zero.
+func (bc *BetweennessCentrality) normalize(c float64) float64 {
+	if bc.max == bc.min {
+		return 0
+	}
+
+	return (c - bc.min) / (bc.max - bc.min)
+}
+
+// centralityGraph is a compact representation of a channel graph, in which
+// each node is identified by its index, and its neighbours are kept within an
+// adjacency list.
+type centralityGraph struct {
+	// nodes maps the index of each node to its ID.
+	nodes []NodeID
+
+	// adj holds the indexes of the neighbours of each node.
+	adj [][]int
+}
+
+// newCentralityGraph builds the compact representation of the passed channel
+// graph.
+func newCentralityGraph(graph ChannelGraph) (*centralityGraph, error) {
+	g := &centralityGraph{}
+
+	index := make(map[NodeID]int)
+	nodeIndex := func(id NodeID) int {
+		i, ok := index[id]
+		if !ok {
+			i = len(g.nodes)
+			index[id] = i
+			g.nodes = append(g.nodes, id)
+			g.adj = append(g.adj, nil)
+		}
+
+		return i
+	}
+
+	// Each channel is encountered from both of its ends, and nodes may
+	// share several channels, so we'll track the pairs of nodes we've
+	// already connected.
+	connected := make(map[[2]int]struct{})
+	err := graph.ForEachNode(func(node Node) error {
+		u := nodeIndex(NewNodeID(node.PubKey()))
+
+		return node.ForEachChannel(func(edge ChannelEdge) error {
+			v := nodeIndex(NewNodeID(edge.Peer.PubKey()))
+			if u == v {
+				return nil
+			}
+
+			pair := [2]int{u, v}
+			if v < u {
+				pair = [2]int{v, u}
+			}
+			if _, ok := connected[pair]; ok {
+				return nil
+			}
+			connected[pair] = struct{}{}
+
+			g.adj[u] = append(g.adj[u], v)
+			g.adj[v] = append(g.adj[v], u)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// centralitySearch holds the state of a single breadth first search of
+// Brandes' algorithm. It's reused across searches to avoid allocating it for
+// each node of the graph.
+type centralitySearch struct {
+	// dist is the distance of each node from the source, or -1 if it
+	// wasn't reached yet.
+	dist []int
+
+	// sigma is the number of shortest paths from the source to each node.
+	sigma []float64
+
+	// delta is the dependency of the source on each node.
+	delta []float64
+
+	// pred holds the predecessors of each node along its shortest paths
+	// from the source.
+	pred [][]int
+
+	// order holds the reached nodes in the order of their distance from
+	// the source, which doubles as the queue of the search.
+	order []int
+}
+
+// newCentralitySearch allocates the state of a search within a graph of the
+// passed number of nodes.
+func newCentralitySearch(numNodes int) *centralitySearch {
+	return &centralitySearch{
+		dist:  make([]int, numNodes),
+		sigma: make([]float64, numNodes),
+		delta: make([]float64, numNodes),
+		pred:  make([][]int, numNodes),
+		order: make([]int, 0, numNodes),
+	}
+}
+
+// accumulate runs a breadth first search from the passed source, and adds the
+// dependency of the source on each other node to its centrality.
+func (s *centralitySearch) accumulate(g *centralityGraph, source int,
+	centrality []float64) {
+
+	for i := range s.dist {
+		s.dist[i] = -1
+		s.sigma[i] = 0
+		s.delta[i] = 0
+		s.pred[i] = s.pred[i][:0]
+	}
+	s.order = s.order[:0]
+
+	s.dist[source] = 0
+	s.sigma[source] = 1
+	s.order = append(s.order, source)
+
+	// Count the shortest paths to each node, recording its predecessors
+	// along them.
+	for i := 0; i < len(s.order); i++ {
+		u := s.order[i]
+		for _, v := range g.adj[u] {
+			if s.dist[v] < 0 {
+				s.dist[v] = s.dist[u] + 1
+				s.order = append(s.order, v)
+			}
+			if s.dist[v] == s.dist[u]+1 {
+				s.sigma[v] += s.sigma[u]
+				s.pred[v] = append(s.pred[v], u)
+			}
+		}
+	}
+
+	// Then, starting from the nodes farthest from the source, propagate
+	// the dependencies back to their predecessors.
+	for i := len(s.order) - 1; i > 0; i-- {
+		w := s.order[i]
+		for _, v := range s.pred[w] {
+			s.delta[v] += s.sigma[v] / s.sigma[w] * (1 + s.delta[w])
+		}
+		centrality[w] += s.delta[w]
+	}
+}
@@ -0,0 +1,110 @@
+package autopilot
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcutil"
+)
+
+// TestBetweennessCentralityEmptyGraph tests that the centrality of an empty
+// graph can be computed.
+func TestBetweennessCentralityEmptyGraph(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewBetweennessCentralityMetric(0); err == nil {
+		t.Fatalf("expected metric without workers to be refused")
+	}
+
+	metric, err := NewBetweennessCentralityMetric(1)
+	if err != nil {
+		t.Fatalf("unable to create metric: %v", err)
+	}
+	if err := metric.Refresh(newMemChannelGraph()); err != nil {
+		t.Fatalf("unable to refresh metric: %v", err)
+	}
+	if centrality := metric.GetMetric(true); len(centrality) != 0 {
+		t.Fatalf("expected no centrality, got %v", centrality)
+	}
+}
+
+// TestBetweennessCentrality tests the centrality computed for a small graph
+// with a known centrality, using varying numbers of workers.
+func TestBetweennessCentrality(t *testing.T) {
+	t.Parallel()
+
+	// We'll build the following graph, in which A, B, C and D form a path,
+	// and E is a leaf of B. The channel between A and B is duplicated,
+	// which mustn't affect the centrality.
+	//
+	//   A == B -- C -- D
+	//        |
+	//        E
+	keys := make([]*btcec.PublicKey, 5)
+	for i := range keys {
+		key, err := randKey()
+		if err != nil {
+			t.Fatalf("unable to generate key: %v", err)
+		}
+		keys[i] = key
+	}
+	a, b, c, d, e := keys[0], keys[1], keys[2], keys[3], keys[4]
+
+	graph := newMemChannelGraph()
+	for _, channel := range [][2]*btcec.PublicKey{
+		{a, b}, {a, b}, {b, c}, {c, d}, {b, e},
+	} {
+		_, _, err := graph.addRandChannel(
+			channel[0], channel[1], btcutil.SatoshiPerBitcoin,
+		)
+		if err != nil {
+			t.Fatalf("unable to add channel: %v", err)
+		}
+	}
+
+	// B lies on the shortest paths between A, E and the pairs (A, C),
+	// (A, D), (E, C), (E, D), and (A, E), whereas C lies on those between
+	// D and A, B and E.
+	expected := map[NodeID]float64{
+		NewNodeID(a): 0,
+		NewNodeID(b): 5,
+		NewNodeID(c): 3,
+		NewNodeID(d): 0,
+		NewNodeID(e): 0,
+	}
+	expectedNormalized := map[NodeID]float64{
+		NewNodeID(a): 0,
+		NewNodeID(b): 1,
+		NewNodeID(c): 0.6,
+		NewNodeID(d): 0,
+		NewNodeID(e): 0,
+	}
+
+	assertMetric := func(metric map[NodeID]float64,
+		expected map[NodeID]float64) {
+
+		if len(metric) != len(expected) {
+			t.Fatalf("expected %v nodes, got %v", len(expected),
+				len(metric))
+		}
+		for node, c := range expected {
+			if metric[node] != c {
+				t.Fatalf("expected centrality %v for node %x, "+
+					"got %v", c, node[:], metric[node])
+			}
+		}
+	}
+
+	for _, workers := range []int{1, 2, 7} {
+		metric, err := NewBetweennessCentralityMetric(workers)
+		if err != nil {
+			t.Fatalf("unable to create metric: %v", err)
+		}
+		if err := metric.Refresh(graph); err != nil {
+			t.Fatalf("unable to refresh metric: %v", err)
+		}
+
+		assertMetric(metric.GetMetric(false), expected)
+		assertMetric(metric.GetMetric(true), expectedNormalized)
+	}
+}
@@ -81,6 +81,11 @@ type BreachConfig struct {
 	// breached channels. This is used in conjunction with DB to recover
 	// from crashes, restarts, or other failures.
 	Store RetributionStore
+
+	// NotifyClosedChannel is called with the funding outpoint of a
+	// breached channel once justice was served, and the channel is fully
+	// closed.
+	NotifyClosedChannel func(wire.OutPoint)
 }
 
 // breachArbiter is a special subsystem which is responsible for watching and
@@ -656,6 +661,8 @@ secondLevelCheck:
 			return
 		}
 
+		b.cfg.NotifyClosedChannel(breachInfo.chanPoint)
+
 		// Justice has been carried out; we can safely delete the
 		// retribution info from the database.
 		err = b.cfg.Store.Remove(&breachInfo.chanPoint)
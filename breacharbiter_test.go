@@ -1227,10 +1227,11 @@ func createTestArbiter(t *testing.T, chainEvents *contractcourt.ChainEventSubscr
 		SubscribeChannelEvents: func(_ wire.OutPoint) (*contractcourt.ChainEventSubscription, error) {
 			return chainEvents, nil
 		},
-		Signer:             signer,
-		Notifier:           notifier,
-		PublishTransaction: func(_ *wire.MsgTx) error { return nil },
-		Store:              store,
+		Signer:              signer,
+		Notifier:            notifier,
+		PublishTransaction:  func(_ *wire.MsgTx) error { return nil },
+		Store:               store,
+		NotifyClosedChannel: func(wire.OutPoint) {},
 	})
 
 	if err := ba.Start(); err != nil {
@@ -0,0 +1,48 @@
+package chainntnfs
+
+import (
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// SpendHintCache is an interface whose implementations persist, for each
+// watched outpoint, the height from which a rescan for its spend must start.
+// As the hints survive restarts, a notifier can resume watching an outpoint
+// from the last height it scanned rather than from the creation of the
+// output.
+type SpendHintCache interface {
+	// CommitSpendHint records height as the height from which a rescan
+	// for the spend of each of the passed outpoints must start.
+	CommitSpendHint(height uint32, ops ...wire.OutPoint) error
+
+	// QuerySpendHint returns the height from which a rescan for the spend
+	// of the passed outpoint must start, or zero if no hint is known.
+	QuerySpendHint(op wire.OutPoint) (uint32, error)
+
+	// PurgeSpendHint removes the spend hints of the passed outpoints.
+	PurgeSpendHint(ops ...wire.OutPoint) error
+}
+
+// ConfirmHintCache is an interface whose implementations persist, for each
+// watched transaction, the height from which a rescan for its confirmation
+// must start.
+type ConfirmHintCache interface {
+	// CommitConfirmHint records height as the height from which a rescan
+	// for the confirmation of each of the passed transactions must start.
+	CommitConfirmHint(height uint32, txids ...chainhash.Hash) error
+
+	// QueryConfirmHint returns the height from which a rescan for the
+	// confirmation of the passed transaction must start, or zero if no
+	// hint is known.
+	QueryConfirmHint(txid chainhash.Hash) (uint32, error)
+
+	// PurgeConfirmHint removes the confirmation hints of the passed
+	// transactions.
+	PurgeConfirmHint(txids ...chainhash.Hash) error
+}
+
+// HeightHintCache is a cache of both spend and confirmation height hints.
+type HeightHintCache interface {
+	SpendHintCache
+	ConfirmHintCache
+}
@@ -10,9 +10,9 @@ import (
 // createNewNotifier creates a new instance of the ChainNotifier interface
 // implemented by NeutrinoNotifier.
 func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
-	if len(args) != 1 {
+	if len(args) != 1 && len(args) != 2 {
 		return nil, fmt.Errorf("incorrect number of arguments to .New(...), "+
-			"expected 1, instead passed %v", len(args))
+			"expected 1 or 2, instead passed %v", len(args))
 	}
 
 	config, ok := args[0].(*neutrino.ChainService)
@@ -21,7 +21,19 @@ func createNewNotifier(args ...interface{}) (chainntnfs.ChainNotifier, error) {
 			"incorrect, expected a *neutrino.ChainService")
 	}
 
-	return New(config)
+	// The height hint cache is optional, without it hints simply aren't
+	// persisted across restarts.
+	var hintCache chainntnfs.HeightHintCache
+	if len(args) == 2 {
+		hintCache, ok = args[1].(chainntnfs.HeightHintCache)
+		if !ok {
+			return nil, fmt.Errorf("second argument to " +
+				"neutrinonotify.New is incorrect, expected a " +
+				"chainntnfs.HeightHintCache")
+		}
+	}
+
+	return New(config, hintCache)
 }
 
 // init registers a driver for the NeutrinoNotify concrete implementation of
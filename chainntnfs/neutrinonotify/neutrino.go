@@ -67,6 +67,12 @@ type NeutrinoNotifier struct {
 
 	txConfNotifier *chainntnfs.TxConfNotifier
 
+	// hintCache, if non-nil, persists the heights from which rescans for
+	// the spends and confirmations of watched outpoints and transactions
+	// must start, allowing them to resume from the last scanned height
+	// after a restart.
+	hintCache chainntnfs.HeightHintCache
+
 	blockEpochClients map[uint64]*blockEpochRegistration
 
 	rescanErr <-chan error
@@ -84,8 +90,11 @@ var _ chainntnfs.ChainNotifier = (*NeutrinoNotifier)(nil)
 // of the ChainNotifier interface.
 //
 // NOTE: The passed neutrino node should already be running and active before
-// being passed into this function.
-func New(node *neutrino.ChainService) (*NeutrinoNotifier, error) {
+// being passed into this function. The hint cache may be nil, in which case
+// height hints aren't persisted.
+func New(node *neutrino.ChainService,
+	hintCache chainntnfs.HeightHintCache) (*NeutrinoNotifier, error) {
+
 	notifier := &NeutrinoNotifier{
 		notificationCancels:  make(chan interface{}),
 		notificationRegistry: make(chan interface{}),
@@ -96,6 +105,8 @@ func New(node *neutrino.ChainService) (*NeutrinoNotifier, error) {
 
 		p2pNode: node,
 
+		hintCache: hintCache,
+
 		rescanErr: make(chan error),
 
 		chainUpdates: chainntnfs.NewConcurrentQueue(10),
@@ -295,6 +306,13 @@ func (n *NeutrinoNotifier) notificationDispatcher() {
 				n.spendNotifications[op][msg.spendID] = msg
 
 			case *confirmationsNotification:
+				// If we've already scanned past the passed
+				// height hint for this transaction before,
+				// we'll resume from where we left off.
+				msg.heightHint = n.confirmHint(
+					msg.TxID, msg.heightHint,
+				)
+
 				chainntnfs.Log.Infof("New confirmations subscription: "+
 					"txid=%v, numconfs=%v, height_hint=%v",
 					msg.TxID, msg.NumConfirmations, msg.heightHint)
@@ -359,6 +377,12 @@ func (n *NeutrinoNotifier) notificationDispatcher() {
 				if err != nil {
 					chainntnfs.Log.Error(err)
 				}
+
+				// None of the outpoints and transactions
+				// still being watched were spent or confirmed
+				// within this block, so future rescans for
+				// them can start from it.
+				n.commitHeightHints(update.height)
 				continue
 			}
 
@@ -382,6 +406,12 @@ func (n *NeutrinoNotifier) notificationDispatcher() {
 				chainntnfs.Log.Error(err)
 			}
 
+			// The disconnected block may be replaced by one
+			// spending or confirming a watched outpoint or
+			// transaction, so the hints are rewound to the new
+			// tip.
+			n.commitHeightHints(update.height - 1)
+
 		case err := <-n.rescanErr:
 			chainntnfs.Log.Errorf("Error during rescan: %v", err)
 
@@ -392,6 +422,50 @@ func (n *NeutrinoNotifier) notificationDispatcher() {
 	}
 }
 
+// confirmHint returns the height from which to scan for the confirmation of
+// the passed transaction, which is the later of the passed height hint and the
+// cached one.
+func (n *NeutrinoNotifier) confirmHint(txid *chainhash.Hash,
+	heightHint uint32) uint32 {
+
+	if n.hintCache == nil {
+		return heightHint
+	}
+
+	hint, err := n.hintCache.QueryConfirmHint(*txid)
+	if err != nil {
+		chainntnfs.Log.Errorf("Unable to query confirm hint for %v: %v",
+			txid, err)
+		return heightHint
+	}
+	if hint > heightHint {
+		return hint
+	}
+
+	return heightHint
+}
+
+// commitHeightHints records height as the height from which rescans for all
+// outpoints and transactions which are still being watched must start.
+func (n *NeutrinoNotifier) commitHeightHints(height uint32) {
+	if n.hintCache == nil {
+		return
+	}
+
+	ops := make([]wire.OutPoint, 0, len(n.spendNotifications))
+	for op := range n.spendNotifications {
+		ops = append(ops, op)
+	}
+	if err := n.hintCache.CommitSpendHint(height, ops...); err != nil {
+		chainntnfs.Log.Errorf("Unable to commit spend hints: %v", err)
+	}
+
+	txids := n.txConfNotifier.UnconfirmedTxIDs()
+	if err := n.hintCache.CommitConfirmHint(height, txids...); err != nil {
+		chainntnfs.Log.Errorf("Unable to commit confirm hints: %v", err)
+	}
+}
+
 // historicalConfDetails looks up whether a transaction is already included in a
 // block in the active chain and, if so, returns details about the confirmation.
 func (n *NeutrinoNotifier) historicalConfDetails(targetHash *chainhash.Hash,
@@ -508,6 +582,15 @@ func (n *NeutrinoNotifier) handleBlockConnected(newBlock *filteredBlock) error {
 			}
 
 			delete(n.spendNotifications, prevOut)
+
+			if n.hintCache != nil {
+				err := n.hintCache.PurgeSpendHint(prevOut)
+				if err != nil {
+					chainntnfs.Log.Errorf("Unable to purge "+
+						"spend hint for %v: %v",
+						prevOut, err)
+				}
+			}
 		}
 	}
 
@@ -572,6 +655,18 @@ func (n *NeutrinoNotifier) RegisterSpendNtfn(outpoint *wire.OutPoint,
 	currentHeight := n.bestHeight
 	n.heightMtx.RUnlock()
 
+	// If we've already scanned past the passed height hint for a spend of
+	// this outpoint before, we'll resume from where we left off.
+	if n.hintCache != nil {
+		hint, err := n.hintCache.QuerySpendHint(*outpoint)
+		if err != nil {
+			chainntnfs.Log.Errorf("Unable to query spend hint for "+
+				"%v: %v", outpoint, err)
+		} else if hint > heightHint {
+			heightHint = hint
+		}
+	}
+
 	chainntnfs.Log.Infof("New spend notification for outpoint=%v, "+
 		"height_hint=%v", outpoint, heightHint)
 
@@ -247,6 +247,10 @@ func (tcn *TxConfNotifier) DisconnectTip(blockHeight uint32) error {
 
 	for _, txHash := range tcn.confTxsByInitialHeight[blockHeight] {
 		for _, ntfn := range tcn.confNotifications[*txHash] {
+			// The transaction is no longer included in the chain,
+			// so its confirmation details are no longer valid.
+			ntfn.details = nil
+
 			// If notification has been dispatched with sufficient
 			// confirmations, notify of the reversal.
 			if ntfn.dispatched {
@@ -277,6 +281,22 @@ func (tcn *TxConfNotifier) DisconnectTip(blockHeight uint32) error {
 	return nil
 }
 
+// UnconfirmedTxIDs returns the txids of all watched transactions which
+// haven't been included in the chain yet.
+func (tcn *TxConfNotifier) UnconfirmedTxIDs() []chainhash.Hash {
+	var txids []chainhash.Hash
+	for txid, ntfns := range tcn.confNotifications {
+		for _, ntfn := range ntfns {
+			if ntfn.details == nil {
+				txids = append(txids, txid)
+				break
+			}
+		}
+	}
+
+	return txids
+}
+
 // TearDown is to be called when the owner of the TxConfNotifier is exiting.
 // This closes the event channels of all registered notifications that have
 // not been dispatched yet.
@@ -413,6 +413,72 @@ func TestTxConfTearDown(t *testing.T) {
 	}
 }
 
+// TestTxConfUnconfirmedTxIDs tests that the TxConfNotifier reports the txids
+// of all watched transactions which haven't been included in the chain,
+// including those whose confirming block was disconnected.
+func TestTxConfUnconfirmedTxIDs(t *testing.T) {
+	t.Parallel()
+
+	txConfNotifier := chainntnfs.NewTxConfNotifier(10, 100)
+
+	var (
+		tx1 = wire.MsgTx{Version: 1}
+		tx2 = wire.MsgTx{Version: 2}
+	)
+
+	tx1Hash := tx1.TxHash()
+	ntfn1 := chainntnfs.ConfNtfn{
+		TxID:             &tx1Hash,
+		NumConfirmations: 2,
+		Event:            chainntnfs.NewConfirmationEvent(),
+	}
+	txConfNotifier.Register(&ntfn1, nil)
+
+	tx2Hash := tx2.TxHash()
+	ntfn2 := chainntnfs.ConfNtfn{
+		TxID:             &tx2Hash,
+		NumConfirmations: 2,
+		Event:            chainntnfs.NewConfirmationEvent(),
+	}
+	txConfNotifier.Register(&ntfn2, nil)
+
+	assertUnconfirmed := func(expected ...chainhash.Hash) {
+		txids := txConfNotifier.UnconfirmedTxIDs()
+		if len(txids) != len(expected) {
+			t.Fatalf("expected %v unconfirmed txids, got %v",
+				len(expected), len(txids))
+		}
+		for _, txid := range expected {
+			var found bool
+			for _, unconfirmed := range txids {
+				if unconfirmed == txid {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected %v to be unconfirmed", txid)
+			}
+		}
+	}
+	assertUnconfirmed(tx1Hash, tx2Hash)
+
+	block := btcutil.NewBlock(&wire.MsgBlock{
+		Transactions: []*wire.MsgTx{&tx1},
+	})
+	err := txConfNotifier.ConnectTip(block.Hash(), 11, block.Transactions())
+	if err != nil {
+		t.Fatalf("Failed to connect block: %v", err)
+	}
+	assertUnconfirmed(tx2Hash)
+
+	// Once the block including tx1 is disconnected, tx1 should be
+	// reported as unconfirmed again.
+	if err := txConfNotifier.DisconnectTip(11); err != nil {
+		t.Fatalf("Failed to disconnect block: %v", err)
+	}
+	assertUnconfirmed(tx1Hash, tx2Hash)
+}
+
 func assertEqualTxConf(t *testing.T,
 	actualConf, expectedConf *chainntnfs.TxConfirmation) {
 
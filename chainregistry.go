@@ -231,8 +231,10 @@ func newChainControlFromConfig(cfg *config, chanDB *channeldb.DB,
 
 		// Next we'll create the instances of the ChainNotifier and
 		// FilteredChainView interface which is backed by the neutrino
-		// light client.
-		cc.chainNotifier, err = neutrinonotify.New(svc)
+		// light client. The notifier persists its height hints within
+		// the channel database, so rescans after a restart resume from
+		// the last scanned height.
+		cc.chainNotifier, err = neutrinonotify.New(svc, chanDB)
 		if err != nil {
 			return nil, nil, err
 		}
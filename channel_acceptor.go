@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// channelAcceptorTimeout is the time we'll wait for the channel acceptor
+// client to decide upon a channel extended to us, before rejecting it.
+const channelAcceptorTimeout = 15 * time.Second
+
+// errChannelAcceptorActive is returned when a channel acceptor client is
+// registered while another one is still active.
+var errChannelAcceptorActive = errors.New("a channel acceptor is already " +
+	"active")
+
+// channelAcceptRequest is a channel extended to us by a remote peer, which is
+// to be accepted or rejected by the channel acceptor client.
+type channelAcceptRequest struct {
+	// node is the identity public key of the funder of the channel.
+	node *btcec.PublicKey
+
+	// openChan is the open_channel message the funder sent us.
+	openChan *lnwire.OpenChannel
+
+	// resp is the channel over which the client's decision is sent.
+	resp chan *channelAcceptResponse
+}
+
+// channelAcceptResponse is the decision of the channel acceptor client upon a
+// channel extended to us.
+type channelAcceptResponse struct {
+	// accept denotes whether we'll accept the channel.
+	accept bool
+
+	// reason is the reason for rejecting the channel, which is sent to the
+	// funder.
+	reason string
+
+	// minAcceptDepth, if non-zero, overrides the number of confirmations
+	// we require for the funding transaction of the channel.
+	minAcceptDepth uint16
+}
+
+// channelAcceptorClient is the single client deciding upon the channels
+// extended to us.
+type channelAcceptorClient struct {
+	// requests is the channel over which the channels extended to us are
+	// delivered to the client.
+	requests chan *channelAcceptRequest
+
+	quit chan struct{}
+}
+
+// channelAcceptor hands the channels extended to us to the registered channel
+// acceptor client, if any, which decides whether we accept them. Without a
+// registered client, all channels are accepted.
+type channelAcceptor struct {
+	mtx    sync.Mutex
+	client *channelAcceptorClient
+}
+
+// newChannelAcceptor creates a new channel acceptor without a client.
+func newChannelAcceptor() *channelAcceptor {
+	return &channelAcceptor{}
+}
+
+// RegisterClient registers a new channel acceptor client, which is handed all
+// channels extended to us until it's unregistered. Only a single client may
+// be registered at a time.
+func (c *channelAcceptor) RegisterClient() (*channelAcceptorClient, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.client != nil {
+		return nil, errChannelAcceptorActive
+	}
+
+	c.client = &channelAcceptorClient{
+		requests: make(chan *channelAcceptRequest),
+		quit:     make(chan struct{}),
+	}
+
+	return c.client, nil
+}
+
+// UnregisterClient unregisters the passed client. Any channel the client
+// didn't decide upon yet is rejected, and all channels extended to us
+// afterwards are accepted.
+func (c *channelAcceptor) UnregisterClient(client *channelAcceptorClient) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.client != client {
+		return
+	}
+
+	close(client.quit)
+	c.client = nil
+}
+
+// Accept hands the passed open_channel message of the funder to the
+// registered client, and returns its decision. If the client doesn't decide
+// in time, or is unregistered in the meantime, then the channel is rejected.
+func (c *channelAcceptor) Accept(node *btcec.PublicKey,
+	openChan *lnwire.OpenChannel) *channelAcceptResponse {
+
+	c.mtx.Lock()
+	client := c.client
+	c.mtx.Unlock()
+
+	if client == nil {
+		return &channelAcceptResponse{accept: true}
+	}
+
+	req := &channelAcceptRequest{
+		node:     node,
+		openChan: openChan,
+		resp:     make(chan *channelAcceptResponse, 1),
+	}
+
+	timeout := time.After(channelAcceptorTimeout)
+	reject := func(reason string) *channelAcceptResponse {
+		return &channelAcceptResponse{reason: reason}
+	}
+
+	select {
+	case client.requests <- req:
+	case <-client.quit:
+		return reject("channel acceptor unavailable")
+	case <-timeout:
+		return reject("channel acceptor timed out")
+	}
+
+	select {
+	case resp := <-req.resp:
+		return resp
+	case <-client.quit:
+		return reject("channel acceptor unavailable")
+	case <-timeout:
+		return reject("channel acceptor timed out")
+	}
+}
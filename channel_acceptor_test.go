@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestChannelAcceptor tests that channels are accepted without a registered
+// client, that the decision of a registered client is returned, and that any
+// channel a client didn't decide upon is rejected once it's unregistered.
+func TestChannelAcceptor(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create key: %v", err)
+	}
+	node := privKey.PubKey()
+	openChan := &lnwire.OpenChannel{PendingChannelID: [32]byte{1}}
+
+	acceptor := newChannelAcceptor()
+
+	// Without a registered client, all channels should be accepted.
+	if resp := acceptor.Accept(node, openChan); !resp.accept {
+		t.Fatalf("expected channel to be accepted")
+	}
+
+	client, err := acceptor.RegisterClient()
+	if err != nil {
+		t.Fatalf("unable to register client: %v", err)
+	}
+	if _, err := acceptor.RegisterClient(); err != errChannelAcceptorActive {
+		t.Fatalf("expected second client to be refused, got: %v", err)
+	}
+
+	// The decision of the registered client should be returned as is.
+	respChan := make(chan *channelAcceptResponse, 1)
+	go func() {
+		respChan <- acceptor.Accept(node, openChan)
+	}()
+
+	select {
+	case req := <-client.requests:
+		if req.openChan.PendingChannelID != openChan.PendingChannelID {
+			t.Fatalf("unexpected channel %x",
+				req.openChan.PendingChannelID)
+		}
+		req.resp <- &channelAcceptResponse{
+			accept:         true,
+			minAcceptDepth: 6,
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("channel not delivered to client")
+	}
+
+	select {
+	case resp := <-respChan:
+		if !resp.accept || resp.minAcceptDepth != 6 {
+			t.Fatalf("unexpected decision: %v", resp)
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("decision not returned")
+	}
+
+	// A channel the client didn't decide upon should be rejected once the
+	// client is unregistered.
+	go func() {
+		respChan <- acceptor.Accept(node, openChan)
+	}()
+
+	select {
+	case <-client.requests:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("channel not delivered to client")
+	}
+	acceptor.UnregisterClient(client)
+
+	select {
+	case resp := <-respChan:
+		if resp.accept {
+			t.Fatalf("expected channel to be rejected")
+		}
+
+	case <-time.After(5 * time.Second):
+		t.Fatalf("decision not returned")
+	}
+
+	// Once unregistered, channels should again be accepted.
+	if resp := acceptor.Accept(node, openChan); !resp.accept {
+		t.Fatalf("expected channel to be accepted")
+	}
+}
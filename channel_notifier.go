@@ -0,0 +1,258 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// channelEventType denotes the kind of channel lifecycle transition which
+// registered channel event clients are notified of.
+type channelEventType uint8
+
+const (
+	// pendingOpenChannelEvent denotes that the funding transaction of a
+	// new channel was broadcast, or received from the funder.
+	pendingOpenChannelEvent channelEventType = iota
+
+	// openChannelEvent denotes that the funding transaction of a channel
+	// confirmed, and the channel is now open.
+	openChannelEvent
+
+	// closingChannelEvent denotes that a commitment transaction of a
+	// channel confirmed, and the channel is waiting for its outputs to be
+	// swept before it's fully closed.
+	closingChannelEvent
+
+	// closedChannelEvent denotes that a channel was fully closed, as all
+	// of its contracts were resolved.
+	closedChannelEvent
+
+	// activeChannelEvent denotes that a channel became usable for
+	// payments, as the link to its peer was added to the switch.
+	activeChannelEvent
+
+	// inactiveChannelEvent denotes that a channel is no longer usable for
+	// payments, as the link to its peer was removed from the switch.
+	inactiveChannelEvent
+)
+
+// String returns a human readable representation of the channel event type.
+func (e channelEventType) String() string {
+	switch e {
+	case pendingOpenChannelEvent:
+		return "PendingOpen"
+	case openChannelEvent:
+		return "Open"
+	case closingChannelEvent:
+		return "Closing"
+	case closedChannelEvent:
+		return "Closed"
+	case activeChannelEvent:
+		return "Active"
+	case inactiveChannelEvent:
+		return "Inactive"
+	default:
+		return "Unknown"
+	}
+}
+
+// channelEvent is a single lifecycle transition of a channel.
+type channelEvent struct {
+	// eventType is the kind of transition the channel went through.
+	eventType channelEventType
+
+	// chanPoint is the funding outpoint of the channel.
+	chanPoint wire.OutPoint
+}
+
+// channelNotifier dispatches the lifecycle transitions of all channels, as
+// reported by the funding manager, the switch and the contract court, to all
+// registered clients. Each client receives the events in the order they were
+// reported.
+type channelNotifier struct {
+	stopped uint32 // To be used atomically.
+
+	clientMtx           sync.Mutex
+	nextClientID        uint32
+	notificationClients map[uint32]*channelEventSubscription
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newChannelNotifier creates a new channel notifier without any clients.
+func newChannelNotifier() *channelNotifier {
+	return &channelNotifier{
+		notificationClients: make(map[uint32]*channelEventSubscription),
+		quit:                make(chan struct{}),
+	}
+}
+
+// Stop signals the goroutines delivering events to clients to exit, and waits
+// for them to do so.
+func (c *channelNotifier) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Channel notifier shutting down")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// NotifyPendingOpenChannelEvent notifies all clients that the passed channel
+// is pending open.
+func (c *channelNotifier) NotifyPendingOpenChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(pendingOpenChannelEvent, chanPoint)
+}
+
+// NotifyOpenChannelEvent notifies all clients that the passed channel is
+// open.
+func (c *channelNotifier) NotifyOpenChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(openChannelEvent, chanPoint)
+}
+
+// NotifyClosingChannelEvent notifies all clients that the passed channel is
+// closing.
+func (c *channelNotifier) NotifyClosingChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(closingChannelEvent, chanPoint)
+}
+
+// NotifyClosedChannelEvent notifies all clients that the passed channel is
+// fully closed.
+func (c *channelNotifier) NotifyClosedChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(closedChannelEvent, chanPoint)
+}
+
+// NotifyActiveChannelEvent notifies all clients that the passed channel
+// became active.
+func (c *channelNotifier) NotifyActiveChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(activeChannelEvent, chanPoint)
+}
+
+// NotifyInactiveChannelEvent notifies all clients that the passed channel
+// became inactive.
+func (c *channelNotifier) NotifyInactiveChannelEvent(chanPoint wire.OutPoint) {
+	c.notifyClients(inactiveChannelEvent, chanPoint)
+}
+
+// notifyClients queues the passed event for delivery to all currently
+// registered clients.
+func (c *channelNotifier) notifyClients(eventType channelEventType,
+	chanPoint wire.OutPoint) {
+
+	srvrLog.Debugf("ChannelPoint(%v) transitioned to %v", chanPoint,
+		eventType)
+
+	event := &channelEvent{
+		eventType: eventType,
+		chanPoint: chanPoint,
+	}
+
+	c.clientMtx.Lock()
+	defer c.clientMtx.Unlock()
+
+	for _, client := range c.notificationClients {
+		client.queueMtx.Lock()
+		client.queue = append(client.queue, event)
+		client.queueMtx.Unlock()
+
+		select {
+		case client.queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// channelEventSubscription represents an intent to receive the lifecycle
+// transitions of all channels. Each event is sent over the Updates channel, in
+// the order the events were reported.
+type channelEventSubscription struct {
+	Updates chan *channelEvent
+
+	// queue holds the events which weren't delivered to the client yet,
+	// and queued is signaled whenever an event is appended to it.
+	queueMtx sync.Mutex
+	queue    []*channelEvent
+	queued   chan struct{}
+
+	notifier *channelNotifier
+	id       uint32
+	quit     chan struct{}
+}
+
+// Cancel unregisters the channelEventSubscription, freeing any previously
+// allocated resources.
+func (s *channelEventSubscription) Cancel() {
+	s.notifier.clientMtx.Lock()
+	if _, ok := s.notifier.notificationClients[s.id]; ok {
+		delete(s.notifier.notificationClients, s.id)
+		close(s.quit)
+	}
+	s.notifier.clientMtx.Unlock()
+}
+
+// SubscribeChannelEvents returns a channelEventSubscription which allows the
+// caller to receive async notifications of each channel lifecycle transition.
+func (c *channelNotifier) SubscribeChannelEvents() *channelEventSubscription {
+	client := &channelEventSubscription{
+		Updates:  make(chan *channelEvent),
+		queued:   make(chan struct{}, 1),
+		notifier: c,
+		quit:     make(chan struct{}),
+	}
+
+	c.clientMtx.Lock()
+	c.notificationClients[c.nextClientID] = client
+	client.id = c.nextClientID
+	c.nextClientID++
+	c.clientMtx.Unlock()
+
+	c.wg.Add(1)
+	go client.deliverEvents()
+
+	return client
+}
+
+// deliverEvents sends the queued events to the client in order, until the
+// subscription is canceled or the notifier is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *channelEventSubscription) deliverEvents() {
+	defer s.notifier.wg.Done()
+
+	for {
+		s.queueMtx.Lock()
+		var next *channelEvent
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+		}
+		s.queueMtx.Unlock()
+
+		if next == nil {
+			select {
+			case <-s.queued:
+				continue
+			case <-s.quit:
+				return
+			case <-s.notifier.quit:
+				return
+			}
+		}
+
+		select {
+		case s.Updates <- next:
+		case <-s.quit:
+			return
+		case <-s.notifier.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestChannelNotifierOrdering tests that each client of the channel notifier
+// receives all events reported after it subscribed in the order they were
+// reported, and none after it canceled its subscription.
+func TestChannelNotifierOrdering(t *testing.T) {
+	t.Parallel()
+
+	notifier := newChannelNotifier()
+	defer notifier.Stop()
+
+	client := notifier.SubscribeChannelEvents()
+
+	chanPoint := wire.OutPoint{Index: 1}
+	expected := []channelEventType{
+		pendingOpenChannelEvent,
+		openChannelEvent,
+		activeChannelEvent,
+		inactiveChannelEvent,
+		closingChannelEvent,
+		closedChannelEvent,
+	}
+
+	// All events are reported before any is received, to ensure they're
+	// queued rather than dropped or reordered.
+	notifier.NotifyPendingOpenChannelEvent(chanPoint)
+	notifier.NotifyOpenChannelEvent(chanPoint)
+	notifier.NotifyActiveChannelEvent(chanPoint)
+	notifier.NotifyInactiveChannelEvent(chanPoint)
+	notifier.NotifyClosingChannelEvent(chanPoint)
+	notifier.NotifyClosedChannelEvent(chanPoint)
+
+	for _, eventType := range expected {
+		select {
+		case event := <-client.Updates:
+			if event.eventType != eventType {
+				t.Fatalf("expected %v event, got %v",
+					eventType, event.eventType)
+			}
+			if event.chanPoint != chanPoint {
+				t.Fatalf("expected event for %v, got %v",
+					chanPoint, event.chanPoint)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("%v event not received", eventType)
+		}
+	}
+
+	// Once canceled, the client shouldn't receive any further events.
+	client.Cancel()
+	notifier.NotifyOpenChannelEvent(chanPoint)
+
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("received %v event after canceling",
+			event.eventType)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
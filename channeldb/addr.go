@@ -66,7 +66,7 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 	var scratch [8]byte
 	var address net.Addr
 
-	if _, err := r.Read(scratch[:1]); err != nil {
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
 		return nil, err
 	}
 
@@ -75,11 +75,11 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 	case tcp4Addr:
 		addr := &net.TCPAddr{}
 		var ip [4]byte
-		if _, err := r.Read(ip[:]); err != nil {
+		if _, err := io.ReadFull(r, ip[:]); err != nil {
 			return nil, err
 		}
 		addr.IP = (net.IP)(ip[:])
-		if _, err := r.Read(scratch[:2]); err != nil {
+		if _, err := io.ReadFull(r, scratch[:2]); err != nil {
 			return nil, err
 		}
 		addr.Port = int(byteOrder.Uint16(scratch[:2]))
@@ -87,11 +87,11 @@ func deserializeAddr(r io.Reader) (net.Addr, error) {
 	case tcp6Addr:
 		addr := &net.TCPAddr{}
 		var ip [16]byte
-		if _, err := r.Read(ip[:]); err != nil {
+		if _, err := io.ReadFull(r, ip[:]); err != nil {
 			return nil, err
 		}
 		addr.IP = (net.IP)(ip[:])
-		if _, err := r.Read(scratch[:2]); err != nil {
+		if _, err := io.ReadFull(r, scratch[:2]); err != nil {
 			return nil, err
 		}
 		addr.Port = int(byteOrder.Uint16(scratch[:2]))
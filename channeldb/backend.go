@@ -0,0 +1,445 @@
+package channeldb
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// ReadCursor iterates over the key/value pairs of a bucket in byte-sorted
+// order of their keys. Values are nil for keys of nested buckets.
+type ReadCursor interface {
+	// First moves the cursor to the first key of the bucket.
+	First() (key, value []byte)
+
+	// Last moves the cursor to the last key of the bucket.
+	Last() (key, value []byte)
+
+	// Next moves the cursor to the next key of the bucket.
+	Next() (key, value []byte)
+
+	// Prev moves the cursor to the previous key of the bucket.
+	Prev() (key, value []byte)
+
+	// Seek moves the cursor to the passed key, or to the next key if it
+	// doesn't exist.
+	Seek(seek []byte) (key, value []byte)
+}
+
+// ReadBucket is a bucket of key/value pairs, which may hold nested buckets,
+// as seen by a read-only transaction.
+type ReadBucket interface {
+	// NestedReadBucket returns the nested bucket with the passed key, or
+	// nil if it doesn't exist.
+	NestedReadBucket(key []byte) ReadBucket
+
+	// Get returns the value of the passed key, or nil if it doesn't exist
+	// or is a nested bucket.
+	Get(key []byte) []byte
+
+	// ForEach calls fn for each key/value pair of the bucket.
+	ForEach(fn func(k, v []byte) error) error
+
+	// ReadCursor returns a cursor over the bucket.
+	ReadCursor() ReadCursor
+}
+
+// RwBucket is a bucket of key/value pairs as seen by a read-write
+// transaction.
+type RwBucket interface {
+	ReadBucket
+
+	// NestedReadWriteBucket returns the nested bucket with the passed
+	// key, or nil if it doesn't exist.
+	NestedReadWriteBucket(key []byte) RwBucket
+
+	// CreateBucketIfNotExists returns the nested bucket with the passed
+	// key, creating it if it doesn't exist yet.
+	CreateBucketIfNotExists(key []byte) (RwBucket, error)
+
+	// DeleteNestedBucket deletes the nested bucket with the passed key.
+	DeleteNestedBucket(key []byte) error
+
+	// Put sets the value of the passed key.
+	Put(key, value []byte) error
+
+	// Delete deletes the passed key.
+	Delete(key []byte) error
+
+	// NextSequence returns the next value of the bucket's sequence.
+	NextSequence() (uint64, error)
+}
+
+// ReadTx is a read-only transaction of a Backend.
+type ReadTx interface {
+	// ReadBucket returns the top-level bucket with the passed key, or nil
+	// if it doesn't exist.
+	ReadBucket(key []byte) ReadBucket
+}
+
+// RwTx is a read-write transaction of a Backend.
+type RwTx interface {
+	ReadTx
+
+	// ReadWriteBucket returns the top-level bucket with the passed key,
+	// or nil if it doesn't exist.
+	ReadWriteBucket(key []byte) RwBucket
+
+	// CreateTopLevelBucket returns the top-level bucket with the passed
+	// key, creating it if it doesn't exist yet.
+	CreateTopLevelBucket(key []byte) (RwBucket, error)
+
+	// DeleteTopLevelBucket deletes the top-level bucket with the passed
+	// key.
+	DeleteTopLevelBucket(key []byte) error
+}
+
+// Backend is the transactional store that all transactions of the database
+// are executed against. Transactions may be executed by a remote store, such
+// as a clustered database, in which case they can fail because they
+// conflicted with a transaction executed concurrently by another member of
+// the cluster. Such failures are signalled by returning an error for which
+// IsRetryable returns true, leading the database to retry the transaction.
+// The context passed to each transaction carries its deadline, if any, which
+// a remote store is expected to propagate.
+//
+// NOTE: Most of the database is still built upon the View, Update and Batch
+// methods of the DB, which hand bolt transactions to their callers. These
+// fail with ErrBoltTxUnavailable unless the backend's transactions are those
+// of the local bolt database. Only the transactions executed through ViewTx,
+// which path finding is built upon, are independent of the backend.
+type Backend interface {
+	// View executes fn within a read-only transaction.
+	View(ctx context.Context, fn func(ReadTx) error) error
+
+	// Update executes fn within a read-write transaction.
+	Update(ctx context.Context, fn func(RwTx) error) error
+
+	// Batch executes fn as part of a batch of read-write transactions,
+	// which may be executed more than once.
+	Batch(ctx context.Context, fn func(RwTx) error) error
+}
+
+// retryableError is implemented by errors which signal that a failed
+// transaction may succeed if executed again.
+type retryableError interface {
+	Retryable() bool
+}
+
+// IsRetryable returns true if the passed error, as returned by a transaction,
+// signals that the transaction may succeed if executed again. This is the
+// case for ErrTxConflict, as well as for any error of a Backend implementing
+// a Retryable method which returns true.
+func IsRetryable(err error) bool {
+	if err == ErrTxConflict {
+		return true
+	}
+
+	rErr, ok := err.(retryableError)
+	return ok && rErr.Retryable()
+}
+
+// boltBackend is the Backend executing transactions against the local bolt
+// database. As its transactions can't be interrupted once started, the
+// context of a transaction is only checked before it's started.
+type boltBackend struct {
+	db *bolt.DB
+}
+
+// A compile-time check to ensure boltBackend implements the Backend
+// interface.
+var _ Backend = (*boltBackend)(nil)
+
+// View executes fn within a read-only transaction of the bolt database.
+func (b *boltBackend) View(ctx context.Context, fn func(ReadTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return b.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx})
+	})
+}
+
+// Update executes fn within a read-write transaction of the bolt database.
+func (b *boltBackend) Update(ctx context.Context, fn func(RwTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx})
+	})
+}
+
+// Batch executes fn as part of a batch of read-write transactions of the bolt
+// database.
+func (b *boltBackend) Batch(ctx context.Context, fn func(RwTx) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return b.db.Batch(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx})
+	})
+}
+
+// boltTx is a transaction of the bolt database, implementing both the ReadTx
+// and RwTx interfaces.
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+// ReadBucket returns the top-level bucket with the passed key, or nil if it
+// doesn't exist.
+func (t *boltTx) ReadBucket(key []byte) ReadBucket {
+	return t.ReadWriteBucket(key)
+}
+
+// ReadWriteBucket returns the top-level bucket with the passed key, or nil if
+// it doesn't exist.
+func (t *boltTx) ReadWriteBucket(key []byte) RwBucket {
+	b := t.tx.Bucket(key)
+	if b == nil {
+		return nil
+	}
+
+	return &boltBucket{b}
+}
+
+// CreateTopLevelBucket returns the top-level bucket with the passed key,
+// creating it if it doesn't exist yet.
+func (t *boltTx) CreateTopLevelBucket(key []byte) (RwBucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltBucket{b}, nil
+}
+
+// DeleteTopLevelBucket deletes the top-level bucket with the passed key.
+func (t *boltTx) DeleteTopLevelBucket(key []byte) error {
+	return t.tx.DeleteBucket(key)
+}
+
+// boltBucket is a bucket of the bolt database, implementing both the
+// ReadBucket and RwBucket interfaces.
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+// NestedReadBucket returns the nested bucket with the passed key, or nil if it
+// doesn't exist.
+func (b *boltBucket) NestedReadBucket(key []byte) ReadBucket {
+	return b.NestedReadWriteBucket(key)
+}
+
+// NestedReadWriteBucket returns the nested bucket with the passed key, or nil
+// if it doesn't exist.
+func (b *boltBucket) NestedReadWriteBucket(key []byte) RwBucket {
+	nested := b.Bucket.Bucket(key)
+	if nested == nil {
+		return nil
+	}
+
+	return &boltBucket{nested}
+}
+
+// CreateBucketIfNotExists returns the nested bucket with the passed key,
+// creating it if it doesn't exist yet.
+func (b *boltBucket) CreateBucketIfNotExists(key []byte) (RwBucket, error) {
+	nested, err := b.Bucket.CreateBucketIfNotExists(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &boltBucket{nested}, nil
+}
+
+// DeleteNestedBucket deletes the nested bucket with the passed key.
+func (b *boltBucket) DeleteNestedBucket(key []byte) error {
+	return b.Bucket.DeleteBucket(key)
+}
+
+// ReadCursor returns a cursor over the bucket.
+func (b *boltBucket) ReadCursor() ReadCursor {
+	return b.Bucket.Cursor()
+}
+
+// asBoltTx returns the bolt transaction underlying the passed transaction of
+// the backend, or ErrBoltTxUnavailable if it isn't one of the local bolt
+// database.
+func asBoltTx(tx ReadTx) (*bolt.Tx, error) {
+	btx, ok := tx.(*boltTx)
+	if !ok {
+		return nil, ErrBoltTxUnavailable
+	}
+
+	return btx.tx, nil
+}
+
+// boltTxFunc adapts the passed function taking a bolt transaction to one
+// taking a read-write transaction of the backend.
+func boltTxFunc(fn func(*bolt.Tx) error) func(RwTx) error {
+	return func(tx RwTx) error {
+		btx, err := asBoltTx(tx)
+		if err != nil {
+			return err
+		}
+
+		return fn(btx)
+	}
+}
+
+// View executes fn within a read-only transaction, exactly like the View
+// method of the underlying bolt database, recording its latency.
+func (d *DB) View(fn func(*bolt.Tx) error) error {
+	return d.ViewContext(context.Background(), fn)
+}
+
+// ViewContext executes fn within a read-only transaction of the backend,
+// recording its latency. If the context is done before the transaction is
+// started, its error is returned without calling fn.
+func (d *DB) ViewContext(ctx context.Context, fn func(*bolt.Tx) error) error {
+	return d.ViewTxContext(ctx, func(tx ReadTx) error {
+		btx, err := asBoltTx(tx)
+		if err != nil {
+			return err
+		}
+
+		return fn(btx)
+	})
+}
+
+// ViewTx executes fn within a read-only transaction of the backend, recording
+// its latency. Unlike View, it doesn't depend on the backend being the local
+// bolt database.
+func (d *DB) ViewTx(fn func(ReadTx) error) error {
+	return d.ViewTxContext(context.Background(), fn)
+}
+
+// ViewTxContext executes fn within a read-only transaction of the backend,
+// recording its latency. If the context is done before the transaction is
+// started, its error is returned without calling fn.
+func (d *DB) ViewTxContext(ctx context.Context, fn func(ReadTx) error) error {
+	start := time.Now()
+	err := d.backend.View(ctx, fn)
+	d.metrics.view.record(time.Since(start), 0, err)
+
+	return err
+}
+
+// Update executes fn within a read-write transaction, exactly like the
+// Update method of the underlying bolt database, recording its latency. If
+// the database has been opened read-only, ErrDBReadOnly is returned without
+// calling fn.
+func (d *DB) Update(fn func(*bolt.Tx) error) error {
+	return d.UpdateContext(context.Background(), fn)
+}
+
+// UpdateContext executes fn within a read-write transaction of the backend,
+// recording its latency and the number of times fn was retried. If the
+// transaction fails with a retryable error, it's retried after an exponential
+// backoff, up to the configured number of retries or until the context is
+// done. If the database has been opened read-only, ErrDBReadOnly is returned
+// without calling fn.
+func (d *DB) UpdateContext(ctx context.Context,
+	fn func(*bolt.Tx) error) error {
+
+	if d.readOnly {
+		return ErrDBReadOnly
+	}
+
+	start := time.Now()
+	retries, err := d.retryTx(ctx, d.backend.Update, boltTxFunc(fn))
+	d.metrics.update.record(time.Since(start), retries, err)
+
+	return err
+}
+
+// Batch executes fn as part of a batch of read-write transactions, exactly
+// like the Batch method of the underlying bolt database, recording its
+// latency and the number of times fn was retried. If fn returns
+// ErrTxConflict, it's retried within a later batch after an exponential
+// backoff, up to the configured number of retries. If the database has been
+// opened read-only, ErrDBReadOnly is returned without calling fn.
+func (d *DB) Batch(fn func(*bolt.Tx) error) error {
+	return d.BatchContext(context.Background(), fn)
+}
+
+// BatchContext executes fn as part of a batch of read-write transactions of
+// the backend, recording its latency and the number of times fn was retried.
+// If the transaction fails with a retryable error, it's retried within a later
+// batch after an exponential backoff, up to the configured number of retries
+// or until the context is done. If the database has been opened read-only,
+// ErrDBReadOnly is returned without calling fn.
+func (d *DB) BatchContext(ctx context.Context, fn func(*bolt.Tx) error) error {
+	if d.readOnly {
+		return ErrDBReadOnly
+	}
+
+	start := time.Now()
+	retries, err := d.retryTx(ctx, d.backend.Batch, boltTxFunc(fn))
+	d.metrics.batch.record(time.Since(start), retries, err)
+
+	return err
+}
+
+// retryTx executes fn using the passed transaction method of the backend,
+// executing it again for as long as it fails with a retryable error, up to
+// the configured number of retries. The number of times fn was executed
+// beyond its first attempt is returned along with the final error.
+func (d *DB) retryTx(ctx context.Context,
+	execTx func(context.Context, func(RwTx) error) error,
+	fn func(RwTx) error) (uint64, error) {
+
+	var (
+		attempts uint64
+		err      error
+	)
+	backoff := d.batchRetryBackoff
+retry:
+	for i := 0; ; i++ {
+		err = execTx(ctx, func(tx RwTx) error {
+			atomic.AddUint64(&attempts, 1)
+			return fn(tx)
+		})
+		if !IsRetryable(err) || i >= d.batchRetries {
+			break
+		}
+
+		select {
+		case <-time.After(jitterBackoff(backoff)):
+		case <-ctx.Done():
+			err = ctx.Err()
+			break retry
+		}
+
+		backoff *= 2
+		if backoff > maxBatchRetryBackoff {
+			backoff = maxBatchRetryBackoff
+		}
+	}
+
+	var retries uint64
+	if n := atomic.LoadUint64(&attempts); n > 1 {
+		retries = n - 1
+	}
+
+	return retries, err
+}
+
+// jitterBackoff randomizes the passed backoff by up to half of its value, so
+// that conflicting transactions retried at the same time are spread out.
+func jitterBackoff(backoff time.Duration) time.Duration {
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff
+	}
+
+	return time.Duration(half + rand.Int63n(half+1))
+}
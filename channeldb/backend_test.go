@@ -0,0 +1,251 @@
+package channeldb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// remoteConflictErr is the error returned by conflictBackend for transactions
+// conflicting with a concurrent remote transaction.
+type remoteConflictErr struct{}
+
+func (remoteConflictErr) Error() string   { return "remote conflict" }
+func (remoteConflictErr) Retryable() bool { return true }
+
+// conflictBackend is a Backend which fails the first conflicts read-write
+// transactions with conflictErr before executing them, mimicking a remote
+// store.
+type conflictBackend struct {
+	Backend
+
+	conflicts   int
+	conflictErr error
+}
+
+func (c *conflictBackend) Update(ctx context.Context, fn func(RwTx) error) error {
+	if c.conflicts > 0 {
+		c.conflicts--
+		return c.conflictErr
+	}
+
+	return c.Backend.Update(ctx, fn)
+}
+
+func (c *conflictBackend) Batch(ctx context.Context, fn func(RwTx) error) error {
+	if c.conflicts > 0 {
+		c.conflicts--
+		return c.conflictErr
+	}
+
+	return c.Backend.Batch(ctx, fn)
+}
+
+// TestBackendRetryableErrors tests that read-write transactions failing with
+// a retryable error of the backend are retried, unless their context is done.
+func TestBackendRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(
+		tempDirName,
+		OptionSetBatchRetries(3),
+		OptionSetBatchRetryBackoff(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	backend := &conflictBackend{
+		Backend:     db.backend,
+		conflictErr: remoteConflictErr{},
+	}
+	db.backend = backend
+
+	if IsRetryable(nil) || !IsRetryable(remoteConflictErr{}) ||
+		!IsRetryable(ErrTxConflict) {
+
+		t.Fatalf("errors misclassified as (non-)retryable")
+	}
+
+	// A transaction which conflicts twice should succeed on its third
+	// attempt.
+	backend.conflicts = 2
+	var executed bool
+	err = db.Update(func(*bolt.Tx) error {
+		executed = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update db: %v", err)
+	}
+	if !executed || backend.conflicts != 0 {
+		t.Fatalf("transaction not retried until it succeeded")
+	}
+
+	// A transaction which keeps conflicting should have the conflict
+	// returned once its retries are exhausted.
+	backend.conflicts = 10
+	err = db.Update(func(*bolt.Tx) error { return nil })
+	if _, ok := err.(remoteConflictErr); !ok {
+		t.Fatalf("expected remote conflict, got %v", err)
+	}
+	if backend.conflicts != 6 {
+		t.Fatalf("expected 4 attempts, got %v", 10-backend.conflicts)
+	}
+
+	// Once the context of a transaction is done, it shouldn't be retried
+	// any longer, nor should a new transaction be started.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	backend.conflicts = 1
+	err = db.UpdateContext(ctx, func(*bolt.Tx) error { return nil })
+	if err != context.Canceled {
+		t.Fatalf("expected context to be canceled, got %v", err)
+	}
+	err = db.ViewContext(ctx, func(*bolt.Tx) error { return nil })
+	if err != context.Canceled {
+		t.Fatalf("expected context to be canceled, got %v", err)
+	}
+}
+
+// TestBackendTxConflict tests that batched transactions which the backend
+// fails with ErrTxConflict are retried within later batches until they
+// succeed.
+func TestBackendTxConflict(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(
+		tempDirName,
+		OptionSetBatchRetries(3),
+		OptionSetBatchRetryBackoff(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	backend := &conflictBackend{
+		Backend:     db.backend,
+		conflicts:   3,
+		conflictErr: ErrTxConflict,
+	}
+	db.backend = backend
+
+	key, value := []byte("key"), []byte("value")
+	err = db.Batch(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(key)
+		if err != nil {
+			return err
+		}
+		return b.Put(key, value)
+	})
+	if err != nil {
+		t.Fatalf("unable to batch update db: %v", err)
+	}
+	if backend.conflicts != 0 {
+		t.Fatalf("transaction not retried until it succeeded")
+	}
+	if db.metrics.batch.retries != 3 {
+		t.Fatalf("expected 3 retries, got %v",
+			db.metrics.batch.retries)
+	}
+
+	// The update should be visible to backend-neutral transactions.
+	err = db.ViewTx(func(tx ReadTx) error {
+		b := tx.ReadBucket(key)
+		if b == nil || !bytes.Equal(b.Get(key), value) {
+			return fmt.Errorf("update not found")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to view db: %v", err)
+	}
+
+	// Once its retries are exhausted, the conflict should be returned.
+	backend.conflicts = 10
+	err = db.Batch(func(*bolt.Tx) error { return nil })
+	if err != ErrTxConflict {
+		t.Fatalf("expected transaction conflict, got %v", err)
+	}
+}
+
+// fakeReadTx is a read-only transaction of a backend other than the local
+// bolt database, which holds no buckets.
+type fakeReadTx struct{}
+
+func (fakeReadTx) ReadBucket([]byte) ReadBucket { return nil }
+
+// fakeViewBackend is a Backend handing fakeReadTx to read-only transactions.
+type fakeViewBackend struct {
+	Backend
+}
+
+func (fakeViewBackend) View(_ context.Context, fn func(ReadTx) error) error {
+	return fn(fakeReadTx{})
+}
+
+// TestBackendNeutralView tests that transactions requiring a bolt transaction
+// fail against other backends, while backend-neutral ones are executed.
+func TestBackendNeutralView(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	db.backend = fakeViewBackend{Backend: db.backend}
+
+	err = db.View(func(*bolt.Tx) error { return nil })
+	if err != ErrBoltTxUnavailable {
+		t.Fatalf("expected ErrBoltTxUnavailable, got %v", err)
+	}
+
+	var executed bool
+	err = db.ViewTx(func(tx ReadTx) error {
+		executed = tx.ReadBucket(nodeBucket) == nil
+		return nil
+	})
+	if err != nil || !executed {
+		t.Fatalf("backend-neutral transaction not executed: %v", err)
+	}
+
+	// The graph should be traversable through the fake backend, which
+	// doesn't hold the graph.
+	graphDB := db.graphDB
+	graphDB.backend = fakeViewBackend{Backend: graphDB.backend}
+	err = db.ChannelGraph().ForEachNode(nil,
+		func(ReadTx, *LightningNode) error { return nil },
+	)
+	if err != ErrGraphNotFound {
+		t.Fatalf("expected ErrGraphNotFound, got %v", err)
+	}
+}
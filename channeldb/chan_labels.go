@@ -0,0 +1,113 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/coreos/bbolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// MaxChannelLabelLen is the maximum length in bytes of the label which may be
+// attached to a channel.
+const MaxChannelLabelLen = 500
+
+var (
+	// chanLabelBucket is the name of the bucket within the database that
+	// stores the labels attached to channels by the operator, such as a
+	// note on why a channel was opened.
+	//
+	// maps: chanPoint -> label
+	chanLabelBucket = []byte("chan-labels")
+)
+
+// SetChannelLabel attaches the passed label to the channel with the passed
+// channel point, replacing any label attached to it before. An empty label
+// removes the channel's label. The label is kept until the channel is fully
+// closed.
+func (d *DB) SetChannelLabel(chanPoint *wire.OutPoint, label string) error {
+	if len(label) > MaxChannelLabelLen {
+		return ErrChannelLabelTooLong
+	}
+
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, chanPoint); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		if label == "" {
+			return deleteChannelLabel(tx, k.Bytes())
+		}
+
+		labels, err := tx.CreateBucketIfNotExists(chanLabelBucket)
+		if err != nil {
+			return err
+		}
+
+		return labels.Put(k.Bytes(), []byte(label))
+	})
+}
+
+// FetchChannelLabel returns the label attached to the channel with the passed
+// channel point, or an empty string if it has none.
+func (d *DB) FetchChannelLabel(chanPoint *wire.OutPoint) (string, error) {
+	var k bytes.Buffer
+	if err := writeOutpoint(&k, chanPoint); err != nil {
+		return "", err
+	}
+
+	var label string
+	err := d.View(func(tx *bolt.Tx) error {
+		labels := tx.Bucket(chanLabelBucket)
+		if labels == nil {
+			return nil
+		}
+
+		label = string(labels.Get(k.Bytes()))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return label, nil
+}
+
+// FetchChannelLabels returns the labels attached to all channels, keyed by
+// their channel point.
+func (d *DB) FetchChannelLabels() (map[wire.OutPoint]string, error) {
+	labelMap := make(map[wire.OutPoint]string)
+	err := d.View(func(tx *bolt.Tx) error {
+		labels := tx.Bucket(chanLabelBucket)
+		if labels == nil {
+			return nil
+		}
+
+		return labels.ForEach(func(k, v []byte) error {
+			var chanPoint wire.OutPoint
+			err := readOutpoint(bytes.NewReader(k), &chanPoint)
+			if err != nil {
+				return err
+			}
+
+			labelMap[chanPoint] = string(v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return labelMap, nil
+}
+
+// deleteChannelLabel removes the label attached to the channel with the
+// passed serialized channel point, if any.
+func deleteChannelLabel(tx *bolt.Tx, chanPoint []byte) error {
+	labels := tx.Bucket(chanLabelBucket)
+	if labels == nil {
+		return nil
+	}
+
+	return labels.Delete(chanPoint)
+}
@@ -0,0 +1,104 @@
+package channeldb
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/roasbeef/btcutil"
+)
+
+// TestChannelLabels tests that labels can be attached to and removed from
+// channels, and that a channel's label is removed once it's fully closed.
+func TestChannelLabels(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := channel.SyncPending(addr, 101); err != nil {
+		t.Fatalf("unable to save channel state: %v", err)
+	}
+	chanPoint := &channel.FundingOutpoint
+
+	assertLabel := func(expected string) {
+		t.Helper()
+
+		label, err := cdb.FetchChannelLabel(chanPoint)
+		if err != nil {
+			t.Fatalf("unable to fetch label: %v", err)
+		}
+		if label != expected {
+			t.Fatalf("expected label %q, got %q", expected, label)
+		}
+
+		labels, err := cdb.FetchChannelLabels()
+		if err != nil {
+			t.Fatalf("unable to fetch labels: %v", err)
+		}
+		if labels[*chanPoint] != expected {
+			t.Fatalf("expected label %q, got %q", expected,
+				labels[*chanPoint])
+		}
+	}
+
+	// Initially, the channel has no label.
+	assertLabel("")
+
+	// A label which is too long should be rejected.
+	longLabel := strings.Repeat("a", MaxChannelLabelLen+1)
+	err = cdb.SetChannelLabel(chanPoint, longLabel)
+	if err != ErrChannelLabelTooLong {
+		t.Fatalf("expected ErrChannelLabelTooLong, got %v", err)
+	}
+
+	// Setting a label should replace the previous one.
+	if err := cdb.SetChannelLabel(chanPoint, "merchant"); err != nil {
+		t.Fatalf("unable to set label: %v", err)
+	}
+	assertLabel("merchant")
+	err = cdb.SetChannelLabel(chanPoint, "rebalance partner")
+	if err != nil {
+		t.Fatalf("unable to set label: %v", err)
+	}
+	assertLabel("rebalance partner")
+
+	// An empty label should remove it.
+	if err := cdb.SetChannelLabel(chanPoint, ""); err != nil {
+		t.Fatalf("unable to remove label: %v", err)
+	}
+	assertLabel("")
+
+	// Finally, the label should be kept while the channel is pending
+	// close, and removed once it's fully closed.
+	if err := cdb.SetChannelLabel(chanPoint, "merchant"); err != nil {
+		t.Fatalf("unable to set label: %v", err)
+	}
+	closeSummary := &ChannelCloseSummary{
+		ChanPoint:      *chanPoint,
+		RemotePub:      channel.IdentityPub,
+		SettledBalance: btcutil.Amount(500),
+		IsPending:      true,
+		CloseType:      CooperativeClose,
+	}
+	if err := channel.CloseChannel(closeSummary); err != nil {
+		t.Fatalf("unable to close channel: %v", err)
+	}
+	assertLabel("merchant")
+
+	if err := cdb.MarkChanFullyClosed(chanPoint); err != nil {
+		t.Fatalf("unable to mark channel fully closed: %v", err)
+	}
+	assertLabel("")
+}
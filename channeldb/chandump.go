@@ -0,0 +1,203 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+	"github.com/roasbeef/btcutil"
+)
+
+// ChannelDump is the static state of an open channel, as recovered from a
+// possibly corrupt database by DumpChannels. It holds what's needed to
+// identify the channel and re-derive the keys controlling its funds.
+type ChannelDump struct {
+	// IdentityPub is the identity public key of the remote node.
+	IdentityPub *btcec.PublicKey
+
+	// ChainHash is the hash of the chain the channel was opened within.
+	ChainHash chainhash.Hash
+
+	// FundingOutpoint is the outpoint of the funding transaction.
+	FundingOutpoint wire.OutPoint
+
+	// ShortChanID encodes the location of the funding transaction within
+	// the chain.
+	ShortChanID lnwire.ShortChannelID
+
+	// IsInitiator indicates whether we initiated the channel.
+	IsInitiator bool
+
+	// Capacity is the total capacity of the channel.
+	Capacity btcutil.Amount
+
+	// LocalChanCfg is the channel configuration for the local node,
+	// including the key locators of our keys within the channel.
+	LocalChanCfg ChannelConfig
+
+	// RemoteChanCfg is the channel configuration for the remote node,
+	// including the remote party's base points.
+	RemoteChanCfg ChannelConfig
+
+	// RemoteCurrentRevocation is the commitment point of the current
+	// commitment transaction of the remote party.
+	RemoteCurrentRevocation *btcec.PublicKey
+
+	// RemoteNextRevocation is the commitment point of the next commitment
+	// transaction of the remote party, if known.
+	RemoteNextRevocation *btcec.PublicKey
+
+	// Errors holds the errors encountered while decoding the state of the
+	// channel. If non-empty, some of the above fields may be incomplete.
+	Errors []error
+}
+
+// DumpChannels walks the open channels within the channel database in dbPath,
+// recovering as much of their static state as possible, even if the database
+// is partially corrupt. Rather than aborting on the first error, the errors
+// encountered while decoding a channel are recorded within its ChannelDump.
+// The database is opened read-only, without applying any migrations. If an
+// error is returned, the channels dumped before it was encountered are
+// returned along side it.
+//
+// NOTE: As the bolt database panics when it encounters a corrupt page, such
+// panics are recovered from and reported as errors as well.
+func DumpChannels(dbPath string) ([]*ChannelDump, error) {
+	path := filepath.Join(dbPath, dbName)
+	if !fileExists(path) {
+		return nil, ErrNoChanDBExists
+	}
+
+	bdb, err := bolt.Open(path, dbFilePermission, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  readOnlyLockTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer bdb.Close()
+
+	var dumps []*ChannelDump
+	err = bdb.View(func(tx *bolt.Tx) error {
+		return recoverPanic(func() error {
+			return dumpOpenChannels(tx, func(dump *ChannelDump) {
+				dumps = append(dumps, dump)
+			})
+		})
+	})
+
+	return dumps, err
+}
+
+// dumpOpenChannels walks the nested buckets of the open channel bucket,
+// calling cb with the dump of each channel found within them.
+func dumpOpenChannels(tx *bolt.Tx, cb func(*ChannelDump)) error {
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return ErrNoActiveChannels
+	}
+
+	// The channels are nested within a bucket for the remote node, and
+	// one for the chain they reside on, which are both keyed by what
+	// they contain.
+	return openChanBucket.ForEach(func(nodePub, v []byte) error {
+		nodeBucket := openChanBucket.Bucket(nodePub)
+		if v != nil || nodeBucket == nil {
+			return nil
+		}
+
+		return nodeBucket.ForEach(func(chainHash, v []byte) error {
+			chainBucket := nodeBucket.Bucket(chainHash)
+			if v != nil || chainBucket == nil {
+				return nil
+			}
+
+			return chainBucket.ForEach(func(chanPnt, v []byte) error {
+				chanBucket := chainBucket.Bucket(chanPnt)
+				if v != nil || chanBucket == nil {
+					return nil
+				}
+
+				cb(dumpChannel(
+					nodePub, chainHash, chanPnt, chanBucket,
+				))
+
+				return nil
+			})
+		})
+	})
+}
+
+// dumpChannel recovers the static state of the channel stored within
+// chanBucket. The keys of the buckets the channel is nested within are
+// decoded first, so that the channel can be identified even if its own state
+// can't be decoded.
+func dumpChannel(nodePub, chainHash, chanPoint []byte,
+	chanBucket *bolt.Bucket) *ChannelDump {
+
+	dump := &ChannelDump{}
+
+	var err error
+	dump.IdentityPub, err = btcec.ParsePubKey(nodePub, btcec.S256())
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Errorf("unable to "+
+			"parse node public key: %v", err))
+	}
+
+	if len(chainHash) == chainhash.HashSize {
+		copy(dump.ChainHash[:], chainHash)
+	} else {
+		dump.Errors = append(dump.Errors, fmt.Errorf("invalid chain "+
+			"hash length: %v", len(chainHash)))
+	}
+
+	err = readOutpoint(bytes.NewReader(chanPoint), &dump.FundingOutpoint)
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Errorf("unable to "+
+			"read funding outpoint: %v", err))
+	}
+
+	// The channel's info and revocation state are decoded independently,
+	// keeping any fields read before an error was encountered.
+	var channel OpenChannel
+	err = recoverPanic(func() error {
+		return fetchChanInfo(chanBucket, &channel)
+	})
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Errorf("unable to "+
+			"fetch channel info: %v", err))
+	}
+	dump.ShortChanID = channel.ShortChanID
+	dump.IsInitiator = channel.IsInitiator
+	dump.Capacity = channel.Capacity
+	dump.LocalChanCfg = channel.LocalChanCfg
+	dump.RemoteChanCfg = channel.RemoteChanCfg
+
+	err = recoverPanic(func() error {
+		return fetchChanRevocationState(chanBucket, &channel)
+	})
+	if err != nil {
+		dump.Errors = append(dump.Errors, fmt.Errorf("unable to "+
+			"fetch revocation state: %v", err))
+	}
+	dump.RemoteCurrentRevocation = channel.RemoteCurrentRevocation
+	dump.RemoteNextRevocation = channel.RemoteNextRevocation
+
+	return dump
+}
+
+// recoverPanic calls f, returning any panic raised within it as an error.
+func recoverPanic(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic: %v", r)
+		}
+	}()
+
+	return f()
+}
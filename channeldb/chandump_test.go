@@ -0,0 +1,106 @@
+package channeldb
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestDumpChannels checks that the static state of open channels is dumped
+// from the database, even if the state of a channel is partially corrupt.
+func TestDumpChannels(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	cdb, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	channel, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := channel.SyncPending(addr, 101); err != nil {
+		t.Fatalf("unable to save channel state: %v", err)
+	}
+
+	// Truncate the revocation state of the channel, right after the
+	// remote party's current commitment point.
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		chanBucket, err := readChanBucket(
+			tx, channel.IdentityPub, &channel.FundingOutpoint,
+			channel.ChainHash,
+		)
+		if err != nil {
+			return err
+		}
+
+		revState := chanBucket.Get(revocationStateKey)
+		return chanBucket.Put(
+			revocationStateKey, append([]byte(nil), revState[:40]...),
+		)
+	})
+	if err != nil {
+		t.Fatalf("unable to corrupt revocation state: %v", err)
+	}
+	cdb.Close()
+
+	dumps, err := DumpChannels(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to dump channels: %v", err)
+	}
+	if len(dumps) != 1 {
+		t.Fatalf("expected 1 dumped channel, got %v", len(dumps))
+	}
+	dump := dumps[0]
+
+	// The static state of the channel should be recovered in full, along
+	// with the remote party's current commitment point, which precedes
+	// the corrupt part of the revocation state.
+	if !dump.IdentityPub.IsEqual(channel.IdentityPub) {
+		t.Fatalf("identity pub doesn't match")
+	}
+	if dump.ChainHash != channel.ChainHash {
+		t.Fatalf("chain hash doesn't match: expected %v, got %v",
+			channel.ChainHash, dump.ChainHash)
+	}
+	if dump.FundingOutpoint != channel.FundingOutpoint {
+		t.Fatalf("funding outpoint doesn't match: expected %v, got %v",
+			channel.FundingOutpoint, dump.FundingOutpoint)
+	}
+	if dump.Capacity != channel.Capacity {
+		t.Fatalf("capacity doesn't match: expected %v, got %v",
+			channel.Capacity, dump.Capacity)
+	}
+	if !reflect.DeepEqual(dump.LocalChanCfg, channel.LocalChanCfg) {
+		t.Fatalf("local channel config doesn't match")
+	}
+	if !reflect.DeepEqual(dump.RemoteChanCfg, channel.RemoteChanCfg) {
+		t.Fatalf("remote channel config doesn't match")
+	}
+	if !dump.RemoteCurrentRevocation.IsEqual(
+		channel.RemoteCurrentRevocation,
+	) {
+		t.Fatalf("remote current revocation doesn't match")
+	}
+
+	// The error encountered while decoding the remainder of the
+	// revocation state should be reported.
+	if len(dump.Errors) != 1 {
+		t.Fatalf("expected 1 error, got %v", dump.Errors)
+	}
+}
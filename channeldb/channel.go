@@ -23,6 +23,14 @@ var (
 	// previously open, but now closed channels.
 	closedChannelBucket = []byte("closed-chan-bucket")
 
+	// closedChannelTxidIndexBucket indexes the summaries within the
+	// closedChannelBucket by the txid of the transaction which closed the
+	// channel. Each key is a closing txid, mapping to the serialized
+	// channel point of the channel it closed.
+	//
+	// closeTxid -> chanPoint
+	closedChannelTxidIndexBucket = []byte("closed-chan-txid-index")
+
 	// openChanBucket stores all the currently open channels. This bucket
 	// has a second, nested bucket which is keyed by a node's ID. Within
 	// that node ID bucket, all attributes required to track, update, and
@@ -333,6 +341,12 @@ type OpenChannel struct {
 	// been confirmed before a certain height.
 	FundingBroadcastHeight uint32
 
+	// ThawHeight is the block height until which the channel is frozen.
+	// Until then, we refuse to initiate a cooperative close of the
+	// channel, allowing it to be leased to the remote party for a fixed
+	// period. A value of zero indicates the channel isn't frozen.
+	ThawHeight uint32
+
 	// NumConfsRequired is the number of confirmations a channel's funding
 	// transaction must have received in order to be considered available
 	// for normal transactional use.
@@ -1290,8 +1304,6 @@ func (c *OpenChannel) AdvanceCommitChainTail(fwdPkg *FwdPkg) error {
 
 		// With the commitment pointer swapped, we can now add the
 		// revoked (prior) state to the revocation log.
-		//
-		// TODO(roasbeef): store less
 		err = appendChannelLogEntry(logBucket, &c.RemoteCommitment)
 		if err != nil {
 			return err
@@ -1393,7 +1405,7 @@ func (c *OpenChannel) RevocationLogTail() (*ChannelCommitment, error) {
 		// Once we have the entry, we'll decode it into the channel
 		// delta pointer we created above.
 		var dbErr error
-		commit, dbErr = deserializeChanCommit(logEntryReader)
+		commit, dbErr = deserializeRevocationLogEntry(logEntryReader)
 		if dbErr != nil {
 			return dbErr
 		}
@@ -1445,6 +1457,10 @@ func (c *OpenChannel) CommitmentHeight() (uint64, error) {
 // intended to be used for obtaining the relevant data needed to claim all
 // funds rightfully spendable in the case of an on-chain broadcast of the
 // commitment transaction.
+//
+// NOTE: As the revocation log only stores revoked states in a compact form,
+// the returned commitment won't include the commitment transaction or
+// signature, nor the signature and onion blob of its HTLCs.
 func (c *OpenChannel) FindPreviousState(updateNum uint64) (*ChannelCommitment, error) {
 	c.RLock()
 	defer c.RUnlock()
@@ -1502,6 +1518,12 @@ const (
 	// we or the remote fail at some point during the opening workflow, or
 	// we timeout waiting for the funding transaction to be confirmed.
 	FundingCanceled
+
+	// Abandoned indicates that the channel was removed from the database
+	// by the operator without any on-chain action, as it's deemed
+	// unrecoverable. This can be the case if its funding transaction
+	// never confirmed, or its counterparty is known to be defunct.
+	Abandoned
 )
 
 // ChannelCloseSummary contains the final state of a channel at the point it
@@ -1574,63 +1596,83 @@ func (c *OpenChannel) CloseChannel(summary *ChannelCloseSummary) error {
 	defer c.Unlock()
 
 	return c.Db.Update(func(tx *bolt.Tx) error {
-		openChanBucket := tx.Bucket(openChannelBucket)
-		if openChanBucket == nil {
-			return ErrNoChanDBExists
-		}
+		return c.closeChannel(tx, summary)
+	})
+}
 
-		nodePub := c.IdentityPub.SerializeCompressed()
-		nodeChanBucket := openChanBucket.Bucket(nodePub)
-		if nodeChanBucket == nil {
-			return ErrNoActiveChannels
-		}
+// closeChannel deletes all saved state of the channel within the passed
+// database transaction, leaving only the passed close summary behind.
+//
+// NOTE: The channel's mutex must be held when calling this method.
+func (c *OpenChannel) closeChannel(tx *bolt.Tx,
+	summary *ChannelCloseSummary) error {
 
-		chainBucket := nodeChanBucket.Bucket(c.ChainHash[:])
-		if chainBucket == nil {
-			return ErrNoActiveChannels
-		}
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return ErrNoChanDBExists
+	}
+
+	nodePub := c.IdentityPub.SerializeCompressed()
+	nodeChanBucket := openChanBucket.Bucket(nodePub)
+	if nodeChanBucket == nil {
+		return ErrNoActiveChannels
+	}
+
+	chainBucket := nodeChanBucket.Bucket(c.ChainHash[:])
+	if chainBucket == nil {
+		return ErrNoActiveChannels
+	}
+
+	var chanPointBuf bytes.Buffer
+	chanPointBuf.Grow(outPointSize)
+	err := writeOutpoint(&chanPointBuf, &c.FundingOutpoint)
+	if err != nil {
+		return err
+	}
+	chanBucket := chainBucket.Bucket(chanPointBuf.Bytes())
+	if chanBucket == nil {
+		return ErrNoActiveChannels
+	}
+
+	// Now that the index to this channel has been deleted, purge the
+	// remaining channel metadata from the database.
+	err = deleteOpenChannel(chanBucket, chanPointBuf.Bytes())
+	if err != nil {
+		return err
+	}
 
-		var chanPointBuf bytes.Buffer
-		chanPointBuf.Grow(outPointSize)
-		err := writeOutpoint(&chanPointBuf, &c.FundingOutpoint)
+	// With the base channel data deleted, attempt to delete the
+	// information stored within the revocation log.
+	logBucket := chanBucket.Bucket(revocationLogBucket)
+	if logBucket != nil {
+		err := wipeChannelLogEntries(logBucket)
 		if err != nil {
 			return err
 		}
-		chanBucket := chainBucket.Bucket(chanPointBuf.Bytes())
-		if chanBucket == nil {
-			return ErrNoActiveChannels
-		}
-
-		// Now that the index to this channel has been deleted, purge
-		// the remaining channel metadata from the database.
-		err = deleteOpenChannel(chanBucket, chanPointBuf.Bytes())
+		err = chanBucket.DeleteBucket(revocationLogBucket)
 		if err != nil {
 			return err
 		}
+	}
 
-		// With the base channel data deleted, attempt to delete the
-		// information stored within the revocation log.
-		logBucket := chanBucket.Bucket(revocationLogBucket)
-		if logBucket != nil {
-			err := wipeChannelLogEntries(logBucket)
-			if err != nil {
-				return err
-			}
-			err = chanBucket.DeleteBucket(revocationLogBucket)
-			if err != nil {
-				return err
-			}
-		}
+	err = chainBucket.DeleteBucket(chanPointBuf.Bytes())
+	if err != nil {
+		return err
+	}
 
-		err = chainBucket.DeleteBucket(chanPointBuf.Bytes())
+	// If the channel is already fully closed, we'll also remove any label
+	// the operator attached to it. Otherwise, the label is kept until the
+	// channel is marked as fully closed.
+	if !summary.IsPending {
+		err := deleteChannelLabel(tx, chanPointBuf.Bytes())
 		if err != nil {
 			return err
 		}
+	}
 
-		// Finally, create a summary of this channel in the closed
-		// channel bucket for this node.
-		return putChannelCloseSummary(tx, chanPointBuf.Bytes(), summary)
-	})
+	// Finally, create a summary of this channel in the closed channel
+	// bucket for this node.
+	return putChannelCloseSummary(tx, chanPointBuf.Bytes(), summary)
 }
 
 // ChannelSnapshot is a frozen snapshot of the current channel state. A
@@ -1756,7 +1798,31 @@ func putChannelCloseSummary(tx *bolt.Tx, chanID []byte,
 		return err
 	}
 
-	return closedChanBucket.Put(chanID, b.Bytes())
+	if err := closedChanBucket.Put(chanID, b.Bytes()); err != nil {
+		return err
+	}
+
+	return indexClosingTxid(tx, summary.ClosingTXID, chanID)
+}
+
+// indexClosingTxid adds the channel point of a closed channel to the index of
+// closed channels by their closing txid. Channels which never confirmed have
+// no closing transaction, so a zero txid isn't indexed.
+func indexClosingTxid(tx *bolt.Tx, closingTxid chainhash.Hash,
+	chanID []byte) error {
+
+	if closingTxid == (chainhash.Hash{}) {
+		return nil
+	}
+
+	txidIndex, err := tx.CreateBucketIfNotExists(
+		closedChannelTxidIndexBucket,
+	)
+	if err != nil {
+		return err
+	}
+
+	return txidIndex.Put(closingTxid[:], chanID)
 }
 
 func serializeChannelCloseSummary(w io.Writer, cs *ChannelCloseSummary) error {
@@ -1834,6 +1900,10 @@ func putChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	if err := writeElement(&w, channel.ThawHeight); err != nil {
+		return err
+	}
+
 	return chanBucket.Put(chanInfoKey, w.Bytes())
 }
 
@@ -1943,6 +2013,13 @@ func fetchChanInfo(chanBucket *bolt.Bucket, channel *OpenChannel) error {
 		return err
 	}
 
+	// Channels stored before they could be frozen lack a thaw height.
+	if r.Len() > 0 {
+		if err := readElement(r, &channel.ThawHeight); err != nil {
+			return err
+		}
+	}
+
 	channel.Packager = NewChannelPackager(channel.ShortChanID)
 
 	return nil
@@ -2059,11 +2136,83 @@ func makeLogKey(updateNum uint64) [8]byte {
 	return key
 }
 
+// serializeRevocationLogEntry writes the compact form of a revoked commitment
+// in which it's stored within the revocation log. Only the fields needed to
+// reconstruct and sweep a breach of the revoked state are written: the
+// commitment transaction and signature, as well as the signature and onion
+// blob of each HTLC, are omitted as they're never consulted once the state
+// has been revoked.
+func serializeRevocationLogEntry(w io.Writer, c *ChannelCommitment) error {
+	if err := writeElements(w,
+		c.CommitHeight, c.LocalLogIndex, c.LocalHtlcIndex,
+		c.RemoteLogIndex, c.RemoteHtlcIndex, c.LocalBalance,
+		c.RemoteBalance, c.CommitFee, c.FeePerKw,
+	); err != nil {
+		return err
+	}
+
+	numHtlcs := uint16(len(c.Htlcs))
+	if err := writeElement(w, numHtlcs); err != nil {
+		return err
+	}
+
+	for _, htlc := range c.Htlcs {
+		if err := writeElements(w,
+			htlc.RHash, htlc.Amt, htlc.RefundTimeout,
+			htlc.OutputIndex, htlc.Incoming, htlc.HtlcIndex,
+			htlc.LogIndex,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializeRevocationLogEntry reads a revoked commitment previously written
+// by serializeRevocationLogEntry. The fields omitted from the revocation log
+// are left empty within the returned commitment.
+func deserializeRevocationLogEntry(r io.Reader) (ChannelCommitment, error) {
+	var c ChannelCommitment
+
+	err := readElements(r,
+		&c.CommitHeight, &c.LocalLogIndex, &c.LocalHtlcIndex,
+		&c.RemoteLogIndex, &c.RemoteHtlcIndex, &c.LocalBalance,
+		&c.RemoteBalance, &c.CommitFee, &c.FeePerKw,
+	)
+	if err != nil {
+		return c, err
+	}
+
+	var numHtlcs uint16
+	if err := readElement(r, &numHtlcs); err != nil {
+		return c, err
+	}
+
+	if numHtlcs == 0 {
+		return c, nil
+	}
+
+	c.Htlcs = make([]HTLC, numHtlcs)
+	for i := uint16(0); i < numHtlcs; i++ {
+		if err := readElements(r,
+			&c.Htlcs[i].RHash, &c.Htlcs[i].Amt,
+			&c.Htlcs[i].RefundTimeout, &c.Htlcs[i].OutputIndex,
+			&c.Htlcs[i].Incoming, &c.Htlcs[i].HtlcIndex,
+			&c.Htlcs[i].LogIndex,
+		); err != nil {
+			return c, err
+		}
+	}
+
+	return c, nil
+}
+
 func appendChannelLogEntry(log *bolt.Bucket,
 	commit *ChannelCommitment) error {
 
 	var b bytes.Buffer
-	if err := serializeChanCommit(&b, commit); err != nil {
+	if err := serializeRevocationLogEntry(&b, commit); err != nil {
 		return err
 	}
 
@@ -2081,7 +2230,7 @@ func fetchChannelLogEntry(log *bolt.Bucket,
 	}
 
 	commitReader := bytes.NewReader(commitBytes)
-	return deserializeChanCommit(commitReader)
+	return deserializeRevocationLogEntry(commitReader)
 }
 
 func wipeChannelLogEntries(log *bolt.Bucket) error {
@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -214,6 +215,7 @@ func createTestChannelState(cdb *DB) (*OpenChannel, error) {
 			CommitSig:     bytes.Repeat([]byte{1}, 71),
 		},
 		NumConfsRequired:        4,
+		ThawHeight:              500000,
 		RemoteCurrentRevocation: privKey.PubKey(),
 		RemoteNextRevocation:    privKey.PubKey(),
 		RevocationProducer:      producer,
@@ -344,6 +346,23 @@ func assertCommitmentEqual(t *testing.T, a, b *ChannelCommitment) {
 	}
 }
 
+// revokedCommitment returns a copy of the passed commitment containing only
+// the fields which are retained within the revocation log.
+func revokedCommitment(c *ChannelCommitment) *ChannelCommitment {
+	revoked := *c
+	revoked.CommitTx = nil
+	revoked.CommitSig = nil
+
+	revoked.Htlcs = nil
+	for _, htlc := range c.Htlcs {
+		htlc.Signature = nil
+		htlc.OnionBlob = nil
+		revoked.Htlcs = append(revoked.Htlcs, htlc)
+	}
+
+	return &revoked
+}
+
 func TestChannelStateTransition(t *testing.T) {
 	t.Parallel()
 
@@ -538,8 +557,11 @@ func TestChannelStateTransition(t *testing.T) {
 	}
 
 	// The two deltas (the original vs the on-disk version) should
-	// identical, and all HTLC data should properly be retained.
-	assertCommitmentEqual(t, &oldRemoteCommit, diskPrevCommit)
+	// identical, and all HTLC data needed to act on a breach should
+	// properly be retained.
+	assertCommitmentEqual(
+		t, revokedCommitment(&oldRemoteCommit), diskPrevCommit,
+	)
 
 	// The state number recovered from the tail of the revocation log
 	// should be identical to this current state.
@@ -575,7 +597,7 @@ func TestChannelStateTransition(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to fetch past delta: %v", err)
 	}
-	assertCommitmentEqual(t, &oldRemoteCommit, prevCommit)
+	assertCommitmentEqual(t, revokedCommitment(&oldRemoteCommit), prevCommit)
 
 	// Once again, state number recovered from the tail of the revocation
 	// log should be identical to this current state.
@@ -805,6 +827,17 @@ func TestFetchClosedChannels(t *testing.T) {
 			spew.Sdump(summary), spew.Sdump(closed[0]))
 	}
 
+	// The summary should also be found by the txid of the closing
+	// transaction.
+	txidSummary, err := cdb.LookupCloseByTxid(&summary.ClosingTXID)
+	if err != nil {
+		t.Fatalf("unable to lookup closed channel by txid: %v", err)
+	}
+	if !reflect.DeepEqual(summary, txidSummary) {
+		t.Fatalf("database summaries don't match: expected %v got %v",
+			spew.Sdump(summary), spew.Sdump(txidSummary))
+	}
+
 	// Mark the channel as fully closed.
 	err = cdb.MarkChanFullyClosed(&state.FundingOutpoint)
 	if err != nil {
@@ -830,3 +863,95 @@ func TestFetchClosedChannels(t *testing.T) {
 			"got %v", 0, len(closed))
 	}
 }
+
+// TestAbandonChannel tests that abandoning a channel removes all of its state
+// from the database, leaving only a tombstone close summary behind.
+func TestAbandonChannel(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	defer cleanUp()
+
+	state, err := createTestChannelState(cdb)
+	if err != nil {
+		t.Fatalf("unable to create channel state: %v", err)
+	}
+	addr := &net.TCPAddr{
+		IP:   net.ParseIP("127.0.0.1"),
+		Port: 18555,
+	}
+	if err := state.SyncPending(addr, 99); err != nil {
+		t.Fatalf("unable to save and serialize channel state: %v", err)
+	}
+	chanOpenLoc := lnwire.ShortChannelID{
+		BlockHeight: 5,
+		TxIndex:     10,
+		TxPosition:  15,
+	}
+	if err := state.MarkAsOpen(chanOpenLoc); err != nil {
+		t.Fatalf("unable to mark channel as open: %v", err)
+	}
+
+	// We'll also write a forwarding package for the channel, which should
+	// be removed along with the rest of its state.
+	fwdPkg := NewFwdPkg(chanOpenLoc, 0, nil, nil)
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		return state.Packager.AddFwdPkg(tx, fwdPkg)
+	})
+	if err != nil {
+		t.Fatalf("unable to add fwd pkg: %v", err)
+	}
+
+	// Abandoning an unknown channel should fail.
+	unknownChanPoint := state.FundingOutpoint
+	unknownChanPoint.Index++
+	err = cdb.AbandonChannel(&unknownChanPoint, 100)
+	if err != ErrChannelNotFound {
+		t.Fatalf("expected ErrChannelNotFound, instead got: %v", err)
+	}
+
+	if err := cdb.AbandonChannel(&state.FundingOutpoint, 100); err != nil {
+		t.Fatalf("unable to abandon channel: %v", err)
+	}
+
+	// The channel should no longer be found amongst the open channels.
+	_, err = cdb.FetchChannel(state.FundingOutpoint)
+	if err != ErrChannelNotFound {
+		t.Fatalf("expected ErrChannelNotFound, instead got: %v", err)
+	}
+
+	// Instead, a fully closed summary of type Abandoned should be left
+	// behind.
+	summary, err := cdb.FetchClosedChannel(&state.FundingOutpoint)
+	if err != nil {
+		t.Fatalf("unable to fetch close summary: %v", err)
+	}
+	if summary.CloseType != Abandoned {
+		t.Fatalf("expected close type %v, got %v", Abandoned,
+			summary.CloseType)
+	}
+	if summary.IsPending {
+		t.Fatalf("abandoned channel shouldn't be pending close")
+	}
+	if summary.CloseHeight != 100 {
+		t.Fatalf("expected close height %v, got %v", 100,
+			summary.CloseHeight)
+	}
+
+	// Finally, the forwarding packages of the channel should be gone.
+	var fwdPkgs []*FwdPkg
+	err = cdb.View(func(tx *bolt.Tx) error {
+		var err error
+		fwdPkgs, err = state.Packager.LoadFwdPkgs(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to load fwd pkgs: %v", err)
+	}
+	if len(fwdPkgs) != 0 {
+		t.Fatalf("expected no fwd pkgs, found %v", len(fwdPkgs))
+	}
+}
@@ -0,0 +1,732 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// Inconsistency is a record within the database which can't be decoded, or
+// an index entry which doesn't agree with the records it indexes, as found
+// by CheckDB.
+type Inconsistency struct {
+	// Bucket is the path of the bucket the record or index entry is
+	// stored within, with the names of nested buckets separated by
+	// slashes.
+	Bucket string
+
+	// Key is the key the record or index entry is stored under.
+	Key []byte
+
+	// Err describes the inconsistency.
+	Err error
+}
+
+// CheckDB walks all records of the channel and graph databases within dbPath,
+// decoding each of them strictly, and cross-checks the indexes over them
+// against the records they index. All inconsistencies are collected rather
+// than returned on the first one, so the damage can be assessed before it
+// causes a failure at runtime. The databases are opened read-only, without
+// running any migrations, so lnd must not be running. Policies encrypted at
+// rest can't be decoded without their key, so only their index entries are
+// checked.
+//
+// NOTE: As the bolt database panics on corrupt pages, such panics are
+// recovered from and returned as an error, along with the inconsistencies
+// found before.
+func CheckDB(dbPath string) ([]*Inconsistency, error) {
+	path := filepath.Join(dbPath, dbName)
+	if !fileExists(path) {
+		return nil, ErrNoChanDBExists
+	}
+
+	c := &dbChecker{}
+	if err := checkBoltDB(path, c.checkChannelDB); err != nil {
+		return c.inconsistencies, err
+	}
+
+	// Databases which haven't been opened since the graph was moved into
+	// its own file still have it stored within the channel database,
+	// where it was checked above.
+	graphPath := filepath.Join(dbPath, graphDBName)
+	if !fileExists(graphPath) {
+		return c.inconsistencies, nil
+	}
+
+	err := checkBoltDB(graphPath, c.checkGraph)
+	return c.inconsistencies, err
+}
+
+// checkBoltDB opens the bolt database at path read-only, and calls check
+// within a read-only transaction of it.
+func checkBoltDB(path string, check func(*bolt.Tx) error) error {
+	bdb, err := bolt.Open(path, dbFilePermission, &bolt.Options{
+		ReadOnly: true,
+		Timeout:  readOnlyLockTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer bdb.Close()
+
+	return bdb.View(func(tx *bolt.Tx) error {
+		return recoverPanic(func() error {
+			return check(tx)
+		})
+	})
+}
+
+// dbChecker collects the inconsistencies found while checking the database.
+type dbChecker struct {
+	inconsistencies []*Inconsistency
+}
+
+// report records an inconsistency of the record or index entry stored under
+// key within the passed bucket.
+func (c *dbChecker) report(bucket string, key []byte, format string,
+	args ...interface{}) {
+
+	// The key is copied, as it's only valid for the duration of the
+	// transaction.
+	c.inconsistencies = append(c.inconsistencies, &Inconsistency{
+		Bucket: bucket,
+		Key:    append([]byte(nil), key...),
+		Err:    fmt.Errorf(format, args...),
+	})
+}
+
+// checkChannelDB checks all records stored within the channel database.
+func (c *dbChecker) checkChannelDB(tx *bolt.Tx) error {
+	checks := []func(*bolt.Tx) error{
+		c.checkLinkNodes,
+		c.checkOpenChannels,
+		c.checkClosedChannels,
+		c.checkInvoices,
+		c.checkPayments,
+		c.checkPolicies,
+		c.checkGraph,
+	}
+	for _, check := range checks {
+		if err := check(tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLinkNodes checks that all link nodes can be decoded.
+func (c *dbChecker) checkLinkNodes(tx *bolt.Tx) error {
+	linkNodes := tx.Bucket(nodeInfoBucket)
+	if linkNodes == nil {
+		return nil
+	}
+	bucketName := string(nodeInfoBucket)
+
+	return linkNodes.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		r := bytes.NewReader(v)
+		node, err := deserializeLinkNode(r)
+		switch {
+		case err != nil:
+			c.report(bucketName, k, "unable to decode link node: %v",
+				err)
+
+		case r.Len() != 0:
+			c.report(bucketName, k, "%v trailing bytes after link "+
+				"node", r.Len())
+
+		case !bytes.Equal(node.IdentityPub.SerializeCompressed(), k):
+			c.report(bucketName, k, "link node stored under key of "+
+				"other node")
+		}
+
+		return nil
+	})
+}
+
+// checkOpenChannels checks that all open channels can be decoded, and that
+// they can be found through the link node of their remote node.
+func (c *dbChecker) checkOpenChannels(tx *bolt.Tx) error {
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return nil
+	}
+	linkNodes := tx.Bucket(nodeInfoBucket)
+	bucketName := string(openChannelBucket)
+
+	return openChanBucket.ForEach(func(nodePub, v []byte) error {
+		nodeBucket := openChanBucket.Bucket(nodePub)
+		if v != nil || nodeBucket == nil {
+			return nil
+		}
+
+		// Channels are only ever loaded through the link node of their
+		// remote node, so without one they're never seen.
+		if linkNodes == nil || linkNodes.Get(nodePub) == nil {
+			c.report(bucketName, nodePub, "channels with node "+
+				"lacking a link node")
+		}
+
+		return nodeBucket.ForEach(func(chainHash, v []byte) error {
+			chainBucket := nodeBucket.Bucket(chainHash)
+			if v != nil || chainBucket == nil {
+				return nil
+			}
+
+			return chainBucket.ForEach(func(chanPnt, v []byte) error {
+				chanBucket := chainBucket.Bucket(chanPnt)
+				if v != nil || chanBucket == nil {
+					return nil
+				}
+
+				c.checkOpenChannel(
+					bucketName, nodePub, chanPnt, chanBucket,
+				)
+				return nil
+			})
+		})
+	})
+}
+
+// checkOpenChannel checks that the open channel stored within chanBucket
+// under the channel point chanPnt can be decoded, and that it's stored under
+// the node it's been opened with.
+func (c *dbChecker) checkOpenChannel(bucketName string, nodePub,
+	chanPnt []byte, chanBucket *bolt.Bucket) {
+
+	var chanPoint wire.OutPoint
+	r := bytes.NewReader(chanPnt)
+	if err := readOutpoint(r, &chanPoint); err != nil || r.Len() != 0 {
+		c.report(bucketName, chanPnt, "invalid channel point")
+		return
+	}
+
+	channel, err := fetchOpenChannel(chanBucket, &chanPoint)
+	if err != nil {
+		c.report(bucketName, chanPnt, "unable to decode channel: %v",
+			err)
+		return
+	}
+
+	if !bytes.Equal(channel.IdentityPub.SerializeCompressed(), nodePub) {
+		c.report(bucketName, chanPnt, "channel stored under node %x, "+
+			"but opened with %x", nodePub,
+			channel.IdentityPub.SerializeCompressed())
+	}
+}
+
+// checkClosedChannels checks that all close summaries can be decoded, and
+// that the index of closed channels by their closing txid agrees with them.
+func (c *dbChecker) checkClosedChannels(tx *bolt.Tx) error {
+	closedChans := tx.Bucket(closedChannelBucket)
+	bucketName := string(closedChannelBucket)
+
+	summaries := make(map[string]*ChannelCloseSummary)
+	if closedChans != nil {
+		err := closedChans.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			r := bytes.NewReader(v)
+			summary, err := deserializeCloseChannelSummary(r)
+			if err != nil {
+				c.report(bucketName, k, "unable to decode close "+
+					"summary: %v", err)
+				return nil
+			}
+			if r.Len() != 0 {
+				c.report(bucketName, k, "%v trailing bytes after "+
+					"close summary", r.Len())
+			}
+
+			var chanPoint bytes.Buffer
+			err = writeOutpoint(&chanPoint, &summary.ChanPoint)
+			if err != nil || !bytes.Equal(chanPoint.Bytes(), k) {
+				c.report(bucketName, k, "close summary of %v "+
+					"stored under other channel point",
+					summary.ChanPoint)
+			}
+
+			summaries[string(k)] = summary
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	txidIndex := tx.Bucket(closedChannelTxidIndexBucket)
+	if txidIndex == nil {
+		return nil
+	}
+	indexName := string(closedChannelTxidIndexBucket)
+
+	return txidIndex.ForEach(func(txid, chanPnt []byte) error {
+		if chanPnt == nil {
+			return nil
+		}
+
+		if closedChans == nil || closedChans.Get(chanPnt) == nil {
+			c.report(indexName, txid, "indexed close summary %x not "+
+				"found", chanPnt)
+			return nil
+		}
+
+		// Summaries which can't be decoded have been reported above.
+		summary, ok := summaries[string(chanPnt)]
+		if ok && !bytes.Equal(summary.ClosingTXID[:], txid) {
+			c.report(indexName, txid, "indexed close summary %x "+
+				"has closing txid %v", chanPnt,
+				summary.ClosingTXID)
+		}
+
+		return nil
+	})
+}
+
+// checkInvoices checks that all invoices can be decoded, and that the index
+// of invoices by their payment hash agrees with them.
+func (c *dbChecker) checkInvoices(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+	bucketName := string(invoiceBucket)
+
+	var (
+		invoiceKeys [][]byte
+		hashes      = make(map[string][32]byte)
+	)
+	err := invoices.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		r := bytes.NewReader(v)
+		invoice, err := deserializeStoredInvoice(r)
+		if err != nil {
+			c.report(bucketName, k, "unable to decode invoice: %v",
+				err)
+			return nil
+		}
+		if r.Len() != 0 {
+			c.report(bucketName, k, "%v trailing bytes after invoice",
+				r.Len())
+		}
+
+		invoiceKeys = append(invoiceKeys, append([]byte(nil), k...))
+		hashes[string(k)] = invoice.PaymentHash()
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	index := invoices.Bucket(invoiceIndexBucket)
+	if index == nil {
+		if len(invoiceKeys) > 0 {
+			c.report(bucketName, nil, "payment hash index missing")
+		}
+		return nil
+	}
+	indexName := bucketName + "/" + string(invoiceIndexBucket)
+
+	var numInvoices uint32
+	if v := index.Get(numInvoicesKey); len(v) == 4 {
+		numInvoices = byteOrder.Uint32(v)
+	}
+
+	indexed := make(map[string]struct{})
+	err = index.ForEach(func(k, v []byte) error {
+		if v == nil || bytes.Equal(k, numInvoicesKey) {
+			return nil
+		}
+
+		hash, ok := hashes[string(v)]
+		switch {
+		case invoices.Get(v) == nil:
+			c.report(indexName, k, "indexed invoice %x not found", v)
+
+		// Invoices which can't be decoded have been reported above.
+		case !ok:
+
+		case !bytes.Equal(hash[:], k):
+			c.report(indexName, k, "indexed invoice %x has payment "+
+				"hash %x", v, hash[:])
+
+		default:
+			indexed[string(v)] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range invoiceKeys {
+		if _, ok := indexed[string(k)]; !ok {
+			c.report(bucketName, k, "invoice not indexed by its "+
+				"payment hash")
+		}
+
+		// The counter holds the number of the next invoice, which
+		// must be beyond that of all stored invoices.
+		if len(k) == 4 && byteOrder.Uint32(k) >= numInvoices {
+			c.report(bucketName, k, "invoice not below invoice "+
+				"counter %v", numInvoices)
+		}
+	}
+
+	return nil
+}
+
+// checkPayments checks that all payments and their attempts can be decoded,
+// and that the index of payments by their payment hash agrees with them.
+func (c *dbChecker) checkPayments(tx *bolt.Tx) error {
+	payments := tx.Bucket(paymentBucket)
+	attempts := tx.Bucket(paymentAttemptsBucket)
+	bucketName := string(paymentBucket)
+
+	var (
+		paymentKeys [][]byte
+		hashes      = make(map[string][32]byte)
+	)
+	if payments != nil {
+		err := payments.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+			if len(k) != 8 {
+				c.report(bucketName, k, "invalid payment key")
+				return nil
+			}
+
+			r := bytes.NewReader(v)
+			payment, err := deserializeOutgoingPayment(r)
+			if err != nil {
+				c.report(bucketName, k, "unable to decode "+
+					"payment: %v", err)
+				return nil
+			}
+			if r.Len() != 0 {
+				c.report(bucketName, k, "%v trailing bytes after "+
+					"payment", r.Len())
+			}
+
+			_, err = fetchPaymentAttempts(attempts, k)
+			if err != nil {
+				c.report(bucketName, k, "unable to decode payment "+
+					"attempts: %v", err)
+			}
+
+			paymentKeys = append(paymentKeys, append([]byte(nil), k...))
+			hashes[string(k)] = sha256.Sum256(
+				payment.PaymentPreimage[:],
+			)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Attempts are deleted along with their payment, so any left behind
+	// are orphans.
+	if attempts != nil {
+		attemptsName := string(paymentAttemptsBucket)
+		err := attempts.ForEach(func(k, v []byte) error {
+			if v == nil && (payments == nil || payments.Get(k) == nil) {
+				c.report(attemptsName, k, "attempts of unknown "+
+					"payment")
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	hashIndex := tx.Bucket(paymentHashIndexBucket)
+	if hashIndex == nil {
+		if len(paymentKeys) > 0 {
+			c.report(bucketName, nil, "payment hash index missing")
+		}
+		return nil
+	}
+	indexName := string(paymentHashIndexBucket)
+
+	indexed := make(map[string]struct{})
+	err := hashIndex.ForEach(func(k, _ []byte) error {
+		if len(k) != 40 {
+			c.report(indexName, k, "invalid payment hash index key")
+			return nil
+		}
+
+		seqKey := k[32:]
+		hash, ok := hashes[string(seqKey)]
+		switch {
+		case payments == nil || payments.Get(seqKey) == nil:
+			c.report(indexName, k, "indexed payment %v not found",
+				binary.BigEndian.Uint64(seqKey))
+
+		// Payments which can't be decoded have been reported above.
+		case !ok:
+
+		case !bytes.Equal(hash[:], k[:32]):
+			c.report(indexName, k, "indexed payment %v has payment "+
+				"hash %x", binary.BigEndian.Uint64(seqKey),
+				hash[:])
+
+		default:
+			indexed[string(seqKey)] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range paymentKeys {
+		if _, ok := indexed[string(k)]; !ok {
+			c.report(bucketName, k, "payment not indexed by its "+
+				"payment hash")
+		}
+	}
+
+	return nil
+}
+
+// checkPolicies checks that all payment hash and node policies can be
+// decoded, that each is stored under the key matching its amount band, and
+// that the fee index of the payment hash policies agrees with them.
+func (c *dbChecker) checkPolicies(tx *bolt.Tx) error {
+	nodePolicies := tx.Bucket(nodePolicyBucket)
+	if nodePolicies != nil {
+		err := c.checkPolicyBucket(
+			string(nodePolicyBucket), nodePolicies, 33,
+			func([]byte, *Policy) {},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	policies := tx.Bucket(policyBucket)
+	if policies == nil {
+		return nil
+	}
+	bucketName := string(policyBucket)
+
+	// The fee of each policy is recorded to cross-check the fee index,
+	// unless the policy is encrypted, in which case it's only checked
+	// that it's indexed.
+	var (
+		policyKeys [][]byte
+		fees       = make(map[string]lnwire.MilliSatoshi)
+	)
+	err := c.checkPolicyBucket(
+		bucketName, policies, 32, func(k []byte, policy *Policy) {
+			policyKeys = append(policyKeys, append([]byte(nil), k...))
+			if policy != nil {
+				fees[string(k)] = policy.Fee
+			}
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	feeIndex := policies.Bucket(policyFeeIndexBucket)
+	if feeIndex == nil {
+		if len(policyKeys) > 0 {
+			c.report(bucketName, nil, "fee index missing")
+		}
+		return nil
+	}
+	indexName := bucketName + "/" + string(policyFeeIndexBucket)
+
+	indexed := make(map[string]struct{})
+	err = feeIndex.ForEach(func(k, _ []byte) error {
+		if len(k) <= 8 {
+			c.report(indexName, k, "invalid fee index key")
+			return nil
+		}
+
+		fee := lnwire.MilliSatoshi(byteOrder.Uint64(k[:8]))
+		policyKey := k[8:]
+		policyFee, ok := fees[string(policyKey)]
+		switch {
+		case policies.Get(policyKey) == nil:
+			c.report(indexName, k, "indexed policy %x not found",
+				policyKey)
+
+		case ok && policyFee != fee:
+			c.report(indexName, k, "indexed policy %x has fee %v, "+
+				"not %v", policyKey, policyFee, fee)
+
+		default:
+			indexed[string(policyKey)] = struct{}{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range policyKeys {
+		if _, ok := indexed[string(k)]; !ok {
+			c.report(bucketName, k, "policy not indexed by its fee")
+		}
+	}
+
+	return nil
+}
+
+// checkPolicyBucket decodes all policies stored within the passed policy
+// bucket, whose keys are prefixed by prefixLen bytes, and checks that each
+// is stored under the key matching its amount band. The default policy is
+// checked as well, but cb is only called for the other policies, with a nil
+// policy if it's encrypted.
+func (c *dbChecker) checkPolicyBucket(bucketName string, policies *bolt.Bucket,
+	prefixLen int, cb func([]byte, *Policy)) error {
+
+	return policies.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		isDefault := bytes.Equal(k, defaultPolicyKey)
+		if !isDefault && len(k) < prefixLen {
+			c.report(bucketName, k, "invalid policy key")
+			return nil
+		}
+
+		policyBytes, err := (*policyCrypter)(nil).open(k, v)
+		if err == ErrPolicyEncrypted {
+			if !isDefault {
+				cb(k, nil)
+			}
+			return nil
+		}
+
+		var policy *Policy
+		if err == nil {
+			policy, err = deserializePolicy(
+				bytes.NewReader(policyBytes),
+			)
+		}
+		if err != nil {
+			c.report(bucketName, k, "unable to decode policy: %v",
+				err)
+			return nil
+		}
+
+		if isDefault {
+			return nil
+		}
+
+		if !bytes.Equal(policyKey(k[:prefixLen], policy), k) {
+			c.report(bucketName, k, "policy stored under key not "+
+				"matching its amount band")
+		}
+
+		cb(k, policy)
+		return nil
+	})
+}
+
+// checkGraph checks that all nodes and edges of the channel graph can be
+// decoded, and that the index of edges by their channel point agrees with
+// them.
+func (c *dbChecker) checkGraph(tx *bolt.Tx) error {
+	nodes := tx.Bucket(nodeBucket)
+	if nodes != nil {
+		bucketName := string(nodeBucket)
+		err := nodes.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			if bytes.Equal(k, sourceKey) {
+				if nodes.Get(v) == nil {
+					c.report(bucketName, k, "source node %x "+
+						"not found", v)
+				}
+				return nil
+			}
+
+			_, err := deserializeLightningNode(bytes.NewReader(v))
+			if err != nil {
+				c.report(bucketName, k, "unable to decode "+
+					"node: %v", err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return nil
+	}
+	edgesName := string(edgeBucket)
+
+	edgeIndex := edges.Bucket(edgeIndexBucket)
+	if edgeIndex != nil {
+		indexName := edgesName + "/" + string(edgeIndexBucket)
+		err := edgeIndex.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil
+			}
+
+			_, err := deserializeChanEdgeInfo(bytes.NewReader(v))
+			if err != nil {
+				c.report(indexName, k, "unable to decode edge: %v",
+					err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	chanIndex := edges.Bucket(channelPointBucket)
+	if chanIndex == nil {
+		return nil
+	}
+	indexName := edgesName + "/" + string(channelPointBucket)
+
+	return chanIndex.ForEach(func(chanPnt, chanID []byte) error {
+		if chanID == nil {
+			return nil
+		}
+
+		if edgeIndex == nil || edgeIndex.Get(chanID) == nil {
+			c.report(indexName, chanPnt, "indexed edge %x not found",
+				chanID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,152 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestCheckDB tests that a consistent database passes the integrity check,
+// and that corrupt records and inconsistent index entries are reported.
+func TestCheckDB(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	cdb, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	invoice, err := randInvoice(1000)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := cdb.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	// An unsettled hold invoice doesn't know its preimage yet, but should
+	// still be found indexed by its payment hash.
+	holdInvoice, err := randInvoice(1000)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	holdInvoice.Terms.PaymentPreimage = [32]byte{}
+	holdInvoice.Terms.Hold = true
+	holdInvoice.Terms.PaymentHash = sha256.Sum256([]byte("hold"))
+	if err := cdb.AddInvoice(holdInvoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+
+	payment := makeFakePayment()
+	if err := cdb.AddPayment(payment); err != nil {
+		t.Fatalf("unable to add payment: %v", err)
+	}
+
+	policy := &Policy{
+		PaymentHash: sha256.Sum256([]byte("policy")),
+		Fee:         1000,
+	}
+	if err := cdb.AddPolicy(policy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	cdb.Close()
+
+	inconsistencies, err := CheckDB(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to check db: %v", err)
+	}
+	if len(inconsistencies) != 0 {
+		t.Fatalf("expected consistent db, got %v inconsistencies, "+
+			"first: %v", len(inconsistencies), inconsistencies[0].Err)
+	}
+
+	// Corrupt the database by truncating the invoice, removing the
+	// payment from the payment hash index, and indexing the policy under
+	// the wrong fee.
+	paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
+	cdb, err = Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		var invoiceKey [4]byte
+		invoiceBytes := invoices.Get(invoiceKey[:])
+		err := invoices.Put(
+			invoiceKey[:], append([]byte(nil), invoiceBytes[:10]...),
+		)
+		if err != nil {
+			return err
+		}
+
+		hashIndex := tx.Bucket(paymentHashIndexBucket)
+		err = hashIndex.Delete(
+			paymentHashIndexKey(paymentHash, payment.SequenceNum),
+		)
+		if err != nil {
+			return err
+		}
+
+		feeIndex := tx.Bucket(policyBucket).Bucket(policyFeeIndexBucket)
+		policyKey := policy.PaymentHash[:]
+		err = feeIndex.Delete(feeIndexKey(policy.Fee, policyKey))
+		if err != nil {
+			return err
+		}
+		return feeIndex.Put(feeIndexKey(2000, policyKey), []byte{})
+	})
+	if err != nil {
+		t.Fatalf("unable to corrupt db: %v", err)
+	}
+	cdb.Close()
+
+	inconsistencies, err = CheckDB(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to check db: %v", err)
+	}
+
+	feeIndexName := string(policyBucket) + "/" +
+		string(policyFeeIndexBucket)
+	expected := map[string]bool{
+		string(invoiceBucket): false,
+		string(paymentBucket): false,
+		string(policyBucket):  false,
+		feeIndexName:          false,
+	}
+	for _, inconsistency := range inconsistencies {
+		if _, ok := expected[inconsistency.Bucket]; !ok {
+			t.Fatalf("unexpected inconsistency within %v: %v",
+				inconsistency.Bucket, inconsistency.Err)
+		}
+		expected[inconsistency.Bucket] = true
+	}
+	for bucket, found := range expected {
+		if !found {
+			t.Fatalf("no inconsistency reported within %v", bucket)
+		}
+	}
+
+	// The payment's sequence number should be the key the inconsistency
+	// of the payment was reported under.
+	for _, inconsistency := range inconsistencies {
+		if inconsistency.Bucket != string(paymentBucket) {
+			continue
+		}
+		if !bytes.Equal(inconsistency.Key,
+			paymentIndexKey(payment.SequenceNum)) {
+
+			t.Fatalf("payment inconsistency reported under key %x",
+				inconsistency.Key)
+		}
+	}
+}
@@ -0,0 +1,559 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+const (
+	// compactMarkerSuffix is appended to the path of the database to
+	// obtain the path of the file which, if present, causes the database
+	// to be compacted the next time it's opened.
+	compactMarkerSuffix = ".compact"
+
+	// compactTempSuffix is appended to the path of the database to obtain
+	// the path of the file the compacted database is written to, before
+	// it replaces the original.
+	compactTempSuffix = ".compacting"
+
+	// compactBackupSuffix is appended to the path of the database to
+	// obtain the path the original database is moved to once it has been
+	// replaced by its compacted copy. It's kept as a safety copy until the
+	// compacted database has been opened successfully.
+	compactBackupSuffix = ".precompact"
+
+	// compactLockTimeout is the maximum duration for which opening the
+	// database to compact it, or to record its free page statistics,
+	// waits to obtain its file lock. If another process holds the lock,
+	// the database is in use and can't be compacted, so we'll fail rather
+	// than block indefinitely.
+	compactLockTimeout = 5 * time.Second
+
+	// compactTxMaxSize is the maximum number of bytes of keys and values
+	// copied into the compacted database within a single transaction. As
+	// bolt holds all pages written by a transaction in memory until it's
+	// committed, this bounds the memory used to compact large databases.
+	compactTxMaxSize = 64 << 20
+)
+
+var (
+	// freePageStatsKey is the key within the meta bucket under which the
+	// free page statistics of the database, as of the last time it was
+	// opened, are stored.
+	freePageStatsKey = []byte("free-page-stats")
+
+	// autoCompactMinSize is the size in bytes the database must at least
+	// have for it to be compacted automatically due to its free pages. It
+	// prevents small databases, whose free page ratio is naturally high,
+	// from being compacted on every startup.
+	autoCompactMinSize int64 = 100 << 20
+)
+
+// FreePageStats describes the pages within the database file which bolt
+// keeps around for reuse, as it never returns freed pages to the filesystem.
+type FreePageStats struct {
+	// FreePages is the number of pages which are free, or pending to be
+	// freed once no transaction uses them anymore.
+	FreePages uint64
+
+	// TotalPages is the total number of pages within the database.
+	TotalPages uint64
+
+	// PageSize is the size of a single page in bytes.
+	PageSize uint32
+
+	// RecordedAt is the time at which the statistics were recorded.
+	RecordedAt time.Time
+}
+
+// FreeRatio returns the fraction of the pages within the database which are
+// free.
+func (s *FreePageStats) FreeRatio() float64 {
+	if s.TotalPages == 0 {
+		return 0
+	}
+
+	return float64(s.FreePages) / float64(s.TotalPages)
+}
+
+// Size returns the size of the database in bytes.
+func (s *FreePageStats) Size() int64 {
+	return int64(s.TotalPages) * int64(s.PageSize)
+}
+
+// FetchFreePageStats returns the free page statistics of the database as
+// recorded the last time it was opened. If they haven't been recorded yet,
+// then ErrMetaNotFound is returned.
+func (d *DB) FetchFreePageStats() (*FreePageStats, error) {
+	var stats *FreePageStats
+	err := d.View(func(tx *bolt.Tx) error {
+		metaBucket := tx.Bucket(metaBucket)
+		if metaBucket == nil {
+			return ErrMetaNotFound
+		}
+
+		statsBytes := metaBucket.Get(freePageStatsKey)
+		if statsBytes == nil {
+			return ErrMetaNotFound
+		}
+
+		var err error
+		stats, err = deserializeFreePageStats(statsBytes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// recordFreePageStats opens the database at path to determine its current
+// free page statistics, which are then stored within the meta bucket and
+// returned.
+//
+// NOTE: The database must not be open while its statistics are recorded.
+func recordFreePageStats(path string) (*FreePageStats, error) {
+	bdb, err := bolt.Open(path, dbFilePermission, &bolt.Options{
+		Timeout: compactLockTimeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer bdb.Close()
+
+	dbStats := bdb.Stats()
+	stats := &FreePageStats{
+		FreePages:  uint64(dbStats.FreePageN + dbStats.PendingPageN),
+		PageSize:   uint32(bdb.Info().PageSize),
+		RecordedAt: time.Now(),
+	}
+
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		stats.TotalPages = uint64(tx.Size()) / uint64(stats.PageSize)
+
+		metaBucket, err := tx.CreateBucketIfNotExists(metaBucket)
+		if err != nil {
+			return err
+		}
+
+		return metaBucket.Put(
+			freePageStatsKey, serializeFreePageStats(stats),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// needsCompaction returns true if the free pages within the database, as
+// described by stats, make up at least the passed fraction of it. Databases
+// smaller than autoCompactMinSize never need compaction. A non-positive
+// fraction disables automatic compaction.
+func needsCompaction(stats *FreePageStats, freeRatio float64) bool {
+	if freeRatio <= 0 || stats.Size() < autoCompactMinSize {
+		return false
+	}
+
+	return stats.FreeRatio() >= freeRatio
+}
+
+func serializeFreePageStats(stats *FreePageStats) []byte {
+	var b [28]byte
+	byteOrder.PutUint64(b[:8], stats.FreePages)
+	byteOrder.PutUint64(b[8:16], stats.TotalPages)
+	byteOrder.PutUint32(b[16:20], stats.PageSize)
+	byteOrder.PutUint64(b[20:], uint64(stats.RecordedAt.Unix()))
+
+	return b[:]
+}
+
+func deserializeFreePageStats(b []byte) (*FreePageStats, error) {
+	if len(b) != 28 {
+		return nil, fmt.Errorf("invalid free page stats length: %v",
+			len(b))
+	}
+
+	return &FreePageStats{
+		FreePages:  byteOrder.Uint64(b[:8]),
+		TotalPages: byteOrder.Uint64(b[8:16]),
+		PageSize:   byteOrder.Uint32(b[16:20]),
+		RecordedAt: time.Unix(int64(byteOrder.Uint64(b[20:])), 0),
+	}, nil
+}
+
+// ScheduleCompaction schedules the database to be compacted the next time
+// it's opened. As the database is in use by all subsystems while the daemon
+// is running, it can't be swapped out in place, so compaction is deferred
+// until the next startup.
+func (d *DB) ScheduleCompaction() error {
+	marker := d.DB.Path() + compactMarkerSuffix
+	return ioutil.WriteFile(marker, nil, dbFilePermission)
+}
+
+// compactionScheduled returns true if compaction of the database at path has
+// been scheduled using ScheduleCompaction.
+func compactionScheduled(path string) bool {
+	return fileExists(path + compactMarkerSuffix)
+}
+
+// maybeCompactDB compacts the database at path before it's opened, either on
+// every startup if so configured, once if compaction has been scheduled, or
+// if its free pages make up too large a fraction of it. As compaction
+// rewrites the database, it's skipped if it's opened read-only or to dry run
+// its migrations.
+func maybeCompactDB(path string, opts *Options) error {
+	if opts.ReadOnly || opts.DryRunMigration {
+		return nil
+	}
+
+	compact := opts.AutoCompact || compactionScheduled(path)
+
+	stats, err := recordFreePageStats(path)
+	if err != nil {
+		return err
+	}
+	if !compact && needsCompaction(stats, opts.AutoCompactFreeRatio) {
+		log.Infof("Free pages make up %.1f%% of %v, compacting it",
+			stats.FreeRatio()*100, filepath.Base(path))
+		compact = true
+	}
+
+	if !compact {
+		return nil
+	}
+	if err := compactDB(path); err != nil {
+		return err
+	}
+
+	// Record the statistics of the compacted database, so they reflect
+	// the database as it's opened.
+	_, err = recordFreePageStats(path)
+	return err
+}
+
+// compactDB compacts the database at path. As bolt never returns free
+// pages to the filesystem, all buckets, keys and values are copied into a
+// fresh file. The copy is verified to hold exactly the same contents as the
+// original, before it replaces it. The original is kept as a safety copy
+// until removeCompactBackup is called once the compacted database has been
+// opened successfully. If anything fails, the original database is left
+// untouched.
+//
+// NOTE: The database must not be open while it is being compacted.
+func compactDB(path string) error {
+	tempPath := path + compactTempSuffix
+	backupPath := path + compactBackupSuffix
+
+	// Remove any leftovers of a previously interrupted compaction.
+	if err := os.Remove(tempPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	srcInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if err := copyDB(path, tempPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("unable to compact database: %v", err)
+	}
+
+	dstInfo, err := os.Stat(tempPath)
+	if err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	// Move the original out of the way as a safety copy, restoring it if
+	// the compacted copy can't take its place.
+	if err := os.Rename(path, backupPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		if rErr := os.Rename(backupPath, path); rErr != nil {
+			return fmt.Errorf("unable to restore database from "+
+				"%v: %v", backupPath, rErr)
+		}
+		return err
+	}
+
+	// With the compacted database in place, the compaction is no longer
+	// pending.
+	marker := path + compactMarkerSuffix
+	if err := os.Remove(marker); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	log.Infof("Compacted database from %d to %d bytes, keeping the "+
+		"original at %v until the compacted database is opened",
+		srcInfo.Size(), dstInfo.Size(), backupPath)
+
+	return nil
+}
+
+// removeCompactBackup removes the safety copy of the database at path kept by
+// compactDB, if any.
+func removeCompactBackup(path string) error {
+	backupPath := path + compactBackupSuffix
+	err := os.Remove(backupPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// copyDB copies the contents of the database at srcPath into a new database
+// at dstPath, and verifies that both hold the same contents. The contents are
+// copied within a single read-only transaction of the source, but committed
+// to the destination in transactions of at most compactTxMaxSize bytes.
+func copyDB(srcPath, dstPath string) error {
+	src, err := bolt.Open(srcPath, dbFilePermission, &bolt.Options{
+		Timeout: compactLockTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := bolt.Open(dstPath, dbFilePermission, &bolt.Options{
+		Timeout: compactLockTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	err = src.View(func(srcTx *bolt.Tx) error {
+		return copyTx(dst, srcTx, compactTxMaxSize)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Before the copy may replace the original, we'll ensure it's
+	// structurally sound and that it holds exactly the same contents.
+	var srcDigest, dstDigest []byte
+	err = src.View(func(tx *bolt.Tx) error {
+		srcDigest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = dst.View(func(tx *bolt.Tx) error {
+		for err := range tx.Check() {
+			return err
+		}
+
+		dstDigest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(srcDigest, dstDigest) {
+		return fmt.Errorf("compacted database doesn't match original")
+	}
+
+	return dst.Sync()
+}
+
+// copyTx copies all buckets, keys, values and sequences visible within srcTx
+// into dst. Once the keys and values copied within the current transaction of
+// dst exceed txMaxSize bytes, it's committed and a new one is started.
+func copyTx(dst *bolt.DB, srcTx *bolt.Tx, txMaxSize int) error {
+	dstTx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		dstTx.Rollback()
+	}()
+
+	var txSize int
+	err = walkTx(srcTx, func(path [][]byte, k, v []byte, seq uint64) error {
+		// Once the current transaction is full, we'll commit it and
+		// continue within a fresh one.
+		if txSize+len(k)+len(v) > txMaxSize && txSize > 0 {
+			if err := dstTx.Commit(); err != nil {
+				return err
+			}
+
+			dstTx, err = dst.Begin(true)
+			if err != nil {
+				return err
+			}
+			txSize = 0
+		}
+		txSize += len(k) + len(v)
+
+		// Top-level buckets are created directly within the
+		// transaction.
+		if len(path) == 0 {
+			bucket, err := dstTx.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+
+			return bucket.SetSequence(seq)
+		}
+
+		// Otherwise, we'll look up the parent bucket, as the buckets
+		// of a committed transaction can't be reused.
+		parent := dstTx.Bucket(path[0])
+		for _, name := range path[1:] {
+			parent = parent.Bucket(name)
+		}
+
+		// As keys are inserted in order, pages can be filled
+		// completely.
+		parent.FillPercent = 1.0
+
+		// A nil value indicates a nested bucket.
+		if v == nil {
+			bucket, err := parent.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+
+			return bucket.SetSequence(seq)
+		}
+
+		return parent.Put(k, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return dstTx.Commit()
+}
+
+// walkTx calls fn for each bucket, key and value visible within tx in order,
+// along with the path of names of the buckets containing them. Buckets are
+// passed with a nil value and their sequence, before their contents.
+func walkTx(tx *bolt.Tx, fn func(path [][]byte, k, v []byte,
+	seq uint64) error) error {
+
+	return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		return walkBucket(b, nil, name, fn)
+	})
+}
+
+// walkBucket calls fn for the bucket b, stored under name within the bucket
+// at path, followed by all of its keys, values and nested buckets.
+func walkBucket(b *bolt.Bucket, path [][]byte, name []byte,
+	fn func(path [][]byte, k, v []byte, seq uint64) error) error {
+
+	if err := fn(path, name, nil, b.Sequence()); err != nil {
+		return err
+	}
+
+	// The path is copied, so buckets sharing a prefix don't overwrite
+	// each other's paths.
+	bucketPath := make([][]byte, len(path)+1)
+	copy(bucketPath, path)
+	bucketPath[len(path)] = name
+
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return walkBucket(b.Bucket(k), bucketPath, k, fn)
+		}
+
+		return fn(bucketPath, k, v, 0)
+	})
+}
+
+// copyBucket recursively copies all keys, values and nested buckets of src,
+// along with their sequences, into dst.
+func copyBucket(dst, src *bolt.Bucket) error {
+	// As keys are inserted in order, pages can be filled completely.
+	dst.FillPercent = 1.0
+
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+
+	return src.ForEach(func(k, v []byte) error {
+		// A nil value indicates a nested bucket.
+		if v == nil {
+			nested, err := dst.CreateBucket(k)
+			if err != nil {
+				return err
+			}
+
+			return copyBucket(nested, src.Bucket(k))
+		}
+
+		return dst.Put(k, v)
+	})
+}
+
+// digestDB returns a digest committing to all buckets, keys, values and
+// sequences within the database.
+func digestDB(tx *bolt.Tx) ([]byte, error) {
+	h := sha256.New()
+	err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		writeDigestElement(h, name)
+		return digestBucket(h, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
+// digestBucket recursively writes all keys, values and nested buckets of b,
+// along with their sequences, to h. Each nested bucket is delimited, so
+// different bucket layouts can't result in the same digest.
+func digestBucket(h hash.Hash, b *bolt.Bucket) error {
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], b.Sequence())
+	h.Write(seq[:])
+
+	err := b.ForEach(func(k, v []byte) error {
+		writeDigestElement(h, k)
+
+		if v == nil {
+			h.Write([]byte{1})
+			return digestBucket(h, b.Bucket(k))
+		}
+
+		h.Write([]byte{0})
+		writeDigestElement(h, v)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Mark the end of the bucket.
+	h.Write([]byte{2})
+
+	return nil
+}
+
+// writeDigestElement writes the length prefixed element to h.
+func writeDigestElement(h hash.Hash, element []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(element)))
+	h.Write(length[:])
+	h.Write(element)
+}
@@ -0,0 +1,371 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestCompactDB tests that scheduling compaction shrinks the database the
+// next time it's opened, while preserving its contents.
+func TestCompactDB(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	policy := makeFakePolicy(1, 1000)
+	if err := db.AddPolicy(policy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	// Fill a nested bucket with data, most of which we'll delete again,
+	// leaving the database with plenty of free pages.
+	testBucket := []byte("compact-test")
+	nestedBucket := []byte("nested")
+	value := bytes.Repeat([]byte{0xaa}, 1024)
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket(testBucket)
+		if err != nil {
+			return err
+		}
+		nested, err := b.CreateBucket(nestedBucket)
+		if err != nil {
+			return err
+		}
+		if err := nested.SetSequence(42); err != nil {
+			return err
+		}
+
+		for i := uint32(0); i < 5000; i++ {
+			var k [4]byte
+			binary.BigEndian.PutUint32(k[:], i)
+			if err := nested.Put(k[:], value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to fill db: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		nested := tx.Bucket(testBucket).Bucket(nestedBucket)
+		for i := uint32(1); i < 5000; i++ {
+			var k [4]byte
+			binary.BigEndian.PutUint32(k[:], i)
+			if err := nested.Delete(k[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to delete data: %v", err)
+	}
+
+	if err := db.ScheduleCompaction(); err != nil {
+		t.Fatalf("unable to schedule compaction: %v", err)
+	}
+	db.Close()
+
+	path := filepath.Join(tempDirName, dbName)
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat db: %v", err)
+	}
+
+	// Reopening the database should compact it, and remove the marker so
+	// it isn't compacted again.
+	db, err = Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	defer db.Close()
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat db: %v", err)
+	}
+	if after.Size() >= before.Size() {
+		t.Fatalf("expected db to shrink from %d bytes, is %d bytes",
+			before.Size(), after.Size())
+	}
+	if compactionScheduled(path) {
+		t.Fatalf("compaction still scheduled after compacting")
+	}
+	if fileExists(path + compactTempSuffix) {
+		t.Fatalf("temporary compaction file wasn't removed")
+	}
+
+	// Finally, the remaining contents should be unchanged.
+	dbPolicy, err := db.LookupPolicy(policy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(policy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(policy), spew.Sdump(dbPolicy))
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		nested := tx.Bucket(testBucket).Bucket(nestedBucket)
+		if nested.Sequence() != 42 {
+			t.Fatalf("expected sequence 42, got %d",
+				nested.Sequence())
+		}
+		if nested.Stats().KeyN != 1 {
+			t.Fatalf("expected 1 key, got %d",
+				nested.Stats().KeyN)
+		}
+		if !bytes.Equal(nested.Get([]byte{0, 0, 0, 0}), value) {
+			t.Fatalf("value mismatch after compaction")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read db: %v", err)
+	}
+}
+
+// TestAutoCompactFreeRatio tests that the database is compacted when it's
+// opened if its free pages make up a large enough fraction of it, and that
+// its free page statistics are recorded each time it's opened.
+func TestAutoCompactFreeRatio(t *testing.T) {
+	// As the minimum size of automatically compacted databases is
+	// lowered, this test can't be run in parallel with others.
+	defer func(minSize int64) {
+		autoCompactMinSize = minSize
+	}(autoCompactMinSize)
+	autoCompactMinSize = 0
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	// Fill a bucket with data, then delete it again, leaving the database
+	// consisting mostly of free pages.
+	testBucket := []byte("compact-test")
+	value := bytes.Repeat([]byte{0xaa}, 1024)
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket(testBucket)
+		if err != nil {
+			return err
+		}
+
+		for i := uint32(0); i < 5000; i++ {
+			var k [4]byte
+			binary.BigEndian.PutUint32(k[:], i)
+			if err := b.Put(k[:], value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to fill db: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.DeleteBucket(testBucket)
+	})
+	if err != nil {
+		t.Fatalf("unable to delete data: %v", err)
+	}
+	db.Close()
+
+	path := filepath.Join(tempDirName, dbName)
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat db: %v", err)
+	}
+
+	// Without a free page ratio configured, reopening the database should
+	// only record its statistics.
+	db, err = Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	stats, err := db.FetchFreePageStats()
+	if err != nil {
+		t.Fatalf("unable to fetch free page stats: %v", err)
+	}
+	if stats.FreeRatio() < 0.5 {
+		t.Fatalf("expected at least half of the pages to be free, "+
+			"got %v of %v", stats.FreePages, stats.TotalPages)
+	}
+	db.Close()
+
+	// A ratio above the actual one shouldn't cause compaction either.
+	db, err = Open(tempDirName, OptionSetAutoCompactFreeRatio(1))
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	db.Close()
+
+	unchanged, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat db: %v", err)
+	}
+	if unchanged.Size() != before.Size() {
+		t.Fatalf("expected db size to remain %d bytes, is %d bytes",
+			before.Size(), unchanged.Size())
+	}
+
+	// Once the ratio is exceeded, the database should be compacted, with
+	// the safety copy removed after it has been opened.
+	db, err = Open(tempDirName, OptionSetAutoCompactFreeRatio(0.5))
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	defer db.Close()
+
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unable to stat db: %v", err)
+	}
+	if after.Size() >= before.Size() {
+		t.Fatalf("expected db to shrink from %d bytes, is %d bytes",
+			before.Size(), after.Size())
+	}
+	if fileExists(path + compactBackupSuffix) {
+		t.Fatalf("safety copy wasn't removed")
+	}
+
+	stats, err = db.FetchFreePageStats()
+	if err != nil {
+		t.Fatalf("unable to fetch free page stats: %v", err)
+	}
+	if stats.FreeRatio() >= 0.5 {
+		t.Fatalf("expected compacted db to have few free pages, "+
+			"got %v of %v", stats.FreePages, stats.TotalPages)
+	}
+}
+
+// TestCopyTxBatches tests that a database copied within several transactions,
+// each bounded in size, holds exactly the same contents as the original.
+func TestCopyTxBatches(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	src, err := bolt.Open(
+		filepath.Join(tempDirName, "src.db"), dbFilePermission, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to open src db: %v", err)
+	}
+	defer src.Close()
+
+	// Fill the source with several levels of nested buckets, so the copy
+	// has to resume within a nested bucket after each commit.
+	value := bytes.Repeat([]byte{0xbb}, 100)
+	err = src.Update(func(tx *bolt.Tx) error {
+		for i := byte(0); i < 3; i++ {
+			b, err := tx.CreateBucket([]byte{'b', i})
+			if err != nil {
+				return err
+			}
+			if err := b.SetSequence(uint64(i) + 7); err != nil {
+				return err
+			}
+
+			nested, err := b.CreateBucket([]byte("nested"))
+			if err != nil {
+				return err
+			}
+			for j := uint32(0); j < 100; j++ {
+				var k [4]byte
+				binary.BigEndian.PutUint32(k[:], j)
+				if err := b.Put(k[:], value); err != nil {
+					return err
+				}
+				if err := nested.Put(k[:], value); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to fill db: %v", err)
+	}
+
+	dst, err := bolt.Open(
+		filepath.Join(tempDirName, "dst.db"), dbFilePermission, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to open dst db: %v", err)
+	}
+	defer dst.Close()
+
+	// Allow for only a handful of values per transaction.
+	err = src.View(func(tx *bolt.Tx) error {
+		return copyTx(dst, tx, 5*len(value))
+	})
+	if err != nil {
+		t.Fatalf("unable to copy db: %v", err)
+	}
+
+	var srcDigest, dstDigest []byte
+	err = src.View(func(tx *bolt.Tx) error {
+		srcDigest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to digest src db: %v", err)
+	}
+	err = dst.View(func(tx *bolt.Tx) error {
+		dstDigest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to digest dst db: %v", err)
+	}
+	if !bytes.Equal(srcDigest, dstDigest) {
+		t.Fatalf("copy doesn't match original")
+	}
+
+	// As the copy was committed in batches, the ID of the last
+	// transaction of the copy should reflect many commits.
+	err = dst.View(func(tx *bolt.Tx) error {
+		if tx.ID() < 10 {
+			t.Fatalf("expected copy to be committed in batches, "+
+				"got %v transactions", tx.ID())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read dst db: %v", err)
+	}
+}
@@ -7,16 +7,25 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/go-errors/errors"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
 )
 
 const (
 	dbName           = "channel.db"
 	dbFilePermission = 0600
+
+	// readOnlyLockTimeout is the maximum duration for which opening the
+	// database read-only waits to obtain its file lock. A process which
+	// has the database opened for writing, such as a running lnd, holds
+	// an exclusive lock on it, in which case opening it read-only fails
+	// after this timeout rather than blocking indefinitely.
+	readOnlyLockTimeout = 5 * time.Second
 )
 
 // migration is a function which takes a prior outdated version of the database
@@ -27,6 +36,11 @@ type migration func(tx *bolt.Tx) error
 type version struct {
 	number    uint32
 	migration migration
+
+	// chunkedMigration, if set, is used instead of migration for
+	// migrations which rewrite buckets too large to be processed within
+	// a single transaction.
+	chunkedMigration chunkedMigration
 }
 
 var (
@@ -40,6 +54,45 @@ var (
 			number:    0,
 			migration: nil,
 		},
+		{
+			// The version of the database where policies are
+			// encoded as versioned TLV records.
+			number:    1,
+			migration: migratePolicyEncoding,
+		},
+		{
+			// The version of the database where payment hash
+			// policies are indexed by their fee.
+			number:    2,
+			migration: migratePolicyFeeIndex,
+		},
+		{
+			// The version of the database where payments are
+			// indexed by their payment hash.
+			number: 3,
+			chunkedMigration: migratePaymentHashIndex(
+				migrationChunkSize,
+			),
+		},
+		{
+			// The version of the database where closed channels
+			// are indexed by their closing txid.
+			number:    4,
+			migration: migrateClosingTxidIndex,
+		},
+		{
+			// The version of the database where revoked states
+			// within the revocation log are stored in a compact
+			// form.
+			number:    5,
+			migration: migrateCompactRevocationLog,
+		},
+		{
+			// The version of the database where invoices are
+			// indexed by the order they were added and settled in.
+			number:    6,
+			migration: migrateInvoiceIndices,
+		},
 	}
 
 	// Big endian is the preferred byte order, due to cursor scans over
@@ -57,48 +110,197 @@ var bufPool = &sync.Pool{
 type DB struct {
 	*bolt.DB
 	dbPath string
+
+	// policyCache caches recently used policies keyed by payment hash.
+	// Writers hold policyCacheMtx exclusively for the duration of both
+	// the database transaction and the cache update, while readers which
+	// populate the cache hold it shared, ensuring the cache never holds a
+	// stale policy.
+	policyCache    *policyCache
+	policyCacheMtx sync.RWMutex
+
+	// graphCache is an in-memory copy of the channel graph, or nil if the
+	// graph cache is disabled. Writers hold graphCacheMtx exclusively for
+	// the duration of both the database transaction and the cache update,
+	// while readers of the cache hold it shared.
+	graphCache    *graphCache
+	graphCacheMtx sync.RWMutex
+
+	// graphDB is the database the channel graph is stored within. As the
+	// graph can be reconstructed from the network, it's kept within a
+	// file of its own, which is compacted and can be wiped independently
+	// of all channel state. It's the database itself if it holds the
+	// graph, which is the case for the graph database, as well as for a
+	// database opened read-only whose graph hasn't been moved into its
+	// own file yet.
+	graphDB *DB
+
+	// graphBatcher coalesces node announcements and channel updates
+	// arriving at the same time into a single transaction.
+	graphBatcher *graphBatcher
+
+	// policyAuditRetention is the duration for which records are kept
+	// within the policy audit log.
+	policyAuditRetention time.Duration
+
+	// forwardingLogRetention is the duration for which events are kept
+	// within the forwarding log.
+	forwardingLogRetention time.Duration
+
+	// policyCrypter encrypts and decrypts policy records at rest. It is
+	// nil until ConfigurePolicyEncryption is called, in which case
+	// policies are written in plaintext.
+	policyCrypter *policyCrypter
+
+	// readOnly indicates whether the database has been opened read-only.
+	readOnly bool
+
+	// dryRunMigration indicates whether pending migrations are only to be
+	// reported, rather than applied.
+	dryRunMigration bool
+
+	// backend is the store all transactions of the database are executed
+	// against.
+	backend Backend
+
+	// batchRetries is the number of times a read-write transaction which
+	// failed with a retryable error is retried.
+	batchRetries int
+
+	// batchRetryBackoff is the duration waited before the first retry of
+	// a read-write transaction.
+	batchRetryBackoff time.Duration
+
+	// metrics records the latency of all transactions executed against
+	// the database.
+	metrics *dbMetrics
 }
 
 // Open opens an existing channeldb. Any necessary schemas migrations due to
-// updates will take place as necessary.
-func Open(dbPath string) (*DB, error) {
+// updates will take place as necessary. If the database is opened read-only,
+// it must already exist and be up to date.
+func Open(dbPath string, modifiers ...OptionModifier) (*DB, error) {
+	opts := DefaultOptions()
+	for _, modifier := range modifiers {
+		modifier(&opts)
+	}
+
 	path := filepath.Join(dbPath, dbName)
 
 	if !fileExists(path) {
+		if opts.ReadOnly {
+			return nil, ErrNoChanDBExists
+		}
 		if err := createChannelDB(dbPath); err != nil {
 			return nil, err
 		}
 	}
 
-	bdb, err := bolt.Open(path, dbFilePermission, nil)
-	if err != nil {
+	if err := maybeCompactDB(path, &opts); err != nil {
 		return nil, err
 	}
 
-	chanDB := &DB{
-		DB:     bdb,
-		dbPath: dbPath,
+	bdb, err := openBoltDB(path, &opts)
+	if err != nil {
+		return nil, err
 	}
 
+	chanDB := newDB(bdb, dbPath, &opts)
+	chanDB.policyCache = newPolicyCache(opts.PolicyCacheSize)
+	chanDB.policyAuditRetention = opts.PolicyAuditRetention
+	chanDB.forwardingLogRetention = opts.ForwardingLogRetention
+	chanDB.dryRunMigration = opts.DryRunMigration
+
 	// Synchronize the version of database and apply migrations if needed.
 	if err := chanDB.syncVersions(dbVersions); err != nil {
 		bdb.Close()
 		return nil, err
 	}
 
+	// Now that the database has been opened successfully, any safety copy
+	// kept from compacting it is no longer needed.
+	if !opts.ReadOnly {
+		if err := removeCompactBackup(path); err != nil {
+			bdb.Close()
+			return nil, err
+		}
+	}
+
+	// With the database up to date, we'll open the channel graph, which
+	// is stored within a database of its own.
+	chanDB.graphDB, err = openGraphDB(chanDB, &opts)
+	if err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
 	return chanDB, nil
 }
 
+// openBoltDB opens the bolt database at path according to the passed
+// options.
+func openBoltDB(path string, opts *Options) (*bolt.DB, error) {
+	// A read-only database is opened with a shared file lock, allowing
+	// several readers to open it at once, while excluding any writer.
+	var boltOpts *bolt.Options
+	if opts.ReadOnly {
+		boltOpts = &bolt.Options{
+			ReadOnly: true,
+			Timeout:  readOnlyLockTimeout,
+		}
+	}
+
+	bdb, err := bolt.Open(path, dbFilePermission, boltOpts)
+	if err != nil {
+		return nil, err
+	}
+	bdb.MaxBatchSize = opts.MaxBatchSize
+	bdb.MaxBatchDelay = opts.MaxBatchDelay
+
+	return bdb, nil
+}
+
+// newDB wraps the passed bolt database, which resides within dbPath, setting
+// up the transaction handling shared by all databases.
+func newDB(bdb *bolt.DB, dbPath string, opts *Options) *DB {
+	return &DB{
+		DB:                bdb,
+		backend:           &boltBackend{db: bdb},
+		dbPath:            dbPath,
+		readOnly:          opts.ReadOnly,
+		metrics:           &dbMetrics{},
+		batchRetries:      opts.BatchRetries,
+		batchRetryBackoff: opts.BatchRetryBackoff,
+	}
+}
+
+// Close closes the database, along with the graph database opened with it.
+func (d *DB) Close() error {
+	if d.graphDB != nil && d.graphDB != d {
+		if err := d.graphDB.Close(); err != nil {
+			d.DB.Close()
+			return err
+		}
+	}
+
+	return d.DB.Close()
+}
+
 // Path returns the file path to the channel database.
 func (d *DB) Path() string {
 	return d.dbPath
 }
 
+// ReadOnly returns true if the database has been opened read-only.
+func (d *DB) ReadOnly() bool {
+	return d.readOnly
+}
+
 // Wipe completely deletes all saved state within all used buckets within the
-// database. The deletion is done in a single transaction, therefore this
-// operation is fully atomic.
+// database, including the channel graph. The channel state and the graph are
+// each deleted within a single transaction.
 func (d *DB) Wipe() error {
-	return d.Update(func(tx *bolt.Tx) error {
+	err := d.Update(func(tx *bolt.Tx) error {
 		err := tx.DeleteBucket(openChannelBucket)
 		if err != nil && err != bolt.ErrBucketNotFound {
 			return err
@@ -109,35 +311,28 @@ func (d *DB) Wipe() error {
 			return err
 		}
 
-		err = tx.DeleteBucket(invoiceBucket)
+		err = tx.DeleteBucket(closedChannelTxidIndexBucket)
 		if err != nil && err != bolt.ErrBucketNotFound {
 			return err
 		}
 
-		err = tx.DeleteBucket(nodeInfoBucket)
+		err = tx.DeleteBucket(invoiceBucket)
 		if err != nil && err != bolt.ErrBucketNotFound {
 			return err
 		}
 
-		err = tx.DeleteBucket(nodeBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
-			return err
-		}
-		err = tx.DeleteBucket(edgeBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
-			return err
-		}
-		err = tx.DeleteBucket(edgeIndexBucket)
-		if err != nil && err != bolt.ErrBucketNotFound {
-			return err
-		}
-		err = tx.DeleteBucket(graphMetaBucket)
+		err = tx.DeleteBucket(nodeInfoBucket)
 		if err != nil && err != bolt.ErrBucketNotFound {
 			return err
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return d.WipeGraph()
 }
 
 // createChannelDB creates and initializes a fresh version of channeldb. In
@@ -174,19 +369,6 @@ func createChannelDB(dbPath string) error {
 			return err
 		}
 
-		if _, err := tx.CreateBucket(nodeBucket); err != nil {
-			return err
-		}
-		if _, err := tx.CreateBucket(edgeBucket); err != nil {
-			return err
-		}
-		if _, err := tx.CreateBucket(edgeIndexBucket); err != nil {
-			return err
-		}
-		if _, err := tx.CreateBucket(graphMetaBucket); err != nil {
-			return err
-		}
-
 		if _, err := tx.CreateBucket(metaBucket); err != nil {
 			return err
 		}
@@ -396,6 +578,63 @@ func fetchChannels(d *DB, pendingOnly bool) ([]*OpenChannel, error) {
 	return channels, err
 }
 
+// FetchChannel attempts to locate the open channel identified by the passed
+// channel point. If the channel can't be found, then ErrChannelNotFound is
+// returned.
+func (d *DB) FetchChannel(chanPoint wire.OutPoint) (*OpenChannel, error) {
+	channels, err := d.FetchAllChannels()
+	if err != nil && err != ErrNoActiveChannels {
+		return nil, err
+	}
+
+	for _, channel := range channels {
+		if channel.FundingOutpoint == chanPoint {
+			return channel, nil
+		}
+	}
+
+	return nil, ErrChannelNotFound
+}
+
+// AbandonChannel removes the open channel identified by the passed channel
+// point from the database, without taking any action on-chain. All state of
+// the channel is deleted, including its revocation log, label and forwarding
+// packages, and a close summary of type Abandoned is left behind as a
+// tombstone. The best height is recorded as the height the channel was
+// closed at.
+//
+// NOTE: This should only be used for channels which are deemed unrecoverable,
+// as any funds still committed to the channel are forfeited.
+func (d *DB) AbandonChannel(chanPoint *wire.OutPoint, bestHeight uint32) error {
+	channel, err := d.FetchChannel(*chanPoint)
+	if err != nil {
+		return err
+	}
+
+	summary := &ChannelCloseSummary{
+		ChanPoint:      channel.FundingOutpoint,
+		ShortChanID:    channel.ShortChanID,
+		ChainHash:      channel.ChainHash,
+		RemotePub:      channel.IdentityPub,
+		Capacity:       channel.Capacity,
+		CloseHeight:    bestHeight,
+		SettledBalance: channel.LocalCommitment.LocalBalance.ToSatoshis(),
+		CloseType:      Abandoned,
+		IsPending:      false,
+	}
+
+	channel.Lock()
+	defer channel.Unlock()
+
+	return d.Update(func(tx *bolt.Tx) error {
+		if err := channel.closeChannel(tx, summary); err != nil {
+			return err
+		}
+
+		return wipeFwdPkgs(tx, channel.ShortChanID)
+	})
+}
+
 // FetchClosedChannels attempts to fetch all closed channels from the database.
 // The pendingOnly bool toggles if channels that aren't yet fully closed should
 // be returned in the response or not. When a channel was cooperatively closed,
@@ -471,6 +710,46 @@ func (d *DB) FetchClosedChannel(chanID *wire.OutPoint) (*ChannelCloseSummary, er
 	return chanSummary, nil
 }
 
+// LookupCloseByTxid queries for the summary of the channel which was closed by
+// the transaction with the passed txid. If no channel is known to have been
+// closed by the transaction, ErrClosedChannelNotFound is returned.
+func (d *DB) LookupCloseByTxid(
+	closingTxid *chainhash.Hash) (*ChannelCloseSummary, error) {
+
+	var chanSummary *ChannelCloseSummary
+	err := d.View(func(tx *bolt.Tx) error {
+		txidIndex := tx.Bucket(closedChannelTxidIndexBucket)
+		if txidIndex == nil {
+			return ErrClosedChannelNotFound
+		}
+		closeBucket := tx.Bucket(closedChannelBucket)
+		if closeBucket == nil {
+			return ErrClosedChannelNotFound
+		}
+
+		chanID := txidIndex.Get(closingTxid[:])
+		if chanID == nil {
+			return ErrClosedChannelNotFound
+		}
+
+		summaryBytes := closeBucket.Get(chanID)
+		if summaryBytes == nil {
+			return ErrClosedChannelNotFound
+		}
+
+		var err error
+		chanSummary, err = deserializeCloseChannelSummary(
+			bytes.NewReader(summaryBytes),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chanSummary, nil
+}
+
 // MarkChanFullyClosed marks a channel as fully closed within the database. A
 // channel should be marked as fully closed if the channel was initially
 // cooperatively closed and it's reached a single confirmation, or after all the
@@ -507,6 +786,12 @@ func (d *DB) MarkChanFullyClosed(chanPoint *wire.OutPoint) error {
 
 		chanSummary.IsPending = false
 
+		// With the channel fully closed, the label attached to it is
+		// no longer of use.
+		if err := deleteChannelLabel(tx, chanID); err != nil {
+			return err
+		}
+
 		var newSummary bytes.Buffer
 		err = serializeChannelCloseSummary(&newSummary, chanSummary)
 		if err != nil {
@@ -521,7 +806,10 @@ func (d *DB) MarkChanFullyClosed(chanPoint *wire.OutPoint) error {
 // applies migration functions to the current database and recovers the
 // previous state of db if at least one error/panic appeared during migration.
 func (d *DB) syncVersions(versions []version) error {
-	meta, err := d.FetchMeta(nil)
+	meta := &Meta{}
+	err := d.View(func(tx *bolt.Tx) error {
+		return fetchMetaVersions(meta, tx, versions)
+	})
 	if err != nil {
 		if err == ErrMetaNotFound {
 			meta = &Meta{}
@@ -535,40 +823,100 @@ func (d *DB) syncVersions(versions []version) error {
 	latestVersion := getLatestDBVersion(versions)
 	log.Infof("Checking for schema update: latest_version=%v, "+
 		"db_version=%v", latestVersion, meta.DbVersionNumber)
+
+	// A database written by a newer version than we know of can't be
+	// understood, so we refuse to open it rather than risk corrupting it.
+	if meta.DbVersionNumber > latestVersion {
+		return ErrDBReversion
+	}
+
 	if meta.DbVersionNumber == latestVersion {
+		if d.dryRunMigration {
+			log.Infof("No pending database migrations")
+			return ErrMigrationDryRun
+		}
 		return nil
 	}
 
+	// A read-only database can't be migrated, so it must be opened for
+	// writing first.
+	if d.readOnly {
+		return fmt.Errorf("unable to migrate read-only database from "+
+			"version %v to %v", meta.DbVersionNumber, latestVersion)
+	}
+
+	// If this is a dry run, then we'll apply the migrations which need to
+	// be applied and report their effects, rolling them back afterwards.
+	if d.dryRunMigration {
+		migrations, migrationVersions := getMigrationsToApply(
+			versions, meta.DbVersionNumber,
+		)
+		return dryRunMigrations(d, migrations, migrationVersions)
+	}
+
+	// Before touching the database, we'll write a backup of it, allowing
+	// the previous version to be restored if anything goes wrong.
+	if err := d.backupBeforeMigration(meta.DbVersionNumber); err != nil {
+		return fmt.Errorf("unable to back up database before "+
+			"migration: %v", err)
+	}
+
 	log.Infof("Performing database schema migration")
 
-	// Otherwise, we fetch the migrations which need to applied, and
-	// execute them serially within a single database transaction to ensure
-	// the migration is atomic.
-	migrations, migrationVersions := getMigrationsToApply(versions,
-		meta.DbVersionNumber)
-	return d.Update(func(tx *bolt.Tx) error {
-		for i, migration := range migrations {
-			if migration == nil {
-				continue
+	// We then execute the migrations serially. Consecutive regular
+	// migrations are applied within a single database transaction to
+	// ensure they're atomic, while each chunked migration commits its
+	// progress after every chunk, allowing it to resume after an
+	// interruption.
+	pending := getVersionsToApply(versions, meta.DbVersionNumber)
+	for len(pending) > 0 {
+		if pending[0].chunkedMigration != nil {
+			if err := d.applyChunkedMigration(pending[0]); err != nil {
+				return err
 			}
 
-			log.Infof("Applying migration #%v", migrationVersions[i])
+			pending = pending[1:]
+			continue
+		}
 
-			if err := migration(tx); err != nil {
-				log.Infof("Unable to apply migration #%v",
-					migrationVersions[i])
-				return err
+		numRegular := 1
+		for numRegular < len(pending) &&
+			pending[numRegular].chunkedMigration == nil {
+
+			numRegular++
+		}
+		regular := pending[:numRegular]
+		pending = pending[numRegular:]
+
+		err := d.Update(func(tx *bolt.Tx) error {
+			for _, v := range regular {
+				if v.migration == nil {
+					continue
+				}
+
+				log.Infof("Applying migration #%v", v.number)
+
+				if err := v.migration(tx); err != nil {
+					log.Infof("Unable to apply migration #%v",
+						v.number)
+					return err
+				}
 			}
+
+			meta.DbVersionNumber = regular[len(regular)-1].number
+			return putMeta(meta, tx)
+		})
+		if err != nil {
+			return err
 		}
+	}
 
-		meta.DbVersionNumber = latestVersion
-		return putMeta(meta, tx)
-	})
+	return nil
 }
 
 // ChannelGraph returns a new instance of the directed channel graph.
 func (d *DB) ChannelGraph() *ChannelGraph {
-	return &ChannelGraph{d}
+	return &ChannelGraph{d.graphDB}
 }
 
 func getLatestDBVersion(versions []version) uint32 {
@@ -581,12 +929,28 @@ func getMigrationsToApply(versions []version, version uint32) ([]migration, []ui
 	migrations := make([]migration, 0, len(versions))
 	migrationVersions := make([]uint32, 0, len(versions))
 
+	for _, v := range getVersionsToApply(versions, version) {
+		m := v.migration
+		if v.chunkedMigration != nil {
+			m = v.chunkedMigration.singleTx(v.number)
+		}
+
+		migrations = append(migrations, m)
+		migrationVersions = append(migrationVersions, v.number)
+	}
+
+	return migrations, migrationVersions
+}
+
+// getVersionsToApply returns the versions following the passed version,
+// whose migrations have yet to be applied to the database.
+func getVersionsToApply(versions []version, version uint32) []version {
+	var pending []version
 	for _, v := range versions {
 		if v.number > version {
-			migrations = append(migrations, v.migration)
-			migrationVersions = append(migrationVersions, v.number)
+			pending = append(pending, v)
 		}
 	}
 
-	return migrations, migrationVersions
+	return pending
 }
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/coreos/bbolt"
 )
 
 func TestOpenWithCreate(t *testing.T) {
@@ -33,3 +35,70 @@ func TestOpenWithCreate(t *testing.T) {
 		t.Fatalf("channeldb failed to create data directory")
 	}
 }
+
+// TestOpenReadOnly tests that a database opened read-only can be read from,
+// but refuses all modifications.
+func TestOpenReadOnly(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	// A database which doesn't exist yet shouldn't be created when it's
+	// opened read-only.
+	dbPath := filepath.Join(tempDirName, "cdb")
+	_, err = Open(dbPath, OptionReadOnly(true))
+	if err != ErrNoChanDBExists {
+		t.Fatalf("expected ErrNoChanDBExists, got %v", err)
+	}
+	if fileExists(dbPath) {
+		t.Fatalf("read-only open created data directory")
+	}
+
+	cdb, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("unable to create channeldb: %v", err)
+	}
+	node, err := createTestVertex(cdb)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	if err := cdb.ChannelGraph().AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	if err := cdb.Close(); err != nil {
+		t.Fatalf("unable to close channeldb: %v", err)
+	}
+
+	cdb, err = Open(dbPath, OptionReadOnly(true))
+	if err != nil {
+		t.Fatalf("unable to open channeldb read-only: %v", err)
+	}
+	defer cdb.Close()
+
+	if !cdb.ReadOnly() {
+		t.Fatalf("channeldb not opened read-only")
+	}
+
+	// The state written before should be readable.
+	pub, err := node.PubKey()
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+	if _, err := cdb.ChannelGraph().FetchLightningNode(pub); err != nil {
+		t.Fatalf("unable to fetch node: %v", err)
+	}
+
+	// Any attempt to modify the database should be refused.
+	err = cdb.ChannelGraph().SetSourceNode(node)
+	if err != ErrDBReadOnly {
+		t.Fatalf("expected ErrDBReadOnly, got %v", err)
+	}
+	err = cdb.Batch(func(*bolt.Tx) error { return nil })
+	if err != ErrDBReadOnly {
+		t.Fatalf("expected ErrDBReadOnly, got %v", err)
+	}
+}
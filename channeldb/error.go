@@ -15,6 +15,10 @@ var (
 	// channels within the database.
 	ErrNoActiveChannels = fmt.Errorf("no active channels exist")
 
+	// ErrChannelNotFound is returned when an open channel with a given
+	// channel point can't be found.
+	ErrChannelNotFound = fmt.Errorf("channel not found")
+
 	// ErrNoPastDeltas is returned when the channel delta bucket hasn't been
 	// created.
 	ErrNoPastDeltas = fmt.Errorf("channel has no recorded deltas")
@@ -31,14 +35,116 @@ var (
 	// payment hash already exists.
 	ErrDuplicateInvoice = fmt.Errorf("invoice with payment hash already exists")
 
+	// ErrInvoiceAlreadySettled is returned when an attempt is made to
+	// cancel an invoice which has already been settled, or to settle a
+	// hold invoice again.
+	ErrInvoiceAlreadySettled = fmt.Errorf("invoice already settled")
+
+	// ErrInvoiceNotAMP is returned when an attempt is made to settle an
+	// HTLC set paying an invoice which isn't an AMP invoice.
+	ErrInvoiceNotAMP = fmt.Errorf("invoice isn't an AMP invoice")
+
+	// ErrInvoiceNotHold is returned when an attempt is made to accept or
+	// settle an invoice as a hold invoice, which isn't one.
+	ErrInvoiceNotHold = fmt.Errorf("invoice isn't a hold invoice")
+
+	// ErrInvoiceNotAccepted is returned when an attempt is made to settle
+	// a hold invoice before an HTLC paying it has been accepted.
+	ErrInvoiceNotAccepted = fmt.Errorf("invoice hasn't been paid yet")
+
+	// ErrInvoiceAlreadyCanceled is returned when an attempt is made to
+	// settle an invoice which has already been canceled.
+	ErrInvoiceAlreadyCanceled = fmt.Errorf("invoice already canceled")
+
 	// ErrNoPaymentsCreated is returned when bucket of payments hasn't been
 	// created.
 	ErrNoPaymentsCreated = fmt.Errorf("there are no existing payments")
 
+	// ErrPaymentInFlight is returned when attempting to initiate a payment
+	// whose payment hash is already being paid.
+	ErrPaymentInFlight = fmt.Errorf("payment is in transition")
+
+	// ErrAlreadyPaid is returned when attempting to initiate or resolve a
+	// payment whose payment hash has already been paid successfully.
+	ErrAlreadyPaid = fmt.Errorf("invoice is already paid")
+
+	// ErrPaymentNotInitiated is returned when attempting to resolve a
+	// payment which was never initiated.
+	ErrPaymentNotInitiated = fmt.Errorf("payment isn't initiated")
+
+	// ErrPaymentAlreadyFailed is returned when attempting to resolve a
+	// payment which has already failed.
+	ErrPaymentAlreadyFailed = fmt.Errorf("payment has already failed")
+
+	// ErrNoPoliciesCreated is returned when bucket of policies hasn't been
+	// created.
+	ErrNoPoliciesCreated = fmt.Errorf("there are no existing policies")
+
+	// ErrPolicyNotFound is returned when a targeted policy can't be found.
+	ErrPolicyNotFound = fmt.Errorf("unable to locate policy")
+
+	// ErrPolicyExists is returned when attempting to strictly add a policy
+	// while a different policy for the same payment hash and amount band
+	// already exists.
+	ErrPolicyExists = fmt.Errorf("policy with payment hash already exists")
+
+	// ErrPolicyHashMismatch is returned when an update attempts to change
+	// the payment hash of an existing policy.
+	ErrPolicyHashMismatch = fmt.Errorf("policy payment hash may not be " +
+		"modified")
+
+	// ErrPolicyBudgetExceeded is returned when a payment would exceed the
+	// budget of the policy which governs it.
+	ErrPolicyBudgetExceeded = fmt.Errorf("payment would exceed policy " +
+		"budget")
+
+	// ErrPolicyInvalidAmountBand is returned when the maximum amount of a
+	// policy is below its minimum amount.
+	ErrPolicyInvalidAmountBand = fmt.Errorf("policy max amount must not " +
+		"be below its min amount")
+
+	// ErrPolicyDenied is returned when a payment is governed by a policy
+	// which denies all payments.
+	ErrPolicyDenied = fmt.Errorf("payment denied by policy")
+
+	// ErrDefaultPolicyAmountBand is returned when attempting to set a
+	// default policy which is limited to an amount band.
+	ErrDefaultPolicyAmountBand = fmt.Errorf("default policy may not be " +
+		"limited to an amount band")
+
+	// ErrPolicyLabelTooLong is returned when the label of a policy exceeds
+	// MaxPolicyLabelLen bytes.
+	ErrPolicyLabelTooLong = fmt.Errorf("policy label must not exceed %v "+
+		"bytes", MaxPolicyLabelLen)
+
+	// ErrChannelLabelTooLong is returned when the label attached to a
+	// channel exceeds MaxChannelLabelLen bytes.
+	ErrChannelLabelTooLong = fmt.Errorf("channel label must not exceed "+
+		"%v bytes", MaxChannelLabelLen)
+
+	// ErrPolicyEncrypted is returned when reading a policy which is
+	// encrypted at rest before the policy encryption key has been set.
+	ErrPolicyEncrypted = fmt.Errorf("policy is encrypted, but no policy " +
+		"encryption key is set")
+
+	// ErrStopPolicyIteration may be returned by the callback passed to
+	// ForEachPolicies in order to stop the iteration early without
+	// signalling a failure to the caller.
+	ErrStopPolicyIteration = fmt.Errorf("policy iteration stopped")
+
+	// ErrStopGraphIteration may be returned by the callback passed to
+	// ForEachNodeAfter or ForEachChannelAfter in order to stop the
+	// iteration early without signalling a failure to the caller.
+	ErrStopGraphIteration = fmt.Errorf("graph iteration stopped")
+
 	// ErrNodeNotFound is returned when node bucket exists, but node with
 	// specific identity can't be found.
 	ErrNodeNotFound = fmt.Errorf("link node with target identity not found")
 
+	// ErrPeerNotFound is returned when a peer with the target identity
+	// hasn't been recorded within the peer store.
+	ErrPeerNotFound = fmt.Errorf("peer with target identity not found")
+
 	// ErrMetaNotFound is returned when meta bucket hasn't been
 	// created.
 	ErrMetaNotFound = fmt.Errorf("unable to locate meta information")
@@ -89,4 +195,29 @@ var (
 	// ErrNoForwardingEvents is returned in the case that a query fails due
 	// to the log not having any recorded events.
 	ErrNoForwardingEvents = fmt.Errorf("no recorded forwarding events")
+
+	// ErrDBReadOnly is returned when attempting to modify a database which
+	// has been opened read-only.
+	ErrDBReadOnly = fmt.Errorf("channel db is opened read-only")
+
+	// ErrDBReversion is returned when a database was written by a newer
+	// version, whose schema is unknown to this one.
+	ErrDBReversion = fmt.Errorf("channel db cannot revert to prior " +
+		"version")
+
+	// ErrMigrationDryRun is returned when opening a database to dry run
+	// its pending migrations, once the dry run has completed.
+	ErrMigrationDryRun = fmt.Errorf("migration dry run complete")
+
+	// ErrTxConflict may be returned by a batched transaction function to
+	// signal that it conflicts with a concurrent transaction, for example
+	// because state it depends on changed before it was executed. Batch
+	// retries such functions with an exponential backoff.
+	ErrTxConflict = fmt.Errorf("transaction conflict")
+
+	// ErrBoltTxUnavailable is returned when attempting to execute a
+	// transaction which requires a bolt transaction against a backend
+	// other than the local bolt database.
+	ErrBoltTxUnavailable = fmt.Errorf("backend doesn't expose bolt " +
+		"transactions")
 )
@@ -1 +1,1860 @@
 package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// policyBucket is the name of the bucket within the database that
+	// stores all fee policies for outgoing payments.
+	//
+	// Within the policy bucket, each policy is keyed by the payment hash
+	// of the payment it governs. Policies which only apply to payments
+	// within an amount band are keyed by the payment hash, followed by the
+	// 8-byte minimum and maximum amount of the band.
+	policyBucket = []byte("policies")
+
+	// policyFeeIndexBucket is the name of the sub-bucket within the
+	// policy bucket which indexes all payment hash policies by their
+	// absolute fee.
+	//
+	// Within the fee index, each key is the 8-byte fee of a policy,
+	// followed by the key the policy is stored under within the policy
+	// bucket. As the fee is big endian encoded, a cursor scan over the
+	// index visits the policies in ascending order of their fee.
+	policyFeeIndexBucket = []byte("fee-index")
+
+	// defaultPolicyKey is the reserved key within the policy bucket under
+	// which the default policy is stored. As payment hash keys are at
+	// least 32 bytes long, it can't collide with the key of any payment
+	// hash policy.
+	defaultPolicyKey = []byte("default")
+
+	// nodePolicyBucket is the name of the bucket within the database that
+	// stores all fee policies which apply to payments towards a particular
+	// destination node.
+	//
+	// Within the node policy bucket, each policy is keyed by the 33-byte
+	// compressed public key of the destination node, followed by the
+	// amount band of the policy if it has one.
+	nodePolicyBucket = []byte("node-policies")
+)
+
+const (
+	// policySchemaVersion is the current version of the serialized policy
+	// format. Each record starts with this version, followed by the
+	// number of fields and the fields themselves, each of which is
+	// type-length-value encoded. As the number of fields is recorded, a
+	// record truncated between two fields is detected.
+	//
+	// NOTE: Records written before the TLV format was introduced are
+	// rewritten by the policy encoding migration, and are only readable
+	// through deserializeLegacyPolicy. New optional fields only require a
+	// new field type, while any other change to the format must bump this
+	// version and register a migration within dbVersions which rewrites
+	// the existing records.
+	policySchemaVersion byte = 3
+
+	// policyUncountedSchemaVersion is the version of the serialized
+	// policy format which preceded policySchemaVersion, whose records
+	// lack the number of fields. Such records remain readable and are
+	// rewritten in the current format once they're next written, rather
+	// than by a migration, as encrypted records can't be rewritten
+	// without the policy encryption key.
+	policyUncountedSchemaVersion byte = 2
+
+	// feeRateParts is the total number of parts used to express fee
+	// rates, making the fee rate of a policy expressed in parts per
+	// million.
+	feeRateParts = 1000000
+
+	// policyAmountBandLen is the length of the amount band which is
+	// appended to the key of policies that only apply to payments within
+	// an amount band.
+	policyAmountBandLen = 16
+
+	// MaxPolicyLabelLen is the maximum length in bytes of the label which
+	// may be attached to a policy.
+	MaxPolicyLabelLen = 256
+)
+
+// policyFieldType is the type of a single TLV encoded policy field.
+type policyFieldType uint16
+
+const (
+	// policyHashType is the type of the payment hash field, which holds
+	// 32 bytes.
+	policyHashType policyFieldType = 0
+
+	// policyFeeType is the type of the absolute fee field, which holds a
+	// uint64.
+	policyFeeType policyFieldType = 1
+
+	// policyBaseFeeType is the type of the base fee field, which holds a
+	// uint64.
+	policyBaseFeeType policyFieldType = 2
+
+	// policyFeeRateType is the type of the fee rate field, which holds a
+	// uint64.
+	policyFeeRateType policyFieldType = 3
+
+	// policyExpiryHeightType is the type of the expiry height field, which
+	// holds a uint32.
+	policyExpiryHeightType policyFieldType = 4
+
+	// policyExpiryTimeType is the type of the expiry time field, which
+	// holds a uint64 unix timestamp.
+	policyExpiryTimeType policyFieldType = 5
+
+	// policyBudgetType is the type of the budget field, which holds a
+	// uint64.
+	policyBudgetType policyFieldType = 6
+
+	// policySpentToDateType is the type of the spent to date field, which
+	// holds a uint64.
+	policySpentToDateType policyFieldType = 7
+
+	// policyMinAmtType is the type of the minimum amount field, which
+	// holds a uint64.
+	policyMinAmtType policyFieldType = 8
+
+	// policyMaxAmtType is the type of the maximum amount field, which
+	// holds a uint64.
+	policyMaxAmtType policyFieldType = 9
+
+	// policyMaxCLTVDeltaType is the type of the maximum CLTV delta field,
+	// which holds a uint32.
+	policyMaxCLTVDeltaType policyFieldType = 10
+
+	// policyLabelType is the type of the label field, which holds a
+	// variable length UTF-8 string.
+	policyLabelType policyFieldType = 11
+
+	// policyDenyType is the type of the deny field, which holds no value.
+	// Its presence marks a policy which denies all payments it governs.
+	policyDenyType policyFieldType = 12
+
+	// policyOutgoingChanIDType is the type of the outgoing channel ID
+	// field, which holds a uint64.
+	policyOutgoingChanIDType policyFieldType = 13
+
+	// policyFeeRejectionsType is the type of the fee rejections field,
+	// which holds a uint32.
+	policyFeeRejectionsType policyFieldType = 14
+
+	// policyCheapestRejectedFeeType is the type of the cheapest rejected
+	// fee field, which holds a uint64.
+	policyCheapestRejectedFeeType policyFieldType = 15
+)
+
+// Policy describes the maximum fee we're willing to pay in order to complete
+// a payment to a particular payment hash, or to a particular destination
+// node.
+type Policy struct {
+	// PaymentHash is the payment hash of the payment this policy applies
+	// to. For policies that apply to a destination node, this is left
+	// blank.
+	PaymentHash [32]byte
+
+	// Fee is the maximum total fee in milli-satoshis which may be paid to
+	// route the payment. If BaseFee or FeeRate are set, this acts as an
+	// upper bound on the proportional fee, unless it is zero.
+	Fee lnwire.MilliSatoshi
+
+	// BaseFee is the fixed part of the maximum fee in milli-satoshis which
+	// may be paid to route the payment.
+	BaseFee lnwire.MilliSatoshi
+
+	// FeeRate is the proportional part of the maximum fee which may be
+	// paid to route the payment, expressed in parts per million of the
+	// payment amount.
+	FeeRate lnwire.MilliSatoshi
+
+	// ExpiryHeight is the block height at which this policy expires. A
+	// value of zero indicates that the policy doesn't expire by height.
+	ExpiryHeight uint32
+
+	// ExpiryTime is the time at which this policy expires. A zero value
+	// indicates that the policy doesn't expire by time.
+	ExpiryTime time.Time
+
+	// Budget is the maximum cumulative amount in milli-satoshis, including
+	// fees, which may be spent on payments governed by this policy over
+	// its lifetime. A value of zero indicates an unlimited budget.
+	Budget lnwire.MilliSatoshi
+
+	// SpentToDate is the cumulative amount in milli-satoshis, including
+	// fees, which has been reserved for payments governed by this policy
+	// so far.
+	SpentToDate lnwire.MilliSatoshi
+
+	// MinAmt is the minimum payment amount in milli-satoshis this policy
+	// applies to.
+	MinAmt lnwire.MilliSatoshi
+
+	// MaxAmt is the maximum payment amount in milli-satoshis this policy
+	// applies to. A value of zero indicates that there is no upper bound.
+	MaxAmt lnwire.MilliSatoshi
+
+	// MaxCLTVDelta is the maximum total time lock delta, relative to the
+	// current block height, which a route for the payment may impose. A
+	// value of zero indicates that the time lock is unbounded.
+	MaxCLTVDelta uint32
+
+	// Label is an optional free-form description of the policy, e.g. the
+	// reason it was created. It has no effect on the payments governed by
+	// the policy.
+	Label string
+
+	// Deny indicates that all payments governed by this policy are to be
+	// rejected outright, regardless of their fee. This can be used to
+	// block payments towards known-bad counterparties.
+	Deny bool
+
+	// OutgoingChanID is the short channel ID of the channel which payments
+	// governed by this policy must leave through. A value of zero
+	// indicates that any channel may be used.
+	OutgoingChanID uint64
+
+	// FeeRejections is the number of payments governed by this policy
+	// which were rejected as even the route found for them exceeded the
+	// fee limit of the policy.
+	FeeRejections uint32
+
+	// CheapestRejectedFee is the lowest total fee in milli-satoshis among
+	// the routes which were rejected for exceeding the fee limit of this
+	// policy. Compared against the fee limit, this indicates by how much
+	// the limit falls short of the fees demanded by the network.
+	CheapestRejectedFee lnwire.MilliSatoshi
+}
+
+// HasFeeLimit returns true if the policy limits the fee of the payments it
+// governs, i.e. if any of its Fee, BaseFee or FeeRate is set. Policies which
+// only restrict payments otherwise, e.g. by their time lock or budget, leave
+// the fee unbounded.
+func (p *Policy) HasFeeLimit() bool {
+	return p.Fee != 0 || p.BaseFee != 0 || p.FeeRate != 0
+}
+
+// MaxFee returns the maximum total fee which may be paid to route a payment of
+// the passed amount under this policy. Policies without a base fee or fee rate
+// allow for the absolute Fee, regardless of the payment amount. The result is
+// only meaningful if the policy has a fee limit, as reported by HasFeeLimit.
+func (p *Policy) MaxFee(amt lnwire.MilliSatoshi) lnwire.MilliSatoshi {
+	if p.BaseFee == 0 && p.FeeRate == 0 {
+		return p.Fee
+	}
+
+	maxFee := p.BaseFee + (amt*p.FeeRate)/feeRateParts
+	if p.Fee != 0 && maxFee > p.Fee {
+		return p.Fee
+	}
+
+	return maxFee
+}
+
+// IsExpired returns true if the policy has expired as of the passed block
+// height or time.
+func (p *Policy) IsExpired(height uint32, now time.Time) bool {
+	if p.ExpiryHeight != 0 && height >= p.ExpiryHeight {
+		return true
+	}
+
+	return !p.ExpiryTime.IsZero() && !now.Before(p.ExpiryTime)
+}
+
+// RemainingBudget returns the amount which may still be spent under this
+// policy. For policies with an unlimited budget, ok is false.
+func (p *Policy) RemainingBudget() (remaining lnwire.MilliSatoshi, ok bool) {
+	if p.Budget == 0 {
+		return 0, false
+	}
+
+	if p.SpentToDate >= p.Budget {
+		return 0, true
+	}
+
+	return p.Budget - p.SpentToDate, true
+}
+
+// HasAmountBand returns true if the policy only applies to payments within an
+// amount band.
+func (p *Policy) HasAmountBand() bool {
+	return p.MinAmt != 0 || p.MaxAmt != 0
+}
+
+// MatchesAmount returns true if a payment of the passed amount falls within
+// the amount band of the policy. Policies without an amount band match any
+// amount.
+func (p *Policy) MatchesAmount(amt lnwire.MilliSatoshi) bool {
+	if amt < p.MinAmt {
+		return false
+	}
+
+	return p.MaxAmt == 0 || amt <= p.MaxAmt
+}
+
+// bandWidth returns the width of the amount band of the policy. Policies
+// without an amount band span all amounts.
+func (p *Policy) bandWidth() lnwire.MilliSatoshi {
+	maxAmt := p.MaxAmt
+	if maxAmt == 0 {
+		maxAmt = math.MaxUint64
+	}
+
+	return maxAmt - p.MinAmt
+}
+
+// validateAmountBand returns an error if the amount band of the policy is
+// empty.
+func (p *Policy) validateAmountBand() error {
+	if p.MaxAmt != 0 && p.MaxAmt < p.MinAmt {
+		return ErrPolicyInvalidAmountBand
+	}
+
+	return nil
+}
+
+// policyKey returns the key the policy is stored under within a policy
+// bucket, given the payment hash or node public key it applies to. Policies
+// with an amount band are keyed by the prefix followed by their band, which
+// allows several policies to apply to the same prefix.
+func policyKey(prefix []byte, p *Policy) []byte {
+	key := make([]byte, len(prefix), len(prefix)+policyAmountBandLen)
+	copy(key, prefix)
+
+	if !p.HasAmountBand() {
+		return key
+	}
+
+	var band [policyAmountBandLen]byte
+	byteOrder.PutUint64(band[:8], uint64(p.MinAmt))
+	byteOrder.PutUint64(band[8:], uint64(p.MaxAmt))
+
+	return append(key, band[:]...)
+}
+
+// selectPolicy returns the index of the most specific policy out of the
+// passed ones which applies to a payment of the passed amount, i.e. the one
+// with the narrowest amount band. On a tie, the earliest policy wins, which
+// favours policies without an amount band as their key sorts first. If none
+// of the policies apply, then -1 is returned.
+func selectPolicy(policies []*Policy, amt lnwire.MilliSatoshi) int {
+	best := -1
+	for i, policy := range policies {
+		if !policy.MatchesAmount(amt) {
+			continue
+		}
+
+		if best == -1 ||
+			policy.bandWidth() < policies[best].bandWidth() {
+
+			best = i
+		}
+	}
+
+	return best
+}
+
+// AddPolicy saves a policy to the database. If a policy for the same payment
+// hash and amount band already exists, it will be overwritten. The policy is
+// also written through to the policy cache.
+func (db *DB) AddPolicy(policy *Policy) error {
+	return db.addPolicy(policy, false)
+}
+
+// AddPolicyStrict saves a policy to the database, unless a different policy
+// for the same payment hash and amount band already exists, in which case
+// ErrPolicyExists is returned. Adding a policy identical to the existing one
+// succeeds without modifying it. This prevents independent callers from
+// silently overwriting each other's policies.
+func (db *DB) AddPolicyStrict(policy *Policy) error {
+	return db.addPolicy(policy, true)
+}
+
+// addPolicy saves a policy to the database. If strict is set, the policy is
+// only saved if no different policy exists under the same key.
+func (db *DB) addPolicy(policy *Policy, strict bool) error {
+	if err := policy.validateAmountBand(); err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	if err := serializePolicy(&b, policy); err != nil {
+		return err
+	}
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	// As the cache holds all policies for a payment hash, we'll gather
+	// them after writing the new one so the cache can be updated.
+	var hashPolicies []*Policy
+	err := db.Update(func(tx *bolt.Tx) error {
+		policies, err := createPolicyBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		key := policyKey(policy.PaymentHash[:], policy)
+		if strict {
+			err := db.checkPolicyConflict(policies, key, b.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+
+		err = db.putSerializedPolicy(
+			policies, key, policy.Fee, b.Bytes(),
+		)
+		if err != nil {
+			return err
+		}
+
+		_, hashPolicies, err = db.fetchPolicies(
+			tx, policyBucket, policy.PaymentHash[:],
+		)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	db.policyCache.put(policy.PaymentHash, hashPolicies)
+
+	return nil
+}
+
+// checkPolicyConflict returns ErrPolicyExists if a policy other than the
+// passed serialized one is stored under the key within the policy bucket.
+func (db *DB) checkPolicyConflict(policies *bolt.Bucket, key,
+	policyBytes []byte) error {
+
+	existing := policies.Get(key)
+	if existing == nil {
+		return nil
+	}
+
+	// As encrypted records differ even if their contents are the same,
+	// we'll compare the serialized policies rather than the records.
+	existingBytes, err := db.policyCrypter.open(key, existing)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(existingBytes, policyBytes) {
+		return ErrPolicyExists
+	}
+
+	return nil
+}
+
+// AddPolicies saves all passed policies to the database within a single
+// transaction. Either all policies are saved, or none of them are. As with
+// AddPolicy, any existing policy for the same payment hash and amount band is
+// overwritten, and within the batch, later policies take precedence over
+// earlier ones. The cached policies of all affected payment hashes are
+// invalidated.
+func (db *DB) AddPolicies(policies []*Policy) error {
+	// We'll validate and serialize all policies before starting the
+	// database transaction, so an invalid policy doesn't hold it open.
+	serialized := make([][]byte, 0, len(policies))
+	for _, policy := range policies {
+		if err := policy.validateAmountBand(); err != nil {
+			return err
+		}
+
+		var b bytes.Buffer
+		if err := serializePolicy(&b, policy); err != nil {
+			return err
+		}
+		serialized = append(serialized, b.Bytes())
+	}
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		hashPolicies, err := createPolicyBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		for i, policy := range policies {
+			key := policyKey(policy.PaymentHash[:], policy)
+			err := db.putSerializedPolicy(
+				hashPolicies, key, policy.Fee, serialized[i],
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		db.policyCache.remove(policy.PaymentHash)
+	}
+
+	return nil
+}
+
+// FetchAllPolicies returns all policies stored in the DB.
+func (db *DB) FetchAllPolicies() ([]*Policy, error) {
+	var policies []*Policy
+
+	err := db.ForEachPolicies(func(policy *Policy) error {
+		policies = append(policies, policy)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// CountPolicies returns the number of payment hash policies stored in the DB
+// without deserializing any of them. As the fee index holds exactly one entry
+// per policy, its key count is used rather than that of the policy bucket,
+// which would also include the index itself.
+func (db *DB) CountPolicies() (uint64, error) {
+	var numPolicies uint64
+	err := db.View(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		if policies == nil {
+			return nil
+		}
+
+		feeIndex := policies.Bucket(policyFeeIndexBucket)
+		if feeIndex == nil {
+			return nil
+		}
+
+		numPolicies = uint64(feeIndex.Stats().KeyN)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numPolicies, nil
+}
+
+// ForEachPolicies iterates through all policies stored in the DB in the order
+// of their payment hash, executing the passed callback for each one. Unlike
+// FetchAllPolicies, policies aren't held in memory beyond the duration of the
+// callback. If the callback returns ErrStopPolicyIteration, the iteration
+// stops early and nil is returned. Any other error returned by the callback
+// aborts the iteration and is passed through to the caller.
+func (db *DB) ForEachPolicies(cb func(*Policy) error) error {
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(policyBucket)
+		if bucket == nil {
+			return ErrNoPoliciesCreated
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket. The default policy doesn't govern any
+			// particular payment hash, so it's skipped as well.
+			if v == nil || bytes.Equal(k, defaultPolicyKey) {
+				return nil
+			}
+
+			policy, err := db.decodePolicy(k, v)
+			if err != nil {
+				return err
+			}
+
+			return cb(policy)
+		})
+	})
+	if err != nil && err != ErrStopPolicyIteration {
+		return err
+	}
+
+	return nil
+}
+
+// PoliciesByHashPrefix executes the passed callback for each policy whose
+// payment hash starts with the passed prefix, in the order of their payment
+// hash. This allows policies to be located when only a truncated payment hash
+// is known. If no policy has ever been added, then ErrNoPoliciesCreated is
+// returned. As with ForEachPolicies, the callback may return
+// ErrStopPolicyIteration to stop the iteration early.
+func (db *DB) PoliciesByHashPrefix(prefix []byte,
+	cb func(*Policy) error) error {
+
+	if len(prefix) > 32 {
+		return fmt.Errorf("payment hash prefix must be at most 32 "+
+			"bytes, is instead %v", len(prefix))
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(policyBucket)
+		if bucket == nil {
+			return ErrNoPoliciesCreated
+		}
+
+		// As policies are keyed by their payment hash, all matching
+		// policies are stored in a contiguous range of keys starting
+		// at the prefix itself.
+		c := bucket.Cursor()
+		for k, v := c.Seek(prefix); k != nil; k, v = c.Next() {
+			if !bytes.HasPrefix(k, prefix) {
+				break
+			}
+
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket. The default policy doesn't govern any
+			// particular payment hash, so it's skipped as well.
+			if v == nil || bytes.Equal(k, defaultPolicyKey) {
+				continue
+			}
+
+			policy, err := db.decodePolicy(k, v)
+			if err != nil {
+				return err
+			}
+
+			if err := cb(policy); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrStopPolicyIteration {
+		return err
+	}
+
+	return nil
+}
+
+// PolicySlice is the response to a paginated policy query. It includes the
+// set of policies which answer the query, along with the offset of the last
+// returned policy, which can be used to resume the query.
+type PolicySlice struct {
+	// Policies is the page of policies answering the query.
+	Policies []*Policy
+
+	// LastIndexOffset is the offset of the policy following the last one
+	// in the set of returned Policies above. Callers can pass this as the
+	// offset of a subsequent query in order to fetch the next page.
+	LastIndexOffset uint64
+}
+
+// QueryPolicies returns a page of at most limit policies, skipping the first
+// offset policies in the order of their payment hash. If reversed is true,
+// the policies are returned in descending order, with the offset counting
+// from the last policy instead. The returned slice contains the offset at
+// which the next page starts.
+func (db *DB) QueryPolicies(offset, limit uint64,
+	reversed bool) (PolicySlice, error) {
+
+	resp := PolicySlice{
+		LastIndexOffset: offset,
+	}
+
+	err := db.View(func(tx *bolt.Tx) error {
+		// If the bucket wasn't found, then there aren't any policies
+		// to be returned.
+		policies := tx.Bucket(policyBucket)
+		if policies == nil {
+			return nil
+		}
+
+		// Depending on the direction of the query, we'll either seek
+		// forwards from the first policy, or backwards from the last
+		// one.
+		c := policies.Cursor()
+		first, next := c.First, c.Next
+		if reversed {
+			first, next = c.Last, c.Prev
+		}
+
+		recordsToSkip := offset
+		for k, v := first(); k != nil; k, v = next() {
+			// If our current page already holds the max number of
+			// policies, then we'll exit now.
+			if uint64(len(resp.Policies)) >= limit {
+				return nil
+			}
+
+			// Sub-buckets don't hold any policies, and neither
+			// they nor the default policy count towards the
+			// offset.
+			if v == nil || bytes.Equal(k, defaultPolicyKey) {
+				continue
+			}
+
+			// If we're not yet past the requested offset, then
+			// we'll continue to seek forward.
+			if recordsToSkip > 0 {
+				recordsToSkip--
+				continue
+			}
+
+			policy, err := db.decodePolicy(k, v)
+			if err != nil {
+				return err
+			}
+
+			resp.Policies = append(resp.Policies, policy)
+			resp.LastIndexOffset++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PolicySlice{}, err
+	}
+
+	return resp, nil
+}
+
+// LookupPolicy attempts to look up the policy without an amount band for the
+// target payment hash. If no such policy exists, then ErrPolicyNotFound is
+// returned. Policies are served from the policy cache when possible.
+func (db *DB) LookupPolicy(paymentHash [32]byte) (*Policy, error) {
+	policies, err := db.fetchHashPolicies(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range policies {
+		if !policy.HasAmountBand() {
+			return policy, nil
+		}
+	}
+
+	return nil, ErrPolicyNotFound
+}
+
+// LookupPolicyForAmount attempts to look up the most specific policy for the
+// target payment hash which applies to a payment of the passed amount.
+// Policies with an amount band take precedence over those without, and
+// narrower bands over wider ones. If no such policy exists, then
+// ErrPolicyNotFound is returned.
+func (db *DB) LookupPolicyForAmount(paymentHash [32]byte,
+	amt lnwire.MilliSatoshi) (*Policy, error) {
+
+	policies, err := db.fetchHashPolicies(paymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	i := selectPolicy(policies, amt)
+	if i == -1 {
+		return nil, ErrPolicyNotFound
+	}
+
+	return policies[i], nil
+}
+
+// fetchHashPolicies returns all policies for the target payment hash, serving
+// them from the policy cache when possible. If there are none, then
+// ErrPolicyNotFound is returned.
+func (db *DB) fetchHashPolicies(paymentHash [32]byte) ([]*Policy, error) {
+	db.policyCacheMtx.RLock()
+	defer db.policyCacheMtx.RUnlock()
+
+	if policies, ok := db.policyCache.get(paymentHash); ok {
+		return policies, nil
+	}
+
+	var policies []*Policy
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		_, policies, err = db.fetchPolicies(
+			tx, policyBucket, paymentHash[:],
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	db.policyCache.put(paymentHash, policies)
+
+	return policies, nil
+}
+
+// PolicyCacheStats returns the hit and miss counters of the policy cache.
+func (db *DB) PolicyCacheStats() PolicyCacheStats {
+	return db.policyCache.stats()
+}
+
+// UpdatePolicy performs a read-modify-write of the policy without an amount
+// band for the target payment hash within a single database transaction. The
+// passed closure is handed the current policy and may modify any field except
+// for the payment hash. If the closure assigns an amount band to the policy,
+// it is moved to the key of that band, overwriting any policy stored there.
+// If no such policy exists, then ErrPolicyNotFound is returned. If the
+// closure returns an error, the transaction is aborted and the stored policy
+// is left untouched.
+func (db *DB) UpdatePolicy(paymentHash [32]byte,
+	cb func(*Policy) error) error {
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	// The cached policy is invalidated regardless of the outcome, as the
+	// closure may have mutated it before returning an error.
+	defer db.policyCache.remove(paymentHash)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		if policies == nil {
+			return ErrPolicyNotFound
+		}
+
+		policyBytes := policies.Get(paymentHash[:])
+		if policyBytes == nil {
+			return ErrPolicyNotFound
+		}
+
+		policy, err := db.decodePolicy(paymentHash[:], policyBytes)
+		if err != nil {
+			return err
+		}
+
+		if err := cb(policy); err != nil {
+			return err
+		}
+
+		// As policies are keyed by their payment hash, we don't allow
+		// the closure to move the policy to another key.
+		if policy.PaymentHash != paymentHash {
+			return ErrPolicyHashMismatch
+		}
+		if err := policy.validateAmountBand(); err != nil {
+			return err
+		}
+
+		key := policyKey(paymentHash[:], policy)
+		if !bytes.Equal(key, paymentHash[:]) {
+			err := db.deletePolicy(policies, paymentHash[:])
+			if err != nil {
+				return err
+			}
+		}
+
+		return db.putPolicy(policies, key, policy)
+	})
+}
+
+// DeletePolicy removes all policies for the target payment hash from the
+// database, including those limited to an amount band. If no such policy
+// exists, then ErrPolicyNotFound is returned.
+func (db *DB) DeletePolicy(paymentHash [32]byte) error {
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	defer db.policyCache.remove(paymentHash)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		if policies == nil {
+			return ErrPolicyNotFound
+		}
+
+		keys, _, err := db.fetchPolicies(
+			tx, policyBucket, paymentHash[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := db.deletePolicy(policies, key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AddNodePolicy saves a policy which applies to all payments towards the
+// target destination node. If a policy for the same node and amount band
+// already exists, it will be overwritten.
+func (db *DB) AddNodePolicy(nodePub [33]byte, policy *Policy) error {
+	if err := policy.validateAmountBand(); err != nil {
+		return err
+	}
+
+	return db.Batch(func(tx *bolt.Tx) error {
+		policies, err := tx.CreateBucketIfNotExists(nodePolicyBucket)
+		if err != nil {
+			return err
+		}
+
+		key := policyKey(nodePub[:], policy)
+		return db.putPolicy(policies, key, policy)
+	})
+}
+
+// LookupNodePolicy attempts to look up the policy without an amount band for
+// the target destination node. If no such policy exists, then
+// ErrPolicyNotFound is returned.
+func (db *DB) LookupNodePolicy(nodePub [33]byte) (*Policy, error) {
+	var policy *Policy
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		policy, err = db.fetchNodePolicy(tx, nodePub)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// LookupNodePolicyForAmount attempts to look up the most specific policy for
+// the target destination node which applies to a payment of the passed
+// amount, in the same manner as LookupPolicyForAmount. If no such policy
+// exists, then ErrPolicyNotFound is returned.
+func (db *DB) LookupNodePolicyForAmount(nodePub [33]byte,
+	amt lnwire.MilliSatoshi) (*Policy, error) {
+
+	var policy *Policy
+	err := db.View(func(tx *bolt.Tx) error {
+		_, policies, err := db.fetchPolicies(
+			tx, nodePolicyBucket, nodePub[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		i := selectPolicy(policies, amt)
+		if i == -1 {
+			return ErrPolicyNotFound
+		}
+		policy = policies[i]
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// SetDefaultPolicy saves the default policy, which governs all payments for
+// which neither a payment hash nor a node policy matches. Any existing default
+// policy is overwritten. The default policy may not be limited to an amount
+// band, and its payment hash is ignored.
+func (db *DB) SetDefaultPolicy(policy *Policy) error {
+	if policy.HasAmountBand() {
+		return ErrDefaultPolicyAmountBand
+	}
+
+	p := *policy
+	p.PaymentHash = [32]byte{}
+
+	return db.Batch(func(tx *bolt.Tx) error {
+		policies, err := createPolicyBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		return db.putPolicy(policies, defaultPolicyKey, &p)
+	})
+}
+
+// FetchDefaultPolicy returns the default policy. If it hasn't been set, then
+// ErrPolicyNotFound is returned.
+func (db *DB) FetchDefaultPolicy() (*Policy, error) {
+	var policy *Policy
+	err := db.View(func(tx *bolt.Tx) error {
+		var err error
+		policy, err = db.fetchDefaultPolicy(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeleteDefaultPolicy removes the default policy from the database. If it
+// hasn't been set, then ErrPolicyNotFound is returned.
+func (db *DB) DeleteDefaultPolicy() error {
+	return db.Update(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		if policies == nil || policies.Get(defaultPolicyKey) == nil {
+			return ErrPolicyNotFound
+		}
+
+		return policies.Delete(defaultPolicyKey)
+	})
+}
+
+// fetchDefaultPolicy is an internal helper which looks up the default policy
+// within the passed transaction.
+func (db *DB) fetchDefaultPolicy(tx *bolt.Tx) (*Policy, error) {
+	policies := tx.Bucket(policyBucket)
+	if policies == nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	policyBytes := policies.Get(defaultPolicyKey)
+	if policyBytes == nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	return db.decodePolicy(defaultPolicyKey, policyBytes)
+}
+
+// FetchPaymentPolicy returns the policy which governs a payment of the passed
+// amount to the target payment hash and destination node. A matching policy
+// for the payment hash takes precedence, otherwise we fall back to the
+// matching policy of the destination node, and finally to the default policy.
+// If none of them exists, then ErrPolicyNotFound is returned.
+func (db *DB) FetchPaymentPolicy(paymentHash [32]byte, nodePub [33]byte,
+	amt lnwire.MilliSatoshi) (*Policy, error) {
+
+	policy, err := db.LookupPolicyForAmount(paymentHash, amt)
+	if err != ErrPolicyNotFound {
+		return policy, err
+	}
+
+	policy, err = db.LookupNodePolicyForAmount(nodePub, amt)
+	if err != ErrPolicyNotFound {
+		return policy, err
+	}
+
+	return db.FetchDefaultPolicy()
+}
+
+// ReservePolicyBudget atomically reserves amt from the budget of the policy
+// which governs a payment of payAmt to the target payment hash and
+// destination node, as selected by FetchPaymentPolicy. If the reservation
+// would exceed the budget of the policy, then ErrPolicyBudgetExceeded is
+// returned and the policy is left untouched. If no policy governs the
+// payment, then ErrPolicyNotFound is returned.
+func (db *DB) ReservePolicyBudget(paymentHash [32]byte, nodePub [33]byte,
+	payAmt, amt lnwire.MilliSatoshi) error {
+
+	reserve := func(p *Policy) error {
+		remaining, ok := p.RemainingBudget()
+		if ok && amt > remaining {
+			return ErrPolicyBudgetExceeded
+		}
+
+		p.SpentToDate += amt
+		return nil
+	}
+
+	return db.updatePaymentPolicy(paymentHash, nodePub, payAmt, reserve)
+}
+
+// ReleasePolicyBudget atomically returns amt to the budget of the policy
+// which governs a payment of payAmt to the target payment hash and
+// destination node. This should be used to release a reservation made by
+// ReservePolicyBudget which wasn't spent, either in part or in full. If no
+// policy governs the payment, then ErrPolicyNotFound is returned.
+func (db *DB) ReleasePolicyBudget(paymentHash [32]byte, nodePub [33]byte,
+	payAmt, amt lnwire.MilliSatoshi) error {
+
+	release := func(p *Policy) error {
+		if amt > p.SpentToDate {
+			p.SpentToDate = 0
+		} else {
+			p.SpentToDate -= amt
+		}
+		return nil
+	}
+
+	return db.updatePaymentPolicy(paymentHash, nodePub, payAmt, release)
+}
+
+// RecordPolicyFeeRejection records that a payment of payAmt to the target
+// payment hash and destination node was rejected, as the fee of the route
+// found for it exceeded the fee limit of the policy which governs it. The
+// number of fee rejections of the policy is incremented, and its cheapest
+// rejected fee is lowered to the passed route fee if necessary. If no policy
+// governs the payment, then ErrPolicyNotFound is returned.
+func (db *DB) RecordPolicyFeeRejection(paymentHash [32]byte, nodePub [33]byte,
+	payAmt, routeFee lnwire.MilliSatoshi) error {
+
+	record := func(p *Policy) error {
+		if p.FeeRejections == 0 || routeFee < p.CheapestRejectedFee {
+			p.CheapestRejectedFee = routeFee
+		}
+		p.FeeRejections++
+		return nil
+	}
+
+	return db.updatePaymentPolicy(paymentHash, nodePub, payAmt, record)
+}
+
+// updatePaymentPolicy performs a read-modify-write of the policy which governs
+// a payment of payAmt to the target payment hash and destination node within
+// a single database transaction. A matching policy for the payment hash takes
+// precedence, otherwise the matching policy of the destination node or the
+// default policy is modified. The closure must not modify the amount band of
+// the policy.
+func (db *DB) updatePaymentPolicy(paymentHash [32]byte, nodePub [33]byte,
+	payAmt lnwire.MilliSatoshi, cb func(*Policy) error) error {
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	defer db.policyCache.remove(paymentHash)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		bucket := policyBucket
+		key, policy, err := db.selectBucketPolicy(
+			tx, bucket, paymentHash[:], payAmt,
+		)
+		if err == ErrPolicyNotFound {
+			bucket = nodePolicyBucket
+			key, policy, err = db.selectBucketPolicy(
+				tx, bucket, nodePub[:], payAmt,
+			)
+		}
+		if err == ErrPolicyNotFound {
+			bucket, key = policyBucket, defaultPolicyKey
+			policy, err = db.fetchDefaultPolicy(tx)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := cb(policy); err != nil {
+			return err
+		}
+
+		return db.putPolicy(tx.Bucket(bucket), key, policy)
+	})
+}
+
+// selectBucketPolicy returns the most specific policy within the target
+// bucket which applies to a payment of amt to the passed payment hash or node
+// public key, along with the key it is stored under. If there is no such
+// policy, then ErrPolicyNotFound is returned.
+func (db *DB) selectBucketPolicy(tx *bolt.Tx, bucket, prefix []byte,
+	amt lnwire.MilliSatoshi) ([]byte, *Policy, error) {
+
+	keys, policies, err := db.fetchPolicies(tx, bucket, prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i := selectPolicy(policies, amt)
+	if i == -1 {
+		return nil, nil, ErrPolicyNotFound
+	}
+
+	return keys[i], policies[i], nil
+}
+
+// fetchPolicies is an internal helper which returns all policies within the
+// target bucket for the passed payment hash or node public key, along with
+// the keys they're stored under, in the order of their key. If there are no
+// such policies, then ErrPolicyNotFound is returned.
+func (db *DB) fetchPolicies(tx *bolt.Tx, bucket,
+	prefix []byte) ([][]byte, []*Policy, error) {
+
+	policies := tx.Bucket(bucket)
+	if policies == nil {
+		return nil, nil, ErrPolicyNotFound
+	}
+
+	var (
+		keys    [][]byte
+		matches []*Policy
+	)
+	c := policies.Cursor()
+	k, v := c.Seek(prefix)
+	for ; bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil {
+			continue
+		}
+
+		policy, err := db.decodePolicy(k, v)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		keys = append(keys, k)
+		matches = append(matches, policy)
+	}
+
+	if len(matches) == 0 {
+		return nil, nil, ErrPolicyNotFound
+	}
+
+	return keys, matches, nil
+}
+
+// PoliciesWithFeeAbove returns all payment hash policies whose absolute Fee
+// exceeds the passed amount, in ascending order of their fee. The policies
+// are looked up through the fee index, so only the matching policies are
+// read from the database.
+func (db *DB) PoliciesWithFeeAbove(
+	fee lnwire.MilliSatoshi) ([]*Policy, error) {
+
+	// No policy can have a fee above the maximum amount.
+	if fee == math.MaxUint64 {
+		return nil, nil
+	}
+
+	var policies []*Policy
+	err := db.View(func(tx *bolt.Tx) error {
+		// If either bucket wasn't found, then there aren't any
+		// policies to be returned.
+		hashPolicies := tx.Bucket(policyBucket)
+		if hashPolicies == nil {
+			return nil
+		}
+		feeIndex := hashPolicies.Bucket(policyFeeIndexBucket)
+		if feeIndex == nil {
+			return nil
+		}
+
+		var start [8]byte
+		byteOrder.PutUint64(start[:], uint64(fee)+1)
+
+		c := feeIndex.Cursor()
+		for k, _ := c.Seek(start[:]); k != nil; k, _ = c.Next() {
+			policyBytes := hashPolicies.Get(k[8:])
+			if policyBytes == nil {
+				return fmt.Errorf("fee index entry %x refers "+
+					"to unknown policy", k)
+			}
+
+			policy, err := db.decodePolicy(k[8:], policyBytes)
+			if err != nil {
+				return err
+			}
+
+			policies = append(policies, policy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// createPolicyBucket creates the policy bucket along with its fee index, if
+// they don't exist yet.
+func createPolicyBucket(tx *bolt.Tx) (*bolt.Bucket, error) {
+	policies, err := tx.CreateBucketIfNotExists(policyBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = policies.CreateBucketIfNotExists(policyFeeIndexBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// feeIndexKey returns the key of the fee index entry for a policy with the
+// passed fee, which is stored under key within the policy bucket.
+func feeIndexKey(fee lnwire.MilliSatoshi, key []byte) []byte {
+	indexKey := make([]byte, 8+len(key))
+	byteOrder.PutUint64(indexKey[:8], uint64(fee))
+	copy(indexKey[8:], key)
+
+	return indexKey
+}
+
+// putPolicy stores the policy under the key within the passed policy bucket.
+// If the bucket holds a fee index, the index entry of any policy previously
+// stored under the same key is replaced by the one of the new policy. The
+// default policy isn't indexed, as it doesn't govern any particular payment
+// hash.
+func (db *DB) putPolicy(policies *bolt.Bucket, key []byte,
+	policy *Policy) error {
+
+	var b bytes.Buffer
+	if err := serializePolicy(&b, policy); err != nil {
+		return err
+	}
+
+	return db.putSerializedPolicy(policies, key, policy.Fee, b.Bytes())
+}
+
+// putSerializedPolicy stores an already serialized policy with the passed fee
+// under the key within the passed policy bucket, encrypting it if policy
+// encryption is enabled. See putPolicy for more information.
+func (db *DB) putSerializedPolicy(policies *bolt.Bucket, key []byte,
+	fee lnwire.MilliSatoshi, policyBytes []byte) error {
+
+	feeIndex := policies.Bucket(policyFeeIndexBucket)
+	if feeIndex != nil && !bytes.Equal(key, defaultPolicyKey) {
+		err := db.unindexPolicy(policies, feeIndex, key)
+		if err != nil {
+			return err
+		}
+
+		err = feeIndex.Put(feeIndexKey(fee, key), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+
+	value, err := db.policyCrypter.seal(key, policyBytes)
+	if err != nil {
+		return err
+	}
+
+	return policies.Put(key, value)
+}
+
+// deletePolicy removes the policy stored under the key within the passed
+// policy bucket, along with its fee index entry if the bucket holds a fee
+// index.
+func (db *DB) deletePolicy(policies *bolt.Bucket, key []byte) error {
+	if feeIndex := policies.Bucket(policyFeeIndexBucket); feeIndex != nil {
+		err := db.unindexPolicy(policies, feeIndex, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return policies.Delete(key)
+}
+
+// unindexPolicy removes the fee index entry of the policy stored under the
+// key within the passed policy bucket, if there is one.
+func (db *DB) unindexPolicy(policies, feeIndex *bolt.Bucket,
+	key []byte) error {
+
+	policyBytes := policies.Get(key)
+	if policyBytes == nil {
+		return nil
+	}
+
+	policy, err := db.decodePolicy(key, policyBytes)
+	if err != nil {
+		return err
+	}
+
+	return feeIndex.Delete(feeIndexKey(policy.Fee, key))
+}
+
+// fetchNodePolicy is an internal helper which looks up the policy for the
+// target destination node within the passed transaction.
+func (db *DB) fetchNodePolicy(tx *bolt.Tx, nodePub [33]byte) (*Policy, error) {
+	policies := tx.Bucket(nodePolicyBucket)
+	if policies == nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	policyBytes := policies.Get(nodePub[:])
+	if policyBytes == nil {
+		return nil, ErrPolicyNotFound
+	}
+
+	return db.decodePolicy(nodePub[:], policyBytes)
+}
+
+// PruneExpiredPolicies removes all payment hash and node policies, as well as
+// the default policy, which have expired as of the passed block height or time
+// from the database. The number of pruned policies is returned.
+func (db *DB) PruneExpiredPolicies(height uint32,
+	now time.Time) (uint32, error) {
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	var (
+		numPruned    uint32
+		prunedHashes [][32]byte
+	)
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{policyBucket, nodePolicyBucket} {
+			policies := tx.Bucket(bucket)
+			if policies == nil {
+				continue
+			}
+
+			pruned, err := db.pruneExpiredPolicies(
+				policies, height, now,
+			)
+			if err != nil {
+				return err
+			}
+			numPruned += uint32(len(pruned))
+
+			// Only policies keyed by payment hash are cached.
+			if bytes.Equal(bucket, policyBucket) {
+				for _, policy := range pruned {
+					prunedHashes = append(
+						prunedHashes, policy.PaymentHash,
+					)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, paymentHash := range prunedHashes {
+		db.policyCache.remove(paymentHash)
+	}
+
+	return numPruned, nil
+}
+
+// pruneExpiredPolicies removes all expired policies from the passed policy
+// bucket, returning the policies removed.
+func (db *DB) pruneExpiredPolicies(policies *bolt.Bucket, height uint32,
+	now time.Time) ([]*Policy, error) {
+
+	// We'll first gather the keys of all expired policies, as it isn't
+	// safe to delete from a bucket while iterating over it.
+	var (
+		expired         [][]byte
+		expiredPolicies []*Policy
+	)
+	err := policies.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return nil
+		}
+
+		policy, err := db.decodePolicy(k, v)
+		if err != nil {
+			return err
+		}
+
+		if policy.IsExpired(height, now) {
+			expired = append(expired, k)
+			expiredPolicies = append(expiredPolicies, policy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, k := range expired {
+		if err := db.deletePolicy(policies, k); err != nil {
+			return nil, err
+		}
+	}
+
+	return expiredPolicies, nil
+}
+
+// DeleteAllPolicies deletes all policies from DB, including the default
+// policy.
+func (db *DB) DeleteAllPolicies() error {
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	defer db.policyCache.purge()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket(policyBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+
+		_, err = createPolicyBucket(tx)
+		return err
+	})
+}
+
+// policyField is a single TLV encoded field of a serialized policy.
+type policyField struct {
+	fieldType policyFieldType
+	value     []byte
+}
+
+// serializePolicy writes the policy to w as the schema version, followed by
+// its fields in ascending order of their type. Each field is encoded as a
+// 2-byte type, a 2-byte length and the value itself. Optional fields which
+// are unset are omitted.
+func serializePolicy(w io.Writer, p *Policy) error {
+	uint64Field := func(t policyFieldType, v uint64) policyField {
+		var b [8]byte
+		byteOrder.PutUint64(b[:], v)
+		return policyField{t, b[:]}
+	}
+
+	fields := []policyField{
+		{policyHashType, p.PaymentHash[:]},
+		uint64Field(policyFeeType, uint64(p.Fee)),
+	}
+	if p.BaseFee != 0 {
+		fields = append(fields, uint64Field(
+			policyBaseFeeType, uint64(p.BaseFee),
+		))
+	}
+	if p.FeeRate != 0 {
+		fields = append(fields, uint64Field(
+			policyFeeRateType, uint64(p.FeeRate),
+		))
+	}
+	if p.ExpiryHeight != 0 {
+		var b [4]byte
+		byteOrder.PutUint32(b[:], p.ExpiryHeight)
+		fields = append(fields, policyField{
+			policyExpiryHeightType, b[:],
+		})
+	}
+	if !p.ExpiryTime.IsZero() {
+		fields = append(fields, uint64Field(
+			policyExpiryTimeType, uint64(p.ExpiryTime.Unix()),
+		))
+	}
+	if p.Budget != 0 {
+		fields = append(fields, uint64Field(
+			policyBudgetType, uint64(p.Budget),
+		))
+	}
+	if p.SpentToDate != 0 {
+		fields = append(fields, uint64Field(
+			policySpentToDateType, uint64(p.SpentToDate),
+		))
+	}
+	if p.MinAmt != 0 {
+		fields = append(fields, uint64Field(
+			policyMinAmtType, uint64(p.MinAmt),
+		))
+	}
+	if p.MaxAmt != 0 {
+		fields = append(fields, uint64Field(
+			policyMaxAmtType, uint64(p.MaxAmt),
+		))
+	}
+	if p.MaxCLTVDelta != 0 {
+		var b [4]byte
+		byteOrder.PutUint32(b[:], p.MaxCLTVDelta)
+		fields = append(fields, policyField{
+			policyMaxCLTVDeltaType, b[:],
+		})
+	}
+	if p.Label != "" {
+		if len(p.Label) > MaxPolicyLabelLen {
+			return ErrPolicyLabelTooLong
+		}
+		fields = append(fields, policyField{
+			policyLabelType, []byte(p.Label),
+		})
+	}
+	if p.Deny {
+		fields = append(fields, policyField{policyDenyType, nil})
+	}
+	if p.OutgoingChanID != 0 {
+		fields = append(fields, uint64Field(
+			policyOutgoingChanIDType, p.OutgoingChanID,
+		))
+	}
+	if p.FeeRejections != 0 {
+		var b [4]byte
+		byteOrder.PutUint32(b[:], p.FeeRejections)
+		fields = append(fields, policyField{
+			policyFeeRejectionsType, b[:],
+		})
+	}
+	if p.CheapestRejectedFee != 0 {
+		fields = append(fields, uint64Field(
+			policyCheapestRejectedFeeType,
+			uint64(p.CheapestRejectedFee),
+		))
+	}
+
+	var scratch [4]byte
+	scratch[0] = policySchemaVersion
+	byteOrder.PutUint16(scratch[1:3], uint16(len(fields)))
+	if _, err := w.Write(scratch[:3]); err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		byteOrder.PutUint16(scratch[:2], uint16(field.fieldType))
+		byteOrder.PutUint16(scratch[2:], uint16(len(field.value)))
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(field.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializePolicy reads a policy encoded by serializePolicy from r. Fields
+// of an unknown type are skipped, allowing older versions to read records
+// written by newer ones. Records of the current version which are truncated
+// or followed by trailing data are rejected.
+func deserializePolicy(r io.Reader) (*Policy, error) {
+	var scratch [4]byte
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+
+	// Records of the current version record their number of fields,
+	// while those of the previous version extend up to the end of the
+	// record.
+	var numFields int
+	switch scratch[0] {
+	case policySchemaVersion:
+		if _, err := io.ReadFull(r, scratch[:2]); err != nil {
+			return nil, err
+		}
+		numFields = int(byteOrder.Uint16(scratch[:2]))
+
+	case policyUncountedSchemaVersion:
+		numFields = -1
+
+	default:
+		return nil, fmt.Errorf("unknown policy schema version: %v",
+			scratch[0])
+	}
+
+	p := &Policy{}
+
+	var (
+		prevType policyFieldType
+		first    = true
+	)
+	for i := 0; numFields < 0 || i < numFields; i++ {
+		_, err := io.ReadFull(r, scratch[:])
+		switch {
+		case err == io.EOF && numFields < 0:
+			return p, nil
+		case err == io.EOF:
+			return nil, io.ErrUnexpectedEOF
+		case err != nil:
+			return nil, err
+		}
+
+		fieldType := policyFieldType(byteOrder.Uint16(scratch[:2]))
+		length := byteOrder.Uint16(scratch[2:])
+
+		// Fields must be written in strictly ascending order, which
+		// ensures each field appears at most once.
+		if !first && fieldType <= prevType {
+			return nil, fmt.Errorf("policy field of type %v out "+
+				"of order", fieldType)
+		}
+		first = false
+		prevType = fieldType
+
+		value := make([]byte, length)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+
+		if err := p.decodeField(fieldType, value); err != nil {
+			return nil, err
+		}
+	}
+
+	// With all fields read, nothing may follow them.
+	if n, _ := r.Read(scratch[:1]); n != 0 {
+		return nil, fmt.Errorf("policy record has trailing data")
+	}
+
+	return p, nil
+}
+
+// decodeField populates the policy field of the passed type from its encoded
+// value. Unknown field types are ignored.
+func (p *Policy) decodeField(fieldType policyFieldType, value []byte) error {
+	expectLen := func(n int) error {
+		if len(value) != n {
+			return fmt.Errorf("policy field of type %v has invalid "+
+				"length %v, expected %v", fieldType,
+				len(value), n)
+		}
+		return nil
+	}
+
+	switch fieldType {
+	case policyHashType:
+		if err := expectLen(32); err != nil {
+			return err
+		}
+		copy(p.PaymentHash[:], value)
+
+	case policyFeeType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyBaseFeeType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.BaseFee = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyFeeRateType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.FeeRate = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyExpiryHeightType:
+		if err := expectLen(4); err != nil {
+			return err
+		}
+		p.ExpiryHeight = byteOrder.Uint32(value)
+
+	case policyExpiryTimeType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.ExpiryTime = time.Unix(int64(byteOrder.Uint64(value)), 0)
+
+	case policyBudgetType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.Budget = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policySpentToDateType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.SpentToDate = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyMinAmtType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.MinAmt = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyMaxAmtType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.MaxAmt = lnwire.MilliSatoshi(byteOrder.Uint64(value))
+
+	case policyMaxCLTVDeltaType:
+		if err := expectLen(4); err != nil {
+			return err
+		}
+		p.MaxCLTVDelta = byteOrder.Uint32(value)
+
+	case policyLabelType:
+		if len(value) > MaxPolicyLabelLen {
+			return ErrPolicyLabelTooLong
+		}
+		p.Label = string(value)
+
+	case policyDenyType:
+		if err := expectLen(0); err != nil {
+			return err
+		}
+		p.Deny = true
+
+	case policyOutgoingChanIDType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.OutgoingChanID = byteOrder.Uint64(value)
+
+	case policyFeeRejectionsType:
+		if err := expectLen(4); err != nil {
+			return err
+		}
+		p.FeeRejections = byteOrder.Uint32(value)
+
+	case policyCheapestRejectedFeeType:
+		if err := expectLen(8); err != nil {
+			return err
+		}
+		p.CheapestRejectedFee = lnwire.MilliSatoshi(
+			byteOrder.Uint64(value),
+		)
+	}
+
+	return nil
+}
+
+// deserializeLegacyPolicy reads a policy written in the fixed-width format
+// which preceded the TLV format. Such records consist of the payment hash and
+// the absolute fee, optionally followed by the expiry height and time, which
+// are in turn optionally followed by a version byte and the proportional fee
+// fields.
+func deserializeLegacyPolicy(r io.Reader) (*Policy, error) {
+	var scratch [8]byte
+
+	p := &Policy{}
+
+	if _, err := io.ReadFull(r, p.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	p.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	// Policies written before expiries were introduced end after the fee,
+	// in which case the policy never expires.
+	if _, err := io.ReadFull(r, scratch[:4]); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	p.ExpiryHeight = byteOrder.Uint32(scratch[:4])
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	if expiryTime := byteOrder.Uint64(scratch[:]); expiryTime != 0 {
+		p.ExpiryTime = time.Unix(int64(expiryTime), 0)
+	}
+
+	// Unversioned policies end after the expiry, and only carry an
+	// absolute fee.
+	if _, err := io.ReadFull(r, scratch[:1]); err == io.EOF {
+		return p, nil
+	} else if err != nil {
+		return nil, err
+	}
+	version := scratch[0]
+
+	if version >= 1 {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		p.BaseFee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		p.FeeRate = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+	}
+
+	return p, nil
+}
@@ -0,0 +1,1458 @@
+package channeldb
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+func makeFakePolicy(seed byte, fee lnwire.MilliSatoshi) *Policy {
+	policy := &Policy{
+		Fee: fee,
+	}
+	copy(policy.PaymentHash[:], bytes.Repeat([]byte{seed}, 32))
+
+	return policy
+}
+
+func TestPolicySerialization(t *testing.T) {
+	t.Parallel()
+
+	fakePolicy := makeFakePolicy(1, 1000)
+	fakePolicy.BaseFee = 10
+	fakePolicy.FeeRate = 500
+	fakePolicy.ExpiryHeight = 500000
+	fakePolicy.Budget = 1000000
+	fakePolicy.SpentToDate = 250000
+	fakePolicy.MinAmt = 10000
+	fakePolicy.MaxAmt = 20000
+	fakePolicy.MaxCLTVDelta = 144
+	fakePolicy.Label = "rebalance cap"
+	fakePolicy.Deny = true
+	fakePolicy.OutgoingChanID = 12345
+	fakePolicy.FeeRejections = 3
+	fakePolicy.CheapestRejectedFee = 1500
+
+	// Use single second precision to avoid false positive test failures
+	// due to the monotonic time component.
+	fakePolicy.ExpiryTime = time.Unix(time.Now().Unix(), 0)
+
+	var b bytes.Buffer
+	if err := serializePolicy(&b, fakePolicy); err != nil {
+		t.Fatalf("unable to serialize policy: %v", err)
+	}
+
+	newPolicy, err := deserializePolicy(&b)
+	if err != nil {
+		t.Fatalf("unable to deserialize policy: %v", err)
+	}
+
+	if !reflect.DeepEqual(fakePolicy, newPolicy) {
+		t.Fatalf("policies do not match after "+
+			"serialization/deserialization %v vs %v",
+			spew.Sdump(fakePolicy), spew.Sdump(newPolicy))
+	}
+
+	// Labels exceeding the maximum length should be rejected.
+	fakePolicy.Label = strings.Repeat("a", MaxPolicyLabelLen+1)
+	err = serializePolicy(&b, fakePolicy)
+	if err != ErrPolicyLabelTooLong {
+		t.Fatalf("expected ErrPolicyLabelTooLong, got %v", err)
+	}
+}
+
+// TestLegacyPolicyDeserialization tests that policies written in each of the
+// fixed-width formats which preceded the TLV format can still be read.
+func TestLegacyPolicyDeserialization(t *testing.T) {
+	t.Parallel()
+
+	fakePolicy := makeFakePolicy(1, 1000)
+	fakePolicy.BaseFee = 10
+	fakePolicy.FeeRate = 500
+	fakePolicy.ExpiryHeight = 500000
+	fakePolicy.ExpiryTime = time.Unix(time.Now().Unix(), 0)
+
+	// The original format consists of solely the payment hash and the
+	// absolute fee.
+	var unversioned bytes.Buffer
+	unversioned.Write(fakePolicy.PaymentHash[:])
+	unversioned.Write([]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8})
+	unexpiring := makeFakePolicy(1, 1000)
+
+	// The expiry was then appended, followed by a version byte and the
+	// proportional fee fields.
+	var versioned bytes.Buffer
+	versioned.Write(unversioned.Bytes())
+	var scratch [8]byte
+	byteOrder.PutUint32(scratch[:4], fakePolicy.ExpiryHeight)
+	versioned.Write(scratch[:4])
+	byteOrder.PutUint64(scratch[:], uint64(fakePolicy.ExpiryTime.Unix()))
+	versioned.Write(scratch[:])
+	versioned.WriteByte(1)
+	byteOrder.PutUint64(scratch[:], uint64(fakePolicy.BaseFee))
+	versioned.Write(scratch[:])
+	byteOrder.PutUint64(scratch[:], uint64(fakePolicy.FeeRate))
+	versioned.Write(scratch[:])
+
+	tests := []struct {
+		name     string
+		record   []byte
+		expected *Policy
+	}{
+		{"unversioned", unversioned.Bytes(), unexpiring},
+		{"versioned", versioned.Bytes(), fakePolicy},
+	}
+
+	for _, test := range tests {
+		newPolicy, err := deserializeLegacyPolicy(
+			bytes.NewReader(test.record),
+		)
+		if err != nil {
+			t.Fatalf("%v: unable to deserialize policy: %v",
+				test.name, err)
+		}
+
+		if !reflect.DeepEqual(test.expected, newPolicy) {
+			t.Fatalf("%v: policies do not match after "+
+				"deserialization %v vs %v", test.name,
+				spew.Sdump(test.expected), spew.Sdump(newPolicy))
+		}
+	}
+}
+
+// TestPolicyUnknownFields tests that fields of an unknown type are skipped
+// when deserializing a policy, while malformed records are rejected.
+func TestPolicyUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	fakePolicy := makeFakePolicy(1, 1000)
+
+	var b bytes.Buffer
+	if err := serializePolicy(&b, fakePolicy); err != nil {
+		t.Fatalf("unable to serialize policy: %v", err)
+	}
+	record := b.Bytes()
+
+	// Append a field with a type which is unknown to us.
+	withUnknown := appendPolicyField(
+		record, 0xff, 0xff, 0, 2, 0xaa, 0xbb,
+	)
+
+	newPolicy, err := deserializePolicy(bytes.NewReader(withUnknown))
+	if err != nil {
+		t.Fatalf("unable to deserialize policy: %v", err)
+	}
+	if !reflect.DeepEqual(fakePolicy, newPolicy) {
+		t.Fatalf("policies do not match after deserialization "+
+			"%v vs %v", spew.Sdump(fakePolicy),
+			spew.Sdump(newPolicy))
+	}
+
+	// A record with an unknown schema version should be rejected.
+	badVersion := append([]byte(nil), record...)
+	badVersion[0] = policySchemaVersion + 1
+	if _, err := deserializePolicy(bytes.NewReader(badVersion)); err == nil {
+		t.Fatalf("expected failure for unknown schema version")
+	}
+
+	// A truncated record should be rejected.
+	truncated := record[:len(record)-1]
+	if _, err := deserializePolicy(bytes.NewReader(truncated)); err == nil {
+		t.Fatalf("expected failure for truncated record")
+	}
+
+	// A repeated field should be rejected.
+	repeated := appendPolicyField(
+		record, 0, 1, 0, 8, 0, 0, 0, 0, 0, 0, 0, 1,
+	)
+	if _, err := deserializePolicy(bytes.NewReader(repeated)); err == nil {
+		t.Fatalf("expected failure for repeated field")
+	}
+
+	// A field which isn't accounted for by the number of fields should
+	// be rejected as trailing data.
+	trailing := append([]byte(nil), record...)
+	trailing = append(trailing, 0xff, 0xff, 0, 0)
+	if _, err := deserializePolicy(bytes.NewReader(trailing)); err == nil {
+		t.Fatalf("expected failure for trailing data")
+	}
+
+	// Records of the previous version, which lack the number of fields,
+	// should still be readable.
+	uncounted := append([]byte{policyUncountedSchemaVersion}, record[3:]...)
+	newPolicy, err = deserializePolicy(bytes.NewReader(uncounted))
+	if err != nil {
+		t.Fatalf("unable to deserialize uncounted policy: %v", err)
+	}
+	if !reflect.DeepEqual(fakePolicy, newPolicy) {
+		t.Fatalf("policies do not match after deserialization "+
+			"%v vs %v", spew.Sdump(fakePolicy),
+			spew.Sdump(newPolicy))
+	}
+}
+
+// appendPolicyField returns a copy of the serialized policy record with the
+// passed encoded field appended to it, accounting for it within the record's
+// number of fields.
+func appendPolicyField(record []byte, field ...byte) []byte {
+	extended := append([]byte(nil), record...)
+	numFields := byteOrder.Uint16(extended[1:3])
+	byteOrder.PutUint16(extended[1:3], numFields+1)
+
+	return append(extended, field...)
+}
+
+// TestPolicyTruncation tests that a serialized policy cut at any offset is
+// rejected, including at the boundaries between its fields.
+func TestPolicyTruncation(t *testing.T) {
+	t.Parallel()
+
+	fullPolicy := makeFakePolicy(1, 1000)
+	fullPolicy.BaseFee = 10
+	fullPolicy.FeeRate = 500
+	fullPolicy.ExpiryHeight = 500
+	fullPolicy.ExpiryTime = time.Unix(1000000, 0)
+	fullPolicy.Budget = 100000
+	fullPolicy.SpentToDate = 5000
+	fullPolicy.MinAmt = 10
+	fullPolicy.MaxAmt = 1000000
+	fullPolicy.MaxCLTVDelta = 144
+	fullPolicy.Label = "full policy"
+	fullPolicy.OutgoingChanID = 12345
+	fullPolicy.FeeRejections = 2
+	fullPolicy.CheapestRejectedFee = 2000
+
+	denyPolicy := &Policy{
+		PaymentHash: fullPolicy.PaymentHash,
+		Deny:        true,
+	}
+
+	tests := []struct {
+		name   string
+		policy *Policy
+	}{
+		{"minimal", &Policy{PaymentHash: fullPolicy.PaymentHash}},
+		{"fake", makeFakePolicy(1, 1000)},
+		{"full", fullPolicy},
+		{"deny", denyPolicy},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := serializePolicy(&b, test.policy); err != nil {
+			t.Fatalf("%v: unable to serialize policy: %v",
+				test.name, err)
+		}
+		record := b.Bytes()
+
+		_, err := deserializePolicy(bytes.NewReader(record))
+		if err != nil {
+			t.Fatalf("%v: unable to deserialize policy: %v",
+				test.name, err)
+		}
+
+		for i := 0; i < len(record); i++ {
+			_, err := deserializePolicy(bytes.NewReader(record[:i]))
+			if err == nil {
+				t.Fatalf("%v: record truncated to %v of %v "+
+					"bytes accepted", test.name, i,
+					len(record))
+			}
+		}
+	}
+}
+
+// TestPolicyLabelTooLong tests that a serialized policy holding a label
+// exceeding MaxPolicyLabelLen is rejected.
+func TestPolicyLabelTooLong(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+	if err := serializePolicy(&b, makeFakePolicy(1, 1000)); err != nil {
+		t.Fatalf("unable to serialize policy: %v", err)
+	}
+
+	for _, labelLen := range []int{MaxPolicyLabelLen, MaxPolicyLabelLen + 1} {
+		var header [4]byte
+		byteOrder.PutUint16(header[:2], uint16(policyLabelType))
+		byteOrder.PutUint16(header[2:], uint16(labelLen))
+		field := append(header[:], bytes.Repeat([]byte("a"), labelLen)...)
+		record := appendPolicyField(b.Bytes(), field...)
+
+		_, err := deserializePolicy(bytes.NewReader(record))
+		tooLong := labelLen > MaxPolicyLabelLen
+		switch {
+		case !tooLong && err != nil:
+			t.Fatalf("unable to deserialize label of length %v: %v",
+				labelLen, err)
+
+		case tooLong && err != ErrPolicyLabelTooLong:
+			t.Fatalf("expected ErrPolicyLabelTooLong for label of "+
+				"length %v, got %v", labelLen, err)
+		}
+	}
+}
+
+// TestPolicyMaxFee tests that the maximum fee of a policy is properly
+// computed for both absolute and proportional policies.
+func TestPolicyMaxFee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy Policy
+		amt    lnwire.MilliSatoshi
+		maxFee lnwire.MilliSatoshi
+	}{
+		{
+			name:   "absolute fee",
+			policy: Policy{Fee: 1000},
+			amt:    1000000,
+			maxFee: 1000,
+		},
+		{
+			name:   "proportional fee",
+			policy: Policy{BaseFee: 100, FeeRate: 1000},
+			amt:    1000000,
+			maxFee: 1100,
+		},
+		{
+			name: "proportional fee capped by absolute fee",
+			policy: Policy{
+				Fee:     500,
+				BaseFee: 100,
+				FeeRate: 1000,
+			},
+			amt:    1000000,
+			maxFee: 500,
+		},
+		{
+			name: "proportional fee below absolute fee",
+			policy: Policy{
+				Fee:     5000,
+				BaseFee: 100,
+				FeeRate: 1000,
+			},
+			amt:    1000000,
+			maxFee: 1100,
+		},
+	}
+
+	for _, test := range tests {
+		maxFee := test.policy.MaxFee(test.amt)
+		if maxFee != test.maxFee {
+			t.Fatalf("%v: expected max fee of %v, got %v",
+				test.name, test.maxFee, maxFee)
+		}
+	}
+}
+
+func TestPolicyWorkflow(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Looking up a policy before any have been added should fail.
+	fakePolicy := makeFakePolicy(1, 1000)
+	_, err = db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	if err := db.AddPolicy(fakePolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	dbPolicy, err := db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(fakePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(fakePolicy), spew.Sdump(dbPolicy))
+	}
+
+	// Add a second policy, and ensure both are returned when fetching
+	// all policies.
+	secondPolicy := makeFakePolicy(2, 2000)
+	if err := db.AddPolicy(secondPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	policies, err := db.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	expectedPolicies := []*Policy{fakePolicy, secondPolicy}
+	if !reflect.DeepEqual(policies, expectedPolicies) {
+		t.Fatalf("wrong policies after reading from DB: "+
+			"got %v, want %v", spew.Sdump(policies),
+			spew.Sdump(expectedPolicies))
+	}
+
+	// Delete all policies, afterwards no policies should remain.
+	if err := db.DeleteAllPolicies(); err != nil {
+		t.Fatalf("unable to delete policies: %v", err)
+	}
+	policies, err = db.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	if len(policies) != 0 {
+		t.Fatalf("after deletion DB has %v policies, want 0",
+			len(policies))
+	}
+}
+
+// TestAddPolicyStrict tests that strictly adding a policy fails if a
+// different policy for the same payment hash and amount band exists.
+func TestAddPolicyStrict(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	policy := makeFakePolicy(1, 1000)
+	if err := db.AddPolicyStrict(policy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	// Adding the same policy again should succeed, as nothing changes.
+	if err := db.AddPolicyStrict(makeFakePolicy(1, 1000)); err != nil {
+		t.Fatalf("unable to re-add identical policy: %v", err)
+	}
+
+	// A policy with a different fee should be rejected, leaving the
+	// existing one in place.
+	conflicting := makeFakePolicy(1, 2000)
+	if err := db.AddPolicyStrict(conflicting); err != ErrPolicyExists {
+		t.Fatalf("expected ErrPolicyExists, got %v", err)
+	}
+	dbPolicy, err := db.LookupPolicy(policy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(policy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(policy), spew.Sdump(dbPolicy))
+	}
+
+	// A policy for a different amount band doesn't conflict with it.
+	banded := makeFakePolicy(1, 2000)
+	banded.MaxAmt = 10000
+	if err := db.AddPolicyStrict(banded); err != nil {
+		t.Fatalf("unable to add banded policy: %v", err)
+	}
+
+	// The non-strict variant should still overwrite the existing policy.
+	if err := db.AddPolicy(conflicting); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	dbPolicy, err = db.LookupPolicy(policy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(conflicting, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(conflicting), spew.Sdump(dbPolicy))
+	}
+}
+
+// TestAddPolicies tests that policies can be added in bulk, and that a batch
+// containing an invalid policy leaves the database untouched.
+func TestAddPolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	assertPolicies := func(expected []*Policy) {
+		policies, err := db.PoliciesWithFeeAbove(0)
+		if err != nil {
+			t.Fatalf("unable to query policies: %v", err)
+		}
+		if !reflect.DeepEqual(expected, policies) {
+			t.Fatalf("wrong policies: got %v, want %v",
+				spew.Sdump(policies), spew.Sdump(expected))
+		}
+	}
+
+	// Add a policy individually and look it up, so it's cached.
+	oldPolicy := makeFakePolicy(1, 1000)
+	if err := db.AddPolicy(oldPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	if _, err := db.LookupPolicy(oldPolicy.PaymentHash); err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+
+	// The batch overwrites the existing policy, which should be reflected
+	// by both the fee index and the cache.
+	newPolicy := makeFakePolicy(1, 3000)
+	secondPolicy := makeFakePolicy(2, 2000)
+	err = db.AddPolicies([]*Policy{newPolicy, secondPolicy})
+	if err != nil {
+		t.Fatalf("unable to add policies: %v", err)
+	}
+	assertPolicies([]*Policy{secondPolicy, newPolicy})
+
+	dbPolicy, err := db.LookupPolicy(newPolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(newPolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(newPolicy), spew.Sdump(dbPolicy))
+	}
+
+	// A batch containing an invalid policy should be rejected as a whole.
+	invalidPolicy := makeFakePolicy(4, 4000)
+	invalidPolicy.Label = strings.Repeat("a", MaxPolicyLabelLen+1)
+	err = db.AddPolicies([]*Policy{makeFakePolicy(3, 500), invalidPolicy})
+	if err != ErrPolicyLabelTooLong {
+		t.Fatalf("expected ErrPolicyLabelTooLong, got %v", err)
+	}
+	assertPolicies([]*Policy{secondPolicy, newPolicy})
+}
+
+// TestUpdatePolicy tests that an existing policy can be modified in place,
+// and that updates to unknown or re-keyed policies are rejected.
+func TestUpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	fakePolicy := makeFakePolicy(1, 1000)
+
+	// Updating a policy that doesn't yet exist should fail.
+	err = db.UpdatePolicy(fakePolicy.PaymentHash, func(p *Policy) error {
+		return nil
+	})
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	if err := db.AddPolicy(fakePolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	err = db.UpdatePolicy(fakePolicy.PaymentHash, func(p *Policy) error {
+		p.Fee += 500
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+
+	dbPolicy, err := db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if dbPolicy.Fee != 1500 {
+		t.Fatalf("expected fee of 1500, got %v", dbPolicy.Fee)
+	}
+
+	// An attempt to modify the payment hash should be rejected, and leave
+	// the stored policy untouched.
+	err = db.UpdatePolicy(fakePolicy.PaymentHash, func(p *Policy) error {
+		p.PaymentHash[0] ^= 0xff
+		p.Fee = 1
+		return nil
+	})
+	if err != ErrPolicyHashMismatch {
+		t.Fatalf("expected ErrPolicyHashMismatch, got %v", err)
+	}
+
+	dbPolicy, err = db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if dbPolicy.Fee != 1500 {
+		t.Fatalf("expected fee of 1500, got %v", dbPolicy.Fee)
+	}
+}
+
+// TestQueryPolicies tests that policies can be paginated through in both
+// directions.
+func TestQueryPolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Querying before any policies have been added should return an
+	// empty page.
+	resp, err := db.QueryPolicies(0, 10, false)
+	if err != nil {
+		t.Fatalf("unable to query policies: %v", err)
+	}
+	if len(resp.Policies) != 0 || resp.LastIndexOffset != 0 {
+		t.Fatalf("expected empty page, got %v", spew.Sdump(resp))
+	}
+
+	const numPolicies = 5
+	var policies []*Policy
+	for i := byte(1); i <= numPolicies; i++ {
+		policy := makeFakePolicy(i, lnwire.MilliSatoshi(i)*1000)
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	reversedPolicies := make([]*Policy, numPolicies)
+	for i, policy := range policies {
+		reversedPolicies[numPolicies-1-i] = policy
+	}
+
+	tests := []struct {
+		offset     uint64
+		limit      uint64
+		reversed   bool
+		expected   []*Policy
+		nextOffset uint64
+	}{
+		{0, 2, false, policies[:2], 2},
+		{2, 2, false, policies[2:4], 4},
+		{4, 2, false, policies[4:], 5},
+		{5, 2, false, nil, 5},
+		{0, 10, false, policies, 5},
+		{0, 2, true, reversedPolicies[:2], 2},
+		{2, 10, true, reversedPolicies[2:], 5},
+		{0, 0, false, nil, 0},
+	}
+
+	for i, test := range tests {
+		resp, err := db.QueryPolicies(
+			test.offset, test.limit, test.reversed,
+		)
+		if err != nil {
+			t.Fatalf("test #%d: unable to query policies: %v",
+				i, err)
+		}
+
+		if !reflect.DeepEqual(resp.Policies, test.expected) {
+			t.Fatalf("test #%d: wrong policies: got %v, want %v",
+				i, spew.Sdump(resp.Policies),
+				spew.Sdump(test.expected))
+		}
+		if resp.LastIndexOffset != test.nextOffset {
+			t.Fatalf("test #%d: expected next offset %v, got %v",
+				i, test.nextOffset, resp.LastIndexOffset)
+		}
+	}
+}
+
+// TestPruneExpiredPolicies tests that only policies which have expired by
+// either height or time are removed by the garbage collector.
+func TestPruneExpiredPolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	now := time.Unix(time.Now().Unix(), 0)
+
+	// We'll create four policies: one without any expiry, one that
+	// expires by height, one that expires by time, and one that expires
+	// in the future.
+	noExpiry := makeFakePolicy(1, 1000)
+
+	heightExpiry := makeFakePolicy(2, 1000)
+	heightExpiry.ExpiryHeight = 100
+
+	timeExpiry := makeFakePolicy(3, 1000)
+	timeExpiry.ExpiryTime = now.Add(-time.Hour)
+
+	futureExpiry := makeFakePolicy(4, 1000)
+	futureExpiry.ExpiryHeight = 200
+	futureExpiry.ExpiryTime = now.Add(time.Hour)
+
+	policies := []*Policy{noExpiry, heightExpiry, timeExpiry, futureExpiry}
+	for _, policy := range policies {
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	numPruned, err := db.PruneExpiredPolicies(100, now)
+	if err != nil {
+		t.Fatalf("unable to prune policies: %v", err)
+	}
+	if numPruned != 2 {
+		t.Fatalf("expected 2 pruned policies, got %v", numPruned)
+	}
+
+	dbPolicies, err := db.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	expectedPolicies := []*Policy{noExpiry, futureExpiry}
+	if !reflect.DeepEqual(dbPolicies, expectedPolicies) {
+		t.Fatalf("wrong policies after pruning: got %v, want %v",
+			spew.Sdump(dbPolicies), spew.Sdump(expectedPolicies))
+	}
+
+	// A subsequent prune at the same height should be a no-op.
+	numPruned, err = db.PruneExpiredPolicies(100, now)
+	if err != nil {
+		t.Fatalf("unable to prune policies: %v", err)
+	}
+	if numPruned != 0 {
+		t.Fatalf("expected no pruned policies, got %v", numPruned)
+	}
+}
+
+// TestNodePolicies tests that policies can be stored per destination node,
+// and that payment hash policies take precedence over node policies.
+func TestNodePolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	_, err = db.LookupNodePolicy(nodePub)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	nodePolicy := &Policy{Fee: 3000}
+	if err := db.AddNodePolicy(nodePub, nodePolicy); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+
+	dbPolicy, err := db.LookupNodePolicy(nodePub)
+	if err != nil {
+		t.Fatalf("unable to lookup node policy: %v", err)
+	}
+	if !reflect.DeepEqual(nodePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(nodePolicy), spew.Sdump(dbPolicy))
+	}
+
+	// Without a policy for the payment hash, we should fall back to the
+	// policy of the destination node.
+	hashPolicy := makeFakePolicy(1, 1000)
+	dbPolicy, err = db.FetchPaymentPolicy(
+		hashPolicy.PaymentHash, nodePub, 1000,
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch payment policy: %v", err)
+	}
+	if !reflect.DeepEqual(nodePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(nodePolicy), spew.Sdump(dbPolicy))
+	}
+
+	// Once a policy for the payment hash is added, it should take
+	// precedence.
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	dbPolicy, err = db.FetchPaymentPolicy(
+		hashPolicy.PaymentHash, nodePub, 1000,
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch payment policy: %v", err)
+	}
+	if !reflect.DeepEqual(hashPolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(hashPolicy), spew.Sdump(dbPolicy))
+	}
+
+	// A payment to an unknown hash and node shouldn't have any policy.
+	var otherPub [33]byte
+	_, err = db.FetchPaymentPolicy([32]byte{}, otherPub, 1000)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
+
+// TestPolicyBudget tests that budgets are reserved from and released to the
+// policy which governs a payment, and that reservations exceeding the budget
+// are rejected.
+func TestPolicyBudget(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	hashPolicy := makeFakePolicy(1, 1000)
+	hashPolicy.Budget = 10000
+	otherHash := makeFakePolicy(2, 0).PaymentHash
+
+	const payAmt = 1000
+
+	// Reserving from the budget of a payment which isn't governed by any
+	// policy should fail.
+	err = db.ReservePolicyBudget(
+		hashPolicy.PaymentHash, nodePub, payAmt, 1000,
+	)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	nodePolicy := &Policy{Budget: 5000}
+	if err := db.AddNodePolicy(nodePub, nodePolicy); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+
+	assertSpent := func(lookup func() (*Policy, error),
+		spent lnwire.MilliSatoshi) {
+
+		policy, err := lookup()
+		if err != nil {
+			t.Fatalf("unable to lookup policy: %v", err)
+		}
+		if policy.SpentToDate != spent {
+			t.Fatalf("expected %v spent to date, got %v", spent,
+				policy.SpentToDate)
+		}
+	}
+	lookupHash := func() (*Policy, error) {
+		return db.LookupPolicy(hashPolicy.PaymentHash)
+	}
+	lookupNode := func() (*Policy, error) {
+		return db.LookupNodePolicy(nodePub)
+	}
+
+	// The policy for the payment hash takes precedence over the one of
+	// the destination node.
+	err = db.ReservePolicyBudget(
+		hashPolicy.PaymentHash, nodePub, payAmt, 6000,
+	)
+	if err != nil {
+		t.Fatalf("unable to reserve budget: %v", err)
+	}
+	assertSpent(lookupHash, 6000)
+	assertSpent(lookupNode, 0)
+
+	// A reservation exceeding the remaining budget should be rejected,
+	// leaving the policy untouched.
+	err = db.ReservePolicyBudget(
+		hashPolicy.PaymentHash, nodePub, payAmt, 4001,
+	)
+	if err != ErrPolicyBudgetExceeded {
+		t.Fatalf("expected ErrPolicyBudgetExceeded, got %v", err)
+	}
+	assertSpent(lookupHash, 6000)
+
+	// Reserving the exact remaining budget should succeed.
+	err = db.ReservePolicyBudget(
+		hashPolicy.PaymentHash, nodePub, payAmt, 4000,
+	)
+	if err != nil {
+		t.Fatalf("unable to reserve budget: %v", err)
+	}
+	assertSpent(lookupHash, 10000)
+
+	// Releasing part of the reservation should make it available again.
+	err = db.ReleasePolicyBudget(
+		hashPolicy.PaymentHash, nodePub, payAmt, 3000,
+	)
+	if err != nil {
+		t.Fatalf("unable to release budget: %v", err)
+	}
+	assertSpent(lookupHash, 7000)
+
+	// Payments to other payment hashes fall back to the node policy.
+	err = db.ReservePolicyBudget(otherHash, nodePub, payAmt, 5001)
+	if err != ErrPolicyBudgetExceeded {
+		t.Fatalf("expected ErrPolicyBudgetExceeded, got %v", err)
+	}
+	err = db.ReservePolicyBudget(otherHash, nodePub, payAmt, 2000)
+	if err != nil {
+		t.Fatalf("unable to reserve budget: %v", err)
+	}
+	assertSpent(lookupNode, 2000)
+
+	// Releasing more than was reserved should never underflow.
+	err = db.ReleasePolicyBudget(otherHash, nodePub, payAmt, 3000)
+	if err != nil {
+		t.Fatalf("unable to release budget: %v", err)
+	}
+	assertSpent(lookupNode, 0)
+}
+
+// TestRecordPolicyFeeRejection tests that fee rejections are recorded on the
+// policy which governs the rejected payment.
+func TestRecordPolicyFeeRejection(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+	hashPolicy := makeFakePolicy(1, 1000)
+
+	const payAmt = 1000
+
+	// Recording a rejection of a payment which isn't governed by any
+	// policy should fail.
+	err = db.RecordPolicyFeeRejection(
+		hashPolicy.PaymentHash, nodePub, payAmt, 2000,
+	)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	assertRejections := func(rejections uint32,
+		cheapest lnwire.MilliSatoshi) {
+
+		policy, err := db.LookupPolicy(hashPolicy.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to lookup policy: %v", err)
+		}
+		if policy.FeeRejections != rejections {
+			t.Fatalf("expected %v fee rejections, got %v",
+				rejections, policy.FeeRejections)
+		}
+		if policy.CheapestRejectedFee != cheapest {
+			t.Fatalf("expected cheapest rejected fee of %v, got %v",
+				cheapest, policy.CheapestRejectedFee)
+		}
+	}
+
+	// Only the cheapest of the rejected route fees should be kept.
+	routeFees := []lnwire.MilliSatoshi{3000, 2000, 2500}
+	for _, routeFee := range routeFees {
+		err := db.RecordPolicyFeeRejection(
+			hashPolicy.PaymentHash, nodePub, payAmt, routeFee,
+		)
+		if err != nil {
+			t.Fatalf("unable to record fee rejection: %v", err)
+		}
+	}
+	assertRejections(3, 2000)
+
+	// Re-adding the policy should reset its rejections.
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	assertRejections(0, 0)
+}
+
+// TestPolicyAmountBands tests that the most specific policy matching the
+// amount of a payment is selected, and that policies with an amount band
+// coexist with the policy without one for the same payment hash.
+func TestPolicyAmountBands(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// A policy whose maximum amount is below its minimum amount can never
+	// apply, so it should be rejected.
+	invalid := makeFakePolicy(1, 1000)
+	invalid.MinAmt = 2000
+	invalid.MaxAmt = 1000
+	if err := db.AddPolicy(invalid); err != ErrPolicyInvalidAmountBand {
+		t.Fatalf("expected ErrPolicyInvalidAmountBand, got %v", err)
+	}
+
+	// We'll add a policy without an amount band, along with policies for
+	// small and large payments, and a narrow band nested within the band
+	// for large payments.
+	unbanded := makeFakePolicy(1, 1000)
+
+	small := makeFakePolicy(1, 100)
+	small.MaxAmt = 10000
+
+	large := makeFakePolicy(1, 5000)
+	large.MinAmt = 100000
+
+	narrow := makeFakePolicy(1, 3000)
+	narrow.MinAmt = 150000
+	narrow.MaxAmt = 200000
+
+	policies := []*Policy{unbanded, small, large, narrow}
+	for _, policy := range policies {
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	tests := []struct {
+		amt      lnwire.MilliSatoshi
+		expected *Policy
+	}{
+		{0, small},
+		{10000, small},
+		{10001, unbanded},
+		{99999, unbanded},
+		{100000, large},
+		{150000, narrow},
+		{200000, narrow},
+		{200001, large},
+	}
+	for _, test := range tests {
+		dbPolicy, err := db.LookupPolicyForAmount(
+			unbanded.PaymentHash, test.amt,
+		)
+		if err != nil {
+			t.Fatalf("unable to lookup policy for %v: %v",
+				test.amt, err)
+		}
+		if !reflect.DeepEqual(test.expected, dbPolicy) {
+			t.Fatalf("policy mismatch for %v: expected %v, got %v",
+				test.amt, spew.Sdump(test.expected),
+				spew.Sdump(dbPolicy))
+		}
+	}
+
+	// A plain lookup should only return the policy without an amount
+	// band.
+	dbPolicy, err := db.LookupPolicy(unbanded.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(unbanded, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(unbanded), spew.Sdump(dbPolicy))
+	}
+
+	dbPolicies, err := db.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	if len(dbPolicies) != len(policies) {
+		t.Fatalf("expected %v policies, got %v", len(policies),
+			len(dbPolicies))
+	}
+
+	// Without a matching policy for the payment hash, we should fall back
+	// to the matching policy of the destination node.
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	nodePolicy := &Policy{Fee: 2000, MinAmt: 50000}
+	if err := db.AddNodePolicy(nodePub, nodePolicy); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+
+	otherHash := makeFakePolicy(2, 0).PaymentHash
+	dbPolicy, err = db.FetchPaymentPolicy(otherHash, nodePub, 50000)
+	if err != nil {
+		t.Fatalf("unable to fetch payment policy: %v", err)
+	}
+	if !reflect.DeepEqual(nodePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(nodePolicy), spew.Sdump(dbPolicy))
+	}
+	_, err = db.FetchPaymentPolicy(otherHash, nodePub, 49999)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	// Deleting the policy for the payment hash should remove all of its
+	// amount bands as well.
+	if err := db.DeletePolicy(unbanded.PaymentHash); err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+	_, err = db.LookupPolicyForAmount(unbanded.PaymentHash, 150000)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
+
+// TestPoliciesByHashPrefix tests that policies can be located by a prefix of
+// their payment hash.
+func TestPoliciesByHashPrefix(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	assertPrefix := func(prefix []byte, expected []*Policy) {
+		var policies []*Policy
+		err := db.PoliciesByHashPrefix(prefix, func(p *Policy) error {
+			policies = append(policies, p)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to query policies: %v", err)
+		}
+		if !reflect.DeepEqual(expected, policies) {
+			t.Fatalf("wrong policies with prefix %x: got %v, "+
+				"want %v", prefix, spew.Sdump(policies),
+				spew.Sdump(expected))
+		}
+	}
+
+	err = db.PoliciesByHashPrefix(nil, func(*Policy) error { return nil })
+	if err != ErrNoPoliciesCreated {
+		t.Fatalf("expected ErrNoPoliciesCreated, got %v", err)
+	}
+
+	// The payment hashes of the policies start with the same byte as the
+	// key of the default policy, which shouldn't be matched.
+	first := makeFakePolicy(1, 1000)
+	first.PaymentHash[0] = 'd'
+	banded := makeFakePolicy(1, 2000)
+	banded.PaymentHash[0] = 'd'
+	banded.MaxAmt = 10000
+	second := makeFakePolicy(2, 3000)
+	second.PaymentHash[0] = 'd'
+	third := makeFakePolicy(3, 4000)
+	third.PaymentHash[0] = 'e'
+
+	err = db.AddPolicies([]*Policy{first, banded, second, third})
+	if err != nil {
+		t.Fatalf("unable to add policies: %v", err)
+	}
+	if err := db.SetDefaultPolicy(&Policy{Fee: 5000}); err != nil {
+		t.Fatalf("unable to set default policy: %v", err)
+	}
+
+	assertPrefix(nil, []*Policy{first, banded, second, third})
+	assertPrefix([]byte("d"), []*Policy{first, banded, second})
+	assertPrefix([]byte{'d', 1}, []*Policy{first, banded})
+	assertPrefix(second.PaymentHash[:], []*Policy{second})
+	assertPrefix([]byte{'f'}, nil)
+
+	// The iteration should stop once the callback asks it to.
+	var numPolicies int
+	err = db.PoliciesByHashPrefix([]byte("d"), func(*Policy) error {
+		numPolicies++
+		return ErrStopPolicyIteration
+	})
+	if err != nil {
+		t.Fatalf("unable to query policies: %v", err)
+	}
+	if numPolicies != 1 {
+		t.Fatalf("expected iteration to stop after 1 policy, "+
+			"visited %v", numPolicies)
+	}
+
+	// Prefixes longer than a payment hash should be rejected.
+	tooLong := make([]byte, 33)
+	err = db.PoliciesByHashPrefix(tooLong, func(*Policy) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("expected prefix longer than payment hash to fail")
+	}
+}
+
+// TestPoliciesWithFeeAbove tests that the fee index is kept in sync as
+// policies are added, updated, deleted and pruned, and that it can be used to
+// query for the policies with a fee above a threshold.
+func TestPoliciesWithFeeAbove(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	assertFeeAbove := func(fee lnwire.MilliSatoshi, expected []*Policy) {
+		dbPolicies, err := db.PoliciesWithFeeAbove(fee)
+		if err != nil {
+			t.Fatalf("unable to query policies: %v", err)
+		}
+		if !reflect.DeepEqual(expected, dbPolicies) {
+			t.Fatalf("wrong policies with fee above %v: got %v, "+
+				"want %v", fee, spew.Sdump(dbPolicies),
+				spew.Sdump(expected))
+		}
+	}
+
+	// Before any policies are added, the query should come up empty.
+	assertFeeAbove(0, nil)
+
+	cheap := makeFakePolicy(1, 1000)
+	generous := makeFakePolicy(2, 5000)
+	banded := makeFakePolicy(3, 3000)
+	banded.MinAmt = 100000
+	expiring := makeFakePolicy(4, 4000)
+	expiring.ExpiryHeight = 100
+
+	for _, policy := range []*Policy{cheap, generous, banded, expiring} {
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	assertFeeAbove(0, []*Policy{cheap, banded, expiring, generous})
+	assertFeeAbove(3000, []*Policy{expiring, generous})
+	assertFeeAbove(5000, nil)
+	assertFeeAbove(math.MaxUint64, nil)
+
+	// Overwriting a policy should replace its index entry.
+	cheap.Fee = 6000
+	if err := db.AddPolicy(cheap); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	assertFeeAbove(3000, []*Policy{expiring, generous, cheap})
+
+	// As should updating it.
+	err = db.UpdatePolicy(cheap.PaymentHash, func(p *Policy) error {
+		p.Fee = 2000
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+	cheap.Fee = 2000
+	assertFeeAbove(1000, []*Policy{cheap, banded, expiring, generous})
+
+	// Deleted and pruned policies should no longer be returned.
+	if err := db.DeletePolicy(generous.PaymentHash); err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+	if _, err := db.PruneExpiredPolicies(100, time.Now()); err != nil {
+		t.Fatalf("unable to prune policies: %v", err)
+	}
+	assertFeeAbove(0, []*Policy{cheap, banded})
+
+	if err := db.DeleteAllPolicies(); err != nil {
+		t.Fatalf("unable to delete policies: %v", err)
+	}
+	assertFeeAbove(0, nil)
+}
+
+// TestCountPolicies tests that the number of stored policies is reported
+// correctly as policies are added, overwritten and removed.
+func TestCountPolicies(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	assertCount := func(expected uint64) {
+		numPolicies, err := db.CountPolicies()
+		if err != nil {
+			t.Fatalf("unable to count policies: %v", err)
+		}
+		if numPolicies != expected {
+			t.Fatalf("expected %v policies, got %v", expected,
+				numPolicies)
+		}
+	}
+
+	// Before any policies are added, there should be none.
+	assertCount(0)
+
+	policy := makeFakePolicy(1, 1000)
+	banded := makeFakePolicy(1, 3000)
+	banded.MinAmt = 100000
+	other := makeFakePolicy(2, 2000)
+
+	for _, p := range []*Policy{policy, banded, other} {
+		if err := db.AddPolicy(p); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+	assertCount(3)
+
+	// Overwriting a policy shouldn't change the count, and neither should
+	// adding a node policy.
+	policy.Fee = 5000
+	if err := db.AddPolicy(policy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	var nodePub [33]byte
+	nodePub[0] = 2
+	if err := db.AddNodePolicy(nodePub, other); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+	assertCount(3)
+
+	// Deleting a payment hash should remove all of its policies.
+	if err := db.DeletePolicy(policy.PaymentHash); err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+	assertCount(1)
+
+	if err := db.DeleteAllPolicies(); err != nil {
+		t.Fatalf("unable to delete policies: %v", err)
+	}
+	assertCount(0)
+}
+
+// TestDefaultPolicy tests that the default policy applies to payments which
+// aren't governed by any payment hash or node policy, without being listed
+// among the payment hash policies.
+func TestDefaultPolicy(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var nodePub [33]byte
+	nodePub[0] = 2
+
+	const payAmt = 1000
+	hashPolicy := makeFakePolicy(1, 1000)
+	var otherHash [32]byte
+
+	// Before the default policy is set, it can't be found.
+	if _, err := db.FetchDefaultPolicy(); err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+	if err := db.DeleteDefaultPolicy(); err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	// The default policy may not be limited to an amount band.
+	banded := &Policy{Fee: 5000, MinAmt: 100}
+	err = db.SetDefaultPolicy(banded)
+	if err != ErrDefaultPolicyAmountBand {
+		t.Fatalf("expected ErrDefaultPolicyAmountBand, got %v", err)
+	}
+
+	// The payment hash of the default policy should be ignored.
+	defaultPolicy := makeFakePolicy(9, 5000)
+	defaultPolicy.Budget = 100000
+	if err := db.SetDefaultPolicy(defaultPolicy); err != nil {
+		t.Fatalf("unable to set default policy: %v", err)
+	}
+	defaultPolicy.PaymentHash = [32]byte{}
+
+	dbPolicy, err := db.FetchDefaultPolicy()
+	if err != nil {
+		t.Fatalf("unable to fetch default policy: %v", err)
+	}
+	if !reflect.DeepEqual(defaultPolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(defaultPolicy), spew.Sdump(dbPolicy))
+	}
+
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	// The default policy shouldn't be listed or counted among the payment
+	// hash policies.
+	dbPolicies, err := db.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	if !reflect.DeepEqual([]*Policy{hashPolicy}, dbPolicies) {
+		t.Fatalf("wrong policies: %v", spew.Sdump(dbPolicies))
+	}
+	numPolicies, err := db.CountPolicies()
+	if err != nil {
+		t.Fatalf("unable to count policies: %v", err)
+	}
+	if numPolicies != 1 {
+		t.Fatalf("expected 1 policy, got %v", numPolicies)
+	}
+	policySlice, err := db.QueryPolicies(0, 10, false)
+	if err != nil {
+		t.Fatalf("unable to query policies: %v", err)
+	}
+	if len(policySlice.Policies) != 1 {
+		t.Fatalf("expected 1 policy, got %v",
+			len(policySlice.Policies))
+	}
+
+	// A payment hash policy should take precedence over the default
+	// policy, which only applies to payments without one.
+	policy, err := db.FetchPaymentPolicy(
+		hashPolicy.PaymentHash, nodePub, payAmt,
+	)
+	if err != nil {
+		t.Fatalf("unable to fetch payment policy: %v", err)
+	}
+	if policy.Fee != hashPolicy.Fee {
+		t.Fatalf("expected hash policy, got %v", spew.Sdump(policy))
+	}
+	policy, err = db.FetchPaymentPolicy(otherHash, nodePub, payAmt)
+	if err != nil {
+		t.Fatalf("unable to fetch payment policy: %v", err)
+	}
+	if policy.Fee != defaultPolicy.Fee {
+		t.Fatalf("expected default policy, got %v", spew.Sdump(policy))
+	}
+
+	// Budget reservations for payments governed by the default policy
+	// should be taken from its budget.
+	err = db.ReservePolicyBudget(otherHash, nodePub, payAmt, 60000)
+	if err != nil {
+		t.Fatalf("unable to reserve budget: %v", err)
+	}
+	err = db.ReservePolicyBudget(otherHash, nodePub, payAmt, 60000)
+	if err != ErrPolicyBudgetExceeded {
+		t.Fatalf("expected ErrPolicyBudgetExceeded, got %v", err)
+	}
+	dbPolicy, err = db.FetchDefaultPolicy()
+	if err != nil {
+		t.Fatalf("unable to fetch default policy: %v", err)
+	}
+	if dbPolicy.SpentToDate != 60000 {
+		t.Fatalf("expected 60000 spent, got %v", dbPolicy.SpentToDate)
+	}
+
+	// Once deleted, payments without a payment hash or node policy are
+	// no longer governed by any policy.
+	if err := db.DeleteDefaultPolicy(); err != nil {
+		t.Fatalf("unable to delete default policy: %v", err)
+	}
+	_, err = db.FetchPaymentPolicy(otherHash, nodePub, payAmt)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
@@ -100,7 +100,10 @@ func decodeForwardingEvent(r io.Reader, f *ForwardingEvent) error {
 
 // AddForwardingEvents adds a series of forwarding events to the database.
 // Before inserting, the set of events will be sorted according to their
-// timestamp. This ensures that all writes to disk are sequential.
+// timestamp. This ensures that all writes to disk are sequential. Within the
+// same transaction, all events which have fallen out of the retention window
+// of the forwarding log, relative to the timestamp of the latest new event,
+// are removed.
 func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 	// Before we create the database transaction, we'll ensure that the set
 	// of forwarding events are properly sorted according to their
@@ -145,7 +148,13 @@ func (f *ForwardingLog) AddForwardingEvents(events []ForwardingEvent) error {
 			}
 		}
 
-		return nil
+		if f.db.forwardingLogRetention == 0 || len(events) == 0 {
+			return nil
+		}
+
+		latest := events[len(events)-1].Timestamp
+		cutoff := latest.Add(-f.db.forwardingLogRetention)
+		return pruneTimeSeries(logBucket, cutoff)
 	})
 }
 
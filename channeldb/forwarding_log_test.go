@@ -263,3 +263,69 @@ func TestForwardingLogQueryLimit(t *testing.T) {
 			timeSlice.LastIndexOffset)
 	}
 }
+
+// TestForwardingLogRetention tests that events which have fallen out of the
+// retention window of the forwarding log are pruned as new events are added.
+func TestForwardingLogRetention(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	db.forwardingLogRetention = 24 * time.Hour
+	log := db.ForwardingLog()
+
+	makeEvent := func(timestamp time.Time) ForwardingEvent {
+		return ForwardingEvent{
+			Timestamp:      timestamp,
+			IncomingChanID: lnwire.NewShortChanIDFromInt(1),
+			OutgoingChanID: lnwire.NewShortChanIDFromInt(2),
+			AmtIn:          2000,
+			AmtOut:         1000,
+		}
+	}
+
+	initialTime := time.Unix(1234, 0)
+	queryAll := func() []ForwardingEvent {
+		timeSlice, err := log.Query(ForwardingEventQuery{
+			StartTime:    initialTime,
+			EndTime:      initialTime.Add(48 * time.Hour),
+			NumMaxEvents: 1000,
+		})
+		if err != nil {
+			t.Fatalf("unable to query for events: %v", err)
+		}
+
+		return timeSlice.ForwardingEvents
+	}
+
+	// Add two events, both within the retention window.
+	oldEvents := []ForwardingEvent{
+		makeEvent(initialTime),
+		makeEvent(initialTime.Add(time.Hour)),
+	}
+	if err := log.AddForwardingEvents(oldEvents); err != nil {
+		t.Fatalf("unable to add events: %v", err)
+	}
+	if events := queryAll(); !reflect.DeepEqual(oldEvents, events) {
+		t.Fatalf("event mismatch: expected %v vs %v",
+			spew.Sdump(oldEvents), spew.Sdump(events))
+	}
+
+	// Adding an event a day after the second one should prune the first
+	// one, while keeping the second one, which lies exactly on the
+	// cutoff.
+	newEvent := makeEvent(initialTime.Add(25 * time.Hour))
+	err = log.AddForwardingEvents([]ForwardingEvent{newEvent})
+	if err != nil {
+		t.Fatalf("unable to add event: %v", err)
+	}
+
+	expected := []ForwardingEvent{oldEvents[1], newEvent}
+	if events := queryAll(); !reflect.DeepEqual(expected, events) {
+		t.Fatalf("event mismatch: expected %v vs %v",
+			spew.Sdump(expected), spew.Sdump(events))
+	}
+}
@@ -900,6 +900,23 @@ func (p *ChannelPackager) RemovePkg(tx *bolt.Tx, height uint64) error {
 	return sourceBkt.DeleteBucket(heightKey[:])
 }
 
+// wipeFwdPkgs deletes all forwarding packages of the channel with the passed
+// short channel ID. If the channel has no forwarding packages, then this is a
+// noop.
+func wipeFwdPkgs(tx *bolt.Tx, source lnwire.ShortChannelID) error {
+	fwdPkgBkt := tx.Bucket(fwdPackagesKey)
+	if fwdPkgBkt == nil {
+		return nil
+	}
+
+	sourceBytes := makeLogKey(source.ToUint64())
+	if fwdPkgBkt.Bucket(sourceBytes[:]) == nil {
+		return nil
+	}
+
+	return fwdPkgBkt.DeleteBucket(sourceBytes[:])
+}
+
 // uint16Key writes the provided 16-bit unsigned integer to a 2-byte slice.
 func uint16Key(i uint16) []byte {
 	key := make([]byte, 2)
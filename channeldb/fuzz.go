@@ -0,0 +1,217 @@
+// +build gofuzz
+
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// fuzzCodec couples the decoder of a record stored within the database with
+// its encoder. A nil encoder denotes a record which is only decoded.
+type fuzzCodec struct {
+	name   string
+	decode func(r io.Reader) (interface{}, error)
+	encode func(w io.Writer, v interface{}) error
+}
+
+// fuzzCodecs is the set of records exercised by Fuzz. The first byte of the
+// fuzzed input selects the record, while the remainder is fed to its decoder.
+var fuzzCodecs = []fuzzCodec{
+	{
+		name: "policy",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializePolicy(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializePolicy(w, v.(*Policy))
+		},
+	},
+	{
+		name: "legacy policy",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeLegacyPolicy(r)
+		},
+	},
+	{
+		name: "policy audit record",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializePolicyAuditRecord(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializePolicyAuditRecord(
+				w, v.(*PolicyAuditRecord),
+			)
+		},
+	},
+	{
+		name: "stored invoice",
+		decode: func(r io.Reader) (interface{}, error) {
+			var b bytes.Buffer
+			if _, err := b.ReadFrom(r); err != nil {
+				return nil, err
+			}
+
+			return deserializeStoredInvoice(
+				bytes.NewReader(b.Bytes()),
+			)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeStoredInvoice(w, v.(*Invoice))
+		},
+	},
+	{
+		name: "AMP settlement",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeAMPSettlement(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeAMPSettlement(w, v.(*AMPSettlement))
+		},
+	},
+	{
+		name: "outgoing payment",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeOutgoingPayment(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeOutgoingPayment(w, v.(*OutgoingPayment))
+		},
+	},
+	{
+		name: "htlc attempt",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeHTLCAttempt(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeHTLCAttempt(w, v.(*HTLCAttempt))
+		},
+	},
+	{
+		name: "link node",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeLinkNode(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeLinkNode(w, v.(*LinkNode))
+		},
+	},
+	{
+		name: "lightning node",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeLightningNode(r)
+		},
+	},
+	{
+		name: "channel edge info",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeChanEdgeInfo(r)
+		},
+	},
+	{
+		name: "channel edge policy",
+		decode: func(r io.Reader) (interface{}, error) {
+			edge, _, err := deserializeChanEdgePolicyRaw(r)
+			return edge, err
+		},
+	},
+	{
+		name: "channel commitment",
+		decode: func(r io.Reader) (interface{}, error) {
+			c, err := deserializeChanCommit(r)
+			return &c, err
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeChanCommit(w, v.(*ChannelCommitment))
+		},
+	},
+	{
+		name: "revocation log entry",
+		decode: func(r io.Reader) (interface{}, error) {
+			c, err := deserializeRevocationLogEntry(r)
+			return &c, err
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeRevocationLogEntry(
+				w, v.(*ChannelCommitment),
+			)
+		},
+	},
+	{
+		name: "commit diff",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeCommitDiff(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeCommitDiff(w, v.(*CommitDiff))
+		},
+	},
+	{
+		name: "channel close summary",
+		decode: func(r io.Reader) (interface{}, error) {
+			return deserializeCloseChannelSummary(r)
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return serializeChannelCloseSummary(
+				w, v.(*ChannelCloseSummary),
+			)
+		},
+	},
+	{
+		name: "forwarding event",
+		decode: func(r io.Reader) (interface{}, error) {
+			var f ForwardingEvent
+			err := decodeForwardingEvent(r, &f)
+			return &f, err
+		},
+		encode: func(w io.Writer, v interface{}) error {
+			return encodeForwardingEvent(w, v.(*ForwardingEvent))
+		},
+	},
+}
+
+// Fuzz is used by go-fuzz to feed malformed records to the deserializers of
+// the database. The first byte of data selects the type of record to decode.
+// Decoding is expected to fail gracefully, while a record that is decoded
+// successfully must be encoded to the same bytes once re-decoded.
+func Fuzz(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+	codec := fuzzCodecs[int(data[0])%len(fuzzCodecs)]
+
+	v, err := codec.decode(bytes.NewReader(data[1:]))
+	if err != nil {
+		// Ignore this input, as the decoder rejected it.
+		return 0
+	}
+	if codec.encode == nil {
+		return 1
+	}
+
+	// The decoded record may not be encodable, as some of its fields are
+	// validated on encoding only.
+	var b1 bytes.Buffer
+	if err := codec.encode(&b1, v); err != nil {
+		return 0
+	}
+
+	// Once encoded, the record must survive a round trip unchanged.
+	v, err = codec.decode(bytes.NewReader(b1.Bytes()))
+	if err != nil {
+		panic(fmt.Errorf("unable to decode encoded %v: %v",
+			codec.name, err))
+	}
+	var b2 bytes.Buffer
+	if err := codec.encode(&b2, v); err != nil {
+		panic(fmt.Errorf("unable to encode decoded %v: %v",
+			codec.name, err))
+	}
+	if !bytes.Equal(b1.Bytes(), b2.Bytes()) {
+		panic(fmt.Errorf("encoding of %v isn't stable: %x vs %x",
+			codec.name, b1.Bytes(), b2.Bytes()))
+	}
+
+	return 1
+}
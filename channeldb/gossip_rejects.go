@@ -0,0 +1,228 @@
+package channeldb
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// gossipRejectBucket stores the channels whose gossip messages were
+	// rejected, allowing stale messages to be dropped without being
+	// validated again after a restart.
+	//
+	// maps: short channel ID -> sequence number || update timestamp
+	gossipRejectBucket = []byte("gossip-rejects")
+)
+
+// gossipReject is a rejected channel within the GossipRejectCache.
+type gossipReject struct {
+	chanID uint64
+
+	// seq is the sequence number of the entry, which orders entries by
+	// the time they were first added.
+	seq uint64
+
+	// timestamp is the timestamp of the latest rejected channel update,
+	// or zero if the channel announcement itself was rejected.
+	timestamp uint32
+}
+
+// GossipRejectCache is a persistent, size-bounded cache of channels whose
+// gossip messages were rejected. For each channel, it records either that its
+// announcement was rejected, in which case all of its messages are rejected,
+// or the timestamp of its latest rejected channel update, in which case only
+// updates that aren't newer are rejected. Once the cache is full, the oldest
+// entries are evicted first.
+type GossipRejectCache struct {
+	db      *DB
+	maxSize int
+
+	mu      sync.Mutex
+	entries map[uint64]*list.Element
+	order   *list.List
+}
+
+// NewGossipRejectCache returns a cache of rejected gossip holding at most
+// maxSize channels, loading any entries stored by a previous instance. A
+// maxSize of zero disables the cache, in which case no messages are rejected
+// by it.
+func NewGossipRejectCache(db *DB, maxSize int) (*GossipRejectCache, error) {
+	c := &GossipRejectCache{
+		db:      db,
+		maxSize: maxSize,
+		entries: make(map[uint64]*list.Element),
+		order:   list.New(),
+	}
+
+	var rejects []*gossipReject
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(gossipRejectBucket)
+		if bucket == nil {
+			return nil
+		}
+
+		return bucket.ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 12 {
+				return nil
+			}
+
+			rejects = append(rejects, &gossipReject{
+				chanID:    byteOrder.Uint64(k),
+				seq:       byteOrder.Uint64(v[:8]),
+				timestamp: byteOrder.Uint32(v[8:]),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rejects, func(i, j int) bool {
+		return rejects[i].seq < rejects[j].seq
+	})
+
+	// If the cache was shrunk since the entries were stored, only the
+	// most recent ones are kept.
+	var evicted []*gossipReject
+	if len(rejects) > maxSize {
+		numEvicted := len(rejects) - maxSize
+		evicted, rejects = rejects[:numEvicted], rejects[numEvicted:]
+	}
+	for _, reject := range rejects {
+		c.entries[reject.chanID] = c.order.PushBack(reject)
+	}
+
+	if len(evicted) == 0 {
+		return c, nil
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(gossipRejectBucket)
+		for _, reject := range evicted {
+			err := bucket.Delete(gossipRejectKey(reject.chanID))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Add records that a message of the passed channel was rejected. A timestamp
+// of zero records that the channel announcement was rejected, otherwise it's
+// the timestamp of the rejected channel update.
+func (c *GossipRejectCache) Add(chanID uint64, timestamp uint32) error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted *gossipReject
+	reject := &gossipReject{
+		chanID:    chanID,
+		timestamp: timestamp,
+	}
+	if elem, ok := c.entries[chanID]; ok {
+		// A rejected announcement rejects all updates, otherwise we
+		// keep the timestamp of the latest rejected update.
+		prev := elem.Value.(*gossipReject)
+		reject.seq = prev.seq
+		if prev.timestamp == 0 || (timestamp != 0 &&
+			timestamp < prev.timestamp) {
+
+			reject.timestamp = prev.timestamp
+		}
+		if *reject == *prev {
+			return nil
+		}
+	} else if c.order.Len() >= c.maxSize {
+		evicted = c.order.Front().Value.(*gossipReject)
+	}
+
+	err := c.db.Batch(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(gossipRejectBucket)
+		if err != nil {
+			return err
+		}
+
+		if evicted != nil {
+			err := bucket.Delete(gossipRejectKey(evicted.chanID))
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, ok := c.entries[chanID]; !ok {
+			seq, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			reject.seq = seq
+		}
+
+		var value [12]byte
+		byteOrder.PutUint64(value[:8], reject.seq)
+		byteOrder.PutUint32(value[8:], reject.timestamp)
+
+		return bucket.Put(gossipRejectKey(chanID), value[:])
+	})
+	if err != nil {
+		return err
+	}
+
+	if evicted != nil {
+		c.order.Remove(c.entries[evicted.chanID])
+		delete(c.entries, evicted.chanID)
+	}
+	if elem, ok := c.entries[chanID]; ok {
+		elem.Value = reject
+	} else {
+		c.entries[chanID] = c.order.PushBack(reject)
+	}
+
+	return nil
+}
+
+// IsRejected returns true if a message of the passed channel should be
+// rejected. A timestamp of zero queries whether the channel announcement
+// should be rejected, otherwise it's the timestamp of a channel update.
+func (c *GossipRejectCache) IsRejected(chanID uint64, timestamp uint32) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[chanID]
+	if !ok {
+		return false
+	}
+
+	reject := elem.Value.(*gossipReject)
+	return reject.timestamp == 0 || timestamp <= reject.timestamp
+}
+
+// Len returns the number of channels within the cache.
+func (c *GossipRejectCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// gossipRejectKey returns the key the passed channel is stored under within
+// the gossip reject bucket.
+func gossipRejectKey(chanID uint64) []byte {
+	var key [8]byte
+	byteOrder.PutUint64(key[:], chanID)
+	return key[:]
+}
@@ -0,0 +1,115 @@
+package channeldb
+
+import "testing"
+
+// TestGossipRejectCache tests that the gossip reject cache rejects messages of
+// channels according to whether their announcement or an update was
+// rejected, evicts its oldest entries once full, and retains its entries
+// across restarts.
+func TestGossipRejectCache(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	cache, err := NewGossipRejectCache(db, 3)
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+
+	// Reject the announcement of the first channel, and an update of the
+	// second.
+	if err := cache.Add(1, 0); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	if err := cache.Add(2, 1000); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+
+	type query struct {
+		chanID    uint64
+		timestamp uint32
+		rejected  bool
+	}
+	assertRejected := func(c *GossipRejectCache, queries ...query) {
+		for _, q := range queries {
+			rejected := c.IsRejected(q.chanID, q.timestamp)
+			if rejected != q.rejected {
+				t.Fatalf("expected rejected=%v for channel "+
+					"%v at %v, got %v", q.rejected,
+					q.chanID, q.timestamp, rejected)
+			}
+		}
+	}
+
+	// All messages of the first channel should be rejected, while only
+	// updates of the second which aren't newer than the rejected one
+	// should be.
+	assertRejected(cache,
+		query{1, 0, true},
+		query{1, 2000, true},
+		query{2, 0, true},
+		query{2, 999, true},
+		query{2, 1000, true},
+		query{2, 1001, false},
+		query{3, 0, false},
+	)
+
+	// Rejecting a newer update should move the timestamp forward, while
+	// rejecting an older one shouldn't move it back.
+	if err := cache.Add(2, 2000); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	if err := cache.Add(2, 1500); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	assertRejected(cache, query{2, 2000, true}, query{2, 2001, false})
+
+	// Adding two more channels should evict the oldest one, the first.
+	if err := cache.Add(3, 0); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	if err := cache.Add(4, 0); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	if cache.Len() != 3 {
+		t.Fatalf("expected 3 entries, got %v", cache.Len())
+	}
+	assertRejected(cache, query{1, 0, false}, query{4, 0, true})
+
+	// A new cache should load the entries stored by the previous one.
+	cache, err = NewGossipRejectCache(db, 3)
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	assertRejected(cache,
+		query{1, 0, false},
+		query{2, 2000, true},
+		query{2, 2001, false},
+		query{3, 0, true},
+		query{4, 0, true},
+	)
+
+	// Shrinking the cache should only keep the most recent entries.
+	cache, err = NewGossipRejectCache(db, 1)
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("expected 1 entry, got %v", cache.Len())
+	}
+	assertRejected(cache, query{3, 0, false}, query{4, 0, true})
+
+	// A disabled cache shouldn't reject anything.
+	cache, err = NewGossipRejectCache(db, 0)
+	if err != nil {
+		t.Fatalf("unable to create cache: %v", err)
+	}
+	if err := cache.Add(5, 0); err != nil {
+		t.Fatalf("unable to add reject: %v", err)
+	}
+	assertRejected(cache, query{4, 0, false}, query{5, 0, false})
+}
@@ -127,6 +127,13 @@ func (c *ChannelGraph) Database() *DB {
 	return c.db
 }
 
+// ViewTx executes fn within a read-only transaction of the graph's backend.
+// Unlike the transactions of the underlying database's View method, the
+// transaction doesn't depend on the backend being a local bolt database.
+func (c *ChannelGraph) ViewTx(fn func(ReadTx) error) error {
+	return c.db.ViewTx(fn)
+}
+
 // ForEachChannel iterates through all the channel edges stored within the
 // graph and invokes the passed callback for each edge. The callback takes two
 // edges as since this is a directed graph, both the in/out edges are visited.
@@ -169,44 +176,13 @@ func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdgeInfo, *ChannelEdgePoli
 				return err
 			}
 
-			// The first node is contained within the first half of
-			// the edge information.
-			node1Pub := edgeInfoBytes[:33]
-			edge1, err := fetchChanEdgePolicy(edges, chanID, node1Pub, nodes)
-			if err != nil && err != ErrEdgeNotFound &&
-				err != ErrGraphNodeNotFound {
-				return err
-			}
-
-			// The targeted edge may have not been advertised
-			// within the network, so we ensure it's non-nil before
-			// dereferencing its attributes.
-			if edge1 != nil {
-				edge1.db = c.db
-				if edge1.Node != nil {
-					edge1.Node.db = c.db
-				}
-			}
-
-			// Similarly, the second node is contained within the
-			// latter half of the edge information.
-			node2Pub := edgeInfoBytes[33:]
-			edge2, err := fetchChanEdgePolicy(edges, chanID, node2Pub, nodes)
-			if err != nil && err != ErrEdgeNotFound &&
-				err != ErrGraphNodeNotFound {
+			edge1, edge2, err := c.fetchChanEdgePolicies(
+				edges, nodes, chanID, edgeInfoBytes,
+			)
+			if err != nil {
 				return err
 			}
 
-			// The targeted edge may have not been advertised
-			// within the network, so we ensure it's non-nil before
-			// dereferencing its attributes.
-			if edge2 != nil {
-				edge2.db = c.db
-				if edge2.Node != nil {
-					edge2.Node.db = c.db
-				}
-			}
-
 			// With both edges read, execute the call back. IF this
 			// function returns an error then the transaction will
 			// be aborted.
@@ -215,23 +191,66 @@ func (c *ChannelGraph) ForEachChannel(cb func(*ChannelEdgeInfo, *ChannelEdgePoli
 	})
 }
 
+// fetchChanEdgePolicies fetches the routing policies of both nodes of the
+// channel with the passed ID, whose serialized edge info is passed as well.
+// Either policy is nil if it hasn't been advertised within the network.
+func (c *ChannelGraph) fetchChanEdgePolicies(edges, nodes kvReader,
+	chanID, edgeInfoBytes []byte) (*ChannelEdgePolicy, *ChannelEdgePolicy,
+	error) {
+
+	// The first node is contained within the first half of the edge
+	// information.
+	node1Pub := edgeInfoBytes[:33]
+	edge1, err := fetchChanEdgePolicy(edges, chanID, node1Pub, nodes)
+	if err != nil && err != ErrEdgeNotFound &&
+		err != ErrGraphNodeNotFound {
+		return nil, nil, err
+	}
+
+	// The targeted edge may have not been advertised within the network,
+	// so we ensure it's non-nil before dereferencing its attributes.
+	if edge1 != nil {
+		edge1.db = c.db
+		if edge1.Node != nil {
+			edge1.Node.db = c.db
+		}
+	}
+
+	// Similarly, the second node is contained within the latter half of
+	// the edge information.
+	node2Pub := edgeInfoBytes[33:]
+	edge2, err := fetchChanEdgePolicy(edges, chanID, node2Pub, nodes)
+	if err != nil && err != ErrEdgeNotFound &&
+		err != ErrGraphNodeNotFound {
+		return nil, nil, err
+	}
+
+	if edge2 != nil {
+		edge2.db = c.db
+		if edge2.Node != nil {
+			edge2.Node.db = c.db
+		}
+	}
+
+	return edge1, edge2, nil
+}
+
 // ForEachNode iterates through all the stored vertices/nodes in the graph,
 // executing the passed callback with each node encountered. If the callback
 // returns an error, then the transaction is aborted and the iteration stops
 // early.
 //
-// If the caller wishes to re-use an existing boltdb transaction, then it
-// should be passed as the first argument.  Otherwise the first argument should
-// be nil and a fresh transaction will be created to execute the graph
-// traversal
+// If the caller wishes to re-use an existing transaction, then it should be
+// passed as the first argument.  Otherwise the first argument should be nil
+// and a fresh transaction will be created to execute the graph traversal
 //
 // TODO(roasbeef): add iterator interface to allow for memory efficient graph
 // traversal when graph gets mega
-func (c *ChannelGraph) ForEachNode(tx *bolt.Tx, cb func(*bolt.Tx, *LightningNode) error) error {
-	traversal := func(tx *bolt.Tx) error {
+func (c *ChannelGraph) ForEachNode(tx ReadTx, cb func(ReadTx, *LightningNode) error) error {
+	traversal := func(tx ReadTx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
-		nodes := tx.Bucket(nodeBucket)
+		nodes := tx.ReadBucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNotFound
 		}
@@ -260,7 +279,7 @@ func (c *ChannelGraph) ForEachNode(tx *bolt.Tx, cb func(*bolt.Tx, *LightningNode
 	// If no transaction was provided, then we'll create a new transaction
 	// to execute the transaction within.
 	if tx == nil {
-		return c.db.View(traversal)
+		return c.db.ViewTx(traversal)
 	}
 
 	// Otherwise, we re-use the existing transaction to execute the graph
@@ -311,7 +330,11 @@ func (c *ChannelGraph) SourceNode() (*LightningNode, error) {
 func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 	nodePubBytes := node.PubKeyBytes[:]
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	var cachedNode LightningNode
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		// First grab the nodes bucket which stores the mapping from
 		// pubKey to node information.
 		nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
@@ -327,8 +350,20 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 
 		// Finally, we commit the information of the lightning node
 		// itself.
-		return addLightningNode(tx, node)
+		if err := addLightningNode(tx, node); err != nil {
+			return err
+		}
+
+		cachedNode, err = fetchLightningNode(nodes, nodePubBytes)
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.putNode(&cachedNode)
+
+	return nil
 }
 
 // AddLightningNode adds a vertex/node to the graph database. If the node is not
@@ -340,8 +375,22 @@ func (c *ChannelGraph) SetSourceNode(node *LightningNode) error {
 //
 // TODO(roasbeef): also need sig of announcement
 func (c *ChannelGraph) AddLightningNode(node *LightningNode) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
-		return addLightningNode(tx, node)
+	// We'll read the node back after writing it, so the graph cache holds
+	// exactly what's stored within the database. The write is batched
+	// with others arriving at the same time.
+	var cachedNode LightningNode
+	return c.db.graphBatcher.execute(func(tx *bolt.Tx) error {
+		if err := addLightningNode(tx, node); err != nil {
+			return err
+		}
+
+		var err error
+		cachedNode, err = fetchLightningNode(
+			tx.Bucket(nodeBucket), node.PubKeyBytes[:],
+		)
+		return err
+	}, func() {
+		c.db.graphCache.putNode(&cachedNode)
 	})
 }
 
@@ -398,8 +447,11 @@ func (c *ChannelGraph) LookupAlias(pub *btcec.PublicKey) (string, error) {
 func (c *ChannelGraph) DeleteLightningNode(nodePub *btcec.PublicKey) error {
 	pub := nodePub.SerializeCompressed()
 
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
 	// TODO(roasbeef): ensure dangling edges are removed...
-	return c.db.Update(func(tx *bolt.Tx) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		nodes, err := tx.CreateBucketIfNotExists(nodeBucket)
 		if err != nil {
 			return err
@@ -413,8 +465,20 @@ func (c *ChannelGraph) DeleteLightningNode(nodePub *btcec.PublicKey) error {
 		if err := aliases.Delete(pub); err != nil {
 			return err
 		}
+		if err := delNodeHistory(nodes, pub); err != nil {
+			return err
+		}
 		return nodes.Delete(pub)
 	})
+	if err != nil {
+		return err
+	}
+
+	var nodeKey [33]byte
+	copy(nodeKey[:], pub)
+	c.db.graphCache.delNode(nodeKey)
+
+	return nil
 }
 
 // AddChannelEdge adds a new (undirected, blank) edge to the graph database. An
@@ -428,7 +492,11 @@ func (c *ChannelGraph) AddChannelEdge(edge *ChannelEdgeInfo) error {
 	var chanKey [8]byte
 	binary.BigEndian.PutUint64(chanKey[:], edge.ChannelID)
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	var cachedEdge ChannelEdgeInfo
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -462,8 +530,20 @@ func (c *ChannelGraph) AddChannelEdge(edge *ChannelEdgeInfo) error {
 		if err := writeOutpoint(&b, &edge.ChannelPoint); err != nil {
 			return err
 		}
-		return chanIndex.Put(b.Bytes(), chanKey[:])
+		if err := chanIndex.Put(b.Bytes(), chanKey[:]); err != nil {
+			return err
+		}
+
+		cachedEdge, err = fetchChanEdgeInfo(edgeIndex, chanKey[:])
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.putEdge(&cachedEdge)
+
+	return nil
 }
 
 // HasChannelEdge returns true if the database knows of a channel edge with the
@@ -539,7 +619,11 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *ChannelEdgeInfo) error {
 	var chanKey [8]byte
 	binary.BigEndian.PutUint64(chanKey[:], edge.ChannelID)
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	var cachedEdge ChannelEdgeInfo
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -553,8 +637,21 @@ func (c *ChannelGraph) UpdateChannelEdge(edge *ChannelEdgeInfo) error {
 			return ErrEdgeNotFound
 		}
 
-		return putChanEdgeInfo(edgeIndex, edge, chanKey)
+		err = putChanEdgeInfo(edgeIndex, edge, chanKey)
+		if err != nil {
+			return err
+		}
+
+		cachedEdge, err = fetchChanEdgeInfo(edgeIndex, chanKey[:])
+		return err
 	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.putEdge(&cachedEdge)
+
+	return nil
 }
 
 const (
@@ -578,6 +675,9 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 
 	var chansClosed []*ChannelEdgeInfo
 
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
 	err := c.db.Update(func(tx *bolt.Tx) error {
 		// First grab the edges bucket which houses the information
 		// we'd like to delete
@@ -596,6 +696,11 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 			return err
 		}
 
+		metaBucket, err := tx.CreateBucketIfNotExists(graphMetaBucket)
+		if err != nil {
+			return err
+		}
+
 		// For each of the outpoints that have been spent within the
 		// block, we attempt to delete them from the graph as if that
 		// outpoint was a channel, then it has now been closed.
@@ -633,11 +738,19 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 			if err != nil && err != ErrEdgeNotFound {
 				return err
 			}
-		}
 
-		metaBucket, err := tx.CreateBucketIfNotExists(graphMetaBucket)
-		if err != nil {
-			return err
+			// Finally, we'll record the channel within the spend
+			// journal, so the channels closed by this block can
+			// later be looked up directly.
+			var chanIDBytes [8]byte
+			byteOrder.PutUint64(chanIDBytes[:], edgeInfo.ChannelID)
+			err = putSpendJournal(
+				metaBucket, blockHeight, opBytes.Bytes(),
+				chanIDBytes[:],
+			)
+			if err != nil {
+				return err
+			}
 		}
 
 		pruneBucket, err := metaBucket.CreateBucketIfNotExists(pruneLogBucket)
@@ -660,6 +773,10 @@ func (c *ChannelGraph) PruneGraph(spentOutputs []*wire.OutPoint,
 		return nil, err
 	}
 
+	for _, edgeInfo := range chansClosed {
+		c.db.graphCache.delEdge(edgeInfo.ChannelID)
+	}
+
 	return chansClosed, nil
 }
 
@@ -694,6 +811,9 @@ func (c *ChannelGraph) DisconnectBlockAtHeight(height uint32) ([]*ChannelEdgeInf
 	// Keep track of the channels that are removed from the graph.
 	var removedChans []*ChannelEdgeInfo
 
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
 	if err := c.db.Update(func(tx *bolt.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
@@ -756,11 +876,17 @@ func (c *ChannelGraph) DisconnectBlockAtHeight(height uint32) ([]*ChannelEdgeInf
 			}
 		}
 
-		return nil
+		// The spend journal entries of the disconnected blocks are
+		// removed as well, as their spends are no longer confirmed.
+		return delSpendJournal(metaBucket, height)
 	}); err != nil {
 		return nil, err
 	}
 
+	for _, edgeInfo := range removedChans {
+		c.db.graphCache.delEdge(edgeInfo.ChannelID)
+	}
+
 	return removedChans, nil
 }
 
@@ -815,7 +941,11 @@ func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
 	// channels
 	// TODO(roasbeef): don't delete both edges?
 
-	return c.db.Update(func(tx *bolt.Tx) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	var chanID uint64
+	err := c.db.Update(func(tx *bolt.Tx) error {
 		// First grab the edges bucket which houses the information
 		// we'd like to delete
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
@@ -832,8 +962,25 @@ func (c *ChannelGraph) DeleteChannelEdge(chanPoint *wire.OutPoint) error {
 			return err
 		}
 
+		// We'll note the ID of the channel before deleting it, so it
+		// can be removed from the graph cache as well.
+		var b bytes.Buffer
+		if err := writeOutpoint(&b, chanPoint); err != nil {
+			return err
+		}
+		if chanIDBytes := chanIndex.Get(b.Bytes()); chanIDBytes != nil {
+			chanID = byteOrder.Uint64(chanIDBytes)
+		}
+
 		return delChannelByEdge(edges, edgeIndex, chanIndex, chanPoint)
 	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.delEdge(chanID)
+
+	return nil
 }
 
 // ChannelID attempt to lookup the 8-byte compact channel ID which maps to the
@@ -917,6 +1064,13 @@ func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
 		}
 	}
 
+	// A deleted channel is no longer a zombie either.
+	if zombieIndex := edges.Bucket(zombieIndexBucket); zombieIndex != nil {
+		if err := zombieIndex.Delete(chanID); err != nil {
+			return err
+		}
+	}
+
 	// Finally, with the edge data deleted, we can purge the
 	// information from the two edge indexes.
 	if err := edgeIndex.Delete(chanID); err != nil {
@@ -933,7 +1087,14 @@ func delChannelByEdge(edges *bolt.Bucket, edgeIndex *bolt.Bucket,
 // determined by the lexicographical ordering of the identity public keys of
 // the nodes on either side of the channel.
 func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
-	return c.db.Update(func(tx *bolt.Tx) error {
+	// The update is batched with others arriving at the same time, such
+	// that a burst of channel updates is written within a single
+	// transaction.
+	var (
+		dbPolicy       *ChannelEdgePolicy
+		fromPub, toPub [33]byte
+	)
+	return c.db.graphBatcher.execute(func(tx *bolt.Tx) error {
 		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
 		if err != nil {
 			return err
@@ -968,7 +1129,27 @@ func (c *ChannelGraph) UpdateEdgePolicy(edge *ChannelEdgePolicy) error {
 
 		// Finally, with the direction of the edge being updated
 		// identified, we update the on-disk edge representation.
-		return putChanEdgePolicy(edges, edge, fromNode, toNode)
+		err = putChanEdgePolicy(edges, edge, fromNode, toNode)
+		if err != nil {
+			return err
+		}
+
+		// We'll read the policy back, so the graph cache holds exactly
+		// what's stored within the database.
+		var edgeKey [33 + 8]byte
+		copy(edgeKey[:], fromNode)
+		copy(edgeKey[33:], chanID[:])
+		dbPolicy, toPub, err = deserializeChanEdgePolicyRaw(
+			bytes.NewReader(edges.Get(edgeKey[:])),
+		)
+		if err != nil {
+			return err
+		}
+		copy(fromPub[:], fromNode)
+
+		return nil
+	}, func() {
+		c.db.graphCache.putPolicy(dbPolicy, fromPub, toPub)
 	})
 }
 
@@ -1146,25 +1327,24 @@ func (c *ChannelGraph) HasLightningNode(nodePub [33]byte) (time.Time, bool, erro
 // returns an error, then the iteration is halted with the error propagated
 // back up to the caller.
 //
-// If the caller wishes to re-use an existing boltdb transaction, then it
-// should be passed as the first argument.  Otherwise the first argument should
-// be nil and a fresh transaction will be created to execute the graph
-// traversal.
-func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
-	cb func(*bolt.Tx, *ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
+// If the caller wishes to re-use an existing transaction, then it should be
+// passed as the first argument.  Otherwise the first argument should be nil
+// and a fresh transaction will be created to execute the graph traversal.
+func (l *LightningNode) ForEachChannel(tx ReadTx,
+	cb func(ReadTx, *ChannelEdgeInfo, *ChannelEdgePolicy, *ChannelEdgePolicy) error) error {
 
 	nodePub := l.PubKeyBytes[:]
 
-	traversal := func(tx *bolt.Tx) error {
-		nodes := tx.Bucket(nodeBucket)
+	traversal := func(tx ReadTx) error {
+		nodes := tx.ReadBucket(nodeBucket)
 		if nodes == nil {
 			return ErrGraphNotFound
 		}
-		edges := tx.Bucket(edgeBucket)
+		edges := tx.ReadBucket(edgeBucket)
 		if edges == nil {
 			return ErrGraphNotFound
 		}
-		edgeIndex := edges.Bucket(edgeIndexBucket)
+		edgeIndex := edges.NestedReadBucket(edgeIndexBucket)
 		if edgeIndex == nil {
 			return ErrGraphNoEdgesFound
 		}
@@ -1184,7 +1364,7 @@ func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
 		// bucket until the retrieved key no longer has the public key
 		// as its prefix. This indicates that we've stepped over into
 		// another node's edges, so we can terminate our scan.
-		edgeCursor := edges.Cursor()
+		edgeCursor := edges.ReadCursor()
 		for nodeEdge, edgeInfo := edgeCursor.Seek(nodeStart[:]); bytes.HasPrefix(nodeEdge, nodePub); nodeEdge, edgeInfo = edgeCursor.Next() {
 			// If the prefix still matches, then the value is the
 			// raw edge information. So we can now serialize the
@@ -1234,7 +1414,7 @@ func (l *LightningNode) ForEachChannel(tx *bolt.Tx,
 	// If no transaction was provided, then we'll create a new transaction
 	// to execute the transaction within.
 	if tx == nil {
-		return l.db.View(traversal)
+		return l.db.ViewTx(traversal)
 	}
 
 	// Otherwise, we re-use the existing transaction to execute the graph
@@ -1568,6 +1748,11 @@ type ChannelEdgePolicy struct {
 	// HTLCs for each millionth of a satoshi forwarded.
 	FeeProportionalMillionths lnwire.MilliSatoshi
 
+	// MaxHTLC is the largest value HTLC this node will accept, expressed
+	// in millisatoshi. This is only set if the ChanUpdateOptionMaxHtlc
+	// bit is set within the Flags.
+	MaxHTLC lnwire.MilliSatoshi
+
 	// Node is the LightningNode that this directed edge leads to. Using
 	// this pointer the channel graph can further be traversed.
 	Node *LightningNode
@@ -1860,11 +2045,21 @@ func putLightningNode(nodeBucket *bolt.Bucket, aliasBucket *bolt.Bucket, node *L
 		return err
 	}
 
+	if err := putNodeHistory(nodeBucket, nodePub, node); err != nil {
+		return err
+	}
+
 	return nodeBucket.Put(nodePub, b.Bytes())
 
 }
 
-func fetchLightningNode(nodeBucket *bolt.Bucket,
+// kvReader is implemented by both the buckets of the bolt database and those
+// of the backend, allowing records to be fetched from either.
+type kvReader interface {
+	Get(key []byte) []byte
+}
+
+func fetchLightningNode(nodeBucket kvReader,
 	nodePub []byte) (LightningNode, error) {
 
 	nodeBytes := nodeBucket.Get(nodePub)
@@ -1883,7 +2078,7 @@ func deserializeLightningNode(r io.Reader) (LightningNode, error) {
 		err     error
 	)
 
-	if _, err := r.Read(scratch[:]); err != nil {
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
 		return LightningNode{}, err
 	}
 
@@ -1894,7 +2089,7 @@ func deserializeLightningNode(r io.Reader) (LightningNode, error) {
 		return LightningNode{}, err
 	}
 
-	if _, err := r.Read(scratch[:2]); err != nil {
+	if _, err := io.ReadFull(r, scratch[:2]); err != nil {
 		return LightningNode{}, err
 	}
 
@@ -1923,10 +2118,15 @@ func deserializeLightningNode(r io.Reader) (LightningNode, error) {
 		return LightningNode{}, err
 	}
 
-	node.Alias, err = wire.ReadVarString(r, 0)
+	// The alias is taken from the node's announcement, so it can't exceed
+	// the maximum size of a message.
+	alias, err := wire.ReadVarBytes(
+		r, 0, lnwire.MaxMessagePayload, "alias",
+	)
 	if err != nil {
 		return LightningNode{}, err
 	}
+	node.Alias = string(alias)
 
 	fv := lnwire.NewFeatureVector(nil, lnwire.GlobalFeatures)
 	err = fv.Decode(r)
@@ -1935,7 +2135,7 @@ func deserializeLightningNode(r io.Reader) (LightningNode, error) {
 	}
 	node.Features = fv
 
-	if _, err := r.Read(scratch[:2]); err != nil {
+	if _, err := io.ReadFull(r, scratch[:2]); err != nil {
 		return LightningNode{}, err
 	}
 	numAddresses := int(byteOrder.Uint16(scratch[:2]))
@@ -2016,7 +2216,7 @@ func putChanEdgeInfo(edgeIndex *bolt.Bucket, edgeInfo *ChannelEdgeInfo, chanID [
 	return edgeIndex.Put(chanID[:], b.Bytes())
 }
 
-func fetchChanEdgeInfo(edgeIndex *bolt.Bucket,
+func fetchChanEdgeInfo(edgeIndex kvReader,
 	chanID []byte) (ChannelEdgeInfo, error) {
 
 	edgeInfoBytes := edgeIndex.Get(chanID)
@@ -2136,11 +2336,20 @@ func putChanEdgePolicy(edges *bolt.Bucket, edge *ChannelEdgePolicy, from, to []b
 		return err
 	}
 
+	// The maximum HTLC value is optional, so we'll only write it after
+	// the rest of the policy if it's signalled within the flags.
+	if edge.Flags.HasMaxHtlc() {
+		err := binary.Write(&b, byteOrder, uint64(edge.MaxHTLC))
+		if err != nil {
+			return err
+		}
+	}
+
 	return edges.Put(edgeKey[:], b.Bytes()[:])
 }
 
-func fetchChanEdgePolicy(edges *bolt.Bucket, chanID []byte,
-	nodePub []byte, nodes *bolt.Bucket) (*ChannelEdgePolicy, error) {
+func fetchChanEdgePolicy(edges kvReader, chanID []byte,
+	nodePub []byte, nodes kvReader) (*ChannelEdgePolicy, error) {
 
 	var edgeKey [33 + 8]byte
 	copy(edgeKey[:], nodePub)
@@ -2198,60 +2407,81 @@ func fetchChanEdgePolicies(edgeIndex *bolt.Bucket, edges *bolt.Bucket,
 }
 
 func deserializeChanEdgePolicy(r io.Reader,
-	nodes *bolt.Bucket) (*ChannelEdgePolicy, error) {
+	nodes kvReader) (*ChannelEdgePolicy, error) {
+
+	edge, pub, err := deserializeChanEdgePolicyRaw(r)
+	if err != nil {
+		return nil, err
+	}
 
+	node, err := fetchLightningNode(nodes, pub[:])
+	if err != nil {
+		return nil, err
+	}
+
+	edge.Node = &node
+	return edge, nil
+}
+
+// deserializeChanEdgePolicyRaw deserializes a channel edge policy without
+// fetching the node it points to, returning the public key of that node
+// instead.
+func deserializeChanEdgePolicyRaw(r io.Reader) (*ChannelEdgePolicy, [33]byte,
+	error) {
+
+	var pub [33]byte
 	edge := &ChannelEdgePolicy{}
 
 	var err error
 	edge.SigBytes, err = wire.ReadVarBytes(r, 0, 80, "sig")
 	if err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	if err := binary.Read(r, byteOrder, &edge.ChannelID); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	var scratch [8]byte
-	if _, err := r.Read(scratch[:]); err != nil {
-		return nil, err
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, pub, err
 	}
 	unix := int64(byteOrder.Uint64(scratch[:]))
 	edge.LastUpdate = time.Unix(unix, 0)
 
 	if err := binary.Read(r, byteOrder, &edge.Flags); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	if err := binary.Read(r, byteOrder, &edge.TimeLockDelta); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 
 	var n uint64
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.MinHTLC = lnwire.MilliSatoshi(n)
 
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.FeeBaseMSat = lnwire.MilliSatoshi(n)
 
 	if err := binary.Read(r, byteOrder, &n); err != nil {
-		return nil, err
+		return nil, pub, err
 	}
 	edge.FeeProportionalMillionths = lnwire.MilliSatoshi(n)
 
-	var pub [33]byte
-	if _, err := r.Read(pub[:]); err != nil {
-		return nil, err
+	if _, err := io.ReadFull(r, pub[:]); err != nil {
+		return nil, pub, err
 	}
 
-	node, err := fetchLightningNode(nodes, pub[:])
-	if err != nil {
-		return nil, err
+	if edge.Flags.HasMaxHtlc() {
+		if err := binary.Read(r, byteOrder, &n); err != nil {
+			return nil, pub, err
+		}
+		edge.MaxHTLC = lnwire.MilliSatoshi(n)
 	}
 
-	edge.Node = &node
-	return edge, nil
+	return edge, pub, nil
 }
@@ -0,0 +1,135 @@
+package channeldb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// graphWrite is a single modification of the channel graph, awaiting its
+// execution as part of a batch.
+type graphWrite struct {
+	// update modifies the graph within the transaction of the batch. It
+	// may be executed more than once, if another write of the batch
+	// fails.
+	update func(tx *bolt.Tx) error
+
+	// onCommit is called once the transaction executing update has been
+	// committed, allowing the graph cache to be updated.
+	onCommit func()
+
+	// errChan receives the result of the write.
+	errChan chan error
+}
+
+// graphBatcher coalesces the modifications of the channel graph which arrive
+// within a short window into a single database transaction. During the
+// initial graph sync, this replaces the fsync of a transaction per channel
+// update by one per batch.
+//
+// NOTE: Each batch is executed while holding the graphCacheMtx of the
+// database exclusively, covering both the transaction and the updates of the
+// graph cache.
+type graphBatcher struct {
+	db *DB
+
+	// window is the duration for which writes are collected before the
+	// batch is executed. A value of zero disables batching.
+	window time.Duration
+
+	mu      sync.Mutex
+	pending []*graphWrite
+}
+
+// newGraphBatcher creates a new graph batcher which collects writes for the
+// passed window before executing them.
+func newGraphBatcher(db *DB, window time.Duration) *graphBatcher {
+	return &graphBatcher{
+		db:     db,
+		window: window,
+	}
+}
+
+// execute adds a write to the current batch, starting a new one if there's
+// none, and blocks until the batch has been executed. Once the write has been
+// committed, onCommit is called, after which the result of the write is
+// returned.
+func (b *graphBatcher) execute(update func(tx *bolt.Tx) error,
+	onCommit func()) error {
+
+	write := &graphWrite{
+		update:   update,
+		onCommit: onCommit,
+		errChan:  make(chan error, 1),
+	}
+
+	if b.window == 0 {
+		b.db.graphCacheMtx.Lock()
+		defer b.db.graphCacheMtx.Unlock()
+
+		b.commit([]*graphWrite{write})
+		return <-write.errChan
+	}
+
+	// The first write of a batch schedules its execution once the window
+	// has passed.
+	b.mu.Lock()
+	b.pending = append(b.pending, write)
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	return <-write.errChan
+}
+
+// flush executes all writes collected so far.
+func (b *graphBatcher) flush() {
+	b.mu.Lock()
+	writes := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	b.db.graphCacheMtx.Lock()
+	defer b.db.graphCacheMtx.Unlock()
+
+	b.commit(writes)
+}
+
+// commit executes the writes within a single transaction, delivering the
+// result to each of them. If a write fails, the transaction is rolled back,
+// and the remaining writes are executed again without it.
+//
+// NOTE: This method MUST be called with the graphCacheMtx of the database
+// held exclusively.
+func (b *graphBatcher) commit(writes []*graphWrite) {
+	for len(writes) > 0 {
+		failed := -1
+		err := b.db.Update(func(tx *bolt.Tx) error {
+			for i, write := range writes {
+				if err := write.update(tx); err != nil {
+					failed = i
+					return err
+				}
+			}
+			return nil
+		})
+
+		// If one of the writes failed, it receives the error, while
+		// the others are retried.
+		if failed >= 0 {
+			writes[failed].errChan <- err
+			writes = append(writes[:failed], writes[failed+1:]...)
+			continue
+		}
+
+		for _, write := range writes {
+			if err == nil {
+				write.onCommit()
+			}
+			write.errChan <- err
+		}
+		return
+	}
+}
@@ -0,0 +1,86 @@
+package channeldb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestGraphBatcher tests that channel updates arriving at the same time are
+// written within a single transaction, and that a failing update doesn't
+// affect the others of its batch.
+func TestGraphBatcher(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	nodes := make([]*LightningNode, 2)
+	for i := range nodes {
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(nodes[i]); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	const numChans = 20
+	chanIDs := make([]uint64, numChans)
+	for i := range chanIDs {
+		chanIDs[i] = uint64(i + 1)
+		addTestChannel(t, db, chanIDs[i], nodes[0], nodes[1], false,
+			false)
+	}
+
+	before, err := db.Metrics()
+	if err != nil {
+		t.Fatalf("unable to fetch metrics: %v", err)
+	}
+
+	// Send an update for each channel at once, along with one for an
+	// unknown channel.
+	var wg sync.WaitGroup
+	errs := make([]error, numChans+1)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			edge := randEdgePolicy(uint64(i+1), wire.OutPoint{}, db)
+			edge.Node = nodes[1]
+			edge.SigBytes = testSig.Serialize()
+			errs[i] = graph.UpdateEdgePolicy(edge)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs[:numChans] {
+		if err != nil {
+			t.Fatalf("unable to update edge %v: %v", i+1, err)
+		}
+	}
+	if errs[numChans] != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", errs[numChans])
+	}
+
+	after, err := db.Metrics()
+	if err != nil {
+		t.Fatalf("unable to fetch metrics: %v", err)
+	}
+	numUpdates := after.Update.Count - before.Update.Count
+	if numUpdates >= numChans {
+		t.Fatalf("expected updates to be batched, got %v "+
+			"transactions for %v updates", numUpdates, numChans+1)
+	}
+
+	assertNodeChannels(t, graph, nodes[0], chanIDs...)
+	assertGraphCacheConsistent(t, db, nodes)
+}
@@ -0,0 +1,368 @@
+package channeldb
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/coreos/bbolt"
+)
+
+// cachedPolicy is a channel edge policy held within the graph cache. The
+// policy itself doesn't reference the node it points to, as nodes are cached
+// separately and may be updated independently of the policy.
+type cachedPolicy struct {
+	policy *ChannelEdgePolicy
+	toNode [33]byte
+}
+
+// graphCache is an in-memory copy of the channel graph, allowing path finding
+// to traverse the graph without any database reads. It's populated from the
+// database when it's opened, and updated by each method of the ChannelGraph
+// which modifies the graph, after the modification has been committed.
+//
+// NOTE: The graph cache isn't safe for concurrent use by itself. Accesses to
+// it are guarded by the graphCacheMtx of the database. A nil graph cache
+// ignores all updates.
+type graphCache struct {
+	// nodes holds all nodes within the graph, keyed by their public key.
+	nodes map[[33]byte]*LightningNode
+
+	// edges holds the static information of all channels within the
+	// graph, keyed by their channel ID.
+	edges map[uint64]*ChannelEdgeInfo
+
+	// policies holds the routing policies of all channels within the
+	// graph, keyed by the node which advertised them, then by the channel
+	// ID.
+	policies map[[33]byte]map[uint64]*cachedPolicy
+
+	// zombies is the set of channel IDs of all channels marked as
+	// zombies.
+	zombies map[uint64]struct{}
+}
+
+// newGraphCache creates a new, empty graph cache.
+func newGraphCache() *graphCache {
+	return &graphCache{
+		nodes:    make(map[[33]byte]*LightningNode),
+		edges:    make(map[uint64]*ChannelEdgeInfo),
+		policies: make(map[[33]byte]map[uint64]*cachedPolicy),
+		zombies:  make(map[uint64]struct{}),
+	}
+}
+
+// loadGraphCache creates a graph cache populated with the channel graph
+// stored within the database.
+func loadGraphCache(tx *bolt.Tx) (*graphCache, error) {
+	cache := newGraphCache()
+
+	nodes := tx.Bucket(nodeBucket)
+	if nodes != nil {
+		err := nodes.ForEach(func(pubKey, nodeBytes []byte) error {
+			// The source key maps to a public key rather than raw
+			// node information, and sub-buckets have no value.
+			if len(pubKey) != 33 || nodeBytes == nil {
+				return nil
+			}
+
+			node, err := deserializeLightningNode(
+				bytes.NewReader(nodeBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			cache.putNode(&node)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return cache, nil
+	}
+
+	edgeIndex := edges.Bucket(edgeIndexBucket)
+	if edgeIndex != nil {
+		err := edgeIndex.ForEach(func(_, edgeInfoBytes []byte) error {
+			if edgeInfoBytes == nil {
+				return nil
+			}
+
+			edgeInfo, err := deserializeChanEdgeInfo(
+				bytes.NewReader(edgeInfoBytes),
+			)
+			if err != nil {
+				return err
+			}
+
+			cache.putEdge(&edgeInfo)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Each directed edge policy is stored under the public key of the
+	// node which advertised it, followed by the channel ID.
+	err := edges.ForEach(func(edgeKey, edgeBytes []byte) error {
+		if len(edgeKey) != 33+8 || edgeBytes == nil {
+			return nil
+		}
+
+		policy, toNode, err := deserializeChanEdgePolicyRaw(
+			bytes.NewReader(edgeBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		var fromNode [33]byte
+		copy(fromNode[:], edgeKey[:33])
+		cache.putPolicy(policy, fromNode, toNode)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachZombieEdge(tx, cache.markZombie)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// putNode adds the node to the cache, replacing any previous version of it.
+func (c *graphCache) putNode(node *LightningNode) {
+	if c == nil {
+		return
+	}
+
+	nodeCopy := *node
+	nodeCopy.db = nil
+	c.nodes[node.PubKeyBytes] = &nodeCopy
+}
+
+// delNode removes the node with the passed public key from the cache.
+func (c *graphCache) delNode(pubKey [33]byte) {
+	if c == nil {
+		return
+	}
+
+	delete(c.nodes, pubKey)
+}
+
+// putEdge adds the static information of a channel to the cache, replacing
+// any previous version of it.
+func (c *graphCache) putEdge(edgeInfo *ChannelEdgeInfo) {
+	if c == nil {
+		return
+	}
+
+	edgeCopy := *edgeInfo
+	c.edges[edgeInfo.ChannelID] = &edgeCopy
+}
+
+// delEdge removes the channel with the passed channel ID from the cache, along
+// with both of its policies.
+func (c *graphCache) delEdge(chanID uint64) {
+	if c == nil {
+		return
+	}
+
+	edgeInfo, ok := c.edges[chanID]
+	if !ok {
+		return
+	}
+
+	for _, node := range [][33]byte{
+		edgeInfo.NodeKey1Bytes, edgeInfo.NodeKey2Bytes,
+	} {
+		delete(c.policies[node], chanID)
+		if len(c.policies[node]) == 0 {
+			delete(c.policies, node)
+		}
+	}
+	delete(c.edges, chanID)
+	delete(c.zombies, chanID)
+}
+
+// markZombie marks the channel with the passed channel ID as a zombie.
+func (c *graphCache) markZombie(chanID uint64) {
+	if c == nil {
+		return
+	}
+
+	c.zombies[chanID] = struct{}{}
+}
+
+// markLive removes the zombie mark of the channel with the passed channel ID.
+func (c *graphCache) markLive(chanID uint64) {
+	if c == nil {
+		return
+	}
+
+	delete(c.zombies, chanID)
+}
+
+// putPolicy adds the policy advertised by fromNode for its channel to toNode
+// to the cache, replacing any previous version of it.
+func (c *graphCache) putPolicy(policy *ChannelEdgePolicy, fromNode,
+	toNode [33]byte) {
+
+	if c == nil {
+		return
+	}
+
+	policyCopy := *policy
+	policyCopy.Node = nil
+	policyCopy.db = nil
+
+	nodePolicies, ok := c.policies[fromNode]
+	if !ok {
+		nodePolicies = make(map[uint64]*cachedPolicy)
+		c.policies[fromNode] = nodePolicies
+	}
+	nodePolicies[policy.ChannelID] = &cachedPolicy{
+		policy: &policyCopy,
+		toNode: toNode,
+	}
+}
+
+// cachedChannel is a channel of a node as returned by the graph cache, along
+// with the outgoing and incoming policies from the point of view of the node.
+type cachedChannel struct {
+	edgeInfo *ChannelEdgeInfo
+	outgoing *ChannelEdgePolicy
+	incoming *ChannelEdgePolicy
+}
+
+// nodeChannels returns copies of all channels for which the node with the
+// passed public key advertised a policy, in ascending order of their channel
+// ID. This mirrors the channels traversed by LightningNode.ForEachChannel,
+// including the errors it returns for missing edges or nodes, except that
+// zombie channels are skipped.
+func (c *graphCache) nodeChannels(pubKey [33]byte,
+	db *DB) ([]cachedChannel, error) {
+
+	nodePolicies := c.policies[pubKey]
+
+	chanIDs := make([]uint64, 0, len(nodePolicies))
+	for chanID := range nodePolicies {
+		if _, ok := c.zombies[chanID]; ok {
+			continue
+		}
+		chanIDs = append(chanIDs, chanID)
+	}
+	sort.Slice(chanIDs, func(i, j int) bool {
+		return chanIDs[i] < chanIDs[j]
+	})
+
+	channels := make([]cachedChannel, 0, len(chanIDs))
+	for _, chanID := range chanIDs {
+		outgoing := nodePolicies[chanID]
+
+		toNode, ok := c.nodes[outgoing.toNode]
+		if !ok {
+			return nil, ErrGraphNodeNotFound
+		}
+		edgeInfo, ok := c.edges[chanID]
+		if !ok {
+			return nil, ErrEdgeNotFound
+		}
+
+		channel := cachedChannel{
+			edgeInfo: copyEdgeInfo(edgeInfo),
+			outgoing: copyPolicy(outgoing.policy, toNode, db),
+		}
+
+		// The incoming policy is only known if the connecting node
+		// advertised one, pointing back to this node.
+		incoming, ok := c.policies[outgoing.toNode][chanID]
+		if ok {
+			if node, ok := c.nodes[incoming.toNode]; ok {
+				channel.incoming = copyPolicy(
+					incoming.policy, node, db,
+				)
+			}
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels, nil
+}
+
+// copyEdgeInfo returns a copy of the cached channel information.
+func copyEdgeInfo(edgeInfo *ChannelEdgeInfo) *ChannelEdgeInfo {
+	edgeCopy := *edgeInfo
+	return &edgeCopy
+}
+
+// copyPolicy returns a copy of the cached policy, pointing to a copy of the
+// cached node.
+func copyPolicy(policy *ChannelEdgePolicy, node *LightningNode,
+	db *DB) *ChannelEdgePolicy {
+
+	nodeCopy := *node
+	nodeCopy.db = db
+
+	policyCopy := *policy
+	policyCopy.Node = &nodeCopy
+	policyCopy.db = db
+
+	return &policyCopy
+}
+
+// ForEachNodeChannel iterates through all the outgoing channel edges of the
+// node with the passed public key, like LightningNode.ForEachChannel, but
+// skipping zombie channels. If the graph cache is enabled, the channels are
+// read from memory, in which case the passed transaction, if any, is merely
+// handed to the callback. Otherwise, they're read from the database.
+func (c *ChannelGraph) ForEachNodeChannel(tx ReadTx, nodePub [33]byte,
+	cb func(ReadTx, *ChannelEdgeInfo, *ChannelEdgePolicy,
+		*ChannelEdgePolicy) error) error {
+
+	if c.db.graphCache == nil {
+		node := &LightningNode{
+			PubKeyBytes: nodePub,
+			db:          c.db,
+		}
+		return node.ForEachChannel(tx, func(tx ReadTx,
+			edgeInfo *ChannelEdgeInfo,
+			outEdge, inEdge *ChannelEdgePolicy) error {
+
+			if isZombieEdge(tx, edgeInfo.ChannelID) {
+				return nil
+			}
+
+			return cb(tx, edgeInfo, outEdge, inEdge)
+		})
+	}
+
+	// We'll copy the channels out of the cache before executing the
+	// callback, so the callback may safely access the graph itself.
+	c.db.graphCacheMtx.RLock()
+	channels, err := c.db.graphCache.nodeChannels(nodePub, c.db)
+	c.db.graphCacheMtx.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		err := cb(tx, channel.edgeInfo, channel.outgoing,
+			channel.incoming)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,313 @@
+package channeldb
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// nodeChannel is a single channel of a node as passed to the callback of a
+// channel traversal.
+type nodeChannel struct {
+	edgeInfo *ChannelEdgeInfo
+	outgoing *ChannelEdgePolicy
+	incoming *ChannelEdgePolicy
+}
+
+// assertGraphCacheConsistent asserts that the graph cache of the database
+// matches a cache freshly loaded from disk, and that traversing the live
+// channels of each node through the cache yields the same result as
+// traversing them on disk.
+func assertGraphCacheConsistent(t *testing.T, db *DB,
+	nodes []*LightningNode) {
+
+	t.Helper()
+
+	err := db.graphDB.View(func(tx *bolt.Tx) error {
+		diskCache, err := loadGraphCache(tx)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(diskCache, db.graphDB.graphCache) {
+			t.Fatalf("graph cache mismatch: expected %v, got %v",
+				spew.Sdump(diskCache),
+				spew.Sdump(db.graphDB.graphCache))
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to load graph cache: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+	for _, node := range nodes {
+		var diskChans, cacheChans []nodeChannel
+		diskNode := &LightningNode{
+			PubKeyBytes: node.PubKeyBytes,
+			db:          db.graphDB,
+		}
+		err := diskNode.ForEachChannel(nil, func(tx ReadTx,
+			e *ChannelEdgeInfo, out, in *ChannelEdgePolicy) error {
+
+			if isZombieEdge(tx, e.ChannelID) {
+				return nil
+			}
+
+			diskChans = append(diskChans, nodeChannel{e, out, in})
+			return nil
+		})
+
+		// Before any channel is added, the edge index doesn't exist
+		// on disk, while the cache simply holds no channels.
+		if err != nil && err != ErrGraphNoEdgesFound {
+			t.Fatalf("unable to traverse channels: %v", err)
+		}
+
+		err = graph.ForEachNodeChannel(nil, node.PubKeyBytes,
+			func(_ ReadTx, e *ChannelEdgeInfo,
+				out, in *ChannelEdgePolicy) error {
+
+				cacheChans = append(
+					cacheChans, nodeChannel{e, out, in},
+				)
+				return nil
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to traverse cached channels: %v", err)
+		}
+
+		if !reflect.DeepEqual(diskChans, cacheChans) {
+			t.Fatalf("channel mismatch: expected %v, got %v",
+				spew.Sdump(diskChans), spew.Sdump(cacheChans))
+		}
+	}
+}
+
+// addTestChannel adds a channel between the two nodes to the graph, along with
+// the policies of the nodes for which withPolicy is set.
+func addTestChannel(t *testing.T, db *DB, chanID uint64, node1,
+	node2 *LightningNode, withPolicy1, withPolicy2 bool) *ChannelEdgeInfo {
+
+	t.Helper()
+
+	graph := db.ChannelGraph()
+	op := wire.OutPoint{
+		Hash: sha256.Sum256([]byte{byte(chanID)}),
+	}
+	edgeInfo := &ChannelEdgeInfo{
+		ChannelID:    chanID,
+		ChainHash:    key,
+		ChannelPoint: op,
+		Capacity:     1000,
+	}
+	copy(edgeInfo.NodeKey1Bytes[:], node1.PubKeyBytes[:])
+	copy(edgeInfo.NodeKey2Bytes[:], node2.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey1Bytes[:], node1.PubKeyBytes[:])
+	copy(edgeInfo.BitcoinKey2Bytes[:], node2.PubKeyBytes[:])
+	if err := graph.AddChannelEdge(edgeInfo); err != nil {
+		t.Fatalf("unable to add edge: %v", err)
+	}
+
+	if withPolicy1 {
+		edge := randEdgePolicy(chanID, op, db)
+		edge.Flags = 0
+		edge.Node = node2
+		edge.SigBytes = testSig.Serialize()
+		if err := graph.UpdateEdgePolicy(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+	if withPolicy2 {
+		edge := randEdgePolicy(chanID, op, db)
+		edge.Flags = 1
+		edge.Node = node1
+		edge.SigBytes = testSig.Serialize()
+		if err := graph.UpdateEdgePolicy(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	return edgeInfo
+}
+
+// TestGraphCache tests that the graph cache is kept in sync with all
+// modifications of the channel graph.
+func TestGraphCache(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	const numNodes = 4
+	nodes := make([]*LightningNode, numNodes)
+	for i := range nodes {
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(nodes[i]); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+	if err := graph.SetSourceNode(nodes[0]); err != nil {
+		t.Fatalf("unable to set source node: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// Connect the nodes, leaving one of the channels with a policy in a
+	// single direction only.
+	addTestChannel(t, db, 1, nodes[0], nodes[1], true, true)
+	addTestChannel(t, db, 2, nodes[1], nodes[2], true, false)
+	edge3 := addTestChannel(t, db, 3, nodes[2], nodes[3], true, true)
+	addTestChannel(t, db, 4, nodes[0], nodes[2], true, true)
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// Updating a node or a channel should be reflected by the cache.
+	nodes[1].Alias = "updated"
+	if err := graph.AddLightningNode(nodes[1]); err != nil {
+		t.Fatalf("unable to update node: %v", err)
+	}
+	edge3.Capacity = 2000
+	if err := graph.UpdateChannelEdge(edge3); err != nil {
+		t.Fatalf("unable to update edge: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// Deleting or pruning channels should remove them from the cache.
+	chanPoint := wire.OutPoint{Hash: sha256.Sum256([]byte{1})}
+	if err := graph.DeleteChannelEdge(&chanPoint); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	_, err = graph.PruneGraph(
+		[]*wire.OutPoint{&edge3.ChannelPoint}, &chainhash.Hash{}, 100,
+	)
+	if err != nil {
+		t.Fatalf("unable to prune graph: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	// As the IDs of all remaining channels place them within the genesis
+	// block, disconnecting it should remove them all.
+	if _, err := graph.DisconnectBlockAtHeight(0); err != nil {
+		t.Fatalf("unable to disconnect block: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+
+	err = graph.DeleteLightningNode(mustPubKey(t, nodes[3]))
+	if err != nil {
+		t.Fatalf("unable to delete node: %v", err)
+	}
+	assertGraphCacheConsistent(t, db, nodes[:3])
+
+	if err := db.Wipe(); err != nil {
+		t.Fatalf("unable to wipe db: %v", err)
+	}
+	if len(db.graphDB.graphCache.nodes) != 0 || len(db.graphDB.graphCache.edges) != 0 ||
+		len(db.graphDB.graphCache.policies) != 0 {
+
+		t.Fatalf("graph cache not emptied by wipe")
+	}
+}
+
+// mustPubKey returns the parsed public key of the node.
+func mustPubKey(t *testing.T, node *LightningNode) *btcec.PublicKey {
+	t.Helper()
+
+	pub, err := node.PubKey()
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	return pub
+}
+
+// TestGraphCacheLoad tests that the graph cache is populated when the
+// database is opened, and that the graph is read from disk if the cache is
+// disabled.
+func TestGraphCacheLoad(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	nodes := make([]*LightningNode, 3)
+	for i := range nodes {
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		err := db.ChannelGraph().AddLightningNode(nodes[i])
+		if err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+	addTestChannel(t, db, 1, nodes[0], nodes[1], true, true)
+	addTestChannel(t, db, 2, nodes[1], nodes[2], false, true)
+	db.Close()
+
+	db, err = Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	if db.graphDB.graphCache == nil {
+		t.Fatalf("graph cache not enabled")
+	}
+	if len(db.graphDB.graphCache.edges) != 2 {
+		t.Fatalf("expected 2 cached edges, got %d",
+			len(db.graphDB.graphCache.edges))
+	}
+	assertGraphCacheConsistent(t, db, nodes)
+	db.Close()
+
+	db, err = Open(tempDirName, OptionSetGraphCache(false))
+	if err != nil {
+		t.Fatalf("unable to reopen db: %v", err)
+	}
+	defer db.Close()
+	if db.graphDB.graphCache != nil {
+		t.Fatalf("graph cache not disabled")
+	}
+
+	// Without the cache, the channels should be read from disk.
+	var numChans int
+	err = db.ChannelGraph().ForEachNodeChannel(nil, nodes[1].PubKeyBytes,
+		func(ReadTx, *ChannelEdgeInfo, *ChannelEdgePolicy,
+			*ChannelEdgePolicy) error {
+
+			numChans++
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to traverse channels: %v", err)
+	}
+	if numChans != 1 {
+		t.Fatalf("expected 1 channel, got %d", numChans)
+	}
+}
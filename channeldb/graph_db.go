@@ -0,0 +1,257 @@
+package channeldb
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/coreos/bbolt"
+)
+
+const (
+	// graphDBName is the name of the database file within the database
+	// directory that the channel graph is stored within.
+	graphDBName = "graph.db"
+)
+
+var (
+	// graphDBVersions are the versions of the graph database. They're
+	// tracked independently of those of the channel database, as the
+	// graph is stored within a file of its own, so that a graph database
+	// written by a newer version is refused rather than misread.
+	graphDBVersions = []version{
+		{
+			// The base version of the graph database, holding the
+			// graph as it was moved out of the channel database,
+			// requires no migration.
+			number:    0,
+			migration: nil,
+		},
+	}
+)
+
+var (
+	// graphBuckets are the top-level buckets the channel graph is stored
+	// within.
+	graphBuckets = [][]byte{
+		nodeBucket,
+		aliasIndexBucket,
+		edgeBucket,
+		edgeIndexBucket,
+		graphMetaBucket,
+	}
+)
+
+// openGraphDB opens the database the channel graph of chanDB is stored within,
+// creating it if it doesn't exist yet. If the graph is still stored within
+// chanDB itself, as was the case before it was moved into a file of its own,
+// then it's moved into the graph database. If the database is opened
+// read-only, the graph is instead read from wherever it's currently stored.
+func openGraphDB(chanDB *DB, opts *Options) (*DB, error) {
+	path := filepath.Join(chanDB.dbPath, graphDBName)
+
+	// As the graph is reconstructed from the network, it can be rebuilt
+	// by starting over with an empty graph database.
+	if opts.ResetGraph && !opts.ReadOnly {
+		for _, p := range []string{path, path + compactBackupSuffix} {
+			err := os.Remove(p)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+		}
+
+		log.Infof("Removed graph database %v, the graph will be rebuilt "+
+			"from the network", path)
+	}
+
+	if !fileExists(path) {
+		if opts.ReadOnly {
+			chanDB.graphDB = chanDB
+			if err := chanDB.initGraph(opts); err != nil {
+				return nil, err
+			}
+
+			return chanDB, nil
+		}
+		if err := createGraphDB(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := maybeCompactDB(path, opts); err != nil {
+		return nil, err
+	}
+
+	bdb, err := openBoltDB(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	graphDB := newDB(bdb, chanDB.dbPath, opts)
+	graphDB.graphDB = graphDB
+
+	if err := graphDB.syncVersions(graphDBVersions); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	if !opts.ReadOnly {
+		err := moveLegacyGraph(chanDB, graphDB, opts.ResetGraph)
+		if err != nil {
+			bdb.Close()
+			return nil, err
+		}
+
+		if err := removeCompactBackup(path); err != nil {
+			bdb.Close()
+			return nil, err
+		}
+	}
+
+	if err := graphDB.initGraph(opts); err != nil {
+		bdb.Close()
+		return nil, err
+	}
+
+	return graphDB, nil
+}
+
+// createGraphDB creates and initializes a fresh graph database at path.
+func createGraphDB(path string) error {
+	bdb, err := bolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		return err
+	}
+
+	err = bdb.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucket(nodeBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(edgeBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(edgeIndexBucket); err != nil {
+			return err
+		}
+		if _, err := tx.CreateBucket(graphMetaBucket); err != nil {
+			return err
+		}
+
+		meta := &Meta{
+			DbVersionNumber: getLatestDBVersion(graphDBVersions),
+		}
+		return putMeta(meta, tx)
+	})
+	if err != nil {
+		bdb.Close()
+		return err
+	}
+
+	return bdb.Close()
+}
+
+// moveLegacyGraph moves the channel graph stored within chanDB, if any, into
+// graphDB, replacing the graph stored within it. The graph is first copied
+// into graphDB, before it's deleted from chanDB, so it's copied again if the
+// move is interrupted. If discard is true, then the graph stored within chanDB
+// is deleted without being copied.
+func moveLegacyGraph(chanDB, graphDB *DB, discard bool) error {
+	var legacyBuckets [][]byte
+	err := chanDB.View(func(tx *bolt.Tx) error {
+		for _, name := range graphBuckets {
+			if tx.Bucket(name) != nil {
+				legacyBuckets = append(legacyBuckets, name)
+			}
+		}
+		return nil
+	})
+	if err != nil || len(legacyBuckets) == 0 {
+		return err
+	}
+
+	if !discard {
+		log.Infof("Moving channel graph into %v", graphDBName)
+
+		err := chanDB.View(func(srcTx *bolt.Tx) error {
+			return graphDB.Update(func(dstTx *bolt.Tx) error {
+				for _, name := range legacyBuckets {
+					err := dstTx.DeleteBucket(name)
+					if err != nil &&
+						err != bolt.ErrBucketNotFound {
+
+						return err
+					}
+
+					dst, err := dstTx.CreateBucket(name)
+					if err != nil {
+						return err
+					}
+
+					err = copyBucket(dst, srcTx.Bucket(name))
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return chanDB.Update(func(tx *bolt.Tx) error {
+		for _, name := range legacyBuckets {
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// initGraph sets up the batching of writes to the channel graph stored within
+// the database, and loads the graph into memory if the graph cache is
+// enabled.
+func (d *DB) initGraph(opts *Options) error {
+	d.graphBatcher = newGraphBatcher(d, opts.GraphBatchWindow)
+
+	if !opts.GraphCache {
+		return nil
+	}
+
+	return d.View(func(tx *bolt.Tx) error {
+		var err error
+		d.graphCache, err = loadGraphCache(tx)
+		return err
+	})
+}
+
+// WipeGraph deletes the channel graph, leaving all channel state untouched.
+// As the graph is reconstructed from the network, this allows recovering from
+// a graph which has become corrupted or bloated.
+func (d *DB) WipeGraph() error {
+	graphDB := d.graphDB
+
+	graphDB.graphCacheMtx.Lock()
+	defer graphDB.graphCacheMtx.Unlock()
+
+	err := graphDB.Update(func(tx *bolt.Tx) error {
+		for _, name := range graphBuckets {
+			err := tx.DeleteBucket(name)
+			if err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// With the graph deleted, the graph cache is emptied as well.
+	if graphDB.graphCache != nil {
+		graphDB.graphCache = newGraphCache()
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package channeldb
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestGraphDB tests that the channel graph is stored within a database of its
+// own, into which a graph stored within the channel database is moved, and
+// that the graph can be wiped without affecting any channel state.
+func TestGraphDB(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	chanPath := filepath.Join(tempDirName, dbName)
+	graphPath := filepath.Join(tempDirName, graphDBName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+
+	node, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create node: %v", err)
+	}
+	if err := db.ChannelGraph().AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	pub, err := node.PubKey()
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	// Some channel state is recorded as well, which must survive any
+	// changes to the graph.
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	err = db.RecordPeerConnection(pub, addr, time.Unix(1000, 0))
+	if err != nil {
+		t.Fatalf("unable to record connection: %v", err)
+	}
+
+	assertNoGraphBuckets := func(db *DB) {
+		t.Helper()
+
+		err := db.View(func(tx *bolt.Tx) error {
+			for _, name := range graphBuckets {
+				if tx.Bucket(name) != nil {
+					t.Fatalf("graph bucket %s stored within "+
+						"channel database", name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to read db: %v", err)
+		}
+	}
+	assertNode := func(db *DB, expected bool) {
+		t.Helper()
+
+		_, err := db.ChannelGraph().FetchLightningNode(pub)
+		if expected && err != nil {
+			t.Fatalf("unable to fetch node: %v", err)
+		}
+		if !expected && err == nil {
+			t.Fatalf("node still part of the graph")
+		}
+		if _, err := db.FetchPeer(pub); err != nil {
+			t.Fatalf("unable to fetch peer: %v", err)
+		}
+	}
+
+	// The graph should only be stored within the graph database.
+	if !fileExists(graphPath) {
+		t.Fatalf("graph database not created")
+	}
+	assertNoGraphBuckets(db)
+	assertNode(db, true)
+	db.Close()
+
+	// Move the graph back into the channel database, as it was stored
+	// before it was split into its own file.
+	chanBolt, err := bolt.Open(chanPath, dbFilePermission, nil)
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+	graphBolt, err := bolt.Open(graphPath, dbFilePermission, nil)
+	if err != nil {
+		t.Fatalf("unable to open graph db: %v", err)
+	}
+	err = graphBolt.View(func(srcTx *bolt.Tx) error {
+		return chanBolt.Update(func(dstTx *bolt.Tx) error {
+			for _, name := range graphBuckets {
+				src := srcTx.Bucket(name)
+				if src == nil {
+					continue
+				}
+				dst, err := dstTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+				if err := copyBucket(dst, src); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unable to copy graph: %v", err)
+	}
+	chanBolt.Close()
+	graphBolt.Close()
+	if err := os.Remove(graphPath); err != nil {
+		t.Fatalf("unable to remove graph db: %v", err)
+	}
+
+	// A graph stored within the channel database should be read from
+	// there when opened read-only.
+	db, err = Open(tempDirName, OptionReadOnly(true))
+	if err != nil {
+		t.Fatalf("unable to open db read-only: %v", err)
+	}
+	assertNode(db, true)
+	db.Close()
+
+	// Otherwise, it should be moved into a freshly versioned graph
+	// database, leaving no graph buckets behind.
+	db, err = Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	if db.graphDB == db {
+		t.Fatalf("graph not moved into graph database")
+	}
+	assertNoGraphBuckets(db)
+	assertNode(db, true)
+	assertGraphVersion(t, db.graphDB, getLatestDBVersion(graphDBVersions))
+
+	// Wiping the graph should leave all channel state untouched.
+	if err := db.WipeGraph(); err != nil {
+		t.Fatalf("unable to wipe graph: %v", err)
+	}
+	assertNode(db, false)
+
+	if err := db.ChannelGraph().AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+	assertNode(db, true)
+	db.Close()
+
+	// The same should hold when the graph is reset on startup.
+	db, err = Open(tempDirName, OptionResetGraph(true))
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	assertNode(db, false)
+}
+
+// assertGraphVersion asserts that the version recorded within the graph
+// database is the expected one.
+func assertGraphVersion(t *testing.T, graphDB *DB, expected uint32) {
+	t.Helper()
+
+	meta := &Meta{}
+	err := graphDB.View(func(tx *bolt.Tx) error {
+		return fetchMetaVersions(meta, tx, graphDBVersions)
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch graph db meta: %v", err)
+	}
+	if meta.DbVersionNumber != expected {
+		t.Fatalf("expected graph db version %v, got %v", expected,
+			meta.DbVersionNumber)
+	}
+}
+
+// TestGraphDBVersions tests that the graph database is versioned
+// independently of the channel database, and that a graph database written
+// by a newer version is refused.
+func TestGraphDBVersions(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(tempDirName)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	assertGraphVersion(t, db.graphDB, getLatestDBVersion(graphDBVersions))
+
+	// Bump the version of the graph database past the latest one known.
+	newerVersion := getLatestDBVersion(graphDBVersions) + 1
+	err = db.graphDB.Update(func(tx *bolt.Tx) error {
+		return putMeta(&Meta{DbVersionNumber: newerVersion}, tx)
+	})
+	if err != nil {
+		t.Fatalf("unable to update graph db meta: %v", err)
+	}
+	db.Close()
+
+	// The newer graph database must not be opened, neither for writing
+	// nor read-only, while the channel database is still at its latest
+	// version.
+	if _, err := Open(tempDirName); err != ErrDBReversion {
+		t.Fatalf("expected ErrDBReversion, got %v", err)
+	}
+	_, err = Open(tempDirName, OptionReadOnly(true))
+	if err != ErrDBReversion {
+		t.Fatalf("expected ErrDBReversion for read-only db, got %v",
+			err)
+	}
+
+	chanDB, err := bolt.Open(
+		filepath.Join(tempDirName, dbName), dbFilePermission, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to open channel db: %v", err)
+	}
+	defer chanDB.Close()
+
+	err = chanDB.View(func(tx *bolt.Tx) error {
+		meta := &Meta{}
+		if err := fetchMeta(meta, tx); err != nil {
+			return err
+		}
+		if meta.DbVersionNumber != getLatestDBVersion(dbVersions) {
+			t.Fatalf("channel db version changed to %v",
+				meta.DbVersionNumber)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to fetch channel db meta: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/color"
+	"io"
+	"net"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// maxNodeHistory is the maximum number of records kept within the
+// announcement history of a single node. Once exceeded, the oldest records
+// are removed.
+const maxNodeHistory = 100
+
+var (
+	// nodeHistoryBucket is the name of the sub-bucket within the
+	// nodeBucket which houses the announcement history of each node.
+	// Within it, each node has a sub-bucket keyed by its public key,
+	// mapping the timestamp of each announcement which changed the node's
+	// alias, color or addresses to those attributes.
+	//
+	// maps: pubKey -> timestamp -> alias || color || addresses
+	nodeHistoryBucket = []byte("node-history")
+)
+
+// NodeAnnouncementRecord is a record of the attributes a node advertised
+// within one of its past announcements.
+type NodeAnnouncementRecord struct {
+	// Timestamp is the time the node announced the attributes.
+	Timestamp time.Time
+
+	// Alias is the alias announced by the node.
+	Alias string
+
+	// Color is the color announced by the node.
+	Color color.RGBA
+
+	// Addresses is the set of addresses announced by the node.
+	Addresses []net.Addr
+}
+
+// FetchNodeHistory returns the announcement history of the node with the
+// passed public key, ordered from oldest to newest. A record is only kept for
+// announcements which changed the alias, color or addresses of the node, so
+// the last record reflects the node's current attributes.
+func (c *ChannelGraph) FetchNodeHistory(
+	pub *btcec.PublicKey) ([]*NodeAnnouncementRecord, error) {
+
+	var records []*NodeAnnouncementRecord
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+		history := nodes.Bucket(nodeHistoryBucket)
+		if history == nil {
+			return nil
+		}
+		nodeHistory := history.Bucket(pub.SerializeCompressed())
+		if nodeHistory == nil {
+			return nil
+		}
+
+		return nodeHistory.ForEach(func(k, v []byte) error {
+			record, err := deserializeNodeAnnouncementRecord(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			record.Timestamp = time.Unix(
+				int64(byteOrder.Uint64(k)), 0,
+			)
+
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// putNodeHistory records the attributes announced by the node with the passed
+// public key within its announcement history, unless they're unchanged since
+// its latest record.
+func putNodeHistory(nodeBucket *bolt.Bucket, nodePub []byte,
+	node *LightningNode) error {
+
+	history, err := nodeBucket.CreateBucketIfNotExists(nodeHistoryBucket)
+	if err != nil {
+		return err
+	}
+	nodeHistory, err := history.CreateBucketIfNotExists(nodePub)
+	if err != nil {
+		return err
+	}
+
+	record := &NodeAnnouncementRecord{
+		Alias:     node.Alias,
+		Color:     node.Color,
+		Addresses: node.Addresses,
+	}
+
+	var b bytes.Buffer
+	if err := serializeNodeAnnouncementRecord(&b, record); err != nil {
+		return err
+	}
+
+	// As the timestamp is stored within the key, we can compare the
+	// serialized attributes with those of the latest record directly.
+	// Announcements older than the latest record are ignored.
+	var k [8]byte
+	byteOrder.PutUint64(k[:], uint64(node.LastUpdate.Unix()))
+
+	cursor := nodeHistory.Cursor()
+	lastKey, lastRecord := cursor.Last()
+	if lastKey != nil {
+		if bytes.Equal(lastRecord, b.Bytes()) {
+			return nil
+		}
+		if bytes.Compare(k[:], lastKey) < 0 {
+			return nil
+		}
+	}
+
+	if err := nodeHistory.Put(k[:], b.Bytes()); err != nil {
+		return err
+	}
+
+	// Finally, we'll trim the history down to its maximum size, removing
+	// the oldest records first.
+	var numRecords int
+	err = nodeHistory.ForEach(func(_, _ []byte) error {
+		numRecords++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for ; numRecords > maxNodeHistory; numRecords-- {
+		oldest, _ := nodeHistory.Cursor().First()
+		if err := nodeHistory.Delete(oldest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delNodeHistory removes the announcement history of the node with the
+// passed public key.
+func delNodeHistory(nodeBucket *bolt.Bucket, nodePub []byte) error {
+	history := nodeBucket.Bucket(nodeHistoryBucket)
+	if history == nil {
+		return nil
+	}
+
+	err := history.DeleteBucket(nodePub)
+	if err != nil && err != bolt.ErrBucketNotFound {
+		return err
+	}
+
+	return nil
+}
+
+func serializeNodeAnnouncementRecord(w io.Writer,
+	r *NodeAnnouncementRecord) error {
+
+	if err := wire.WriteVarString(w, 0, r.Alias); err != nil {
+		return err
+	}
+
+	rgb := []byte{r.Color.R, r.Color.G, r.Color.B}
+	if _, err := w.Write(rgb); err != nil {
+		return err
+	}
+
+	numAddresses := uint16(len(r.Addresses))
+	if err := binary.Write(w, byteOrder, numAddresses); err != nil {
+		return err
+	}
+	for _, address := range r.Addresses {
+		if err := serializeAddr(w, address); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializeNodeAnnouncementRecord(
+	r io.Reader) (*NodeAnnouncementRecord, error) {
+
+	record := &NodeAnnouncementRecord{}
+
+	alias, err := wire.ReadVarBytes(
+		r, 0, lnwire.MaxMessagePayload, "alias",
+	)
+	if err != nil {
+		return nil, err
+	}
+	record.Alias = string(alias)
+
+	var rgb [3]byte
+	if _, err := io.ReadFull(r, rgb[:]); err != nil {
+		return nil, err
+	}
+	record.Color = color.RGBA{R: rgb[0], G: rgb[1], B: rgb[2]}
+
+	var numAddresses uint16
+	if err := binary.Read(r, byteOrder, &numAddresses); err != nil {
+		return nil, err
+	}
+	for i := uint16(0); i < numAddresses; i++ {
+		address, err := deserializeAddr(r)
+		if err != nil {
+			return nil, err
+		}
+		record.Addresses = append(record.Addresses, address)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,103 @@
+package channeldb
+
+import (
+	"image/color"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestNodeHistory tests that the announcement history of a node records each
+// announcement which changed its alias, color or addresses, while skipping
+// announcements which changed none of them, or are older than the latest
+// record.
+func TestNodeHistory(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	node, err := createTestVertex(db)
+	if err != nil {
+		t.Fatalf("unable to create test node: %v", err)
+	}
+	pub, err := node.PubKey()
+	if err != nil {
+		t.Fatalf("unable to parse pubkey: %v", err)
+	}
+
+	newAddr := &net.TCPAddr{IP: net.IP{10, 0, 0, 2}, Port: 9735}
+	announcements := []struct {
+		timestamp int64
+		alias     string
+		color     color.RGBA
+		addresses []net.Addr
+		recorded  bool
+	}{
+		{1000, "alice", color.RGBA{1, 2, 3, 0}, testAddrs, true},
+
+		// A re-announcement of the same attributes isn't recorded.
+		{2000, "alice", color.RGBA{1, 2, 3, 0}, testAddrs, false},
+
+		// Changing the addresses, color or alias is recorded.
+		{3000, "alice", color.RGBA{1, 2, 3, 0}, []net.Addr{newAddr},
+			true},
+		{4000, "alice", color.RGBA{4, 5, 6, 0}, []net.Addr{newAddr},
+			true},
+		{5000, "bob", color.RGBA{4, 5, 6, 0}, []net.Addr{newAddr},
+			true},
+
+		// An announcement older than the latest record isn't.
+		{4500, "carol", color.RGBA{4, 5, 6, 0}, []net.Addr{newAddr},
+			false},
+	}
+
+	var expected []*NodeAnnouncementRecord
+	for _, ann := range announcements {
+		node.LastUpdate = time.Unix(ann.timestamp, 0)
+		node.Alias = ann.alias
+		node.Color = ann.color
+		node.Addresses = ann.addresses
+		if err := graph.AddLightningNode(node); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+
+		if ann.recorded {
+			expected = append(expected, &NodeAnnouncementRecord{
+				Timestamp: node.LastUpdate,
+				Alias:     ann.alias,
+				Color:     ann.color,
+				Addresses: ann.addresses,
+			})
+		}
+	}
+
+	history, err := graph.FetchNodeHistory(pub)
+	if err != nil {
+		t.Fatalf("unable to fetch node history: %v", err)
+	}
+	if !reflect.DeepEqual(history, expected) {
+		t.Fatalf("history mismatch: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(history))
+	}
+
+	// Once the node is deleted, so is its history.
+	if err := graph.DeleteLightningNode(pub); err != nil {
+		t.Fatalf("unable to delete node: %v", err)
+	}
+	history, err = graph.FetchNodeHistory(pub)
+	if err != nil {
+		t.Fatalf("unable to fetch node history: %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("expected empty history, got %v", len(history))
+	}
+}
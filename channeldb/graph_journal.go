@@ -0,0 +1,112 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/coreos/bbolt"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// spendJournalBucket is a bucket within the graphMetaBucket that
+	// records the channels closed by each block used to prune the graph.
+	// Each key is the big endian height of the block followed by the
+	// funding outpoint of a channel it spent, and maps to the channel's
+	// ID. As the keys are prefixed by the block height, the channels
+	// closed by a block can be found with a single range scan, and the
+	// entries of disconnected blocks can be removed along with their
+	// prune log entries.
+	//
+	// maps: blockHeight || outPoint -> chanID
+	spendJournalBucket = []byte("spend-journal")
+)
+
+// FetchSpendJournal returns the channels which were closed by the block at
+// the passed height, mapping the funding outpoint of each channel to its
+// channel ID. If the block hasn't been used to prune the graph, or didn't
+// close any channels, then an empty map is returned.
+func (c *ChannelGraph) FetchSpendJournal(
+	blockHeight uint32) (map[wire.OutPoint]uint64, error) {
+
+	spentChans := make(map[wire.OutPoint]uint64)
+	err := c.db.View(func(tx *bolt.Tx) error {
+		metaBucket := tx.Bucket(graphMetaBucket)
+		if metaBucket == nil {
+			return nil
+		}
+		journal := metaBucket.Bucket(spendJournalBucket)
+		if journal == nil {
+			return nil
+		}
+
+		var prefix [4]byte
+		byteOrder.PutUint32(prefix[:], blockHeight)
+
+		cursor := journal.Cursor()
+		for k, v := cursor.Seek(prefix[:]); k != nil &&
+			bytes.HasPrefix(k, prefix[:]); k, v = cursor.Next() {
+
+			var chanPoint wire.OutPoint
+			err := readOutpoint(bytes.NewReader(k[4:]), &chanPoint)
+			if err != nil {
+				return err
+			}
+
+			spentChans[chanPoint] = byteOrder.Uint64(v)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return spentChans, nil
+}
+
+// putSpendJournal records within the spend journal that the channel with the
+// passed serialized funding outpoint and channel ID was closed by the block
+// at the passed height.
+func putSpendJournal(metaBucket *bolt.Bucket, blockHeight uint32,
+	chanPoint, chanID []byte) error {
+
+	journal, err := metaBucket.CreateBucketIfNotExists(spendJournalBucket)
+	if err != nil {
+		return err
+	}
+
+	var k bytes.Buffer
+	var heightBytes [4]byte
+	byteOrder.PutUint32(heightBytes[:], blockHeight)
+	k.Write(heightBytes[:])
+	k.Write(chanPoint)
+
+	return journal.Put(k.Bytes(), chanID)
+}
+
+// delSpendJournal removes the entries of the spend journal for all blocks at
+// or above the passed height.
+func delSpendJournal(metaBucket *bolt.Bucket, blockHeight uint32) error {
+	journal := metaBucket.Bucket(spendJournalBucket)
+	if journal == nil {
+		return nil
+	}
+
+	var keyStart [4]byte
+	byteOrder.PutUint32(keyStart[:], blockHeight)
+
+	// We'll collect the keys to delete first, as deleting while iterating
+	// may cause the cursor to skip entries.
+	var keys [][]byte
+	cursor := journal.Cursor()
+	for k, _ := cursor.Seek(keyStart[:]); k != nil; k, _ = cursor.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	for _, k := range keys {
+		if err := journal.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
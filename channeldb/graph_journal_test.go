@@ -0,0 +1,94 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestSpendJournal tests that the spend journal records the channels closed
+// by each block used to prune the graph, and that the entries of
+// disconnected blocks are removed.
+func TestSpendJournal(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	graph := db.ChannelGraph()
+
+	const numNodes = 3
+	nodes := make([]*LightningNode, numNodes)
+	for i := range nodes {
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(nodes[i]); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	edge1 := addTestChannel(t, db, 1, nodes[0], nodes[1], true, true)
+	edge2 := addTestChannel(t, db, 2, nodes[1], nodes[2], true, true)
+	edge3 := addTestChannel(t, db, 3, nodes[0], nodes[2], true, true)
+
+	assertJournal := func(height uint32, edges ...*ChannelEdgeInfo) {
+		t.Helper()
+
+		expected := make(map[wire.OutPoint]uint64)
+		for _, edge := range edges {
+			expected[edge.ChannelPoint] = edge.ChannelID
+		}
+
+		spentChans, err := graph.FetchSpendJournal(height)
+		if err != nil {
+			t.Fatalf("unable to fetch spend journal: %v", err)
+		}
+		if !reflect.DeepEqual(spentChans, expected) {
+			t.Fatalf("spend journal mismatch at height %v: "+
+				"expected %v, got %v", height, expected,
+				spentChans)
+		}
+	}
+
+	// Before any block has been used to prune the graph, the journal
+	// should be empty.
+	assertJournal(100)
+
+	// Prune the first two channels at height 100, along with an outpoint
+	// which isn't a channel, and the last one at height 101.
+	nonChannel := &wire.OutPoint{Index: 9}
+	_, err = graph.PruneGraph(
+		[]*wire.OutPoint{&edge1.ChannelPoint, nonChannel,
+			&edge2.ChannelPoint},
+		&chainhash.Hash{1}, 100,
+	)
+	if err != nil {
+		t.Fatalf("unable to prune graph: %v", err)
+	}
+	_, err = graph.PruneGraph(
+		[]*wire.OutPoint{&edge3.ChannelPoint}, &chainhash.Hash{2}, 101,
+	)
+	if err != nil {
+		t.Fatalf("unable to prune graph: %v", err)
+	}
+
+	assertJournal(99)
+	assertJournal(100, edge1, edge2)
+	assertJournal(101, edge3)
+
+	// Disconnecting the block at height 101 should only remove its own
+	// entries from the journal.
+	if _, err := graph.DisconnectBlockAtHeight(101); err != nil {
+		t.Fatalf("unable to disconnect block: %v", err)
+	}
+	assertJournal(100, edge1, edge2)
+	assertJournal(101)
+	assertPruneTip(t, graph, &chainhash.Hash{1}, 100)
+}
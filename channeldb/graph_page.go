@@ -0,0 +1,127 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/coreos/bbolt"
+)
+
+// ForEachNodeAfter iterates through the nodes within the graph in the order of
+// their public keys, executing the passed callback with each node whose public
+// key sorts after the passed one. If the passed public key is nil, then all
+// nodes are visited. This allows the graph to be traversed in pages, each
+// resuming after the last node of the previous one, without visiting the
+// nodes which precede it. If the callback returns ErrStopGraphIteration, the
+// iteration stops early and nil is returned. Any other error returned by the
+// callback aborts the iteration and is passed through to the caller.
+func (c *ChannelGraph) ForEachNodeAfter(after []byte,
+	cb func(*LightningNode) error) error {
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+
+		cursor := nodes.Cursor()
+		pubKey, nodeBytes := cursor.First()
+		if after != nil {
+			pubKey, nodeBytes = cursor.Seek(after)
+			if bytes.Equal(pubKey, after) {
+				pubKey, nodeBytes = cursor.Next()
+			}
+		}
+
+		for ; pubKey != nil; pubKey, nodeBytes = cursor.Next() {
+			// Besides the nodes themselves, the bucket holds the
+			// source key and the sub-buckets of the node indexes,
+			// all of which are skipped.
+			if nodeBytes == nil || len(pubKey) != 33 {
+				continue
+			}
+
+			nodeReader := bytes.NewReader(nodeBytes)
+			node, err := deserializeLightningNode(nodeReader)
+			if err != nil {
+				return err
+			}
+			node.db = c.db
+
+			if err := cb(&node); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrStopGraphIteration {
+		return err
+	}
+
+	return nil
+}
+
+// ForEachChannelAfter iterates through the channels within the graph in the
+// order of their channel IDs, executing the passed callback with each channel
+// whose ID is greater than the passed one, along with its routing policies.
+// As with ForEachChannel, a policy which wasn't advertised is passed as nil.
+// As no channel has an ID of zero, passing zero visits all channels. If the
+// callback returns ErrStopGraphIteration, the iteration stops early and nil is
+// returned. Any other error returned by the callback aborts the iteration and
+// is passed through to the caller.
+func (c *ChannelGraph) ForEachChannelAfter(afterChanID uint64,
+	cb func(*ChannelEdgeInfo, *ChannelEdgePolicy,
+		*ChannelEdgePolicy) error) error {
+
+	err := c.db.View(func(tx *bolt.Tx) error {
+		nodes := tx.Bucket(nodeBucket)
+		if nodes == nil {
+			return ErrGraphNotFound
+		}
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		edgeIndex := edges.Bucket(edgeIndexBucket)
+		if edgeIndex == nil {
+			return nil
+		}
+
+		// As the channels are keyed by their big endian channel ID,
+		// seeking past the passed ID skips all preceding channels.
+		var after [8]byte
+		byteOrder.PutUint64(after[:], afterChanID)
+
+		cursor := edgeIndex.Cursor()
+		chanID, edgeInfoBytes := cursor.Seek(after[:])
+		if bytes.Equal(chanID, after[:]) {
+			chanID, edgeInfoBytes = cursor.Next()
+		}
+
+		for ; chanID != nil; chanID, edgeInfoBytes = cursor.Next() {
+			infoReader := bytes.NewReader(edgeInfoBytes)
+			edgeInfo, err := deserializeChanEdgeInfo(infoReader)
+			if err != nil {
+				return err
+			}
+
+			edge1, edge2, err := c.fetchChanEdgePolicies(
+				edges, nodes, chanID, edgeInfoBytes,
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := cb(&edgeInfo, edge1, edge2); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil && err != ErrStopGraphIteration {
+		return err
+	}
+
+	return nil
+}
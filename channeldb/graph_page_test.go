@@ -0,0 +1,132 @@
+package channeldb
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+)
+
+// TestGraphPages tests that the nodes and channels of the graph can be
+// traversed in pages, each resuming after the last node or channel of the
+// previous one, and that the traversal can be stopped early.
+func TestGraphPages(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+	graph := db.ChannelGraph()
+
+	nodes := make([]*LightningNode, 5)
+	for i := range nodes {
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(nodes[i]); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return bytes.Compare(
+			nodes[i].PubKeyBytes[:], nodes[j].PubKeyBytes[:],
+		) < 0
+	})
+
+	// The source node is stored under its own key within the nodes
+	// bucket, which shouldn't be mistaken for a node.
+	if err := graph.SetSourceNode(nodes[0]); err != nil {
+		t.Fatalf("unable to set source node: %v", err)
+	}
+
+	// Only the channels with an even ID have both policies advertised.
+	for i := 1; i < len(nodes); i++ {
+		addTestChannel(
+			t, db, uint64(i), nodes[0], nodes[i], true, i%2 == 0,
+		)
+	}
+
+	// Traverse the nodes in pages of two, each resuming after the last
+	// node of the previous page.
+	var (
+		traversedNodes [][33]byte
+		after          []byte
+	)
+	for {
+		var page [][33]byte
+		err := graph.ForEachNodeAfter(after, func(n *LightningNode) error {
+			page = append(page, n.PubKeyBytes)
+			if len(page) == 2 {
+				return ErrStopGraphIteration
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to traverse nodes: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		traversedNodes = append(traversedNodes, page...)
+		last := page[len(page)-1]
+		after = last[:]
+	}
+
+	if len(traversedNodes) != len(nodes) {
+		t.Fatalf("expected %v nodes, got %v", len(nodes),
+			len(traversedNodes))
+	}
+	for i, node := range nodes {
+		if traversedNodes[i] != node.PubKeyBytes {
+			t.Fatalf("expected node %v to be %x, got %x", i,
+				node.PubKeyBytes[:], traversedNodes[i][:])
+		}
+	}
+
+	// Similarly, traverse the channels in pages of two. Each channel
+	// should be passed along with the policies which were advertised for
+	// it.
+	var (
+		traversedChans []uint64
+		afterChanID    uint64
+	)
+	for {
+		var page []uint64
+		cb := func(e *ChannelEdgeInfo, p1, p2 *ChannelEdgePolicy) error {
+			withPolicy2 := e.ChannelID%2 == 0
+			if p1 == nil || (p2 != nil) != withPolicy2 {
+				t.Fatalf("unexpected policies for channel %v",
+					e.ChannelID)
+			}
+
+			page = append(page, e.ChannelID)
+			if len(page) == 2 {
+				return ErrStopGraphIteration
+			}
+			return nil
+		}
+		if err := graph.ForEachChannelAfter(afterChanID, cb); err != nil {
+			t.Fatalf("unable to traverse channels: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		traversedChans = append(traversedChans, page...)
+		afterChanID = page[len(page)-1]
+	}
+
+	if len(traversedChans) != len(nodes)-1 {
+		t.Fatalf("expected %v channels, got %v", len(nodes)-1,
+			len(traversedChans))
+	}
+	for i, chanID := range traversedChans {
+		if chanID != uint64(i+1) {
+			t.Fatalf("expected channel %v to be %v, got %v", i,
+				i+1, chanID)
+		}
+	}
+}
@@ -14,7 +14,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/btcec"
@@ -58,7 +57,7 @@ func createTestVertex(db *DB) (*LightningNode, error) {
 		Alias:                "kek" + string(pub[:]),
 		Features:             testFeatures,
 		Addresses:            testAddrs,
-		db:                   db,
+		db:                   db.graphDB,
 	}
 	copy(n.PubKeyBytes[:], priv.PubKey().SerializeCompressed())
 
@@ -87,7 +86,7 @@ func TestNodeInsertionAndDeletion(t *testing.T) {
 		Alias:                "kek",
 		Features:             testFeatures,
 		Addresses:            testAddrs,
-		db:                   db,
+		db:                   db.graphDB,
 	}
 	copy(node.PubKeyBytes[:], testPub.SerializeCompressed())
 
@@ -172,7 +171,7 @@ func TestPartialNode(t *testing.T) {
 	node = &LightningNode{
 		HaveNodeAnnouncement: false,
 		LastUpdate:           time.Unix(0, 0),
-		db:                   db,
+		db:                   db.graphDB,
 	}
 	copy(node.PubKeyBytes[:], testPub.SerializeCompressed())
 
@@ -678,19 +677,20 @@ func TestEdgeInfoUpdates(t *testing.T) {
 		FeeBaseMSat:               4352345,
 		FeeProportionalMillionths: 3452352,
 		Node: secondNode,
-		db:   db,
+		db:   db.graphDB,
 	}
 	edge2 := &ChannelEdgePolicy{
 		SigBytes:                  testSig.Serialize(),
 		ChannelID:                 chanID,
 		LastUpdate:                time.Unix(124234, 0),
-		Flags:                     1,
+		Flags:                     1 | lnwire.ChanUpdateOptionMaxHtlc,
 		TimeLockDelta:             99,
 		MinHTLC:                   2342135,
 		FeeBaseMSat:               4352345,
 		FeeProportionalMillionths: 90392423,
+		MaxHTLC:                   13928598,
 		Node: firstNode,
-		db:   db,
+		db:   db.graphDB,
 	}
 
 	// Next, insert both nodes into the database, they should both be
@@ -761,7 +761,7 @@ func randEdgePolicy(chanID uint64, op wire.OutPoint, db *DB) *ChannelEdgePolicy
 		MinHTLC:                   lnwire.MilliSatoshi(prand.Int63()),
 		FeeBaseMSat:               lnwire.MilliSatoshi(prand.Int63()),
 		FeeProportionalMillionths: lnwire.MilliSatoshi(prand.Int63()),
-		db: db,
+		db: db.graphDB,
 	}
 }
 
@@ -802,7 +802,7 @@ func TestGraphTraversal(t *testing.T) {
 
 	// Iterate over each node as returned by the graph, if all nodes are
 	// reached, then the map created above should be empty.
-	err = graph.ForEachNode(nil, func(_ *bolt.Tx, node *LightningNode) error {
+	err = graph.ForEachNode(nil, func(_ ReadTx, node *LightningNode) error {
 		delete(nodeIndex, node.Alias)
 		return nil
 	})
@@ -898,7 +898,7 @@ func TestGraphTraversal(t *testing.T) {
 	// Finally, we want to test the ability to iterate over all the
 	// outgoing channels for a particular node.
 	numNodeChans := 0
-	err = firstNode.ForEachChannel(nil, func(_ *bolt.Tx, _ *ChannelEdgeInfo,
+	err = firstNode.ForEachChannel(nil, func(_ ReadTx, _ *ChannelEdgeInfo,
 		outEdge, inEdge *ChannelEdgePolicy) error {
 
 		// Each each should indicate that it's outgoing (pointed
@@ -1232,6 +1232,10 @@ func compareEdgePolicies(a, b *ChannelEdgePolicy) error {
 			"expected %v, got %v", a.FeeProportionalMillionths,
 			b.FeeProportionalMillionths)
 	}
+	if a.MaxHTLC != b.MaxHTLC {
+		return fmt.Errorf("MaxHTLC doesn't match: expected %v, "+
+			"got %v", a.MaxHTLC, b.MaxHTLC)
+	}
 	if err := compareNodes(a.Node, b.Node); err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+package channeldb
+
+import (
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// zombieIndexBucket is the name of the sub-bucket within the
+	// edgeBucket which indexes all zombie channels. A channel is a zombie
+	// if neither of its policies has been updated for a prolonged period
+	// of time, which likely means it can no longer route payments. Zombie
+	// channels are kept within the graph, but skipped during path finding
+	// and not relayed to other nodes. Each key is the big endian channel
+	// ID of a zombie channel, and maps to the public keys of both of its
+	// nodes.
+	zombieIndexBucket = []byte("zombie-index")
+)
+
+// MarkEdgeZombie marks the channel with the passed channel ID as a zombie. If
+// the channel doesn't exist within the graph, then ErrEdgeNotFound is
+// returned.
+func (c *ChannelGraph) MarkEdgeZombie(chanID uint64) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		edges, err := tx.CreateBucketIfNotExists(edgeBucket)
+		if err != nil {
+			return err
+		}
+		edgeIndex, err := edges.CreateBucketIfNotExists(edgeIndexBucket)
+		if err != nil {
+			return err
+		}
+		zombieIndex, err := edges.CreateBucketIfNotExists(
+			zombieIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		var k [8]byte
+		byteOrder.PutUint64(k[:], chanID)
+
+		// The edge info starts with the public keys of both nodes of
+		// the channel.
+		edgeInfo := edgeIndex.Get(k[:])
+		if edgeInfo == nil {
+			return ErrEdgeNotFound
+		}
+
+		return zombieIndex.Put(k[:], edgeInfo[:33+33])
+	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.markZombie(chanID)
+
+	return nil
+}
+
+// MarkEdgeLive removes the channel with the passed channel ID from the zombie
+// index, resurrecting it. If the channel isn't a zombie, this is a no-op.
+func (c *ChannelGraph) MarkEdgeLive(chanID uint64) error {
+	c.db.graphCacheMtx.Lock()
+	defer c.db.graphCacheMtx.Unlock()
+
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(edgeBucket)
+		if edges == nil {
+			return nil
+		}
+		zombieIndex := edges.Bucket(zombieIndexBucket)
+		if zombieIndex == nil {
+			return nil
+		}
+
+		var k [8]byte
+		byteOrder.PutUint64(k[:], chanID)
+
+		return zombieIndex.Delete(k[:])
+	})
+	if err != nil {
+		return err
+	}
+
+	c.db.graphCache.markLive(chanID)
+
+	return nil
+}
+
+// IsZombieEdge returns true if the channel with the passed channel ID is
+// marked as a zombie.
+func (c *ChannelGraph) IsZombieEdge(chanID uint64) (bool, error) {
+	if c.db.graphCache != nil {
+		c.db.graphCacheMtx.RLock()
+		defer c.db.graphCacheMtx.RUnlock()
+
+		_, ok := c.db.graphCache.zombies[chanID]
+		return ok, nil
+	}
+
+	var isZombie bool
+	err := c.db.ViewTx(func(tx ReadTx) error {
+		isZombie = isZombieEdge(tx, chanID)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return isZombie, nil
+}
+
+// FetchZombieEdges returns the set of channel IDs of all zombie channels.
+func (c *ChannelGraph) FetchZombieEdges() (map[uint64]struct{}, error) {
+	zombies := make(map[uint64]struct{})
+	err := c.db.View(func(tx *bolt.Tx) error {
+		return forEachZombieEdge(tx, func(chanID uint64) {
+			zombies[chanID] = struct{}{}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return zombies, nil
+}
+
+// isZombieEdge returns true if the channel with the passed channel ID is
+// indexed as a zombie within the database.
+func isZombieEdge(tx ReadTx, chanID uint64) bool {
+	edges := tx.ReadBucket(edgeBucket)
+	if edges == nil {
+		return false
+	}
+	zombieIndex := edges.NestedReadBucket(zombieIndexBucket)
+	if zombieIndex == nil {
+		return false
+	}
+
+	var k [8]byte
+	byteOrder.PutUint64(k[:], chanID)
+
+	return zombieIndex.Get(k[:]) != nil
+}
+
+// forEachZombieEdge calls cb with the channel ID of each zombie channel
+// indexed within the database.
+func forEachZombieEdge(tx *bolt.Tx, cb func(chanID uint64)) error {
+	edges := tx.Bucket(edgeBucket)
+	if edges == nil {
+		return nil
+	}
+	zombieIndex := edges.Bucket(zombieIndexBucket)
+	if zombieIndex == nil {
+		return nil
+	}
+
+	return zombieIndex.ForEach(func(k, _ []byte) error {
+		cb(byteOrder.Uint64(k))
+		return nil
+	})
+}
@@ -0,0 +1,150 @@
+package channeldb
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/roasbeef/btcd/wire"
+)
+
+// assertNodeChannels asserts that traversing the channels of the node yields
+// exactly the channels with the passed IDs.
+func assertNodeChannels(t *testing.T, graph *ChannelGraph,
+	node *LightningNode, chanIDs ...uint64) {
+
+	t.Helper()
+
+	var traversed []uint64
+	err := graph.ForEachNodeChannel(nil, node.PubKeyBytes,
+		func(_ ReadTx, e *ChannelEdgeInfo,
+			_, _ *ChannelEdgePolicy) error {
+
+			traversed = append(traversed, e.ChannelID)
+			return nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unable to traverse channels: %v", err)
+	}
+
+	if len(traversed) != len(chanIDs) {
+		t.Fatalf("expected channels %v, got %v", chanIDs, traversed)
+	}
+	for i := range chanIDs {
+		if traversed[i] != chanIDs[i] {
+			t.Fatalf("expected channels %v, got %v", chanIDs,
+				traversed)
+		}
+	}
+}
+
+// assertZombie asserts whether the channel is marked as a zombie.
+func assertZombie(t *testing.T, graph *ChannelGraph, chanID uint64,
+	expected bool) {
+
+	t.Helper()
+
+	isZombie, err := graph.IsZombieEdge(chanID)
+	if err != nil {
+		t.Fatalf("unable to check for zombie: %v", err)
+	}
+	if isZombie != expected {
+		t.Fatalf("expected zombie=%v for chan_id=%v, got %v",
+			expected, chanID, isZombie)
+	}
+
+	zombies, err := graph.FetchZombieEdges()
+	if err != nil {
+		t.Fatalf("unable to fetch zombies: %v", err)
+	}
+	if _, ok := zombies[chanID]; ok != expected {
+		t.Fatalf("expected zombie=%v for chan_id=%v in index",
+			expected, chanID)
+	}
+}
+
+// TestZombieEdges tests that channels can be marked as zombies and brought
+// back to life, that zombie channels are skipped when traversing the channels
+// of a node, and that deleting a channel removes it from the zombie index.
+func TestZombieEdges(t *testing.T) {
+	t.Parallel()
+
+	for _, withCache := range []bool{true, false} {
+		db, cleanUp, err := makeTestDB()
+		if err != nil {
+			t.Fatalf("unable to make test database: %v", err)
+		}
+		if !withCache {
+			db.graphDB.graphCache = nil
+		}
+
+		testZombieEdges(t, db, withCache)
+		cleanUp()
+	}
+}
+
+func testZombieEdges(t *testing.T, db *DB, withCache bool) {
+	graph := db.ChannelGraph()
+
+	nodes := make([]*LightningNode, 3)
+	for i := range nodes {
+		var err error
+		nodes[i], err = createTestVertex(db)
+		if err != nil {
+			t.Fatalf("unable to create node: %v", err)
+		}
+		if err := graph.AddLightningNode(nodes[i]); err != nil {
+			t.Fatalf("unable to add node: %v", err)
+		}
+	}
+
+	// Marking an unknown channel as a zombie should fail.
+	if err := graph.MarkEdgeZombie(1); err != ErrEdgeNotFound {
+		t.Fatalf("expected ErrEdgeNotFound, got %v", err)
+	}
+
+	addTestChannel(t, db, 1, nodes[0], nodes[1], true, true)
+	addTestChannel(t, db, 2, nodes[0], nodes[2], true, true)
+	assertNodeChannels(t, graph, nodes[0], 1, 2)
+	assertZombie(t, graph, 1, false)
+
+	// Once marked as a zombie, the channel should no longer be traversed
+	// from either of its nodes.
+	if err := graph.MarkEdgeZombie(1); err != nil {
+		t.Fatalf("unable to mark zombie: %v", err)
+	}
+	assertZombie(t, graph, 1, true)
+	assertNodeChannels(t, graph, nodes[0], 2)
+	assertNodeChannels(t, graph, nodes[1])
+	if withCache {
+		assertGraphCacheConsistent(t, db, nodes)
+	}
+
+	// Resurrecting it should make it visible again, while resurrecting a
+	// live channel is a no-op.
+	if err := graph.MarkEdgeLive(1); err != nil {
+		t.Fatalf("unable to mark live: %v", err)
+	}
+	if err := graph.MarkEdgeLive(2); err != nil {
+		t.Fatalf("unable to mark live: %v", err)
+	}
+	assertZombie(t, graph, 1, false)
+	assertNodeChannels(t, graph, nodes[0], 1, 2)
+	if withCache {
+		assertGraphCacheConsistent(t, db, nodes)
+	}
+
+	// Deleting a zombie channel should remove it from the index.
+	if err := graph.MarkEdgeZombie(2); err != nil {
+		t.Fatalf("unable to mark zombie: %v", err)
+	}
+	chanPoint := wire.OutPoint{Hash: sha256.Sum256([]byte{2})}
+	if err := graph.DeleteChannelEdge(&chanPoint); err != nil {
+		t.Fatalf("unable to delete edge: %v", err)
+	}
+	assertZombie(t, graph, 2, false)
+	assertNodeChannels(t, graph, nodes[0], 1)
+	if withCache {
+		assertGraphCacheConsistent(t, db, nodes)
+	}
+}
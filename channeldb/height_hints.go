@@ -0,0 +1,175 @@
+package channeldb
+
+import (
+	"bytes"
+
+	"github.com/coreos/bbolt"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+var (
+	// spendHintBucket maps outpoints to the height from which a rescan for
+	// their spend must start, as every block below it is known not to
+	// spend them.
+	//
+	// maps: outpoint -> height
+	spendHintBucket = []byte("spend-hints")
+
+	// confirmHintBucket maps txids to the height from which a rescan for
+	// their confirmation must start, as every block below it is known not
+	// to include them.
+	//
+	// maps: txid -> height
+	confirmHintBucket = []byte("confirm-hints")
+)
+
+// CommitSpendHint records height as the height from which a rescan for the
+// spend of each of the passed outpoints must start.
+func (d *DB) CommitSpendHint(height uint32, ops ...wire.OutPoint) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		hints, err := tx.CreateBucketIfNotExists(spendHintBucket)
+		if err != nil {
+			return err
+		}
+
+		for i := range ops {
+			var key bytes.Buffer
+			if err := writeOutpoint(&key, &ops[i]); err != nil {
+				return err
+			}
+			err := putHeightHint(hints, key.Bytes(), height)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// QuerySpendHint returns the height from which a rescan for the spend of the
+// passed outpoint must start, or zero if no hint is known for it.
+func (d *DB) QuerySpendHint(op wire.OutPoint) (uint32, error) {
+	var key bytes.Buffer
+	if err := writeOutpoint(&key, &op); err != nil {
+		return 0, err
+	}
+
+	return d.fetchHeightHint(spendHintBucket, key.Bytes())
+}
+
+// PurgeSpendHint removes the spend hints of the passed outpoints.
+func (d *DB) PurgeSpendHint(ops ...wire.OutPoint) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		hints := tx.Bucket(spendHintBucket)
+		if hints == nil {
+			return nil
+		}
+
+		for i := range ops {
+			var key bytes.Buffer
+			if err := writeOutpoint(&key, &ops[i]); err != nil {
+				return err
+			}
+			if err := hints.Delete(key.Bytes()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// CommitConfirmHint records height as the height from which a rescan for the
+// confirmation of each of the passed transactions must start.
+func (d *DB) CommitConfirmHint(height uint32, txids ...chainhash.Hash) error {
+	if len(txids) == 0 {
+		return nil
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		hints, err := tx.CreateBucketIfNotExists(confirmHintBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, txid := range txids {
+			if err := putHeightHint(hints, txid[:], height); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// QueryConfirmHint returns the height from which a rescan for the
+// confirmation of the passed transaction must start, or zero if no hint is
+// known for it.
+func (d *DB) QueryConfirmHint(txid chainhash.Hash) (uint32, error) {
+	return d.fetchHeightHint(confirmHintBucket, txid[:])
+}
+
+// PurgeConfirmHint removes the confirmation hints of the passed transactions.
+func (d *DB) PurgeConfirmHint(txids ...chainhash.Hash) error {
+	if len(txids) == 0 {
+		return nil
+	}
+
+	return d.Batch(func(tx *bolt.Tx) error {
+		hints := tx.Bucket(confirmHintBucket)
+		if hints == nil {
+			return nil
+		}
+
+		for _, txid := range txids {
+			if err := hints.Delete(txid[:]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// fetchHeightHint returns the height hint stored under key within the passed
+// top-level bucket, or zero if there is none.
+func (d *DB) fetchHeightHint(bucket, key []byte) (uint32, error) {
+	var height uint32
+	err := d.View(func(tx *bolt.Tx) error {
+		hints := tx.Bucket(bucket)
+		if hints == nil {
+			return nil
+		}
+
+		hint := hints.Get(key)
+		if len(hint) != 4 {
+			return nil
+		}
+
+		height = byteOrder.Uint32(hint)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// putHeightHint stores height as the height hint under key.
+func putHeightHint(hints *bolt.Bucket, key []byte, height uint32) error {
+	var scratch [4]byte
+	byteOrder.PutUint32(scratch[:], height)
+
+	return hints.Put(key, scratch[:])
+}
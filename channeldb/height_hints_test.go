@@ -0,0 +1,85 @@
+package channeldb
+
+import (
+	"testing"
+
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// TestHeightHints tests that spend and confirmation height hints can be
+// committed, updated, queried and purged.
+func TestHeightHints(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	op1 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	op2 := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	txid1 := chainhash.Hash{2}
+	txid2 := chainhash.Hash{3}
+
+	assertSpendHint := func(op wire.OutPoint, expected uint32) {
+		hint, err := db.QuerySpendHint(op)
+		if err != nil {
+			t.Fatalf("unable to query spend hint: %v", err)
+		}
+		if hint != expected {
+			t.Fatalf("expected spend hint %v for %v, got %v",
+				expected, op, hint)
+		}
+	}
+	assertConfirmHint := func(txid chainhash.Hash, expected uint32) {
+		hint, err := db.QueryConfirmHint(txid)
+		if err != nil {
+			t.Fatalf("unable to query confirm hint: %v", err)
+		}
+		if hint != expected {
+			t.Fatalf("expected confirm hint %v for %v, got %v",
+				expected, txid, hint)
+		}
+	}
+
+	// Without any hints committed, no hints should be known.
+	assertSpendHint(op1, 0)
+	assertConfirmHint(txid1, 0)
+
+	if err := db.CommitSpendHint(100, op1, op2); err != nil {
+		t.Fatalf("unable to commit spend hints: %v", err)
+	}
+	if err := db.CommitConfirmHint(100, txid1, txid2); err != nil {
+		t.Fatalf("unable to commit confirm hints: %v", err)
+	}
+	assertSpendHint(op1, 100)
+	assertSpendHint(op2, 100)
+	assertConfirmHint(txid1, 100)
+	assertConfirmHint(txid2, 100)
+
+	// Committing a hint again, as is done when a block is disconnected,
+	// should overwrite the previous hint.
+	if err := db.CommitSpendHint(99, op1); err != nil {
+		t.Fatalf("unable to commit spend hint: %v", err)
+	}
+	if err := db.CommitConfirmHint(101, txid1); err != nil {
+		t.Fatalf("unable to commit confirm hint: %v", err)
+	}
+	assertSpendHint(op1, 99)
+	assertSpendHint(op2, 100)
+	assertConfirmHint(txid1, 101)
+	assertConfirmHint(txid2, 100)
+
+	if err := db.PurgeSpendHint(op1); err != nil {
+		t.Fatalf("unable to purge spend hint: %v", err)
+	}
+	if err := db.PurgeConfirmHint(txid2); err != nil {
+		t.Fatalf("unable to purge confirm hint: %v", err)
+	}
+	assertSpendHint(op1, 0)
+	assertSpendHint(op2, 100)
+	assertConfirmHint(txid1, 101)
+	assertConfirmHint(txid2, 0)
+}
@@ -0,0 +1,242 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// MaxAMPChildren is the maximum number of child preimages recorded within a
+// single AMP settlement. This matches the maximum number of HTLCs which may
+// be in flight within a commitment, which bounds the size of an HTLC set
+// arriving over a single channel.
+const MaxAMPChildren = 483
+
+var (
+	// ampSettlementBucket is the name of the sub-bucket within the
+	// invoiceBucket which houses the settlements of AMP invoices. Within
+	// it, each AMP invoice which has been paid has a sub-bucket keyed by
+	// its invoice ID, mapping the set ID of each HTLC set which paid the
+	// invoice to its settlement.
+	//
+	// maps: invoiceID -> setID -> settleDate || amount || childPreimages
+	ampSettlementBucket = []byte("amp-settlements")
+)
+
+// AMPSettlement is the record of a single HTLC set which paid an AMP invoice.
+// Each HTLC within the set carries a child preimage, which together with the
+// others allows the payee to settle the set as a whole.
+type AMPSettlement struct {
+	// SetID identifies the HTLC set, and is shared by all of its HTLCs.
+	SetID [32]byte
+
+	// ChildPreimages are the preimages which settled each HTLC within the
+	// set.
+	ChildPreimages [][32]byte
+
+	// Amount is the total amount paid by the HTLC set.
+	Amount lnwire.MilliSatoshi
+
+	// SettleDate is the time at which the HTLC set was settled.
+	SettleDate time.Time
+}
+
+// SettleAMPInvoice records the settlement of an HTLC set paying the AMP
+// invoice with the passed payment hash. The first settlement also marks the
+// invoice itself as settled, while later settlements are recorded along side
+// it, so a single AMP invoice may be paid many times. The settle date of the
+// settlement is set to the current time. Settling an HTLC set which has
+// already been recorded is a noop.
+func (d *DB) SettleAMPInvoice(paymentHash [32]byte,
+	settlement *AMPSettlement) error {
+
+	if len(settlement.ChildPreimages) == 0 {
+		return fmt.Errorf("AMP settlement must have at least one " +
+			"child preimage")
+	}
+	if len(settlement.ChildPreimages) > MaxAMPChildren {
+		return fmt.Errorf("max number of child preimages is %v, "+
+			"number provided was %v", MaxAMPChildren,
+			len(settlement.ChildPreimages))
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
+		if err != nil {
+			return err
+		}
+		invoiceIndex, err := invoices.CreateBucketIfNotExists(invoiceIndexBucket)
+		if err != nil {
+			return err
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		invoice, err := fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+		if !invoice.Terms.AMP {
+			return ErrInvoiceNotAMP
+		}
+
+		settlements, err := invoices.CreateBucketIfNotExists(
+			ampSettlementBucket,
+		)
+		if err != nil {
+			return err
+		}
+		invoiceSettlements, err := settlements.CreateBucketIfNotExists(
+			invoiceNum,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Add idempotency to duplicate settles of the same HTLC set,
+		// return here to avoid overwriting the previous info.
+		if invoiceSettlements.Get(settlement.SetID[:]) != nil {
+			return nil
+		}
+
+		s := *settlement
+		s.SettleDate = time.Now()
+
+		var b bytes.Buffer
+		if err := serializeAMPSettlement(&b, &s); err != nil {
+			return err
+		}
+		err = invoiceSettlements.Put(settlement.SetID[:], b.Bytes())
+		if err != nil {
+			return err
+		}
+
+		// The first HTLC set to be settled also settles the invoice
+		// itself, so it's no longer considered pending.
+		if !invoice.Terms.Settled {
+			return settleInvoice(invoices, invoiceNum, nil)
+		}
+
+		// Otherwise, the invoice is assigned a new settle index, so
+		// clients querying for the invoices settled since their last
+		// known one learn of this settlement as well.
+		err = indexInvoiceSettle(invoices, invoice, invoiceNum)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := serializeStoredInvoice(&buf, invoice); err != nil {
+			return err
+		}
+
+		return invoices.Put(invoiceNum, buf.Bytes())
+	})
+}
+
+// fetchAMPSettlements returns the settlements of the AMP invoice stored under
+// invoiceNum, ordered by the time they were settled.
+func fetchAMPSettlements(invoices *bolt.Bucket,
+	invoiceNum []byte) ([]*AMPSettlement, error) {
+
+	settlements := invoices.Bucket(ampSettlementBucket)
+	if settlements == nil {
+		return nil, nil
+	}
+	invoiceSettlements := settlements.Bucket(invoiceNum)
+	if invoiceSettlements == nil {
+		return nil, nil
+	}
+
+	var ampSettlements []*AMPSettlement
+	err := invoiceSettlements.ForEach(func(k, v []byte) error {
+		settlement, err := deserializeAMPSettlement(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+		copy(settlement.SetID[:], k)
+
+		ampSettlements = append(ampSettlements, settlement)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// As the settlements are keyed by their set ID, we'll need to sort
+	// them by the time they were settled.
+	sort.SliceStable(ampSettlements, func(i, j int) bool {
+		return ampSettlements[i].SettleDate.Before(
+			ampSettlements[j].SettleDate,
+		)
+	})
+
+	return ampSettlements, nil
+}
+
+func serializeAMPSettlement(w io.Writer, s *AMPSettlement) error {
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(s.SettleDate.UnixNano()))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(s.Amount))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	numChildren := uint64(len(s.ChildPreimages))
+	if err := wire.WriteVarInt(w, 0, numChildren); err != nil {
+		return err
+	}
+	for _, preimage := range s.ChildPreimages {
+		if _, err := w.Write(preimage[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializeAMPSettlement(r io.Reader) (*AMPSettlement, error) {
+	s := &AMPSettlement{}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.SettleDate = time.Unix(0, int64(byteOrder.Uint64(scratch[:])))
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	s.Amount = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	numChildren, err := wire.ReadVarInt(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if numChildren > MaxAMPChildren {
+		return nil, fmt.Errorf("AMP settlement has %v child "+
+			"preimages, max is %v", numChildren, MaxAMPChildren)
+	}
+
+	s.ChildPreimages = make([][32]byte, numChildren)
+	for i := range s.ChildPreimages {
+		if _, err := io.ReadFull(r, s.ChildPreimages[i][:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
@@ -0,0 +1,142 @@
+package channeldb
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestAMPInvoiceSettlements tests that an AMP invoice can be paid many times,
+// with each HTLC set paying it recorded as a separate settlement.
+func TestAMPInvoiceSettlements(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	invoice, err := randInvoice(0)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	invoice.Terms.AMP = true
+	if err := db.AddInvoice(invoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	paymentHash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+
+	// A regular invoice shouldn't accept AMP settlements.
+	regularInvoice, err := randInvoice(1000)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(regularInvoice); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	regularHash := sha256.Sum256(regularInvoice.Terms.PaymentPreimage[:])
+
+	settlements := []*AMPSettlement{
+		{
+			SetID:          [32]byte{1},
+			ChildPreimages: [][32]byte{{2}, {3}},
+			Amount:         lnwire.MilliSatoshi(5000),
+		},
+		{
+			SetID:          [32]byte{4},
+			ChildPreimages: [][32]byte{{5}},
+			Amount:         lnwire.MilliSatoshi(2000),
+		},
+	}
+
+	err = db.SettleAMPInvoice(regularHash, settlements[0])
+	if err != ErrInvoiceNotAMP {
+		t.Fatalf("expected ErrInvoiceNotAMP, got %v", err)
+	}
+
+	// A settlement without any child preimages should be rejected.
+	err = db.SettleAMPInvoice(paymentHash, &AMPSettlement{
+		SetID: [32]byte{9},
+	})
+	if err == nil {
+		t.Fatalf("expected settlement without children to be rejected")
+	}
+
+	assertSettlements := func(expected []*AMPSettlement) {
+		t.Helper()
+
+		dbInvoice, err := db.LookupInvoice(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to lookup invoice: %v", err)
+		}
+		if !dbInvoice.Terms.AMP {
+			t.Fatalf("invoice should be an AMP invoice")
+		}
+		if len(expected) > 0 && !dbInvoice.Terms.Settled {
+			t.Fatalf("invoice should be settled")
+		}
+		if len(dbInvoice.AMPSettlements) != len(expected) {
+			t.Fatalf("expected %v settlements, got %v",
+				len(expected), len(dbInvoice.AMPSettlements))
+		}
+
+		for i, settlement := range dbInvoice.AMPSettlements {
+			if settlement.SettleDate.IsZero() {
+				t.Fatalf("settlement %v has no settle date", i)
+			}
+			settlement.SettleDate = expected[i].SettleDate
+			if !reflect.DeepEqual(settlement, expected[i]) {
+				t.Fatalf("settlement %v mismatch: expected %v, "+
+					"got %v", i, expected[i], settlement)
+			}
+		}
+	}
+
+	assertSettlements(nil)
+
+	// Each HTLC set settled should be recorded, while settling the same
+	// set again should be a noop.
+	for i, settlement := range settlements {
+		if err := db.SettleAMPInvoice(paymentHash, settlement); err != nil {
+			t.Fatalf("unable to settle HTLC set: %v", err)
+		}
+		assertSettlements(settlements[:i+1])
+	}
+	if err := db.SettleAMPInvoice(paymentHash, settlements[0]); err != nil {
+		t.Fatalf("unable to settle HTLC set: %v", err)
+	}
+	assertSettlements(settlements)
+
+	// The settlements should also be populated when fetching all
+	// invoices, while the settled invoice is no longer pending.
+	dbInvoices, err := db.FetchAllInvoices(false)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	var found bool
+	for _, dbInvoice := range dbInvoices {
+		if !dbInvoice.Terms.AMP {
+			continue
+		}
+		found = true
+		if len(dbInvoice.AMPSettlements) != len(settlements) {
+			t.Fatalf("expected %v settlements, got %v",
+				len(settlements), len(dbInvoice.AMPSettlements))
+		}
+	}
+	if !found {
+		t.Fatalf("AMP invoice not found")
+	}
+
+	pendingInvoices, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(pendingInvoices) != 1 {
+		t.Fatalf("expected 1 pending invoice, got %v",
+			len(pendingInvoices))
+	}
+}
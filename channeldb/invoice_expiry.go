@@ -0,0 +1,122 @@
+package channeldb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// invoiceExpiryIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all open invoices by the time at which
+	// they expire. Each key is the big endian unix timestamp of the expiry
+	// in nanoseconds, followed by the invoice ID, and maps to the payment
+	// hash of the invoice. Invoices which never expire aren't indexed, and
+	// invoices are removed from the index once settled or canceled.
+	invoiceExpiryIndexBucket = []byte("invoice-expiry-index")
+)
+
+// invoiceExpiryKey returns the key of the invoice stored under invoiceNum
+// within the expiry index.
+func invoiceExpiryKey(expiry time.Time, invoiceNum []byte) []byte {
+	var key [12]byte
+	byteOrder.PutUint64(key[:8], uint64(expiry.UnixNano()))
+	copy(key[8:], invoiceNum)
+
+	return key[:]
+}
+
+// unindexInvoiceExpiry removes the invoice stored under invoiceNum from the
+// expiry index, if it's indexed.
+func unindexInvoiceExpiry(invoices *bolt.Bucket, invoice *Invoice,
+	invoiceNum []byte) error {
+
+	expiryIndex := invoices.Bucket(invoiceExpiryIndexBucket)
+	if expiryIndex == nil || invoice.Expiry == 0 {
+		return nil
+	}
+
+	return expiryIndex.Delete(
+		invoiceExpiryKey(invoice.ExpiryTime(), invoiceNum),
+	)
+}
+
+// NextInvoiceExpiry returns the earliest time at which an open invoice
+// expires. If no open invoice expires, the zero time is returned.
+func (d *DB) NextInvoiceExpiry() (time.Time, error) {
+	var nextExpiry time.Time
+	err := d.View(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return nil
+		}
+		expiryIndex := invoices.Bucket(invoiceExpiryIndexBucket)
+		if expiryIndex == nil {
+			return nil
+		}
+
+		k, _ := expiryIndex.Cursor().First()
+		if k == nil {
+			return nil
+		}
+
+		nextExpiry = time.Unix(0, int64(byteOrder.Uint64(k[:8])))
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return nextExpiry, nil
+}
+
+// CancelExpiredInvoices cancels all open invoices which expired at or before
+// the passed time within a single transaction, and returns them.
+func (d *DB) CancelExpiredInvoices(now time.Time) ([]*Invoice, error) {
+	var canceled []*Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return nil
+		}
+		expiryIndex := invoices.Bucket(invoiceExpiryIndexBucket)
+		if expiryIndex == nil {
+			return nil
+		}
+
+		var nowKey [8]byte
+		byteOrder.PutUint64(nowKey[:], uint64(now.UnixNano()))
+
+		// As canceling an invoice removes it from the expiry index,
+		// we'll first gather the expired invoices before canceling
+		// them.
+		var expiredNums [][]byte
+		c := expiryIndex.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if bytes.Compare(k[:8], nowKey[:]) > 0 {
+				break
+			}
+
+			expiredNums = append(
+				expiredNums, append([]byte(nil), k[8:]...),
+			)
+		}
+
+		for _, invoiceNum := range expiredNums {
+			invoice, err := cancelInvoice(invoices, invoiceNum)
+			if err != nil {
+				return err
+			}
+
+			canceled = append(canceled, invoice)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return canceled, nil
+}
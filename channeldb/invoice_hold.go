@@ -0,0 +1,142 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// AcceptHoldInvoice marks the hold invoice with the passed payment hash as
+// accepted, as an HTLC paying it is held until the invoice is settled or
+// canceled. The custom records carried by the HTLC are stored along side the
+// invoice. As the HTLC is held instead, an accepted invoice no longer expires.
+// If the invoice has already been accepted, settled or canceled, then it's
+// left untouched. In either case, the resulting invoice is returned.
+func (d *DB) AcceptHoldInvoice(paymentHash [32]byte,
+	customRecords map[uint64][]byte) (*Invoice, error) {
+
+	if err := validateCustomRecords(customRecords); err != nil {
+		return nil, err
+	}
+
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return ErrNoInvoicesCreated
+		}
+		invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+		if invoiceIndex == nil {
+			return ErrNoInvoicesCreated
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		var err error
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		terms := &invoice.Terms
+		switch {
+		case !terms.Hold:
+			return ErrInvoiceNotHold
+
+		case terms.Accepted || terms.Settled || terms.Canceled:
+			return nil
+		}
+
+		terms.Accepted = true
+		invoice.CustomRecords = customRecords
+
+		var buf bytes.Buffer
+		if err := serializeStoredInvoice(&buf, invoice); err != nil {
+			return err
+		}
+
+		if err := invoices.Put(invoiceNum, buf.Bytes()); err != nil {
+			return err
+		}
+
+		return unindexInvoiceExpiry(invoices, invoice, invoiceNum)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// SettleHoldInvoice settles the hold invoice paid to the hash of the passed
+// preimage, storing the preimage along side it. The invoice can only be
+// settled once an HTLC paying it has been accepted, as settling it otherwise
+// would record a payment which was never received. The settled invoice is
+// returned.
+func (d *DB) SettleHoldInvoice(preimage [32]byte) (*Invoice, error) {
+	paymentHash := sha256.Sum256(preimage[:])
+
+	var invoice *Invoice
+	err := d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return ErrNoInvoicesCreated
+		}
+		invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+		if invoiceIndex == nil {
+			return ErrNoInvoicesCreated
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		var err error
+		invoice, err = fetchInvoice(invoiceNum, invoices)
+		if err != nil {
+			return err
+		}
+
+		terms := &invoice.Terms
+		switch {
+		case !terms.Hold:
+			return ErrInvoiceNotHold
+
+		case terms.Settled:
+			return ErrInvoiceAlreadySettled
+
+		case terms.Canceled:
+			return ErrInvoiceAlreadyCanceled
+
+		case !terms.Accepted:
+			return ErrInvoiceNotAccepted
+		}
+
+		terms.PaymentPreimage = preimage
+		terms.Settled = true
+		invoice.SettleDate = time.Now()
+
+		err = indexInvoiceSettle(invoices, invoice, invoiceNum)
+		if err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := serializeStoredInvoice(&buf, invoice); err != nil {
+			return err
+		}
+
+		return invoices.Put(invoiceNum, buf.Bytes())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
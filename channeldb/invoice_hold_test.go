@@ -0,0 +1,164 @@
+package channeldb
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestHoldInvoice tests that a hold invoice is identified by its payment hash
+// until it's settled, and that it may only be settled once an HTLC paying it
+// was accepted.
+func TestHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	paymentHash := sha256.Sum256(preimage[:])
+
+	addHoldInvoice := func(paymentHash [32]byte) *Invoice {
+		invoice, err := randInvoice(1000)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		invoice.Expiry = time.Hour
+		invoice.Terms.PaymentPreimage = [32]byte{}
+		invoice.Terms.Hold = true
+		invoice.Terms.PaymentHash = paymentHash
+
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		return invoice
+	}
+
+	invoice := addHoldInvoice(paymentHash)
+
+	// The invoice should be indexed by its payment hash, even though its
+	// preimage isn't known yet.
+	dbInvoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if !reflect.DeepEqual(invoice, dbInvoice) {
+		t.Fatalf("invoice fetched from db doesn't match original "+
+			"%v vs %v", spew.Sdump(invoice), spew.Sdump(dbInvoice))
+	}
+	if dbInvoice.PaymentHash() != paymentHash {
+		t.Fatalf("expected payment hash %x, got %x", paymentHash,
+			dbInvoice.PaymentHash())
+	}
+
+	// Regular invoices can't be accepted, nor settled, as hold invoices.
+	regular, err := randInvoice(1000)
+	if err != nil {
+		t.Fatalf("unable to create invoice: %v", err)
+	}
+	if err := db.AddInvoice(regular); err != nil {
+		t.Fatalf("unable to add invoice: %v", err)
+	}
+	_, err = db.AcceptHoldInvoice(regular.PaymentHash(), nil)
+	if err != ErrInvoiceNotHold {
+		t.Fatalf("expected ErrInvoiceNotHold, got %v", err)
+	}
+	_, err = db.SettleHoldInvoice(regular.Terms.PaymentPreimage)
+	if err != ErrInvoiceNotHold {
+		t.Fatalf("expected ErrInvoiceNotHold, got %v", err)
+	}
+
+	// Until an HTLC paying the invoice is accepted, it can't be settled.
+	_, err = db.SettleHoldInvoice(preimage)
+	if err != ErrInvoiceNotAccepted {
+		t.Fatalf("expected ErrInvoiceNotAccepted, got %v", err)
+	}
+
+	customRecords := map[uint64][]byte{65537: []byte("hello")}
+	dbInvoice, err = db.AcceptHoldInvoice(paymentHash, customRecords)
+	if err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	if !dbInvoice.Terms.Accepted {
+		t.Fatalf("expected invoice to be accepted")
+	}
+	if !reflect.DeepEqual(dbInvoice.CustomRecords, customRecords) {
+		t.Fatalf("expected custom records %v, got %v", customRecords,
+			dbInvoice.CustomRecords)
+	}
+
+	// As the HTLC paying it is held instead, the accepted invoice should
+	// no longer expire.
+	canceled, err := db.CancelExpiredInvoices(
+		invoice.ExpiryTime().Add(time.Second),
+	)
+	if err != nil {
+		t.Fatalf("unable to cancel expired invoices: %v", err)
+	}
+	if len(canceled) != 0 {
+		t.Fatalf("expected no canceled invoices, got %v",
+			len(canceled))
+	}
+
+	// Settling the invoice should store its preimage along side it.
+	dbInvoice, err = db.SettleHoldInvoice(preimage)
+	if err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	terms := dbInvoice.Terms
+	if !terms.Settled || terms.PaymentPreimage != preimage {
+		t.Fatalf("expected invoice to be settled with its preimage")
+	}
+	if dbInvoice.SettleIndex == 0 {
+		t.Fatalf("expected settled invoice to be assigned a settle " +
+			"index")
+	}
+	dbInvoice, err = db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	terms = dbInvoice.Terms
+	if !terms.Settled || terms.PaymentPreimage != preimage {
+		t.Fatalf("expected invoice to be settled with its preimage")
+	}
+
+	// It can neither be settled again, nor canceled.
+	_, err = db.SettleHoldInvoice(preimage)
+	if err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+	err = db.CancelInvoice(paymentHash)
+	if err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+
+	// Finally, a canceled hold invoice can't be settled, even once an
+	// HTLC paying it was accepted.
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	paymentHash = sha256.Sum256(preimage[:])
+	addHoldInvoice(paymentHash)
+
+	if _, err := db.AcceptHoldInvoice(paymentHash, nil); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	if err := db.CancelInvoice(paymentHash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	_, err = db.SettleHoldInvoice(preimage)
+	if err != ErrInvoiceAlreadyCanceled {
+		t.Fatalf("expected ErrInvoiceAlreadyCanceled, got %v", err)
+	}
+}
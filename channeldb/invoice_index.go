@@ -0,0 +1,135 @@
+package channeldb
+
+import (
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// invoiceAddIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all invoices by their add index. The add
+	// index is a monotonically increasing sequence number assigned to each
+	// invoice once it's added, allowing clients to query for the invoices
+	// added since the last one they know of.
+	//
+	// maps: addIndex -> invoiceID
+	invoiceAddIndexBucket = []byte("invoice-add-index")
+
+	// invoiceSettleIndexBucket is the name of the sub-bucket within the
+	// invoiceBucket which indexes all settled invoices by their settle
+	// index. Similar to the add index, the settle index is a monotonically
+	// increasing sequence number assigned to each invoice once it's
+	// settled. As an AMP invoice may be paid many times, it's assigned a
+	// new settle index with each HTLC set paying it, replacing the prior
+	// one.
+	//
+	// maps: settleIndex -> invoiceID
+	invoiceSettleIndexBucket = []byte("invoice-settle-index")
+)
+
+// indexInvoiceAdd assigns the next add index to the invoice stored under
+// invoiceNum, and adds it to the add index.
+func indexInvoiceAdd(invoices *bolt.Bucket, invoice *Invoice,
+	invoiceNum []byte) error {
+
+	addIndex, err := invoices.CreateBucketIfNotExists(invoiceAddIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	nextIndex, err := addIndex.NextSequence()
+	if err != nil {
+		return err
+	}
+	invoice.AddIndex = nextIndex
+
+	return addIndex.Put(invoiceIndexKey(nextIndex), invoiceNum)
+}
+
+// indexInvoiceSettle assigns the next settle index to the invoice stored under
+// invoiceNum, and adds it to the settle index. If the invoice already has a
+// settle index, then it's removed from the index first.
+func indexInvoiceSettle(invoices *bolt.Bucket, invoice *Invoice,
+	invoiceNum []byte) error {
+
+	settleIndex, err := invoices.CreateBucketIfNotExists(
+		invoiceSettleIndexBucket,
+	)
+	if err != nil {
+		return err
+	}
+
+	if invoice.SettleIndex != 0 {
+		err := settleIndex.Delete(invoiceIndexKey(invoice.SettleIndex))
+		if err != nil {
+			return err
+		}
+	}
+
+	nextIndex, err := settleIndex.NextSequence()
+	if err != nil {
+		return err
+	}
+	invoice.SettleIndex = nextIndex
+
+	return settleIndex.Put(invoiceIndexKey(nextIndex), invoiceNum)
+}
+
+// invoiceIndexKey returns the key of an invoice within the add or settle
+// index.
+func invoiceIndexKey(index uint64) []byte {
+	var key [8]byte
+	byteOrder.PutUint64(key[:], index)
+	return key[:]
+}
+
+// InvoicesAddedSince returns all invoices with an add index greater than the
+// passed one, in the order they were added. An add index of zero returns all
+// invoices.
+func (d *DB) InvoicesAddedSince(sinceAddIndex uint64) ([]*Invoice, error) {
+	return d.invoicesIndexedSince(invoiceAddIndexBucket, sinceAddIndex)
+}
+
+// InvoicesSettledSince returns all invoices with a settle index greater than
+// the passed one, in the order they were last settled. A settle index of zero
+// returns all settled invoices.
+func (d *DB) InvoicesSettledSince(sinceSettleIndex uint64) ([]*Invoice, error) {
+	return d.invoicesIndexedSince(invoiceSettleIndexBucket, sinceSettleIndex)
+}
+
+// invoicesIndexedSince returns all invoices with an index greater than the
+// passed one within the named index bucket, ordered by their index.
+func (d *DB) invoicesIndexedSince(indexBucket []byte,
+	sinceIndex uint64) ([]*Invoice, error) {
+
+	var invoices []*Invoice
+	err := d.View(func(tx *bolt.Tx) error {
+		invoiceB := tx.Bucket(invoiceBucket)
+		if invoiceB == nil {
+			return nil
+		}
+		index := invoiceB.Bucket(indexBucket)
+		if index == nil {
+			return nil
+		}
+
+		// As the index keys are big endian, we can seek to the first
+		// index past the passed one, and scan from there on.
+		c := index.Cursor()
+		k, invoiceNum := c.Seek(invoiceIndexKey(sinceIndex + 1))
+		for ; k != nil; k, invoiceNum = c.Next() {
+			invoice, err := fetchInvoice(invoiceNum, invoiceB)
+			if err != nil {
+				return err
+			}
+
+			invoices = append(invoices, invoice)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
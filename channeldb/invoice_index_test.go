@@ -0,0 +1,141 @@
+package channeldb
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// assertInvoiceIndices asserts that the passed invoices match the expected
+// ones, in order, by their payment preimage.
+func assertInvoiceIndices(t *testing.T, expected, invoices []*Invoice) {
+	t.Helper()
+
+	if len(invoices) != len(expected) {
+		t.Fatalf("expected %v invoices, got %v", len(expected),
+			len(invoices))
+	}
+	for i := range expected {
+		if invoices[i].Terms.PaymentPreimage !=
+			expected[i].Terms.PaymentPreimage {
+
+			t.Fatalf("invoice %v doesn't match: expected add "+
+				"index %v, got %v", i, expected[i].AddIndex,
+				invoices[i].AddIndex)
+		}
+	}
+}
+
+// TestInvoiceIndices tests that invoices are assigned increasing add and
+// settle indices, and that the invoices added or settled since a given index
+// are returned in order.
+func TestInvoiceIndices(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Without any invoices, no invoices should be returned.
+	added, err := db.InvoicesAddedSince(0)
+	if err != nil {
+		t.Fatalf("unable to fetch added invoices: %v", err)
+	}
+	if len(added) != 0 {
+		t.Fatalf("expected no invoices, got %v", len(added))
+	}
+
+	const numInvoices = 5
+	invoices := make([]*Invoice, numInvoices)
+	for i := range invoices {
+		invoice, err := randInvoice(lnwire.MilliSatoshi(1000))
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		if invoice.AddIndex != uint64(i+1) {
+			t.Fatalf("expected add index %v, got %v", i+1,
+				invoice.AddIndex)
+		}
+
+		invoices[i] = invoice
+	}
+
+	// Only the invoices added after the passed add index should be
+	// returned.
+	added, err = db.InvoicesAddedSince(2)
+	if err != nil {
+		t.Fatalf("unable to fetch added invoices: %v", err)
+	}
+	assertInvoiceIndices(t, invoices[2:], added)
+
+	// Settle the invoices out of the order they were added in. Their
+	// settle indices should follow the order they were settled in.
+	settleOrder := []*Invoice{invoices[3], invoices[0], invoices[4]}
+	for i, invoice := range settleOrder {
+		hash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		if err := db.SettleInvoice(hash, nil); err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+
+		dbInvoice, err := db.LookupInvoice(hash)
+		if err != nil {
+			t.Fatalf("unable to lookup invoice: %v", err)
+		}
+		if dbInvoice.SettleIndex != uint64(i+1) {
+			t.Fatalf("expected settle index %v, got %v", i+1,
+				dbInvoice.SettleIndex)
+		}
+		if dbInvoice.AddIndex != invoice.AddIndex {
+			t.Fatalf("expected add index %v, got %v",
+				invoice.AddIndex, dbInvoice.AddIndex)
+		}
+	}
+
+	// Settling an invoice again shouldn't assign it a new settle index.
+	hash := sha256.Sum256(invoices[3].Terms.PaymentPreimage[:])
+	if err := db.SettleInvoice(hash, nil); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+
+	settled, err := db.InvoicesSettledSince(0)
+	if err != nil {
+		t.Fatalf("unable to fetch settled invoices: %v", err)
+	}
+	assertInvoiceIndices(t, settleOrder, settled)
+
+	settled, err = db.InvoicesSettledSince(1)
+	if err != nil {
+		t.Fatalf("unable to fetch settled invoices: %v", err)
+	}
+	assertInvoiceIndices(t, settleOrder[1:], settled)
+
+	// A keysend invoice is added settled, so it should be assigned both
+	// indices at once.
+	var preimage [32]byte
+	preimage[0] = 1
+	keySend, err := db.AddKeySendInvoice(preimage, 1000, nil)
+	if err != nil {
+		t.Fatalf("unable to add keysend invoice: %v", err)
+	}
+	if keySend.AddIndex != numInvoices+1 {
+		t.Fatalf("expected add index %v, got %v", numInvoices+1,
+			keySend.AddIndex)
+	}
+	if keySend.SettleIndex != uint64(len(settleOrder)+1) {
+		t.Fatalf("expected settle index %v, got %v",
+			len(settleOrder)+1, keySend.SettleIndex)
+	}
+
+	settled, err = db.InvoicesSettledSince(uint64(len(settleOrder)))
+	if err != nil {
+		t.Fatalf("unable to fetch settled invoices: %v", err)
+	}
+	assertInvoiceIndices(t, []*Invoice{keySend}, settled)
+}
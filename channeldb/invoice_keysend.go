@@ -0,0 +1,44 @@
+package channeldb
+
+import (
+	"time"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// KeySendRecordType is the type of the custom record which carries the
+// preimage of a spontaneous keysend payment within the onion payload of the
+// HTLC paying it.
+const KeySendRecordType uint64 = 5482373484
+
+// AddKeySendInvoice persists a synthetic invoice for a spontaneous keysend
+// payment of the passed amount, which carried the passed preimage and custom
+// records. As there's no invoice for the payment to settle, the synthetic
+// invoice is stored already settled, so the payment is accounted for along
+// side all other invoices. If an invoice with the same payment hash already
+// exists, then ErrDuplicateInvoice is returned.
+func (d *DB) AddKeySendInvoice(preimage [32]byte, amt lnwire.MilliSatoshi,
+	customRecords map[uint64][]byte) (*Invoice, error) {
+
+	if err := validateCustomRecords(customRecords); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invoice := &Invoice{
+		CreationDate:  now,
+		SettleDate:    now,
+		CustomRecords: customRecords,
+		KeySend:       true,
+		Terms: ContractTerm{
+			PaymentPreimage: preimage,
+			Value:           amt,
+			Settled:         true,
+		},
+	}
+	if err := d.AddInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
@@ -0,0 +1,89 @@
+package channeldb
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// TestKeySendInvoice tests that a synthetic invoice is persisted for a
+// keysend payment, and that it's listed along side regular invoices.
+func TestKeySendInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	customRecords := map[uint64][]byte{
+		KeySendRecordType: preimage[:],
+		65537:             []byte("hello"),
+	}
+	amt := lnwire.MilliSatoshi(1000)
+
+	// Custom records outside of the custom range should be rejected.
+	_, err = db.AddKeySendInvoice(preimage, amt, map[uint64][]byte{
+		1: []byte("reserved"),
+	})
+	if err == nil {
+		t.Fatalf("expected invalid custom records to be rejected")
+	}
+
+	if _, err := db.AddKeySendInvoice(preimage, amt, customRecords); err != nil {
+		t.Fatalf("unable to add keysend invoice: %v", err)
+	}
+
+	// The synthetic invoice should be stored as settled, along with the
+	// amount and custom records of the payment.
+	paymentHash := sha256.Sum256(preimage[:])
+	invoice, err := db.LookupInvoice(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if !invoice.KeySend {
+		t.Fatalf("invoice should be marked as keysend")
+	}
+	if !invoice.Terms.Settled || invoice.SettleDate.IsZero() {
+		t.Fatalf("keysend invoice should be settled")
+	}
+	if invoice.Terms.Value != amt {
+		t.Fatalf("expected value %v, got %v", amt, invoice.Terms.Value)
+	}
+	if !reflect.DeepEqual(invoice.CustomRecords, customRecords) {
+		t.Fatalf("expected custom records %v, got %v", customRecords,
+			invoice.CustomRecords)
+	}
+
+	// A second payment with the same preimage shouldn't create another
+	// invoice.
+	_, err = db.AddKeySendInvoice(preimage, amt, customRecords)
+	if err != ErrDuplicateInvoice {
+		t.Fatalf("expected ErrDuplicateInvoice, got %v", err)
+	}
+
+	// Finally, the synthetic invoice should be listed along side all
+	// other invoices, but not as pending.
+	invoices, err := db.FetchAllInvoices(false)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(invoices) != 1 || !invoices[0].KeySend {
+		t.Fatalf("expected keysend invoice to be listed")
+	}
+	invoices, err = db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch invoices: %v", err)
+	}
+	if len(invoices) != 0 {
+		t.Fatalf("expected no pending invoices, got %v", len(invoices))
+	}
+}
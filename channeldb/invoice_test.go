@@ -87,10 +87,23 @@ func TestInvoiceWorkflow(t *testing.T) {
 			spew.Sdump(fakeInvoice), spew.Sdump(dbInvoice))
 	}
 
+	// Settling the invoice with records outside of the custom range
+	// should fail.
+	err = db.SettleInvoice(paymentHash, map[uint64][]byte{
+		CustomRecordTypeStart - 1: []byte("reserved"),
+	})
+	if err == nil {
+		t.Fatalf("expected settling with a reserved record type to fail")
+	}
+
 	// Settle the invoice, the version retrieved from the database should
-	// now have the settled bit toggle to true and a non-default
-	// SettledDate
-	if err := db.SettleInvoice(paymentHash); err != nil {
+	// now have the settled bit toggle to true, a non-default SettledDate,
+	// and the custom records of the settling HTLC.
+	customRecords := map[uint64][]byte{
+		CustomRecordTypeStart:     []byte("message"),
+		CustomRecordTypeStart + 1: {},
+	}
+	if err := db.SettleInvoice(paymentHash, customRecords); err != nil {
 		t.Fatalf("unable to settle invoice: %v", err)
 	}
 	dbInvoice2, err := db.LookupInvoice(paymentHash)
@@ -105,6 +118,12 @@ func TestInvoiceWorkflow(t *testing.T) {
 		t.Fatalf("invoice should have non-zero SettledDate but isn't")
 	}
 
+	if !reflect.DeepEqual(customRecords, dbInvoice2.CustomRecords) {
+		t.Fatalf("custom records don't match: expected %v, got %v",
+			spew.Sdump(customRecords),
+			spew.Sdump(dbInvoice2.CustomRecords))
+	}
+
 	// Attempt to insert generated above again, this should fail as
 	// duplicates are rejected by the processing logic.
 	if err := db.AddInvoice(fakeInvoice); err != ErrDuplicateInvoice {
@@ -155,3 +174,131 @@ func TestInvoiceWorkflow(t *testing.T) {
 		}
 	}
 }
+
+// TestInvoiceExpiry tests that expired invoices are canceled in order of their
+// expiry, while settled invoices and invoices which never expire are left
+// untouched.
+func TestInvoiceExpiry(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Before any invoices are added, no invoice should expire.
+	nextExpiry, err := db.NextInvoiceExpiry()
+	if err != nil {
+		t.Fatalf("unable to fetch next expiry: %v", err)
+	}
+	if !nextExpiry.IsZero() {
+		t.Fatalf("expected no expiry, got %v", nextExpiry)
+	}
+
+	addInvoice := func(expiry time.Duration) *Invoice {
+		invoice, err := randInvoice(1000)
+		if err != nil {
+			t.Fatalf("unable to create invoice: %v", err)
+		}
+		invoice.Expiry = expiry
+
+		if err := db.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		return invoice
+	}
+
+	early := addInvoice(time.Minute)
+	late := addInvoice(time.Hour)
+	settled := addInvoice(time.Second)
+	addInvoice(0)
+
+	payHash := func(invoice *Invoice) [32]byte {
+		return sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	}
+
+	// Once settled, an invoice should no longer expire, nor may it be
+	// canceled.
+	if err := db.SettleInvoice(payHash(settled), nil); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	err = db.CancelInvoice(payHash(settled))
+	if err != ErrInvoiceAlreadySettled {
+		t.Fatalf("expected ErrInvoiceAlreadySettled, got %v", err)
+	}
+
+	nextExpiry, err = db.NextInvoiceExpiry()
+	if err != nil {
+		t.Fatalf("unable to fetch next expiry: %v", err)
+	}
+	if !nextExpiry.Equal(early.ExpiryTime()) {
+		t.Fatalf("expected next expiry %v, got %v",
+			early.ExpiryTime(), nextExpiry)
+	}
+
+	// Canceling invoices before the earliest expiry shouldn't cancel
+	// any.
+	canceled, err := db.CancelExpiredInvoices(
+		early.ExpiryTime().Add(-time.Second),
+	)
+	if err != nil {
+		t.Fatalf("unable to cancel expired invoices: %v", err)
+	}
+	if len(canceled) != 0 {
+		t.Fatalf("expected no canceled invoices, got %v",
+			len(canceled))
+	}
+
+	// Once the earliest expiry has passed, only that invoice should be
+	// canceled.
+	canceled, err = db.CancelExpiredInvoices(early.ExpiryTime())
+	if err != nil {
+		t.Fatalf("unable to cancel expired invoices: %v", err)
+	}
+	early.Terms.Canceled = true
+	if !reflect.DeepEqual([]*Invoice{early}, canceled) {
+		t.Fatalf("wrong canceled invoices: expected %v, got %v",
+			spew.Sdump(early), spew.Sdump(canceled))
+	}
+
+	dbEarly, err := db.LookupInvoice(payHash(early))
+	if err != nil {
+		t.Fatalf("unable to lookup invoice: %v", err)
+	}
+	if !dbEarly.Terms.Canceled {
+		t.Fatalf("expected invoice to be canceled")
+	}
+
+	nextExpiry, err = db.NextInvoiceExpiry()
+	if err != nil {
+		t.Fatalf("unable to fetch next expiry: %v", err)
+	}
+	if !nextExpiry.Equal(late.ExpiryTime()) {
+		t.Fatalf("expected next expiry %v, got %v",
+			late.ExpiryTime(), nextExpiry)
+	}
+
+	// Canceled invoices shouldn't be reported as pending.
+	pending, err := db.FetchAllInvoices(true)
+	if err != nil {
+		t.Fatalf("unable to fetch pending invoices: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending invoices, got %v", len(pending))
+	}
+
+	// Finally, explicitly canceling the remaining expiring invoice should
+	// leave no invoice to expire.
+	if err := db.CancelInvoice(payHash(late)); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	nextExpiry, err = db.NextInvoiceExpiry()
+	if err != nil {
+		t.Fatalf("unable to fetch next expiry: %v", err)
+	}
+	if !nextExpiry.IsZero() {
+		t.Fatalf("expected no expiry, got %v", nextExpiry)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sort"
 	"time"
 
 	"github.com/coreos/bbolt"
@@ -50,6 +51,17 @@ const (
 	// TODO(halseth): determine the max length payment request when field
 	// lengths are final.
 	MaxPaymentRequestSize = 4096
+
+	// MaxCustomRecordsSize is the maximum total size of the values of the
+	// custom records stored along side an invoice. This matches the size
+	// of the routing info within an onion packet, which bounds the records
+	// an HTLC is able to carry.
+	MaxCustomRecordsSize = 1300
+
+	// CustomRecordTypeStart is the smallest type of a custom record. Types
+	// below it are reserved for records defined by the protocol, so only
+	// records from this type on are stored with invoices.
+	CustomRecordTypeStart = 65536
 )
 
 // ContractTerm is a companion struct to the Invoice struct. This struct houses
@@ -68,6 +80,32 @@ type ContractTerm struct {
 	// Settled indicates if this particular contract term has been fully
 	// settled by the payer.
 	Settled bool
+
+	// Canceled indicates if this particular contract term has been
+	// canceled, either explicitly or because it expired before being
+	// settled. Payments to a canceled invoice are rejected.
+	Canceled bool
+
+	// AMP indicates that the invoice may be paid many times using atomic
+	// multi-path payments. Each set of HTLCs paying the invoice is
+	// settled independently, and recorded as a separate AMPSettlement.
+	AMP bool
+
+	// Hold indicates that this is a hold invoice, whose preimage isn't
+	// known when it's added. An HTLC paying a hold invoice is accepted,
+	// but held until the invoice is either settled with its preimage or
+	// canceled. Until then, PaymentPreimage is unset, and the invoice is
+	// identified by PaymentHash instead.
+	Hold bool
+
+	// PaymentHash is the payment hash of a hold invoice. It's only set
+	// for hold invoices, as the payment hash of any other invoice is
+	// derived from its preimage.
+	PaymentHash [32]byte
+
+	// Accepted indicates that an HTLC paying this hold invoice has been
+	// accepted, and is held until the invoice is settled or canceled.
+	Accepted bool
 }
 
 // Invoice is a payment invoice generated by a payee in order to request
@@ -103,6 +141,11 @@ type Invoice struct {
 	// SettleDate is the exact time the invoice was settled.
 	SettleDate time.Time
 
+	// Expiry is the duration after the creation date of the invoice after
+	// which it is canceled if it hasn't been settled by then. A value of
+	// zero means the invoice never expires.
+	Expiry time.Duration
+
 	// Terms are the contractual payment terms of the invoice. Once
 	// all the terms have been satisfied by the payer, then the invoice can
 	// be considered fully fulfilled.
@@ -110,6 +153,56 @@ type Invoice struct {
 	// TODO(roasbeef): later allow for multiple terms to fulfill the final
 	// invoice: payment fragmentation, etc.
 	Terms ContractTerm
+
+	// CustomRecords are the custom records carried within the onion
+	// payload of the HTLC which settled the invoice, keyed by their type.
+	// These may be used by applications to attach arbitrary data to a
+	// payment, such as a message to the payee.
+	CustomRecords map[uint64][]byte
+
+	// AMPSettlements are the settlements of the HTLC sets which paid an
+	// AMP invoice, ordered by the time they were settled. These aren't
+	// stored within the invoice itself, but populated once the invoice is
+	// fetched from the database.
+	AMPSettlements []*AMPSettlement
+
+	// KeySend indicates that the invoice wasn't created by the payee, but
+	// synthesized upon receiving a spontaneous keysend payment, which
+	// carries its preimage within the onion payload of the HTLC.
+	KeySend bool
+
+	// AddIndex is the sequence number at which the invoice was added. Add
+	// indices are assigned in increasing order as invoices are added, so
+	// clients can query for the invoices added since the last one they
+	// know of. This is set once the invoice is added to the database.
+	AddIndex uint64
+
+	// SettleIndex is the sequence number at which the invoice was last
+	// settled, or zero if it hasn't been settled yet. Settle indices are
+	// assigned in increasing order as invoices are settled, so clients can
+	// query for the invoices settled since the last one they know of.
+	SettleIndex uint64
+}
+
+// PaymentHash returns the payment hash of the invoice, which is either stored
+// along side a hold invoice, or derived from the preimage of any other
+// invoice.
+func (i *Invoice) PaymentHash() [32]byte {
+	if i.Terms.Hold {
+		return i.Terms.PaymentHash
+	}
+
+	return sha256.Sum256(i.Terms.PaymentPreimage[:])
+}
+
+// ExpiryTime returns the time at which the invoice expires, or the zero time
+// if it never expires.
+func (i *Invoice) ExpiryTime() time.Time {
+	if i.Expiry == 0 {
+		return time.Time{}
+	}
+
+	return i.CreationDate.Add(i.Expiry)
 }
 
 func validateInvoice(i *Invoice) error {
@@ -130,6 +223,28 @@ func validateInvoice(i *Invoice) error {
 	return nil
 }
 
+// validateCustomRecords ensures that all the passed custom records have a type
+// within the custom range, and that they don't exceed the maximum size.
+func validateCustomRecords(records map[uint64][]byte) error {
+	var size int
+	for recordType, value := range records {
+		if recordType < CustomRecordTypeStart {
+			return fmt.Errorf("custom record type %v is below the "+
+				"custom range starting at %v", recordType,
+				CustomRecordTypeStart)
+		}
+
+		size += len(value)
+	}
+
+	if size > MaxCustomRecordsSize {
+		return fmt.Errorf("max size of custom records is %v, size "+
+			"provided was %v", MaxCustomRecordsSize, size)
+	}
+
+	return nil
+}
+
 // AddInvoice inserts the targeted invoice into the database. If the invoice
 // has *any* payment hashes which already exists within the database, then the
 // insertion will be aborted and rejected due to the strict policy banning any
@@ -148,10 +263,16 @@ func (d *DB) AddInvoice(i *Invoice) error {
 		if err != nil {
 			return err
 		}
+		expiryIndex, err := invoices.CreateBucketIfNotExists(
+			invoiceExpiryIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
 
 		// Ensure that an invoice an identical payment hash doesn't
 		// already exist within the index.
-		paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
+		paymentHash := i.PaymentHash()
 		if invoiceIndex.Get(paymentHash[:]) != nil {
 			return ErrDuplicateInvoice
 		}
@@ -170,7 +291,24 @@ func (d *DB) AddInvoice(i *Invoice) error {
 			invoiceNum = byteOrder.Uint32(invoiceCounter)
 		}
 
-		return putInvoice(invoices, invoiceIndex, i, invoiceNum)
+		err = putInvoice(invoices, invoiceIndex, i, invoiceNum)
+		if err != nil {
+			return err
+		}
+
+		// If the invoice expires, we'll add it to the expiry index so
+		// it can be canceled once it does.
+		if i.Expiry == 0 || i.Terms.Settled || i.Terms.Canceled {
+			return nil
+		}
+
+		var invoiceKey [4]byte
+		byteOrder.PutUint32(invoiceKey[:], invoiceNum)
+
+		return expiryIndex.Put(
+			invoiceExpiryKey(i.ExpiryTime(), invoiceKey[:]),
+			paymentHash[:],
+		)
 	})
 }
 
@@ -218,7 +356,7 @@ func (d *DB) LookupInvoice(paymentHash [32]byte) (*Invoice, error) {
 
 // FetchAllInvoices returns all invoices currently stored within the database.
 // If the pendingOnly param is true, then only unsettled invoices will be
-// returned, skipping all invoices that are fully settled.
+// returned, skipping all invoices that are fully settled or canceled.
 func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 	var invoices []*Invoice
 
@@ -237,15 +375,25 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 			}
 
 			invoiceReader := bytes.NewReader(v)
-			invoice, err := deserializeInvoice(invoiceReader)
+			invoice, err := deserializeStoredInvoice(invoiceReader)
 			if err != nil {
 				return err
 			}
 
-			if pendingOnly && invoice.Terms.Settled {
+			terms := invoice.Terms
+			if pendingOnly && (terms.Settled || terms.Canceled) {
 				return nil
 			}
 
+			if terms.AMP {
+				invoice.AMPSettlements, err = fetchAMPSettlements(
+					invoiceB, k,
+				)
+				if err != nil {
+					return err
+				}
+			}
+
 			invoices = append(invoices, invoice)
 
 			return nil
@@ -259,10 +407,20 @@ func (d *DB) FetchAllInvoices(pendingOnly bool) ([]*Invoice, error) {
 }
 
 // SettleInvoice attempts to mark an invoice corresponding to the passed
-// payment hash as fully settled. If an invoice matching the passed payment
-// hash doesn't existing within the database, then the action will fail with a
-// "not found" error.
-func (d *DB) SettleInvoice(paymentHash [32]byte) error {
+// payment hash as fully settled. The custom records carried by the HTLC which
+// settled the invoice are stored along side it. If an invoice matching the
+// passed payment hash doesn't existing within the database, then the action
+// will fail with a "not found" error.
+//
+// NOTE: An invoice which was canceled after an HTLC paying it was accepted is
+// settled regardless, as the payment has already been received by then.
+func (d *DB) SettleInvoice(paymentHash [32]byte,
+	customRecords map[uint64][]byte) error {
+
+	if err := validateCustomRecords(customRecords); err != nil {
+		return err
+	}
+
 	return d.Update(func(tx *bolt.Tx) error {
 		invoices, err := tx.CreateBucketIfNotExists(invoiceBucket)
 		if err != nil {
@@ -280,7 +438,32 @@ func (d *DB) SettleInvoice(paymentHash [32]byte) error {
 			return ErrInvoiceNotFound
 		}
 
-		return settleInvoice(invoices, invoiceNum)
+		return settleInvoice(invoices, invoiceNum, customRecords)
+	})
+}
+
+// CancelInvoice attempts to mark an invoice corresponding to the passed
+// payment hash as canceled, after which payments to it are rejected. Canceling
+// an invoice which has already been canceled is a noop, while an invoice
+// which has already been settled can't be canceled.
+func (d *DB) CancelInvoice(paymentHash [32]byte) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		invoices := tx.Bucket(invoiceBucket)
+		if invoices == nil {
+			return ErrNoInvoicesCreated
+		}
+		invoiceIndex := invoices.Bucket(invoiceIndexBucket)
+		if invoiceIndex == nil {
+			return ErrNoInvoicesCreated
+		}
+
+		invoiceNum := invoiceIndex.Get(paymentHash[:])
+		if invoiceNum == nil {
+			return ErrInvoiceNotFound
+		}
+
+		_, err := cancelInvoice(invoices, invoiceNum)
+		return err
 	})
 }
 
@@ -304,20 +487,235 @@ func putInvoice(invoices *bolt.Bucket, invoiceIndex *bolt.Bucket,
 	// Add the payment hash to the invoice index. This will let us quickly
 	// identify if we can settle an incoming payment, and also to possibly
 	// allow a single invoice to have multiple payment installations.
-	paymentHash := sha256.Sum256(i.Terms.PaymentPreimage[:])
+	paymentHash := i.PaymentHash()
 	if err := invoiceIndex.Put(paymentHash[:], invoiceKey[:]); err != nil {
 		return err
 	}
 
+	// Assign the invoice the next add index, along with the next settle
+	// index if it's added settled already.
+	if err := indexInvoiceAdd(invoices, i, invoiceKey[:]); err != nil {
+		return err
+	}
+	if i.Terms.Settled {
+		err := indexInvoiceSettle(invoices, i, invoiceKey[:])
+		if err != nil {
+			return err
+		}
+	}
+
 	// Finally, serialize the invoice itself to be written to the disk.
 	var buf bytes.Buffer
-	if err := serializeInvoice(&buf, i); err != nil {
+	if err := serializeStoredInvoice(&buf, i); err != nil {
 		return nil
 	}
 
 	return invoices.Put(invoiceKey[:], buf.Bytes())
 }
 
+// serializeStoredInvoice serializes an invoice stored within the invoice
+// bucket. As the serialization of invoices is shared with outgoing payments,
+// the fields only tracked for invoices are appended after it.
+func serializeStoredInvoice(w io.Writer, i *Invoice) error {
+	if err := serializeInvoice(w, i); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(i.Expiry))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, i.Terms.Canceled); err != nil {
+		return err
+	}
+
+	if err := serializeCustomRecords(w, i.CustomRecords); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, i.Terms.AMP); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, i.KeySend); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], i.AddIndex)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], i.SettleIndex)
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, byteOrder, i.Terms.Hold); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(i.Terms.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	return binary.Write(w, byteOrder, i.Terms.Accepted)
+}
+
+// serializeCustomRecords writes the passed custom records ordered by their
+// type, each as its type followed by its length prefixed value.
+func serializeCustomRecords(w io.Writer, records map[uint64][]byte) error {
+	recordTypes := make([]uint64, 0, len(records))
+	for recordType := range records {
+		recordTypes = append(recordTypes, recordType)
+	}
+	sort.Slice(recordTypes, func(i, j int) bool {
+		return recordTypes[i] < recordTypes[j]
+	})
+
+	var scratch [8]byte
+	byteOrder.PutUint16(scratch[:2], uint16(len(recordTypes)))
+	if _, err := w.Write(scratch[:2]); err != nil {
+		return err
+	}
+
+	for _, recordType := range recordTypes {
+		byteOrder.PutUint64(scratch[:], recordType)
+		if _, err := w.Write(scratch[:]); err != nil {
+			return err
+		}
+
+		err := wire.WriteVarBytes(w, 0, records[recordType])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deserializeCustomRecords reads a set of custom records previously written
+// by serializeCustomRecords. If the set is empty, nil is returned.
+func deserializeCustomRecords(r io.Reader) (map[uint64][]byte, error) {
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:2]); err != nil {
+		return nil, err
+	}
+	numRecords := byteOrder.Uint16(scratch[:2])
+	if numRecords == 0 {
+		return nil, nil
+	}
+
+	records := make(map[uint64][]byte, numRecords)
+	for i := uint16(0); i < numRecords; i++ {
+		if _, err := io.ReadFull(r, scratch[:]); err != nil {
+			return nil, err
+		}
+		recordType := byteOrder.Uint64(scratch[:])
+
+		value, err := wire.ReadVarBytes(
+			r, 0, MaxCustomRecordsSize, "custom record",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		records[recordType] = value
+	}
+
+	return records, nil
+}
+
+// deserializeStoredInvoice deserializes an invoice stored within the invoice
+// bucket. Invoices stored before their expiry and cancellation were tracked
+// lack these fields, in which case the invoice never expires and isn't
+// canceled. Similarly, invoices stored before custom records, AMP, keysend,
+// the add and settle indices or hold invoices were tracked lack them.
+func deserializeStoredInvoice(r *bytes.Reader) (*Invoice, error) {
+	invoice, err := deserializeInvoice(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	invoice.Expiry = time.Duration(byteOrder.Uint64(scratch[:]))
+
+	err = binary.Read(r, byteOrder, &invoice.Terms.Canceled)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	invoice.CustomRecords, err = deserializeCustomRecords(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	err = binary.Read(r, byteOrder, &invoice.Terms.AMP)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	err = binary.Read(r, byteOrder, &invoice.KeySend)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	invoice.AddIndex = byteOrder.Uint64(scratch[:])
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	invoice.SettleIndex = byteOrder.Uint64(scratch[:])
+
+	if r.Len() == 0 {
+		return invoice, nil
+	}
+
+	err = binary.Read(r, byteOrder, &invoice.Terms.Hold)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(r, invoice.Terms.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	err = binary.Read(r, byteOrder, &invoice.Terms.Accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
 func serializeInvoice(w io.Writer, i *Invoice) error {
 	if err := wire.WriteVarBytes(w, 0, i.Memo[:]); err != nil {
 		return err
@@ -372,14 +770,27 @@ func fetchInvoice(invoiceNum []byte, invoices *bolt.Bucket) (*Invoice, error) {
 
 	invoiceReader := bytes.NewReader(invoiceBytes)
 
-	return deserializeInvoice(invoiceReader)
+	invoice, err := deserializeStoredInvoice(invoiceReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Terms.AMP {
+		invoice.AMPSettlements, err = fetchAMPSettlements(
+			invoices, invoiceNum,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return invoice, nil
 }
 
 func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	var err error
 	invoice := &Invoice{}
 
-	// TODO(roasbeef): use read full everywhere
 	invoice.Memo, err = wire.ReadVarBytes(r, 0, MaxMemoSize, "")
 	if err != nil {
 		return nil, err
@@ -426,7 +837,9 @@ func deserializeInvoice(r io.Reader) (*Invoice, error) {
 	return invoice, nil
 }
 
-func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
+func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte,
+	customRecords map[uint64][]byte) error {
+
 	invoice, err := fetchInvoice(invoiceNum, invoices)
 	if err != nil {
 		return err
@@ -439,12 +852,61 @@ func settleInvoice(invoices *bolt.Bucket, invoiceNum []byte) error {
 	}
 
 	invoice.Terms.Settled = true
+	invoice.Terms.Canceled = false
 	invoice.SettleDate = time.Now()
+	invoice.CustomRecords = customRecords
+
+	err = indexInvoiceSettle(invoices, invoice, invoiceNum)
+	if err != nil {
+		return err
+	}
 
 	var buf bytes.Buffer
-	if err := serializeInvoice(&buf, invoice); err != nil {
+	if err := serializeStoredInvoice(&buf, invoice); err != nil {
 		return nil
 	}
 
-	return invoices.Put(invoiceNum[:], buf.Bytes())
+	if err := invoices.Put(invoiceNum[:], buf.Bytes()); err != nil {
+		return err
+	}
+
+	// Now that the invoice is settled, it can no longer expire.
+	return unindexInvoiceExpiry(invoices, invoice, invoiceNum)
+}
+
+// cancelInvoice marks the invoice stored under invoiceNum as canceled, and
+// removes it from the expiry index. The canceled invoice is returned.
+func cancelInvoice(invoices *bolt.Bucket, invoiceNum []byte) (*Invoice,
+	error) {
+
+	invoice, err := fetchInvoice(invoiceNum, invoices)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case invoice.Terms.Settled:
+		return nil, ErrInvoiceAlreadySettled
+
+	case invoice.Terms.Canceled:
+		return invoice, nil
+	}
+
+	invoice.Terms.Canceled = true
+
+	var buf bytes.Buffer
+	if err := serializeStoredInvoice(&buf, invoice); err != nil {
+		return nil, err
+	}
+
+	if err := invoices.Put(invoiceNum[:], buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	err = unindexInvoiceExpiry(invoices, invoice, invoiceNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
 }
@@ -39,6 +39,13 @@ func (d *DB) FetchMeta(tx *bolt.Tx) (*Meta, error) {
 // re-use a database transaction. See the publicly exported FetchMeta method
 // for more information.
 func fetchMeta(meta *Meta, tx *bolt.Tx) error {
+	return fetchMetaVersions(meta, tx, dbVersions)
+}
+
+// fetchMetaVersions reads the meta-data of a database whose versions are
+// versions. A database without a recorded version is assumed to be at the
+// latest version.
+func fetchMetaVersions(meta *Meta, tx *bolt.Tx, versions []version) error {
 	metaBucket := tx.Bucket(metaBucket)
 	if metaBucket == nil {
 		return ErrMetaNotFound
@@ -46,7 +53,7 @@ func fetchMeta(meta *Meta, tx *bolt.Tx) error {
 
 	data := metaBucket.Get(dbVersionKey)
 	if data == nil {
-		meta.DbVersionNumber = getLatestDBVersion(dbVersions)
+		meta.DbVersionNumber = getLatestDBVersion(versions)
 	} else {
 		meta.DbVersionNumber = byteOrder.Uint32(data)
 	}
@@ -2,6 +2,7 @@ package channeldb
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/coreos/bbolt"
@@ -52,13 +53,13 @@ func TestOrderOfMigrations(t *testing.T) {
 
 	appliedMigration := -1
 	versions := []version{
-		{0, nil},
-		{1, nil},
-		{2, func(tx *bolt.Tx) error {
+		{number: 0},
+		{number: 1},
+		{number: 2, migration: func(tx *bolt.Tx) error {
 			appliedMigration = 2
 			return nil
 		}},
-		{3, func(tx *bolt.Tx) error {
+		{number: 3, migration: func(tx *bolt.Tx) error {
 			appliedMigration = 3
 			return nil
 		}},
@@ -122,6 +123,17 @@ func TestGlobalVersionList(t *testing.T) {
 func applyMigration(t *testing.T, beforeMigration, afterMigration func(d *DB),
 	migrationFunc migration, shouldFail bool) {
 
+	applyVersion(t, beforeMigration, afterMigration, version{
+		number:    1,
+		migration: migrationFunc,
+	}, shouldFail)
+}
+
+// applyVersion is the same as applyMigration, but takes the version to
+// migrate to, allowing chunked migrations to be applied as well.
+func applyVersion(t *testing.T, beforeMigration, afterMigration func(d *DB),
+	newVersion version, shouldFail bool) {
+
 	cdb, cleanUp, err := makeTestDB()
 	defer cleanUp()
 	if err != nil {
@@ -144,10 +156,7 @@ func applyMigration(t *testing.T, beforeMigration, afterMigration func(d *DB),
 			number:    0,
 			migration: nil,
 		},
-		{
-			number:    1,
-			migration: migrationFunc,
-		},
+		newVersion,
 	}
 
 	defer func() {
@@ -380,3 +389,138 @@ func TestMigrationWithoutErrors(t *testing.T) {
 		migrationWithoutErrors,
 		false)
 }
+
+// TestChunkedMigrationResume checks that the progress of a chunked migration
+// is committed after each chunk, and that an interrupted migration resumes
+// from its last checkpoint without processing any record twice.
+func TestChunkedMigrationResume(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const numRecords = 10
+	bucketName := []byte("chunked")
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(bucketName)
+		if err != nil {
+			return err
+		}
+
+		for i := byte(0); i < numRecords; i++ {
+			if err := bucket.Put([]byte{i}, []byte{0}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to populate db: %v", err)
+	}
+
+	if err := cdb.PutMeta(&Meta{DbVersionNumber: 0}); err != nil {
+		t.Fatalf("unable to store meta data: %v", err)
+	}
+
+	// The migration marks three records per chunk as migrated, failing
+	// on its third chunk the first time it's applied.
+	var numCalls int
+	errInterrupted := errors.New("interrupted")
+	migrateChunk := func(tx *bolt.Tx, resumeKey []byte) ([]byte, error) {
+		numCalls++
+		if numCalls == 3 {
+			return nil, errInterrupted
+		}
+
+		bucket := tx.Bucket(bucketName)
+		cursor := bucket.Cursor()
+		k, v := cursor.First()
+		if resumeKey != nil {
+			k, v = cursor.Seek(resumeKey)
+		}
+
+		for i := 0; k != nil; k, v = cursor.Next() {
+			if i == 3 {
+				return append([]byte(nil), k...), nil
+			}
+			if v[0] != 0 {
+				return nil, fmt.Errorf("record %x migrated "+
+					"twice", k)
+			}
+			if err := bucket.Put(k, []byte{1}); err != nil {
+				return nil, err
+			}
+			i++
+		}
+
+		return nil, nil
+	}
+
+	versions := []version{
+		{number: 0},
+		{number: 1, chunkedMigration: migrateChunk},
+	}
+
+	// assertState asserts the version of the database, the key the
+	// migration would resume from and the number of migrated records.
+	assertState := func(expVersion uint32, expResumeKey []byte,
+		expMigrated int) {
+
+		meta, err := cdb.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.DbVersionNumber != expVersion {
+			t.Fatalf("expected version %v, got %v", expVersion,
+				meta.DbVersionNumber)
+		}
+
+		err = cdb.View(func(tx *bolt.Tx) error {
+			resumeKey := fetchMigrationCheckpoint(
+				tx.Bucket(metaBucket), 1,
+			)
+			if !bytes.Equal(resumeKey, expResumeKey) {
+				return fmt.Errorf("expected resume key %x, "+
+					"got %x", expResumeKey, resumeKey)
+			}
+
+			var numMigrated int
+			bucket := tx.Bucket(bucketName)
+			err := bucket.ForEach(func(k, v []byte) error {
+				numMigrated += int(v[0])
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if numMigrated != expMigrated {
+				return fmt.Errorf("expected %v migrated "+
+					"records, got %v", expMigrated,
+					numMigrated)
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The interrupted migration should have committed its first two
+	// chunks, without bumping the version of the database.
+	if err := cdb.syncVersions(versions); err != errInterrupted {
+		t.Fatalf("expected interrupted migration, got %v", err)
+	}
+	assertState(0, []byte{6}, 6)
+
+	// Applying the migration again should resume at the checkpoint and
+	// complete it.
+	if err := cdb.syncVersions(versions); err != nil {
+		t.Fatalf("unable to resume migration: %v", err)
+	}
+	assertState(1, nil, numRecords)
+}
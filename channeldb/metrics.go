@@ -0,0 +1,133 @@
+package channeldb
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// OpMetrics summarizes all transactions of a single kind executed against the
+// database since it was opened.
+type OpMetrics struct {
+	// Count is the number of transactions executed.
+	Count uint64
+
+	// Errors is the number of transactions which returned an error.
+	Errors uint64
+
+	// Retries is the number of times a transaction function was executed
+	// again after its first attempt. Only read-write transactions are ever
+	// retried, which happens when a transaction function of a batch
+	// fails, requiring the others to be executed again without it, or
+	// when the transaction fails with a retryable error.
+	Retries uint64
+
+	// TotalLatency is the sum of the durations of all transactions,
+	// including the time spent waiting to obtain the database lock.
+	TotalLatency time.Duration
+
+	// MaxLatency is the duration of the slowest transaction.
+	MaxLatency time.Duration
+}
+
+// DBMetrics summarizes the operations executed against the database, along
+// with the size of its buckets.
+type DBMetrics struct {
+	// View summarizes all read-only transactions.
+	View OpMetrics
+
+	// Update summarizes all read-write transactions.
+	Update OpMetrics
+
+	// Batch summarizes all batched read-write transactions.
+	Batch OpMetrics
+
+	// BucketKeys maps the name of each top-level bucket to the total
+	// number of keys within it, including those of all nested buckets.
+	BucketKeys map[string]int
+}
+
+// opMetrics records the metrics of a single kind of transaction.
+//
+// NOTE: All fields are to be used atomically.
+type opMetrics struct {
+	count        uint64
+	errors       uint64
+	retries      uint64
+	totalLatency uint64
+	maxLatency   uint64
+}
+
+// record records a single transaction which took the passed duration and
+// was retried the passed number of times.
+func (m *opMetrics) record(latency time.Duration, retries uint64, err error) {
+	atomic.AddUint64(&m.count, 1)
+	atomic.AddUint64(&m.retries, retries)
+	if err != nil {
+		atomic.AddUint64(&m.errors, 1)
+	}
+
+	atomic.AddUint64(&m.totalLatency, uint64(latency))
+	for {
+		max := atomic.LoadUint64(&m.maxLatency)
+		if uint64(latency) <= max {
+			break
+		}
+		if atomic.CompareAndSwapUint64(&m.maxLatency, max,
+			uint64(latency)) {
+
+			break
+		}
+	}
+}
+
+// snapshot returns the metrics recorded so far.
+func (m *opMetrics) snapshot() OpMetrics {
+	return OpMetrics{
+		Count:   atomic.LoadUint64(&m.count),
+		Errors:  atomic.LoadUint64(&m.errors),
+		Retries: atomic.LoadUint64(&m.retries),
+		TotalLatency: time.Duration(
+			atomic.LoadUint64(&m.totalLatency),
+		),
+		MaxLatency: time.Duration(atomic.LoadUint64(&m.maxLatency)),
+	}
+}
+
+// dbMetrics records the metrics of all transactions executed against the
+// database.
+type dbMetrics struct {
+	view   opMetrics
+	update opMetrics
+	batch  opMetrics
+}
+
+// Metrics returns a summary of all transactions executed against the database
+// since it was opened, along with the number of keys within each of its
+// top-level buckets.
+//
+// NOTE: Counting the keys requires traversing all buckets, so it's as costly
+// as reading the entire database.
+func (d *DB) Metrics() (*DBMetrics, error) {
+	metrics := &DBMetrics{
+		View:       d.metrics.view.snapshot(),
+		Update:     d.metrics.update.snapshot(),
+		Batch:      d.metrics.batch.snapshot(),
+		BucketKeys: make(map[string]int),
+	}
+
+	// The bucket traversal is read from the underlying database directly,
+	// so that it isn't itself recorded as a transaction.
+	err := d.DB.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			metrics.BucketKeys[string(name)] = b.Stats().KeyN
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,141 @@
+package channeldb
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestDBMetrics tests that the transactions executed against the database
+// and the number of keys within its buckets are reflected by its metrics.
+func TestDBMetrics(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	before, err := db.Metrics()
+	if err != nil {
+		t.Fatalf("unable to fetch metrics: %v", err)
+	}
+
+	testBucket := []byte("test-bucket")
+	err = db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucket(testBucket)
+		if err != nil {
+			return err
+		}
+		for _, k := range []string{"a", "b", "c"} {
+			if err := bucket.Put([]byte(k), []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update db: %v", err)
+	}
+
+	errTest := errors.New("test error")
+	err = db.View(func(*bolt.Tx) error { return errTest })
+	if err != errTest {
+		t.Fatalf("expected test error, got %v", err)
+	}
+	err = db.Batch(func(*bolt.Tx) error { return nil })
+	if err != nil {
+		t.Fatalf("unable to batch: %v", err)
+	}
+
+	after, err := db.Metrics()
+	if err != nil {
+		t.Fatalf("unable to fetch metrics: %v", err)
+	}
+
+	if after.Update.Count != before.Update.Count+1 {
+		t.Fatalf("expected %v updates, got %v",
+			before.Update.Count+1, after.Update.Count)
+	}
+	if after.View.Count != before.View.Count+1 ||
+		after.View.Errors != before.View.Errors+1 {
+
+		t.Fatalf("expected %v views with %v errors, got %v with %v",
+			before.View.Count+1, before.View.Errors+1,
+			after.View.Count, after.View.Errors)
+	}
+	if after.Batch.Count != before.Batch.Count+1 {
+		t.Fatalf("expected %v batches, got %v",
+			before.Batch.Count+1, after.Batch.Count)
+	}
+	if after.Update.MaxLatency == 0 ||
+		after.Update.TotalLatency < after.Update.MaxLatency {
+
+		t.Fatalf("invalid update latencies: total=%v, max=%v",
+			after.Update.TotalLatency, after.Update.MaxLatency)
+	}
+
+	if keys := after.BucketKeys[string(testBucket)]; keys != 3 {
+		t.Fatalf("expected 3 keys in test bucket, got %v", keys)
+	}
+}
+
+// TestBatchRetryConflict tests that a batched transaction function returning
+// ErrTxConflict is retried up to the configured number of times, and that the
+// retries are reflected by the metrics of the database.
+func TestBatchRetryConflict(t *testing.T) {
+	t.Parallel()
+
+	tempDirName, err := ioutil.TempDir("", "channeldb")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	db, err := Open(
+		tempDirName,
+		OptionSetMaxBatchDelay(time.Millisecond),
+		OptionSetBatchRetries(3),
+		OptionSetBatchRetryBackoff(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unable to open db: %v", err)
+	}
+	defer db.Close()
+
+	// A transaction function which conflicts twice should succeed on its
+	// third attempt.
+	var conflicts int
+	err = db.Batch(func(*bolt.Tx) error {
+		if conflicts < 2 {
+			conflicts++
+			return ErrTxConflict
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to batch: %v", err)
+	}
+
+	metrics, err := db.Metrics()
+	if err != nil {
+		t.Fatalf("unable to fetch metrics: %v", err)
+	}
+	if metrics.Batch.Retries == 0 {
+		t.Fatalf("expected batch retries to be recorded")
+	}
+
+	// A transaction function which keeps conflicting should have the
+	// conflict returned once its retries are exhausted.
+	err = db.Batch(func(*bolt.Tx) error {
+		return ErrTxConflict
+	})
+	if err != ErrTxConflict {
+		t.Fatalf("expected transaction conflict, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package channeldb
+
+import (
+	"github.com/coreos/bbolt"
+)
+
+// migrationChunkSize is the maximum number of records a chunked migration
+// processes within a single transaction.
+const migrationChunkSize = 1000
+
+// migrationCheckpointKey is the key within the meta bucket under which the
+// progress of an interrupted chunked migration is stored, as the version of
+// the migration followed by the key to resume from.
+var migrationCheckpointKey = []byte("mcp")
+
+// chunkedMigration is a migration which processes the records of buckets too
+// large to be rewritten within a single transaction in bounded chunks. Each
+// invocation processes a single chunk, starting at resumeKey, or at the first
+// record if resumeKey is nil, and returns the key of the first record of the
+// next chunk, or nil once all records have been processed.
+type chunkedMigration func(tx *bolt.Tx, resumeKey []byte) ([]byte, error)
+
+// singleTx returns a migration applying all remaining chunks of the chunked
+// migration of the passed version within a single transaction.
+func (m chunkedMigration) singleTx(number uint32) migration {
+	return func(tx *bolt.Tx) error {
+		var resumeKey []byte
+		if meta := tx.Bucket(metaBucket); meta != nil {
+			resumeKey = fetchMigrationCheckpoint(meta, number)
+		}
+
+		for {
+			next, err := m(tx, resumeKey)
+			if err != nil {
+				return err
+			}
+			if next == nil {
+				return nil
+			}
+
+			resumeKey = next
+		}
+	}
+}
+
+// applyChunkedMigration applies the chunked migration of the passed version,
+// committing each chunk within a transaction of its own along with a
+// checkpoint of its progress. If the migration is interrupted, it resumes
+// from the last checkpoint the next time it's applied. The version of the
+// database is only bumped once all chunks have been processed.
+func (d *DB) applyChunkedMigration(v version) error {
+	log.Infof("Applying chunked migration #%v", v.number)
+
+	var numChunks int
+	for {
+		var done bool
+		err := d.Update(func(tx *bolt.Tx) error {
+			meta, err := tx.CreateBucketIfNotExists(metaBucket)
+			if err != nil {
+				return err
+			}
+
+			resumeKey := fetchMigrationCheckpoint(meta, v.number)
+			if resumeKey != nil && numChunks == 0 {
+				log.Infof("Resuming migration #%v at key %x",
+					v.number, resumeKey)
+			}
+
+			next, err := v.chunkedMigration(tx, resumeKey)
+			if err != nil {
+				return err
+			}
+
+			// Once all chunks have been processed, the checkpoint
+			// is removed and the new version recorded within the
+			// same transaction as the final chunk.
+			if next == nil {
+				done = true
+
+				err := meta.Delete(migrationCheckpointKey)
+				if err != nil {
+					return err
+				}
+
+				return putDbVersion(
+					meta, &Meta{DbVersionNumber: v.number},
+				)
+			}
+
+			return putMigrationCheckpoint(meta, v.number, next)
+		})
+		if err != nil {
+			log.Infof("Unable to apply migration #%v", v.number)
+			return err
+		}
+
+		numChunks++
+		if done {
+			log.Infof("Applied migration #%v in %v chunks",
+				v.number, numChunks)
+			return nil
+		}
+
+		log.Debugf("Migration #%v processed chunk %v", v.number,
+			numChunks)
+	}
+}
+
+// fetchMigrationCheckpoint returns the key the chunked migration of the passed
+// version should resume from, or nil if there is no checkpoint for it.
+func fetchMigrationCheckpoint(meta *bolt.Bucket, number uint32) []byte {
+	checkpoint := meta.Get(migrationCheckpointKey)
+	if len(checkpoint) < 4 || byteOrder.Uint32(checkpoint[:4]) != number {
+		return nil
+	}
+
+	return append([]byte(nil), checkpoint[4:]...)
+}
+
+// putMigrationCheckpoint records that the chunked migration of the passed
+// version should resume from resumeKey.
+func putMigrationCheckpoint(meta *bolt.Bucket, number uint32,
+	resumeKey []byte) error {
+
+	checkpoint := make([]byte, 4+len(resumeKey))
+	byteOrder.PutUint32(checkpoint[:4], number)
+	copy(checkpoint[4:], resumeKey)
+
+	return meta.Put(migrationCheckpointKey, checkpoint)
+}
@@ -0,0 +1,160 @@
+package channeldb
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+// errRollbackDryRun is returned from within the transaction of a migration dry
+// run, rolling it back.
+var errRollbackDryRun = errors.New("rollback dry run")
+
+// bucketSize is the number of keys within a bucket, including all nested
+// buckets, along with the total size of those keys and their values.
+type bucketSize struct {
+	keys  int
+	bytes int
+}
+
+// addBucketSize adds the size of the bucket, including all of its nested
+// buckets, to size.
+func addBucketSize(b *bolt.Bucket, size *bucketSize) error {
+	return b.ForEach(func(k, v []byte) error {
+		size.keys++
+		size.bytes += len(k) + len(v)
+
+		if v != nil {
+			return nil
+		}
+		if nested := b.Bucket(k); nested != nil {
+			return addBucketSize(nested, size)
+		}
+		return nil
+	})
+}
+
+// fetchBucketSizes returns the size of each top-level bucket of the database,
+// as seen by the passed transaction.
+func fetchBucketSizes(tx *bolt.Tx) (map[string]bucketSize, error) {
+	sizes := make(map[string]bucketSize)
+	err := tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		var size bucketSize
+		if err := addBucketSize(b, &size); err != nil {
+			return err
+		}
+
+		sizes[string(name)] = size
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sizes, nil
+}
+
+// logBucketChanges logs each top-level bucket whose size differs between
+// before and after, returning the number of such buckets.
+func logBucketChanges(migrationVersion uint32, before,
+	after map[string]bucketSize) int {
+
+	var names []string
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var numChanged int
+	for _, name := range names {
+		sizeBefore, sizeAfter := before[name], after[name]
+		if sizeBefore == sizeAfter {
+			continue
+		}
+
+		log.Infof("Migration #%v would change bucket %q: keys %v -> "+
+			"%v, bytes %v -> %v", migrationVersion, name,
+			sizeBefore.keys, sizeAfter.keys, sizeBefore.bytes,
+			sizeAfter.bytes)
+		numChanged++
+	}
+
+	return numChanged
+}
+
+// dryRunMigrations applies the passed migrations within a single transaction,
+// logging how each of them changes the size of the top-level buckets, and
+// then rolls the transaction back. If all migrations succeed,
+// ErrMigrationDryRun is returned.
+func dryRunMigrations(d *DB, migrations []migration,
+	migrationVersions []uint32) error {
+
+	log.Infof("Performing dry run of database schema migration")
+
+	err := d.Update(func(tx *bolt.Tx) error {
+		for i, migration := range migrations {
+			if migration == nil {
+				continue
+			}
+
+			before, err := fetchBucketSizes(tx)
+			if err != nil {
+				return err
+			}
+
+			log.Infof("Applying migration #%v",
+				migrationVersions[i])
+
+			if err := migration(tx); err != nil {
+				return fmt.Errorf("migration #%v failed: %v",
+					migrationVersions[i], err)
+			}
+
+			after, err := fetchBucketSizes(tx)
+			if err != nil {
+				return err
+			}
+
+			numChanged := logBucketChanges(
+				migrationVersions[i], before, after,
+			)
+			if numChanged == 0 {
+				log.Infof("Migration #%v wouldn't change the "+
+					"size of any bucket",
+					migrationVersions[i])
+			}
+		}
+
+		return errRollbackDryRun
+	})
+	if err != errRollbackDryRun {
+		return err
+	}
+
+	log.Infof("Dry run of database schema migration complete, no " +
+		"changes were made")
+
+	return ErrMigrationDryRun
+}
+
+// backupBeforeMigration writes a copy of the database at the passed version
+// next to it, named after the version and the current time.
+func (d *DB) backupBeforeMigration(version uint32) error {
+	backupPath := filepath.Join(d.dbPath, fmt.Sprintf("%v.v%v.%v.backup",
+		filepath.Base(d.Path()), version, time.Now().Format("20060102-150405")))
+
+	log.Infof("Backing up database to %v before migration", backupPath)
+
+	return d.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(backupPath, dbFilePermission)
+	})
+}
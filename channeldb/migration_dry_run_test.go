@@ -0,0 +1,157 @@
+package channeldb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestMigrationDryRun tests that a dry run of the pending migrations leaves
+// the database untouched, while a real migration backs it up first.
+func TestMigrationDryRun(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucketName := []byte("somebucket")
+	key := []byte("somekey")
+	beforeMigration := []byte("beforemigration")
+	afterMigration := []byte("aftermigration")
+
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(bucketName)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(key, beforeMigration)
+	})
+	if err != nil {
+		t.Fatalf("unable to populate db: %v", err)
+	}
+
+	if err := cdb.PutMeta(&Meta{DbVersionNumber: 0}); err != nil {
+		t.Fatalf("unable to store meta data: %v", err)
+	}
+
+	versions := []version{
+		{
+			number:    0,
+			migration: nil,
+		},
+		{
+			number: 1,
+			migration: func(tx *bolt.Tx) error {
+				bucket := tx.Bucket(bucketName)
+				return bucket.Put(key, afterMigration)
+			},
+		},
+	}
+
+	// assertState asserts the version of the database and the value
+	// stored under the test key.
+	assertState := func(expVersion uint32, expValue []byte) {
+		t.Helper()
+
+		meta, err := cdb.FetchMeta(nil)
+		if err != nil {
+			t.Fatalf("unable to fetch meta data: %v", err)
+		}
+		if meta.DbVersionNumber != expVersion {
+			t.Fatalf("expected version %v, got %v", expVersion,
+				meta.DbVersionNumber)
+		}
+
+		err = cdb.View(func(tx *bolt.Tx) error {
+			value := tx.Bucket(bucketName).Get(key)
+			if !bytes.Equal(value, expValue) {
+				t.Fatalf("expected value %s, got %s",
+					expValue, value)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// listBackups returns the names of all backups written next to the
+	// database.
+	listBackups := func() []string {
+		t.Helper()
+
+		files, err := ioutil.ReadDir(cdb.Path())
+		if err != nil {
+			t.Fatalf("unable to read db dir: %v", err)
+		}
+
+		var backups []string
+		for _, file := range files {
+			if strings.HasSuffix(file.Name(), ".backup") {
+				backups = append(backups, file.Name())
+			}
+		}
+		return backups
+	}
+
+	// A dry run should leave both the version and the data untouched,
+	// without writing a backup.
+	cdb.dryRunMigration = true
+	if err := cdb.syncVersions(versions); err != ErrMigrationDryRun {
+		t.Fatalf("expected ErrMigrationDryRun, got %v", err)
+	}
+	assertState(0, beforeMigration)
+	if backups := listBackups(); len(backups) != 0 {
+		t.Fatalf("dry run wrote backups: %v", backups)
+	}
+
+	// Applying the migration for real should write a backup holding the
+	// previous version of the database.
+	cdb.dryRunMigration = false
+	if err := cdb.syncVersions(versions); err != nil {
+		t.Fatalf("unable to migrate: %v", err)
+	}
+	assertState(1, afterMigration)
+
+	backups := listBackups()
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %v", backups)
+	}
+	if !strings.HasPrefix(backups[0], dbName+".v0.") {
+		t.Fatalf("unexpected backup name %v", backups[0])
+	}
+
+	backupDB, err := bolt.Open(
+		filepath.Join(cdb.Path(), backups[0]), dbFilePermission, nil,
+	)
+	if err != nil {
+		t.Fatalf("unable to open backup: %v", err)
+	}
+	defer backupDB.Close()
+
+	err = backupDB.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(bucketName).Get(key)
+		if !bytes.Equal(value, beforeMigration) {
+			t.Fatalf("expected backup value %s, got %s",
+				beforeMigration, value)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With no migrations pending, a dry run should still be reported as
+	// such.
+	cdb.dryRunMigration = true
+	if err := cdb.syncVersions(versions); err != ErrMigrationDryRun {
+		t.Fatalf("expected ErrMigrationDryRun, got %v", err)
+	}
+}
@@ -1 +1,393 @@
 package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+
+	"github.com/coreos/bbolt"
+)
+
+// migratePolicyEncoding rewrites all payment hash and node policies which are
+// still stored in the legacy fixed-width format using the versioned TLV
+// format.
+func migratePolicyEncoding(tx *bolt.Tx) error {
+	for _, bucketKey := range [][]byte{policyBucket, nodePolicyBucket} {
+		policies := tx.Bucket(bucketKey)
+		if policies == nil {
+			continue
+		}
+
+		// We'll first decode all legacy records, as it isn't safe to
+		// modify a bucket while iterating over it.
+		var (
+			keys     [][]byte
+			migrated [][]byte
+		)
+		err := policies.ForEach(func(k, v []byte) error {
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket.
+			if v == nil {
+				return nil
+			}
+
+			policy, err := deserializeLegacyPolicy(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			if err := serializePolicy(&b, policy); err != nil {
+				return err
+			}
+
+			keys = append(keys, append([]byte(nil), k...))
+			migrated = append(migrated, b.Bytes())
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, k := range keys {
+			if err := policies.Put(k, migrated[i]); err != nil {
+				return err
+			}
+		}
+
+		log.Infof("Migrated %v policies in bucket %s to TLV encoding",
+			len(keys), bucketKey)
+	}
+
+	return nil
+}
+
+// migratePolicyFeeIndex creates the fee index of the policy bucket, and
+// populates it with an entry for each existing payment hash policy.
+func migratePolicyFeeIndex(tx *bolt.Tx) error {
+	policies := tx.Bucket(policyBucket)
+	if policies == nil {
+		return nil
+	}
+
+	feeIndex, err := policies.CreateBucketIfNotExists(policyFeeIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	// We'll first gather the index keys of all policies, as it isn't
+	// safe to modify the bucket while iterating over it.
+	var indexKeys [][]byte
+	err = policies.ForEach(func(k, v []byte) error {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil {
+			return nil
+		}
+
+		policy, err := deserializePolicy(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		indexKeys = append(indexKeys, feeIndexKey(policy.Fee, k))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range indexKeys {
+		if err := feeIndex.Put(k, []byte{}); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Indexed %v policies by fee", len(indexKeys))
+
+	return nil
+}
+
+// migratePaymentHashIndex returns a chunked migration which creates the
+// payment hash index, and populates it with an entry for each existing
+// payment, indexing at most chunkSize payments per chunk.
+func migratePaymentHashIndex(chunkSize int) chunkedMigration {
+	return func(tx *bolt.Tx, resumeKey []byte) ([]byte, error) {
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return nil, nil
+		}
+
+		hashIndex, err := tx.CreateBucketIfNotExists(
+			paymentHashIndexBucket,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor := payments.Cursor()
+		k, v := cursor.First()
+		if resumeKey != nil {
+			k, v = cursor.Seek(resumeKey)
+		}
+
+		var numIndexed int
+		for ; k != nil; k, v = cursor.Next() {
+			if numIndexed == chunkSize {
+				return append([]byte(nil), k...), nil
+			}
+
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket.
+			if v == nil {
+				continue
+			}
+
+			payment, err := fetchPayment(nil, k, v)
+			if err != nil {
+				return nil, err
+			}
+
+			paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
+			indexKey := paymentHashIndexKey(
+				paymentHash, payment.SequenceNum,
+			)
+			if err := hashIndex.Put(indexKey, []byte{}); err != nil {
+				return nil, err
+			}
+
+			numIndexed++
+		}
+
+		log.Infof("Indexed remaining %v payments by payment hash",
+			numIndexed)
+
+		return nil, nil
+	}
+}
+
+// migrateClosingTxidIndex indexes the summaries of all closed channels by the
+// txid of the transaction which closed them.
+func migrateClosingTxidIndex(tx *bolt.Tx) error {
+	closedChans := tx.Bucket(closedChannelBucket)
+	if closedChans == nil {
+		return nil
+	}
+
+	var numIndexed int
+	err := closedChans.ForEach(func(chanID, summaryBytes []byte) error {
+		summary, err := deserializeCloseChannelSummary(
+			bytes.NewReader(summaryBytes),
+		)
+		if err != nil {
+			return err
+		}
+
+		err = indexClosingTxid(tx, summary.ClosingTXID, chanID)
+		if err != nil {
+			return err
+		}
+
+		numIndexed++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Indexed %v closed channels by closing txid", numIndexed)
+
+	return nil
+}
+
+// migrateCompactRevocationLog rewrites the revocation log of each open
+// channel, which stores every revoked state of the remote commitment chain in
+// full, using the compact revocation log entry format.
+func migrateCompactRevocationLog(tx *bolt.Tx) error {
+	openChanBucket := tx.Bucket(openChannelBucket)
+	if openChanBucket == nil {
+		return nil
+	}
+
+	// The revocation log of each channel is nested within the buckets of
+	// its node and chain, so we'll first gather all of them before
+	// rewriting any entries.
+	var (
+		chainBuckets []*bolt.Bucket
+		logBuckets   []*bolt.Bucket
+	)
+	collectChains := func(chainBucket *bolt.Bucket) error {
+		chainBuckets = append(chainBuckets, chainBucket)
+		return nil
+	}
+	err := forEachSubBucket(
+		openChanBucket, func(nodeBucket *bolt.Bucket) error {
+			return forEachSubBucket(nodeBucket, collectChains)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	collectLogs := func(chanBucket *bolt.Bucket) error {
+		logBucket := chanBucket.Bucket(revocationLogBucket)
+		if logBucket != nil {
+			logBuckets = append(logBuckets, logBucket)
+		}
+
+		return nil
+	}
+	for _, chainBucket := range chainBuckets {
+		err := forEachSubBucket(chainBucket, collectLogs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var numMigrated, oldSize, newSize int
+	for _, logBucket := range logBuckets {
+		// We'll first decode all entries of the log, as it isn't safe
+		// to modify a bucket while iterating over it.
+		var (
+			keys     [][]byte
+			migrated [][]byte
+		)
+		err := logBucket.ForEach(func(k, v []byte) error {
+			commit, err := deserializeChanCommit(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			err = serializeRevocationLogEntry(&b, &commit)
+			if err != nil {
+				return err
+			}
+
+			keys = append(keys, append([]byte(nil), k...))
+			migrated = append(migrated, b.Bytes())
+
+			oldSize += len(v)
+			newSize += b.Len()
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, k := range keys {
+			if err := logBucket.Put(k, migrated[i]); err != nil {
+				return err
+			}
+		}
+
+		numMigrated += len(keys)
+	}
+
+	log.Infof("Compacted %v revocation log entries of %v channels "+
+		"from %v to %v bytes", numMigrated, len(logBuckets), oldSize,
+		newSize)
+
+	return nil
+}
+
+// migrateInvoiceIndices assigns an add index to each existing invoice in the
+// order the invoices were added, and a settle index to each settled invoice in
+// the order the invoices were settled.
+func migrateInvoiceIndices(tx *bolt.Tx) error {
+	invoices := tx.Bucket(invoiceBucket)
+	if invoices == nil {
+		return nil
+	}
+
+	// We'll first decode all invoices, as it isn't safe to modify the
+	// bucket while iterating over it. As invoice IDs are big endian, the
+	// invoices are decoded in the order they were added.
+	var (
+		keys        [][]byte
+		allInvoices []*Invoice
+	)
+	err := invoices.ForEach(func(k, v []byte) error {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil {
+			return nil
+		}
+
+		invoice, err := deserializeStoredInvoice(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, append([]byte(nil), k...))
+		allInvoices = append(allInvoices, invoice)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var settled []int
+	for i, invoice := range allInvoices {
+		err := indexInvoiceAdd(invoices, invoice, keys[i])
+		if err != nil {
+			return err
+		}
+
+		if invoice.Terms.Settled {
+			settled = append(settled, i)
+		}
+	}
+
+	sort.SliceStable(settled, func(i, j int) bool {
+		return allInvoices[settled[i]].SettleDate.Before(
+			allInvoices[settled[j]].SettleDate,
+		)
+	})
+	for _, i := range settled {
+		err := indexInvoiceSettle(invoices, allInvoices[i], keys[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, k := range keys {
+		var b bytes.Buffer
+		if err := serializeStoredInvoice(&b, allInvoices[i]); err != nil {
+			return err
+		}
+
+		if err := invoices.Put(k, b.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Indexed %v invoices, of which %v are settled", len(keys),
+		len(settled))
+
+	return nil
+}
+
+// forEachSubBucket calls cb for each of the nested buckets of the passed
+// bucket, skipping any values stored directly within it.
+func forEachSubBucket(bucket *bolt.Bucket,
+	cb func(*bolt.Bucket) error) error {
+
+	return bucket.ForEach(func(k, v []byte) error {
+		// If the value isn't nil, then this key doesn't refer to a
+		// sub-bucket.
+		if v != nil {
+			return nil
+		}
+
+		subBucket := bucket.Bucket(k)
+		if subBucket == nil {
+			return nil
+		}
+
+		return cb(subBucket)
+	})
+}
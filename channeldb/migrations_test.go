@@ -0,0 +1,623 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
+)
+
+// serializeLegacyPolicy encodes the policy using the unversioned legacy
+// format, which only carries the payment hash, the fee and the expiry.
+func serializeLegacyPolicy(p *Policy) []byte {
+	var b bytes.Buffer
+	var scratch [8]byte
+	b.Write(p.PaymentHash[:])
+	byteOrder.PutUint64(scratch[:], uint64(p.Fee))
+	b.Write(scratch[:])
+	byteOrder.PutUint32(scratch[:4], p.ExpiryHeight)
+	b.Write(scratch[:4])
+	var expiryTime uint64
+	if !p.ExpiryTime.IsZero() {
+		expiryTime = uint64(p.ExpiryTime.Unix())
+	}
+	byteOrder.PutUint64(scratch[:], expiryTime)
+	b.Write(scratch[:])
+	return b.Bytes()
+}
+
+// TestMigratePolicyEncoding checks that policies stored in the legacy
+// fixed-width format are rewritten using the TLV format.
+func TestMigratePolicyEncoding(t *testing.T) {
+	t.Parallel()
+
+	hashPolicy := makeFakePolicy(1, 1000)
+	hashPolicy.ExpiryHeight = 500000
+	hashPolicy.ExpiryTime = time.Unix(time.Now().Unix(), 0)
+
+	nodePolicy := &Policy{Fee: 2000}
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	// Store the policies using the unversioned legacy format.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			policies, err := tx.CreateBucketIfNotExists(policyBucket)
+			if err != nil {
+				return err
+			}
+			err = policies.Put(
+				hashPolicy.PaymentHash[:],
+				serializeLegacyPolicy(hashPolicy),
+			)
+			if err != nil {
+				return err
+			}
+
+			nodePolicies, err := tx.CreateBucketIfNotExists(
+				nodePolicyBucket,
+			)
+			if err != nil {
+				return err
+			}
+			return nodePolicies.Put(
+				nodePub[:], serializeLegacyPolicy(nodePolicy),
+			)
+		})
+		if err != nil {
+			t.Fatalf("unable to store legacy policies: %v", err)
+		}
+	}
+
+	// After the migration, both policies should be readable using the
+	// regular accessors.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'policy encoding' wasn't applied")
+		}
+
+		dbHashPolicy, err := d.LookupPolicy(hashPolicy.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to lookup policy: %v", err)
+		}
+		if !reflect.DeepEqual(hashPolicy, dbHashPolicy) {
+			t.Fatalf("policies don't match after migration: "+
+				"%v vs %v", spew.Sdump(hashPolicy),
+				spew.Sdump(dbHashPolicy))
+		}
+
+		dbNodePolicy, err := d.LookupNodePolicy(nodePub)
+		if err != nil {
+			t.Fatalf("unable to lookup node policy: %v", err)
+		}
+		if !reflect.DeepEqual(nodePolicy, dbNodePolicy) {
+			t.Fatalf("node policies don't match after migration: "+
+				"%v vs %v", spew.Sdump(nodePolicy),
+				spew.Sdump(dbNodePolicy))
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migratePolicyEncoding,
+		false)
+}
+
+// TestMigratePolicyFeeIndex checks that the fee index is populated with all
+// existing payment hash policies.
+func TestMigratePolicyFeeIndex(t *testing.T) {
+	t.Parallel()
+
+	cheap := makeFakePolicy(1, 1000)
+	generous := makeFakePolicy(2, 5000)
+
+	// Store the policies directly, without creating the fee index.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			policies, err := tx.CreateBucketIfNotExists(policyBucket)
+			if err != nil {
+				return err
+			}
+
+			for _, policy := range []*Policy{generous, cheap} {
+				var b bytes.Buffer
+				err := serializePolicy(&b, policy)
+				if err != nil {
+					return err
+				}
+
+				key := policy.PaymentHash[:]
+				if err := policies.Put(key, b.Bytes()); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to store policies: %v", err)
+		}
+	}
+
+	// After the migration, both policies should be found through the fee
+	// index, in ascending order of their fee.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'policy fee index' wasn't applied")
+		}
+
+		dbPolicies, err := d.PoliciesWithFeeAbove(0)
+		if err != nil {
+			t.Fatalf("unable to query policies: %v", err)
+		}
+		expected := []*Policy{cheap, generous}
+		if !reflect.DeepEqual(expected, dbPolicies) {
+			t.Fatalf("policies don't match after migration: "+
+				"%v vs %v", spew.Sdump(expected),
+				spew.Sdump(dbPolicies))
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migratePolicyFeeIndex,
+		false)
+}
+
+// TestMigratePoliciesFromBaseVersion checks that policies written by the base
+// version of the database are upgraded to the latest policy schema by
+// applying all registered migrations in order.
+func TestMigratePoliciesFromBaseVersion(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	cheap := makeFakePolicy(1, 1000)
+	generous := makeFakePolicy(2, 5000)
+
+	// Store the policies in the legacy format, and roll back the version
+	// of the database to the base version which used it.
+	err = cdb.Update(func(tx *bolt.Tx) error {
+		policies, err := tx.CreateBucketIfNotExists(policyBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, policy := range []*Policy{generous, cheap} {
+			err := policies.Put(
+				policy.PaymentHash[:],
+				serializeLegacyPolicy(policy),
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return putMeta(&Meta{DbVersionNumber: 0}, tx)
+	})
+	if err != nil {
+		t.Fatalf("unable to store legacy policies: %v", err)
+	}
+
+	if err := cdb.syncVersions(dbVersions); err != nil {
+		t.Fatalf("unable to migrate database: %v", err)
+	}
+
+	meta, err := cdb.FetchMeta(nil)
+	if err != nil {
+		t.Fatalf("unable to fetch meta: %v", err)
+	}
+	latestVersion := getLatestDBVersion(dbVersions)
+	if meta.DbVersionNumber != latestVersion {
+		t.Fatalf("expected db version %v, got %v", latestVersion,
+			meta.DbVersionNumber)
+	}
+
+	// Both policies should now be readable using the TLV format, and be
+	// found through the fee index in ascending order of their fee.
+	dbPolicies, err := cdb.PoliciesWithFeeAbove(0)
+	if err != nil {
+		t.Fatalf("unable to query policies: %v", err)
+	}
+	expected := []*Policy{cheap, generous}
+	if !reflect.DeepEqual(expected, dbPolicies) {
+		t.Fatalf("policies don't match after migration: %v vs %v",
+			spew.Sdump(expected), spew.Sdump(dbPolicies))
+	}
+}
+
+// TestMigratePaymentHashIndex checks that payments stored before the payment
+// hash index existed are indexed by their payment hash.
+func TestMigratePaymentHashIndex(t *testing.T) {
+	t.Parallel()
+
+	first := makeFakePayment()
+	first.SequenceNum = 1
+	other, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("unable to create payment: %v", err)
+	}
+	other.SequenceNum = 2
+	second := makeFakePayment()
+	second.Fee = 202
+	second.SequenceNum = 3
+
+	// Store the payments directly, without creating the payment hash
+	// index.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			payments, err := tx.CreateBucketIfNotExists(
+				paymentBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			toStore := []*OutgoingPayment{first, other, second}
+			for _, p := range toStore {
+				var b bytes.Buffer
+				err := serializeOutgoingPayment(&b, p)
+				if err != nil {
+					return err
+				}
+
+				key := paymentIndexKey(p.SequenceNum)
+				err = payments.Put(key, b.Bytes())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to store payments: %v", err)
+		}
+	}
+
+	// After the migration, both payments to the same hash should be
+	// found through the index, in the order in which they were made.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'payment hash index' wasn't applied")
+		}
+
+		paymentHash := sha256.Sum256(first.PaymentPreimage[:])
+		dbPayments, err := d.FetchPaymentsByHash(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch payments: %v", err)
+		}
+		expected := []*OutgoingPayment{first, second}
+		if !reflect.DeepEqual(expected, dbPayments) {
+			t.Fatalf("payments don't match after migration: "+
+				"%v vs %v", spew.Sdump(expected),
+				spew.Sdump(dbPayments))
+		}
+	}
+
+	// Index a single payment per chunk, ensuring the migration resumes
+	// correctly across chunks.
+	applyVersion(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		version{
+			number:           1,
+			chunkedMigration: migratePaymentHashIndex(1),
+		},
+		false)
+}
+
+// TestMigrateClosingTxidIndex checks that the closing txid index is populated
+// with all existing closed channels which have a closing transaction.
+func TestMigrateClosingTxidIndex(t *testing.T) {
+	t.Parallel()
+
+	closed := &ChannelCloseSummary{
+		ChanPoint:   wire.OutPoint{Index: 1},
+		ClosingTXID: chainhash.Hash{1},
+		RemotePub:   pubKey,
+		Capacity:    1000,
+		CloseType:   CooperativeClose,
+	}
+	canceled := &ChannelCloseSummary{
+		ChanPoint: wire.OutPoint{Index: 2},
+		RemotePub: pubKey,
+		CloseType: FundingCanceled,
+	}
+
+	// Store the summaries directly, without creating the index.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			closedChans, err := tx.CreateBucketIfNotExists(
+				closedChannelBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			for _, s := range []*ChannelCloseSummary{
+				closed, canceled,
+			} {
+				var k, v bytes.Buffer
+				err := writeOutpoint(&k, &s.ChanPoint)
+				if err != nil {
+					return err
+				}
+				err = serializeChannelCloseSummary(&v, s)
+				if err != nil {
+					return err
+				}
+
+				err = closedChans.Put(k.Bytes(), v.Bytes())
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to store summaries: %v", err)
+		}
+	}
+
+	// After the migration, the closed channel should be found by its
+	// closing txid, while the canceled one shouldn't be indexed.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'closing txid index' wasn't applied")
+		}
+
+		summary, err := d.LookupCloseByTxid(&closed.ClosingTXID)
+		if err != nil {
+			t.Fatalf("unable to lookup closed channel: %v", err)
+		}
+		if !reflect.DeepEqual(closed, summary) {
+			t.Fatalf("summaries don't match after migration: "+
+				"%v vs %v", spew.Sdump(closed),
+				spew.Sdump(summary))
+		}
+
+		_, err = d.LookupCloseByTxid(&chainhash.Hash{})
+		if err != ErrClosedChannelNotFound {
+			t.Fatalf("expected ErrClosedChannelNotFound, got %v",
+				err)
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateClosingTxidIndex,
+		false)
+}
+
+// TestMigrateCompactRevocationLog checks that revoked states stored in full
+// within the revocation log are rewritten in the compact format.
+func TestMigrateCompactRevocationLog(t *testing.T) {
+	t.Parallel()
+
+	var (
+		channel *OpenChannel
+		revoked ChannelCommitment
+	)
+
+	// Create an open channel, and append a revoked state to its
+	// revocation log using the legacy, full format.
+	beforeMigrationFunc := func(d *DB) {
+		var err error
+		channel, err = createTestChannelState(d)
+		if err != nil {
+			t.Fatalf("unable to create channel state: %v", err)
+		}
+
+		addr := &net.TCPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: 18555,
+		}
+		if err := channel.SyncPending(addr, 101); err != nil {
+			t.Fatalf("unable to save channel state: %v", err)
+		}
+
+		revoked = channel.RemoteCommitment
+		revoked.CommitHeight = 1
+		revoked.Htlcs = []HTLC{{
+			Signature:     testSig.Serialize(),
+			RHash:         key,
+			Amt:           10,
+			RefundTimeout: 144,
+			OutputIndex:   2,
+			Incoming:      true,
+			OnionBlob:     bytes.Repeat([]byte{2}, 10),
+			HtlcIndex:     3,
+			LogIndex:      4,
+		}}
+
+		err = d.Update(func(tx *bolt.Tx) error {
+			chanBucket, err := updateChanBucket(
+				tx, channel.IdentityPub,
+				&channel.FundingOutpoint, channel.ChainHash,
+			)
+			if err != nil {
+				return err
+			}
+
+			logBucket, err := chanBucket.CreateBucketIfNotExists(
+				revocationLogBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			var b bytes.Buffer
+			if err := serializeChanCommit(&b, &revoked); err != nil {
+				return err
+			}
+
+			logKey := makeLogKey(revoked.CommitHeight)
+			return logBucket.Put(logKey[:], b.Bytes())
+		})
+		if err != nil {
+			t.Fatalf("unable to store revocation log: %v", err)
+		}
+	}
+
+	// After the migration, the revoked state should be found with all
+	// the fields retained within the compact format.
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'compact revocation log' wasn't " +
+				"applied")
+		}
+
+		prevCommit, err := channel.FindPreviousState(
+			revoked.CommitHeight,
+		)
+		if err != nil {
+			t.Fatalf("unable to fetch revoked state: %v", err)
+		}
+		assertCommitmentEqual(t, revokedCommitment(&revoked), prevCommit)
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateCompactRevocationLog,
+		false)
+}
+
+// TestMigrateInvoiceIndices checks that existing invoices are assigned add
+// indices in the order they were added, and settle indices in the order they
+// were settled.
+func TestMigrateInvoiceIndices(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(time.Now().Unix(), 0)
+	invoices := make([]*Invoice, 3)
+	for i := range invoices {
+		invoices[i] = &Invoice{
+			CreationDate: now,
+		}
+		invoices[i].Terms.PaymentPreimage[0] = byte(i)
+	}
+
+	// The last invoice is settled before the first one, while the second
+	// one isn't settled at all.
+	invoices[0].Terms.Settled = true
+	invoices[0].SettleDate = now.Add(time.Minute)
+	invoices[2].Terms.Settled = true
+	invoices[2].SettleDate = now
+
+	// Store the invoices directly, without assigning any indices.
+	beforeMigrationFunc := func(d *DB) {
+		err := d.Update(func(tx *bolt.Tx) error {
+			invoiceB, err := tx.CreateBucketIfNotExists(
+				invoiceBucket,
+			)
+			if err != nil {
+				return err
+			}
+
+			for i, invoice := range invoices {
+				var k [4]byte
+				byteOrder.PutUint32(k[:], uint32(i))
+
+				var v bytes.Buffer
+				err := serializeStoredInvoice(&v, invoice)
+				if err != nil {
+					return err
+				}
+
+				if err := invoiceB.Put(k[:], v.Bytes()); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unable to store invoices: %v", err)
+		}
+	}
+
+	afterMigrationFunc := func(d *DB) {
+		meta, err := d.FetchMeta(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if meta.DbVersionNumber != 1 {
+			t.Fatal("migration 'invoice indices' wasn't applied")
+		}
+
+		added, err := d.InvoicesAddedSince(0)
+		if err != nil {
+			t.Fatalf("unable to fetch added invoices: %v", err)
+		}
+		assertInvoiceIndices(t, invoices, added)
+		for i, invoice := range added {
+			if invoice.AddIndex != uint64(i+1) {
+				t.Fatalf("expected add index %v, got %v",
+					i+1, invoice.AddIndex)
+			}
+		}
+
+		settled, err := d.InvoicesSettledSince(0)
+		if err != nil {
+			t.Fatalf("unable to fetch settled invoices: %v", err)
+		}
+		assertInvoiceIndices(
+			t, []*Invoice{invoices[2], invoices[0]}, settled,
+		)
+		for i, invoice := range settled {
+			if invoice.SettleIndex != uint64(i+1) {
+				t.Fatalf("expected settle index %v, got %v",
+					i+1, invoice.SettleIndex)
+			}
+		}
+	}
+
+	applyMigration(t,
+		beforeMigrationFunc,
+		afterMigrationFunc,
+		migrateInvoiceIndices,
+		false)
+}
@@ -267,13 +267,15 @@ func deserializeLinkNode(r io.Reader) (*LinkNode, error) {
 	}
 	numAddrs := byteOrder.Uint32(buf[:4])
 
-	node.Addresses = make([]net.Addr, numAddrs)
+	// As the number of addresses isn't bounded, they're appended as
+	// they're read, rather than allocated upfront according to the
+	// stored count.
 	for i := uint32(0); i < numAddrs; i++ {
 		addr, err := deserializeAddr(r)
 		if err != nil {
 			return nil, err
 		}
-		node.Addresses[i] = addr
+		node.Addresses = append(node.Addresses, addr)
 	}
 
 	return node, nil
@@ -0,0 +1,245 @@
+package channeldb
+
+import (
+	"time"
+
+	"github.com/coreos/bbolt"
+)
+
+const (
+	// DefaultPolicyCacheSize is the default number of policies held in
+	// the in-memory policy cache.
+	DefaultPolicyCacheSize = 10000
+
+	// DefaultPolicyAuditRetention is the default duration for which
+	// records are kept within the policy audit log.
+	DefaultPolicyAuditRetention = 90 * 24 * time.Hour
+
+	// DefaultGraphBatchWindow is the default duration for which writes to
+	// the channel graph are collected before being committed within a
+	// single transaction.
+	DefaultGraphBatchWindow = 10 * time.Millisecond
+
+	// DefaultMaxBatchSize is the default maximum number of transaction
+	// functions committed within a single batch.
+	DefaultMaxBatchSize = bolt.DefaultMaxBatchSize
+
+	// DefaultMaxBatchDelay is the default maximum duration for which
+	// transaction functions are collected before a batch is committed.
+	DefaultMaxBatchDelay = bolt.DefaultMaxBatchDelay
+
+	// DefaultBatchRetries is the default number of times a batched
+	// transaction function is retried after a transaction conflict.
+	DefaultBatchRetries = 5
+
+	// DefaultBatchRetryBackoff is the default duration waited before the
+	// first retry of a batched transaction function after a transaction
+	// conflict.
+	DefaultBatchRetryBackoff = 10 * time.Millisecond
+
+	// maxBatchRetryBackoff bounds the duration waited between retries of
+	// a batched transaction function, which doubles with every retry.
+	maxBatchRetryBackoff = time.Second
+)
+
+// Options holds parameters for tuning and customizing a channeldb.DB.
+type Options struct {
+	// PolicyCacheSize is the maximum number of policies held in the
+	// in-memory policy cache. A value of zero disables the cache.
+	PolicyCacheSize int
+
+	// PolicyAuditRetention is the duration for which records are kept
+	// within the policy audit log. A value of zero keeps records
+	// indefinitely.
+	PolicyAuditRetention time.Duration
+
+	// ForwardingLogRetention is the duration for which events are kept
+	// within the forwarding log. A value of zero keeps events
+	// indefinitely.
+	ForwardingLogRetention time.Duration
+
+	// AutoCompact indicates whether the database is compacted each time
+	// it's opened.
+	AutoCompact bool
+
+	// AutoCompactFreeRatio is the fraction of the database which free
+	// pages must at least make up for it to be compacted when it's
+	// opened. A value of zero disables compaction due to free pages.
+	AutoCompactFreeRatio float64
+
+	// GraphCache indicates whether the channel graph is kept in memory,
+	// allowing path finding to traverse it without reading from disk.
+	GraphCache bool
+
+	// GraphBatchWindow is the duration for which node announcements and
+	// channel updates are collected before being written within a single
+	// transaction. A value of zero writes each of them within its own
+	// transaction.
+	GraphBatchWindow time.Duration
+
+	// ResetGraph indicates whether the channel graph is deleted when the
+	// database is opened, so it's rebuilt from the network. All channel
+	// state is left untouched.
+	ResetGraph bool
+
+	// MaxBatchSize is the maximum number of transaction functions
+	// committed within a single batch. A value of zero disables batching,
+	// committing each transaction function within its own transaction.
+	MaxBatchSize int
+
+	// MaxBatchDelay is the maximum duration for which transaction
+	// functions are collected before a batch is committed. Longer delays
+	// amortize the cost of syncing the database to disk over more
+	// transactions, at the cost of latency. A value of zero disables
+	// batching.
+	MaxBatchDelay time.Duration
+
+	// BatchRetries is the number of times a read-write transaction, batched
+	// or not, which fails with a retryable error such as ErrTxConflict is
+	// retried, before the error is returned.
+	BatchRetries int
+
+	// BatchRetryBackoff is the duration waited before the first retry of
+	// a read-write transaction. It doubles with every retry, up to
+	// a second, and is randomized by up to half of its value so that
+	// conflicting transactions don't retry in lockstep.
+	BatchRetryBackoff time.Duration
+
+	// ReadOnly indicates whether the database is opened read-only. A
+	// read-only database is never created, migrated or compacted, and
+	// refuses all Update and Batch transactions.
+	ReadOnly bool
+
+	// DryRunMigration indicates whether pending migrations are only
+	// applied within a transaction which is rolled back, logging their
+	// effects on the database. If set, Open always fails with
+	// ErrMigrationDryRun.
+	DryRunMigration bool
+}
+
+// DefaultOptions returns an Options populated with default values.
+func DefaultOptions() Options {
+	return Options{
+		PolicyCacheSize:      DefaultPolicyCacheSize,
+		PolicyAuditRetention: DefaultPolicyAuditRetention,
+		GraphCache:           true,
+		GraphBatchWindow:     DefaultGraphBatchWindow,
+		MaxBatchSize:         DefaultMaxBatchSize,
+		MaxBatchDelay:        DefaultMaxBatchDelay,
+		BatchRetries:         DefaultBatchRetries,
+		BatchRetryBackoff:    DefaultBatchRetryBackoff,
+	}
+}
+
+// OptionModifier is a function signature for modifying the default Options.
+type OptionModifier func(*Options)
+
+// OptionSetPolicyCacheSize sets the maximum number of policies held in the
+// in-memory policy cache.
+func OptionSetPolicyCacheSize(n int) OptionModifier {
+	return func(o *Options) {
+		o.PolicyCacheSize = n
+	}
+}
+
+// OptionSetPolicyAuditRetention sets the duration for which records are kept
+// within the policy audit log.
+func OptionSetPolicyAuditRetention(d time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.PolicyAuditRetention = d
+	}
+}
+
+// OptionSetForwardingLogRetention sets the duration for which events are kept
+// within the forwarding log.
+func OptionSetForwardingLogRetention(d time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.ForwardingLogRetention = d
+	}
+}
+
+// OptionAutoCompact sets whether the database is compacted each time it's
+// opened.
+func OptionAutoCompact(autoCompact bool) OptionModifier {
+	return func(o *Options) {
+		o.AutoCompact = autoCompact
+	}
+}
+
+// OptionSetAutoCompactFreeRatio sets the fraction of the database which free
+// pages must at least make up for it to be compacted when it's opened.
+func OptionSetAutoCompactFreeRatio(ratio float64) OptionModifier {
+	return func(o *Options) {
+		o.AutoCompactFreeRatio = ratio
+	}
+}
+
+// OptionReadOnly sets whether the database is opened read-only.
+func OptionReadOnly(readOnly bool) OptionModifier {
+	return func(o *Options) {
+		o.ReadOnly = readOnly
+	}
+}
+
+// OptionDryRunMigration sets whether pending migrations are only reported,
+// rather than applied.
+func OptionDryRunMigration(dryRun bool) OptionModifier {
+	return func(o *Options) {
+		o.DryRunMigration = dryRun
+	}
+}
+
+// OptionSetGraphCache sets whether the channel graph is kept in memory.
+func OptionSetGraphCache(enabled bool) OptionModifier {
+	return func(o *Options) {
+		o.GraphCache = enabled
+	}
+}
+
+// OptionResetGraph sets whether the channel graph is deleted when the database
+// is opened, so it's rebuilt from the network.
+func OptionResetGraph(reset bool) OptionModifier {
+	return func(o *Options) {
+		o.ResetGraph = reset
+	}
+}
+
+// OptionSetGraphBatchWindow sets the duration for which writes to the channel
+// graph are collected before being committed within a single transaction.
+func OptionSetGraphBatchWindow(window time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.GraphBatchWindow = window
+	}
+}
+
+// OptionSetMaxBatchSize sets the maximum number of transaction functions
+// committed within a single batch.
+func OptionSetMaxBatchSize(n int) OptionModifier {
+	return func(o *Options) {
+		o.MaxBatchSize = n
+	}
+}
+
+// OptionSetMaxBatchDelay sets the maximum duration for which transaction
+// functions are collected before a batch is committed.
+func OptionSetMaxBatchDelay(d time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.MaxBatchDelay = d
+	}
+}
+
+// OptionSetBatchRetries sets the number of times a batched transaction
+// function is retried after a transaction conflict.
+func OptionSetBatchRetries(n int) OptionModifier {
+	return func(o *Options) {
+		o.BatchRetries = n
+	}
+}
+
+// OptionSetBatchRetryBackoff sets the duration waited before the first retry
+// of a batched transaction function after a transaction conflict.
+func OptionSetBatchRetryBackoff(d time.Duration) OptionModifier {
+	return func(o *Options) {
+		o.BatchRetryBackoff = d
+	}
+}
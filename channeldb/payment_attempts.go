@@ -0,0 +1,299 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// paymentAttemptsBucket is the name of the bucket which stores the
+	// HTLC attempts of all payments. For each payment, it holds a
+	// sub-bucket keyed by the sequence number of the payment, within which
+	// each attempt is keyed by its big-endian index, in the order in
+	// which the attempts were made.
+	paymentAttemptsBucket = []byte("payment-attempts")
+)
+
+// AttemptHop describes a single hop of the route an HTLC attempt was sent
+// along.
+type AttemptHop struct {
+	// ChannelID is the short channel ID of the channel the HTLC was
+	// forwarded over to reach this hop.
+	ChannelID uint64
+
+	// PubKey is the compressed public key of the node at this hop.
+	PubKey [33]byte
+
+	// AmtToForward is the amount this hop was asked to forward to the
+	// next one.
+	AmtToForward lnwire.MilliSatoshi
+
+	// Fee is the fee this hop was offered for forwarding the HTLC.
+	Fee lnwire.MilliSatoshi
+
+	// OutgoingTimeLock is the time lock of the HTLC this hop was asked to
+	// extend to the next one.
+	OutgoingTimeLock uint32
+}
+
+// HTLCAttempt records a single HTLC which was dispatched in an attempt to
+// complete a payment, along with its outcome.
+type HTLCAttempt struct {
+	// AttemptTime is the time at which the HTLC was dispatched.
+	AttemptTime time.Time
+
+	// ResolveTime is the time at which the HTLC was either settled or
+	// failed. It's the zero time if the HTLC is still in flight.
+	ResolveTime time.Time
+
+	// TotalAmount is the amount of the HTLC extended to the first hop,
+	// including all fees.
+	TotalAmount lnwire.MilliSatoshi
+
+	// TotalFees is the sum of the fees offered to all hops of the route.
+	TotalFees lnwire.MilliSatoshi
+
+	// TotalTimeLock is the time lock of the HTLC extended to the first
+	// hop.
+	TotalTimeLock uint32
+
+	// Hops is the route the HTLC was sent along.
+	Hops []AttemptHop
+
+	// Failure describes why the attempt failed. It's empty if the
+	// attempt succeeded.
+	Failure string
+
+	// FailureSource is the compressed public key of the node which
+	// reported the failure of the attempt. It's all zeroes if the attempt
+	// didn't fail, or the source of its failure is unknown.
+	FailureSource [33]byte
+}
+
+// putPaymentAttempts stores the attempts of the payment with the passed
+// sequence number within the attempts bucket.
+func putPaymentAttempts(attemptsBucket *bolt.Bucket, seqKey []byte,
+	attempts []HTLCAttempt) error {
+
+	if len(attempts) == 0 {
+		return nil
+	}
+
+	paymentAttempts, err := attemptsBucket.CreateBucketIfNotExists(seqKey)
+	if err != nil {
+		return err
+	}
+
+	for i := range attempts {
+		var b bytes.Buffer
+		if err := serializeHTLCAttempt(&b, &attempts[i]); err != nil {
+			return err
+		}
+
+		var k [4]byte
+		binary.BigEndian.PutUint32(k[:], uint32(i))
+		if err := paymentAttempts.Put(k[:], b.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchPaymentAttempts returns the attempts of the payment with the passed
+// sequence number, in the order in which they were made. If the attempts
+// bucket is nil, or no attempts were stored for the payment, nil is returned.
+func fetchPaymentAttempts(attemptsBucket *bolt.Bucket,
+	seqKey []byte) ([]HTLCAttempt, error) {
+
+	if attemptsBucket == nil {
+		return nil, nil
+	}
+	paymentAttempts := attemptsBucket.Bucket(seqKey)
+	if paymentAttempts == nil {
+		return nil, nil
+	}
+
+	var attempts []HTLCAttempt
+	err := paymentAttempts.ForEach(func(_, v []byte) error {
+		attempt, err := deserializeHTLCAttempt(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		attempts = append(attempts, *attempt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// deleteFailedAttempts deletes the failed attempts of the payment with the
+// passed sequence number from the attempts bucket, returning the number of
+// deleted attempts. If no attempts remain, the sub-bucket of the payment is
+// deleted as well.
+func deleteFailedAttempts(attemptsBucket *bolt.Bucket,
+	seqKey []byte) (uint64, error) {
+
+	if attemptsBucket == nil {
+		return 0, nil
+	}
+	paymentAttempts := attemptsBucket.Bucket(seqKey)
+	if paymentAttempts == nil {
+		return 0, nil
+	}
+
+	var (
+		failedKeys  [][]byte
+		numAttempts int
+	)
+	err := paymentAttempts.ForEach(func(k, v []byte) error {
+		numAttempts++
+
+		attempt, err := deserializeHTLCAttempt(bytes.NewReader(v))
+		if err != nil {
+			return err
+		}
+
+		if attempt.Failure != "" {
+			key := append([]byte(nil), k...)
+			failedKeys = append(failedKeys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(failedKeys) == numAttempts {
+		if err := attemptsBucket.DeleteBucket(seqKey); err != nil {
+			return 0, err
+		}
+		return uint64(len(failedKeys)), nil
+	}
+
+	for _, k := range failedKeys {
+		if err := paymentAttempts.Delete(k); err != nil {
+			return 0, err
+		}
+	}
+
+	return uint64(len(failedKeys)), nil
+}
+
+func serializeHTLCAttempt(w io.Writer, a *HTLCAttempt) error {
+	err := writeElements(w,
+		serializeAttemptTime(a.AttemptTime),
+		serializeAttemptTime(a.ResolveTime),
+		a.TotalAmount, a.TotalFees, a.TotalTimeLock,
+		uint32(len(a.Hops)),
+	)
+	if err != nil {
+		return err
+	}
+
+	for i := range a.Hops {
+		if err := serializeAttemptHop(w, &a.Hops[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := writeElement(w, []byte(a.Failure)); err != nil {
+		return err
+	}
+
+	_, err = w.Write(a.FailureSource[:])
+	return err
+}
+
+func deserializeHTLCAttempt(r io.Reader) (*HTLCAttempt, error) {
+	var (
+		a                        HTLCAttempt
+		attemptTime, resolveTime uint64
+		numHops                  uint32
+	)
+	err := readElements(r,
+		&attemptTime, &resolveTime, &a.TotalAmount, &a.TotalFees,
+		&a.TotalTimeLock, &numHops,
+	)
+	if err != nil {
+		return nil, err
+	}
+	a.AttemptTime = deserializeAttemptTime(attemptTime)
+	a.ResolveTime = deserializeAttemptTime(resolveTime)
+
+	if numHops > maxPaymentHops {
+		return nil, fmt.Errorf("attempt route of length %v exceeds "+
+			"maximum of %v hops", numHops, maxPaymentHops)
+	}
+	a.Hops = make([]AttemptHop, numHops)
+	for i := range a.Hops {
+		if err := deserializeAttemptHop(r, &a.Hops[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	var failure []byte
+	if err := readElement(r, &failure); err != nil {
+		return nil, err
+	}
+	a.Failure = string(failure)
+
+	// Attempts recorded before the failure source was stored end here, in
+	// which case the source is left unknown.
+	_, err = io.ReadFull(r, a.FailureSource[:])
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// serializeAttemptTime encodes the passed time as nanoseconds since the unix
+// epoch, encoding the zero time as zero.
+func serializeAttemptTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return uint64(t.UnixNano())
+}
+
+// deserializeAttemptTime decodes a time encoded by serializeAttemptTime.
+func deserializeAttemptTime(nanos uint64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(nanos))
+}
+
+func serializeAttemptHop(w io.Writer, hop *AttemptHop) error {
+	if _, err := w.Write(hop.PubKey[:]); err != nil {
+		return err
+	}
+
+	return writeElements(w,
+		hop.ChannelID, hop.AmtToForward, hop.Fee, hop.OutgoingTimeLock,
+	)
+}
+
+func deserializeAttemptHop(r io.Reader, hop *AttemptHop) error {
+	if _, err := io.ReadFull(r, hop.PubKey[:]); err != nil {
+		return err
+	}
+
+	return readElements(r,
+		&hop.ChannelID, &hop.AmtToForward, &hop.Fee,
+		&hop.OutgoingTimeLock,
+	)
+}
@@ -0,0 +1,304 @@
+package channeldb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/coreos/bbolt"
+)
+
+var (
+	// paymentStatusBucket is the name of the bucket which tracks the
+	// state of each outgoing payment by its payment hash. Each value is
+	// the status of the payment, followed by the reason it failed, if it
+	// did.
+	//
+	// maps: payment hash -> status || failure reason
+	paymentStatusBucket = []byte("payment-status")
+
+	// paymentStatusAttemptsBucket is the name of the bucket which stores
+	// the HTLC attempts of the latest payment to each payment hash, as
+	// they're made. It holds a sub-bucket for each payment hash, within
+	// which each attempt is keyed by its big-endian index. The attempts
+	// are reset once the payment hash is paid again.
+	//
+	// maps: payment hash -> attempt index -> attempt
+	paymentStatusAttemptsBucket = []byte("payment-status-attempts")
+)
+
+// PaymentStatus is the state of an outgoing payment. Payments transition
+// between the states as follows, any other transition is refused:
+//
+//	StatusUnknown   -> StatusInFlight
+//	StatusInFlight  -> StatusSucceeded
+//	StatusInFlight  -> StatusFailed
+//	StatusFailed    -> StatusInFlight
+type PaymentStatus byte
+
+const (
+	// StatusUnknown is the status of a payment hash which was never paid.
+	StatusUnknown PaymentStatus = 0
+
+	// StatusInFlight is the status of a payment which was initiated, but
+	// whose outcome isn't known yet.
+	StatusInFlight PaymentStatus = 1
+
+	// StatusSucceeded is the status of a payment whose preimage was
+	// received. A payment hash can't be paid again once it succeeded.
+	StatusSucceeded PaymentStatus = 2
+
+	// StatusFailed is the status of a payment which failed. It may be
+	// attempted again.
+	StatusFailed PaymentStatus = 3
+)
+
+// String returns a human readable representation of the payment status.
+func (s PaymentStatus) String() string {
+	switch s {
+	case StatusUnknown:
+		return "Unknown"
+	case StatusInFlight:
+		return "In Flight"
+	case StatusSucceeded:
+		return "Succeeded"
+	case StatusFailed:
+		return "Failed"
+	default:
+		return "Invalid"
+	}
+}
+
+// PaymentState is the persisted state of an outgoing payment.
+type PaymentState struct {
+	// Status is the current status of the payment.
+	Status PaymentStatus
+
+	// FailureReason describes why the payment failed. It's only set if
+	// the status is StatusFailed.
+	FailureReason string
+}
+
+// InitPayment records that a payment to the passed payment hash is being
+// initiated. ErrPaymentInFlight is returned if the payment hash is already
+// being paid, and ErrAlreadyPaid if it was paid successfully before.
+//
+// Any HTLC attempts recorded for an earlier payment to the payment hash are
+// removed, as they don't belong to the new payment.
+func (d *DB) InitPayment(paymentHash [32]byte) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		check := func(s PaymentStatus) error {
+			switch s {
+			case StatusUnknown, StatusFailed:
+				return nil
+			case StatusInFlight:
+				return ErrPaymentInFlight
+			default:
+				return ErrAlreadyPaid
+			}
+		}
+		err := transitionPayment(
+			tx, paymentHash, check, PaymentState{Status: StatusInFlight},
+		)
+		if err != nil {
+			return err
+		}
+
+		attempts := tx.Bucket(paymentStatusAttemptsBucket)
+		if attempts == nil || attempts.Bucket(paymentHash[:]) == nil {
+			return nil
+		}
+
+		return attempts.DeleteBucket(paymentHash[:])
+	})
+}
+
+// RecordHTLCAttempt stores the HTLC attempt with the passed index, made to
+// complete the in-flight payment to the passed payment hash. Attempts are
+// indexed in the order in which they were made, starting from zero. Recording
+// an attempt under an existing index overwrites it, which allows an attempt
+// to be recorded once when it's dispatched, and again once it's resolved.
+func (d *DB) RecordHTLCAttempt(paymentHash [32]byte, index uint32,
+	attempt *HTLCAttempt) error {
+
+	var b bytes.Buffer
+	if err := serializeHTLCAttempt(&b, attempt); err != nil {
+		return err
+	}
+
+	return d.Update(func(tx *bolt.Tx) error {
+		state := fetchPaymentState(
+			tx.Bucket(paymentStatusBucket), paymentHash,
+		)
+		if err := checkPaymentInFlight(state.Status); err != nil {
+			return err
+		}
+
+		attempts, err := tx.CreateBucketIfNotExists(
+			paymentStatusAttemptsBucket,
+		)
+		if err != nil {
+			return err
+		}
+		hashAttempts, err := attempts.CreateBucketIfNotExists(
+			paymentHash[:],
+		)
+		if err != nil {
+			return err
+		}
+
+		var k [4]byte
+		binary.BigEndian.PutUint32(k[:], index)
+		return hashAttempts.Put(k[:], b.Bytes())
+	})
+}
+
+// FetchHTLCAttempts returns the HTLC attempts recorded for the latest payment
+// to the passed payment hash, in the order in which they were made. Attempts
+// which haven't been resolved yet have a zero ResolveTime.
+func (d *DB) FetchHTLCAttempts(paymentHash [32]byte) ([]HTLCAttempt, error) {
+	var attempts []HTLCAttempt
+	err := d.View(func(tx *bolt.Tx) error {
+		var err error
+		attempts, err = fetchPaymentAttempts(
+			tx.Bucket(paymentStatusAttemptsBucket), paymentHash[:],
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// SucceedPayment records that the in-flight payment to the passed payment
+// hash succeeded.
+func (d *DB) SucceedPayment(paymentHash [32]byte) error {
+	return d.transitionPayment(
+		paymentHash, checkPaymentInFlight,
+		PaymentState{Status: StatusSucceeded},
+	)
+}
+
+// FailPayment records that the in-flight payment to the passed payment hash
+// failed for the passed reason, allowing the payment hash to be paid again.
+func (d *DB) FailPayment(paymentHash [32]byte, reason string) error {
+	return d.transitionPayment(
+		paymentHash, checkPaymentInFlight,
+		PaymentState{Status: StatusFailed, FailureReason: reason},
+	)
+}
+
+// FetchPaymentState returns the state of the payment to the passed payment
+// hash. A payment hash which was never paid has status StatusUnknown.
+func (d *DB) FetchPaymentState(paymentHash [32]byte) (*PaymentState, error) {
+	var state *PaymentState
+	err := d.View(func(tx *bolt.Tx) error {
+		state = fetchPaymentState(tx.Bucket(paymentStatusBucket),
+			paymentHash)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// FetchInFlightPayments returns the payment hashes of all payments which are
+// in flight.
+func (d *DB) FetchInFlightPayments() ([][32]byte, error) {
+	var inFlight [][32]byte
+	err := d.View(func(tx *bolt.Tx) error {
+		statuses := tx.Bucket(paymentStatusBucket)
+		if statuses == nil {
+			return nil
+		}
+
+		return statuses.ForEach(func(k, v []byte) error {
+			if len(k) != 32 || len(v) == 0 ||
+				PaymentStatus(v[0]) != StatusInFlight {
+
+				return nil
+			}
+
+			var paymentHash [32]byte
+			copy(paymentHash[:], k)
+			inFlight = append(inFlight, paymentHash)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return inFlight, nil
+}
+
+// checkPaymentInFlight returns an error unless the passed status is
+// StatusInFlight, which is the only status a payment may be resolved from.
+func checkPaymentInFlight(s PaymentStatus) error {
+	switch s {
+	case StatusInFlight:
+		return nil
+	case StatusSucceeded:
+		return ErrAlreadyPaid
+	case StatusFailed:
+		return ErrPaymentAlreadyFailed
+	default:
+		return ErrPaymentNotInitiated
+	}
+}
+
+// transitionPayment moves the payment to the passed payment hash into the
+// passed state, if check permits the transition from its current status.
+func (d *DB) transitionPayment(paymentHash [32]byte,
+	check func(PaymentStatus) error, newState PaymentState) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		return transitionPayment(tx, paymentHash, check, newState)
+	})
+}
+
+// transitionPayment moves the payment to the passed payment hash into the
+// passed state within the passed transaction, if check permits the
+// transition from its current status.
+func transitionPayment(tx *bolt.Tx, paymentHash [32]byte,
+	check func(PaymentStatus) error, newState PaymentState) error {
+
+	statuses, err := tx.CreateBucketIfNotExists(paymentStatusBucket)
+	if err != nil {
+		return err
+	}
+
+	state := fetchPaymentState(statuses, paymentHash)
+	if err := check(state.Status); err != nil {
+		return err
+	}
+
+	value := make([]byte, 1+len(newState.FailureReason))
+	value[0] = byte(newState.Status)
+	copy(value[1:], newState.FailureReason)
+
+	return statuses.Put(paymentHash[:], value)
+}
+
+// fetchPaymentState returns the state of the payment to the passed payment
+// hash stored within the passed bucket, which may be nil.
+func fetchPaymentState(statuses *bolt.Bucket,
+	paymentHash [32]byte) *PaymentState {
+
+	state := &PaymentState{Status: StatusUnknown}
+	if statuses == nil {
+		return state
+	}
+
+	value := statuses.Get(paymentHash[:])
+	if len(value) == 0 {
+		return state
+	}
+
+	state.Status = PaymentStatus(value[0])
+	state.FailureReason = string(value[1:])
+	return state
+}
@@ -0,0 +1,174 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestPaymentStatusTransitions tests that payments may only move between
+// states along valid transitions, and that invalid transitions are refused
+// with the appropriate error.
+func TestPaymentStatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	var paymentHash [32]byte
+	paymentHash[0] = 1
+
+	assertState := func(status PaymentStatus, reason string) {
+		state, err := db.FetchPaymentState(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch payment state: %v", err)
+		}
+		if state.Status != status || state.FailureReason != reason {
+			t.Fatalf("expected status %v with reason %q, got %v "+
+				"with reason %q", status, reason, state.Status,
+				state.FailureReason)
+		}
+	}
+	assertInFlight := func(expected bool) {
+		inFlight, err := db.FetchInFlightPayments()
+		if err != nil {
+			t.Fatalf("unable to fetch in-flight payments: %v", err)
+		}
+		if expected != (len(inFlight) == 1 &&
+			inFlight[0] == paymentHash) {
+
+			t.Fatalf("expected in flight=%v, got %v", expected,
+				inFlight)
+		}
+	}
+	assertErr := func(err, expected error) {
+		if err != expected {
+			t.Fatalf("expected %v, got %v", expected, err)
+		}
+	}
+
+	// A payment which was never initiated can't be resolved.
+	assertState(StatusUnknown, "")
+	assertErr(db.SucceedPayment(paymentHash), ErrPaymentNotInitiated)
+	assertErr(db.FailPayment(paymentHash, "fail"), ErrPaymentNotInitiated)
+
+	// Once initiated, the payment can't be initiated again until it's
+	// resolved.
+	assertErr(db.InitPayment(paymentHash), nil)
+	assertState(StatusInFlight, "")
+	assertInFlight(true)
+	assertErr(db.InitPayment(paymentHash), ErrPaymentInFlight)
+
+	// A failed payment records its reason, and may be attempted again.
+	assertErr(db.FailPayment(paymentHash, "no route"), nil)
+	assertState(StatusFailed, "no route")
+	assertInFlight(false)
+	assertErr(db.FailPayment(paymentHash, "fail"), ErrPaymentAlreadyFailed)
+	assertErr(db.SucceedPayment(paymentHash), ErrPaymentAlreadyFailed)
+
+	assertErr(db.InitPayment(paymentHash), nil)
+	assertState(StatusInFlight, "")
+
+	// Once succeeded, the payment hash can't be paid again, and its
+	// status can't change anymore.
+	assertErr(db.SucceedPayment(paymentHash), nil)
+	assertState(StatusSucceeded, "")
+	assertInFlight(false)
+	assertErr(db.InitPayment(paymentHash), ErrAlreadyPaid)
+	assertErr(db.SucceedPayment(paymentHash), ErrAlreadyPaid)
+	assertErr(db.FailPayment(paymentHash, "fail"), ErrAlreadyPaid)
+}
+
+// TestRecordHTLCAttempts tests that the HTLC attempts of an in-flight payment
+// are recorded as they're made and resolved, and that they're reset once the
+// payment hash is paid again.
+func TestRecordHTLCAttempts(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	var paymentHash [32]byte
+	paymentHash[0] = 2
+
+	assertAttempts := func(expected []HTLCAttempt) {
+		attempts, err := db.FetchHTLCAttempts(paymentHash)
+		if err != nil {
+			t.Fatalf("unable to fetch attempts: %v", err)
+		}
+		if len(attempts) == 0 && len(expected) == 0 {
+			return
+		}
+		if !reflect.DeepEqual(attempts, expected) {
+			t.Fatalf("expected attempts %v, got %v",
+				spew.Sdump(expected), spew.Sdump(attempts))
+		}
+	}
+
+	hop := AttemptHop{
+		ChannelID:        12345,
+		AmtToForward:     10000,
+		OutgoingTimeLock: 1000,
+	}
+	hop.PubKey[0] = 3
+	first := HTLCAttempt{
+		AttemptTime:   time.Unix(1000, 100),
+		TotalAmount:   10000,
+		TotalTimeLock: 1000,
+		Hops:          []AttemptHop{hop},
+	}
+	second := first
+	second.AttemptTime = time.Unix(1002, 300)
+
+	// Attempts can only be recorded for an in-flight payment.
+	err = db.RecordHTLCAttempt(paymentHash, 0, &first)
+	if err != ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+	if err := db.InitPayment(paymentHash); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	assertAttempts(nil)
+
+	// An attempt which is still in flight is recorded without a resolve
+	// time, which is set once it's recorded again after it resolved.
+	if err := db.RecordHTLCAttempt(paymentHash, 0, &first); err != nil {
+		t.Fatalf("unable to record attempt: %v", err)
+	}
+	assertAttempts([]HTLCAttempt{first})
+
+	first.ResolveTime = time.Unix(1001, 200)
+	first.Failure = "TemporaryChannelFailure"
+	first.FailureSource[0] = 2
+	if err := db.RecordHTLCAttempt(paymentHash, 0, &first); err != nil {
+		t.Fatalf("unable to record attempt: %v", err)
+	}
+	if err := db.RecordHTLCAttempt(paymentHash, 1, &second); err != nil {
+		t.Fatalf("unable to record attempt: %v", err)
+	}
+	assertAttempts([]HTLCAttempt{first, second})
+
+	// The attempts remain available after the payment failed, until it's
+	// initiated again.
+	if err := db.FailPayment(paymentHash, "no route"); err != nil {
+		t.Fatalf("unable to fail payment: %v", err)
+	}
+	err = db.RecordHTLCAttempt(paymentHash, 2, &second)
+	if err != ErrPaymentAlreadyFailed {
+		t.Fatalf("expected ErrPaymentAlreadyFailed, got %v", err)
+	}
+	assertAttempts([]HTLCAttempt{first, second})
+
+	if err := db.InitPayment(paymentHash); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+	assertAttempts(nil)
+}
@@ -2,8 +2,12 @@ package channeldb
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"fmt"
 	"io"
+	"math"
+	"time"
 
 	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -17,6 +21,20 @@ var (
 	// which is a monotonically increasing uint64.  BoltDB's sequence
 	// feature is used for generating monotonically increasing id.
 	paymentBucket = []byte("payments")
+
+	// paymentHashIndexBucket is the name of the bucket which indexes all
+	// payments by their payment hash. As the same payment hash may be paid
+	// several times, each key is the payment hash followed by the
+	// big-endian sequence number of the payment within the payments
+	// bucket. The values are empty.
+	paymentHashIndexBucket = []byte("payment-hash-index")
+)
+
+const (
+	// maxPaymentHops is the maximum number of hops within the route of a
+	// payment, as bounded by the number of hops an onion packet is able
+	// to encode.
+	maxPaymentHops = 20
 )
 
 // OutgoingPayment represents a successful payment between the daemon and a
@@ -37,13 +55,31 @@ type OutgoingPayment struct {
 	// compressed public key of each of the nodes involved in the payment.
 	Path [][33]byte
 
+	// Route is the full route the payment was settled over, including the
+	// channel, amount, fee and time lock of each hop. Payments stored
+	// before routes were recorded lack it.
+	Route []AttemptHop
+
 	// PaymentPreimage is the preImage of a successful payment. This is used
 	// to calculate the PaymentHash as well as serve as a proof of payment.
 	PaymentPreimage [32]byte
+
+	// SequenceNum is the index of the payment within the payments bucket.
+	// Payments are indexed in the order in which they were added, starting
+	// from one. It isn't serialized, but populated from the key the
+	// payment is stored under.
+	SequenceNum uint64
+
+	// Attempts are the HTLCs which were dispatched to complete the
+	// payment, including the successful one, in the order in which they
+	// were made. They're stored separately from the payment itself.
+	Attempts []HTLCAttempt
 }
 
-// AddPayment saves a successful payment to the database. It is assumed that
-// all payment are sent using unique payment hashes.
+// AddPayment saves a successful payment to the database. Each payment is
+// stored under a new sequence number, so paying the same payment hash again
+// doesn't overwrite earlier payments to it. Once saved, the sequence number of
+// the payment is set.
 func (db *DB) AddPayment(payment *OutgoingPayment) error {
 	// Validate the field of the inner voice within the outgoing payment,
 	// these must also adhere to the same constraints as regular invoices.
@@ -60,11 +96,25 @@ func (db *DB) AddPayment(payment *OutgoingPayment) error {
 	}
 	paymentBytes := b.Bytes()
 
+	paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
+
 	return db.Batch(func(tx *bolt.Tx) error {
 		payments, err := tx.CreateBucketIfNotExists(paymentBucket)
 		if err != nil {
 			return err
 		}
+		hashIndex, err := tx.CreateBucketIfNotExists(
+			paymentHashIndexBucket,
+		)
+		if err != nil {
+			return err
+		}
+		attempts, err := tx.CreateBucketIfNotExists(
+			paymentAttemptsBucket,
+		)
+		if err != nil {
+			return err
+		}
 
 		// Obtain the new unique sequence number for this payment.
 		paymentID, err := payments.NextSequence()
@@ -75,10 +125,26 @@ func (db *DB) AddPayment(payment *OutgoingPayment) error {
 		// We use BigEndian for keys as it orders keys in
 		// ascending order. This allows bucket scans to order payments
 		// in the order in which they were created.
-		paymentIDBytes := make([]byte, 8)
-		binary.BigEndian.PutUint64(paymentIDBytes, paymentID)
+		paymentIDBytes := paymentIndexKey(paymentID)
+		err = payments.Put(paymentIDBytes, paymentBytes)
+		if err != nil {
+			return err
+		}
+
+		indexKey := paymentHashIndexKey(paymentHash, paymentID)
+		if err := hashIndex.Put(indexKey, []byte{}); err != nil {
+			return err
+		}
+
+		err = putPaymentAttempts(
+			attempts, paymentIDBytes, payment.Attempts,
+		)
+		if err != nil {
+			return err
+		}
 
-		return payments.Put(paymentIDBytes, paymentBytes)
+		payment.SequenceNum = paymentID
+		return nil
 	})
 }
 
@@ -91,6 +157,7 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 		if bucket == nil {
 			return ErrNoPaymentsCreated
 		}
+		attempts := tx.Bucket(paymentAttemptsBucket)
 
 		return bucket.ForEach(func(k, v []byte) error {
 			// If the value is nil, then we ignore it as it may be
@@ -99,8 +166,7 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 				return nil
 			}
 
-			r := bytes.NewReader(v)
-			payment, err := deserializeOutgoingPayment(r)
+			payment, err := fetchPayment(attempts, k, v)
 			if err != nil {
 				return err
 			}
@@ -116,6 +182,250 @@ func (db *DB) FetchAllPayments() ([]*OutgoingPayment, error) {
 	return payments, nil
 }
 
+// FetchPaymentsByHash returns all payments made to the passed payment hash, in
+// the order in which they were made. If no payment was made to the payment
+// hash, an empty slice is returned.
+func (db *DB) FetchPaymentsByHash(
+	paymentHash [32]byte) ([]*OutgoingPayment, error) {
+
+	var payments []*OutgoingPayment
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(paymentBucket)
+		hashIndex := tx.Bucket(paymentHashIndexBucket)
+		if bucket == nil || hashIndex == nil {
+			return nil
+		}
+		attempts := tx.Bucket(paymentAttemptsBucket)
+
+		prefix := paymentHash[:]
+		c := hashIndex.Cursor()
+		for k, _ := c.Seek(prefix); k != nil; k, _ = c.Next() {
+			if !bytes.HasPrefix(k, prefix) {
+				break
+			}
+
+			seqKey := k[len(prefix):]
+			v := bucket.Get(seqKey)
+			if v == nil {
+				return fmt.Errorf("indexed payment %x not "+
+					"found", seqKey)
+			}
+
+			payment, err := fetchPayment(attempts, seqKey, v)
+			if err != nil {
+				return err
+			}
+
+			payments = append(payments, payment)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+// PaymentsQuery represents a query to the payments database, selecting a
+// window of payments by their sequence number, optionally restricted to the
+// payments created within a time range.
+type PaymentsQuery struct {
+	// IndexOffset is the sequence number of the payment at which the
+	// query starts. The payment at the offset itself isn't included. If
+	// zero, the query starts at the first payment, or at the last one if
+	// Reversed is set.
+	IndexOffset uint64
+
+	// MaxPayments is the maximum number of payments returned. A value of
+	// zero doesn't limit the number of payments.
+	MaxPayments uint64
+
+	// Reversed indicates whether the query seeks backwards from the index
+	// offset, returning the payments made before it, rather than those
+	// made after it.
+	Reversed bool
+
+	// CreationDateStart, if non-zero, excludes the payments created
+	// before it.
+	CreationDateStart time.Time
+
+	// CreationDateEnd, if non-zero, excludes the payments created at or
+	// after it.
+	CreationDateEnd time.Time
+}
+
+// matches returns whether the passed payment was created within the time
+// range of the query.
+func (q *PaymentsQuery) matches(payment *OutgoingPayment) bool {
+	if !q.CreationDateStart.IsZero() &&
+		payment.CreationDate.Before(q.CreationDateStart) {
+
+		return false
+	}
+
+	if !q.CreationDateEnd.IsZero() &&
+		!payment.CreationDate.Before(q.CreationDateEnd) {
+
+		return false
+	}
+
+	return true
+}
+
+// PaymentsResponse contains the payments selected by a PaymentsQuery, along
+// with the index offsets to be used to query the adjacent windows.
+type PaymentsResponse struct {
+	// Payments are the payments selected by the query, in ascending order
+	// of their sequence numbers regardless of the query direction.
+	Payments []*OutgoingPayment
+
+	// FirstIndexOffset is the sequence number of the first payment
+	// returned. It can be used as the index offset of a reversed query to
+	// fetch the preceding payments.
+	FirstIndexOffset uint64
+
+	// LastIndexOffset is the sequence number of the last payment
+	// returned. It can be used as the index offset of a query to fetch
+	// the following payments.
+	LastIndexOffset uint64
+}
+
+// QueryPayments returns the window of payments selected by the query. As the
+// payments are looked up by their sequence number, the cost of the query
+// depends only on the number of payments returned, rather than on the total
+// number of payments, unless the query is restricted to a time range, in
+// which case the payments outside of it are skipped.
+func (db *DB) QueryPayments(query PaymentsQuery) (PaymentsResponse, error) {
+	var resp PaymentsResponse
+
+	err := db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(paymentBucket)
+		if bucket == nil {
+			return nil
+		}
+		attempts := tx.Bucket(paymentAttemptsBucket)
+
+		c := bucket.Cursor()
+
+		// Position the cursor on the first payment to return, and
+		// determine the direction in which it moves from there.
+		var (
+			k, v []byte
+			next func() ([]byte, []byte)
+		)
+		switch {
+		case query.Reversed && query.IndexOffset == 0:
+			k, v = c.Last()
+			next = c.Prev
+
+		case query.Reversed:
+			// The cursor is positioned on the first payment at or
+			// after the offset, so the payment preceding it is
+			// the first one before the offset.
+			k, v = c.Seek(paymentIndexKey(query.IndexOffset))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+			next = c.Prev
+
+		// No payment may follow the largest possible offset.
+		case query.IndexOffset == math.MaxUint64:
+			return nil
+
+		default:
+			k, v = c.Seek(paymentIndexKey(query.IndexOffset + 1))
+			next = c.Next
+		}
+
+		for ; k != nil; k, v = next() {
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket.
+			if v == nil {
+				continue
+			}
+
+			payment, err := fetchPayment(attempts, k, v)
+			if err != nil {
+				return err
+			}
+
+			if !query.matches(payment) {
+				continue
+			}
+
+			resp.Payments = append(resp.Payments, payment)
+
+			numPayments := uint64(len(resp.Payments))
+			if numPayments == query.MaxPayments {
+				break
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return PaymentsResponse{}, err
+	}
+
+	// A reversed query collects the payments in descending order, so
+	// we'll restore their ascending order.
+	if query.Reversed {
+		payments := resp.Payments
+		for i, j := 0, len(payments)-1; i < j; i, j = i+1, j-1 {
+			payments[i], payments[j] = payments[j], payments[i]
+		}
+	}
+
+	if len(resp.Payments) > 0 {
+		resp.FirstIndexOffset = resp.Payments[0].SequenceNum
+		resp.LastIndexOffset =
+			resp.Payments[len(resp.Payments)-1].SequenceNum
+	}
+
+	return resp, nil
+}
+
+// paymentIndexKey returns the key a payment with the passed sequence number
+// is stored under.
+func paymentIndexKey(seqNum uint64) []byte {
+	var k [8]byte
+	binary.BigEndian.PutUint64(k[:], seqNum)
+	return k[:]
+}
+
+// paymentHashIndexKey returns the key within the payment hash index of the
+// payment to paymentHash stored under the passed sequence number.
+func paymentHashIndexKey(paymentHash [32]byte, seqNum uint64) []byte {
+	var k [40]byte
+	copy(k[:32], paymentHash[:])
+	binary.BigEndian.PutUint64(k[32:], seqNum)
+	return k[:]
+}
+
+// fetchPayment deserializes the payment stored under key k as value v, and
+// loads its attempts from the attempts bucket. If the attempts bucket is nil,
+// the attempts of the payment aren't loaded.
+func fetchPayment(attempts *bolt.Bucket, k, v []byte) (*OutgoingPayment,
+	error) {
+
+	payment, err := deserializeOutgoingPayment(bytes.NewReader(v))
+	if err != nil {
+		return nil, err
+	}
+	payment.SequenceNum = binary.BigEndian.Uint64(k)
+
+	payment.Attempts, err = fetchPaymentAttempts(attempts, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
 // DeleteAllPayments deletes all payments from DB.
 func (db *DB) DeleteAllPayments() error {
 	return db.Update(func(tx *bolt.Tx) error {
@@ -123,12 +433,116 @@ func (db *DB) DeleteAllPayments() error {
 		if err != nil && err != bolt.ErrBucketNotFound {
 			return err
 		}
+		err = tx.DeleteBucket(paymentHashIndexBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
+		err = tx.DeleteBucket(paymentAttemptsBucket)
+		if err != nil && err != bolt.ErrBucketNotFound {
+			return err
+		}
 
 		_, err = tx.CreateBucket(paymentBucket)
 		return err
 	})
 }
 
+// DeletePayments deletes the payments created before beforeTime, along with
+// their attempts, and returns the number of deleted payments. If failedOnly is
+// set, the payments themselves are kept, so the records of settled payments
+// are retained, and only their failed HTLC attempts are deleted, in which case
+// the number of deleted attempts is returned. A zero beforeTime doesn't limit
+// the deletion by time.
+func (db *DB) DeletePayments(failedOnly bool,
+	beforeTime time.Time) (uint64, error) {
+
+	var numDeleted uint64
+	err := db.Update(func(tx *bolt.Tx) error {
+		numDeleted = 0
+
+		payments := tx.Bucket(paymentBucket)
+		if payments == nil {
+			return nil
+		}
+		hashIndex := tx.Bucket(paymentHashIndexBucket)
+		attempts := tx.Bucket(paymentAttemptsBucket)
+
+		// We'll first collect the payments in question, as the
+		// buckets can't be modified while iterating over them.
+		var toDelete []*OutgoingPayment
+		err := payments.ForEach(func(k, v []byte) error {
+			// If the value is nil, then we ignore it as it may be
+			// a sub-bucket.
+			if v == nil {
+				return nil
+			}
+
+			payment, err := fetchPayment(nil, k, v)
+			if err != nil {
+				return err
+			}
+
+			if !beforeTime.IsZero() &&
+				!payment.CreationDate.Before(beforeTime) {
+
+				return nil
+			}
+
+			toDelete = append(toDelete, payment)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, payment := range toDelete {
+			seqKey := paymentIndexKey(payment.SequenceNum)
+
+			if failedOnly {
+				n, err := deleteFailedAttempts(attempts, seqKey)
+				if err != nil {
+					return err
+				}
+				numDeleted += n
+				continue
+			}
+
+			if err := payments.Delete(seqKey); err != nil {
+				return err
+			}
+
+			if hashIndex != nil {
+				paymentHash := sha256.Sum256(
+					payment.PaymentPreimage[:],
+				)
+				indexKey := paymentHashIndexKey(
+					paymentHash, payment.SequenceNum,
+				)
+				err := hashIndex.Delete(indexKey)
+				if err != nil {
+					return err
+				}
+			}
+
+			if attempts != nil && attempts.Bucket(seqKey) != nil {
+				err := attempts.DeleteBucket(seqKey)
+				if err != nil {
+					return err
+				}
+			}
+
+			numDeleted++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return numDeleted, nil
+}
+
 func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 	var scratch [8]byte
 
@@ -165,6 +579,16 @@ func serializeOutgoingPayment(w io.Writer, p *OutgoingPayment) error {
 		return err
 	}
 
+	byteOrder.PutUint32(scratch[:4], uint32(len(p.Route)))
+	if _, err := w.Write(scratch[:4]); err != nil {
+		return err
+	}
+	for i := range p.Route {
+		if err := serializeAttemptHop(w, &p.Route[i]); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -179,32 +603,59 @@ func deserializeOutgoingPayment(r io.Reader) (*OutgoingPayment, error) {
 	}
 	p.Invoice = *inv
 
-	if _, err := r.Read(scratch[:]); err != nil {
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
 		return nil, err
 	}
 	p.Fee = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
 
-	if _, err = r.Read(scratch[:4]); err != nil {
+	if _, err = io.ReadFull(r, scratch[:4]); err != nil {
 		return nil, err
 	}
 	pathLen := byteOrder.Uint32(scratch[:4])
+	if pathLen > maxPaymentHops {
+		return nil, fmt.Errorf("payment path of length %v exceeds "+
+			"maximum of %v hops", pathLen, maxPaymentHops)
+	}
 
 	path := make([][33]byte, pathLen)
 	for i := uint32(0); i < pathLen; i++ {
-		if _, err := r.Read(path[i][:]); err != nil {
+		if _, err := io.ReadFull(r, path[i][:]); err != nil {
 			return nil, err
 		}
 	}
 	p.Path = path
 
-	if _, err = r.Read(scratch[:4]); err != nil {
+	if _, err = io.ReadFull(r, scratch[:4]); err != nil {
 		return nil, err
 	}
 	p.TimeLockLength = byteOrder.Uint32(scratch[:4])
 
-	if _, err := r.Read(p.PaymentPreimage[:]); err != nil {
+	if _, err := io.ReadFull(r, p.PaymentPreimage[:]); err != nil {
+		return nil, err
+	}
+
+	// Payments stored before their route was recorded end here.
+	_, err = io.ReadFull(r, scratch[:4])
+	switch {
+	case err == io.EOF:
+		return p, nil
+	case err != nil:
 		return nil, err
 	}
+	routeLen := byteOrder.Uint32(scratch[:4])
+	if routeLen > maxPaymentHops {
+		return nil, fmt.Errorf("payment route of length %v exceeds "+
+			"maximum of %v hops", routeLen, maxPaymentHops)
+	}
+
+	if routeLen > 0 {
+		p.Route = make([]AttemptHop, routeLen)
+	}
+	for i := range p.Route {
+		if err := deserializeAttemptHop(r, &p.Route[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	return p, nil
 }
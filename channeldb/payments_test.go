@@ -2,12 +2,14 @@ package channeldb
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"math/rand"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
@@ -30,10 +32,22 @@ func makeFakePayment() *OutgoingPayment {
 		copy(fakePath[i][:], bytes.Repeat([]byte{byte(i)}, 33))
 	}
 
+	fakeRoute := make([]AttemptHop, 3)
+	for i := range fakeRoute {
+		fakeRoute[i] = AttemptHop{
+			ChannelID:        uint64(i + 1),
+			PubKey:           fakePath[i],
+			AmtToForward:     lnwire.NewMSatFromSatoshis(10000),
+			Fee:              lnwire.MilliSatoshi(50 - i*25),
+			OutgoingTimeLock: uint32(1000 - i*40),
+		}
+	}
+
 	fakePayment := &OutgoingPayment{
 		Invoice:        *fakeInvoice,
 		Fee:            101,
 		Path:           fakePath,
+		Route:          fakeRoute,
 		TimeLockLength: 1000,
 	}
 	copy(fakePayment.PaymentPreimage[:], rev[:])
@@ -125,6 +139,40 @@ func TestOutgoingPaymentSerialization(t *testing.T) {
 	}
 }
 
+// TestOutgoingPaymentWithoutRoute tests that payments stored before their
+// route was recorded can still be deserialized.
+func TestOutgoingPaymentWithoutRoute(t *testing.T) {
+	t.Parallel()
+
+	fakePayment := makeFakePayment()
+
+	var b bytes.Buffer
+	if err := serializeOutgoingPayment(&b, fakePayment); err != nil {
+		t.Fatalf("unable to serialize outgoing payment: %v", err)
+	}
+
+	// Strip the route, along with its length, from the end of the
+	// serialized payment to obtain the legacy encoding.
+	routeLen := 4 + len(fakePayment.Route)*(33+8+8+8+4)
+	legacyPayment := b.Bytes()[:b.Len()-routeLen]
+
+	newPayment, err := deserializeOutgoingPayment(
+		bytes.NewReader(legacyPayment),
+	)
+	if err != nil {
+		t.Fatalf("unable to deserialize outgoing payment: %v", err)
+	}
+
+	fakePayment.Route = nil
+	if !reflect.DeepEqual(fakePayment, newPayment) {
+		t.Fatalf("Payments do not match after "+
+			"serialization/deserialization %v vs %v",
+			spew.Sdump(fakePayment),
+			spew.Sdump(newPayment),
+		)
+	}
+}
+
 func TestOutgoingPaymentWorkflow(t *testing.T) {
 	t.Parallel()
 
@@ -195,3 +243,479 @@ func TestOutgoingPaymentWorkflow(t *testing.T) {
 			len(paymentsAfterDeletion), 0)
 	}
 }
+
+// TestQueryPayments tests that windows of payments can be queried forwards
+// and backwards from an index offset.
+func TestQueryPayments(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// Querying before any payments were made should return nothing.
+	resp, err := db.QueryPayments(PaymentsQuery{})
+	if err != nil {
+		t.Fatalf("unable to query payments: %v", err)
+	}
+	if len(resp.Payments) != 0 {
+		t.Fatalf("expected no payments, got %d", len(resp.Payments))
+	}
+
+	// Each payment is created a second after the previous one, so the
+	// payment with sequence number i is created at paymentTime(i).
+	const numPayments = 10
+	paymentTime := func(seqNum int64) time.Time {
+		return time.Unix(1000+seqNum, 0)
+	}
+	for i := 0; i < numPayments; i++ {
+		payment, err := makeRandomFakePayment()
+		if err != nil {
+			t.Fatalf("Internal error in tests: %v", err)
+		}
+		payment.CreationDate = paymentTime(int64(i + 1))
+		if err := db.AddPayment(payment); err != nil {
+			t.Fatalf("unable to put payment in DB: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query PaymentsQuery
+
+		// first and last are the sequence numbers of the first and
+		// last payments expected, or zero if none are.
+		first uint64
+		last  uint64
+	}{
+		{
+			name:  "all payments",
+			query: PaymentsQuery{},
+			first: 1,
+			last:  numPayments,
+		},
+		{
+			name:  "first page",
+			query: PaymentsQuery{MaxPayments: 3},
+			first: 1,
+			last:  3,
+		},
+		{
+			name: "next page",
+			query: PaymentsQuery{
+				IndexOffset: 3,
+				MaxPayments: 3,
+			},
+			first: 4,
+			last:  6,
+		},
+		{
+			name: "last page truncated",
+			query: PaymentsQuery{
+				IndexOffset: 8,
+				MaxPayments: 3,
+			},
+			first: 9,
+			last:  numPayments,
+		},
+		{
+			name: "offset beyond last payment",
+			query: PaymentsQuery{
+				IndexOffset: numPayments,
+			},
+		},
+		{
+			name: "latest payments",
+			query: PaymentsQuery{
+				MaxPayments: 3,
+				Reversed:    true,
+			},
+			first: 8,
+			last:  numPayments,
+		},
+		{
+			name: "previous page",
+			query: PaymentsQuery{
+				IndexOffset: 8,
+				MaxPayments: 3,
+				Reversed:    true,
+			},
+			first: 5,
+			last:  7,
+		},
+		{
+			name: "first page reversed truncated",
+			query: PaymentsQuery{
+				IndexOffset: 3,
+				MaxPayments: 3,
+				Reversed:    true,
+			},
+			first: 1,
+			last:  2,
+		},
+		{
+			name: "reversed offset beyond last payment",
+			query: PaymentsQuery{
+				IndexOffset: numPayments + 5,
+				MaxPayments: 2,
+				Reversed:    true,
+			},
+			first: numPayments - 1,
+			last:  numPayments,
+		},
+		{
+			name: "reversed from first payment",
+			query: PaymentsQuery{
+				IndexOffset: 1,
+				Reversed:    true,
+			},
+		},
+		{
+			name: "time range",
+			query: PaymentsQuery{
+				CreationDateStart: paymentTime(4),
+				CreationDateEnd:   paymentTime(7),
+			},
+			first: 4,
+			last:  6,
+		},
+		{
+			name: "time range next page",
+			query: PaymentsQuery{
+				IndexOffset:       4,
+				MaxPayments:       3,
+				CreationDateStart: paymentTime(4),
+				CreationDateEnd:   paymentTime(7),
+			},
+			first: 5,
+			last:  6,
+		},
+		{
+			name: "time range latest payments",
+			query: PaymentsQuery{
+				MaxPayments:       2,
+				Reversed:          true,
+				CreationDateStart: paymentTime(4),
+				CreationDateEnd:   paymentTime(7),
+			},
+			first: 5,
+			last:  6,
+		},
+		{
+			name: "time range from start",
+			query: PaymentsQuery{
+				MaxPayments:       2,
+				CreationDateStart: paymentTime(9),
+			},
+			first: 9,
+			last:  numPayments,
+		},
+	}
+
+	for _, test := range tests {
+		resp, err := db.QueryPayments(test.query)
+		if err != nil {
+			t.Fatalf("%s: unable to query payments: %v", test.name,
+				err)
+		}
+
+		if resp.FirstIndexOffset != test.first ||
+			resp.LastIndexOffset != test.last {
+
+			t.Fatalf("%s: expected offsets %d-%d, got %d-%d",
+				test.name, test.first, test.last,
+				resp.FirstIndexOffset, resp.LastIndexOffset)
+		}
+
+		var numExpected uint64
+		if test.last != 0 {
+			numExpected = test.last - test.first + 1
+		}
+		if uint64(len(resp.Payments)) != numExpected {
+			t.Fatalf("%s: expected %d payments, got %d", test.name,
+				numExpected, len(resp.Payments))
+		}
+		for i, payment := range resp.Payments {
+			if payment.SequenceNum != test.first+uint64(i) {
+				t.Fatalf("%s: expected payment %d at position "+
+					"%d, got %d", test.name,
+					test.first+uint64(i), i,
+					payment.SequenceNum)
+			}
+		}
+	}
+}
+
+// TestFetchPaymentsByHash tests that repeated payments to the same payment
+// hash are all kept, and can be looked up by their payment hash.
+func TestFetchPaymentsByHash(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	paymentHash := sha256.Sum256(rev[:])
+
+	// Before any payments are made, none should be found.
+	payments, err := db.FetchPaymentsByHash(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if len(payments) != 0 {
+		t.Fatalf("expected no payments, got %v", len(payments))
+	}
+
+	// Pay the same payment hash twice, with another payment in between.
+	first := makeFakePayment()
+	if err := db.AddPayment(first); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	other, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	if err := db.AddPayment(other); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	second := makeFakePayment()
+	second.Fee = 202
+	if err := db.AddPayment(second); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+
+	// Both payments to the hash should be kept, in the order in which
+	// they were made.
+	payments, err = db.FetchPaymentsByHash(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	expected := []*OutgoingPayment{first, second}
+	if !reflect.DeepEqual(expected, payments) {
+		t.Fatalf("wrong payments: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(payments))
+	}
+
+	// Once all payments are deleted, none should be found anymore.
+	if err := db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+	payments, err = db.FetchPaymentsByHash(paymentHash)
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if len(payments) != 0 {
+		t.Fatalf("expected no payments, got %v", len(payments))
+	}
+}
+
+// TestPaymentAttempts tests that the HTLC attempts of a payment are stored
+// along with it, and returned by all payment queries.
+func TestPaymentAttempts(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	hops := []AttemptHop{
+		{
+			ChannelID:        12345,
+			AmtToForward:     10000,
+			Fee:              1,
+			OutgoingTimeLock: 1040,
+		},
+		{
+			ChannelID:        67890,
+			AmtToForward:     10000,
+			OutgoingTimeLock: 1000,
+		},
+	}
+	copy(hops[0].PubKey[:], bytes.Repeat([]byte{1}, 33))
+	copy(hops[1].PubKey[:], bytes.Repeat([]byte{2}, 33))
+
+	payment := makeFakePayment()
+	payment.Attempts = []HTLCAttempt{
+		{
+			AttemptTime:   time.Unix(1000, 100),
+			ResolveTime:   time.Unix(1001, 200),
+			TotalAmount:   10002,
+			TotalFees:     2,
+			TotalTimeLock: 1080,
+			Hops:          hops[:1],
+			Failure:       "TemporaryChannelFailure",
+		},
+		{
+			AttemptTime:   time.Unix(1002, 300),
+			ResolveTime:   time.Unix(1003, 400),
+			TotalAmount:   10001,
+			TotalFees:     1,
+			TotalTimeLock: 1040,
+			Hops:          hops,
+		},
+	}
+	if err := db.AddPayment(payment); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+
+	// A payment without any attempts should be stored without them.
+	other, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	if err := db.AddPayment(other); err != nil {
+		t.Fatalf("unable to put payment in DB: %v", err)
+	}
+	expected := []*OutgoingPayment{payment, other}
+
+	payments, err := db.FetchAllPayments()
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if !reflect.DeepEqual(expected, payments) {
+		t.Fatalf("wrong payments: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(payments))
+	}
+
+	resp, err := db.QueryPayments(PaymentsQuery{})
+	if err != nil {
+		t.Fatalf("unable to query payments: %v", err)
+	}
+	if !reflect.DeepEqual(expected, resp.Payments) {
+		t.Fatalf("wrong payments: expected %v, got %v",
+			spew.Sdump(expected), spew.Sdump(resp.Payments))
+	}
+
+	payments, err = db.FetchPaymentsByHash(sha256.Sum256(rev[:]))
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if !reflect.DeepEqual(expected[:1], payments) {
+		t.Fatalf("wrong payments: expected %v, got %v",
+			spew.Sdump(expected[:1]), spew.Sdump(payments))
+	}
+
+	// Deleting all payments should delete their attempts too.
+	if err := db.DeleteAllPayments(); err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+	err = db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(paymentAttemptsBucket) != nil {
+			t.Fatalf("payment attempts not deleted")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to read db: %v", err)
+	}
+}
+
+// TestDeletePayments tests that payments created before a given time can be
+// deleted, either entirely or only their failed attempts.
+func TestDeletePayments(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	failed := HTLCAttempt{
+		AttemptTime: time.Unix(1000, 0),
+		ResolveTime: time.Unix(1001, 0),
+		Hops:        []AttemptHop{},
+		Failure:     "UnknownNextPeer",
+	}
+	settled := HTLCAttempt{
+		AttemptTime: time.Unix(1002, 0),
+		ResolveTime: time.Unix(1003, 0),
+		Hops:        []AttemptHop{},
+	}
+
+	// We'll add an old payment with a failed and a settled attempt, an
+	// old payment with only failed attempts, and a recent payment with a
+	// failed attempt.
+	old := makeFakePayment()
+	old.CreationDate = time.Unix(1000, 0)
+	old.Attempts = []HTLCAttempt{failed, settled}
+
+	oldFailed, err := makeRandomFakePayment()
+	if err != nil {
+		t.Fatalf("Internal error in tests: %v", err)
+	}
+	oldFailed.CreationDate = time.Unix(2000, 0)
+	oldFailed.Attempts = []HTLCAttempt{failed, failed}
+
+	recent := makeFakePayment()
+	recent.CreationDate = time.Unix(4000, 0)
+	recent.Attempts = []HTLCAttempt{failed}
+
+	for _, p := range []*OutgoingPayment{old, oldFailed, recent} {
+		if err := db.AddPayment(p); err != nil {
+			t.Fatalf("unable to put payment in DB: %v", err)
+		}
+	}
+
+	assertPayments := func(expected []*OutgoingPayment) {
+		t.Helper()
+
+		payments, err := db.FetchAllPayments()
+		if err != nil {
+			t.Fatalf("unable to fetch payments: %v", err)
+		}
+		if !reflect.DeepEqual(expected, payments) {
+			t.Fatalf("wrong payments: expected %v, got %v",
+				spew.Sdump(expected), spew.Sdump(payments))
+		}
+	}
+
+	// Deleting only the failed attempts of the old payments should keep
+	// the payments themselves, along with their settled attempts.
+	numDeleted, err := db.DeletePayments(true, time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("unable to delete failed attempts: %v", err)
+	}
+	if numDeleted != 3 {
+		t.Fatalf("expected 3 deleted attempts, got %v", numDeleted)
+	}
+	old.Attempts = []HTLCAttempt{settled}
+	oldFailed.Attempts = nil
+	assertPayments([]*OutgoingPayment{old, oldFailed, recent})
+
+	// Deleting the old payments entirely should also remove them from
+	// the payment hash index.
+	numDeleted, err = db.DeletePayments(false, time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+	if numDeleted != 2 {
+		t.Fatalf("expected 2 deleted payments, got %v", numDeleted)
+	}
+	assertPayments([]*OutgoingPayment{recent})
+
+	payments, err := db.FetchPaymentsByHash(sha256.Sum256(rev[:]))
+	if err != nil {
+		t.Fatalf("unable to fetch payments: %v", err)
+	}
+	if !reflect.DeepEqual([]*OutgoingPayment{recent}, payments) {
+		t.Fatalf("wrong payments by hash: expected %v, got %v",
+			spew.Sdump(recent), spew.Sdump(payments))
+	}
+
+	// Without a time limit, all remaining payments should be deleted.
+	numDeleted, err = db.DeletePayments(false, time.Time{})
+	if err != nil {
+		t.Fatalf("unable to delete payments: %v", err)
+	}
+	if numDeleted != 1 {
+		t.Fatalf("expected 1 deleted payment, got %v", numDeleted)
+	}
+	assertPayments(nil)
+}
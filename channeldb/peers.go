@@ -0,0 +1,274 @@
+package channeldb
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// MaxPeerAddresses is the maximum number of addresses stored for a single
+// peer. Once the limit is reached, the address we least recently connected
+// over is dropped in favor of the new one.
+const MaxPeerAddresses = 10
+
+var (
+	// peerBucket is the name of the bucket within the database that stores
+	// the peers we've successfully connected to in the past, whether or
+	// not we have a channel with them. Unlike the nodeInfoBucket, which
+	// only tracks channel counterparties, this allows us to reconnect to
+	// all previously known peers on restart.
+	//
+	// maps: pubKey -> lastConnected || lastGossip || numAddrs || addrs
+	peerBucket = []byte("peers")
+)
+
+// Peer is the record of a peer we've successfully connected to in the past.
+type Peer struct {
+	// IdentityPub is the identity public key of the peer.
+	IdentityPub *btcec.PublicKey
+
+	// Addresses are the addresses we've successfully connected to the
+	// peer over, ordered from the most to the least recently used.
+	Addresses []net.Addr
+
+	// LastConnected is the time of the last successful connection to the
+	// peer.
+	LastConnected time.Time
+
+	// LastGossip is the timestamp of the most recent gossip message we've
+	// received from the peer. On reconnection, only gossip newer than
+	// this timestamp needs to be requested from the peer. A zero value
+	// indicates that no gossip has been received from the peer yet.
+	LastGossip time.Time
+}
+
+// RecordPeerConnection records a successful connection to the peer with the
+// passed identity public key at the passed address. The address becomes the
+// peer's most recently used one, and the connection time its last successful
+// connection. If the peer isn't known yet, then a new record is created for
+// it.
+func (d *DB) RecordPeerConnection(pub *btcec.PublicKey, addr net.Addr,
+	connTime time.Time) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		peers, err := tx.CreateBucketIfNotExists(peerBucket)
+		if err != nil {
+			return err
+		}
+
+		peer, err := fetchPeer(peers, pub)
+		switch {
+		case err == ErrPeerNotFound:
+			peer = &Peer{IdentityPub: pub}
+		case err != nil:
+			return err
+		}
+
+		// Move the address to the front of the list, removing any
+		// prior occurrence of it, while keeping the list bounded.
+		addrs := []net.Addr{addr}
+		for _, a := range peer.Addresses {
+			if a.String() == addr.String() {
+				continue
+			}
+			addrs = append(addrs, a)
+		}
+		if len(addrs) > MaxPeerAddresses {
+			addrs = addrs[:MaxPeerAddresses]
+		}
+
+		peer.Addresses = addrs
+		peer.LastConnected = connTime
+
+		return putPeer(peers, peer)
+	})
+}
+
+// UpdatePeerGossipTimestamp records that we've received gossip from the peer
+// with the passed identity public key up to the passed timestamp. As gossip
+// may be received out of order, timestamps older than the one already stored
+// are ignored. If the peer isn't known, then ErrPeerNotFound is returned.
+func (d *DB) UpdatePeerGossipTimestamp(pub *btcec.PublicKey,
+	timestamp time.Time) error {
+
+	return d.Update(func(tx *bolt.Tx) error {
+		peers := tx.Bucket(peerBucket)
+		if peers == nil {
+			return ErrPeerNotFound
+		}
+
+		peer, err := fetchPeer(peers, pub)
+		if err != nil {
+			return err
+		}
+
+		if !timestamp.After(peer.LastGossip) {
+			return nil
+		}
+		peer.LastGossip = timestamp
+
+		return putPeer(peers, peer)
+	})
+}
+
+// FetchPeer returns the record of the peer with the passed identity public
+// key. If the peer isn't known, then ErrPeerNotFound is returned.
+func (d *DB) FetchPeer(pub *btcec.PublicKey) (*Peer, error) {
+	var peer *Peer
+	err := d.View(func(tx *bolt.Tx) error {
+		peers := tx.Bucket(peerBucket)
+		if peers == nil {
+			return ErrPeerNotFound
+		}
+
+		var err error
+		peer, err = fetchPeer(peers, pub)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+// FetchAllPeers returns the records of all peers we've successfully
+// connected to in the past.
+func (d *DB) FetchAllPeers() ([]*Peer, error) {
+	var allPeers []*Peer
+	err := d.View(func(tx *bolt.Tx) error {
+		peers := tx.Bucket(peerBucket)
+		if peers == nil {
+			return nil
+		}
+
+		return peers.ForEach(func(k, v []byte) error {
+			peer, err := deserializePeer(bytes.NewReader(v))
+			if err != nil {
+				return err
+			}
+			peer.IdentityPub, err = btcec.ParsePubKey(k, btcec.S256())
+			if err != nil {
+				return err
+			}
+
+			allPeers = append(allPeers, peer)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allPeers, nil
+}
+
+// DeletePeer removes the record of the peer with the passed identity public
+// key, so we no longer attempt to reconnect to it.
+func (d *DB) DeletePeer(pub *btcec.PublicKey) error {
+	return d.Update(func(tx *bolt.Tx) error {
+		peers := tx.Bucket(peerBucket)
+		if peers == nil {
+			return nil
+		}
+
+		return peers.Delete(pub.SerializeCompressed())
+	})
+}
+
+// fetchPeer reads the record of the peer with the passed identity public key
+// from the peers bucket.
+func fetchPeer(peers *bolt.Bucket, pub *btcec.PublicKey) (*Peer, error) {
+	peerBytes := peers.Get(pub.SerializeCompressed())
+	if peerBytes == nil {
+		return nil, ErrPeerNotFound
+	}
+
+	peer, err := deserializePeer(bytes.NewReader(peerBytes))
+	if err != nil {
+		return nil, err
+	}
+	peer.IdentityPub = pub
+
+	return peer, nil
+}
+
+// putPeer writes the record of the passed peer into the peers bucket, keyed by
+// its compressed identity public key.
+func putPeer(peers *bolt.Bucket, peer *Peer) error {
+	var b bytes.Buffer
+	if err := serializePeer(&b, peer); err != nil {
+		return err
+	}
+
+	return peers.Put(peer.IdentityPub.SerializeCompressed(), b.Bytes())
+}
+
+func serializePeer(w io.Writer, peer *Peer) error {
+	var buf [8]byte
+
+	byteOrder.PutUint64(buf[:], uint64(peer.LastConnected.Unix()))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+
+	// A zero gossip timestamp is stored as zero, rather than the unix
+	// time of the zero time.Time, so it's restored as such.
+	var lastGossip uint64
+	if !peer.LastGossip.IsZero() {
+		lastGossip = uint64(peer.LastGossip.Unix())
+	}
+	byteOrder.PutUint64(buf[:], lastGossip)
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint32(buf[:4], uint32(len(peer.Addresses)))
+	if _, err := w.Write(buf[:4]); err != nil {
+		return err
+	}
+	for _, addr := range peer.Addresses {
+		if err := serializeAddr(w, addr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func deserializePeer(r io.Reader) (*Peer, error) {
+	var buf [8]byte
+	peer := &Peer{}
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	peer.LastConnected = time.Unix(int64(byteOrder.Uint64(buf[:])), 0)
+
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	if lastGossip := byteOrder.Uint64(buf[:]); lastGossip != 0 {
+		peer.LastGossip = time.Unix(int64(lastGossip), 0)
+	}
+
+	if _, err := io.ReadFull(r, buf[:4]); err != nil {
+		return nil, err
+	}
+	numAddrs := byteOrder.Uint32(buf[:4])
+
+	peer.Addresses = make([]net.Addr, 0, numAddrs)
+	for i := uint32(0); i < numAddrs; i++ {
+		addr, err := deserializeAddr(r)
+		if err != nil {
+			return nil, err
+		}
+		peer.Addresses = append(peer.Addresses, addr)
+	}
+
+	return peer, nil
+}
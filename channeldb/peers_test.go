@@ -0,0 +1,143 @@
+package channeldb
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/roasbeef/btcd/btcec"
+)
+
+// TestPeerStore tests that connections to peers and the gossip received from
+// them are recorded within the peer store.
+func TestPeerStore(t *testing.T) {
+	t.Parallel()
+
+	cdb, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test database: %v", err)
+	}
+
+	_, pub := btcec.PrivKeyFromBytes(btcec.S256(), key[:])
+
+	// Before connecting to the peer, it should be unknown.
+	if _, err := cdb.FetchPeer(pub); err != ErrPeerNotFound {
+		t.Fatalf("expected ErrPeerNotFound, got %v", err)
+	}
+	err = cdb.UpdatePeerGossipTimestamp(pub, time.Unix(100, 0))
+	if err != ErrPeerNotFound {
+		t.Fatalf("expected ErrPeerNotFound, got %v", err)
+	}
+
+	assertPeer := func(lastConnected, lastGossip time.Time,
+		addrs ...string) {
+
+		t.Helper()
+
+		peer, err := cdb.FetchPeer(pub)
+		if err != nil {
+			t.Fatalf("unable to fetch peer: %v", err)
+		}
+		if !peer.IdentityPub.IsEqual(pub) {
+			t.Fatalf("identity key mismatch")
+		}
+		if !peer.LastConnected.Equal(lastConnected) {
+			t.Fatalf("expected last connection at %v, got %v",
+				lastConnected, peer.LastConnected)
+		}
+		if !peer.LastGossip.Equal(lastGossip) {
+			t.Fatalf("expected last gossip at %v, got %v",
+				lastGossip, peer.LastGossip)
+		}
+		if len(peer.Addresses) != len(addrs) {
+			t.Fatalf("expected %v addresses, got %v", len(addrs),
+				len(peer.Addresses))
+		}
+		for i, addr := range peer.Addresses {
+			if addr.String() != addrs[i] {
+				t.Fatalf("expected address %v, got %v",
+					addrs[i], addr)
+			}
+		}
+	}
+
+	addr1 := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 9735}
+	addr2 := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 9735}
+
+	err = cdb.RecordPeerConnection(pub, addr1, time.Unix(1000, 0))
+	if err != nil {
+		t.Fatalf("unable to record connection: %v", err)
+	}
+	assertPeer(time.Unix(1000, 0), time.Time{}, addr1.String())
+
+	// Connecting over a new address should make it the most recently used
+	// one, while reconnecting over an old one should move it to the
+	// front.
+	err = cdb.RecordPeerConnection(pub, addr2, time.Unix(2000, 0))
+	if err != nil {
+		t.Fatalf("unable to record connection: %v", err)
+	}
+	assertPeer(
+		time.Unix(2000, 0), time.Time{}, addr2.String(),
+		addr1.String(),
+	)
+	err = cdb.RecordPeerConnection(pub, addr1, time.Unix(3000, 0))
+	if err != nil {
+		t.Fatalf("unable to record connection: %v", err)
+	}
+	assertPeer(
+		time.Unix(3000, 0), time.Time{}, addr1.String(),
+		addr2.String(),
+	)
+
+	// The gossip timestamp should only ever move forward.
+	err = cdb.UpdatePeerGossipTimestamp(pub, time.Unix(2500, 0))
+	if err != nil {
+		t.Fatalf("unable to update gossip timestamp: %v", err)
+	}
+	err = cdb.UpdatePeerGossipTimestamp(pub, time.Unix(2400, 0))
+	if err != nil {
+		t.Fatalf("unable to update gossip timestamp: %v", err)
+	}
+	assertPeer(
+		time.Unix(3000, 0), time.Unix(2500, 0), addr1.String(),
+		addr2.String(),
+	)
+
+	// The number of addresses stored for the peer should be bounded.
+	for i := 0; i < MaxPeerAddresses+5; i++ {
+		addr := &net.TCPAddr{IP: net.IPv4(10, 0, 1, byte(i)), Port: 9735}
+		err := cdb.RecordPeerConnection(pub, addr, time.Unix(4000, 0))
+		if err != nil {
+			t.Fatalf("unable to record connection: %v", err)
+		}
+	}
+	peer, err := cdb.FetchPeer(pub)
+	if err != nil {
+		t.Fatalf("unable to fetch peer: %v", err)
+	}
+	if len(peer.Addresses) != MaxPeerAddresses {
+		t.Fatalf("expected %v addresses, got %v", MaxPeerAddresses,
+			len(peer.Addresses))
+	}
+	if peer.LastGossip.Unix() != 2500 {
+		t.Fatalf("gossip timestamp not retained")
+	}
+
+	peers, err := cdb.FetchAllPeers()
+	if err != nil {
+		t.Fatalf("unable to fetch peers: %v", err)
+	}
+	if len(peers) != 1 || !peers[0].IdentityPub.IsEqual(pub) {
+		t.Fatalf("expected peer to be returned, got %v", peers)
+	}
+
+	// Finally, once deleted, the peer should be unknown again.
+	if err := cdb.DeletePeer(pub); err != nil {
+		t.Fatalf("unable to delete peer: %v", err)
+	}
+	if _, err := cdb.FetchPeer(pub); err != ErrPeerNotFound {
+		t.Fatalf("expected ErrPeerNotFound, got %v", err)
+	}
+}
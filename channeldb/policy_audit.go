@@ -0,0 +1,263 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// policyAuditBucket is the name of the bucket within the database that
+	// stores the audit log of all decisions made by fee policies.
+	//
+	// Within the audit bucket, each record is keyed by the 8-byte unix
+	// nanosecond timestamp at which the decision was made, followed by an
+	// 8-byte sequence number which disambiguates records with the same
+	// timestamp. As both are big endian encoded, a cursor scan over the
+	// bucket visits the records in chronological order.
+	policyAuditBucket = []byte("policy-audit-log")
+)
+
+// PolicyDecision describes whether a fee policy permitted or rejected the
+// payment it governs, and in the latter case, why.
+type PolicyDecision uint8
+
+const (
+	// PolicyPermitted indicates that the route chosen for the payment was
+	// within the limits of the policy.
+	PolicyPermitted PolicyDecision = 0
+
+	// PolicyRejectedFee indicates that the payment was rejected as the
+	// fee of its route exceeded the fee limit of the policy.
+	PolicyRejectedFee PolicyDecision = 1
+
+	// PolicyRejectedCLTV indicates that the payment was rejected as the
+	// time lock of its route exceeded the CLTV limit of the policy.
+	PolicyRejectedCLTV PolicyDecision = 2
+
+	// PolicyRejectedBudget indicates that the payment was rejected before
+	// a route was requested, as it would have exceeded the budget of the
+	// policy.
+	PolicyRejectedBudget PolicyDecision = 3
+
+	// PolicyRejectedDeny indicates that the payment was rejected before a
+	// route was requested, as the policy denies all payments it governs.
+	PolicyRejectedDeny PolicyDecision = 4
+)
+
+// String returns a human readable representation of the decision.
+func (d PolicyDecision) String() string {
+	switch d {
+	case PolicyPermitted:
+		return "Permitted"
+	case PolicyRejectedFee:
+		return "RejectedFee"
+	case PolicyRejectedCLTV:
+		return "RejectedCLTV"
+	case PolicyRejectedBudget:
+		return "RejectedBudget"
+	case PolicyRejectedDeny:
+		return "RejectedDeny"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(d))
+	}
+}
+
+// PolicyAuditRecord records a single decision made by the fee policy which
+// governed a payment.
+type PolicyAuditRecord struct {
+	// Timestamp is the time at which the decision was made.
+	Timestamp time.Time
+
+	// PaymentHash is the payment hash of the payment the decision was
+	// made for.
+	PaymentHash [32]byte
+
+	// FeeRequested is the total fee in milli-satoshis of the route chosen
+	// for the payment. This is zero for payments rejected before a route
+	// was requested.
+	FeeRequested lnwire.MilliSatoshi
+
+	// FeeAllowed is the maximum total fee in milli-satoshis the policy
+	// allowed for the payment. This is zero for payments denied by the
+	// policy.
+	FeeAllowed lnwire.MilliSatoshi
+
+	// Decision is the decision the policy made for the payment.
+	Decision PolicyDecision
+}
+
+// AddPolicyAuditRecord appends the record to the policy audit log. Within the
+// same transaction, all records which have fallen out of the retention window
+// of the audit log, relative to the timestamp of the new record, are removed.
+func (db *DB) AddPolicyAuditRecord(record *PolicyAuditRecord) error {
+	var b bytes.Buffer
+	if err := serializePolicyAuditRecord(&b, record); err != nil {
+		return err
+	}
+
+	return db.Update(func(tx *bolt.Tx) error {
+		auditLog, err := tx.CreateBucketIfNotExists(policyAuditBucket)
+		if err != nil {
+			return err
+		}
+
+		seqNum, err := auditLog.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		var key [16]byte
+		timestamp := record.Timestamp.UnixNano()
+		byteOrder.PutUint64(key[:8], uint64(timestamp))
+		byteOrder.PutUint64(key[8:], seqNum)
+
+		if err := auditLog.Put(key[:], b.Bytes()); err != nil {
+			return err
+		}
+
+		if db.policyAuditRetention == 0 {
+			return nil
+		}
+
+		cutoff := record.Timestamp.Add(-db.policyAuditRetention)
+		return pruneTimeSeries(auditLog, cutoff)
+	})
+}
+
+// FetchPolicyAuditLog returns all records within the policy audit log whose
+// timestamp lies within the passed time range, inclusive, in chronological
+// order. A zero end time leaves the range unbounded from above.
+func (db *DB) FetchPolicyAuditLog(start,
+	end time.Time) ([]*PolicyAuditRecord, error) {
+
+	var records []*PolicyAuditRecord
+	err := db.View(func(tx *bolt.Tx) error {
+		auditLog := tx.Bucket(policyAuditBucket)
+		if auditLog == nil {
+			return nil
+		}
+
+		var startKey [8]byte
+		if !start.IsZero() {
+			startNano := start.UnixNano()
+			byteOrder.PutUint64(startKey[:], uint64(startNano))
+		}
+
+		c := auditLog.Cursor()
+		for k, v := c.Seek(startKey[:]); k != nil; k, v = c.Next() {
+			nano := int64(byteOrder.Uint64(k[:8]))
+			timestamp := time.Unix(0, nano)
+			if !end.IsZero() && timestamp.After(end) {
+				break
+			}
+
+			record, err := deserializePolicyAuditRecord(
+				bytes.NewReader(v),
+			)
+			if err != nil {
+				return err
+			}
+			record.Timestamp = timestamp
+
+			records = append(records, record)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// pruneTimeSeries removes all records from a time series bucket, such as the
+// audit log or the forwarding log, which were made before the cutoff. The keys
+// of the bucket must start with the big endian encoded unix timestamp of the
+// record in nanoseconds.
+func pruneTimeSeries(bucket *bolt.Bucket, cutoff time.Time) error {
+	// Timestamps before the unix epoch can't be encoded as keys, so no
+	// record can precede such a cutoff.
+	if cutoff.UnixNano() <= 0 {
+		return nil
+	}
+
+	var cutoffKey [8]byte
+	byteOrder.PutUint64(cutoffKey[:], uint64(cutoff.UnixNano()))
+
+	// As deleting keys while iterating over them with a cursor may cause
+	// keys to be skipped, we'll first gather the expired keys and delete
+	// them afterwards.
+	var expiredKeys [][]byte
+	c := bucket.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if bytes.Compare(k[:8], cutoffKey[:]) >= 0 {
+			break
+		}
+
+		expiredKeys = append(expiredKeys, append([]byte(nil), k...))
+	}
+
+	for _, k := range expiredKeys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serializePolicyAuditRecord writes the record to w. The timestamp of the
+// record isn't written, as it's part of the key the record is stored under.
+func serializePolicyAuditRecord(w io.Writer, r *PolicyAuditRecord) error {
+	if _, err := w.Write(r.PaymentHash[:]); err != nil {
+		return err
+	}
+
+	var scratch [8]byte
+	byteOrder.PutUint64(scratch[:], uint64(r.FeeRequested))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	byteOrder.PutUint64(scratch[:], uint64(r.FeeAllowed))
+	if _, err := w.Write(scratch[:]); err != nil {
+		return err
+	}
+
+	_, err := w.Write([]byte{byte(r.Decision)})
+	return err
+}
+
+// deserializePolicyAuditRecord reads a record written by
+// serializePolicyAuditRecord from r.
+func deserializePolicyAuditRecord(r io.Reader) (*PolicyAuditRecord, error) {
+	record := &PolicyAuditRecord{}
+
+	if _, err := io.ReadFull(r, record.PaymentHash[:]); err != nil {
+		return nil, err
+	}
+
+	var scratch [8]byte
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	record.FeeRequested = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:]); err != nil {
+		return nil, err
+	}
+	record.FeeAllowed = lnwire.MilliSatoshi(byteOrder.Uint64(scratch[:]))
+
+	if _, err := io.ReadFull(r, scratch[:1]); err != nil {
+		return nil, err
+	}
+	record.Decision = PolicyDecision(scratch[0])
+
+	return record, nil
+}
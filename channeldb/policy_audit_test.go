@@ -0,0 +1,93 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestPolicyAuditLog tests that policy audit records can be added to and
+// queried from the audit log, and that records are pruned once they fall out
+// of the retention window.
+func TestPolicyAuditLog(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+	db.policyAuditRetention = 24 * time.Hour
+
+	assertAuditLog := func(start, end time.Time,
+		expected []*PolicyAuditRecord) {
+
+		records, err := db.FetchPolicyAuditLog(start, end)
+		if err != nil {
+			t.Fatalf("unable to fetch audit log: %v", err)
+		}
+		if !reflect.DeepEqual(expected, records) {
+			t.Fatalf("wrong audit records: got %v, want %v",
+				spew.Sdump(records), spew.Sdump(expected))
+		}
+	}
+
+	// Before any records are added, the audit log should be empty.
+	assertAuditLog(time.Time{}, time.Time{}, nil)
+
+	now := time.Unix(time.Now().Unix(), 0)
+	permitted := &PolicyAuditRecord{
+		Timestamp:    now.Add(-2 * time.Hour),
+		PaymentHash:  [32]byte{1},
+		FeeRequested: 500,
+		FeeAllowed:   1000,
+		Decision:     PolicyPermitted,
+	}
+	rejected := &PolicyAuditRecord{
+		Timestamp:    now.Add(-time.Hour),
+		PaymentHash:  [32]byte{2},
+		FeeRequested: 2000,
+		FeeAllowed:   1000,
+		Decision:     PolicyRejectedFee,
+	}
+
+	// Records sharing a timestamp should both be kept.
+	overBudget := &PolicyAuditRecord{
+		Timestamp:   now.Add(-time.Hour),
+		PaymentHash: [32]byte{3},
+		FeeAllowed:  1000,
+		Decision:    PolicyRejectedBudget,
+	}
+
+	records := []*PolicyAuditRecord{permitted, rejected, overBudget}
+	for _, record := range records {
+		if err := db.AddPolicyAuditRecord(record); err != nil {
+			t.Fatalf("unable to add audit record: %v", err)
+		}
+	}
+
+	assertAuditLog(time.Time{}, time.Time{}, records)
+	assertAuditLog(now.Add(-time.Hour), time.Time{}, records[1:])
+	assertAuditLog(time.Time{}, now.Add(-time.Hour-1), records[:1])
+	assertAuditLog(now, time.Time{}, nil)
+
+	// Adding a record more than a day after the first one should prune
+	// it from the audit log, while keeping the others.
+	later := &PolicyAuditRecord{
+		Timestamp:    now.Add(23 * time.Hour),
+		PaymentHash:  [32]byte{4},
+		FeeRequested: 100,
+		FeeAllowed:   1000,
+		Decision:     PolicyPermitted,
+	}
+	if err := db.AddPolicyAuditRecord(later); err != nil {
+		t.Fatalf("unable to add audit record: %v", err)
+	}
+
+	assertAuditLog(
+		time.Time{}, time.Time{},
+		[]*PolicyAuditRecord{rejected, overBudget, later},
+	)
+}
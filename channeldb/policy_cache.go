@@ -0,0 +1,135 @@
+package channeldb
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// PolicyCacheStats describes the effectiveness of the policy cache.
+type PolicyCacheStats struct {
+	// Hits is the number of policy lookups answered from the cache.
+	Hits uint64
+
+	// Misses is the number of policy lookups which had to hit the
+	// database.
+	Misses uint64
+}
+
+// policyCacheEntry is a single entry within the policy cache.
+type policyCacheEntry struct {
+	paymentHash [32]byte
+	policies    []Policy
+}
+
+// policyCache is a fixed-size LRU cache of policies keyed by payment hash.
+// Each entry holds all policies for a payment hash, including those limited
+// to an amount band. Once the cache is full, the least recently used entry is
+// evicted to make room for a new one.
+type policyCache struct {
+	hits   uint64 // To be used atomically.
+	misses uint64 // To be used atomically.
+
+	size int
+
+	mtx     sync.Mutex
+	entries map[[32]byte]*list.Element
+	lru     *list.List
+}
+
+// newPolicyCache creates a new policy cache which holds the policies of at
+// most size payment hashes.
+func newPolicyCache(size int) *policyCache {
+	return &policyCache{
+		size:    size,
+		entries: make(map[[32]byte]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns a copy of the cached policies for the target payment hash, if
+// any, marking them as most recently used.
+func (c *policyCache) get(paymentHash [32]byte) ([]*Policy, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[paymentHash]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+
+	c.lru.MoveToFront(elem)
+	cached := elem.Value.(*policyCacheEntry).policies
+
+	policies := make([]*Policy, 0, len(cached))
+	for i := range cached {
+		policy := cached[i]
+		policies = append(policies, &policy)
+	}
+
+	return policies, true
+}
+
+// put inserts a copy of the policies for the target payment hash into the
+// cache, evicting the least recently used entry if the cache is full.
+func (c *policyCache) put(paymentHash [32]byte, policies []*Policy) {
+	// A cache of size zero is disabled.
+	if c.size <= 0 {
+		return
+	}
+
+	cached := make([]Policy, 0, len(policies))
+	for _, policy := range policies {
+		cached = append(cached, *policy)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[paymentHash]; ok {
+		elem.Value.(*policyCacheEntry).policies = cached
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	if c.lru.Len() >= c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*policyCacheEntry).paymentHash)
+	}
+
+	c.entries[paymentHash] = c.lru.PushFront(&policyCacheEntry{
+		paymentHash: paymentHash,
+		policies:    cached,
+	})
+}
+
+// remove evicts the policies for the target payment hash from the cache.
+func (c *policyCache) remove(paymentHash [32]byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[paymentHash]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, paymentHash)
+	}
+}
+
+// purge evicts all policies from the cache.
+func (c *policyCache) purge() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries = make(map[[32]byte]*list.Element)
+	c.lru.Init()
+}
+
+// stats returns the current hit and miss counters of the cache.
+func (c *policyCache) stats() PolicyCacheStats {
+	return PolicyCacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+}
@@ -0,0 +1,136 @@
+package channeldb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestPolicyCacheEviction tests that the policy cache evicts the least
+// recently used policy once full.
+func TestPolicyCacheEviction(t *testing.T) {
+	t.Parallel()
+
+	cache := newPolicyCache(2)
+
+	first := makeFakePolicy(1, 1000)
+	second := makeFakePolicy(2, 2000)
+	third := makeFakePolicy(3, 3000)
+
+	cache.put(first.PaymentHash, []*Policy{first})
+	cache.put(second.PaymentHash, []*Policy{second})
+
+	// Accessing the first policy marks it as most recently used, so the
+	// second one should be evicted once the third is added.
+	if _, ok := cache.get(first.PaymentHash); !ok {
+		t.Fatalf("expected first policy to be cached")
+	}
+	cache.put(third.PaymentHash, []*Policy{third})
+
+	if _, ok := cache.get(second.PaymentHash); ok {
+		t.Fatalf("expected second policy to be evicted")
+	}
+	for _, policy := range []*Policy{first, third} {
+		cached, ok := cache.get(policy.PaymentHash)
+		if !ok {
+			t.Fatalf("expected policy %x to be cached",
+				policy.PaymentHash[:])
+		}
+		if !reflect.DeepEqual([]*Policy{policy}, cached) {
+			t.Fatalf("policy mismatch: expected %v, got %v",
+				spew.Sdump(policy), spew.Sdump(cached))
+		}
+	}
+
+	stats := cache.stats()
+	if stats.Hits != 3 || stats.Misses != 1 {
+		t.Fatalf("expected 3 hits and 1 miss, got %v hits and %v "+
+			"misses", stats.Hits, stats.Misses)
+	}
+
+	// A cache of size zero should never hold any policies.
+	disabled := newPolicyCache(0)
+	disabled.put(first.PaymentHash, []*Policy{first})
+	if _, ok := disabled.get(first.PaymentHash); ok {
+		t.Fatalf("expected disabled cache to be empty")
+	}
+}
+
+// TestPolicyCacheInvalidation tests that policy lookups are served from the
+// cache, and that the cache is invalidated when policies are modified.
+func TestPolicyCacheInvalidation(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	lookupPolicy := func(expected *Policy) {
+		dbPolicy, err := db.LookupPolicy(expected.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to lookup policy: %v", err)
+		}
+		if !reflect.DeepEqual(expected, dbPolicy) {
+			t.Fatalf("policy mismatch: expected %v, got %v",
+				spew.Sdump(expected), spew.Sdump(dbPolicy))
+		}
+	}
+
+	assertStats := func(hits, misses uint64) {
+		stats := db.PolicyCacheStats()
+		if stats.Hits != hits || stats.Misses != misses {
+			t.Fatalf("expected %v hits and %v misses, got %v hits "+
+				"and %v misses", hits, misses, stats.Hits,
+				stats.Misses)
+		}
+	}
+
+	// As the cache is write-through, a lookup right after adding the
+	// policy should hit the cache.
+	fakePolicy := makeFakePolicy(1, 1000)
+	if err := db.AddPolicy(fakePolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	lookupPolicy(fakePolicy)
+	assertStats(1, 0)
+
+	// Modifying the policy returned by a lookup shouldn't affect the
+	// cached copy.
+	dbPolicy, err := db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	dbPolicy.Fee = 1
+	lookupPolicy(fakePolicy)
+	assertStats(3, 0)
+
+	// Updating the policy should invalidate the cache, so the next lookup
+	// misses and returns the updated policy, while the one after hits
+	// again.
+	err = db.UpdatePolicy(fakePolicy.PaymentHash, func(p *Policy) error {
+		p.Fee = 2000
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to update policy: %v", err)
+	}
+	fakePolicy.Fee = 2000
+	lookupPolicy(fakePolicy)
+	assertStats(3, 1)
+	lookupPolicy(fakePolicy)
+	assertStats(4, 1)
+
+	// Finally, once the policy is deleted, it should no longer be served
+	// from the cache.
+	if err := db.DeletePolicy(fakePolicy.PaymentHash); err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+	_, err = db.LookupPolicy(fakePolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+	assertStats(4, 2)
+}
@@ -0,0 +1,206 @@
+package channeldb
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/coreos/bbolt"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// encryptedPolicyVersion is the version byte which precedes policy
+	// records that are encrypted at rest. It is followed by a random
+	// nonce and the sealed serialized policy, which in turn starts with
+	// policySchemaVersion once opened.
+	encryptedPolicyVersion byte = 0x80
+
+	// PolicyEncryptionKeySize is the size in bytes of the symmetric key
+	// used to encrypt policy records at rest.
+	PolicyEncryptionKeySize = chacha20poly1305.KeySize
+)
+
+// policyCrypter seals and opens the stored values of policy records. Each
+// record is encrypted using ChaCha20-Poly1305, with the key it is stored under
+// as associated data. This binds the record to its key, so encrypted records
+// can't be moved to another payment hash or node undetected.
+type policyCrypter struct {
+	aead cipher.AEAD
+
+	// encrypt indicates whether newly written records are encrypted. If
+	// false, records are written in plaintext, while existing encrypted
+	// records can still be read.
+	encrypt bool
+}
+
+// newPolicyCrypter returns a policyCrypter which uses the passed key.
+func newPolicyCrypter(key [PolicyEncryptionKeySize]byte,
+	encrypt bool) (*policyCrypter, error) {
+
+	aead, err := chacha20poly1305.New(key[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyCrypter{
+		aead:    aead,
+		encrypt: encrypt,
+	}, nil
+}
+
+// seal returns the value to store under key for the passed serialized
+// policy. Unless encryption is enabled, the serialized policy is returned
+// unmodified. A nil policyCrypter never encrypts.
+func (c *policyCrypter) seal(key, policyBytes []byte) ([]byte, error) {
+	if c == nil || !c.encrypt {
+		return policyBytes, nil
+	}
+
+	nonceSize := c.aead.NonceSize()
+	value := make([]byte, 1+nonceSize, 1+nonceSize+len(policyBytes)+
+		c.aead.Overhead())
+	value[0] = encryptedPolicyVersion
+
+	nonce := value[1:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return c.aead.Seal(value, nonce, policyBytes, key), nil
+}
+
+// open returns the serialized policy stored under key as value, decrypting it
+// if necessary. If the value is encrypted, but the policyCrypter is nil, then
+// ErrPolicyEncrypted is returned.
+func (c *policyCrypter) open(key, value []byte) ([]byte, error) {
+	if !isEncryptedPolicy(value) {
+		return value, nil
+	}
+	if c == nil {
+		return nil, ErrPolicyEncrypted
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(value) < 1+nonceSize {
+		return nil, fmt.Errorf("encrypted policy is too short")
+	}
+
+	nonce, sealed := value[1:1+nonceSize], value[1+nonceSize:]
+	policyBytes, err := c.aead.Open(nil, nonce, sealed, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt policy: %v", err)
+	}
+
+	return policyBytes, nil
+}
+
+// isEncryptedPolicy returns true if the stored policy value is encrypted.
+func isEncryptedPolicy(value []byte) bool {
+	return len(value) > 0 && value[0] == encryptedPolicyVersion
+}
+
+// ConfigurePolicyEncryption installs the key used to encrypt policy records
+// at rest. If encrypt is true, all plaintext payment hash and node policies,
+// as well as the default policy, are encrypted, and all policies written from
+// now on are encrypted as well. Otherwise, all encrypted policies are
+// decrypted, and policies are written in plaintext. Either way, the existing
+// records are rewritten within a single transaction.
+//
+// As the key is usually derived from the wallet, this is meant to be called
+// once the wallet is unlocked, but before the policies are accessed by any
+// other caller. Until then, reading an encrypted policy fails with
+// ErrPolicyEncrypted.
+//
+// NOTE: Only the values of policy records are encrypted. The keys they are
+// stored under, i.e. payment hashes and node public keys, along with the
+// entries of the fee index, remain readable.
+func (db *DB) ConfigurePolicyEncryption(key [PolicyEncryptionKeySize]byte,
+	encrypt bool) error {
+
+	crypter, err := newPolicyCrypter(key, encrypt)
+	if err != nil {
+		return err
+	}
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	buckets := [][]byte{policyBucket, nodePolicyBucket}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range buckets {
+			policies := tx.Bucket(bucket)
+			if policies == nil {
+				continue
+			}
+
+			err := reencryptPolicies(policies, crypter)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	db.policyCrypter = crypter
+	db.policyCache.purge()
+
+	return nil
+}
+
+// reencryptPolicies rewrites all records within the policy bucket whose
+// encryption doesn't match the setting of the passed policyCrypter.
+func reencryptPolicies(policies *bolt.Bucket, crypter *policyCrypter) error {
+	// We'll first gather the rewritten records, as it isn't safe to
+	// modify a bucket while iterating over it.
+	var keys, values [][]byte
+	err := policies.ForEach(func(k, v []byte) error {
+		// If the value is nil, then we ignore it as it may be a
+		// sub-bucket.
+		if v == nil || isEncryptedPolicy(v) == crypter.encrypt {
+			return nil
+		}
+
+		policyBytes, err := crypter.open(k, v)
+		if err != nil {
+			return err
+		}
+
+		value, err := crypter.seal(k, policyBytes)
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, append([]byte(nil), k...))
+		values = append(values, value)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, k := range keys {
+		if err := policies.Put(k, values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodePolicy decodes the policy stored under key as value, decrypting it if
+// necessary.
+func (db *DB) decodePolicy(key, value []byte) (*Policy, error) {
+	policyBytes, err := db.policyCrypter.open(key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return deserializePolicy(bytes.NewReader(policyBytes))
+}
@@ -0,0 +1,171 @@
+package channeldb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/coreos/bbolt"
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestPolicyEncryption tests that policies are transparently encrypted at
+// rest once policy encryption is enabled, and decrypted once it's disabled.
+func TestPolicyEncryption(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	var key, otherKey [PolicyEncryptionKeySize]byte
+	copy(key[:], bytes.Repeat([]byte{1}, PolicyEncryptionKeySize))
+	copy(otherKey[:], bytes.Repeat([]byte{2}, PolicyEncryptionKeySize))
+
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	hashPolicy := makeFakePolicy(1, 1000)
+	hashPolicy.Label = "supplier"
+	nodePolicy := &Policy{Fee: 2000}
+	defaultPolicy := &Policy{Fee: 3000}
+
+	// Add the policies in plaintext, before encryption is enabled.
+	if err := db.AddPolicy(hashPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	if err := db.AddNodePolicy(nodePub, nodePolicy); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+	if err := db.SetDefaultPolicy(defaultPolicy); err != nil {
+		t.Fatalf("unable to set default policy: %v", err)
+	}
+
+	// assertEncrypted checks whether all policy records are stored
+	// encrypted or in plaintext.
+	assertEncrypted := func(encrypted bool) {
+		checkPolicy := func(k, v []byte) error {
+			if v != nil && isEncryptedPolicy(v) != encrypted {
+				t.Fatalf("expected policy %x to be "+
+					"encrypted=%v", k, encrypted)
+			}
+			return nil
+		}
+
+		err := db.View(func(tx *bolt.Tx) error {
+			err := tx.Bucket(policyBucket).ForEach(checkPolicy)
+			if err != nil {
+				return err
+			}
+
+			nodePolicies := tx.Bucket(nodePolicyBucket)
+			return nodePolicies.ForEach(checkPolicy)
+		})
+		if err != nil {
+			t.Fatalf("unable to read policies: %v", err)
+		}
+	}
+
+	assertPolicies := func() {
+		dbHashPolicy, err := db.LookupPolicy(hashPolicy.PaymentHash)
+		if err != nil {
+			t.Fatalf("unable to lookup policy: %v", err)
+		}
+		if !reflect.DeepEqual(hashPolicy, dbHashPolicy) {
+			t.Fatalf("policy mismatch: expected %v, got %v",
+				spew.Sdump(hashPolicy),
+				spew.Sdump(dbHashPolicy))
+		}
+
+		dbNodePolicy, err := db.LookupNodePolicy(nodePub)
+		if err != nil {
+			t.Fatalf("unable to lookup node policy: %v", err)
+		}
+		if !reflect.DeepEqual(nodePolicy, dbNodePolicy) {
+			t.Fatalf("node policy mismatch: expected %v, got %v",
+				spew.Sdump(nodePolicy),
+				spew.Sdump(dbNodePolicy))
+		}
+
+		dbDefaultPolicy, err := db.FetchDefaultPolicy()
+		if err != nil {
+			t.Fatalf("unable to fetch default policy: %v", err)
+		}
+		if !reflect.DeepEqual(defaultPolicy, dbDefaultPolicy) {
+			t.Fatalf("default policy mismatch: expected %v, got %v",
+				spew.Sdump(defaultPolicy),
+				spew.Sdump(dbDefaultPolicy))
+		}
+	}
+
+	// Enabling encryption should encrypt all existing records, while
+	// leaving their contents unchanged.
+	assertEncrypted(false)
+	if err := db.ConfigurePolicyEncryption(key, true); err != nil {
+		t.Fatalf("unable to enable policy encryption: %v", err)
+	}
+	assertEncrypted(true)
+	assertPolicies()
+
+	// Policies written from now on should be encrypted as well. Strictly
+	// re-adding an identical policy shouldn't be seen as a conflict,
+	// even though the encrypted records differ.
+	secondPolicy := makeFakePolicy(2, 4000)
+	if err := db.AddPolicy(secondPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+	if err := db.AddPolicyStrict(makeFakePolicy(2, 4000)); err != nil {
+		t.Fatalf("unable to re-add identical policy: %v", err)
+	}
+	assertEncrypted(true)
+
+	// An encrypted record moved to another payment hash shouldn't be
+	// accepted.
+	var original []byte
+	err = db.Update(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		from := policies.Get(secondPolicy.PaymentHash[:])
+		to := hashPolicy.PaymentHash[:]
+		original = append([]byte(nil), policies.Get(to)...)
+		return policies.Put(to, append([]byte(nil), from...))
+	})
+	if err != nil {
+		t.Fatalf("unable to move policy: %v", err)
+	}
+	db.policyCache.purge()
+	if _, err := db.LookupPolicy(hashPolicy.PaymentHash); err == nil {
+		t.Fatalf("expected moved policy to be rejected")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		policies := tx.Bucket(policyBucket)
+		return policies.Put(hashPolicy.PaymentHash[:], original)
+	})
+	if err != nil {
+		t.Fatalf("unable to restore policy: %v", err)
+	}
+
+	// Without the key, as is the case after a restart before the wallet
+	// is unlocked, encrypted policies can't be read.
+	db.policyCrypter = nil
+	db.policyCache.purge()
+	_, err = db.LookupPolicy(hashPolicy.PaymentHash)
+	if err != ErrPolicyEncrypted {
+		t.Fatalf("expected ErrPolicyEncrypted, got %v", err)
+	}
+
+	// Disabling encryption using the wrong key should fail, leaving the
+	// records encrypted.
+	if err := db.ConfigurePolicyEncryption(otherKey, false); err == nil {
+		t.Fatalf("expected decryption with wrong key to fail")
+	}
+	assertEncrypted(true)
+
+	// Using the right key, all records should be decrypted.
+	if err := db.ConfigurePolicyEncryption(key, false); err != nil {
+		t.Fatalf("unable to disable policy encryption: %v", err)
+	}
+	assertEncrypted(false)
+	assertPolicies()
+}
@@ -0,0 +1,297 @@
+package channeldb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/coreos/bbolt"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// jsonPolicy is the JSON representation of a single policy within a policy
+// export. Exactly one of PaymentHash, NodePub and Default is set, depending on
+// whether the policy governs a payment hash, a destination node or is the
+// default policy.
+type jsonPolicy struct {
+	PaymentHash         string `json:"payment_hash,omitempty"`
+	NodePub             string `json:"node_pub,omitempty"`
+	Default             bool   `json:"default,omitempty"`
+	Fee                 uint64 `json:"fee_msat"`
+	BaseFee             uint64 `json:"base_fee_msat,omitempty"`
+	FeeRate             uint64 `json:"fee_rate_ppm,omitempty"`
+	ExpiryHeight        uint32 `json:"expiry_height,omitempty"`
+	ExpiryTime          int64  `json:"expiry_time,omitempty"`
+	Budget              uint64 `json:"budget_msat,omitempty"`
+	SpentToDate         uint64 `json:"spent_to_date_msat,omitempty"`
+	MinAmt              uint64 `json:"min_amt_msat,omitempty"`
+	MaxAmt              uint64 `json:"max_amt_msat,omitempty"`
+	MaxCLTVDelta        uint32 `json:"max_cltv_delta,omitempty"`
+	Label               string `json:"label,omitempty"`
+	Deny                bool   `json:"deny,omitempty"`
+	OutgoingChanID      uint64 `json:"outgoing_chan_id,omitempty"`
+	FeeRejections       uint32 `json:"fee_rejections,omitempty"`
+	CheapestRejectedFee uint64 `json:"cheapest_rejected_fee_msat,omitempty"`
+}
+
+// newJSONPolicy converts the policy into its JSON representation. The node
+// public key is only set for policies which govern a destination node, while
+// isDefault marks the default policy.
+func newJSONPolicy(p *Policy, nodePub []byte, isDefault bool) *jsonPolicy {
+	jp := &jsonPolicy{
+		Fee:                 uint64(p.Fee),
+		BaseFee:             uint64(p.BaseFee),
+		FeeRate:             uint64(p.FeeRate),
+		ExpiryHeight:        p.ExpiryHeight,
+		Budget:              uint64(p.Budget),
+		SpentToDate:         uint64(p.SpentToDate),
+		MinAmt:              uint64(p.MinAmt),
+		MaxAmt:              uint64(p.MaxAmt),
+		MaxCLTVDelta:        p.MaxCLTVDelta,
+		Label:               p.Label,
+		Deny:                p.Deny,
+		OutgoingChanID:      p.OutgoingChanID,
+		FeeRejections:       p.FeeRejections,
+		CheapestRejectedFee: uint64(p.CheapestRejectedFee),
+	}
+	if !p.ExpiryTime.IsZero() {
+		jp.ExpiryTime = p.ExpiryTime.Unix()
+	}
+
+	switch {
+	case isDefault:
+		jp.Default = true
+	case nodePub != nil:
+		jp.NodePub = hex.EncodeToString(nodePub)
+	default:
+		jp.PaymentHash = hex.EncodeToString(p.PaymentHash[:])
+	}
+
+	return jp
+}
+
+// policy converts the JSON representation back into a policy, returning the
+// node public key the policy governs, if any.
+func (jp *jsonPolicy) policy() (*Policy, []byte, error) {
+	p := &Policy{
+		Fee:            lnwire.MilliSatoshi(jp.Fee),
+		BaseFee:        lnwire.MilliSatoshi(jp.BaseFee),
+		FeeRate:        lnwire.MilliSatoshi(jp.FeeRate),
+		ExpiryHeight:   jp.ExpiryHeight,
+		Budget:         lnwire.MilliSatoshi(jp.Budget),
+		SpentToDate:    lnwire.MilliSatoshi(jp.SpentToDate),
+		MinAmt:         lnwire.MilliSatoshi(jp.MinAmt),
+		MaxAmt:         lnwire.MilliSatoshi(jp.MaxAmt),
+		MaxCLTVDelta:   jp.MaxCLTVDelta,
+		Label:          jp.Label,
+		Deny:           jp.Deny,
+		OutgoingChanID: jp.OutgoingChanID,
+		FeeRejections:  jp.FeeRejections,
+		CheapestRejectedFee: lnwire.MilliSatoshi(
+			jp.CheapestRejectedFee,
+		),
+	}
+	if jp.ExpiryTime != 0 {
+		p.ExpiryTime = time.Unix(jp.ExpiryTime, 0)
+	}
+
+	if err := p.validateAmountBand(); err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case jp.PaymentHash != "" && jp.NodePub != "":
+		return nil, nil, fmt.Errorf("policy may not apply to both a " +
+			"payment hash and a node")
+
+	case jp.Default && (jp.PaymentHash != "" || jp.NodePub != ""):
+		return nil, nil, fmt.Errorf("default policy may not apply " +
+			"to a payment hash or a node")
+
+	case jp.Default:
+		if p.HasAmountBand() {
+			return nil, nil, ErrDefaultPolicyAmountBand
+		}
+
+		return p, nil, nil
+
+	case jp.NodePub != "":
+		nodePub, err := hex.DecodeString(jp.NodePub)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(nodePub) != 33 {
+			return nil, nil, fmt.Errorf("node pubkey must be "+
+				"exactly 33 bytes, is instead %v", len(nodePub))
+		}
+
+		return p, nodePub, nil
+
+	default:
+		paymentHash, err := hex.DecodeString(jp.PaymentHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(paymentHash) != 32 {
+			return nil, nil, fmt.Errorf("payment hash must be "+
+				"exactly 32 bytes, is instead %v",
+				len(paymentHash))
+		}
+		copy(p.PaymentHash[:], paymentHash)
+
+		return p, nil, nil
+	}
+}
+
+// ExportPolicies writes all payment hash and node policies stored in the DB,
+// along with the default policy, to w in the JSON lines format, i.e. as one
+// JSON object per line. The export can be restored on this or any other node
+// using ImportPolicies.
+func (db *DB) ExportPolicies(w io.Writer) error {
+	return db.View(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{policyBucket, nodePolicyBucket} {
+			policies := tx.Bucket(bucket)
+			if policies == nil {
+				continue
+			}
+
+			err := policies.ForEach(func(k, v []byte) error {
+				// If the value is nil, then we ignore it as it
+				// may be a sub-bucket.
+				if v == nil {
+					return nil
+				}
+
+				policy, err := db.decodePolicy(k, v)
+				if err != nil {
+					return err
+				}
+
+				// Node policies are keyed by the public key
+				// of the node, optionally followed by their
+				// amount band.
+				var (
+					nodePub   []byte
+					isDefault bool
+				)
+				switch {
+				case bytes.Equal(bucket, nodePolicyBucket):
+					nodePub = k[:33]
+				case bytes.Equal(k, defaultPolicyKey):
+					isDefault = true
+				}
+
+				jp := newJSONPolicy(policy, nodePub, isDefault)
+				line, err := json.Marshal(jp)
+				if err != nil {
+					return err
+				}
+
+				_, err = w.Write(append(line, '\n'))
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ImportPolicies reads policies in the JSON lines format written by
+// ExportPolicies from r and saves them to the DB, overwriting any existing
+// policies for the same payment hash or node and amount band, as well as the
+// default policy if the export contains one. Blank lines are skipped. The
+// policies are imported within a single transaction, so if any of them is
+// invalid, none are imported. The number of imported policies is returned.
+func (db *DB) ImportPolicies(r io.Reader) (int, error) {
+	type importedPolicy struct {
+		bucket []byte
+		key    []byte
+		policy *Policy
+	}
+
+	// We'll first decode all policies before starting the database
+	// transaction, so a malformed export doesn't hold it open.
+	var imported []importedPolicy
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var jp jsonPolicy
+		if err := json.Unmarshal(line, &jp); err != nil {
+			return 0, fmt.Errorf("unable to decode policy on line "+
+				"%v: %v", lineNum, err)
+		}
+
+		policy, nodePub, err := jp.policy()
+		if err != nil {
+			return 0, fmt.Errorf("invalid policy on line %v: %v",
+				lineNum, err)
+		}
+
+		bucket := policyBucket
+		key := policyKey(policy.PaymentHash[:], policy)
+		switch {
+		case jp.Default:
+			key = defaultPolicyKey
+		case nodePub != nil:
+			bucket = nodePolicyBucket
+			key = policyKey(nodePub, policy)
+		}
+
+		imported = append(imported, importedPolicy{
+			bucket: bucket,
+			key:    key,
+			policy: policy,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	db.policyCacheMtx.Lock()
+	defer db.policyCacheMtx.Unlock()
+
+	defer db.policyCache.purge()
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		hashPolicies, err := createPolicyBucket(tx)
+		if err != nil {
+			return err
+		}
+		nodePolicies, err := tx.CreateBucketIfNotExists(
+			nodePolicyBucket,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range imported {
+			policies := hashPolicies
+			if bytes.Equal(p.bucket, nodePolicyBucket) {
+				policies = nodePolicies
+			}
+
+			err := db.putPolicy(policies, p.key, p.policy)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(imported), nil
+}
@@ -0,0 +1,142 @@
+package channeldb
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestPolicyExportImport tests that policies exported from one database can
+// be imported into another, and that malformed exports are rejected as a
+// whole.
+func TestPolicyExportImport(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	hashPolicy := makeFakePolicy(1, 1000)
+	hashPolicy.FeeRate = 500
+	hashPolicy.ExpiryTime = time.Unix(time.Now().Unix(), 0)
+	hashPolicy.Budget = 100000
+	hashPolicy.SpentToDate = 5000
+	hashPolicy.Label = "invoice from vendor"
+
+	bandedPolicy := makeFakePolicy(1, 3000)
+	bandedPolicy.MinAmt = 100000
+	bandedPolicy.MaxCLTVDelta = 144
+	bandedPolicy.OutgoingChanID = 12345
+	bandedPolicy.FeeRejections = 2
+	bandedPolicy.CheapestRejectedFee = 4000
+
+	hashPolicies := []*Policy{hashPolicy, bandedPolicy}
+	for _, policy := range hashPolicies {
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	var nodePub [33]byte
+	copy(nodePub[:], bytes.Repeat([]byte{2}, 33))
+
+	nodePolicy := &Policy{Fee: 2000, ExpiryHeight: 500000, Deny: true}
+	if err := db.AddNodePolicy(nodePub, nodePolicy); err != nil {
+		t.Fatalf("unable to add node policy: %v", err)
+	}
+
+	defaultPolicy := &Policy{Fee: 10000, Label: "global ceiling"}
+	if err := db.SetDefaultPolicy(defaultPolicy); err != nil {
+		t.Fatalf("unable to set default policy: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := db.ExportPolicies(&b); err != nil {
+		t.Fatalf("unable to export policies: %v", err)
+	}
+	export := b.String()
+
+	numLines := strings.Count(export, "\n")
+	if numLines != 4 {
+		t.Fatalf("expected 4 exported policies, got %v", numLines)
+	}
+
+	otherDB, otherCleanUp, err := makeTestDB()
+	defer otherCleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	// A malformed line should cause the entire import to be rejected.
+	_, err = otherDB.ImportPolicies(strings.NewReader(export + "{\n"))
+	if err == nil {
+		t.Fatalf("expected import of malformed export to fail")
+	}
+	if _, err := otherDB.FetchAllPolicies(); err != ErrNoPoliciesCreated {
+		t.Fatalf("expected ErrNoPoliciesCreated, got %v", err)
+	}
+
+	// Blank lines within an export should be skipped.
+	numImported, err := otherDB.ImportPolicies(
+		strings.NewReader(export + "\n"),
+	)
+	if err != nil {
+		t.Fatalf("unable to import policies: %v", err)
+	}
+	if numImported != 4 {
+		t.Fatalf("expected 4 imported policies, got %v", numImported)
+	}
+
+	dbPolicies, err := otherDB.FetchAllPolicies()
+	if err != nil {
+		t.Fatalf("unable to fetch policies: %v", err)
+	}
+	if !reflect.DeepEqual(hashPolicies, dbPolicies) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(hashPolicies), spew.Sdump(dbPolicies))
+	}
+
+	dbPolicy, err := otherDB.LookupNodePolicy(nodePub)
+	if err != nil {
+		t.Fatalf("unable to lookup node policy: %v", err)
+	}
+	if !reflect.DeepEqual(nodePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(nodePolicy), spew.Sdump(dbPolicy))
+	}
+
+	dbPolicy, err = otherDB.FetchDefaultPolicy()
+	if err != nil {
+		t.Fatalf("unable to fetch default policy: %v", err)
+	}
+	if !reflect.DeepEqual(defaultPolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(defaultPolicy), spew.Sdump(dbPolicy))
+	}
+
+	// Records which don't identify what they apply to, or apply to more
+	// than one of a payment hash, a node and the default policy, are
+	// invalid.
+	invalidRecords := []string{
+		`{"fee_msat":1000}`,
+		`{"payment_hash":"0101","fee_msat":1000}`,
+		`{"node_pub":"02","fee_msat":1000}`,
+		`{"payment_hash":"` + strings.Repeat("01", 32) + `",` +
+			`"node_pub":"` + strings.Repeat("02", 33) + `"}`,
+		`{"default":true,"node_pub":"` + strings.Repeat("02", 33) +
+			`"}`,
+		`{"default":true,"min_amt_msat":1000}`,
+	}
+	for _, record := range invalidRecords {
+		_, err := otherDB.ImportPolicies(strings.NewReader(record))
+		if err == nil {
+			t.Fatalf("expected import of %v to fail", record)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package channeldb
+
+// PolicyStore is an interface which abstracts over the persistence of fee
+// policies keyed by payment hash. This allows callers to remain agnostic of
+// the backend used to store policies, be it the channel database itself, a
+// SQL database, a remote key-value store, or an in-memory map.
+type PolicyStore interface {
+	// AddPolicy saves a policy to the store. If a policy for the same
+	// payment hash already exists, it will be overwritten.
+	AddPolicy(policy *Policy) error
+
+	// LookupPolicy attempts to look up the policy for the target payment
+	// hash. If no such policy exists, then ErrPolicyNotFound is returned.
+	LookupPolicy(paymentHash [32]byte) (*Policy, error)
+
+	// DeletePolicy removes the policy for the target payment hash from
+	// the store. If no such policy exists, then ErrPolicyNotFound is
+	// returned.
+	DeletePolicy(paymentHash [32]byte) error
+
+	// ForEachPolicies executes the passed callback for each stored policy
+	// in the order of their payment hash. If no policy has ever been
+	// added to the store, then ErrNoPoliciesCreated is returned. If the
+	// callback returns ErrStopPolicyIteration, the iteration stops early
+	// and nil is returned, any other error is passed through.
+	ForEachPolicies(cb func(*Policy) error) error
+}
+
+// A compile time check to ensure DB implements the PolicyStore interface.
+var _ PolicyStore = (*DB)(nil)
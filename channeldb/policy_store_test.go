@@ -0,0 +1,312 @@
+package channeldb
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// memPolicyStore is an in-memory implementation of the PolicyStore
+// interface.
+type memPolicyStore struct {
+	sync.RWMutex
+
+	policies map[[32]byte]Policy
+}
+
+// A compile time check to ensure memPolicyStore implements the PolicyStore
+// interface.
+var _ PolicyStore = (*memPolicyStore)(nil)
+
+func newMemPolicyStore() *memPolicyStore {
+	return &memPolicyStore{}
+}
+
+func (m *memPolicyStore) AddPolicy(policy *Policy) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.policies == nil {
+		m.policies = make(map[[32]byte]Policy)
+	}
+	m.policies[policy.PaymentHash] = *policy
+
+	return nil
+}
+
+func (m *memPolicyStore) LookupPolicy(paymentHash [32]byte) (*Policy, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	policy, ok := m.policies[paymentHash]
+	if !ok {
+		return nil, ErrPolicyNotFound
+	}
+
+	return &policy, nil
+}
+
+func (m *memPolicyStore) DeletePolicy(paymentHash [32]byte) error {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, ok := m.policies[paymentHash]; !ok {
+		return ErrPolicyNotFound
+	}
+	delete(m.policies, paymentHash)
+
+	return nil
+}
+
+func (m *memPolicyStore) ForEachPolicies(cb func(*Policy) error) error {
+	m.RLock()
+	defer m.RUnlock()
+
+	if m.policies == nil {
+		return ErrNoPoliciesCreated
+	}
+
+	hashes := make([][32]byte, 0, len(m.policies))
+	for hash := range m.policies {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+
+	for _, hash := range hashes {
+		policy := m.policies[hash]
+		err := cb(&policy)
+		if err == ErrStopPolicyIteration {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchStorePolicies returns all policies within the passed store.
+func fetchStorePolicies(t *testing.T, store PolicyStore) []*Policy {
+	var policies []*Policy
+	err := store.ForEachPolicies(func(policy *Policy) error {
+		policies = append(policies, policy)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate policies: %v", err)
+	}
+
+	return policies
+}
+
+// testAddLookupPolicy tests that policies can be added and looked up, and
+// that adding a policy for an existing payment hash overwrites it.
+func testAddLookupPolicy(store PolicyStore, t *testing.T) {
+	// Looking up a policy before any have been added should fail.
+	fakePolicy := makeFakePolicy(1, 1000)
+	_, err := store.LookupPolicy(fakePolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	if err := store.AddPolicy(fakePolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	dbPolicy, err := store.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(fakePolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(fakePolicy), spew.Sdump(dbPolicy))
+	}
+
+	// Adding a policy for the same payment hash should replace the
+	// existing one.
+	newPolicy := makeFakePolicy(1, 2000)
+	newPolicy.FeeRate = 100
+	if err := store.AddPolicy(newPolicy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	dbPolicy, err = store.LookupPolicy(fakePolicy.PaymentHash)
+	if err != nil {
+		t.Fatalf("unable to lookup policy: %v", err)
+	}
+	if !reflect.DeepEqual(newPolicy, dbPolicy) {
+		t.Fatalf("policy mismatch: expected %v, got %v",
+			spew.Sdump(newPolicy), spew.Sdump(dbPolicy))
+	}
+}
+
+// testDeletePolicy tests that a single policy can be removed without
+// affecting any other stored policies.
+func testDeletePolicy(store PolicyStore, t *testing.T) {
+	firstPolicy := makeFakePolicy(1, 1000)
+	secondPolicy := makeFakePolicy(2, 2000)
+
+	// Deleting a policy that was never added should fail.
+	err := store.DeletePolicy(firstPolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+
+	for _, policy := range []*Policy{firstPolicy, secondPolicy} {
+		if err := store.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	if err := store.DeletePolicy(firstPolicy.PaymentHash); err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+
+	// The deleted policy should no longer be found, while the other one
+	// should remain untouched.
+	_, err = store.LookupPolicy(firstPolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+	policies := fetchStorePolicies(t, store)
+	expectedPolicies := []*Policy{secondPolicy}
+	if !reflect.DeepEqual(policies, expectedPolicies) {
+		t.Fatalf("wrong policies after deletion: got %v, want %v",
+			spew.Sdump(policies), spew.Sdump(expectedPolicies))
+	}
+
+	// A second deletion of the same policy should fail.
+	err = store.DeletePolicy(firstPolicy.PaymentHash)
+	if err != ErrPolicyNotFound {
+		t.Fatalf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
+
+// testForEachPolicies tests that the policy iterator visits every stored
+// policy, and that the iteration can be stopped early by the callback.
+func testForEachPolicies(store PolicyStore, t *testing.T) {
+	// Iterating before any policies have been added should fail.
+	err := store.ForEachPolicies(func(*Policy) error {
+		return nil
+	})
+	if err != ErrNoPoliciesCreated {
+		t.Fatalf("expected ErrNoPoliciesCreated, got %v", err)
+	}
+
+	// We'll add the policies in reverse order to ensure they're returned
+	// in the order of their payment hash rather than insertion order.
+	var expectedPolicies []*Policy
+	for i := byte(5); i >= 1; i-- {
+		policy := makeFakePolicy(i, lnwire.MilliSatoshi(i)*1000)
+		if err := store.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+		expectedPolicies = append([]*Policy{policy}, expectedPolicies...)
+	}
+
+	policies := fetchStorePolicies(t, store)
+	if !reflect.DeepEqual(policies, expectedPolicies) {
+		t.Fatalf("wrong policies: got %v, want %v",
+			spew.Sdump(policies), spew.Sdump(expectedPolicies))
+	}
+
+	// Returning the sentinel error should stop the iteration without
+	// surfacing an error.
+	var numVisited int
+	err = store.ForEachPolicies(func(*Policy) error {
+		numVisited++
+		if numVisited == 2 {
+			return ErrStopPolicyIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to iterate policies: %v", err)
+	}
+	if numVisited != 2 {
+		t.Fatalf("expected iteration to stop after 2 policies, "+
+			"visited %v", numVisited)
+	}
+
+	// Any other error should abort the iteration and be returned.
+	errFake := fmt.Errorf("fake error")
+	err = store.ForEachPolicies(func(*Policy) error {
+		return errFake
+	})
+	if err != errFake {
+		t.Fatalf("expected %v, got %v", errFake, err)
+	}
+}
+
+type policyStoreTestCase struct {
+	name string
+	test func(store PolicyStore, t *testing.T)
+}
+
+var policyStoreTests = []policyStoreTestCase{
+	{
+		name: "add and lookup",
+		test: testAddLookupPolicy,
+	},
+	{
+		name: "delete",
+		test: testDeletePolicy,
+	},
+	{
+		name: "for each",
+		test: testForEachPolicies,
+	},
+}
+
+// TestPolicyStore runs the shared policy store test cases against each
+// implementation of the PolicyStore interface, using a fresh store for each
+// test case.
+func TestPolicyStore(t *testing.T) {
+	t.Parallel()
+
+	storeTypes := []struct {
+		name      string
+		makeStore func() (PolicyStore, func(), error)
+	}{
+		{
+			name: "bolt",
+			makeStore: func() (PolicyStore, func(), error) {
+				return makeTestDB()
+			},
+		},
+		{
+			name: "memory",
+			makeStore: func() (PolicyStore, func(), error) {
+				return newMemPolicyStore(), func() {}, nil
+			},
+		},
+	}
+
+	for _, storeType := range storeTypes {
+		for _, storeTest := range policyStoreTests {
+			testName := fmt.Sprintf("%v:%v", storeType.name,
+				storeTest.name)
+
+			success := t.Run(testName, func(t *testing.T) {
+				store, cleanUp, err := storeType.makeStore()
+				if err != nil {
+					t.Fatalf("unable to make store: %v", err)
+				}
+				defer cleanUp()
+
+				storeTest.test(store, t)
+			})
+			if !success {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package channeldb
+
+import (
+	"io"
+
+	"github.com/coreos/bbolt"
+)
+
+// Snapshot writes a consistent, point-in-time copy of the entire database to
+// w, returning the number of bytes written. The copy is taken within a single
+// read transaction, so it's safe to call while the database is in use. The
+// written copy is itself a valid database, which can be opened in place of
+// the original.
+//
+// NOTE: As the read transaction is held open until the copy has been fully
+// written, a slow writer delays the reuse of pages freed in the meantime,
+// causing the database to grow.
+func (d *DB) Snapshot(w io.Writer) (int64, error) {
+	var n int64
+	err := d.View(func(tx *bolt.Tx) error {
+		var err error
+		n, err = tx.WriteTo(w)
+		return err
+	})
+
+	return n, err
+}
@@ -0,0 +1,75 @@
+package channeldb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coreos/bbolt"
+)
+
+// TestSnapshot tests that a snapshot of the database can be opened as a
+// database holding the same contents as the original.
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to make test db: %v", err)
+	}
+
+	if err := db.AddPolicy(makeFakePolicy(1, 1000)); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	var snapshot bytes.Buffer
+	n, err := db.Snapshot(&snapshot)
+	if err != nil {
+		t.Fatalf("unable to take snapshot: %v", err)
+	}
+	if n != int64(snapshot.Len()) {
+		t.Fatalf("expected %d bytes to be written, got %d",
+			snapshot.Len(), n)
+	}
+
+	tempDirName, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDirName)
+
+	path := filepath.Join(tempDirName, dbName)
+	err = ioutil.WriteFile(path, snapshot.Bytes(), dbFilePermission)
+	if err != nil {
+		t.Fatalf("unable to write snapshot: %v", err)
+	}
+
+	snapshotDB, err := bolt.Open(path, dbFilePermission, nil)
+	if err != nil {
+		t.Fatalf("unable to open snapshot: %v", err)
+	}
+	defer snapshotDB.Close()
+
+	var digest, snapshotDigest []byte
+	err = db.View(func(tx *bolt.Tx) error {
+		digest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to digest db: %v", err)
+	}
+	err = snapshotDB.View(func(tx *bolt.Tx) error {
+		snapshotDigest, err = digestDB(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to digest snapshot: %v", err)
+	}
+
+	if !bytes.Equal(digest, snapshotDigest) {
+		t.Fatalf("snapshot doesn't match database")
+	}
+}
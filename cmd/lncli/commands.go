@@ -16,11 +16,15 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"text/tabwriter"
 
 	"github.com/awalterschulze/gographviz"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/roasbeef/btcd/btcec"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
 	"github.com/urfave/cli"
@@ -284,6 +288,51 @@ func sendMany(ctx *cli.Context) error {
 	return nil
 }
 
+var estimateFeeCommand = cli.Command{
+	Name:      "estimatefee",
+	Usage:     "Get fee estimates for sending bitcoin on-chain to multiple addresses.",
+	ArgsUsage: "send-json-string [--conf_target=N]",
+	Description: `
+	Get fee estimates for sending a transaction paying the specified amount(s) to the passed address(es).
+
+	The send-json-string' param decodes addresses and the amount to send respectively in the following format:
+
+	    '{"ExampleAddr": NumCoinsInSatoshis, "SecondAddr": NumCoins}'
+	`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "conf_target",
+			Usage: "(optional) the number of blocks that the transaction *should* " +
+				"confirm in",
+		},
+	},
+	Action: actionDecorator(estimateFees),
+}
+
+func estimateFees(ctx *cli.Context) error {
+	var amountToAddr map[string]int64
+
+	jsonMap := ctx.Args().First()
+	if err := json.Unmarshal([]byte(jsonMap), &amountToAddr); err != nil {
+		return err
+	}
+
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	resp, err := client.EstimateFee(ctxb, &lnrpc.EstimateFeeRequest{
+		AddrToAmount: amountToAddr,
+		TargetConf:   int32(ctx.Int64("conf_target")),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var connectCommand = cli.Command{
 	Name:      "connect",
 	Usage:     "Connect to a remote lnd peer",
@@ -446,6 +495,12 @@ var openChannelCommand = cli.Command{
 				"not set, we will scale the value according to the " +
 				"channel size",
 		},
+		cli.Uint64Flag{
+			Name: "thaw_height",
+			Usage: "(optional) the block height until which we " +
+				"will refuse to cooperatively close the " +
+				"channel",
+		},
 	},
 	Action: actionDecorator(openChannel),
 }
@@ -470,6 +525,7 @@ func openChannel(ctx *cli.Context) error {
 		SatPerByte:     ctx.Int64("sat_per_byte"),
 		MinHtlcMsat:    ctx.Int64("min_htlc_msat"),
 		RemoteCsvDelay: uint32(ctx.Uint64("remote_csv_delay")),
+		ThawHeight:     uint32(ctx.Uint64("thaw_height")),
 	}
 
 	switch {
@@ -992,6 +1048,84 @@ func promptForConfirmation(msg string) bool {
 	}
 }
 
+var abandonChannelCommand = cli.Command{
+	Name:  "abandonchannel",
+	Usage: "Abandons an existing channel.",
+	Description: `
+	Removes all channel state from the database except for a close
+	summary. This method can be used to get rid of permanently unusable
+	channels due to bugs fixed in newer versions of lnd.
+
+	No action is taken on-chain, so any funds still committed to the
+	channel are lost. Only available when lnd is running with the
+	--unsafe-abandon flag.`,
+	ArgsUsage: "funding_txid [output_index]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "funding_txid",
+			Usage: "the txid of the channel's funding transaction",
+		},
+		cli.IntFlag{
+			Name: "output_index",
+			Usage: "the output index for the funding output of the funding " +
+				"transaction",
+		},
+	},
+	Action: actionDecorator(abandonChannel),
+}
+
+func abandonChannel(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	// Show command help if no arguments and flags were provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "abandonchannel")
+		return nil
+	}
+
+	req := &lnrpc.AbandonChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{},
+	}
+
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("funding_txid"):
+		req.ChannelPoint.FundingTxid = &lnrpc.ChannelPoint_FundingTxidStr{
+			FundingTxidStr: ctx.String("funding_txid"),
+		}
+	case args.Present():
+		req.ChannelPoint.FundingTxid = &lnrpc.ChannelPoint_FundingTxidStr{
+			FundingTxidStr: args.First(),
+		}
+		args = args.Tail()
+	default:
+		return fmt.Errorf("funding txid argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("output_index"):
+		req.ChannelPoint.OutputIndex = uint32(ctx.Int("output_index"))
+	case args.Present():
+		index, err := strconv.ParseUint(args.First(), 10, 32)
+		if err != nil {
+			return fmt.Errorf("unable to decode output index: %v", err)
+		}
+		req.ChannelPoint.OutputIndex = uint32(index)
+	default:
+		req.ChannelPoint.OutputIndex = 0
+	}
+
+	resp, err := client.AbandonChannel(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var listPeersCommand = cli.Command{
 	Name:   "listpeers",
 	Usage:  "List all active, currently connected peers.",
@@ -1460,6 +1594,10 @@ var sendPaymentCommand = cli.Command{
 	it'll use the hash of all zeroes. This mode allows one to quickly test
 	payment connectivity without having to create an invoice at the
 	destination.
+
+	If --stream is specified, then an update is printed each time an HTLC
+	is dispatched or fails while the payment is in flight, followed by a
+	final update once the payment either succeeded or failed.
 	`,
 	ArgsUsage: "dest amt payment_hash final_cltv_delta | --pay_req=[payment request]",
 	Flags: []cli.Flag{
@@ -1488,6 +1626,7 @@ var sendPaymentCommand = cli.Command{
 			Name:  "final_cltv_delta",
 			Usage: "the number of blocks the last hop has to reveal the preimage",
 		},
+		paymentStreamFlag,
 	},
 	Action: sendPayment,
 }
@@ -1586,7 +1725,19 @@ func sendPayment(ctx *cli.Context) error {
 	return sendPaymentRequest(ctx, req)
 }
 
+// paymentStreamFlag makes a payment command print the updates of the payment
+// while it's in flight.
+var paymentStreamFlag = cli.BoolFlag{
+	Name: "stream",
+	Usage: "print an update for each HTLC attempt while the payment " +
+		"is in flight",
+}
+
 func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
+	if ctx.Bool("stream") {
+		return streamPaymentRequest(ctx, req)
+	}
+
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
@@ -1619,6 +1770,105 @@ func sendPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
 	return nil
 }
 
+// streamPaymentRequest sends the passed payment, printing each update of the
+// payment until it either succeeded or failed.
+func streamPaymentRequest(ctx *cli.Context, req *lnrpc.SendRequest) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	stream, err := client.SendPaymentStream(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printPaymentUpdate(update)
+	}
+}
+
+// printPaymentUpdate prints the passed update of a payment as JSON.
+func printPaymentUpdate(update *lnrpc.PaymentUpdate) {
+	printJSON(struct {
+		Type        string             `json:"type"`
+		Hash        string             `json:"payment_hash"`
+		NumAttempts uint32             `json:"num_attempts"`
+		Attempt     *lnrpc.HTLCAttempt `json:"attempt,omitempty"`
+		Source      string             `json:"failure_source_pub_key,omitempty"`
+		FeePaid     int64              `json:"fee_paid_msat"`
+		Preimage    string             `json:"payment_preimage,omitempty"`
+		Error       string             `json:"payment_error,omitempty"`
+	}{
+		Type:        update.Type.String(),
+		Hash:        hex.EncodeToString(update.PaymentHash),
+		NumAttempts: update.NumAttempts,
+		Attempt:     update.Attempt,
+		Source:      update.FailureSourcePubKey,
+		FeePaid:     update.FeePaidMsat,
+		Preimage:    hex.EncodeToString(update.PaymentPreimage),
+		Error:       update.PaymentError,
+	})
+}
+
+var trackPaymentCommand = cli.Command{
+	Name:      "trackpayment",
+	Usage:     "Track the progress of a payment by its payment hash.",
+	ArgsUsage: "payment_hash",
+	Description: `
+	Print the current state of the payment to the specified payment hash,
+	followed by an update each time an HTLC attempt is dispatched or fails,
+	until the payment either succeeded or failed.
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "payment_hash",
+			Usage: "the hex-encoded payment hash of the payment " +
+				"to track",
+		},
+	},
+	Action: actionDecorator(trackPayment),
+}
+
+func trackPayment(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var paymentHash string
+	switch {
+	case ctx.IsSet("payment_hash"):
+		paymentHash = ctx.String("payment_hash")
+	case ctx.Args().Present():
+		paymentHash = ctx.Args().First()
+	default:
+		return fmt.Errorf("payment_hash argument missing")
+	}
+
+	req := &lnrpc.TrackPaymentRequest{
+		PaymentHashStr: paymentHash,
+	}
+	stream, err := client.TrackPayment(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		printPaymentUpdate(update)
+	}
+}
+
 var payInvoiceCommand = cli.Command{
 	Name:      "payinvoice",
 	Usage:     "Pay an invoice over lightning",
@@ -1633,6 +1883,7 @@ var payInvoiceCommand = cli.Command{
 			Usage: "(optional) number of satoshis to fulfill the " +
 				"invoice",
 		},
+		paymentStreamFlag,
 	},
 	Action: actionDecorator(payInvoice),
 }
@@ -1659,6 +1910,132 @@ func payInvoice(ctx *cli.Context) error {
 	return sendPaymentRequest(ctx, req)
 }
 
+var sendToRouteCommand = cli.Command{
+	Name:  "sendtoroute",
+	Usage: "Send a payment over a predefined route",
+	Description: `
+	Send a payment over Lightning using a specific set of routes, rather
+	than routes found by the node itself. The routes are attempted in
+	order, until one of them succeeds.
+
+	The routes are expected to be in the JSON format output by the
+	queryroutes command, and can either be passed using the --routes
+	argument, or read from stdin by passing "-" instead:
+
+	    lncli queryroutes --dest=<dest> --amt=<amt> | lncli sendtoroute \
+	        --payment_hash=<hash> --routes=-
+	`,
+	ArgsUsage: "--payment_hash=H --routes=R",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "payment_hash, r",
+			Usage: "the hash to use within the payment's HTLC",
+		},
+		cli.StringFlag{
+			Name: "routes",
+			Usage: "a json array string in the format of the " +
+				"response of queryroutes that denotes which " +
+				"routes to use, or \"-\" to read them from stdin",
+		},
+	},
+	Action: sendToRoute,
+}
+
+func sendToRoute(ctx *cli.Context) error {
+	// Show command help if no arguments provided.
+	if ctx.NArg() == 0 && ctx.NumFlags() == 0 {
+		cli.ShowCommandHelp(ctx, "sendtoroute")
+		return nil
+	}
+
+	args := ctx.Args()
+
+	var (
+		rHash []byte
+		err   error
+	)
+	switch {
+	case ctx.IsSet("payment_hash"):
+		rHash, err = hex.DecodeString(ctx.String("payment_hash"))
+	case args.Present():
+		rHash, err = hex.DecodeString(args.First())
+		args = args.Tail()
+	default:
+		return fmt.Errorf("payment hash argument missing")
+	}
+	if err != nil {
+		return err
+	}
+	if len(rHash) != 32 {
+		return fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(rHash))
+	}
+
+	var jsonRoutes string
+	switch {
+	case ctx.IsSet("routes"):
+		jsonRoutes = ctx.String("routes")
+	case args.Present():
+		jsonRoutes = args.First()
+	default:
+		return fmt.Errorf("routes argument missing")
+	}
+
+	// If the routes are to be read from stdin, then we'll consume all of
+	// it, as they may be piped in from queryroutes.
+	if jsonRoutes == "-" {
+		b, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		jsonRoutes = string(b)
+	}
+
+	routes := &lnrpc.QueryRoutesResponse{}
+	if err := jsonpb.UnmarshalString(jsonRoutes, routes); err != nil {
+		return fmt.Errorf("unable to unmarshal json string from "+
+			"incoming array of routes: %v", err)
+	}
+	if len(routes.Routes) == 0 {
+		return fmt.Errorf("at least one route must be specified")
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	paymentStream, err := client.SendToRoute(context.Background())
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.SendToRouteRequest{
+		PaymentHash: rHash,
+		Routes:      routes.Routes,
+	}
+	if err := paymentStream.Send(req); err != nil {
+		return err
+	}
+
+	resp, err := paymentStream.Recv()
+	if err != nil {
+		return err
+	}
+
+	paymentStream.CloseSend()
+
+	printJSON(struct {
+		E string       `json:"payment_error"`
+		P string       `json:"payment_preimage"`
+		R *lnrpc.Route `json:"payment_route"`
+	}{
+		E: resp.PaymentError,
+		P: hex.EncodeToString(resp.PaymentPreimage),
+		R: resp.PaymentRoute,
+	})
+
+	return nil
+}
+
 var addInvoiceCommand = cli.Command{
 	Name:  "addinvoice",
 	Usage: "Add a new invoice.",
@@ -1709,6 +2086,12 @@ var addInvoiceCommand = cli.Command{
 				"specified an expiry of 3600 seconds (1 hour) " +
 				"is implied.",
 		},
+		cli.BoolFlag{
+			Name: "amp",
+			Usage: "if set, the invoice may be paid many times " +
+				"using atomic multi-path payments, with each " +
+				"payment settled independently",
+		},
 	},
 	Action: actionDecorator(addInvoice),
 }
@@ -1767,6 +2150,7 @@ func addInvoice(ctx *cli.Context) error {
 		DescriptionHash: descHash,
 		FallbackAddr:    ctx.String("fallback_addr"),
 		Expiry:          ctx.Int64("expiry"),
+		Amp:             ctx.Bool("amp"),
 	}
 
 	resp, err := client.AddInvoice(context.Background(), invoice)
@@ -1881,6 +2265,41 @@ var describeGraphCommand = cli.Command{
 			Name:  "render",
 			Usage: "If set, then an image of graph will be generated and displayed. The generated image is stored within the current directory with a file name of 'graph.svg'",
 		},
+		cli.Int64Flag{
+			Name: "updated_since",
+			Usage: "if set, only nodes which announced an update " +
+				"at or after this unix timestamp are returned",
+		},
+		cli.Int64Flag{
+			Name: "min_capacity",
+			Usage: "if set, only channels with at least this " +
+				"capacity in satoshis are returned",
+		},
+		cli.BoolFlag{
+			Name: "omit_disabled",
+			Usage: "if set, zombie channels and channels disabled " +
+				"in both directions are omitted",
+		},
+		cli.StringFlag{
+			Name: "node_offset",
+			Usage: "the public key of the last node of the " +
+				"previous page, nodes are returned after it",
+		},
+		cli.Uint64Flag{
+			Name: "max_nodes",
+			Usage: "the max number of nodes to return, if 0 " +
+				"then all nodes are returned",
+		},
+		cli.Uint64Flag{
+			Name: "chan_id_offset",
+			Usage: "the channel ID of the last channel of the " +
+				"previous page, channels are returned after it",
+		},
+		cli.Uint64Flag{
+			Name: "max_edges",
+			Usage: "the max number of channels to return, if 0 " +
+				"then all channels are returned",
+		},
 	},
 	Action: actionDecorator(describeGraph),
 }
@@ -1889,7 +2308,15 @@ func describeGraph(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.ChannelGraphRequest{}
+	req := &lnrpc.ChannelGraphRequest{
+		NodesUpdatedSince: ctx.Int64("updated_since"),
+		MinCapacity:       ctx.Int64("min_capacity"),
+		OmitDisabledEdges: ctx.Bool("omit_disabled"),
+		NodeOffset:        ctx.String("node_offset"),
+		NumMaxNodes:       uint32(ctx.Uint64("max_nodes")),
+		ChanIdOffset:      ctx.Uint64("chan_id_offset"),
+		NumMaxEdges:       uint32(ctx.Uint64("max_edges")),
+	}
 
 	graph, err := client.DescribeGraph(context.Background(), req)
 	if err != nil {
@@ -2067,16 +2494,57 @@ func drawChannelGraph(graph *lnrpc.ChannelGraph) error {
 }
 
 var listPaymentsCommand = cli.Command{
-	Name:   "listpayments",
-	Usage:  "List all outgoing payments",
-	Action: actionDecorator(listPayments),
-}
-
+	Name:  "listpayments",
+	Usage: "List all outgoing payments",
+	Description: `
+	List outgoing payments in the order in which they were made. The list
+	may be paginated by passing the first_index_offset or
+	last_index_offset of the previous response as the index_offset of the
+	next request, together with the reversed flag to page backwards. The
+	list may further be restricted to the payments created within a time
+	range.`,
+	Flags: []cli.Flag{
+		cli.Uint64Flag{
+			Name: "index_offset",
+			Usage: "the index of the payment at which the list " +
+				"starts, excluding the payment itself",
+		},
+		cli.Uint64Flag{
+			Name: "max_payments",
+			Usage: "the maximum number of payments to list, or 0 " +
+				"to list all of them",
+		},
+		cli.BoolFlag{
+			Name: "reversed",
+			Usage: "if set, the payments made before the index " +
+				"offset are listed, starting from the most " +
+				"recent payment if no offset is given",
+		},
+		cli.Int64Flag{
+			Name: "creation_date_start",
+			Usage: "if set, only payments created at or after this " +
+				"unix timestamp in seconds are listed",
+		},
+		cli.Int64Flag{
+			Name: "creation_date_end",
+			Usage: "if set, only payments created before this unix " +
+				"timestamp in seconds are listed",
+		},
+	},
+	Action: actionDecorator(listPayments),
+}
+
 func listPayments(ctx *cli.Context) error {
 	client, cleanUp := getClient(ctx)
 	defer cleanUp()
 
-	req := &lnrpc.ListPaymentsRequest{}
+	req := &lnrpc.ListPaymentsRequest{
+		IndexOffset:       ctx.Uint64("index_offset"),
+		MaxPayments:       ctx.Uint64("max_payments"),
+		Reversed:          ctx.Bool("reversed"),
+		CreationDateStart: ctx.Int64("creation_date_start"),
+		CreationDateEnd:   ctx.Int64("creation_date_end"),
+	}
 
 	payments, err := client.ListPayments(context.Background(), req)
 	if err != nil {
@@ -2087,6 +2555,58 @@ func listPayments(ctx *cli.Context) error {
 	return nil
 }
 
+var deletePaymentsCommand = cli.Command{
+	Name:  "deletepayments",
+	Usage: "Delete outgoing payments or their failed attempts",
+	Description: `
+	Delete the outgoing payments created before before_time, along with
+	their HTLC attempts. If failed_only is set, the payments themselves are
+	kept, and only their failed HTLC attempts are deleted, which retains
+	the records of all settled payments.
+
+	If before_time isn't set, all payments are considered, in which case
+	deleting the payments themselves needs to be confirmed.`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "failed_only",
+			Usage: "if set, only the failed HTLC attempts of the " +
+				"payments are deleted",
+		},
+		cli.Int64Flag{
+			Name: "before_time",
+			Usage: "only payments created before this unix " +
+				"timestamp in seconds are deleted",
+		},
+	},
+	Action: actionDecorator(deletePayments),
+}
+
+func deletePayments(ctx *cli.Context) error {
+	// Deleting the entire payment history requires confirmation.
+	if !ctx.IsSet("before_time") && !ctx.Bool("failed_only") {
+		msg := "Delete all outgoing payments? (yes/no): "
+		if !promptForConfirmation(msg) {
+			return nil
+		}
+	}
+
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DeletePaymentsRequest{
+		FailedOnly: ctx.Bool("failed_only"),
+		BeforeTime: ctx.Int64("before_time"),
+	}
+
+	resp, err := client.DeletePayments(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var getChanInfoCommand = cli.Command{
 	Name:  "getchaninfo",
 	Usage: "Get the state of a channel",
@@ -2199,6 +2719,32 @@ var queryRoutesCommand = cli.Command{
 			Usage: "the max number of routes to be returned (default: 10)",
 			Value: 10,
 		},
+		cli.Int64Flag{
+			Name: "fee_limit",
+			Usage: "maximum fee allowed in satoshis when sending " +
+				"the payment",
+		},
+		cli.Int64Flag{
+			Name: "fee_limit_percent",
+			Usage: "percentage of the payment's amount used as the " +
+				"maximum fee allowed when sending the payment",
+		},
+		cli.StringSliceFlag{
+			Name: "ignore_node",
+			Usage: "the 33-byte hex-encoded public key of a node to " +
+				"ignore during path finding, can be specified " +
+				"multiple times",
+		},
+		cli.Int64SliceFlag{
+			Name: "ignore_chan",
+			Usage: "the id of a channel to ignore during path " +
+				"finding, can be specified multiple times",
+		},
+		cli.StringFlag{
+			Name: "source",
+			Usage: "the 33-byte hex-encoded public key of the node " +
+				"the routes start at, instead of our own node",
+		},
 	},
 	Action: actionDecorator(queryRoutes),
 }
@@ -2238,10 +2784,28 @@ func queryRoutes(ctx *cli.Context) error {
 		return fmt.Errorf("amt argument missing")
 	}
 
+	feeLimit, err := retrieveFeeLimit(ctx)
+	if err != nil {
+		return err
+	}
+
 	req := &lnrpc.QueryRoutesRequest{
-		PubKey:    dest,
-		Amt:       amt,
-		NumRoutes: int32(ctx.Int("num_max_routes")),
+		PubKey:       dest,
+		Amt:          amt,
+		NumRoutes:    int32(ctx.Int("num_max_routes")),
+		FeeLimit:     feeLimit,
+		SourcePubKey: ctx.String("source"),
+	}
+	for _, node := range ctx.StringSlice("ignore_node") {
+		nodeBytes, err := hex.DecodeString(node)
+		if err != nil {
+			return fmt.Errorf("unable to decode ignored node: %v",
+				err)
+		}
+		req.IgnoredNodes = append(req.IgnoredNodes, nodeBytes)
+	}
+	for _, chanID := range ctx.Int64Slice("ignore_chan") {
+		req.IgnoredEdges = append(req.IgnoredEdges, uint64(chanID))
 	}
 
 	route, err := client.QueryRoutes(ctxb, req)
@@ -2253,6 +2817,34 @@ func queryRoutes(ctx *cli.Context) error {
 	return nil
 }
 
+// retrieveFeeLimit retrieves the fee limit based on the different fee limit
+// flags passed.
+func retrieveFeeLimit(ctx *cli.Context) (*lnrpc.FeeLimit, error) {
+	switch {
+	case ctx.IsSet("fee_limit") && ctx.IsSet("fee_limit_percent"):
+		return nil, fmt.Errorf("either fee_limit or fee_limit_percent " +
+			"can be set, but not both")
+
+	case ctx.IsSet("fee_limit"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Fixed{
+				Fixed: ctx.Int64("fee_limit"),
+			},
+		}, nil
+
+	case ctx.IsSet("fee_limit_percent"):
+		return &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Percent{
+				Percent: ctx.Int64("fee_limit_percent"),
+			},
+		}, nil
+	}
+
+	// Since the fee limit flags aren't required, we don't return an error
+	// if they're not set.
+	return nil, nil
+}
+
 var getNetworkInfoCommand = cli.Command{
 	Name:  "getnetworkinfo",
 	Usage: "Getnetworkinfo",
@@ -2277,6 +2869,200 @@ func getNetworkInfo(ctx *cli.Context) error {
 	return nil
 }
 
+var getNodeMetricsCommand = cli.Command{
+	Name:  "getnodemetrics",
+	Usage: "Get the betweenness centrality of each node in the graph",
+	Description: `
+	Computes the betweenness centrality of every node within the known
+	channel graph, which indicates how well the node is positioned to
+	forward payments within the network. The nodes are listed by
+	decreasing centrality.
+
+	As the centrality is computed over the whole graph, this may take a
+	while on large graphs.
+	`,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name: "degree_stats",
+			Usage: "also report the number of channels and the " +
+				"total capacity of each node",
+		},
+	},
+	Action: actionDecorator(getNodeMetrics),
+}
+
+func getNodeMetrics(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.NodeMetricsRequest{
+		BetweennessCentrality: true,
+		DegreeStats:           ctx.Bool("degree_stats"),
+	}
+
+	metrics, err := client.GetNodeMetrics(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(metrics)
+	return nil
+}
+
+var bakeMacaroonCommand = cli.Command{
+	Name:      "bakemacaroon",
+	Usage:     "Bake a new macaroon with restricted permissions",
+	ArgsUsage: "permissions...",
+	Description: `
+	Bakes a new macaroon which only grants the passed permissions, each of
+	which is given as entity:action, such as invoices:read. The known
+	entities are onchain, offchain, address, message, peers, info and
+	invoices, and the known actions are read and write.
+
+	The macaroon may optionally be locked to an IP address and restricted
+	to a lifetime. It's printed in hex unless --save_to is set, in which
+	case it's written to the passed file in its binary encoding, which
+	can be used by lncli through the --macaroonpath option.
+
+	Example:
+
+	lncli bakemacaroon --timeout=3600 invoices:read invoices:write
+	`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "save_to",
+			Usage: "the file to save the new macaroon to",
+		},
+		cli.Int64Flag{
+			Name: "timeout",
+			Usage: "the number of seconds after which the new " +
+				"macaroon expires",
+		},
+		cli.StringFlag{
+			Name:  "ip_address",
+			Usage: "the IP address the new macaroon is locked to",
+		},
+		cli.Uint64Flag{
+			Name: "root_key_id",
+			Usage: "the ID of the root key the new macaroon is " +
+				"derived from, which allows revoking it " +
+				"through deletemacaroonid",
+		},
+	},
+	Action: actionDecorator(bakeMacaroon),
+}
+
+func bakeMacaroon(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() == 0 {
+		return cli.ShowCommandHelp(ctx, "bakemacaroon")
+	}
+
+	req := &lnrpc.BakeMacaroonRequest{
+		IpAddress: ctx.String("ip_address"),
+		Timeout:   ctx.Int64("timeout"),
+		RootKeyId: ctx.Uint64("root_key_id"),
+	}
+	for _, arg := range ctx.Args() {
+		parts := strings.Split(arg, ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid permission %q, expected "+
+				"entity:action", arg)
+		}
+
+		req.Permissions = append(
+			req.Permissions, &lnrpc.MacaroonPermission{
+				Entity: parts[0],
+				Action: parts[1],
+			},
+		)
+	}
+
+	resp, err := client.BakeMacaroon(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.IsSet("save_to") {
+		macBytes, err := hex.DecodeString(resp.Macaroon)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(ctx.String("save_to"), macBytes, 0600)
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var listMacaroonIDsCommand = cli.Command{
+	Name:  "listmacaroonids",
+	Usage: "List the IDs of all macaroon root keys",
+	Description: `
+	Lists the IDs of all root keys macaroons may be derived from. The
+	default macaroons are derived from the root key with ID 0.
+	`,
+	Action: actionDecorator(listMacaroonIDs),
+}
+
+func listMacaroonIDs(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ListMacaroonIDsRequest{}
+	resp, err := client.ListMacaroonIDs(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var deleteMacaroonIDCommand = cli.Command{
+	Name:      "deletemacaroonid",
+	Usage:     "Delete a macaroon root key, revoking its macaroons",
+	ArgsUsage: "root_key_id",
+	Description: `
+	Deletes the root key with the passed ID, which instantly invalidates
+	all macaroons derived from it. The root key of the default macaroons,
+	which has ID 0, can't be deleted.
+	`,
+	Action: actionDecorator(deleteMacaroonID),
+}
+
+func deleteMacaroonID(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	if ctx.NArg() != 1 {
+		return cli.ShowCommandHelp(ctx, "deletemacaroonid")
+	}
+
+	rootKeyID, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid root key ID: %v", err)
+	}
+
+	req := &lnrpc.DeleteMacaroonIDRequest{
+		RootKeyId: rootKeyID,
+	}
+	resp, err := client.DeleteMacaroonID(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var debugLevelCommand = cli.Command{
 	Name:  "debuglevel",
 	Usage: "Set the debug level.",
@@ -2558,6 +3344,12 @@ var updateChannelPolicyCommand = cli.Command{
 			Usage: "the CLTV delta that will be applied to all " +
 				"forwarded HTLCs",
 		},
+		cli.Uint64Flag{
+			Name: "max_htlc_msat",
+			Usage: "(optional) if set, the max HTLC size that " +
+				"will be applied to all forwarded HTLCs. If " +
+				"unset, the max HTLC is left unchanged",
+		},
 		cli.StringFlag{
 			Name: "chan_point",
 			Usage: "The channel whose fee policy should be " +
@@ -2659,6 +3451,7 @@ func updateChannelPolicy(ctx *cli.Context) error {
 		BaseFeeMsat:   baseFee,
 		FeeRate:       feeRate,
 		TimeLockDelta: uint32(timeLockDelta),
+		MaxHtlcMsat:   ctx.Uint64("max_htlc_msat"),
 	}
 
 	if chanPoint != nil {
@@ -2680,6 +3473,89 @@ func updateChannelPolicy(ctx *cli.Context) error {
 	return nil
 }
 
+var labelChannelCommand = cli.Command{
+	Name:      "labelchannel",
+	Usage:     "Attach a label to a channel",
+	ArgsUsage: "chan_point label",
+	Description: `
+	Attaches a free-form label to the channel identified by its channel
+	point, such as a note on why it was opened. Any label attached to the
+	channel before is replaced, while an empty label removes it. The label
+	is reported by listchannels and pendingchannels, and is kept until the
+	channel is fully closed.
+	Channel points are encoded as: funding_txid:output_index`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "chan_point",
+			Usage: "the channel to attach the label to. Takes " +
+				"the form of: txid:output_index",
+		},
+		cli.StringFlag{
+			Name:  "label",
+			Usage: "the label to attach to the channel",
+		},
+	},
+	Action: actionDecorator(labelChannel),
+}
+
+func labelChannel(ctx *cli.Context) error {
+	ctxb := context.Background()
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		chanPointStr string
+		label        string
+	)
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("chan_point"):
+		chanPointStr = ctx.String("chan_point")
+	case args.Present():
+		chanPointStr = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("chan_point argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("label"):
+		label = ctx.String("label")
+	case args.Present():
+		label = args.First()
+	}
+
+	split := strings.Split(chanPointStr, ":")
+	if len(split) != 2 {
+		return fmt.Errorf("expecting chan_point to be in format of: " +
+			"txid:index")
+	}
+
+	index, err := strconv.ParseInt(split[1], 10, 32)
+	if err != nil {
+		return fmt.Errorf("unable to decode output index: %v", err)
+	}
+
+	req := &lnrpc.LabelChannelRequest{
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+				FundingTxidStr: split[0],
+			},
+			OutputIndex: uint32(index),
+		},
+		Label: label,
+	}
+
+	resp, err := client.LabelChannel(ctxb, req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
 var forwardingHistoryCommand = cli.Command{
 	Name:      "fwdinghistory",
 	Usage:     "Query the history of all forwarded htlcs",
@@ -2689,7 +3565,8 @@ var forwardingHistoryCommand = cli.Command{
 	payment circuits (HTLCs) over a particular time range (--start_time and
 	--end_time). The start and end times are meant to be expressed in
 	seconds since the Unix epoch. If a start and end time aren't provided,
-	then events over the past 24 hours are queried for.
+	then events over the past 24 hours are queried for. If only the end
+	time isn't provided, then events up until now are queried for.
 
 	The max number of events returned is 50k. The default number is 100,
 	callers can use the --max_events param to modify this value.
@@ -2793,3 +3670,965 @@ func forwardingHistory(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var policyCommand = cli.Command{
+	Name:  "policy",
+	Usage: "Manage the fee policies of outgoing payments.",
+	Description: `
+	Fee policies bound the total fee which may be paid to route a payment
+	to a particular payment hash. A policy may be set up before paying an
+	invoice in order to cap the fees paid for it. A policy may be limited
+	to payments within an amount band, in which case the most specific
+	policy matching the amount of a payment applies. Payments which aren't
+	governed by any other policy fall back to the default policy, if set.
+
+	The list, lookup and getdefault commands print the policies as a table,
+	or as JSON if the --json flag is set.`,
+	Subcommands: []cli.Command{
+		addPolicyCommand,
+		listPoliciesCommand,
+		lookupPolicyCommand,
+		deletePolicyCommand,
+		setDefaultPolicyCommand,
+		getDefaultPolicyCommand,
+		deleteDefaultPolicyCommand,
+		exportPoliciesCommand,
+		importPoliciesCommand,
+		policyAuditLogCommand,
+	},
+}
+
+var addPolicyCommand = cli.Command{
+	Name:      "add",
+	Usage:     "Add a fee policy for a payment hash.",
+	ArgsUsage: "hash maxfee",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "hash",
+			Usage: "the 32 byte payment hash the policy applies " +
+				"to, the hash should be a hex-encoded string",
+		},
+		cli.Int64Flag{
+			Name: "maxfee",
+			Usage: "the maximum total fee in milli-satoshis that " +
+				"may be paid to route the payment",
+		},
+		cli.Int64Flag{
+			Name: "basefee",
+			Usage: "the fixed part of the maximum fee in " +
+				"milli-satoshis",
+		},
+		cli.Int64Flag{
+			Name: "feerate_ppm",
+			Usage: "the proportional part of the maximum fee, in " +
+				"parts per million of the payment amount",
+		},
+		cli.Uint64Flag{
+			Name:  "expiry_height",
+			Usage: "the block height at which the policy expires",
+		},
+		cli.Int64Flag{
+			Name:  "expiry_time",
+			Usage: "the unix timestamp at which the policy expires",
+		},
+		cli.Int64Flag{
+			Name: "budget",
+			Usage: "the maximum cumulative amount in " +
+				"milli-satoshis, including fees, that may " +
+				"be spent on payments governed by the policy",
+		},
+		cli.Int64Flag{
+			Name: "min_amt",
+			Usage: "the minimum payment amount in milli-satoshis " +
+				"the policy applies to",
+		},
+		cli.Int64Flag{
+			Name: "max_amt",
+			Usage: "the maximum payment amount in milli-satoshis " +
+				"the policy applies to, if zero there is no " +
+				"upper bound",
+		},
+		cli.Uint64Flag{
+			Name: "max_cltv_delta",
+			Usage: "the maximum total time lock delta that a " +
+				"route for the payment may impose",
+		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "an optional description of the policy, e.g. " +
+				"the reason it was created",
+		},
+		cli.BoolFlag{
+			Name: "deny",
+			Usage: "if set, all payments to the payment hash are " +
+				"rejected outright, regardless of their fee",
+		},
+		cli.Uint64Flag{
+			Name: "outgoing_chan_id",
+			Usage: "if set, payments to the payment hash may " +
+				"only leave through the channel with this " +
+				"short channel ID",
+		},
+	},
+	Action: actionDecorator(addPolicy),
+}
+
+func addPolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		payHash string
+		maxFee  int64
+		err     error
+	)
+	args := ctx.Args()
+
+	switch {
+	case ctx.IsSet("hash"):
+		payHash = ctx.String("hash")
+	case args.Present():
+		payHash = args.First()
+		args = args.Tail()
+	default:
+		return fmt.Errorf("hash argument missing")
+	}
+
+	switch {
+	case ctx.IsSet("maxfee"):
+		maxFee = ctx.Int64("maxfee")
+	case args.Present():
+		maxFee, err = strconv.ParseInt(args.First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode maxfee: %v", err)
+		}
+	}
+
+	req := &lnrpc.PaymentPolicy{
+		PaymentHash:    payHash,
+		FeeMsat:        maxFee,
+		BaseFeeMsat:    ctx.Int64("basefee"),
+		FeeRatePpm:     ctx.Int64("feerate_ppm"),
+		ExpiryHeight:   uint32(ctx.Uint64("expiry_height")),
+		ExpiryTime:     ctx.Int64("expiry_time"),
+		BudgetMsat:     ctx.Int64("budget"),
+		MinAmtMsat:     ctx.Int64("min_amt"),
+		MaxAmtMsat:     ctx.Int64("max_amt"),
+		MaxCltvDelta:   uint32(ctx.Uint64("max_cltv_delta")),
+		Label:          ctx.String("label"),
+		Deny:           ctx.Bool("deny"),
+		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+	}
+
+	resp, err := client.AddPolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// policyJSONFlag switches the output of the policy commands which display
+// policies from a table to the JSON encoded response of lnd.
+var policyJSONFlag = cli.BoolFlag{
+	Name:  "json",
+	Usage: "print the policies as JSON instead of a table",
+}
+
+// printPolicies writes the passed fee policies to stdout as a table, with one
+// policy per row. Fields which are unset are shown as "-".
+func printPolicies(policies []*lnrpc.PaymentPolicy) {
+	orNone := func(v int64) string {
+		if v == 0 {
+			return "-"
+		}
+		return strconv.FormatInt(v, 10)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PAYMENT_HASH\tMAX_FEE_MSAT\tBASE_FEE_MSAT\t"+
+		"FEE_RATE_PPM\tMIN_AMT_MSAT\tMAX_AMT_MSAT\tBUDGET_MSAT\t"+
+		"SPENT_MSAT\tEXPIRY_HEIGHT\tEXPIRY_TIME\tDENY\tLABEL")
+
+	for _, p := range policies {
+		payHash := p.PaymentHash
+		if payHash == "" {
+			payHash = "default"
+		}
+
+		label := p.Label
+		if label == "" {
+			label = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%v\t%s\n",
+			payHash, orNone(p.FeeMsat), orNone(p.BaseFeeMsat),
+			orNone(p.FeeRatePpm), orNone(p.MinAmtMsat),
+			orNone(p.MaxAmtMsat), orNone(p.BudgetMsat),
+			orNone(p.SpentToDateMsat), orNone(int64(p.ExpiryHeight)),
+			orNone(p.ExpiryTime), p.Deny, label)
+	}
+
+	w.Flush()
+}
+
+var listPoliciesCommand = cli.Command{
+	Name:  "list",
+	Usage: "List all fee policies.",
+	Flags: []cli.Flag{
+		policyJSONFlag,
+	},
+	Action: actionDecorator(listPolicies),
+}
+
+func listPolicies(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ListPoliciesRequest{}
+
+	policies, err := client.ListPolicies(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("json") {
+		printRespJSON(policies)
+		return nil
+	}
+
+	printPolicies(policies.Policies)
+	return nil
+}
+
+var lookupPolicyCommand = cli.Command{
+	Name:      "lookup",
+	Usage:     "Look up the fee policy for a payment hash.",
+	ArgsUsage: "hash",
+	Description: `
+	Look up the fee policy for a payment hash which isn't limited to an
+	amount band. Policies limited to an amount band are shown by the list
+	command.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "hash",
+			Usage: "the 32 byte payment hash of the policy to " +
+				"look up, the hash should be a hex-encoded string",
+		},
+		policyJSONFlag,
+	},
+	Action: actionDecorator(lookupPolicy),
+}
+
+func lookupPolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var payHash string
+	switch {
+	case ctx.IsSet("hash"):
+		payHash = ctx.String("hash")
+	case ctx.Args().Present():
+		payHash = ctx.Args().First()
+	default:
+		return fmt.Errorf("hash argument missing")
+	}
+
+	req := &lnrpc.PolicyPaymentHash{
+		PaymentHashStr: payHash,
+	}
+
+	policy, err := client.LookupPolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("json") {
+		printRespJSON(policy)
+		return nil
+	}
+
+	printPolicies([]*lnrpc.PaymentPolicy{policy})
+	return nil
+}
+
+var deletePolicyCommand = cli.Command{
+	Name:      "delete",
+	Usage:     "Delete the fee policy for a payment hash.",
+	ArgsUsage: "hash",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "hash",
+			Usage: "the 32 byte payment hash of the policy to " +
+				"delete, the hash should be a hex-encoded string",
+		},
+	},
+	Action: actionDecorator(deletePolicy),
+}
+
+func deletePolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var payHash string
+	switch {
+	case ctx.IsSet("hash"):
+		payHash = ctx.String("hash")
+	case ctx.Args().Present():
+		payHash = ctx.Args().First()
+	default:
+		return fmt.Errorf("hash argument missing")
+	}
+
+	req := &lnrpc.PolicyPaymentHash{
+		PaymentHashStr: payHash,
+	}
+
+	resp, err := client.DeletePolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var setDefaultPolicyCommand = cli.Command{
+	Name:      "setdefault",
+	Usage:     "Set the default fee policy.",
+	ArgsUsage: "maxfee",
+	Description: `
+	Set the default fee policy, which governs all outgoing payments for
+	which neither a payment hash nor a node policy matches. This can be
+	used to impose a global fee ceiling on all outgoing payments. Any
+	existing default policy is overwritten.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "maxfee",
+			Usage: "the maximum total fee in milli-satoshis that " +
+				"may be paid to route a payment",
+		},
+		cli.Int64Flag{
+			Name: "basefee",
+			Usage: "the fixed part of the maximum fee in " +
+				"milli-satoshis",
+		},
+		cli.Int64Flag{
+			Name: "feerate_ppm",
+			Usage: "the proportional part of the maximum fee, in " +
+				"parts per million of the payment amount",
+		},
+		cli.Uint64Flag{
+			Name:  "expiry_height",
+			Usage: "the block height at which the policy expires",
+		},
+		cli.Int64Flag{
+			Name:  "expiry_time",
+			Usage: "the unix timestamp at which the policy expires",
+		},
+		cli.Int64Flag{
+			Name: "budget",
+			Usage: "the maximum cumulative amount in " +
+				"milli-satoshis, including fees, that may " +
+				"be spent on payments governed by the policy",
+		},
+		cli.Uint64Flag{
+			Name: "max_cltv_delta",
+			Usage: "the maximum total time lock delta that a " +
+				"route for a payment may impose",
+		},
+		cli.StringFlag{
+			Name: "label",
+			Usage: "an optional description of the policy, e.g. " +
+				"the reason it was created",
+		},
+		cli.Uint64Flag{
+			Name: "outgoing_chan_id",
+			Usage: "if set, payments governed by the policy may " +
+				"only leave through the channel with this " +
+				"short channel ID",
+		},
+	},
+	Action: actionDecorator(setDefaultPolicy),
+}
+
+func setDefaultPolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		maxFee int64
+		err    error
+	)
+	switch {
+	case ctx.IsSet("maxfee"):
+		maxFee = ctx.Int64("maxfee")
+	case ctx.Args().Present():
+		maxFee, err = strconv.ParseInt(ctx.Args().First(), 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to decode maxfee: %v", err)
+		}
+	}
+
+	req := &lnrpc.PaymentPolicy{
+		FeeMsat:        maxFee,
+		BaseFeeMsat:    ctx.Int64("basefee"),
+		FeeRatePpm:     ctx.Int64("feerate_ppm"),
+		ExpiryHeight:   uint32(ctx.Uint64("expiry_height")),
+		ExpiryTime:     ctx.Int64("expiry_time"),
+		BudgetMsat:     ctx.Int64("budget"),
+		MaxCltvDelta:   uint32(ctx.Uint64("max_cltv_delta")),
+		Label:          ctx.String("label"),
+		OutgoingChanId: ctx.Uint64("outgoing_chan_id"),
+	}
+
+	resp, err := client.SetDefaultPolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var getDefaultPolicyCommand = cli.Command{
+	Name:  "getdefault",
+	Usage: "Show the default fee policy.",
+	Flags: []cli.Flag{
+		policyJSONFlag,
+	},
+	Action: actionDecorator(getDefaultPolicy),
+}
+
+func getDefaultPolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DefaultPolicyRequest{}
+
+	resp, err := client.DefaultPolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("json") {
+		printRespJSON(resp)
+		return nil
+	}
+
+	printPolicies([]*lnrpc.PaymentPolicy{resp})
+	return nil
+}
+
+var deleteDefaultPolicyCommand = cli.Command{
+	Name:   "deletedefault",
+	Usage:  "Delete the default fee policy.",
+	Action: actionDecorator(deleteDefaultPolicy),
+}
+
+func deleteDefaultPolicy(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.DeleteDefaultPolicyRequest{}
+
+	resp, err := client.DeleteDefaultPolicy(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var exportPoliciesCommand = cli.Command{
+	Name:  "export",
+	Usage: "Export all fee policies in the JSON lines format.",
+	Description: `
+	Export all payment hash and node fee policies, encoded as one JSON
+	object per line. The export can be imported on this or any other node
+	using the import command. If no output file is specified, the policies
+	are written to stdout.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file to write the exported policies to",
+		},
+	},
+	Action: actionDecorator(exportPolicies),
+}
+
+func exportPolicies(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.ExportPoliciesRequest{}
+
+	backup, err := client.ExportPolicies(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.IsSet("output_file") {
+		_, err := os.Stdout.Write(backup.Policies)
+		return err
+	}
+
+	return ioutil.WriteFile(ctx.String("output_file"), backup.Policies, 0600)
+}
+
+var importPoliciesCommand = cli.Command{
+	Name:      "import",
+	Usage:     "Import fee policies exported by the export command.",
+	ArgsUsage: "input_file",
+	Description: `
+	Import all fee policies contained within a file created by the export
+	command. Existing policies for the same payment hash or node and amount
+	band are overwritten. If any policy within the file is invalid, none
+	are imported.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "input_file",
+			Usage: "the file to read the policies to import from",
+		},
+	},
+	Action: actionDecorator(importPolicies),
+}
+
+func importPolicies(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var inputFile string
+	switch {
+	case ctx.IsSet("input_file"):
+		inputFile = ctx.String("input_file")
+	case ctx.Args().Present():
+		inputFile = ctx.Args().First()
+	default:
+		return fmt.Errorf("input_file argument missing")
+	}
+
+	policies, err := ioutil.ReadFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	req := &lnrpc.PolicyBackup{
+		Policies: policies,
+	}
+
+	resp, err := client.ImportPolicies(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var policyAuditLogCommand = cli.Command{
+	Name:  "auditlog",
+	Usage: "List the decisions made by fee policies.",
+	Description: `
+	List the decisions made by fee policies for the payments they govern,
+	in chronological order. Each record states whether the policy permitted
+	the payment, or why it rejected it. Records are only kept for the
+	retention window configured on the node.`,
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name: "start_time",
+			Usage: "if set, only records made at or after this " +
+				"unix timestamp are listed",
+		},
+		cli.Int64Flag{
+			Name: "end_time",
+			Usage: "if set, only records made at or before this " +
+				"unix timestamp are listed",
+		},
+	},
+	Action: actionDecorator(policyAuditLog),
+}
+
+func policyAuditLog(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.PolicyAuditLogRequest{
+		StartTime: ctx.Int64("start_time"),
+		EndTime:   ctx.Int64("end_time"),
+	}
+
+	resp, err := client.PolicyAuditLog(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var dbCommand = cli.Command{
+	Name:  "db",
+	Usage: "Manage the channel database.",
+	Subcommands: []cli.Command{
+		compactDBCommand,
+		exportDBCommand,
+		dumpDBCommand,
+		checkDBCommand,
+	},
+}
+
+var compactDBCommand = cli.Command{
+	Name:  "compact",
+	Usage: "Compact the channel database on the next startup.",
+	Description: `
+	Schedule the channel database to be compacted the next time lnd starts.
+	Compaction copies the database into a fresh file, returning space freed
+	within it to the filesystem. It requires as much free disk space as the
+	database occupies. To compact the database on every startup, set
+	db.bolt.auto-compact instead.`,
+	Action: actionDecorator(compactDB),
+}
+
+func compactDB(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	req := &lnrpc.CompactDatabaseRequest{}
+	resp, err := client.CompactDatabase(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var exportDBCommand = cli.Command{
+	Name:  "export",
+	Usage: "Export a consistent snapshot of the channel database.",
+	Description: `
+	Export a point-in-time snapshot of the channel database, which is safe
+	to take while lnd is running. The snapshot is a valid database file,
+	which may be used in place of channel.db. If no output file is
+	specified, the snapshot is written to stdout.
+
+	If db_dir is set, the snapshot is instead taken directly from the
+	channel.db within that directory, without connecting to lnd. The
+	database is opened read-only, so it's never modified. As lnd holds an
+	exclusive lock on its database while running, this is only possible
+	while lnd is stopped, e.g. to inspect the database of a standby node.
+
+	NOTE: The snapshot holds the revocation secrets of all channels, and
+	must be kept as private as the database itself. Restoring a snapshot
+	which isn't the latest state of the database may result in the loss of
+	all funds within channels.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output_file",
+			Usage: "the file to write the snapshot to",
+		},
+		cli.StringFlag{
+			Name: "db_dir",
+			Usage: "the directory of a channel.db to export " +
+				"directly, opening it read-only",
+		},
+	},
+	Action: actionDecorator(exportDB),
+}
+
+func exportDB(ctx *cli.Context) error {
+	// The snapshot is either taken from the database on disk, or streamed
+	// from lnd.
+	var writeSnapshot func(w io.Writer) error
+	if ctx.IsSet("db_dir") {
+		writeSnapshot = func(w io.Writer) error {
+			return writeDBSnapshot(ctx.String("db_dir"), w)
+		}
+	} else {
+		client, cleanUp := getClient(ctx)
+		defer cleanUp()
+
+		req := &lnrpc.ExportDatabaseRequest{}
+		stream, err := client.ExportDatabase(context.Background(), req)
+		if err != nil {
+			return err
+		}
+
+		writeSnapshot = func(w io.Writer) error {
+			return writeDBChunks(stream, w)
+		}
+	}
+
+	if !ctx.IsSet("output_file") {
+		return writeSnapshot(os.Stdout)
+	}
+
+	out, err := os.OpenFile(
+		ctx.String("output_file"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+		0600,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := writeSnapshot(out); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// writeDBSnapshot opens the channel database within dbDir read-only, and
+// writes a snapshot of it to w.
+func writeDBSnapshot(dbDir string, w io.Writer) error {
+	db, err := channeldb.Open(
+		cleanAndExpandPath(dbDir), channeldb.OptionReadOnly(true),
+		channeldb.OptionSetGraphCache(false),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to open channel db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Snapshot(w)
+	return err
+}
+
+// writeDBChunks writes all database chunks received over the stream to w.
+func writeDBChunks(stream lnrpc.Lightning_ExportDatabaseClient,
+	w io.Writer) error {
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+var dumpDBCommand = cli.Command{
+	Name:      "dump",
+	Usage:     "Dump the static state of all open channels from a channel.db.",
+	ArgsUsage: "db_dir",
+	Description: `
+	Walk the open channels within the channel.db in db_dir, and print the
+	static state needed to identify them and re-derive the keys controlling
+	their funds as JSON: the funding outpoints, the remote node and its
+	base points, the key locators of our own keys, and the remote party's
+	commitment points.
+
+	The command is meant to recover from a partially corrupt database.
+	Rather than failing on the first error, any state which can't be
+	decoded is skipped, and the errors encountered are listed along side
+	each channel. The database is opened read-only without connecting to
+	lnd, so this is only possible while lnd is stopped.`,
+	Action: actionDecorator(dumpDB),
+}
+
+// keyDump is the JSON representation of a key within a channel dump. The key
+// locator is omitted for keys of the remote party.
+type keyDump struct {
+	KeyFamily *uint32 `json:"key_family,omitempty"`
+	KeyIndex  *uint32 `json:"key_index,omitempty"`
+	PubKey    string  `json:"pub_key,omitempty"`
+}
+
+// chanConfigDump is the JSON representation of the keys of one party within
+// a channel dump.
+type chanConfigDump struct {
+	CsvDelay            uint16  `json:"csv_delay"`
+	MultiSigKey         keyDump `json:"multi_sig_key"`
+	RevocationBasePoint keyDump `json:"revocation_base_point"`
+	PaymentBasePoint    keyDump `json:"payment_base_point"`
+	DelayBasePoint      keyDump `json:"delay_base_point"`
+	HtlcBasePoint       keyDump `json:"htlc_base_point"`
+}
+
+// chanDump is the JSON representation of a single channel dumped by
+// channeldb.DumpChannels.
+type chanDump struct {
+	ChannelPoint            string         `json:"channel_point"`
+	ChainHash               string         `json:"chain_hash"`
+	RemotePubKey            string         `json:"remote_pub_key"`
+	ChanID                  uint64         `json:"chan_id"`
+	IsInitiator             bool           `json:"is_initiator"`
+	Capacity                int64          `json:"capacity"`
+	LocalConfig             chanConfigDump `json:"local_config"`
+	RemoteConfig            chanConfigDump `json:"remote_config"`
+	RemoteCurrentRevocation string         `json:"remote_current_revocation,omitempty"`
+	RemoteNextRevocation    string         `json:"remote_next_revocation,omitempty"`
+	Errors                  []string       `json:"errors,omitempty"`
+}
+
+func dumpDB(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("db_dir argument missing")
+	}
+
+	dumps, dumpErr := channeldb.DumpChannels(
+		cleanAndExpandPath(ctx.Args().First()),
+	)
+
+	// The channels dumped before an error was encountered are printed
+	// regardless, as they may be all that's recoverable.
+	resp := struct {
+		Channels []chanDump `json:"channels"`
+		Error    string     `json:"error,omitempty"`
+	}{
+		Channels: make([]chanDump, 0, len(dumps)),
+	}
+	for _, dump := range dumps {
+		resp.Channels = append(resp.Channels, newChanDump(dump))
+	}
+	if dumpErr != nil {
+		resp.Error = dumpErr.Error()
+	}
+
+	printJSON(resp)
+
+	return nil
+}
+
+// newChanDump converts a channel dumped by channeldb.DumpChannels into its
+// JSON representation.
+func newChanDump(dump *channeldb.ChannelDump) chanDump {
+	serializeKey := func(key *btcec.PublicKey) string {
+		if key == nil {
+			return ""
+		}
+		return hex.EncodeToString(key.SerializeCompressed())
+	}
+
+	newKeyDump := func(desc keychain.KeyDescriptor, local bool) keyDump {
+		k := keyDump{
+			PubKey: serializeKey(desc.PubKey),
+		}
+		if local {
+			family := uint32(desc.Family)
+			index := desc.Index
+			k.KeyFamily = &family
+			k.KeyIndex = &index
+		}
+
+		return k
+	}
+
+	newConfigDump := func(cfg *channeldb.ChannelConfig,
+		local bool) chanConfigDump {
+
+		return chanConfigDump{
+			CsvDelay: cfg.CsvDelay,
+			MultiSigKey: newKeyDump(
+				cfg.MultiSigKey, local,
+			),
+			RevocationBasePoint: newKeyDump(
+				cfg.RevocationBasePoint, local,
+			),
+			PaymentBasePoint: newKeyDump(
+				cfg.PaymentBasePoint, local,
+			),
+			DelayBasePoint: newKeyDump(
+				cfg.DelayBasePoint, local,
+			),
+			HtlcBasePoint: newKeyDump(
+				cfg.HtlcBasePoint, local,
+			),
+		}
+	}
+
+	d := chanDump{
+		ChannelPoint: dump.FundingOutpoint.String(),
+		ChainHash:    dump.ChainHash.String(),
+		RemotePubKey: serializeKey(dump.IdentityPub),
+		ChanID:       dump.ShortChanID.ToUint64(),
+		IsInitiator:  dump.IsInitiator,
+		Capacity:     int64(dump.Capacity),
+		LocalConfig:  newConfigDump(&dump.LocalChanCfg, true),
+		RemoteConfig: newConfigDump(&dump.RemoteChanCfg, false),
+		RemoteCurrentRevocation: serializeKey(
+			dump.RemoteCurrentRevocation,
+		),
+		RemoteNextRevocation: serializeKey(dump.RemoteNextRevocation),
+	}
+	for _, err := range dump.Errors {
+		d.Errors = append(d.Errors, err.Error())
+	}
+
+	return d
+}
+
+var checkDBCommand = cli.Command{
+	Name:      "check",
+	Usage:     "Check the integrity of the channel and graph databases.",
+	ArgsUsage: "db_dir",
+	Description: `
+	Walk all records within the channel.db and graph.db in db_dir, decoding
+	each of them strictly, and cross-check the indexes over them, such as
+	the payment hash indexes of invoices and payments, and the fee index of
+	policies. All records which can't be decoded and all index entries which
+	don't agree with the records they index are printed as JSON, so
+	corruption can be detected before it causes a failure at runtime.
+
+	The databases are opened read-only without connecting to lnd, so this
+	is only possible while lnd is stopped.`,
+	Action: actionDecorator(checkDB),
+}
+
+// inconsistencyDump is the JSON representation of an inconsistency found by
+// channeldb.CheckDB.
+type inconsistencyDump struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Error  string `json:"error"`
+}
+
+func checkDB(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("db_dir argument missing")
+	}
+
+	inconsistencies, checkErr := channeldb.CheckDB(
+		cleanAndExpandPath(ctx.Args().First()),
+	)
+
+	// The inconsistencies found before an error was encountered are
+	// printed regardless, as the error may well be caused by them.
+	resp := struct {
+		Inconsistencies []inconsistencyDump `json:"inconsistencies"`
+		Error           string              `json:"error,omitempty"`
+	}{
+		Inconsistencies: make(
+			[]inconsistencyDump, 0, len(inconsistencies),
+		),
+	}
+	for _, inconsistency := range inconsistencies {
+		resp.Inconsistencies = append(
+			resp.Inconsistencies, inconsistencyDump{
+				Bucket: inconsistency.Bucket,
+				Key:    hex.EncodeToString(inconsistency.Key),
+				Error:  inconsistency.Err.Error(),
+			},
+		)
+	}
+	if checkErr != nil {
+		resp.Error = checkErr.Error()
+	}
+
+	printJSON(resp)
+
+	return nil
+}
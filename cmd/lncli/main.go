@@ -196,12 +196,14 @@ func main() {
 		unlockCommand,
 		newAddressCommand,
 		sendManyCommand,
+		estimateFeeCommand,
 		sendCoinsCommand,
 		connectCommand,
 		disconnectCommand,
 		openChannelCommand,
 		closeChannelCommand,
 		closeAllChannelsCommand,
+		abandonChannelCommand,
 		listPeersCommand,
 		walletBalanceCommand,
 		channelBalanceCommand,
@@ -209,16 +211,20 @@ func main() {
 		pendingChannelsCommand,
 		sendPaymentCommand,
 		payInvoiceCommand,
+		sendToRouteCommand,
 		addInvoiceCommand,
 		lookupInvoiceCommand,
 		listInvoicesCommand,
 		listChannelsCommand,
 		listPaymentsCommand,
+		trackPaymentCommand,
+		deletePaymentsCommand,
 		describeGraphCommand,
 		getChanInfoCommand,
 		getNodeInfoCommand,
 		queryRoutesCommand,
 		getNetworkInfoCommand,
+		getNodeMetricsCommand,
 		debugLevelCommand,
 		decodePayReqCommand,
 		listChainTxnsCommand,
@@ -227,7 +233,13 @@ func main() {
 		verifyMessageCommand,
 		feeReportCommand,
 		updateChannelPolicyCommand,
+		labelChannelCommand,
 		forwardingHistoryCommand,
+		policyCommand,
+		dbCommand,
+		bakeMacaroonCommand,
+		listMacaroonIDsCommand,
+		deleteMacaroonIDCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -51,6 +51,18 @@ const (
 
 	defaultBroadcastDelta = 10
 
+	defaultPolicyCacheSize      = 10000
+	defaultPolicyAuditRetention = 90 * 24 * time.Hour
+
+	defaultGraphBatchWindow = 10 * time.Millisecond
+
+	defaultRejectCacheSize = 50000
+
+	defaultMaxBatchSize      = 1000
+	defaultMaxBatchDelay     = 10 * time.Millisecond
+	defaultBatchRetries      = 5
+	defaultBatchRetryBackoff = 10 * time.Millisecond
+
 	// minTimeLockDelta is the minimum timelock we require for incoming
 	// HTLCs on our channels.
 	minTimeLockDelta = 4
@@ -140,6 +152,28 @@ type torConfig struct {
 	StreamIsolation bool   `long:"streamisolation" description:"Enable Tor stream isolation by randomizing user credentials for each connection."`
 }
 
+type boltConfig struct {
+	AutoCompact          bool    `long:"auto-compact" description:"Compact the channel database each time lnd starts, returning space freed within it to the filesystem."`
+	AutoCompactFreeRatio float64 `long:"auto-compact-free-ratio" description:"Compact the channel database when lnd starts if free pages make up at least this fraction of it. Set to 0 to disable."`
+
+	MaxBatchSize      int           `long:"max-batch-size" description:"The maximum number of batched writes committed within a single transaction. Set to 0 to disable batching."`
+	MaxBatchDelay     time.Duration `long:"max-batch-delay" description:"How long to collect batched writes before committing them within a single transaction. Longer delays sync the database to disk less often, at the cost of latency. Set to 0 to disable batching. Valid time units are {ms, s, m, h}."`
+	BatchRetries      int           `long:"batch-retries" description:"How many times to retry a write after a transaction conflict."`
+	BatchRetryBackoff time.Duration `long:"batch-retry-backoff" description:"How long to wait before the first retry of a write after a transaction conflict. The wait doubles with every retry, up to a second. Valid time units are {ms, s, m, h}."`
+}
+
+type dbConfig struct {
+	NoGraphCache bool `long:"no-graph-cache" description:"Don't keep the channel graph in memory. This reduces memory usage at the cost of reading the graph from disk during path finding."`
+
+	GraphBatchWindow time.Duration `long:"graph-batch-window" description:"How long to collect node announcements and channel updates before writing them to the database within a single transaction. Set to 0 to write each of them separately. Valid time units are {ms, s, m, h}."`
+
+	ResetGraph bool `long:"reset-graph" description:"Delete the channel graph stored within graph.db on startup, so it's rebuilt from the network. Channel state isn't affected."`
+
+	DryRunMigration bool `long:"dry-run-migration" description:"Apply any pending channel database migrations within a transaction which is rolled back, log how they would change the database, then exit."`
+
+	Bolt *boltConfig `group:"bolt" namespace:"bolt"`
+}
+
 // config defines the configuration options for lnd.
 //
 // See loadConfig for further details regarding the configuration
@@ -177,6 +211,7 @@ type config struct {
 	HodlHTLC           bool `long:"hodlhtlc" description:"Activate the hodl HTLC mode.  With hodl HTLC mode, all incoming HTLCs will be accepted by the receiving node, but no attempt will be made to settle the payment with the sender."`
 	UnsafeDisconnect   bool `long:"unsafe-disconnect" description:"Allows the rpcserver to intentionally disconnect from peers with open channels. USED FOR TESTING ONLY."`
 	UnsafeReplay       bool `long:"unsafe-replay" description:"Causes a link to replay the adds on its commitment txn after starting up, this enables testing of the sphinx replay logic."`
+	UnsafeAbandon      bool `long:"unsafe-abandon" description:"Allows the rpcserver to abandon channels, removing all of their state from the database without taking any action on-chain. Any funds still committed to an abandoned channel are lost."`
 	MaxPendingChannels int  `long:"maxpendingchannels" description:"The maximum number of incoming pending channels permitted per peer."`
 
 	Bitcoin      *chainConfig    `group:"Bitcoin" namespace:"bitcoin"`
@@ -192,6 +227,8 @@ type config struct {
 
 	Tor *torConfig `group:"Tor" namespace:"tor"`
 
+	DB *dbConfig `group:"db" namespace:"db"`
+
 	NoNetBootstrap bool `long:"nobootstrap" description:"If true, then automatic network bootstrapping will not be attempted."`
 
 	NoEncryptWallet bool `long:"noencryptwallet" description:"If set, wallet will be encrypted using the default passphrase."`
@@ -202,6 +239,16 @@ type config struct {
 	Color       string `long:"color" description:"The color of the node in hex format (i.e. '#3399FF'). Used to customize node appearance in intelligence services"`
 	MinChanSize int64  `long:"minchansize" description:"The smallest channel size (in satoshis) that we should accept. Incoming channels smaller than this will be rejected"`
 
+	PolicyCacheSize      int           `long:"policycachesize" description:"The maximum number of payment fee policies to keep in memory. Set to 0 to disable the cache."`
+	PolicyAuditRetention time.Duration `long:"policyauditretention" description:"How long to keep records of the decisions made by payment fee policies. Set to 0 to keep them indefinitely. Valid time units are {s, m, h}."`
+	EncryptPolicies      bool          `long:"encryptpolicies" description:"If set, payment fee policies are encrypted at rest using a key derived from the wallet. Unsetting it decrypts any previously encrypted policies."`
+
+	ForwardingLogRetention time.Duration `long:"forwardinglogretention" description:"How long to keep records of forwarded HTLCs. Set to 0 to keep them indefinitely. Valid time units are {s, m, h}."`
+
+	RejectCacheSize int `long:"rejectcachesize" description:"The maximum number of channels whose rejected announcements and updates are remembered across restarts, so they're dropped without being validated again. Set to 0 to disable the cache."`
+
+	AcceptKeySend bool `long:"accept-keysend" description:"If true, spontaneous keysend payments carrying their preimage are accepted, and recorded as settled invoices."`
+
 	net torsvc.Net
 }
 
@@ -267,10 +314,22 @@ func loadConfig() (*config, error) {
 			MinChannelSize: int64(minChanFundingSize),
 			MaxChannelSize: int64(maxFundingAmount),
 		},
-		TrickleDelay: defaultTrickleDelay,
-		Alias:        defaultAlias,
-		Color:        defaultColor,
-		MinChanSize:  int64(minChanFundingSize),
+		DB: &dbConfig{
+			GraphBatchWindow: defaultGraphBatchWindow,
+			Bolt: &boltConfig{
+				MaxBatchSize:      defaultMaxBatchSize,
+				MaxBatchDelay:     defaultMaxBatchDelay,
+				BatchRetries:      defaultBatchRetries,
+				BatchRetryBackoff: defaultBatchRetryBackoff,
+			},
+		},
+		TrickleDelay:         defaultTrickleDelay,
+		Alias:                defaultAlias,
+		Color:                defaultColor,
+		MinChanSize:          int64(minChanFundingSize),
+		PolicyCacheSize:      defaultPolicyCacheSize,
+		PolicyAuditRetention: defaultPolicyAuditRetention,
+		RejectCacheSize:      defaultRejectCacheSize,
 	}
 
 	// Pre-parse the command line options to pick up an alternative config
@@ -378,6 +437,17 @@ func loadConfig() (*config, error) {
 		return nil, err
 	}
 
+	// The free page ratio triggering compaction of the channel database
+	// is a fraction of it.
+	ratio := cfg.DB.Bolt.AutoCompactFreeRatio
+	if ratio < 0 || ratio > 1 {
+		str := "%s: db.bolt.auto-compact-free-ratio must be between " +
+			"0 and 1"
+		err := fmt.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		return nil, err
+	}
+
 	// Ensure that the specified values for the min and max channel size
 	// don't are within the bounds of the normal chan size constraints.
 	if cfg.Autopilot.MinChannelSize < int64(minChanFundingSize) {
@@ -115,6 +115,15 @@ type ChainArbitratorConfig struct {
 
 	// ChainIO allows us to query the state of the current main chain.
 	ChainIO lnwallet.BlockChainIO
+
+	// NotifyClosingChannel is called with the funding outpoint of a
+	// channel once a commitment transaction of the channel confirmed, and
+	// its outputs are to be swept before it's fully closed.
+	NotifyClosingChannel func(wire.OutPoint)
+
+	// NotifyClosedChannel is called with the funding outpoint of a channel
+	// once it was fully closed, as all of its contracts were resolved.
+	NotifyClosedChannel func(wire.OutPoint)
 }
 
 // ChainArbitrator is a sub-system that oversees the on-chain resolution of all
@@ -279,6 +288,8 @@ func (c *ChainArbitrator) resolveContract(chanPoint wire.OutPoint,
 		return err
 	}
 
+	c.cfg.NotifyClosedChannel(chanPoint)
+
 	if arbLog != nil {
 		// Once this has been marked as resolved, we'll wipe the log
 		// that the channel arbitrator was using to store its
@@ -633,6 +644,29 @@ func (c *ChainArbitrator) ForceCloseContract(chanPoint wire.OutPoint) (*wire.Msg
 	return closeTx, nil
 }
 
+// ResolveContract stops the arbitrator and chain watcher of the channel
+// identified by the passed channel point, and marks the contract as fully
+// resolved. This is to be used when a channel has been abandoned, and we no
+// longer wish to take any action on-chain on its behalf.
+func (c *ChainArbitrator) ResolveContract(chanPoint wire.OutPoint) error {
+	c.Lock()
+	arbitrator, ok := c.activeChannels[chanPoint]
+	c.Unlock()
+
+	// If the channel doesn't have an active arbitrator, then there's no
+	// log to wipe, but we'll still mark it resolved to ensure the watcher
+	// (if any) is torn down.
+	var arbLog ArbitratorLog
+	if ok {
+		if err := arbitrator.Stop(); err != nil {
+			return err
+		}
+		arbLog = arbitrator.log
+	}
+
+	return c.resolveContract(chanPoint, arbLog)
+}
+
 // WatchNewChannel sends the ChainArbitrator a message to create a
 // ChannelArbitrator tasked with watching over a new channel. Once a new
 // channel has finished its final funding flow, it should be registered with
@@ -1386,6 +1386,11 @@ func (c *ChannelArbitrator) channelAttendant(bestHeight int32,
 				return
 			}
 
+			// The chain watcher already marked the channel as
+			// pending close, so it's now closing while we sweep
+			// our outputs.
+			c.cfg.NotifyClosingChannel(c.cfg.ChanPoint)
+
 			// TODO(roasbeef): modify signal to also detect
 			// cooperative closures?
 
@@ -1527,5 +1532,11 @@ func (c *ChannelArbitrator) markContractClosed(closeTx *wire.MsgTx,
 		closeInfo.TimeLockedBalance += htlcValue
 	}
 
-	return c.cfg.CloseChannel(closeInfo)
+	if err := c.cfg.CloseChannel(closeInfo); err != nil {
+		return err
+	}
+
+	c.cfg.NotifyClosingChannel(c.cfg.ChanPoint)
+
+	return nil
 }
@@ -1041,29 +1041,33 @@ func (h *htlcIncomingContestResolver) Resolve() (ContractResolver, error) {
 		copy(h.htlcResolution.Preimage[:], preimage[:])
 	}
 
-	// If the HTLC hasn't yet expired, then we'll query to see if we
-	// already know the preimage.
-	preimage, ok := h.PreimageDB.LookupPreimage(h.payHash[:])
-	if ok {
-		// If we do, then this means we can claim the HTLC!  However,
-		// we don't know how to ourselves, so we'll return our inner
-		// resolver which has the knowledge to do so.
-		applyPreimage(preimage[:])
-		return &h.htlcSuccessResolver, nil
-	}
-
 	// If the HTLC hasn't expired yet, then we may still be able to claim
 	// it if we learn of the pre-image, so we'll wait and see if it pops
-	// up, or the HTLC times out.
+	// up, or the HTLC times out. We subscribe before querying for the
+	// preimage, so a preimage learned in between isn't missed.
 	preimageSubscription := h.PreimageDB.SubscribeUpdates()
 	blockEpochs, err := h.Notifier.RegisterBlockEpochNtfn()
 	if err != nil {
+		preimageSubscription.CancelSubscription()
 		return nil, err
 	}
 	defer func() {
 		preimageSubscription.CancelSubscription()
 		blockEpochs.Cancel()
 	}()
+
+	// With the subscription active, we'll query to see if we already know
+	// the preimage, either as it's for one of our invoices, or as we've
+	// learned it from a downstream settle or on-chain claim, possibly
+	// before a restart.
+	preimage, ok := h.PreimageDB.LookupPreimage(h.payHash[:])
+	if ok {
+		// If we do, then this means we can claim the HTLC!  However,
+		// we don't know how to ourselves, so we'll return our inner
+		// resolver which has the knowledge to do so.
+		applyPreimage(preimage[:])
+		return &h.htlcSuccessResolver, nil
+	}
 	for {
 
 		select {
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/contractcourt"
+)
+
+// errHTLCNotPending is the failure reason recorded for payments which were in
+// flight when the daemon stopped, and whose HTLC turned out to no longer be
+// pending within any channel without its preimage having been learned.
+const errHTLCNotPending = "payment HTLC no longer pending after restart"
+
+// controlTowerConfig houses the resources required by the control tower.
+type controlTowerConfig struct {
+	// DB is the database which stores the state of each payment, along
+	// with the channels whose commitments may carry the HTLCs of payments
+	// in flight.
+	DB *channeldb.DB
+
+	// PreimageCache is used to learn the preimages of payments which
+	// succeeded, both before and after the daemon restarted.
+	PreimageCache contractcourt.WitnessBeacon
+
+	// Notifier is used to receive a notification for each new block,
+	// upon which payments whose outcome is still unknown are checked
+	// again.
+	Notifier chainntnfs.ChainNotifier
+
+	// PaymentResolved, if set, is called with the final state of each
+	// payment resolved by the control tower, once it's been recorded.
+	PaymentResolved func(paymentHash [32]byte,
+		state *channeldb.PaymentState)
+}
+
+// controlTower resumes payments which were in flight when the daemon stopped.
+// As the router only learns the outcome of the payments it dispatched itself,
+// the outcome of such payments is determined from the preimages learned by
+// the daemon and the commitments of its channels: a payment whose preimage is
+// known succeeded, while a payment whose HTLC is no longer pending within any
+// channel failed. Until either is the case, the payment remains in flight,
+// preventing its payment hash from being paid again.
+type controlTower struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	cfg *controlTowerConfig
+
+	// resuming is the set of payments which were in flight on startup,
+	// and whose outcome isn't known yet. It's only accessed by the
+	// resolver goroutine once Start returns.
+	resuming map[[32]byte]struct{}
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newControlTower creates a new control tower backed by the passed config. The
+// payments in flight are loaded here, before any new payment can be
+// dispatched, so that only payments which were in flight when the daemon
+// stopped are resumed.
+func newControlTower(cfg *controlTowerConfig) (*controlTower, error) {
+	inFlight, err := cfg.DB.FetchInFlightPayments()
+	if err != nil {
+		return nil, err
+	}
+
+	resuming := make(map[[32]byte]struct{}, len(inFlight))
+	for _, paymentHash := range inFlight {
+		resuming[paymentHash] = struct{}{}
+	}
+
+	return &controlTower{
+		cfg:      cfg,
+		resuming: resuming,
+		quit:     make(chan struct{}),
+	}, nil
+}
+
+// Start resolves all payments which were in flight when the daemon stopped
+// and whose outcome is already known, and launches the goroutine which
+// resolves the remaining ones as their outcome becomes known.
+func (c *controlTower) Start() error {
+	if !atomic.CompareAndSwapUint32(&c.started, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Tracef("Starting control tower")
+
+	if len(c.resuming) == 0 {
+		return nil
+	}
+
+	srvrLog.Infof("Resuming %v payments in flight", len(c.resuming))
+
+	// We subscribe to new preimages and blocks before the initial
+	// resolution to ensure we don't miss any outcome in between.
+	preimages := c.cfg.PreimageCache.SubscribeUpdates()
+	newBlockChan, err := c.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		preimages.CancelSubscription()
+		return err
+	}
+
+	if err := c.resolve(); err != nil {
+		preimages.CancelSubscription()
+		newBlockChan.Cancel()
+		return err
+	}
+
+	c.wg.Add(1)
+	go c.resolver(preimages, newBlockChan)
+
+	return nil
+}
+
+// Stop signals the control tower to exit, and waits for it to do so.
+func (c *controlTower) Stop() error {
+	if !atomic.CompareAndSwapUint32(&c.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Control tower shutting down")
+
+	close(c.quit)
+	c.wg.Wait()
+
+	return nil
+}
+
+// resolver resolves the payments which were in flight on startup as new
+// preimages are learned and new blocks are connected, until all of them are
+// resolved.
+//
+// NOTE: This MUST be run as a goroutine.
+func (c *controlTower) resolver(preimages *contractcourt.WitnessSubscription,
+	newBlockChan *chainntnfs.BlockEpochEvent) {
+
+	defer c.wg.Done()
+	defer preimages.CancelSubscription()
+	defer newBlockChan.Cancel()
+
+	for len(c.resuming) > 0 {
+		select {
+		case preimage := <-preimages.WitnessUpdates:
+			paymentHash := sha256.Sum256(preimage)
+			if _, ok := c.resuming[paymentHash]; !ok {
+				continue
+			}
+
+			err := c.resolvePayment(paymentHash, true)
+			if err != nil {
+				srvrLog.Errorf("Unable to resolve payment "+
+					"%x: %v", paymentHash[:], err)
+			}
+
+		case _, ok := <-newBlockChan.Epochs:
+			if !ok {
+				return
+			}
+
+			if err := c.resolve(); err != nil {
+				srvrLog.Errorf("Unable to resolve payments in "+
+					"flight: %v", err)
+			}
+
+		case <-c.quit:
+			return
+		}
+	}
+
+	srvrLog.Infof("All payments in flight on startup resolved")
+}
+
+// resolve resolves all payments which were in flight on startup whose
+// outcome is known by now.
+func (c *controlTower) resolve() error {
+	pending, err := c.pendingHTLCs()
+	if err != nil {
+		return err
+	}
+
+	for paymentHash := range c.resuming {
+		_, succeeded := c.cfg.PreimageCache.LookupPreimage(
+			paymentHash[:],
+		)
+
+		// Without its preimage, a payment has only failed once its
+		// HTLC is known to no longer be pending.
+		if !succeeded {
+			if pending == nil {
+				continue
+			}
+			if _, ok := pending[paymentHash]; ok {
+				continue
+			}
+		}
+
+		err := c.resolvePayment(paymentHash, succeeded)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolvePayment records the outcome of a payment which was in flight on
+// startup, and stops tracking it.
+func (c *controlTower) resolvePayment(paymentHash [32]byte,
+	succeeded bool) error {
+
+	var (
+		state = &channeldb.PaymentState{Status: channeldb.StatusSucceeded}
+		err   error
+	)
+	if succeeded {
+		srvrLog.Infof("Payment %x in flight on startup succeeded",
+			paymentHash[:])
+		err = c.cfg.DB.SucceedPayment(paymentHash)
+	} else {
+		srvrLog.Infof("Payment %x in flight on startup failed",
+			paymentHash[:])
+		state.Status = channeldb.StatusFailed
+		state.FailureReason = errHTLCNotPending
+		err = c.cfg.DB.FailPayment(paymentHash, errHTLCNotPending)
+	}
+
+	// The payment may have been resolved already, in which case there is
+	// nothing left to track.
+	switch err {
+	case nil:
+		delete(c.resuming, paymentHash)
+		if c.cfg.PaymentResolved != nil {
+			c.cfg.PaymentResolved(paymentHash, state)
+		}
+		return nil
+	case channeldb.ErrAlreadyPaid, channeldb.ErrPaymentAlreadyFailed:
+		delete(c.resuming, paymentHash)
+		return nil
+	default:
+		return err
+	}
+}
+
+// pendingHTLCs returns the payment hashes of all outgoing HTLCs which are
+// pending within the commitments of our channels. If any channel is pending
+// close, the HTLCs it carries are only resolved by its contract resolution,
+// so nil is returned as the set of pending HTLCs can't be determined.
+func (c *controlTower) pendingHTLCs() (map[[32]byte]struct{}, error) {
+	closing, err := c.cfg.DB.FetchClosedChannels(true)
+	if err != nil && err != channeldb.ErrNoClosedChannels {
+		return nil, err
+	}
+	if len(closing) != 0 {
+		return nil, nil
+	}
+
+	channels, err := c.cfg.DB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[[32]byte]struct{})
+	addHTLCs := func(htlcs []channeldb.HTLC) {
+		for _, htlc := range htlcs {
+			if !htlc.Incoming {
+				pending[htlc.RHash] = struct{}{}
+			}
+		}
+	}
+	for _, channel := range channels {
+		addHTLCs(channel.LocalCommitment.Htlcs)
+		addHTLCs(channel.RemoteCommitment.Htlcs)
+
+		// A commitment we signed, but which the remote party didn't
+		// revoke their prior commitment for yet, may also carry an
+		// HTLC.
+		tip, err := channel.RemoteCommitChainTip()
+		switch {
+		case err == channeldb.ErrNoPendingCommit:
+		case err != nil:
+			return nil, err
+		default:
+			addHTLCs(tip.Commitment.Htlcs)
+		}
+	}
+
+	return pending, nil
+}
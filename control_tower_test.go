@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// TestControlTowerResolve tests that the control tower only resumes payments
+// which were in flight when it was created, marking those whose preimage is
+// known as succeeded, and those whose HTLC is no longer pending as failed.
+func TestControlTowerResolve(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	preimageCache := &mockPreimageCache{
+		preimageMap: make(map[[32]byte][]byte),
+	}
+
+	succeeded := []byte("succeeded preimage")
+	succeededHash := sha256.Sum256(succeeded)
+	failedHash := sha256.Sum256([]byte("failed preimage"))
+	for _, hash := range [][32]byte{succeededHash, failedHash} {
+		if err := db.InitPayment(hash); err != nil {
+			t.Fatalf("unable to init payment: %v", err)
+		}
+	}
+
+	tower, err := newControlTower(&controlTowerConfig{
+		DB:            db,
+		PreimageCache: preimageCache,
+	})
+	if err != nil {
+		t.Fatalf("unable to create control tower: %v", err)
+	}
+
+	// A payment initiated after the control tower was created shouldn't
+	// be resumed by it, even though its HTLC isn't pending yet.
+	liveHash := sha256.Sum256([]byte("live preimage"))
+	if err := db.InitPayment(liveHash); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	if err := preimageCache.AddPreimage(succeeded); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+	if err := tower.resolve(); err != nil {
+		t.Fatalf("unable to resolve payments: %v", err)
+	}
+
+	if len(tower.resuming) != 0 {
+		t.Fatalf("expected all payments to be resolved, %v remain",
+			len(tower.resuming))
+	}
+
+	expected := map[[32]byte]channeldb.PaymentStatus{
+		succeededHash: channeldb.StatusSucceeded,
+		failedHash:    channeldb.StatusFailed,
+		liveHash:      channeldb.StatusInFlight,
+	}
+	for hash, status := range expected {
+		state, err := db.FetchPaymentState(hash)
+		if err != nil {
+			t.Fatalf("unable to fetch payment state: %v", err)
+		}
+		if state.Status != status {
+			t.Fatalf("expected payment %x to be %v, got %v",
+				hash[:], status, state.Status)
+		}
+	}
+}
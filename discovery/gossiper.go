@@ -113,6 +113,12 @@ type Config struct {
 	// proof storage to make waiting proofs persistent.
 	DB *channeldb.DB
 
+	// RejectCacheSize is the maximum number of channels whose rejected
+	// announcements and updates are remembered, both in memory and across
+	// restarts, so they're dropped without being validated again. Set to
+	// 0 to disable the cache.
+	RejectCacheSize int
+
 	// AnnSigner is an instance of the MessageSigner interface which will
 	// be used to manually sign any outgoing channel updates. The signer
 	// implementation should be backed by the public key of the backing
@@ -193,8 +199,10 @@ type AuthenticatedGossiper struct {
 	// consistent between when the DB is first read until it's written.
 	channelMtx *multimutex.Mutex
 
-	rejectMtx     sync.RWMutex
-	recentRejects map[uint64]struct{}
+	// recentRejects is a persistent cache of channels whose
+	// announcements or updates were rejected, allowing stale messages to
+	// be dropped without validating them again, even after a restart.
+	recentRejects *channeldb.GossipRejectCache
 
 	sync.Mutex
 }
@@ -207,6 +215,13 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		return nil, err
 	}
 
+	recentRejects, err := channeldb.NewGossipRejectCache(
+		cfg.DB, cfg.RejectCacheSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AuthenticatedGossiper{
 		selfKey:                 selfKey,
 		cfg:                     &cfg,
@@ -217,7 +232,7 @@ func New(cfg Config, selfKey *btcec.PublicKey) (*AuthenticatedGossiper, error) {
 		prematureChannelUpdates: make(map[uint64][]*networkMsg),
 		waitingProofs:           storage,
 		channelMtx:              multimutex.NewMutex(),
-		recentRejects:           make(map[uint64]struct{}),
+		recentRejects:           recentRejects,
 	}, nil
 }
 
@@ -1025,20 +1040,33 @@ func (d *AuthenticatedGossiper) networkHandler() {
 	}
 }
 
+// rejectMsg records that a message of the passed channel was rejected,
+// causing further messages of the channel to be dropped without being
+// validated again. A timestamp of zero rejects all of its messages, otherwise
+// only channel updates which aren't newer than the rejected one are dropped.
+func (d *AuthenticatedGossiper) rejectMsg(chanID lnwire.ShortChannelID,
+	timestamp uint32) {
+
+	err := d.recentRejects.Add(chanID.ToUint64(), timestamp)
+	if err != nil {
+		log.Errorf("Unable to record rejected message for "+
+			"short_chan_id=%v: %v", chanID, err)
+	}
+}
+
 // isRecentlyRejectedMsg returns true if we recently rejected a message, and
 // false otherwise, This avoids expensive reprocessing of the message.
 func (d *AuthenticatedGossiper) isRecentlyRejectedMsg(msg lnwire.Message) bool {
-	d.rejectMtx.RLock()
-	defer d.rejectMtx.RUnlock()
-
 	switch m := msg.(type) {
 	case *lnwire.ChannelUpdate:
-		_, ok := d.recentRejects[m.ShortChannelID.ToUint64()]
-		return ok
+		return d.recentRejects.IsRejected(
+			m.ShortChannelID.ToUint64(), m.Timestamp,
+		)
 
 	case *lnwire.ChannelAnnouncement:
-		_, ok := d.recentRejects[m.ShortChannelID.ToUint64()]
-		return ok
+		return d.recentRejects.IsRejected(
+			m.ShortChannelID.ToUint64(), 0,
+		)
 
 	default:
 		return false
@@ -1181,6 +1209,26 @@ func (d *AuthenticatedGossiper) processChanPolicyUpdate(
 		)
 		edge.TimeLockDelta = uint16(policyUpdate.newSchema.TimeLockDelta)
 
+		// The maximum HTLC value is optional, so we'll only update it
+		// if one was specified. It can't exceed the capacity of the
+		// channel, nor be below the minimum HTLC value.
+		if maxHTLC := policyUpdate.newSchema.MaxHTLC; maxHTLC != 0 {
+			capacity := lnwire.NewMSatFromSatoshis(info.Capacity)
+			if maxHTLC > capacity {
+				return fmt.Errorf("max htlc of %v exceeds "+
+					"capacity of ChannelPoint(%v)", maxHTLC,
+					info.ChannelPoint)
+			}
+			if maxHTLC < edge.MinHTLC {
+				return fmt.Errorf("max htlc of %v is below "+
+					"min htlc of ChannelPoint(%v)", maxHTLC,
+					info.ChannelPoint)
+			}
+
+			edge.MaxHTLC = maxHTLC
+			edge.Flags |= lnwire.ChanUpdateOptionMaxHtlc
+		}
+
 		edgesToUpdate = append(edgesToUpdate, edgeWithInfo{
 			info: info,
 			edge: edge,
@@ -1390,9 +1438,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			log.Error("Ignoring ChannelAnnouncement from "+
 				"chain=%v, gossiper on chain=%v", msg.ChainHash,
 				d.cfg.ChainHash)
-			d.rejectMtx.Lock()
-			d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-			d.rejectMtx.Unlock()
+			d.rejectMsg(msg.ShortChannelID, 0)
 			return nil
 		}
 
@@ -1431,9 +1477,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			if err := ValidateChannelAnn(msg); err != nil {
 				err := errors.Errorf("unable to validate "+
 					"announcement: %v", err)
-				d.rejectMtx.Lock()
-				d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-				d.rejectMtx.Unlock()
+				d.rejectMsg(msg.ShortChannelID, 0)
 
 				log.Error(err)
 				nMsg.err <- err
@@ -1494,9 +1538,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				// see if we get any new announcements.
 				anns, rErr := d.processRejectedEdge(msg, proof)
 				if rErr != nil {
-					d.rejectMtx.Lock()
-					d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-					d.rejectMtx.Unlock()
+					d.rejectMsg(msg.ShortChannelID, 0)
 					nMsg.err <- rErr
 					return nil
 				}
@@ -1599,9 +1641,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			log.Errorf("Ignoring ChannelUpdate from "+
 				"chain=%v, gossiper on chain=%v", msg.ChainHash,
 				d.cfg.ChainHash)
-			d.rejectMtx.Lock()
-			d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-			d.rejectMtx.Unlock()
+			d.rejectMsg(msg.ShortChannelID, msg.Timestamp)
 			return nil
 		}
 
@@ -1688,9 +1728,7 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 				log.Error(err)
 				nMsg.err <- err
 
-				d.rejectMtx.Lock()
-				d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-				d.rejectMtx.Unlock()
+				d.rejectMsg(msg.ShortChannelID, msg.Timestamp)
 				return nil
 			}
 		}
@@ -1728,15 +1766,14 @@ func (d *AuthenticatedGossiper) processNetworkAnnouncement(nMsg *networkMsg) []n
 			MinHTLC:                   msg.HtlcMinimumMsat,
 			FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 			FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
+			MaxHTLC:                   msg.HtlcMaximumMsat,
 		}
 
 		if err := d.cfg.Router.UpdateEdge(update); err != nil {
 			if routing.IsError(err, routing.ErrOutdated, routing.ErrIgnored) {
 				log.Debug(err)
 			} else {
-				d.rejectMtx.Lock()
-				d.recentRejects[msg.ShortChannelID.ToUint64()] = struct{}{}
-				d.rejectMtx.Unlock()
+				d.rejectMsg(msg.ShortChannelID, msg.Timestamp)
 				log.Error(err)
 			}
 
@@ -2167,6 +2204,7 @@ func (d *AuthenticatedGossiper) updateChannel(info *channeldb.ChannelEdgeInfo,
 		HtlcMinimumMsat: edge.MinHTLC,
 		BaseFee:         uint32(edge.FeeBaseMSat),
 		FeeRate:         uint32(edge.FeeProportionalMillionths),
+		HtlcMaximumMsat: edge.MaxHTLC,
 	}
 	chanUpdate.Signature, err = lnwire.NewSigFromRawSignature(edge.SigBytes)
 	if err != nil {
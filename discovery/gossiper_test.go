@@ -540,6 +540,7 @@ func createTestCtx(startHeight uint32) (*testCtx, func(), error) {
 		RetransmitDelay:  retransmitDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               db,
+		RejectCacheSize:  1000,
 	}, nodeKeyPub1)
 	if err != nil {
 		cleanUpDb()
@@ -1424,6 +1425,7 @@ func TestSignatureAnnouncementRetryAtStartup(t *testing.T) {
 		RetransmitDelay:  retransmitDelay,
 		ProofMatureDelta: proofMatureDelta,
 		DB:               ctx.gossiper.cfg.DB,
+		RejectCacheSize:  ctx.gossiper.cfg.RejectCacheSize,
 	}, ctx.gossiper.selfKey)
 	if err != nil {
 		t.Fatalf("unable to recreate gossiper: %v", err)
@@ -277,6 +277,13 @@ type fundingConfig struct {
 	// process to determine how many confirmations we'll require.
 	NumRequiredConfs func(btcutil.Amount, lnwire.MilliSatoshi) uint16
 
+	// AcceptChannel is consulted for each channel extended to us which
+	// passed all of our own checks, and decides whether we accept it. The
+	// decision may also override the number of confirmations we require
+	// for the channel.
+	AcceptChannel func(*btcec.PublicKey,
+		*lnwire.OpenChannel) *channelAcceptResponse
+
 	// RequiredRemoteDelay is a function that maps the total amount in a
 	// proposed channel to the CSV delay that we'll require for the remote
 	// party. Naturally a larger channel should require a higher CSV delay
@@ -312,6 +319,21 @@ type fundingConfig struct {
 	// sub-systems.
 	ReportShortChanID func(wire.OutPoint, lnwire.ShortChannelID) error
 
+	// NotifyPendingOpenChannelEvent is used to notify outside sub-systems
+	// that a new channel is pending open, waiting for its funding
+	// transaction to confirm.
+	NotifyPendingOpenChannelEvent func(wire.OutPoint)
+
+	// NotifyOpenChannelEvent is used to notify outside sub-systems that
+	// the funding transaction of a channel confirmed, and the channel is
+	// now open.
+	NotifyOpenChannelEvent func(wire.OutPoint)
+
+	// NotifyClosedChannelEvent is used to notify outside sub-systems that
+	// a pending channel was forgotten, as its funding transaction didn't
+	// confirm in time.
+	NotifyClosedChannelEvent func(wire.OutPoint)
+
 	// ZombieSweeperInterval is the periodic time interval in which the
 	// zombie sweeper is run.
 	ZombieSweeperInterval time.Duration
@@ -524,8 +546,11 @@ func (f *fundingManager) Start() error {
 				if err := ch.CloseChannel(closeInfo); err != nil {
 					fndgLog.Errorf("Failed closing channel "+
 						"%v: %v", ch.FundingOutpoint, err)
+					return
 				}
 
+				f.cfg.NotifyClosedChannelEvent(ch.FundingOutpoint)
+
 			case <-f.quit:
 				// The fundingManager is shutting down, and will
 				// resume wait on startup.
@@ -969,6 +994,17 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 		msg.CsvDelay, msg.PendingChannelID,
 		fmsg.peerAddress.IdentityKey.SerializeCompressed())
 
+	// Now that the channel passed all of our own checks, we'll let the
+	// channel acceptor decide whether we accept it.
+	acceptResp := f.cfg.AcceptChannel(fmsg.peerAddress.IdentityKey, msg)
+	if !acceptResp.accept {
+		f.failFundingFlow(
+			fmsg.peerAddress.IdentityKey, fmsg.msg.PendingChannelID,
+			lnwallet.ErrChanRejected(acceptResp.reason),
+		)
+		return
+	}
+
 	// Attempt to initialize a reservation within the wallet. If the wallet
 	// has insufficient resources to create the channel, then the
 	// reservation attempt may be rejected. Note that since we're on the
@@ -994,6 +1030,9 @@ func (f *fundingManager) handleFundingOpen(fmsg *fundingOpenMsg) {
 	// confirmations based on the amount of the channel, and also if any
 	// funds are being pushed to us.
 	numConfsReq := f.cfg.NumRequiredConfs(msg.FundingAmount, msg.PushAmount)
+	if acceptResp.minAcceptDepth != 0 {
+		numConfsReq = acceptResp.minAcceptDepth
+	}
 	reservation.SetNumConfsRequired(numConfsReq)
 
 	// We'll also validate and apply all the constraints the initiating
@@ -1382,6 +1421,8 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 			"arbitration: %v", fundingOut, err)
 	}
 
+	f.cfg.NotifyPendingOpenChannelEvent(fundingOut)
+
 	// Create an entry in the local discovery map so we can ensure that we
 	// process the channel confirmation fully before we receive a funding
 	// locked message.
@@ -1425,6 +1466,7 @@ func (f *fundingManager) handleFundingCreated(fmsg *fundingCreatedMsg) {
 			// We did not see the funding confirmation before
 			// timeout, so we forget the channel.
 			deleteFromDatabase()
+			f.cfg.NotifyClosedChannelEvent(fundingOut)
 			return
 		case <-f.quit:
 			// The fundingManager is shutting down, will resume
@@ -1531,6 +1573,8 @@ func (f *fundingManager) handleFundingSigned(fmsg *fundingSignedMsg) {
 			"arbitration: %v", fundingPoint, err)
 	}
 
+	f.cfg.NotifyPendingOpenChannelEvent(*fundingPoint)
+
 	fndgLog.Infof("Finalizing pendingID(%x) over ChannelPoint(%v), "+
 		"waiting for channel open on-chain", pendingChanID[:], fundingPoint)
 
@@ -1800,6 +1844,8 @@ func (f *fundingManager) waitForFundingConfirmation(completeChan *channeldb.Open
 		return
 	}
 
+	f.cfg.NotifyOpenChannelEvent(fundingPoint)
+
 	// TODO(roasbeef): ideally persistent state update for chan above
 	// should be abstracted
 
@@ -2537,6 +2583,10 @@ func (f *fundingManager) handleInitFundingMsg(msg *initFundingMsg) {
 		return
 	}
 
+	// If the channel is to be frozen, then we'll record its thaw height
+	// along side it.
+	reservation.SetThawHeight(msg.thawHeight)
+
 	// Obtain a new pending channel ID which is used to track this
 	// reservation throughout its lifetime.
 	chanID := f.nextPendingChanID()
@@ -293,6 +293,7 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 			pushAmt lnwire.MilliSatoshi) uint16 {
 			return 3
 		},
+		AcceptChannel: newChannelAcceptor().Accept,
 		RequiredRemoteDelay: func(amt btcutil.Amount) uint16 {
 			return 4
 		},
@@ -313,8 +314,11 @@ func createTestFundingManager(t *testing.T, privKey *btcec.PrivateKey,
 		ReportShortChanID: func(wire.OutPoint, lnwire.ShortChannelID) error {
 			return nil
 		},
-		ZombieSweeperInterval: 1 * time.Hour,
-		ReservationTimeout:    1 * time.Nanosecond,
+		NotifyPendingOpenChannelEvent: func(wire.OutPoint) {},
+		NotifyOpenChannelEvent:        func(wire.OutPoint) {},
+		NotifyClosedChannelEvent:      func(wire.OutPoint) {},
+		ZombieSweeperInterval:         1 * time.Hour,
+		ReservationTimeout:            1 * time.Nanosecond,
 	})
 	if err != nil {
 		t.Fatalf("failed creating fundingManager: %v", err)
@@ -392,8 +396,12 @@ func recreateAliceFundingManager(t *testing.T, alice *testNode) {
 			publishChan <- txn
 			return nil
 		},
-		ZombieSweeperInterval: oldCfg.ZombieSweeperInterval,
-		ReservationTimeout:    oldCfg.ReservationTimeout,
+		AcceptChannel:                 oldCfg.AcceptChannel,
+		NotifyPendingOpenChannelEvent: oldCfg.NotifyPendingOpenChannelEvent,
+		NotifyOpenChannelEvent:        oldCfg.NotifyOpenChannelEvent,
+		NotifyClosedChannelEvent:      oldCfg.NotifyClosedChannelEvent,
+		ZombieSweeperInterval:         oldCfg.ZombieSweeperInterval,
+		ReservationTimeout:            oldCfg.ReservationTimeout,
 	})
 	if err != nil {
 		t.Fatalf("failed recreating aliceFundingManager: %v", err)
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/lightningnetwork/lnd/htlcswitch"
+)
+
+// interceptAction denotes how an intercepted HTLC forward is resolved.
+type interceptAction uint8
+
+const (
+	// interceptResume forwards the HTLC as if it hadn't been intercepted.
+	interceptResume interceptAction = iota
+
+	// interceptSettle settles the HTLC back to the incoming channel.
+	interceptSettle
+
+	// interceptFail fails the HTLC back to the incoming channel.
+	interceptFail
+)
+
+// forwardInterceptor holds the HTLC forwards intercepted by the switch on
+// behalf of a single client, until the client resolves them. Held forwards
+// are delivered to the client over the Forwards channel in the order they
+// were intercepted. Once stopped, any forward the client didn't resolve yet
+// is resumed, so no HTLC is held indefinitely.
+type forwardInterceptor struct {
+	htlcSwitch *htlcswitch.Switch
+
+	// Forwards is the channel over which each held forward is delivered
+	// to the client.
+	Forwards chan *htlcswitch.InterceptedForward
+
+	// held is the set of forwards which the client didn't resolve yet,
+	// and queue holds those which weren't delivered to the client yet.
+	// queued is signaled whenever a forward is appended to the queue.
+	mtx     sync.Mutex
+	held    map[htlcswitch.CircuitKey]*htlcswitch.InterceptedForward
+	queue   []*htlcswitch.InterceptedForward
+	queued  chan struct{}
+	stopped bool
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newForwardInterceptor creates a new forward interceptor, and sets it as the
+// interceptor of the passed switch.
+func newForwardInterceptor(
+	htlcSwitch *htlcswitch.Switch) *forwardInterceptor {
+
+	f := &forwardInterceptor{
+		htlcSwitch: htlcSwitch,
+		Forwards:   make(chan *htlcswitch.InterceptedForward),
+		held: make(
+			map[htlcswitch.CircuitKey]*htlcswitch.InterceptedForward,
+		),
+		queued: make(chan struct{}, 1),
+		quit:   make(chan struct{}),
+	}
+
+	f.wg.Add(1)
+	go f.deliverForwards()
+
+	htlcSwitch.SetInterceptor(f.intercept)
+
+	return f
+}
+
+// Stop removes the interceptor from the switch, and resumes all forwards
+// which the client didn't resolve.
+func (f *forwardInterceptor) Stop() {
+	f.htlcSwitch.SetInterceptor(nil)
+
+	// Any forward intercepted concurrently with the removal of the
+	// interceptor is rejected once we're marked as stopped, so it's
+	// forwarded as usual.
+	f.mtx.Lock()
+	f.stopped = true
+	held := f.held
+	f.held = nil
+	f.mtx.Unlock()
+
+	close(f.quit)
+	f.wg.Wait()
+
+	for key, fwd := range held {
+		rpcsLog.Debugf("Resuming HTLC %v held by interceptor", key)
+
+		if err := fwd.Resume(); err != nil {
+			rpcsLog.Errorf("Unable to resume HTLC %v: %v", key,
+				err)
+		}
+	}
+}
+
+// intercept holds the passed forward, and queues it for delivery to the
+// client.
+//
+// NOTE: This is part of the htlcswitch.ForwardInterceptor type.
+func (f *forwardInterceptor) intercept(
+	fwd *htlcswitch.InterceptedForward) bool {
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.stopped {
+		return false
+	}
+
+	f.held[fwd.Packet.IncomingCircuit] = fwd
+	f.queue = append(f.queue, fwd)
+
+	select {
+	case f.queued <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// deliverForwards sends the queued forwards to the client in order, until the
+// interceptor is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (f *forwardInterceptor) deliverForwards() {
+	defer f.wg.Done()
+
+	for {
+		f.mtx.Lock()
+		var next *htlcswitch.InterceptedForward
+		if len(f.queue) > 0 {
+			next = f.queue[0]
+			f.queue[0] = nil
+			f.queue = f.queue[1:]
+		}
+		f.mtx.Unlock()
+
+		if next == nil {
+			select {
+			case <-f.queued:
+				continue
+			case <-f.quit:
+				return
+			}
+		}
+
+		select {
+		case f.Forwards <- next:
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// Resolve resolves the held forward of the HTLC identified by the passed
+// circuit key using the passed action. The preimage is only used to settle
+// the HTLC.
+func (f *forwardInterceptor) Resolve(key htlcswitch.CircuitKey,
+	action interceptAction, preimage [32]byte) error {
+
+	f.mtx.Lock()
+	fwd, ok := f.held[key]
+	if !ok {
+		f.mtx.Unlock()
+		return fmt.Errorf("no HTLC %v held by interceptor", key)
+	}
+
+	// An invalid preimage is rejected before releasing the forward, so
+	// the client is still able to resolve it afterwards.
+	if action == interceptSettle &&
+		sha256.Sum256(preimage[:]) != fwd.Packet.Hash {
+
+		f.mtx.Unlock()
+		return htlcswitch.ErrInvalidInterceptedPreimage
+	}
+
+	delete(f.held, key)
+	f.mtx.Unlock()
+
+	switch action {
+	case interceptResume:
+		return fwd.Resume()
+
+	case interceptSettle:
+		return fwd.Settle(preimage)
+
+	case interceptFail:
+		return fwd.Fail()
+
+	default:
+		return fmt.Errorf("unknown intercept action %v", action)
+	}
+}
@@ -0,0 +1,152 @@
+package htlcswitch
+
+import (
+	"crypto/sha256"
+
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+var (
+	// ErrInvalidInterceptedPreimage is returned when an intercepted HTLC
+	// is settled with a preimage which doesn't match its payment hash.
+	ErrInvalidInterceptedPreimage = errors.New("preimage doesn't match " +
+		"payment hash of intercepted htlc")
+)
+
+// InterceptedPacket contains the details of an HTLC forward which is held by
+// the switch until a ForwardInterceptor decides whether it's settled, failed
+// or resumed.
+type InterceptedPacket struct {
+	// IncomingCircuit identifies the incoming channel and the index of the
+	// HTLC within it.
+	IncomingCircuit CircuitKey
+
+	// OutgoingChanID is the channel over which the sender requested the
+	// HTLC to be forwarded.
+	OutgoingChanID lnwire.ShortChannelID
+
+	// Hash is the payment hash of the HTLC.
+	Hash [32]byte
+
+	// IncomingAmount is the amount of the incoming HTLC.
+	IncomingAmount lnwire.MilliSatoshi
+
+	// OutgoingAmount is the amount the sender requested to be forwarded.
+	OutgoingAmount lnwire.MilliSatoshi
+
+	// OutgoingExpiry is the absolute expiry height of the outgoing HTLC.
+	OutgoingExpiry uint32
+
+	// OnionBlob is the onion packet to be forwarded to the next hop.
+	OnionBlob [lnwire.OnionPacketSize]byte
+}
+
+// InterceptedForward is an HTLC forward held by the switch on behalf of a
+// ForwardInterceptor. Exactly one of Resume, Settle or Fail must be called for
+// each intercepted forward to release it.
+type InterceptedForward struct {
+	// Packet contains the details of the held forward.
+	Packet InterceptedPacket
+
+	packet *htlcPacket
+	sw     *Switch
+}
+
+// ForwardInterceptor is called by the switch for each HTLC which is about to
+// be forwarded. If it returns true, then the switch holds the HTLC until the
+// interceptor resolves it through the passed InterceptedForward. Otherwise,
+// the HTLC is forwarded as usual.
+//
+// NOTE: The interceptor is called by the main event loop of the switch, so it
+// MUST NOT block.
+type ForwardInterceptor func(*InterceptedForward) bool
+
+// SetInterceptor sets the interceptor called for each HTLC forward. Passing
+// nil removes the current interceptor, after which HTLCs are forwarded without
+// being intercepted. HTLCs held by a removed interceptor must still be
+// resolved by it.
+func (s *Switch) SetInterceptor(interceptor ForwardInterceptor) {
+	s.interceptorMtx.Lock()
+	s.interceptor = interceptor
+	s.interceptorMtx.Unlock()
+}
+
+// interceptForward hands the passed add packet to the current interceptor, if
+// any, and returns true if the interceptor holds it.
+func (s *Switch) interceptForward(packet *htlcPacket) bool {
+	s.interceptorMtx.RLock()
+	interceptor := s.interceptor
+	s.interceptorMtx.RUnlock()
+
+	if interceptor == nil {
+		return false
+	}
+
+	htlc := packet.htlc.(*lnwire.UpdateAddHTLC)
+	packet.intercepted = true
+
+	return interceptor(&InterceptedForward{
+		Packet: InterceptedPacket{
+			IncomingCircuit: packet.inKey(),
+			OutgoingChanID:  packet.outgoingChanID,
+			Hash:            htlc.PaymentHash,
+			IncomingAmount:  packet.incomingAmount,
+			OutgoingAmount:  htlc.Amount,
+			OutgoingExpiry:  htlc.Expiry,
+			OnionBlob:       htlc.OnionBlob,
+		},
+		packet: packet,
+		sw:     s,
+	})
+}
+
+// Resume forwards the held HTLC as if it hadn't been intercepted.
+func (f *InterceptedForward) Resume() error {
+	return f.sw.route(f.packet)
+}
+
+// Settle settles the held HTLC back to the incoming channel using the passed
+// preimage, without forwarding it.
+func (f *InterceptedForward) Settle(preimage [32]byte) error {
+	if sha256.Sum256(preimage[:]) != f.Packet.Hash {
+		return ErrInvalidInterceptedPreimage
+	}
+
+	return f.resolve(&lnwire.UpdateFulfillHTLC{
+		PaymentPreimage: preimage,
+	})
+}
+
+// Fail fails the held HTLC back to the incoming channel with a temporary
+// channel failure, without forwarding it.
+func (f *InterceptedForward) Fail() error {
+	// As we're the one failing the HTLC, we'll encrypt the failure for
+	// the sender's eyes only, just as the switch does when failing an HTLC
+	// which can't be forwarded.
+	reason, err := f.packet.obfuscator.EncryptFirstHop(
+		lnwire.NewTemporaryChannelFailure(nil),
+	)
+	if err != nil {
+		return errors.Errorf("unable to obfuscate error: %v", err)
+	}
+
+	return f.resolve(&lnwire.UpdateFailHTLC{
+		Reason: reason,
+	})
+}
+
+// resolve routes the passed settle or fail of the held HTLC back to the
+// incoming channel.
+func (f *InterceptedForward) resolve(htlc lnwire.Message) error {
+	log.Debugf("Resolving intercepted HTLC %v with %v",
+		f.Packet.IncomingCircuit, htlc.MsgType())
+
+	sourceMailbox := f.sw.getOrCreateMailBox(f.packet.incomingChanID)
+	return sourceMailbox.AddPacket(&htlcPacket{
+		incomingChanID: f.packet.incomingChanID,
+		incomingHTLCID: f.packet.incomingHTLCID,
+		circuit:        f.packet.circuit,
+		htlc:           htlc,
+	})
+}
@@ -15,8 +15,43 @@ type InvoiceDatabase interface {
 	LookupInvoice(chainhash.Hash) (channeldb.Invoice, error)
 
 	// SettleInvoice attempts to mark an invoice corresponding to the
-	// passed payment hash as fully settled.
-	SettleInvoice(chainhash.Hash) error
+	// passed payment hash as fully settled. The passed custom records,
+	// which were carried by the settling HTLC, are stored along side the
+	// invoice.
+	SettleInvoice(chainhash.Hash, map[uint64][]byte) error
+
+	// AddKeySendInvoice records a settled invoice for a spontaneous
+	// keysend payment of the passed amount, which carried the passed
+	// preimage and custom records.
+	AddKeySendInvoice([32]byte, lnwire.MilliSatoshi, map[uint64][]byte) error
+
+	// AcceptHoldInvoice marks the hold invoice with the passed payment
+	// hash as accepted, as an HTLC paying it is held until the invoice is
+	// settled or canceled. The passed custom records, which were carried
+	// by the HTLC, are stored along side the invoice. Once the invoice is
+	// resolved, a HodlEvent is sent over the passed channel.
+	AcceptHoldInvoice(chainhash.Hash, map[uint64][]byte,
+		chan<- HodlEvent) error
+
+	// CancelInvoice attempts to cancel the invoice corresponding to the
+	// passed payment hash, after which payments to it are rejected.
+	CancelInvoice(chainhash.Hash) error
+
+	// HodlUnsubscribeAll stops the delivery of HodlEvents over the passed
+	// channel, for all hold invoices it was subscribed to.
+	HodlUnsubscribeAll(chan<- HodlEvent)
+}
+
+// HodlEvent describes the resolution of a hold invoice, for which the HTLCs
+// paying it were held.
+type HodlEvent struct {
+	// PaymentHash is the payment hash of the resolved invoice.
+	PaymentHash chainhash.Hash
+
+	// Preimage is the preimage the invoice was settled with, or nil if the
+	// invoice was canceled, in which case the HTLCs paying it are to be
+	// failed.
+	Preimage *[32]byte
 }
 
 // ChannelLink is an interface which represents the subsystem for managing the
@@ -60,6 +95,10 @@ type ChannelLink interface {
 	// is a more compact representation of a channel's full outpoint.
 	ChanID() lnwire.ChannelID
 
+	// ChannelPoint returns the funding outpoint of the channel the link
+	// is operating on.
+	ChannelPoint() *wire.OutPoint
+
 	// ShortChanID returns the short channel ID for the channel link. The
 	// short channel ID encodes the exact location in the main chain that
 	// the original funding output can be found.
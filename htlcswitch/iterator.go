@@ -70,6 +70,11 @@ type ForwardingInfo struct {
 	// in the outgoing HTLC.
 	OutgoingCTLV uint32
 
+	// CustomRecords are the custom records included within the hop
+	// payload, keyed by their type. These are only meaningful to the exit
+	// hop, which stores them along side the invoice the HTLC settles.
+	CustomRecords map[uint64][]byte
+
 	// TODO(roasbeef): modify sphinx logic to not just discard the
 	// remaining bytes, instead should include the rest as excess
 }
@@ -151,6 +156,8 @@ func (r *sphinxHopIterator) ForwardingInstructions() ForwardingInfo {
 		nextHop = lnwire.NewShortChanIDFromInt(s)
 	}
 
+	// NOTE: The fixed size hop payload of the sphinx packet doesn't have
+	// room for any custom records, so none are returned.
 	return ForwardingInfo{
 		Network:         BitcoinHop,
 		NextHop:         nextHop,
@@ -17,6 +17,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
+	"github.com/roasbeef/btcd/wire"
 )
 
 const (
@@ -69,6 +70,10 @@ type ForwardingPolicy struct {
 	//    per-hop payload of the incoming HTLC's onion packet.
 	TimeLockDelta uint32
 
+	// MaxHTLC is the largest HTLC that is to be forwarded. If zero, then
+	// no maximum is enforced beyond the available bandwidth of the link.
+	MaxHTLC lnwire.MilliSatoshi
+
 	// TODO(roasbeef): add fee module inside of switch
 }
 
@@ -208,6 +213,12 @@ type ChannelLinkConfig struct {
 	// NOTE: HodlHTLC should be active in conjunction with DebugHTLC.
 	HodlHTLC bool
 
+	// AcceptKeySend should be active if you want this node to accept
+	// spontaneous keysend payments, which carry their preimage within the
+	// onion payload rather than paying an invoice. A settled invoice is
+	// recorded for each keysend payment accepted.
+	AcceptKeySend bool
+
 	// SyncStates is used to indicate that we need send the channel
 	// reestablishment message to the remote peer. It should be done if our
 	// clients have been restarted, or remote peer have been reconnected.
@@ -325,12 +336,39 @@ type channelLink struct {
 	logCommitTimer *time.Timer
 	logCommitTick  <-chan time.Time
 
+	// heldHTLCs are the HTLCs paying hold invoices, which are held until
+	// the invoices are either settled or canceled, keyed by their payment
+	// hash.
+	heldHTLCs map[chainhash.Hash][]*heldHTLC
+
+	// hodlQueue is the channel over which the invoice registry delivers
+	// the resolutions of the hold invoices the link holds HTLCs for.
+	hodlQueue chan HodlEvent
+
 	sync.RWMutex
 
 	wg   sync.WaitGroup
 	quit chan struct{}
 }
 
+// heldHTLC is an HTLC paying a hold invoice, which is held by the link until
+// the invoice is settled or canceled.
+type heldHTLC struct {
+	// htlcIndex is the index of the HTLC within the remote update log.
+	htlcIndex uint64
+
+	// sourceRef is the reference to the add within the forwarding package
+	// which locked it in, which is acked once the HTLC is resolved.
+	sourceRef *channeldb.AddRef
+
+	// obfuscator is used to encrypt the failure sent back to the payer,
+	// should the invoice be canceled.
+	obfuscator ErrorEncrypter
+
+	// expiry is the absolute height at which the HTLC times out.
+	expiry uint32
+}
+
 // NewChannelLink creates a new instance of a ChannelLink given a configuration
 // and active channel that will be used to verify/apply updates to.
 func NewChannelLink(cfg ChannelLinkConfig, channel *lnwallet.LightningChannel,
@@ -345,6 +383,8 @@ func NewChannelLink(cfg ChannelLinkConfig, channel *lnwallet.LightningChannel,
 		overflowQueue:  newPacketQueue(lnwallet.MaxHTLCNumber / 2),
 		bestHeight:     currentHeight,
 		htlcUpdates:    make(chan []channeldb.HTLC),
+		heldHTLCs:      make(map[chainhash.Hash][]*heldHTLC),
+		hodlQueue:      make(chan HodlEvent),
 		quit:           make(chan struct{}),
 	}
 }
@@ -719,6 +759,7 @@ func (l *channelLink) htlcManager() {
 	defer func() {
 		l.wg.Done()
 		l.cfg.BlockEpochs.Cancel()
+		l.cfg.Registry.HodlUnsubscribeAll(l.hodlQueue)
 		log.Infof("ChannelLink(%v) has exited", l)
 	}()
 
@@ -789,13 +830,15 @@ out:
 
 		// A new block has arrived, we'll check the network fee to see
 		// if we should adjust our commitment fee, and also update our
-		// track of the best current height.
+		// track of the best current height. As the height advances,
+		// the HTLCs we hold may be about to expire.
 		case blockEpoch, ok := <-l.cfg.BlockEpochs.Epochs:
 			if !ok {
 				break out
 			}
 
 			l.bestHeight = uint32(blockEpoch.Height)
+			l.cancelExpiringHeldHTLCs()
 
 			// If we're not the initiator of the channel, don't we
 			// don't control the fees, so we can ignore this.
@@ -917,12 +960,95 @@ out:
 		case msg := <-l.upstream:
 			l.handleUpstreamMsg(msg)
 
+		// A hold invoice we hold HTLCs for has been resolved, so we'll
+		// settle or fail the HTLCs accordingly.
+		case event := <-l.hodlQueue:
+			if err := l.processHodlEvent(event); err != nil {
+				l.fail("unable to process hodl event: %v", err)
+				break out
+			}
+
 		case <-l.quit:
 			break out
 		}
 	}
 }
 
+// processHodlEvent settles or fails the HTLCs held for the resolved hold
+// invoice, depending on whether it was settled or canceled, and commits the
+// resulting updates.
+func (l *channelLink) processHodlEvent(event HodlEvent) error {
+	htlcs, ok := l.heldHTLCs[event.PaymentHash]
+	if !ok {
+		return nil
+	}
+	delete(l.heldHTLCs, event.PaymentHash)
+
+	for _, htlc := range htlcs {
+		if event.Preimage == nil {
+			l.infof("failing held htlc(%x) of canceled invoice",
+				event.PaymentHash[:])
+
+			failure := lnwire.FailUnknownPaymentHash{}
+			l.sendHTLCError(
+				htlc.htlcIndex, failure, htlc.obfuscator,
+				htlc.sourceRef,
+			)
+			continue
+		}
+
+		preimage := *event.Preimage
+		err := l.channel.SettleHTLC(
+			preimage, htlc.htlcIndex, htlc.sourceRef, nil, nil,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to settle htlc: %v", err)
+		}
+
+		l.infof("settling held %x as exit hop", event.PaymentHash[:])
+
+		l.cfg.Peer.SendMessage(&lnwire.UpdateFulfillHTLC{
+			ChanID:          l.ChanID(),
+			ID:              htlc.htlcIndex,
+			PaymentPreimage: preimage,
+		}, false)
+	}
+
+	return l.updateCommitTx()
+}
+
+// cancelExpiringHeldHTLCs cancels the hold invoices for which HTLCs are held
+// that are about to expire. The HTLCs must be failed back before they time
+// out, as the remote party would otherwise be forced to go on-chain to reclaim
+// them. Canceling the invoices also ensures they can no longer be settled once
+// the HTLCs are gone. The HTLCs themselves are failed once the cancellations
+// are delivered over the hodl queue.
+func (l *channelLink) cancelExpiringHeldHTLCs() {
+	for payHash, htlcs := range l.heldHTLCs {
+		expiring := false
+		for _, htlc := range htlcs {
+			if htlc.expiry-expiryGraceDelta <= l.bestHeight {
+				expiring = true
+				break
+			}
+		}
+		if !expiring {
+			continue
+		}
+
+		l.warnf("canceling hold invoice %x, as its held htlc is "+
+			"about to expire at height=%v", payHash[:], l.bestHeight)
+
+		// If the invoice was settled in the meantime, then the HTLCs
+		// will be settled once its resolution is delivered instead.
+		err := l.cfg.Registry.CancelInvoice(payHash)
+		if err != nil && err != channeldb.ErrInvoiceAlreadySettled {
+			l.errorf("unable to cancel hold invoice %x: %v",
+				payHash[:], err)
+		}
+	}
+}
+
 // handleDownStreamPkt processes an HTLC packet sent from the downstream HTLC
 // Switch. Possible messages sent by the switch include requests to forward new
 // HTLCs, timeout previously cleared HTLCs, and finally to settle currently
@@ -1144,14 +1270,15 @@ func (l *channelLink) handleUpstreamMsg(msg lnwire.Message) {
 		// As we've learned of a new preimage for the first time, we'll
 		// add it to to our preimage cache. By doing this, we ensure
 		// any contested contracts watched by any on-chain arbitrators
-		// can now sweep this HTLC on-chain.
-		go func() {
-			err := l.cfg.PreimageCache.AddPreimage(pre[:])
-			if err != nil {
-				l.errorf("unable to add preimage=%x to "+
-					"cache", pre[:])
-			}
-		}()
+		// can now sweep this HTLC on-chain. We do so before processing
+		// any further messages, so the preimage is durably stored
+		// before the settle is forwarded to the incoming link, and
+		// remains available to claim the incoming HTLC even if we
+		// restart before it's settled.
+		if err := l.cfg.PreimageCache.AddPreimage(pre[:]); err != nil {
+			l.errorf("unable to add preimage=%x to cache: %v",
+				pre[:], err)
+		}
 
 	case *lnwire.UpdateFailMalformedHTLC:
 		// Convert the failure type encoded within the HTLC fail
@@ -1517,6 +1644,14 @@ func (l *channelLink) ChanID() lnwire.ChannelID {
 	return lnwire.NewChanIDFromOutPoint(l.channel.ChannelPoint())
 }
 
+// ChannelPoint returns the funding outpoint of the channel the link is
+// operating on.
+//
+// NOTE: Part of the ChannelLink interface.
+func (l *channelLink) ChannelPoint() *wire.OutPoint {
+	return l.channel.ChannelPoint()
+}
+
 // Bandwidth returns the total amount that can flow through the channel link at
 // this given instance. The value returned is expressed in millisatoshi and can
 // be used by callers when making forwarding decisions to determine if a link
@@ -1584,6 +1719,9 @@ func (l *channelLink) UpdateForwardingPolicy(newPolicy ForwardingPolicy) {
 	if newPolicy.MinHTLC != 0 {
 		l.cfg.FwrdingPolicy.MinHTLC = newPolicy.MinHTLC
 	}
+	if newPolicy.MaxHTLC != 0 {
+		l.cfg.FwrdingPolicy.MaxHTLC = newPolicy.MaxHTLC
+	}
 }
 
 // HtlcSatifiesPolicy should return a nil error if the passed HTLC details
@@ -1623,6 +1761,25 @@ func (l *channelLink) HtlcSatifiesPolicy(payHash [32]byte,
 		return failure
 	}
 
+	// Similarly, if a maximum HTLC value is set, we'll ensure that the
+	// passed HTLC isn't too large for the next hop.
+	maxHTLC := l.cfg.FwrdingPolicy.MaxHTLC
+	if maxHTLC != 0 && amtToForward > maxHTLC {
+		l.errorf("outgoing htlc(%x) is too large: max_htlc=%v, "+
+			"htlc_value=%v", payHash[:], maxHTLC, amtToForward)
+
+		// As part of the returned error, we'll send our latest routing
+		// policy so the sending node obtains the most up to date data.
+		update, err := l.cfg.FetchLastChannelUpdate(
+			l.shortChanID,
+		)
+		if err != nil {
+			return lnwire.NewTemporaryChannelFailure(nil)
+		}
+
+		return lnwire.NewTemporaryChannelFailure(update)
+	}
+
 	// Next, using the amount of the incoming HTLC, we'll calculate the
 	// expected fee this incoming HTLC must carry in order to satisfy the
 	// constraints of the outgoing link.
@@ -1947,6 +2104,24 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			// which'll allow us to settle this htlc.
 			invoiceHash := chainhash.Hash(pd.RHash)
 			invoice, err := l.cfg.Registry.LookupInvoice(invoiceHash)
+
+			// If there's no invoice, then this may be a keysend
+			// payment carrying its own preimage, in which case
+			// we'll record a synthetic invoice for it to settle.
+			keySendPreimage, isKeySend := extractKeySendPreimage(
+				pd.RHash, fwdInfo.CustomRecords,
+			)
+			if err != nil && l.cfg.AcceptKeySend && isKeySend {
+				err = l.cfg.Registry.AddKeySendInvoice(
+					keySendPreimage, pd.Amount,
+					fwdInfo.CustomRecords,
+				)
+				if err == nil {
+					invoice, err = l.cfg.Registry.LookupInvoice(
+						invoiceHash,
+					)
+				}
+			}
 			if err != nil {
 				log.Errorf("unable to query invoice registry: "+
 					" %v", err)
@@ -1981,6 +2156,23 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 					"hash=%x", pd.RHash[:])
 			}
 
+			// If the invoice has been canceled, either explicitly
+			// or because it expired, then we'll reject the
+			// payment as if the invoice were unknown.
+			if invoice.Terms.Canceled {
+				log.Errorf("rejecting htlc for canceled "+
+					"invoice: hash=%x", pd.RHash[:])
+
+				failure := lnwire.FailUnknownPaymentHash{}
+				l.sendHTLCError(
+					pd.HtlcIndex, failure, obfuscator,
+					pd.SourceRef,
+				)
+
+				needUpdate = true
+				continue
+			}
+
 			// If we're not currently in debug mode, and the
 			// extended htlc doesn't meet the value requested, then
 			// we'll fail the htlc.  Otherwise, we settle this htlc
@@ -2076,6 +2268,42 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 				continue
 			}
 
+			// If this is a hold invoice whose preimage isn't known
+			// yet, then we'll hold the HTLC until the invoice is
+			// either settled or canceled. As the HTLC isn't acked
+			// until then, it's held again should the link restart.
+			if invoice.Terms.Hold && !invoice.Terms.Settled {
+				err := l.cfg.Registry.AcceptHoldInvoice(
+					invoiceHash, fwdInfo.CustomRecords,
+					l.hodlQueue,
+				)
+				if err != nil {
+					log.Errorf("unable to accept hold "+
+						"invoice: %v", err)
+
+					l.sendHTLCError(
+						pd.HtlcIndex,
+						lnwire.FailUnknownPaymentHash{},
+						obfuscator, pd.SourceRef,
+					)
+
+					needUpdate = true
+					continue
+				}
+
+				l.heldHTLCs[invoiceHash] = append(
+					l.heldHTLCs[invoiceHash], &heldHTLC{
+						htlcIndex:  pd.HtlcIndex,
+						sourceRef:  pd.SourceRef,
+						obfuscator: obfuscator,
+						expiry:     pd.Timeout,
+					},
+				)
+
+				l.infof("holding %x as exit hop", pd.RHash)
+				continue
+			}
+
 			preimage := invoice.Terms.PaymentPreimage
 			err = l.channel.SettleHTLC(preimage,
 				pd.HtlcIndex, pd.SourceRef, nil, nil)
@@ -2085,11 +2313,17 @@ func (l *channelLink) processRemoteAdds(fwdPkg *channeldb.FwdPkg,
 			}
 
 			// Notify the invoiceRegistry of the invoices we just
-			// settled with this latest commitment update.
-			err = l.cfg.Registry.SettleInvoice(invoiceHash)
-			if err != nil {
-				l.fail("unable to settle invoice: %v", err)
-				return false
+			// settled with this latest commitment update. A hold
+			// invoice was already settled along with its preimage.
+			if !invoice.Terms.Hold {
+				err = l.cfg.Registry.SettleInvoice(
+					invoiceHash, fwdInfo.CustomRecords,
+				)
+				if err != nil {
+					l.fail("unable to settle invoice: %v",
+						err)
+					return false
+				}
 			}
 
 			l.infof("settling %x as exit hop", pd.RHash)
@@ -2421,6 +2655,27 @@ func (l *channelLink) tracef(format string, a ...interface{}) {
 	log.Tracef("ChannelLink(%s) %s", l.ShortChanID(), msg)
 }
 
+// extractKeySendPreimage returns the preimage carried within the keysend
+// record of the passed custom records. The boolean is false if there's no
+// such record, or its preimage doesn't match the passed payment hash.
+func extractKeySendPreimage(rHash lnwallet.PaymentHash,
+	customRecords map[uint64][]byte) ([32]byte, bool) {
+
+	var preimage [32]byte
+
+	record, ok := customRecords[channeldb.KeySendRecordType]
+	if !ok || len(record) != len(preimage) {
+		return preimage, false
+	}
+	copy(preimage[:], record)
+
+	if lnwallet.PaymentHash(sha256.Sum256(preimage[:])) != rHash {
+		return preimage, false
+	}
+
+	return preimage, true
+}
+
 // isASCII is a helper method that checks whether all bytes in `data` would be
 // printable ASCII characters if interpreted as a string.
 func isASCII(data []byte) bool {
@@ -3,6 +3,7 @@ package htlcswitch
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -747,6 +748,66 @@ func TestLinkForwardMinHTLCPolicyMismatch(t *testing.T) {
 	}
 }
 
+// TestLinkForwardMaxHTLCPolicyMismatch tests that if a node is an
+// intermediate node and receives an HTLC which is _above_ its max HTLC
+// policy, then the HTLC will be rejected.
+func TestLinkForwardMaxHTLCPolicyMismatch(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*5,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.stop()
+
+	// We'll restrict the size of the HTLCs Bob is willing to forward to
+	// Carol to 100k SAT, and then attempt to send a payment just above
+	// that limit.
+	newPolicy := n.globalPolicy
+	newPolicy.MaxHTLC = lnwire.NewMSatFromSatoshis(100000)
+	n.secondBobChannelLink.UpdateForwardingPolicy(newPolicy)
+
+	amountNoFee := lnwire.NewMSatFromSatoshis(100001)
+
+	// With the amount set, we'll generate a route over 2 hops within the
+	// network that attempts to pay out our specified amount.
+	htlcAmt, htlcExpiry, hops := generateHops(amountNoFee, testStartingHeight,
+		n.firstBobChannelLink, n.carolChannelLink)
+
+	// Next, we'll make the payment which'll send an HTLC with our
+	// specified parameters to the first hop in the route.
+	_, err = n.makePayment(n.aliceServer, n.carolServer,
+		n.bobServer.PubKey(), hops, amountNoFee, htlcAmt,
+		htlcExpiry).Wait(30 * time.Second)
+
+	// We should get an error, and that error should indicate that the HTLC
+	// should be rejected due to a policy violation (above max HTLC).
+	if err == nil {
+		t.Fatalf("payment should have failed but didn't")
+	}
+
+	ferr, ok := err.(*ForwardingError)
+	if !ok {
+		t.Fatalf("expected a ForwardingError, instead got: %T", err)
+	}
+
+	switch ferr.FailureMessage.(type) {
+	case *lnwire.FailTemporaryChannelFailure:
+	default:
+		t.Fatalf("incorrect error, expected temporary channel "+
+			"failure, instead have: %v", err)
+	}
+}
+
 // TestUpdateForwardingPolicy tests that the forwarding policy for a link is
 // able to be updated properly. We'll first create an HTLC that meets the
 // specified policy, assert that it succeeds, update the policy (to invalidate
@@ -1458,7 +1519,11 @@ func newSingleLinkTestHarness(chanAmt, chanReserve btcutil.Amount) (
 
 	aliceDb := aliceChannel.State().Db
 
-	aliceSwitch, err := New(Config{DB: aliceDb})
+	aliceSwitch, err := New(Config{
+		DB:                    aliceDb,
+		NotifyActiveChannel:   func(wire.OutPoint) {},
+		NotifyInactiveChannel: func(wire.OutPoint) {},
+	})
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -3110,3 +3175,188 @@ func TestChannelLinkAcceptOverpay(t *testing.T) {
 			expectedCarolBandwidth, n.carolChannelLink.Bandwidth())
 	}
 }
+
+// TestChannelLinkHoldInvoice tests that an HTLC paying a hold invoice is held
+// by the exit hop until the invoice is either settled, in which case the HTLC
+// is settled with the supplied preimage, or canceled, in which case the HTLC
+// is failed back.
+func TestChannelLinkHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	channels, cleanUp, _, err := createClusterChannels(
+		btcutil.SatoshiPerBitcoin*5,
+		btcutil.SatoshiPerBitcoin*5)
+	if err != nil {
+		t.Fatalf("unable to create channel: %v", err)
+	}
+	defer cleanUp()
+
+	n := newThreeHopNetwork(t, channels.aliceToBob, channels.bobToAlice,
+		channels.bobToCarol, channels.carolToBob, testStartingHeight)
+	if err := n.start(); err != nil {
+		t.Fatalf("unable to start three hop network: %v", err)
+	}
+	defer n.stop()
+
+	amount := lnwire.NewMSatFromSatoshis(btcutil.SatoshiPerBitcoin)
+	registry := n.carolServer.registry
+
+	// sendHoldPayment sends a payment from Alice to Carol, paying a hold
+	// invoice at Carol. Once Carol accepted the HTLC, the preimage of the
+	// invoice is returned along with the channel the result of the
+	// payment is sent over.
+	sendHoldPayment := func() ([32]byte, chan error) {
+		htlcAmt, totalTimelock, hops := generateHops(
+			amount, testStartingHeight,
+			n.firstBobChannelLink, n.carolChannelLink,
+		)
+		blob, err := generateRoute(hops...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		invoice, htlc, err := generatePayment(
+			amount, htlcAmt, totalTimelock, blob,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Carol only knows the payment hash of the invoice.
+		preimage := invoice.Terms.PaymentPreimage
+		invoice.Terms.PaymentPreimage = [32]byte{}
+		invoice.Terms.Hold = true
+		invoice.Terms.PaymentHash = htlc.PaymentHash
+		if err := registry.AddInvoice(*invoice); err != nil {
+			t.Fatalf("unable to add invoice in carol registry: %v",
+				err)
+		}
+
+		paymentErr := make(chan error, 1)
+		go func() {
+			_, err := n.aliceServer.htlcSwitch.SendHTLC(
+				n.bobServer.PubKey(), htlc,
+				newMockDeobfuscator(),
+			)
+			paymentErr <- err
+		}()
+
+		// Wait for Carol to accept the HTLC paying the invoice.
+		rhash := chainhash.Hash(htlc.PaymentHash)
+		timeout := time.After(5 * time.Second)
+		for {
+			invoice, err := registry.LookupInvoice(rhash)
+			if err != nil {
+				t.Fatalf("unable to get invoice: %v", err)
+			}
+			if invoice.Terms.Accepted {
+				break
+			}
+
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-timeout:
+				t.Fatalf("carol didn't accept the htlc")
+			}
+		}
+
+		// As the invoice hasn't been resolved yet, the payment
+		// should still be in flight.
+		select {
+		case err := <-paymentErr:
+			t.Fatalf("payment completed before invoice was "+
+				"resolved: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		return preimage, paymentErr
+	}
+
+	// Once the invoice is settled, the payment should succeed.
+	preimage, paymentErr := sendHoldPayment()
+	if err := registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	select {
+	case err := <-paymentErr:
+		if err != nil {
+			t.Fatalf("unable to send payment: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("payment wasn't settled")
+	}
+
+	// Once the invoice is canceled instead, the payment should fail as if
+	// the invoice were unknown.
+	preimage, paymentErr = sendHoldPayment()
+	rhash := chainhash.Hash(sha256.Sum256(preimage[:]))
+	if err := registry.CancelInvoice(rhash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	select {
+	case err := <-paymentErr:
+		if err == nil ||
+			err.Error() != lnwire.CodeUnknownPaymentHash.String() {
+
+			t.Fatalf("expected payment to fail with unknown "+
+				"payment hash, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("payment wasn't failed")
+	}
+}
+
+// TestExtractKeySendPreimage tests that the preimage of a keysend payment is
+// only extracted if it matches the payment hash of the HTLC.
+func TestExtractKeySendPreimage(t *testing.T) {
+	t.Parallel()
+
+	var preimage [32]byte
+	if _, err := rand.Read(preimage[:]); err != nil {
+		t.Fatalf("unable to generate preimage: %v", err)
+	}
+	rHash := lnwallet.PaymentHash(sha256.Sum256(preimage[:]))
+
+	tests := []struct {
+		name          string
+		customRecords map[uint64][]byte
+		isKeySend     bool
+	}{
+		{
+			name:          "no keysend record",
+			customRecords: map[uint64][]byte{65536: preimage[:]},
+		},
+		{
+			name: "short preimage",
+			customRecords: map[uint64][]byte{
+				channeldb.KeySendRecordType: preimage[:31],
+			},
+		},
+		{
+			name: "mismatched preimage",
+			customRecords: map[uint64][]byte{
+				channeldb.KeySendRecordType: rHash[:],
+			},
+		},
+		{
+			name: "valid keysend",
+			customRecords: map[uint64][]byte{
+				channeldb.KeySendRecordType: preimage[:],
+			},
+			isKeySend: true,
+		},
+	}
+
+	for _, test := range tests {
+		extracted, isKeySend := extractKeySendPreimage(
+			rHash, test.customRecords,
+		)
+		if isKeySend != test.isKeySend {
+			t.Fatalf("%v: expected keysend=%v, got %v", test.name,
+				test.isKeySend, isKeySend)
+		}
+		if isKeySend && extracted != preimage {
+			t.Fatalf("%v: expected preimage %x, got %x", test.name,
+				preimage, extracted)
+		}
+	}
+}
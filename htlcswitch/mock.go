@@ -140,6 +140,8 @@ func initSwitchWithDB(db *channeldb.DB) (*Switch, error) {
 		FwdingLog: &mockForwardingLog{
 			events: make(map[time.Time]channeldb.ForwardingEvent),
 		},
+		NotifyActiveChannel:   func(wire.OutPoint) {},
+		NotifyInactiveChannel: func(wire.OutPoint) {},
 	})
 }
 
@@ -625,6 +627,7 @@ func (f *mockChannelLink) Start() error {
 }
 
 func (f *mockChannelLink) ChanID() lnwire.ChannelID                    { return f.chanID }
+func (f *mockChannelLink) ChannelPoint() *wire.OutPoint                { return &wire.OutPoint{} }
 func (f *mockChannelLink) ShortChanID() lnwire.ShortChannelID          { return f.shortChanID }
 func (f *mockChannelLink) UpdateShortChanID(sid lnwire.ShortChannelID) { f.shortChanID = sid }
 func (f *mockChannelLink) Bandwidth() lnwire.MilliSatoshi              { return 99999999 }
@@ -636,12 +639,14 @@ var _ ChannelLink = (*mockChannelLink)(nil)
 
 type mockInvoiceRegistry struct {
 	sync.Mutex
-	invoices map[chainhash.Hash]channeldb.Invoice
+	invoices        map[chainhash.Hash]channeldb.Invoice
+	hodlSubscribers map[chainhash.Hash][]chan<- HodlEvent
 }
 
 func newMockRegistry() *mockInvoiceRegistry {
 	return &mockInvoiceRegistry{
-		invoices: make(map[chainhash.Hash]channeldb.Invoice),
+		invoices:        make(map[chainhash.Hash]channeldb.Invoice),
+		hodlSubscribers: make(map[chainhash.Hash][]chan<- HodlEvent),
 	}
 }
 
@@ -657,7 +662,9 @@ func (i *mockInvoiceRegistry) LookupInvoice(rHash chainhash.Hash) (channeldb.Inv
 	return invoice, nil
 }
 
-func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash) error {
+func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash,
+	customRecords map[uint64][]byte) error {
+
 	i.Lock()
 	defer i.Unlock()
 
@@ -671,16 +678,141 @@ func (i *mockInvoiceRegistry) SettleInvoice(rhash chainhash.Hash) error {
 	}
 
 	invoice.Terms.Settled = true
+	invoice.CustomRecords = customRecords
 	i.invoices[rhash] = invoice
 
 	return nil
 }
 
+func (i *mockInvoiceRegistry) AddKeySendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi, customRecords map[uint64][]byte) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	if _, ok := i.invoices[rhash]; ok {
+		return channeldb.ErrDuplicateInvoice
+	}
+
+	i.invoices[rhash] = channeldb.Invoice{
+		CustomRecords: customRecords,
+		KeySend:       true,
+		Terms: channeldb.ContractTerm{
+			PaymentPreimage: preimage,
+			Value:           amt,
+			Settled:         true,
+		},
+	}
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) AcceptHoldInvoice(rhash chainhash.Hash,
+	customRecords map[uint64][]byte, events chan<- HodlEvent) error {
+
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+
+	invoice.Terms.Accepted = true
+	invoice.CustomRecords = customRecords
+	i.invoices[rhash] = invoice
+
+	i.hodlSubscribers[rhash] = append(i.hodlSubscribers[rhash], events)
+
+	return nil
+}
+
+func (i *mockInvoiceRegistry) CancelInvoice(rhash chainhash.Hash) error {
+	i.Lock()
+	defer i.Unlock()
+
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+	if invoice.Terms.Settled {
+		return channeldb.ErrInvoiceAlreadySettled
+	}
+
+	invoice.Terms.Canceled = true
+	i.invoices[rhash] = invoice
+
+	i.resolveHoldInvoice(rhash, nil)
+
+	return nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice paid to the hash of the
+// passed preimage, and delivers the preimage to the links holding HTLCs for
+// it.
+func (i *mockInvoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	i.Lock()
+	defer i.Unlock()
+
+	rhash := chainhash.Hash(fastsha256.Sum256(preimage[:]))
+	invoice, ok := i.invoices[rhash]
+	if !ok {
+		return fmt.Errorf("can't find mock invoice: %x", rhash[:])
+	}
+	if !invoice.Terms.Accepted {
+		return channeldb.ErrInvoiceNotAccepted
+	}
+
+	invoice.Terms.PaymentPreimage = preimage
+	invoice.Terms.Settled = true
+	i.invoices[rhash] = invoice
+
+	i.resolveHoldInvoice(rhash, &preimage)
+
+	return nil
+}
+
+// resolveHoldInvoice delivers the resolution of the hold invoice with the
+// passed payment hash to all of its subscribers.
+//
+// NOTE: This method MUST be called with the registry's mutex held.
+func (i *mockInvoiceRegistry) resolveHoldInvoice(rhash chainhash.Hash,
+	preimage *[32]byte) {
+
+	for _, events := range i.hodlSubscribers[rhash] {
+		go func(events chan<- HodlEvent) {
+			events <- HodlEvent{
+				PaymentHash: rhash,
+				Preimage:    preimage,
+			}
+		}(events)
+	}
+	delete(i.hodlSubscribers, rhash)
+}
+
+func (i *mockInvoiceRegistry) HodlUnsubscribeAll(events chan<- HodlEvent) {
+	i.Lock()
+	defer i.Unlock()
+
+	for rhash, subscribers := range i.hodlSubscribers {
+		for k, subscriber := range subscribers {
+			if subscriber == events {
+				subscribers = append(
+					subscribers[:k], subscribers[k+1:]...,
+				)
+				break
+			}
+		}
+		i.hodlSubscribers[rhash] = subscribers
+	}
+}
+
 func (i *mockInvoiceRegistry) AddInvoice(invoice channeldb.Invoice) error {
 	i.Lock()
 	defer i.Unlock()
 
-	rhash := fastsha256.Sum256(invoice.Terms.PaymentPreimage[:])
+	rhash := invoice.PaymentHash()
 	i.invoices[chainhash.Hash(rhash)] = invoice
 
 	return nil
@@ -77,6 +77,11 @@ type htlcPacket struct {
 	// hop.
 	isResolution bool
 
+	// intercepted is set once the packet has been handed to the forward
+	// interceptor of the switch, so it isn't intercepted again once
+	// resumed.
+	intercepted bool
+
 	// circuit holds a reference to an Add's circuit which is persisted in
 	// the switch during successful forwarding.
 	circuit *PaymentCircuit
@@ -135,6 +135,15 @@ type Config struct {
 	// error encrypters stored in the circuit map on restarts, since they
 	// are not stored directly within the database.
 	ExtractErrorEncrypter ErrorEncrypterExtracter
+
+	// NotifyActiveChannel is called with the funding outpoint of a channel
+	// once its link was added to the switch, making the channel usable
+	// for payments.
+	NotifyActiveChannel func(wire.OutPoint)
+
+	// NotifyInactiveChannel is called with the funding outpoint of a
+	// channel once its link was removed from the switch.
+	NotifyInactiveChannel func(wire.OutPoint)
 }
 
 // Switch is the central messaging bus for all incoming/outgoing HTLCs.
@@ -219,6 +228,12 @@ type Switch struct {
 	// to the forwarding log.
 	fwdEventMtx         sync.Mutex
 	pendingFwdingEvents []channeldb.ForwardingEvent
+
+	// interceptor, if set, is called for each HTLC which is about to be
+	// forwarded, and may hold it in order to settle, fail or resume it
+	// later on.
+	interceptorMtx sync.RWMutex
+	interceptor    ForwardInterceptor
 }
 
 // New creates the new instance of htlc switch.
@@ -871,6 +886,13 @@ func (s *Switch) handlePacketForward(packet *htlcPacket) error {
 			return s.handleLocalDispatch(packet)
 		}
 
+		// If an interceptor is set, then it may hold the HTLC instead
+		// of it being forwarded. Once resumed, the HTLC re-enters the
+		// switch, at which point it's forwarded as usual.
+		if !packet.intercepted && s.interceptForward(packet) {
+			return nil
+		}
+
 		s.indexMtx.RLock()
 		targetLink, err := s.getLinkByShortID(packet.outgoingChanID)
 		if err != nil {
@@ -1706,6 +1728,8 @@ func (s *Switch) AddLink(link ChannelLink) error {
 	log.Infof("Added channel link with chan_id=%v, short_chan_id=(%v)",
 		link.ChanID(), spew.Sdump(link.ShortChanID()))
 
+	s.cfg.NotifyActiveChannel(*link.ChannelPoint())
+
 	return nil
 }
 
@@ -1792,6 +1816,8 @@ func (s *Switch) removeLink(chanID lnwire.ChannelID) error {
 
 	link.Stop()
 
+	s.cfg.NotifyInactiveChannel(*link.ChannelPoint())
+
 	return nil
 }
 
@@ -1778,3 +1778,155 @@ func TestMultiHopPaymentForwardingEvents(t *testing.T) {
 		}
 	}
 }
+
+// TestSwitchForwardInterceptor checks that HTLCs held by a forward interceptor
+// aren't forwarded until resumed, and that they may be settled or failed back
+// to the incoming link instead.
+func TestSwitchForwardInterceptor(t *testing.T) {
+	t.Parallel()
+
+	alicePeer, err := newMockServer(t, "alice", nil)
+	if err != nil {
+		t.Fatalf("unable to create alice server: %v", err)
+	}
+	bobPeer, err := newMockServer(t, "bob", nil)
+	if err != nil {
+		t.Fatalf("unable to create bob server: %v", err)
+	}
+
+	s, err := initSwitchWithDB(nil)
+	if err != nil {
+		t.Fatalf("unable to init switch: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("unable to start switch: %v", err)
+	}
+	defer s.Stop()
+
+	chanID1, chanID2, aliceChanID, bobChanID := genIDs()
+
+	aliceChannelLink := newMockChannelLink(
+		s, chanID1, aliceChanID, alicePeer, true,
+	)
+	bobChannelLink := newMockChannelLink(
+		s, chanID2, bobChanID, bobPeer, true,
+	)
+	if err := s.AddLink(aliceChannelLink); err != nil {
+		t.Fatalf("unable to add alice link: %v", err)
+	}
+	if err := s.AddLink(bobChannelLink); err != nil {
+		t.Fatalf("unable to add bob link: %v", err)
+	}
+
+	forwards := make(chan *InterceptedForward, 1)
+	s.SetInterceptor(func(fwd *InterceptedForward) bool {
+		forwards <- fwd
+		return true
+	})
+
+	// forwardHTLC forwards a new HTLC from Alice's link to Bob's, and
+	// returns its preimage along with the intercepted forward.
+	forwardHTLC := func(htlcID uint64) ([32]byte, *InterceptedForward) {
+		preimage, err := genPreimage()
+		if err != nil {
+			t.Fatalf("unable to generate preimage: %v", err)
+		}
+		packet := &htlcPacket{
+			incomingChanID: aliceChannelLink.ShortChanID(),
+			incomingHTLCID: htlcID,
+			outgoingChanID: bobChannelLink.ShortChanID(),
+			obfuscator:     NewMockObfuscator(),
+			htlc: &lnwire.UpdateAddHTLC{
+				PaymentHash: fastsha256.Sum256(preimage[:]),
+				Amount:      1,
+			},
+		}
+		if err := s.forward(packet); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case fwd := <-forwards:
+			if fwd.Packet.IncomingCircuit != packet.inKey() {
+				t.Fatalf("expected circuit %v, got %v",
+					packet.inKey(), fwd.Packet.IncomingCircuit)
+			}
+			return preimage, fwd
+
+		case <-time.After(time.Second):
+			t.Fatal("htlc was not intercepted")
+		}
+
+		return preimage, nil
+	}
+
+	// A held HTLC shouldn't be forwarded to Bob until it's resumed.
+	_, fwd := forwardHTLC(0)
+	select {
+	case <-bobChannelLink.packets:
+		t.Fatal("held htlc was forwarded")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := fwd.Resume(); err != nil {
+		t.Fatalf("unable to resume htlc: %v", err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("resumed htlc was not forwarded")
+	}
+
+	// A held HTLC may only be settled with its preimage, in which case
+	// the settle is sent back to Alice.
+	preimage, fwd := forwardHTLC(1)
+	if err := fwd.Settle([32]byte{}); err != ErrInvalidInterceptedPreimage {
+		t.Fatalf("expected ErrInvalidInterceptedPreimage, got %v", err)
+	}
+	if err := fwd.Settle(preimage); err != nil {
+		t.Fatalf("unable to settle htlc: %v", err)
+	}
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if _, ok := pkt.htlc.(*lnwire.UpdateFulfillHTLC); !ok {
+			t.Fatalf("expected settle, got %T", pkt.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("settle was not sent back to alice")
+	}
+
+	// A failed HTLC should be failed back to Alice.
+	_, fwd = forwardHTLC(2)
+	if err := fwd.Fail(); err != nil {
+		t.Fatalf("unable to fail htlc: %v", err)
+	}
+	select {
+	case pkt := <-aliceChannelLink.packets:
+		if _, ok := pkt.htlc.(*lnwire.UpdateFailHTLC); !ok {
+			t.Fatalf("expected fail, got %T", pkt.htlc)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("fail was not sent back to alice")
+	}
+
+	// Once the interceptor is removed, HTLCs should be forwarded right
+	// away.
+	s.SetInterceptor(nil)
+	packet := &htlcPacket{
+		incomingChanID: aliceChannelLink.ShortChanID(),
+		incomingHTLCID: 3,
+		outgoingChanID: bobChannelLink.ShortChanID(),
+		obfuscator:     NewMockObfuscator(),
+		htlc: &lnwire.UpdateAddHTLC{
+			Amount: 1,
+		},
+	}
+	if err := s.forward(packet); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-bobChannelLink.packets:
+	case <-time.After(time.Second):
+		t.Fatal("htlc was not forwarded")
+	}
+}
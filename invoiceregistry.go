@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnwire"
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcutil"
@@ -24,14 +26,44 @@ var (
 	debugHash = chainhash.Hash(sha256.Sum256(debugPre[:]))
 )
 
+// invoiceEvent denotes the kind of change to an invoice which registered
+// notification clients are notified of.
+type invoiceEvent uint8
+
+const (
+	// invoiceAdded denotes that a new invoice was added.
+	invoiceAdded invoiceEvent = iota
+
+	// invoiceSettled denotes that an invoice was settled.
+	invoiceSettled
+
+	// invoiceCanceled denotes that an invoice was canceled.
+	invoiceCanceled
+
+	// invoiceAccepted denotes that an HTLC paying a hold invoice was
+	// accepted, and is held until the invoice is settled or canceled.
+	invoiceAccepted
+)
+
 // invoiceRegistry is a central registry of all the outstanding invoices
 // created by the daemon. The registry is a thin wrapper around a map in order
-// to ensure that all updates/reads are thread safe.
+// to ensure that all updates/reads are thread safe. Once started, the registry
+// also cancels invoices as they expire.
 type invoiceRegistry struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
 	sync.RWMutex
 
 	cdb *channeldb.DB
 
+	// updateMtx serializes the updates of invoices which are assigned an
+	// add or settle index, along with their notification, with the
+	// registration of new notification clients. This ensures clients
+	// replaying the invoices they missed receive each invoice exactly
+	// once, in the order of its index.
+	updateMtx sync.Mutex
+
 	clientMtx           sync.Mutex
 	nextClientID        uint32
 	notificationClients map[uint32]*invoiceSubscription
@@ -40,6 +72,19 @@ type invoiceRegistry struct {
 	// should be only created/used when manual tests require an invoice
 	// that *all* nodes are able to fully settle.
 	debugInvoices map[chainhash.Hash]*channeldb.Invoice
+
+	// expiryUpdates is signaled whenever an invoice which expires is
+	// added, so the expiry watcher can reschedule its next cancellation.
+	expiryUpdates chan struct{}
+
+	// hodlSubscribers are the links holding HTLCs which pay hold
+	// invoices, keyed by the channel over which they're notified of the
+	// resolutions of the invoices.
+	hodlMtx         sync.Mutex
+	hodlSubscribers map[chan<- htlcswitch.HodlEvent]*hodlSubscriber
+
+	quit chan struct{}
+	wg   sync.WaitGroup
 }
 
 // newInvoiceRegistry creates a new invoice registry. The invoice registry
@@ -51,7 +96,109 @@ func newInvoiceRegistry(cdb *channeldb.DB) *invoiceRegistry {
 		cdb:                 cdb,
 		debugInvoices:       make(map[chainhash.Hash]*channeldb.Invoice),
 		notificationClients: make(map[uint32]*invoiceSubscription),
+		expiryUpdates:       make(chan struct{}, 1),
+		hodlSubscribers:     make(map[chan<- htlcswitch.HodlEvent]*hodlSubscriber),
+		quit:                make(chan struct{}),
+	}
+}
+
+// Start cancels all invoices which expired while we were offline, and
+// launches the goroutine responsible for canceling invoices as they expire.
+func (i *invoiceRegistry) Start() error {
+	if !atomic.CompareAndSwapUint32(&i.started, 0, 1) {
+		return nil
 	}
+
+	ltndLog.Tracef("Starting invoice registry")
+
+	if err := i.cancelExpiredInvoices(); err != nil {
+		return err
+	}
+
+	i.wg.Add(1)
+	go i.expiryWatcher()
+
+	return nil
+}
+
+// Stop signals the expiry watcher to exit, and waits for it to do so.
+func (i *invoiceRegistry) Stop() error {
+	if !atomic.CompareAndSwapUint32(&i.stopped, 0, 1) {
+		return nil
+	}
+
+	ltndLog.Infof("Invoice registry shutting down")
+
+	close(i.quit)
+	i.wg.Wait()
+
+	return nil
+}
+
+// expiryWatcher cancels invoices as they expire. It sleeps until the earliest
+// expiry of any open invoice, and is woken early whenever an invoice which
+// may expire sooner is added.
+//
+// NOTE: This MUST be run as a goroutine.
+func (i *invoiceRegistry) expiryWatcher() {
+	defer i.wg.Done()
+
+	for {
+		// If there's no invoice to expire, or we're unable to
+		// determine when the next one does, then we'll wait for a new
+		// invoice to be added before checking again.
+		var (
+			timer       *time.Timer
+			expiryTimer <-chan time.Time
+		)
+		nextExpiry, err := i.cdb.NextInvoiceExpiry()
+		switch {
+		case err != nil:
+			ltndLog.Errorf("Unable to fetch next invoice expiry: "+
+				"%v", err)
+
+		case !nextExpiry.IsZero():
+			timer = time.NewTimer(time.Until(nextExpiry))
+			expiryTimer = timer.C
+		}
+
+		select {
+		case <-expiryTimer:
+			if err := i.cancelExpiredInvoices(); err != nil {
+				ltndLog.Errorf("Unable to cancel expired "+
+					"invoices: %v", err)
+			}
+
+		case <-i.expiryUpdates:
+			if timer != nil {
+				timer.Stop()
+			}
+
+		case <-i.quit:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
+
+// cancelExpiredInvoices cancels all invoices which have expired by now, and
+// notifies all registered notification clients of their cancellation.
+func (i *invoiceRegistry) cancelExpiredInvoices() error {
+	canceled, err := i.cdb.CancelExpiredInvoices(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, invoice := range canceled {
+		paymentHash := invoice.PaymentHash()
+		ltndLog.Infof("Canceled expired invoice %x", paymentHash[:])
+
+		i.notifyClients(invoice, invoiceCanceled)
+	}
+
+	return nil
 }
 
 // addDebugInvoice adds a debug invoice for the specified amount, identified
@@ -89,10 +236,24 @@ func (i *invoiceRegistry) AddInvoice(invoice *channeldb.Invoice) error {
 	}))
 
 	// TODO(roasbeef): also check in memory for quick lookups/settles?
-	return i.cdb.AddInvoice(invoice)
+	i.updateMtx.Lock()
+	if err := i.cdb.AddInvoice(invoice); err != nil {
+		i.updateMtx.Unlock()
+		return err
+	}
+	i.notifyClients(invoice, invoiceAdded)
+	i.updateMtx.Unlock()
+
+	// If the invoice expires, the expiry watcher may need to wake up
+	// sooner than it planned to.
+	if invoice.Expiry != 0 {
+		select {
+		case i.expiryUpdates <- struct{}{}:
+		default:
+		}
+	}
 
-	// TODO(roasbeef): re-enable?
-	//go i.notifyClients(invoice, false)
+	return nil
 }
 
 // lookupInvoice looks up an invoice by its payment hash (R-Hash), if found
@@ -120,10 +281,13 @@ func (i *invoiceRegistry) LookupInvoice(rHash chainhash.Hash) (channeldb.Invoice
 	return *invoice, nil
 }
 
-// SettleInvoice attempts to mark an invoice as settled. If the invoice is a
+// SettleInvoice attempts to mark an invoice as settled, storing the custom
+// records carried by the settling HTLC along side it. If the invoice is a
 // debug invoice, then this method is a noop as debug invoices are never fully
 // settled.
-func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
+func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash,
+	customRecords map[uint64][]byte) error {
+
 	ltndLog.Debugf("Settling invoice %x", rHash[:])
 
 	// First check the in-memory debug invoice index to see if this is an
@@ -140,76 +304,393 @@ func (i *invoiceRegistry) SettleInvoice(rHash chainhash.Hash) error {
 
 	// If this isn't a debug invoice, then we'll attempt to settle an
 	// invoice matching this rHash on disk (if one exists).
-	if err := i.cdb.SettleInvoice(rHash); err != nil {
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	if err := i.cdb.SettleInvoice(rHash, customRecords); err != nil {
 		return err
 	}
 
-	// Launch a new goroutine to notify any/all registered invoice
-	// notification clients.
-	go func() {
-		invoice, err := i.cdb.LookupInvoice(rHash)
-		if err != nil {
-			ltndLog.Errorf("unable to find invoice: %v", err)
-			return
-		}
+	// Notify any/all registered invoice notification clients.
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		ltndLog.Errorf("unable to find invoice: %v", err)
+		return nil
+	}
+
+	ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+
+	i.notifyClients(invoice, invoiceSettled)
+
+	return nil
+}
+
+// AddKeySendInvoice records a settled invoice for a spontaneous keysend
+// payment of the passed amount, which carried the passed preimage and custom
+// records. Notification clients are notified of the invoice as if it had been
+// settled.
+func (i *invoiceRegistry) AddKeySendInvoice(preimage [32]byte,
+	amt lnwire.MilliSatoshi, customRecords map[uint64][]byte) error {
+
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	invoice, err := i.cdb.AddKeySendInvoice(preimage, amt, customRecords)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Keysend payment received: %v", spew.Sdump(invoice))
+
+	i.notifyClients(invoice, invoiceSettled)
+
+	return nil
+}
+
+// SettleAMPInvoice records the settlement of an HTLC set paying the AMP
+// invoice with the passed payment hash. Each HTLC set paying the invoice is
+// recorded separately, and notification clients are notified of each one.
+func (i *invoiceRegistry) SettleAMPInvoice(rHash chainhash.Hash,
+	settlement *channeldb.AMPSettlement) error {
+
+	ltndLog.Debugf("Settling HTLC set %x of AMP invoice %x",
+		settlement.SetID[:], rHash[:])
+
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	err := i.cdb.SettleAMPInvoice(rHash, settlement)
+	if err != nil {
+		return err
+	}
+
+	// Notify any/all registered invoice notification clients.
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		ltndLog.Errorf("unable to find invoice: %v", err)
+		return nil
+	}
+
+	ltndLog.Infof("AMP payment received: %v", spew.Sdump(invoice))
+
+	i.notifyClients(invoice, invoiceSettled)
+
+	return nil
+}
+
+// AcceptHoldInvoice marks the hold invoice with the passed payment hash as
+// accepted, storing the custom records carried by the HTLC paying it along side
+// it. The HTLC is held by the link until the invoice is settled or canceled,
+// at which point the link is notified over the passed channel. If the invoice
+// was already resolved by the time the HTLC is accepted, then the link is
+// notified right away. Notification clients are notified of the invoice the
+// first time an HTLC paying it is accepted.
+func (i *invoiceRegistry) AcceptHoldInvoice(rHash chainhash.Hash,
+	customRecords map[uint64][]byte,
+	events chan<- htlcswitch.HodlEvent) error {
+
+	ltndLog.Debugf("Accepting htlc paying hold invoice %x", rHash[:])
+
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+	wasAccepted := invoice.Terms.Accepted
+
+	invoice, err = i.cdb.AcceptHoldInvoice(rHash, customRecords)
+	if err != nil {
+		return err
+	}
+
+	i.hodlSubscribe(events, rHash)
+
+	switch {
+	case invoice.Terms.Settled:
+		preimage := invoice.Terms.PaymentPreimage
+		i.resolveHoldInvoice(rHash, &preimage)
+
+	case invoice.Terms.Canceled:
+		i.resolveHoldInvoice(rHash, nil)
+
+	case !wasAccepted:
+		ltndLog.Infof("Hold invoice accepted: %v", spew.Sdump(invoice))
+
+		i.notifyClients(invoice, invoiceAccepted)
+	}
+
+	return nil
+}
+
+// SettleHoldInvoice settles the accepted hold invoice paid to the hash of the
+// passed preimage, and notifies the links holding HTLCs paying it, so they
+// settle the HTLCs with the preimage.
+func (i *invoiceRegistry) SettleHoldInvoice(preimage [32]byte) error {
+	rHash := chainhash.Hash(sha256.Sum256(preimage[:]))
+
+	ltndLog.Debugf("Settling hold invoice %x", rHash[:])
 
-		ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
 
-		i.notifyClients(invoice, true)
-	}()
+	invoice, err := i.cdb.SettleHoldInvoice(preimage)
+	if err != nil {
+		return err
+	}
+
+	ltndLog.Infof("Payment received: %v", spew.Sdump(invoice))
+
+	i.notifyClients(invoice, invoiceSettled)
+	i.resolveHoldInvoice(rHash, &preimage)
 
 	return nil
 }
 
+// CancelInvoice cancels the invoice with the passed payment hash, after which
+// payments to it are rejected. If HTLCs paying the invoice are held, then the
+// links holding them are notified, so they fail the HTLCs back. Canceling an
+// invoice which has already been canceled is a noop, while an invoice which has
+// already been settled can't be canceled.
+func (i *invoiceRegistry) CancelInvoice(rHash chainhash.Hash) error {
+	ltndLog.Debugf("Canceling invoice %x", rHash[:])
+
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	invoice, err := i.cdb.LookupInvoice(rHash)
+	if err != nil {
+		return err
+	}
+	if invoice.Terms.Canceled {
+		return nil
+	}
+
+	if err := i.cdb.CancelInvoice(rHash); err != nil {
+		return err
+	}
+	invoice.Terms.Canceled = true
+
+	ltndLog.Infof("Canceled invoice %x", rHash[:])
+
+	i.notifyClients(invoice, invoiceCanceled)
+	i.resolveHoldInvoice(rHash, nil)
+
+	return nil
+}
+
+// hodlSubscriber is a link holding HTLCs which pay hold invoices, and which is
+// notified once the invoices are resolved.
+type hodlSubscriber struct {
+	// events is the channel over which the resolutions are delivered.
+	events chan<- htlcswitch.HodlEvent
+
+	// hashes are the payment hashes of the hold invoices the subscriber
+	// awaits the resolution of.
+	hashes map[chainhash.Hash]struct{}
+
+	// quit is closed once the subscriber unsubscribes, aborting any of
+	// its pending deliveries.
+	quit chan struct{}
+}
+
+// hodlSubscribe subscribes the passed channel to the resolution of the hold
+// invoice with the passed payment hash.
+func (i *invoiceRegistry) hodlSubscribe(events chan<- htlcswitch.HodlEvent,
+	rHash chainhash.Hash) {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	subscriber, ok := i.hodlSubscribers[events]
+	if !ok {
+		subscriber = &hodlSubscriber{
+			events: events,
+			hashes: make(map[chainhash.Hash]struct{}),
+			quit:   make(chan struct{}),
+		}
+		i.hodlSubscribers[events] = subscriber
+	}
+	subscriber.hashes[rHash] = struct{}{}
+}
+
+// HodlUnsubscribeAll unsubscribes the passed channel from the resolutions of
+// all hold invoices, aborting any pending deliveries over it.
+func (i *invoiceRegistry) HodlUnsubscribeAll(
+	events chan<- htlcswitch.HodlEvent) {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	subscriber, ok := i.hodlSubscribers[events]
+	if !ok {
+		return
+	}
+
+	delete(i.hodlSubscribers, events)
+	close(subscriber.quit)
+}
+
+// resolveHoldInvoice notifies the subscribers awaiting the resolution of the
+// hold invoice with the passed payment hash that it was either settled with
+// the passed preimage, or canceled if the preimage is nil. Each subscriber is
+// notified at most once per invoice.
+func (i *invoiceRegistry) resolveHoldInvoice(rHash chainhash.Hash,
+	preimage *[32]byte) {
+
+	i.hodlMtx.Lock()
+	defer i.hodlMtx.Unlock()
+
+	event := htlcswitch.HodlEvent{
+		PaymentHash: rHash,
+		Preimage:    preimage,
+	}
+	for _, subscriber := range i.hodlSubscribers {
+		if _, ok := subscriber.hashes[rHash]; !ok {
+			continue
+		}
+		delete(subscriber.hashes, rHash)
+
+		// The event is delivered in the background, as the link may
+		// be busy calling into the registry itself.
+		i.wg.Add(1)
+		go func(s *hodlSubscriber) {
+			defer i.wg.Done()
+
+			select {
+			case s.events <- event:
+			case <-s.quit:
+			case <-i.quit:
+			}
+		}(subscriber)
+	}
+}
+
 // notifyClients notifies all currently registered invoice notification clients
-// of a newly added/settled invoice.
-func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice, settle bool) {
+// of a newly added/settled/canceled invoice. The invoice is queued for
+// delivery to each client, so clients receive invoices in the order they were
+// notified of.
+func (i *invoiceRegistry) notifyClients(invoice *channeldb.Invoice,
+	event invoiceEvent) {
+
 	i.clientMtx.Lock()
 	defer i.clientMtx.Unlock()
 
 	for _, client := range i.notificationClients {
-		var eventChan chan *channeldb.Invoice
-		if settle {
-			eventChan = client.SettledInvoices
-		} else {
-			eventChan = client.NewInvoices
-		}
-
-		go func() {
-			eventChan <- invoice
-		}()
+		client.enqueue(&invoiceUpdate{
+			invoice: invoice,
+			event:   event,
+		})
 	}
 }
 
-// invoiceSubscription represents an intent to receive updates for newly added
-// or settled invoices. For each newly added invoice, a copy of the invoice
-// will be sent over the NewInvoices channel. Similarly, for each newly settled
-// invoice, a copy of the invoice will be sent over the SettledInvoices
-// channel.
-type invoiceSubscription struct {
-	NewInvoices     chan *channeldb.Invoice
-	SettledInvoices chan *channeldb.Invoice
+// invoiceUpdate is a single change to an invoice which is queued for delivery
+// to a notification client.
+type invoiceUpdate struct {
+	invoice *channeldb.Invoice
+	event   invoiceEvent
+}
 
-	inv *invoiceRegistry
-	id  uint32
+// invoiceSubscription represents an intent to receive updates for newly added,
+// settled, canceled or accepted invoices. For each newly added invoice, a copy
+// of the invoice will be sent over the NewInvoices channel. Similarly, for each
+// newly settled, canceled or accepted invoice, a copy of the invoice will be
+// sent over the SettledInvoices, CanceledInvoices or AcceptedInvoices channel
+// respectively. Invoices are sent in the order they were updated in.
+type invoiceSubscription struct {
+	NewInvoices      chan *channeldb.Invoice
+	SettledInvoices  chan *channeldb.Invoice
+	CanceledInvoices chan *channeldb.Invoice
+	AcceptedInvoices chan *channeldb.Invoice
+
+	// queue holds the updates which weren't delivered to the client yet,
+	// and queued is signaled whenever an update is appended to it.
+	queueMtx sync.Mutex
+	queue    []*invoiceUpdate
+	queued   chan struct{}
+
+	inv  *invoiceRegistry
+	id   uint32
+	quit chan struct{}
 }
 
 // Cancel unregisters the invoiceSubscription, freeing any previously allocated
 // resources.
 func (i *invoiceSubscription) Cancel() {
 	i.inv.clientMtx.Lock()
-	delete(i.inv.notificationClients, i.id)
+	if _, ok := i.inv.notificationClients[i.id]; ok {
+		delete(i.inv.notificationClients, i.id)
+		close(i.quit)
+	}
 	i.inv.clientMtx.Unlock()
 }
 
+// enqueue appends the passed update to the updates awaiting delivery to the
+// client.
+func (i *invoiceSubscription) enqueue(update *invoiceUpdate) {
+	i.queueMtx.Lock()
+	i.queue = append(i.queue, update)
+	i.queueMtx.Unlock()
+
+	select {
+	case i.queued <- struct{}{}:
+	default:
+	}
+}
+
 // SubscribeNotifications returns an invoiceSubscription which allows the
-// caller to receive async notifications when any invoices are settled or
-// added.
-func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
+// caller to receive async notifications when any invoices are settled,
+// canceled, accepted or added. If addIndex is non-zero, then all invoices with a greater
+// add index are delivered first as added invoices. Similarly, if settleIndex is
+// non-zero, then all invoices with a greater settle index are delivered next
+// as settled invoices. This allows a client to replay the invoices it missed
+// since it last received an invoice.
+func (i *invoiceRegistry) SubscribeNotifications(addIndex,
+	settleIndex uint64) (*invoiceSubscription, error) {
+
 	client := &invoiceSubscription{
-		NewInvoices:     make(chan *channeldb.Invoice),
-		SettledInvoices: make(chan *channeldb.Invoice),
-		inv:             i,
+		NewInvoices:      make(chan *channeldb.Invoice),
+		SettledInvoices:  make(chan *channeldb.Invoice),
+		CanceledInvoices: make(chan *channeldb.Invoice),
+		AcceptedInvoices: make(chan *channeldb.Invoice),
+		queued:           make(chan struct{}, 1),
+		inv:              i,
+		quit:             make(chan struct{}),
+	}
+
+	// We'll fetch the invoices the client missed while holding the update
+	// mutex, so no invoice is added or settled before the client is
+	// registered without being included in its backlog.
+	i.updateMtx.Lock()
+	defer i.updateMtx.Unlock()
+
+	if addIndex != 0 {
+		added, err := i.cdb.InvoicesAddedSince(addIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, invoice := range added {
+			client.queue = append(client.queue, &invoiceUpdate{
+				invoice: invoice,
+				event:   invoiceAdded,
+			})
+		}
+	}
+
+	if settleIndex != 0 {
+		settled, err := i.cdb.InvoicesSettledSince(settleIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, invoice := range settled {
+			client.queue = append(client.queue, &invoiceUpdate{
+				invoice: invoice,
+				event:   invoiceSettled,
+			})
+		}
 	}
 
 	i.clientMtx.Lock()
@@ -218,5 +699,58 @@ func (i *invoiceRegistry) SubscribeNotifications() *invoiceSubscription {
 	i.nextClientID++
 	i.clientMtx.Unlock()
 
-	return client
+	i.wg.Add(1)
+	go client.deliverUpdates()
+
+	return client, nil
+}
+
+// deliverUpdates sends the queued updates to the client in order, until the
+// subscription is canceled or the registry is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (i *invoiceSubscription) deliverUpdates() {
+	defer i.inv.wg.Done()
+
+	for {
+		i.queueMtx.Lock()
+		var next *invoiceUpdate
+		if len(i.queue) > 0 {
+			next = i.queue[0]
+			i.queue[0] = nil
+			i.queue = i.queue[1:]
+		}
+		i.queueMtx.Unlock()
+
+		if next == nil {
+			select {
+			case <-i.queued:
+				continue
+			case <-i.quit:
+				return
+			case <-i.inv.quit:
+				return
+			}
+		}
+
+		var eventChan chan *channeldb.Invoice
+		switch next.event {
+		case invoiceAdded:
+			eventChan = i.NewInvoices
+		case invoiceSettled:
+			eventChan = i.SettledInvoices
+		case invoiceCanceled:
+			eventChan = i.CanceledInvoices
+		case invoiceAccepted:
+			eventChan = i.AcceptedInvoices
+		}
+
+		select {
+		case eventChan <- next.invoice:
+		case <-i.quit:
+			return
+		case <-i.inv.quit:
+			return
+		}
+	}
 }
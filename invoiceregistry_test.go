@@ -0,0 +1,252 @@
+package main
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/htlcswitch"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
+)
+
+// TestInvoiceRegistryReplay tests that a notification client subscribing with
+// an add and settle index first receives the invoices added and settled since
+// then, followed by any invoices added or settled afterwards.
+func TestInvoiceRegistryReplay(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	registry := newInvoiceRegistry(db)
+	defer registry.Stop()
+
+	newInvoice := func(i byte) *channeldb.Invoice {
+		invoice := &channeldb.Invoice{
+			CreationDate: time.Unix(time.Now().Unix(), 0),
+		}
+		invoice.Terms.PaymentPreimage[0] = i
+
+		if err := registry.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		return invoice
+	}
+	settleInvoice := func(invoice *channeldb.Invoice) {
+		hash := sha256.Sum256(invoice.Terms.PaymentPreimage[:])
+		if err := registry.SettleInvoice(hash, nil); err != nil {
+			t.Fatalf("unable to settle invoice: %v", err)
+		}
+	}
+	expectInvoice := func(updates chan *channeldb.Invoice,
+		expected *channeldb.Invoice) {
+
+		select {
+		case invoice := <-updates:
+			if invoice.Terms.PaymentPreimage !=
+				expected.Terms.PaymentPreimage {
+
+				t.Fatalf("expected invoice with add index "+
+					"%v, got %v", expected.AddIndex,
+					invoice.AddIndex)
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("invoice with add index %v not delivered",
+				expected.AddIndex)
+		}
+	}
+
+	// Add three invoices, and settle the first two of them.
+	invoices := []*channeldb.Invoice{newInvoice(1), newInvoice(2),
+		newInvoice(3)}
+	settleInvoice(invoices[0])
+	settleInvoice(invoices[1])
+
+	// A client which last received the first added and settled invoice
+	// should first receive the two invoices added since, followed by the
+	// one invoice settled since.
+	client, err := registry.SubscribeNotifications(1, 1)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer client.Cancel()
+
+	expectInvoice(client.NewInvoices, invoices[1])
+	expectInvoice(client.NewInvoices, invoices[2])
+	expectInvoice(client.SettledInvoices, invoices[1])
+
+	// Invoices added or settled afterwards should be delivered as they
+	// are.
+	settleInvoice(invoices[2])
+	expectInvoice(client.SettledInvoices, invoices[2])
+
+	live := newInvoice(4)
+	expectInvoice(client.NewInvoices, live)
+
+	select {
+	case invoice := <-client.NewInvoices:
+		t.Fatalf("unexpected invoice with add index %v",
+			invoice.AddIndex)
+	case invoice := <-client.SettledInvoices:
+		t.Fatalf("unexpected invoice with settle index %v",
+			invoice.SettleIndex)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestInvoiceRegistryHoldInvoice tests that the links holding HTLCs which pay
+// a hold invoice are notified once the invoice is settled or canceled, and
+// that notification clients are notified of the accepted invoice.
+func TestInvoiceRegistryHoldInvoice(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	registry := newInvoiceRegistry(db)
+	defer registry.Stop()
+
+	client, err := registry.SubscribeNotifications(0, 0)
+	if err != nil {
+		t.Fatalf("unable to subscribe: %v", err)
+	}
+	defer client.Cancel()
+
+	newHoldInvoice := func(i byte) ([32]byte, chainhash.Hash) {
+		var preimage [32]byte
+		preimage[0] = i
+		hash := sha256.Sum256(preimage[:])
+
+		invoice := &channeldb.Invoice{
+			CreationDate: time.Unix(time.Now().Unix(), 0),
+			Terms: channeldb.ContractTerm{
+				Hold:        true,
+				PaymentHash: hash,
+			},
+		}
+		if err := registry.AddInvoice(invoice); err != nil {
+			t.Fatalf("unable to add invoice: %v", err)
+		}
+
+		select {
+		case <-client.NewInvoices:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("added invoice not delivered")
+		}
+
+		return preimage, hash
+	}
+	expectInvoice := func(updates chan *channeldb.Invoice,
+		hash chainhash.Hash) {
+
+		select {
+		case invoice := <-updates:
+			if invoice.PaymentHash() != hash {
+				t.Fatalf("expected invoice %x, got %x", hash[:],
+					invoice.PaymentHash())
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("invoice %x not delivered", hash[:])
+		}
+	}
+	expectEvent := func(events chan htlcswitch.HodlEvent,
+		hash chainhash.Hash, preimage *[32]byte) {
+
+		select {
+		case event := <-events:
+			if event.PaymentHash != hash {
+				t.Fatalf("expected event for invoice %x, got "+
+					"%x", hash[:], event.PaymentHash[:])
+			}
+			switch {
+			case preimage == nil && event.Preimage != nil:
+				t.Fatalf("expected invoice to be canceled")
+			case preimage != nil && (event.Preimage == nil ||
+				*event.Preimage != *preimage):
+				t.Fatalf("expected invoice to be settled")
+			}
+
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event for invoice %x not delivered", hash[:])
+		}
+	}
+
+	events := make(chan htlcswitch.HodlEvent)
+	preimage, hash := newHoldInvoice(1)
+
+	// The invoice can't be settled before an HTLC paying it is accepted.
+	err = registry.SettleHoldInvoice(preimage)
+	if err != channeldb.ErrInvoiceNotAccepted {
+		t.Fatalf("expected ErrInvoiceNotAccepted, got %v", err)
+	}
+
+	// Once accepted, the client should be notified of it, while accepting
+	// another HTLC paying it shouldn't notify the client again.
+	if err := registry.AcceptHoldInvoice(hash, nil, events); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	expectInvoice(client.AcceptedInvoices, hash)
+	if err := registry.AcceptHoldInvoice(hash, nil, events); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+
+	// Settling the invoice should deliver its preimage to the link holding
+	// the HTLCs, exactly once.
+	if err := registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	expectInvoice(client.SettledInvoices, hash)
+	expectEvent(events, hash, &preimage)
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// An HTLC accepted after the invoice was settled should be settled
+	// right away.
+	if err := registry.AcceptHoldInvoice(hash, nil, events); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	expectEvent(events, hash, &preimage)
+
+	// Canceling another accepted invoice should notify the link, so it
+	// fails the HTLCs back.
+	_, hash = newHoldInvoice(2)
+	if err := registry.AcceptHoldInvoice(hash, nil, events); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	expectInvoice(client.AcceptedInvoices, hash)
+	if err := registry.CancelInvoice(hash); err != nil {
+		t.Fatalf("unable to cancel invoice: %v", err)
+	}
+	expectInvoice(client.CanceledInvoices, hash)
+	expectEvent(events, hash, nil)
+
+	// Finally, once the link unsubscribes, it should no longer be
+	// notified.
+	preimage, hash = newHoldInvoice(3)
+	if err := registry.AcceptHoldInvoice(hash, nil, events); err != nil {
+		t.Fatalf("unable to accept invoice: %v", err)
+	}
+	expectInvoice(client.AcceptedInvoices, hash)
+	registry.HodlUnsubscribeAll(events)
+	if err := registry.SettleHoldInvoice(preimage); err != nil {
+		t.Fatalf("unable to settle invoice: %v", err)
+	}
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -67,6 +67,11 @@ const (
 	// in order to establish a transport session with us on the Lightning
 	// p2p level (BOLT-0008).
 	KeyFamilyNodeKey KeyFamily = 6
+
+	// KeyFamilyPolicyEncryption is a family of keys that will be used to
+	// derive the symmetric key with which payment fee policies are
+	// encrypted at rest within the channel database.
+	KeyFamilyPolicyEncryption KeyFamily = 7
 )
 
 // KeyLocator is a two-tuple that can be used to derive *any* key that has ever
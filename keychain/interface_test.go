@@ -26,6 +26,7 @@ var versionZeroKeyFamilies = []KeyFamily{
 	KeyFamilyDelayBase,
 	KeyFamilyRevocationRoot,
 	KeyFamilyNodeKey,
+	KeyFamilyPolicyEncryption,
 }
 
 var (
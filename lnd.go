@@ -9,10 +9,12 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"expvar"
 	"fmt"
 	"io/ioutil"
 	"math/big"
@@ -40,6 +42,9 @@ import (
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/debugrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/invoicesrpc"
+	"github.com/lightningnetwork/lnd/lnrpc/walletrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwallet/btcwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -160,7 +165,34 @@ func lndMain() error {
 
 	// Open the channeldb, which is dedicated to storing channel, and
 	// network related metadata.
-	chanDB, err := channeldb.Open(graphDir)
+	chanDB, err := channeldb.Open(
+		graphDir,
+		channeldb.OptionSetPolicyCacheSize(cfg.PolicyCacheSize),
+		channeldb.OptionSetPolicyAuditRetention(
+			cfg.PolicyAuditRetention,
+		),
+		channeldb.OptionSetForwardingLogRetention(
+			cfg.ForwardingLogRetention,
+		),
+		channeldb.OptionAutoCompact(cfg.DB.Bolt.AutoCompact),
+		channeldb.OptionSetAutoCompactFreeRatio(
+			cfg.DB.Bolt.AutoCompactFreeRatio,
+		),
+		channeldb.OptionSetMaxBatchSize(cfg.DB.Bolt.MaxBatchSize),
+		channeldb.OptionSetMaxBatchDelay(cfg.DB.Bolt.MaxBatchDelay),
+		channeldb.OptionSetBatchRetries(cfg.DB.Bolt.BatchRetries),
+		channeldb.OptionSetBatchRetryBackoff(
+			cfg.DB.Bolt.BatchRetryBackoff,
+		),
+		channeldb.OptionSetGraphCache(!cfg.DB.NoGraphCache),
+		channeldb.OptionSetGraphBatchWindow(cfg.DB.GraphBatchWindow),
+		channeldb.OptionResetGraph(cfg.DB.ResetGraph),
+		channeldb.OptionDryRunMigration(cfg.DB.DryRunMigration),
+	)
+	if err == channeldb.ErrMigrationDryRun {
+		ltndLog.Infof("Database migration dry run complete, exiting")
+		return nil
+	}
 	if err != nil {
 		ltndLog.Errorf("unable to open channeldb: %v", err)
 		return err
@@ -200,7 +232,7 @@ func lndMain() error {
 	if !cfg.NoMacaroons {
 		// Create the macaroon authentication/authorization service.
 		macaroonService, err = macaroons.NewService(macaroonDatabaseDir,
-			macaroons.IPLockChecker)
+			macaroons.IPLockChecker, macaroons.CustomChecker)
 		if err != nil {
 			srvrLog.Errorf("unable to create macaroon service: %v", err)
 			return err
@@ -291,6 +323,27 @@ func lndMain() error {
 	}
 	idPrivKey.Curve = btcec.S256()
 
+	// With the wallet unlocked, we'll derive the key used to encrypt fee
+	// policies at rest. The key is installed even if encryption is
+	// disabled, so that any previously encrypted policies are decrypted.
+	policyPrivKey, err := activeChainControl.wallet.DerivePrivKey(
+		keychain.KeyDescriptor{
+			KeyLocator: keychain.KeyLocator{
+				Family: keychain.KeyFamilyPolicyEncryption,
+				Index:  0,
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	policyKey := sha256.Sum256(policyPrivKey.Serialize())
+	err = chanDB.ConfigurePolicyEncryption(policyKey, cfg.EncryptPolicies)
+	if err != nil {
+		ltndLog.Errorf("unable to configure policy encryption: %v", err)
+		return err
+	}
+
 	if cfg.Tor.Socks != "" && cfg.Tor.DNS != "" {
 		srvrLog.Infof("Proxying all network traffic via Tor "+
 			"(stream_isolation=%v)! NOTE: If running with a full-node "+
@@ -308,6 +361,10 @@ func lndMain() error {
 		return err
 	}
 
+	// Export the metrics of the channeldb, which are served by the
+	// profiling server at /debug/vars if it's enabled.
+	expvar.Publish("channeldb", channelDBVarsFunc(chanDB, server.policyGC))
+
 	// Next, we'll initialize the funding manager itself so it can answer
 	// queries while the wallet+chain are still syncing.
 	nodeSigner := newNodeSigner(idPrivKey)
@@ -450,6 +507,10 @@ func lndMain() error {
 			cid := lnwire.NewChanIDFromOutPoint(&chanPoint)
 			return server.htlcSwitch.UpdateShortChanID(cid, sid)
 		},
+		AcceptChannel:                 server.chanAcceptor.Accept,
+		NotifyPendingOpenChannelEvent: server.channelNotifier.NotifyPendingOpenChannelEvent,
+		NotifyOpenChannelEvent:        server.channelNotifier.NotifyOpenChannelEvent,
+		NotifyClosedChannelEvent:      server.channelNotifier.NotifyClosedChannelEvent,
 		RequiredRemoteChanReserve: func(chanAmt btcutil.Amount) btcutil.Amount {
 			// By default, we'll require the remote peer to maintain
 			// at least 1% of the total channel capacity at all
@@ -480,19 +541,47 @@ func lndMain() error {
 	}
 	server.fundingMgr = fundingMgr
 
-	// Check macaroon authentication if macaroons aren't disabled.
+	// Check macaroon authentication if macaroons aren't disabled, before
+	// handing the authorized calls to the registered RPC middlewares.
+	var (
+		unaryInterceptors  []grpc.UnaryServerInterceptor
+		streamInterceptors []grpc.StreamServerInterceptor
+	)
 	if macaroonService != nil {
-		serverOpts = append(serverOpts,
-			grpc.UnaryInterceptor(macaroonService.
-				UnaryServerInterceptor(permissions)),
-			grpc.StreamInterceptor(macaroonService.
-				StreamServerInterceptor(permissions)),
-		)
+		// The WalletKit, Debug and Invoices services are gated by
+		// their own sets of permissions, which are checked alongside
+		// those of the Lightning service.
+		for method, ops := range walletrpc.Permissions {
+			permissions[method] = ops
+		}
+		for method, ops := range debugrpc.Permissions {
+			permissions[method] = ops
+		}
+		for method, ops := range invoicesrpc.Permissions {
+			permissions[method] = ops
+		}
+
+		unaryInterceptors = append(unaryInterceptors,
+			macaroonService.UnaryServerInterceptor(permissions))
+		streamInterceptors = append(streamInterceptors,
+			macaroonService.StreamServerInterceptor(permissions))
 	}
+	unaryInterceptors = append(unaryInterceptors,
+		server.rpcMiddleware.UnaryServerInterceptor())
+	streamInterceptors = append(streamInterceptors,
+		server.rpcMiddleware.StreamServerInterceptor())
+	serverOpts = append(serverOpts,
+		grpc.UnaryInterceptor(
+			chainUnaryServerInterceptors(unaryInterceptors...),
+		),
+		grpc.StreamInterceptor(
+			chainStreamServerInterceptors(streamInterceptors...),
+		),
+	)
 
 	// Initialize, and register our implementation of the gRPC interface
 	// exported by the rpcServer.
-	rpcServer := newRPCServer(server)
+	rpcServer := newRPCServer(server, macaroonService)
 	if err := rpcServer.Start(); err != nil {
 		return err
 	}
@@ -500,6 +589,43 @@ func lndMain() error {
 	grpcServer := grpc.NewServer(serverOpts...)
 	lnrpc.RegisterLightningServer(grpcServer, rpcServer)
 
+	// We'll also expose the low-level operations of our wallet through
+	// the WalletKit service.
+	walletKit := walletrpc.New(&walletrpc.Config{
+		Wallet:       activeChainControl.wallet,
+		KeyRing:      activeChainControl.wallet,
+		FeeEstimator: activeChainControl.feeEstimator,
+	})
+	walletrpc.RegisterWalletKitServer(grpcServer, walletKit)
+
+	// The Debug service allows the admin to inspect the runtime of the
+	// daemon, and to adjust its log levels without restarting it.
+	debugServer := debugrpc.New(&debugrpc.Config{
+		SetLogLevels: parseAndSetDebugLevels,
+		LogLevels:    subsystemLogLevels,
+	})
+	debugrpc.RegisterDebugServer(grpcServer, debugServer)
+
+	// The Invoices service allows applications to hold the HTLCs paying
+	// an invoice until they settle or cancel it.
+	invoicesServer := invoicesrpc.New(&invoicesrpc.Config{
+		AddHoldInvoice: func(hash [32]byte,
+			invoice *lnrpc.Invoice) (string, error) {
+
+			resp, err := rpcServer.addInvoice(invoice, &hash)
+			if err != nil {
+				return "", err
+			}
+
+			return resp.PaymentRequest, nil
+		},
+		SettleHoldInvoice: server.invoices.SettleHoldInvoice,
+		CancelInvoice: func(hash [32]byte) error {
+			return server.invoices.CancelInvoice(hash)
+		},
+	})
+	invoicesrpc.RegisterInvoicesServer(grpcServer, invoicesServer)
+
 	// Next, Start the gRPC server listening for HTTP/2 connections.
 	for _, listener := range cfg.RPCListeners {
 		lis, err := net.Listen("tcp", listener)
@@ -514,7 +640,9 @@ func lndMain() error {
 		}()
 	}
 
-	// Finally, start the REST proxy for our gRPC server above.
+	// Finally, start the REST proxy for our gRPC server above. Its
+	// endpoints are also served over websockets, which allows browser
+	// clients to use the streaming endpoints.
 	mux := proxy.NewServeMux()
 	err = lnrpc.RegisterLightningHandlerFromEndpoint(ctx, mux,
 		cfg.RPCListeners[0], proxyOpts)
@@ -530,7 +658,7 @@ func lndMain() error {
 		defer listener.Close()
 		go func() {
 			rpcsLog.Infof("gRPC proxy started at %s", listener.Addr())
-			http.Serve(listener, mux)
+			http.Serve(listener, newWebsocketProxy(mux))
 		}()
 	}
 
@@ -0,0 +1,139 @@
+package debugrpc
+
+import (
+	"bytes"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// Permissions maps the RPC calls of the Debug service to the macaroon
+	// permissions they require. As the calls expose the internals of the
+	// daemon, and may change its behaviour, they all require the write
+	// permission of the info entity, which is only granted to the admin
+	// macaroon.
+	Permissions = map[string][]bakery.Op{
+		"/debugrpc.Debug/GoroutineDump": {{
+			Entity: "info",
+			Action: "write",
+		}},
+		"/debugrpc.Debug/HeapProfile": {{
+			Entity: "info",
+			Action: "write",
+		}},
+		"/debugrpc.Debug/SetLogLevel": {{
+			Entity: "info",
+			Action: "write",
+		}},
+	}
+)
+
+// Config is the set of dependencies the Debug service needs in order to carry
+// out its duties.
+type Config struct {
+	// SetLogLevels changes the log levels of the subsystems of the daemon
+	// according to the passed level spec, which has the same format as
+	// the --debuglevel option.
+	SetLogLevels func(levelSpec string) error
+
+	// LogLevels returns the current log level of each subsystem of the
+	// daemon, keyed by the identifier of the subsystem.
+	LogLevels func() map[string]string
+}
+
+// Debug implements the Debug service, which exposes the runtime internals of
+// the daemon for debugging purposes.
+type Debug struct {
+	cfg *Config
+}
+
+// A compile time check to ensure that Debug fully implements the DebugServer
+// gRPC service.
+var _ DebugServer = (*Debug)(nil)
+
+// New creates and returns a new Debug service backed by the passed config.
+func New(cfg *Config) *Debug {
+	return &Debug{
+		cfg: cfg,
+	}
+}
+
+// GoroutineDump returns the stack traces of all goroutines of the daemon.
+// Unless the full stacks are requested, goroutines with identical stacks are
+// grouped together, which keeps the dump readable for large numbers of
+// goroutines.
+func (d *Debug) GoroutineDump(ctx context.Context,
+	req *GoroutineDumpRequest) (*GoroutineDumpResponse, error) {
+
+	// A debug level of 2 writes the stacks in the same format as an
+	// unrecovered panic, whereas 1 groups identical stacks.
+	debugLevel := 1
+	if req.Full {
+		debugLevel = 2
+	}
+
+	var b bytes.Buffer
+	profile := pprof.Lookup("goroutine")
+	if err := profile.WriteTo(&b, debugLevel); err != nil {
+		return nil, err
+	}
+
+	return &GoroutineDumpResponse{
+		NumGoroutines: uint32(runtime.NumGoroutine()),
+		Dump:          b.String(),
+	}, nil
+}
+
+// HeapProfile returns a profile of the memory allocations of the daemon in the
+// pprof format.
+func (d *Debug) HeapProfile(ctx context.Context,
+	req *HeapProfileRequest) (*HeapProfileResponse, error) {
+
+	// The heap profile only reflects the state as of the last garbage
+	// collection, so we'll run one first if requested.
+	if req.Gc {
+		runtime.GC()
+	}
+
+	var b bytes.Buffer
+	if err := pprof.WriteHeapProfile(&b); err != nil {
+		return nil, err
+	}
+
+	return &HeapProfileResponse{
+		Profile: b.Bytes(),
+	}, nil
+}
+
+// SetLogLevel changes the log levels of the subsystems of the daemon according
+// to the passed level spec, if any, and returns the resulting log level of
+// each subsystem.
+func (d *Debug) SetLogLevel(ctx context.Context,
+	req *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+
+	if req.LevelSpec != "" {
+		if err := d.cfg.SetLogLevels(req.LevelSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	levels := d.cfg.LogLevels()
+	resp := &SetLogLevelResponse{
+		Levels: make([]*SubsystemLevel, 0, len(levels)),
+	}
+	for subsystem, level := range levels {
+		resp.Levels = append(resp.Levels, &SubsystemLevel{
+			Subsystem: subsystem,
+			Level:     level,
+		})
+	}
+	sort.Slice(resp.Levels, func(i, j int) bool {
+		return resp.Levels[i].Subsystem < resp.Levels[j].Subsystem
+	})
+
+	return resp, nil
+}
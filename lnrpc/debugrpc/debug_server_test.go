@@ -0,0 +1,164 @@
+package debugrpc
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+// mockLogLevels is an in-memory set of subsystem log levels, which accepts
+// level specs in the format of the --debuglevel option.
+type mockLogLevels struct {
+	levels map[string]string
+}
+
+// setLogLevels changes the levels according to the passed spec, leaving them
+// untouched if any part of the spec is invalid.
+func (m *mockLogLevels) setLogLevels(levelSpec string) error {
+	validLevel := func(level string) bool {
+		switch level {
+		case "trace", "debug", "info", "warn", "error", "critical":
+			return true
+		}
+		return false
+	}
+
+	updated := make(map[string]string, len(m.levels))
+	for subsystem, level := range m.levels {
+		updated[subsystem] = level
+	}
+
+	for _, pair := range strings.Split(levelSpec, ",") {
+		fields := strings.Split(pair, "=")
+		switch {
+		case len(fields) == 1 && validLevel(fields[0]):
+			for subsystem := range updated {
+				updated[subsystem] = fields[0]
+			}
+
+		case len(fields) == 2 && validLevel(fields[1]):
+			if _, ok := updated[fields[0]]; !ok {
+				return fmt.Errorf("unknown subsystem %v",
+					fields[0])
+			}
+			updated[fields[0]] = fields[1]
+
+		default:
+			return fmt.Errorf("invalid level spec %q", pair)
+		}
+	}
+
+	m.levels = updated
+	return nil
+}
+
+// logLevels returns a copy of the current levels.
+func (m *mockLogLevels) logLevels() map[string]string {
+	levels := make(map[string]string, len(m.levels))
+	for subsystem, level := range m.levels {
+		levels[subsystem] = level
+	}
+	return levels
+}
+
+// TestSetLogLevel tests that invalid level specs are rejected without changing
+// any level, and that the resulting levels are returned sorted by subsystem.
+func TestSetLogLevel(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockLogLevels{
+		levels: map[string]string{
+			"SRVR": "info",
+			"HSWC": "info",
+			"CRTR": "info",
+		},
+	}
+	server := New(&Config{
+		SetLogLevels: mock.setLogLevels,
+		LogLevels:    mock.logLevels,
+	})
+	ctx := context.Background()
+
+	assertLevels := func(resp *SetLogLevelResponse, expected [][2]string) {
+		var levels [][2]string
+		for _, level := range resp.Levels {
+			levels = append(levels, [2]string{
+				level.Subsystem, level.Level,
+			})
+		}
+		if !reflect.DeepEqual(levels, expected) {
+			t.Fatalf("expected levels %v, got %v", expected,
+				levels)
+		}
+	}
+
+	// Without a level spec, the current levels are returned as is.
+	resp, err := server.SetLogLevel(ctx, &SetLogLevelRequest{})
+	if err != nil {
+		t.Fatalf("unable to fetch log levels: %v", err)
+	}
+	assertLevels(resp, [][2]string{
+		{"CRTR", "info"}, {"HSWC", "info"}, {"SRVR", "info"},
+	})
+
+	// An invalid spec should be rejected, even if part of it is valid.
+	for _, spec := range []string{"verbose", "HSWC=debug,SRVR=loud"} {
+		_, err := server.SetLogLevel(ctx, &SetLogLevelRequest{
+			LevelSpec: spec,
+		})
+		if err == nil {
+			t.Fatalf("expected level spec %q to be rejected", spec)
+		}
+	}
+
+	resp, err = server.SetLogLevel(ctx, &SetLogLevelRequest{
+		LevelSpec: "HSWC=debug,CRTR=trace",
+	})
+	if err != nil {
+		t.Fatalf("unable to set log levels: %v", err)
+	}
+	assertLevels(resp, [][2]string{
+		{"CRTR", "trace"}, {"HSWC", "debug"}, {"SRVR", "info"},
+	})
+}
+
+// TestProfiles tests that the goroutine dump and heap profile aren't empty.
+func TestProfiles(t *testing.T) {
+	t.Parallel()
+
+	server := New(&Config{})
+	ctx := context.Background()
+
+	for _, full := range []bool{false, true} {
+		dump, err := server.GoroutineDump(ctx, &GoroutineDumpRequest{
+			Full: full,
+		})
+		if err != nil {
+			t.Fatalf("unable to dump goroutines: %v", err)
+		}
+		if dump.NumGoroutines == 0 {
+			t.Fatalf("expected at least one goroutine")
+		}
+
+		// This very goroutine should be part of the dump.
+		if !strings.Contains(dump.Dump, "TestProfiles") {
+			t.Fatalf("goroutine dump doesn't contain the test: %v",
+				dump.Dump)
+		}
+	}
+
+	// The heap profile is written in the gzipped protobuf format of
+	// pprof.
+	profile, err := server.HeapProfile(ctx, &HeapProfileRequest{Gc: true})
+	if err != nil {
+		t.Fatalf("unable to fetch heap profile: %v", err)
+	}
+	if !bytes.HasPrefix(profile.Profile, []byte{0x1f, 0x8b}) {
+		t.Fatalf("expected gzipped heap profile, got %x",
+			profile.Profile)
+	}
+}
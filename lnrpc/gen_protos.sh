@@ -22,3 +22,24 @@ protoc -I/usr/local/include -I. \
        -I$GOPATH/src/github.com/grpc-ecosystem/grpc-gateway/third_party/googleapis \
        --swagger_out=logtostderr=true:. \
        rpc.proto
+
+# Generate the protos of the WalletKit service.
+cd walletrpc
+protoc -I/usr/local/include -I. \
+       -I$GOPATH/src \
+       --go_out=plugins=grpc:. \
+       walletkit.proto
+
+# Generate the protos of the Debug service.
+cd ../debugrpc
+protoc -I/usr/local/include -I. \
+       -I$GOPATH/src \
+       --go_out=plugins=grpc:. \
+       debug.proto
+
+# Generate the protos of the Invoices service.
+cd ../invoicesrpc
+protoc -I/usr/local/include -I. \
+       -I$GOPATH/src \
+       --go_out=plugins=grpc:. \
+       invoices.proto
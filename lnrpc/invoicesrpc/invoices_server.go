@@ -0,0 +1,142 @@
+package invoicesrpc
+
+import (
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// Permissions maps the RPC calls of the Invoices service to the
+	// macaroon permissions they require. As with AddInvoice on the main
+	// Lightning service, managing hold invoices requires the write
+	// permission of the invoices entity.
+	Permissions = map[string][]bakery.Op{
+		"/invoicesrpc.Invoices/AddHoldInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/invoicesrpc.Invoices/SettleInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+		"/invoicesrpc.Invoices/CancelInvoice": {{
+			Entity: "invoices",
+			Action: "write",
+		}},
+	}
+)
+
+// Config is the set of dependencies the Invoices service needs in order to
+// carry out its duties.
+type Config struct {
+	// AddHoldInvoice adds a hold invoice paid to the passed hash, using
+	// the remaining parameters of the passed invoice, and returns its
+	// encoded payment request.
+	AddHoldInvoice func(hash [32]byte, invoice *lnrpc.Invoice) (string,
+		error)
+
+	// SettleHoldInvoice settles the accepted hold invoice paid to the hash
+	// of the passed preimage, which settles the HTLCs paying it.
+	SettleHoldInvoice func(preimage [32]byte) error
+
+	// CancelInvoice cancels the invoice paid to the passed hash, which
+	// fails back any HTLCs paying it.
+	CancelInvoice func(hash [32]byte) error
+}
+
+// Invoices implements the Invoices service, which allows applications to
+// manage hold invoices.
+type Invoices struct {
+	cfg *Config
+}
+
+// A compile time check to ensure that Invoices fully implements the
+// InvoicesServer gRPC service.
+var _ InvoicesServer = (*Invoices)(nil)
+
+// New creates and returns a new Invoices service backed by the passed config.
+func New(cfg *Config) *Invoices {
+	return &Invoices{
+		cfg: cfg,
+	}
+}
+
+// AddHoldInvoice creates a hold invoice paid to the specified payment hash.
+// The HTLCs paying it are held until the invoice is settled or canceled.
+func (i *Invoices) AddHoldInvoice(ctx context.Context,
+	req *AddHoldInvoiceRequest) (*AddHoldInvoiceResp, error) {
+
+	hash, err := parseHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &lnrpc.Invoice{
+		Memo:            req.Memo,
+		Value:           req.Value,
+		DescriptionHash: req.DescriptionHash,
+		Expiry:          req.Expiry,
+		FallbackAddr:    req.FallbackAddr,
+		CltvExpiry:      req.CltvExpiry,
+	}
+	payReq, err := i.cfg.AddHoldInvoice(hash, invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddHoldInvoiceResp{
+		PaymentRequest: payReq,
+	}, nil
+}
+
+// SettleInvoice settles an accepted hold invoice using the preimage of its
+// payment hash.
+func (i *Invoices) SettleInvoice(ctx context.Context,
+	req *SettleInvoiceMsg) (*SettleInvoiceResp, error) {
+
+	if len(req.Preimage) != 32 {
+		return nil, fmt.Errorf("preimage must be exactly 32 bytes, is "+
+			"instead %v", len(req.Preimage))
+	}
+
+	var preimage [32]byte
+	copy(preimage[:], req.Preimage)
+
+	if err := i.cfg.SettleHoldInvoice(preimage); err != nil {
+		return nil, err
+	}
+
+	return &SettleInvoiceResp{}, nil
+}
+
+// CancelInvoice cancels the invoice with the specified payment hash, failing
+// back any HTLCs paying it.
+func (i *Invoices) CancelInvoice(ctx context.Context,
+	req *CancelInvoiceMsg) (*CancelInvoiceResp, error) {
+
+	hash, err := parseHash(req.PaymentHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.cfg.CancelInvoice(hash); err != nil {
+		return nil, err
+	}
+
+	return &CancelInvoiceResp{}, nil
+}
+
+// parseHash parses a payment hash, which must be exactly 32 bytes.
+func parseHash(b []byte) ([32]byte, error) {
+	var hash [32]byte
+	if len(b) != 32 {
+		return hash, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(b))
+	}
+	copy(hash[:], b)
+
+	return hash, nil
+}
@@ -89,10 +89,13 @@ It has these top-level messages:
 	ListInvoiceResponse
 	InvoiceSubscription
 	Payment
+	HTLCAttempt
 	ListPaymentsRequest
 	ListPaymentsResponse
 	DeleteAllPaymentsRequest
 	DeleteAllPaymentsResponse
+	DeletePaymentsRequest
+	DeletePaymentsResponse
 	DebugLevelRequest
 	DebugLevelResponse
 	PayReqString
@@ -105,6 +108,63 @@ It has these top-level messages:
 	ForwardingHistoryRequest
 	ForwardingEvent
 	ForwardingHistoryResponse
+	PaymentPolicy
+	AddPolicyResponse
+	ListPoliciesRequest
+	ListPoliciesResponse
+	PolicyPaymentHash
+	DeletePolicyResponse
+	SetDefaultPolicyResponse
+	DefaultPolicyRequest
+	DeleteDefaultPolicyRequest
+	DeleteDefaultPolicyResponse
+	ExportPoliciesRequest
+	PolicyBackup
+	ImportPoliciesResponse
+	PolicyAuditLogRequest
+	PolicyAuditRecord
+	PolicyAuditLogResponse
+	CompactDatabaseRequest
+	CompactDatabaseResponse
+	ExportDatabaseRequest
+	DatabaseChunk
+	LabelChannelRequest
+	LabelChannelResponse
+	AMPSettlement
+	ChannelEventSubscription
+	ChannelEventUpdate
+	CircuitKey
+	ForwardHtlcInterceptRequest
+	ForwardHtlcInterceptResponse
+	ChannelAcceptRequest
+	ChannelAcceptResponse
+	MiddlewareRegistration
+	InterceptFeedback
+	RPCMiddlewareRequest
+	RPCMiddlewareResponse
+	FeeLimit
+	SendToRouteRequest
+	EstimateFeeRequest
+	EstimateFeeResponse
+	AbandonChannelRequest
+	AbandonChannelResponse
+	PaymentUpdate
+	NodeMetricsRequest
+	NodeMetrics
+	NodeMetricsResponse
+	MacaroonPermission
+	BakeMacaroonRequest
+	BakeMacaroonResponse
+	ListMacaroonIDsRequest
+	ListMacaroonIDsResponse
+	DeleteMacaroonIDRequest
+	DeleteMacaroonIDResponse
+	CheckMacaroonPermissionsRequest
+	MacaroonCustomCaveat
+	CheckMacaroonPermissionsResponse
+	PeerEventSubscription
+	PeerEvent
+	TrackPaymentRequest
 */
 package lnrpc
 
@@ -152,6 +212,201 @@ func (NewAddressRequest_AddressType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor0, []int{17, 0}
 }
 
+type Invoice_InvoiceState int32
+
+const (
+	Invoice_OPEN     Invoice_InvoiceState = 0
+	Invoice_SETTLED  Invoice_InvoiceState = 1
+	Invoice_CANCELED Invoice_InvoiceState = 2
+	Invoice_ACCEPTED Invoice_InvoiceState = 3
+)
+
+var Invoice_InvoiceState_name = map[int32]string{
+	0: "OPEN",
+	1: "SETTLED",
+	2: "CANCELED",
+	3: "ACCEPTED",
+}
+var Invoice_InvoiceState_value = map[string]int32{
+	"OPEN":     0,
+	"SETTLED":  1,
+	"CANCELED": 2,
+	"ACCEPTED": 3,
+}
+
+func (x Invoice_InvoiceState) String() string {
+	return proto.EnumName(Invoice_InvoiceState_name, int32(x))
+}
+func (Invoice_InvoiceState) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{74, 0} }
+
+type PolicyAuditRecord_Decision int32
+
+const (
+	PolicyAuditRecord_PERMITTED       PolicyAuditRecord_Decision = 0
+	PolicyAuditRecord_REJECTED_FEE    PolicyAuditRecord_Decision = 1
+	PolicyAuditRecord_REJECTED_CLTV   PolicyAuditRecord_Decision = 2
+	PolicyAuditRecord_REJECTED_BUDGET PolicyAuditRecord_Decision = 3
+	PolicyAuditRecord_REJECTED_DENY   PolicyAuditRecord_Decision = 4
+)
+
+var PolicyAuditRecord_Decision_name = map[int32]string{
+	0: "PERMITTED",
+	1: "REJECTED_FEE",
+	2: "REJECTED_CLTV",
+	3: "REJECTED_BUDGET",
+	4: "REJECTED_DENY",
+}
+var PolicyAuditRecord_Decision_value = map[string]int32{
+	"PERMITTED":       0,
+	"REJECTED_FEE":    1,
+	"REJECTED_CLTV":   2,
+	"REJECTED_BUDGET": 3,
+	"REJECTED_DENY":   4,
+}
+
+func (x PolicyAuditRecord_Decision) String() string {
+	return proto.EnumName(PolicyAuditRecord_Decision_name, int32(x))
+}
+func (PolicyAuditRecord_Decision) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{114, 0}
+}
+
+type ChannelEventUpdate_UpdateType int32
+
+const (
+	ChannelEventUpdate_PENDING_OPEN_CHANNEL ChannelEventUpdate_UpdateType = 0
+	ChannelEventUpdate_OPEN_CHANNEL         ChannelEventUpdate_UpdateType = 1
+	ChannelEventUpdate_CLOSING_CHANNEL      ChannelEventUpdate_UpdateType = 2
+	ChannelEventUpdate_CLOSED_CHANNEL       ChannelEventUpdate_UpdateType = 3
+	ChannelEventUpdate_ACTIVE_CHANNEL       ChannelEventUpdate_UpdateType = 4
+	ChannelEventUpdate_INACTIVE_CHANNEL     ChannelEventUpdate_UpdateType = 5
+)
+
+var ChannelEventUpdate_UpdateType_name = map[int32]string{
+	0: "PENDING_OPEN_CHANNEL",
+	1: "OPEN_CHANNEL",
+	2: "CLOSING_CHANNEL",
+	3: "CLOSED_CHANNEL",
+	4: "ACTIVE_CHANNEL",
+	5: "INACTIVE_CHANNEL",
+}
+var ChannelEventUpdate_UpdateType_value = map[string]int32{
+	"PENDING_OPEN_CHANNEL": 0,
+	"OPEN_CHANNEL":         1,
+	"CLOSING_CHANNEL":      2,
+	"CLOSED_CHANNEL":       3,
+	"ACTIVE_CHANNEL":       4,
+	"INACTIVE_CHANNEL":     5,
+}
+
+func (x ChannelEventUpdate_UpdateType) String() string {
+	return proto.EnumName(ChannelEventUpdate_UpdateType_name, int32(x))
+}
+func (ChannelEventUpdate_UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{124, 0}
+}
+
+type ForwardHtlcInterceptResponse_Action int32
+
+const (
+	ForwardHtlcInterceptResponse_RESUME ForwardHtlcInterceptResponse_Action = 0
+	ForwardHtlcInterceptResponse_SETTLE ForwardHtlcInterceptResponse_Action = 1
+	ForwardHtlcInterceptResponse_FAIL   ForwardHtlcInterceptResponse_Action = 2
+)
+
+var ForwardHtlcInterceptResponse_Action_name = map[int32]string{
+	0: "RESUME",
+	1: "SETTLE",
+	2: "FAIL",
+}
+var ForwardHtlcInterceptResponse_Action_value = map[string]int32{
+	"RESUME": 0,
+	"SETTLE": 1,
+	"FAIL":   2,
+}
+
+func (x ForwardHtlcInterceptResponse_Action) String() string {
+	return proto.EnumName(ForwardHtlcInterceptResponse_Action_name, int32(x))
+}
+func (ForwardHtlcInterceptResponse_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{127, 0}
+}
+
+type RPCMiddlewareRequest_InterceptType int32
+
+const (
+	RPCMiddlewareRequest_REQUEST  RPCMiddlewareRequest_InterceptType = 0
+	RPCMiddlewareRequest_RESPONSE RPCMiddlewareRequest_InterceptType = 1
+)
+
+var RPCMiddlewareRequest_InterceptType_name = map[int32]string{
+	0: "REQUEST",
+	1: "RESPONSE",
+}
+var RPCMiddlewareRequest_InterceptType_value = map[string]int32{
+	"REQUEST":  0,
+	"RESPONSE": 1,
+}
+
+func (x RPCMiddlewareRequest_InterceptType) String() string {
+	return proto.EnumName(RPCMiddlewareRequest_InterceptType_name, int32(x))
+}
+func (RPCMiddlewareRequest_InterceptType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{132, 0}
+}
+
+type PaymentUpdate_UpdateType int32
+
+const (
+	PaymentUpdate_ATTEMPT_DISPATCHED PaymentUpdate_UpdateType = 0
+	PaymentUpdate_ATTEMPT_FAILED     PaymentUpdate_UpdateType = 1
+	PaymentUpdate_SUCCEEDED          PaymentUpdate_UpdateType = 2
+	PaymentUpdate_FAILED             PaymentUpdate_UpdateType = 3
+)
+
+var PaymentUpdate_UpdateType_name = map[int32]string{
+	0: "ATTEMPT_DISPATCHED",
+	1: "ATTEMPT_FAILED",
+	2: "SUCCEEDED",
+	3: "FAILED",
+}
+var PaymentUpdate_UpdateType_value = map[string]int32{
+	"ATTEMPT_DISPATCHED": 0,
+	"ATTEMPT_FAILED":     1,
+	"SUCCEEDED":          2,
+	"FAILED":             3,
+}
+
+func (x PaymentUpdate_UpdateType) String() string {
+	return proto.EnumName(PaymentUpdate_UpdateType_name, int32(x))
+}
+func (PaymentUpdate_UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{140, 0}
+}
+
+type PeerEvent_EventType int32
+
+const (
+	PeerEvent_PEER_ONLINE  PeerEvent_EventType = 0
+	PeerEvent_PEER_OFFLINE PeerEvent_EventType = 1
+)
+
+var PeerEvent_EventType_name = map[int32]string{
+	0: "PEER_ONLINE",
+	1: "PEER_OFFLINE",
+}
+var PeerEvent_EventType_value = map[string]int32{
+	"PEER_ONLINE":  0,
+	"PEER_OFFLINE": 1,
+}
+
+func (x PeerEvent_EventType) String() string {
+	return proto.EnumName(PeerEvent_EventType_name, int32(x))
+}
+func (PeerEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{155, 0}
+}
+
 type GenSeedRequest struct {
 	// *
 	// aezeed_passphrase is an optional user provided passphrase that will be used
@@ -1053,6 +1308,10 @@ type Channel struct {
 	CsvDelay uint32 `protobuf:"varint,16,opt,name=csv_delay" json:"csv_delay,omitempty"`
 	// / Whether this channel is advertised to the network or not
 	Private bool `protobuf:"varint,17,opt,name=private" json:"private,omitempty"`
+	// / The label attached to the channel by the operator, if any.
+	Label string `protobuf:"bytes,18,opt,name=label" json:"label,omitempty"`
+	// / The block height until which we refuse to cooperatively close the channel. If zero, the channel isn't frozen.
+	ThawHeight uint32 `protobuf:"varint,19,opt,name=thaw_height" json:"thaw_height,omitempty"`
 }
 
 func (m *Channel) Reset()                    { *m = Channel{} }
@@ -1179,6 +1438,20 @@ func (m *Channel) GetPrivate() bool {
 	return false
 }
 
+func (m *Channel) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *Channel) GetThawHeight() uint32 {
+	if m != nil {
+		return m.ThawHeight
+	}
+	return 0
+}
+
 type ListChannelsRequest struct {
 	ActiveOnly   bool `protobuf:"varint,1,opt,name=active_only,json=activeOnly" json:"active_only,omitempty"`
 	InactiveOnly bool `protobuf:"varint,2,opt,name=inactive_only,json=inactiveOnly" json:"inactive_only,omitempty"`
@@ -1251,8 +1524,12 @@ type Peer struct {
 	SatRecv int64 `protobuf:"varint,7,opt,name=sat_recv" json:"sat_recv,omitempty"`
 	// / A channel is inbound if the counterparty initiated the channel
 	Inbound bool `protobuf:"varint,8,opt,name=inbound" json:"inbound,omitempty"`
-	// / Ping time to this peer
+	// / Round-trip ping time to this peer in microseconds
 	PingTime int64 `protobuf:"varint,9,opt,name=ping_time" json:"ping_time,omitempty"`
+	// / The number of times the peer went online or offline since lnd started
+	FlapCount uint32 `protobuf:"varint,10,opt,name=flap_count" json:"flap_count,omitempty"`
+	// / The time in unix nanoseconds at which the peer last went online or offline
+	LastFlapNs int64 `protobuf:"varint,11,opt,name=last_flap_ns" json:"last_flap_ns,omitempty"`
 }
 
 func (m *Peer) Reset()                    { *m = Peer{} }
@@ -1316,6 +1593,20 @@ func (m *Peer) GetPingTime() int64 {
 	return 0
 }
 
+func (m *Peer) GetFlapCount() uint32 {
+	if m != nil {
+		return m.FlapCount
+	}
+	return 0
+}
+
+func (m *Peer) GetLastFlapNs() int64 {
+	if m != nil {
+		return m.LastFlapNs
+	}
+	return 0
+}
+
 type ListPeersRequest struct {
 }
 
@@ -1777,6 +2068,8 @@ type OpenChannelRequest struct {
 	MinHtlcMsat int64 `protobuf:"varint,9,opt,name=min_htlc_msat" json:"min_htlc_msat,omitempty"`
 	// / The delay we require on the remote's commitment transaction. If this is not set, it will be scaled automatically with the channel size.
 	RemoteCsvDelay uint32 `protobuf:"varint,10,opt,name=remote_csv_delay" json:"remote_csv_delay,omitempty"`
+	// / If non-zero, we'll refuse to cooperatively close the channel until this block height has been reached.
+	ThawHeight uint32 `protobuf:"varint,11,opt,name=thaw_height" json:"thaw_height,omitempty"`
 }
 
 func (m *OpenChannelRequest) Reset()                    { *m = OpenChannelRequest{} }
@@ -1847,6 +2140,13 @@ func (m *OpenChannelRequest) GetRemoteCsvDelay() uint32 {
 	return 0
 }
 
+func (m *OpenChannelRequest) GetThawHeight() uint32 {
+	if m != nil {
+		return m.ThawHeight
+	}
+	return 0
+}
+
 type OpenStatusUpdate struct {
 	// Types that are valid to be assigned to Update:
 	//	*OpenStatusUpdate_ChanPending
@@ -2120,6 +2420,8 @@ type PendingChannelsResponse_PendingChannel struct {
 	Capacity      int64  `protobuf:"varint,3,opt,name=capacity" json:"capacity,omitempty"`
 	LocalBalance  int64  `protobuf:"varint,4,opt,name=local_balance" json:"local_balance,omitempty"`
 	RemoteBalance int64  `protobuf:"varint,5,opt,name=remote_balance" json:"remote_balance,omitempty"`
+	// / The label attached to the channel by the operator, if any.
+	Label string `protobuf:"bytes,6,opt,name=label" json:"label,omitempty"`
 }
 
 func (m *PendingChannelsResponse_PendingChannel) Reset() {
@@ -2166,6 +2468,13 @@ func (m *PendingChannelsResponse_PendingChannel) GetRemoteBalance() int64 {
 	return 0
 }
 
+func (m *PendingChannelsResponse_PendingChannel) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type PendingChannelsResponse_PendingOpenChannel struct {
 	// / The pending channel
 	Channel *PendingChannelsResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
@@ -2185,6 +2494,12 @@ type PendingChannelsResponse_PendingOpenChannel struct {
 	// pay at all times, for both the funding transaction and commitment
 	// transaction. This value can later be updated once the channel is open.
 	FeePerKw int64 `protobuf:"varint,6,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// *
+	// The number of blocks until this channel is forgotten if its funding
+	// transaction hasn't confirmed by then. This is only set for channels
+	// initiated by the remote party, as we never forget channels funded by
+	// our own wallet.
+	FundingExpiryBlocks int32 `protobuf:"varint,7,opt,name=funding_expiry_blocks" json:"funding_expiry_blocks,omitempty"`
 }
 
 func (m *PendingChannelsResponse_PendingOpenChannel) Reset() {
@@ -2233,6 +2548,13 @@ func (m *PendingChannelsResponse_PendingOpenChannel) GetFeePerKw() int64 {
 	return 0
 }
 
+func (m *PendingChannelsResponse_PendingOpenChannel) GetFundingExpiryBlocks() int32 {
+	if m != nil {
+		return m.FundingExpiryBlocks
+	}
+	return 0
+}
+
 type PendingChannelsResponse_ClosedChannel struct {
 	// / The pending channel to be closed
 	Channel *PendingChannelsResponse_PendingChannel `protobuf:"bytes,1,opt,name=channel" json:"channel,omitempty"`
@@ -2424,6 +2746,23 @@ type QueryRoutesRequest struct {
 	Amt int64 `protobuf:"varint,2,opt,name=amt" json:"amt,omitempty"`
 	// / The max number of routes to return.
 	NumRoutes int32 `protobuf:"varint,3,opt,name=num_routes,json=numRoutes" json:"num_routes,omitempty"`
+	// *
+	// The maximum fee that may be paid to route the payment, either as a fixed
+	// amount or as a percentage of the payment amount. Routes with a higher total
+	// fee are not returned. If not set, the fee is unbounded.
+	FeeLimit *FeeLimit `protobuf:"bytes,4,opt,name=fee_limit" json:"fee_limit,omitempty"`
+	// *
+	// A list of nodes to ignore during path finding, identified by their 33-byte
+	// compressed public key.
+	IgnoredNodes [][]byte `protobuf:"bytes,5,rep,name=ignored_nodes,proto3" json:"ignored_nodes,omitempty"`
+	// *
+	// A list of channels to ignore during path finding, identified by their
+	// channel ID. A channel is ignored in both directions.
+	IgnoredEdges []uint64 `protobuf:"varint,6,rep,packed,name=ignored_edges" json:"ignored_edges,omitempty"`
+	// *
+	// The 33-byte hex-encoded public key of the node the routes start at. If
+	// empty, the routes start at our own node.
+	SourcePubKey string `protobuf:"bytes,7,opt,name=source_pub_key" json:"source_pub_key,omitempty"`
 }
 
 func (m *QueryRoutesRequest) Reset()                    { *m = QueryRoutesRequest{} }
@@ -2452,6 +2791,34 @@ func (m *QueryRoutesRequest) GetNumRoutes() int32 {
 	return 0
 }
 
+func (m *QueryRoutesRequest) GetFeeLimit() *FeeLimit {
+	if m != nil {
+		return m.FeeLimit
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetIgnoredNodes() [][]byte {
+	if m != nil {
+		return m.IgnoredNodes
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetIgnoredEdges() []uint64 {
+	if m != nil {
+		return m.IgnoredEdges
+	}
+	return nil
+}
+
+func (m *QueryRoutesRequest) GetSourcePubKey() string {
+	if m != nil {
+		return m.SourcePubKey
+	}
+	return ""
+}
+
 type QueryRoutesResponse struct {
 	Routes []*Route `protobuf:"bytes,1,rep,name=routes" json:"routes,omitempty"`
 }
@@ -2478,6 +2845,12 @@ type Hop struct {
 	AmtToForward int64  `protobuf:"varint,3,opt,name=amt_to_forward" json:"amt_to_forward,omitempty"`
 	Fee          int64  `protobuf:"varint,4,opt,name=fee" json:"fee,omitempty"`
 	Expiry       uint32 `protobuf:"varint,5,opt,name=expiry" json:"expiry,omitempty"`
+	// / The amount forwarded by this hop in millisatoshis
+	AmtToForwardMsat int64 `protobuf:"varint,6,opt,name=amt_to_forward_msat" json:"amt_to_forward_msat,omitempty"`
+	// / The fee paid to this hop in millisatoshis
+	FeeMsat int64 `protobuf:"varint,7,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The hex-encoded compressed public key of the node at this hop
+	PubKey string `protobuf:"bytes,8,opt,name=pub_key" json:"pub_key,omitempty"`
 }
 
 func (m *Hop) Reset()                    { *m = Hop{} }
@@ -2520,6 +2893,27 @@ func (m *Hop) GetExpiry() uint32 {
 	return 0
 }
 
+func (m *Hop) GetAmtToForwardMsat() int64 {
+	if m != nil {
+		return m.AmtToForwardMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *Hop) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
 // *
 // A path through the channel graph which runs over one or more channels in
 // succession. This struct carries all the information required to craft the
@@ -2548,6 +2942,10 @@ type Route struct {
 	// *
 	// Contains details concerning the specific forwarding details at each hop.
 	Hops []*Hop `protobuf:"bytes,4,rep,name=hops" json:"hops,omitempty"`
+	// / The sum of the fees paid at each hop in millisatoshis
+	TotalFeesMsat int64 `protobuf:"varint,5,opt,name=total_fees_msat" json:"total_fees_msat,omitempty"`
+	// / The total amount extended to the first hop in millisatoshis
+	TotalAmtMsat int64 `protobuf:"varint,6,opt,name=total_amt_msat" json:"total_amt_msat,omitempty"`
 }
 
 func (m *Route) Reset()                    { *m = Route{} }
@@ -2583,6 +2981,20 @@ func (m *Route) GetHops() []*Hop {
 	return nil
 }
 
+func (m *Route) GetTotalFeesMsat() int64 {
+	if m != nil {
+		return m.TotalFeesMsat
+	}
+	return 0
+}
+
+func (m *Route) GetTotalAmtMsat() int64 {
+	if m != nil {
+		return m.TotalAmtMsat
+	}
+	return 0
+}
+
 type NodeInfoRequest struct {
 	// / The 33-byte hex-encoded compressed public of the target node
 	PubKey string `protobuf:"bytes,1,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
@@ -2609,6 +3021,11 @@ type NodeInfo struct {
 	Node          *LightningNode `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
 	NumChannels   uint32         `protobuf:"varint,2,opt,name=num_channels" json:"num_channels,omitempty"`
 	TotalCapacity int64          `protobuf:"varint,3,opt,name=total_capacity" json:"total_capacity,omitempty"`
+	// *
+	// Prior announcements of the node's alias, color and addresses, oldest
+	// first. Only announcements which changed any of them are recorded, so the
+	// last entry reflects the node's current attributes.
+	History []*LightningNode `protobuf:"bytes,4,rep,name=history" json:"history,omitempty"`
 }
 
 func (m *NodeInfo) Reset()                    { *m = NodeInfo{} }
@@ -2637,6 +3054,13 @@ func (m *NodeInfo) GetTotalCapacity() int64 {
 	return 0
 }
 
+func (m *NodeInfo) GetHistory() []*LightningNode {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
 // *
 // An individual vertex/node within the channel graph. A node is
 // connected to other nodes by one or more channel edges emanating from it. As the
@@ -2719,6 +3143,8 @@ type RoutingPolicy struct {
 	MinHtlc          int64  `protobuf:"varint,2,opt,name=min_htlc" json:"min_htlc,omitempty"`
 	FeeBaseMsat      int64  `protobuf:"varint,3,opt,name=fee_base_msat" json:"fee_base_msat,omitempty"`
 	FeeRateMilliMsat int64  `protobuf:"varint,4,opt,name=fee_rate_milli_msat" json:"fee_rate_milli_msat,omitempty"`
+	// / The maximum HTLC size in milli-satoshis, if advertised.
+	MaxHtlcMsat uint64 `protobuf:"varint,5,opt,name=max_htlc_msat" json:"max_htlc_msat,omitempty"`
 }
 
 func (m *RoutingPolicy) Reset()                    { *m = RoutingPolicy{} }
@@ -2754,6 +3180,13 @@ func (m *RoutingPolicy) GetFeeRateMilliMsat() int64 {
 	return 0
 }
 
+func (m *RoutingPolicy) GetMaxHtlcMsat() uint64 {
+	if m != nil {
+		return m.MaxHtlcMsat
+	}
+	return 0
+}
+
 // *
 // A fully authenticated channel along with all its unique attributes.
 // Once an authenticated channel announcement has been processed on the network,
@@ -2837,6 +3270,30 @@ func (m *ChannelEdge) GetNode2Policy() *RoutingPolicy {
 }
 
 type ChannelGraphRequest struct {
+	// *
+	// Only nodes whose last update is at or after this unix timestamp are
+	// returned. If zero, nodes are returned regardless of their last update.
+	NodesUpdatedSince int64 `protobuf:"varint,1,opt,name=nodes_updated_since" json:"nodes_updated_since,omitempty"`
+	// / Only channels with at least this capacity in satoshis are returned.
+	MinCapacity int64 `protobuf:"varint,2,opt,name=min_capacity" json:"min_capacity,omitempty"`
+	// *
+	// Whether to omit channels which are marked as zombies, or for which no
+	// enabled routing policy was advertised by either of their nodes.
+	OmitDisabledEdges bool `protobuf:"varint,3,opt,name=omit_disabled_edges" json:"omit_disabled_edges,omitempty"`
+	// *
+	// The hex-encoded public key of the node after which to resume listing nodes,
+	// as returned in the last_node_offset of the previous page. If empty, nodes are
+	// listed from the start.
+	NodeOffset string `protobuf:"bytes,4,opt,name=node_offset" json:"node_offset,omitempty"`
+	// / The maximum number of nodes to return. If zero, all remaining nodes are returned.
+	NumMaxNodes uint32 `protobuf:"varint,5,opt,name=num_max_nodes" json:"num_max_nodes,omitempty"`
+	// *
+	// The channel ID after which to resume listing channels, as returned in the
+	// last_chan_id_offset of the previous page. If zero, channels are listed from
+	// the start.
+	ChanIdOffset uint64 `protobuf:"varint,6,opt,name=chan_id_offset" json:"chan_id_offset,omitempty"`
+	// / The maximum number of channels to return. If zero, all remaining channels are returned.
+	NumMaxEdges uint32 `protobuf:"varint,7,opt,name=num_max_edges" json:"num_max_edges,omitempty"`
 }
 
 func (m *ChannelGraphRequest) Reset()                    { *m = ChannelGraphRequest{} }
@@ -2844,12 +3301,69 @@ func (m *ChannelGraphRequest) String() string            { return proto.CompactT
 func (*ChannelGraphRequest) ProtoMessage()               {}
 func (*ChannelGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
 
+func (m *ChannelGraphRequest) GetNodesUpdatedSince() int64 {
+	if m != nil {
+		return m.NodesUpdatedSince
+	}
+	return 0
+}
+
+func (m *ChannelGraphRequest) GetMinCapacity() int64 {
+	if m != nil {
+		return m.MinCapacity
+	}
+	return 0
+}
+
+func (m *ChannelGraphRequest) GetOmitDisabledEdges() bool {
+	if m != nil {
+		return m.OmitDisabledEdges
+	}
+	return false
+}
+
+func (m *ChannelGraphRequest) GetNodeOffset() string {
+	if m != nil {
+		return m.NodeOffset
+	}
+	return ""
+}
+
+func (m *ChannelGraphRequest) GetNumMaxNodes() uint32 {
+	if m != nil {
+		return m.NumMaxNodes
+	}
+	return 0
+}
+
+func (m *ChannelGraphRequest) GetChanIdOffset() uint64 {
+	if m != nil {
+		return m.ChanIdOffset
+	}
+	return 0
+}
+
+func (m *ChannelGraphRequest) GetNumMaxEdges() uint32 {
+	if m != nil {
+		return m.NumMaxEdges
+	}
+	return 0
+}
+
 // / Returns a new instance of the directed channel graph.
 type ChannelGraph struct {
 	// / The list of `LightningNode`s in this channel graph
 	Nodes []*LightningNode `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
 	// / The list of `ChannelEdge`s in this channel graph
 	Edges []*ChannelEdge `protobuf:"bytes,2,rep,name=edges" json:"edges,omitempty"`
+	// *
+	// The public key of the last node returned, to be passed as the node_offset
+	// of the request for the next page of nodes.
+	LastNodeOffset string `protobuf:"bytes,3,opt,name=last_node_offset" json:"last_node_offset,omitempty"`
+	// *
+	// The channel ID of the last channel returned, to be passed as the
+	// chan_id_offset of the request for the next page of channels.
+	LastChanIdOffset uint64 `protobuf:"varint,4,opt,name=last_chan_id_offset" json:"last_chan_id_offset,omitempty"`
 }
 
 func (m *ChannelGraph) Reset()                    { *m = ChannelGraph{} }
@@ -2871,6 +3385,20 @@ func (m *ChannelGraph) GetEdges() []*ChannelEdge {
 	return nil
 }
 
+func (m *ChannelGraph) GetLastNodeOffset() string {
+	if m != nil {
+		return m.LastNodeOffset
+	}
+	return ""
+}
+
+func (m *ChannelGraph) GetLastChanIdOffset() uint64 {
+	if m != nil {
+		return m.LastChanIdOffset
+	}
+	return 0
+}
+
 type ChanInfoRequest struct {
 	// *
 	// The unique channel ID for the channel. The first 3 bytes are the block
@@ -3218,6 +3746,33 @@ type Invoice struct {
 	FallbackAddr string `protobuf:"bytes,12,opt,name=fallback_addr" json:"fallback_addr,omitempty"`
 	// / Delta to use for the time-lock of the CLTV extended to the final hop.
 	CltvExpiry uint64 `protobuf:"varint,13,opt,name=cltv_expiry" json:"cltv_expiry,omitempty"`
+	// *
+	// The state of the invoice. An open invoice which isn't settled before its
+	// expiry is canceled, after which payments to it are rejected.
+	State Invoice_InvoiceState `protobuf:"varint,14,opt,name=state,enum=lnrpc.Invoice_InvoiceState" json:"state,omitempty"`
+	// *
+	// The custom records carried within the onion payload of the HTLC which
+	// settled this invoice, keyed by their type.
+	CustomRecords map[uint64][]byte `protobuf:"bytes,15,rep,name=custom_records" json:"custom_records,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// *
+	// Whether this is an AMP invoice, which may be paid many times. Each set of
+	// HTLCs paying it is settled independently.
+	Amp bool `protobuf:"varint,16,opt,name=amp" json:"amp,omitempty"`
+	// / The settlements of the HTLC sets which paid this AMP invoice.
+	AmpSettlements []*AMPSettlement `protobuf:"bytes,17,rep,name=amp_settlements" json:"amp_settlements,omitempty"`
+	// *
+	// Whether this invoice was synthesized upon receiving a spontaneous keysend
+	// payment, rather than created by us.
+	IsKeysend bool `protobuf:"varint,18,opt,name=is_keysend" json:"is_keysend,omitempty"`
+	// *
+	// The index of this invoice. Each newly added invoice has an increasing
+	// index, which may be used to resume a subscription to invoices.
+	AddIndex uint64 `protobuf:"varint,19,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// The index at which this invoice was last settled, or zero if it isn't
+	// settled. Each newly settled invoice has an increasing index, which may be
+	// used to resume a subscription to invoices.
+	SettleIndex uint64 `protobuf:"varint,20,opt,name=settle_index" json:"settle_index,omitempty"`
 }
 
 func (m *Invoice) Reset()                    { *m = Invoice{} }
@@ -3316,46 +3871,95 @@ func (m *Invoice) GetCltvExpiry() uint64 {
 	return 0
 }
 
-type AddInvoiceResponse struct {
-	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
-	// *
-	// A bare-bones invoice for a payment within the Lightning Network.  With the
-	// details of the invoice, the sender has all the data necessary to send a
-	// payment to the recipient.
-	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+func (m *Invoice) GetState() Invoice_InvoiceState {
+	if m != nil {
+		return m.State
+	}
+	return Invoice_OPEN
 }
 
-func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
-func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
-func (*AddInvoiceResponse) ProtoMessage()               {}
-func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
-
-func (m *AddInvoiceResponse) GetRHash() []byte {
+func (m *Invoice) GetCustomRecords() map[uint64][]byte {
 	if m != nil {
-		return m.RHash
+		return m.CustomRecords
 	}
 	return nil
 }
 
-func (m *AddInvoiceResponse) GetPaymentRequest() string {
+func (m *Invoice) GetAmp() bool {
 	if m != nil {
-		return m.PaymentRequest
+		return m.Amp
 	}
-	return ""
+	return false
 }
 
-type PaymentHash struct {
-	// *
-	// The hex-encoded payment hash of the invoice to be looked up. The passed
-	// payment hash must be exactly 32 bytes, otherwise an error is returned.
-	RHashStr string `protobuf:"bytes,1,opt,name=r_hash_str" json:"r_hash_str,omitempty"`
-	// / The payment hash of the invoice to be looked up.
-	RHash []byte `protobuf:"bytes,2,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+func (m *Invoice) GetAmpSettlements() []*AMPSettlement {
+	if m != nil {
+		return m.AmpSettlements
+	}
+	return nil
 }
 
-func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
-func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
-func (*PaymentHash) ProtoMessage()               {}
+func (m *Invoice) GetIsKeysend() bool {
+	if m != nil {
+		return m.IsKeysend
+	}
+	return false
+}
+
+func (m *Invoice) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *Invoice) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
+type AddInvoiceResponse struct {
+	RHash []byte `protobuf:"bytes,1,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+	// *
+	// A bare-bones invoice for a payment within the Lightning Network.  With the
+	// details of the invoice, the sender has all the data necessary to send a
+	// payment to the recipient.
+	PaymentRequest string `protobuf:"bytes,2,opt,name=payment_request" json:"payment_request,omitempty"`
+}
+
+func (m *AddInvoiceResponse) Reset()                    { *m = AddInvoiceResponse{} }
+func (m *AddInvoiceResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddInvoiceResponse) ProtoMessage()               {}
+func (*AddInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+func (m *AddInvoiceResponse) GetRHash() []byte {
+	if m != nil {
+		return m.RHash
+	}
+	return nil
+}
+
+func (m *AddInvoiceResponse) GetPaymentRequest() string {
+	if m != nil {
+		return m.PaymentRequest
+	}
+	return ""
+}
+
+type PaymentHash struct {
+	// *
+	// The hex-encoded payment hash of the invoice to be looked up. The passed
+	// payment hash must be exactly 32 bytes, otherwise an error is returned.
+	RHashStr string `protobuf:"bytes,1,opt,name=r_hash_str" json:"r_hash_str,omitempty"`
+	// / The payment hash of the invoice to be looked up.
+	RHash []byte `protobuf:"bytes,2,opt,name=r_hash,proto3" json:"r_hash,omitempty"`
+}
+
+func (m *PaymentHash) Reset()                    { *m = PaymentHash{} }
+func (m *PaymentHash) String() string            { return proto.CompactTextString(m) }
+func (*PaymentHash) ProtoMessage()               {}
 func (*PaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
 
 func (m *PaymentHash) GetRHashStr() string {
@@ -3406,6 +4010,16 @@ func (m *ListInvoiceResponse) GetInvoices() []*Invoice {
 }
 
 type InvoiceSubscription struct {
+	// *
+	// If specified (non-zero), then all invoices with an add index greater than
+	// this one are sent first, allowing a client to replay the invoices added
+	// since it last received one.
+	AddIndex uint64 `protobuf:"varint,1,opt,name=add_index" json:"add_index,omitempty"`
+	// *
+	// If specified (non-zero), then all invoices with a settle index greater
+	// than this one are sent first, allowing a client to replay the invoices
+	// settled since it last received one.
+	SettleIndex uint64 `protobuf:"varint,2,opt,name=settle_index" json:"settle_index,omitempty"`
 }
 
 func (m *InvoiceSubscription) Reset()                    { *m = InvoiceSubscription{} }
@@ -3413,6 +4027,20 @@ func (m *InvoiceSubscription) String() string            { return proto.CompactT
 func (*InvoiceSubscription) ProtoMessage()               {}
 func (*InvoiceSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{79} }
 
+func (m *InvoiceSubscription) GetAddIndex() uint64 {
+	if m != nil {
+		return m.AddIndex
+	}
+	return 0
+}
+
+func (m *InvoiceSubscription) GetSettleIndex() uint64 {
+	if m != nil {
+		return m.SettleIndex
+	}
+	return 0
+}
+
 type Payment struct {
 	// / The payment hash
 	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
@@ -3426,6 +4054,12 @@ type Payment struct {
 	Fee int64 `protobuf:"varint,5,opt,name=fee" json:"fee,omitempty"`
 	// / The payment preimage
 	PaymentPreimage string `protobuf:"bytes,6,opt,name=payment_preimage" json:"payment_preimage,omitempty"`
+	// / The index of the payment, in the order in which payments were made
+	PaymentIndex uint64 `protobuf:"varint,7,opt,name=payment_index" json:"payment_index,omitempty"`
+	// / The HTLCs dispatched to complete this payment, in the order they were made
+	Htlcs []*HTLCAttempt `protobuf:"bytes,8,rep,name=htlcs" json:"htlcs,omitempty"`
+	// / The route the payment was settled over, including the fee paid to each hop
+	Route *Route `protobuf:"bytes,9,opt,name=route" json:"route,omitempty"`
 }
 
 func (m *Payment) Reset()                    { *m = Payment{} }
@@ -3475,23 +4109,150 @@ func (m *Payment) GetPaymentPreimage() string {
 	return ""
 }
 
+func (m *Payment) GetPaymentIndex() uint64 {
+	if m != nil {
+		return m.PaymentIndex
+	}
+	return 0
+}
+
+func (m *Payment) GetHtlcs() []*HTLCAttempt {
+	if m != nil {
+		return m.Htlcs
+	}
+	return nil
+}
+
+func (m *Payment) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+type HTLCAttempt struct {
+	// / The route the HTLC was sent along
+	Route *Route `protobuf:"bytes,1,opt,name=route" json:"route,omitempty"`
+	// / The time in unix nanoseconds at which the HTLC was dispatched
+	AttemptTimeNs int64 `protobuf:"varint,2,opt,name=attempt_time_ns" json:"attempt_time_ns,omitempty"`
+	// / The time in unix nanoseconds at which the HTLC was settled or failed
+	ResolveTimeNs int64 `protobuf:"varint,3,opt,name=resolve_time_ns" json:"resolve_time_ns,omitempty"`
+	// / The reason the HTLC failed, empty if it was settled
+	Failure string `protobuf:"bytes,4,opt,name=failure" json:"failure,omitempty"`
+}
+
+func (m *HTLCAttempt) Reset()                    { *m = HTLCAttempt{} }
+func (m *HTLCAttempt) String() string            { return proto.CompactTextString(m) }
+func (*HTLCAttempt) ProtoMessage()               {}
+func (*HTLCAttempt) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+
+func (m *HTLCAttempt) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+func (m *HTLCAttempt) GetAttemptTimeNs() int64 {
+	if m != nil {
+		return m.AttemptTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetResolveTimeNs() int64 {
+	if m != nil {
+		return m.ResolveTimeNs
+	}
+	return 0
+}
+
+func (m *HTLCAttempt) GetFailure() string {
+	if m != nil {
+		return m.Failure
+	}
+	return ""
+}
+
 type ListPaymentsRequest struct {
+	// *
+	// The index of the payment at which the list starts. The payment at the
+	// offset itself isn't included. If zero, the list starts at the first
+	// payment, or at the last one if reversed is set.
+	IndexOffset uint64 `protobuf:"varint,1,opt,name=index_offset" json:"index_offset,omitempty"`
+	// / The maximum number of payments returned. If zero, all are returned.
+	MaxPayments uint64 `protobuf:"varint,2,opt,name=max_payments" json:"max_payments,omitempty"`
+	// *
+	// If set, the payments made before the index offset are returned, rather
+	// than those made after it.
+	Reversed bool `protobuf:"varint,3,opt,name=reversed" json:"reversed,omitempty"`
+	// *
+	// If set, only the payments created at or after this unix timestamp in
+	// seconds are returned.
+	CreationDateStart int64 `protobuf:"varint,4,opt,name=creation_date_start" json:"creation_date_start,omitempty"`
+	// *
+	// If set, only the payments created before this unix timestamp in seconds
+	// are returned.
+	CreationDateEnd int64 `protobuf:"varint,5,opt,name=creation_date_end" json:"creation_date_end,omitempty"`
 }
 
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{81} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+
+func (m *ListPaymentsRequest) GetIndexOffset() uint64 {
+	if m != nil {
+		return m.IndexOffset
+	}
+	return 0
+}
+
+func (m *ListPaymentsRequest) GetMaxPayments() uint64 {
+	if m != nil {
+		return m.MaxPayments
+	}
+	return 0
+}
+
+func (m *ListPaymentsRequest) GetReversed() bool {
+	if m != nil {
+		return m.Reversed
+	}
+	return false
+}
+
+func (m *ListPaymentsRequest) GetCreationDateStart() int64 {
+	if m != nil {
+		return m.CreationDateStart
+	}
+	return 0
+}
+
+func (m *ListPaymentsRequest) GetCreationDateEnd() int64 {
+	if m != nil {
+		return m.CreationDateEnd
+	}
+	return 0
+}
 
 type ListPaymentsResponse struct {
-	// / The list of payments
+	// / The list of payments, in the order in which they were made
 	Payments []*Payment `protobuf:"bytes,1,rep,name=payments" json:"payments,omitempty"`
+	// *
+	// The index of the first payment in the list. It can be used as the index
+	// offset of a reversed request to fetch the preceding payments.
+	FirstIndexOffset uint64 `protobuf:"varint,2,opt,name=first_index_offset" json:"first_index_offset,omitempty"`
+	// *
+	// The index of the last payment in the list. It can be used as the index
+	// offset of a request to fetch the following payments.
+	LastIndexOffset uint64 `protobuf:"varint,3,opt,name=last_index_offset" json:"last_index_offset,omitempty"`
 }
 
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{82} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -3500,13 +4261,27 @@ func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	return nil
 }
 
+func (m *ListPaymentsResponse) GetFirstIndexOffset() uint64 {
+	if m != nil {
+		return m.FirstIndexOffset
+	}
+	return 0
+}
+
+func (m *ListPaymentsResponse) GetLastIndexOffset() uint64 {
+	if m != nil {
+		return m.LastIndexOffset
+	}
+	return 0
+}
+
 type DeleteAllPaymentsRequest struct {
 }
 
 func (m *DeleteAllPaymentsRequest) Reset()                    { *m = DeleteAllPaymentsRequest{} }
 func (m *DeleteAllPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsRequest) ProtoMessage()               {}
-func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{83} }
+func (*DeleteAllPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
 
 type DeleteAllPaymentsResponse struct {
 }
@@ -3514,7 +4289,56 @@ type DeleteAllPaymentsResponse struct {
 func (m *DeleteAllPaymentsResponse) Reset()                    { *m = DeleteAllPaymentsResponse{} }
 func (m *DeleteAllPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteAllPaymentsResponse) ProtoMessage()               {}
-func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{84} }
+func (*DeleteAllPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+
+type DeletePaymentsRequest struct {
+	// *
+	// If set, only the failed HTLC attempts of the payments are deleted, while
+	// the payments themselves, along with their successful attempts, are kept.
+	FailedOnly bool `protobuf:"varint,1,opt,name=failed_only,json=failedOnly" json:"failed_only,omitempty"`
+	// *
+	// Only payments created before this unix timestamp in seconds are deleted.
+	// If zero, all payments are deleted.
+	BeforeTime int64 `protobuf:"varint,2,opt,name=before_time,json=beforeTime" json:"before_time,omitempty"`
+}
+
+func (m *DeletePaymentsRequest) Reset()                    { *m = DeletePaymentsRequest{} }
+func (m *DeletePaymentsRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeletePaymentsRequest) ProtoMessage()               {}
+func (*DeletePaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+func (m *DeletePaymentsRequest) GetFailedOnly() bool {
+	if m != nil {
+		return m.FailedOnly
+	}
+	return false
+}
+
+func (m *DeletePaymentsRequest) GetBeforeTime() int64 {
+	if m != nil {
+		return m.BeforeTime
+	}
+	return 0
+}
+
+type DeletePaymentsResponse struct {
+	// *
+	// The number of deleted payments, or the number of deleted HTLC attempts if
+	// failed_only was set.
+	NumDeleted uint64 `protobuf:"varint,1,opt,name=num_deleted" json:"num_deleted,omitempty"`
+}
+
+func (m *DeletePaymentsResponse) Reset()                    { *m = DeletePaymentsResponse{} }
+func (m *DeletePaymentsResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeletePaymentsResponse) ProtoMessage()               {}
+func (*DeletePaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
+func (m *DeletePaymentsResponse) GetNumDeleted() uint64 {
+	if m != nil {
+		return m.NumDeleted
+	}
+	return 0
+}
 
 type DebugLevelRequest struct {
 	Show      bool   `protobuf:"varint,1,opt,name=show" json:"show,omitempty"`
@@ -3524,7 +4348,7 @@ type DebugLevelRequest struct {
 func (m *DebugLevelRequest) Reset()                    { *m = DebugLevelRequest{} }
 func (m *DebugLevelRequest) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelRequest) ProtoMessage()               {}
-func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{85} }
+func (*DebugLevelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
 
 func (m *DebugLevelRequest) GetShow() bool {
 	if m != nil {
@@ -3547,7 +4371,7 @@ type DebugLevelResponse struct {
 func (m *DebugLevelResponse) Reset()                    { *m = DebugLevelResponse{} }
 func (m *DebugLevelResponse) String() string            { return proto.CompactTextString(m) }
 func (*DebugLevelResponse) ProtoMessage()               {}
-func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+func (*DebugLevelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
 
 func (m *DebugLevelResponse) GetSubSystems() string {
 	if m != nil {
@@ -3564,7 +4388,7 @@ type PayReqString struct {
 func (m *PayReqString) Reset()                    { *m = PayReqString{} }
 func (m *PayReqString) String() string            { return proto.CompactTextString(m) }
 func (*PayReqString) ProtoMessage()               {}
-func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+func (*PayReqString) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
 
 func (m *PayReqString) GetPayReq() string {
 	if m != nil {
@@ -3588,7 +4412,7 @@ type PayReq struct {
 func (m *PayReq) Reset()                    { *m = PayReq{} }
 func (m *PayReq) String() string            { return proto.CompactTextString(m) }
 func (*PayReq) ProtoMessage()               {}
-func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{88} }
+func (*PayReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
 
 func (m *PayReq) GetDestination() string {
 	if m != nil {
@@ -3659,7 +4483,7 @@ type FeeReportRequest struct {
 func (m *FeeReportRequest) Reset()                    { *m = FeeReportRequest{} }
 func (m *FeeReportRequest) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportRequest) ProtoMessage()               {}
-func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{89} }
+func (*FeeReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
 
 type ChannelFeeReport struct {
 	// / The channel that this fee report belongs to.
@@ -3675,7 +4499,7 @@ type ChannelFeeReport struct {
 func (m *ChannelFeeReport) Reset()                    { *m = ChannelFeeReport{} }
 func (m *ChannelFeeReport) String() string            { return proto.CompactTextString(m) }
 func (*ChannelFeeReport) ProtoMessage()               {}
-func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{90} }
+func (*ChannelFeeReport) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
 
 func (m *ChannelFeeReport) GetChanPoint() string {
 	if m != nil {
@@ -3719,7 +4543,7 @@ type FeeReportResponse struct {
 func (m *FeeReportResponse) Reset()                    { *m = FeeReportResponse{} }
 func (m *FeeReportResponse) String() string            { return proto.CompactTextString(m) }
 func (*FeeReportResponse) ProtoMessage()               {}
-func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{91} }
+func (*FeeReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
 
 func (m *FeeReportResponse) GetChannelFees() []*ChannelFeeReport {
 	if m != nil {
@@ -3760,12 +4584,14 @@ type PolicyUpdateRequest struct {
 	FeeRate float64 `protobuf:"fixed64,4,opt,name=fee_rate" json:"fee_rate,omitempty"`
 	// / The required timelock delta for HTLCs forwarded over the channel.
 	TimeLockDelta uint32 `protobuf:"varint,5,opt,name=time_lock_delta" json:"time_lock_delta,omitempty"`
+	// / If set, the maximum HTLC size in milli-satoshis. If unset, the maximum HTLC will be unchanged.
+	MaxHtlcMsat uint64 `protobuf:"varint,6,opt,name=max_htlc_msat" json:"max_htlc_msat,omitempty"`
 }
 
 func (m *PolicyUpdateRequest) Reset()                    { *m = PolicyUpdateRequest{} }
 func (m *PolicyUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateRequest) ProtoMessage()               {}
-func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{92} }
+func (*PolicyUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
 
 type isPolicyUpdateRequest_Scope interface{ isPolicyUpdateRequest_Scope() }
 
@@ -3821,6 +4647,13 @@ func (m *PolicyUpdateRequest) GetTimeLockDelta() uint32 {
 	return 0
 }
 
+func (m *PolicyUpdateRequest) GetMaxHtlcMsat() uint64 {
+	if m != nil {
+		return m.MaxHtlcMsat
+	}
+	return 0
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PolicyUpdateRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _PolicyUpdateRequest_OneofMarshaler, _PolicyUpdateRequest_OneofUnmarshaler, _PolicyUpdateRequest_OneofSizer, []interface{}{
@@ -3900,7 +4733,7 @@ type PolicyUpdateResponse struct {
 func (m *PolicyUpdateResponse) Reset()                    { *m = PolicyUpdateResponse{} }
 func (m *PolicyUpdateResponse) String() string            { return proto.CompactTextString(m) }
 func (*PolicyUpdateResponse) ProtoMessage()               {}
-func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{93} }
+func (*PolicyUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
 
 type ForwardingHistoryRequest struct {
 	// / Start time is the starting point of the forwarding history request. All records beyond this point will be included, respecting the end time, and the index offset.
@@ -3916,7 +4749,7 @@ type ForwardingHistoryRequest struct {
 func (m *ForwardingHistoryRequest) Reset()                    { *m = ForwardingHistoryRequest{} }
 func (m *ForwardingHistoryRequest) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryRequest) ProtoMessage()               {}
-func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{94} }
+func (*ForwardingHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{97} }
 
 func (m *ForwardingHistoryRequest) GetStartTime() uint64 {
 	if m != nil {
@@ -3959,12 +4792,18 @@ type ForwardingEvent struct {
 	AmtOut uint64 `protobuf:"varint,6,opt,name=amt_out" json:"amt_out,omitempty"`
 	// / The total fee that this payment circuit carried.
 	Fee uint64 `protobuf:"varint,7,opt,name=fee" json:"fee,omitempty"`
+	// / The total fee in milli-satoshis that this payment circuit carried.
+	FeeMsat uint64 `protobuf:"varint,8,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The total amount in milli-satoshis of the incoming HTLC that created half the circuit.
+	AmtInMsat uint64 `protobuf:"varint,9,opt,name=amt_in_msat" json:"amt_in_msat,omitempty"`
+	// / The total amount in milli-satoshis of the outgoing HTLC that created the second half of the circuit.
+	AmtOutMsat uint64 `protobuf:"varint,10,opt,name=amt_out_msat" json:"amt_out_msat,omitempty"`
 }
 
 func (m *ForwardingEvent) Reset()                    { *m = ForwardingEvent{} }
 func (m *ForwardingEvent) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingEvent) ProtoMessage()               {}
-func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{95} }
+func (*ForwardingEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{98} }
 
 func (m *ForwardingEvent) GetTimestamp() uint64 {
 	if m != nil {
@@ -4008,6 +4847,27 @@ func (m *ForwardingEvent) GetFee() uint64 {
 	return 0
 }
 
+func (m *ForwardingEvent) GetFeeMsat() uint64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetAmtInMsat() uint64 {
+	if m != nil {
+		return m.AmtInMsat
+	}
+	return 0
+}
+
+func (m *ForwardingEvent) GetAmtOutMsat() uint64 {
+	if m != nil {
+		return m.AmtOutMsat
+	}
+	return 0
+}
+
 type ForwardingHistoryResponse struct {
 	// / A list of forwarding events from the time slice of the time series specified in the request.
 	ForwardingEvents []*ForwardingEvent `protobuf:"bytes,1,rep,name=forwarding_events" json:"forwarding_events,omitempty"`
@@ -4018,7 +4878,7 @@ type ForwardingHistoryResponse struct {
 func (m *ForwardingHistoryResponse) Reset()                    { *m = ForwardingHistoryResponse{} }
 func (m *ForwardingHistoryResponse) String() string            { return proto.CompactTextString(m) }
 func (*ForwardingHistoryResponse) ProtoMessage()               {}
-func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{96} }
+func (*ForwardingHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{99} }
 
 func (m *ForwardingHistoryResponse) GetForwardingEvents() []*ForwardingEvent {
 	if m != nil {
@@ -4034,1944 +4894,5212 @@ func (m *ForwardingHistoryResponse) GetLastOffsetIndex() uint32 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
-	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
-	proto.RegisterType((*InitWalletRequest)(nil), "lnrpc.InitWalletRequest")
-	proto.RegisterType((*InitWalletResponse)(nil), "lnrpc.InitWalletResponse")
-	proto.RegisterType((*UnlockWalletRequest)(nil), "lnrpc.UnlockWalletRequest")
-	proto.RegisterType((*UnlockWalletResponse)(nil), "lnrpc.UnlockWalletResponse")
-	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
-	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
-	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
-	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
-	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
-	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
-	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
-	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
-	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
-	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
-	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
-	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
-	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
-	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
-	proto.RegisterType((*SignMessageRequest)(nil), "lnrpc.SignMessageRequest")
-	proto.RegisterType((*SignMessageResponse)(nil), "lnrpc.SignMessageResponse")
-	proto.RegisterType((*VerifyMessageRequest)(nil), "lnrpc.VerifyMessageRequest")
-	proto.RegisterType((*VerifyMessageResponse)(nil), "lnrpc.VerifyMessageResponse")
-	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
-	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
-	proto.RegisterType((*DisconnectPeerRequest)(nil), "lnrpc.DisconnectPeerRequest")
-	proto.RegisterType((*DisconnectPeerResponse)(nil), "lnrpc.DisconnectPeerResponse")
-	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
-	proto.RegisterType((*Channel)(nil), "lnrpc.Channel")
-	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
-	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
-	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
-	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
-	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
-	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
-	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
-	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
-	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
-	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
-	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
-	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
-	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
-	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
-	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
-	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
-	proto.RegisterType((*PendingChannelsRequest)(nil), "lnrpc.PendingChannelsRequest")
-	proto.RegisterType((*PendingChannelsResponse)(nil), "lnrpc.PendingChannelsResponse")
-	proto.RegisterType((*PendingChannelsResponse_PendingChannel)(nil), "lnrpc.PendingChannelsResponse.PendingChannel")
-	proto.RegisterType((*PendingChannelsResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelsResponse.PendingOpenChannel")
-	proto.RegisterType((*PendingChannelsResponse_ClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ClosedChannel")
-	proto.RegisterType((*PendingChannelsResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ForceClosedChannel")
-	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
-	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
-	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
-	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
-	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
-	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
-	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
-	proto.RegisterType((*Route)(nil), "lnrpc.Route")
-	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
-	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
-	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
-	proto.RegisterType((*NodeAddress)(nil), "lnrpc.NodeAddress")
-	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
-	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
-	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
-	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
-	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
-	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
-	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
-	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
-	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
-	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
-	proto.RegisterType((*GraphTopologyUpdate)(nil), "lnrpc.GraphTopologyUpdate")
-	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
-	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
-	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
-	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
-	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
-	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
-	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
-	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
-	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
-	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
-	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
-	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
-	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
-	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
-	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
-	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
-	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
-	proto.RegisterType((*PayReq)(nil), "lnrpc.PayReq")
-	proto.RegisterType((*FeeReportRequest)(nil), "lnrpc.FeeReportRequest")
-	proto.RegisterType((*ChannelFeeReport)(nil), "lnrpc.ChannelFeeReport")
-	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
-	proto.RegisterType((*PolicyUpdateRequest)(nil), "lnrpc.PolicyUpdateRequest")
-	proto.RegisterType((*PolicyUpdateResponse)(nil), "lnrpc.PolicyUpdateResponse")
-	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
-	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
-	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
-	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+type PaymentPolicy struct {
+	// / The hex-encoded payment hash of the payment this policy applies to.
+	PaymentHash string `protobuf:"bytes,1,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// / The maximum total fee in milli-satoshis that may be paid to route the payment. If a base fee or fee rate is set, this caps the proportional fee unless zero. If neither of the fees is set, the fee is unbounded.
+	FeeMsat int64 `protobuf:"varint,2,opt,name=fee_msat" json:"fee_msat,omitempty"`
+	// / The fixed part of the maximum fee in milli-satoshis.
+	BaseFeeMsat int64 `protobuf:"varint,3,opt,name=base_fee_msat" json:"base_fee_msat,omitempty"`
+	// / The proportional part of the maximum fee, expressed in parts per million of the payment amount.
+	FeeRatePpm int64 `protobuf:"varint,4,opt,name=fee_rate_ppm" json:"fee_rate_ppm,omitempty"`
+	// / The block height at which this policy expires. Zero if the policy doesn't expire by height.
+	ExpiryHeight uint32 `protobuf:"varint,5,opt,name=expiry_height" json:"expiry_height,omitempty"`
+	// / The unix timestamp at which this policy expires. Zero if the policy doesn't expire by time.
+	ExpiryTime int64 `protobuf:"varint,6,opt,name=expiry_time" json:"expiry_time,omitempty"`
+	// / The maximum cumulative amount in milli-satoshis, including fees, that may be spent on payments governed by this policy. Zero if the budget is unlimited.
+	BudgetMsat int64 `protobuf:"varint,7,opt,name=budget_msat" json:"budget_msat,omitempty"`
+	// / The cumulative amount in milli-satoshis, including fees, reserved for payments governed by this policy so far. Ignored when adding a policy.
+	SpentToDateMsat int64 `protobuf:"varint,8,opt,name=spent_to_date_msat" json:"spent_to_date_msat,omitempty"`
+	// / The minimum payment amount in milli-satoshis this policy applies to.
+	MinAmtMsat int64 `protobuf:"varint,9,opt,name=min_amt_msat" json:"min_amt_msat,omitempty"`
+	// / The maximum payment amount in milli-satoshis this policy applies to. Zero if there is no upper bound.
+	MaxAmtMsat int64 `protobuf:"varint,10,opt,name=max_amt_msat" json:"max_amt_msat,omitempty"`
+	// / The maximum total time lock delta, relative to the current block height, that a route for the payment may impose. Zero if the time lock is unbounded.
+	MaxCltvDelta uint32 `protobuf:"varint,11,opt,name=max_cltv_delta" json:"max_cltv_delta,omitempty"`
+	// / An optional free-form description of the policy, e.g. the reason it was created.
+	Label string `protobuf:"bytes,12,opt,name=label" json:"label,omitempty"`
+	// / If true, all payments governed by this policy are rejected outright, regardless of their fee.
+	Deny bool `protobuf:"varint,13,opt,name=deny" json:"deny,omitempty"`
+	// / If non-zero, payments governed by this policy may only leave through the channel with this short channel ID.
+	OutgoingChanId uint64 `protobuf:"varint,14,opt,name=outgoing_chan_id" json:"outgoing_chan_id,omitempty"`
+	// / The number of payments governed by this policy that were rejected as the route found for them exceeded the fee limit. Ignored when adding a policy.
+	FeeRejections uint32 `protobuf:"varint,15,opt,name=fee_rejections" json:"fee_rejections,omitempty"`
+	// / The lowest total fee in milli-satoshis among the routes rejected for exceeding the fee limit of this policy. Ignored when adding a policy.
+	CheapestRejectedFeeMsat int64 `protobuf:"varint,16,opt,name=cheapest_rejected_fee_msat" json:"cheapest_rejected_fee_msat,omitempty"`
+}
+
+func (m *PaymentPolicy) Reset()                    { *m = PaymentPolicy{} }
+func (m *PaymentPolicy) String() string            { return proto.CompactTextString(m) }
+func (*PaymentPolicy) ProtoMessage()               {}
+func (*PaymentPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{100} }
+
+func (m *PaymentPolicy) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for WalletUnlocker service
-
-type WalletUnlockerClient interface {
-	// *
-	// GenSeed is the first method that should be used to instantiate a new lnd
-	// instance. This method allows a caller to generate a new aezeed cipher seed
-	// given an optional passphrase. If provided, the passphrase will be necessary
-	// to decrypt the cipherseed to expose the internal wallet seed.
-	//
-	// Once the cipherseed is obtained and verified by the user, the InitWallet
-	// method should be used to commit the newly generated seed, and create the
-	// wallet.
-	GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error)
-	// *
-	// InitWallet is used when lnd is starting up for the first time to fully
-	// initialize the daemon and its internal wallet. At the very least a wallet
-	// password must be provided. This will be used to encrypt sensitive material
-	// on disk.
-	//
-	// In the case of a recovery scenario, the user can also specify their aezeed
-	// mnemonic and passphrase. If set, then the daemon will use this prior state
-	// to initialize its internal wallet.
-	//
-	// Alternatively, this can be used along with the GenSeed RPC to obtain a
-	// seed, then present it to the user. Once it has been verified by the user,
-	// the seed can be fed into this RPC in order to commit the new wallet.
-	InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error)
-	// * lncli: `unlock`
-	// UnlockWallet is used at startup of lnd to provide a password to unlock
-	// the wallet database.
-	UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error)
+func (m *PaymentPolicy) GetFeeMsat() int64 {
+	if m != nil {
+		return m.FeeMsat
+	}
+	return 0
 }
 
-type walletUnlockerClient struct {
-	cc *grpc.ClientConn
+func (m *PaymentPolicy) GetBaseFeeMsat() int64 {
+	if m != nil {
+		return m.BaseFeeMsat
+	}
+	return 0
 }
 
-func NewWalletUnlockerClient(cc *grpc.ClientConn) WalletUnlockerClient {
-	return &walletUnlockerClient{cc}
+func (m *PaymentPolicy) GetFeeRatePpm() int64 {
+	if m != nil {
+		return m.FeeRatePpm
+	}
+	return 0
 }
 
-func (c *walletUnlockerClient) GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error) {
-	out := new(GenSeedResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/GenSeed", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PaymentPolicy) GetExpiryHeight() uint32 {
+	if m != nil {
+		return m.ExpiryHeight
 	}
-	return out, nil
+	return 0
 }
 
-func (c *walletUnlockerClient) InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error) {
-	out := new(InitWalletResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/InitWallet", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PaymentPolicy) GetExpiryTime() int64 {
+	if m != nil {
+		return m.ExpiryTime
 	}
-	return out, nil
+	return 0
 }
 
-func (c *walletUnlockerClient) UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error) {
-	out := new(UnlockWalletResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/UnlockWallet", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PaymentPolicy) GetBudgetMsat() int64 {
+	if m != nil {
+		return m.BudgetMsat
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for WalletUnlocker service
-
-type WalletUnlockerServer interface {
-	// *
-	// GenSeed is the first method that should be used to instantiate a new lnd
-	// instance. This method allows a caller to generate a new aezeed cipher seed
-	// given an optional passphrase. If provided, the passphrase will be necessary
-	// to decrypt the cipherseed to expose the internal wallet seed.
-	//
-	// Once the cipherseed is obtained and verified by the user, the InitWallet
-	// method should be used to commit the newly generated seed, and create the
-	// wallet.
-	GenSeed(context.Context, *GenSeedRequest) (*GenSeedResponse, error)
-	// *
-	// InitWallet is used when lnd is starting up for the first time to fully
-	// initialize the daemon and its internal wallet. At the very least a wallet
-	// password must be provided. This will be used to encrypt sensitive material
-	// on disk.
-	//
-	// In the case of a recovery scenario, the user can also specify their aezeed
-	// mnemonic and passphrase. If set, then the daemon will use this prior state
-	// to initialize its internal wallet.
-	//
-	// Alternatively, this can be used along with the GenSeed RPC to obtain a
-	// seed, then present it to the user. Once it has been verified by the user,
-	// the seed can be fed into this RPC in order to commit the new wallet.
-	InitWallet(context.Context, *InitWalletRequest) (*InitWalletResponse, error)
-	// * lncli: `unlock`
-	// UnlockWallet is used at startup of lnd to provide a password to unlock
-	// the wallet database.
-	UnlockWallet(context.Context, *UnlockWalletRequest) (*UnlockWalletResponse, error)
+func (m *PaymentPolicy) GetSpentToDateMsat() int64 {
+	if m != nil {
+		return m.SpentToDateMsat
+	}
+	return 0
 }
 
-func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
-	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+func (m *PaymentPolicy) GetMinAmtMsat() int64 {
+	if m != nil {
+		return m.MinAmtMsat
+	}
+	return 0
 }
 
-func _WalletUnlocker_GenSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GenSeedRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).GenSeed(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/GenSeed",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).GenSeed(ctx, req.(*GenSeedRequest))
+func (m *PaymentPolicy) GetMaxAmtMsat() int64 {
+	if m != nil {
+		return m.MaxAmtMsat
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _WalletUnlocker_InitWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InitWalletRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).InitWallet(ctx, in)
+func (m *PaymentPolicy) GetMaxCltvDelta() uint32 {
+	if m != nil {
+		return m.MaxCltvDelta
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/InitWallet",
+	return 0
+}
+
+func (m *PaymentPolicy) GetLabel() string {
+	if m != nil {
+		return m.Label
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).InitWallet(ctx, req.(*InitWalletRequest))
+	return ""
+}
+
+func (m *PaymentPolicy) GetDeny() bool {
+	if m != nil {
+		return m.Deny
 	}
-	return interceptor(ctx, in, info, handler)
+	return false
 }
 
-func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnlockWalletRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *PaymentPolicy) GetOutgoingChanId() uint64 {
+	if m != nil {
+		return m.OutgoingChanId
 	}
-	if interceptor == nil {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+	return 0
+}
+
+func (m *PaymentPolicy) GetFeeRejections() uint32 {
+	if m != nil {
+		return m.FeeRejections
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+	return 0
+}
+
+func (m *PaymentPolicy) GetCheapestRejectedFeeMsat() int64 {
+	if m != nil {
+		return m.CheapestRejectedFeeMsat
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+	return 0
+}
+
+type AddPolicyResponse struct {
+}
+
+func (m *AddPolicyResponse) Reset()                    { *m = AddPolicyResponse{} }
+func (m *AddPolicyResponse) String() string            { return proto.CompactTextString(m) }
+func (*AddPolicyResponse) ProtoMessage()               {}
+func (*AddPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{101} }
+
+type ListPoliciesRequest struct {
+}
+
+func (m *ListPoliciesRequest) Reset()                    { *m = ListPoliciesRequest{} }
+func (m *ListPoliciesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListPoliciesRequest) ProtoMessage()               {}
+func (*ListPoliciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{102} }
+
+type ListPoliciesResponse struct {
+	// / The list of fee policies.
+	Policies []*PaymentPolicy `protobuf:"bytes,1,rep,name=policies" json:"policies,omitempty"`
+}
+
+func (m *ListPoliciesResponse) Reset()                    { *m = ListPoliciesResponse{} }
+func (m *ListPoliciesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListPoliciesResponse) ProtoMessage()               {}
+func (*ListPoliciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{103} }
+
+func (m *ListPoliciesResponse) GetPolicies() []*PaymentPolicy {
+	if m != nil {
+		return m.Policies
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "lnrpc.WalletUnlocker",
-	HandlerType: (*WalletUnlockerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GenSeed",
-			Handler:    _WalletUnlocker_GenSeed_Handler,
-		},
-		{
-			MethodName: "InitWallet",
-			Handler:    _WalletUnlocker_InitWallet_Handler,
-		},
-		{
-			MethodName: "UnlockWallet",
-			Handler:    _WalletUnlocker_UnlockWallet_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "rpc.proto",
+type PolicyPaymentHash struct {
+	// / The hex-encoded payment hash of the target policy.
+	PaymentHashStr string `protobuf:"bytes,1,opt,name=payment_hash_str" json:"payment_hash_str,omitempty"`
+	// / The payment hash of the target policy.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
 }
 
-// Client API for Lightning service
+func (m *PolicyPaymentHash) Reset()                    { *m = PolicyPaymentHash{} }
+func (m *PolicyPaymentHash) String() string            { return proto.CompactTextString(m) }
+func (*PolicyPaymentHash) ProtoMessage()               {}
+func (*PolicyPaymentHash) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{104} }
 
-type LightningClient interface {
-	// * lncli: `walletbalance`
-	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
-	// confirmed unspent outputs and all unconfirmed unspent outputs under control
-	// of the wallet.
-	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
-	// * lncli: `channelbalance`
-	// ChannelBalance returns the total funds available across all open channels
-	// in satoshis.
-	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
-	// * lncli: `listchaintxns`
-	// GetTransactions returns a list describing all the known transactions
-	// relevant to the wallet.
-	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
-	// * lncli: `sendcoins`
-	// SendCoins executes a request to send coins to a particular address. Unlike
-	// SendMany, this RPC call only allows creating a single output at a time. If
-	// neither target_conf, or sat_per_byte are set, then the internal wallet will
-	// consult its fee model to determine a fee for the default confirmation
-	// target.
-	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
-	// *
-	// SubscribeTransactions creates a uni-directional stream from the server to
-	// the client in which any newly discovered transactions relevant to the
-	// wallet are sent over.
-	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
-	// * lncli: `sendmany`
-	// SendMany handles a request for a transaction that creates multiple specified
-	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
-	// the internal wallet will consult its fee model to determine a fee for the
-	// default confirmation target.
-	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
-	// * lncli: `newaddress`
-	// NewAddress creates a new address under control of the local wallet.
-	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	// *
-	// NewWitnessAddress creates a new witness address under control of the local wallet.
-	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
-	// * lncli: `signmessage`
-	// SignMessage signs a message with this node's private key. The returned
-	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
-	// only the message digest and signature are needed for verification.
-	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
-	// * lncli: `verifymessage`
-	// VerifyMessage verifies a signature over a msg. The signature must be
-	// zbase32 encoded and signed by an active node in the resident node's
-	// channel database. In addition to returning the validity of the signature,
-	// VerifyMessage also returns the recovered pubkey from the signature.
-	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
-	// * lncli: `connect`
-	// ConnectPeer attempts to establish a connection to a remote peer. This is at
-	// the networking level, and is used for communication between nodes. This is
-	// distinct from establishing a channel with a peer.
-	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
-	// * lncli: `disconnect`
-	// DisconnectPeer attempts to disconnect one peer from another identified by a
-	// given pubKey. In the case that we currently have a pending or active channel
-	// with the target peer, then this action will be not be allowed.
-	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
-	// * lncli: `listpeers`
-	// ListPeers returns a verbose listing of all currently active peers.
-	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
-	// * lncli: `getinfo`
-	// GetInfo returns general information concerning the lightning node including
-	// it's identity pubkey, alias, the chains it is connected to, and information
-	// concerning the number of open+pending channels.
-	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
-	// * lncli: `pendingchannels`
-	// PendingChannels returns a list of all the channels that are currently
-	// considered "pending". A channel is pending if it has finished the funding
-	// workflow and is waiting for confirmations for the funding txn, or is in the
-	// process of closure, either initiated cooperatively or non-cooperatively.
-	PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error)
-	// * lncli: `listchannels`
-	// ListChannels returns a description of all the open channels that this node
-	// is a participant in.
-	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
-	// *
-	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
-	// call is meant to be consumed by clients to the REST proxy. As with all
-	// other sync calls, all byte slices are intended to be populated as hex
-	// encoded strings.
-	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
-	// * lncli: `openchannel`
-	// OpenChannel attempts to open a singly funded channel specified in the
-	// request to a remote peer. Users are able to specify a target number of
-	// blocks that the funding transaction should be confirmed in, or a manual fee
-	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
-	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
-	// * lncli: `closechannel`
-	// CloseChannel attempts to close an active channel identified by its channel
-	// outpoint (ChannelPoint). The actions of this method can additionally be
-	// augmented to attempt a force close after a timeout period in the case of an
-	// inactive peer. If a non-force close (cooperative closure) is requested,
-	// then the user can specify either a target number of blocks until the
-	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
-	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
-	// * lncli: `sendpayment`
-	// SendPayment dispatches a bi-directional streaming RPC for sending payments
-	// through the Lightning Network. A single RPC invocation creates a persistent
-	// bi-directional stream allowing clients to rapidly send payments through the
-	// Lightning Network with a single persistent connection.
-	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
-	// *
-	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
-	// This RPC is intended to be consumed by clients of the REST proxy.
-	// Additionally, this RPC expects the destination's public key and the payment
-	// hash (if any) to be encoded as hex strings.
-	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
-	// * lncli: `addinvoice`
-	// AddInvoice attempts to add a new invoice to the invoice database. Any
-	// duplicated invoices are rejected, therefore all invoices *must* have a
-	// unique payment preimage.
-	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
-	// * lncli: `listinvoices`
-	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
-	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
-	// * lncli: `lookupinvoice`
-	// LookupInvoice attempts to look up an invoice according to its payment hash.
-	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
-	// returned.
-	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
-	// *
-	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
-	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
-	// * lncli: `decodepayreq`
-	// DecodePayReq takes an encoded payment request string and attempts to decode
-	// it, returning a full description of the conditions encoded within the
-	// payment request.
-	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
-	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
-	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
-	// *
-	// DeleteAllPayments deletes all outgoing payments from DB.
-	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
-	// * lncli: `describegraph`
-	// DescribeGraph returns a description of the latest graph state from the
-	// point of view of the node. The graph information is partitioned into two
-	// components: all the nodes/vertexes, and all the edges that connect the
-	// vertexes themselves.  As this is a directed graph, the edges also contain
-	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
-	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
-	// * lncli: `getchaninfo`
-	// GetChanInfo returns the latest authenticated network announcement for the
-	// given channel identified by its channel ID: an 8-byte integer which
-	// uniquely identifies the location of transaction's funding output within the
-	// blockchain.
-	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
-	// * lncli: `getnodeinfo`
-	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
-	// channel information for the specified node identified by its public key.
-	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
-	// * lncli: `queryroutes`
-	// QueryRoutes attempts to query the daemon's Channel Router for a possible
-	// route to a target destination capable of carrying a specific amount of
-	// satoshis. The retuned route contains the full details required to craft and
-	// send an HTLC, also including the necessary information that should be
-	// present within the Sphinx packet encapsulated within the HTLC.
-	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
-	// * lncli: `getnetworkinfo`
-	// GetNetworkInfo returns some basic stats about the known channel graph from
-	// the point of view of the node.
-	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
-	// * lncli: `stop`
-	// StopDaemon will send a shutdown request to the interrupt handler, triggering
-	// a graceful shutdown of the daemon.
-	StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
-	// *
-	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
-	// receive notifications upon any changes to the channel graph topology from
-	// the point of view of the responding node. Events notified include: new
-	// nodes coming online, nodes updating their authenticated attributes, new
-	// channels being advertised, updates in the routing policy for a directional
-	// channel edge, and when channels are closed on-chain.
-	SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error)
-	// * lncli: `debuglevel`
-	// DebugLevel allows a caller to programmatically set the logging verbosity of
-	// lnd. The logging can be targeted according to a coarse daemon-wide logging
-	// level, or in a granular fashion to specify the logging for a target
-	// sub-system.
-	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
-	// * lncli: `feereport`
-	// FeeReport allows the caller to obtain a report detailing the current fee
-	// schedule enforced by the node globally for each channel.
-	FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error)
-	// * lncli: `updatechanpolicy`
-	// UpdateChannelPolicy allows the caller to update the fee schedule and
-	// channel policies for all channels globally, or a particular channel.
-	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
-	// * lncli: `fwdinghistory`
-	// ForwardingHistory allows the caller to query the htlcswitch for a record of
-	// all HTLC's forwarded within the target time range, and integer offset
-	// within that time range. If no time-range is specified, then the first chunk
-	// of the past 24 hrs of forwarding history are returned.
-	//
-	// A list of forwarding events are returned. The size of each forwarding event
-	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-	// As a result each message can only contain 50k entries.  Each response has
-	// the index offset of the last entry. The index offset can be provided to the
-	// request to allow the caller to skip a series of records.
-	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+func (m *PolicyPaymentHash) GetPaymentHashStr() string {
+	if m != nil {
+		return m.PaymentHashStr
+	}
+	return ""
 }
 
-type lightningClient struct {
-	cc *grpc.ClientConn
+func (m *PolicyPaymentHash) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
 }
 
-func NewLightningClient(cc *grpc.ClientConn) LightningClient {
-	return &lightningClient{cc}
+type DeletePolicyResponse struct {
 }
 
-func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
-	out := new(WalletBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *DeletePolicyResponse) Reset()                    { *m = DeletePolicyResponse{} }
+func (m *DeletePolicyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeletePolicyResponse) ProtoMessage()               {}
+func (*DeletePolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{105} }
+
+type SetDefaultPolicyResponse struct {
 }
 
-func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
-	out := new(ChannelBalanceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *SetDefaultPolicyResponse) Reset()                    { *m = SetDefaultPolicyResponse{} }
+func (m *SetDefaultPolicyResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetDefaultPolicyResponse) ProtoMessage()               {}
+func (*SetDefaultPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{106} }
+
+type DefaultPolicyRequest struct {
 }
 
-func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
-	out := new(TransactionDetails)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *DefaultPolicyRequest) Reset()                    { *m = DefaultPolicyRequest{} }
+func (m *DefaultPolicyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DefaultPolicyRequest) ProtoMessage()               {}
+func (*DefaultPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{107} }
+
+type DeleteDefaultPolicyRequest struct {
 }
 
-func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
-	out := new(SendCoinsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *DeleteDefaultPolicyRequest) Reset()                    { *m = DeleteDefaultPolicyRequest{} }
+func (m *DeleteDefaultPolicyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDefaultPolicyRequest) ProtoMessage()               {}
+func (*DeleteDefaultPolicyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{108} }
+
+type DeleteDefaultPolicyResponse struct {
 }
 
-func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSubscribeTransactionsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
-}
+func (m *DeleteDefaultPolicyResponse) Reset()                    { *m = DeleteDefaultPolicyResponse{} }
+func (m *DeleteDefaultPolicyResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteDefaultPolicyResponse) ProtoMessage()               {}
+func (*DeleteDefaultPolicyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{109} }
 
-type Lightning_SubscribeTransactionsClient interface {
-	Recv() (*Transaction, error)
-	grpc.ClientStream
+type ExportPoliciesRequest struct {
 }
 
-type lightningSubscribeTransactionsClient struct {
-	grpc.ClientStream
+func (m *ExportPoliciesRequest) Reset()                    { *m = ExportPoliciesRequest{} }
+func (m *ExportPoliciesRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportPoliciesRequest) ProtoMessage()               {}
+func (*ExportPoliciesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{110} }
+
+type PolicyBackup struct {
+	// / The fee policies, encoded as one JSON object per line.
+	Policies []byte `protobuf:"bytes,1,opt,name=policies,proto3" json:"policies,omitempty"`
 }
 
-func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
-	m := new(Transaction)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *PolicyBackup) Reset()                    { *m = PolicyBackup{} }
+func (m *PolicyBackup) String() string            { return proto.CompactTextString(m) }
+func (*PolicyBackup) ProtoMessage()               {}
+func (*PolicyBackup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{111} }
+
+func (m *PolicyBackup) GetPolicies() []byte {
+	if m != nil {
+		return m.Policies
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
-	out := new(SendManyResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ImportPoliciesResponse struct {
+	// / The number of fee policies imported from the backup.
+	NumImported uint32 `protobuf:"varint,1,opt,name=num_imported" json:"num_imported,omitempty"`
 }
 
-func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ImportPoliciesResponse) Reset()                    { *m = ImportPoliciesResponse{} }
+func (m *ImportPoliciesResponse) String() string            { return proto.CompactTextString(m) }
+func (*ImportPoliciesResponse) ProtoMessage()               {}
+func (*ImportPoliciesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{112} }
+
+func (m *ImportPoliciesResponse) GetNumImported() uint32 {
+	if m != nil {
+		return m.NumImported
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
-	out := new(NewAddressResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PolicyAuditLogRequest struct {
+	// / If set, only records made at or after this unix timestamp are returned.
+	StartTime int64 `protobuf:"varint,1,opt,name=start_time" json:"start_time,omitempty"`
+	// / If set, only records made at or before this unix timestamp are returned.
+	EndTime int64 `protobuf:"varint,2,opt,name=end_time" json:"end_time,omitempty"`
 }
 
-func (c *lightningClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
-	out := new(SignMessageResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignMessage", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditLogRequest) Reset()                    { *m = PolicyAuditLogRequest{} }
+func (m *PolicyAuditLogRequest) String() string            { return proto.CompactTextString(m) }
+func (*PolicyAuditLogRequest) ProtoMessage()               {}
+func (*PolicyAuditLogRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{113} }
+
+func (m *PolicyAuditLogRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
-	out := new(VerifyMessageResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyMessage", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditLogRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
-	out := new(ConnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PolicyAuditRecord struct {
+	// / The unix timestamp at which the decision was made.
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	// / The hex-encoded payment hash of the payment the decision was made for.
+	PaymentHash string `protobuf:"bytes,2,opt,name=payment_hash" json:"payment_hash,omitempty"`
+	// / The total fee in milli-satoshis of the route chosen for the payment. Zero if the payment was rejected before a route was requested.
+	FeeRequestedMsat int64 `protobuf:"varint,3,opt,name=fee_requested_msat" json:"fee_requested_msat,omitempty"`
+	// / The maximum total fee in milli-satoshis the policy allowed for the payment. Zero if the payment was denied by the policy.
+	FeeAllowedMsat int64 `protobuf:"varint,4,opt,name=fee_allowed_msat" json:"fee_allowed_msat,omitempty"`
+	// / Whether the policy permitted the payment, or why it rejected it.
+	Decision PolicyAuditRecord_Decision `protobuf:"varint,5,opt,name=decision,enum=lnrpc.PolicyAuditRecord_Decision" json:"decision,omitempty"`
 }
 
-func (c *lightningClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
-	out := new(DisconnectPeerResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DisconnectPeer", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditRecord) Reset()                    { *m = PolicyAuditRecord{} }
+func (m *PolicyAuditRecord) String() string            { return proto.CompactTextString(m) }
+func (*PolicyAuditRecord) ProtoMessage()               {}
+func (*PolicyAuditRecord) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{114} }
+
+func (m *PolicyAuditRecord) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
-	out := new(ListPeersResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditRecord) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
 	}
-	return out, nil
+	return ""
 }
 
-func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
-	out := new(GetInfoResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditRecord) GetFeeRequestedMsat() int64 {
+	if m != nil {
+		return m.FeeRequestedMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
-	out := new(PendingChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditRecord) GetFeeAllowedMsat() int64 {
+	if m != nil {
+		return m.FeeAllowedMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
-	out := new(ListChannelsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *PolicyAuditRecord) GetDecision() PolicyAuditRecord_Decision {
+	if m != nil {
+		return m.Decision
 	}
-	return out, nil
+	return PolicyAuditRecord_PERMITTED
 }
 
-func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
-	out := new(ChannelPoint)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PolicyAuditLogResponse struct {
+	// / The audit records within the requested time range.
+	Records []*PolicyAuditRecord `protobuf:"bytes,1,rep,name=records" json:"records,omitempty"`
 }
 
-func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningOpenChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *PolicyAuditLogResponse) Reset()                    { *m = PolicyAuditLogResponse{} }
+func (m *PolicyAuditLogResponse) String() string            { return proto.CompactTextString(m) }
+func (*PolicyAuditLogResponse) ProtoMessage()               {}
+func (*PolicyAuditLogResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{115} }
+
+func (m *PolicyAuditLogResponse) GetRecords() []*PolicyAuditRecord {
+	if m != nil {
+		return m.Records
 	}
-	return x, nil
+	return nil
 }
 
-type Lightning_OpenChannelClient interface {
-	Recv() (*OpenStatusUpdate, error)
-	grpc.ClientStream
+type CompactDatabaseRequest struct {
 }
 
-type lightningOpenChannelClient struct {
-	grpc.ClientStream
+func (m *CompactDatabaseRequest) Reset()                    { *m = CompactDatabaseRequest{} }
+func (m *CompactDatabaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*CompactDatabaseRequest) ProtoMessage()               {}
+func (*CompactDatabaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{116} }
+
+type CompactDatabaseResponse struct {
 }
 
-func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
-	m := new(OpenStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *CompactDatabaseResponse) Reset()                    { *m = CompactDatabaseResponse{} }
+func (m *CompactDatabaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*CompactDatabaseResponse) ProtoMessage()               {}
+func (*CompactDatabaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{117} }
+
+type ExportDatabaseRequest struct {
 }
 
-func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningCloseChannelClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *ExportDatabaseRequest) Reset()                    { *m = ExportDatabaseRequest{} }
+func (m *ExportDatabaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportDatabaseRequest) ProtoMessage()               {}
+func (*ExportDatabaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{118} }
+
+type DatabaseChunk struct {
+	// / The next chunk of the database snapshot.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-type Lightning_CloseChannelClient interface {
-	Recv() (*CloseStatusUpdate, error)
-	grpc.ClientStream
+func (m *DatabaseChunk) Reset()                    { *m = DatabaseChunk{} }
+func (m *DatabaseChunk) String() string            { return proto.CompactTextString(m) }
+func (*DatabaseChunk) ProtoMessage()               {}
+func (*DatabaseChunk) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{119} }
+
+func (m *DatabaseChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-type lightningCloseChannelClient struct {
-	grpc.ClientStream
+type LabelChannelRequest struct {
+	// / The channel to attach the label to.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+	// / The label to attach to the channel. If empty, the channel's label is removed.
+	Label string `protobuf:"bytes,2,opt,name=label" json:"label,omitempty"`
 }
 
-func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
-	m := new(CloseStatusUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *LabelChannelRequest) Reset()                    { *m = LabelChannelRequest{} }
+func (m *LabelChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*LabelChannelRequest) ProtoMessage()               {}
+func (*LabelChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{120} }
+
+func (m *LabelChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
-	if err != nil {
-		return nil, err
+func (m *LabelChannelRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
 	}
-	x := &lightningSendPaymentClient{stream}
-	return x, nil
+	return ""
 }
 
-type Lightning_SendPaymentClient interface {
-	Send(*SendRequest) error
-	Recv() (*SendResponse, error)
-	grpc.ClientStream
+type LabelChannelResponse struct {
 }
 
-type lightningSendPaymentClient struct {
-	grpc.ClientStream
-}
+func (m *LabelChannelResponse) Reset()                    { *m = LabelChannelResponse{} }
+func (m *LabelChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*LabelChannelResponse) ProtoMessage()               {}
+func (*LabelChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{121} }
 
-func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
-	return x.ClientStream.SendMsg(m)
+type AMPSettlement struct {
+	// / The set ID shared by all HTLCs within the set.
+	SetId []byte `protobuf:"bytes,1,opt,name=set_id,proto3" json:"set_id,omitempty"`
+	// / The child preimages which settled each HTLC within the set.
+	ChildPreimages [][]byte `protobuf:"bytes,2,rep,name=child_preimages,proto3" json:"child_preimages,omitempty"`
+	// / The total amount paid by the HTLC set in milli-satoshis.
+	AmtPaidMsat int64 `protobuf:"varint,3,opt,name=amt_paid_msat" json:"amt_paid_msat,omitempty"`
+	// / When the HTLC set was settled.
+	SettleDate int64 `protobuf:"varint,4,opt,name=settle_date" json:"settle_date,omitempty"`
 }
 
-func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
-	m := new(SendResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
-}
+func (m *AMPSettlement) Reset()                    { *m = AMPSettlement{} }
+func (m *AMPSettlement) String() string            { return proto.CompactTextString(m) }
+func (*AMPSettlement) ProtoMessage()               {}
+func (*AMPSettlement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{122} }
 
-func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
-	out := new(SendResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AMPSettlement) GetSetId() []byte {
+	if m != nil {
+		return m.SetId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
-	out := new(AddInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AMPSettlement) GetChildPreimages() [][]byte {
+	if m != nil {
+		return m.ChildPreimages
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
-	out := new(ListInvoiceResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AMPSettlement) GetAmtPaidMsat() int64 {
+	if m != nil {
+		return m.AmtPaidMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
-	out := new(Invoice)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AMPSettlement) GetSettleDate() int64 {
+	if m != nil {
+		return m.SettleDate
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &lightningSubscribeInvoicesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type ChannelEventSubscription struct {
 }
 
-type Lightning_SubscribeInvoicesClient interface {
-	Recv() (*Invoice, error)
-	grpc.ClientStream
-}
+func (m *ChannelEventSubscription) Reset()                    { *m = ChannelEventSubscription{} }
+func (m *ChannelEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventSubscription) ProtoMessage()               {}
+func (*ChannelEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{123} }
 
-type lightningSubscribeInvoicesClient struct {
-	grpc.ClientStream
+type ChannelEventUpdate struct {
+	// / The lifecycle transition the channel went through
+	Type ChannelEventUpdate_UpdateType `protobuf:"varint,1,opt,name=type,enum=lnrpc.ChannelEventUpdate_UpdateType" json:"type,omitempty"`
+	// / The funding outpoint of the channel
+	ChannelPoint *ChannelPoint `protobuf:"bytes,2,opt,name=channel_point" json:"channel_point,omitempty"`
 }
 
-func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
-	m := new(Invoice)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ChannelEventUpdate) Reset()                    { *m = ChannelEventUpdate{} }
+func (m *ChannelEventUpdate) String() string            { return proto.CompactTextString(m) }
+func (*ChannelEventUpdate) ProtoMessage()               {}
+func (*ChannelEventUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{124} }
+
+func (m *ChannelEventUpdate) GetType() ChannelEventUpdate_UpdateType {
+	if m != nil {
+		return m.Type
 	}
-	return m, nil
+	return ChannelEventUpdate_PENDING_OPEN_CHANNEL
 }
 
-func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
-	out := new(PayReq)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelEventUpdate) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
-	out := new(ListPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type CircuitKey struct {
+	// / The short channel id of the channel the HTLC was received over
+	ChanId uint64 `protobuf:"varint,1,opt,name=chan_id" json:"chan_id,omitempty"`
+	// / The index of the HTLC within the channel it was received over
+	HtlcId uint64 `protobuf:"varint,2,opt,name=htlc_id" json:"htlc_id,omitempty"`
+}
+
+func (m *CircuitKey) Reset()                    { *m = CircuitKey{} }
+func (m *CircuitKey) String() string            { return proto.CompactTextString(m) }
+func (*CircuitKey) ProtoMessage()               {}
+func (*CircuitKey) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{125} }
+
+func (m *CircuitKey) GetChanId() uint64 {
+	if m != nil {
+		return m.ChanId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
-	out := new(DeleteAllPaymentsResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *CircuitKey) GetHtlcId() uint64 {
+	if m != nil {
+		return m.HtlcId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
-	out := new(ChannelGraph)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ForwardHtlcInterceptRequest struct {
+	// / The key of the incoming HTLC which is held by the interceptor
+	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key" json:"incoming_circuit_key,omitempty"`
+	// / The amount of the incoming HTLC in milli-satoshis
+	IncomingAmountMsat uint64 `protobuf:"varint,2,opt,name=incoming_amount_msat" json:"incoming_amount_msat,omitempty"`
+	// / The payment hash of the HTLC
+	PaymentHash []byte `protobuf:"bytes,3,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The channel the sender requested the HTLC to be forwarded over
+	OutgoingRequestedChanId uint64 `protobuf:"varint,4,opt,name=outgoing_requested_chan_id" json:"outgoing_requested_chan_id,omitempty"`
+	// / The amount to be forwarded in milli-satoshis
+	OutgoingAmountMsat uint64 `protobuf:"varint,5,opt,name=outgoing_amount_msat" json:"outgoing_amount_msat,omitempty"`
+	// / The absolute expiry height of the outgoing HTLC
+	OutgoingExpiry uint32 `protobuf:"varint,6,opt,name=outgoing_expiry" json:"outgoing_expiry,omitempty"`
+	// / The onion packet to be forwarded to the next hop
+	OnionBlob []byte `protobuf:"bytes,7,opt,name=onion_blob,proto3" json:"onion_blob,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptRequest) Reset()                    { *m = ForwardHtlcInterceptRequest{} }
+func (m *ForwardHtlcInterceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptRequest) ProtoMessage()               {}
+func (*ForwardHtlcInterceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{126} }
+
+func (m *ForwardHtlcInterceptRequest) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
-	out := new(ChannelEdge)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetIncomingAmountMsat() uint64 {
+	if m != nil {
+		return m.IncomingAmountMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
-	out := new(NodeInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error) {
-	out := new(QueryRoutesResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoutes", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetOutgoingRequestedChanId() uint64 {
+	if m != nil {
+		return m.OutgoingRequestedChanId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
-	out := new(NetworkInfo)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetOutgoingAmountMsat() uint64 {
+	if m != nil {
+		return m.OutgoingAmountMsat
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
-	out := new(StopResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetOutgoingExpiry() uint32 {
+	if m != nil {
+		return m.OutgoingExpiry
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
-	if err != nil {
-		return nil, err
+func (m *ForwardHtlcInterceptRequest) GetOnionBlob() []byte {
+	if m != nil {
+		return m.OnionBlob
 	}
-	x := &lightningSubscribeChannelGraphClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return nil
+}
+
+type ForwardHtlcInterceptResponse struct {
+	// / The key of the held HTLC to resolve
+	IncomingCircuitKey *CircuitKey `protobuf:"bytes,1,opt,name=incoming_circuit_key" json:"incoming_circuit_key,omitempty"`
+	// / Whether the HTLC is forwarded as usual, settled or failed back
+	Action ForwardHtlcInterceptResponse_Action `protobuf:"varint,2,opt,name=action,enum=lnrpc.ForwardHtlcInterceptResponse_Action" json:"action,omitempty"`
+	// / The preimage to settle the HTLC with, only used to settle it
+	Preimage []byte `protobuf:"bytes,3,opt,name=preimage,proto3" json:"preimage,omitempty"`
+}
+
+func (m *ForwardHtlcInterceptResponse) Reset()                    { *m = ForwardHtlcInterceptResponse{} }
+func (m *ForwardHtlcInterceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ForwardHtlcInterceptResponse) ProtoMessage()               {}
+func (*ForwardHtlcInterceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{127} }
+
+func (m *ForwardHtlcInterceptResponse) GetIncomingCircuitKey() *CircuitKey {
+	if m != nil {
+		return m.IncomingCircuitKey
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *ForwardHtlcInterceptResponse) GetAction() ForwardHtlcInterceptResponse_Action {
+	if m != nil {
+		return m.Action
 	}
-	return x, nil
+	return ForwardHtlcInterceptResponse_RESUME
 }
 
-type Lightning_SubscribeChannelGraphClient interface {
-	Recv() (*GraphTopologyUpdate, error)
-	grpc.ClientStream
+func (m *ForwardHtlcInterceptResponse) GetPreimage() []byte {
+	if m != nil {
+		return m.Preimage
+	}
+	return nil
 }
 
-type lightningSubscribeChannelGraphClient struct {
-	grpc.ClientStream
+type ChannelAcceptRequest struct {
+	// / The identity public key of the funder of the channel
+	NodePubkey []byte `protobuf:"bytes,1,opt,name=node_pubkey,proto3" json:"node_pubkey,omitempty"`
+	// / The hash of the genesis block of the chain the channel is opened on
+	ChainHash []byte `protobuf:"bytes,2,opt,name=chain_hash,proto3" json:"chain_hash,omitempty"`
+	// / The pending channel id the funder assigned to the channel
+	PendingChanId []byte `protobuf:"bytes,3,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// / The capacity of the channel in satoshis
+	FundingAmt uint64 `protobuf:"varint,4,opt,name=funding_amt" json:"funding_amt,omitempty"`
+	// / The amount pushed to us by the funder in milli-satoshis
+	PushAmt uint64 `protobuf:"varint,5,opt,name=push_amt" json:"push_amt,omitempty"`
+	// / The dust limit of the funder's commitment transaction in satoshis
+	DustLimit uint64 `protobuf:"varint,6,opt,name=dust_limit" json:"dust_limit,omitempty"`
+	// / The maximum value of HTLCs in flight we may offer in milli-satoshis
+	MaxValueInFlight uint64 `protobuf:"varint,7,opt,name=max_value_in_flight" json:"max_value_in_flight,omitempty"`
+	// / The reserve the funder requires us to keep in satoshis
+	ChannelReserve uint64 `protobuf:"varint,8,opt,name=channel_reserve" json:"channel_reserve,omitempty"`
+	// / The smallest HTLC the funder accepts in milli-satoshis
+	MinHtlc uint64 `protobuf:"varint,9,opt,name=min_htlc" json:"min_htlc,omitempty"`
+	// / The initial fee rate of the commitment transactions in sat/kw
+	FeePerKw uint64 `protobuf:"varint,10,opt,name=fee_per_kw" json:"fee_per_kw,omitempty"`
+	// / The delay of our commitment outputs the funder requires in blocks
+	CsvDelay uint32 `protobuf:"varint,11,opt,name=csv_delay" json:"csv_delay,omitempty"`
+	// / The maximum number of HTLCs we may offer the funder
+	MaxAcceptedHtlcs uint32 `protobuf:"varint,12,opt,name=max_accepted_htlcs" json:"max_accepted_htlcs,omitempty"`
+	// / The channel flags sent by the funder
+	ChannelFlags uint32 `protobuf:"varint,13,opt,name=channel_flags" json:"channel_flags,omitempty"`
+}
+
+func (m *ChannelAcceptRequest) Reset()                    { *m = ChannelAcceptRequest{} }
+func (m *ChannelAcceptRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelAcceptRequest) ProtoMessage()               {}
+func (*ChannelAcceptRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{128} }
+
+func (m *ChannelAcceptRequest) GetNodePubkey() []byte {
+	if m != nil {
+		return m.NodePubkey
+	}
+	return nil
 }
 
-func (x *lightningSubscribeChannelGraphClient) Recv() (*GraphTopologyUpdate, error) {
-	m := new(GraphTopologyUpdate)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetChainHash() []byte {
+	if m != nil {
+		return m.ChainHash
 	}
-	return m, nil
+	return nil
 }
 
-func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
-	out := new(DebugLevelResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugLevel", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
 	}
-	return out, nil
+	return nil
 }
 
-func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
-	out := new(FeeReportResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetFundingAmt() uint64 {
+	if m != nil {
+		return m.FundingAmt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error) {
-	out := new(PolicyUpdateResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelPolicy", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetPushAmt() uint64 {
+	if m != nil {
+		return m.PushAmt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
-	out := new(ForwardingHistoryResponse)
-	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *ChannelAcceptRequest) GetDustLimit() uint64 {
+	if m != nil {
+		return m.DustLimit
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for Lightning service
+func (m *ChannelAcceptRequest) GetMaxValueInFlight() uint64 {
+	if m != nil {
+		return m.MaxValueInFlight
+	}
+	return 0
+}
 
-type LightningServer interface {
-	// * lncli: `walletbalance`
-	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
-	// confirmed unspent outputs and all unconfirmed unspent outputs under control
-	// of the wallet.
-	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
-	// * lncli: `channelbalance`
-	// ChannelBalance returns the total funds available across all open channels
-	// in satoshis.
-	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
-	// * lncli: `listchaintxns`
-	// GetTransactions returns a list describing all the known transactions
-	// relevant to the wallet.
-	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
-	// * lncli: `sendcoins`
-	// SendCoins executes a request to send coins to a particular address. Unlike
-	// SendMany, this RPC call only allows creating a single output at a time. If
-	// neither target_conf, or sat_per_byte are set, then the internal wallet will
-	// consult its fee model to determine a fee for the default confirmation
-	// target.
-	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+func (m *ChannelAcceptRequest) GetChannelReserve() uint64 {
+	if m != nil {
+		return m.ChannelReserve
+	}
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetMinHtlc() uint64 {
+	if m != nil {
+		return m.MinHtlc
+	}
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetFeePerKw() uint64 {
+	if m != nil {
+		return m.FeePerKw
+	}
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetCsvDelay() uint32 {
+	if m != nil {
+		return m.CsvDelay
+	}
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetMaxAcceptedHtlcs() uint32 {
+	if m != nil {
+		return m.MaxAcceptedHtlcs
+	}
+	return 0
+}
+
+func (m *ChannelAcceptRequest) GetChannelFlags() uint32 {
+	if m != nil {
+		return m.ChannelFlags
+	}
+	return 0
+}
+
+type ChannelAcceptResponse struct {
+	// / Whether we accept the channel
+	Accept bool `protobuf:"varint,1,opt,name=accept" json:"accept,omitempty"`
+	// / The pending channel id of the channel being decided upon
+	PendingChanId []byte `protobuf:"bytes,2,opt,name=pending_chan_id,proto3" json:"pending_chan_id,omitempty"`
+	// / The reason for rejecting the channel, which is sent to the funder
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
 	// *
-	// SubscribeTransactions creates a uni-directional stream from the server to
-	// the client in which any newly discovered transactions relevant to the
-	// wallet are sent over.
-	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
-	// * lncli: `sendmany`
-	// SendMany handles a request for a transaction that creates multiple specified
-	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
-	// the internal wallet will consult its fee model to determine a fee for the
-	// default confirmation target.
-	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
-	// * lncli: `newaddress`
-	// NewAddress creates a new address under control of the local wallet.
-	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	// If non-zero, the number of confirmations we require for the funding
+	// transaction of the accepted channel, instead of our default.
+	MinAcceptDepth uint32 `protobuf:"varint,4,opt,name=min_accept_depth" json:"min_accept_depth,omitempty"`
+}
+
+func (m *ChannelAcceptResponse) Reset()                    { *m = ChannelAcceptResponse{} }
+func (m *ChannelAcceptResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelAcceptResponse) ProtoMessage()               {}
+func (*ChannelAcceptResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{129} }
+
+func (m *ChannelAcceptResponse) GetAccept() bool {
+	if m != nil {
+		return m.Accept
+	}
+	return false
+}
+
+func (m *ChannelAcceptResponse) GetPendingChanId() []byte {
+	if m != nil {
+		return m.PendingChanId
+	}
+	return nil
+}
+
+func (m *ChannelAcceptResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ChannelAcceptResponse) GetMinAcceptDepth() uint32 {
+	if m != nil {
+		return m.MinAcceptDepth
+	}
+	return 0
+}
+
+type MiddlewareRegistration struct {
+	// / The name of the middleware, which must be unique among the registered middlewares.
+	MiddlewareName string `protobuf:"bytes,1,opt,name=middleware_name" json:"middleware_name,omitempty"`
 	// *
-	// NewWitnessAddress creates a new witness address under control of the local wallet.
-	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
-	// * lncli: `signmessage`
-	// SignMessage signs a message with this node's private key. The returned
-	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
-	// only the message digest and signature are needed for verification.
-	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
-	// * lncli: `verifymessage`
-	// VerifyMessage verifies a signature over a msg. The signature must be
-	// zbase32 encoded and signed by an active node in the resident node's
-	// channel database. In addition to returning the validity of the signature,
-	// VerifyMessage also returns the recovered pubkey from the signature.
-	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
-	// * lncli: `connect`
-	// ConnectPeer attempts to establish a connection to a remote peer. This is at
-	// the networking level, and is used for communication between nodes. This is
-	// distinct from establishing a channel with a peer.
-	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
-	// * lncli: `disconnect`
-	// DisconnectPeer attempts to disconnect one peer from another identified by a
-	// given pubKey. In the case that we currently have a pending or active channel
-	// with the target peer, then this action will be not be allowed.
-	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
-	// * lncli: `listpeers`
-	// ListPeers returns a verbose listing of all currently active peers.
-	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
-	// * lncli: `getinfo`
-	// GetInfo returns general information concerning the lightning node including
-	// it's identity pubkey, alias, the chains it is connected to, and information
-	// concerning the number of open+pending channels.
-	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
-	// * lncli: `pendingchannels`
-	// PendingChannels returns a list of all the channels that are currently
-	// considered "pending". A channel is pending if it has finished the funding
-	// workflow and is waiting for confirmations for the funding txn, or is in the
-	// process of closure, either initiated cooperatively or non-cooperatively.
-	PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error)
-	// * lncli: `listchannels`
-	// ListChannels returns a description of all the open channels that this node
-	// is a participant in.
-	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
-	// *
-	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
-	// call is meant to be consumed by clients to the REST proxy. As with all
-	// other sync calls, all byte slices are intended to be populated as hex
-	// encoded strings.
-	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
-	// * lncli: `openchannel`
-	// OpenChannel attempts to open a singly funded channel specified in the
-	// request to a remote peer. Users are able to specify a target number of
-	// blocks that the funding transaction should be confirmed in, or a manual fee
-	// rate to us for the funding transaction. If neither are specified, then a
-	// lax block confirmation target is used.
-	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
-	// * lncli: `closechannel`
-	// CloseChannel attempts to close an active channel identified by its channel
-	// outpoint (ChannelPoint). The actions of this method can additionally be
-	// augmented to attempt a force close after a timeout period in the case of an
-	// inactive peer. If a non-force close (cooperative closure) is requested,
-	// then the user can specify either a target number of blocks until the
-	// closure transaction is confirmed, or a manual fee rate. If neither are
-	// specified, then a default lax, block confirmation target is used.
-	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
-	// * lncli: `sendpayment`
-	// SendPayment dispatches a bi-directional streaming RPC for sending payments
-	// through the Lightning Network. A single RPC invocation creates a persistent
-	// bi-directional stream allowing clients to rapidly send payments through the
-	// Lightning Network with a single persistent connection.
-	SendPayment(Lightning_SendPaymentServer) error
-	// *
-	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
-	// This RPC is intended to be consumed by clients of the REST proxy.
-	// Additionally, this RPC expects the destination's public key and the payment
-	// hash (if any) to be encoded as hex strings.
-	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
-	// * lncli: `addinvoice`
-	// AddInvoice attempts to add a new invoice to the invoice database. Any
-	// duplicated invoices are rejected, therefore all invoices *must* have a
-	// unique payment preimage.
-	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
-	// * lncli: `listinvoices`
-	// ListInvoices returns a list of all the invoices currently stored within the
-	// database. Any active debug invoices are ignored.
-	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
-	// * lncli: `lookupinvoice`
-	// LookupInvoice attempts to look up an invoice according to its payment hash.
-	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
-	// returned.
-	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
-	// *
-	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
-	// notifying the client of newly added/settled invoices.
-	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
-	// * lncli: `decodepayreq`
-	// DecodePayReq takes an encoded payment request string and attempts to decode
-	// it, returning a full description of the conditions encoded within the
-	// payment request.
-	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
-	// * lncli: `listpayments`
-	// ListPayments returns a list of all outgoing payments.
-	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// The name of the custom macaroon caveat the middleware is responsible for.
+	// Only the calls made with a macaroon carrying a custom caveat of this name
+	// are intercepted, and the middleware may reject or modify them.
+	CustomMacaroonCaveatName string `protobuf:"bytes,2,opt,name=custom_macaroon_caveat_name" json:"custom_macaroon_caveat_name,omitempty"`
 	// *
-	// DeleteAllPayments deletes all outgoing payments from DB.
-	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
-	// * lncli: `describegraph`
-	// DescribeGraph returns a description of the latest graph state from the
-	// point of view of the node. The graph information is partitioned into two
-	// components: all the nodes/vertexes, and all the edges that connect the
-	// vertexes themselves.  As this is a directed graph, the edges also contain
-	// the node directional specific routing policy which includes: the time lock
-	// delta, fee information, etc.
-	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
-	// * lncli: `getchaninfo`
-	// GetChanInfo returns the latest authenticated network announcement for the
-	// given channel identified by its channel ID: an 8-byte integer which
-	// uniquely identifies the location of transaction's funding output within the
-	// blockchain.
-	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
-	// * lncli: `getnodeinfo`
-	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
-	// channel information for the specified node identified by its public key.
-	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
-	// * lncli: `queryroutes`
-	// QueryRoutes attempts to query the daemon's Channel Router for a possible
-	// route to a target destination capable of carrying a specific amount of
-	// satoshis. The retuned route contains the full details required to craft and
-	// send an HTLC, also including the necessary information that should be
-	// present within the Sphinx packet encapsulated within the HTLC.
-	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
-	// * lncli: `getnetworkinfo`
-	// GetNetworkInfo returns some basic stats about the known channel graph from
-	// the point of view of the node.
-	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
-	// * lncli: `stop`
-	// StopDaemon will send a shutdown request to the interrupt handler, triggering
-	// a graceful shutdown of the daemon.
-	StopDaemon(context.Context, *StopRequest) (*StopResponse, error)
+	// If set, the middleware is handed all calls, but may only inspect them, as
+	// its feedback is ignored. Mutually exclusive with
+	// custom_macaroon_caveat_name.
+	ReadOnlyMode bool `protobuf:"varint,3,opt,name=read_only_mode" json:"read_only_mode,omitempty"`
+}
+
+func (m *MiddlewareRegistration) Reset()                    { *m = MiddlewareRegistration{} }
+func (m *MiddlewareRegistration) String() string            { return proto.CompactTextString(m) }
+func (*MiddlewareRegistration) ProtoMessage()               {}
+func (*MiddlewareRegistration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{130} }
+
+func (m *MiddlewareRegistration) GetMiddlewareName() string {
+	if m != nil {
+		return m.MiddlewareName
+	}
+	return ""
+}
+
+func (m *MiddlewareRegistration) GetCustomMacaroonCaveatName() string {
+	if m != nil {
+		return m.CustomMacaroonCaveatName
+	}
+	return ""
+}
+
+func (m *MiddlewareRegistration) GetReadOnlyMode() bool {
+	if m != nil {
+		return m.ReadOnlyMode
+	}
+	return false
+}
+
+type InterceptFeedback struct {
+	// / If set, the call is rejected with this error.
+	Error string `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
 	// *
-	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
-	// receive notifications upon any changes to the channel graph topology from
-	// the point of view of the responding node. Events notified include: new
-	// nodes coming online, nodes updating their authenticated attributes, new
-	// channels being advertised, updates in the routing policy for a directional
-	// channel edge, and when channels are closed on-chain.
-	SubscribeChannelGraph(*GraphTopologySubscription, Lightning_SubscribeChannelGraphServer) error
-	// * lncli: `debuglevel`
-	// DebugLevel allows a caller to programmatically set the logging verbosity of
-	// lnd. The logging can be targeted according to a coarse daemon-wide logging
-	// level, or in a granular fashion to specify the logging for a target
-	// sub-system.
-	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
-	// * lncli: `feereport`
-	// FeeReport allows the caller to obtain a report detailing the current fee
-	// schedule enforced by the node globally for each channel.
-	FeeReport(context.Context, *FeeReportRequest) (*FeeReportResponse, error)
-	// * lncli: `updatechanpolicy`
-	// UpdateChannelPolicy allows the caller to update the fee schedule and
-	// channel policies for all channels globally, or a particular channel.
-	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
-	// * lncli: `fwdinghistory`
-	// ForwardingHistory allows the caller to query the htlcswitch for a record of
-	// all HTLC's forwarded within the target time range, and integer offset
-	// within that time range. If no time-range is specified, then the first chunk
-	// of the past 24 hrs of forwarding history are returned.
-	//
-	// A list of forwarding events are returned. The size of each forwarding event
-	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-	// As a result each message can only contain 50k entries.  Each response has
-	// the index offset of the last entry. The index offset can be provided to the
-	// request to allow the caller to skip a series of records.
-	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// If set, the intercepted message is replaced with replacement_serialized,
+	// which must be a serialized message of the same type.
+	ReplaceMessage bool `protobuf:"varint,2,opt,name=replace_message" json:"replace_message,omitempty"`
+	// / The replacement of the intercepted message, in its binary encoding.
+	ReplacementSerialized []byte `protobuf:"bytes,3,opt,name=replacement_serialized,proto3" json:"replacement_serialized,omitempty"`
 }
 
-func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
-	s.RegisterService(&_Lightning_serviceDesc, srv)
+func (m *InterceptFeedback) Reset()                    { *m = InterceptFeedback{} }
+func (m *InterceptFeedback) String() string            { return proto.CompactTextString(m) }
+func (*InterceptFeedback) ProtoMessage()               {}
+func (*InterceptFeedback) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{131} }
+
+func (m *InterceptFeedback) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *InterceptFeedback) GetReplaceMessage() bool {
+	if m != nil {
+		return m.ReplaceMessage
+	}
+	return false
+}
+
+func (m *InterceptFeedback) GetReplacementSerialized() []byte {
+	if m != nil {
+		return m.ReplacementSerialized
+	}
+	return nil
+}
+
+type RPCMiddlewareRequest struct {
+	// / The id of the call the intercepted message belongs to. All messages of a streaming call share it.
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id" json:"request_id,omitempty"`
+	// / The id of the intercepted message, referenced by the feedback of the middleware.
+	MsgId uint64 `protobuf:"varint,2,opt,name=msg_id" json:"msg_id,omitempty"`
+	// / The full URI of the called method, such as /lnrpc.Lightning/GetInfo.
+	MethodFullUri string `protobuf:"bytes,3,opt,name=method_full_uri" json:"method_full_uri,omitempty"`
+	// / Whether the called method is a streaming method.
+	StreamRpc bool `protobuf:"varint,4,opt,name=stream_rpc" json:"stream_rpc,omitempty"`
+	// / Whether the intercepted message is a request or a response.
+	Type RPCMiddlewareRequest_InterceptType `protobuf:"varint,5,opt,name=type,enum=lnrpc.RPCMiddlewareRequest_InterceptType" json:"type,omitempty"`
+	// / The full name of the type of the intercepted message, such as lnrpc.GetInfoRequest.
+	TypeName string `protobuf:"bytes,6,opt,name=type_name" json:"type_name,omitempty"`
+	// / The intercepted message, in its binary encoding.
+	Serialized []byte `protobuf:"bytes,7,opt,name=serialized,proto3" json:"serialized,omitempty"`
+	// / The macaroon the call was made with, in its binary encoding.
+	RawMacaroon []byte `protobuf:"bytes,8,opt,name=raw_macaroon,proto3" json:"raw_macaroon,omitempty"`
+	// / The condition of the custom caveat the middleware is responsible for, as found in the macaroon.
+	CustomCaveatCondition string `protobuf:"bytes,9,opt,name=custom_caveat_condition" json:"custom_caveat_condition,omitempty"`
+}
+
+func (m *RPCMiddlewareRequest) Reset()                    { *m = RPCMiddlewareRequest{} }
+func (m *RPCMiddlewareRequest) String() string            { return proto.CompactTextString(m) }
+func (*RPCMiddlewareRequest) ProtoMessage()               {}
+func (*RPCMiddlewareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{132} }
+
+func (m *RPCMiddlewareRequest) GetRequestId() uint64 {
+	if m != nil {
+		return m.RequestId
+	}
+	return 0
+}
+
+func (m *RPCMiddlewareRequest) GetMsgId() uint64 {
+	if m != nil {
+		return m.MsgId
+	}
+	return 0
+}
+
+func (m *RPCMiddlewareRequest) GetMethodFullUri() string {
+	if m != nil {
+		return m.MethodFullUri
+	}
+	return ""
+}
+
+func (m *RPCMiddlewareRequest) GetStreamRpc() bool {
+	if m != nil {
+		return m.StreamRpc
+	}
+	return false
+}
+
+func (m *RPCMiddlewareRequest) GetType() RPCMiddlewareRequest_InterceptType {
+	if m != nil {
+		return m.Type
+	}
+	return RPCMiddlewareRequest_REQUEST
+}
+
+func (m *RPCMiddlewareRequest) GetTypeName() string {
+	if m != nil {
+		return m.TypeName
+	}
+	return ""
+}
+
+func (m *RPCMiddlewareRequest) GetSerialized() []byte {
+	if m != nil {
+		return m.Serialized
+	}
+	return nil
+}
+
+func (m *RPCMiddlewareRequest) GetRawMacaroon() []byte {
+	if m != nil {
+		return m.RawMacaroon
+	}
+	return nil
+}
+
+func (m *RPCMiddlewareRequest) GetCustomCaveatCondition() string {
+	if m != nil {
+		return m.CustomCaveatCondition
+	}
+	return ""
+}
+
+type RPCMiddlewareResponse struct {
+	// / The id of the intercepted message the feedback refers to.
+	RefMsgId uint64 `protobuf:"varint,1,opt,name=ref_msg_id" json:"ref_msg_id,omitempty"`
+	// / The registration of the middleware, which must be the first message sent, and only that one.
+	Register *MiddlewareRegistration `protobuf:"bytes,2,opt,name=register" json:"register,omitempty"`
+	// / The feedback upon the intercepted message referenced by ref_msg_id.
+	Feedback *InterceptFeedback `protobuf:"bytes,3,opt,name=feedback" json:"feedback,omitempty"`
+}
+
+func (m *RPCMiddlewareResponse) Reset()                    { *m = RPCMiddlewareResponse{} }
+func (m *RPCMiddlewareResponse) String() string            { return proto.CompactTextString(m) }
+func (*RPCMiddlewareResponse) ProtoMessage()               {}
+func (*RPCMiddlewareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{133} }
+
+func (m *RPCMiddlewareResponse) GetRefMsgId() uint64 {
+	if m != nil {
+		return m.RefMsgId
+	}
+	return 0
+}
+
+func (m *RPCMiddlewareResponse) GetRegister() *MiddlewareRegistration {
+	if m != nil {
+		return m.Register
+	}
+	return nil
+}
+
+func (m *RPCMiddlewareResponse) GetFeedback() *InterceptFeedback {
+	if m != nil {
+		return m.Feedback
+	}
+	return nil
+}
+
+type FeeLimit struct {
+	// Types that are valid to be assigned to Limit:
+	//	*FeeLimit_Fixed
+	//	*FeeLimit_Percent
+	Limit isFeeLimit_Limit `protobuf_oneof:"limit"`
+}
+
+func (m *FeeLimit) Reset()                    { *m = FeeLimit{} }
+func (m *FeeLimit) String() string            { return proto.CompactTextString(m) }
+func (*FeeLimit) ProtoMessage()               {}
+func (*FeeLimit) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{134} }
+
+type isFeeLimit_Limit interface{ isFeeLimit_Limit() }
+
+type FeeLimit_Fixed struct {
+	Fixed int64 `protobuf:"varint,1,opt,name=fixed,oneof"`
+}
+type FeeLimit_Percent struct {
+	Percent int64 `protobuf:"varint,2,opt,name=percent,oneof"`
+}
+
+func (*FeeLimit_Fixed) isFeeLimit_Limit()   {}
+func (*FeeLimit_Percent) isFeeLimit_Limit() {}
+
+func (m *FeeLimit) GetLimit() isFeeLimit_Limit {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *FeeLimit) GetFixed() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_Fixed); ok {
+		return x.Fixed
+	}
+	return 0
+}
+
+func (m *FeeLimit) GetPercent() int64 {
+	if x, ok := m.GetLimit().(*FeeLimit_Percent); ok {
+		return x.Percent
+	}
+	return 0
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*FeeLimit) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _FeeLimit_OneofMarshaler, _FeeLimit_OneofUnmarshaler, _FeeLimit_OneofSizer, []interface{}{
+		(*FeeLimit_Fixed)(nil),
+		(*FeeLimit_Percent)(nil),
+	}
+}
+
+func _FeeLimit_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_Fixed:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Fixed))
+	case *FeeLimit_Percent:
+		b.EncodeVarint(2<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		return fmt.Errorf("FeeLimit.Limit has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _FeeLimit_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*FeeLimit)
+	switch tag {
+	case 1: // limit.fixed
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_Fixed{int64(x)}
+		return true, err
+	case 2: // limit.percent
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Limit = &FeeLimit_Percent{int64(x)}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _FeeLimit_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*FeeLimit)
+	// limit
+	switch x := m.Limit.(type) {
+	case *FeeLimit_Fixed:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Fixed))
+	case *FeeLimit_Percent:
+		n += proto.SizeVarint(2<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Percent))
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type SendToRouteRequest struct {
+	// / The payment hash to use for the HTLC.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / An optional hex-encoded payment hash to be used for the HTLC.
+	PaymentHashString string `protobuf:"bytes,2,opt,name=payment_hash_string" json:"payment_hash_string,omitempty"`
+	// *
+	// The set of routes that should be used to attempt to complete the payment.
+	// The routes are attempted in order, until one of them succeeds.
+	Routes []*Route `protobuf:"bytes,3,rep,name=routes" json:"routes,omitempty"`
+}
+
+func (m *SendToRouteRequest) Reset()                    { *m = SendToRouteRequest{} }
+func (m *SendToRouteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendToRouteRequest) ProtoMessage()               {}
+func (*SendToRouteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{135} }
+
+func (m *SendToRouteRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *SendToRouteRequest) GetPaymentHashString() string {
+	if m != nil {
+		return m.PaymentHashString
+	}
+	return ""
+}
+
+func (m *SendToRouteRequest) GetRoutes() []*Route {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+type EstimateFeeRequest struct {
+	// / The map from addresses to amounts for the transaction.
+	AddrToAmount map[string]int64 `protobuf:"bytes,1,rep,name=AddrToAmount" json:"AddrToAmount,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// / The target number of blocks that this transaction should be confirmed by.
+	TargetConf int32 `protobuf:"varint,2,opt,name=target_conf" json:"target_conf,omitempty"`
+}
+
+func (m *EstimateFeeRequest) Reset()                    { *m = EstimateFeeRequest{} }
+func (m *EstimateFeeRequest) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeRequest) ProtoMessage()               {}
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{136} }
+
+func (m *EstimateFeeRequest) GetAddrToAmount() map[string]int64 {
+	if m != nil {
+		return m.AddrToAmount
+	}
+	return nil
+}
+
+func (m *EstimateFeeRequest) GetTargetConf() int32 {
+	if m != nil {
+		return m.TargetConf
+	}
+	return 0
+}
+
+type EstimateFeeResponse struct {
+	// / The total fee in satoshis.
+	FeeSat int64 `protobuf:"varint,1,opt,name=fee_sat" json:"fee_sat,omitempty"`
+	// / The fee rate in satoshi/byte.
+	FeerateSatPerByte int64 `protobuf:"varint,2,opt,name=feerate_sat_per_byte" json:"feerate_sat_per_byte,omitempty"`
+}
+
+func (m *EstimateFeeResponse) Reset()                    { *m = EstimateFeeResponse{} }
+func (m *EstimateFeeResponse) String() string            { return proto.CompactTextString(m) }
+func (*EstimateFeeResponse) ProtoMessage()               {}
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{137} }
+
+func (m *EstimateFeeResponse) GetFeeSat() int64 {
+	if m != nil {
+		return m.FeeSat
+	}
+	return 0
+}
+
+func (m *EstimateFeeResponse) GetFeerateSatPerByte() int64 {
+	if m != nil {
+		return m.FeerateSatPerByte
+	}
+	return 0
+}
+
+type AbandonChannelRequest struct {
+	// / The outpoint (txid:index) of the funding transaction of the channel to abandon.
+	ChannelPoint *ChannelPoint `protobuf:"bytes,1,opt,name=channel_point" json:"channel_point,omitempty"`
+}
+
+func (m *AbandonChannelRequest) Reset()                    { *m = AbandonChannelRequest{} }
+func (m *AbandonChannelRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelRequest) ProtoMessage()               {}
+func (*AbandonChannelRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{138} }
+
+func (m *AbandonChannelRequest) GetChannelPoint() *ChannelPoint {
+	if m != nil {
+		return m.ChannelPoint
+	}
+	return nil
+}
+
+type AbandonChannelResponse struct {
+}
+
+func (m *AbandonChannelResponse) Reset()                    { *m = AbandonChannelResponse{} }
+func (m *AbandonChannelResponse) String() string            { return proto.CompactTextString(m) }
+func (*AbandonChannelResponse) ProtoMessage()               {}
+func (*AbandonChannelResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{139} }
+
+type PaymentUpdate struct {
+	// / The kind of update.
+	Type PaymentUpdate_UpdateType `protobuf:"varint,1,opt,name=type,enum=lnrpc.PaymentUpdate_UpdateType" json:"type,omitempty"`
+	// / The payment hash of the payment.
+	PaymentHash []byte `protobuf:"bytes,2,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// *
+	// The HTLC attempt the update concerns. It's unset if the payment failed
+	// without an attempt being dispatched.
+	Attempt *HTLCAttempt `protobuf:"bytes,3,opt,name=attempt" json:"attempt,omitempty"`
+	// / The number of HTLC attempts dispatched for the payment so far.
+	NumAttempts uint32 `protobuf:"varint,4,opt,name=num_attempts" json:"num_attempts,omitempty"`
+	// / The public key of the node which reported the failure of the attempt.
+	FailureSourcePubKey string `protobuf:"bytes,5,opt,name=failure_source_pub_key" json:"failure_source_pub_key,omitempty"`
+	// / The fees paid so far in milli-satoshis, which is non-zero once settled.
+	FeePaidMsat int64 `protobuf:"varint,6,opt,name=fee_paid_msat" json:"fee_paid_msat,omitempty"`
+	// / The preimage of the payment hash, set once the payment succeeded.
+	PaymentPreimage []byte `protobuf:"bytes,7,opt,name=payment_preimage,proto3" json:"payment_preimage,omitempty"`
+	// / The reason the payment failed, set once the payment failed.
+	PaymentError string `protobuf:"bytes,8,opt,name=payment_error" json:"payment_error,omitempty"`
+}
+
+func (m *PaymentUpdate) Reset()                    { *m = PaymentUpdate{} }
+func (m *PaymentUpdate) String() string            { return proto.CompactTextString(m) }
+func (*PaymentUpdate) ProtoMessage()               {}
+func (*PaymentUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{140} }
+
+func (m *PaymentUpdate) GetType() PaymentUpdate_UpdateType {
+	if m != nil {
+		return m.Type
+	}
+	return PaymentUpdate_ATTEMPT_DISPATCHED
+}
+
+func (m *PaymentUpdate) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetAttempt() *HTLCAttempt {
+	if m != nil {
+		return m.Attempt
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetNumAttempts() uint32 {
+	if m != nil {
+		return m.NumAttempts
+	}
+	return 0
+}
+
+func (m *PaymentUpdate) GetFailureSourcePubKey() string {
+	if m != nil {
+		return m.FailureSourcePubKey
+	}
+	return ""
+}
+
+func (m *PaymentUpdate) GetFeePaidMsat() int64 {
+	if m != nil {
+		return m.FeePaidMsat
+	}
+	return 0
+}
+
+func (m *PaymentUpdate) GetPaymentPreimage() []byte {
+	if m != nil {
+		return m.PaymentPreimage
+	}
+	return nil
+}
+
+func (m *PaymentUpdate) GetPaymentError() string {
+	if m != nil {
+		return m.PaymentError
+	}
+	return ""
+}
+
+type NodeMetricsRequest struct {
+	// / Whether to compute the betweenness centrality of each node.
+	BetweennessCentrality bool `protobuf:"varint,1,opt,name=betweenness_centrality" json:"betweenness_centrality,omitempty"`
+	// / Whether to report the number of channels and capacity of each node.
+	DegreeStats bool `protobuf:"varint,2,opt,name=degree_stats" json:"degree_stats,omitempty"`
+}
+
+func (m *NodeMetricsRequest) Reset()                    { *m = NodeMetricsRequest{} }
+func (m *NodeMetricsRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeMetricsRequest) ProtoMessage()               {}
+func (*NodeMetricsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{141} }
+
+func (m *NodeMetricsRequest) GetBetweennessCentrality() bool {
+	if m != nil {
+		return m.BetweennessCentrality
+	}
+	return false
+}
+
+func (m *NodeMetricsRequest) GetDegreeStats() bool {
+	if m != nil {
+		return m.DegreeStats
+	}
+	return false
+}
+
+type NodeMetrics struct {
+	// / The identity pubkey of the node.
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// *
+	// The betweenness centrality of the node, which is the sum over all pairs
+	// of other nodes of the fraction of shortest paths between them passing
+	// through the node.
+	BetweennessCentrality float64 `protobuf:"fixed64,2,opt,name=betweenness_centrality" json:"betweenness_centrality,omitempty"`
+	// / The betweenness centrality of the node, scaled to the range [0, 1].
+	BetweennessCentralityNormalized float64 `protobuf:"fixed64,3,opt,name=betweenness_centrality_normalized" json:"betweenness_centrality_normalized,omitempty"`
+	// / The number of channels of the node.
+	NumChannels uint32 `protobuf:"varint,4,opt,name=num_channels" json:"num_channels,omitempty"`
+	// / The total capacity of the channels of the node in satoshis.
+	TotalCapacity int64 `protobuf:"varint,5,opt,name=total_capacity" json:"total_capacity,omitempty"`
+}
+
+func (m *NodeMetrics) Reset()                    { *m = NodeMetrics{} }
+func (m *NodeMetrics) String() string            { return proto.CompactTextString(m) }
+func (*NodeMetrics) ProtoMessage()               {}
+func (*NodeMetrics) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{142} }
+
+func (m *NodeMetrics) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *NodeMetrics) GetBetweennessCentrality() float64 {
+	if m != nil {
+		return m.BetweennessCentrality
+	}
+	return 0
+}
+
+func (m *NodeMetrics) GetBetweennessCentralityNormalized() float64 {
+	if m != nil {
+		return m.BetweennessCentralityNormalized
+	}
+	return 0
+}
+
+func (m *NodeMetrics) GetNumChannels() uint32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *NodeMetrics) GetTotalCapacity() int64 {
+	if m != nil {
+		return m.TotalCapacity
+	}
+	return 0
+}
+
+type NodeMetricsResponse struct {
+	// *
+	// The metrics of each node within the graph, ordered by decreasing
+	// betweenness centrality.
+	Nodes []*NodeMetrics `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *NodeMetricsResponse) Reset()                    { *m = NodeMetricsResponse{} }
+func (m *NodeMetricsResponse) String() string            { return proto.CompactTextString(m) }
+func (*NodeMetricsResponse) ProtoMessage()               {}
+func (*NodeMetricsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{143} }
+
+func (m *NodeMetricsResponse) GetNodes() []*NodeMetrics {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type MacaroonPermission struct {
+	// / The entity the permission grants access to, such as offchain or invoices.
+	Entity string `protobuf:"bytes,1,opt,name=entity" json:"entity,omitempty"`
+	// / The action the permission grants on the entity, either read or write.
+	Action string `protobuf:"bytes,2,opt,name=action" json:"action,omitempty"`
+}
+
+func (m *MacaroonPermission) Reset()                    { *m = MacaroonPermission{} }
+func (m *MacaroonPermission) String() string            { return proto.CompactTextString(m) }
+func (*MacaroonPermission) ProtoMessage()               {}
+func (*MacaroonPermission) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{144} }
+
+func (m *MacaroonPermission) GetEntity() string {
+	if m != nil {
+		return m.Entity
+	}
+	return ""
+}
+
+func (m *MacaroonPermission) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+type BakeMacaroonRequest struct {
+	// / The permissions the new macaroon grants, at least one is required.
+	Permissions []*MacaroonPermission `protobuf:"bytes,1,rep,name=permissions" json:"permissions,omitempty"`
+	// / If set, the new macaroon may only be used from this IP address.
+	IpAddress string `protobuf:"bytes,2,opt,name=ip_address" json:"ip_address,omitempty"`
+	// / If non-zero, the number of seconds after which the new macaroon expires.
+	Timeout int64 `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	// / The ID of the root key the new macaroon is derived from, which is created if it doesn't exist yet. Defaults to the root key of the default macaroons.
+	RootKeyId uint64 `protobuf:"varint,4,opt,name=root_key_id" json:"root_key_id,omitempty"`
+}
+
+func (m *BakeMacaroonRequest) Reset()                    { *m = BakeMacaroonRequest{} }
+func (m *BakeMacaroonRequest) String() string            { return proto.CompactTextString(m) }
+func (*BakeMacaroonRequest) ProtoMessage()               {}
+func (*BakeMacaroonRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{145} }
+
+func (m *BakeMacaroonRequest) GetPermissions() []*MacaroonPermission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *BakeMacaroonRequest) GetIpAddress() string {
+	if m != nil {
+		return m.IpAddress
+	}
+	return ""
+}
+
+func (m *BakeMacaroonRequest) GetTimeout() int64 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *BakeMacaroonRequest) GetRootKeyId() uint64 {
+	if m != nil {
+		return m.RootKeyId
+	}
+	return 0
+}
+
+type BakeMacaroonResponse struct {
+	// / The hex encoded new macaroon.
+	Macaroon string `protobuf:"bytes,1,opt,name=macaroon" json:"macaroon,omitempty"`
+}
+
+func (m *BakeMacaroonResponse) Reset()                    { *m = BakeMacaroonResponse{} }
+func (m *BakeMacaroonResponse) String() string            { return proto.CompactTextString(m) }
+func (*BakeMacaroonResponse) ProtoMessage()               {}
+func (*BakeMacaroonResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{146} }
+
+func (m *BakeMacaroonResponse) GetMacaroon() string {
+	if m != nil {
+		return m.Macaroon
+	}
+	return ""
+}
+
+type ListMacaroonIDsRequest struct {
+}
+
+func (m *ListMacaroonIDsRequest) Reset()                    { *m = ListMacaroonIDsRequest{} }
+func (m *ListMacaroonIDsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListMacaroonIDsRequest) ProtoMessage()               {}
+func (*ListMacaroonIDsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{147} }
+
+type ListMacaroonIDsResponse struct {
+	// / The IDs of all root keys macaroons may be derived from.
+	RootKeyIds []uint64 `protobuf:"varint,1,rep,packed,name=root_key_ids" json:"root_key_ids,omitempty"`
+}
+
+func (m *ListMacaroonIDsResponse) Reset()                    { *m = ListMacaroonIDsResponse{} }
+func (m *ListMacaroonIDsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListMacaroonIDsResponse) ProtoMessage()               {}
+func (*ListMacaroonIDsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{148} }
+
+func (m *ListMacaroonIDsResponse) GetRootKeyIds() []uint64 {
+	if m != nil {
+		return m.RootKeyIds
+	}
+	return nil
+}
+
+type DeleteMacaroonIDRequest struct {
+	// / The ID of the root key to delete.
+	RootKeyId uint64 `protobuf:"varint,1,opt,name=root_key_id" json:"root_key_id,omitempty"`
+}
+
+func (m *DeleteMacaroonIDRequest) Reset()                    { *m = DeleteMacaroonIDRequest{} }
+func (m *DeleteMacaroonIDRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMacaroonIDRequest) ProtoMessage()               {}
+func (*DeleteMacaroonIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{149} }
+
+func (m *DeleteMacaroonIDRequest) GetRootKeyId() uint64 {
+	if m != nil {
+		return m.RootKeyId
+	}
+	return 0
+}
+
+type DeleteMacaroonIDResponse struct {
+	// / Whether a root key with the passed ID existed, and was deleted.
+	Deleted bool `protobuf:"varint,1,opt,name=deleted" json:"deleted,omitempty"`
+}
+
+func (m *DeleteMacaroonIDResponse) Reset()                    { *m = DeleteMacaroonIDResponse{} }
+func (m *DeleteMacaroonIDResponse) String() string            { return proto.CompactTextString(m) }
+func (*DeleteMacaroonIDResponse) ProtoMessage()               {}
+func (*DeleteMacaroonIDResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{150} }
+
+func (m *DeleteMacaroonIDResponse) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+type CheckMacaroonPermissionsRequest struct {
+	// / The macaroon to check, in its binary encoding.
+	Macaroon []byte `protobuf:"bytes,1,opt,name=macaroon,proto3" json:"macaroon,omitempty"`
+	// / The permissions the macaroon must grant.
+	Permissions []*MacaroonPermission `protobuf:"bytes,2,rep,name=permissions" json:"permissions,omitempty"`
+	// *
+	// The IP address of the client which presented the macaroon, which IP
+	// locked macaroons are checked against. If not set, the address the check
+	// is requested from is used instead.
+	ClientIp string `protobuf:"bytes,3,opt,name=client_ip" json:"client_ip,omitempty"`
+}
+
+func (m *CheckMacaroonPermissionsRequest) Reset()                    { *m = CheckMacaroonPermissionsRequest{} }
+func (m *CheckMacaroonPermissionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CheckMacaroonPermissionsRequest) ProtoMessage()               {}
+func (*CheckMacaroonPermissionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{151} }
+
+func (m *CheckMacaroonPermissionsRequest) GetMacaroon() []byte {
+	if m != nil {
+		return m.Macaroon
+	}
+	return nil
+}
+
+func (m *CheckMacaroonPermissionsRequest) GetPermissions() []*MacaroonPermission {
+	if m != nil {
+		return m.Permissions
+	}
+	return nil
+}
+
+func (m *CheckMacaroonPermissionsRequest) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+type MacaroonCustomCaveat struct {
+	// / The name of the custom caveat.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// / The condition of the custom caveat, which may be empty.
+	Condition string `protobuf:"bytes,2,opt,name=condition" json:"condition,omitempty"`
+}
+
+func (m *MacaroonCustomCaveat) Reset()                    { *m = MacaroonCustomCaveat{} }
+func (m *MacaroonCustomCaveat) String() string            { return proto.CompactTextString(m) }
+func (*MacaroonCustomCaveat) ProtoMessage()               {}
+func (*MacaroonCustomCaveat) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{152} }
+
+func (m *MacaroonCustomCaveat) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MacaroonCustomCaveat) GetCondition() string {
+	if m != nil {
+		return m.Condition
+	}
+	return ""
+}
+
+type CheckMacaroonPermissionsResponse struct {
+	// / Whether the macaroon is valid, and grants all passed permissions.
+	Valid bool `protobuf:"varint,1,opt,name=valid" json:"valid,omitempty"`
+	// / The reason the macaroon was refused, if it isn't valid.
+	Error string `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// / The unix timestamp in seconds after which the macaroon expires, or zero if it doesn't.
+	Expiry int64 `protobuf:"varint,3,opt,name=expiry" json:"expiry,omitempty"`
+	// / The IP address the macaroon is locked to, if any.
+	IpAddress string `protobuf:"bytes,4,opt,name=ip_address" json:"ip_address,omitempty"`
+	// *
+	// The custom caveats of the macaroon, whose conditions aren't enforced by
+	// lnd itself, but by the RPC middleware responsible for them.
+	CustomCaveats []*MacaroonCustomCaveat `protobuf:"bytes,5,rep,name=custom_caveats" json:"custom_caveats,omitempty"`
+	// / The conditions of all first party caveats of the macaroon.
+	Caveats []string `protobuf:"bytes,6,rep,name=caveats" json:"caveats,omitempty"`
+}
+
+func (m *CheckMacaroonPermissionsResponse) Reset()                    { *m = CheckMacaroonPermissionsResponse{} }
+func (m *CheckMacaroonPermissionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CheckMacaroonPermissionsResponse) ProtoMessage()               {}
+func (*CheckMacaroonPermissionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{153} }
+
+func (m *CheckMacaroonPermissionsResponse) GetValid() bool {
+	if m != nil {
+		return m.Valid
+	}
+	return false
+}
+
+func (m *CheckMacaroonPermissionsResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *CheckMacaroonPermissionsResponse) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *CheckMacaroonPermissionsResponse) GetIpAddress() string {
+	if m != nil {
+		return m.IpAddress
+	}
+	return ""
+}
+
+func (m *CheckMacaroonPermissionsResponse) GetCustomCaveats() []*MacaroonCustomCaveat {
+	if m != nil {
+		return m.CustomCaveats
+	}
+	return nil
+}
+
+func (m *CheckMacaroonPermissionsResponse) GetCaveats() []string {
+	if m != nil {
+		return m.Caveats
+	}
+	return nil
+}
+
+type PeerEventSubscription struct {
+}
+
+func (m *PeerEventSubscription) Reset()                    { *m = PeerEventSubscription{} }
+func (m *PeerEventSubscription) String() string            { return proto.CompactTextString(m) }
+func (*PeerEventSubscription) ProtoMessage()               {}
+func (*PeerEventSubscription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{154} }
+
+type PeerEvent struct {
+	// / The identity pubkey of the peer
+	PubKey string `protobuf:"bytes,1,opt,name=pub_key" json:"pub_key,omitempty"`
+	// / Whether the peer came online or went offline
+	Type PeerEvent_EventType `protobuf:"varint,2,opt,name=type,enum=lnrpc.PeerEvent_EventType" json:"type,omitempty"`
+}
+
+func (m *PeerEvent) Reset()                    { *m = PeerEvent{} }
+func (m *PeerEvent) String() string            { return proto.CompactTextString(m) }
+func (*PeerEvent) ProtoMessage()               {}
+func (*PeerEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{155} }
+
+func (m *PeerEvent) GetPubKey() string {
+	if m != nil {
+		return m.PubKey
+	}
+	return ""
+}
+
+func (m *PeerEvent) GetType() PeerEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return PeerEvent_PEER_ONLINE
+}
+
+type TrackPaymentRequest struct {
+	// / The payment hash of the payment to track.
+	PaymentHash []byte `protobuf:"bytes,1,opt,name=payment_hash,proto3" json:"payment_hash,omitempty"`
+	// / The hex-encoded payment hash of the payment to track.
+	PaymentHashStr string `protobuf:"bytes,2,opt,name=payment_hash_str" json:"payment_hash_str,omitempty"`
+}
+
+func (m *TrackPaymentRequest) Reset()                    { *m = TrackPaymentRequest{} }
+func (m *TrackPaymentRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrackPaymentRequest) ProtoMessage()               {}
+func (*TrackPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{156} }
+
+func (m *TrackPaymentRequest) GetPaymentHash() []byte {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return nil
+}
+
+func (m *TrackPaymentRequest) GetPaymentHashStr() string {
+	if m != nil {
+		return m.PaymentHashStr
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*GenSeedRequest)(nil), "lnrpc.GenSeedRequest")
+	proto.RegisterType((*GenSeedResponse)(nil), "lnrpc.GenSeedResponse")
+	proto.RegisterType((*InitWalletRequest)(nil), "lnrpc.InitWalletRequest")
+	proto.RegisterType((*InitWalletResponse)(nil), "lnrpc.InitWalletResponse")
+	proto.RegisterType((*UnlockWalletRequest)(nil), "lnrpc.UnlockWalletRequest")
+	proto.RegisterType((*UnlockWalletResponse)(nil), "lnrpc.UnlockWalletResponse")
+	proto.RegisterType((*Transaction)(nil), "lnrpc.Transaction")
+	proto.RegisterType((*GetTransactionsRequest)(nil), "lnrpc.GetTransactionsRequest")
+	proto.RegisterType((*TransactionDetails)(nil), "lnrpc.TransactionDetails")
+	proto.RegisterType((*SendRequest)(nil), "lnrpc.SendRequest")
+	proto.RegisterType((*SendResponse)(nil), "lnrpc.SendResponse")
+	proto.RegisterType((*ChannelPoint)(nil), "lnrpc.ChannelPoint")
+	proto.RegisterType((*LightningAddress)(nil), "lnrpc.LightningAddress")
+	proto.RegisterType((*SendManyRequest)(nil), "lnrpc.SendManyRequest")
+	proto.RegisterType((*SendManyResponse)(nil), "lnrpc.SendManyResponse")
+	proto.RegisterType((*SendCoinsRequest)(nil), "lnrpc.SendCoinsRequest")
+	proto.RegisterType((*SendCoinsResponse)(nil), "lnrpc.SendCoinsResponse")
+	proto.RegisterType((*NewAddressRequest)(nil), "lnrpc.NewAddressRequest")
+	proto.RegisterType((*NewWitnessAddressRequest)(nil), "lnrpc.NewWitnessAddressRequest")
+	proto.RegisterType((*NewAddressResponse)(nil), "lnrpc.NewAddressResponse")
+	proto.RegisterType((*SignMessageRequest)(nil), "lnrpc.SignMessageRequest")
+	proto.RegisterType((*SignMessageResponse)(nil), "lnrpc.SignMessageResponse")
+	proto.RegisterType((*VerifyMessageRequest)(nil), "lnrpc.VerifyMessageRequest")
+	proto.RegisterType((*VerifyMessageResponse)(nil), "lnrpc.VerifyMessageResponse")
+	proto.RegisterType((*ConnectPeerRequest)(nil), "lnrpc.ConnectPeerRequest")
+	proto.RegisterType((*ConnectPeerResponse)(nil), "lnrpc.ConnectPeerResponse")
+	proto.RegisterType((*DisconnectPeerRequest)(nil), "lnrpc.DisconnectPeerRequest")
+	proto.RegisterType((*DisconnectPeerResponse)(nil), "lnrpc.DisconnectPeerResponse")
+	proto.RegisterType((*HTLC)(nil), "lnrpc.HTLC")
+	proto.RegisterType((*Channel)(nil), "lnrpc.Channel")
+	proto.RegisterType((*ListChannelsRequest)(nil), "lnrpc.ListChannelsRequest")
+	proto.RegisterType((*ListChannelsResponse)(nil), "lnrpc.ListChannelsResponse")
+	proto.RegisterType((*Peer)(nil), "lnrpc.Peer")
+	proto.RegisterType((*ListPeersRequest)(nil), "lnrpc.ListPeersRequest")
+	proto.RegisterType((*ListPeersResponse)(nil), "lnrpc.ListPeersResponse")
+	proto.RegisterType((*GetInfoRequest)(nil), "lnrpc.GetInfoRequest")
+	proto.RegisterType((*GetInfoResponse)(nil), "lnrpc.GetInfoResponse")
+	proto.RegisterType((*ConfirmationUpdate)(nil), "lnrpc.ConfirmationUpdate")
+	proto.RegisterType((*ChannelOpenUpdate)(nil), "lnrpc.ChannelOpenUpdate")
+	proto.RegisterType((*ChannelCloseUpdate)(nil), "lnrpc.ChannelCloseUpdate")
+	proto.RegisterType((*CloseChannelRequest)(nil), "lnrpc.CloseChannelRequest")
+	proto.RegisterType((*CloseStatusUpdate)(nil), "lnrpc.CloseStatusUpdate")
+	proto.RegisterType((*PendingUpdate)(nil), "lnrpc.PendingUpdate")
+	proto.RegisterType((*OpenChannelRequest)(nil), "lnrpc.OpenChannelRequest")
+	proto.RegisterType((*OpenStatusUpdate)(nil), "lnrpc.OpenStatusUpdate")
+	proto.RegisterType((*PendingHTLC)(nil), "lnrpc.PendingHTLC")
+	proto.RegisterType((*PendingChannelsRequest)(nil), "lnrpc.PendingChannelsRequest")
+	proto.RegisterType((*PendingChannelsResponse)(nil), "lnrpc.PendingChannelsResponse")
+	proto.RegisterType((*PendingChannelsResponse_PendingChannel)(nil), "lnrpc.PendingChannelsResponse.PendingChannel")
+	proto.RegisterType((*PendingChannelsResponse_PendingOpenChannel)(nil), "lnrpc.PendingChannelsResponse.PendingOpenChannel")
+	proto.RegisterType((*PendingChannelsResponse_ClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ClosedChannel")
+	proto.RegisterType((*PendingChannelsResponse_ForceClosedChannel)(nil), "lnrpc.PendingChannelsResponse.ForceClosedChannel")
+	proto.RegisterType((*WalletBalanceRequest)(nil), "lnrpc.WalletBalanceRequest")
+	proto.RegisterType((*WalletBalanceResponse)(nil), "lnrpc.WalletBalanceResponse")
+	proto.RegisterType((*ChannelBalanceRequest)(nil), "lnrpc.ChannelBalanceRequest")
+	proto.RegisterType((*ChannelBalanceResponse)(nil), "lnrpc.ChannelBalanceResponse")
+	proto.RegisterType((*QueryRoutesRequest)(nil), "lnrpc.QueryRoutesRequest")
+	proto.RegisterType((*QueryRoutesResponse)(nil), "lnrpc.QueryRoutesResponse")
+	proto.RegisterType((*Hop)(nil), "lnrpc.Hop")
+	proto.RegisterType((*Route)(nil), "lnrpc.Route")
+	proto.RegisterType((*NodeInfoRequest)(nil), "lnrpc.NodeInfoRequest")
+	proto.RegisterType((*NodeInfo)(nil), "lnrpc.NodeInfo")
+	proto.RegisterType((*LightningNode)(nil), "lnrpc.LightningNode")
+	proto.RegisterType((*NodeAddress)(nil), "lnrpc.NodeAddress")
+	proto.RegisterType((*RoutingPolicy)(nil), "lnrpc.RoutingPolicy")
+	proto.RegisterType((*ChannelEdge)(nil), "lnrpc.ChannelEdge")
+	proto.RegisterType((*ChannelGraphRequest)(nil), "lnrpc.ChannelGraphRequest")
+	proto.RegisterType((*ChannelGraph)(nil), "lnrpc.ChannelGraph")
+	proto.RegisterType((*ChanInfoRequest)(nil), "lnrpc.ChanInfoRequest")
+	proto.RegisterType((*NetworkInfoRequest)(nil), "lnrpc.NetworkInfoRequest")
+	proto.RegisterType((*NetworkInfo)(nil), "lnrpc.NetworkInfo")
+	proto.RegisterType((*StopRequest)(nil), "lnrpc.StopRequest")
+	proto.RegisterType((*StopResponse)(nil), "lnrpc.StopResponse")
+	proto.RegisterType((*GraphTopologySubscription)(nil), "lnrpc.GraphTopologySubscription")
+	proto.RegisterType((*GraphTopologyUpdate)(nil), "lnrpc.GraphTopologyUpdate")
+	proto.RegisterType((*NodeUpdate)(nil), "lnrpc.NodeUpdate")
+	proto.RegisterType((*ChannelEdgeUpdate)(nil), "lnrpc.ChannelEdgeUpdate")
+	proto.RegisterType((*ClosedChannelUpdate)(nil), "lnrpc.ClosedChannelUpdate")
+	proto.RegisterType((*Invoice)(nil), "lnrpc.Invoice")
+	proto.RegisterType((*AddInvoiceResponse)(nil), "lnrpc.AddInvoiceResponse")
+	proto.RegisterType((*PaymentHash)(nil), "lnrpc.PaymentHash")
+	proto.RegisterType((*ListInvoiceRequest)(nil), "lnrpc.ListInvoiceRequest")
+	proto.RegisterType((*ListInvoiceResponse)(nil), "lnrpc.ListInvoiceResponse")
+	proto.RegisterType((*InvoiceSubscription)(nil), "lnrpc.InvoiceSubscription")
+	proto.RegisterType((*Payment)(nil), "lnrpc.Payment")
+	proto.RegisterType((*HTLCAttempt)(nil), "lnrpc.HTLCAttempt")
+	proto.RegisterType((*ListPaymentsRequest)(nil), "lnrpc.ListPaymentsRequest")
+	proto.RegisterType((*ListPaymentsResponse)(nil), "lnrpc.ListPaymentsResponse")
+	proto.RegisterType((*DeleteAllPaymentsRequest)(nil), "lnrpc.DeleteAllPaymentsRequest")
+	proto.RegisterType((*DeleteAllPaymentsResponse)(nil), "lnrpc.DeleteAllPaymentsResponse")
+	proto.RegisterType((*DeletePaymentsRequest)(nil), "lnrpc.DeletePaymentsRequest")
+	proto.RegisterType((*DeletePaymentsResponse)(nil), "lnrpc.DeletePaymentsResponse")
+	proto.RegisterType((*DebugLevelRequest)(nil), "lnrpc.DebugLevelRequest")
+	proto.RegisterType((*DebugLevelResponse)(nil), "lnrpc.DebugLevelResponse")
+	proto.RegisterType((*PayReqString)(nil), "lnrpc.PayReqString")
+	proto.RegisterType((*PayReq)(nil), "lnrpc.PayReq")
+	proto.RegisterType((*FeeReportRequest)(nil), "lnrpc.FeeReportRequest")
+	proto.RegisterType((*ChannelFeeReport)(nil), "lnrpc.ChannelFeeReport")
+	proto.RegisterType((*FeeReportResponse)(nil), "lnrpc.FeeReportResponse")
+	proto.RegisterType((*PolicyUpdateRequest)(nil), "lnrpc.PolicyUpdateRequest")
+	proto.RegisterType((*PolicyUpdateResponse)(nil), "lnrpc.PolicyUpdateResponse")
+	proto.RegisterType((*ForwardingHistoryRequest)(nil), "lnrpc.ForwardingHistoryRequest")
+	proto.RegisterType((*ForwardingEvent)(nil), "lnrpc.ForwardingEvent")
+	proto.RegisterType((*ForwardingHistoryResponse)(nil), "lnrpc.ForwardingHistoryResponse")
+	proto.RegisterType((*PaymentPolicy)(nil), "lnrpc.PaymentPolicy")
+	proto.RegisterType((*AddPolicyResponse)(nil), "lnrpc.AddPolicyResponse")
+	proto.RegisterType((*ListPoliciesRequest)(nil), "lnrpc.ListPoliciesRequest")
+	proto.RegisterType((*ListPoliciesResponse)(nil), "lnrpc.ListPoliciesResponse")
+	proto.RegisterType((*PolicyPaymentHash)(nil), "lnrpc.PolicyPaymentHash")
+	proto.RegisterType((*DeletePolicyResponse)(nil), "lnrpc.DeletePolicyResponse")
+	proto.RegisterType((*SetDefaultPolicyResponse)(nil), "lnrpc.SetDefaultPolicyResponse")
+	proto.RegisterType((*DefaultPolicyRequest)(nil), "lnrpc.DefaultPolicyRequest")
+	proto.RegisterType((*DeleteDefaultPolicyRequest)(nil), "lnrpc.DeleteDefaultPolicyRequest")
+	proto.RegisterType((*DeleteDefaultPolicyResponse)(nil), "lnrpc.DeleteDefaultPolicyResponse")
+	proto.RegisterType((*ExportPoliciesRequest)(nil), "lnrpc.ExportPoliciesRequest")
+	proto.RegisterType((*PolicyBackup)(nil), "lnrpc.PolicyBackup")
+	proto.RegisterType((*ImportPoliciesResponse)(nil), "lnrpc.ImportPoliciesResponse")
+	proto.RegisterType((*PolicyAuditLogRequest)(nil), "lnrpc.PolicyAuditLogRequest")
+	proto.RegisterType((*PolicyAuditRecord)(nil), "lnrpc.PolicyAuditRecord")
+	proto.RegisterType((*PolicyAuditLogResponse)(nil), "lnrpc.PolicyAuditLogResponse")
+	proto.RegisterType((*CompactDatabaseRequest)(nil), "lnrpc.CompactDatabaseRequest")
+	proto.RegisterType((*CompactDatabaseResponse)(nil), "lnrpc.CompactDatabaseResponse")
+	proto.RegisterType((*ExportDatabaseRequest)(nil), "lnrpc.ExportDatabaseRequest")
+	proto.RegisterType((*DatabaseChunk)(nil), "lnrpc.DatabaseChunk")
+	proto.RegisterType((*LabelChannelRequest)(nil), "lnrpc.LabelChannelRequest")
+	proto.RegisterType((*LabelChannelResponse)(nil), "lnrpc.LabelChannelResponse")
+	proto.RegisterType((*AMPSettlement)(nil), "lnrpc.AMPSettlement")
+	proto.RegisterType((*ChannelEventSubscription)(nil), "lnrpc.ChannelEventSubscription")
+	proto.RegisterType((*ChannelEventUpdate)(nil), "lnrpc.ChannelEventUpdate")
+	proto.RegisterType((*CircuitKey)(nil), "lnrpc.CircuitKey")
+	proto.RegisterType((*ForwardHtlcInterceptRequest)(nil), "lnrpc.ForwardHtlcInterceptRequest")
+	proto.RegisterType((*ForwardHtlcInterceptResponse)(nil), "lnrpc.ForwardHtlcInterceptResponse")
+	proto.RegisterType((*ChannelAcceptRequest)(nil), "lnrpc.ChannelAcceptRequest")
+	proto.RegisterType((*ChannelAcceptResponse)(nil), "lnrpc.ChannelAcceptResponse")
+	proto.RegisterType((*MiddlewareRegistration)(nil), "lnrpc.MiddlewareRegistration")
+	proto.RegisterType((*InterceptFeedback)(nil), "lnrpc.InterceptFeedback")
+	proto.RegisterType((*RPCMiddlewareRequest)(nil), "lnrpc.RPCMiddlewareRequest")
+	proto.RegisterType((*RPCMiddlewareResponse)(nil), "lnrpc.RPCMiddlewareResponse")
+	proto.RegisterType((*FeeLimit)(nil), "lnrpc.FeeLimit")
+	proto.RegisterType((*SendToRouteRequest)(nil), "lnrpc.SendToRouteRequest")
+	proto.RegisterType((*EstimateFeeRequest)(nil), "lnrpc.EstimateFeeRequest")
+	proto.RegisterType((*EstimateFeeResponse)(nil), "lnrpc.EstimateFeeResponse")
+	proto.RegisterType((*AbandonChannelRequest)(nil), "lnrpc.AbandonChannelRequest")
+	proto.RegisterType((*AbandonChannelResponse)(nil), "lnrpc.AbandonChannelResponse")
+	proto.RegisterType((*PaymentUpdate)(nil), "lnrpc.PaymentUpdate")
+	proto.RegisterType((*NodeMetricsRequest)(nil), "lnrpc.NodeMetricsRequest")
+	proto.RegisterType((*NodeMetrics)(nil), "lnrpc.NodeMetrics")
+	proto.RegisterType((*NodeMetricsResponse)(nil), "lnrpc.NodeMetricsResponse")
+	proto.RegisterType((*MacaroonPermission)(nil), "lnrpc.MacaroonPermission")
+	proto.RegisterType((*BakeMacaroonRequest)(nil), "lnrpc.BakeMacaroonRequest")
+	proto.RegisterType((*BakeMacaroonResponse)(nil), "lnrpc.BakeMacaroonResponse")
+	proto.RegisterType((*ListMacaroonIDsRequest)(nil), "lnrpc.ListMacaroonIDsRequest")
+	proto.RegisterType((*ListMacaroonIDsResponse)(nil), "lnrpc.ListMacaroonIDsResponse")
+	proto.RegisterType((*DeleteMacaroonIDRequest)(nil), "lnrpc.DeleteMacaroonIDRequest")
+	proto.RegisterType((*DeleteMacaroonIDResponse)(nil), "lnrpc.DeleteMacaroonIDResponse")
+	proto.RegisterType((*CheckMacaroonPermissionsRequest)(nil), "lnrpc.CheckMacaroonPermissionsRequest")
+	proto.RegisterType((*MacaroonCustomCaveat)(nil), "lnrpc.MacaroonCustomCaveat")
+	proto.RegisterType((*CheckMacaroonPermissionsResponse)(nil), "lnrpc.CheckMacaroonPermissionsResponse")
+	proto.RegisterType((*PeerEventSubscription)(nil), "lnrpc.PeerEventSubscription")
+	proto.RegisterType((*PeerEvent)(nil), "lnrpc.PeerEvent")
+	proto.RegisterType((*TrackPaymentRequest)(nil), "lnrpc.TrackPaymentRequest")
+	proto.RegisterEnum("lnrpc.NewAddressRequest_AddressType", NewAddressRequest_AddressType_name, NewAddressRequest_AddressType_value)
+	proto.RegisterEnum("lnrpc.Invoice_InvoiceState", Invoice_InvoiceState_name, Invoice_InvoiceState_value)
+	proto.RegisterEnum("lnrpc.PolicyAuditRecord_Decision", PolicyAuditRecord_Decision_name, PolicyAuditRecord_Decision_value)
+	proto.RegisterEnum("lnrpc.ChannelEventUpdate_UpdateType", ChannelEventUpdate_UpdateType_name, ChannelEventUpdate_UpdateType_value)
+	proto.RegisterEnum("lnrpc.ForwardHtlcInterceptResponse_Action", ForwardHtlcInterceptResponse_Action_name, ForwardHtlcInterceptResponse_Action_value)
+	proto.RegisterEnum("lnrpc.RPCMiddlewareRequest_InterceptType", RPCMiddlewareRequest_InterceptType_name, RPCMiddlewareRequest_InterceptType_value)
+	proto.RegisterEnum("lnrpc.PaymentUpdate_UpdateType", PaymentUpdate_UpdateType_name, PaymentUpdate_UpdateType_value)
+	proto.RegisterEnum("lnrpc.PeerEvent_EventType", PeerEvent_EventType_name, PeerEvent_EventType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for WalletUnlocker service
+
+type WalletUnlockerClient interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new aezeed cipher seed
+	// given an optional passphrase. If provided, the passphrase will be necessary
+	// to decrypt the cipherseed to expose the internal wallet seed.
+	//
+	// Once the cipherseed is obtained and verified by the user, the InitWallet
+	// method should be used to commit the newly generated seed, and create the
+	// wallet.
+	GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error)
+	// *
+	// InitWallet is used when lnd is starting up for the first time to fully
+	// initialize the daemon and its internal wallet. At the very least a wallet
+	// password must be provided. This will be used to encrypt sensitive material
+	// on disk.
+	//
+	// In the case of a recovery scenario, the user can also specify their aezeed
+	// mnemonic and passphrase. If set, then the daemon will use this prior state
+	// to initialize its internal wallet.
+	//
+	// Alternatively, this can be used along with the GenSeed RPC to obtain a
+	// seed, then present it to the user. Once it has been verified by the user,
+	// the seed can be fed into this RPC in order to commit the new wallet.
+	InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error)
+	// * lncli: `unlock`
+	// UnlockWallet is used at startup of lnd to provide a password to unlock
+	// the wallet database.
+	UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error)
+}
+
+type walletUnlockerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewWalletUnlockerClient(cc *grpc.ClientConn) WalletUnlockerClient {
+	return &walletUnlockerClient{cc}
+}
+
+func (c *walletUnlockerClient) GenSeed(ctx context.Context, in *GenSeedRequest, opts ...grpc.CallOption) (*GenSeedResponse, error) {
+	out := new(GenSeedResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/GenSeed", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletUnlockerClient) InitWallet(ctx context.Context, in *InitWalletRequest, opts ...grpc.CallOption) (*InitWalletResponse, error) {
+	out := new(InitWalletResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/InitWallet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletUnlockerClient) UnlockWallet(ctx context.Context, in *UnlockWalletRequest, opts ...grpc.CallOption) (*UnlockWalletResponse, error) {
+	out := new(UnlockWalletResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.WalletUnlocker/UnlockWallet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for WalletUnlocker service
+
+type WalletUnlockerServer interface {
+	// *
+	// GenSeed is the first method that should be used to instantiate a new lnd
+	// instance. This method allows a caller to generate a new aezeed cipher seed
+	// given an optional passphrase. If provided, the passphrase will be necessary
+	// to decrypt the cipherseed to expose the internal wallet seed.
+	//
+	// Once the cipherseed is obtained and verified by the user, the InitWallet
+	// method should be used to commit the newly generated seed, and create the
+	// wallet.
+	GenSeed(context.Context, *GenSeedRequest) (*GenSeedResponse, error)
+	// *
+	// InitWallet is used when lnd is starting up for the first time to fully
+	// initialize the daemon and its internal wallet. At the very least a wallet
+	// password must be provided. This will be used to encrypt sensitive material
+	// on disk.
+	//
+	// In the case of a recovery scenario, the user can also specify their aezeed
+	// mnemonic and passphrase. If set, then the daemon will use this prior state
+	// to initialize its internal wallet.
+	//
+	// Alternatively, this can be used along with the GenSeed RPC to obtain a
+	// seed, then present it to the user. Once it has been verified by the user,
+	// the seed can be fed into this RPC in order to commit the new wallet.
+	InitWallet(context.Context, *InitWalletRequest) (*InitWalletResponse, error)
+	// * lncli: `unlock`
+	// UnlockWallet is used at startup of lnd to provide a password to unlock
+	// the wallet database.
+	UnlockWallet(context.Context, *UnlockWalletRequest) (*UnlockWalletResponse, error)
+}
+
+func RegisterWalletUnlockerServer(s *grpc.Server, srv WalletUnlockerServer) {
+	s.RegisterService(&_WalletUnlocker_serviceDesc, srv)
+}
+
+func _WalletUnlocker_GenSeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenSeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/GenSeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).GenSeed(ctx, req.(*GenSeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_InitWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).InitWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/InitWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).InitWallet(ctx, req.(*InitWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletUnlocker_UnlockWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.WalletUnlocker/UnlockWallet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletUnlockerServer).UnlockWallet(ctx, req.(*UnlockWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _WalletUnlocker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "lnrpc.WalletUnlocker",
+	HandlerType: (*WalletUnlockerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenSeed",
+			Handler:    _WalletUnlocker_GenSeed_Handler,
+		},
+		{
+			MethodName: "InitWallet",
+			Handler:    _WalletUnlocker_InitWallet_Handler,
+		},
+		{
+			MethodName: "UnlockWallet",
+			Handler:    _WalletUnlocker_UnlockWallet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
+
+// Client API for Lightning service
+
+type LightningClient interface {
+	// * lncli: `walletbalance`
+	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
+	// confirmed unspent outputs and all unconfirmed unspent outputs under control
+	// of the wallet.
+	WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error)
+	// * lncli: `channelbalance`
+	// ChannelBalance returns the total funds available across all open channels
+	// in satoshis.
+	ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error)
+	// * lncli: `listchaintxns`
+	// GetTransactions returns a list describing all the known transactions
+	// relevant to the wallet.
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error)
+	// * lncli: `sendcoins`
+	// SendCoins executes a request to send coins to a particular address. Unlike
+	// SendMany, this RPC call only allows creating a single output at a time. If
+	// neither target_conf, or sat_per_byte are set, then the internal wallet will
+	// consult its fee model to determine a fee for the default confirmation
+	// target.
+	SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error)
+	// *
+	// SubscribeTransactions creates a uni-directional stream from the server to
+	// the client in which any newly discovered transactions relevant to the
+	// wallet are sent over.
+	SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error)
+	// * lncli: `sendmany`
+	// SendMany handles a request for a transaction that creates multiple specified
+	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
+	// the internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error)
+	// * lncli: `newaddress`
+	// NewAddress creates a new address under control of the local wallet.
+	NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	// *
+	// NewWitnessAddress creates a new witness address under control of the local wallet.
+	NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error)
+	// * lncli: `signmessage`
+	// SignMessage signs a message with this node's private key. The returned
+	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
+	// only the message digest and signature are needed for verification.
+	// The message is prefixed with `Lightning Signed Message:` before it's
+	// signed, which keeps the signature compatible with other implementations.
+	SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error)
+	// * lncli: `verifymessage`
+	// VerifyMessage verifies a signature over a msg. The signature must be
+	// zbase32 encoded and signed by an active node in the resident node's
+	// channel database. In addition to returning the validity of the signature,
+	// VerifyMessage also returns the recovered pubkey from the signature.
+	VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error)
+	// * lncli: `connect`
+	// ConnectPeer attempts to establish a connection to a remote peer. This is at
+	// the networking level, and is used for communication between nodes. This is
+	// distinct from establishing a channel with a peer.
+	ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error)
+	// * lncli: `disconnect`
+	// DisconnectPeer attempts to disconnect one peer from another identified by a
+	// given pubKey. In the case that we currently have a pending or active channel
+	// with the target peer, then this action will be not be allowed.
+	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
+	// * lncli: `listpeers`
+	// ListPeers returns a verbose listing of all currently active peers.
+	ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error)
+	// * lncli: `getinfo`
+	// GetInfo returns general information concerning the lightning node including
+	// it's identity pubkey, alias, the chains it is connected to, and information
+	// concerning the number of open+pending channels.
+	GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error)
+	// * lncli: `pendingchannels`
+	// PendingChannels returns a list of all the channels that are currently
+	// considered "pending". A channel is pending if it has finished the funding
+	// workflow and is waiting for confirmations for the funding txn, or is in the
+	// process of closure, either initiated cooperatively or non-cooperatively.
+	PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error)
+	// * lncli: `listchannels`
+	// ListChannels returns a description of all the open channels that this node
+	// is a participant in.
+	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// *
+	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
+	// call is meant to be consumed by clients to the REST proxy. As with all
+	// other sync calls, all byte slices are intended to be populated as hex
+	// encoded strings.
+	OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error)
+	// * lncli: `openchannel`
+	// OpenChannel attempts to open a singly funded channel specified in the
+	// request to a remote peer. Users are able to specify a target number of
+	// blocks that the funding transaction should be confirmed in, or a manual fee
+	// rate to us for the funding transaction. If neither are specified, then a
+	// lax block confirmation target is used.
+	OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error)
+	// * lncli: `closechannel`
+	// CloseChannel attempts to close an active channel identified by its channel
+	// outpoint (ChannelPoint). The actions of this method can additionally be
+	// augmented to attempt a force close after a timeout period in the case of an
+	// inactive peer. If a non-force close (cooperative closure) is requested,
+	// then the user can specify either a target number of blocks until the
+	// closure transaction is confirmed, or a manual fee rate. If neither are
+	// specified, then a default lax, block confirmation target is used.
+	CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error)
+	// * lncli: `sendpayment`
+	// SendPayment dispatches a bi-directional streaming RPC for sending payments
+	// through the Lightning Network. A single RPC invocation creates a persistent
+	// bi-directional stream allowing clients to rapidly send payments through the
+	// Lightning Network with a single persistent connection.
+	SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error)
+	// *
+	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	// Additionally, this RPC expects the destination's public key and the payment
+	// hash (if any) to be encoded as hex strings.
+	SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// * lncli: `addinvoice`
+	// AddInvoice attempts to add a new invoice to the invoice database. Any
+	// duplicated invoices are rejected, therefore all invoices *must* have a
+	// unique payment preimage.
+	AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error)
+	// * lncli: `listinvoices`
+	// ListInvoices returns a list of all the invoices currently stored within the
+	// database. Any active debug invoices are ignored.
+	ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error)
+	// * lncli: `lookupinvoice`
+	// LookupInvoice attempts to look up an invoice according to its payment hash.
+	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
+	// returned.
+	LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error)
+	// *
+	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
+	// notifying the client of newly added, settled or canceled invoices. If the
+	// add or settle index is specified, then the invoices added or settled after
+	// it are sent first.
+	SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error)
+	// * lncli: `decodepayreq`
+	// DecodePayReq takes an encoded payment request string and attempts to decode
+	// it, returning a full description of the conditions encoded within the
+	// payment request.
+	DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error)
+	// * lncli: `listpayments`
+	// ListPayments returns a list of outgoing payments. The list may be paginated
+	// using the index of the payments.
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	// *
+	// DeleteAllPayments deletes all outgoing payments from DB.
+	DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `deletepayments`
+	// DeletePayments deletes the outgoing payments created before a given time,
+	// along with their HTLC attempts. If failed_only is set, the payments
+	// themselves are kept, and only their failed HTLC attempts are deleted.
+	DeletePayments(ctx context.Context, in *DeletePaymentsRequest, opts ...grpc.CallOption) (*DeletePaymentsResponse, error)
+	// * lncli: `describegraph`
+	// DescribeGraph returns a description of the latest graph state from the
+	// point of view of the node. The graph information is partitioned into two
+	// components: all the nodes/vertexes, and all the edges that connect the
+	// vertexes themselves.  As this is a directed graph, the edges also contain
+	// the node directional specific routing policy which includes: the time lock
+	// delta, fee information, etc.
+	DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error)
+	// * lncli: `getchaninfo`
+	// GetChanInfo returns the latest authenticated network announcement for the
+	// given channel identified by its channel ID: an 8-byte integer which
+	// uniquely identifies the location of transaction's funding output within the
+	// blockchain.
+	GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error)
+	// * lncli: `getnodeinfo`
+	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
+	// channel information for the specified node identified by its public key.
+	GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error)
+	// * lncli: `queryroutes`
+	// QueryRoutes attempts to query the daemon's Channel Router for a possible
+	// route to a target destination capable of carrying a specific amount of
+	// satoshis. The retuned route contains the full details required to craft and
+	// send an HTLC, also including the necessary information that should be
+	// present within the Sphinx packet encapsulated within the HTLC.
+	QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error)
+	// * lncli: `getnetworkinfo`
+	// GetNetworkInfo returns some basic stats about the known channel graph from
+	// the point of view of the node.
+	GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error)
+	// * lncli: `stop`
+	// StopDaemon will send a shutdown request to the interrupt handler, triggering
+	// a graceful shutdown of the daemon.
+	StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error)
+	// *
+	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+	// receive notifications upon any changes to the channel graph topology from
+	// the point of view of the responding node. Events notified include: new
+	// nodes coming online, nodes updating their authenticated attributes, new
+	// channels being advertised, updates in the routing policy for a directional
+	// channel edge, and when channels are closed on-chain.
+	SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error)
+	// * lncli: `debuglevel`
+	// DebugLevel allows a caller to programmatically set the logging verbosity of
+	// lnd. The logging can be targeted according to a coarse daemon-wide logging
+	// level, or in a granular fashion to specify the logging for a target
+	// sub-system.
+	DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error)
+	// * lncli: `feereport`
+	// FeeReport allows the caller to obtain a report detailing the current fee
+	// schedule enforced by the node globally for each channel.
+	FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error)
+	// * lncli: `updatechanpolicy`
+	// UpdateChannelPolicy allows the caller to update the fee schedule and
+	// channel policies for all channels globally, or a particular channel.
+	UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error)
+	// * lncli: `labelchannel`
+	// LabelChannel attaches a label to an open or pending channel, such as a note
+	// on why the channel was opened. The label is returned by ListChannels and
+	// PendingChannels, and kept until the channel is fully closed.
+	LabelChannel(ctx context.Context, in *LabelChannelRequest, opts ...grpc.CallOption) (*LabelChannelResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the first chunk
+	// of the past 24 hrs of forwarding history are returned.
+	//
+	// A list of forwarding events are returned. The size of each forwarding event
+	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+	// As a result each message can only contain 50k entries.  Each response has
+	// the index offset of the last entry. The index offset can be provided to the
+	// request to allow the caller to skip a series of records.
+	ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error)
+	// * lncli: `policy add`
+	// AddPolicy adds a fee policy which bounds the total fee that may be paid to
+	// route a payment to the target payment hash. Any existing policy for the
+	// same payment hash and amount band is overwritten.
+	AddPolicy(ctx context.Context, in *PaymentPolicy, opts ...grpc.CallOption) (*AddPolicyResponse, error)
+	// * lncli: `policy list`
+	// ListPolicies returns a list of all the fee policies currently stored within
+	// the database.
+	ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error)
+	// * lncli: `policy lookup`
+	// LookupPolicy attempts to look up the fee policy without an amount band for
+	// a payment hash. The passed payment hash *must* be exactly 32 bytes, if
+	// not, an error is returned.
+	LookupPolicy(ctx context.Context, in *PolicyPaymentHash, opts ...grpc.CallOption) (*PaymentPolicy, error)
+	// * lncli: `policy delete`
+	// DeletePolicy removes all fee policies for a payment hash from the
+	// database, including those limited to an amount band.
+	DeletePolicy(ctx context.Context, in *PolicyPaymentHash, opts ...grpc.CallOption) (*DeletePolicyResponse, error)
+	// * lncli: `policy setdefault`
+	// SetDefaultPolicy sets the default fee policy, which governs all payments
+	// for which neither a payment hash nor a node policy matches. Any existing
+	// default policy is overwritten. The payment hash of the policy is ignored,
+	// and it may not be limited to an amount band.
+	SetDefaultPolicy(ctx context.Context, in *PaymentPolicy, opts ...grpc.CallOption) (*SetDefaultPolicyResponse, error)
+	// * lncli: `policy getdefault`
+	// DefaultPolicy returns the default fee policy. If it hasn't been set, an
+	// error is returned.
+	DefaultPolicy(ctx context.Context, in *DefaultPolicyRequest, opts ...grpc.CallOption) (*PaymentPolicy, error)
+	// * lncli: `policy deletedefault`
+	// DeleteDefaultPolicy removes the default fee policy from the database.
+	DeleteDefaultPolicy(ctx context.Context, in *DeleteDefaultPolicyRequest, opts ...grpc.CallOption) (*DeleteDefaultPolicyResponse, error)
+	// * lncli: `policy export`
+	// ExportPolicies returns a backup of all payment hash and node fee policies
+	// stored within the database, encoded in the JSON lines format.
+	ExportPolicies(ctx context.Context, in *ExportPoliciesRequest, opts ...grpc.CallOption) (*PolicyBackup, error)
+	// * lncli: `policy import`
+	// ImportPolicies adds all fee policies contained within a backup created by
+	// ExportPolicies. Existing policies for the same payment hash or node and
+	// amount band are overwritten. If any policy within the backup is invalid,
+	// none are imported.
+	ImportPolicies(ctx context.Context, in *PolicyBackup, opts ...grpc.CallOption) (*ImportPoliciesResponse, error)
+	// * lncli: `policy auditlog`
+	// PolicyAuditLog returns the decisions made by fee policies for the payments
+	// they govern, in chronological order. Records are only kept for the
+	// retention window configured on the node.
+	PolicyAuditLog(ctx context.Context, in *PolicyAuditLogRequest, opts ...grpc.CallOption) (*PolicyAuditLogResponse, error)
+	// * lncli: `db compact`
+	// CompactDatabase schedules the channel database to be compacted the next
+	// time the daemon starts. Compaction copies the database into a fresh file,
+	// returning space freed within it to the filesystem. The copy is verified
+	// against the original before it atomically replaces it.
+	CompactDatabase(ctx context.Context, in *CompactDatabaseRequest, opts ...grpc.CallOption) (*CompactDatabaseResponse, error)
+	// * lncli: `db export`
+	// ExportDatabase streams a consistent, point-in-time snapshot of the channel
+	// database, which is safe to take while the daemon is running. The snapshot
+	// is sent in chunks, which concatenated form a valid database file.
+	ExportDatabase(ctx context.Context, in *ExportDatabaseRequest, opts ...grpc.CallOption) (Lightning_ExportDatabaseClient, error)
+	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which the lifecycle transitions of all channels are sent
+	// over: channels becoming pending open, open, closing and closed, as well as
+	// channels becoming active or inactive as their peer connects or
+	// disconnects.
+	SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error)
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which every
+	// HTLC about to be forwarded is held and sent to the client, which then
+	// decides whether the HTLC is forwarded as usual, settled or failed back.
+	// Only a single interceptor may be active at a time. Any HTLC held by the
+	// interceptor which isn't resolved once the stream ends is forwarded as
+	// usual.
+	HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error)
+	// *
+	// ChannelAcceptor dispatches a bi-directional streaming RPC in which every
+	// channel extended to us by a remote peer is sent to the client, which then
+	// decides whether we accept or reject it. Only a single acceptor may be
+	// active at a time. Any channel the client doesn't decide upon within 15
+	// seconds, or before the stream ends, is rejected.
+	ChannelAcceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_ChannelAcceptorClient, error)
+	// *
+	// RegisterRPCMiddleware registers an RPC middleware, which intercepts the
+	// requests and responses of calls to lnd and may reject or modify them. The
+	// first message sent by the middleware must be its registration. A middleware
+	// is either responsible for a custom macaroon caveat, in which case it
+	// intercepts the calls made with macaroons carrying the caveat, or runs in
+	// read-only mode, in which case it's handed all calls but can't alter them.
+	// Each intercepted message must be answered within 5 seconds, otherwise the
+	// call fails.
+	RegisterRPCMiddleware(ctx context.Context, opts ...grpc.CallOption) (Lightning_RegisterRPCMiddlewareClient, error)
+	// *
+	// SendToRoute is a bi-directional streaming RPC for sending payment through the
+	// Lightning Network. This method differs from SendPayment in that it allows
+	// users to specify a full route manually. This can be used for things like
+	// rebalancing, and atomic swaps.
+	SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error)
+	// *
+	// SendToRouteSync is a synchronous version of SendToRoute. It will block until
+	// the payment either fails or succeeds.
+	SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error)
+	// *
+	// lncli: `estimatefee`
+	// EstimateFee asks the chain backend to estimate the fee rate and total fees
+	// for a transaction that pays to multiple specified outputs.
+	EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error)
+	// *
+	// lncli: `abandonchannel`
+	// AbandonChannel removes all channel state from the database except for a
+	// close summary. This method can be used to get rid of permanently unusable
+	// channels due to bugs fixed in newer versions of lnd. Any funds still
+	// committed to the channel are lost. This method is only available if lnd
+	// was started with the --unsafe-abandon flag.
+	AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error)
+	// *
+	// lncli: `sendpayment --stream`
+	// SendPaymentStream sends a single payment through the Lightning Network,
+	// streaming an update each time an HTLC attempt is dispatched or fails while
+	// the payment is in flight. The stream ends with a final update once the
+	// payment either succeeded or failed.
+	SendPaymentStream(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (Lightning_SendPaymentStreamClient, error)
+	// *
+	// lncli: `getnodemetrics`
+	// GetNodeMetrics computes metrics of every node within the known channel graph,
+	// such as its betweenness centrality, which indicates how well the node is
+	// positioned to forward payments within the network.
+	GetNodeMetrics(ctx context.Context, in *NodeMetricsRequest, opts ...grpc.CallOption) (*NodeMetricsResponse, error)
+	// *
+	// lncli: `bakemacaroon`
+	// BakeMacaroon mints a new macaroon which only grants the passed permissions,
+	// optionally restricted to an IP address and a lifetime. This allows handing out
+	// credentials to applications which only need access to a subset of the calls,
+	// such as invoice-only or read-only access, without sharing the admin macaroon.
+	BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error)
+	// *
+	// lncli: `listmacaroonids`
+	// ListMacaroonIDs returns the IDs of all root keys macaroons may be derived
+	// from.
+	ListMacaroonIDs(ctx context.Context, in *ListMacaroonIDsRequest, opts ...grpc.CallOption) (*ListMacaroonIDsResponse, error)
+	// *
+	// lncli: `deletemacaroonid`
+	// DeleteMacaroonID deletes the root key with the passed ID, which instantly
+	// invalidates all macaroons derived from it. The root key of the default
+	// macaroons can't be deleted.
+	DeleteMacaroonID(ctx context.Context, in *DeleteMacaroonIDRequest, opts ...grpc.CallOption) (*DeleteMacaroonIDResponse, error)
+	// *
+	// CheckMacaroonPermissions checks whether the passed macaroon is valid, and
+	// grants the passed permissions, and returns the constraints its caveats impose
+	// on its use. This allows reverse proxies and other middleware to offload the
+	// verification of macaroons to lnd.
+	CheckMacaroonPermissions(ctx context.Context, in *CheckMacaroonPermissionsRequest, opts ...grpc.CallOption) (*CheckMacaroonPermissionsResponse, error)
+	// *
+	// SubscribePeerEvents creates a uni-directional stream from the server to the
+	// client in which an event is sent whenever a connection to a peer is
+	// established or lost.
+	SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error)
+	// *
+	// lncli: `trackpayment`
+	// TrackPayment streams the progress of the payment to the specified payment
+	// hash, as recorded within the database. The current state of the payment is
+	// replayed first, after which an update is streamed each time an HTLC attempt
+	// is dispatched or fails. The stream ends with a final update once the payment
+	// either succeeded or failed, which is sent right away if it already did.
+	TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error)
+}
+
+type lightningClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewLightningClient(cc *grpc.ClientConn) LightningClient {
+	return &lightningClient{cc}
+}
+
+func (c *lightningClient) WalletBalance(ctx context.Context, in *WalletBalanceRequest, opts ...grpc.CallOption) (*WalletBalanceResponse, error) {
+	out := new(WalletBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/WalletBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ChannelBalance(ctx context.Context, in *ChannelBalanceRequest, opts ...grpc.CallOption) (*ChannelBalanceResponse, error) {
+	out := new(ChannelBalanceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ChannelBalance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionDetails, error) {
+	out := new(TransactionDetails)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetTransactions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendCoins(ctx context.Context, in *SendCoinsRequest, opts ...grpc.CallOption) (*SendCoinsResponse, error) {
+	out := new(SendCoinsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendCoins", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (Lightning_SubscribeTransactionsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[0], c.cc, "/lnrpc.Lightning/SubscribeTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendMany(ctx context.Context, in *SendManyRequest, opts ...grpc.CallOption) (*SendManyResponse, error) {
+	out := new(SendManyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendMany", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewAddress(ctx context.Context, in *NewAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) NewWitnessAddress(ctx context.Context, in *NewWitnessAddressRequest, opts ...grpc.CallOption) (*NewAddressResponse, error) {
+	out := new(NewAddressResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/NewWitnessAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SignMessage(ctx context.Context, in *SignMessageRequest, opts ...grpc.CallOption) (*SignMessageResponse, error) {
+	out := new(SignMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SignMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) VerifyMessage(ctx context.Context, in *VerifyMessageRequest, opts ...grpc.CallOption) (*VerifyMessageResponse, error) {
+	out := new(VerifyMessageResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/VerifyMessage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ConnectPeer(ctx context.Context, in *ConnectPeerRequest, opts ...grpc.CallOption) (*ConnectPeerResponse, error) {
+	out := new(ConnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ConnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
+	out := new(DisconnectPeerResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DisconnectPeer", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*ListPeersResponse, error) {
+	out := new(ListPeersResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPeers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetInfo(ctx context.Context, in *GetInfoRequest, opts ...grpc.CallOption) (*GetInfoResponse, error) {
+	out := new(GetInfoResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) PendingChannels(ctx context.Context, in *PendingChannelsRequest, opts ...grpc.CallOption) (*PendingChannelsResponse, error) {
+	out := new(PendingChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PendingChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error) {
+	out := new(ListChannelsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListChannels", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannelSync(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (*ChannelPoint, error) {
+	out := new(ChannelPoint)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/OpenChannelSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) OpenChannel(ctx context.Context, in *OpenChannelRequest, opts ...grpc.CallOption) (Lightning_OpenChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[1], c.cc, "/lnrpc.Lightning/OpenChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningOpenChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_OpenChannelClient interface {
+	Recv() (*OpenStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningOpenChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningOpenChannelClient) Recv() (*OpenStatusUpdate, error) {
+	m := new(OpenStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) CloseChannel(ctx context.Context, in *CloseChannelRequest, opts ...grpc.CallOption) (Lightning_CloseChannelClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[2], c.cc, "/lnrpc.Lightning/CloseChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningCloseChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_CloseChannelClient interface {
+	Recv() (*CloseStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningCloseChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningCloseChannelClient) Recv() (*CloseStatusUpdate, error) {
+	m := new(CloseStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPayment(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[3], c.cc, "/lnrpc.Lightning/SendPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentClient{stream}
+	return x, nil
+}
+
+type Lightning_SendPaymentClient interface {
+	Send(*SendRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendPaymentSync(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendPaymentSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AddInvoice(ctx context.Context, in *Invoice, opts ...grpc.CallOption) (*AddInvoiceResponse, error) {
+	out := new(AddInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListInvoices(ctx context.Context, in *ListInvoiceRequest, opts ...grpc.CallOption) (*ListInvoiceResponse, error) {
+	out := new(ListInvoiceResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListInvoices", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LookupInvoice(ctx context.Context, in *PaymentHash, opts ...grpc.CallOption) (*Invoice, error) {
+	out := new(Invoice)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupInvoice", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeInvoices(ctx context.Context, in *InvoiceSubscription, opts ...grpc.CallOption) (Lightning_SubscribeInvoicesClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[4], c.cc, "/lnrpc.Lightning/SubscribeInvoices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeInvoicesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeInvoicesClient interface {
+	Recv() (*Invoice, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeInvoicesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeInvoicesClient) Recv() (*Invoice, error) {
+	m := new(Invoice)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) DecodePayReq(ctx context.Context, in *PayReqString, opts ...grpc.CallOption) (*PayReq, error) {
+	out := new(PayReq)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DecodePayReq", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	out := new(ListPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteAllPayments(ctx context.Context, in *DeleteAllPaymentsRequest, opts ...grpc.CallOption) (*DeleteAllPaymentsResponse, error) {
+	out := new(DeleteAllPaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteAllPayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeletePayments(ctx context.Context, in *DeletePaymentsRequest, opts ...grpc.CallOption) (*DeletePaymentsResponse, error) {
+	out := new(DeletePaymentsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeletePayments", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DescribeGraph(ctx context.Context, in *ChannelGraphRequest, opts ...grpc.CallOption) (*ChannelGraph, error) {
+	out := new(ChannelGraph)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DescribeGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetChanInfo(ctx context.Context, in *ChanInfoRequest, opts ...grpc.CallOption) (*ChannelEdge, error) {
+	out := new(ChannelEdge)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetChanInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfo, error) {
+	out := new(NodeInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) QueryRoutes(ctx context.Context, in *QueryRoutesRequest, opts ...grpc.CallOption) (*QueryRoutesResponse, error) {
+	out := new(QueryRoutesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/QueryRoutes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) GetNetworkInfo(ctx context.Context, in *NetworkInfoRequest, opts ...grpc.CallOption) (*NetworkInfo, error) {
+	out := new(NetworkInfo)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNetworkInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) StopDaemon(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (*StopResponse, error) {
+	out := new(StopResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/StopDaemon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribeChannelGraph(ctx context.Context, in *GraphTopologySubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelGraphClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[5], c.cc, "/lnrpc.Lightning/SubscribeChannelGraph", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelGraphClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelGraphClient interface {
+	Recv() (*GraphTopologyUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelGraphClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelGraphClient) Recv() (*GraphTopologyUpdate, error) {
+	m := new(GraphTopologyUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) DebugLevel(ctx context.Context, in *DebugLevelRequest, opts ...grpc.CallOption) (*DebugLevelResponse, error) {
+	out := new(DebugLevelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DebugLevel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) FeeReport(ctx context.Context, in *FeeReportRequest, opts ...grpc.CallOption) (*FeeReportResponse, error) {
+	out := new(FeeReportResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/FeeReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) UpdateChannelPolicy(ctx context.Context, in *PolicyUpdateRequest, opts ...grpc.CallOption) (*PolicyUpdateResponse, error) {
+	out := new(PolicyUpdateResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/UpdateChannelPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LabelChannel(ctx context.Context, in *LabelChannelRequest, opts ...grpc.CallOption) (*LabelChannelResponse, error) {
+	out := new(LabelChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LabelChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ForwardingHistory(ctx context.Context, in *ForwardingHistoryRequest, opts ...grpc.CallOption) (*ForwardingHistoryResponse, error) {
+	out := new(ForwardingHistoryResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ForwardingHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AddPolicy(ctx context.Context, in *PaymentPolicy, opts ...grpc.CallOption) (*AddPolicyResponse, error) {
+	out := new(AddPolicyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AddPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListPolicies(ctx context.Context, in *ListPoliciesRequest, opts ...grpc.CallOption) (*ListPoliciesResponse, error) {
+	out := new(ListPoliciesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListPolicies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) LookupPolicy(ctx context.Context, in *PolicyPaymentHash, opts ...grpc.CallOption) (*PaymentPolicy, error) {
+	out := new(PaymentPolicy)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/LookupPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeletePolicy(ctx context.Context, in *PolicyPaymentHash, opts ...grpc.CallOption) (*DeletePolicyResponse, error) {
+	out := new(DeletePolicyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeletePolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SetDefaultPolicy(ctx context.Context, in *PaymentPolicy, opts ...grpc.CallOption) (*SetDefaultPolicyResponse, error) {
+	out := new(SetDefaultPolicyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SetDefaultPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DefaultPolicy(ctx context.Context, in *DefaultPolicyRequest, opts ...grpc.CallOption) (*PaymentPolicy, error) {
+	out := new(PaymentPolicy)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DefaultPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteDefaultPolicy(ctx context.Context, in *DeleteDefaultPolicyRequest, opts ...grpc.CallOption) (*DeleteDefaultPolicyResponse, error) {
+	out := new(DeleteDefaultPolicyResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteDefaultPolicy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportPolicies(ctx context.Context, in *ExportPoliciesRequest, opts ...grpc.CallOption) (*PolicyBackup, error) {
+	out := new(PolicyBackup)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ExportPolicies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ImportPolicies(ctx context.Context, in *PolicyBackup, opts ...grpc.CallOption) (*ImportPoliciesResponse, error) {
+	out := new(ImportPoliciesResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ImportPolicies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) PolicyAuditLog(ctx context.Context, in *PolicyAuditLogRequest, opts ...grpc.CallOption) (*PolicyAuditLogResponse, error) {
+	out := new(PolicyAuditLogResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/PolicyAuditLog", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CompactDatabase(ctx context.Context, in *CompactDatabaseRequest, opts ...grpc.CallOption) (*CompactDatabaseResponse, error) {
+	out := new(CompactDatabaseResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CompactDatabase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ExportDatabase(ctx context.Context, in *ExportDatabaseRequest, opts ...grpc.CallOption) (Lightning_ExportDatabaseClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[6], c.cc, "/lnrpc.Lightning/ExportDatabase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningExportDatabaseClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_ExportDatabaseClient interface {
+	Recv() (*DatabaseChunk, error)
+	grpc.ClientStream
+}
+
+type lightningExportDatabaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningExportDatabaseClient) Recv() (*DatabaseChunk, error) {
+	m := new(DatabaseChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SubscribeChannelEvents(ctx context.Context, in *ChannelEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribeChannelEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[7], c.cc, "/lnrpc.Lightning/SubscribeChannelEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribeChannelEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribeChannelEventsClient interface {
+	Recv() (*ChannelEventUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribeChannelEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribeChannelEventsClient) Recv() (*ChannelEventUpdate, error) {
+	m := new(ChannelEventUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) HtlcInterceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_HtlcInterceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[8], c.cc, "/lnrpc.Lightning/HtlcInterceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningHtlcInterceptorClient{stream}
+	return x, nil
+}
+
+type Lightning_HtlcInterceptorClient interface {
+	Send(*ForwardHtlcInterceptResponse) error
+	Recv() (*ForwardHtlcInterceptRequest, error)
+	grpc.ClientStream
+}
+
+type lightningHtlcInterceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningHtlcInterceptorClient) Send(m *ForwardHtlcInterceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningHtlcInterceptorClient) Recv() (*ForwardHtlcInterceptRequest, error) {
+	m := new(ForwardHtlcInterceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) ChannelAcceptor(ctx context.Context, opts ...grpc.CallOption) (Lightning_ChannelAcceptorClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[9], c.cc, "/lnrpc.Lightning/ChannelAcceptor", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningChannelAcceptorClient{stream}
+	return x, nil
+}
+
+type Lightning_ChannelAcceptorClient interface {
+	Send(*ChannelAcceptResponse) error
+	Recv() (*ChannelAcceptRequest, error)
+	grpc.ClientStream
+}
+
+type lightningChannelAcceptorClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningChannelAcceptorClient) Send(m *ChannelAcceptResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningChannelAcceptorClient) Recv() (*ChannelAcceptRequest, error) {
+	m := new(ChannelAcceptRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) RegisterRPCMiddleware(ctx context.Context, opts ...grpc.CallOption) (Lightning_RegisterRPCMiddlewareClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[10], c.cc, "/lnrpc.Lightning/RegisterRPCMiddleware", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningRegisterRPCMiddlewareClient{stream}
+	return x, nil
+}
+
+type Lightning_RegisterRPCMiddlewareClient interface {
+	Send(*RPCMiddlewareResponse) error
+	Recv() (*RPCMiddlewareRequest, error)
+	grpc.ClientStream
+}
+
+type lightningRegisterRPCMiddlewareClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningRegisterRPCMiddlewareClient) Send(m *RPCMiddlewareResponse) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningRegisterRPCMiddlewareClient) Recv() (*RPCMiddlewareRequest, error) {
+	m := new(RPCMiddlewareRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendToRoute(ctx context.Context, opts ...grpc.CallOption) (Lightning_SendToRouteClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[11], c.cc, "/lnrpc.Lightning/SendToRoute", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendToRouteClient{stream}
+	return x, nil
+}
+
+type Lightning_SendToRouteClient interface {
+	Send(*SendToRouteRequest) error
+	Recv() (*SendResponse, error)
+	grpc.ClientStream
+}
+
+type lightningSendToRouteClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendToRouteClient) Send(m *SendToRouteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lightningSendToRouteClient) Recv() (*SendResponse, error) {
+	m := new(SendResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) SendToRouteSync(ctx context.Context, in *SendToRouteRequest, opts ...grpc.CallOption) (*SendResponse, error) {
+	out := new(SendResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/SendToRouteSync", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*EstimateFeeResponse, error) {
+	out := new(EstimateFeeResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/EstimateFee", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) AbandonChannel(ctx context.Context, in *AbandonChannelRequest, opts ...grpc.CallOption) (*AbandonChannelResponse, error) {
+	out := new(AbandonChannelResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/AbandonChannel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SendPaymentStream(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (Lightning_SendPaymentStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[12], c.cc, "/lnrpc.Lightning/SendPaymentStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSendPaymentStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SendPaymentStreamClient interface {
+	Recv() (*PaymentUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningSendPaymentStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSendPaymentStreamClient) Recv() (*PaymentUpdate, error) {
+	m := new(PaymentUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) GetNodeMetrics(ctx context.Context, in *NodeMetricsRequest, opts ...grpc.CallOption) (*NodeMetricsResponse, error) {
+	out := new(NodeMetricsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/GetNodeMetrics", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) BakeMacaroon(ctx context.Context, in *BakeMacaroonRequest, opts ...grpc.CallOption) (*BakeMacaroonResponse, error) {
+	out := new(BakeMacaroonResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/BakeMacaroon", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) ListMacaroonIDs(ctx context.Context, in *ListMacaroonIDsRequest, opts ...grpc.CallOption) (*ListMacaroonIDsResponse, error) {
+	out := new(ListMacaroonIDsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/ListMacaroonIDs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) DeleteMacaroonID(ctx context.Context, in *DeleteMacaroonIDRequest, opts ...grpc.CallOption) (*DeleteMacaroonIDResponse, error) {
+	out := new(DeleteMacaroonIDResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/DeleteMacaroonID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) CheckMacaroonPermissions(ctx context.Context, in *CheckMacaroonPermissionsRequest, opts ...grpc.CallOption) (*CheckMacaroonPermissionsResponse, error) {
+	out := new(CheckMacaroonPermissionsResponse)
+	err := grpc.Invoke(ctx, "/lnrpc.Lightning/CheckMacaroonPermissions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lightningClient) SubscribePeerEvents(ctx context.Context, in *PeerEventSubscription, opts ...grpc.CallOption) (Lightning_SubscribePeerEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[13], c.cc, "/lnrpc.Lightning/SubscribePeerEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningSubscribePeerEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_SubscribePeerEventsClient interface {
+	Recv() (*PeerEvent, error)
+	grpc.ClientStream
+}
+
+type lightningSubscribePeerEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningSubscribePeerEventsClient) Recv() (*PeerEvent, error) {
+	m := new(PeerEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lightningClient) TrackPayment(ctx context.Context, in *TrackPaymentRequest, opts ...grpc.CallOption) (Lightning_TrackPaymentClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Lightning_serviceDesc.Streams[14], c.cc, "/lnrpc.Lightning/TrackPayment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lightningTrackPaymentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Lightning_TrackPaymentClient interface {
+	Recv() (*PaymentUpdate, error)
+	grpc.ClientStream
+}
+
+type lightningTrackPaymentClient struct {
+	grpc.ClientStream
+}
+
+func (x *lightningTrackPaymentClient) Recv() (*PaymentUpdate, error) {
+	m := new(PaymentUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for Lightning service
+
+type LightningServer interface {
+	// * lncli: `walletbalance`
+	// WalletBalance returns total unspent outputs(confirmed and unconfirmed), all
+	// confirmed unspent outputs and all unconfirmed unspent outputs under control
+	// of the wallet.
+	WalletBalance(context.Context, *WalletBalanceRequest) (*WalletBalanceResponse, error)
+	// * lncli: `channelbalance`
+	// ChannelBalance returns the total funds available across all open channels
+	// in satoshis.
+	ChannelBalance(context.Context, *ChannelBalanceRequest) (*ChannelBalanceResponse, error)
+	// * lncli: `listchaintxns`
+	// GetTransactions returns a list describing all the known transactions
+	// relevant to the wallet.
+	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionDetails, error)
+	// * lncli: `sendcoins`
+	// SendCoins executes a request to send coins to a particular address. Unlike
+	// SendMany, this RPC call only allows creating a single output at a time. If
+	// neither target_conf, or sat_per_byte are set, then the internal wallet will
+	// consult its fee model to determine a fee for the default confirmation
+	// target.
+	SendCoins(context.Context, *SendCoinsRequest) (*SendCoinsResponse, error)
+	// *
+	// SubscribeTransactions creates a uni-directional stream from the server to
+	// the client in which any newly discovered transactions relevant to the
+	// wallet are sent over.
+	SubscribeTransactions(*GetTransactionsRequest, Lightning_SubscribeTransactionsServer) error
+	// * lncli: `sendmany`
+	// SendMany handles a request for a transaction that creates multiple specified
+	// outputs in parallel. If neither target_conf, or sat_per_byte are set, then
+	// the internal wallet will consult its fee model to determine a fee for the
+	// default confirmation target.
+	SendMany(context.Context, *SendManyRequest) (*SendManyResponse, error)
+	// * lncli: `newaddress`
+	// NewAddress creates a new address under control of the local wallet.
+	NewAddress(context.Context, *NewAddressRequest) (*NewAddressResponse, error)
+	// *
+	// NewWitnessAddress creates a new witness address under control of the local wallet.
+	NewWitnessAddress(context.Context, *NewWitnessAddressRequest) (*NewAddressResponse, error)
+	// * lncli: `signmessage`
+	// SignMessage signs a message with this node's private key. The returned
+	// signature string is `zbase32` encoded and pubkey recoverable, meaning that
+	// only the message digest and signature are needed for verification.
+	// The message is prefixed with `Lightning Signed Message:` before it's
+	// signed, which keeps the signature compatible with other implementations.
+	SignMessage(context.Context, *SignMessageRequest) (*SignMessageResponse, error)
+	// * lncli: `verifymessage`
+	// VerifyMessage verifies a signature over a msg. The signature must be
+	// zbase32 encoded and signed by an active node in the resident node's
+	// channel database. In addition to returning the validity of the signature,
+	// VerifyMessage also returns the recovered pubkey from the signature.
+	VerifyMessage(context.Context, *VerifyMessageRequest) (*VerifyMessageResponse, error)
+	// * lncli: `connect`
+	// ConnectPeer attempts to establish a connection to a remote peer. This is at
+	// the networking level, and is used for communication between nodes. This is
+	// distinct from establishing a channel with a peer.
+	ConnectPeer(context.Context, *ConnectPeerRequest) (*ConnectPeerResponse, error)
+	// * lncli: `disconnect`
+	// DisconnectPeer attempts to disconnect one peer from another identified by a
+	// given pubKey. In the case that we currently have a pending or active channel
+	// with the target peer, then this action will be not be allowed.
+	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
+	// * lncli: `listpeers`
+	// ListPeers returns a verbose listing of all currently active peers.
+	ListPeers(context.Context, *ListPeersRequest) (*ListPeersResponse, error)
+	// * lncli: `getinfo`
+	// GetInfo returns general information concerning the lightning node including
+	// it's identity pubkey, alias, the chains it is connected to, and information
+	// concerning the number of open+pending channels.
+	GetInfo(context.Context, *GetInfoRequest) (*GetInfoResponse, error)
+	// * lncli: `pendingchannels`
+	// PendingChannels returns a list of all the channels that are currently
+	// considered "pending". A channel is pending if it has finished the funding
+	// workflow and is waiting for confirmations for the funding txn, or is in the
+	// process of closure, either initiated cooperatively or non-cooperatively.
+	PendingChannels(context.Context, *PendingChannelsRequest) (*PendingChannelsResponse, error)
+	// * lncli: `listchannels`
+	// ListChannels returns a description of all the open channels that this node
+	// is a participant in.
+	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// *
+	// OpenChannelSync is a synchronous version of the OpenChannel RPC call. This
+	// call is meant to be consumed by clients to the REST proxy. As with all
+	// other sync calls, all byte slices are intended to be populated as hex
+	// encoded strings.
+	OpenChannelSync(context.Context, *OpenChannelRequest) (*ChannelPoint, error)
+	// * lncli: `openchannel`
+	// OpenChannel attempts to open a singly funded channel specified in the
+	// request to a remote peer. Users are able to specify a target number of
+	// blocks that the funding transaction should be confirmed in, or a manual fee
+	// rate to us for the funding transaction. If neither are specified, then a
+	// lax block confirmation target is used.
+	OpenChannel(*OpenChannelRequest, Lightning_OpenChannelServer) error
+	// * lncli: `closechannel`
+	// CloseChannel attempts to close an active channel identified by its channel
+	// outpoint (ChannelPoint). The actions of this method can additionally be
+	// augmented to attempt a force close after a timeout period in the case of an
+	// inactive peer. If a non-force close (cooperative closure) is requested,
+	// then the user can specify either a target number of blocks until the
+	// closure transaction is confirmed, or a manual fee rate. If neither are
+	// specified, then a default lax, block confirmation target is used.
+	CloseChannel(*CloseChannelRequest, Lightning_CloseChannelServer) error
+	// * lncli: `sendpayment`
+	// SendPayment dispatches a bi-directional streaming RPC for sending payments
+	// through the Lightning Network. A single RPC invocation creates a persistent
+	// bi-directional stream allowing clients to rapidly send payments through the
+	// Lightning Network with a single persistent connection.
+	SendPayment(Lightning_SendPaymentServer) error
+	// *
+	// SendPaymentSync is the synchronous non-streaming version of SendPayment.
+	// This RPC is intended to be consumed by clients of the REST proxy.
+	// Additionally, this RPC expects the destination's public key and the payment
+	// hash (if any) to be encoded as hex strings.
+	SendPaymentSync(context.Context, *SendRequest) (*SendResponse, error)
+	// * lncli: `addinvoice`
+	// AddInvoice attempts to add a new invoice to the invoice database. Any
+	// duplicated invoices are rejected, therefore all invoices *must* have a
+	// unique payment preimage.
+	AddInvoice(context.Context, *Invoice) (*AddInvoiceResponse, error)
+	// * lncli: `listinvoices`
+	// ListInvoices returns a list of all the invoices currently stored within the
+	// database. Any active debug invoices are ignored.
+	ListInvoices(context.Context, *ListInvoiceRequest) (*ListInvoiceResponse, error)
+	// * lncli: `lookupinvoice`
+	// LookupInvoice attempts to look up an invoice according to its payment hash.
+	// The passed payment hash *must* be exactly 32 bytes, if not, an error is
+	// returned.
+	LookupInvoice(context.Context, *PaymentHash) (*Invoice, error)
+	// *
+	// SubscribeInvoices returns a uni-directional stream (sever -> client) for
+	// notifying the client of newly added, settled or canceled invoices. If the
+	// add or settle index is specified, then the invoices added or settled after
+	// it are sent first.
+	SubscribeInvoices(*InvoiceSubscription, Lightning_SubscribeInvoicesServer) error
+	// * lncli: `decodepayreq`
+	// DecodePayReq takes an encoded payment request string and attempts to decode
+	// it, returning a full description of the conditions encoded within the
+	// payment request.
+	DecodePayReq(context.Context, *PayReqString) (*PayReq, error)
+	// * lncli: `listpayments`
+	// ListPayments returns a list of outgoing payments. The list may be paginated
+	// using the index of the payments.
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// *
+	// DeleteAllPayments deletes all outgoing payments from DB.
+	DeleteAllPayments(context.Context, *DeleteAllPaymentsRequest) (*DeleteAllPaymentsResponse, error)
+	// * lncli: `deletepayments`
+	// DeletePayments deletes the outgoing payments created before a given time,
+	// along with their HTLC attempts. If failed_only is set, the payments
+	// themselves are kept, and only their failed HTLC attempts are deleted.
+	DeletePayments(context.Context, *DeletePaymentsRequest) (*DeletePaymentsResponse, error)
+	// * lncli: `describegraph`
+	// DescribeGraph returns a description of the latest graph state from the
+	// point of view of the node. The graph information is partitioned into two
+	// components: all the nodes/vertexes, and all the edges that connect the
+	// vertexes themselves.  As this is a directed graph, the edges also contain
+	// the node directional specific routing policy which includes: the time lock
+	// delta, fee information, etc.
+	DescribeGraph(context.Context, *ChannelGraphRequest) (*ChannelGraph, error)
+	// * lncli: `getchaninfo`
+	// GetChanInfo returns the latest authenticated network announcement for the
+	// given channel identified by its channel ID: an 8-byte integer which
+	// uniquely identifies the location of transaction's funding output within the
+	// blockchain.
+	GetChanInfo(context.Context, *ChanInfoRequest) (*ChannelEdge, error)
+	// * lncli: `getnodeinfo`
+	// GetNodeInfo returns the latest advertised, aggregated, and authenticated
+	// channel information for the specified node identified by its public key.
+	GetNodeInfo(context.Context, *NodeInfoRequest) (*NodeInfo, error)
+	// * lncli: `queryroutes`
+	// QueryRoutes attempts to query the daemon's Channel Router for a possible
+	// route to a target destination capable of carrying a specific amount of
+	// satoshis. The retuned route contains the full details required to craft and
+	// send an HTLC, also including the necessary information that should be
+	// present within the Sphinx packet encapsulated within the HTLC.
+	QueryRoutes(context.Context, *QueryRoutesRequest) (*QueryRoutesResponse, error)
+	// * lncli: `getnetworkinfo`
+	// GetNetworkInfo returns some basic stats about the known channel graph from
+	// the point of view of the node.
+	GetNetworkInfo(context.Context, *NetworkInfoRequest) (*NetworkInfo, error)
+	// * lncli: `stop`
+	// StopDaemon will send a shutdown request to the interrupt handler, triggering
+	// a graceful shutdown of the daemon.
+	StopDaemon(context.Context, *StopRequest) (*StopResponse, error)
+	// *
+	// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+	// receive notifications upon any changes to the channel graph topology from
+	// the point of view of the responding node. Events notified include: new
+	// nodes coming online, nodes updating their authenticated attributes, new
+	// channels being advertised, updates in the routing policy for a directional
+	// channel edge, and when channels are closed on-chain.
+	SubscribeChannelGraph(*GraphTopologySubscription, Lightning_SubscribeChannelGraphServer) error
+	// * lncli: `debuglevel`
+	// DebugLevel allows a caller to programmatically set the logging verbosity of
+	// lnd. The logging can be targeted according to a coarse daemon-wide logging
+	// level, or in a granular fashion to specify the logging for a target
+	// sub-system.
+	DebugLevel(context.Context, *DebugLevelRequest) (*DebugLevelResponse, error)
+	// * lncli: `feereport`
+	// FeeReport allows the caller to obtain a report detailing the current fee
+	// schedule enforced by the node globally for each channel.
+	FeeReport(context.Context, *FeeReportRequest) (*FeeReportResponse, error)
+	// * lncli: `updatechanpolicy`
+	// UpdateChannelPolicy allows the caller to update the fee schedule and
+	// channel policies for all channels globally, or a particular channel.
+	UpdateChannelPolicy(context.Context, *PolicyUpdateRequest) (*PolicyUpdateResponse, error)
+	// * lncli: `labelchannel`
+	// LabelChannel attaches a label to an open or pending channel, such as a note
+	// on why the channel was opened. The label is returned by ListChannels and
+	// PendingChannels, and kept until the channel is fully closed.
+	LabelChannel(context.Context, *LabelChannelRequest) (*LabelChannelResponse, error)
+	// * lncli: `fwdinghistory`
+	// ForwardingHistory allows the caller to query the htlcswitch for a record of
+	// all HTLC's forwarded within the target time range, and integer offset
+	// within that time range. If no time-range is specified, then the first chunk
+	// of the past 24 hrs of forwarding history are returned.
+	//
+	// A list of forwarding events are returned. The size of each forwarding event
+	// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+	// As a result each message can only contain 50k entries.  Each response has
+	// the index offset of the last entry. The index offset can be provided to the
+	// request to allow the caller to skip a series of records.
+	ForwardingHistory(context.Context, *ForwardingHistoryRequest) (*ForwardingHistoryResponse, error)
+	// * lncli: `policy add`
+	// AddPolicy adds a fee policy which bounds the total fee that may be paid to
+	// route a payment to the target payment hash. Any existing policy for the
+	// same payment hash and amount band is overwritten.
+	AddPolicy(context.Context, *PaymentPolicy) (*AddPolicyResponse, error)
+	// * lncli: `policy list`
+	// ListPolicies returns a list of all the fee policies currently stored within
+	// the database.
+	ListPolicies(context.Context, *ListPoliciesRequest) (*ListPoliciesResponse, error)
+	// * lncli: `policy lookup`
+	// LookupPolicy attempts to look up the fee policy without an amount band for
+	// a payment hash. The passed payment hash *must* be exactly 32 bytes, if
+	// not, an error is returned.
+	LookupPolicy(context.Context, *PolicyPaymentHash) (*PaymentPolicy, error)
+	// * lncli: `policy delete`
+	// DeletePolicy removes all fee policies for a payment hash from the
+	// database, including those limited to an amount band.
+	DeletePolicy(context.Context, *PolicyPaymentHash) (*DeletePolicyResponse, error)
+	// * lncli: `policy setdefault`
+	// SetDefaultPolicy sets the default fee policy, which governs all payments
+	// for which neither a payment hash nor a node policy matches. Any existing
+	// default policy is overwritten. The payment hash of the policy is ignored,
+	// and it may not be limited to an amount band.
+	SetDefaultPolicy(context.Context, *PaymentPolicy) (*SetDefaultPolicyResponse, error)
+	// * lncli: `policy getdefault`
+	// DefaultPolicy returns the default fee policy. If it hasn't been set, an
+	// error is returned.
+	DefaultPolicy(context.Context, *DefaultPolicyRequest) (*PaymentPolicy, error)
+	// * lncli: `policy deletedefault`
+	// DeleteDefaultPolicy removes the default fee policy from the database.
+	DeleteDefaultPolicy(context.Context, *DeleteDefaultPolicyRequest) (*DeleteDefaultPolicyResponse, error)
+	// * lncli: `policy export`
+	// ExportPolicies returns a backup of all payment hash and node fee policies
+	// stored within the database, encoded in the JSON lines format.
+	ExportPolicies(context.Context, *ExportPoliciesRequest) (*PolicyBackup, error)
+	// * lncli: `policy import`
+	// ImportPolicies adds all fee policies contained within a backup created by
+	// ExportPolicies. Existing policies for the same payment hash or node and
+	// amount band are overwritten. If any policy within the backup is invalid,
+	// none are imported.
+	ImportPolicies(context.Context, *PolicyBackup) (*ImportPoliciesResponse, error)
+	// * lncli: `policy auditlog`
+	// PolicyAuditLog returns the decisions made by fee policies for the payments
+	// they govern, in chronological order. Records are only kept for the
+	// retention window configured on the node.
+	PolicyAuditLog(context.Context, *PolicyAuditLogRequest) (*PolicyAuditLogResponse, error)
+	// * lncli: `db compact`
+	// CompactDatabase schedules the channel database to be compacted the next
+	// time the daemon starts. Compaction copies the database into a fresh file,
+	// returning space freed within it to the filesystem. The copy is verified
+	// against the original before it atomically replaces it.
+	CompactDatabase(context.Context, *CompactDatabaseRequest) (*CompactDatabaseResponse, error)
+	// * lncli: `db export`
+	// ExportDatabase streams a consistent, point-in-time snapshot of the channel
+	// database, which is safe to take while the daemon is running. The snapshot
+	// is sent in chunks, which concatenated form a valid database file.
+	ExportDatabase(*ExportDatabaseRequest, Lightning_ExportDatabaseServer) error
+	// *
+	// SubscribeChannelEvents creates a uni-directional stream from the server to
+	// the client in which the lifecycle transitions of all channels are sent
+	// over: channels becoming pending open, open, closing and closed, as well as
+	// channels becoming active or inactive as their peer connects or
+	// disconnects.
+	SubscribeChannelEvents(*ChannelEventSubscription, Lightning_SubscribeChannelEventsServer) error
+	// *
+	// HtlcInterceptor dispatches a bi-directional streaming RPC in which every
+	// HTLC about to be forwarded is held and sent to the client, which then
+	// decides whether the HTLC is forwarded as usual, settled or failed back.
+	// Only a single interceptor may be active at a time. Any HTLC held by the
+	// interceptor which isn't resolved once the stream ends is forwarded as
+	// usual.
+	HtlcInterceptor(Lightning_HtlcInterceptorServer) error
+	// *
+	// ChannelAcceptor dispatches a bi-directional streaming RPC in which every
+	// channel extended to us by a remote peer is sent to the client, which then
+	// decides whether we accept or reject it. Only a single acceptor may be
+	// active at a time. Any channel the client doesn't decide upon within 15
+	// seconds, or before the stream ends, is rejected.
+	ChannelAcceptor(Lightning_ChannelAcceptorServer) error
+	// *
+	// RegisterRPCMiddleware registers an RPC middleware, which intercepts the
+	// requests and responses of calls to lnd and may reject or modify them. The
+	// first message sent by the middleware must be its registration. A middleware
+	// is either responsible for a custom macaroon caveat, in which case it
+	// intercepts the calls made with macaroons carrying the caveat, or runs in
+	// read-only mode, in which case it's handed all calls but can't alter them.
+	// Each intercepted message must be answered within 5 seconds, otherwise the
+	// call fails.
+	RegisterRPCMiddleware(Lightning_RegisterRPCMiddlewareServer) error
+	// *
+	// SendToRoute is a bi-directional streaming RPC for sending payment through the
+	// Lightning Network. This method differs from SendPayment in that it allows
+	// users to specify a full route manually. This can be used for things like
+	// rebalancing, and atomic swaps.
+	SendToRoute(Lightning_SendToRouteServer) error
+	// *
+	// SendToRouteSync is a synchronous version of SendToRoute. It will block until
+	// the payment either fails or succeeds.
+	SendToRouteSync(context.Context, *SendToRouteRequest) (*SendResponse, error)
+	// *
+	// lncli: `estimatefee`
+	// EstimateFee asks the chain backend to estimate the fee rate and total fees
+	// for a transaction that pays to multiple specified outputs.
+	EstimateFee(context.Context, *EstimateFeeRequest) (*EstimateFeeResponse, error)
+	// *
+	// lncli: `abandonchannel`
+	// AbandonChannel removes all channel state from the database except for a
+	// close summary. This method can be used to get rid of permanently unusable
+	// channels due to bugs fixed in newer versions of lnd. Any funds still
+	// committed to the channel are lost. This method is only available if lnd
+	// was started with the --unsafe-abandon flag.
+	AbandonChannel(context.Context, *AbandonChannelRequest) (*AbandonChannelResponse, error)
+	// *
+	// lncli: `sendpayment --stream`
+	// SendPaymentStream sends a single payment through the Lightning Network,
+	// streaming an update each time an HTLC attempt is dispatched or fails while
+	// the payment is in flight. The stream ends with a final update once the
+	// payment either succeeded or failed.
+	SendPaymentStream(*SendRequest, Lightning_SendPaymentStreamServer) error
+	// *
+	// lncli: `getnodemetrics`
+	// GetNodeMetrics computes metrics of every node within the known channel graph,
+	// such as its betweenness centrality, which indicates how well the node is
+	// positioned to forward payments within the network.
+	GetNodeMetrics(context.Context, *NodeMetricsRequest) (*NodeMetricsResponse, error)
+	// *
+	// lncli: `bakemacaroon`
+	// BakeMacaroon mints a new macaroon which only grants the passed permissions,
+	// optionally restricted to an IP address and a lifetime. This allows handing out
+	// credentials to applications which only need access to a subset of the calls,
+	// such as invoice-only or read-only access, without sharing the admin macaroon.
+	BakeMacaroon(context.Context, *BakeMacaroonRequest) (*BakeMacaroonResponse, error)
+	// *
+	// lncli: `listmacaroonids`
+	// ListMacaroonIDs returns the IDs of all root keys macaroons may be derived
+	// from.
+	ListMacaroonIDs(context.Context, *ListMacaroonIDsRequest) (*ListMacaroonIDsResponse, error)
+	// *
+	// lncli: `deletemacaroonid`
+	// DeleteMacaroonID deletes the root key with the passed ID, which instantly
+	// invalidates all macaroons derived from it. The root key of the default
+	// macaroons can't be deleted.
+	DeleteMacaroonID(context.Context, *DeleteMacaroonIDRequest) (*DeleteMacaroonIDResponse, error)
+	// *
+	// CheckMacaroonPermissions checks whether the passed macaroon is valid, and
+	// grants the passed permissions, and returns the constraints its caveats impose
+	// on its use. This allows reverse proxies and other middleware to offload the
+	// verification of macaroons to lnd.
+	CheckMacaroonPermissions(context.Context, *CheckMacaroonPermissionsRequest) (*CheckMacaroonPermissionsResponse, error)
+	// *
+	// SubscribePeerEvents creates a uni-directional stream from the server to the
+	// client in which an event is sent whenever a connection to a peer is
+	// established or lost.
+	SubscribePeerEvents(*PeerEventSubscription, Lightning_SubscribePeerEventsServer) error
+	// *
+	// lncli: `trackpayment`
+	// TrackPayment streams the progress of the payment to the specified payment
+	// hash, as recorded within the database. The current state of the payment is
+	// replayed first, after which an update is streamed each time an HTLC attempt
+	// is dispatched or fails. The stream ends with a final update once the payment
+	// either succeeded or failed, which is sent right away if it already did.
+	TrackPayment(*TrackPaymentRequest, Lightning_TrackPaymentServer) error
+}
+
+func RegisterLightningServer(s *grpc.Server, srv LightningServer) {
+	s.RegisterService(&_Lightning_serviceDesc, srv)
+}
+
+func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).WalletBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/WalletBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ChannelBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendCoinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendCoins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendCoins",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
+}
+
+type Lightning_SubscribeTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendManyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendMany(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendMany",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewWitnessAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).NewWitnessAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SignMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SignMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SignMessage(ctx, req.(*SignMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).VerifyMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/VerifyMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ConnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DisconnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DisconnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).PendingChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/PendingChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChannelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).OpenChannelSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(OpenChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
+}
+
+type Lightning_OpenChannelServer interface {
+	Send(*OpenStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningOpenChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CloseChannelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+}
+
+type Lightning_CloseChannelServer interface {
+	Send(*CloseStatusUpdate) error
+	grpc.ServerStream
+}
+
+type lightningCloseChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+}
+
+type Lightning_SendPaymentServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
+}
+
+type lightningSendPaymentServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).SendPaymentSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Invoice)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).AddInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/AddInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvoiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListInvoices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListInvoices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentHash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LookupInvoice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LookupInvoice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InvoiceSubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
+}
+
+type Lightning_SubscribeInvoicesServer interface {
+	Send(*Invoice) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeInvoicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayReqString)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DecodePayReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DecodePayReq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DecodePayReq(ctx, req.(*PayReqString))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/ListPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAllPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeleteAllPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DeletePayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DeletePayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DeletePayments",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DeletePayments(ctx, req.(*DeletePaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).DescribeGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/DescribeGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChanInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetChanInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetChanInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).QueryRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/QueryRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).QueryRoutes(ctx, req.(*QueryRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_WalletBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WalletBalanceRequest)
+func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).WalletBalance(ctx, in)
+		return srv.(LightningServer).GetNetworkInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/WalletBalance",
+		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).WalletBalance(ctx, req.(*WalletBalanceRequest))
+		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ChannelBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelBalanceRequest)
+func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ChannelBalance(ctx, in)
+		return srv.(LightningServer).StopDaemon(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ChannelBalance",
+		FullMethod: "/lnrpc.Lightning/StopDaemon",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ChannelBalance(ctx, req.(*ChannelBalanceRequest))
+		return srv.(LightningServer).StopDaemon(ctx, req.(*StopRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTransactionsRequest)
+func _Lightning_SubscribeChannelGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GraphTopologySubscription)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SubscribeChannelGraph(m, &lightningSubscribeChannelGraphServer{stream})
+}
+
+type Lightning_SubscribeChannelGraphServer interface {
+	Send(*GraphTopologyUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSubscribeChannelGraphServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSubscribeChannelGraphServer) Send(m *GraphTopologyUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugLevelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetTransactions(ctx, in)
+		return srv.(LightningServer).DebugLevel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetTransactions",
+		FullMethod: "/lnrpc.Lightning/DebugLevel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+		return srv.(LightningServer).DebugLevel(ctx, req.(*DebugLevelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendCoins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendCoinsRequest)
+func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeeReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendCoins(ctx, in)
+		return srv.(LightningServer).FeeReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendCoins",
+		FullMethod: "/lnrpc.Lightning/FeeReport",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendCoins(ctx, req.(*SendCoinsRequest))
+		return srv.(LightningServer).FeeReport(ctx, req.(*FeeReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetTransactionsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(LightningServer).SubscribeTransactions(m, &lightningSubscribeTransactionsServer{stream})
-}
-
-type Lightning_SubscribeTransactionsServer interface {
-	Send(*Transaction) error
-	grpc.ServerStream
-}
-
-type lightningSubscribeTransactionsServer struct {
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(LightningServer).UpdateChannelPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/UpdateChannelPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).UpdateChannelPolicy(ctx, req.(*PolicyUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *lightningSubscribeTransactionsServer) Send(m *Transaction) error {
-	return x.ServerStream.SendMsg(m)
+func _Lightning_LabelChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelChannelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LightningServer).LabelChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lnrpc.Lightning/LabelChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LightningServer).LabelChannel(ctx, req.(*LabelChannelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SendMany_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendManyRequest)
+func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardingHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SendMany(ctx, in)
+		return srv.(LightningServer).ForwardingHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendMany",
+		FullMethod: "/lnrpc.Lightning/ForwardingHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendMany(ctx, req.(*SendManyRequest))
+		return srv.(LightningServer).ForwardingHistory(ctx, req.(*ForwardingHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_NewAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewAddressRequest)
+func _Lightning_AddPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentPolicy)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).NewAddress(ctx, in)
+		return srv.(LightningServer).AddPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewAddress",
+		FullMethod: "/lnrpc.Lightning/AddPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewAddress(ctx, req.(*NewAddressRequest))
+		return srv.(LightningServer).AddPolicy(ctx, req.(*PaymentPolicy))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_NewWitnessAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NewWitnessAddressRequest)
+func _Lightning_ListPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPoliciesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).NewWitnessAddress(ctx, in)
+		return srv.(LightningServer).ListPolicies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/NewWitnessAddress",
+		FullMethod: "/lnrpc.Lightning/ListPolicies",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).NewWitnessAddress(ctx, req.(*NewWitnessAddressRequest))
+		return srv.(LightningServer).ListPolicies(ctx, req.(*ListPoliciesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SignMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SignMessageRequest)
+func _Lightning_LookupPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyPaymentHash)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).SignMessage(ctx, in)
+		return srv.(LightningServer).LookupPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SignMessage",
+		FullMethod: "/lnrpc.Lightning/LookupPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SignMessage(ctx, req.(*SignMessageRequest))
+		return srv.(LightningServer).LookupPolicy(ctx, req.(*PolicyPaymentHash))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_VerifyMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyMessageRequest)
+func _Lightning_DeletePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyPaymentHash)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).VerifyMessage(ctx, in)
+		return srv.(LightningServer).DeletePolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/VerifyMessage",
+		FullMethod: "/lnrpc.Lightning/DeletePolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).VerifyMessage(ctx, req.(*VerifyMessageRequest))
+		return srv.(LightningServer).DeletePolicy(ctx, req.(*PolicyPaymentHash))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ConnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectPeerRequest)
+func _Lightning_SetDefaultPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentPolicy)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ConnectPeer(ctx, in)
+		return srv.(LightningServer).SetDefaultPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ConnectPeer",
+		FullMethod: "/lnrpc.Lightning/SetDefaultPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ConnectPeer(ctx, req.(*ConnectPeerRequest))
+		return srv.(LightningServer).SetDefaultPolicy(ctx, req.(*PaymentPolicy))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DisconnectPeerRequest)
+func _Lightning_DefaultPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefaultPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DisconnectPeer(ctx, in)
+		return srv.(LightningServer).DefaultPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DisconnectPeer",
+		FullMethod: "/lnrpc.Lightning/DefaultPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+		return srv.(LightningServer).DefaultPolicy(ctx, req.(*DefaultPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPeersRequest)
+func _Lightning_DeleteDefaultPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDefaultPolicyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListPeers(ctx, in)
+		return srv.(LightningServer).DeleteDefaultPolicy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPeers",
+		FullMethod: "/lnrpc.Lightning/DeleteDefaultPolicy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPeers(ctx, req.(*ListPeersRequest))
+		return srv.(LightningServer).DeleteDefaultPolicy(ctx, req.(*DeleteDefaultPolicyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetInfoRequest)
+func _Lightning_ExportPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportPoliciesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetInfo(ctx, in)
+		return srv.(LightningServer).ExportPolicies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetInfo",
+		FullMethod: "/lnrpc.Lightning/ExportPolicies",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetInfo(ctx, req.(*GetInfoRequest))
+		return srv.(LightningServer).ExportPolicies(ctx, req.(*ExportPoliciesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_PendingChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PendingChannelsRequest)
+func _Lightning_ImportPolicies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyBackup)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).PendingChannels(ctx, in)
+		return srv.(LightningServer).ImportPolicies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/PendingChannels",
+		FullMethod: "/lnrpc.Lightning/ImportPolicies",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).PendingChannels(ctx, req.(*PendingChannelsRequest))
+		return srv.(LightningServer).ImportPolicies(ctx, req.(*PolicyBackup))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListChannelsRequest)
+func _Lightning_PolicyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PolicyAuditLogRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListChannels(ctx, in)
+		return srv.(LightningServer).PolicyAuditLog(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListChannels",
+		FullMethod: "/lnrpc.Lightning/PolicyAuditLog",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListChannels(ctx, req.(*ListChannelsRequest))
+		return srv.(LightningServer).PolicyAuditLog(ctx, req.(*PolicyAuditLogRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_OpenChannelSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(OpenChannelRequest)
+func _Lightning_CompactDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactDatabaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).OpenChannelSync(ctx, in)
+		return srv.(LightningServer).CompactDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/OpenChannelSync",
+		FullMethod: "/lnrpc.Lightning/CompactDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).OpenChannelSync(ctx, req.(*OpenChannelRequest))
+		return srv.(LightningServer).CompactDatabase(ctx, req.(*CompactDatabaseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_OpenChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(OpenChannelRequest)
+func _Lightning_ExportDatabase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDatabaseRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).OpenChannel(m, &lightningOpenChannelServer{stream})
+	return srv.(LightningServer).ExportDatabase(m, &lightningExportDatabaseServer{stream})
 }
 
-type Lightning_OpenChannelServer interface {
-	Send(*OpenStatusUpdate) error
+type Lightning_ExportDatabaseServer interface {
+	Send(*DatabaseChunk) error
 	grpc.ServerStream
 }
 
-type lightningOpenChannelServer struct {
+type lightningExportDatabaseServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningOpenChannelServer) Send(m *OpenStatusUpdate) error {
+func (x *lightningExportDatabaseServer) Send(m *DatabaseChunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_CloseChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(CloseChannelRequest)
+func _Lightning_SubscribeChannelEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChannelEventSubscription)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).CloseChannel(m, &lightningCloseChannelServer{stream})
+	return srv.(LightningServer).SubscribeChannelEvents(m, &lightningSubscribeChannelEventsServer{stream})
 }
 
-type Lightning_CloseChannelServer interface {
-	Send(*CloseStatusUpdate) error
+type Lightning_SubscribeChannelEventsServer interface {
+	Send(*ChannelEventUpdate) error
 	grpc.ServerStream
 }
 
-type lightningCloseChannelServer struct {
+type lightningSubscribeChannelEventsServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningCloseChannelServer) Send(m *CloseStatusUpdate) error {
+func (x *lightningSubscribeChannelEventsServer) Send(m *ChannelEventUpdate) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_SendPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(LightningServer).SendPayment(&lightningSendPaymentServer{stream})
+func _Lightning_HtlcInterceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).HtlcInterceptor(&lightningHtlcInterceptorServer{stream})
 }
 
-type Lightning_SendPaymentServer interface {
-	Send(*SendResponse) error
-	Recv() (*SendRequest, error)
+type Lightning_HtlcInterceptorServer interface {
+	Send(*ForwardHtlcInterceptRequest) error
+	Recv() (*ForwardHtlcInterceptResponse, error)
 	grpc.ServerStream
 }
 
-type lightningSendPaymentServer struct {
+type lightningHtlcInterceptorServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSendPaymentServer) Send(m *SendResponse) error {
+func (x *lightningHtlcInterceptorServer) Send(m *ForwardHtlcInterceptRequest) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *lightningSendPaymentServer) Recv() (*SendRequest, error) {
-	m := new(SendRequest)
+func (x *lightningHtlcInterceptorServer) Recv() (*ForwardHtlcInterceptResponse, error) {
+	m := new(ForwardHtlcInterceptResponse)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _Lightning_SendPaymentSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SendRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).SendPaymentSync(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/SendPaymentSync",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).SendPaymentSync(ctx, req.(*SendRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func _Lightning_ChannelAcceptor_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).ChannelAcceptor(&lightningChannelAcceptorServer{stream})
 }
 
-func _Lightning_AddInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Invoice)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).AddInvoice(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/AddInvoice",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).AddInvoice(ctx, req.(*Invoice))
-	}
-	return interceptor(ctx, in, info, handler)
+type Lightning_ChannelAcceptorServer interface {
+	Send(*ChannelAcceptRequest) error
+	Recv() (*ChannelAcceptResponse, error)
+	grpc.ServerStream
 }
 
-func _Lightning_ListInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListInvoiceRequest)
-	if err := dec(in); err != nil {
+type lightningChannelAcceptorServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningChannelAcceptorServer) Send(m *ChannelAcceptRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningChannelAcceptorServer) Recv() (*ChannelAcceptResponse, error) {
+	m := new(ChannelAcceptResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).ListInvoices(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListInvoices",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListInvoices(ctx, req.(*ListInvoiceRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
+}
+
+func _Lightning_RegisterRPCMiddleware_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).RegisterRPCMiddleware(&lightningRegisterRPCMiddlewareServer{stream})
 }
 
-func _Lightning_LookupInvoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PaymentHash)
-	if err := dec(in); err != nil {
+type Lightning_RegisterRPCMiddlewareServer interface {
+	Send(*RPCMiddlewareRequest) error
+	Recv() (*RPCMiddlewareResponse, error)
+	grpc.ServerStream
+}
+
+type lightningRegisterRPCMiddlewareServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningRegisterRPCMiddlewareServer) Send(m *RPCMiddlewareRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lightningRegisterRPCMiddlewareServer) Recv() (*RPCMiddlewareResponse, error) {
+	m := new(RPCMiddlewareResponse)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).LookupInvoice(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/LookupInvoice",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).LookupInvoice(ctx, req.(*PaymentHash))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _Lightning_SubscribeInvoices_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(InvoiceSubscription)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(LightningServer).SubscribeInvoices(m, &lightningSubscribeInvoicesServer{stream})
+func _Lightning_SendToRoute_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LightningServer).SendToRoute(&lightningSendToRouteServer{stream})
 }
 
-type Lightning_SubscribeInvoicesServer interface {
-	Send(*Invoice) error
+type Lightning_SendToRouteServer interface {
+	Send(*SendResponse) error
+	Recv() (*SendToRouteRequest, error)
 	grpc.ServerStream
 }
 
-type lightningSubscribeInvoicesServer struct {
+type lightningSendToRouteServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSubscribeInvoicesServer) Send(m *Invoice) error {
+func (x *lightningSendToRouteServer) Send(m *SendResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_DecodePayReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PayReqString)
-	if err := dec(in); err != nil {
+func (x *lightningSendToRouteServer) Recv() (*SendToRouteRequest, error) {
+	m := new(SendToRouteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(LightningServer).DecodePayReq(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DecodePayReq",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DecodePayReq(ctx, req.(*PayReqString))
-	}
-	return interceptor(ctx, in, info, handler)
+	return m, nil
 }
 
-func _Lightning_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListPaymentsRequest)
+func _Lightning_SendToRouteSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendToRouteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).ListPayments(ctx, in)
+		return srv.(LightningServer).SendToRouteSync(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ListPayments",
+		FullMethod: "/lnrpc.Lightning/SendToRouteSync",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+		return srv.(LightningServer).SendToRouteSync(ctx, req.(*SendToRouteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DeleteAllPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAllPaymentsRequest)
+func _Lightning_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateFeeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DeleteAllPayments(ctx, in)
+		return srv.(LightningServer).EstimateFee(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DeleteAllPayments",
+		FullMethod: "/lnrpc.Lightning/EstimateFee",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DeleteAllPayments(ctx, req.(*DeleteAllPaymentsRequest))
+		return srv.(LightningServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_DescribeGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChannelGraphRequest)
+func _Lightning_AbandonChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonChannelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).DescribeGraph(ctx, in)
+		return srv.(LightningServer).AbandonChannel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DescribeGraph",
+		FullMethod: "/lnrpc.Lightning/AbandonChannel",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DescribeGraph(ctx, req.(*ChannelGraphRequest))
+		return srv.(LightningServer).AbandonChannel(ctx, req.(*AbandonChannelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetChanInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChanInfoRequest)
+func _Lightning_SendPaymentStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SendRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LightningServer).SendPaymentStream(m, &lightningSendPaymentStreamServer{stream})
+}
+
+type Lightning_SendPaymentStreamServer interface {
+	Send(*PaymentUpdate) error
+	grpc.ServerStream
+}
+
+type lightningSendPaymentStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *lightningSendPaymentStreamServer) Send(m *PaymentUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Lightning_GetNodeMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeMetricsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetChanInfo(ctx, in)
+		return srv.(LightningServer).GetNodeMetrics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetChanInfo",
+		FullMethod: "/lnrpc.Lightning/GetNodeMetrics",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetChanInfo(ctx, req.(*ChanInfoRequest))
+		return srv.(LightningServer).GetNodeMetrics(ctx, req.(*NodeMetricsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NodeInfoRequest)
+func _Lightning_BakeMacaroon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BakeMacaroonRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNodeInfo(ctx, in)
+		return srv.(LightningServer).BakeMacaroon(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNodeInfo",
+		FullMethod: "/lnrpc.Lightning/BakeMacaroon",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNodeInfo(ctx, req.(*NodeInfoRequest))
+		return srv.(LightningServer).BakeMacaroon(ctx, req.(*BakeMacaroonRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_QueryRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(QueryRoutesRequest)
+func _Lightning_ListMacaroonIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMacaroonIDsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).QueryRoutes(ctx, in)
+		return srv.(LightningServer).ListMacaroonIDs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/QueryRoutes",
+		FullMethod: "/lnrpc.Lightning/ListMacaroonIDs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).QueryRoutes(ctx, req.(*QueryRoutesRequest))
+		return srv.(LightningServer).ListMacaroonIDs(ctx, req.(*ListMacaroonIDsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NetworkInfoRequest)
+func _Lightning_DeleteMacaroonID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMacaroonIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).GetNetworkInfo(ctx, in)
+		return srv.(LightningServer).DeleteMacaroonID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/GetNetworkInfo",
+		FullMethod: "/lnrpc.Lightning/DeleteMacaroonID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).GetNetworkInfo(ctx, req.(*NetworkInfoRequest))
+		return srv.(LightningServer).DeleteMacaroonID(ctx, req.(*DeleteMacaroonIDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_StopDaemon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopRequest)
+func _Lightning_CheckMacaroonPermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckMacaroonPermissionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LightningServer).StopDaemon(ctx, in)
+		return srv.(LightningServer).CheckMacaroonPermissions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/StopDaemon",
+		FullMethod: "/lnrpc.Lightning/CheckMacaroonPermissions",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).StopDaemon(ctx, req.(*StopRequest))
+		return srv.(LightningServer).CheckMacaroonPermissions(ctx, req.(*CheckMacaroonPermissionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Lightning_SubscribeChannelGraph_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GraphTopologySubscription)
+func _Lightning_SubscribePeerEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PeerEventSubscription)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(LightningServer).SubscribeChannelGraph(m, &lightningSubscribeChannelGraphServer{stream})
+	return srv.(LightningServer).SubscribePeerEvents(m, &lightningSubscribePeerEventsServer{stream})
 }
 
-type Lightning_SubscribeChannelGraphServer interface {
-	Send(*GraphTopologyUpdate) error
+type Lightning_SubscribePeerEventsServer interface {
+	Send(*PeerEvent) error
 	grpc.ServerStream
 }
 
-type lightningSubscribeChannelGraphServer struct {
+type lightningSubscribePeerEventsServer struct {
 	grpc.ServerStream
 }
 
-func (x *lightningSubscribeChannelGraphServer) Send(m *GraphTopologyUpdate) error {
+func (x *lightningSubscribePeerEventsServer) Send(m *PeerEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Lightning_DebugLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DebugLevelRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).DebugLevel(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/DebugLevel",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).DebugLevel(ctx, req.(*DebugLevelRequest))
+func _Lightning_TrackPayment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackPaymentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(LightningServer).TrackPayment(m, &lightningTrackPaymentServer{stream})
 }
 
-func _Lightning_FeeReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FeeReportRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).FeeReport(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/FeeReport",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).FeeReport(ctx, req.(*FeeReportRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type Lightning_TrackPaymentServer interface {
+	Send(*PaymentUpdate) error
+	grpc.ServerStream
 }
 
-func _Lightning_UpdateChannelPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PolicyUpdateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).UpdateChannelPolicy(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/UpdateChannelPolicy",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).UpdateChannelPolicy(ctx, req.(*PolicyUpdateRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type lightningTrackPaymentServer struct {
+	grpc.ServerStream
 }
 
-func _Lightning_ForwardingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ForwardingHistoryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(LightningServer).ForwardingHistory(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/lnrpc.Lightning/ForwardingHistory",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LightningServer).ForwardingHistory(ctx, req.(*ForwardingHistoryRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (x *lightningTrackPaymentServer) Send(m *PaymentUpdate) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 var _Lightning_serviceDesc = grpc.ServiceDesc{
@@ -6070,6 +10198,10 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAllPayments",
 			Handler:    _Lightning_DeleteAllPayments_Handler,
 		},
+		{
+			MethodName: "DeletePayments",
+			Handler:    _Lightning_DeletePayments_Handler,
+		},
 		{
 			MethodName: "DescribeGraph",
 			Handler:    _Lightning_DescribeGraph_Handler,
@@ -6106,10 +10238,90 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateChannelPolicy",
 			Handler:    _Lightning_UpdateChannelPolicy_Handler,
 		},
+		{
+			MethodName: "LabelChannel",
+			Handler:    _Lightning_LabelChannel_Handler,
+		},
 		{
 			MethodName: "ForwardingHistory",
 			Handler:    _Lightning_ForwardingHistory_Handler,
 		},
+		{
+			MethodName: "AddPolicy",
+			Handler:    _Lightning_AddPolicy_Handler,
+		},
+		{
+			MethodName: "ListPolicies",
+			Handler:    _Lightning_ListPolicies_Handler,
+		},
+		{
+			MethodName: "LookupPolicy",
+			Handler:    _Lightning_LookupPolicy_Handler,
+		},
+		{
+			MethodName: "DeletePolicy",
+			Handler:    _Lightning_DeletePolicy_Handler,
+		},
+		{
+			MethodName: "SetDefaultPolicy",
+			Handler:    _Lightning_SetDefaultPolicy_Handler,
+		},
+		{
+			MethodName: "DefaultPolicy",
+			Handler:    _Lightning_DefaultPolicy_Handler,
+		},
+		{
+			MethodName: "DeleteDefaultPolicy",
+			Handler:    _Lightning_DeleteDefaultPolicy_Handler,
+		},
+		{
+			MethodName: "ExportPolicies",
+			Handler:    _Lightning_ExportPolicies_Handler,
+		},
+		{
+			MethodName: "ImportPolicies",
+			Handler:    _Lightning_ImportPolicies_Handler,
+		},
+		{
+			MethodName: "PolicyAuditLog",
+			Handler:    _Lightning_PolicyAuditLog_Handler,
+		},
+		{
+			MethodName: "CompactDatabase",
+			Handler:    _Lightning_CompactDatabase_Handler,
+		},
+		{
+			MethodName: "SendToRouteSync",
+			Handler:    _Lightning_SendToRouteSync_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _Lightning_EstimateFee_Handler,
+		},
+		{
+			MethodName: "AbandonChannel",
+			Handler:    _Lightning_AbandonChannel_Handler,
+		},
+		{
+			MethodName: "GetNodeMetrics",
+			Handler:    _Lightning_GetNodeMetrics_Handler,
+		},
+		{
+			MethodName: "BakeMacaroon",
+			Handler:    _Lightning_BakeMacaroon_Handler,
+		},
+		{
+			MethodName: "ListMacaroonIDs",
+			Handler:    _Lightning_ListMacaroonIDs_Handler,
+		},
+		{
+			MethodName: "DeleteMacaroonID",
+			Handler:    _Lightning_DeleteMacaroonID_Handler,
+		},
+		{
+			MethodName: "CheckMacaroonPermissions",
+			Handler:    _Lightning_CheckMacaroonPermissions_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -6143,6 +10355,55 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Lightning_SubscribeChannelGraph_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportDatabase",
+			Handler:       _Lightning_ExportDatabase_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeChannelEvents",
+			Handler:       _Lightning_SubscribeChannelEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "HtlcInterceptor",
+			Handler:       _Lightning_HtlcInterceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ChannelAcceptor",
+			Handler:       _Lightning_ChannelAcceptor_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RegisterRPCMiddleware",
+			Handler:       _Lightning_RegisterRPCMiddleware_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SendToRoute",
+			Handler:       _Lightning_SendToRoute_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SendPaymentStream",
+			Handler:       _Lightning_SendPaymentStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribePeerEvents",
+			Handler:       _Lightning_SubscribePeerEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TrackPayment",
+			Handler:       _Lightning_TrackPayment_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -6150,342 +10411,577 @@ var _Lightning_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 5382 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5c, 0x4d, 0x90, 0x1c, 0xd9,
-	0x51, 0x56, 0xf5, 0xf4, 0xfc, 0x74, 0x76, 0xcf, 0xdf, 0x9b, 0xd1, 0xa8, 0xd5, 0xab, 0xd5, 0x6a,
-	0xcb, 0x1b, 0x2b, 0x21, 0x16, 0x8d, 0x76, 0x6c, 0x2f, 0xcb, 0x0a, 0xd6, 0xa1, 0xff, 0x59, 0x5b,
-	0x2b, 0x8f, 0x6b, 0x24, 0x2f, 0x78, 0x81, 0x76, 0x4d, 0xf7, 0x9b, 0x9e, 0xb2, 0xba, 0xab, 0x6a,
-	0xab, 0x5e, 0xcf, 0xa8, 0x77, 0x51, 0x04, 0x3f, 0x11, 0x9c, 0x70, 0xf8, 0x00, 0x11, 0x84, 0x21,
-	0x1c, 0x44, 0xd8, 0x17, 0x38, 0x70, 0xe4, 0x64, 0x02, 0xee, 0x8e, 0x20, 0x38, 0xf8, 0x44, 0x70,
-	0xe3, 0xe7, 0x02, 0x67, 0x2e, 0x1c, 0x08, 0x22, 0xf3, 0xfd, 0xd4, 0x7b, 0x55, 0x35, 0x92, 0x6c,
-	0x83, 0x6f, 0xf3, 0xbe, 0x97, 0x95, 0xef, 0x2f, 0x33, 0x5f, 0x66, 0xbe, 0xec, 0x81, 0x56, 0x96,
-	0x0e, 0xae, 0xa5, 0x59, 0x22, 0x12, 0x36, 0x3f, 0x8e, 0xb3, 0x74, 0xd0, 0xbb, 0x30, 0x4a, 0x92,
-	0xd1, 0x98, 0x6f, 0x87, 0x69, 0xb4, 0x1d, 0xc6, 0x71, 0x22, 0x42, 0x11, 0x25, 0x71, 0x2e, 0x89,
-	0xfc, 0x6f, 0xc2, 0xca, 0x7d, 0x1e, 0xef, 0x73, 0x3e, 0x0c, 0xf8, 0x27, 0x53, 0x9e, 0x0b, 0xf6,
-	0x8b, 0xb0, 0x1e, 0xf2, 0x4f, 0x39, 0x1f, 0xf6, 0xd3, 0x30, 0xcf, 0xd3, 0xa3, 0x2c, 0xcc, 0x79,
-	0xd7, 0xbb, 0xe4, 0x5d, 0xe9, 0x04, 0x6b, 0xb2, 0x63, 0xcf, 0xe0, 0xec, 0x75, 0xe8, 0xe4, 0x48,
-	0xca, 0x63, 0x91, 0x25, 0xe9, 0xac, 0xdb, 0x20, 0xba, 0x36, 0x62, 0x77, 0x25, 0xe4, 0x8f, 0x61,
-	0xd5, 0x8c, 0x90, 0xa7, 0x49, 0x9c, 0x73, 0x76, 0x1d, 0x36, 0x07, 0x51, 0x7a, 0xc4, 0xb3, 0x3e,
-	0x7d, 0x3c, 0x89, 0xf9, 0x24, 0x89, 0xa3, 0x41, 0xd7, 0xbb, 0x34, 0x77, 0xa5, 0x15, 0x30, 0xd9,
-	0x87, 0x5f, 0x7c, 0xa8, 0x7a, 0xd8, 0x65, 0x58, 0xe5, 0xb1, 0xc4, 0xf9, 0x90, 0xbe, 0x52, 0x43,
-	0xad, 0x14, 0x30, 0x7e, 0xe0, 0xff, 0xb9, 0x07, 0xeb, 0x1f, 0xc4, 0x91, 0xf8, 0x28, 0x1c, 0x8f,
-	0xb9, 0xd0, 0x6b, 0xba, 0x0c, 0xab, 0x27, 0x04, 0xd0, 0x9a, 0x4e, 0x92, 0x6c, 0xa8, 0x56, 0xb4,
-	0x22, 0xe1, 0x3d, 0x85, 0x9e, 0x3a, 0xb3, 0xc6, 0xa9, 0x33, 0xab, 0xdd, 0xae, 0xb9, 0xfa, 0xed,
-	0xf2, 0x37, 0x81, 0xd9, 0x93, 0x93, 0xdb, 0xe1, 0xbf, 0x0f, 0x1b, 0x8f, 0xe3, 0x71, 0x32, 0x78,
-	0xf2, 0xd3, 0x4d, 0xda, 0xdf, 0x82, 0x4d, 0xf7, 0x7b, 0xc5, 0xf7, 0xbb, 0x0d, 0x68, 0x3f, 0xca,
-	0xc2, 0x38, 0x0f, 0x07, 0x78, 0xe4, 0xac, 0x0b, 0x8b, 0xe2, 0x69, 0xff, 0x28, 0xcc, 0x8f, 0x88,
-	0x51, 0x2b, 0xd0, 0x4d, 0xb6, 0x05, 0x0b, 0xe1, 0x24, 0x99, 0xc6, 0x82, 0x76, 0x75, 0x2e, 0x50,
-	0x2d, 0xf6, 0x16, 0xac, 0xc7, 0xd3, 0x49, 0x7f, 0x90, 0xc4, 0x87, 0x51, 0x36, 0x91, 0x82, 0x43,
-	0x8b, 0x9b, 0x0f, 0xaa, 0x1d, 0xec, 0x22, 0xc0, 0x01, 0x4e, 0x43, 0x0e, 0xd1, 0xa4, 0x21, 0x2c,
-	0x84, 0xf9, 0xd0, 0x51, 0x2d, 0x1e, 0x8d, 0x8e, 0x44, 0x77, 0x9e, 0x18, 0x39, 0x18, 0xf2, 0x10,
-	0xd1, 0x84, 0xf7, 0x73, 0x11, 0x4e, 0xd2, 0xee, 0x02, 0xcd, 0xc6, 0x42, 0xa8, 0x3f, 0x11, 0xe1,
-	0xb8, 0x7f, 0xc8, 0x79, 0xde, 0x5d, 0x54, 0xfd, 0x06, 0x61, 0x6f, 0xc2, 0xca, 0x90, 0xe7, 0xa2,
-	0x1f, 0x0e, 0x87, 0x19, 0xcf, 0x73, 0x9e, 0x77, 0x97, 0xe8, 0xe8, 0x4a, 0xa8, 0xdf, 0x85, 0xad,
-	0xfb, 0x5c, 0x58, 0xbb, 0x93, 0xab, 0x6d, 0xf7, 0x1f, 0x00, 0xb3, 0xe0, 0x3b, 0x5c, 0x84, 0xd1,
-	0x38, 0x67, 0xef, 0x40, 0x47, 0x58, 0xc4, 0x24, 0xaa, 0xed, 0x1d, 0x76, 0x8d, 0x74, 0xec, 0x9a,
-	0xf5, 0x41, 0xe0, 0xd0, 0xf9, 0xff, 0xed, 0x41, 0x7b, 0x9f, 0xc7, 0x46, 0xbb, 0x18, 0x34, 0x71,
-	0x26, 0xea, 0x24, 0xe9, 0x6f, 0xf6, 0x1a, 0xb4, 0x69, 0x76, 0xb9, 0xc8, 0xa2, 0x78, 0x44, 0x47,
-	0xd0, 0x0a, 0x00, 0xa1, 0x7d, 0x42, 0xd8, 0x1a, 0xcc, 0x85, 0x13, 0x41, 0x1b, 0x3f, 0x17, 0xe0,
-	0x9f, 0xa8, 0x77, 0x69, 0x38, 0x9b, 0xf0, 0x58, 0x14, 0x9b, 0xdd, 0x09, 0xda, 0x0a, 0xdb, 0xc5,
-	0xdd, 0xbe, 0x06, 0x1b, 0x36, 0x89, 0xe6, 0x3e, 0x4f, 0xdc, 0xd7, 0x2d, 0x4a, 0x35, 0xc8, 0x65,
-	0x58, 0xd5, 0xf4, 0x99, 0x9c, 0x2c, 0x6d, 0x7f, 0x2b, 0x58, 0x51, 0xb0, 0x5e, 0xc2, 0x15, 0x58,
-	0x3b, 0x8c, 0xe2, 0x70, 0xdc, 0x1f, 0x8c, 0xc5, 0x71, 0x7f, 0xc8, 0xc7, 0x22, 0xa4, 0x83, 0x98,
-	0x0f, 0x56, 0x08, 0xbf, 0x3d, 0x16, 0xc7, 0x77, 0x10, 0xf5, 0xff, 0xc4, 0x83, 0x8e, 0x5c, 0xbc,
-	0x52, 0xfc, 0x37, 0x60, 0x59, 0x8f, 0xc1, 0xb3, 0x2c, 0xc9, 0x94, 0x1c, 0xba, 0x20, 0xbb, 0x0a,
-	0x6b, 0x1a, 0x48, 0x33, 0x1e, 0x4d, 0xc2, 0x11, 0x57, 0xda, 0x5e, 0xc1, 0xd9, 0x4e, 0xc1, 0x31,
-	0x4b, 0xa6, 0x42, 0xaa, 0x5e, 0x7b, 0xa7, 0xa3, 0x0e, 0x26, 0x40, 0x2c, 0x70, 0x49, 0xfc, 0xef,
-	0x7b, 0xd0, 0xb9, 0x7d, 0x14, 0xc6, 0x31, 0x1f, 0xef, 0x25, 0x51, 0x2c, 0xd8, 0x75, 0x60, 0x87,
-	0xd3, 0x78, 0x18, 0xc5, 0xa3, 0xbe, 0x78, 0x1a, 0x0d, 0xfb, 0x07, 0x33, 0xc1, 0x73, 0x79, 0x44,
-	0xbb, 0x67, 0x82, 0x9a, 0x3e, 0xf6, 0x16, 0xac, 0x39, 0x68, 0x2e, 0x32, 0x79, 0x6e, 0xbb, 0x67,
-	0x82, 0x4a, 0x0f, 0x0a, 0x7e, 0x32, 0x15, 0xe9, 0x54, 0xf4, 0xa3, 0x78, 0xc8, 0x9f, 0xd2, 0x1c,
-	0x97, 0x03, 0x07, 0xbb, 0xb5, 0x02, 0x1d, 0xfb, 0x3b, 0xff, 0x7d, 0x58, 0x7b, 0x80, 0x1a, 0x11,
-	0x47, 0xf1, 0xe8, 0xa6, 0x14, 0x5b, 0x54, 0xd3, 0x74, 0x7a, 0xf0, 0x84, 0xcf, 0xd4, 0xbe, 0xa9,
-	0x16, 0x0a, 0xd5, 0x51, 0x92, 0x0b, 0x25, 0x39, 0xf4, 0xb7, 0xff, 0xaf, 0x1e, 0xac, 0xe2, 0xde,
-	0x7f, 0x18, 0xc6, 0x33, 0x7d, 0x72, 0x0f, 0xa0, 0x83, 0xac, 0x1e, 0x25, 0x37, 0xa5, 0xb2, 0x4b,
-	0x21, 0xbe, 0xa2, 0xf6, 0xaa, 0x44, 0x7d, 0xcd, 0x26, 0x45, 0x63, 0x3e, 0x0b, 0x9c, 0xaf, 0x51,
-	0x6c, 0x45, 0x98, 0x8d, 0xb8, 0x20, 0x33, 0xa0, 0xcc, 0x02, 0x48, 0xe8, 0x76, 0x12, 0x1f, 0xb2,
-	0x4b, 0xd0, 0xc9, 0x43, 0xd1, 0x4f, 0x79, 0x46, 0xbb, 0x46, 0xa2, 0x37, 0x17, 0x40, 0x1e, 0x8a,
-	0x3d, 0x9e, 0xdd, 0x9a, 0x09, 0xde, 0xfb, 0x12, 0xac, 0x57, 0x46, 0x41, 0x69, 0x2f, 0x96, 0x88,
-	0x7f, 0xb2, 0x4d, 0x98, 0x3f, 0x0e, 0xc7, 0x53, 0xae, 0xac, 0x93, 0x6c, 0xbc, 0xd7, 0x78, 0xd7,
-	0xf3, 0xdf, 0x84, 0xb5, 0x62, 0xda, 0x4a, 0xc8, 0x18, 0x34, 0x71, 0x07, 0x15, 0x03, 0xfa, 0xdb,
-	0xff, 0x3d, 0x4f, 0x12, 0xde, 0x4e, 0x22, 0xa3, 0xe9, 0x48, 0x88, 0x06, 0x41, 0x13, 0xe2, 0xdf,
-	0xa7, 0x5a, 0xc2, 0x9f, 0x7d, 0xb1, 0xfe, 0x65, 0x58, 0xb7, 0xa6, 0xf0, 0x9c, 0xc9, 0x7e, 0xdb,
-	0x83, 0xf5, 0x87, 0xfc, 0x44, 0x9d, 0xba, 0x9e, 0xed, 0xbb, 0xd0, 0x14, 0xb3, 0x54, 0x5e, 0xc5,
-	0x2b, 0x3b, 0x6f, 0xa8, 0x43, 0xab, 0xd0, 0x5d, 0x53, 0xcd, 0x47, 0xb3, 0x94, 0x07, 0xf4, 0x85,
-	0xff, 0x3e, 0xb4, 0x2d, 0x90, 0x9d, 0x83, 0x8d, 0x8f, 0x3e, 0x78, 0xf4, 0xf0, 0xee, 0xfe, 0x7e,
-	0x7f, 0xef, 0xf1, 0xad, 0xaf, 0xdc, 0xfd, 0x8d, 0xfe, 0xee, 0xcd, 0xfd, 0xdd, 0xb5, 0x33, 0x6c,
-	0x0b, 0xd8, 0xc3, 0xbb, 0xfb, 0x8f, 0xee, 0xde, 0x71, 0x70, 0xcf, 0xef, 0x41, 0xf7, 0x21, 0x3f,
-	0xf9, 0x28, 0x12, 0x31, 0xcf, 0x73, 0x77, 0x34, 0xff, 0x1a, 0x30, 0x7b, 0x0a, 0x6a, 0x55, 0x5d,
-	0x58, 0x54, 0xa6, 0x56, 0xdf, 0x34, 0xaa, 0xe9, 0xbf, 0x09, 0x6c, 0x3f, 0x1a, 0xc5, 0x1f, 0xf2,
-	0x3c, 0x0f, 0x47, 0x5c, 0xaf, 0x6d, 0x0d, 0xe6, 0x26, 0xf9, 0x48, 0x19, 0x45, 0xfc, 0xd3, 0xff,
-	0x3c, 0x6c, 0x38, 0x74, 0x8a, 0xf1, 0x05, 0x68, 0xe5, 0xd1, 0x28, 0x0e, 0xc5, 0x34, 0xe3, 0x8a,
-	0x75, 0x01, 0xf8, 0xf7, 0x60, 0xf3, 0xeb, 0x3c, 0x8b, 0x0e, 0x67, 0x2f, 0x62, 0xef, 0xf2, 0x69,
-	0x94, 0xf9, 0xdc, 0x85, 0xb3, 0x25, 0x3e, 0x6a, 0x78, 0x29, 0x88, 0xea, 0xb8, 0x96, 0x02, 0xd9,
-	0xb0, 0xd4, 0xb2, 0x61, 0xab, 0xa5, 0xff, 0x18, 0xd8, 0xed, 0x24, 0x8e, 0xf9, 0x40, 0xec, 0x71,
-	0x9e, 0x15, 0xfe, 0x55, 0x21, 0x75, 0xed, 0x9d, 0x73, 0xea, 0x1c, 0xcb, 0xba, 0xae, 0xc4, 0x91,
-	0x41, 0x33, 0xe5, 0xd9, 0x84, 0x18, 0x2f, 0x05, 0xf4, 0xb7, 0x7f, 0x16, 0x36, 0x1c, 0xb6, 0xea,
-	0xb6, 0x7f, 0x1b, 0xce, 0xde, 0x89, 0xf2, 0x41, 0x75, 0xc0, 0x2e, 0x2c, 0xa6, 0xd3, 0x83, 0x7e,
-	0xa1, 0x53, 0xba, 0x89, 0x97, 0x60, 0xf9, 0x13, 0xc5, 0xec, 0x0f, 0x3d, 0x68, 0xee, 0x3e, 0x7a,
-	0x70, 0x9b, 0xf5, 0x60, 0x29, 0x8a, 0x07, 0xc9, 0x04, 0xaf, 0x0e, 0xb9, 0x68, 0xd3, 0x3e, 0x55,
-	0x57, 0x2e, 0x40, 0x8b, 0x6e, 0x1c, 0xbc, 0xd7, 0x95, 0x2b, 0x54, 0x00, 0xe8, 0x53, 0xf0, 0xa7,
-	0x69, 0x94, 0x91, 0xd3, 0xa0, 0x5d, 0x81, 0x26, 0x59, 0xc4, 0x6a, 0x87, 0xff, 0x3f, 0x4d, 0x58,
-	0x54, 0xb6, 0x9a, 0xc6, 0x1b, 0x88, 0xe8, 0x98, 0xab, 0x99, 0xa8, 0x16, 0xde, 0x2a, 0x19, 0x9f,
-	0x24, 0x82, 0xf7, 0x9d, 0x63, 0x70, 0x41, 0xa4, 0x1a, 0x48, 0x46, 0xfd, 0x14, 0xad, 0x3e, 0xcd,
-	0xac, 0x15, 0xb8, 0x20, 0x6e, 0x16, 0x02, 0xfd, 0x68, 0x48, 0x73, 0x6a, 0x06, 0xba, 0x89, 0x3b,
-	0x31, 0x08, 0xd3, 0x70, 0x10, 0x89, 0x99, 0x52, 0x6e, 0xd3, 0x46, 0xde, 0xe3, 0x64, 0x10, 0x8e,
-	0xfb, 0x07, 0xe1, 0x38, 0x8c, 0x07, 0x5c, 0x39, 0x2e, 0x2e, 0x88, 0xbe, 0x89, 0x9a, 0x92, 0x26,
-	0x93, 0xfe, 0x4b, 0x09, 0x45, 0x1f, 0x67, 0x90, 0x4c, 0x26, 0x91, 0x40, 0x97, 0xa6, 0xbb, 0x24,
-	0x0d, 0x49, 0x81, 0xd0, 0x4a, 0x64, 0xeb, 0x44, 0xee, 0x5e, 0x4b, 0x8e, 0xe6, 0x80, 0xc8, 0xe5,
-	0x90, 0x73, 0x32, 0x48, 0x4f, 0x4e, 0xba, 0x20, 0xb9, 0x14, 0x08, 0x9e, 0xc3, 0x34, 0xce, 0xb9,
-	0x10, 0x63, 0x3e, 0x34, 0x13, 0x6a, 0x13, 0x59, 0xb5, 0x83, 0x5d, 0x87, 0x0d, 0xe9, 0x65, 0xe5,
-	0xa1, 0x48, 0xf2, 0xa3, 0x28, 0xef, 0xe7, 0x3c, 0x16, 0xdd, 0x0e, 0xd1, 0xd7, 0x75, 0xb1, 0x77,
-	0xe1, 0x5c, 0x09, 0xce, 0xf8, 0x80, 0x47, 0xc7, 0x7c, 0xd8, 0x5d, 0xa6, 0xaf, 0x4e, 0xeb, 0x66,
-	0x97, 0xa0, 0x8d, 0xce, 0xe5, 0x34, 0x1d, 0x86, 0x78, 0x0f, 0xaf, 0xd0, 0x39, 0xd8, 0x10, 0x7b,
-	0x1b, 0x96, 0x53, 0x2e, 0x2f, 0xcb, 0x23, 0x31, 0x1e, 0xe4, 0xdd, 0x55, 0xba, 0xc9, 0xda, 0x4a,
-	0x99, 0x50, 0x72, 0x03, 0x97, 0x02, 0x85, 0x72, 0x90, 0x93, 0xbb, 0x12, 0xce, 0xba, 0x6b, 0x24,
-	0x6e, 0x05, 0x40, 0x3a, 0x92, 0x45, 0xc7, 0xa1, 0xe0, 0xdd, 0x75, 0x92, 0x2d, 0xdd, 0xf4, 0xff,
-	0xc2, 0x83, 0x8d, 0x07, 0x51, 0x2e, 0x94, 0x10, 0x1a, 0x73, 0xfc, 0x1a, 0xb4, 0xa5, 0xf8, 0xf5,
-	0x93, 0x78, 0x3c, 0x53, 0x12, 0x09, 0x12, 0xfa, 0x6a, 0x3c, 0x9e, 0xb1, 0xcf, 0xc1, 0x72, 0x14,
-	0xdb, 0x24, 0x52, 0x87, 0x3b, 0x1a, 0x24, 0xa2, 0xd7, 0xa0, 0x9d, 0x4e, 0x0f, 0xc6, 0xd1, 0x40,
-	0x92, 0xcc, 0x49, 0x2e, 0x12, 0x22, 0x02, 0x74, 0xf4, 0xe4, 0x4c, 0x24, 0x45, 0x93, 0x28, 0xda,
-	0x0a, 0x43, 0x12, 0xff, 0x16, 0x6c, 0xba, 0x13, 0x54, 0xc6, 0xea, 0x2a, 0x2c, 0x29, 0xd9, 0xce,
-	0xbb, 0x6d, 0xda, 0x9f, 0x15, 0xb5, 0x3f, 0x8a, 0x34, 0x30, 0xfd, 0xfe, 0x7f, 0x78, 0xd0, 0x44,
-	0x03, 0x70, 0xba, 0xb1, 0xb0, 0x6d, 0xfa, 0x9c, 0x63, 0xd3, 0xc9, 0xef, 0x47, 0xaf, 0x48, 0x8a,
-	0x84, 0x54, 0x1b, 0x0b, 0x29, 0xfa, 0x33, 0x3e, 0x38, 0x26, 0xdd, 0x31, 0xfd, 0x88, 0xa0, 0x66,
-	0xe1, 0xd5, 0x49, 0x5f, 0x4b, 0xc5, 0x31, 0x6d, 0xdd, 0x47, 0x5f, 0x2e, 0x16, 0x7d, 0xf4, 0x5d,
-	0x17, 0x16, 0xa3, 0xf8, 0x20, 0x99, 0xc6, 0x43, 0x52, 0x92, 0xa5, 0x40, 0x37, 0xf1, 0xb0, 0x53,
-	0xf2, 0xa4, 0xa2, 0x09, 0x57, 0xda, 0x51, 0x00, 0x3e, 0x43, 0xd7, 0x2a, 0x27, 0x83, 0x67, 0xee,
-	0xb1, 0x77, 0x60, 0xdd, 0xc2, 0xd4, 0x0e, 0xbe, 0x0e, 0xf3, 0x29, 0x02, 0xca, 0x51, 0xd2, 0xe2,
-	0x45, 0x96, 0x52, 0xf6, 0xf8, 0x6b, 0x18, 0x3f, 0x8b, 0x0f, 0xe2, 0xc3, 0x44, 0x73, 0xfa, 0xfb,
-	0x39, 0x0c, 0x78, 0x15, 0xa4, 0x18, 0x5d, 0x81, 0xd5, 0x68, 0xc8, 0x63, 0x11, 0x89, 0x59, 0xdf,
-	0xf1, 0xe0, 0xca, 0x30, 0xde, 0x30, 0xe1, 0x38, 0x0a, 0x73, 0x65, 0xc3, 0x64, 0x83, 0xed, 0xc0,
-	0x26, 0x8a, 0xbf, 0x96, 0x68, 0x73, 0xac, 0xd2, 0x91, 0xac, 0xed, 0x43, 0x8d, 0x45, 0x5c, 0x49,
-	0xa0, 0xf9, 0x44, 0x5a, 0xda, 0xba, 0x2e, 0xdc, 0x35, 0xc9, 0x09, 0x97, 0x3c, 0x2f, 0x55, 0xc4,
-	0x00, 0x95, 0xe8, 0x6d, 0x41, 0x3a, 0xb1, 0xe5, 0xe8, 0xcd, 0x8a, 0x00, 0x97, 0x2a, 0x11, 0xe0,
-	0x15, 0x58, 0xcd, 0x67, 0xf1, 0x80, 0x0f, 0xfb, 0x22, 0xc1, 0x71, 0xa3, 0x98, 0x4e, 0x67, 0x29,
-	0x28, 0xc3, 0x14, 0xab, 0xf2, 0x5c, 0xc4, 0x5c, 0x90, 0xe9, 0x5a, 0x0a, 0x74, 0x13, 0x6f, 0x01,
-	0x22, 0x91, 0x42, 0xdd, 0x0a, 0x54, 0x0b, 0xaf, 0xca, 0x69, 0x16, 0xe5, 0xdd, 0x0e, 0xa1, 0xf4,
-	0x37, 0xfb, 0x02, 0x9c, 0x3d, 0xc0, 0xc8, 0xea, 0x88, 0x87, 0x43, 0x9e, 0xd1, 0xe9, 0xcb, 0xc0,
-	0x52, 0x5a, 0xa0, 0xfa, 0x4e, 0xff, 0x53, 0xba, 0xb7, 0x4d, 0x60, 0xfb, 0x98, 0x8c, 0x0e, 0x7b,
-	0x05, 0x5a, 0x72, 0x25, 0xf9, 0x51, 0xa8, 0x5c, 0x89, 0x25, 0x02, 0xf6, 0x8f, 0x42, 0x54, 0x53,
-	0x67, 0x73, 0x1a, 0xe4, 0x1f, 0xb6, 0x09, 0xdb, 0x95, 0x7b, 0xf3, 0x06, 0xac, 0xe8, 0x90, 0x39,
-	0xef, 0x8f, 0xf9, 0xa1, 0xd0, 0x61, 0x40, 0x3c, 0x9d, 0xe0, 0x70, 0xf9, 0x03, 0x7e, 0x28, 0xfc,
-	0x87, 0xb0, 0xae, 0xb4, 0xf3, 0xab, 0x29, 0xd7, 0x43, 0xff, 0x4a, 0xf9, 0xea, 0x92, 0xbe, 0xc3,
-	0x86, 0xab, 0xce, 0x14, 0xcb, 0x94, 0xee, 0x33, 0x3f, 0x00, 0xa6, 0xba, 0x6f, 0x8f, 0x93, 0x9c,
-	0x2b, 0x86, 0x3e, 0x74, 0x06, 0xe3, 0x24, 0xd7, 0xc1, 0x86, 0x5a, 0x8e, 0x83, 0xe1, 0x09, 0xe4,
-	0xd3, 0xc1, 0x00, 0xf5, 0x5d, 0x5a, 0x2e, 0xdd, 0xf4, 0xff, 0xd2, 0x83, 0x0d, 0xe2, 0xa6, 0xed,
-	0x88, 0xf1, 0x50, 0x5f, 0x7e, 0x9a, 0x9d, 0x81, 0x1d, 0x80, 0x6d, 0xc2, 0xfc, 0x61, 0x92, 0x0d,
-	0xb8, 0x1a, 0x49, 0x36, 0x7e, 0x72, 0x9f, 0xbb, 0x59, 0xf1, 0xb9, 0xff, 0xc9, 0x83, 0x75, 0x9a,
-	0xea, 0xbe, 0x08, 0xc5, 0x34, 0x57, 0xcb, 0xff, 0x55, 0x58, 0xc6, 0xa5, 0x72, 0xad, 0x34, 0x6a,
-	0xa2, 0x9b, 0x46, 0xbf, 0x09, 0x95, 0xc4, 0xbb, 0x67, 0x02, 0x97, 0x98, 0x7d, 0x09, 0x3a, 0x76,
-	0xde, 0x83, 0xe6, 0xdc, 0xde, 0x39, 0xaf, 0x57, 0x59, 0x91, 0x9c, 0xdd, 0x33, 0x81, 0xf3, 0x01,
-	0xbb, 0x01, 0x40, 0x4e, 0x05, 0xb1, 0x55, 0x01, 0xeb, 0x79, 0x77, 0x93, 0xac, 0xc3, 0xda, 0x3d,
-	0x13, 0x58, 0xe4, 0xb7, 0x96, 0x60, 0x41, 0xde, 0x82, 0xfe, 0x7d, 0x58, 0x76, 0x66, 0xea, 0xc4,
-	0x12, 0x1d, 0x19, 0x4b, 0x54, 0x42, 0xcf, 0x46, 0x35, 0xf4, 0xf4, 0xff, 0xbd, 0x01, 0x0c, 0xa5,
-	0xad, 0x74, 0x9c, 0x78, 0x0d, 0x27, 0x43, 0xc7, 0xa9, 0xea, 0x04, 0x36, 0xc4, 0xae, 0x01, 0xb3,
-	0x9a, 0x3a, 0xc3, 0x20, 0x6f, 0x87, 0x9a, 0x1e, 0x34, 0x63, 0xd2, 0x23, 0xd2, 0x91, 0xae, 0x72,
-	0x1f, 0xe5, 0xb9, 0xd5, 0xf6, 0xe1, 0x05, 0x90, 0x4e, 0xf3, 0x23, 0x74, 0x13, 0xb4, 0xdb, 0xa5,
-	0xdb, 0x65, 0x01, 0x59, 0x78, 0xa1, 0x80, 0x2c, 0x96, 0x05, 0xc4, 0xbe, 0xf8, 0x97, 0x9c, 0x8b,
-	0x1f, 0xbd, 0xac, 0x49, 0x14, 0x93, 0xf7, 0xd0, 0x9f, 0xe0, 0xe8, 0xca, 0xcb, 0x72, 0x40, 0x76,
-	0x15, 0xd6, 0x94, 0xf7, 0x56, 0x78, 0x17, 0x40, 0x7b, 0x5c, 0xc1, 0xfd, 0x1f, 0x7b, 0xb0, 0x86,
-	0xfb, 0xec, 0xc8, 0xe2, 0x7b, 0x40, 0xaa, 0xf0, 0x92, 0xa2, 0xe8, 0xd0, 0xfe, 0xec, 0x92, 0xf8,
-	0x2e, 0xb4, 0x88, 0x61, 0x92, 0xf2, 0x58, 0x09, 0x62, 0xd7, 0x15, 0xc4, 0xc2, 0x0a, 0xed, 0x9e,
-	0x09, 0x0a, 0x62, 0x4b, 0x0c, 0xff, 0xd1, 0x83, 0xb6, 0x9a, 0xe6, 0x4f, 0x1d, 0x31, 0xf4, 0x60,
-	0x09, 0x25, 0xd2, 0x72, 0xcb, 0x4d, 0x1b, 0xef, 0x8c, 0x09, 0x86, 0x65, 0x78, 0x49, 0x3a, 0xd1,
-	0x42, 0x19, 0xc6, 0x1b, 0x8f, 0x0c, 0x6e, 0xde, 0x17, 0xd1, 0xb8, 0xaf, 0x7b, 0x55, 0x9a, 0xb1,
-	0xae, 0x0b, 0xed, 0x4e, 0x2e, 0xc2, 0x11, 0x57, 0x97, 0x99, 0x6c, 0x60, 0x58, 0xa4, 0x16, 0x54,
-	0x72, 0xfa, 0xfc, 0x1f, 0x01, 0x9c, 0xab, 0x74, 0x99, 0xa4, 0xb6, 0x72, 0x83, 0xc7, 0xd1, 0xe4,
-	0x20, 0x31, 0x1e, 0xb5, 0x67, 0x7b, 0xc8, 0x4e, 0x17, 0x1b, 0xc1, 0x59, 0x7d, 0x6b, 0xe3, 0x9e,
-	0x16, 0x77, 0x74, 0x83, 0xdc, 0x8d, 0xb7, 0x5d, 0x19, 0x28, 0x0f, 0xa8, 0x71, 0x5b, 0x73, 0xeb,
-	0xf9, 0xb1, 0x23, 0xe8, 0x1a, 0xf7, 0x40, 0x99, 0x78, 0xcb, 0x85, 0xc0, 0xb1, 0xde, 0x7a, 0xc1,
-	0x58, 0x64, 0x8f, 0x86, 0x7a, 0x98, 0x53, 0xb9, 0xb1, 0x19, 0x5c, 0xd4, 0x7d, 0x64, 0xc3, 0xab,
-	0xe3, 0x35, 0x5f, 0x6a, 0x6d, 0xf7, 0xf0, 0x63, 0x77, 0xd0, 0x17, 0x30, 0xee, 0xfd, 0xc8, 0x83,
-	0x15, 0x97, 0x1d, 0x8a, 0x8e, 0x52, 0x42, 0x6d, 0x8c, 0xb4, 0xdb, 0x55, 0x82, 0xab, 0xc1, 0x61,
-	0xa3, 0x2e, 0x38, 0xb4, 0x43, 0xc0, 0xb9, 0x17, 0x85, 0x80, 0xcd, 0x97, 0x0b, 0x01, 0xe7, 0xeb,
-	0x42, 0xc0, 0xde, 0x7f, 0x79, 0xc0, 0xaa, 0xe7, 0xcb, 0xee, 0xcb, 0xe8, 0x34, 0xe6, 0x63, 0x65,
-	0x27, 0x7e, 0xe9, 0xe5, 0x64, 0x44, 0xef, 0xa1, 0xfe, 0x1a, 0x85, 0xd5, 0x36, 0x04, 0xb6, 0xdb,
-	0xb2, 0x1c, 0xd4, 0x75, 0x95, 0x82, 0xd2, 0xe6, 0x8b, 0x83, 0xd2, 0xf9, 0x17, 0x07, 0xa5, 0x0b,
-	0xe5, 0xa0, 0xb4, 0xf7, 0x3b, 0xb0, 0xec, 0x9c, 0xfa, 0xff, 0xdd, 0x8a, 0xcb, 0x2e, 0x8f, 0x3c,
-	0x60, 0x07, 0xeb, 0xfd, 0x67, 0x03, 0x58, 0x55, 0xf2, 0x7e, 0xae, 0x73, 0x20, 0x39, 0x72, 0x0c,
-	0xc8, 0x9c, 0x92, 0x23, 0xc7, 0x74, 0xfc, 0x7f, 0x1a, 0xc5, 0xb7, 0x60, 0x3d, 0xe3, 0x83, 0xe4,
-	0x98, 0x9e, 0xda, 0xdc, 0x84, 0x46, 0xb5, 0x03, 0x9d, 0x3e, 0x37, 0x14, 0x5f, 0x72, 0x5e, 0x46,
-	0xac, 0x9b, 0xa1, 0x14, 0x91, 0xfb, 0x5b, 0xb0, 0x29, 0x1f, 0xac, 0x6e, 0x49, 0x56, 0xda, 0xc8,
-	0x7e, 0xcf, 0x83, 0xb3, 0xa5, 0x8e, 0xe2, 0xf9, 0x40, 0xda, 0x51, 0xd7, 0xb8, 0xba, 0x20, 0xce,
-	0x5f, 0x09, 0xb0, 0x35, 0x7f, 0x79, 0xdf, 0x54, 0x3b, 0x70, 0x7f, 0xa6, 0x71, 0x95, 0x5e, 0xee,
-	0x7a, 0x5d, 0x97, 0x7f, 0x0e, 0xce, 0xaa, 0x93, 0x2d, 0x4d, 0xfc, 0x10, 0xb6, 0xca, 0x1d, 0x45,
-	0x3e, 0xd4, 0x9d, 0xb2, 0x6e, 0xa2, 0x4b, 0xe4, 0xd8, 0x6c, 0x77, 0xbe, 0xb5, 0x7d, 0xfe, 0x6f,
-	0x03, 0xfb, 0xda, 0x94, 0x67, 0x33, 0x7a, 0xdc, 0x30, 0x09, 0x89, 0x73, 0xe5, 0xc8, 0x7d, 0x21,
-	0x9d, 0x1e, 0x7c, 0x85, 0xcf, 0xf4, 0xeb, 0x51, 0xa3, 0x78, 0x3d, 0x7a, 0x15, 0x00, 0x43, 0x11,
-	0x7a, 0x0d, 0xd1, 0xef, 0x79, 0x18, 0xe9, 0x49, 0x86, 0xfe, 0x0d, 0xd8, 0x70, 0xf8, 0x9b, 0xdd,
-	0x5f, 0x50, 0x5f, 0xc8, 0x70, 0xd8, 0x7d, 0x63, 0x51, 0x7d, 0xfe, 0x9f, 0x7a, 0x30, 0xb7, 0x9b,
-	0xa4, 0x76, 0x22, 0xcd, 0x73, 0x13, 0x69, 0xca, 0xd6, 0xf6, 0x8d, 0x29, 0x6d, 0x28, 0x4b, 0x61,
-	0x83, 0x68, 0x29, 0xc3, 0x89, 0xc0, 0x80, 0xf0, 0x30, 0xc9, 0x4e, 0xc2, 0x6c, 0xa8, 0x8e, 0xa4,
-	0x84, 0xe2, 0xea, 0x0a, 0x83, 0x84, 0x7f, 0xa2, 0x93, 0x41, 0x79, 0xc4, 0x99, 0x8a, 0x61, 0x55,
-	0xcb, 0xff, 0x8e, 0x07, 0xf3, 0x34, 0x57, 0xd4, 0x1e, 0x29, 0x32, 0xf4, 0xb0, 0x48, 0x69, 0x4a,
-	0x4f, 0x6a, 0x4f, 0x09, 0x2e, 0x3d, 0x37, 0x36, 0x2a, 0xcf, 0x8d, 0x17, 0xa0, 0x25, 0x5b, 0xc5,
-	0xfb, 0x5c, 0x01, 0xb0, 0x8b, 0xd0, 0x3c, 0x4a, 0x52, 0x7d, 0xe7, 0x81, 0xce, 0x4e, 0x25, 0x69,
-	0x40, 0xb8, 0x7f, 0x15, 0x56, 0x1f, 0x26, 0x43, 0x6e, 0x65, 0x0f, 0x4e, 0x3d, 0x45, 0xff, 0x77,
-	0x3d, 0x58, 0xd2, 0xc4, 0xec, 0x0a, 0x34, 0xf1, 0xea, 0x2a, 0x39, 0x8b, 0x26, 0x87, 0x8c, 0x74,
-	0x01, 0x51, 0xa0, 0xc9, 0xa1, 0xa8, 0xb3, 0x70, 0x2d, 0x74, 0xcc, 0x59, 0x5c, 0xda, 0x6f, 0xc2,
-	0x8a, 0x9c, 0x73, 0xe9, 0x72, 0x2b, 0xa1, 0xfe, 0x5f, 0x79, 0xb0, 0xec, 0x8c, 0x81, 0x21, 0xc2,
-	0x38, 0xcc, 0x85, 0xca, 0xcb, 0xa9, 0x4d, 0xb4, 0x21, 0x3b, 0x9f, 0xd4, 0x70, 0xf3, 0x49, 0x26,
-	0xd3, 0x31, 0x67, 0x67, 0x3a, 0xae, 0x43, 0xab, 0x78, 0xba, 0x6d, 0x3a, 0xa6, 0x04, 0x47, 0xd4,
-	0xd9, 0xf1, 0x82, 0x08, 0xf9, 0x0c, 0x92, 0x71, 0x92, 0xa9, 0x97, 0x4d, 0xd9, 0xf0, 0x6f, 0x40,
-	0xdb, 0xa2, 0xc7, 0x69, 0xc4, 0x5c, 0x9c, 0x24, 0xd9, 0x13, 0x9d, 0xd6, 0x52, 0x4d, 0xf3, 0x08,
-	0xd4, 0x28, 0x1e, 0x81, 0xfc, 0xbf, 0xf6, 0x60, 0x19, 0x25, 0x25, 0x8a, 0x47, 0x7b, 0xc9, 0x38,
-	0x1a, 0xcc, 0x48, 0x62, 0xb4, 0x50, 0xa8, 0x27, 0x4f, 0x2d, 0x31, 0x2e, 0x8c, 0x3e, 0x82, 0x8e,
-	0x10, 0x94, 0xbc, 0x98, 0x36, 0x4a, 0x3e, 0xde, 0x75, 0x07, 0x61, 0xce, 0x65, 0x48, 0xa1, 0x6c,
-	0xbb, 0x03, 0xa2, 0x45, 0x42, 0x20, 0x0b, 0x05, 0xef, 0x4f, 0xa2, 0xf1, 0x38, 0x92, 0xb4, 0x52,
-	0xc2, 0xeb, 0xba, 0xfc, 0x1f, 0x36, 0xa0, 0xad, 0x2c, 0xcf, 0xdd, 0xe1, 0x48, 0x26, 0x90, 0x95,
-	0xe3, 0x62, 0xd4, 0xcf, 0x42, 0x74, 0xbf, 0xe3, 0xea, 0x58, 0x48, 0xf9, 0x58, 0xe7, 0xaa, 0xc7,
-	0x7a, 0x01, 0x5a, 0x28, 0x5e, 0x6f, 0x93, 0x4f, 0x25, 0x5f, 0xfa, 0x0b, 0x40, 0xf7, 0xee, 0x50,
-	0xef, 0x7c, 0xd1, 0x4b, 0x80, 0xe3, 0x45, 0x2d, 0x94, 0xbc, 0xa8, 0x77, 0xa1, 0xa3, 0xd8, 0xd0,
-	0xbe, 0x53, 0xc0, 0x56, 0x08, 0xb8, 0x73, 0x26, 0x81, 0x43, 0xa9, 0xbf, 0xdc, 0xd1, 0x5f, 0x2e,
-	0xbd, 0xe8, 0x4b, 0x4d, 0x49, 0xef, 0x29, 0x72, 0x6f, 0xee, 0x67, 0x61, 0x7a, 0xa4, 0xad, 0xf9,
-	0xd0, 0x3c, 0x12, 0x13, 0xcc, 0xae, 0xc2, 0x3c, 0x7e, 0xa6, 0xad, 0x5f, 0xbd, 0xd2, 0x49, 0x12,
-	0x76, 0x05, 0xe6, 0xf9, 0x70, 0xc4, 0xb5, 0x27, 0xcf, 0xdc, 0x98, 0x0a, 0xcf, 0x28, 0x90, 0x04,
-	0x68, 0x02, 0x10, 0x2d, 0x99, 0x00, 0xd7, 0x72, 0x2e, 0x60, 0xf3, 0x83, 0xa1, 0xbf, 0x09, 0xec,
-	0xa1, 0x94, 0x5a, 0x3b, 0xdf, 0xf8, 0x07, 0x73, 0xd0, 0xb6, 0x60, 0xd4, 0xe6, 0x11, 0x4e, 0xb8,
-	0x3f, 0x8c, 0xc2, 0x09, 0x17, 0x3c, 0x53, 0x92, 0x5a, 0x42, 0xc9, 0xc0, 0x1e, 0x8f, 0xfa, 0xc9,
-	0x54, 0xf4, 0x87, 0x7c, 0x94, 0x71, 0x79, 0xe7, 0x78, 0x41, 0x09, 0x45, 0xba, 0x49, 0xf8, 0xd4,
-	0xa6, 0x93, 0xf2, 0x50, 0x42, 0x75, 0xf6, 0x50, 0xee, 0x51, 0xb3, 0xc8, 0x1e, 0xca, 0x1d, 0x29,
-	0xdb, 0xa1, 0xf9, 0x1a, 0x3b, 0xf4, 0x0e, 0x6c, 0x49, 0x8b, 0xa3, 0x74, 0xb3, 0x5f, 0x12, 0x93,
-	0x53, 0x7a, 0x31, 0x06, 0xc7, 0x39, 0x6b, 0x01, 0xcf, 0xa3, 0x4f, 0x65, 0xa4, 0xef, 0x05, 0x15,
-	0x1c, 0x69, 0x51, 0x1d, 0x1d, 0x5a, 0xf9, 0xc2, 0x52, 0xc1, 0x89, 0x36, 0x7c, 0xea, 0xd2, 0xb6,
-	0x14, 0x6d, 0x09, 0xf7, 0x97, 0xa1, 0xbd, 0x2f, 0x92, 0x54, 0x1f, 0xca, 0x0a, 0x74, 0x64, 0x53,
-	0xbd, 0xa7, 0xbd, 0x02, 0xe7, 0x49, 0x8a, 0x1e, 0x25, 0x69, 0x32, 0x4e, 0x46, 0xb3, 0xfd, 0xe9,
-	0x41, 0x3e, 0xc8, 0xa2, 0x14, 0x3d, 0x6c, 0xff, 0x1f, 0x3c, 0xd8, 0x70, 0x7a, 0x55, 0x6a, 0xe0,
-	0x0b, 0x52, 0xa4, 0xcd, 0x43, 0x88, 0x14, 0xbc, 0x75, 0xcb, 0x1c, 0x4a, 0x42, 0x99, 0x94, 0x79,
-	0xac, 0xde, 0x46, 0x6e, 0xc2, 0xaa, 0x9e, 0x99, 0xfe, 0x50, 0x4a, 0x61, 0xb7, 0x2a, 0x85, 0xea,
-	0xfb, 0x15, 0xf5, 0x81, 0x66, 0xf1, 0x6b, 0xd2, 0x4f, 0xe5, 0x43, 0x5a, 0xa3, 0x8e, 0x11, 0x7b,
-	0xfa, 0x7b, 0xdb, 0x39, 0xd6, 0x33, 0x18, 0x18, 0x30, 0xf7, 0xff, 0xc8, 0x03, 0x28, 0x66, 0x87,
-	0x82, 0x51, 0x98, 0x74, 0x59, 0xe2, 0x65, 0x99, 0xef, 0xd7, 0xa1, 0x63, 0x72, 0xe0, 0xc5, 0x2d,
-	0xd1, 0xd6, 0x18, 0x3a, 0x30, 0x97, 0x61, 0x75, 0x34, 0x4e, 0x0e, 0xe8, 0xce, 0xa5, 0x07, 0xda,
-	0x5c, 0xbd, 0x2a, 0xae, 0x48, 0xf8, 0x9e, 0x42, 0x8b, 0x2b, 0xa5, 0x69, 0x5d, 0x29, 0xfe, 0xb7,
-	0x1b, 0x26, 0xa7, 0x5a, 0xac, 0xf9, 0x54, 0x2d, 0x63, 0x3b, 0x15, 0xe3, 0x78, 0x4a, 0x0a, 0x93,
-	0xb2, 0x21, 0x7b, 0x2f, 0x0c, 0x0c, 0x6f, 0xc0, 0x4a, 0x26, 0xad, 0x8f, 0x36, 0x4d, 0xcd, 0xe7,
-	0x98, 0xa6, 0xe5, 0xcc, 0xb9, 0x77, 0x7e, 0x01, 0xd6, 0xc2, 0xe1, 0x31, 0xcf, 0x44, 0x44, 0x11,
-	0x02, 0x5d, 0xfa, 0xd2, 0xa0, 0xae, 0x5a, 0x38, 0xdd, 0xc5, 0x97, 0x61, 0x55, 0xbd, 0xe4, 0x1a,
-	0x4a, 0x55, 0xbf, 0x53, 0xc0, 0x48, 0xe8, 0xff, 0x40, 0xa7, 0x6f, 0xdd, 0x33, 0x3c, 0x7d, 0x47,
-	0xec, 0xd5, 0x35, 0x4a, 0xab, 0xfb, 0x9c, 0x4a, 0xa5, 0x0e, 0x75, 0x18, 0xa2, 0x92, 0xda, 0x12,
-	0x54, 0xa9, 0x6f, 0x77, 0x4b, 0x9b, 0x2f, 0xb3, 0xa5, 0xfe, 0xf7, 0xe6, 0x60, 0xf1, 0x83, 0xf8,
-	0x38, 0x89, 0x06, 0x94, 0xd8, 0x9c, 0xf0, 0x49, 0xa2, 0x8b, 0x24, 0xf0, 0x6f, 0xbc, 0xd1, 0xe9,
-	0xc1, 0x30, 0x15, 0x2a, 0x33, 0xa9, 0x9b, 0x78, 0xbb, 0x65, 0x45, 0xe1, 0x90, 0x94, 0x14, 0x0b,
-	0x41, 0xff, 0x30, 0xb3, 0xab, 0xa6, 0x54, 0xab, 0xa8, 0x32, 0x99, 0xb7, 0xaa, 0x4c, 0x28, 0x0d,
-	0x2e, 0xdf, 0x42, 0x69, 0x3b, 0x97, 0x02, 0xdd, 0x24, 0x3f, 0x36, 0xe3, 0x32, 0x48, 0xa6, 0x7b,
-	0x72, 0x51, 0xf9, 0xb1, 0x36, 0x88, 0x77, 0xa9, 0xfc, 0x40, 0xd2, 0x48, 0x5b, 0x63, 0x43, 0xe8,
-	0x5b, 0x94, 0x0b, 0xaf, 0x5a, 0xf2, 0x88, 0x4b, 0x30, 0x1a, 0xa4, 0x21, 0x37, 0x76, 0x43, 0xae,
-	0x01, 0x64, 0x61, 0x54, 0x19, 0xb7, 0xbc, 0x60, 0xf9, 0xa6, 0xab, 0x5a, 0xe4, 0x83, 0x84, 0xe3,
-	0xf1, 0x41, 0x38, 0x78, 0x42, 0xe5, 0x70, 0xf4, 0x84, 0xdb, 0x0a, 0x5c, 0x10, 0x67, 0x4d, 0xd5,
-	0x5d, 0x8a, 0xc5, 0xb2, 0x7c, 0x82, 0xb5, 0x20, 0xff, 0xeb, 0xc0, 0x6e, 0x0e, 0x87, 0xea, 0x84,
-	0x4c, 0x8c, 0x50, 0xec, 0xad, 0xe7, 0xec, 0x6d, 0xcd, 0x1a, 0x1b, 0xb5, 0x6b, 0xf4, 0xef, 0x42,
-	0x7b, 0xcf, 0xaa, 0x62, 0xa3, 0xc3, 0xd4, 0xf5, 0x6b, 0x4a, 0x00, 0x2c, 0xc4, 0x1a, 0xb0, 0x61,
-	0x0f, 0xe8, 0xff, 0x32, 0xb0, 0x07, 0x51, 0x2e, 0xcc, 0xfc, 0xe4, 0x06, 0xbe, 0x0e, 0x1d, 0x13,
-	0x51, 0x15, 0xaf, 0xb6, 0x6d, 0x85, 0xd1, 0x6b, 0xea, 0x4d, 0xf9, 0xdc, 0x5b, 0x5e, 0xd8, 0x55,
-	0x58, 0x8a, 0x24, 0xa4, 0xed, 0xb0, 0x7e, 0x4c, 0xd5, 0x94, 0xa6, 0x1f, 0x1d, 0x0a, 0x05, 0x3a,
-	0x66, 0xfe, 0x87, 0x1e, 0x2c, 0xaa, 0xa5, 0xe1, 0x75, 0xe8, 0xd4, 0xef, 0xc9, 0x85, 0x39, 0x58,
-	0x7d, 0xd5, 0x53, 0x55, 0xea, 0xe6, 0xea, 0xa4, 0x8e, 0x41, 0x33, 0x0d, 0xc5, 0x11, 0x79, 0xd0,
-	0xad, 0x80, 0xfe, 0xd6, 0x91, 0xd2, 0x7c, 0x11, 0x29, 0xd5, 0x15, 0xda, 0x49, 0x9b, 0x51, 0xc1,
-	0x71, 0x51, 0xf4, 0x40, 0x2a, 0x71, 0x93, 0x11, 0x55, 0x8f, 0xcf, 0x05, 0x5c, 0xec, 0x97, 0x62,
-	0x51, 0xde, 0x2f, 0x45, 0x1a, 0x98, 0x7e, 0xbf, 0x07, 0xdd, 0x3b, 0x7c, 0xcc, 0x05, 0xbf, 0x39,
-	0x1e, 0x97, 0xf9, 0xbf, 0x02, 0xe7, 0x6b, 0xfa, 0xd4, 0xad, 0x7a, 0x0f, 0xd6, 0xef, 0xf0, 0x83,
-	0xe9, 0xe8, 0x01, 0x3f, 0x2e, 0x9e, 0x2d, 0x18, 0x34, 0xf3, 0xa3, 0xe4, 0x44, 0x9d, 0x2d, 0xfd,
-	0x8d, 0x01, 0xef, 0x18, 0x69, 0xfa, 0x79, 0xca, 0x07, 0xba, 0xde, 0x87, 0x90, 0xfd, 0x94, 0x0f,
-	0xfc, 0x77, 0x80, 0xd9, 0x7c, 0xd4, 0x12, 0x50, 0x73, 0xa7, 0x07, 0xfd, 0x7c, 0x96, 0x0b, 0x3e,
-	0xd1, 0x85, 0x4c, 0x36, 0xe4, 0x5f, 0x86, 0xce, 0x5e, 0x38, 0x0b, 0xf8, 0x27, 0xaa, 0x84, 0x12,
-	0x83, 0xb7, 0x70, 0x86, 0xa2, 0x6c, 0x82, 0x37, 0xea, 0xf6, 0xff, 0xae, 0x01, 0x0b, 0x92, 0x12,
-	0xb9, 0x0e, 0x79, 0x2e, 0xa2, 0x58, 0xa6, 0xec, 0x15, 0x57, 0x0b, 0xaa, 0xc8, 0x46, 0xa3, 0x46,
-	0x36, 0x94, 0x3b, 0xa5, 0x6b, 0x27, 0x94, 0x10, 0x38, 0x18, 0xc5, 0xa6, 0xe6, 0xc1, 0xb3, 0xa9,
-	0x62, 0x53, 0x0d, 0x94, 0xa2, 0xe4, 0xc2, 0x3e, 0xc8, 0xf9, 0x69, 0xa1, 0x55, 0xe2, 0x60, 0x43,
-	0xb5, 0x56, 0x68, 0x51, 0x4a, 0x4d, 0xc5, 0x0a, 0x55, 0xac, 0xcd, 0xd2, 0x4b, 0x58, 0x1b, 0xe9,
-	0x63, 0x39, 0xd6, 0x86, 0xc1, 0xda, 0x3d, 0xce, 0x03, 0x9e, 0x26, 0x99, 0xae, 0x43, 0xf5, 0xbf,
-	0xeb, 0xc1, 0x9a, 0xba, 0x3d, 0x4c, 0x1f, 0x7b, 0xdd, 0xb9, 0x6a, 0xbc, 0xba, 0x2c, 0xee, 0x1b,
-	0xb0, 0x4c, 0xc1, 0x16, 0x46, 0x52, 0x14, 0x59, 0xa9, 0xfc, 0x83, 0x03, 0xe2, 0x9c, 0x74, 0x5e,
-	0x72, 0x12, 0x8d, 0xd5, 0x06, 0xdb, 0x10, 0x5e, 0x8b, 0x3a, 0x18, 0xa3, 0xed, 0xf5, 0x02, 0xd3,
-	0xf6, 0xff, 0xd6, 0x83, 0x75, 0x6b, 0xc2, 0x4a, 0xa2, 0x6e, 0x80, 0x7e, 0xf6, 0x94, 0xf9, 0x04,
-	0xa9, 0x18, 0xe7, 0xdc, 0x9b, 0xb0, 0xf8, 0xcc, 0x21, 0xa6, 0x83, 0x09, 0x67, 0x34, 0xc1, 0x7c,
-	0x2a, 0x2b, 0xc2, 0x9a, 0x81, 0x0d, 0xa1, 0x50, 0x9c, 0x70, 0xfe, 0xc4, 0x90, 0xcc, 0x11, 0x89,
-	0x83, 0xd1, 0xab, 0x56, 0x12, 0x8b, 0x23, 0x43, 0x24, 0xcb, 0x35, 0x5c, 0xd0, 0xff, 0x67, 0x0f,
-	0x36, 0xa4, 0x07, 0xa2, 0xfc, 0x3b, 0x53, 0x4a, 0xb6, 0x20, 0x5d, 0x2e, 0xa9, 0x5d, 0xbb, 0x67,
-	0x02, 0xd5, 0x66, 0x5f, 0x7c, 0x49, 0xaf, 0xc9, 0xbc, 0x66, 0x9e, 0x72, 0x16, 0x73, 0x75, 0x67,
-	0xf1, 0x9c, 0x9d, 0xae, 0x8b, 0xcc, 0xe7, 0x6b, 0x23, 0xf3, 0x5b, 0x8b, 0x30, 0x9f, 0x0f, 0x92,
-	0x94, 0xfb, 0x5b, 0xb0, 0xe9, 0x2e, 0x4e, 0x99, 0x93, 0xef, 0x7b, 0xd0, 0xbd, 0x27, 0xd3, 0x4a,
-	0x51, 0x3c, 0xda, 0x8d, 0x72, 0x91, 0x64, 0xa6, 0x76, 0xf6, 0x22, 0x40, 0x2e, 0xc2, 0x4c, 0xc8,
-	0x9a, 0x12, 0x15, 0x53, 0x17, 0x08, 0xce, 0x91, 0xc7, 0x43, 0xd9, 0x2b, 0xcf, 0xc6, 0xb4, 0xf1,
-	0x60, 0xe8, 0xa5, 0xb5, 0x9f, 0x1c, 0x1e, 0xe6, 0xdc, 0xf8, 0x48, 0x36, 0x86, 0x61, 0x16, 0x6a,
-	0x2f, 0x06, 0x16, 0xfc, 0x98, 0xcc, 0xa6, 0x8c, 0xa1, 0x4a, 0xa8, 0xff, 0x37, 0x1e, 0xac, 0x16,
-	0x93, 0xbc, 0x8b, 0xa0, 0xab, 0xe9, 0x72, 0x6a, 0x96, 0xa6, 0xeb, 0x68, 0x3f, 0x1a, 0xf6, 0xa3,
-	0x58, 0xcd, 0xcd, 0x42, 0x48, 0xfb, 0x54, 0x2b, 0x99, 0xea, 0xfa, 0x1d, 0x1b, 0x92, 0xcf, 0x76,
-	0x02, 0xbf, 0x96, 0xc5, 0x3b, 0xaa, 0x45, 0x25, 0x41, 0x13, 0x41, 0x5f, 0x2d, 0xc8, 0x1c, 0x9f,
-	0x6a, 0xea, 0xbb, 0x66, 0x91, 0x50, 0xfc, 0xd3, 0xff, 0x8e, 0x07, 0xe7, 0x6b, 0x36, 0x57, 0x69,
-	0xc6, 0x1d, 0x58, 0x3f, 0x34, 0x9d, 0x7a, 0x03, 0xa4, 0x7a, 0x6c, 0x29, 0x29, 0x2a, 0x2d, 0x3a,
-	0xa8, 0x7e, 0xc0, 0xde, 0x82, 0x75, 0x4a, 0x52, 0xc8, 0x2d, 0x75, 0x5e, 0xbc, 0xab, 0x1d, 0x3b,
-	0x3f, 0x68, 0xc0, 0x8a, 0xcc, 0x33, 0xcb, 0x5f, 0x4f, 0xf0, 0x8c, 0x7d, 0x08, 0x8b, 0xea, 0xb7,
-	0x2a, 0xec, 0xac, 0x1a, 0xd6, 0xfd, 0x75, 0x4c, 0x6f, 0xab, 0x0c, 0x2b, 0xd9, 0xd9, 0xf8, 0xfd,
-	0x1f, 0xff, 0xdb, 0x1f, 0x37, 0x96, 0x59, 0x7b, 0xfb, 0xf8, 0xed, 0xed, 0x11, 0x8f, 0x73, 0xe4,
-	0xf1, 0x9b, 0x00, 0xc5, 0xcf, 0x3d, 0x58, 0xd7, 0x38, 0x0c, 0xa5, 0x9f, 0xa7, 0xf4, 0xce, 0xd7,
-	0xf4, 0x28, 0xbe, 0xe7, 0x89, 0xef, 0x86, 0xbf, 0x82, 0x7c, 0xa3, 0x38, 0x12, 0xf2, 0xb7, 0x1f,
-	0xef, 0x79, 0x57, 0xd9, 0x10, 0x3a, 0xf6, 0xcf, 0x3e, 0x98, 0x8e, 0xcf, 0x6a, 0x7e, 0x4b, 0xd2,
-	0x7b, 0xa5, 0xb6, 0x4f, 0x07, 0xa7, 0x34, 0xc6, 0x59, 0x7f, 0x0d, 0xc7, 0x98, 0x12, 0x85, 0x19,
-	0x65, 0xe7, 0x5f, 0x5e, 0x81, 0x96, 0xc9, 0x71, 0xb0, 0x6f, 0xc1, 0xb2, 0x93, 0x9a, 0x67, 0x9a,
-	0x71, 0x5d, 0x26, 0xbf, 0x77, 0xa1, 0xbe, 0x53, 0x0d, 0x7b, 0x91, 0x86, 0xed, 0xb2, 0x2d, 0x1c,
-	0x56, 0xe5, 0xb6, 0xb7, 0xe9, 0x41, 0x42, 0x96, 0x00, 0x3d, 0x81, 0x15, 0x37, 0x9d, 0xce, 0x2e,
-	0xb8, 0x06, 0xa5, 0x34, 0xda, 0xab, 0xa7, 0xf4, 0xaa, 0xe1, 0x2e, 0xd0, 0x70, 0x5b, 0x6c, 0xd3,
-	0x1e, 0xce, 0xe4, 0x1e, 0x38, 0x15, 0x6d, 0xd9, 0xbf, 0x07, 0x61, 0xaf, 0x9a, 0xa3, 0xae, 0xfb,
-	0x9d, 0x88, 0x39, 0xb4, 0xea, 0x8f, 0x45, 0xfc, 0x2e, 0x0d, 0xc5, 0x18, 0x6d, 0xa8, 0xfd, 0x73,
-	0x10, 0xf6, 0x31, 0xb4, 0x4c, 0x0d, 0x38, 0x3b, 0x67, 0x15, 0xde, 0xdb, 0x85, 0xe9, 0xbd, 0x6e,
-	0xb5, 0xa3, 0xee, 0xa8, 0x6c, 0xce, 0x28, 0x10, 0x0f, 0xe0, 0xac, 0x72, 0x38, 0x0f, 0xf8, 0x4f,
-	0xb2, 0x92, 0x9a, 0x5f, 0xb1, 0x5c, 0xf7, 0xd8, 0x0d, 0x58, 0xd2, 0xa5, 0xf5, 0x6c, 0xab, 0xfe,
-	0x27, 0x02, 0xbd, 0x73, 0x15, 0x5c, 0xe9, 0xf3, 0x4d, 0x80, 0xa2, 0x2c, 0xdc, 0x48, 0x7e, 0xa5,
-	0x58, 0xdd, 0x6c, 0x62, 0x4d, 0x0d, 0xf9, 0x88, 0x8a, 0xe0, 0xdd, 0xaa, 0x73, 0xf6, 0x5a, 0x41,
-	0x5f, 0x5b, 0x8f, 0xfe, 0x1c, 0x86, 0xfe, 0x16, 0xed, 0xdd, 0x1a, 0x23, 0x55, 0x8a, 0xf9, 0x89,
-	0x2e, 0x5f, 0xbc, 0x03, 0x6d, 0xab, 0xd4, 0x9c, 0x69, 0x0e, 0xd5, 0x32, 0xf5, 0x5e, 0xaf, 0xae,
-	0x4b, 0x4d, 0xf7, 0xcb, 0xb0, 0xec, 0xd4, 0x8c, 0x1b, 0xcd, 0xa8, 0xab, 0x48, 0x37, 0x9a, 0x51,
-	0x5f, 0x66, 0xfe, 0x0d, 0x68, 0x5b, 0x15, 0xde, 0xcc, 0x2a, 0xe8, 0x28, 0xd5, 0x76, 0x9b, 0x19,
-	0xd5, 0x15, 0x84, 0x6f, 0xd2, 0x7a, 0x57, 0xfc, 0x16, 0xae, 0x97, 0x6a, 0xf8, 0x50, 0x48, 0xbe,
-	0x05, 0x2b, 0x6e, 0xcd, 0xb7, 0xd1, 0xaa, 0xda, 0xea, 0x71, 0xa3, 0x55, 0xa7, 0x14, 0x8a, 0x2b,
-	0x81, 0xbc, 0xba, 0x61, 0x06, 0xd9, 0xfe, 0x4c, 0x65, 0xf8, 0x9f, 0xb1, 0xaf, 0xa1, 0xe9, 0x50,
-	0x45, 0x95, 0xac, 0xa8, 0x74, 0x77, 0x4b, 0x2f, 0x8d, 0xb4, 0x57, 0xea, 0x2f, 0xfd, 0x75, 0x62,
-	0xde, 0x66, 0xc5, 0x0a, 0xa4, 0x85, 0xa6, 0xe2, 0x4a, 0xcb, 0x42, 0xdb, 0xf5, 0x97, 0x96, 0x85,
-	0x76, 0x6a, 0x30, 0xcb, 0x16, 0x5a, 0x44, 0xc8, 0x23, 0x86, 0xd5, 0xd2, 0x23, 0xae, 0x51, 0x96,
-	0xfa, 0x12, 0x90, 0xde, 0xc5, 0xe7, 0xbf, 0xfd, 0xba, 0x66, 0x46, 0x9b, 0x97, 0x6d, 0x5d, 0xb1,
-	0xf3, 0x5b, 0xd0, 0xb1, 0x6b, 0x75, 0x8d, 0xcd, 0xae, 0xa9, 0x30, 0x36, 0x36, 0xbb, 0xae, 0xb8,
-	0x57, 0x1f, 0x2e, 0xeb, 0xd8, 0xc3, 0xb0, 0x6f, 0xc0, 0xaa, 0x55, 0x2e, 0xb0, 0x3f, 0x8b, 0x07,
-	0x46, 0x78, 0xaa, 0x05, 0x5e, 0xbd, 0x3a, 0xff, 0xcc, 0x3f, 0x47, 0x8c, 0xd7, 0x7d, 0x87, 0x31,
-	0x0a, 0xce, 0x6d, 0x68, 0xdb, 0xa5, 0x08, 0xcf, 0xe1, 0x7b, 0xce, 0xea, 0xb2, 0x6b, 0x9d, 0xae,
-	0x7b, 0xec, 0xcf, 0x3c, 0xe8, 0xd8, 0xa5, 0x83, 0xcc, 0x49, 0x2a, 0x96, 0xf8, 0x74, 0xed, 0x3e,
-	0x9b, 0x91, 0x1f, 0xd0, 0x24, 0x1f, 0x5c, 0xfd, 0xb2, 0xb3, 0xc9, 0x9f, 0x39, 0x7e, 0xfe, 0xb5,
-	0xf2, 0xcf, 0xb0, 0x9e, 0x95, 0x09, 0xec, 0x22, 0xb8, 0x67, 0xd7, 0x3d, 0xf6, 0x9e, 0xfc, 0xa9,
-	0x9e, 0x8e, 0xd1, 0x99, 0x65, 0xdc, 0xca, 0x5b, 0x66, 0xff, 0xaa, 0xed, 0x8a, 0x77, 0xdd, 0x63,
-	0xdf, 0x94, 0xbf, 0xb6, 0x52, 0xdf, 0xd2, 0xce, 0xbf, 0xec, 0xf7, 0xfe, 0x1b, 0xb4, 0x9a, 0x8b,
-	0xfe, 0x79, 0x67, 0x35, 0x65, 0xeb, 0xbe, 0x07, 0x50, 0x24, 0x5c, 0x58, 0x29, 0xfb, 0x60, 0xec,
-	0x5e, 0x35, 0x27, 0xe3, 0x9e, 0xa8, 0x4e, 0x52, 0x20, 0xc7, 0x8f, 0xa5, 0x30, 0x2a, 0xfa, 0xdc,
-	0x1c, 0x69, 0x35, 0x71, 0xd2, 0xeb, 0xd5, 0x75, 0xd5, 0x89, 0xa2, 0xe6, 0xcf, 0x1e, 0xc3, 0xf2,
-	0x83, 0x24, 0x79, 0x32, 0x4d, 0x4d, 0x12, 0xcf, 0x8d, 0xff, 0x77, 0xc3, 0xfc, 0xa8, 0x57, 0x5a,
-	0x85, 0x7f, 0x89, 0x58, 0xf5, 0x58, 0xd7, 0x62, 0xb5, 0xfd, 0x59, 0x91, 0xee, 0x79, 0xc6, 0x42,
-	0x58, 0x37, 0x77, 0x9c, 0x99, 0x78, 0xcf, 0x65, 0x63, 0x67, 0x5d, 0x2a, 0x43, 0x38, 0x5e, 0x87,
-	0x9e, 0xed, 0x76, 0xae, 0x79, 0x5e, 0xf7, 0xd8, 0x1e, 0x74, 0xee, 0xf0, 0x41, 0x32, 0xe4, 0x2a,
-	0x62, 0xdf, 0x28, 0x26, 0x6e, 0x42, 0xfd, 0xde, 0xb2, 0x03, 0xba, 0x5a, 0x9f, 0x86, 0xb3, 0x8c,
-	0x7f, 0xb2, 0xfd, 0x99, 0xca, 0x05, 0x3c, 0xd3, 0x5a, 0xaf, 0xf3, 0x17, 0x8e, 0xd6, 0x97, 0x12,
-	0x1e, 0x8e, 0xd6, 0x57, 0x12, 0x1e, 0xce, 0x56, 0xeb, 0xfc, 0x09, 0x1b, 0xc3, 0x7a, 0x25, 0x47,
-	0x62, 0x6e, 0xca, 0xd3, 0x32, 0x2b, 0xbd, 0x4b, 0xa7, 0x13, 0xb8, 0xa3, 0x5d, 0x75, 0x47, 0xdb,
-	0x87, 0xe5, 0x3b, 0x5c, 0x6e, 0x96, 0x7c, 0x18, 0xeb, 0xb9, 0x66, 0xc4, 0x7e, 0x44, 0x2b, 0x9b,
-	0x18, 0xea, 0x73, 0xcd, 0x3a, 0xbd, 0x4a, 0xb1, 0x8f, 0xa1, 0x7d, 0x9f, 0x0b, 0xfd, 0x12, 0x66,
-	0xfc, 0x8d, 0xd2, 0xd3, 0x58, 0xaf, 0xe6, 0x21, 0xcd, 0x95, 0x19, 0xe2, 0xb6, 0xcd, 0x87, 0x23,
-	0x2e, 0x95, 0xbd, 0x1f, 0x0d, 0x9f, 0xb1, 0x5f, 0x27, 0xe6, 0xe6, 0xf1, 0x7c, 0xcb, 0x7a, 0x40,
-	0xb1, 0x99, 0xaf, 0x96, 0xf0, 0x3a, 0xce, 0x71, 0x32, 0xe4, 0xd6, 0x05, 0x17, 0x43, 0xdb, 0xaa,
-	0x94, 0x30, 0x0a, 0x54, 0xad, 0xce, 0x30, 0x0a, 0x54, 0x53, 0x58, 0xe1, 0x5f, 0xa1, 0x71, 0x7c,
-	0x76, 0xa9, 0x18, 0x47, 0x16, 0x53, 0x14, 0x23, 0x6d, 0x7f, 0x16, 0x4e, 0xc4, 0x33, 0xf6, 0x11,
-	0xfd, 0xda, 0xc0, 0x7e, 0xed, 0x2b, 0xfc, 0x9d, 0xf2, 0xc3, 0xa0, 0xd9, 0x2c, 0xab, 0xcb, 0xf5,
-	0x81, 0xe4, 0x50, 0x74, 0x0f, 0x7e, 0x11, 0x60, 0x5f, 0x24, 0xe9, 0x9d, 0x90, 0x4f, 0x92, 0xb8,
-	0xb0, 0x5c, 0xc5, 0x8b, 0x56, 0x61, 0xb9, 0xac, 0x67, 0x2d, 0xf6, 0x91, 0xe5, 0x71, 0x3a, 0x8f,
-	0xa5, 0x5a, 0xb8, 0x4e, 0x7d, 0xf4, 0x32, 0x1b, 0x52, 0xf3, 0xf0, 0x75, 0xdd, 0x43, 0xff, 0xb1,
-	0xc8, 0xc8, 0x19, 0xff, 0xb1, 0x92, 0xec, 0x33, 0x66, 0xaf, 0x26, 0x7d, 0xb7, 0x07, 0xad, 0x22,
-	0x2d, 0xa4, 0xaf, 0xa4, 0x72, 0x12, 0xc9, 0xdc, 0x31, 0x95, 0x64, 0x8d, 0xbf, 0x46, 0x5b, 0x05,
-	0x6c, 0x09, 0xb7, 0x8a, 0x32, 0x30, 0x11, 0x6c, 0xc8, 0x09, 0x9a, 0x0b, 0x93, 0xde, 0x68, 0xf4,
-	0x4a, 0x6a, 0x12, 0x26, 0x46, 0x9b, 0x6b, 0xf3, 0x0d, 0x4e, 0x6c, 0x87, 0xd2, 0x2a, 0xdf, 0x87,
-	0xd0, 0x34, 0x4f, 0x60, 0xbd, 0x12, 0x2c, 0x1b, 0x95, 0x3e, 0x2d, 0x47, 0x61, 0x54, 0xfa, 0xd4,
-	0x38, 0xdb, 0x3f, 0x4b, 0x43, 0xae, 0xfa, 0x80, 0x43, 0xe6, 0x27, 0x91, 0x18, 0x1c, 0xbd, 0xe7,
-	0x5d, 0x3d, 0x58, 0xa0, 0x7f, 0x06, 0xf1, 0xf9, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x49, 0x75,
-	0xdd, 0x94, 0x3e, 0x42, 0x00, 0x00,
+	// 9149 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbd, 0x7d, 0x4b, 0x90, 0x24, 0x47,
+	0x96, 0x90, 0x32, 0xeb, 0xef, 0x99, 0xf5, 0x8b, 0xfa, 0x74, 0x75, 0xaa, 0xa5, 0x96, 0x62, 0xb4,
+	0x92, 0xb6, 0x47, 0xdb, 0x2d, 0xf5, 0xcc, 0x8a, 0x61, 0xb4, 0xb3, 0x33, 0xd5, 0x55, 0xd9, 0xea,
+	0xde, 0xa9, 0x6e, 0xd5, 0x44, 0x55, 0x8f, 0x18, 0x60, 0x49, 0x45, 0x65, 0x46, 0x55, 0xc5, 0x74,
+	0x66, 0x46, 0x4e, 0x46, 0x64, 0xb7, 0x4a, 0xa2, 0x31, 0xdb, 0xc5, 0x80, 0xc3, 0xee, 0x1a, 0x98,
+	0xf1, 0x5d, 0x30, 0x6c, 0xb1, 0xdd, 0x0b, 0x18, 0xd8, 0x82, 0x19, 0x66, 0x5c, 0xc0, 0x58, 0x33,
+	0x4e, 0x5c, 0x30, 0x0e, 0xcb, 0x01, 0x6e, 0xac, 0x01, 0x27, 0x38, 0xc0, 0x91, 0xbd, 0xc0, 0xfb,
+	0xb9, 0x87, 0x7b, 0x44, 0x64, 0x75, 0xcd, 0x07, 0x2e, 0x5d, 0xe9, 0xcf, 0x5f, 0xf8, 0xe7, 0xf9,
+	0xf3, 0xf7, 0xf3, 0xe7, 0xde, 0x6a, 0x69, 0x3c, 0xea, 0xde, 0x1e, 0x8d, 0x93, 0x2c, 0xf1, 0xe6,
+	0xfa, 0x43, 0x28, 0xb4, 0x6e, 0x9c, 0x25, 0xc9, 0x59, 0x3f, 0xba, 0x13, 0x8e, 0xe2, 0x3b, 0xe1,
+	0x70, 0x98, 0x64, 0x61, 0x16, 0x27, 0xc3, 0x94, 0x91, 0xfc, 0xcf, 0xd4, 0xca, 0xc7, 0xd1, 0xf0,
+	0x28, 0x8a, 0x7a, 0x41, 0xf4, 0xa3, 0x49, 0x94, 0x66, 0xde, 0x57, 0xd5, 0x7a, 0x18, 0x7d, 0x01,
+	0x80, 0xce, 0x28, 0x4c, 0xd3, 0xd1, 0xf9, 0x38, 0x4c, 0xa3, 0x9d, 0xda, 0x1b, 0xb5, 0x77, 0x9b,
+	0xc1, 0x1a, 0x57, 0x1c, 0x1a, 0xb8, 0xf7, 0xa6, 0x6a, 0xa6, 0x88, 0x1a, 0x0d, 0xb3, 0x71, 0x32,
+	0xba, 0xd8, 0xa9, 0x13, 0x5e, 0x03, 0x61, 0x6d, 0x06, 0xf9, 0x7d, 0xb5, 0x6a, 0x7a, 0x48, 0x47,
+	0xd0, 0x73, 0xe4, 0xbd, 0xaf, 0x36, 0xbb, 0xf1, 0xe8, 0x3c, 0x1a, 0x77, 0xe8, 0xe3, 0xc1, 0x30,
+	0x1a, 0x24, 0xc3, 0xb8, 0x0b, 0xbd, 0xcc, 0xbc, 0xbb, 0x14, 0x78, 0x5c, 0x87, 0x5f, 0x3c, 0x92,
+	0x1a, 0xef, 0x1d, 0xb5, 0x1a, 0x0d, 0x19, 0x0e, 0x1f, 0xe0, 0x57, 0xd2, 0xd5, 0x4a, 0x0e, 0xc6,
+	0x0f, 0xfc, 0xbf, 0x57, 0x53, 0xeb, 0x0f, 0x87, 0x71, 0xf6, 0x69, 0xd8, 0xef, 0x47, 0x99, 0x9e,
+	0x13, 0x7c, 0xfe, 0x9c, 0x00, 0x34, 0xa7, 0xe7, 0xc9, 0xb8, 0x27, 0x33, 0x5a, 0x61, 0xf0, 0xa1,
+	0x40, 0xa7, 0x8e, 0xac, 0x3e, 0x75, 0x64, 0x95, 0xe4, 0x9a, 0xa9, 0x26, 0x97, 0xbf, 0xa9, 0x3c,
+	0x7b, 0x70, 0x4c, 0x0e, 0xff, 0x97, 0xd5, 0xc6, 0x93, 0x61, 0x3f, 0xe9, 0x3e, 0xfd, 0xc9, 0x06,
+	0xed, 0x6f, 0xab, 0x4d, 0xf7, 0x7b, 0x69, 0xf7, 0xb7, 0xeb, 0xaa, 0x71, 0x3c, 0x0e, 0x87, 0x69,
+	0xd8, 0xc5, 0x25, 0xf7, 0x76, 0xd4, 0x42, 0xf6, 0x79, 0xe7, 0x3c, 0x4c, 0xcf, 0xa9, 0xa1, 0xa5,
+	0x40, 0x17, 0xbd, 0x6d, 0x35, 0x1f, 0x0e, 0x92, 0xc9, 0x30, 0x23, 0xaa, 0xce, 0x04, 0x52, 0xf2,
+	0xde, 0x53, 0xeb, 0xc3, 0xc9, 0xa0, 0xd3, 0x4d, 0x86, 0xa7, 0xf1, 0x78, 0xc0, 0x8c, 0x43, 0x93,
+	0x9b, 0x0b, 0xca, 0x15, 0xde, 0xeb, 0x4a, 0x9d, 0xe0, 0x30, 0xb8, 0x8b, 0x59, 0xea, 0xc2, 0x82,
+	0x78, 0xbe, 0x6a, 0x4a, 0x29, 0x8a, 0xcf, 0xce, 0xb3, 0x9d, 0x39, 0x6a, 0xc8, 0x81, 0x61, 0x1b,
+	0x59, 0x3c, 0x88, 0x3a, 0x69, 0x16, 0x0e, 0x46, 0x3b, 0xf3, 0x34, 0x1a, 0x0b, 0x42, 0xf5, 0xc0,
+	0xc2, 0xfd, 0xce, 0x69, 0x14, 0xa5, 0x3b, 0x0b, 0x52, 0x6f, 0x20, 0xde, 0xdb, 0x6a, 0xa5, 0x07,
+	0xc4, 0xeb, 0x84, 0xbd, 0xde, 0x38, 0x4a, 0x53, 0xc0, 0x59, 0xa4, 0xa5, 0x2b, 0x40, 0xfd, 0x1d,
+	0xb5, 0xfd, 0x71, 0x94, 0x59, 0xd4, 0x49, 0x85, 0xec, 0xfe, 0x81, 0xf2, 0x2c, 0xf0, 0x7e, 0x94,
+	0x85, 0x71, 0x3f, 0xf5, 0x3e, 0x54, 0xcd, 0xcc, 0x42, 0x26, 0x56, 0x6d, 0xdc, 0xf5, 0x6e, 0xd3,
+	0x1e, 0xbb, 0x6d, 0x7d, 0x10, 0x38, 0x78, 0xfe, 0x1f, 0xd7, 0x54, 0xe3, 0x28, 0x1a, 0x9a, 0xdd,
+	0xe5, 0xa9, 0x59, 0x1c, 0x89, 0xac, 0x24, 0xfd, 0xf6, 0x6e, 0xaa, 0x06, 0x8d, 0x2e, 0xcd, 0xc6,
+	0xf1, 0xf0, 0x8c, 0x96, 0x00, 0x08, 0x87, 0xa0, 0x23, 0x82, 0x78, 0x6b, 0x6a, 0x26, 0x1c, 0x64,
+	0x44, 0xf8, 0x99, 0x00, 0x7f, 0xe2, 0xbe, 0x1b, 0x85, 0x17, 0x03, 0xd8, 0x76, 0x39, 0xb1, 0x61,
+	0xdf, 0x09, 0xec, 0x01, 0x52, 0xfb, 0xb6, 0xda, 0xb0, 0x51, 0x74, 0xeb, 0x73, 0xd4, 0xfa, 0xba,
+	0x85, 0x29, 0x9d, 0x00, 0xbb, 0x69, 0xfc, 0x31, 0x0f, 0x96, 0xc8, 0x0f, 0xa4, 0x13, 0xb0, 0x9e,
+	0xc2, 0xbb, 0x6a, 0xed, 0x34, 0x1e, 0x02, 0xc1, 0xbb, 0xfd, 0xec, 0x59, 0xa7, 0x17, 0xf5, 0xb3,
+	0x90, 0x16, 0x62, 0x2e, 0x58, 0x21, 0xf8, 0x1e, 0x80, 0xf7, 0x11, 0xea, 0xff, 0x8d, 0x9a, 0x6a,
+	0xf2, 0xe4, 0x65, 0xe3, 0xbf, 0xa5, 0x96, 0x75, 0x1f, 0xd1, 0x78, 0x9c, 0x8c, 0x85, 0x0f, 0x5d,
+	0xa0, 0x77, 0x4b, 0xad, 0x69, 0xc0, 0x68, 0x1c, 0xc5, 0x83, 0xf0, 0x2c, 0x92, 0xdd, 0x5e, 0x82,
+	0x7b, 0x77, 0xf3, 0x16, 0xc7, 0xc9, 0x24, 0xe3, 0xad, 0xd7, 0xb8, 0xdb, 0x94, 0x85, 0x09, 0x10,
+	0x16, 0xb8, 0x28, 0xfe, 0xef, 0xc2, 0xb0, 0xf6, 0xce, 0x41, 0x16, 0x46, 0xfd, 0xc3, 0x24, 0x06,
+	0x36, 0x7f, 0x5f, 0x79, 0xa7, 0x93, 0x61, 0x0f, 0xa8, 0xd0, 0xc9, 0x3e, 0x8f, 0x7b, 0x9d, 0x93,
+	0x8b, 0x2c, 0x4a, 0x79, 0x89, 0x1e, 0xbc, 0x12, 0x54, 0xd4, 0xc1, 0xc6, 0x58, 0x73, 0xa0, 0x40,
+	0x5c, 0x5e, 0x37, 0xc0, 0x2f, 0xd5, 0x20, 0xe3, 0x43, 0xc7, 0xa3, 0x49, 0xd6, 0x89, 0x87, 0xbd,
+	0xe8, 0x73, 0x1a, 0xe3, 0x72, 0xe0, 0xc0, 0xee, 0xad, 0xa8, 0xa6, 0xfd, 0x1d, 0x08, 0x85, 0xb5,
+	0x03, 0xdc, 0x11, 0x43, 0x80, 0xec, 0x32, 0xdb, 0xe2, 0x36, 0x1d, 0x4d, 0x4e, 0x9e, 0x46, 0x17,
+	0x42, 0x37, 0x29, 0x21, 0x53, 0x9d, 0x27, 0x69, 0x26, 0x9c, 0x43, 0xbf, 0xfd, 0xff, 0x52, 0x53,
+	0xab, 0x48, 0xfb, 0x47, 0xe1, 0xf0, 0x42, 0xaf, 0xdc, 0x81, 0x6a, 0x62, 0x53, 0xc7, 0xc9, 0x2e,
+	0x6f, 0x76, 0x66, 0xe2, 0x77, 0x85, 0x56, 0x05, 0xec, 0xdb, 0x36, 0x2a, 0x0a, 0xf3, 0x8b, 0xc0,
+	0xf9, 0x1a, 0xd9, 0x36, 0x0b, 0xc7, 0x67, 0x20, 0x9f, 0x50, 0x0c, 0x88, 0x58, 0x50, 0x0c, 0xda,
+	0x03, 0x88, 0xf7, 0x06, 0x28, 0x87, 0x10, 0xd6, 0x0a, 0xa4, 0x29, 0x52, 0x8d, 0x58, 0x0f, 0x76,
+	0x2b, 0xc0, 0x0e, 0xa3, 0xf1, 0x3d, 0x80, 0xb4, 0xbe, 0xad, 0xd6, 0x4b, 0xbd, 0x20, 0xb7, 0xe7,
+	0x53, 0xc4, 0x9f, 0xde, 0xa6, 0x9a, 0x7b, 0x16, 0xf6, 0x27, 0x91, 0x48, 0x27, 0x2e, 0x7c, 0xb3,
+	0xfe, 0x8d, 0x9a, 0xff, 0xb6, 0x5a, 0xcb, 0x87, 0x2d, 0x4c, 0x06, 0xd4, 0x40, 0x0a, 0x4a, 0x03,
+	0xf4, 0xdb, 0xff, 0xb5, 0x1a, 0x23, 0xee, 0xc1, 0x7a, 0xa7, 0xd6, 0x5e, 0x44, 0x81, 0xa0, 0x11,
+	0xf1, 0xf7, 0x54, 0x49, 0xf8, 0xd3, 0x4f, 0xd6, 0x7f, 0x47, 0xad, 0x5b, 0x43, 0xb8, 0x64, 0xb0,
+	0xbf, 0x05, 0x3a, 0xec, 0x71, 0xf4, 0x5c, 0x56, 0x5d, 0x8f, 0xf6, 0x1b, 0x80, 0x79, 0x31, 0x62,
+	0x55, 0xbc, 0x72, 0xf7, 0x2d, 0x59, 0xb4, 0x12, 0xde, 0x6d, 0x29, 0x1e, 0x03, 0x6e, 0x40, 0x5f,
+	0x00, 0x2b, 0x35, 0x2c, 0xa0, 0x77, 0x4d, 0x6d, 0x7c, 0xfa, 0xf0, 0xf8, 0x71, 0xfb, 0xe8, 0xa8,
+	0x73, 0xf8, 0xe4, 0xde, 0x77, 0xdb, 0x3f, 0xe8, 0x3c, 0xd8, 0x3d, 0x7a, 0xb0, 0xf6, 0x0a, 0xcc,
+	0xdd, 0x03, 0xe8, 0x71, 0x7b, 0xdf, 0x81, 0xd7, 0xfc, 0x96, 0xda, 0x81, 0x6e, 0x3e, 0x8d, 0xb3,
+	0x21, 0x34, 0xe1, 0xf6, 0xe6, 0xdf, 0x86, 0x6f, 0xac, 0x21, 0xc8, 0xac, 0x40, 0xd3, 0x88, 0xa8,
+	0xd5, 0x9a, 0x46, 0x8a, 0xb0, 0x60, 0xde, 0x51, 0x7c, 0x36, 0x7c, 0x04, 0xbf, 0x61, 0xfb, 0xea,
+	0xb9, 0xc1, 0x92, 0x0f, 0xd2, 0x33, 0x11, 0x8a, 0xf8, 0xd3, 0xff, 0x9a, 0xda, 0x70, 0xf0, 0xa4,
+	0xe1, 0x1b, 0x6a, 0x29, 0x05, 0x70, 0x98, 0x4d, 0xc6, 0x91, 0x34, 0x9d, 0x03, 0xfc, 0xfb, 0x6a,
+	0xf3, 0xfb, 0xd1, 0x38, 0x3e, 0xbd, 0x78, 0x59, 0xf3, 0x6e, 0x3b, 0xf5, 0x62, 0x3b, 0x6d, 0xb5,
+	0x55, 0x68, 0x47, 0xba, 0x67, 0x46, 0x94, 0xe5, 0x5a, 0x0c, 0xb8, 0x60, 0x6d, 0xcb, 0xba, 0xbd,
+	0x2d, 0xfd, 0x27, 0xca, 0x03, 0xd6, 0x18, 0x46, 0x5d, 0x60, 0x81, 0x68, 0x9c, 0xdb, 0x57, 0x39,
+	0xd7, 0x35, 0xee, 0x5e, 0x93, 0x75, 0x2c, 0xee, 0x75, 0x61, 0x47, 0x60, 0x0f, 0xe0, 0xa8, 0x01,
+	0x35, 0xbc, 0x18, 0xd0, 0x6f, 0x7f, 0x4b, 0x6d, 0x38, 0xcd, 0x8a, 0xb6, 0xff, 0x40, 0x6d, 0xed,
+	0xc7, 0x69, 0xb7, 0xdc, 0x21, 0x2c, 0x06, 0x0c, 0xa8, 0x93, 0xef, 0x29, 0x5d, 0x44, 0x25, 0x58,
+	0xfc, 0x44, 0x1a, 0xfb, 0xcb, 0x35, 0x35, 0xfb, 0xe0, 0xf8, 0x60, 0xcf, 0x6b, 0xa9, 0xc5, 0x78,
+	0xd8, 0x4d, 0x06, 0xa8, 0x3a, 0x78, 0xd2, 0xa6, 0x3c, 0x75, 0xaf, 0x00, 0x71, 0x49, 0xe3, 0xa0,
+	0x5e, 0x17, 0x53, 0x28, 0x07, 0xa0, 0x4d, 0x11, 0x7d, 0x3e, 0x8a, 0xc7, 0x64, 0x34, 0x68, 0x53,
+	0x60, 0x96, 0x24, 0x62, 0xb9, 0xc2, 0xff, 0x17, 0x73, 0x6a, 0x41, 0x64, 0x35, 0xf5, 0x07, 0x6a,
+	0xf5, 0x59, 0x24, 0x23, 0x91, 0x12, 0x6a, 0x95, 0x31, 0x58, 0x63, 0x59, 0xd4, 0x71, 0x96, 0xc1,
+	0x05, 0x22, 0x56, 0x97, 0x1b, 0xea, 0x8c, 0x50, 0xea, 0xd3, 0xc8, 0x00, 0xcb, 0x01, 0x22, 0xb1,
+	0x10, 0xd0, 0x81, 0x35, 0xc6, 0x31, 0xcd, 0x06, 0xba, 0x88, 0x94, 0xe8, 0x86, 0xa3, 0xb0, 0x1b,
+	0x67, 0x17, 0xb2, 0xb9, 0x4d, 0x19, 0xdb, 0x86, 0xb9, 0x81, 0x4a, 0x3c, 0x09, 0xfb, 0xe1, 0xb0,
+	0x1b, 0x89, 0xe1, 0xe2, 0x02, 0xd1, 0x36, 0x91, 0x21, 0x69, 0x34, 0xb6, 0x5f, 0x0a, 0x50, 0xb4,
+	0x71, 0x80, 0xc2, 0x83, 0x38, 0x43, 0x93, 0x06, 0xec, 0x17, 0x12, 0x24, 0x39, 0x84, 0x66, 0xc2,
+	0xa5, 0xe7, 0x4c, 0xbd, 0x25, 0xee, 0xcd, 0x01, 0x62, 0x2b, 0x80, 0x4c, 0x02, 0xe9, 0xe9, 0xf3,
+	0x1d, 0xc5, 0xad, 0xe4, 0x10, 0x5c, 0x87, 0x09, 0x2c, 0x75, 0x96, 0xf5, 0xc1, 0x76, 0xd5, 0x03,
+	0x6a, 0x10, 0x5a, 0xb9, 0x02, 0x54, 0xe4, 0x06, 0x5b, 0x59, 0x20, 0xd0, 0x92, 0xf4, 0x3c, 0x4e,
+	0xc1, 0x40, 0x06, 0x1a, 0x36, 0x09, 0xbf, 0xaa, 0x0a, 0xe4, 0xd5, 0xb5, 0x02, 0x78, 0x1c, 0x75,
+	0x23, 0x58, 0xaf, 0xde, 0xce, 0x32, 0x7d, 0x35, 0xad, 0x1a, 0x44, 0x69, 0x03, 0x8d, 0xcb, 0xc9,
+	0xa8, 0x17, 0xa2, 0x1e, 0x5e, 0xa1, 0x75, 0xb0, 0x41, 0xde, 0x07, 0xa0, 0xf5, 0x23, 0x56, 0x96,
+	0xe7, 0x59, 0xbf, 0x9b, 0xee, 0xac, 0x92, 0x26, 0x6b, 0xc8, 0x66, 0x42, 0xce, 0x0d, 0x5c, 0x0c,
+	0x64, 0xca, 0x6e, 0x4a, 0xe6, 0x4a, 0x78, 0xb1, 0xb3, 0x46, 0xec, 0x96, 0x03, 0x68, 0x8f, 0x8c,
+	0xe3, 0x67, 0xd0, 0xf8, 0xce, 0x3a, 0xf1, 0x96, 0x2e, 0xe2, 0x96, 0xef, 0x87, 0x27, 0x51, 0x7f,
+	0xc7, 0x23, 0x76, 0xe1, 0x02, 0x0e, 0x31, 0x3b, 0x0f, 0x9f, 0x6b, 0xf6, 0xdd, 0xa0, 0xf6, 0x6c,
+	0x90, 0xff, 0x3b, 0x35, 0xb5, 0x71, 0x10, 0xa7, 0x99, 0x30, 0xaf, 0x11, 0xe3, 0xa0, 0x48, 0x98,
+	0x6d, 0x3b, 0xc9, 0xb0, 0x7f, 0x21, 0x9c, 0xac, 0x18, 0xf4, 0x09, 0x40, 0xbc, 0xaf, 0xa8, 0x65,
+	0xb0, 0xa2, 0x2c, 0x14, 0xde, 0xfb, 0x4d, 0x0d, 0x24, 0x24, 0x68, 0x05, 0xd8, 0xba, 0x1f, 0x77,
+	0x19, 0x65, 0x86, 0x5b, 0x61, 0x10, 0x21, 0xa0, 0x81, 0xc8, 0x33, 0x60, 0x8c, 0x59, 0xc2, 0x68,
+	0x08, 0x0c, 0x51, 0xfc, 0x7b, 0x6a, 0xd3, 0x1d, 0xa0, 0x08, 0xb9, 0x5b, 0xc0, 0xe8, 0x02, 0x03,
+	0x7e, 0x40, 0xba, 0xae, 0x08, 0x5d, 0x05, 0x35, 0x30, 0xf5, 0xfe, 0xef, 0xd7, 0xd5, 0x2c, 0x0a,
+	0x8e, 0xe9, 0x42, 0xc6, 0xd6, 0x05, 0x33, 0x8e, 0x2e, 0x20, 0x7f, 0x01, 0xad, 0x29, 0x66, 0x25,
+	0xde, 0x6e, 0x16, 0x24, 0xaf, 0x07, 0xce, 0x78, 0x46, 0x7b, 0xce, 0xd4, 0x23, 0x04, 0x77, 0x24,
+	0xaa, 0x5c, 0xfa, 0x9a, 0x37, 0x9c, 0x29, 0xeb, 0x3a, 0xfa, 0x72, 0x21, 0xaf, 0xa3, 0xef, 0x60,
+	0x44, 0xf1, 0xf0, 0x04, 0x44, 0x55, 0x8f, 0x36, 0x17, 0x2c, 0xb6, 0x14, 0x91, 0x49, 0x46, 0x64,
+	0x81, 0x81, 0xc3, 0x21, 0xbb, 0x2a, 0x07, 0xd0, 0x8e, 0xea, 0x87, 0x23, 0xb0, 0x00, 0x50, 0xe6,
+	0x29, 0x5a, 0x73, 0x0b, 0x82, 0x66, 0x5e, 0x3f, 0x04, 0x3b, 0x9e, 0x40, 0xc3, 0x54, 0x36, 0x93,
+	0x03, 0xf3, 0x3d, 0x34, 0xeb, 0x52, 0x12, 0xb6, 0x46, 0x87, 0x7e, 0xa8, 0xd6, 0x2d, 0x98, 0xac,
+	0xc2, 0x9b, 0x6a, 0x6e, 0x84, 0x00, 0x31, 0xd2, 0x34, 0x6b, 0x93, 0x94, 0xe6, 0x1a, 0x7f, 0x0d,
+	0x7d, 0xf7, 0xec, 0xe1, 0xf0, 0x34, 0xd1, 0x2d, 0xfd, 0xc1, 0x0c, 0x3a, 0xdb, 0x02, 0x92, 0x86,
+	0xde, 0x55, 0xab, 0x71, 0x0f, 0x48, 0x02, 0x72, 0xaa, 0xe3, 0x58, 0x8f, 0x45, 0x30, 0xb2, 0x3a,
+	0xe8, 0xb3, 0x30, 0x15, 0xf9, 0xc9, 0x05, 0xb0, 0xb0, 0x37, 0x71, 0xeb, 0xe9, 0xdd, 0x64, 0x58,
+	0x83, 0x8d, 0xd8, 0xca, 0x3a, 0x94, 0x16, 0x08, 0x17, 0x2e, 0x36, 0x9f, 0xb0, 0x94, 0xaf, 0xaa,
+	0x42, 0xca, 0x73, 0x4b, 0x38, 0xe5, 0x39, 0xde, 0x9e, 0x06, 0x50, 0xf2, 0x1c, 0xe7, 0xd9, 0x80,
+	0x2e, 0x7a, 0x8e, 0x96, 0xf7, 0xb9, 0x58, 0xf2, 0x3e, 0x81, 0x0e, 0xe9, 0x05, 0x88, 0xb2, 0x5e,
+	0x27, 0x4b, 0xb0, 0xdf, 0x78, 0x48, 0x2b, 0xbc, 0x18, 0x14, 0xc1, 0xe4, 0x27, 0x03, 0x35, 0x87,
+	0x11, 0x2f, 0x32, 0xf0, 0x87, 0x14, 0x51, 0x03, 0x11, 0x0a, 0x6f, 0x0c, 0xd0, 0xf4, 0x5c, 0x42,
+	0x35, 0x3d, 0x19, 0xc7, 0x29, 0x88, 0x43, 0x84, 0xd2, 0x6f, 0xef, 0xeb, 0x6a, 0xeb, 0x04, 0xbd,
+	0xba, 0xf3, 0x28, 0xec, 0x81, 0xc4, 0x45, 0x0e, 0x62, 0xa7, 0x96, 0xa5, 0x5f, 0x75, 0xa5, 0xff,
+	0x05, 0xd9, 0x0c, 0xc6, 0xa9, 0x7e, 0x42, 0x02, 0xcf, 0x7b, 0x55, 0x2d, 0xf1, 0x4c, 0xd2, 0xf3,
+	0x50, 0xcc, 0x98, 0x45, 0x02, 0x1c, 0x9d, 0x87, 0xb8, 0xd5, 0x1d, 0xe2, 0xd4, 0xc9, 0x36, 0x6d,
+	0x10, 0xec, 0x01, 0xd3, 0xe6, 0x2d, 0xb5, 0xa2, 0xdd, 0xf5, 0xb4, 0xd3, 0x8f, 0x4e, 0x33, 0xed,
+	0x82, 0x00, 0x14, 0xbb, 0x4b, 0x0f, 0x00, 0xe6, 0x3f, 0x56, 0xeb, 0xb2, 0xc3, 0x3f, 0x81, 0x15,
+	0x95, 0xae, 0xff, 0x64, 0x51, 0x6d, 0xb2, 0xdd, 0xb2, 0xe1, 0x8a, 0x04, 0xf2, 0xa3, 0x0a, 0xba,
+	0xd4, 0x0f, 0x60, 0x2e, 0x0c, 0xd8, 0xeb, 0x27, 0x69, 0x24, 0x0d, 0xc2, 0x5a, 0x76, 0xa1, 0xa8,
+	0x1d, 0x1d, 0x99, 0x8e, 0x03, 0xc3, 0x15, 0x48, 0x27, 0xdd, 0x2e, 0xca, 0x0c, 0x96, 0x7e, 0xba,
+	0xe8, 0xff, 0x43, 0x10, 0xab, 0xd4, 0x9a, 0x96, 0x45, 0xc6, 0x3a, 0xbe, 0xfa, 0x30, 0x9b, 0x5d,
+	0xdb, 0xf9, 0x03, 0xae, 0x3f, 0x4d, 0xc6, 0xdd, 0x48, 0x7a, 0xe2, 0xc2, 0x8f, 0x6f, 0xef, 0xcf,
+	0x96, 0xec, 0xfd, 0xff, 0x04, 0x66, 0x3c, 0x0d, 0xf5, 0x28, 0x03, 0xb3, 0x32, 0x95, 0xe9, 0xff,
+	0x12, 0x0c, 0x14, 0x81, 0x7a, 0xd3, 0xc8, 0x40, 0x37, 0xcd, 0xfe, 0x26, 0x28, 0x23, 0x83, 0x33,
+	0xe9, 0x22, 0x7b, 0xdf, 0x06, 0xe2, 0x59, 0xec, 0x41, 0x63, 0x6e, 0xdc, 0xbd, 0xae, 0x67, 0x59,
+	0xe2, 0x1c, 0x68, 0xc1, 0xf9, 0xc0, 0xfb, 0x08, 0x6c, 0x0b, 0x34, 0x68, 0xa8, 0x59, 0x71, 0x96,
+	0xaf, 0xbb, 0x44, 0xb2, 0x16, 0x0b, 0x3e, 0xb7, 0xd0, 0xef, 0x2d, 0xaa, 0x79, 0xd6, 0xc0, 0xfe,
+	0xc7, 0x6a, 0xd9, 0x19, 0xa9, 0xe3, 0xc7, 0x34, 0xd9, 0x8f, 0x29, 0xb9, 0xbd, 0xf5, 0xb2, 0xdb,
+	0xeb, 0xff, 0x95, 0x19, 0xe5, 0x21, 0xb7, 0x15, 0x96, 0x13, 0x4d, 0x80, 0xa4, 0xe7, 0x18, 0x74,
+	0xcd, 0xc0, 0x06, 0x79, 0xe0, 0x78, 0x58, 0x45, 0x1d, 0xdd, 0x60, 0x0d, 0x53, 0x51, 0x83, 0x62,
+	0x8c, 0xad, 0x31, 0xed, 0x65, 0x8b, 0xe9, 0xca, 0xeb, 0x56, 0x59, 0x87, 0x4a, 0x64, 0x34, 0xc1,
+	0xd0, 0x49, 0x98, 0x69, 0x93, 0x4f, 0x97, 0x8b, 0x0c, 0x32, 0xff, 0x52, 0x06, 0x59, 0x28, 0x32,
+	0x88, 0x6d, 0x74, 0x2c, 0xba, 0x46, 0x07, 0x58, 0x78, 0x60, 0x61, 0x93, 0xe5, 0xd2, 0x19, 0x60,
+	0xef, 0x62, 0xe1, 0x39, 0x40, 0x8c, 0x93, 0x88, 0xe5, 0x98, 0x5b, 0x36, 0xac, 0x95, 0x4a, 0xf0,
+	0xa2, 0xc1, 0xd2, 0x28, 0x1b, 0x2c, 0x7f, 0x08, 0x2e, 0x32, 0xae, 0x84, 0xc3, 0xad, 0xdf, 0x54,
+	0xb4, 0x59, 0xae, 0xc8, 0xac, 0x0e, 0xee, 0x4f, 0xcf, 0xab, 0xdf, 0x00, 0x93, 0x0d, 0x1b, 0x4c,
+	0xa0, 0x45, 0x61, 0xd5, 0x1d, 0x97, 0x55, 0x73, 0x39, 0x05, 0x1f, 0xe7, 0xc8, 0x16, 0xa3, 0xfe,
+	0xfb, 0x9a, 0x6a, 0xc8, 0x30, 0x7f, 0x62, 0x7f, 0x06, 0xbe, 0x41, 0x9e, 0xb5, 0x9c, 0x06, 0x53,
+	0x46, 0xad, 0x32, 0x40, 0xa7, 0x11, 0xd5, 0xa8, 0xe3, 0xcb, 0x14, 0xc1, 0xa8, 0x13, 0x49, 0x24,
+	0xa7, 0x20, 0xed, 0xfb, 0x1d, 0x5d, 0x2b, 0x41, 0xd0, 0xaa, 0x2a, 0x94, 0x4c, 0xa0, 0x14, 0xce,
+	0x22, 0x51, 0x77, 0x5c, 0x40, 0xa7, 0x4d, 0x26, 0x54, 0x30, 0x2d, 0xfd, 0xbf, 0xd5, 0x50, 0xd7,
+	0x4a, 0x55, 0x26, 0xe4, 0x2e, 0x46, 0x7a, 0x3f, 0x1e, 0x9c, 0x24, 0xc6, 0xde, 0xaf, 0xd9, 0xf6,
+	0xbb, 0x53, 0xe5, 0x9d, 0xa9, 0x2d, 0xad, 0xd7, 0x91, 0xa6, 0xb9, 0x16, 0xaf, 0x93, 0x41, 0xf2,
+	0x81, 0xcb, 0x03, 0xc5, 0x0e, 0x35, 0xdc, 0xde, 0xdb, 0xd5, 0xed, 0x79, 0xe7, 0x6a, 0xc7, 0x18,
+	0x10, 0xa2, 0x04, 0x2c, 0x23, 0x03, 0xfb, 0x7a, 0xef, 0x25, 0x7d, 0x91, 0xc4, 0xea, 0xe9, 0x6e,
+	0xa6, 0xb6, 0xe6, 0x5d, 0xa8, 0xd7, 0x75, 0x1d, 0x49, 0xf9, 0x72, 0x7f, 0xb3, 0x57, 0x9a, 0xdb,
+	0x7d, 0xfc, 0xd8, 0xed, 0xf4, 0x25, 0x0d, 0xb7, 0xfe, 0x73, 0x4d, 0xad, 0xb8, 0xcd, 0x21, 0xeb,
+	0xc8, 0x36, 0xd5, 0xe2, 0x4a, 0x1b, 0x66, 0x05, 0x70, 0xd9, 0x75, 0xad, 0x57, 0xb9, 0xae, 0xb6,
+	0x83, 0x3a, 0xf3, 0x32, 0x07, 0x75, 0xf6, 0x6a, 0x0e, 0xea, 0x5c, 0xa5, 0x83, 0x6a, 0x7c, 0xa2,
+	0x79, 0xcb, 0x27, 0x6a, 0xfd, 0xe3, 0xba, 0xf2, 0xca, 0xab, 0xee, 0x7d, 0xcc, 0x1e, 0x35, 0xfc,
+	0x14, 0xe9, 0xf1, 0x0b, 0x57, 0xe3, 0x1c, 0x4d, 0x59, 0xfd, 0x35, 0xb2, 0xb0, 0x2d, 0x1e, 0x6c,
+	0x73, 0x07, 0x8c, 0xca, 0x8a, 0xaa, 0x82, 0x23, 0x3d, 0xfb, 0x72, 0x47, 0x7a, 0xee, 0xe5, 0x8e,
+	0xf4, 0x7c, 0xc9, 0x91, 0x06, 0x43, 0x4f, 0xeb, 0x0d, 0x8a, 0x5f, 0x5c, 0x74, 0x78, 0x33, 0x4b,
+	0x50, 0xbc, 0xba, 0xb2, 0xf5, 0xe7, 0xd5, 0xb2, 0xc3, 0x41, 0x3f, 0x3b, 0x3a, 0x15, 0x0d, 0x2c,
+	0x66, 0x16, 0x07, 0xd6, 0xfa, 0xef, 0xb0, 0x56, 0x65, 0x2e, 0xfe, 0xff, 0x3a, 0x06, 0xe2, 0x49,
+	0x47, 0x18, 0xcd, 0x08, 0x4f, 0x3a, 0x62, 0xe8, 0xff, 0xa5, 0x80, 0x7d, 0x4f, 0xad, 0x83, 0x43,
+	0x98, 0x3c, 0xa3, 0x43, 0x45, 0x37, 0x74, 0x53, 0xae, 0x40, 0x13, 0xd3, 0x0d, 0x3a, 0x2c, 0x3a,
+	0x67, 0x40, 0x96, 0x96, 0x29, 0xc4, 0x1e, 0xf0, 0x80, 0x8e, 0x8f, 0xe6, 0xee, 0x71, 0x53, 0x5a,
+	0x60, 0xff, 0xfd, 0x9a, 0xda, 0x2a, 0x54, 0xe4, 0x07, 0x25, 0x2c, 0x93, 0x5d, 0x41, 0xed, 0x02,
+	0x71, 0xfc, 0xc2, 0xf6, 0xd6, 0xf8, 0x59, 0x77, 0x95, 0x2b, 0x90, 0x3e, 0x93, 0x61, 0x19, 0x9f,
+	0xa9, 0x5e, 0x55, 0xe5, 0x5f, 0x53, 0x5b, 0xb2, 0xb2, 0x85, 0x81, 0x9f, 0xaa, 0xed, 0x62, 0x45,
+	0x1e, 0xf9, 0x75, 0x87, 0xac, 0x8b, 0x68, 0x80, 0x39, 0xf2, 0xdf, 0x1d, 0x6f, 0x65, 0x9d, 0xff,
+	0x6b, 0xc0, 0xa6, 0xdf, 0x9b, 0x44, 0xe3, 0x0b, 0x3a, 0xc7, 0x31, 0x31, 0x94, 0x6b, 0xc5, 0x60,
+	0x03, 0x46, 0x5c, 0xbf, 0x1b, 0x5d, 0xe8, 0x83, 0xb2, 0x7a, 0x7e, 0x50, 0xf6, 0x9a, 0x52, 0xe8,
+	0xf9, 0xd0, 0xc1, 0x8f, 0x3e, 0xba, 0x44, 0xc7, 0x92, 0x1b, 0xf4, 0x7e, 0x41, 0x2d, 0xe1, 0x4e,
+	0x06, 0x96, 0x8b, 0x99, 0xaf, 0x1a, 0x77, 0x57, 0x65, 0x3d, 0xef, 0x47, 0xd1, 0x01, 0x82, 0x83,
+	0x1c, 0x03, 0x97, 0x25, 0x3e, 0x1b, 0x26, 0xc8, 0x15, 0x28, 0x9c, 0xd1, 0x53, 0x9d, 0x01, 0xc3,
+	0xd4, 0x05, 0xda, 0x58, 0x51, 0xef, 0x0c, 0xb0, 0xe6, 0x01, 0x6b, 0x36, 0x70, 0x81, 0x28, 0x6c,
+	0xd3, 0x64, 0x82, 0xca, 0x42, 0xcf, 0x65, 0x81, 0x8f, 0xdb, 0x5c, 0xa8, 0xff, 0x91, 0xda, 0x70,
+	0x48, 0x60, 0x38, 0x64, 0x5e, 0x26, 0xc5, 0x01, 0x02, 0xf7, 0xc4, 0x4b, 0xea, 0xfc, 0xff, 0x53,
+	0x53, 0x33, 0x0f, 0x92, 0x91, 0x1d, 0xd6, 0xac, 0xb9, 0x61, 0x4d, 0xd1, 0x2d, 0x1d, 0xa3, 0x3a,
+	0xea, 0x22, 0x03, 0x6d, 0x20, 0x0e, 0x16, 0xa8, 0x89, 0x2e, 0x32, 0xe8, 0xb7, 0xe7, 0xe1, 0xb8,
+	0x27, 0x6c, 0x53, 0x80, 0xe2, 0x02, 0xe4, 0xa2, 0x16, 0x7f, 0xa2, 0x51, 0xc5, 0x82, 0x4f, 0xbc,
+	0x7a, 0x29, 0x21, 0x37, 0xba, 0xdf, 0xb2, 0xa1, 0xcb, 0xbb, 0xaf, 0xaa, 0x0a, 0xf5, 0x1b, 0xae,
+	0x04, 0xa1, 0x49, 0x48, 0x47, 0x97, 0xed, 0xf0, 0xd3, 0xa2, 0x1b, 0xe3, 0xfe, 0xa3, 0x9a, 0x9a,
+	0x23, 0x9a, 0xa0, 0x24, 0xe1, 0xed, 0x43, 0xc7, 0xc9, 0x14, 0x9c, 0xae, 0xb1, 0x24, 0x29, 0x80,
+	0x0b, 0x87, 0xcc, 0xf5, 0xd2, 0x21, 0xf3, 0x0d, 0xb5, 0xc4, 0xa5, 0xfc, 0x54, 0x36, 0x07, 0xc0,
+	0xd7, 0xb3, 0xe7, 0xc9, 0x48, 0xdb, 0x12, 0x4a, 0xc7, 0x24, 0x93, 0x51, 0x40, 0xf0, 0x7c, 0x1c,
+	0xd8, 0x16, 0x4f, 0x87, 0xf5, 0x4e, 0x11, 0x8c, 0x54, 0x37, 0xcd, 0xda, 0xe4, 0x29, 0x40, 0xfd,
+	0x5b, 0x6a, 0xf5, 0x31, 0x70, 0x9e, 0x15, 0x09, 0x9a, 0xba, 0x45, 0xfc, 0x7f, 0x56, 0x53, 0x8b,
+	0x1a, 0x19, 0x86, 0x32, 0x8b, 0x2c, 0x5b, 0x30, 0xeb, 0xcd, 0x59, 0x04, 0xe2, 0x05, 0x84, 0x81,
+	0x02, 0x9d, 0x22, 0x08, 0xb9, 0x11, 0xa8, 0xe3, 0x07, 0xb9, 0x79, 0x65, 0x86, 0x5b, 0x30, 0x43,
+	0x0a, 0x50, 0x70, 0xdd, 0x16, 0xce, 0xe3, 0x34, 0x4b, 0xc6, 0x17, 0x42, 0xa3, 0xea, 0x8e, 0x35,
+	0x92, 0xff, 0x8f, 0x6a, 0x6a, 0xd9, 0xa9, 0x42, 0x6f, 0x86, 0xa2, 0x6a, 0x6c, 0xe4, 0xcb, 0x32,
+	0xda, 0x20, 0x9b, 0x21, 0xea, 0x6e, 0x3c, 0xd2, 0x44, 0xb9, 0x66, 0xec, 0x28, 0xd7, 0xfb, 0x6a,
+	0x29, 0x4f, 0x19, 0x98, 0x75, 0x04, 0x3b, 0xf6, 0xa8, 0x4f, 0x65, 0x72, 0x24, 0x6c, 0xa7, 0x9b,
+	0xf4, 0x93, 0xb1, 0x9c, 0xa8, 0x73, 0x01, 0x76, 0x6b, 0xc3, 0xc2, 0xc7, 0x61, 0x0c, 0xa3, 0xec,
+	0x79, 0x32, 0x7e, 0xaa, 0xc3, 0xa2, 0x52, 0x34, 0x87, 0x8f, 0xf5, 0xfc, 0xf0, 0x11, 0x5d, 0xb0,
+	0x65, 0xe4, 0x55, 0x98, 0xe6, 0x61, 0xd2, 0x8f, 0xbb, 0x17, 0xc4, 0x2b, 0x9a, 0x2d, 0xe5, 0xa8,
+	0x5d, 0xf3, 0xac, 0x0b, 0xc6, 0xdd, 0xa1, 0xbd, 0x43, 0xe1, 0x58, 0x53, 0xc6, 0x3d, 0x8e, 0x3b,
+	0xe5, 0x24, 0x4c, 0x65, 0xfb, 0x88, 0xa6, 0x75, 0x80, 0xb8, 0x23, 0x11, 0x30, 0xc6, 0x98, 0xf1,
+	0x20, 0xee, 0xf7, 0x63, 0xc6, 0xe5, 0xbd, 0x5c, 0x55, 0x45, 0x6e, 0x6a, 0xf8, 0xb9, 0xe5, 0xa6,
+	0x72, 0x8c, 0xd6, 0x05, 0xfa, 0xff, 0xb2, 0xae, 0x1a, 0xa2, 0x2d, 0xda, 0x20, 0xf9, 0xc8, 0x2a,
+	0x13, 0xc3, 0xd5, 0x88, 0x23, 0x0b, 0xa2, 0xeb, 0x1d, 0x53, 0xd7, 0x82, 0x14, 0x17, 0x7f, 0xa6,
+	0xbc, 0xf8, 0x18, 0x4c, 0x84, 0x45, 0xf8, 0x80, 0x6c, 0x6a, 0xce, 0x43, 0xc9, 0x01, 0xba, 0xf6,
+	0x2e, 0xd5, 0xce, 0xe5, 0xb5, 0x04, 0x70, 0xac, 0xe8, 0xf9, 0x82, 0x15, 0xfd, 0x0d, 0xd8, 0x04,
+	0xdc, 0x0c, 0xad, 0x0e, 0x49, 0xa1, 0x9c, 0x7b, 0x9d, 0x95, 0x0b, 0x1c, 0x4c, 0xfd, 0xe5, 0x5d,
+	0xfd, 0xe5, 0xe2, 0xcb, 0xbe, 0xd4, 0x98, 0xfe, 0x3f, 0xaf, 0xab, 0x0d, 0xa1, 0xde, 0xc7, 0xe3,
+	0x70, 0x74, 0xae, 0x37, 0x38, 0x86, 0x58, 0x51, 0xdb, 0xc8, 0x9c, 0x7b, 0x1d, 0x30, 0xac, 0x72,
+	0x87, 0xae, 0xa2, 0x0a, 0xb7, 0x30, 0x72, 0x44, 0x41, 0xd0, 0x3b, 0x30, 0x6c, 0x35, 0x41, 0xd3,
+	0xb7, 0x17, 0xa7, 0xe1, 0x49, 0xdf, 0x28, 0x30, 0x3e, 0x5f, 0xa8, 0xaa, 0x32, 0x91, 0x9a, 0xe4,
+	0xf4, 0x34, 0x8d, 0x32, 0xa1, 0xb6, 0x0d, 0x42, 0x2e, 0x41, 0x31, 0x81, 0x4c, 0xa1, 0x95, 0x26,
+	0xae, 0x98, 0x0b, 0x44, 0xe1, 0x21, 0x2a, 0x49, 0x37, 0x35, 0x4f, 0x9c, 0x51, 0x80, 0xda, 0xad,
+	0xf1, 0xd8, 0x16, 0xdc, 0xd6, 0x08, 0xe8, 0xff, 0x9b, 0x3c, 0xc5, 0x83, 0xa8, 0xe6, 0xdd, 0x52,
+	0x73, 0xdc, 0x79, 0xed, 0x12, 0x89, 0xc3, 0x28, 0xb0, 0xe9, 0xe6, 0xb8, 0xe9, 0xba, 0x23, 0x07,
+	0x2c, 0x1e, 0x0e, 0x18, 0x01, 0x23, 0x30, 0xc4, 0x77, 0x36, 0x05, 0x58, 0xac, 0x94, 0xe0, 0x48,
+	0x5a, 0x82, 0x15, 0x66, 0xc9, 0xc7, 0x1e, 0x55, 0x55, 0x28, 0xd6, 0xb1, 0xcf, 0x82, 0x58, 0x77,
+	0xf5, 0x38, 0x46, 0xa0, 0x87, 0x0f, 0x7b, 0x98, 0x59, 0xf6, 0x98, 0x25, 0x8b, 0x7d, 0x1e, 0xf0,
+	0x17, 0x67, 0x40, 0x1c, 0xe5, 0x60, 0x24, 0xf2, 0x19, 0x92, 0x03, 0x16, 0x31, 0x1c, 0x44, 0x59,
+	0x34, 0x16, 0x69, 0x52, 0x80, 0x92, 0xba, 0x7f, 0x06, 0xb6, 0xd8, 0x04, 0x96, 0x3b, 0x3a, 0x1b,
+	0x47, 0x6c, 0xa5, 0xd5, 0x82, 0x02, 0x14, 0xf1, 0x90, 0xe6, 0x16, 0x1e, 0xef, 0xc6, 0x02, 0x54,
+	0x47, 0xf7, 0x79, 0x05, 0x66, 0xf3, 0xe8, 0x3e, 0xd3, 0xbb, 0xa8, 0x5b, 0xe6, 0x2a, 0x74, 0xcb,
+	0x87, 0x6a, 0x9b, 0xb5, 0x88, 0xc8, 0xcf, 0x4e, 0x61, 0x93, 0x4e, 0xa9, 0xc5, 0x15, 0xc2, 0x31,
+	0x6b, 0xf1, 0x92, 0xc6, 0x5f, 0x70, 0x24, 0xae, 0x16, 0x94, 0xe0, 0x88, 0x4b, 0x9b, 0xc1, 0xc6,
+	0xe5, 0xd3, 0xd7, 0x12, 0x9c, 0x70, 0x61, 0x8e, 0x0e, 0xee, 0x92, 0xe0, 0x16, 0xe0, 0xfe, 0xb2,
+	0x6a, 0x1c, 0x65, 0xa0, 0xfe, 0x65, 0x51, 0x56, 0x54, 0x93, 0x8b, 0x72, 0xd6, 0xfe, 0xaa, 0xba,
+	0x4e, 0x3c, 0x7a, 0x9c, 0xc0, 0x96, 0x4f, 0xce, 0x2e, 0x8e, 0x26, 0x27, 0x69, 0x77, 0x1c, 0x8f,
+	0xd0, 0x93, 0xf5, 0xff, 0x5d, 0x4d, 0x6d, 0x38, 0xb5, 0x12, 0x98, 0xfb, 0x3a, 0x0b, 0x14, 0x73,
+	0x48, 0xca, 0x6c, 0xbd, 0x6e, 0xa9, 0x2c, 0x46, 0xe4, 0xad, 0xf8, 0x44, 0xce, 0x4d, 0x77, 0xd5,
+	0xaa, 0x1e, 0x99, 0xfe, 0x90, 0x79, 0x7c, 0xa7, 0xcc, 0xe3, 0xf2, 0xfd, 0x8a, 0x7c, 0xa0, 0x9b,
+	0xf8, 0x16, 0x7b, 0x76, 0xb0, 0xff, 0xb1, 0x42, 0x47, 0x68, 0x5a, 0xfa, 0x7b, 0xdb, 0x9d, 0xd4,
+	0x23, 0xe8, 0x1a, 0x60, 0xea, 0xff, 0x66, 0x4d, 0xa9, 0x7c, 0x74, 0xc8, 0x18, 0xb9, 0xda, 0xe5,
+	0xf4, 0x4f, 0x4b, 0xc5, 0xbe, 0xa9, 0x9a, 0xe6, 0x8c, 0x2a, 0xd7, 0xe4, 0x0d, 0x0d, 0x43, 0x8b,
+	0xff, 0x1d, 0xb5, 0x7a, 0xd6, 0x4f, 0x4e, 0xc8, 0x6c, 0xa2, 0xe4, 0x8d, 0x54, 0x32, 0x0e, 0x56,
+	0x18, 0x7c, 0x5f, 0xa0, 0xb9, 0xda, 0x9f, 0xb5, 0xd4, 0xbe, 0xff, 0x5b, 0x75, 0x73, 0xe6, 0x91,
+	0xcf, 0x79, 0xea, 0x2e, 0x03, 0x1f, 0xa6, 0xa8, 0x9a, 0xa6, 0x1c, 0x31, 0x50, 0x2c, 0xf2, 0xf0,
+	0xa5, 0x61, 0x99, 0x8f, 0xd4, 0xca, 0x98, 0x65, 0xbf, 0x56, 0x0c, 0xb3, 0x97, 0x28, 0x86, 0xe5,
+	0xb1, 0x63, 0x1b, 0xfc, 0x3c, 0xb0, 0x76, 0x0f, 0x5c, 0xd4, 0x2c, 0x26, 0x9f, 0x9a, 0x0c, 0x39,
+	0x56, 0x67, 0xab, 0x16, 0x9c, 0xec, 0x25, 0xa0, 0x92, 0x64, 0x79, 0x18, 0x4c, 0xc9, 0xed, 0xcb,
+	0xc1, 0x88, 0xe8, 0xff, 0x9e, 0x3e, 0x5e, 0x71, 0xd7, 0x70, 0x3a, 0x45, 0xec, 0xd9, 0xd5, 0x0b,
+	0xb3, 0xfb, 0x8a, 0x1c, 0x75, 0xf4, 0xb4, 0xe3, 0x2e, 0x87, 0x4e, 0x0c, 0x94, 0xa3, 0x29, 0x97,
+	0xa4, 0xb3, 0x57, 0x21, 0xa9, 0xff, 0x1f, 0xe6, 0xd5, 0xc2, 0xc3, 0xe1, 0xb3, 0x24, 0xee, 0xd2,
+	0xc1, 0xc3, 0x20, 0x1a, 0x24, 0x3a, 0x81, 0x0a, 0x7f, 0xa3, 0xd5, 0x45, 0xc9, 0x04, 0xa3, 0x4c,
+	0x4e, 0x0e, 0x74, 0x11, 0x6d, 0x8b, 0x71, 0x9e, 0x54, 0xc8, 0x9c, 0x62, 0x41, 0xd0, 0x5b, 0x19,
+	0xdb, 0x19, 0x95, 0x52, 0xca, 0x33, 0xd0, 0xe6, 0xac, 0x0c, 0x34, 0x3a, 0xa6, 0xe2, 0x3c, 0x09,
+	0x22, 0x27, 0x1e, 0x53, 0x71, 0x91, 0xbc, 0xaa, 0x71, 0xc4, 0xc1, 0x28, 0xb2, 0x52, 0x16, 0xc4,
+	0xab, 0xb2, 0x81, 0xa8, 0x3b, 0xf9, 0x03, 0xc6, 0x61, 0x59, 0x63, 0x83, 0xd0, 0xfe, 0x2b, 0x26,
+	0x65, 0x2e, 0xf1, 0x12, 0x17, 0xc0, 0x28, 0x90, 0x40, 0x96, 0x6a, 0xb9, 0xc1, 0x73, 0x50, 0x9c,
+	0x34, 0x59, 0x84, 0x5b, 0x3e, 0x19, 0x1f, 0x51, 0x6b, 0x9f, 0x0c, 0xed, 0xc4, 0xb0, 0xdf, 0x3f,
+	0x09, 0xc1, 0xaa, 0x24, 0xe3, 0xb4, 0xc9, 0x71, 0x46, 0x07, 0x88, 0xa3, 0xa6, 0xcc, 0x4f, 0x69,
+	0x62, 0x99, 0xd3, 0x33, 0x2c, 0x90, 0xf7, 0x01, 0x05, 0xae, 0x61, 0x46, 0x2b, 0x94, 0xab, 0xf6,
+	0xaa, 0x2c, 0xa7, 0x2c, 0x99, 0xfe, 0x8b, 0x07, 0x0d, 0x51, 0xc0, 0x98, 0xde, 0x43, 0x50, 0xff,
+	0x13, 0x30, 0xf7, 0x07, 0x78, 0x44, 0x9f, 0x8c, 0x7b, 0x3a, 0xa5, 0xe3, 0xcd, 0xc2, 0xb7, 0x7b,
+	0x84, 0x14, 0x30, 0x0e, 0x67, 0x25, 0x16, 0x3e, 0xe4, 0x20, 0xc0, 0x88, 0x72, 0x3c, 0x16, 0x31,
+	0x08, 0x30, 0xf2, 0x7e, 0x59, 0xad, 0xc2, 0x9f, 0x0e, 0x13, 0x16, 0xa9, 0x96, 0xee, 0xac, 0x3b,
+	0x66, 0xc0, 0xee, 0xa3, 0xc3, 0x23, 0x53, 0x19, 0x14, 0x91, 0x91, 0x6b, 0xe2, 0x14, 0x25, 0x50,
+	0x1a, 0x0d, 0x7b, 0x94, 0x08, 0xb2, 0x18, 0x58, 0x10, 0x91, 0x62, 0x72, 0xca, 0xb5, 0x41, 0xf4,
+	0xc8, 0x01, 0xa8, 0xde, 0x64, 0x49, 0x19, 0x61, 0x93, 0x10, 0x1c, 0x58, 0xeb, 0x3b, 0xca, 0x2b,
+	0xcf, 0xcc, 0xce, 0x84, 0x9c, 0xad, 0xc8, 0x84, 0x6c, 0xda, 0x99, 0x90, 0xbb, 0xaa, 0x69, 0xd3,
+	0xd5, 0x5b, 0x54, 0xb3, 0x9f, 0x1c, 0xb6, 0x1f, 0xaf, 0xbd, 0xe2, 0x35, 0xd4, 0xc2, 0x51, 0xfb,
+	0xf8, 0xf8, 0xa0, 0xbd, 0xbf, 0x56, 0xf3, 0x9a, 0x6a, 0x71, 0x6f, 0xf7, 0xf1, 0x5e, 0x1b, 0x4b,
+	0x75, 0x2c, 0xed, 0xee, 0xed, 0xb5, 0x0f, 0x8f, 0xa1, 0x34, 0xe3, 0x7f, 0x5f, 0x79, 0xe0, 0xb7,
+	0x48, 0x2b, 0x26, 0xd0, 0x90, 0x6f, 0x89, 0x9a, 0xb3, 0x25, 0x2a, 0x58, 0xb3, 0x5e, 0xc9, 0x9a,
+	0x7e, 0x5b, 0x35, 0x0e, 0xad, 0xc4, 0x64, 0xda, 0x83, 0x3a, 0x25, 0x59, 0xf6, 0xad, 0x05, 0xb1,
+	0x3a, 0xac, 0xdb, 0x1d, 0xfa, 0x7f, 0x42, 0x79, 0x98, 0x26, 0x61, 0xc6, 0xc7, 0x7c, 0x8f, 0x89,
+	0x2e, 0x3a, 0x74, 0x94, 0x27, 0xd4, 0x34, 0x04, 0x46, 0x89, 0x2e, 0xbb, 0x9c, 0x89, 0x53, 0x9c,
+	0xd8, 0x2d, 0x3c, 0x0a, 0x22, 0x90, 0x56, 0x9f, 0x2b, 0x2e, 0xb3, 0x05, 0xa6, 0xde, 0xff, 0x54,
+	0x6d, 0x68, 0xea, 0x5a, 0xda, 0xd9, 0x5d, 0xf8, 0xda, 0xcb, 0x16, 0xbe, 0x5e, 0x5e, 0x78, 0xff,
+	0x9f, 0xd6, 0xd5, 0x82, 0x10, 0x07, 0xf1, 0x9d, 0xa4, 0x6e, 0x26, 0x8d, 0x03, 0xab, 0x4e, 0x85,
+	0x2d, 0x8b, 0x9b, 0x99, 0x2a, 0x71, 0x83, 0xc9, 0x84, 0x61, 0x76, 0x4e, 0xee, 0x2d, 0x88, 0x4a,
+	0xfc, 0xad, 0x03, 0x36, 0x73, 0x79, 0xc0, 0xa6, 0x2a, 0xfb, 0x9a, 0x95, 0x45, 0x39, 0xfb, 0xda,
+	0xca, 0xe7, 0xe6, 0x29, 0x2e, 0xb0, 0x03, 0xe8, 0x00, 0xd1, 0x9e, 0xae, 0x0a, 0x98, 0x62, 0xa4,
+	0x74, 0x37, 0xcb, 0xa2, 0xc1, 0x28, 0x0b, 0x18, 0x01, 0x28, 0x30, 0xc7, 0x59, 0xdc, 0x4b, 0x15,
+	0x59, 0xdc, 0x5c, 0x85, 0x89, 0x55, 0x0d, 0xeb, 0xd3, 0xfc, 0x9b, 0xda, 0xd4, 0x6f, 0x90, 0x57,
+	0x43, 0x46, 0xe7, 0x28, 0xcf, 0x50, 0x47, 0x75, 0x8a, 0x60, 0x3e, 0x94, 0x49, 0x93, 0xfe, 0xb3,
+	0xc8, 0x60, 0x32, 0x2d, 0x8b, 0x60, 0x14, 0xfe, 0xa7, 0x61, 0xdc, 0xc7, 0x04, 0x52, 0x36, 0x29,
+	0x74, 0x11, 0x0f, 0xfe, 0x89, 0xe1, 0x64, 0x5d, 0x4d, 0xd8, 0x12, 0xd6, 0x97, 0x08, 0xa2, 0x4d,
+	0x7f, 0x66, 0x18, 0x07, 0x46, 0x4e, 0x1a, 0xd8, 0x8f, 0x42, 0xc0, 0x54, 0xf3, 0x8c, 0x0d, 0x43,
+	0x9d, 0x3b, 0x8e, 0x40, 0xc1, 0x83, 0x12, 0x15, 0xcf, 0xcc, 0x94, 0xe9, 0x90, 0xc4, 0x5e, 0x74,
+	0xbc, 0x36, 0x31, 0x36, 0x4e, 0x7c, 0x45, 0x15, 0x05, 0x91, 0x1d, 0x30, 0xca, 0xb8, 0x39, 0x09,
+	0x22, 0x17, 0x2b, 0xfc, 0x7f, 0x50, 0xe3, 0xac, 0xb1, 0x7c, 0x6e, 0xf9, 0x6e, 0x32, 0x83, 0x76,
+	0x77, 0x93, 0xa0, 0x06, 0xa6, 0x1e, 0xcf, 0xee, 0x4f, 0xe3, 0x71, 0x2a, 0xfc, 0xa1, 0xc9, 0xc1,
+	0x53, 0xad, 0xa8, 0xc1, 0x21, 0x92, 0x7f, 0xe4, 0xa0, 0xcf, 0x10, 0x7a, 0xb9, 0x02, 0xd3, 0x95,
+	0xf7, 0xa3, 0x3e, 0xf8, 0x31, 0xbb, 0xfd, 0x7e, 0x61, 0x09, 0xd0, 0xd6, 0xae, 0xa8, 0x13, 0x43,
+	0xfc, 0x07, 0x6a, 0x8b, 0x2b, 0x8b, 0x0b, 0x77, 0x53, 0x35, 0x70, 0x6d, 0xc1, 0x90, 0xb1, 0x73,
+	0xf6, 0x18, 0xa4, 0xd3, 0xf1, 0x4e, 0xa2, 0xd3, 0x64, 0xcc, 0xdc, 0xa1, 0x23, 0x86, 0x0c, 0x3a,
+	0x06, 0x88, 0xff, 0x4d, 0xb5, 0x5d, 0x6c, 0x5a, 0xe8, 0x26, 0xc9, 0x8e, 0x3d, 0xaa, 0xd5, 0xd6,
+	0x95, 0x0d, 0xf2, 0xef, 0xab, 0xf5, 0xfd, 0xe8, 0x64, 0x72, 0x76, 0x00, 0x6b, 0xdc, 0xb7, 0x72,
+	0xd7, 0xd3, 0xf3, 0xe4, 0xb9, 0x8c, 0x85, 0x7e, 0x63, 0xac, 0xbb, 0x8f, 0x38, 0x9d, 0x74, 0x14,
+	0x75, 0x75, 0x56, 0x33, 0x41, 0x8e, 0x00, 0xe0, 0x7f, 0xa8, 0x3c, 0xbb, 0x9d, 0xbc, 0xff, 0x74,
+	0x72, 0xd2, 0x49, 0x2f, 0x52, 0xd8, 0x08, 0x3a, 0x5d, 0xdb, 0x06, 0xf9, 0xef, 0xa8, 0x26, 0x8c,
+	0x1a, 0x3a, 0x96, 0x8b, 0x22, 0x18, 0x5a, 0x0c, 0x2f, 0x50, 0xba, 0x9b, 0xd0, 0x22, 0x55, 0xfb,
+	0xff, 0xba, 0xae, 0xe6, 0x19, 0x13, 0x5b, 0xc5, 0xfb, 0x2b, 0xf1, 0x90, 0x8f, 0xfe, 0xa5, 0x55,
+	0x0b, 0x54, 0x12, 0x76, 0xf5, 0x0a, 0x61, 0x27, 0x8e, 0xa1, 0xce, 0x10, 0x95, 0x9d, 0xe8, 0xc0,
+	0x28, 0x16, 0x6b, 0x52, 0xab, 0x66, 0x25, 0x16, 0xab, 0x01, 0x85, 0xe8, 0x73, 0x6e, 0xe9, 0xf0,
+	0xf8, 0xb4, 0x1c, 0x17, 0xf9, 0x66, 0x83, 0x2a, 0xed, 0x29, 0x0e, 0xd0, 0x97, 0xed, 0xa9, 0x92,
+	0xdd, 0xb4, 0x78, 0x05, 0xbb, 0x89, 0xbd, 0x45, 0x1b, 0x84, 0xc9, 0x81, 0xf7, 0x23, 0x50, 0x50,
+	0xa3, 0x64, 0xac, 0x6f, 0xdb, 0xf8, 0xbf, 0x5d, 0x53, 0x6b, 0x62, 0x07, 0x9b, 0x3a, 0x50, 0x7a,
+	0xb6, 0xd1, 0x5c, 0xab, 0x3a, 0x0d, 0x86, 0x31, 0x51, 0x68, 0xcf, 0x84, 0xcc, 0x25, 0xae, 0xef,
+	0x00, 0x71, 0x4c, 0xfa, 0x24, 0x73, 0x10, 0xf7, 0x85, 0xc0, 0x36, 0x48, 0x47, 0xdd, 0x31, 0xf4,
+	0x47, 0xe4, 0xad, 0x05, 0xa6, 0xec, 0xff, 0xab, 0x9a, 0x5a, 0xb7, 0x06, 0x2c, 0x1c, 0xf5, 0x91,
+	0xd2, 0x09, 0x56, 0x1c, 0x3f, 0x67, 0x69, 0x70, 0xcd, 0xb5, 0xe9, 0xf3, 0xcf, 0x1c, 0x64, 0x5a,
+	0x18, 0x60, 0x2e, 0xec, 0x22, 0x9d, 0x0c, 0x44, 0x26, 0xd8, 0x20, 0x64, 0x8a, 0xe7, 0x51, 0xf4,
+	0xd4, 0xa0, 0xb0, 0x1c, 0x70, 0x60, 0x14, 0x98, 0x4c, 0x86, 0xd9, 0xb9, 0x41, 0x9a, 0x95, 0xc0,
+	0xa4, 0x0d, 0xc4, 0xd3, 0xa5, 0x0d, 0xf6, 0xa5, 0xc4, 0x53, 0x35, 0x09, 0xf3, 0xf3, 0xec, 0x3c,
+	0xf2, 0xee, 0x7a, 0xf0, 0x4a, 0x20, 0x65, 0xef, 0x17, 0xaf, 0xe8, 0xff, 0x99, 0xbc, 0xa9, 0x29,
+	0x6b, 0x31, 0x53, 0xb5, 0x16, 0x97, 0x50, 0xba, 0x2a, 0x0e, 0x3c, 0x57, 0x19, 0x07, 0xbe, 0xb7,
+	0x00, 0xb6, 0x77, 0x37, 0x19, 0x45, 0xe5, 0xe0, 0xec, 0x7c, 0x55, 0x70, 0x76, 0x5b, 0x6d, 0xba,
+	0x24, 0x10, 0x59, 0xf8, 0xbb, 0x35, 0xb5, 0x73, 0x9f, 0x4f, 0x5f, 0xf0, 0x50, 0x93, 0x23, 0xf1,
+	0x9a, 0x40, 0x60, 0xc1, 0x91, 0xee, 0x60, 0x69, 0x27, 0x11, 0xdc, 0x1c, 0x82, 0x33, 0x01, 0x5d,
+	0x91, 0xcb, 0xc2, 0xd9, 0xc0, 0x94, 0x4b, 0x4a, 0x50, 0x7c, 0x42, 0x47, 0xde, 0xbf, 0xcd, 0xe9,
+	0x8a, 0x14, 0xce, 0x7b, 0x46, 0x1a, 0x85, 0x63, 0x46, 0x05, 0xa8, 0xff, 0xb7, 0xeb, 0x6a, 0x35,
+	0x1f, 0x64, 0x1b, 0x81, 0xae, 0x3c, 0x10, 0x93, 0x2c, 0x97, 0x07, 0x3a, 0xb6, 0x1c, 0xa3, 0x8d,
+	0x26, 0x63, 0xb3, 0x20, 0xb4, 0x47, 0x75, 0x10, 0x6e, 0xa2, 0x83, 0x73, 0x36, 0x88, 0x93, 0x84,
+	0x50, 0xe3, 0x48, 0xb0, 0x5b, 0x4a, 0x94, 0xe6, 0x0c, 0xbf, 0xf0, 0x2b, 0x26, 0xb4, 0x2e, 0x6a,
+	0x13, 0x8b, 0x4d, 0x23, 0x32, 0xb1, 0xec, 0x93, 0xac, 0x45, 0xa6, 0x8f, 0xbd, 0x23, 0xb9, 0xc5,
+	0x3c, 0xf1, 0x0b, 0x46, 0x60, 0x81, 0x90, 0x82, 0xd2, 0x34, 0xa3, 0x28, 0xde, 0x00, 0x36, 0xcc,
+	0xff, 0xab, 0x35, 0x75, 0xbd, 0x62, 0xf9, 0x64, 0x87, 0xee, 0xab, 0xf5, 0x53, 0x53, 0xa9, 0x49,
+	0xcc, 0xdb, 0x74, 0x5b, 0x9f, 0x7e, 0xba, 0x64, 0x0d, 0xca, 0x1f, 0x18, 0xad, 0xcc, 0x8b, 0xe6,
+	0xe4, 0xf8, 0x95, 0x2b, 0xfc, 0x3f, 0x9a, 0x55, 0xcb, 0xa2, 0xfc, 0x24, 0x7e, 0x71, 0x15, 0x73,
+	0xd7, 0xa6, 0x54, 0xbd, 0x70, 0xe6, 0x77, 0xb5, 0x5d, 0x05, 0xbd, 0x98, 0xa3, 0x8b, 0xd1, 0x68,
+	0x20, 0x2a, 0xc2, 0x81, 0x61, 0x4b, 0x92, 0x9c, 0x61, 0xdd, 0x4c, 0x5d, 0x0e, 0x5c, 0x20, 0xae,
+	0x8c, 0x00, 0x88, 0xb1, 0x39, 0xee, 0x68, 0x83, 0x10, 0xe3, 0x64, 0xd2, 0xc3, 0x9c, 0x40, 0xeb,
+	0x90, 0xd2, 0x06, 0xa1, 0xe5, 0x03, 0xca, 0x79, 0x48, 0x87, 0x9b, 0x64, 0x53, 0x19, 0x1e, 0x98,
+	0x09, 0x2a, 0x6a, 0x74, 0xcc, 0xde, 0x9c, 0xff, 0x2d, 0xe5, 0x31, 0x7b, 0x0d, 0xd3, 0x26, 0xa3,
+	0xc1, 0x51, 0x82, 0x63, 0xc1, 0x74, 0xa0, 0xd6, 0xba, 0xb1, 0xd9, 0xc8, 0x03, 0xb5, 0x39, 0x34,
+	0xcf, 0xec, 0x69, 0xda, 0xb7, 0x1d, 0xe8, 0xd2, 0xea, 0x90, 0x5d, 0xfd, 0xc5, 0x80, 0x7e, 0xa3,
+	0x7e, 0x04, 0x6e, 0x3b, 0x4b, 0x74, 0x96, 0x13, 0x86, 0x86, 0xf8, 0xa6, 0x46, 0x09, 0x8e, 0xbd,
+	0x13, 0xbd, 0xa3, 0x1f, 0x46, 0x72, 0x7d, 0x76, 0x95, 0x7b, 0x77, 0xa1, 0xe0, 0xa7, 0xb7, 0xba,
+	0xe7, 0x51, 0x38, 0xc2, 0xcc, 0x68, 0x06, 0x83, 0xc9, 0x65, 0x96, 0x77, 0x8d, 0xe6, 0x75, 0x09,
+	0x86, 0xbf, 0x41, 0xd7, 0x09, 0x25, 0x5a, 0xa6, 0x25, 0xd9, 0x96, 0x18, 0xe3, 0x08, 0x8d, 0x4d,
+	0x0e, 0x81, 0xff, 0x40, 0xec, 0x58, 0x03, 0x36, 0x89, 0x72, 0x8b, 0x23, 0x81, 0x15, 0xce, 0x0a,
+	0x1c, 0xee, 0x0d, 0x0c, 0x96, 0xdf, 0x55, 0xeb, 0x0c, 0xb3, 0x9d, 0x5c, 0xcb, 0x8b, 0x2a, 0xb8,
+	0xba, 0x25, 0x78, 0xa5, 0x29, 0xd4, 0x74, 0x37, 0x02, 0xca, 0x69, 0x31, 0x20, 0xdd, 0xd9, 0x81,
+	0xb1, 0x7b, 0x14, 0x65, 0xfb, 0xd1, 0x69, 0x38, 0xe9, 0x67, 0x85, 0x3a, 0xfa, 0xc6, 0xa9, 0xe0,
+	0xa9, 0xdf, 0x50, 0x2d, 0x6e, 0xab, 0xb2, 0xf6, 0x35, 0xf5, 0x6a, 0x65, 0xad, 0x34, 0x7a, 0x4d,
+	0x6d, 0xb5, 0x3f, 0x47, 0xc5, 0x5d, 0x24, 0xe8, 0x2d, 0x30, 0x13, 0x09, 0xf5, 0x1e, 0x58, 0x3c,
+	0x93, 0x11, 0x25, 0xcf, 0xe6, 0x84, 0xa4, 0x94, 0x75, 0x43, 0xb2, 0x5f, 0x52, 0xdb, 0x0f, 0x07,
+	0x6e, 0x23, 0x42, 0x7e, 0x31, 0xf9, 0x62, 0xaa, 0x15, 0x7b, 0x58, 0xce, 0x02, 0x34, 0xcc, 0x3f,
+	0x52, 0x5b, 0xdc, 0xd3, 0xee, 0xa4, 0x17, 0x67, 0x07, 0xc9, 0xd9, 0x74, 0xbd, 0x34, 0x73, 0xa9,
+	0x5e, 0x9a, 0xc9, 0xf5, 0x92, 0xff, 0x1f, 0xeb, 0x7a, 0x19, 0xa9, 0x55, 0x8e, 0xc3, 0x94, 0xb5,
+	0x89, 0x63, 0x5d, 0x5e, 0xc5, 0x86, 0x45, 0x5f, 0x87, 0xb8, 0x9c, 0x86, 0x18, 0xf5, 0x6c, 0x51,
+	0x55, 0x51, 0x83, 0x8c, 0x83, 0x50, 0xb0, 0x1c, 0x93, 0xe7, 0x1a, 0x9b, 0x65, 0x56, 0x09, 0xee,
+	0x7d, 0x4b, 0x2d, 0xf6, 0xa2, 0x6e, 0x9c, 0xa2, 0x09, 0x3b, 0x47, 0xa1, 0x36, 0x1d, 0x2e, 0x2b,
+	0xcd, 0xe4, 0xf6, 0xbe, 0x20, 0x06, 0xe6, 0x13, 0xff, 0x54, 0x2d, 0x6a, 0xa8, 0xb7, 0xac, 0x96,
+	0x0e, 0xdb, 0xc1, 0xa3, 0x87, 0xc7, 0x18, 0x0a, 0x7a, 0x05, 0x74, 0x56, 0x33, 0x68, 0xff, 0x4a,
+	0x7b, 0x0f, 0x2f, 0x83, 0xde, 0x6f, 0xb7, 0xd7, 0x6a, 0xde, 0xba, 0x5a, 0x36, 0x90, 0xbd, 0x83,
+	0xe3, 0xef, 0xaf, 0xd5, 0xbd, 0x0d, 0xb5, 0x6a, 0x40, 0xf7, 0x9e, 0xec, 0x7f, 0xdc, 0x3e, 0x5e,
+	0x9b, 0x71, 0xf0, 0xf6, 0xdb, 0x8f, 0x7f, 0xb0, 0x36, 0xeb, 0x1f, 0xa8, 0xed, 0xe2, 0x7a, 0xc9,
+	0x6a, 0xdf, 0xa5, 0x40, 0x2d, 0x85, 0xfb, 0x6a, 0xce, 0x39, 0x44, 0x69, 0xfc, 0x81, 0x46, 0xc4,
+	0xfc, 0xd7, 0xbd, 0x64, 0x30, 0x0a, 0xbb, 0xd9, 0x7e, 0x98, 0x85, 0x28, 0xec, 0x35, 0x07, 0x5e,
+	0x57, 0xd7, 0x4a, 0x35, 0x45, 0xae, 0x2d, 0x7e, 0xf3, 0x15, 0xb5, 0xac, 0x41, 0x7b, 0xe7, 0x93,
+	0x21, 0x9d, 0xcb, 0x83, 0xf8, 0x0d, 0xcd, 0x05, 0x7d, 0xf8, 0x0d, 0x84, 0xda, 0x38, 0x40, 0x41,
+	0x58, 0x48, 0x52, 0xff, 0xc9, 0xaf, 0x46, 0xe4, 0x72, 0xb6, 0x6e, 0xc9, 0x59, 0xdc, 0xb0, 0x6e,
+	0x3f, 0xfa, 0x21, 0x87, 0x9a, 0x5a, 0x76, 0x42, 0x94, 0x68, 0x85, 0x90, 0x6a, 0xd5, 0x09, 0xf7,
+	0x52, 0x42, 0x3b, 0xb1, 0x7b, 0x1e, 0xf7, 0x7b, 0x26, 0x44, 0xc3, 0x07, 0x3c, 0xcd, 0xa0, 0x08,
+	0x46, 0x9d, 0x87, 0xda, 0x61, 0x14, 0xc6, 0x0e, 0x4b, 0xba, 0xc0, 0x62, 0x84, 0x7a, 0xb6, 0x14,
+	0xa1, 0x46, 0x01, 0xa4, 0x0f, 0x50, 0xd0, 0x2c, 0x70, 0x0e, 0xaf, 0xc0, 0x3e, 0xf3, 0xec, 0x4a,
+	0x39, 0x4c, 0xa8, 0xbe, 0xc9, 0x5c, 0x46, 0xbc, 0xcd, 0x7f, 0xf2, 0x9b, 0xcc, 0x65, 0x8a, 0xd7,
+	0xaf, 0x7c, 0x19, 0xe5, 0x37, 0x6a, 0x4a, 0xe5, 0xed, 0x81, 0xb9, 0xb6, 0x79, 0xd8, 0x7e, 0xbc,
+	0xff, 0xf0, 0xf1, 0xc7, 0x1d, 0x0c, 0x93, 0x76, 0xf6, 0x1e, 0xec, 0x3e, 0x7e, 0xdc, 0x3e, 0x60,
+	0xd6, 0x77, 0x20, 0x35, 0xe4, 0xf3, 0xbd, 0x83, 0x4f, 0x8e, 0x10, 0x57, 0x03, 0xeb, 0xc0, 0x27,
+	0x2b, 0x08, 0xc4, 0xdd, 0x20, 0xb0, 0x19, 0x84, 0xed, 0xee, 0x1d, 0x3f, 0xfc, 0x7e, 0xdb, 0xc0,
+	0x66, 0x61, 0xa5, 0xd7, 0x1e, 0x3e, 0x2e, 0x40, 0xe7, 0xfc, 0xef, 0x28, 0xb5, 0x17, 0x8f, 0xbb,
+	0x93, 0x38, 0xfb, 0x2e, 0x5f, 0x91, 0x9b, 0x92, 0x9d, 0x05, 0x35, 0x64, 0xab, 0x4b, 0x0a, 0x25,
+	0xd4, 0x48, 0xd1, 0xff, 0xe3, 0xba, 0x7a, 0x55, 0x8c, 0xb4, 0x07, 0x00, 0x7a, 0x38, 0xcc, 0xa2,
+	0x71, 0x37, 0x1a, 0x99, 0x57, 0x1a, 0xda, 0x6a, 0x53, 0x27, 0xb6, 0x77, 0xba, 0xdc, 0x95, 0xc9,
+	0x06, 0xca, 0x0f, 0x0a, 0xf3, 0x41, 0x04, 0x95, 0xe8, 0x98, 0xb5, 0x67, 0xe0, 0x9c, 0x0e, 0x9f,
+	0x1b, 0x63, 0xb3, 0x41, 0x65, 0x5d, 0x49, 0x2c, 0xce, 0x94, 0xf5, 0x19, 0xaa, 0x7a, 0x63, 0x26,
+	0xe4, 0x12, 0xd0, 0xbd, 0x7a, 0x7b, 0x09, 0x06, 0x8e, 0xcb, 0xd4, 0xda, 0xe3, 0x62, 0xa3, 0xbc,
+	0xb2, 0x0e, 0x37, 0x87, 0x81, 0x8b, 0x13, 0xce, 0x99, 0xf5, 0x45, 0x30, 0x2a, 0x92, 0x64, 0x88,
+	0xee, 0xfd, 0x09, 0xf8, 0x7d, 0x64, 0xc7, 0x35, 0x03, 0x0b, 0xe2, 0xff, 0xaf, 0x9a, 0xba, 0x51,
+	0x4d, 0x7c, 0x11, 0x6c, 0x3f, 0x23, 0xea, 0xdf, 0xe3, 0x1b, 0xcf, 0x72, 0x79, 0x62, 0xe5, 0xee,
+	0x2d, 0xd7, 0x3a, 0xaf, 0xec, 0xfb, 0xf6, 0x2e, 0xbf, 0x43, 0x22, 0x5f, 0x92, 0x1e, 0x76, 0x0f,
+	0xbc, 0x4c, 0x19, 0x74, 0xf6, 0x3c, 0x63, 0x7b, 0x4a, 0xcd, 0x07, 0xed, 0xa3, 0x27, 0x8f, 0xda,
+	0xb0, 0x03, 0xe0, 0x37, 0x1f, 0x18, 0x00, 0xef, 0x2f, 0xaa, 0xd9, 0xfb, 0xbb, 0x0f, 0x81, 0xe1,
+	0xfd, 0xff, 0x31, 0xa3, 0x36, 0x65, 0x83, 0xed, 0x76, 0x6d, 0x4e, 0x2b, 0xdc, 0xd5, 0xa9, 0x95,
+	0xef, 0xea, 0xb0, 0xd7, 0x15, 0x0f, 0x6d, 0xf3, 0xc6, 0x82, 0xd0, 0x51, 0x82, 0x75, 0x85, 0x10,
+	0x39, 0x80, 0x47, 0x5a, 0x04, 0x53, 0xbc, 0xc2, 0xdc, 0xd1, 0x31, 0xfe, 0x99, 0x05, 0x32, 0x77,
+	0x76, 0xb0, 0x9a, 0x99, 0xc1, 0x94, 0x71, 0x1c, 0xbd, 0x09, 0x58, 0x8e, 0x9c, 0xee, 0xc9, 0x6e,
+	0x9a, 0x05, 0xc1, 0xe0, 0x29, 0xda, 0xc3, 0x14, 0x53, 0x47, 0x77, 0xeb, 0xb4, 0x4f, 0xde, 0x00,
+	0x7b, 0x6e, 0x55, 0x55, 0x2c, 0x6f, 0x59, 0xcc, 0x8c, 0xa3, 0x34, 0x1a, 0x3f, 0x8b, 0xc4, 0xa1,
+	0x2b, 0x82, 0x9d, 0xfc, 0x2c, 0x76, 0xea, 0xf2, 0xfc, 0xac, 0xf2, 0x55, 0xed, 0x59, 0x27, 0xc3,
+	0xdc, 0xb9, 0xbb, 0xdc, 0x28, 0xde, 0x5d, 0x06, 0x0b, 0x83, 0x6c, 0x7d, 0x5a, 0x14, 0x3c, 0x6c,
+	0xa5, 0x58, 0x7b, 0x93, 0xd0, 0x2a, 0x6a, 0xec, 0xdb, 0x04, 0xa7, 0xfd, 0xf0, 0x2c, 0x25, 0xb3,
+	0x7e, 0x39, 0x70, 0x81, 0xf8, 0x90, 0xd2, 0x56, 0x61, 0xb9, 0xf3, 0x03, 0x21, 0x6e, 0x31, 0xbf,
+	0x86, 0x8f, 0xa5, 0xaa, 0x55, 0xac, 0x57, 0xaf, 0x22, 0x68, 0x3f, 0x7e, 0xfe, 0x45, 0x52, 0xf0,
+	0xcc, 0xb3, 0x2f, 0xe4, 0xd7, 0x50, 0x6b, 0x30, 0xb7, 0x51, 0x76, 0x2e, 0x7e, 0x7f, 0x09, 0xee,
+	0xff, 0x93, 0x9a, 0xda, 0x7e, 0x14, 0xf7, 0x7a, 0xfd, 0x08, 0xf6, 0x01, 0x28, 0xf3, 0x33, 0x30,
+	0xe5, 0xf9, 0xe1, 0x00, 0x4a, 0x18, 0x37, 0x35, 0x9d, 0x61, 0x38, 0xd0, 0x0f, 0x45, 0x14, 0xc1,
+	0xde, 0x77, 0xd4, 0xab, 0x72, 0x74, 0x38, 0x08, 0xbb, 0xe1, 0x38, 0x49, 0x30, 0x11, 0xea, 0x59,
+	0x14, 0x66, 0xfc, 0x15, 0xab, 0xe6, 0xcb, 0x50, 0xf8, 0xc2, 0x44, 0xc8, 0x61, 0xe1, 0xce, 0x00,
+	0x8f, 0xd5, 0x39, 0x1e, 0x5f, 0x80, 0xa2, 0xf2, 0x59, 0x37, 0x1b, 0xf5, 0x7e, 0x14, 0xf5, 0x30,
+	0x2a, 0x98, 0x93, 0xa1, 0x66, 0x93, 0x81, 0x4e, 0x20, 0x46, 0xfd, 0xb0, 0x0b, 0x4e, 0x0d, 0x3f,
+	0x3f, 0x21, 0x37, 0x13, 0x8b, 0x60, 0xcc, 0x89, 0x11, 0x10, 0xc9, 0x55, 0xe0, 0xb3, 0x38, 0xec,
+	0xc7, 0x5f, 0x44, 0x7a, 0xf7, 0x4c, 0xa9, 0xf5, 0x7f, 0x07, 0x76, 0x72, 0x70, 0xb8, 0x67, 0xd3,
+	0xcf, 0xd8, 0xcf, 0x22, 0x69, 0xad, 0xcc, 0xbc, 0x1c, 0x82, 0x2b, 0x3f, 0x48, 0xcf, 0x72, 0x65,
+	0x24, 0x25, 0x22, 0x79, 0x94, 0x9d, 0x27, 0xe0, 0x8a, 0x4d, 0xfa, 0xfd, 0xce, 0x64, 0x1c, 0xcb,
+	0xca, 0x16, 0xc1, 0x6c, 0xa1, 0x03, 0x71, 0x06, 0x1d, 0x10, 0x63, 0x72, 0x29, 0xdd, 0x82, 0x80,
+	0x45, 0xcb, 0xa6, 0x01, 0x5b, 0xb3, 0x3f, 0xaf, 0xcf, 0x72, 0x2a, 0x06, 0x7b, 0xdb, 0xd0, 0xd3,
+	0xb2, 0x0f, 0xd0, 0x5c, 0x87, 0xbf, 0xbc, 0x7e, 0x1c, 0xd4, 0xcd, 0x01, 0xd4, 0x79, 0x4e, 0x23,
+	0x91, 0xea, 0x39, 0x04, 0xf5, 0xd6, 0x38, 0x7c, 0x6e, 0x56, 0x9a, 0x76, 0x32, 0xe8, 0x2d, 0x1b,
+	0x86, 0xaf, 0x1a, 0x08, 0x43, 0x08, 0x1f, 0x74, 0x13, 0xe0, 0x6d, 0x12, 0xd1, 0x7c, 0x30, 0x3f,
+	0xad, 0x1a, 0x64, 0xed, 0xb2, 0x33, 0x64, 0x3c, 0x97, 0x0d, 0xda, 0xdf, 0x7b, 0xd2, 0x3e, 0x3a,
+	0x06, 0x99, 0xdb, 0x54, 0x8b, 0x20, 0x7f, 0x0f, 0x3f, 0x79, 0x7c, 0x04, 0x52, 0x17, 0x6f, 0xb9,
+	0x6e, 0x15, 0x26, 0x2d, 0x9b, 0x8f, 0x96, 0xe8, 0xb4, 0x23, 0xcb, 0x60, 0x96, 0x48, 0x43, 0xc0,
+	0x42, 0x5a, 0x1c, 0xd3, 0x6e, 0x88, 0xc6, 0x62, 0x1c, 0xbd, 0x26, 0x44, 0xac, 0xde, 0x2e, 0x81,
+	0x41, 0xf7, 0xbe, 0x4e, 0xb1, 0x16, 0x62, 0xcd, 0xc2, 0x6d, 0xbb, 0x12, 0xeb, 0x06, 0x06, 0xd3,
+	0xff, 0x58, 0x2d, 0xea, 0x4c, 0x79, 0xe0, 0x8f, 0xb9, 0xd3, 0xf8, 0x73, 0xf1, 0xda, 0x66, 0x1e,
+	0xbc, 0x12, 0x70, 0x11, 0x64, 0xdf, 0xc2, 0x08, 0x1b, 0xd0, 0x37, 0xeb, 0xa0, 0x46, 0x03, 0x30,
+	0x5e, 0x49, 0xc2, 0xd7, 0xff, 0x6b, 0x35, 0xe5, 0xe1, 0xfb, 0x38, 0xc7, 0x09, 0x1f, 0xdd, 0xe5,
+	0x87, 0x66, 0xa5, 0x28, 0x51, 0xd1, 0x98, 0x78, 0xbf, 0xfa, 0xa9, 0x2b, 0xde, 0xc0, 0x55, 0x55,
+	0x56, 0xf6, 0xfc, 0xcc, 0x25, 0xd9, 0xf3, 0xff, 0x16, 0x86, 0xd4, 0x4e, 0xc1, 0xdf, 0x03, 0xab,
+	0x91, 0x02, 0xd6, 0x3c, 0xa4, 0x4f, 0x2a, 0x9f, 0x51, 0xfa, 0xaa, 0x34, 0x51, 0xfe, 0xe0, 0xa5,
+	0x2f, 0x29, 0xbd, 0xe1, 0xde, 0x25, 0x95, 0x0b, 0xdc, 0x16, 0xe8, 0xa7, 0x7f, 0x28, 0xa9, 0xab,
+	0x36, 0x9c, 0x81, 0xe5, 0xd7, 0x35, 0x28, 0x1a, 0x1e, 0x66, 0xfa, 0xba, 0x86, 0x14, 0xd1, 0xc0,
+	0x82, 0x9f, 0x14, 0x22, 0x73, 0xae, 0xb1, 0xca, 0x75, 0x8d, 0xaa, 0x3a, 0x3f, 0x50, 0x5b, 0xbb,
+	0x27, 0xe1, 0xb0, 0x97, 0x0c, 0x7f, 0x66, 0x9e, 0x12, 0xba, 0x7b, 0xc5, 0x36, 0xc5, 0x2b, 0xfa,
+	0x83, 0x19, 0x13, 0x51, 0x14, 0xc7, 0xe2, 0x6b, 0x8e, 0x63, 0x71, 0xd3, 0x8d, 0xdb, 0x4c, 0xf3,
+	0x29, 0xae, 0x10, 0x7d, 0xf1, 0xde, 0x53, 0x0b, 0x72, 0x50, 0x2c, 0x3b, 0xa3, 0xea, 0x0c, 0x5b,
+	0xa3, 0xe8, 0x18, 0x86, 0x14, 0x75, 0xf0, 0xda, 0x81, 0xa1, 0xec, 0x96, 0xe3, 0xe2, 0x4e, 0xe1,
+	0x16, 0x08, 0xa7, 0x70, 0x4d, 0xa9, 0xd5, 0xa9, 0xdc, 0xb9, 0xdb, 0x36, 0x9f, 0xa7, 0x72, 0xe7,
+	0x6e, 0x5b, 0xd5, 0x19, 0xfe, 0xc2, 0x94, 0x17, 0xd4, 0x4a, 0x6f, 0xb2, 0x2d, 0x56, 0xbc, 0xc9,
+	0xe6, 0x1f, 0x39, 0xde, 0xd3, 0xb6, 0xf2, 0x76, 0x8f, 0x8f, 0xdb, 0x8f, 0x0e, 0x8f, 0x3b, 0xfb,
+	0x0f, 0x8f, 0x0e, 0x77, 0x8f, 0xf7, 0x1e, 0x50, 0xd8, 0x00, 0x1d, 0x20, 0x81, 0xa3, 0xd5, 0x48,
+	0x19, 0x27, 0xcb, 0x6a, 0xe9, 0xe8, 0xc9, 0xde, 0x5e, 0xbb, 0xbd, 0x4f, 0x29, 0x27, 0x60, 0x5c,
+	0x4a, 0xd5, 0x8c, 0x3f, 0x52, 0x1e, 0x66, 0x9d, 0x3d, 0x8a, 0x60, 0x53, 0x76, 0xcd, 0x69, 0x2b,
+	0x90, 0xe6, 0x24, 0xca, 0x9e, 0x47, 0xd1, 0x10, 0xdf, 0x9b, 0xea, 0xa0, 0x94, 0x18, 0x83, 0x84,
+	0xce, 0xf4, 0xc1, 0xeb, 0x94, 0x5a, 0x24, 0x3b, 0xa7, 0x9b, 0xe2, 0xc1, 0x76, 0xa6, 0x5f, 0x0e,
+	0x70, 0x60, 0xfe, 0xff, 0xae, 0x71, 0x7e, 0xbe, 0x74, 0x79, 0xc9, 0xb3, 0x25, 0xd3, 0x47, 0xc1,
+	0xa9, 0xb0, 0xd3, 0x46, 0x71, 0xa0, 0xde, 0xac, 0xae, 0xe9, 0x0c, 0x93, 0xf1, 0xc0, 0xd2, 0xcf,
+	0xb5, 0xe0, 0xe5, 0x88, 0xa5, 0xd4, 0xd8, 0xd9, 0x2b, 0x5d, 0xbb, 0x98, 0xab, 0xba, 0x76, 0xe1,
+	0x7f, 0x5b, 0x6d, 0x38, 0xd4, 0x36, 0xef, 0x83, 0x38, 0x99, 0xd1, 0xf6, 0xad, 0x07, 0x8d, 0xca,
+	0x08, 0xfe, 0xbe, 0xf2, 0x1e, 0x89, 0x1e, 0x3c, 0x8c, 0xc6, 0x83, 0x38, 0xa5, 0xc8, 0x11, 0x1e,
+	0xb1, 0x52, 0x3a, 0xa6, 0x3e, 0x0d, 0xe6, 0x92, 0x7e, 0xad, 0x49, 0x7c, 0x97, 0x25, 0xed, 0x8f,
+	0xf8, 0xbf, 0x5f, 0x53, 0x1b, 0xf7, 0xc2, 0xa7, 0x91, 0x6e, 0x4a, 0x2f, 0xfb, 0x47, 0xaa, 0x31,
+	0x32, 0xad, 0xea, 0xd1, 0xe8, 0xdb, 0xe2, 0xe5, 0x7e, 0x03, 0x1b, 0x9b, 0x32, 0xb4, 0x46, 0xfa,
+	0x79, 0x47, 0x7d, 0x67, 0x20, 0x87, 0xd0, 0x93, 0x1e, 0xf1, 0x20, 0xc2, 0xd3, 0x19, 0x8e, 0x73,
+	0xe8, 0x22, 0xca, 0x5e, 0x68, 0x98, 0xdc, 0xad, 0xdc, 0xf3, 0xb4, 0x41, 0x3e, 0x48, 0x42, 0x77,
+	0xbc, 0x42, 0x38, 0xb4, 0xe8, 0xb5, 0xa9, 0xc0, 0x53, 0x37, 0x65, 0x94, 0x5a, 0x18, 0x5d, 0xd6,
+	0xdf, 0x3c, 0xdc, 0x37, 0x61, 0xd2, 0x6f, 0xa9, 0x6b, 0xa5, 0x9a, 0x3c, 0xf6, 0x69, 0xf5, 0xcb,
+	0x24, 0x98, 0x0d, 0x1c, 0x98, 0xff, 0x91, 0xba, 0xc6, 0xd1, 0xd9, 0xbc, 0x01, 0xcb, 0x0f, 0xb3,
+	0x67, 0x52, 0x2b, 0xcf, 0xe4, 0xeb, 0x3a, 0x33, 0xc2, 0xfe, 0x38, 0xd7, 0x04, 0x76, 0x0e, 0xc2,
+	0x62, 0xa0, 0x8b, 0xfe, 0xdf, 0xa9, 0xa9, 0x9b, 0x7b, 0xe7, 0x51, 0xf7, 0x69, 0x79, 0x11, 0xcc,
+	0x9e, 0x2d, 0xd2, 0xa2, 0x99, 0xd3, 0xa2, 0xb8, 0xb0, 0xf5, 0x1f, 0x6b, 0x61, 0xd1, 0xf5, 0xe9,
+	0xc7, 0x94, 0x4b, 0x34, 0x12, 0xa3, 0x32, 0x07, 0x60, 0x10, 0x5f, 0x37, 0xc0, 0xe9, 0x73, 0x7b,
+	0x64, 0x75, 0x61, 0x10, 0xcf, 0x32, 0xfc, 0xe9, 0x37, 0xb5, 0x64, 0x6c, 0x35, 0x49, 0x8e, 0xc8,
+	0xad, 0xb3, 0xff, 0x56, 0x53, 0x6f, 0x4c, 0x9f, 0xe4, 0xa5, 0xcf, 0xbf, 0x19, 0x33, 0xbe, 0x6e,
+	0x9b, 0xf1, 0x79, 0xe6, 0xc1, 0x8c, 0x93, 0x79, 0xe0, 0x72, 0xea, 0x6c, 0x89, 0x53, 0xf7, 0x4c,
+	0x22, 0x24, 0x5b, 0x90, 0x7c, 0xc7, 0xb0, 0x61, 0x92, 0x28, 0xab, 0xe6, 0x1b, 0x14, 0x3e, 0xa1,
+	0x80, 0x92, 0x7c, 0x3d, 0x4f, 0xc9, 0x5e, 0xba, 0x88, 0x81, 0x50, 0x7c, 0x42, 0xa8, 0x1c, 0xab,
+	0xfb, 0x4b, 0x35, 0xb5, 0x64, 0x6a, 0x2e, 0x91, 0x8b, 0xb7, 0x45, 0xc7, 0x72, 0x40, 0xa2, 0x65,
+	0x3d, 0x4b, 0x44, 0x5f, 0xde, 0xa6, 0x7f, 0xad, 0xc7, 0x07, 0x6f, 0xab, 0x25, 0x03, 0xf2, 0x56,
+	0x55, 0xe3, 0xb0, 0xdd, 0x0e, 0x3a, 0x9f, 0x3c, 0x3e, 0x78, 0xf8, 0xb8, 0xcd, 0xc1, 0x36, 0x06,
+	0xdc, 0xbf, 0x4f, 0x90, 0x9a, 0x1f, 0xa9, 0x8d, 0xe3, 0x31, 0x18, 0x96, 0x87, 0xee, 0xa3, 0xa3,
+	0x57, 0x31, 0x03, 0xab, 0xce, 0x5c, 0xea, 0xd5, 0x67, 0x2e, 0x77, 0x7f, 0xaf, 0xae, 0x56, 0xf8,
+	0xea, 0x2d, 0x3f, 0x9d, 0x0b, 0xf6, 0xef, 0x23, 0xb5, 0x20, 0x0f, 0x15, 0x7b, 0x5b, 0x32, 0x2d,
+	0xf7, 0x69, 0xe4, 0xd6, 0x76, 0x11, 0x2c, 0x96, 0xc8, 0xc6, 0xaf, 0xff, 0xe1, 0x7f, 0xfd, 0xeb,
+	0xf5, 0x65, 0xaf, 0x71, 0xe7, 0xd9, 0x07, 0x77, 0xce, 0xa2, 0x21, 0xbe, 0x1d, 0xec, 0xfd, 0x59,
+	0xa5, 0xf2, 0xb7, 0x7e, 0xbd, 0xdc, 0x94, 0x2e, 0xbc, 0x4d, 0xdc, 0xba, 0x5e, 0x51, 0x23, 0xed,
+	0x5e, 0xa7, 0x76, 0x37, 0xfc, 0x15, 0x6c, 0x37, 0x86, 0x7a, 0x7e, 0xf8, 0xf7, 0x9b, 0xb5, 0x5b,
+	0x5e, 0x4f, 0x35, 0xed, 0x37, 0x7f, 0x3d, 0xbd, 0x10, 0x15, 0x0f, 0x09, 0xb7, 0x5e, 0xad, 0xac,
+	0xd3, 0xb7, 0x0f, 0xa8, 0x8f, 0x2d, 0x7f, 0x0d, 0xfb, 0x98, 0x10, 0x86, 0xe9, 0xe5, 0xee, 0xff,
+	0xfc, 0x50, 0x2d, 0x99, 0x2b, 0x32, 0xde, 0x0f, 0xd5, 0xb2, 0x73, 0x5b, 0xd9, 0xd3, 0x0d, 0x57,
+	0x5d, 0x6e, 0x6e, 0xdd, 0xa8, 0xae, 0x94, 0x6e, 0x5f, 0xa7, 0x6e, 0x77, 0xbc, 0x6d, 0xec, 0x56,
+	0xae, 0xfb, 0xde, 0xa1, 0x3b, 0xda, 0xfc, 0x06, 0xd3, 0x53, 0xb5, 0xe2, 0xde, 0x30, 0xf6, 0x6e,
+	0xb8, 0xc6, 0x62, 0xa1, 0xb7, 0xd7, 0xa6, 0xd4, 0x4a, 0x77, 0x37, 0xa8, 0xbb, 0x6d, 0x6f, 0xd3,
+	0xee, 0xce, 0x68, 0xd0, 0x88, 0x5e, 0xcd, 0xb2, 0x1f, 0x03, 0xf6, 0x5e, 0x33, 0x4b, 0x5d, 0xf5,
+	0x48, 0xb0, 0x59, 0xb4, 0xf2, 0x4b, 0xc1, 0xfe, 0x0e, 0x75, 0xe5, 0x79, 0x44, 0x50, 0xfb, 0x2d,
+	0x60, 0xef, 0xcf, 0x80, 0x25, 0xa4, 0x1f, 0x00, 0xf5, 0xae, 0x59, 0xaf, 0xae, 0xda, 0xaf, 0x92,
+	0xb6, 0x76, 0xca, 0x15, 0x55, 0x4b, 0x65, 0xb7, 0x8c, 0x0c, 0x31, 0x52, 0x5b, 0xb2, 0x9f, 0x4f,
+	0xa2, 0x1f, 0x67, 0x26, 0x15, 0x4f, 0x18, 0xfb, 0x3e, 0x75, 0x74, 0xc3, 0x6b, 0x15, 0x3b, 0xba,
+	0x93, 0xea, 0x2e, 0xde, 0xaf, 0x79, 0xbf, 0xaa, 0x16, 0xf5, 0xdb, 0xab, 0xde, 0x76, 0xf5, 0x1b,
+	0xb2, 0xad, 0x6b, 0x25, 0xb8, 0xcc, 0xe5, 0x0d, 0xea, 0xa2, 0xe5, 0x6f, 0x95, 0xba, 0x18, 0x00,
+	0x1a, 0x4e, 0x08, 0xf6, 0x4f, 0xfe, 0xb2, 0xa8, 0xd9, 0x3f, 0xa5, 0xf7, 0x4e, 0xcd, 0x52, 0x94,
+	0x9f, 0x21, 0x75, 0xf7, 0xcf, 0x10, 0x7c, 0x5f, 0xae, 0xc7, 0xd6, 0xcf, 0xe8, 0x89, 0x55, 0xf7,
+	0x4d, 0x53, 0xef, 0x66, 0xde, 0x54, 0xe5, 0x6b, 0xa7, 0x97, 0xf5, 0xb5, 0x4d, 0x7d, 0xad, 0x79,
+	0x85, 0xbe, 0xbc, 0xcf, 0x54, 0xc3, 0x7a, 0xc8, 0xd4, 0xd3, 0x2d, 0x94, 0x1f, 0x41, 0x6d, 0xb5,
+	0xaa, 0xaa, 0xf4, 0x31, 0x2f, 0xb5, 0xbe, 0xe9, 0xaf, 0x62, 0xeb, 0xf8, 0x50, 0xa9, 0xc4, 0x80,
+	0x70, 0x2a, 0xe7, 0x6a, 0xd9, 0x79, 0xad, 0xd4, 0x6c, 0xcb, 0xaa, 0xb7, 0x50, 0xcd, 0xb6, 0xac,
+	0x7c, 0xe0, 0x54, 0xef, 0x13, 0x7f, 0x1d, 0xfb, 0x79, 0x46, 0x28, 0x56, 0x4f, 0x7f, 0x5a, 0x35,
+	0xac, 0x97, 0x47, 0x3d, 0xeb, 0x29, 0x9f, 0xc2, 0x9b, 0xa3, 0x66, 0x2e, 0x55, 0x0f, 0x95, 0x6e,
+	0x52, 0x1f, 0x2b, 0xfe, 0x12, 0xf6, 0x41, 0xef, 0xbb, 0x61, 0xdb, 0x3f, 0x54, 0x2b, 0xee, 0x5b,
+	0xa4, 0x66, 0xc3, 0x57, 0xbe, 0x6a, 0x6a, 0x36, 0xfc, 0x94, 0x07, 0x4c, 0x65, 0xaf, 0xdc, 0xda,
+	0x30, 0x9d, 0xdc, 0xf9, 0x52, 0x54, 0xd8, 0x0b, 0xef, 0x7b, 0x28, 0xd5, 0xe4, 0xc1, 0x3d, 0x2f,
+	0x7f, 0x81, 0xd5, 0x7d, 0x96, 0xcf, 0x6c, 0xc4, 0xd2, 0xdb, 0x7c, 0xfe, 0x3a, 0x35, 0xde, 0xf0,
+	0xf2, 0x19, 0xb0, 0xf2, 0xa0, 0x87, 0xf7, 0x2c, 0xe5, 0x61, 0xbf, 0xcd, 0x67, 0x29, 0x0f, 0xe7,
+	0x7d, 0xbe, 0xa2, 0xf2, 0xc8, 0x62, 0x6c, 0x63, 0xa8, 0x56, 0x0b, 0x4f, 0x6e, 0x98, 0x7d, 0x5c,
+	0xfd, 0xf8, 0x4f, 0xeb, 0xf5, 0xcb, 0x5f, 0xea, 0x70, 0x25, 0xa0, 0x96, 0x7c, 0x77, 0xf4, 0x5b,
+	0x4d, 0xbf, 0xaa, 0x9a, 0xf6, 0x5b, 0x90, 0x46, 0x9d, 0x54, 0xbc, 0x60, 0x69, 0xd4, 0x49, 0xd5,
+	0xe3, 0x91, 0x7a, 0x71, 0xbd, 0xa6, 0xdd, 0x0d, 0x30, 0xce, 0xaa, 0xf5, 0x24, 0xcc, 0xd1, 0xc5,
+	0xb0, 0x6b, 0x98, 0xa7, 0xfc, 0xf8, 0x57, 0xab, 0x2a, 0x2c, 0xe0, 0x5f, 0xa3, 0x86, 0xd7, 0x7d,
+	0xa7, 0x61, 0x64, 0x9c, 0xae, 0x6a, 0xd8, 0xcf, 0xcd, 0x5c, 0xd2, 0xee, 0x35, 0xab, 0xca, 0x7e,
+	0xe5, 0x4a, 0x2b, 0x23, 0x7f, 0xc3, 0xa1, 0x0d, 0x87, 0x25, 0xa1, 0x0b, 0x90, 0x75, 0x7f, 0x17,
+	0xef, 0x93, 0x5a, 0xcf, 0xce, 0x79, 0xce, 0x85, 0xb7, 0x42, 0x3f, 0x3b, 0x76, 0x9d, 0xd3, 0x51,
+	0x40, 0x1d, 0x1d, 0xdc, 0xfa, 0x15, 0xa7, 0xa3, 0x2f, 0x9d, 0x88, 0xc7, 0xed, 0xe2, 0xf3, 0xe1,
+	0x2f, 0x8a, 0x08, 0xf6, 0x03, 0x6a, 0x2f, 0x60, 0x70, 0x67, 0xfc, 0xc4, 0xbc, 0xbe, 0x46, 0xe0,
+	0x59, 0x32, 0xb7, 0x48, 0x52, 0xfb, 0x35, 0x76, 0xff, 0xab, 0x34, 0x9a, 0x9f, 0xf3, 0xdf, 0x70,
+	0x46, 0xe3, 0xca, 0x7b, 0x4d, 0x83, 0x77, 0x6b, 0xd0, 0xd1, 0x67, 0xfc, 0xa4, 0xb8, 0x74, 0x44,
+	0xcb, 0x78, 0xe5, 0xce, 0xde, 0xa2, 0xce, 0x5e, 0xf7, 0xaf, 0x4f, 0xed, 0x0c, 0x17, 0xf3, 0x50,
+	0xa9, 0xfc, 0x0a, 0x8a, 0x57, 0xb8, 0x8f, 0x61, 0xc4, 0x6f, 0xf9, 0x96, 0x8a, 0x66, 0x0f, 0x68,
+	0x83, 0x39, 0x44, 0xdf, 0xdc, 0x00, 0xa5, 0xdb, 0xb4, 0x2e, 0x7f, 0xa4, 0x86, 0x3f, 0xca, 0x57,
+	0x49, 0x5a, 0xad, 0xaa, 0xaa, 0x2a, 0xbe, 0x36, 0x8d, 0x3f, 0x51, 0xcb, 0x07, 0x49, 0xf2, 0x74,
+	0x32, 0x32, 0xb7, 0xd1, 0xdc, 0x98, 0x13, 0xa6, 0x02, 0xb5, 0x0a, 0xb3, 0xd0, 0xaa, 0xcf, 0xdb,
+	0xb1, 0x9a, 0xba, 0xf3, 0x65, 0x7e, 0x01, 0xe6, 0x85, 0x17, 0xaa, 0x75, 0xa3, 0xcb, 0xcd, 0xc0,
+	0x5b, 0x6e, 0x33, 0xb6, 0xf1, 0x5e, 0xea, 0xc2, 0xb1, 0xae, 0xf4, 0x68, 0x1d, 0xe5, 0x7d, 0xa8,
+	0x9a, 0xfb, 0x51, 0x17, 0xdc, 0x7a, 0x49, 0xd8, 0xde, 0xc8, 0x07, 0x6e, 0x32, 0xbd, 0x5b, 0xcb,
+	0x0e, 0xd0, 0x15, 0x21, 0x60, 0x51, 0x8f, 0xa3, 0x1f, 0x81, 0x50, 0xe5, 0x54, 0xf0, 0x17, 0x5a,
+	0x84, 0x1c, 0x9a, 0x5b, 0x0a, 0xb6, 0xf8, 0x74, 0x13, 0xea, 0x1d, 0x11, 0x52, 0x4a, 0xc3, 0x77,
+	0x48, 0x6d, 0xee, 0x0c, 0xf4, 0x31, 0x0b, 0xbe, 0x90, 0xb9, 0x6f, 0x14, 0xf6, 0xb4, 0x7c, 0xff,
+	0xd6, 0x1b, 0xd3, 0x11, 0xdc, 0xde, 0x6e, 0xb9, 0xbd, 0x0d, 0x40, 0x1b, 0x39, 0xf9, 0xfa, 0xb9,
+	0x36, 0xaa, 0xba, 0x21, 0x90, 0x6b, 0xa3, 0xca, 0x24, 0x7f, 0x57, 0xc0, 0xe8, 0x4e, 0xee, 0xb0,
+	0x83, 0x8d, 0x6c, 0x7f, 0xa4, 0x96, 0xf7, 0x23, 0x5e, 0x1b, 0xbe, 0xae, 0xde, 0x72, 0x45, 0xa0,
+	0x7d, 0xf3, 0xbf, 0x28, 0x1e, 0xa9, 0xce, 0x55, 0x49, 0x74, 0x9b, 0x1b, 0x38, 0xbf, 0x01, 0xba,
+	0x46, 0xdf, 0x20, 0x37, 0x26, 0x5a, 0xe1, 0x4a, 0x79, 0xab, 0xe2, 0x7a, 0xbb, 0xcb, 0xa2, 0xd4,
+	0xda, 0x1d, 0xbc, 0xf0, 0xce, 0x82, 0xa8, 0x13, 0xf7, 0x5e, 0x78, 0x7f, 0x8a, 0x1a, 0x37, 0x0f,
+	0x89, 0x6c, 0x5b, 0x51, 0x23, 0xbb, 0xf1, 0xd5, 0x02, 0xbc, 0xaa, 0x65, 0x0c, 0x2e, 0x59, 0xca,
+	0x79, 0xa8, 0x1a, 0xd6, 0x7b, 0x37, 0x66, 0xbf, 0x96, 0x9f, 0x01, 0x32, 0xfb, 0xb5, 0xe2, 0x79,
+	0x1c, 0xff, 0x5d, 0xea, 0xc7, 0xf7, 0xde, 0xc8, 0xfb, 0xe1, 0xa0, 0x7e, 0xde, 0xd3, 0x9d, 0x2f,
+	0xc3, 0x41, 0xf6, 0xc2, 0xfb, 0x94, 0x5e, 0xd1, 0xb5, 0x6f, 0xc9, 0xe7, 0x56, 0x5e, 0xf1, 0x42,
+	0xbd, 0x21, 0x96, 0x55, 0xe5, 0x5a, 0x7e, 0xdc, 0x15, 0xe9, 0xf0, 0x5f, 0x54, 0x0a, 0xef, 0x79,
+	0xef, 0x87, 0xf8, 0x3f, 0xc5, 0xe4, 0x82, 0x32, 0xbf, 0x09, 0x9e, 0x0b, 0x4a, 0xeb, 0x3a, 0x38,
+	0x8c, 0x27, 0x37, 0xe4, 0x9d, 0x27, 0x0c, 0x34, 0x2f, 0x4f, 0xbd, 0x2c, 0x6e, 0x08, 0x52, 0x71,
+	0x61, 0x1c, 0xb6, 0x3c, 0x18, 0xd4, 0xf9, 0xfd, 0x0f, 0x63, 0x50, 0x97, 0xae, 0x96, 0x18, 0x29,
+	0x5b, 0xbe, 0x2c, 0xe2, 0x1a, 0xd4, 0x3d, 0xac, 0xa7, 0xeb, 0x25, 0x2c, 0xb9, 0x97, 0xf2, 0xfb,
+	0x09, 0xd7, 0xf2, 0x37, 0x94, 0x9c, 0xdb, 0x0c, 0x46, 0x35, 0x96, 0x6e, 0x0d, 0xf8, 0x6b, 0xd4,
+	0xb4, 0xf2, 0x16, 0xb1, 0x69, 0xba, 0x0a, 0x10, 0xab, 0x0d, 0x1e, 0xbb, 0xb1, 0x03, 0x28, 0x6d,
+	0xb8, 0xe5, 0xa4, 0x88, 0x39, 0x99, 0xfb, 0x46, 0xae, 0x54, 0xa6, 0xb4, 0x3b, 0x83, 0x47, 0x46,
+	0xe6, 0x2b, 0xd7, 0x38, 0xf8, 0x53, 0x90, 0x5d, 0x56, 0xe2, 0x55, 0x2e, 0xbb, 0xca, 0x59, 0x5f,
+	0xb9, 0xec, 0xaa, 0xca, 0xd4, 0x7a, 0x8d, 0xfa, 0xb8, 0xe6, 0x7b, 0x8e, 0x96, 0xa3, 0xec, 0x2e,
+	0xec, 0x67, 0xa0, 0xd6, 0x4b, 0x59, 0xd9, 0x46, 0x88, 0x4d, 0x4b, 0xb7, 0x37, 0x42, 0x6c, 0x6a,
+	0x42, 0xb7, 0xbf, 0x45, 0xdd, 0xae, 0xfa, 0x8a, 0xdc, 0x83, 0xe7, 0x71, 0xd6, 0x3d, 0xc7, 0xee,
+	0x8e, 0xd5, 0x92, 0xc9, 0x87, 0xf5, 0x2a, 0xd3, 0x58, 0xcd, 0x82, 0x94, 0xf3, 0x66, 0x1d, 0x83,
+	0x4b, 0x67, 0x6e, 0x62, 0xab, 0x5a, 0xd0, 0x0b, 0xc8, 0x15, 0xf4, 0x6e, 0x52, 0xa8, 0x2b, 0xe8,
+	0x0b, 0xb9, 0x9e, 0x05, 0x41, 0xaf, 0x9b, 0x8b, 0xa0, 0x79, 0xd2, 0xa9, 0x32, 0x6e, 0x37, 0x25,
+	0xd0, 0x56, 0xac, 0x95, 0x33, 0xf2, 0x7f, 0x8e, 0x5a, 0xbd, 0xe9, 0xbd, 0x66, 0x5a, 0xbd, 0x20,
+	0x2d, 0xe5, 0xc4, 0x7f, 0x5e, 0x80, 0x3e, 0x69, 0xda, 0x09, 0xb5, 0x97, 0x74, 0xf3, 0xaa, 0x2b,
+	0xdb, 0x5d, 0x2a, 0x49, 0x6f, 0xb7, 0x5e, 0xd2, 0xdb, 0x0f, 0xf1, 0xbf, 0x1f, 0x71, 0xd3, 0x74,
+	0xa7, 0x2c, 0xc8, 0x4d, 0x63, 0x3c, 0x4d, 0xc9, 0xea, 0xbd, 0x49, 0x3d, 0x5e, 0xf7, 0x37, 0x6d,
+	0xaa, 0xc1, 0x66, 0x24, 0x5c, 0x5c, 0x9f, 0xcf, 0x50, 0x99, 0xd8, 0x1d, 0xe5, 0x13, 0x28, 0xa7,
+	0xfb, 0x4e, 0x21, 0xa2, 0xab, 0xea, 0x0b, 0x9d, 0x78, 0x5f, 0xa8, 0x8d, 0x8a, 0x14, 0x61, 0xef,
+	0x4d, 0x87, 0x50, 0x95, 0xbd, 0xf9, 0x97, 0xa1, 0xb8, 0x9e, 0xca, 0xad, 0xea, 0xbe, 0x3f, 0x53,
+	0x2b, 0x6e, 0xfe, 0xb1, 0xd1, 0xcc, 0x95, 0x69, 0xc9, 0x46, 0xc6, 0xda, 0xb9, 0xc9, 0xda, 0x3b,
+	0xf4, 0x36, 0x9c, 0x2e, 0x22, 0x6a, 0xc0, 0xeb, 0xa9, 0x15, 0x37, 0x39, 0xd9, 0xab, 0x6a, 0xc3,
+	0xa8, 0xfc, 0xea, 0x44, 0xe6, 0x82, 0xca, 0xd7, 0x5d, 0x70, 0x0e, 0x33, 0xae, 0x52, 0xac, 0x56,
+	0xdc, 0xa4, 0x58, 0x33, 0x8f, 0xca, 0xdc, 0x66, 0xd3, 0x5d, 0x75, 0x26, 0xad, 0x0e, 0x10, 0x78,
+	0x9e, 0xd3, 0x5d, 0x88, 0x68, 0xde, 0x53, 0xb5, 0x5a, 0xc8, 0x8b, 0x35, 0xce, 0x64, 0x75, 0x26,
+	0xad, 0x71, 0x26, 0xa7, 0xa5, 0xd3, 0x8a, 0x28, 0x45, 0x6b, 0x9b, 0x55, 0xc1, 0xc9, 0x9d, 0x2e,
+	0xa3, 0x82, 0x74, 0x58, 0x71, 0x33, 0x6d, 0x0b, 0xeb, 0x53, 0xec, 0x4a, 0xf3, 0x9f, 0x93, 0x85,
+	0xab, 0x05, 0x9a, 0xb7, 0x2c, 0xad, 0xf3, 0xd2, 0x80, 0x12, 0x7b, 0xa6, 0xb6, 0x8b, 0xda, 0xb1,
+	0xfd, 0xcc, 0xb1, 0x05, 0xa7, 0x65, 0xa3, 0xb6, 0xae, 0x4f, 0x4d, 0x34, 0x75, 0xed, 0xe5, 0xdc,
+	0x01, 0xb4, 0xec, 0xe5, 0xbf, 0xa0, 0x56, 0x9d, 0x6c, 0xbb, 0x64, 0xec, 0x7d, 0xe5, 0x0a, 0xc9,
+	0x78, 0x86, 0xe1, 0x2f, 0x49, 0xd5, 0x74, 0x59, 0x05, 0x73, 0xb4, 0xe2, 0xbc, 0x17, 0xed, 0x7a,
+	0x8d, 0xf9, 0x2d, 0x20, 0x93, 0x8d, 0x95, 0x8c, 0x8b, 0x01, 0x51, 0x37, 0x4b, 0xcb, 0x48, 0xad,
+	0xaa, 0x94, 0x3d, 0x37, 0xfa, 0x66, 0xe6, 0x1b, 0x76, 0xdd, 0x3e, 0x7f, 0xa4, 0xb6, 0x02, 0x49,
+	0x0e, 0x71, 0x92, 0x51, 0x4c, 0xcf, 0x95, 0x29, 0x2a, 0xa6, 0xe7, 0xaa, 0xac, 0x1d, 0x57, 0x09,
+	0xe7, 0x09, 0x59, 0xba, 0xcb, 0x5d, 0x76, 0x65, 0x25, 0x07, 0x24, 0x8f, 0x96, 0x95, 0xf2, 0x42,
+	0x2a, 0x9d, 0x4c, 0x6a, 0x62, 0xc0, 0x4e, 0xaa, 0xa0, 0x3b, 0xb1, 0x86, 0x2b, 0x36, 0xe3, 0xdf,
+	0xa2, 0x41, 0xbe, 0xe5, 0xdf, 0x9c, 0xee, 0x18, 0x93, 0x31, 0x89, 0xfb, 0xf8, 0x44, 0x35, 0xac,
+	0xc4, 0x0a, 0xd3, 0x55, 0x39, 0x0b, 0xc4, 0x58, 0x67, 0x15, 0x79, 0x18, 0xae, 0xbc, 0x75, 0x3a,
+	0xc2, 0xfb, 0x62, 0x43, 0xb5, 0xe2, 0xe6, 0x40, 0x98, 0x15, 0xa8, 0x4c, 0xb7, 0x30, 0xb2, 0x62,
+	0x4a, 0xe2, 0x84, 0xa3, 0x41, 0xf2, 0xd5, 0x67, 0x64, 0x31, 0x53, 0x6c, 0x3f, 0x9f, 0x62, 0x00,
+	0x95, 0x9e, 0xfe, 0x66, 0x55, 0x8a, 0x85, 0xff, 0x1e, 0xb5, 0xff, 0xb6, 0xff, 0xe6, 0x74, 0xf2,
+	0xc9, 0xeb, 0x43, 0x1c, 0x5c, 0x39, 0x65, 0x0b, 0xdc, 0x3a, 0x96, 0xbf, 0x5e, 0x71, 0x08, 0x5d,
+	0xa0, 0x62, 0xc5, 0x51, 0xb6, 0xb6, 0xbe, 0xbc, 0x2d, 0xd7, 0xb9, 0x18, 0x48, 0xab, 0x9f, 0xa9,
+	0xa6, 0x7d, 0x90, 0x6b, 0x0c, 0x97, 0x8a, 0xd3, 0x68, 0xc3, 0xc4, 0x55, 0x27, 0xbf, 0xae, 0x69,
+	0xa4, 0xcf, 0x39, 0x39, 0x88, 0xb9, 0x5a, 0x38, 0xdc, 0x35, 0x92, 0xb6, 0xfa, 0x38, 0xd8, 0x48,
+	0xda, 0x29, 0x67, 0xc2, 0xee, 0x69, 0x82, 0xee, 0xea, 0x4e, 0xdc, 0x4b, 0xbd, 0xe7, 0x6a, 0xad,
+	0x78, 0x98, 0xeb, 0xbd, 0xee, 0xa8, 0xd7, 0xd2, 0x11, 0x71, 0xeb, 0xe6, 0xd4, 0x7a, 0xe9, 0x4e,
+	0x22, 0xff, 0xb7, 0x5a, 0x4e, 0x77, 0x5f, 0x5a, 0x87, 0xc8, 0x2f, 0xbc, 0xbf, 0x59, 0xc3, 0x94,
+	0xff, 0xea, 0xa3, 0x52, 0xef, 0x6d, 0x23, 0x76, 0x2e, 0x3d, 0x30, 0x6e, 0xbd, 0xf3, 0x52, 0x3c,
+	0xd7, 0x91, 0xf3, 0x5f, 0x73, 0x46, 0xd4, 0xc5, 0xcf, 0xac, 0x73, 0x62, 0x3e, 0x12, 0xdb, 0x30,
+	0xaa, 0xc1, 0x9c, 0x47, 0xe6, 0xe6, 0x41, 0xe5, 0xb1, 0x67, 0x6b, 0xad, 0x58, 0x5b, 0xb0, 0x0d,
+	0x28, 0x72, 0x6c, 0x2b, 0x82, 0x3f, 0xa7, 0x9a, 0xf6, 0xf9, 0xa4, 0x61, 0xa2, 0x8a, 0x43, 0xcb,
+	0x29, 0xdb, 0xc3, 0x55, 0xd5, 0x3a, 0x18, 0x90, 0xe1, 0xf7, 0xef, 0xd7, 0x4e, 0xe6, 0xe9, 0xff,
+	0x65, 0xfd, 0xda, 0xff, 0x05, 0x90, 0x7f, 0xaf, 0xf9, 0xc9, 0x75, 0x00, 0x00,
 }
@@ -0,0 +1,250 @@
+package walletrpc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/lightningnetwork/lnd/keychain"
+	"github.com/lightningnetwork/lnd/lnwallet"
+	"github.com/roasbeef/btcd/wire"
+	"golang.org/x/net/context"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+)
+
+var (
+	// Permissions maps the RPC calls of the WalletKit service to the
+	// macaroon permissions they require. Key derivation and address
+	// generation are gated by the address entity, while the calls that
+	// read or spend the funds of the wallet are gated by the onchain
+	// entity.
+	Permissions = map[string][]bakery.Op{
+		"/walletrpc.WalletKit/DeriveNextKey": {{
+			Entity: "address",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/DeriveKey": {{
+			Entity: "address",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/NextAddr": {{
+			Entity: "address",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/ListUnspent": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+		"/walletrpc.WalletKit/PublishTransaction": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/SendOutputs": {{
+			Entity: "onchain",
+			Action: "write",
+		}},
+		"/walletrpc.WalletKit/EstimateFee": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
+	}
+)
+
+// Config is the set of dependencies the WalletKit needs in order to carry out
+// its duties.
+type Config struct {
+	// Wallet is the wallet controller of the daemon, which is used to
+	// generate addresses, and to select and spend our outputs.
+	Wallet lnwallet.WalletController
+
+	// KeyRing is the key ring of the daemon, which is used to derive keys
+	// on behalf of the caller.
+	KeyRing keychain.KeyRing
+
+	// FeeEstimator is the fee estimator of the daemon, which is used to
+	// answer fee estimation requests.
+	FeeEstimator lnwallet.FeeEstimator
+}
+
+// WalletKit implements the WalletKit service, which exposes the low-level
+// wallet operations of the daemon, such as deriving keys, generating
+// addresses and crafting transactions.
+type WalletKit struct {
+	cfg *Config
+}
+
+// A compile time check to ensure that WalletKit fully implements the
+// WalletKitServer gRPC service.
+var _ WalletKitServer = (*WalletKit)(nil)
+
+// New creates and returns a new WalletKit backed by the passed config.
+func New(cfg *Config) *WalletKit {
+	return &WalletKit{
+		cfg: cfg,
+	}
+}
+
+// DeriveNextKey attempts to derive the *next* key within the key family
+// (account in BIP43) specified. This method should return the next external
+// child within this branch.
+func (w *WalletKit) DeriveNextKey(ctx context.Context,
+	req *KeyReq) (*KeyDescriptor, error) {
+
+	keyDesc, err := w.cfg.KeyRing.DeriveNextKey(
+		keychain.KeyFamily(req.KeyFamily),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDescriptor(keyDesc), nil
+}
+
+// DeriveKey attempts to derive an arbitrary key specified by the passed
+// KeyLocator.
+func (w *WalletKit) DeriveKey(ctx context.Context,
+	req *KeyLocator) (*KeyDescriptor, error) {
+
+	keyDesc, err := w.cfg.KeyRing.DeriveKey(keychain.KeyLocator{
+		Family: keychain.KeyFamily(req.KeyFamily),
+		Index:  uint32(req.KeyIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalKeyDescriptor(keyDesc), nil
+}
+
+// marshalKeyDescriptor converts the passed key descriptor into its RPC
+// counterpart.
+func marshalKeyDescriptor(keyDesc keychain.KeyDescriptor) *KeyDescriptor {
+	var rawKeyBytes []byte
+	if keyDesc.PubKey != nil {
+		rawKeyBytes = keyDesc.PubKey.SerializeCompressed()
+	}
+
+	return &KeyDescriptor{
+		RawKeyBytes: rawKeyBytes,
+		KeyLoc: &KeyLocator{
+			KeyFamily: int32(keyDesc.Family),
+			KeyIndex:  int32(keyDesc.Index),
+		},
+	}
+}
+
+// NextAddr returns the next unused address within the wallet.
+func (w *WalletKit) NextAddr(ctx context.Context,
+	req *AddrRequest) (*AddrResponse, error) {
+
+	addr, err := w.cfg.Wallet.NewAddress(lnwallet.WitnessPubKey, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddrResponse{
+		Addr: addr.String(),
+	}, nil
+}
+
+// ListUnspent returns all the unspent witness outputs of the wallet that have
+// at least the specified number of confirmations.
+func (w *WalletKit) ListUnspent(ctx context.Context,
+	req *ListUnspentRequest) (*ListUnspentResponse, error) {
+
+	utxos, err := w.cfg.Wallet.ListUnspentWitness(req.MinConfs)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcUtxos := make([]*Utxo, 0, len(utxos))
+	for _, utxo := range utxos {
+		rpcUtxos = append(rpcUtxos, &Utxo{
+			TxidStr:     utxo.Hash.String(),
+			OutputIndex: utxo.Index,
+			AmountSat:   int64(utxo.Value),
+			PkScript:    utxo.PkScript,
+		})
+	}
+
+	return &ListUnspentResponse{
+		Utxos: rpcUtxos,
+	}, nil
+}
+
+// PublishTransaction attempts to publish the passed transaction to the
+// network. Once this returns without an error, the wallet will continually
+// attempt to re-broadcast the transaction on start up, until it enters the
+// chain.
+func (w *WalletKit) PublishTransaction(ctx context.Context,
+	req *Transaction) (*PublishResponse, error) {
+
+	tx := &wire.MsgTx{}
+	txReader := bytes.NewReader(req.TxHex)
+	if err := tx.Deserialize(txReader); err != nil {
+		return nil, fmt.Errorf("unable to decode transaction: %v", err)
+	}
+
+	if err := w.cfg.Wallet.PublishTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return &PublishResponse{}, nil
+}
+
+// SendOutputs is similar to the existing SendMany call in the Lightning
+// service, but it allows the caller to specify the outputs as raw output
+// scripts rather than addresses. The wallet will select its own inputs and
+// add a change output if needed, and then broadcast the transaction.
+func (w *WalletKit) SendOutputs(ctx context.Context,
+	req *SendOutputsRequest) (*SendOutputsResponse, error) {
+
+	switch {
+	case len(req.Outputs) == 0:
+		return nil, fmt.Errorf("at least one output must be specified")
+
+	case req.SatPerVbyte <= 0:
+		return nil, fmt.Errorf("a positive fee rate must be specified")
+	}
+
+	outputsToCreate := make([]*wire.TxOut, 0, len(req.Outputs))
+	for _, output := range req.Outputs {
+		outputsToCreate = append(outputsToCreate, &wire.TxOut{
+			Value:    output.Value,
+			PkScript: output.PkScript,
+		})
+	}
+
+	txid, err := w.cfg.Wallet.SendOutputs(
+		outputsToCreate, lnwallet.SatPerVByte(req.SatPerVbyte),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendOutputsResponse{
+		Txid: txid.String(),
+	}, nil
+}
+
+// EstimateFee attempts to query the internal fee estimator of the wallet to
+// determine the fee (in sat/vbyte) to attach to a transaction in order to
+// achieve the confirmation target.
+func (w *WalletKit) EstimateFee(ctx context.Context,
+	req *EstimateFeeRequest) (*EstimateFeeResponse, error) {
+
+	if req.ConfTarget < 1 {
+		return nil, fmt.Errorf("confirmation target must be greater " +
+			"than 0")
+	}
+
+	satPerVByte, err := w.cfg.FeeEstimator.EstimateFeePerVSize(
+		uint32(req.ConfTarget),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EstimateFeeResponse{
+		SatPerVbyte: int64(satPerVByte),
+	}, nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/roasbeef/btcwallet/chain"
 	"github.com/roasbeef/btcwallet/waddrmgr"
 	base "github.com/roasbeef/btcwallet/wallet"
+	"github.com/roasbeef/btcwallet/wallet/txauthor"
 	"github.com/roasbeef/btcwallet/walletdb"
 )
 
@@ -273,6 +274,21 @@ func (b *BtcWallet) SendOutputs(outputs []*wire.TxOut,
 	return b.wallet.SendOutputs(outputs, defaultAccount, 1, feeSatPerKB)
 }
 
+// CreateSimpleTx creates a Bitcoin transaction paying to the specified
+// outputs, without broadcasting it. In the case the wallet has insufficient
+// funds, or the outputs are non-standard, a non-nil error will be be returned.
+//
+// This is a part of the WalletController interface.
+func (b *BtcWallet) CreateSimpleTx(outputs []*wire.TxOut,
+	feeRate lnwallet.SatPerVByte) (*txauthor.AuthoredTx, error) {
+
+	// The fee rate is passed in using units of sat/vbyte, so we'll scale
+	// this up to sat/KB as the CreateSimpleTx method requires this unit.
+	feeSatPerKB := btcutil.Amount(feeRate * 1000)
+
+	return b.wallet.CreateSimpleTx(defaultAccount, outputs, 1, feeSatPerKB)
+}
+
 // LockOutpoint marks an outpoint as locked meaning it will no longer be deemed
 // as eligible for coin selection. Locking outputs are utilized in order to
 // avoid race conditions when selecting inputs for usage when funding a
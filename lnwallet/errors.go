@@ -79,6 +79,14 @@ func ErrMinHtlcTooLarge(minHtlc,
 	}
 }
 
+// ErrChanRejected returns an error indicating that we refuse to accept the
+// channel proposed by the remote party for the given reason.
+func ErrChanRejected(reason string) ReservationError {
+	return ReservationError{
+		fmt.Errorf("channel rejected: %v", reason),
+	}
+}
+
 // ErrMaxHtlcNumTooLarge returns an error indicating that the 'max HTLCs in
 // flight' value the remote required is too large to be accepted.
 func ErrMaxHtlcNumTooLarge(maxHtlc, maxMaxHtlc uint16) ReservationError {
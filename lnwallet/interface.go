@@ -9,6 +9,7 @@ import (
 	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcwallet/wallet/txauthor"
 )
 
 // ErrNotMine is an error denoting that a WalletController instance is unable
@@ -152,6 +153,18 @@ type WalletController interface {
 	SendOutputs(outputs []*wire.TxOut,
 		feeRate SatPerVByte) (*chainhash.Hash, error)
 
+	// CreateSimpleTx creates a Bitcoin transaction paying to the specified
+	// outputs, funded by the wallet at the passed fee rate expressed in
+	// sat/vbyte, without broadcasting it. This allows callers to learn the
+	// fee such a transaction would pay. In the case the wallet has
+	// insufficient funds, or the outputs are non-standard, an error should
+	// be returned.
+	//
+	// NOTE: The inputs selected for the transaction aren't locked, so the
+	// transaction isn't guaranteed to remain valid.
+	CreateSimpleTx(outputs []*wire.TxOut,
+		feeRate SatPerVByte) (*txauthor.AuthoredTx, error)
+
 	// ListUnspentWitness returns all unspent outputs which are version 0
 	// witness programs. The 'confirms' parameter indicates the minimum
 	// number of confirmations an output needs in order to be returned by
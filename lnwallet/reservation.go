@@ -276,6 +276,16 @@ func (r *ChannelReservation) SetNumConfsRequired(numConfs uint16) {
 	r.partialState.NumConfsRequired = numConfs
 }
 
+// SetThawHeight sets the block height until which the channel is frozen,
+// during which we refuse to initiate a cooperative close of it. A height of
+// zero indicates the channel isn't frozen.
+func (r *ChannelReservation) SetThawHeight(thawHeight uint32) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.partialState.ThawHeight = thawHeight
+}
+
 // CommitConstraints takes the constraints that the remote party specifies for
 // the type of commitments that we can generate for them. These constraints
 // include several parameters that serve as flow control restricting the amount
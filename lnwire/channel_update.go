@@ -22,8 +22,20 @@ const (
 	// selected by the ChanUpdateDirection bit is to be treated as being
 	// disabled.
 	ChanUpdateDisabled
+
+	// ChanUpdateOptionMaxHtlc is a bit that indicates whether the
+	// optional HtlcMaximumMsat field is present in the ChannelUpdate. As
+	// the most significant byte of the flags is the message_flags byte of
+	// the wire message, this is the least-significant bit of that byte.
+	ChanUpdateOptionMaxHtlc ChanUpdateFlag = 1 << 8
 )
 
+// HasMaxHtlc returns true if the ChanUpdateOptionMaxHtlc bit is set, meaning
+// the optional HtlcMaximumMsat field is present.
+func (c ChanUpdateFlag) HasMaxHtlc() bool {
+	return c&ChanUpdateOptionMaxHtlc != 0
+}
+
 // ChannelUpdate message is used after channel has been initially announced.
 // Each side independently announces its fees and minimum expiry for HTLCs and
 // other parameters. Also this message is used to redeclare initially set
@@ -52,7 +64,8 @@ type ChannelUpdate struct {
 	// least-significant bit must be set to 0 if the creating node
 	// corresponds to the first node in the previously sent channel
 	// announcement and 1 otherwise. If the second bit is set, then the
-	// channel is set to be disabled.
+	// channel is set to be disabled. If the ninth bit is set, then the
+	// optional HtlcMaximumMsat field is present.
 	Flags ChanUpdateFlag
 
 	// TimeLockDelta is the minimum number of blocks this node requires to
@@ -73,6 +86,11 @@ type ChannelUpdate struct {
 	// FeeRate is the fee rate that will be charged per millionth of a
 	// satoshi.
 	FeeRate uint32
+
+	// HtlcMaximumMsat is the maximum HTLC value which will be accepted.
+	// This field is only present on the wire if the
+	// ChanUpdateOptionMaxHtlc bit is set within the Flags.
+	HtlcMaximumMsat MilliSatoshi
 }
 
 // A compile time check to ensure ChannelUpdate implements the lnwire.Message
@@ -84,7 +102,7 @@ var _ Message = (*ChannelUpdate)(nil)
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
-	return readElements(r,
+	err := readElements(r,
 		&a.Signature,
 		a.ChainHash[:],
 		&a.ShortChannelID,
@@ -95,6 +113,17 @@ func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
 		&a.BaseFee,
 		&a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	// The maximum HTLC value is only present if signalled within the
+	// flags.
+	if a.Flags.HasMaxHtlc() {
+		return readElements(r, &a.HtlcMaximumMsat)
+	}
+
+	return nil
 }
 
 // Encode serializes the target ChannelUpdate into the passed io.Writer
@@ -102,7 +131,7 @@ func (a *ChannelUpdate) Decode(r io.Reader, pver uint32) error {
 //
 // This is part of the lnwire.Message interface.
 func (a *ChannelUpdate) Encode(w io.Writer, pver uint32) error {
-	return writeElements(w,
+	err := writeElements(w,
 		a.Signature,
 		a.ChainHash[:],
 		a.ShortChannelID,
@@ -113,6 +142,17 @@ func (a *ChannelUpdate) Encode(w io.Writer, pver uint32) error {
 		a.BaseFee,
 		a.FeeRate,
 	)
+	if err != nil {
+		return err
+	}
+
+	// Only write the maximum HTLC value if it's signalled within the
+	// flags.
+	if a.Flags.HasMaxHtlc() {
+		return writeElements(w, a.HtlcMaximumMsat)
+	}
+
+	return nil
 }
 
 // MsgType returns the integer uniquely identifying this message type on the
@@ -157,6 +197,9 @@ func (a *ChannelUpdate) MaxPayloadLength(pver uint32) uint32 {
 	// FeeProportionalMillionths - 4 bytes
 	length += 4
 
+	// HtlcMaximumMsat - 8 bytes
+	length += 8
+
 	return length
 }
 
@@ -180,5 +223,11 @@ func (a *ChannelUpdate) DataToSign() ([]byte, error) {
 		return nil, err
 	}
 
+	if a.Flags.HasMaxHtlc() {
+		if err := writeElements(&w, a.HtlcMaximumMsat); err != nil {
+			return nil, err
+		}
+	}
+
 	return w.Bytes(), nil
 }
@@ -503,6 +503,12 @@ func TestLightningWireProtocol(t *testing.T) {
 				return
 			}
 
+			// The maximum HTLC value is only encoded if the
+			// corresponding bit is set within the flags.
+			if req.Flags.HasMaxHtlc() {
+				req.HtlcMaximumMsat = MilliSatoshi(r.Int63())
+			}
+
 			v[0] = reflect.ValueOf(req)
 		},
 		MsgAnnounceSignatures: func(v []reflect.Value, r *rand.Rand) {
@@ -162,6 +162,34 @@ func setLogLevels(logLevel string) {
 	}
 }
 
+// subsystemLogLevels returns the current log level of each subsystem logger,
+// using the same names of the levels as the --debuglevel option.
+func subsystemLogLevels() map[string]string {
+	levels := make(map[string]string, len(subsystemLoggers))
+	for subsystemID, logger := range subsystemLoggers {
+		var level string
+		switch logger.Level() {
+		case btclog.LevelTrace:
+			level = "trace"
+		case btclog.LevelDebug:
+			level = "debug"
+		case btclog.LevelInfo:
+			level = "info"
+		case btclog.LevelWarn:
+			level = "warn"
+		case btclog.LevelError:
+			level = "error"
+		case btclog.LevelCritical:
+			level = "critical"
+		default:
+			level = "off"
+		}
+		levels[subsystemID] = level
+	}
+
+	return levels
+}
+
 // logClosure is used to provide a closure over expensive logging operations so
 // don't have to be performed when the logging level doesn't warrant it.
 type logClosure func() string
@@ -3,6 +3,7 @@ package macaroons
 import (
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/peer"
@@ -13,6 +14,12 @@ import (
 	"golang.org/x/net/context"
 )
 
+// CondLndCustom is the condition of custom caveats, whose argument is the
+// name of the caveat, optionally followed by a space and its condition. Custom
+// caveats aren't enforced by lnd itself, but by the RPC middleware responsible
+// for them.
+const CondLndCustom = "lnd-custom"
+
 // Constraint type adds a layer of indirection over macaroon caveats.
 type Constraint func(*macaroon.Macaroon) error
 
@@ -88,3 +95,138 @@ func IPLockChecker() (string, checkers.Func) {
 		return nil
 	}
 }
+
+// CustomConstraint adds a custom caveat with the given name and condition to
+// the macaroon. The calls made with the macaroon are then handed to the RPC
+// middleware responsible for the caveat, which enforces the condition.
+func CustomConstraint(name, condition string) func(*macaroon.Macaroon) error {
+	return func(mac *macaroon.Macaroon) error {
+		if name == "" || strings.Contains(name, " ") {
+			return fmt.Errorf("invalid custom caveat name %q", name)
+		}
+
+		arg := name
+		if condition != "" {
+			arg += " " + condition
+		}
+		caveat := checkers.Condition(CondLndCustom, arg)
+		return mac.AddFirstPartyCaveat([]byte(caveat))
+	}
+}
+
+// CustomChecker accepts all custom caveats, as their conditions are enforced
+// by the RPC middleware responsible for them rather than by lnd itself. Calls
+// made with a custom caveat no registered middleware is responsible for are
+// refused by the middleware registry instead. It is of the `Checker` type.
+func CustomChecker() (string, checkers.Func) {
+	return CondLndCustom, func(ctx context.Context, cond, arg string) error {
+		return nil
+	}
+}
+
+// CustomCaveatCondition returns the condition of the custom caveat with the
+// given name, and whether the macaroon carries such a caveat at all.
+func CustomCaveatCondition(mac *macaroon.Macaroon, name string) (string,
+	bool) {
+
+	prefix := CondLndCustom + " " + name
+	for _, caveat := range mac.Caveats() {
+		id := string(caveat.Id)
+		switch {
+		case id == prefix:
+			return "", true
+
+		case strings.HasPrefix(id, prefix+" "):
+			return strings.TrimPrefix(id, prefix+" "), true
+		}
+	}
+
+	return "", false
+}
+
+// CustomCaveat is a custom caveat of a macaroon, as added by
+// CustomConstraint.
+type CustomCaveat struct {
+	// Name is the name of the caveat.
+	Name string
+
+	// Condition is the condition of the caveat, which may be empty.
+	Condition string
+}
+
+// Constraints are the restrictions the first party caveats of a macaroon
+// impose on its use.
+type Constraints struct {
+	// Expiry is the earliest time after which the macaroon expires, or the
+	// zero time if it doesn't.
+	Expiry time.Time
+
+	// IPAddr is the IP address the macaroon is locked to, or nil if it
+	// isn't.
+	IPAddr net.IP
+
+	// CustomCaveats are the custom caveats of the macaroon, in the order
+	// in which they were added.
+	CustomCaveats []CustomCaveat
+
+	// Caveats are the conditions of all first party caveats of the
+	// macaroon, in the order in which they were added.
+	Caveats []string
+}
+
+// ParseConstraints returns the constraints imposed by the first party caveats
+// of the passed macaroon. It doesn't check whether the macaroon is valid.
+func ParseConstraints(mac *macaroon.Macaroon) (*Constraints, error) {
+	constraints := &Constraints{}
+	for _, caveat := range mac.Caveats() {
+		// Third party caveats are discharged by their location, rather
+		// than checked by us.
+		if caveat.Location != "" {
+			continue
+		}
+
+		id := string(caveat.Id)
+		constraints.Caveats = append(constraints.Caveats, id)
+
+		cond, arg, err := checkers.ParseCaveat(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch cond {
+		case checkers.CondTimeBefore:
+			expiry, err := time.Parse(time.RFC3339Nano, arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiry %q: %v",
+					arg, err)
+			}
+			if constraints.Expiry.IsZero() ||
+				expiry.Before(constraints.Expiry) {
+
+				constraints.Expiry = expiry
+			}
+
+		case "ipaddr":
+			ipAddr := net.ParseIP(arg)
+			if ipAddr == nil {
+				return nil, fmt.Errorf("invalid IP-lock address "+
+					"%q", arg)
+			}
+			constraints.IPAddr = ipAddr
+
+		case CondLndCustom:
+			parts := strings.SplitN(arg, " ", 2)
+			customCaveat := CustomCaveat{
+				Name: parts[0],
+			}
+			if len(parts) == 2 {
+				customCaveat.Condition = parts[1]
+			}
+			constraints.CustomCaveats = append(
+				constraints.CustomCaveats, customCaveat,
+			)
+		}
+	}
+
+	return constraints, nil
+}
@@ -146,13 +146,37 @@ func (svc *Service) StreamServerInterceptor(
 func (svc *Service) ValidateMacaroon(ctx context.Context,
 	requiredPermissions []bakery.Op) error {
 
+	mac, err := MacaroonFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	return svc.CheckMacAuth(ctx, mac, requiredPermissions)
+}
+
+// CheckMacAuth checks that the passed macaroon is valid, and grants the passed
+// permissions. Caveats which depend on the request, such as IP locks, are
+// checked against the passed context.
+func (svc *Service) CheckMacAuth(ctx context.Context, mac *macaroon.Macaroon,
+	requiredPermissions []bakery.Op) error {
+
+	// Check the method being called against the permitted operation and
+	// the expiration time and IP address and return the result.
+	authChecker := svc.Checker.Auth(macaroon.Slice{mac})
+	_, err := authChecker.Allow(ctx, requiredPermissions...)
+	return err
+}
+
+// MacaroonFromContext returns the macaroon a request was made with, which is
+// expected to be encoded as request metadata using the key "macaroon".
+func MacaroonFromContext(ctx context.Context) (*macaroon.Macaroon, error) {
 	// Get macaroon bytes from context and unmarshal into macaroon.
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return fmt.Errorf("unable to get metadata from context")
+		return nil, fmt.Errorf("unable to get metadata from context")
 	}
 	if len(md["macaroon"]) != 1 {
-		return fmt.Errorf("expected 1 macaroon, got %d",
+		return nil, fmt.Errorf("expected 1 macaroon, got %d",
 			len(md["macaroon"]))
 	}
 
@@ -161,19 +185,15 @@ func (svc *Service) ValidateMacaroon(ctx context.Context,
 	// representation.
 	macBytes, err := hex.DecodeString(md["macaroon"][0])
 	if err != nil {
-		return err
+		return nil, err
 	}
 	mac := &macaroon.Macaroon{}
 	err = mac.UnmarshalBinary(macBytes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Check the method being called against the permitted operation and
-	// the expiration time and IP address and return the result.
-	authChecker := svc.Checker.Auth(macaroon.Slice{mac})
-	_, err = authChecker.Allow(ctx, requiredPermissions...)
-	return err
+	return mac, nil
 }
 
 // Close closes the database that underlies the RootKeyStore and zeroes the
@@ -187,3 +207,18 @@ func (svc *Service) Close() error {
 func (svc *Service) CreateUnlock(password *[]byte) error {
 	return svc.rks.CreateUnlock(password)
 }
+
+// ListMacaroonIDs returns the IDs of all root keys within the underlying root
+// key store.
+func (svc *Service) ListMacaroonIDs(ctxt context.Context) ([][]byte, error) {
+	return svc.rks.ListMacaroonIDs(ctxt)
+}
+
+// DeleteMacaroonID removes the root key with the passed ID from the underlying
+// root key store, which invalidates all macaroons derived from it. It returns
+// whether the root key existed.
+func (svc *Service) DeleteMacaroonID(ctxt context.Context,
+	rootKeyID []byte) (bool, error) {
+
+	return svc.rks.DeleteMacaroonID(ctxt, rootKeyID)
+}
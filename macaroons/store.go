@@ -1,6 +1,7 @@
 package macaroons
 
 import (
+	"bytes"
 	"crypto/rand"
 	"fmt"
 	"io"
@@ -21,11 +22,10 @@ var (
 	// rootKeyBucketName is the name of the root key store bucket.
 	rootKeyBucketName = []byte("macrootkeys")
 
-	// defaultRootKeyID is the ID of the default root key. The first is
-	// just 0, to emulate the memory storage that comes with bakery.
-	//
-	// TODO(aakselrod): Add support for key rotation.
-	defaultRootKeyID = []byte("0")
+	// DefaultRootKeyID is the ID of the default root key, which the
+	// default macaroons are derived from. The first is just 0, to emulate
+	// the memory storage that comes with bakery.
+	DefaultRootKeyID = []byte("0")
 
 	// encryptedKeyID is the name of the database key that stores the
 	// encryption key, encrypted with a salted + hashed password. The
@@ -42,8 +42,44 @@ var (
 
 	// ErrPasswordRequired specifies that a nil password has been passed.
 	ErrPasswordRequired = fmt.Errorf("a non-nil password is required")
+
+	// ErrDeletionForbidden specifies that the default root key can't be
+	// deleted, as the default macaroons are derived from it.
+	ErrDeletionForbidden = fmt.Errorf("the default root key can't be " +
+		"deleted")
+
+	// ErrKeyValueForbidden specifies that the passed root key ID is
+	// reserved for the encryption key of the store.
+	ErrKeyValueForbidden = fmt.Errorf("root key ID is reserved")
 )
 
+// rootKeyIDContextKey is the key under which the ID of the root key new
+// macaroons are derived from is stored within a context.
+type rootKeyIDContextKey struct{}
+
+// ContextWithRootKeyID returns a copy of the passed context, which instructs
+// the RootKeyStorage to derive new macaroons from the root key with the passed
+// ID, rather than from the default root key.
+func ContextWithRootKeyID(ctx context.Context, id []byte) context.Context {
+	return context.WithValue(ctx, rootKeyIDContextKey{}, id)
+}
+
+// rootKeyIDFromContext returns the ID of the root key new macaroons are to be
+// derived from, as set by ContextWithRootKeyID, defaulting to the default root
+// key. The passed context may be nil.
+func rootKeyIDFromContext(ctx context.Context) []byte {
+	if ctx == nil {
+		return DefaultRootKeyID
+	}
+
+	id, ok := ctx.Value(rootKeyIDContextKey{}).([]byte)
+	if !ok || len(id) == 0 {
+		return DefaultRootKeyID
+	}
+
+	return id
+}
+
 // RootKeyStorage implements the bakery.RootKeyStorage interface.
 type RootKeyStorage struct {
 	*bolt.DB
@@ -148,14 +184,18 @@ func (r *RootKeyStorage) Get(_ context.Context, id []byte) ([]byte, error) {
 }
 
 // RootKey implements the RootKey method for the bakery.RootKeyStorage
-// interface.
-// TODO(aakselrod): Add support for key rotation.
-func (r *RootKeyStorage) RootKey(_ context.Context) ([]byte, []byte, error) {
+// interface. The root key is the one whose ID is set within the passed context
+// through ContextWithRootKeyID, or the default root key otherwise. It's
+// created if it doesn't exist yet.
+func (r *RootKeyStorage) RootKey(ctx context.Context) ([]byte, []byte, error) {
 	if r.encKey == nil {
 		return nil, nil, ErrStoreLocked
 	}
 	var rootKey []byte
-	id := defaultRootKeyID
+	id := rootKeyIDFromContext(ctx)
+	if bytes.Equal(id, encryptedKeyID) {
+		return nil, nil, ErrKeyValueForbidden
+	}
 	err := r.Update(func(tx *bolt.Tx) error {
 		ns := tx.Bucket(rootKeyBucketName)
 		dbKey := ns.Get(id)
@@ -193,6 +233,65 @@ func (r *RootKeyStorage) RootKey(_ context.Context) ([]byte, []byte, error) {
 	return rootKey, id, nil
 }
 
+// ListMacaroonIDs returns the IDs of all root keys within the store.
+func (r *RootKeyStorage) ListMacaroonIDs(_ context.Context) ([][]byte, error) {
+	var rootKeyIDs [][]byte
+	err := r.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rootKeyBucketName)
+		return bucket.ForEach(func(k, v []byte) error {
+			// The encryption key is stored alongside the root
+			// keys, but isn't one itself.
+			if bytes.Equal(k, encryptedKeyID) {
+				return nil
+			}
+
+			id := make([]byte, len(k))
+			copy(id, k)
+			rootKeyIDs = append(rootKeyIDs, id)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rootKeyIDs, nil
+}
+
+// DeleteMacaroonID removes the root key with the passed ID from the store,
+// which invalidates all macaroons derived from it. It returns whether the root
+// key existed. The default root key can't be deleted.
+func (r *RootKeyStorage) DeleteMacaroonID(_ context.Context,
+	id []byte) (bool, error) {
+
+	switch {
+	case len(id) == 0:
+		return false, fmt.Errorf("root key ID must not be empty")
+
+	case bytes.Equal(id, DefaultRootKeyID):
+		return false, ErrDeletionForbidden
+
+	case bytes.Equal(id, encryptedKeyID):
+		return false, ErrKeyValueForbidden
+	}
+
+	var deleted bool
+	err := r.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rootKeyBucketName)
+		if bucket.Get(id) == nil {
+			return nil
+		}
+
+		deleted = true
+		return bucket.Delete(id)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
 // Close closes the underlying database and zeroes the encryption key stored
 // in memory.
 func (r *RootKeyStorage) Close() error {
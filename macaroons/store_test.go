@@ -12,6 +12,8 @@ import (
 	"github.com/lightningnetwork/lnd/macaroons"
 
 	"github.com/roasbeef/btcwallet/snacl"
+
+	"golang.org/x/net/context"
 )
 
 func TestStore(t *testing.T) {
@@ -135,3 +137,108 @@ func TestStore(t *testing.T) {
 			rootID, id)
 	}
 }
+
+// TestStoreRootKeyIDs tests that root keys with different IDs can be created,
+// listed and deleted, and that the default root key can't be deleted.
+func TestStoreRootKeyIDs(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "macaroonstore-")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	db, err := bolt.Open(path.Join(tempDir, "weks.db"), 0600,
+		bolt.DefaultOptions)
+	if err != nil {
+		t.Fatalf("Error opening store DB: %v", err)
+	}
+
+	store, err := macaroons.NewRootKeyStorage(db)
+	if err != nil {
+		db.Close()
+		t.Fatalf("Error creating root key store: %v", err)
+	}
+	defer store.Close()
+
+	pw := []byte("weks")
+	err = store.CreateUnlock(&pw)
+	if err != nil {
+		t.Fatalf("Error creating store encryption key: %v", err)
+	}
+
+	ctx := context.Background()
+	defaultKey, id, err := store.RootKey(ctx)
+	if err != nil {
+		t.Fatalf("Error getting root key from store: %v", err)
+	}
+	if !bytes.Equal(id, macaroons.DefaultRootKeyID) {
+		t.Fatalf("Root ID doesn't match: expected %v, got %v",
+			macaroons.DefaultRootKeyID, id)
+	}
+
+	// A root key with another ID should be created on demand, and differ
+	// from the default root key.
+	otherID := []byte("1")
+	otherCtx := macaroons.ContextWithRootKeyID(ctx, otherID)
+	otherKey, id, err := store.RootKey(otherCtx)
+	if err != nil {
+		t.Fatalf("Error getting root key from store: %v", err)
+	}
+	if !bytes.Equal(id, otherID) {
+		t.Fatalf("Root ID doesn't match: expected %v, got %v",
+			otherID, id)
+	}
+	if bytes.Equal(otherKey, defaultKey) {
+		t.Fatalf("Expected root keys to differ")
+	}
+
+	// The ID of the encryption key can't be used for a root key.
+	_, _, err = store.RootKey(
+		macaroons.ContextWithRootKeyID(ctx, []byte("enckey")),
+	)
+	if err != macaroons.ErrKeyValueForbidden {
+		t.Fatalf("Received %v instead of ErrKeyValueForbidden", err)
+	}
+
+	ids, err := store.ListMacaroonIDs(ctx)
+	if err != nil {
+		t.Fatalf("Error listing root key IDs: %v", err)
+	}
+	if len(ids) != 2 || !bytes.Equal(ids[0], macaroons.DefaultRootKeyID) ||
+		!bytes.Equal(ids[1], otherID) {
+
+		t.Fatalf("Unexpected root key IDs: %s", ids)
+	}
+
+	_, err = store.DeleteMacaroonID(ctx, macaroons.DefaultRootKeyID)
+	if err != macaroons.ErrDeletionForbidden {
+		t.Fatalf("Received %v instead of ErrDeletionForbidden", err)
+	}
+
+	deleted, err := store.DeleteMacaroonID(ctx, otherID)
+	if err != nil {
+		t.Fatalf("Error deleting root key: %v", err)
+	}
+	if !deleted {
+		t.Fatalf("Expected root key to be deleted")
+	}
+	if _, err := store.Get(ctx, otherID); err == nil {
+		t.Fatalf("Expected deleted root key to be gone")
+	}
+
+	deleted, err = store.DeleteMacaroonID(ctx, otherID)
+	if err != nil {
+		t.Fatalf("Error deleting root key: %v", err)
+	}
+	if deleted {
+		t.Fatalf("Expected no root key to be deleted")
+	}
+
+	ids, err = store.ListMacaroonIDs(ctx)
+	if err != nil {
+		t.Fatalf("Error listing root key IDs: %v", err)
+	}
+	if len(ids) != 1 || !bytes.Equal(ids[0], macaroons.DefaultRootKeyID) {
+		t.Fatalf("Unexpected root key IDs: %s", ids)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/contractcourt"
 	"github.com/lightningnetwork/lnd/keychain"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/roasbeef/btcd/btcec"
@@ -14,6 +15,7 @@ import (
 	"github.com/roasbeef/btcd/txscript"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
+	"github.com/roasbeef/btcwallet/wallet/txauthor"
 )
 
 // The block height returned by the mock BlockChainIO's GetBestBlock.
@@ -224,6 +226,12 @@ func (*mockWalletController) SendOutputs(outputs []*wire.TxOut,
 	return nil, nil
 }
 
+func (*mockWalletController) CreateSimpleTx(outputs []*wire.TxOut,
+	_ lnwallet.SatPerVByte) (*txauthor.AuthoredTx, error) {
+
+	return nil, nil
+}
+
 // ListUnspentWitness is called by the wallet when doing coin selection. We just
 // need one unspent for the funding transaction.
 func (*mockWalletController) ListUnspentWitness(confirms int32) ([]*lnwallet.Utxo, error) {
@@ -292,6 +300,13 @@ type mockPreimageCache struct {
 	preimageMap map[[32]byte][]byte
 }
 
+func (m *mockPreimageCache) SubscribeUpdates() *contractcourt.WitnessSubscription {
+	return &contractcourt.WitnessSubscription{
+		WitnessUpdates:     make(chan []byte),
+		CancelSubscription: func() {},
+	}
+}
+
 func (m *mockPreimageCache) LookupPreimage(hash []byte) ([]byte, bool) {
 	m.Lock()
 	defer m.Unlock()
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// paymentEvent is a single change of the persisted state of an outgoing
+// payment. It either records an HTLC attempt made to complete the payment, or
+// the final state of the payment.
+type paymentEvent struct {
+	// paymentHash is the payment hash of the payment.
+	paymentHash [32]byte
+
+	// attemptIndex is the index of the recorded attempt, in the order in
+	// which the attempts of the payment were made.
+	attemptIndex uint32
+
+	// attempt is the recorded HTLC attempt. It's nil if the event reports
+	// the final state of the payment.
+	attempt *channeldb.HTLCAttempt
+
+	// state is the final state of the payment. It's nil if the event
+	// records an HTLC attempt.
+	state *channeldb.PaymentState
+}
+
+// paymentNotifier dispatches the changes of the persisted state of outgoing
+// payments to the clients tracking them. The changes are reported once
+// they've been written to the database, so the state a client fetches after
+// registering never lags behind the events it receives afterwards. Each
+// client receives the events in the order they were reported.
+type paymentNotifier struct {
+	stopped uint32 // To be used atomically.
+
+	clientMtx           sync.Mutex
+	nextClientID        uint32
+	notificationClients map[uint32]*paymentSubscription
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newPaymentNotifier creates a new payment notifier without any clients.
+func newPaymentNotifier() *paymentNotifier {
+	return &paymentNotifier{
+		notificationClients: make(map[uint32]*paymentSubscription),
+		quit:                make(chan struct{}),
+	}
+}
+
+// Stop signals the goroutines delivering events to clients to exit, and waits
+// for them to do so.
+func (p *paymentNotifier) Stop() error {
+	if !atomic.CompareAndSwapUint32(&p.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Payment notifier shutting down")
+
+	close(p.quit)
+	p.wg.Wait()
+
+	return nil
+}
+
+// NotifyAttempt notifies the clients tracking the payment to the passed
+// payment hash that the passed HTLC attempt was recorded under the passed
+// index.
+func (p *paymentNotifier) NotifyAttempt(paymentHash [32]byte, index uint32,
+	attempt *channeldb.HTLCAttempt) {
+
+	p.notifyClients(&paymentEvent{
+		paymentHash:  paymentHash,
+		attemptIndex: index,
+		attempt:      attempt,
+	})
+}
+
+// NotifyResolved notifies the clients tracking the payment to the passed
+// payment hash that the payment reached the passed final state.
+func (p *paymentNotifier) NotifyResolved(paymentHash [32]byte,
+	state *channeldb.PaymentState) {
+
+	p.notifyClients(&paymentEvent{
+		paymentHash: paymentHash,
+		state:       state,
+	})
+}
+
+// notifyClients queues the passed event for delivery to all clients tracking
+// the payment it concerns.
+func (p *paymentNotifier) notifyClients(event *paymentEvent) {
+	p.clientMtx.Lock()
+	defer p.clientMtx.Unlock()
+
+	for _, client := range p.notificationClients {
+		if client.paymentHash != event.paymentHash {
+			continue
+		}
+
+		client.queueMtx.Lock()
+		client.queue = append(client.queue, event)
+		client.queueMtx.Unlock()
+
+		select {
+		case client.queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// paymentSubscription represents an intent to receive the changes of the
+// persisted state of a single outgoing payment. Each event is sent over the
+// Updates channel, in the order the events were reported.
+type paymentSubscription struct {
+	Updates chan *paymentEvent
+
+	// paymentHash is the payment hash of the tracked payment.
+	paymentHash [32]byte
+
+	// queue holds the events which weren't delivered to the client yet,
+	// and queued is signaled whenever an event is appended to it.
+	queueMtx sync.Mutex
+	queue    []*paymentEvent
+	queued   chan struct{}
+
+	notifier *paymentNotifier
+	id       uint32
+	quit     chan struct{}
+}
+
+// Cancel unregisters the paymentSubscription, freeing any previously
+// allocated resources.
+func (s *paymentSubscription) Cancel() {
+	s.notifier.clientMtx.Lock()
+	if _, ok := s.notifier.notificationClients[s.id]; ok {
+		delete(s.notifier.notificationClients, s.id)
+		close(s.quit)
+	}
+	s.notifier.clientMtx.Unlock()
+}
+
+// SubscribePayment returns a paymentSubscription which allows the caller to
+// receive async notifications whenever the state of the payment to the passed
+// payment hash changes.
+func (p *paymentNotifier) SubscribePayment(
+	paymentHash [32]byte) *paymentSubscription {
+
+	client := &paymentSubscription{
+		Updates:     make(chan *paymentEvent),
+		paymentHash: paymentHash,
+		queued:      make(chan struct{}, 1),
+		notifier:    p,
+		quit:        make(chan struct{}),
+	}
+
+	p.clientMtx.Lock()
+	p.notificationClients[p.nextClientID] = client
+	client.id = p.nextClientID
+	p.nextClientID++
+	p.clientMtx.Unlock()
+
+	p.wg.Add(1)
+	go client.deliverEvents()
+
+	return client
+}
+
+// deliverEvents sends the queued events to the client in order, until the
+// subscription is canceled or the notifier is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *paymentSubscription) deliverEvents() {
+	defer s.notifier.wg.Done()
+
+	for {
+		s.queueMtx.Lock()
+		var next *paymentEvent
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+		}
+		s.queueMtx.Unlock()
+
+		if next == nil {
+			select {
+			case <-s.queued:
+				continue
+			case <-s.quit:
+				return
+			case <-s.notifier.quit:
+				return
+			}
+		}
+
+		select {
+		case s.Updates <- next:
+		case <-s.quit:
+			return
+		case <-s.notifier.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// TestPaymentNotifierFiltering tests that each client of the payment notifier
+// only receives the events of the payment it tracks, in the order they were
+// reported, and none after it canceled its subscription.
+func TestPaymentNotifierFiltering(t *testing.T) {
+	t.Parallel()
+
+	notifier := newPaymentNotifier()
+	defer notifier.Stop()
+
+	tracked := [32]byte{1}
+	other := [32]byte{2}
+	client := notifier.SubscribePayment(tracked)
+
+	attempt := &channeldb.HTLCAttempt{
+		AttemptTime: time.Unix(1, 0),
+	}
+	failed := &channeldb.HTLCAttempt{
+		AttemptTime: time.Unix(1, 0),
+		ResolveTime: time.Unix(2, 0),
+		Failure:     "unknown next peer",
+	}
+	state := &channeldb.PaymentState{
+		Status: channeldb.StatusSucceeded,
+	}
+
+	// All events are reported before any is received, to ensure they're
+	// queued rather than dropped or reordered. The events of the other
+	// payment should be skipped.
+	notifier.NotifyAttempt(tracked, 0, attempt)
+	notifier.NotifyAttempt(other, 0, attempt)
+	notifier.NotifyAttempt(tracked, 0, failed)
+	notifier.NotifyAttempt(tracked, 1, attempt)
+	notifier.NotifyResolved(other, state)
+	notifier.NotifyResolved(tracked, state)
+
+	expected := []paymentEvent{
+		{paymentHash: tracked, attemptIndex: 0, attempt: attempt},
+		{paymentHash: tracked, attemptIndex: 0, attempt: failed},
+		{paymentHash: tracked, attemptIndex: 1, attempt: attempt},
+		{paymentHash: tracked, state: state},
+	}
+	for i, expectedEvent := range expected {
+		select {
+		case event := <-client.Updates:
+			if *event != expectedEvent {
+				t.Fatalf("expected event %v to be %+v, got %+v",
+					i, expectedEvent, *event)
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("event %v not received", i)
+		}
+	}
+
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("received unexpected event: %+v", *event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Once canceled, the client shouldn't receive any further events.
+	client.Cancel()
+	notifier.NotifyResolved(tracked, state)
+
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("received event after canceling: %+v", *event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -95,6 +95,10 @@ type peer struct {
 	// our last ping message.
 	pingLastSend int64
 
+	// lastGossip is the Unix time expressed in seconds of the most recent
+	// gossip message we've received from the peer.
+	lastGossip uint32
+
 	// MUST be used atomically.
 	started    int32
 	disconnect int32
@@ -392,6 +396,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 				BaseFee:       selfPolicy.FeeBaseMSat,
 				FeeRate:       selfPolicy.FeeProportionalMillionths,
 				TimeLockDelta: uint32(selfPolicy.TimeLockDelta),
+				MaxHTLC:       selfPolicy.MaxHTLC,
 			}
 		} else {
 			forwardingPolicy = &p.server.cc.routingPolicy
@@ -418,6 +423,7 @@ func (p *peer) loadActiveChannels(chans []*channeldb.OpenChannel) error {
 			),
 			DebugHTLC:      cfg.DebugHTLC,
 			HodlHTLC:       cfg.HodlHTLC,
+			AcceptKeySend:  cfg.AcceptKeySend,
 			Registry:       p.server.invoices,
 			Switch:         p.server.htlcSwitch,
 			Circuits:       p.server.htlcSwitch.CircuitModifier(),
@@ -726,6 +732,13 @@ func newDiscMsgStream(p *peer) *msgStream {
 		"Update stream for gossiper exited",
 		1000,
 		func(msg lnwire.Message) {
+			switch m := msg.(type) {
+			case *lnwire.ChannelUpdate:
+				p.updateLastGossip(m.Timestamp)
+			case *lnwire.NodeAnnouncement:
+				p.updateLastGossip(m.Timestamp)
+			}
+
 			p.server.authGossiper.ProcessRemoteAnnouncement(msg,
 				p.addr.IdentityKey)
 		},
@@ -1395,6 +1408,7 @@ out:
 				),
 				DebugHTLC:      cfg.DebugHTLC,
 				HodlHTLC:       cfg.HodlHTLC,
+				AcceptKeySend:  cfg.AcceptKeySend,
 				Registry:       p.server.invoices,
 				Switch:         p.server.htlcSwitch,
 				Circuits:       p.server.htlcSwitch.CircuitModifier(),
@@ -1627,6 +1641,26 @@ func (p *peer) handleLocalCloseReq(req *htlcswitch.ChanClose) {
 	// out this channel on-chain, so we execute the cooperative channel
 	// closure workflow.
 	case htlcswitch.CloseRegular:
+		// A frozen channel can't be cooperatively closed by us until
+		// its thaw height has been reached.
+		if thawHeight := channel.State().ThawHeight; thawHeight > 0 {
+			_, bestHeight, err := p.server.cc.chainIO.GetBestBlock()
+			if err != nil {
+				peerLog.Errorf(err.Error())
+				req.Err <- err
+				return
+			}
+
+			if uint32(bestHeight) < thawHeight {
+				err := fmt.Errorf("unable to close channel "+
+					"%v, it's frozen until height %v",
+					req.ChanPoint, thawHeight)
+				peerLog.Errorf(err.Error())
+				req.Err <- err
+				return
+			}
+		}
+
 		// First, we'll fetch a fresh delivery address that we'll use
 		// to send the funds to in the case of a successful
 		// negotiation.
@@ -1906,6 +1940,35 @@ func (p *peer) PubKey() [33]byte {
 	return p.pubKeyBytes
 }
 
+// updateLastGossip records the timestamp of a gossip message received from
+// the peer, if it's newer than any gossip received from the peer before.
+func (p *peer) updateLastGossip(timestamp uint32) {
+	for {
+		lastGossip := atomic.LoadUint32(&p.lastGossip)
+		if timestamp <= lastGossip {
+			return
+		}
+
+		swapped := atomic.CompareAndSwapUint32(
+			&p.lastGossip, lastGossip, timestamp,
+		)
+		if swapped {
+			return
+		}
+	}
+}
+
+// LastGossip returns the timestamp of the most recent gossip message received
+// from the peer, or a zero time if no gossip has been received yet.
+func (p *peer) LastGossip() time.Time {
+	lastGossip := atomic.LoadUint32(&p.lastGossip)
+	if lastGossip == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(int64(lastGossip), 0)
+}
+
 // TODO(roasbeef): make all start/stop mutexes a CAS
 
 // fetchLastChanUpdate returns a function which is able to retrieve the last
@@ -1943,6 +2006,7 @@ func fetchLastChanUpdate(router *routing.ChannelRouter,
 			HtlcMinimumMsat: local.MinHTLC,
 			BaseFee:         uint32(local.FeeBaseMSat),
 			FeeRate:         uint32(local.FeeProportionalMillionths),
+			HtlcMaximumMsat: local.MaxHTLC,
 		}
 		update.Signature, err = lnwire.NewSigFromRawSignature(local.SigBytes)
 		if err != nil {
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// peerEventType denotes the kind of connection change which registered peer
+// event clients are notified of.
+type peerEventType uint8
+
+const (
+	// peerOnlineEvent denotes that a connection to a peer was established,
+	// and the peer was added to the server.
+	peerOnlineEvent peerEventType = iota
+
+	// peerOfflineEvent denotes that the connection to a peer was lost, and
+	// the peer was removed from the server.
+	peerOfflineEvent
+)
+
+// String returns a human readable representation of the peer event type.
+func (e peerEventType) String() string {
+	switch e {
+	case peerOnlineEvent:
+		return "Online"
+	case peerOfflineEvent:
+		return "Offline"
+	default:
+		return "Unknown"
+	}
+}
+
+// peerEvent is a single connection change of a peer.
+type peerEvent struct {
+	// eventType is the kind of connection change.
+	eventType peerEventType
+
+	// pubKey is the serialized identity public key of the peer.
+	pubKey [33]byte
+}
+
+// peerNotifier dispatches the connection changes of all peers, as reported by
+// the server, to all registered clients. Each client receives the events in
+// the order they were reported.
+type peerNotifier struct {
+	stopped uint32 // To be used atomically.
+
+	clientMtx           sync.Mutex
+	nextClientID        uint32
+	notificationClients map[uint32]*peerEventSubscription
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newPeerNotifier creates a new peer notifier without any clients.
+func newPeerNotifier() *peerNotifier {
+	return &peerNotifier{
+		notificationClients: make(map[uint32]*peerEventSubscription),
+		quit:                make(chan struct{}),
+	}
+}
+
+// Stop signals the goroutines delivering events to clients to exit, and waits
+// for them to do so.
+func (p *peerNotifier) Stop() error {
+	if !atomic.CompareAndSwapUint32(&p.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Peer notifier shutting down")
+
+	close(p.quit)
+	p.wg.Wait()
+
+	return nil
+}
+
+// NotifyPeerOnline notifies all clients that the peer with the passed
+// serialized public key came online.
+func (p *peerNotifier) NotifyPeerOnline(pubKey [33]byte) {
+	p.notifyClients(peerOnlineEvent, pubKey)
+}
+
+// NotifyPeerOffline notifies all clients that the peer with the passed
+// serialized public key went offline.
+func (p *peerNotifier) NotifyPeerOffline(pubKey [33]byte) {
+	p.notifyClients(peerOfflineEvent, pubKey)
+}
+
+// notifyClients queues the passed event for delivery to all currently
+// registered clients.
+func (p *peerNotifier) notifyClients(eventType peerEventType,
+	pubKey [33]byte) {
+
+	srvrLog.Debugf("Peer(%x) went %v", pubKey[:], eventType)
+
+	event := &peerEvent{
+		eventType: eventType,
+		pubKey:    pubKey,
+	}
+
+	p.clientMtx.Lock()
+	defer p.clientMtx.Unlock()
+
+	for _, client := range p.notificationClients {
+		client.queueMtx.Lock()
+		client.queue = append(client.queue, event)
+		client.queueMtx.Unlock()
+
+		select {
+		case client.queued <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// peerEventSubscription represents an intent to receive the connection
+// changes of all peers. Each event is sent over the Updates channel, in the
+// order the events were reported.
+type peerEventSubscription struct {
+	Updates chan *peerEvent
+
+	// queue holds the events which weren't delivered to the client yet,
+	// and queued is signaled whenever an event is appended to it.
+	queueMtx sync.Mutex
+	queue    []*peerEvent
+	queued   chan struct{}
+
+	notifier *peerNotifier
+	id       uint32
+	quit     chan struct{}
+}
+
+// Cancel unregisters the peerEventSubscription, freeing any previously
+// allocated resources.
+func (s *peerEventSubscription) Cancel() {
+	s.notifier.clientMtx.Lock()
+	if _, ok := s.notifier.notificationClients[s.id]; ok {
+		delete(s.notifier.notificationClients, s.id)
+		close(s.quit)
+	}
+	s.notifier.clientMtx.Unlock()
+}
+
+// SubscribePeerEvents returns a peerEventSubscription which allows the caller
+// to receive async notifications whenever a peer comes online or goes offline.
+func (p *peerNotifier) SubscribePeerEvents() *peerEventSubscription {
+	client := &peerEventSubscription{
+		Updates:  make(chan *peerEvent),
+		queued:   make(chan struct{}, 1),
+		notifier: p,
+		quit:     make(chan struct{}),
+	}
+
+	p.clientMtx.Lock()
+	p.notificationClients[p.nextClientID] = client
+	client.id = p.nextClientID
+	p.nextClientID++
+	p.clientMtx.Unlock()
+
+	p.wg.Add(1)
+	go client.deliverEvents()
+
+	return client
+}
+
+// deliverEvents sends the queued events to the client in order, until the
+// subscription is canceled or the notifier is stopped.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *peerEventSubscription) deliverEvents() {
+	defer s.notifier.wg.Done()
+
+	for {
+		s.queueMtx.Lock()
+		var next *peerEvent
+		if len(s.queue) > 0 {
+			next = s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+		}
+		s.queueMtx.Unlock()
+
+		if next == nil {
+			select {
+			case <-s.queued:
+				continue
+			case <-s.quit:
+				return
+			case <-s.notifier.quit:
+				return
+			}
+		}
+
+		select {
+		case s.Updates <- next:
+		case <-s.quit:
+			return
+		case <-s.notifier.quit:
+			return
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPeerNotifierOrdering tests that each client of the peer notifier
+// receives all events reported after it subscribed in the order they were
+// reported, and none after it canceled its subscription.
+func TestPeerNotifierOrdering(t *testing.T) {
+	t.Parallel()
+
+	notifier := newPeerNotifier()
+	defer notifier.Stop()
+
+	client := notifier.SubscribePeerEvents()
+
+	alice := [33]byte{2, 1}
+	bob := [33]byte{3, 2}
+	expected := []peerEvent{
+		{eventType: peerOnlineEvent, pubKey: alice},
+		{eventType: peerOnlineEvent, pubKey: bob},
+		{eventType: peerOfflineEvent, pubKey: alice},
+		{eventType: peerOfflineEvent, pubKey: bob},
+	}
+
+	// All events are reported before any is received, to ensure they're
+	// queued rather than dropped or reordered.
+	notifier.NotifyPeerOnline(alice)
+	notifier.NotifyPeerOnline(bob)
+	notifier.NotifyPeerOffline(alice)
+	notifier.NotifyPeerOffline(bob)
+
+	for _, expectedEvent := range expected {
+		select {
+		case event := <-client.Updates:
+			if *event != expectedEvent {
+				t.Fatalf("expected %v event for %x, got %v "+
+					"event for %x", expectedEvent.eventType,
+					expectedEvent.pubKey[:], event.eventType,
+					event.pubKey[:])
+			}
+
+		case <-time.After(time.Second):
+			t.Fatalf("%v event for %x not received",
+				expectedEvent.eventType, expectedEvent.pubKey[:])
+		}
+	}
+
+	// Once canceled, the client shouldn't receive any further events.
+	client.Cancel()
+	notifier.NotifyPeerOnline(alice)
+
+	select {
+	case event := <-client.Updates:
+		t.Fatalf("received %v event after canceling",
+			event.eventType)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
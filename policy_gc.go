@@ -0,0 +1,180 @@
+package main
+
+import (
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lightningnetwork/lnd/chainntnfs"
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnwallet"
+)
+
+// policyGCConfig houses the resources required by the policy garbage
+// collector.
+type policyGCConfig struct {
+	// DB is the database which stores the policies to be swept.
+	DB *channeldb.DB
+
+	// ChainIO is used to determine the current best height on startup.
+	ChainIO lnwallet.BlockChainIO
+
+	// Notifier is used to receive a notification for each new block, which
+	// drives the periodic sweeps of the policy bucket.
+	Notifier chainntnfs.ChainNotifier
+}
+
+// policyGarbageCollector sweeps expired policies out of the database. A sweep
+// is carried out once on startup, and then again for each newly connected
+// block.
+type policyGarbageCollector struct {
+	started uint32 // To be used atomically.
+	stopped uint32 // To be used atomically.
+
+	// numPruned is the total number of policies swept since startup.
+	numPruned uint64 // To be used atomically.
+
+	cfg *policyGCConfig
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newPolicyGarbageCollector creates a new policy garbage collector backed by
+// the passed config.
+func newPolicyGarbageCollector(cfg *policyGCConfig) *policyGarbageCollector {
+	return &policyGarbageCollector{
+		cfg:  cfg,
+		quit: make(chan struct{}),
+	}
+}
+
+// Start sweeps all policies which expired while we were offline, and launches
+// the goroutine responsible for sweeping policies as new blocks arrive.
+func (p *policyGarbageCollector) Start() error {
+	if !atomic.CompareAndSwapUint32(&p.started, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Tracef("Starting policy garbage collector")
+
+	// We register for block notifications before the initial sweep to
+	// ensure we don't miss any blocks in between.
+	newBlockChan, err := p.cfg.Notifier.RegisterBlockEpochNtfn()
+	if err != nil {
+		return err
+	}
+
+	_, bestHeight, err := p.cfg.ChainIO.GetBestBlock()
+	if err != nil {
+		newBlockChan.Cancel()
+		return err
+	}
+
+	if err := p.sweep(uint32(bestHeight)); err != nil {
+		newBlockChan.Cancel()
+		return err
+	}
+
+	p.wg.Add(1)
+	go p.collector(newBlockChan)
+
+	return nil
+}
+
+// Stop signals the garbage collector to exit, and waits for it to do so.
+func (p *policyGarbageCollector) Stop() error {
+	if !atomic.CompareAndSwapUint32(&p.stopped, 0, 1) {
+		return nil
+	}
+
+	srvrLog.Infof("Policy garbage collector shutting down")
+
+	close(p.quit)
+	p.wg.Wait()
+
+	return nil
+}
+
+// NumPruned returns the total number of expired policies swept by the garbage
+// collector since it was started.
+func (p *policyGarbageCollector) NumPruned() uint64 {
+	return atomic.LoadUint64(&p.numPruned)
+}
+
+// channelDBVars are the variables exported for the channel database.
+type channelDBVars struct {
+	*channeldb.DBMetrics
+
+	// PrunedPolicies is the total number of expired policies swept by the
+	// policy garbage collector since it was started.
+	PrunedPolicies uint64
+}
+
+// channelDBVarsFunc returns an expvar.Func reporting the metrics of the
+// passed database along with the number of policies swept out of it by the
+// passed garbage collector.
+func channelDBVarsFunc(db *channeldb.DB,
+	policyGC *policyGarbageCollector) expvar.Func {
+
+	return func() interface{} {
+		metrics, err := db.Metrics()
+		if err != nil {
+			return err.Error()
+		}
+
+		return &channelDBVars{
+			DBMetrics:      metrics,
+			PrunedPolicies: policyGC.NumPruned(),
+		}
+	}
+}
+
+// collector sweeps the policy bucket each time a new block is connected.
+//
+// NOTE: This MUST be run as a goroutine.
+func (p *policyGarbageCollector) collector(
+	newBlockChan *chainntnfs.BlockEpochEvent) {
+
+	defer p.wg.Done()
+	defer newBlockChan.Cancel()
+
+	for {
+		select {
+		case epoch, ok := <-newBlockChan.Epochs:
+			if !ok {
+				return
+			}
+
+			height := uint32(epoch.Height)
+			if err := p.sweep(height); err != nil {
+				srvrLog.Errorf("Unable to sweep expired "+
+					"policies at height=%v: %v", height, err)
+			}
+
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// sweep removes all policies that have expired as of the passed height or the
+// current time.
+func (p *policyGarbageCollector) sweep(height uint32) error {
+	numPruned, err := p.cfg.DB.PruneExpiredPolicies(height, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if numPruned == 0 {
+		return nil
+	}
+
+	total := atomic.AddUint64(&p.numPruned, uint64(numPruned))
+
+	srvrLog.Debugf("Swept %v expired policies at height=%v, "+
+		"total_pruned=%v", numPruned, height, total)
+
+	return nil
+}
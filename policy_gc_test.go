@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+)
+
+// TestPolicyGarbageCollectorVars tests that the number of expired policies
+// swept by the policy garbage collector is exported along with the metrics of
+// the channel database.
+func TestPolicyGarbageCollectorVars(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	policies := []*channeldb.Policy{
+		{
+			PaymentHash:  sha256.Sum256([]byte("expired")),
+			Fee:          1000,
+			ExpiryHeight: 100,
+		},
+		{
+			PaymentHash:  sha256.Sum256([]byte("live")),
+			Fee:          1000,
+			ExpiryHeight: 200,
+		},
+	}
+	for _, policy := range policies {
+		if err := db.AddPolicy(policy); err != nil {
+			t.Fatalf("unable to add policy: %v", err)
+		}
+	}
+
+	policyGC := newPolicyGarbageCollector(&policyGCConfig{DB: db})
+	varsFunc := channelDBVarsFunc(db, policyGC)
+
+	assertPruned := func(expected uint64) {
+		t.Helper()
+
+		vars, ok := varsFunc().(*channelDBVars)
+		if !ok {
+			t.Fatalf("unable to fetch channeldb vars: %v", varsFunc())
+		}
+		if vars.PrunedPolicies != expected {
+			t.Fatalf("expected %v pruned policies, got %v",
+				expected, vars.PrunedPolicies)
+		}
+		if vars.DBMetrics == nil {
+			t.Fatalf("channeldb metrics not exported")
+		}
+	}
+
+	assertPruned(0)
+
+	if err := policyGC.sweep(150); err != nil {
+		t.Fatalf("unable to sweep policies: %v", err)
+	}
+	assertPruned(1)
+
+	// Sweeping again at the same height shouldn't count the swept policy
+	// twice.
+	if err := policyGC.sweep(150); err != nil {
+		t.Fatalf("unable to sweep policies: %v", err)
+	}
+	assertPruned(1)
+
+	if err := policyGC.sweep(200); err != nil {
+		t.Fatalf("unable to sweep policies: %v", err)
+	}
+	assertPruned(2)
+}
@@ -42,6 +42,15 @@ const (
 	// ErrPaymentAttemptTimeout is an error that indicates that a payment
 	// attempt timed out before we were able to successfully route an HTLC.
 	ErrPaymentAttemptTimeout
+
+	// ErrFeeLimitExceeded is returned when the total fee of a candidate
+	// route for a payment exceeds the fee limit of that payment.
+	ErrFeeLimitExceeded
+
+	// ErrCltvLimitExceeded is returned when the total time lock of a
+	// candidate route for a payment exceeds the CLTV limit of that
+	// payment.
+	ErrCltvLimitExceeded
 )
 
 // routerError is a structure that represent the error inside the routing package,
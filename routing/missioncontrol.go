@@ -1,6 +1,7 @@
 package routing
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -152,6 +153,12 @@ func (m *missionControl) GraphPruneView() graphPruneView {
 type paymentSession struct {
 	pruneViewSnapshot graphPruneView
 
+	// preBuiltRoutes is an optional set of routes handed to the session by
+	// the caller. If haveRoutes is true, then RequestRoute will return
+	// these routes in order, rather than finding new ones.
+	preBuiltRoutes []*Route
+	haveRoutes     bool
+
 	mc *missionControl
 }
 
@@ -166,6 +173,20 @@ func (m *missionControl) NewPaymentSession() *paymentSession {
 	}
 }
 
+// NewPaymentSessionFromRoutes creates a new payment session which will only
+// attempt the passed set of pre-built routes, in order, rather than finding
+// its own routes through the graph.
+func (m *missionControl) NewPaymentSessionFromRoutes(
+	routes []*Route) *paymentSession {
+
+	return &paymentSession{
+		pruneViewSnapshot: m.GraphPruneView(),
+		preBuiltRoutes:    routes,
+		haveRoutes:        true,
+		mc:                m,
+	}
+}
+
 // ReportVertexFailure adds a vertex to the graph prune view after a client
 // reports a routing failure localized to the vertex. The time the vertex was
 // added is noted, as it'll be pruned from the shared view after a period of
@@ -206,6 +227,16 @@ func (p *paymentSession) ReportChannelFailure(e uint64) {
 	p.mc.Unlock()
 }
 
+// ExcludeChannel excludes a channel from all routes returned for the rest of
+// the payment session. Unlike ReportChannelFailure, the exclusion isn't
+// reported back to mission control, as the channel hasn't failed, but merely
+// isn't suitable for this particular payment.
+func (p *paymentSession) ExcludeChannel(e uint64) {
+	log.Debugf("Excluding edge %v from payment session", e)
+
+	p.pruneViewSnapshot.edges[e] = struct{}{}
+}
+
 // RequestRoute returns a route which is likely to be capable for successfully
 // routing the specified HTLC payment to the target node. Initially the first
 // set of paths returned from this method may encounter routing failure along
@@ -217,6 +248,21 @@ func (p *paymentSession) ReportChannelFailure(e uint64) {
 func (p *paymentSession) RequestRoute(payment *LightningPayment,
 	height uint32, finalCltvDelta uint16) (*Route, error) {
 
+	// If the session was created with a set of pre-built routes, then
+	// we'll hand out the next one of them, rather than searching the
+	// graph. Once all of them have been attempted, the session is over.
+	if p.haveRoutes {
+		if len(p.preBuiltRoutes) == 0 {
+			return nil, fmt.Errorf("pre-built routes exhausted")
+		}
+
+		route := p.preBuiltRoutes[0]
+		p.preBuiltRoutes[0] = nil
+		p.preBuiltRoutes = p.preBuiltRoutes[1:]
+
+		return route, nil
+	}
+
 	// First, we'll obtain our current prune view snapshot. This view will
 	// only ever grow during the duration of this payment session, never
 	// shrinking.
@@ -228,11 +274,24 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 
 	// TODO(roasbeef): sync logic amongst dist sys
 
+	// If the payment must leave through a particular channel, then we'll
+	// also ignore all of our other channels.
+	ignoredEdges := pruneView.edges
+	if payment.OutgoingChannelID != nil {
+		var err error
+		ignoredEdges, err = p.ignoreOtherChannels(
+			ignoredEdges, *payment.OutgoingChannelID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Taking into account this prune view, we'll attempt to locate a path
 	// to our destination, respecting the recommendations from
 	// missionControl.
 	path, err := findPath(nil, p.mc.graph, p.mc.selfNode, payment.Target,
-		pruneView.vertexes, pruneView.edges, payment.Amount)
+		pruneView.vertexes, ignoredEdges, payment.Amount)
 	if err != nil {
 		return nil, err
 	}
@@ -251,6 +310,34 @@ func (p *paymentSession) RequestRoute(payment *LightningPayment,
 	return route, err
 }
 
+// ignoreOtherChannels returns a copy of the passed set of ignored edges,
+// extended by all of our outgoing channels other than the one with the passed
+// channel ID.
+func (p *paymentSession) ignoreOtherChannels(ignoredEdges map[uint64]struct{},
+	chanID uint64) (map[uint64]struct{}, error) {
+
+	edges := make(map[uint64]struct{}, len(ignoredEdges))
+	for edge := range ignoredEdges {
+		edges[edge] = struct{}{}
+	}
+
+	selfNode := p.mc.selfNode.PubKeyBytes
+	err := p.mc.graph.ForEachNodeChannel(nil, selfNode, func(_ channeldb.ReadTx,
+		info *channeldb.ChannelEdgeInfo, _,
+		_ *channeldb.ChannelEdgePolicy) error {
+
+		if info.ChannelID != chanID {
+			edges[info.ChannelID] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return edges, nil
+}
+
 // ResetHistory resets the history of missionControl returning it to a state as
 // if no payment attempts have been made.
 func (m *missionControl) ResetHistory() {
@@ -8,7 +8,6 @@ import (
 
 	"container/heap"
 
-	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/lnwire"
@@ -374,6 +373,53 @@ func newRoute(amtToSend lnwire.MilliSatoshi, sourceVertex Vertex,
 	return route, nil
 }
 
+// NewRouteFromHops creates a new Route structure from the passed hops, which
+// were constructed by an external source rather than found by path finding.
+// The hops are expected to already carry the amount to forward, fee and
+// outgoing time lock of each hop, with the fee of a hop being the fee paid to
+// the node at that hop for forwarding over the next one. The passed time lock
+// is the one extended to the first hop of the route.
+func NewRouteFromHops(amtToSend lnwire.MilliSatoshi, timeLock uint32,
+	sourceVertex Vertex, hops []*Hop) (*Route, error) {
+
+	if len(hops) == 0 {
+		return nil, fmt.Errorf("route must have at least one hop")
+	}
+
+	route := &Route{
+		Hops:          hops,
+		TotalTimeLock: timeLock,
+		TotalAmount:   amtToSend,
+		nodeIndex:     make(map[Vertex]struct{}),
+		chanIndex:     make(map[uint64]struct{}),
+		nextHopMap:    make(map[Vertex]*ChannelHop),
+		prevHopMap:    make(map[Vertex]*ChannelHop),
+	}
+
+	// We'll populate the next hop map for the _source_ node with the
+	// information for the first hop so the mapping is sound.
+	route.nextHopMap[sourceVertex] = hops[0].Channel
+
+	for i, hop := range hops {
+		v := Vertex(hop.Channel.Node.PubKeyBytes)
+		route.nodeIndex[v] = struct{}{}
+		route.chanIndex[hop.Channel.ChannelID] = struct{}{}
+		route.prevHopMap[v] = hop.Channel
+
+		if i != len(hops)-1 {
+			route.nextHopMap[v] = hops[i+1].Channel
+		}
+
+		// The fees of all hops are paid on top of the amount that
+		// reaches the destination, so they add up to the amount
+		// extended to the first hop.
+		route.TotalFees += hop.Fee
+		route.TotalAmount += hop.Fee
+	}
+
+	return route, nil
+}
+
 // Vertex is a simple alias for the serialization of a compressed Bitcoin
 // public key.
 type Vertex [33]byte
@@ -432,18 +478,24 @@ func edgeWeight(amt lnwire.MilliSatoshi, e *channeldb.ChannelEdgePolicy) int64 {
 // time-lock+fee costs along a particular edge. If a path is found, this
 // function returns a slice of ChannelHop structs which encoded the chosen path
 // from the target to the source.
-func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+func findPath(tx channeldb.ReadTx, graph *channeldb.ChannelGraph,
 	sourceNode *channeldb.LightningNode, target *btcec.PublicKey,
 	ignoredNodes map[Vertex]struct{}, ignoredEdges map[uint64]struct{},
 	amt lnwire.MilliSatoshi) ([]*ChannelHop, error) {
 
-	var err error
+	// If no transaction was provided, then we'll execute the search within
+	// a fresh read-only transaction of the graph.
 	if tx == nil {
-		tx, err = graph.Database().Begin(false)
-		if err != nil {
-			return nil, err
-		}
-		defer tx.Rollback()
+		var path []*ChannelHop
+		err := graph.ViewTx(func(tx channeldb.ReadTx) error {
+			var err error
+			path, err = findPath(
+				tx, graph, sourceNode, target, ignoredNodes,
+				ignoredEdges, amt,
+			)
+			return err
+		})
+		return path, err
 	}
 
 	// First we'll initialize an empty heap which'll help us to quickly
@@ -451,21 +503,11 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 	// traversal.
 	var nodeHeap distanceHeap
 
-	// For each node/Vertex the graph we create an entry in the distance
-	// map for the node set with a distance of "infinity".  We also mark
-	// add the node to our set of unvisited nodes.
+	// The distance map records the best known distance to each node/Vertex
+	// reached so far. Rather than populating it with every node of the
+	// graph up front, any node that's absent is treated as having a
+	// distance of "infinity".
 	distance := make(map[Vertex]nodeWithDist)
-	if err := graph.ForEachNode(tx, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
-		// TODO(roasbeef): with larger graph can just use disk seeks
-		// with a visited map
-		distance[Vertex(node.PubKeyBytes)] = nodeWithDist{
-			dist: infinity,
-			node: node,
-		}
-		return nil
-	}); err != nil {
-		return nil, err
-	}
 
 	// TODO(roasbeef): also add path caching
 	//  * similar to route caching, but doesn't factor in the amount
@@ -505,8 +547,8 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 		// examine all the outgoing edge (channels) from this node to
 		// further our graph traversal.
 		pivot := Vertex(bestNode.PubKeyBytes)
-		err := bestNode.ForEachChannel(tx, func(tx *bolt.Tx,
-			edgeInfo *channeldb.ChannelEdgeInfo,
+		err := graph.ForEachNodeChannel(tx, bestNode.PubKeyBytes, func(
+			tx channeldb.ReadTx, edgeInfo *channeldb.ChannelEdgeInfo,
 			outEdge, inEdge *channeldb.ChannelEdgePolicy) error {
 
 			v := Vertex(outEdge.Node.PubKeyBytes)
@@ -534,16 +576,26 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// pivot node plus the weight of this edge.
 			tempDist := distance[pivot].dist + edgeWeight(amt, outEdge)
 
+			// If the node hasn't been reached yet, then its
+			// current distance is "infinity".
+			curDist := int64(infinity)
+			if dist, ok := distance[v]; ok {
+				curDist = dist.dist
+			}
+
 			// If this new tentative distance is better than the
 			// current best known distance to this node, then we
 			// record the new better distance, and also populate
 			// our "next hop" map with this edge. We'll also shave
 			// off irrelevant edges by adding the sufficient
-			// capacity of an edge and clearing their min-htlc
-			// amount to our relaxation condition.
-			if tempDist < distance[v].dist &&
+			// capacity of an edge, clearing their min-htlc
+			// amount, and not exceeding their max-htlc amount
+			// (if advertised) to our relaxation condition.
+			if tempDist < curDist &&
 				edgeInfo.Capacity >= amt.ToSatoshis() &&
 				amt >= outEdge.MinHTLC &&
+				(!outEdge.Flags.HasMaxHtlc() ||
+					amt <= outEdge.MaxHTLC) &&
 				outEdge.TimeLockDelta != 0 {
 
 				distance[v] = nodeWithDist{
@@ -628,13 +680,22 @@ func findPath(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 // will be ignored by our modified Dijkstra's algorithm. With this approach, we
 // make our inner path finding algorithm aware of our k-shortest paths
 // algorithm, rather than attempting to use an unmodified path finding
-// algorithm in a block box manner.
-func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
+// algorithm in a block box manner. The passed nodes and edges, which may be
+// nil, are excluded from all of the paths found.
+func findPaths(tx channeldb.ReadTx, graph *channeldb.ChannelGraph,
 	source *channeldb.LightningNode, target *btcec.PublicKey,
-	amt lnwire.MilliSatoshi, numPaths uint32) ([][]*ChannelHop, error) {
+	amt lnwire.MilliSatoshi, numPaths uint32,
+	restrictedNodes map[Vertex]struct{},
+	restrictedEdges map[uint64]struct{}) ([][]*ChannelHop, error) {
 
 	ignoredEdges := make(map[uint64]struct{})
 	ignoredVertexes := make(map[Vertex]struct{})
+	for chanID := range restrictedEdges {
+		ignoredEdges[chanID] = struct{}{}
+	}
+	for vertex := range restrictedNodes {
+		ignoredVertexes[vertex] = struct{}{}
+	}
 
 	// TODO(roasbeef): modifying ordering within heap to eliminate final
 	// sorting step?
@@ -682,6 +743,12 @@ func findPaths(tx *bolt.Tx, graph *channeldb.ChannelGraph,
 			// and loopless.
 			ignoredEdges = make(map[uint64]struct{})
 			ignoredVertexes = make(map[Vertex]struct{})
+			for chanID := range restrictedEdges {
+				ignoredEdges[chanID] = struct{}{}
+			}
+			for vertex := range restrictedNodes {
+				ignoredVertexes[vertex] = struct{}{}
+			}
 
 			// Our spur node is the i-th node in the prior shortest
 			// path, and our root path will be all nodes in the
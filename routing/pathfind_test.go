@@ -510,7 +510,7 @@ func TestKShortestPathFinding(t *testing.T) {
 	paymentAmt := lnwire.NewMSatFromSatoshis(100)
 	target := aliases["luoji"]
 	paths, err := findPaths(
-		nil, graph, sourceNode, target, paymentAmt, 100,
+		nil, graph, sourceNode, target, paymentAmt, 100, nil, nil,
 	)
 	if err != nil {
 		t.Fatalf("unable to find paths between roasbeef and "+
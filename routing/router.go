@@ -9,7 +9,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
@@ -18,6 +17,7 @@ import (
 	"github.com/lightningnetwork/lnd/multimutex"
 	"github.com/lightningnetwork/lnd/routing/chainview"
 	"github.com/roasbeef/btcd/btcec"
+	"github.com/roasbeef/btcd/chaincfg/chainhash"
 	"github.com/roasbeef/btcd/wire"
 	"github.com/roasbeef/btcutil"
 
@@ -95,7 +95,7 @@ type ChannelGraphSource interface {
 	ForEachNode(func(node *channeldb.LightningNode) error) error
 
 	// ForEachChannel is used to iterate over every channel in the known
-	// graph.
+	// graph, excluding zombie channels.
 	ForEachChannel(func(chanInfo *channeldb.ChannelEdgeInfo,
 		e1, e2 *channeldb.ChannelEdgePolicy) error) error
 }
@@ -126,6 +126,11 @@ type ChannelPolicy struct {
 	// TimeLockDelta is the required HTLC timelock delta to be used
 	// when forwarding payments.
 	TimeLockDelta uint32
+
+	// MaxHTLC is the maximum HTLC size including fees we are allowed to
+	// forward over this channel. If zero, then the current maximum is
+	// left unchanged.
+	MaxHTLC lnwire.MilliSatoshi
 }
 
 // Config defines the configuration for the ChannelRouter. ALL elements within
@@ -529,16 +534,23 @@ func (r *ChannelRouter) syncGraphWithChain() error {
 	return nil
 }
 
-// pruneZombieChans is a method that will be called periodically to prune out
-// any "zombie" channels. We consider channels zombies if *both* edges haven't
-// been updated since our zombie horizon. We do this periodically to keep a
-// health, lively routing table.
+// pruneZombieChans is a method that will be called periodically to mark any
+// "zombie" channels within the graph. We consider channels zombies if *both*
+// edges haven't been updated since our zombie horizon. Zombie channels are
+// kept within the graph, but are skipped during path finding and aren't
+// relayed to our peers, until a fresh channel update resurrects them. We do
+// this periodically to keep a health, lively routing table.
 func (r *ChannelRouter) pruneZombieChans() error {
-	var chansToPrune []wire.OutPoint
+	var chansToMark []uint64
 	chanExpiry := r.cfg.ChannelPruneExpiry
 
 	log.Infof("Examining Channel Graph for zombie channels")
 
+	zombies, err := r.cfg.Graph.FetchZombieEdges()
+	if err != nil {
+		return fmt.Errorf("Unable to fetch zombie chans: %v", err)
+	}
+
 	// First, we'll collect all the channels which are eligible for garbage
 	// collection due to being zombies.
 	filterPruneChans := func(info *channeldb.ChannelEdgeInfo,
@@ -553,9 +565,14 @@ func (r *ChannelRouter) pruneZombieChans() error {
 			return nil
 		}
 
+		// There's no need to examine channels which are already
+		// marked as zombies.
+		if _, ok := zombies[info.ChannelID]; ok {
+			return nil
+		}
+
 		// If *both* edges haven't been updated for a period of
-		// chanExpiry, then we'll mark the channel itself as eligible
-		// for graph pruning.
+		// chanExpiry, then we'll mark the channel itself as a zombie.
 		e1Zombie, e2Zombie := true, true
 		if e1 != nil {
 			e1Zombie = time.Since(e1.LastUpdate) >= chanExpiry
@@ -575,44 +592,42 @@ func (r *ChannelRouter) pruneZombieChans() error {
 		}
 		if e1Zombie && e2Zombie {
 			log.Debugf("ChannelPoint(%v) is a zombie, collecting "+
-				"to prune", info.ChannelPoint)
+				"to mark", info.ChannelPoint)
 
-			// TODO(roasbeef): add ability to delete single
-			// directional edge
-			chansToPrune = append(chansToPrune, info.ChannelPoint)
-
-			// As we're detecting this as a zombie channel, we'll
-			// add this to the set of recently rejected items so we
-			// don't re-accept it shortly after.
-			r.rejectCache[info.ChannelID] = struct{}{}
+			chansToMark = append(chansToMark, info.ChannelID)
 		}
 
 		return nil
 	}
 
-	r.rejectMtx.Lock()
-	defer r.rejectMtx.Unlock()
-
-	err := r.cfg.Graph.ForEachChannel(filterPruneChans)
+	err = r.cfg.Graph.ForEachChannel(filterPruneChans)
 	if err != nil {
 		return fmt.Errorf("Unable to filter local zombie "+
 			"chans: %v", err)
 	}
 
-	log.Infof("Pruning %v Zombie Channels", len(chansToPrune))
+	log.Infof("Marking %v Zombie Channels", len(chansToMark))
 
 	// With the set zombie-like channels obtained, we'll do another pass to
-	// delete al zombie channels from the channel graph.
-	for _, chanToPrune := range chansToPrune {
-		log.Tracef("Pruning zombie chan ChannelPoint(%v)", chanToPrune)
+	// mark them within the channel graph. We don't add them to the reject
+	// cache, as a fresh channel update should bring them back to life.
+	for _, chanID := range chansToMark {
+		log.Tracef("Marking zombie chan_id=%v", chanID)
 
-		err := r.cfg.Graph.DeleteChannelEdge(&chanToPrune)
-		if err != nil {
-			return fmt.Errorf("Unable to prune zombie "+
+		if err := r.cfg.Graph.MarkEdgeZombie(chanID); err != nil {
+			return fmt.Errorf("Unable to mark zombie "+
 				"chans: %v", err)
 		}
 	}
 
+	// As zombie channels are no longer considered during path finding,
+	// any cached routes may now be invalid.
+	if len(chansToMark) > 0 {
+		r.routeCacheMtx.Lock()
+		r.routeCache = make(map[routeTuple][]*Route)
+		r.routeCacheMtx.Unlock()
+	}
+
 	return nil
 }
 
@@ -698,9 +713,26 @@ func (r *ChannelRouter) networkHandler() {
 			blockHeight := uint32(chainUpdate.Height)
 			atomic.StoreUint32(&r.bestHeight, blockHeight-1)
 
+			// Before disconnecting the block, we'll consult the
+			// spend journal for the channels it closed, as their
+			// closes are no longer confirmed.
+			spentChans, err := r.cfg.Graph.FetchSpendJournal(
+				blockHeight,
+			)
+			if err != nil {
+				log.Errorf("unable to fetch spend journal of "+
+					"stale block: %v", err)
+				continue
+			}
+			for chanPoint, chanID := range spentChans {
+				log.Infof("ChannelPoint(%v) (chan_id=%v) was "+
+					"closed within stale block (height=%v)",
+					chanPoint, chanID, blockHeight)
+			}
+
 			// Update the channel graph to reflect that this block
 			// was disconnected.
-			_, err := r.cfg.Graph.DisconnectBlockAtHeight(blockHeight)
+			_, err = r.cfg.Graph.DisconnectBlockAtHeight(blockHeight)
 			if err != nil {
 				log.Errorf("unable to prune graph with stale "+
 					"block: %v", err)
@@ -724,6 +756,19 @@ func (r *ChannelRouter) networkHandler() {
 				return
 			}
 
+			// If the graph has already been pruned using this
+			// block, as may happen if it was connected while we
+			// were syncing the graph with the chain, then there's
+			// nothing left to do.
+			_, pruneHeight, err := r.cfg.Graph.PruneTip()
+			if err == nil && chainUpdate.Height <= pruneHeight {
+				log.Debugf("Skipping block %v (height=%v), "+
+					"graph already pruned to height=%v",
+					chainUpdate.Hash, chainUpdate.Height,
+					pruneHeight)
+				continue
+			}
+
 			// We'll ensure that any new blocks received attach
 			// directly to the end of our main chain. If not, then
 			// we've somehow missed some blocks. We don't process
@@ -1125,6 +1170,28 @@ func (r *ChannelRouter) processUpdate(msg interface{}) error {
 			return err
 		}
 
+		// If the channel was marked as a zombie, then a fresh update
+		// brings it back to life.
+		if time.Since(msg.LastUpdate) < r.cfg.ChannelPruneExpiry {
+			isZombie, err := r.cfg.Graph.IsZombieEdge(msg.ChannelID)
+			if err != nil {
+				return errors.Errorf("unable to check for "+
+					"zombie chan_id=%v: %v", msg.ChannelID,
+					err)
+			}
+			if isZombie {
+				err := r.cfg.Graph.MarkEdgeLive(msg.ChannelID)
+				if err != nil {
+					return errors.Errorf("unable to "+
+						"resurrect chan_id=%v: %v",
+						msg.ChannelID, err)
+				}
+
+				log.Debugf("Resurrected zombie chan_id=%v",
+					msg.ChannelID)
+			}
+		}
+
 		invalidateCache = true
 		log.Debugf("New channel update applied: %v", spew.Sdump(msg))
 
@@ -1292,6 +1359,36 @@ func pathsToFeeSortedRoutes(source Vertex, paths [][]*ChannelHop, finalCLTVDelta
 func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	amt lnwire.MilliSatoshi, numPaths uint32, finalExpiry ...uint16) ([]*Route, error) {
 
+	return r.FindRestrictedRoutes(target, amt, nil, numPaths, finalExpiry...)
+}
+
+// RouteRestrictions restricts the routes returned by FindRestrictedRoutes.
+type RouteRestrictions struct {
+	// Source is the node the routes start at. If nil, the routes start at
+	// our own node.
+	Source *btcec.PublicKey
+
+	// IgnoredNodes is the set of nodes the routes mustn't pass through.
+	IgnoredNodes map[Vertex]struct{}
+
+	// IgnoredEdges is the set of channels, identified by their channel ID,
+	// the routes mustn't pass through in either direction.
+	IgnoredEdges map[uint64]struct{}
+
+	// FeeLimit is the maximum total fee in milli-satoshis of the routes.
+	// If nil, the fee is unbounded.
+	FeeLimit *lnwire.MilliSatoshi
+}
+
+// FindRestrictedRoutes is identical to FindRoutes, but only returns the routes
+// which satisfy the passed restrictions. This allows callers to implement
+// their own logic to avoid certain nodes and channels on top of our path
+// finding. If the restrictions are nil, then FindRestrictedRoutes behaves
+// exactly like FindRoutes.
+func (r *ChannelRouter) FindRestrictedRoutes(target *btcec.PublicKey,
+	amt lnwire.MilliSatoshi, restrictions *RouteRestrictions,
+	numPaths uint32, finalExpiry ...uint16) ([]*Route, error) {
+
 	var finalCLTVDelta uint16
 	if len(finalExpiry) == 0 {
 		finalCLTVDelta = DefaultFinalCLTVDelta
@@ -1304,17 +1401,23 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 
 	// Before attempting to perform a series of graph traversals to find
 	// the k-shortest paths to the destination, we'll first consult our
-	// path cache
+	// path cache. As the cache only holds unrestricted routes from our own
+	// node, we'll only do so if the routes aren't restricted.
 	rt := newRouteTuple(amt, dest)
-	r.routeCacheMtx.RLock()
-	routes, ok := r.routeCache[rt]
-	r.routeCacheMtx.RUnlock()
+	cacheable := restrictions == nil
+	if cacheable {
+		r.routeCacheMtx.RLock()
+		routes, ok := r.routeCache[rt]
+		r.routeCacheMtx.RUnlock()
+
+		// If we already have a cached route, and it contains at least
+		// the number of paths requested, then we'll return it directly
+		// as there's no need to repeat the computation.
+		if ok && uint32(len(routes)) >= numPaths {
+			return routes, nil
+		}
 
-	// If we already have a cached route, and it contains at least the
-	// number of paths requested, then we'll return it directly as there's
-	// no need to repeat the computation.
-	if ok && uint32(len(routes)) >= numPaths {
-		return routes, nil
+		restrictions = &RouteRestrictions{}
 	}
 
 	// If we don't have a set of routes cached, we'll query the graph for a
@@ -1339,31 +1442,41 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 		return nil, err
 	}
 
-	tx, err := r.cfg.Graph.Database().Begin(false)
-	if err != nil {
-		tx.Rollback()
-		return nil, err
+	// The routes start at our own node, unless another source was
+	// requested, in which case it must be known within the graph as well.
+	source := r.selfNode
+	if restrictions.Source != nil {
+		source, err = r.cfg.Graph.FetchLightningNode(restrictions.Source)
+		if err == channeldb.ErrGraphNodeNotFound {
+			return nil, newErrf(ErrTargetNotInNetwork, "source %x "+
+				"not found", restrictions.Source.SerializeCompressed())
+		} else if err != nil {
+			return nil, err
+		}
 	}
 
 	// Now that we know the destination is reachable within the graph,
 	// we'll execute our KSP algorithm to find the k-shortest paths from
 	// our source to the destination.
-	shortestPaths, err := findPaths(
-		tx, r.cfg.Graph, r.selfNode, target, amt, numPaths,
-	)
+	var shortestPaths [][]*ChannelHop
+	err = r.cfg.Graph.ViewTx(func(tx channeldb.ReadTx) error {
+		var err error
+		shortestPaths, err = findPaths(
+			tx, r.cfg.Graph, source, target, amt, numPaths,
+			restrictions.IgnoredNodes, restrictions.IgnoredEdges,
+		)
+		return err
+	})
 	if err != nil {
-		tx.Rollback()
 		return nil, err
 	}
 
-	tx.Rollback()
-
 	// Now that we have a set of paths, we'll need to turn them into
 	// *routes* by computing the required time-lock and fee information for
 	// each path. During this process, some paths may be discarded if they
 	// aren't able to support the total satoshis flow once fees have been
 	// factored in.
-	sourceVertex := Vertex(r.selfNode.PubKeyBytes)
+	sourceVertex := Vertex(source.PubKeyBytes)
 	validRoutes, err := pathsToFeeSortedRoutes(
 		sourceVertex, shortestPaths, finalCLTVDelta, amt,
 		uint32(currentHeight),
@@ -1372,6 +1485,24 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 		return nil, err
 	}
 
+	// If the routes are subject to a fee limit, then we'll drop those
+	// exceeding it. As the routes are sorted by their fee, these are all
+	// found at the end.
+	if restrictions.FeeLimit != nil {
+		numRoutes := len(validRoutes)
+		for numRoutes > 0 &&
+			validRoutes[numRoutes-1].TotalFees > *restrictions.FeeLimit {
+
+			numRoutes--
+		}
+		if numRoutes == 0 {
+			return nil, newErrf(ErrFeeLimitExceeded, "total fee of "+
+				"cheapest route (%v) exceeds fee limit (%v)",
+				validRoutes[0].TotalFees, *restrictions.FeeLimit)
+		}
+		validRoutes = validRoutes[:numRoutes]
+	}
+
 	go log.Tracef("Obtained %v paths sending %v to %x: %v", len(validRoutes),
 		amt, dest, newLogClosure(func() string {
 			return spew.Sdump(validRoutes)
@@ -1379,10 +1510,12 @@ func (r *ChannelRouter) FindRoutes(target *btcec.PublicKey,
 	)
 
 	// Populate the cache with this set of fresh routes so we can reuse
-	// them in the future.
-	r.routeCacheMtx.Lock()
-	r.routeCache[rt] = validRoutes
-	r.routeCacheMtx.Unlock()
+	// them in the future, unless they were restricted.
+	if cacheable {
+		r.routeCacheMtx.Lock()
+		r.routeCache[rt] = validRoutes
+		r.routeCacheMtx.Unlock()
+	}
 
 	return validRoutes, nil
 }
@@ -1482,17 +1615,159 @@ type LightningPayment struct {
 	// indefinitely.
 	PayAttemptTimeout time.Duration
 
+	// FeeLimit is the maximum total fee in milli-satoshis that may be paid
+	// to route the payment. Any route whose total fee exceeds this limit
+	// is rejected before an HTLC is dispatched along it. If this value is
+	// unspecified, then the fee of the payment is unbounded.
+	FeeLimit *lnwire.MilliSatoshi
+
+	// CltvLimit is the maximum total time lock delta, relative to the
+	// current block height, that a route for the payment may impose. Any
+	// route exceeding this limit is rejected before an HTLC is dispatched
+	// along it. If this value is unspecified, then the time lock of the
+	// payment is unbounded.
+	CltvLimit *uint32
+
+	// OutgoingChannelID is the short channel ID of the channel the
+	// payment must leave through. Only routes whose first hop is this
+	// channel are considered. If this value is unspecified, then any of
+	// our channels may be used.
+	OutgoingChannelID *uint64
+
+	// AttemptDispatched, if non-nil, is called with each HTLC attempt
+	// right before it's handed to the switch. The attempt doesn't have a
+	// ResolveTime yet.
+	AttemptDispatched func(*HTLCAttempt)
+
+	// AttemptResolved, if non-nil, is called once each HTLC dispatched
+	// for the payment has been resolved, whether it succeeded or failed.
+	// It's passed the same attempt AttemptDispatched was called with.
+	AttemptResolved func(*HTLCAttempt)
+
 	// TODO(roasbeef): add e2e message?
 }
 
+// HTLCAttempt describes a single HTLC dispatched by SendPayment in an attempt
+// to complete a payment, along with its outcome.
+type HTLCAttempt struct {
+	// Route is the route the HTLC was sent along.
+	Route *Route
+
+	// AttemptTime is the time at which the HTLC was dispatched.
+	AttemptTime time.Time
+
+	// ResolveTime is the time at which the HTLC was either settled or
+	// failed. It's the zero time while the HTLC is in flight.
+	ResolveTime time.Time
+
+	// Failure is the error the HTLC failed with, or nil if it was settled.
+	Failure error
+}
+
+// mostExpensiveHop returns the ID of the channel whose fee is the highest
+// within the passed route. As each hop pays the fee of the channel following
+// it, the fee of a channel is found within the hop preceding it.
+func mostExpensiveHop(route *Route) uint64 {
+	maxHop := 0
+	for i := range route.Hops[:len(route.Hops)-1] {
+		if route.Hops[i].Fee > route.Hops[maxHop].Fee {
+			maxHop = i
+		}
+	}
+
+	if maxHop+1 < len(route.Hops) {
+		return route.Hops[maxHop+1].Channel.ChannelID
+	}
+	return route.Hops[maxHop].Channel.ChannelID
+}
+
+// longestTimeLockHop returns the ID of the channel imposing the largest time
+// lock delta within the passed route. The final CLTV delta is imposed
+// regardless of the route, so the last hop is only returned if the route
+// consists of it alone.
+func longestTimeLockHop(route *Route) uint64 {
+	lastHop := route.Hops[len(route.Hops)-1]
+	if len(route.Hops) == 1 {
+		return lastHop.Channel.ChannelID
+	}
+
+	maxHop := route.Hops[0]
+	for _, hop := range route.Hops[1 : len(route.Hops)-1] {
+		if hop.Channel.TimeLockDelta > maxHop.Channel.TimeLockDelta {
+			maxHop = hop
+		}
+	}
+
+	return maxHop.Channel.ChannelID
+}
+
 // SendPayment attempts to send a payment as described within the passed
 // LightningPayment. This function is blocking and will return either: when the
 // payment is successful, or all candidates routes have been attempted and
 // resulted in a failed payment. If the payment succeeds, then a non-nil Route
 // will be returned which describes the path the successful payment traversed
 // within the network to reach the destination. Additionally, the payment
-// preimage will also be returned.
+// preimage will also be returned. Routes exceeding the fee or CLTV limit of
+// the payment are skipped. If no route satisfies the limits, then the
+// payment is rejected, and the cheapest offending route is returned along
+// with the error.
 func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route, error) {
+	// Before starting the HTLC routing attempt, we'll create a fresh
+	// payment session which will report our errors back to mission
+	// control.
+	paySession := r.missionControl.NewPaymentSession()
+
+	return r.sendPayment(payment, paySession)
+}
+
+// SendToRoute attempts to send a payment as described within the passed
+// LightningPayment through the provided set of routes. This function is
+// blocking and will return either: when the payment is successful, or all
+// routes have been attempted and resulted in a failed payment. Unlike
+// SendPayment, no path finding is performed, so the routes are attempted in
+// the order given. The target and amount of the payment are taken from the
+// first route, so all routes must lead to the same destination.
+func (r *ChannelRouter) SendToRoute(routes []*Route,
+	payment *LightningPayment) ([32]byte, *Route, error) {
+
+	if len(routes) == 0 {
+		return [32]byte{}, nil, fmt.Errorf("at least one route must " +
+			"be specified")
+	}
+
+	// The destination of the payment is the node at the end of the
+	// routes, which all routes must agree on.
+	firstRoute := routes[0]
+	lastHop := firstRoute.Hops[len(firstRoute.Hops)-1]
+	target := lastHop.Channel.Node.PubKeyBytes
+	for _, route := range routes[1:] {
+		hop := route.Hops[len(route.Hops)-1]
+		if hop.Channel.Node.PubKeyBytes != target {
+			return [32]byte{}, nil, fmt.Errorf("all routes must " +
+				"lead to the same destination")
+		}
+	}
+
+	targetKey, err := btcec.ParsePubKey(target[:], btcec.S256())
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	payment.Target = targetKey
+	payment.Amount = lastHop.AmtToForward
+
+	// Rather than finding routes of its own, the payment session will
+	// hand out the routes we were given, one after another.
+	paySession := r.missionControl.NewPaymentSessionFromRoutes(routes)
+
+	return r.sendPayment(payment, paySession)
+}
+
+// sendPayment attempts to send the passed payment through the routes handed
+// out by the passed payment session, until either the payment succeeds, or
+// the session runs out of routes to try.
+func (r *ChannelRouter) sendPayment(payment *LightningPayment,
+	paySession *paymentSession) ([32]byte, *Route, error) {
+
 	log.Tracef("Dispatching route for lightning payment: %v",
 		newLogClosure(func() string {
 			payment.Target.Curve = nil
@@ -1503,6 +1778,14 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 	var (
 		preImage  [32]byte
 		sendError error
+
+		// limitRoute is the route rejected for exceeding the fee or
+		// CLTV limit of the payment, as reported by limitErr once no
+		// route satisfies the limits. If any route exceeded the fee
+		// limit, it's the cheapest of those, otherwise the first route
+		// exceeding the CLTV limit.
+		limitRoute *Route
+		limitErr   error
 	)
 
 	// errFailedFeeChans is a map of the short channel ID's that were the
@@ -1534,11 +1817,6 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 
 	timeoutChan := time.After(payAttemptTimeout)
 
-	// Before starting the HTLC routing attempt, we'll create a fresh
-	// payment session which will report our errors back to mission
-	// control.
-	paySession := r.missionControl.NewPaymentSession()
-
 	// We'll continue until either our payment succeeds, or we encounter a
 	// critical error during path finding.
 	for {
@@ -1579,9 +1857,56 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 					sendError)
 			}
 
+			// If we found routes, but none of them satisfied the
+			// limits of the payment, then we'll return the
+			// offending route along with the limit it exceeded.
+			if limitErr != nil {
+				return preImage, limitRoute, limitErr
+			}
+
 			return preImage, nil, err
 		}
 
+		// If the payment is subject to a fee limit, then we'll ensure
+		// that the fee of the route doesn't exceed it before
+		// dispatching any HTLCs. Otherwise, we'll exclude the channel
+		// charging the highest fee from the rest of the session, and
+		// look for a cheaper route.
+		if payment.FeeLimit != nil && route.TotalFees > *payment.FeeLimit {
+			if !IsError(limitErr, ErrFeeLimitExceeded) ||
+				route.TotalFees < limitRoute.TotalFees {
+
+				limitRoute = route
+			}
+			limitErr = newErrf(ErrFeeLimitExceeded, "total fee of "+
+				"cheapest route (%v) exceeds fee limit of "+
+				"payment %x (%v)", limitRoute.TotalFees,
+				payment.PaymentHash[:], *payment.FeeLimit)
+
+			paySession.ExcludeChannel(mostExpensiveHop(route))
+			continue
+		}
+
+		// Similarly, if the payment is subject to a CLTV limit, then
+		// we'll ensure that the total time lock of the route doesn't
+		// exceed it, excluding the channel with the largest time lock
+		// delta otherwise.
+		cltvDelta := route.TotalTimeLock - uint32(currentHeight)
+		if payment.CltvLimit != nil && cltvDelta > *payment.CltvLimit {
+			if limitErr == nil {
+				limitRoute = route
+				limitErr = newErrf(ErrCltvLimitExceeded,
+					"total time lock delta of route (%v) "+
+						"exceeds CLTV limit of payment "+
+						"%x (%v)", cltvDelta,
+					payment.PaymentHash[:],
+					*payment.CltvLimit)
+			}
+
+			paySession.ExcludeChannel(longestTimeLockHop(route))
+			continue
+		}
+
 		log.Tracef("Attempting to send payment %x, using route: %v",
 			payment.PaymentHash, newLogClosure(func() string {
 				return spew.Sdump(route)
@@ -1612,9 +1937,21 @@ func (r *ChannelRouter) SendPayment(payment *LightningPayment) ([32]byte, *Route
 		// the payment. If this attempt fails, then we'll continue on
 		// to the next available route.
 		firstHop := route.Hops[0].Channel.Node.PubKeyBytes
+		attempt := &HTLCAttempt{
+			Route:       route,
+			AttemptTime: time.Now(),
+		}
+		if payment.AttemptDispatched != nil {
+			payment.AttemptDispatched(attempt)
+		}
 		preImage, sendError = r.cfg.SendToSwitch(
 			firstHop, htlcAdd, circuit,
 		)
+		attempt.ResolveTime = time.Now()
+		attempt.Failure = sendError
+		if payment.AttemptResolved != nil {
+			payment.AttemptResolved(attempt)
+		}
 		if sendError != nil {
 			// An error occurred when attempting to send the
 			// payment, depending on the error type, we'll either
@@ -1913,6 +2250,7 @@ func (r *ChannelRouter) applyChannelUpdate(msg *lnwire.ChannelUpdate) error {
 		MinHTLC:                   msg.HtlcMinimumMsat,
 		FeeBaseMSat:               lnwire.MilliSatoshi(msg.BaseFee),
 		FeeProportionalMillionths: lnwire.MilliSatoshi(msg.FeeRate),
+		MaxHTLC:                   msg.HtlcMaximumMsat,
 	})
 	if err != nil && !IsError(err, ErrIgnored) {
 		return fmt.Errorf("Unable to apply channel update: %v", err)
@@ -1992,6 +2330,13 @@ func (r *ChannelRouter) UpdateEdge(update *channeldb.ChannelEdgePolicy) error {
 	}
 }
 
+// PruneTip returns the hash and height of the latest block used to prune
+// the channel graph. Upon restart, the router resumes pruning the graph from
+// the block following it.
+func (r *ChannelRouter) PruneTip() (*chainhash.Hash, uint32, error) {
+	return r.cfg.Graph.PruneTip()
+}
+
 // CurrentBlockHeight returns the block height from POV of the router subsystem.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
@@ -2015,7 +2360,7 @@ func (r *ChannelRouter) GetChannelByID(chanID lnwire.ShortChannelID) (
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) error {
-	return r.cfg.Graph.ForEachNode(nil, func(_ *bolt.Tx, n *channeldb.LightningNode) error {
+	return r.cfg.Graph.ForEachNode(nil, func(_ channeldb.ReadTx, n *channeldb.LightningNode) error {
 		return cb(n)
 	})
 }
@@ -2027,7 +2372,7 @@ func (r *ChannelRouter) ForEachNode(cb func(*channeldb.LightningNode) error) err
 func (r *ChannelRouter) ForAllOutgoingChannels(cb func(*channeldb.ChannelEdgeInfo,
 	*channeldb.ChannelEdgePolicy) error) error {
 
-	return r.selfNode.ForEachChannel(nil, func(_ *bolt.Tx, c *channeldb.ChannelEdgeInfo,
+	return r.selfNode.ForEachChannel(nil, func(_ channeldb.ReadTx, c *channeldb.ChannelEdgeInfo,
 		e, _ *channeldb.ChannelEdgePolicy) error {
 
 		return cb(c, e)
@@ -2035,13 +2380,26 @@ func (r *ChannelRouter) ForAllOutgoingChannels(cb func(*channeldb.ChannelEdgeInf
 }
 
 // ForEachChannel is used to iterate over every known edge (channel) within our
-// view of the channel graph.
+// view of the channel graph, skipping zombie channels.
 //
 // NOTE: This method is part of the ChannelGraphSource interface.
 func (r *ChannelRouter) ForEachChannel(cb func(chanInfo *channeldb.ChannelEdgeInfo,
 	e1, e2 *channeldb.ChannelEdgePolicy) error) error {
 
-	return r.cfg.Graph.ForEachChannel(cb)
+	zombies, err := r.cfg.Graph.FetchZombieEdges()
+	if err != nil {
+		return err
+	}
+
+	return r.cfg.Graph.ForEachChannel(func(info *channeldb.ChannelEdgeInfo,
+		e1, e2 *channeldb.ChannelEdgePolicy) error {
+
+		if _, ok := zombies[info.ChannelID]; ok {
+			return nil
+		}
+
+		return cb(info, e1, e2)
+	})
 }
 
 // AddProof updates the channel edge info with proof which is needed to
@@ -203,6 +203,113 @@ func TestFindRoutesFeeSorting(t *testing.T) {
 	}
 }
 
+// TestFindRestrictedRoutes asserts that the routes found by the
+// FindRestrictedRoutes method within the channel router satisfy the fee limit,
+// and avoid the ignored nodes and channels.
+func TestFindRestrictedRoutes(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Without any restrictions, both routes between roasbeef and luo ji
+	// should be found.
+	paymentAmt := lnwire.NewMSatFromSatoshis(100)
+	target := ctx.aliases["luoji"]
+	routes, err := ctx.router.FindRestrictedRoutes(
+		target, paymentAmt, &RouteRestrictions{}, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("2 routes should've been selected, instead %v were: %v",
+			len(routes), spew.Sdump(routes))
+	}
+
+	// A fee limit of the fee of the cheapest route should only leave that
+	// route.
+	feeLimit := routes[0].TotalFees
+	limited, err := ctx.router.FindRestrictedRoutes(
+		target, paymentAmt, &RouteRestrictions{FeeLimit: &feeLimit},
+		defaultNumRoutes, DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(limited) != 1 || limited[0].TotalFees > feeLimit {
+		t.Fatalf("expected single route within fee limit, got: %v",
+			spew.Sdump(limited))
+	}
+
+	// Ignoring the first channel of the cheapest route should only leave
+	// the other route.
+	ignoredChan := routes[0].Hops[0].Channel.ChannelID
+	restrictions := &RouteRestrictions{
+		IgnoredEdges: map[uint64]struct{}{ignoredChan: {}},
+	}
+	avoiding, err := ctx.router.FindRestrictedRoutes(
+		target, paymentAmt, restrictions, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(avoiding) != 1 {
+		t.Fatalf("1 route should've been selected, instead %v were: %v",
+			len(avoiding), spew.Sdump(avoiding))
+	}
+	for _, hop := range avoiding[0].Hops {
+		if hop.Channel.ChannelID == ignoredChan {
+			t.Fatalf("route uses ignored channel: %v",
+				spew.Sdump(avoiding[0]))
+		}
+	}
+
+	// Similarly, ignoring the first node of the more expensive route
+	// should only leave the cheapest one.
+	ignoredNode := routes[1].Hops[0].Channel.Node.PubKeyBytes
+	restrictions = &RouteRestrictions{
+		IgnoredNodes: map[Vertex]struct{}{Vertex(ignoredNode): {}},
+	}
+	avoiding, err = ctx.router.FindRestrictedRoutes(
+		target, paymentAmt, restrictions, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(avoiding) != 1 {
+		t.Fatalf("1 route should've been selected, instead %v were: %v",
+			len(avoiding), spew.Sdump(avoiding))
+	}
+	for _, hop := range avoiding[0].Hops {
+		if hop.Channel.Node.PubKeyBytes == ignoredNode {
+			t.Fatalf("route uses ignored node: %v",
+				spew.Sdump(avoiding[0]))
+		}
+	}
+
+	// Finally, routes can't start at a source unknown to the graph.
+	unknownKey, err := btcec.NewPrivateKey(btcec.S256())
+	if err != nil {
+		t.Fatalf("unable to create key: %v", err)
+	}
+	restrictions = &RouteRestrictions{Source: unknownKey.PubKey()}
+	_, err = ctx.router.FindRestrictedRoutes(
+		target, paymentAmt, restrictions, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if !IsError(err, ErrTargetNotInNetwork) {
+		t.Fatalf("expected unknown source to be rejected, got: %v", err)
+	}
+}
+
 // TestSendPaymentRouteFailureFallback tests that when sending a payment, if
 // one of the target routes is seen as unavailable, then the next route in the
 // queue is used instead. This process should continue until either a payment
@@ -280,6 +387,382 @@ func TestSendPaymentRouteFailureFallback(t *testing.T) {
 	}
 }
 
+// TestSendToRoute tests that payments sent over a set of externally provided
+// routes attempt exactly those routes in order, falling back to the next route
+// if an attempt fails.
+func TestSendToRoute(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// We'll start by finding both routes between roasbeef and luo ji, the
+	// direct one, and the one through satoshi.
+	paymentAmt := lnwire.NewMSatFromSatoshis(1000)
+	routes, err := ctx.router.FindRoutes(
+		ctx.aliases["luoji"], paymentAmt, defaultNumRoutes,
+		DefaultFinalCLTVDelta,
+	)
+	if err != nil {
+		t.Fatalf("unable to find any routes: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("2 routes should've been selected, instead %v were: %v",
+			len(routes), spew.Sdump(routes))
+	}
+	if len(routes[0].Hops) != 1 {
+		t.Fatalf("direct route should be the cheapest, instead: %v",
+			spew.Sdump(routes[0]))
+	}
+
+	// Next, we'll rebuild the route through satoshi from its hops alone,
+	// as an external route builder would, which should result in the
+	// same totals.
+	twoHop := routes[1]
+	sourceVertex := Vertex(ctx.router.selfNode.PubKeyBytes)
+	rebuilt, err := NewRouteFromHops(
+		twoHop.Hops[1].AmtToForward, twoHop.TotalTimeLock, sourceVertex,
+		twoHop.Hops,
+	)
+	if err != nil {
+		t.Fatalf("unable to create route from hops: %v", err)
+	}
+	if rebuilt.TotalAmount != twoHop.TotalAmount ||
+		rebuilt.TotalFees != twoHop.TotalFees {
+
+		t.Fatalf("rebuilt route has totals (%v, %v), expected "+
+			"(%v, %v)", rebuilt.TotalAmount, rebuilt.TotalFees,
+			twoHop.TotalAmount, twoHop.TotalFees)
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	sourceNode := ctx.router.selfNode
+
+	// We'll fail any attempt which has luo ji as the first hop, so only
+	// the route through satoshi is able to succeed.
+	var attempted [][33]byte
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		attempted = append(attempted, n)
+		if bytes.Equal(ctx.aliases["luoji"].SerializeCompressed(), n[:]) {
+			pub, err := sourceNode.PubKey()
+			if err != nil {
+				return preImage, err
+			}
+			return [32]byte{}, &htlcswitch.ForwardingError{
+				ErrorSource:    pub,
+				FailureMessage: &lnwire.FailTemporaryChannelFailure{},
+			}
+		}
+
+		return preImage, nil
+	}
+
+	// If we're only given the direct route, then the payment should fail
+	// without attempting any other route.
+	var payHash [32]byte
+	payment := LightningPayment{
+		PaymentHash: payHash,
+	}
+	_, _, err = ctx.router.SendToRoute(routes[:1], &payment)
+	if err == nil {
+		t.Fatalf("payment over failing route should fail")
+	}
+	if len(attempted) != 1 {
+		t.Fatalf("expected a single attempt, instead %v were made",
+			len(attempted))
+	}
+
+	// Given both routes, the payment should fall back to the rebuilt
+	// route through satoshi.
+	attempted = nil
+	payment = LightningPayment{
+		PaymentHash: payHash,
+	}
+	paymentPreImage, route, err := ctx.router.SendToRoute(
+		[]*Route{routes[0], rebuilt}, &payment,
+	)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if len(attempted) != 2 {
+		t.Fatalf("expected two attempts, instead %v were made",
+			len(attempted))
+	}
+	if route != rebuilt {
+		t.Fatalf("payment should use the route through satoshi, "+
+			"instead used: %v", spew.Sdump(route))
+	}
+	if !bytes.Equal(paymentPreImage[:], preImage[:]) {
+		t.Fatalf("incorrect preimage used: expected %x got %x",
+			preImage[:], paymentPreImage[:])
+	}
+
+	// The target and amount of the payment should've been derived from
+	// the routes.
+	if !payment.Target.IsEqual(ctx.aliases["luoji"]) {
+		t.Fatalf("payment target should be luo ji")
+	}
+	if payment.Amount != paymentAmt {
+		t.Fatalf("payment amount should be %v, is %v", paymentAmt,
+			payment.Amount)
+	}
+}
+
+// TestSendPaymentFeeLimit tests that routes whose total fee exceeds the fee
+// limit of a payment are rejected before any HTLC is dispatched.
+func TestSendPaymentFeeLimit(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Craft a LightningPayment struct that'll send a payment from roasbeef
+	// to sophon for 1000 satoshis. The only path with sufficient capacity
+	// goes through phamnuwen, which charges a hefty fee.
+	var payHash [32]byte
+	feeLimit := lnwire.MilliSatoshi(1000)
+	payment := LightningPayment{
+		Target:      ctx.aliases["sophon"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+		FeeLimit:    &feeLimit,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	var htlcDispatched bool
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		htlcDispatched = true
+		return preImage, nil
+	}
+
+	// As the fee of the route exceeds our limit, the payment should fail
+	// without an HTLC ever being sent.
+	_, route, err := ctx.router.SendPayment(&payment)
+	if !IsError(err, ErrFeeLimitExceeded) {
+		t.Fatalf("expected ErrFeeLimitExceeded, got %v", err)
+	}
+	if htlcDispatched {
+		t.Fatalf("HTLC dispatched for route exceeding fee limit")
+	}
+
+	// The rejected route should be returned along with the error.
+	if route == nil || route.TotalFees <= feeLimit {
+		t.Fatalf("expected route exceeding fee limit to be returned, "+
+			"got %v", spew.Sdump(route))
+	}
+
+	// Once the fee limit is raised above the fee of the route, the
+	// payment should succeed.
+	feeLimit = lnwire.NewMSatFromSatoshis(10000)
+	_, route, err = ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if route.TotalFees > feeLimit {
+		t.Fatalf("route fee of %v exceeds fee limit of %v",
+			route.TotalFees, feeLimit)
+	}
+}
+
+// TestSendPaymentCltvLimit tests that a payment is rejected before any HTLC
+// is dispatched if the total time lock of its route exceeds the CLTV limit of
+// the payment.
+func TestSendPaymentCltvLimit(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Craft a LightningPayment struct that'll send a payment from roasbeef
+	// to sophon for 1000 satoshis. The CLTV limit is below the final CLTV
+	// delta alone, so no route can satisfy it.
+	var payHash [32]byte
+	cltvLimit := uint32(DefaultFinalCLTVDelta - 1)
+	payment := LightningPayment{
+		Target:      ctx.aliases["sophon"],
+		Amount:      lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash: payHash,
+		CltvLimit:   &cltvLimit,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	var htlcDispatched bool
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		htlcDispatched = true
+		return preImage, nil
+	}
+
+	_, _, err = ctx.router.SendPayment(&payment)
+	if !IsError(err, ErrCltvLimitExceeded) {
+		t.Fatalf("expected ErrCltvLimitExceeded, got %v", err)
+	}
+	if htlcDispatched {
+		t.Fatalf("HTLC dispatched for route exceeding CLTV limit")
+	}
+
+	// Once the CLTV limit is raised above the time lock of the route, the
+	// payment should succeed.
+	cltvLimit = 1000
+	_, route, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if route.TotalTimeLock-startingBlockHeight > cltvLimit {
+		t.Fatalf("route time lock of %v exceeds CLTV limit of %v",
+			route.TotalTimeLock, cltvLimit)
+	}
+}
+
+// TestSendPaymentLimitFallback tests that a route exceeding the limits of a
+// payment is skipped in favour of the next route satisfying them, rather than
+// failing the payment.
+func TestSendPaymentLimitFallback(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Raise the time lock delta of the channel between roasbeef and
+	// songoku, so the cheapest route to sophon, which passes through
+	// songoku, exceeds the CLTV limit of the payment.
+	const songokuChanID = 12345
+	_, edge1, edge2, err := ctx.graph.FetchChannelEdgesByID(songokuChanID)
+	if err != nil {
+		t.Fatalf("unable to fetch edges: %v", err)
+	}
+	for _, edge := range []*channeldb.ChannelEdgePolicy{edge1, edge2} {
+		edge.TimeLockDelta = 100
+		if err := ctx.graph.UpdateEdgePolicy(edge); err != nil {
+			t.Fatalf("unable to update edge: %v", err)
+		}
+	}
+
+	var payHash [32]byte
+	cltvLimit := uint32(50)
+	payment := LightningPayment{
+		Target:      ctx.aliases["sophon"],
+		Amount:      lnwire.NewMSatFromSatoshis(100),
+		PaymentHash: payHash,
+		CltvLimit:   &cltvLimit,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		return preImage, nil
+	}
+
+	// The payment should succeed over the more expensive route through
+	// phamnuwen, whose time lock satisfies the limit.
+	_, route, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+	if route.Hops[0].Channel.Node.Alias != "phamnuwen" {
+		t.Fatalf("route should go through phamnuwen as first hop, "+
+			"instead passes through: %v",
+			route.Hops[0].Channel.Node.Alias)
+	}
+
+	// With a zero fee limit, both routes exceed it, so once no route is
+	// left, the payment should fail with the fee limit. The cheapest of
+	// the rejected routes, through songoku, should be returned.
+	feeLimit := lnwire.MilliSatoshi(0)
+	payment.FeeLimit = &feeLimit
+	cltvLimit = 1000
+
+	_, route, err = ctx.router.SendPayment(&payment)
+	if !IsError(err, ErrFeeLimitExceeded) {
+		t.Fatalf("expected ErrFeeLimitExceeded, got %v", err)
+	}
+	if route == nil || route.Hops[0].Channel.Node.Alias != "songoku" {
+		t.Fatalf("expected cheapest route to be returned, got %v",
+			spew.Sdump(route))
+	}
+}
+
+// TestSendPaymentOutgoingChannel tests that a payment restricted to an
+// outgoing channel only considers routes whose first hop is that channel.
+func TestSendPaymentOutgoingChannel(t *testing.T) {
+	t.Parallel()
+
+	const startingBlockHeight = 101
+	ctx, cleanUp, err := createTestCtx(startingBlockHeight, basicGraphFilePath)
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create router: %v", err)
+	}
+
+	// Craft a LightningPayment struct that'll send a payment from roasbeef
+	// to luo ji for 1000 satoshis. Although roasbeef has a direct channel
+	// with luo ji, the payment is restricted to the channel with satoshi.
+	var payHash [32]byte
+	outgoingChanID := uint64(2340213491)
+	payment := LightningPayment{
+		Target:            ctx.aliases["luoji"],
+		Amount:            lnwire.NewMSatFromSatoshis(1000),
+		PaymentHash:       payHash,
+		OutgoingChannelID: &outgoingChanID,
+	}
+
+	var preImage [32]byte
+	copy(preImage[:], bytes.Repeat([]byte{9}, 32))
+
+	ctx.router.cfg.SendToSwitch = func(n [33]byte,
+		_ *lnwire.UpdateAddHTLC, _ *sphinx.Circuit) ([32]byte, error) {
+
+		return preImage, nil
+	}
+
+	_, route, err := ctx.router.SendPayment(&payment)
+	if err != nil {
+		t.Fatalf("unable to send payment: %v", err)
+	}
+
+	// The route should leave through the channel with satoshi, rather
+	// than taking the direct channel to luo ji.
+	if len(route.Hops) != 2 {
+		t.Fatalf("incorrect route length: expected %v got %v", 2,
+			len(route.Hops))
+	}
+	if route.Hops[0].Channel.ChannelID != outgoingChanID {
+		t.Fatalf("route should leave through channel %v, instead "+
+			"leaves through: %v", outgoingChanID,
+			route.Hops[0].Channel.ChannelID)
+	}
+}
+
 // TestSendPaymentErrorRepeatedFeeInsufficient tests that if we receive
 // multiple fee related errors from a channel that we're attempting to route
 // through, then we'll prune the channel after the second attempt.
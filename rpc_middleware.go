@@ -0,0 +1,547 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+const (
+	// rpcMiddlewareTimeout is the time we'll wait for an RPC middleware to
+	// respond to an intercepted message, before failing the call.
+	rpcMiddlewareTimeout = 5 * time.Second
+
+	// registerRPCMiddlewareURI is the URI of the method through which RPC
+	// middlewares register. Its calls are never intercepted, as a
+	// middleware would otherwise be asked to approve its own
+	// registration.
+	registerRPCMiddlewareURI = "/lnrpc.Lightning/RegisterRPCMiddleware"
+)
+
+var (
+	// errRPCMiddlewareNameTaken is returned when an RPC middleware is
+	// registered under the name of one which is still active.
+	errRPCMiddlewareNameTaken = errors.New("an RPC middleware of the " +
+		"same name is already registered")
+
+	// errRPCMiddlewareTimeout is returned when an RPC middleware doesn't
+	// respond to an intercepted message in time.
+	errRPCMiddlewareTimeout = errors.New("RPC middleware timed out")
+
+	// errRPCMiddlewareUnavailable is returned when an RPC middleware is
+	// unregistered before it responded to an intercepted message.
+	errRPCMiddlewareUnavailable = errors.New("RPC middleware unavailable")
+)
+
+// rpcMiddleware is an external process which intercepts the messages of RPC
+// calls. A middleware is either responsible for a custom macaroon caveat, in
+// which case it's handed the calls made with macaroons carrying the caveat and
+// may reject or modify them, or runs in read-only mode, in which case it's
+// handed all calls but its feedback is ignored.
+type rpcMiddleware struct {
+	name             string
+	customCaveatName string
+	readOnly         bool
+
+	// Requests is the channel over which the intercepted messages are
+	// delivered to the middleware.
+	Requests chan *lnrpc.RPCMiddlewareRequest
+
+	// pending maps the id of each message delivered to the middleware,
+	// which it didn't respond to yet, to the channel its feedback is sent
+	// over.
+	mtx       sync.Mutex
+	nextMsgID uint64
+	pending   map[uint64]chan *lnrpc.InterceptFeedback
+
+	quit chan struct{}
+}
+
+// intercept hands the passed message to the middleware, and returns its
+// feedback.
+func (m *rpcMiddleware) intercept(
+	req *lnrpc.RPCMiddlewareRequest) (*lnrpc.InterceptFeedback, error) {
+
+	feedbackChan := make(chan *lnrpc.InterceptFeedback, 1)
+
+	m.mtx.Lock()
+	m.nextMsgID++
+	req.MsgId = m.nextMsgID
+	m.pending[req.MsgId] = feedbackChan
+	m.mtx.Unlock()
+
+	defer func() {
+		m.mtx.Lock()
+		delete(m.pending, req.MsgId)
+		m.mtx.Unlock()
+	}()
+
+	timeout := time.After(rpcMiddlewareTimeout)
+
+	select {
+	case m.Requests <- req:
+	case <-m.quit:
+		return nil, errRPCMiddlewareUnavailable
+	case <-timeout:
+		return nil, errRPCMiddlewareTimeout
+	}
+
+	select {
+	case feedback := <-feedbackChan:
+		return feedback, nil
+	case <-m.quit:
+		return nil, errRPCMiddlewareUnavailable
+	case <-timeout:
+		return nil, errRPCMiddlewareTimeout
+	}
+}
+
+// Resolve hands the feedback of the middleware upon the message with the
+// passed id to the call it was intercepted from.
+func (m *rpcMiddleware) Resolve(msgID uint64,
+	feedback *lnrpc.InterceptFeedback) error {
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	feedbackChan, ok := m.pending[msgID]
+	if !ok {
+		return fmt.Errorf("no message %v pending", msgID)
+	}
+	delete(m.pending, msgID)
+
+	feedbackChan <- feedback
+	return nil
+}
+
+// rpcMiddlewareRegistry holds the registered RPC middlewares, and hands them
+// the messages of the RPC calls they're responsible for through its gRPC
+// interceptors.
+type rpcMiddlewareRegistry struct {
+	nextRequestID uint64 // To be used atomically.
+
+	mtx         sync.RWMutex
+	middlewares map[string]*rpcMiddleware
+}
+
+// newRPCMiddlewareRegistry creates a new registry without any middleware.
+func newRPCMiddlewareRegistry() *rpcMiddlewareRegistry {
+	return &rpcMiddlewareRegistry{
+		middlewares: make(map[string]*rpcMiddleware),
+	}
+}
+
+// Register registers a new middleware, which is handed the messages of the
+// calls it's responsible for until it's unregistered.
+func (r *rpcMiddlewareRegistry) Register(
+	reg *lnrpc.MiddlewareRegistration) (*rpcMiddleware, error) {
+
+	switch {
+	case reg.MiddlewareName == "":
+		return nil, errors.New("middleware name must be set")
+
+	case reg.CustomMacaroonCaveatName == "" && !reg.ReadOnlyMode:
+		return nil, errors.New("middleware must either be responsible " +
+			"for a custom caveat or run in read-only mode")
+
+	case reg.CustomMacaroonCaveatName != "" && reg.ReadOnlyMode:
+		return nil, errors.New("middleware in read-only mode can't be " +
+			"responsible for a custom caveat")
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.middlewares[reg.MiddlewareName]; ok {
+		return nil, errRPCMiddlewareNameTaken
+	}
+
+	middleware := &rpcMiddleware{
+		name:             reg.MiddlewareName,
+		customCaveatName: reg.CustomMacaroonCaveatName,
+		readOnly:         reg.ReadOnlyMode,
+		Requests:         make(chan *lnrpc.RPCMiddlewareRequest),
+		pending:          make(map[uint64]chan *lnrpc.InterceptFeedback),
+		quit:             make(chan struct{}),
+	}
+	r.middlewares[middleware.name] = middleware
+
+	return middleware, nil
+}
+
+// Unregister unregisters the passed middleware. The calls waiting for its
+// feedback are failed, unless it runs in read-only mode.
+func (r *rpcMiddlewareRegistry) Unregister(middleware *rpcMiddleware) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.middlewares[middleware.name] != middleware {
+		return
+	}
+
+	close(middleware.quit)
+	delete(r.middlewares, middleware.name)
+}
+
+// callMiddleware is a middleware intercepting a call, along with the
+// condition of the custom caveat it's responsible for, as found in the
+// macaroon of the call.
+type callMiddleware struct {
+	*rpcMiddleware
+
+	caveatCondition string
+}
+
+// interceptedCall is an RPC call whose messages are handed to the middlewares
+// intercepting it.
+type interceptedCall struct {
+	requestID   uint64
+	fullMethod  string
+	stream      bool
+	rawMacaroon []byte
+
+	// middlewares are the middlewares intercepting the call, in the order
+	// in which each message is handed to them.
+	middlewares []callMiddleware
+}
+
+// newCall returns the call to the passed method made with the given context,
+// if any middleware intercepts it, and nil otherwise. As lnd itself accepts
+// all custom caveats, a call made with a macaroon carrying a custom caveat is
+// refused if no middleware responsible for the caveat is registered, since
+// its condition would otherwise not be enforced at all.
+func (r *rpcMiddlewareRegistry) newCall(ctx context.Context,
+	fullMethod string, stream bool) (*interceptedCall, error) {
+
+	if fullMethod == registerRPCMiddlewareURI {
+		return nil, nil
+	}
+
+	// Calls made without a macaroon, which is the case if macaroons are
+	// disabled, are only handed to the middlewares in read-only mode.
+	// Macaroons which can't be parsed are rejected by the macaroon
+	// service, so we treat them the same way.
+	var customCaveats []macaroons.CustomCaveat
+	mac, err := macaroons.MacaroonFromContext(ctx)
+	if err != nil {
+		mac = nil
+	}
+	if mac != nil {
+		constraints, err := macaroons.ParseConstraints(mac)
+		if err != nil {
+			return nil, err
+		}
+		customCaveats = constraints.CustomCaveats
+	}
+
+	r.mtx.RLock()
+	middlewares := make([]*rpcMiddleware, 0, len(r.middlewares))
+	caveatMiddlewares := make(map[string]*rpcMiddleware)
+	for _, middleware := range r.middlewares {
+		middlewares = append(middlewares, middleware)
+		if !middleware.readOnly {
+			caveatMiddlewares[middleware.customCaveatName] = middleware
+		}
+	}
+	r.mtx.RUnlock()
+
+	for _, caveat := range customCaveats {
+		if _, ok := caveatMiddlewares[caveat.Name]; !ok {
+			return nil, fmt.Errorf("no RPC middleware registered "+
+				"for custom caveat %v", caveat.Name)
+		}
+	}
+
+	if len(middlewares) == 0 {
+		return nil, nil
+	}
+
+	call := &interceptedCall{
+		requestID:  atomic.AddUint64(&r.nextRequestID, 1),
+		fullMethod: fullMethod,
+		stream:     stream,
+	}
+	for _, middleware := range middlewares {
+		if middleware.readOnly {
+			call.middlewares = append(call.middlewares,
+				callMiddleware{rpcMiddleware: middleware})
+			continue
+		}
+
+		if mac == nil {
+			continue
+		}
+		condition, ok := macaroons.CustomCaveatCondition(
+			mac, middleware.customCaveatName,
+		)
+		if !ok {
+			continue
+		}
+		call.middlewares = append(call.middlewares, callMiddleware{
+			rpcMiddleware:   middleware,
+			caveatCondition: condition,
+		})
+	}
+
+	if len(call.middlewares) == 0 {
+		return nil, nil
+	}
+
+	// The middlewares are consulted in a stable order, so that the
+	// modifications of one are seen by the following ones.
+	sort.Slice(call.middlewares, func(i, j int) bool {
+		return call.middlewares[i].name < call.middlewares[j].name
+	})
+
+	if mac != nil {
+		call.rawMacaroon, err = mac.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return call, nil
+}
+
+// intercept hands the passed message of the call to each middleware
+// intercepting it, and returns the message as modified by them. If any
+// middleware rejects the message, then the call is failed.
+func (c *interceptedCall) intercept(
+	interceptType lnrpc.RPCMiddlewareRequest_InterceptType,
+	msg proto.Message) (proto.Message, error) {
+
+	serialized, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, middleware := range c.middlewares {
+		feedback, err := middleware.intercept(&lnrpc.RPCMiddlewareRequest{
+			RequestId:             c.requestID,
+			MethodFullUri:         c.fullMethod,
+			StreamRpc:             c.stream,
+			Type:                  interceptType,
+			TypeName:              proto.MessageName(msg),
+			Serialized:            serialized,
+			RawMacaroon:           c.rawMacaroon,
+			CustomCaveatCondition: middleware.caveatCondition,
+		})
+
+		// The feedback of a middleware in read-only mode is ignored,
+		// so it may not fail the call either.
+		if middleware.readOnly {
+			if err != nil {
+				rpcsLog.Debugf("RPC middleware %v didn't "+
+					"respond to %v: %v", middleware.name,
+					c.fullMethod, err)
+			}
+			continue
+		}
+
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("RPC middleware %v: %v",
+				middleware.name, err)
+
+		case feedback.Error != "":
+			return nil, fmt.Errorf("rejected by RPC middleware "+
+				"%v: %v", middleware.name, feedback.Error)
+
+		case !feedback.ReplaceMessage:
+			continue
+		}
+
+		replacement := proto.Clone(msg)
+		replacement.Reset()
+		err = proto.Unmarshal(feedback.ReplacementSerialized, replacement)
+		if err != nil {
+			return nil, fmt.Errorf("invalid replacement by RPC "+
+				"middleware %v: %v", middleware.name, err)
+		}
+
+		msg = replacement
+		serialized = feedback.ReplacementSerialized
+	}
+
+	return msg, nil
+}
+
+// UnaryServerInterceptor is a gRPC interceptor which hands the request and
+// response of each unary call to the middlewares intercepting it.
+func (r *rpcMiddlewareRegistry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		reqMsg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		call, err := r.newCall(ctx, info.FullMethod, false)
+		if err != nil {
+			return nil, err
+		}
+		if call == nil {
+			return handler(ctx, req)
+		}
+
+		reqMsg, err = call.intercept(
+			lnrpc.RPCMiddlewareRequest_REQUEST, reqMsg,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, reqMsg)
+		if err != nil {
+			return nil, err
+		}
+
+		respMsg, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+
+		respMsg, err = call.intercept(
+			lnrpc.RPCMiddlewareRequest_RESPONSE, respMsg,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return respMsg, nil
+	}
+}
+
+// StreamServerInterceptor is a gRPC interceptor which hands each message
+// received and sent over a streaming call to the middlewares intercepting it.
+func (r *rpcMiddlewareRegistry) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		call, err := r.newCall(ss.Context(), info.FullMethod, true)
+		if err != nil {
+			return err
+		}
+		if call == nil {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &interceptedStream{
+			ServerStream: ss,
+			call:         call,
+		})
+	}
+}
+
+// interceptedStream is a server stream whose messages are handed to the
+// middlewares intercepting the call.
+type interceptedStream struct {
+	grpc.ServerStream
+
+	call *interceptedCall
+}
+
+// RecvMsg receives the next request of the stream, as modified by the
+// middlewares.
+//
+// NOTE: This is part of the grpc.ServerStream interface.
+func (s *interceptedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	replacement, err := s.call.intercept(
+		lnrpc.RPCMiddlewareRequest_REQUEST, msg,
+	)
+	if err != nil {
+		return err
+	}
+
+	// The request is received into the message passed by the caller, so
+	// any replacement is copied into it.
+	if replacement != msg {
+		msg.Reset()
+		proto.Merge(msg, replacement)
+	}
+
+	return nil
+}
+
+// SendMsg sends the passed response over the stream, as modified by the
+// middlewares.
+//
+// NOTE: This is part of the grpc.ServerStream interface.
+func (s *interceptedStream) SendMsg(m interface{}) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return s.ServerStream.SendMsg(m)
+	}
+
+	replacement, err := s.call.intercept(
+		lnrpc.RPCMiddlewareRequest_RESPONSE, msg,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.ServerStream.SendMsg(replacement)
+}
+
+// chainUnaryServerInterceptors returns a unary interceptor which runs the
+// passed interceptors in order, as gRPC only accepts a single one.
+func chainUnaryServerInterceptors(
+	interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context,
+				req interface{}) (interface{}, error) {
+
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chained(ctx, req)
+	}
+}
+
+// chainStreamServerInterceptors returns a stream interceptor which runs the
+// passed interceptors in order, as gRPC only accepts a single one.
+func chainStreamServerInterceptors(
+	interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+
+	return func(srv interface{}, ss grpc.ServerStream,
+		info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+
+		return chained(srv, ss)
+	}
+}
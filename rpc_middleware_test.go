@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+// macaroonContext returns an incoming request context carrying a macaroon
+// with a custom caveat of the passed name and condition. If the name is
+// empty, then the macaroon carries no custom caveat.
+func macaroonContext(t *testing.T, caveatName,
+	condition string) context.Context {
+
+	mac, err := macaroon.New(
+		[]byte("root key"), []byte("id"), "lnd", macaroon.LatestVersion,
+	)
+	if err != nil {
+		t.Fatalf("unable to create macaroon: %v", err)
+	}
+	if caveatName != "" {
+		mac, err = macaroons.AddConstraints(
+			mac, macaroons.CustomConstraint(caveatName, condition),
+		)
+		if err != nil {
+			t.Fatalf("unable to add custom caveat: %v", err)
+		}
+	}
+
+	macBytes, err := mac.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to serialize macaroon: %v", err)
+	}
+	md := metadata.Pairs("macaroon", hex.EncodeToString(macBytes))
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// TestRPCMiddleware tests that the requests and responses of calls made with
+// a macaroon carrying the custom caveat of a middleware are handed to it, and
+// rejected or modified according to its feedback, while the feedback of a
+// middleware in read-only mode is ignored.
+func TestRPCMiddleware(t *testing.T) {
+	t.Parallel()
+
+	registry := newRPCMiddlewareRegistry()
+
+	interceptor := registry.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{
+		FullMethod: "/lnrpc.Lightning/SignMessage",
+	}
+	handler := func(ctx context.Context,
+		req interface{}) (interface{}, error) {
+
+		msg := req.(*lnrpc.SignMessageRequest).Msg
+		return &lnrpc.SignMessageResponse{Signature: string(msg)}, nil
+	}
+	signMessage := func(ctx context.Context) (string, error) {
+		resp, err := interceptor(
+			ctx, &lnrpc.SignMessageRequest{Msg: []byte("msg")},
+			info, handler,
+		)
+		if err != nil {
+			return "", err
+		}
+		return resp.(*lnrpc.SignMessageResponse).Signature, nil
+	}
+
+	// Before any middleware is registered, a call made with the custom
+	// caveat should be refused, as its condition can't be enforced.
+	_, err := signMessage(macaroonContext(t, "account", "allow"))
+	if err == nil || !strings.Contains(err.Error(), "no RPC middleware") {
+		t.Fatalf("expected call to be refused, got: %v", err)
+	}
+
+	middleware, err := registry.Register(&lnrpc.MiddlewareRegistration{
+		MiddlewareName:           "accounts",
+		CustomMacaroonCaveatName: "account",
+	})
+	if err != nil {
+		t.Fatalf("unable to register middleware: %v", err)
+	}
+	_, err = registry.Register(&lnrpc.MiddlewareRegistration{
+		MiddlewareName: "accounts",
+		ReadOnlyMode:   true,
+	})
+	if err != errRPCMiddlewareNameTaken {
+		t.Fatalf("expected duplicate name to be refused, got: %v", err)
+	}
+
+	// The middleware rejects calls made with the "deny" condition, and
+	// otherwise replaces the message to be signed.
+	var seen []*lnrpc.RPCMiddlewareRequest
+	go func() {
+		for req := range middleware.Requests {
+			seen = append(seen, req)
+
+			feedback := &lnrpc.InterceptFeedback{}
+			switch {
+			case req.CustomCaveatCondition == "deny":
+				feedback.Error = "denied"
+
+			case req.Type == lnrpc.RPCMiddlewareRequest_REQUEST:
+				replacement, err := proto.Marshal(
+					&lnrpc.SignMessageRequest{
+						Msg: []byte("replaced"),
+					},
+				)
+				if err != nil {
+					panic(err)
+				}
+				feedback.ReplaceMessage = true
+				feedback.ReplacementSerialized = replacement
+			}
+
+			if err := middleware.Resolve(req.MsgId, feedback); err != nil {
+				panic(err)
+			}
+		}
+	}()
+
+	// A call made without the custom caveat shouldn't be intercepted.
+	sig, err := signMessage(macaroonContext(t, "", ""))
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+	if sig != "msg" {
+		t.Fatalf("expected unmodified request, got %v", sig)
+	}
+
+	// A call made with the custom caveat should have its request
+	// replaced, and both request and response handed to the middleware.
+	sig, err = signMessage(macaroonContext(t, "account", "allow"))
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+	if sig != "replaced" {
+		t.Fatalf("expected replaced request, got %v", sig)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 intercepted messages, got %d", len(seen))
+	}
+	for i, typeName := range []string{
+		"lnrpc.SignMessageRequest", "lnrpc.SignMessageResponse",
+	} {
+		if seen[i].TypeName != typeName {
+			t.Fatalf("expected %v, got %v", typeName,
+				seen[i].TypeName)
+		}
+		if seen[i].CustomCaveatCondition != "allow" {
+			t.Fatalf("unexpected caveat condition %v",
+				seen[i].CustomCaveatCondition)
+		}
+		if seen[i].RequestId != seen[0].RequestId {
+			t.Fatalf("messages of one call have distinct ids")
+		}
+	}
+
+	// A call the middleware rejects should fail.
+	_, err = signMessage(macaroonContext(t, "account", "deny"))
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("expected call to be rejected, got: %v", err)
+	}
+
+	// A call made with a custom caveat no registered middleware is
+	// responsible for should be refused.
+	_, err = signMessage(macaroonContext(t, "other", ""))
+	if err == nil || !strings.Contains(err.Error(), "no RPC middleware") {
+		t.Fatalf("expected call to be refused, got: %v", err)
+	}
+
+	// The feedback of a middleware in read-only mode is ignored, even if
+	// it doesn't respond at all.
+	registry.Unregister(middleware)
+	close(middleware.Requests)
+
+	readOnly, err := registry.Register(&lnrpc.MiddlewareRegistration{
+		MiddlewareName: "logger",
+		ReadOnlyMode:   true,
+	})
+	if err != nil {
+		t.Fatalf("unable to register middleware: %v", err)
+	}
+	go func() {
+		req := <-readOnly.Requests
+		feedback := &lnrpc.InterceptFeedback{Error: "ignored"}
+		if err := readOnly.Resolve(req.MsgId, feedback); err != nil {
+			panic(err)
+		}
+
+		registry.Unregister(readOnly)
+	}()
+
+	sig, err = signMessage(macaroonContext(t, "", ""))
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+	if sig != "msg" {
+		t.Fatalf("expected unmodified request, got %v", sig)
+	}
+
+	// Once the middleware responsible for the custom caveat went offline,
+	// calls made with the caveat should be refused rather than pass
+	// through unrestricted.
+	_, err = signMessage(macaroonContext(t, "account", "allow"))
+	if err == nil || !strings.Contains(err.Error(), "no RPC middleware") {
+		t.Fatalf("expected call to be refused, got: %v", err)
+	}
+}
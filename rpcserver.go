@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,22 +10,26 @@ import (
 	"io"
 	"math"
 	"net"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"gopkg.in/macaroon-bakery.v2/bakery"
+	macaroon "gopkg.in/macaroon.v2"
 
 	"sync"
 	"sync/atomic"
 
-	"github.com/coreos/bbolt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/channeldb"
 	"github.com/lightningnetwork/lnd/htlcswitch"
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/lnwallet"
 	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/macaroons"
 	"github.com/lightningnetwork/lnd/routing"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/roasbeef/btcd/blockchain"
@@ -36,6 +41,9 @@ import (
 	"github.com/roasbeef/btcwallet/waddrmgr"
 	"github.com/tv42/zbase32"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 )
 
 var (
@@ -139,6 +147,10 @@ var (
 			Entity: "onchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/EstimateFee": {{
+			Entity: "onchain",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/NewAddress": {{
 			Entity: "address",
 			Action: "write",
@@ -184,6 +196,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/AbandonChannel": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/GetInfo": {{
 			Entity: "info",
 			Action: "read",
@@ -216,6 +232,22 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/SendPaymentStream": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/TrackPayment": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SendToRoute": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SendToRouteSync": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/AddInvoice": {{
 			Entity: "invoices",
 			Action: "write",
@@ -260,6 +292,10 @@ var (
 			Entity: "info",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/GetNodeMetrics": {{
+			Entity: "info",
+			Action: "read",
+		}},
 		"/lnrpc.Lightning/StopDaemon": {{
 			Entity: "info",
 			Action: "write",
@@ -276,6 +312,10 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/DeletePayments": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/DebugLevel": {{
 			Entity: "info",
 			Action: "write",
@@ -292,10 +332,121 @@ var (
 			Entity: "offchain",
 			Action: "write",
 		}},
+		"/lnrpc.Lightning/LabelChannel": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
 		"/lnrpc.Lightning/ForwardingHistory": {{
 			Entity: "offchain",
 			Action: "read",
 		}},
+		"/lnrpc.Lightning/AddPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ListPolicies": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/LookupPolicy": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/DeletePolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/SetDefaultPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/DefaultPolicy": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/DeleteDefaultPolicy": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ExportPolicies": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/ImportPolicies": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/PolicyAuditLog": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/CompactDatabase": {{
+			Entity: "info",
+			Action: "write",
+		}},
+		// As the database holds the revocation secrets of our
+		// channels, exporting it requires permission to write offchain
+		// state.
+		"/lnrpc.Lightning/ExportDatabase": {{
+			Entity: "info",
+			Action: "read",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}, {
+			Entity: "onchain",
+			Action: "read",
+		}, {
+			Entity: "invoices",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SubscribeChannelEvents": {{
+			Entity: "offchain",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/SubscribePeerEvents": {{
+			Entity: "peers",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/HtlcInterceptor": {{
+			Entity: "offchain",
+			Action: "write",
+		}},
+		"/lnrpc.Lightning/ChannelAcceptor": {{
+			Entity: "onchain",
+			Action: "write",
+		}, {
+			Entity: "offchain",
+			Action: "write",
+		}},
+
+		// An RPC middleware may inspect and modify all calls, so
+		// registering one requires all permissions.
+		"/lnrpc.Lightning/RegisterRPCMiddleware": append(
+			append([]bakery.Op{}, readPermissions...),
+			writePermissions...,
+		),
+
+		// Baking a macaroon also requires all permissions, as it
+		// would otherwise allow a caller to escalate its privileges.
+		// The same goes for deleting a root key, which revokes the
+		// macaroons of others.
+		"/lnrpc.Lightning/BakeMacaroon": append(
+			append([]bakery.Op{}, readPermissions...),
+			writePermissions...,
+		),
+		"/lnrpc.Lightning/ListMacaroonIDs": {{
+			Entity: "info",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/CheckMacaroonPermissions": {{
+			Entity: "info",
+			Action: "read",
+		}},
+		"/lnrpc.Lightning/DeleteMacaroonID": append(
+			append([]bakery.Op{}, readPermissions...),
+			writePermissions...,
+		),
 	}
 )
 
@@ -303,6 +454,19 @@ const (
 	// maxPaymentMSat is the maximum allowed payment permitted currently as
 	// defined in BOLT-0002.
 	maxPaymentMSat = lnwire.MilliSatoshi(math.MaxUint32)
+
+	// maxDBChunkSize is the maximum size in bytes of the chunks in which a
+	// database snapshot is streamed by ExportDatabase.
+	maxDBChunkSize = 1 << 20
+)
+
+var (
+	// signedMsgPrefix is a special prefix that we'll prepend to any
+	// messages we sign/verify. We do this to ensure that we don't
+	// accidentally sign a sighash, or other sensitive material. By
+	// prepending this fragment, we bind message signing to our particular
+	// context.
+	signedMsgPrefix = []byte("Lightning Signed Message:")
 )
 
 // rpcServer is a gRPC, RPC front end to the lnd daemon.
@@ -311,8 +475,17 @@ type rpcServer struct {
 	started  int32 // To be used atomically.
 	shutdown int32 // To be used atomically.
 
+	// interceptorActive is set while a client is intercepting HTLC
+	// forwards, as only a single interceptor may be active at a time.
+	interceptorActive int32 // To be used atomically.
+
 	server *server
 
+	// macService is the macaroon service the calls to the server are
+	// authenticated with, which is used to bake new macaroons. It's nil
+	// if macaroons are disabled.
+	macService *macaroons.Service
+
 	wg sync.WaitGroup
 
 	quit chan struct{}
@@ -322,11 +495,13 @@ type rpcServer struct {
 // LightningServer gRPC service.
 var _ lnrpc.LightningServer = (*rpcServer)(nil)
 
-// newRPCServer creates and returns a new instance of the rpcServer.
-func newRPCServer(s *server) *rpcServer {
+// newRPCServer creates and returns a new instance of the rpcServer. The passed
+// macaroon service may be nil if macaroons are disabled.
+func newRPCServer(s *server, macService *macaroons.Service) *rpcServer {
 	return &rpcServer{
-		server: s,
-		quit:   make(chan struct{}, 1),
+		server:     s,
+		macService: macService,
+		quit:       make(chan struct{}, 1),
 	}
 }
 
@@ -480,6 +655,56 @@ func (r *rpcServer) SendMany(ctx context.Context,
 	return &lnrpc.SendManyResponse{Txid: txid.String()}, nil
 }
 
+// EstimateFee handles a request for estimating the fee for sending a
+// transaction spending to multiple specified outputs in parallel. The
+// transaction is crafted by the wallet, but never broadcast.
+func (r *rpcServer) EstimateFee(ctx context.Context,
+	in *lnrpc.EstimateFeeRequest) (*lnrpc.EstimateFeeResponse, error) {
+
+	if len(in.AddrToAmount) == 0 {
+		return nil, fmt.Errorf("at least one output must be specified")
+	}
+
+	// Based on the confirmation target, we'll determine an appropriate fee
+	// rate for this transaction.
+	feeRate, err := determineFeePerVSize(
+		r.server.cc.feeEstimator, in.TargetConf, 0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := addrPairsToOutputs(in.AddrToAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the outputs and fee rate known, we'll have the wallet craft the
+	// transaction, which will select the inputs and add a change output
+	// if needed.
+	tx, err := r.server.cc.wallet.CreateSimpleTx(outputs, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee of the transaction is whatever its inputs don't pay to its
+	// outputs, including the change output.
+	var totalOutput btcutil.Amount
+	for _, txOut := range tx.Tx.TxOut {
+		totalOutput += btcutil.Amount(txOut.Value)
+	}
+	totalFee := tx.TotalInput - totalOutput
+
+	rpcsLog.Debugf("[estimatefee] fee estimate for outputs=%v: fee=%v, "+
+		"sat/vbyte=%v", spew.Sdump(in.AddrToAmount), totalFee,
+		int64(feeRate))
+
+	return &lnrpc.EstimateFeeResponse{
+		FeeSat:            int64(totalFee),
+		FeerateSatPerByte: int64(feeRate),
+	}, nil
+}
+
 // NewAddress creates a new address under control of the local wallet.
 func (r *rpcServer) NewAddress(ctx context.Context,
 	in *lnrpc.NewAddressRequest) (*lnrpc.NewAddressResponse, error) {
@@ -522,7 +747,8 @@ func (r *rpcServer) NewWitnessAddress(ctx context.Context,
 // SignMessage signs a message with the resident node's private key. The
 // returned signature string is zbase32 encoded and pubkey recoverable,
 // meaning that only the message digest and signature are needed for
-// verification.
+// verification. The message is prefixed with signedMsgPrefix before it's
+// signed.
 func (r *rpcServer) SignMessage(ctx context.Context,
 	in *lnrpc.SignMessageRequest) (*lnrpc.SignMessageResponse, error) {
 
@@ -530,7 +756,10 @@ func (r *rpcServer) SignMessage(ctx context.Context,
 		return nil, fmt.Errorf("need a message to sign")
 	}
 
-	sigBytes, err := r.server.nodeSigner.SignCompact(in.Msg)
+	// The request itself is left untouched, as it may still be seen by
+	// RPC middlewares and logged after we return.
+	msg := append(append([]byte{}, signedMsgPrefix...), in.Msg...)
+	sigBytes, err := r.server.nodeSigner.SignCompact(msg)
 	if err != nil {
 		return nil, err
 	}
@@ -556,8 +785,10 @@ func (r *rpcServer) VerifyMessage(ctx context.Context,
 		return nil, fmt.Errorf("failed to decode signature: %v", err)
 	}
 
-	// The signature is over the double-sha256 hash of the message.
-	digest := chainhash.DoubleHashB(in.Msg)
+	// The signature is over the double-sha256 hash of the message, with
+	// our signed message prefix prepended.
+	msg := append(append([]byte{}, signedMsgPrefix...), in.Msg...)
+	digest := chainhash.DoubleHashB(msg)
 
 	// RecoverCompact both recovers the pubkey and validates the signature.
 	pubKey, _, err := btcec.RecoverCompact(btcec.S256(), sig, digest)
@@ -785,7 +1016,7 @@ func (r *rpcServer) OpenChannel(in *lnrpc.OpenChannelRequest,
 	updateChan, errChan := r.server.OpenChannel(
 		nodePubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, in.ThawHeight,
 	)
 
 	var outpoint wire.OutPoint
@@ -913,7 +1144,7 @@ func (r *rpcServer) OpenChannelSync(ctx context.Context,
 	updateChan, errChan := r.server.OpenChannel(
 		nodepubKey, localFundingAmt,
 		lnwire.NewMSatFromSatoshis(remoteInitialBalance),
-		minHtlc, feeRate, in.Private, remoteCsvDelay,
+		minHtlc, feeRate, in.Private, remoteCsvDelay, in.ThawHeight,
 	)
 
 	select {
@@ -1151,6 +1382,77 @@ out:
 	return nil
 }
 
+// AbandonChannel removes all channel state from the database except for a
+// close summary. This method can be used to get rid of permanently unusable
+// channels due to bugs fixed in newer versions of lnd. As no action is taken
+// on-chain, any funds still committed to the channel are lost.
+func (r *rpcServer) AbandonChannel(ctx context.Context,
+	in *lnrpc.AbandonChannelRequest) (*lnrpc.AbandonChannelResponse, error) {
+
+	// Abandoning a channel forfeits its funds, so we'll only allow it if
+	// the daemon was explicitly started with the unsafe flag.
+	if !cfg.UnsafeAbandon {
+		return nil, fmt.Errorf("AbandonChannel is only available when " +
+			"lnd is started with --unsafe-abandon")
+	}
+
+	index := in.GetChannelPoint().GetOutputIndex()
+	txidHash, err := getChanPointFundingTxid(in.GetChannelPoint())
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.NewOutPoint(txid, index)
+
+	rpcsLog.Infof("[abandonchannel] request for ChannelPoint(%v)",
+		chanPoint)
+
+	dbChan, err := r.server.chanDB.FetchChannel(*chanPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// Before removing the channel from the database, we'll ensure that
+	// the switch no longer considers it eligible for forwarding HTLC's.
+	// If the peer is online, then we'll also purge all of its indexes.
+	if peer, err := r.server.FindPeer(dbChan.IdentityPub); err == nil {
+		peer.WipeChannel(chanPoint)
+	} else {
+		chanID := lnwire.NewChanIDFromOutPoint(chanPoint)
+		r.server.htlcSwitch.RemoveLink(chanID)
+	}
+
+	_, bestHeight, err := r.server.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// With the link torn down, we'll remove all state of the channel from
+	// the database, leaving only a close summary behind.
+	err = r.server.chanDB.AbandonChannel(chanPoint, uint32(bestHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	// Now that the channel is gone from the database, the chain
+	// arbitrator no longer needs to watch over it.
+	if err := r.server.chainArb.ResolveContract(*chanPoint); err != nil {
+		return nil, err
+	}
+
+	// Finally, we'll remove the channel from our view of the graph, if it
+	// was ever announced or added to it.
+	err = r.server.chanDB.ChannelGraph().DeleteChannelEdge(chanPoint)
+	if err != nil && err != channeldb.ErrEdgeNotFound {
+		return nil, err
+	}
+
+	return &lnrpc.AbandonChannelResponse{}, nil
+}
+
 // fetchActiveChannel attempts to locate a channel identified by its channel
 // point from the database's set of all currently opened channels.
 func (r *rpcServer) fetchActiveChannel(chanPoint wire.OutPoint) (*lnwallet.LightningChannel, error) {
@@ -1278,6 +1580,10 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			satRecv += int64(c.TotalMSatReceived.ToSatoshis())
 		}
 
+		flapCount, lastFlap := r.server.PeerFlaps(
+			serverPeer.addr.IdentityKey,
+		)
+
 		nodePub := serverPeer.addr.IdentityKey.SerializeCompressed()
 		peer := &lnrpc.Peer{
 			PubKey:    hex.EncodeToString(nodePub),
@@ -1288,6 +1594,10 @@ func (r *rpcServer) ListPeers(ctx context.Context,
 			SatSent:   satSent,
 			SatRecv:   satRecv,
 			PingTime:  serverPeer.PingTime(),
+			FlapCount: flapCount,
+		}
+		if !lastFlap.IsZero() {
+			peer.LastFlapNs = lastFlap.UnixNano()
 		}
 
 		resp.Peers = append(resp.Peers, peer)
@@ -1366,6 +1676,16 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 
 	resp := &lnrpc.PendingChannelsResponse{}
 
+	chanLabels, err := r.server.chanDB.FetchChannelLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	_, currentHeight, err := r.server.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
 	// First, we'll populate the response with all the channels that are
 	// soon to be opened. We can easily fetch this data from the database
 	// and map the db struct to the proto response.
@@ -1398,17 +1718,23 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 				Capacity:      int64(pendingChan.Capacity),
 				LocalBalance:  int64(localCommitment.LocalBalance.ToSatoshis()),
 				RemoteBalance: int64(localCommitment.RemoteBalance.ToSatoshis()),
+				Label:         chanLabels[pendingChan.FundingOutpoint],
 			},
 			CommitWeight: commitWeight,
 			CommitFee:    int64(localCommitment.CommitFee),
 			FeePerKw:     int64(localCommitment.FeePerKw),
 			// TODO(roasbeef): need to track confirmation height
 		}
-	}
 
-	_, currentHeight, err := r.server.cc.chainIO.GetBestBlock()
-	if err != nil {
-		return nil, err
+		// The funding manager forgets channels initiated by the
+		// remote party whose funding transaction didn't confirm in
+		// time, so we'll report how many blocks remain until then.
+		if !pendingChan.IsInitiator {
+			expiryHeight := pendingChan.FundingBroadcastHeight +
+				maxWaitNumBlocksFundingConf
+			resp.PendingOpenChannels[i].FundingExpiryBlocks =
+				int32(expiryHeight) - currentHeight
+		}
 	}
 
 	// Next, we'll examine the channels that are soon to be closed so we
@@ -1428,6 +1754,7 @@ func (r *rpcServer) PendingChannels(ctx context.Context,
 			ChannelPoint:  chanPoint.String(),
 			Capacity:      int64(pendingClose.Capacity),
 			LocalBalance:  int64(pendingClose.SettledBalance),
+			Label:         chanLabels[chanPoint],
 		}
 
 		closeTXID := pendingClose.ClosingTXID.String()
@@ -1544,6 +1871,11 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 	rpcsLog.Infof("[listchannels] fetched %v channels from DB",
 		len(dbChannels))
 
+	chanLabels, err := r.server.chanDB.FetchChannelLabels()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, dbChannel := range dbChannels {
 		if dbChannel.IsPending {
 			continue
@@ -1633,6 +1965,8 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 			NumUpdates:            localCommit.CommitHeight,
 			PendingHtlcs:          make([]*lnrpc.HTLC, len(localCommit.Htlcs)),
 			CsvDelay:              uint32(dbChannel.LocalChanCfg.CsvDelay),
+			Label:                 chanLabels[chanPoint],
+			ThawHeight:            dbChannel.ThawHeight,
 		}
 
 		for i, htlc := range localCommit.Htlcs {
@@ -1651,8 +1985,10 @@ func (r *rpcServer) ListChannels(ctx context.Context,
 }
 
 // savePayment saves a successfully completed payment to the database for
-// historical record keeping.
-func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi, preImage []byte) error {
+// historical record keeping, along with all HTLC attempts made to complete it.
+func (r *rpcServer) savePayment(route *routing.Route,
+	amount lnwire.MilliSatoshi, preImage []byte,
+	attempts []*routing.HTLCAttempt) error {
 
 	paymentPath := make([][33]byte, len(route.Hops))
 	for i, hop := range route.Hops {
@@ -1668,65 +2004,482 @@ func (r *rpcServer) savePayment(route *routing.Route, amount lnwire.MilliSatoshi
 			CreationDate: time.Now(),
 		},
 		Path:           paymentPath,
+		Route:          newPaymentRoute(route),
 		Fee:            route.TotalFees,
 		TimeLockLength: route.TotalTimeLock,
+		Attempts:       make([]channeldb.HTLCAttempt, len(attempts)),
 	}
 	copy(payment.PaymentPreimage[:], preImage)
 
+	for i, attempt := range attempts {
+		payment.Attempts[i] = newPaymentAttempt(attempt)
+	}
+
 	return r.server.chanDB.AddPayment(payment)
 }
 
-// validatePayReqExpiry checks if the passed payment request has expired. In
-// the case it has expired, an error will be returned.
-func validatePayReqExpiry(payReq *zpay32.Invoice) error {
-	expiry := payReq.Expiry()
-	validUntil := payReq.Timestamp.Add(expiry)
-	if time.Now().After(validUntil) {
-		return fmt.Errorf("invoice expired. Valid until %v", validUntil)
+// dispatchPayment sends the passed payment through the channel router,
+// recording its state within the database as it progresses. The payment is
+// marked as in flight before any HTLC is sent, which refuses the payment if
+// its payment hash is already being paid, or was paid successfully before. If
+// any routes are passed, then the payment is sent over them, in order, rather
+// than over routes found by the router. Each HTLC attempt is recorded once when
+// it's dispatched, and again once it's resolved, before the callbacks of the
+// payment are invoked with it.
+func (r *rpcServer) dispatchPayment(payment *routing.LightningPayment,
+	routes []*routing.Route) ([32]byte, *routing.Route, error) {
+
+	err := r.server.chanDB.InitPayment(payment.PaymentHash)
+	if err != nil {
+		return [32]byte{}, nil, err
 	}
 
-	return nil
+	// The router makes one attempt at a time, so the attempt being
+	// resolved is always the one dispatched last.
+	var (
+		numAttempts uint32
+		dispatched  = payment.AttemptDispatched
+		resolved    = payment.AttemptResolved
+	)
+	payment.AttemptDispatched = func(a *routing.HTLCAttempt) {
+		numAttempts++
+		r.recordHTLCAttempt(payment.PaymentHash, numAttempts-1, a)
+		if dispatched != nil {
+			dispatched(a)
+		}
+	}
+	payment.AttemptResolved = func(a *routing.HTLCAttempt) {
+		r.recordHTLCAttempt(payment.PaymentHash, numAttempts-1, a)
+		if resolved != nil {
+			resolved(a)
+		}
+	}
+
+	var (
+		preImage [32]byte
+		route    *routing.Route
+	)
+	if len(routes) != 0 {
+		preImage, route, err = r.server.chanRouter.SendToRoute(
+			routes, payment,
+		)
+	} else {
+		preImage, route, err = r.server.chanRouter.SendPayment(payment)
+	}
+	if err != nil {
+		dbErr := r.server.chanDB.FailPayment(
+			payment.PaymentHash, err.Error(),
+		)
+		if dbErr != nil {
+			rpcsLog.Errorf("Unable to mark payment %x as failed: %v",
+				payment.PaymentHash[:], dbErr)
+		} else {
+			r.server.paymentNotifier.NotifyResolved(
+				payment.PaymentHash, &channeldb.PaymentState{
+					Status:        channeldb.StatusFailed,
+					FailureReason: err.Error(),
+				},
+			)
+		}
+
+		return preImage, route, err
+	}
+
+	// With the preimage in hand, the payment can't fail anymore, so we
+	// record its success before anything else.
+	if err := r.server.chanDB.SucceedPayment(payment.PaymentHash); err != nil {
+		rpcsLog.Errorf("Unable to mark payment %x as succeeded: %v",
+			payment.PaymentHash[:], err)
+	} else {
+		r.server.paymentNotifier.NotifyResolved(
+			payment.PaymentHash, &channeldb.PaymentState{
+				Status: channeldb.StatusSucceeded,
+			},
+		)
+	}
+
+	return preImage, route, nil
 }
 
-// SendPayment dispatches a bi-directional streaming RPC for sending payments
-// through the Lightning Network. A single RPC invocation creates a persistent
-// bi-directional stream allowing clients to rapidly send payments through the
-// Lightning Network with a single persistent connection.
-func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer) error {
-	// For each payment we need to know the msat amount, the destination
-	// public key, and the payment hash.
-	type payment struct {
-		msat      lnwire.MilliSatoshi
-		dest      []byte
-		pHash     []byte
-		cltvDelta uint16
+// recordHTLCAttempt records the passed attempt of the in-flight payment to the
+// passed payment hash under the passed index, and notifies the clients
+// tracking the payment. As the record is only used to report on the progress
+// of the payment, failing to store it doesn't fail the payment.
+func (r *rpcServer) recordHTLCAttempt(paymentHash [32]byte, index uint32,
+	attempt *routing.HTLCAttempt) {
+
+	dbAttempt := newPaymentAttempt(attempt)
+	err := r.server.chanDB.RecordHTLCAttempt(paymentHash, index, &dbAttempt)
+	if err != nil {
+		rpcsLog.Errorf("Unable to record attempt %v of payment %x: %v",
+			index, paymentHash[:], err)
+		return
 	}
-	payChan := make(chan *payment)
-	errChan := make(chan error, 1)
 
-	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
-	//  * limit either a %, or absolute, or iff more than sending
+	r.server.paymentNotifier.NotifyAttempt(paymentHash, index, &dbAttempt)
+}
 
-	// We don't allow payments to be sent while the daemon itself is still
-	// syncing as we may be trying to sent a payment over a "stale"
-	// channel.
-	if !r.server.Started() {
-		return fmt.Errorf("chain backend is still syncing, server " +
-			"not active yet")
+// attemptFailureSource returns the hex-encoded public key of the node which
+// reported the failure of the passed attempt, or the empty string if it's
+// unknown.
+func attemptFailureSource(attempt *routing.HTLCAttempt) string {
+	fErr, ok := attempt.Failure.(*htlcswitch.ForwardingError)
+	if !ok || fErr.ErrorSource == nil {
+		return ""
 	}
 
-	// TODO(roasbeef): check payment filter to see if already used?
+	return hex.EncodeToString(fErr.ErrorSource.SerializeCompressed())
+}
 
-	// In order to limit the level of concurrency and prevent a client from
-	// attempting to OOM the server, we'll set up a semaphore to create an
-	// upper ceiling on the number of outstanding payments.
-	const numOutstandingPayments = 2000
-	htlcSema := make(chan struct{}, numOutstandingPayments)
-	for i := 0; i < numOutstandingPayments; i++ {
-		htlcSema <- struct{}{}
+// newPaymentRoute converts the hops of a route found by the router into their
+// database representation.
+func newPaymentRoute(route *routing.Route) []channeldb.AttemptHop {
+	hops := make([]channeldb.AttemptHop, len(route.Hops))
+	for i, hop := range route.Hops {
+		hops[i] = channeldb.AttemptHop{
+			ChannelID:        hop.Channel.ChannelID,
+			PubKey:           hop.Channel.Node.PubKeyBytes,
+			AmtToForward:     hop.AmtToForward,
+			Fee:              hop.Fee,
+			OutgoingTimeLock: hop.OutgoingTimeLock,
+		}
 	}
 
-	// Launch a new goroutine to handle reading new payment requests from
+	return hops
+}
+
+// newPaymentAttempt converts an HTLC attempt made by the router into its
+// database representation.
+func newPaymentAttempt(attempt *routing.HTLCAttempt) channeldb.HTLCAttempt {
+	route := attempt.Route
+	dbAttempt := channeldb.HTLCAttempt{
+		AttemptTime:   attempt.AttemptTime,
+		ResolveTime:   attempt.ResolveTime,
+		TotalAmount:   route.TotalAmount,
+		TotalFees:     route.TotalFees,
+		TotalTimeLock: route.TotalTimeLock,
+		Hops:          newPaymentRoute(route),
+	}
+	if attempt.Failure != nil {
+		dbAttempt.Failure = attempt.Failure.Error()
+	}
+	fErr, ok := attempt.Failure.(*htlcswitch.ForwardingError)
+	if ok && fErr.ErrorSource != nil {
+		copy(
+			dbAttempt.FailureSource[:],
+			fErr.ErrorSource.SerializeCompressed(),
+		)
+	}
+
+	return dbAttempt
+}
+
+// dbAttemptFailureSource returns the hex-encoded public key of the node which
+// reported the failure of the passed stored attempt, or the empty string if
+// it's unknown.
+func dbAttemptFailureSource(attempt *channeldb.HTLCAttempt) string {
+	if attempt.FailureSource == ([33]byte{}) {
+		return ""
+	}
+
+	return hex.EncodeToString(attempt.FailureSource[:])
+}
+
+// validatePayReqExpiry checks if the passed payment request has expired. In
+// the case it has expired, an error will be returned.
+func validatePayReqExpiry(payReq *zpay32.Invoice) error {
+	expiry := payReq.Expiry()
+	validUntil := payReq.Timestamp.Add(expiry)
+	if time.Now().After(validUntil) {
+		return fmt.Errorf("invoice expired. Valid until %v", validUntil)
+	}
+
+	return nil
+}
+
+// paymentLimits describes the restrictions imposed on a payment by the fee
+// policy which governs it.
+type paymentLimits struct {
+	// feeLimit is the maximum total fee in milli-satoshis that may be paid
+	// to route the payment. If nil, the fee is unbounded.
+	feeLimit *lnwire.MilliSatoshi
+
+	// cltvLimit is the maximum total time lock delta that a route for the
+	// payment may impose. If nil, the time lock is unbounded.
+	cltvLimit *uint32
+
+	// outgoingChanID is the ID of the channel the payment must leave
+	// through. If nil, any channel may be used.
+	outgoingChanID *uint64
+}
+
+// newPaymentLimits returns the limits imposed by the passed policy on a
+// payment of the passed amount. Policies which don't limit the fee leave it
+// unbounded, unless they have a budget, in which case the fee is bounded by
+// what remains of the budget after the amount of the payment, so that the
+// payment can't exceed it.
+func newPaymentLimits(policy *channeldb.Policy,
+	amt lnwire.MilliSatoshi) *paymentLimits {
+
+	limits := &paymentLimits{}
+	if policy.HasFeeLimit() {
+		feeLimit := policy.MaxFee(amt)
+		limits.feeLimit = &feeLimit
+	} else if remaining, ok := policy.RemainingBudget(); ok {
+		var feeLimit lnwire.MilliSatoshi
+		if remaining > amt {
+			feeLimit = remaining - amt
+		}
+		limits.feeLimit = &feeLimit
+	}
+	if policy.MaxCLTVDelta != 0 {
+		maxCLTVDelta := policy.MaxCLTVDelta
+		limits.cltvLimit = &maxCLTVDelta
+	}
+	if policy.OutgoingChanID != 0 {
+		outgoingChanID := policy.OutgoingChanID
+		limits.outgoingChanID = &outgoingChanID
+	}
+
+	return limits
+}
+
+// maxFee returns the fee limit of the payment, or zero if its fee is
+// unbounded.
+func (l *paymentLimits) maxFee() lnwire.MilliSatoshi {
+	if l.feeLimit == nil {
+		return 0
+	}
+
+	return *l.feeLimit
+}
+
+// apply imposes the limits on the passed payment.
+func (l *paymentLimits) apply(payment *routing.LightningPayment) {
+	payment.FeeLimit = l.feeLimit
+	payment.CltvLimit = l.cltvLimit
+	payment.OutgoingChannelID = l.outgoingChanID
+}
+
+// fetchPaymentLimits returns the limits imposed on a payment by the fee policy
+// which governs it. If no such policy exists, or the policy has already
+// expired, then the payment is unbounded and nil is returned. If the policy
+// denies the payment outright, then an error with the PermissionDenied code is
+// returned.
+func (r *rpcServer) fetchPaymentLimits(rHash [32]byte, dest *btcec.PublicKey,
+	amt lnwire.MilliSatoshi) (*paymentLimits, error) {
+
+	var destPub [33]byte
+	copy(destPub[:], dest.SerializeCompressed())
+
+	policy, err := r.server.chanDB.FetchPaymentPolicy(rHash, destPub, amt)
+	switch {
+	case err == channeldb.ErrPolicyNotFound:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	// The policy may have expired since the garbage collector last swept
+	// the database, in which case it no longer applies.
+	_, bestHeight, err := r.server.cc.chainIO.GetBestBlock()
+	if err != nil {
+		return nil, err
+	}
+	if policy.IsExpired(uint32(bestHeight), time.Now()) {
+		return nil, nil
+	}
+
+	// Payments governed by a deny policy fail before a route is even
+	// requested for them.
+	if policy.Deny {
+		r.recordPolicyDecision(
+			rHash, channeldb.PolicyRejectedDeny, 0, 0,
+		)
+		return nil, grpc.Errorf(
+			codes.PermissionDenied, "payment %x: %v", rHash[:],
+			channeldb.ErrPolicyDenied,
+		)
+	}
+
+	limits := newPaymentLimits(policy, amt)
+
+	feeLimit := "none"
+	if limits.feeLimit != nil {
+		feeLimit = limits.feeLimit.String()
+	}
+	rpcsLog.Debugf("Enforcing fee limit of %v, CLTV limit of %v and "+
+		"outgoing channel %v for payment %x", feeLimit,
+		policy.MaxCLTVDelta, policy.OutgoingChanID, rHash[:])
+
+	return limits, nil
+}
+
+// reservePaymentBudget reserves the maximum amount that a payment subject to
+// the passed fee limit may spend, from the budget of the policy which governs
+// it. The reserved amount is returned, which must later be settled using
+// settlePaymentBudget. If the payment would exceed the budget of the policy,
+// then channeldb.ErrPolicyBudgetExceeded is returned.
+func (r *rpcServer) reservePaymentBudget(rHash [32]byte, dest *btcec.PublicKey,
+	amt, feeLimit lnwire.MilliSatoshi) (lnwire.MilliSatoshi, error) {
+
+	var destPub [33]byte
+	copy(destPub[:], dest.SerializeCompressed())
+
+	reserved := amt + feeLimit
+	err := r.server.chanDB.ReservePolicyBudget(
+		rHash, destPub, amt, reserved,
+	)
+	switch {
+	// The policy may have been removed since we fetched its fee limit, in
+	// which case there's no budget to reserve from.
+	case err == channeldb.ErrPolicyNotFound:
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+
+	return reserved, nil
+}
+
+// settlePaymentBudget returns the part of a budget reservation made by
+// reservePaymentBudget which wasn't spent by the payment. If the payment
+// failed, as indicated by a non-nil sendErr, then the reservation is released
+// in full.
+func (r *rpcServer) settlePaymentBudget(rHash [32]byte, dest *btcec.PublicKey,
+	amt, reserved lnwire.MilliSatoshi, route *routing.Route,
+	sendErr error) {
+
+	unspent := reserved
+	if sendErr == nil {
+		spent := amt + route.TotalFees
+		if spent >= reserved {
+			return
+		}
+		unspent = reserved - spent
+	}
+
+	if unspent == 0 {
+		return
+	}
+
+	var destPub [33]byte
+	copy(destPub[:], dest.SerializeCompressed())
+
+	err := r.server.chanDB.ReleasePolicyBudget(
+		rHash, destPub, amt, unspent,
+	)
+	if err != nil && err != channeldb.ErrPolicyNotFound {
+		rpcsLog.Errorf("Unable to release %v of policy budget for "+
+			"payment %x: %v", unspent, rHash[:], err)
+	}
+}
+
+// auditPaymentPolicy records the decision made by the fee policy governing a
+// payment within the policy audit log, based on the outcome of sending the
+// payment. Payments which failed for reasons unrelated to the limits of the
+// policy aren't recorded. Payments rejected for exceeding the fee limit are
+// additionally recorded on the policy itself, so its fee limit can be tuned
+// against the fees demanded by the network.
+func (r *rpcServer) auditPaymentPolicy(rHash [32]byte, dest *btcec.PublicKey,
+	amt, feeLimit lnwire.MilliSatoshi, route *routing.Route,
+	sendErr error) {
+
+	var decision channeldb.PolicyDecision
+	switch {
+	case sendErr == nil:
+		decision = channeldb.PolicyPermitted
+	case routing.IsError(sendErr, routing.ErrFeeLimitExceeded):
+		decision = channeldb.PolicyRejectedFee
+		r.recordFeeRejection(rHash, dest, amt, route.TotalFees)
+	case routing.IsError(sendErr, routing.ErrCltvLimitExceeded):
+		decision = channeldb.PolicyRejectedCLTV
+	default:
+		return
+	}
+
+	r.recordPolicyDecision(rHash, decision, route.TotalFees, feeLimit)
+}
+
+// recordFeeRejection records on the policy governing a payment that the
+// payment was rejected, as the fee of the route found for it exceeded the fee
+// limit of the policy. As with recordPolicyDecision, failures are logged
+// rather than failing the payment.
+func (r *rpcServer) recordFeeRejection(rHash [32]byte, dest *btcec.PublicKey,
+	amt, routeFee lnwire.MilliSatoshi) {
+
+	var destPub [33]byte
+	copy(destPub[:], dest.SerializeCompressed())
+
+	err := r.server.chanDB.RecordPolicyFeeRejection(
+		rHash, destPub, amt, routeFee,
+	)
+	if err != nil && err != channeldb.ErrPolicyNotFound {
+		rpcsLog.Errorf("Unable to record fee rejection for payment "+
+			"%x: %v", rHash[:], err)
+	}
+}
+
+// recordPolicyDecision appends a record of the decision made by the fee policy
+// governing a payment to the policy audit log. As the decision has already
+// been acted upon, failing to record it is logged rather than failing the
+// payment.
+func (r *rpcServer) recordPolicyDecision(rHash [32]byte,
+	decision channeldb.PolicyDecision,
+	feeRequested, feeAllowed lnwire.MilliSatoshi) {
+
+	record := &channeldb.PolicyAuditRecord{
+		Timestamp:    time.Now(),
+		PaymentHash:  rHash,
+		FeeRequested: feeRequested,
+		FeeAllowed:   feeAllowed,
+		Decision:     decision,
+	}
+	if err := r.server.chanDB.AddPolicyAuditRecord(record); err != nil {
+		rpcsLog.Errorf("Unable to record policy decision %v for "+
+			"payment %x: %v", decision, rHash[:], err)
+	}
+}
+
+// SendPayment dispatches a bi-directional streaming RPC for sending payments
+// through the Lightning Network. A single RPC invocation creates a persistent
+// bi-directional stream allowing clients to rapidly send payments through the
+// Lightning Network with a single persistent connection.
+func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer) error {
+	// For each payment we need to know the msat amount, the destination
+	// public key, and the payment hash.
+	type payment struct {
+		msat      lnwire.MilliSatoshi
+		dest      []byte
+		pHash     []byte
+		cltvDelta uint16
+	}
+	payChan := make(chan *payment)
+	errChan := make(chan error, 1)
+
+	// TODO(roasbeef): enforce fee limits, pass into router, ditch if exceed limit
+	//  * limit either a %, or absolute, or iff more than sending
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	// TODO(roasbeef): check payment filter to see if already used?
+
+	// In order to limit the level of concurrency and prevent a client from
+	// attempting to OOM the server, we'll set up a semaphore to create an
+	// upper ceiling on the number of outstanding payments.
+	const numOutstandingPayments = 2000
+	htlcSema := make(chan struct{}, numOutstandingPayments)
+	for i := 0; i < numOutstandingPayments; i++ {
+		htlcSema <- struct{}{}
+	}
+
+	// Launch a new goroutine to handle reading new payment requests from
 	// the client. This way we can handle errors independently of blocking
 	// and waiting for the next payment request to come through.
 	reqQuit := make(chan struct{})
@@ -1873,6 +2626,58 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				copy(rHash[:], p.pHash)
 			}
 
+			// If a fee policy governs this payment, then we'll
+			// reject any routes which don't satisfy its limits.
+			limits, err := r.fetchPaymentLimits(
+				rHash, destNode, p.msat,
+			)
+			switch {
+			// If the policy denies the payment, then we'll send an
+			// error to the caller, but continue our loop for the
+			// next payment.
+			case grpc.Code(err) == codes.PermissionDenied:
+				if err := paymentStream.Send(&lnrpc.SendResponse{
+					PaymentError: grpc.ErrorDesc(err),
+				}); err != nil {
+					return err
+				}
+				continue
+
+			case err != nil:
+				return err
+			}
+
+			// The policy may also cap the cumulative amount spent,
+			// so we'll reserve the most this payment may spend
+			// from its budget before dispatching it.
+			var reserved lnwire.MilliSatoshi
+			if limits != nil {
+				reserved, err = r.reservePaymentBudget(
+					rHash, destNode, p.msat,
+					limits.maxFee(),
+				)
+				if err == channeldb.ErrPolicyBudgetExceeded {
+					r.recordPolicyDecision(
+						rHash,
+						channeldb.PolicyRejectedBudget,
+						0, limits.maxFee(),
+					)
+
+					// In this case, we'll send an error to
+					// the caller, but continue our loop for
+					// the next payment.
+					pErr := err
+					if err := paymentStream.Send(&lnrpc.SendResponse{
+						PaymentError: pErr.Error(),
+					}); err != nil {
+						return err
+					}
+					continue
+				} else if err != nil {
+					return err
+				}
+			}
+
 			// We launch a new goroutine to execute the current
 			// payment so we can continue to serve requests while
 			// this payment is being dispatched.
@@ -1890,15 +2695,34 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 				// successful, the route chosen will be
 				// returned. Otherwise, we'll get a non-nil
 				// error.
+				var attempts []*routing.HTLCAttempt
 				payment := &routing.LightningPayment{
 					Target:      destNode,
 					Amount:      p.msat,
 					PaymentHash: rHash,
+					AttemptResolved: func(
+						a *routing.HTLCAttempt) {
+
+						attempts = append(attempts, a)
+					},
 				}
 				if p.cltvDelta != 0 {
 					payment.FinalCLTVDelta = &p.cltvDelta
 				}
-				preImage, route, err := r.server.chanRouter.SendPayment(payment)
+				if limits != nil {
+					limits.apply(payment)
+				}
+				preImage, route, err := r.dispatchPayment(payment, nil)
+				if limits != nil {
+					r.auditPaymentPolicy(
+						rHash, destNode, p.msat,
+						limits.maxFee(), route, err,
+					)
+				}
+				r.settlePaymentBudget(
+					rHash, destNode, p.msat, reserved,
+					route, err,
+				)
 				if err != nil {
 					// If we receive payment error than,
 					// instead of terminating the stream,
@@ -1914,7 +2738,10 @@ func (r *rpcServer) SendPayment(paymentStream lnrpc.Lightning_SendPaymentServer)
 
 				// Save the completed payment to the database
 				// for record keeping purposes.
-				if err := r.savePayment(route, p.msat, preImage[:]); err != nil {
+				err = r.savePayment(
+					route, p.msat, preImage[:], attempts,
+				)
+				if err != nil {
 					errChan <- err
 					return
 				}
@@ -1950,1508 +2777,3821 @@ func (r *rpcServer) SendPaymentSync(ctx context.Context,
 			"not active yet")
 	}
 
+	intent, err := extractPaymentIntent(nextPayment)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.sendPaymentIntent(intent, &routing.LightningPayment{})
+	if err != nil {
+		return nil, err
+	}
+	if result.err != nil {
+		return &lnrpc.SendResponse{
+			PaymentError: result.err.Error(),
+		}, nil
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: result.preImage[:],
+		PaymentRoute:    marshallRoute(result.route),
+	}, nil
+}
+
+// SendPaymentStream sends a single payment through the Lightning Network,
+// streaming an update each time an HTLC attempt is dispatched or fails while
+// the payment is in flight. The stream ends with a final update once the
+// payment either succeeded or failed.
+func (r *rpcServer) SendPaymentStream(req *lnrpc.SendRequest,
+	updateStream lnrpc.Lightning_SendPaymentStreamServer) error {
+
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
+
+	intent, err := extractPaymentIntent(req)
+	if err != nil {
+		return err
+	}
+
+	// The router invokes the callbacks of the payment one after another
+	// from within the call dispatching it, so the updates can be sent
+	// directly from them. Once an update couldn't be sent, the client is
+	// gone, and no further updates are sent.
 	var (
-		destPub   *btcec.PublicKey
-		amtMSat   lnwire.MilliSatoshi
-		rHash     [32]byte
-		cltvDelta uint16
+		numAttempts uint32
+		lastAttempt *routing.HTLCAttempt
+		sendErr     error
 	)
-
-	// If the proto request has an encoded payment request, then we we'll
-	// use that solely to dispatch the payment.
-	if nextPayment.PaymentRequest != "" {
-		payReq, err := zpay32.Decode(nextPayment.PaymentRequest,
-			activeNetParams.Params)
-		if err != nil {
-			return nil, err
+	sendUpdate := func(update *lnrpc.PaymentUpdate) {
+		if sendErr != nil {
+			return
 		}
 
-		// We first check that this payment request has not expired.
-		if err := validatePayReqExpiry(payReq); err != nil {
-			return nil, err
+		update.PaymentHash = intent.rHash[:]
+		update.NumAttempts = numAttempts
+		if lastAttempt != nil {
+			dbAttempt := newPaymentAttempt(lastAttempt)
+			update.Attempt = marshallPaymentAttempt(&dbAttempt)
 		}
+		sendErr = updateStream.Send(update)
+	}
 
-		destPub = payReq.Destination
+	payment := &routing.LightningPayment{
+		AttemptDispatched: func(a *routing.HTLCAttempt) {
+			numAttempts++
+			lastAttempt = a
+			sendUpdate(&lnrpc.PaymentUpdate{
+				Type: lnrpc.PaymentUpdate_ATTEMPT_DISPATCHED,
+			})
+		},
+		AttemptResolved: func(a *routing.HTLCAttempt) {
+			// A settled attempt is reported by the final update
+			// of the payment.
+			if a.Failure == nil {
+				return
+			}
 
-		// If the amount was not included in the invoice, then we let
-		// the payee specify the amount of satoshis they wish to send.
-		// We override the amount to pay with the amount provided from
-		// the payment request.
-		if payReq.MilliSat == nil {
-			amtMSat = lnwire.NewMSatFromSatoshis(
-				btcutil.Amount(nextPayment.Amt),
-			)
-		} else {
-			amtMSat = *payReq.MilliSat
-		}
+			sendUpdate(&lnrpc.PaymentUpdate{
+				Type:                lnrpc.PaymentUpdate_ATTEMPT_FAILED,
+				FailureSourcePubKey: attemptFailureSource(a),
+			})
+		},
+	}
 
-		rHash = *payReq.PaymentHash
-		cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+	result, err := r.sendPaymentIntent(intent, payment)
+	if err != nil {
+		return err
+	}
 
-		// Otherwise, the payment conditions have been manually
-		// specified in the proto.
+	update := &lnrpc.PaymentUpdate{}
+	if result.err != nil {
+		update.Type = lnrpc.PaymentUpdate_FAILED
+		update.PaymentError = result.err.Error()
 	} else {
-		// If we're in debug HTLC mode, then all outgoing HTLCs will
-		// pay to the same debug rHash. Otherwise, we pay to the rHash
-		// specified within the RPC request.
-		if cfg.DebugHTLC && nextPayment.PaymentHashString == "" {
-			rHash = debugHash
-		} else {
-			paymentHash, err := hex.DecodeString(nextPayment.PaymentHashString)
-			if err != nil {
-				return nil, err
-			}
+		update.Type = lnrpc.PaymentUpdate_SUCCEEDED
+		update.PaymentPreimage = result.preImage[:]
+		update.FeePaidMsat = int64(result.route.TotalFees)
+	}
+	sendUpdate(update)
 
-			copy(rHash[:], paymentHash)
-		}
+	return sendErr
+}
 
-		pubBytes, err := hex.DecodeString(nextPayment.DestString)
-		if err != nil {
-			return nil, err
-		}
-		destPub, err = btcec.ParsePubKey(pubBytes, btcec.S256())
-		if err != nil {
-			return nil, err
-		}
+// TrackPayment streams the progress of the payment to the passed payment
+// hash, as recorded within the database. The persisted state of the payment
+// is replayed first, after which an update is streamed each time an HTLC
+// attempt is dispatched or fails. The stream ends with a final update once the
+// payment either succeeded or failed.
+func (r *rpcServer) TrackPayment(req *lnrpc.TrackPaymentRequest,
+	updateStream lnrpc.Lightning_TrackPaymentServer) error {
 
-		amtMSat = lnwire.NewMSatFromSatoshis(
-			btcutil.Amount(nextPayment.Amt),
-		)
+	paymentHash, err := parsePaymentHash(
+		req.PaymentHashStr, req.PaymentHash,
+	)
+	if err != nil {
+		return err
 	}
 
-	// Currently, within the bootstrap phase of the network, we limit the
-	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
-	// satoshis.
-	if amtMSat > maxPaymentMSat {
-		err := fmt.Errorf("payment of %v is too large, max payment "+
-			"allowed is %v", nextPayment.Amt, maxPaymentMSat.ToSatoshis())
-		return &lnrpc.SendResponse{
-			PaymentError: err.Error(),
-		}, nil
-	}
+	// We subscribe to the changes of the payment before fetching its
+	// persisted state, to ensure we don't miss any change in between.
+	// Changes already reflected by the persisted state are skipped once
+	// they're received.
+	client := r.server.paymentNotifier.SubscribePayment(paymentHash)
+	defer client.Cancel()
 
-	// Finally, send a payment request to the channel router. If the
-	// payment succeeds, then the returned route will be that was used
-	// successfully within the payment.
-	payment := &routing.LightningPayment{
-		Target:      destPub,
-		Amount:      amtMSat,
-		PaymentHash: rHash,
+	state, err := r.server.chanDB.FetchPaymentState(paymentHash)
+	if err != nil {
+		return err
 	}
-	if cltvDelta != 0 {
-		payment.FinalCLTVDelta = &cltvDelta
+	if state.Status == channeldb.StatusUnknown {
+		return channeldb.ErrPaymentNotInitiated
 	}
-	preImage, route, err := r.server.chanRouter.SendPayment(payment)
+	attempts, err := r.server.chanDB.FetchHTLCAttempts(paymentHash)
 	if err != nil {
-		return &lnrpc.SendResponse{
-			PaymentError: err.Error(),
-		}, nil
+		return err
 	}
 
-	// With the payment completed successfully, we now ave the details of
-	// the completed payment to the database for historical record keeping.
-	if err := r.savePayment(route, amtMSat, preImage[:]); err != nil {
-		return nil, err
-	}
+	// Each attempt is recorded once when it's dispatched, and again once
+	// it's resolved, so we track which of them were already reported.
+	var (
+		numAttempts uint32
+		lastAttempt *channeldb.HTLCAttempt
+		dispatched  = make(map[uint32]struct{})
+		failed      = make(map[uint32]struct{})
+	)
+	sendAttempt := func(index uint32, attempt *channeldb.HTLCAttempt) error {
+		if index >= numAttempts {
+			numAttempts = index + 1
+		}
+		lastAttempt = attempt
 
-	return &lnrpc.SendResponse{
-		PaymentPreimage: preImage[:],
-		PaymentRoute:    marshallRoute(route),
-	}, nil
-}
+		update := &lnrpc.PaymentUpdate{
+			PaymentHash: paymentHash[:],
+			Attempt:     marshallPaymentAttempt(attempt),
+		}
+		switch {
+		case attempt.ResolveTime.IsZero():
+			if _, ok := dispatched[index]; ok {
+				return nil
+			}
+			update.Type = lnrpc.PaymentUpdate_ATTEMPT_DISPATCHED
 
-// AddInvoice attempts to add a new invoice to the invoice database. Any
-// duplicated invoices are rejected, therefore all invoices *must* have a
-// unique payment preimage.
-func (r *rpcServer) AddInvoice(ctx context.Context,
-	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
+		case attempt.Failure != "":
+			if _, ok := failed[index]; ok {
+				return nil
+			}
+			failed[index] = struct{}{}
+			update.Type = lnrpc.PaymentUpdate_ATTEMPT_FAILED
+			update.FailureSourcePubKey = dbAttemptFailureSource(attempt)
 
-	var paymentPreimage [32]byte
+		// A settled attempt is reported by the final update of the
+		// payment.
+		default:
+			return nil
+		}
+		dispatched[index] = struct{}{}
+		update.NumAttempts = numAttempts
 
-	switch {
-	// If a preimage wasn't specified, then we'll generate a new preimage
-	// from fresh cryptographic randomness.
-	case len(invoice.RPreimage) == 0:
-		if _, err := rand.Read(paymentPreimage[:]); err != nil {
-			return nil, err
+		return updateStream.Send(update)
+	}
+	sendFinal := func(state *channeldb.PaymentState) error {
+		update := &lnrpc.PaymentUpdate{
+			PaymentHash: paymentHash[:],
+			NumAttempts: numAttempts,
+		}
+		if lastAttempt != nil {
+			update.Attempt = marshallPaymentAttempt(lastAttempt)
 		}
 
-	// Otherwise, if a preimage was specified, then it MUST be exactly
-	// 32-bytes.
-	case len(invoice.RPreimage) > 0 && len(invoice.RPreimage) != 32:
-		return nil, fmt.Errorf("payment preimage must be exactly "+
-			"32 bytes, is instead %v", len(invoice.RPreimage))
+		if state.Status == channeldb.StatusFailed {
+			update.Type = lnrpc.PaymentUpdate_FAILED
+			update.PaymentError = state.FailureReason
+			return updateStream.Send(update)
+		}
 
-	// If the preimage meets the size specifications, then it can be used
-	// as is.
-	default:
-		copy(paymentPreimage[:], invoice.RPreimage[:])
-	}
+		// The preimage of a successful payment is added to the
+		// preimage cache before the payment is marked as succeeded.
+		update.Type = lnrpc.PaymentUpdate_SUCCEEDED
+		preimage, ok := r.server.witnessBeacon.LookupPreimage(
+			paymentHash[:],
+		)
+		if ok {
+			update.PaymentPreimage = preimage
+		}
+		if lastAttempt != nil && lastAttempt.Failure == "" {
+			update.FeePaidMsat = int64(lastAttempt.TotalFees)
+		}
 
-	// The size of the memo, receipt and description hash attached must not
-	// exceed the maximum values for either of the fields.
-	if len(invoice.Memo) > channeldb.MaxMemoSize {
-		return nil, fmt.Errorf("memo too large: %v bytes "+
-			"(maxsize=%v)", len(invoice.Memo), channeldb.MaxMemoSize)
+		return updateStream.Send(update)
 	}
-	if len(invoice.Receipt) > channeldb.MaxReceiptSize {
-		return nil, fmt.Errorf("receipt too large: %v bytes "+
-			"(maxsize=%v)", len(invoice.Receipt), channeldb.MaxReceiptSize)
+
+	for i := range attempts {
+		if err := sendAttempt(uint32(i), &attempts[i]); err != nil {
+			return err
+		}
 	}
-	if len(invoice.DescriptionHash) > 0 && len(invoice.DescriptionHash) != 32 {
-		return nil, fmt.Errorf("description hash is %v bytes, must be %v",
-			len(invoice.DescriptionHash), channeldb.MaxPaymentRequestSize)
+	if state.Status != channeldb.StatusInFlight {
+		return sendFinal(state)
 	}
 
-	amt := btcutil.Amount(invoice.Value)
-	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	for {
+		select {
+		case event := <-client.Updates:
+			if event.state != nil {
+				return sendFinal(event.state)
+			}
 
-	// The value of the invoice must also not exceed the current soft-limit
-	// on the largest payment within the network.
-	if amtMSat > maxPaymentMSat {
-		return nil, fmt.Errorf("payment of %v is too large, max "+
-			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
-	}
+			err := sendAttempt(event.attemptIndex, event.attempt)
+			if err != nil {
+				return err
+			}
 
-	// Next, generate the payment hash itself from the preimage. This will
-	// be used by clients to query for the state of a particular invoice.
-	rHash := sha256.Sum256(paymentPreimage[:])
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
+	}
+}
 
-	// We also create an encoded payment request which allows the
-	// caller to compactly send the invoice to the payer. We'll create a
-	// list of options to be added to the encoded payment request. For now
-	// we only support the required fields description/description_hash,
-	// expiry, fallback address, and the amount field.
-	var options []func(*zpay32.Invoice)
+// paymentIntent describes a single payment requested by an RPC client.
+type paymentIntent struct {
+	dest      *btcec.PublicKey
+	msat      lnwire.MilliSatoshi
+	rHash     [32]byte
+	cltvDelta uint16
+}
 
-	// We only include the amount in the invoice if it is greater than 0.
-	// By not including the amount, we enable the creation of invoices that
-	// allow the payee to specify the amount of satoshis they wish to send.
-	if amtMSat > 0 {
-		options = append(options, zpay32.Amount(amtMSat))
-	}
+// extractPaymentIntent parses the payment described by the passed request,
+// either from its encoded payment request, or from its destination and payment
+// hash.
+func extractPaymentIntent(req *lnrpc.SendRequest) (*paymentIntent, error) {
+	intent := &paymentIntent{}
 
-	// If specified, add a fallback address to the payment request.
-	if len(invoice.FallbackAddr) > 0 {
-		addr, err := btcutil.DecodeAddress(invoice.FallbackAddr,
+	// If the proto request has an encoded payment request, then we we'll
+	// use that solely to dispatch the payment.
+	if req.PaymentRequest != "" {
+		payReq, err := zpay32.Decode(req.PaymentRequest,
 			activeNetParams.Params)
 		if err != nil {
-			return nil, fmt.Errorf("invalid fallback address: %v",
-				err)
+			return nil, err
 		}
-		options = append(options, zpay32.FallbackAddr(addr))
-	}
 
-	// If expiry is set, specify it. If it is not provided, no expiry time
-	// will be explicitly added to this payment request, which will imply
-	// the default 3600 seconds.
-	if invoice.Expiry > 0 {
+		// We first check that this payment request has not expired.
+		if err := validatePayReqExpiry(payReq); err != nil {
+			return nil, err
+		}
 
-		// We'll ensure that the specified expiry is restricted to sane
-		// number of seconds. As a result, we'll reject an invoice with
-		// an expiry greater than 1 year.
-		maxExpiry := time.Hour * 24 * 365
-		expSeconds := invoice.Expiry
+		intent.dest = payReq.Destination
 
-		if float64(expSeconds) > maxExpiry.Seconds() {
-			return nil, fmt.Errorf("expiry of %v seconds "+
-				"greater than max expiry of %v seconds",
-				float64(expSeconds), maxExpiry.Seconds())
+		// If the amount was not included in the invoice, then we let
+		// the payee specify the amount of satoshis they wish to send.
+		// We override the amount to pay with the amount provided from
+		// the payment request.
+		if payReq.MilliSat == nil {
+			intent.msat = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(req.Amt),
+			)
+		} else {
+			intent.msat = *payReq.MilliSat
 		}
 
-		expiry := time.Duration(invoice.Expiry) * time.Second
-		options = append(options, zpay32.Expiry(expiry))
+		intent.rHash = *payReq.PaymentHash
+		intent.cltvDelta = uint16(payReq.MinFinalCLTVExpiry())
+
+		return intent, nil
 	}
 
-	// If the description hash is set, then we add it do the list of options.
-	// If not, use the memo field as the payment request description.
-	if len(invoice.DescriptionHash) > 0 {
-		var descHash [32]byte
-		copy(descHash[:], invoice.DescriptionHash[:])
-		options = append(options, zpay32.DescriptionHash(descHash))
+	// Otherwise, the payment conditions have been manually specified in
+	// the proto, either as raw bytes, or hex-encoded for clients of the
+	// REST proxy. If we're in debug HTLC mode, then all outgoing HTLCs
+	// will pay to the same debug rHash. Otherwise, we pay to the rHash
+	// specified within the RPC request.
+	paymentHash := req.PaymentHash
+	if req.PaymentHashString != "" {
+		var err error
+		paymentHash, err = hex.DecodeString(req.PaymentHashString)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.DebugHTLC && len(paymentHash) == 0 {
+		intent.rHash = debugHash
 	} else {
-		// Use the memo field as the description. If this is not set
-		// this will just be an empty string.
-		options = append(options, zpay32.Description(invoice.Memo))
+		copy(intent.rHash[:], paymentHash)
 	}
 
-	// We'll use our current default CLTV value unless one was specified as
-	// an option on the command line when creating an invoice.
-	switch {
-	case invoice.CltvExpiry > math.MaxUint16:
-		return nil, fmt.Errorf("CLTV delta of %v is too large, max "+
-			"accepted is: %v", invoice.CltvExpiry, math.MaxUint16)
-	case invoice.CltvExpiry != 0:
-		options = append(options,
-			zpay32.CLTVExpiry(invoice.CltvExpiry))
-	default:
-		// TODO(roasbeef): assumes set delta between versions
-		defaultDelta := cfg.Bitcoin.TimeLockDelta
-		if registeredChains.PrimaryChain() == litecoinChain {
-			defaultDelta = cfg.Litecoin.TimeLockDelta
+	pubBytes := req.Dest
+	if req.DestString != "" {
+		var err error
+		pubBytes, err = hex.DecodeString(req.DestString)
+		if err != nil {
+			return nil, err
 		}
-		options = append(options, zpay32.CLTVExpiry(uint64(defaultDelta)))
 	}
-
-	// Create and encode the payment request as a bech32 (zpay32) string.
-	creationDate := time.Now()
-	payReq, err := zpay32.NewInvoice(
-		activeNetParams.Params,
-		rHash,
-		creationDate,
-		options...,
-	)
+	dest, err := btcec.ParsePubKey(pubBytes, btcec.S256())
 	if err != nil {
 		return nil, err
 	}
+	intent.dest = dest
 
-	payReqString, err := payReq.Encode(
-		zpay32.MessageSigner{
-			SignCompact: r.server.nodeSigner.SignDigestCompact,
-		},
-	)
+	intent.msat = lnwire.NewMSatFromSatoshis(btcutil.Amount(req.Amt))
+	intent.cltvDelta = uint16(req.FinalCltvDelta)
+
+	return intent, nil
+}
+
+// paymentResult is the outcome of a payment sent by sendPaymentIntent.
+type paymentResult struct {
+	// preImage is the preimage of the payment hash, if the payment
+	// succeeded.
+	preImage [32]byte
+
+	// route is the route the payment succeeded over, if it did.
+	route *routing.Route
+
+	// err is the reason the payment either failed, or was refused before
+	// being dispatched.
+	err error
+}
+
+// sendPaymentIntent sends the payment described by the passed intent through
+// the channel router, enforcing the fee policy which governs it, and saves it
+// to the database for historical record keeping once it succeeded. The passed
+// payment is populated from the intent before it's dispatched, which allows
+// callers to observe its HTLC attempts through its callbacks. A non-nil error
+// is only returned if the payment couldn't be processed at all, whereas a
+// payment which failed is reported by the returned result.
+func (r *rpcServer) sendPaymentIntent(intent *paymentIntent,
+	payment *routing.LightningPayment) (*paymentResult, error) {
+
+	destPub, amtMSat, rHash := intent.dest, intent.msat, intent.rHash
+
+	// Currently, within the bootstrap phase of the network, we limit the
+	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
+	// satoshis.
+	if amtMSat > maxPaymentMSat {
+		err := fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amtMSat.ToSatoshis(),
+			maxPaymentMSat.ToSatoshis())
+		return &paymentResult{err: err}, nil
+	}
+
+	// If a fee policy governs this payment, then we'll reject any routes
+	// which don't satisfy its limits.
+	limits, err := r.fetchPaymentLimits(rHash, destPub, amtMSat)
 	if err != nil {
 		return nil, err
 	}
 
-	i := &channeldb.Invoice{
-		CreationDate:   creationDate,
-		Memo:           []byte(invoice.Memo),
-		Receipt:        invoice.Receipt,
-		PaymentRequest: []byte(payReqString),
-		Terms: channeldb.ContractTerm{
-			Value: amtMSat,
-		},
+	// The policy may also cap the cumulative amount spent, so we'll
+	// reserve the most this payment may spend from its budget before
+	// dispatching it.
+	var reserved lnwire.MilliSatoshi
+	if limits != nil {
+		reserved, err = r.reservePaymentBudget(
+			rHash, destPub, amtMSat, limits.maxFee(),
+		)
+		if err == channeldb.ErrPolicyBudgetExceeded {
+			r.recordPolicyDecision(
+				rHash, channeldb.PolicyRejectedBudget, 0,
+				limits.maxFee(),
+			)
+			return &paymentResult{err: err}, nil
+		} else if err != nil {
+			return nil, err
+		}
 	}
-	copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
 
-	rpcsLog.Tracef("[addinvoice] adding new invoice %v",
-		newLogClosure(func() string {
-			return spew.Sdump(i)
-		}),
+	// Finally, send a payment request to the channel router. If the
+	// payment succeeds, then the returned route will be that was used
+	// successfully within the payment.
+	var (
+		attempts []*routing.HTLCAttempt
+		resolved = payment.AttemptResolved
 	)
+	payment.Target = destPub
+	payment.Amount = amtMSat
+	payment.PaymentHash = rHash
+	payment.AttemptResolved = func(a *routing.HTLCAttempt) {
+		attempts = append(attempts, a)
+		if resolved != nil {
+			resolved(a)
+		}
+	}
+	if intent.cltvDelta != 0 {
+		payment.FinalCLTVDelta = &intent.cltvDelta
+	}
+	if limits != nil {
+		limits.apply(payment)
+	}
+	preImage, route, err := r.dispatchPayment(payment, nil)
+	if limits != nil {
+		r.auditPaymentPolicy(
+			rHash, destPub, amtMSat, limits.maxFee(), route, err,
+		)
+	}
+	r.settlePaymentBudget(rHash, destPub, amtMSat, reserved, route, err)
+	if err != nil {
+		return &paymentResult{err: err}, nil
+	}
 
-	// With all sanity checks passed, write the invoice to the database.
-	if err := r.server.invoices.AddInvoice(i); err != nil {
+	// With the payment completed successfully, we now ave the details of
+	// the completed payment to the database for historical record keeping.
+	err = r.savePayment(route, amtMSat, preImage[:], attempts)
+	if err != nil {
 		return nil, err
 	}
 
-	return &lnrpc.AddInvoiceResponse{
-		RHash:          rHash[:],
-		PaymentRequest: payReqString,
-	}, nil
+	return &paymentResult{preImage: preImage, route: route}, nil
 }
 
-// createRPCInvoice creates an *lnrpc.Invoice from the *channeldb.Invoice.
-func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
-	paymentRequest := string(invoice.PaymentRequest)
-	decoded, err := zpay32.Decode(paymentRequest, activeNetParams.Params)
-	if err != nil {
-		return nil, fmt.Errorf("unable to decode payment request: %v",
-			err)
+// SendToRoute dispatches a bi-directional streaming RPC for sending payments
+// through the Lightning Network over routes fully specified by the caller. No
+// path finding takes place: the routes of each request are attempted in
+// order, until one of them succeeds.
+func (r *rpcServer) SendToRoute(stream lnrpc.Lightning_SendToRouteServer) error {
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
 	}
 
-	descHash := []byte("")
-	if decoded.DescriptionHash != nil {
-		descHash = decoded.DescriptionHash[:]
+	// In order to limit the level of concurrency and prevent a client from
+	// attempting to OOM the server, we'll set up a semaphore to create an
+	// upper ceiling on the number of outstanding payments.
+	const numOutstandingPayments = 2000
+	htlcSema := make(chan struct{}, numOutstandingPayments)
+	for i := 0; i < numOutstandingPayments; i++ {
+		htlcSema <- struct{}{}
 	}
 
-	fallbackAddr := ""
-	if decoded.FallbackAddr != nil {
-		fallbackAddr = decoded.FallbackAddr.String()
-	}
+	// As payments are dispatched concurrently, we'll serialize the
+	// responses sent back over the stream, and collect any error
+	// encountered while sending them.
+	var sendMtx sync.Mutex
+	errChan := make(chan error, 1)
+	send := func(resp *lnrpc.SendResponse) {
+		sendMtx.Lock()
+		defer sendMtx.Unlock()
 
-	settleDate := int64(0)
-	if !invoice.SettleDate.IsZero() {
-		settleDate = invoice.SettleDate.Unix()
+		if err := stream.Send(resp); err != nil {
+			select {
+			case errChan <- err:
+			default:
+			}
+		}
 	}
 
-	// Expiry time will default to 3600 seconds if not specified
-	// explicitly.
-	expiry := int64(decoded.Expiry().Seconds())
+	for {
+		select {
+		case err := <-errChan:
+			return err
+		case <-r.quit:
+			return nil
+		default:
+		}
 
-	// The expiry will default to 9 blocks if not specified explicitly.
-	cltvExpiry := decoded.MinFinalCLTVExpiry()
+		// Receive the next payment within the stream sent by the
+		// client. If we read the EOF sentinel, then the client has
+		// closed the stream, and we can exit normally.
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
 
-	preimage := invoice.Terms.PaymentPreimage
-	satAmt := invoice.Terms.Value.ToSatoshis()
+		// We launch a new goroutine to execute the current payment so
+		// we can continue to serve requests while this payment is
+		// being dispatched.
+		<-htlcSema
+		go func() {
+			defer func() {
+				htlcSema <- struct{}{}
+			}()
 
-	return &lnrpc.Invoice{
-		Memo:            string(invoice.Memo[:]),
-		Receipt:         invoice.Receipt[:],
-		RHash:           decoded.PaymentHash[:],
-		RPreimage:       preimage[:],
-		Value:           int64(satAmt),
-		CreationDate:    invoice.CreationDate.Unix(),
-		SettleDate:      settleDate,
-		Settled:         invoice.Terms.Settled,
-		PaymentRequest:  paymentRequest,
-		DescriptionHash: descHash,
-		Expiry:          expiry,
-		CltvExpiry:      cltvExpiry,
-		FallbackAddr:    fallbackAddr,
-	}, nil
+			resp, err := r.sendToRoute(req)
+			switch {
+			// If the fee policy of the payment denies it, or the
+			// payment fails, then we'll send the error to the
+			// caller instead of terminating the stream.
+			case grpc.Code(err) == codes.PermissionDenied:
+				send(&lnrpc.SendResponse{
+					PaymentError: grpc.ErrorDesc(err),
+				})
+
+			case err != nil:
+				send(&lnrpc.SendResponse{
+					PaymentError: err.Error(),
+				})
+
+			default:
+				send(resp)
+			}
+		}()
+	}
 }
 
-// LookupInvoice attempts to look up an invoice according to its payment hash.
-// The passed payment hash *must* be exactly 32 bytes, if not an error is
-// returned.
-func (r *rpcServer) LookupInvoice(ctx context.Context,
-	req *lnrpc.PaymentHash) (*lnrpc.Invoice, error) {
+// SendToRouteSync is the synchronous non-streaming version of SendToRoute.
+// This RPC is intended to be consumed by clients of the REST proxy.
+func (r *rpcServer) SendToRouteSync(ctx context.Context,
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
 
-	var (
-		payHash [32]byte
-		rHash   []byte
-		err     error
-	)
+	// We don't allow payments to be sent while the daemon itself is still
+	// syncing as we may be trying to sent a payment over a "stale"
+	// channel.
+	if !r.server.Started() {
+		return nil, fmt.Errorf("chain backend is still syncing, server " +
+			"not active yet")
+	}
 
-	// If the RHash as a raw string was provided, then decode that and use
-	// that directly. Otherwise, we use the raw bytes provided.
-	if req.RHashStr != "" {
-		rHash, err = hex.DecodeString(req.RHashStr)
+	return r.sendToRoute(req)
+}
+
+// sendToRoute sends the payment described by the passed request over the
+// routes it specifies, subject to the fee policy governing the payment. If the
+// payment fails, then the failure is reported within the returned response,
+// while any other error is returned directly.
+func (r *rpcServer) sendToRoute(
+	req *lnrpc.SendToRouteRequest) (*lnrpc.SendResponse, error) {
+
+	if len(req.Routes) == 0 {
+		return nil, fmt.Errorf("unable to send, no routes provided")
+	}
+
+	// The payment hash may either be specified as raw bytes, or
+	// hex-encoded for the benefit of REST clients.
+	var rHash [32]byte
+	paymentHash := req.PaymentHash
+	if req.PaymentHashString != "" {
+		var err error
+		paymentHash, err = hex.DecodeString(req.PaymentHashString)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		rHash = req.RHash
-	}
-
-	// Ensure that the payment hash is *exactly* 32-bytes.
-	if len(rHash) != 0 && len(rHash) != 32 {
-		return nil, fmt.Errorf("payment hash must be exactly "+
-			"32 bytes, is instead %v", len(rHash))
 	}
-	copy(payHash[:], rHash)
+	switch {
+	// If we're in debug HTLC mode, then all outgoing HTLCs will pay to
+	// the same debug rHash.
+	case cfg.DebugHTLC && len(paymentHash) == 0:
+		rHash = debugHash
 
-	rpcsLog.Tracef("[lookupinvoice] searching for invoice %x", payHash[:])
+	case len(paymentHash) != 32:
+		return nil, fmt.Errorf("payment hash must be exactly 32 "+
+			"bytes, is instead %v", len(paymentHash))
 
-	invoice, err := r.server.invoices.LookupInvoice(payHash)
-	if err != nil {
-		return nil, err
+	default:
+		copy(rHash[:], paymentHash)
 	}
 
-	rpcsLog.Tracef("[lookupinvoice] located invoice %v",
-		newLogClosure(func() string {
-			return spew.Sdump(invoice)
-		}))
+	// With the payment hash known, we'll convert the routes into their
+	// routing counterparts, verifying them against our view of the
+	// channel graph along the way.
+	graph := r.server.chanDB.ChannelGraph()
+	sourceVertex := routing.NewVertex(r.server.identityPriv.PubKey())
+	routes := make([]*routing.Route, 0, len(req.Routes))
+	for _, rpcRoute := range req.Routes {
+		route, err := unmarshallRoute(rpcRoute, graph, sourceVertex)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, route)
+	}
 
-	rpcInvoice, err := createRPCInvoice(&invoice)
+	// The destination and amount of the payment are dictated by the end of
+	// the first route, the router ensures the others agree.
+	firstRoute := routes[0]
+	lastHop := firstRoute.Hops[len(firstRoute.Hops)-1]
+	destPub, err := btcec.ParsePubKey(
+		lastHop.Channel.Node.PubKeyBytes[:], btcec.S256(),
+	)
 	if err != nil {
 		return nil, err
 	}
+	amtMSat := lastHop.AmtToForward
 
-	return rpcInvoice, nil
-}
-
-// ListInvoices returns a list of all the invoices currently stored within the
-// database. Any active debug invoices are ignored.
-func (r *rpcServer) ListInvoices(ctx context.Context,
-	req *lnrpc.ListInvoiceRequest) (*lnrpc.ListInvoiceResponse, error) {
+	// Currently, within the bootstrap phase of the network, we limit the
+	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
+	// satoshis.
+	if amtMSat > maxPaymentMSat {
+		err := fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amtMSat, maxPaymentMSat)
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}, nil
+	}
 
-	dbInvoices, err := r.server.chanDB.FetchAllInvoices(req.PendingOnly)
+	// If a fee policy governs this payment, then we'll reject any routes
+	// which don't satisfy its limits.
+	limits, err := r.fetchPaymentLimits(rHash, destPub, amtMSat)
 	if err != nil {
 		return nil, err
 	}
 
-	invoices := make([]*lnrpc.Invoice, len(dbInvoices))
-	for i, dbInvoice := range dbInvoices {
+	// The router enforces the fee and CLTV limits on each route it
+	// attempts, but as it doesn't choose the routes itself, we'll drop
+	// those that don't leave through the channel the policy mandates.
+	if limits != nil && limits.outgoingChanID != nil {
+		permitted := routes[:0]
+		for _, route := range routes {
+			chanID := route.Hops[0].Channel.ChannelID
+			if chanID == *limits.outgoingChanID {
+				permitted = append(permitted, route)
+			}
+		}
+		if len(permitted) == 0 {
+			err := fmt.Errorf("no route leaves through channel "+
+				"%v as required by the payment policy",
+				*limits.outgoingChanID)
+			return &lnrpc.SendResponse{
+				PaymentError: err.Error(),
+			}, nil
+		}
+		routes = permitted
+	}
 
-		rpcInvoice, err := createRPCInvoice(dbInvoice)
-		if err != nil {
+	// The policy may also cap the cumulative amount spent, so we'll
+	// reserve the most this payment may spend from its budget before
+	// dispatching it.
+	var reserved lnwire.MilliSatoshi
+	if limits != nil {
+		reserved, err = r.reservePaymentBudget(
+			rHash, destPub, amtMSat, limits.maxFee(),
+		)
+		if err == channeldb.ErrPolicyBudgetExceeded {
+			r.recordPolicyDecision(
+				rHash, channeldb.PolicyRejectedBudget, 0,
+				limits.maxFee(),
+			)
+			return &lnrpc.SendResponse{
+				PaymentError: err.Error(),
+			}, nil
+		} else if err != nil {
 			return nil, err
 		}
+	}
 
-		invoices[i] = rpcInvoice
+	// Finally, send the payment over the routes. If the payment succeeds,
+	// then the returned route will be the one that was used successfully.
+	var attempts []*routing.HTLCAttempt
+	payment := &routing.LightningPayment{
+		Target:      destPub,
+		Amount:      amtMSat,
+		PaymentHash: rHash,
+		AttemptResolved: func(a *routing.HTLCAttempt) {
+			attempts = append(attempts, a)
+		},
+	}
+	if limits != nil {
+		limits.apply(payment)
+	}
+	preImage, route, err := r.dispatchPayment(payment, routes)
+	if limits != nil {
+		r.auditPaymentPolicy(
+			rHash, destPub, amtMSat, limits.maxFee(), route, err,
+		)
+	}
+	r.settlePaymentBudget(rHash, destPub, amtMSat, reserved, route, err)
+	if err != nil {
+		return &lnrpc.SendResponse{
+			PaymentError: err.Error(),
+		}, nil
 	}
 
-	return &lnrpc.ListInvoiceResponse{
-		Invoices: invoices,
+	// With the payment completed successfully, we now save the details of
+	// the completed payment to the database for historical record keeping.
+	err = r.savePayment(route, amtMSat, preImage[:], attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.SendResponse{
+		PaymentPreimage: preImage[:],
+		PaymentRoute:    marshallRoute(route),
 	}, nil
 }
 
-// SubscribeInvoices returns a uni-directional stream (server -> client) for
-// notifying the client of newly added/settled invoices.
-func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
-	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
-
-	invoiceClient := r.server.invoices.SubscribeNotifications()
-	defer invoiceClient.Cancel()
-
-	for {
-		select {
-		// TODO(roasbeef): include newly added invoices?
-		case settledInvoice := <-invoiceClient.SettledInvoices:
-
-			rpcInvoice, err := createRPCInvoice(settledInvoice)
-			if err != nil {
-				return err
-			}
+// unmarshallRoute converts a route specified over RPC into its routing
+// counterpart. Each hop is resolved against the channel graph, starting from
+// the passed source node, in order to obtain the policy of the channel it
+// travels along. If a hop specifies the public key of its node, then it must
+// match the node at the other end of the channel.
+func unmarshallRoute(rpcRoute *lnrpc.Route, graph *channeldb.ChannelGraph,
+	sourceVertex routing.Vertex) (*routing.Route, error) {
 
-			if err := updateStream.Send(rpcInvoice); err != nil {
-				return err
-			}
-		case <-r.quit:
-			return nil
-		}
+	if len(rpcRoute.Hops) == 0 {
+		return nil, fmt.Errorf("route must have at least one hop")
 	}
-}
 
-// SubscribeTransactions creates a uni-directional stream (server -> client) in
-// which any newly discovered transactions relevant to the wallet are sent
-// over.
-func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
-	updateStream lnrpc.Lightning_SubscribeTransactionsServer) error {
+	hops := make([]*routing.Hop, len(rpcRoute.Hops))
+	prevNode := sourceVertex
+	for i, rpcHop := range rpcRoute.Hops {
+		edgeInfo, edge1, edge2, err := graph.FetchChannelEdgesByID(
+			rpcHop.ChanId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch channel %v: %v",
+				rpcHop.ChanId, err)
+		}
 
-	txClient, err := r.server.cc.wallet.SubscribeTransactions()
-	if err != nil {
-		return err
-	}
-	defer txClient.Cancel()
+		// The policy we're interested in is the one of the node the
+		// HTLC arrives from, which leads to the node of this hop.
+		var policy *channeldb.ChannelEdgePolicy
+		switch prevNode {
+		case edgeInfo.NodeKey1Bytes:
+			policy = edge1
+		case edgeInfo.NodeKey2Bytes:
+			policy = edge2
+		default:
+			return nil, fmt.Errorf("channel %v doesn't connect to "+
+				"node %x", rpcHop.ChanId, prevNode[:])
+		}
+		if policy == nil {
+			return nil, fmt.Errorf("no policy known for channel "+
+				"%v from node %x", rpcHop.ChanId, prevNode[:])
+		}
 
-	for {
-		select {
-		case tx := <-txClient.ConfirmedTransactions():
-			detail := &lnrpc.Transaction{
-				TxHash:           tx.Hash.String(),
-				Amount:           int64(tx.Value),
-				NumConfirmations: tx.NumConfirmations,
-				BlockHash:        tx.BlockHash.String(),
-				TimeStamp:        tx.Timestamp,
-				TotalFees:        tx.TotalFees,
-			}
-			if err := updateStream.Send(detail); err != nil {
-				return err
-			}
-		case tx := <-txClient.UnconfirmedTransactions():
-			detail := &lnrpc.Transaction{
-				TxHash:    tx.Hash.String(),
-				Amount:    int64(tx.Value),
-				TimeStamp: tx.Timestamp,
-				TotalFees: tx.TotalFees,
+		hopNode := policy.Node.PubKeyBytes
+		if rpcHop.PubKey != "" {
+			pubKey, err := hex.DecodeString(rpcHop.PubKey)
+			if err != nil {
+				return nil, err
 			}
-			if err := updateStream.Send(detail); err != nil {
-				return err
+			if !bytes.Equal(pubKey, hopNode[:]) {
+				return nil, fmt.Errorf("hop %v of route has "+
+					"public key %x, but channel %v leads "+
+					"to %x", i, pubKey, rpcHop.ChanId,
+					hopNode[:])
 			}
-		case <-r.quit:
-			return nil
 		}
-	}
-}
-
-// GetTransactions returns a list of describing all the known transactions
-// relevant to the wallet.
-func (r *rpcServer) GetTransactions(ctx context.Context,
-	_ *lnrpc.GetTransactionsRequest) (*lnrpc.TransactionDetails, error) {
-
-	// TODO(roasbeef): add pagination support
-	transactions, err := r.server.cc.wallet.ListTransactionDetails()
-	if err != nil {
-		return nil, err
-	}
 
-	txDetails := &lnrpc.TransactionDetails{
-		Transactions: make([]*lnrpc.Transaction, len(transactions)),
-	}
-	for i, tx := range transactions {
-		var destAddresses []string
-		for _, destAddress := range tx.DestAddresses {
-			destAddresses = append(destAddresses, destAddress.EncodeAddress())
+		// The milli-satoshi amounts take precedence, as the satoshi
+		// amounts may have been rounded down.
+		amtToForward := lnwire.MilliSatoshi(rpcHop.AmtToForwardMsat)
+		if amtToForward == 0 {
+			amtToForward = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(rpcHop.AmtToForward),
+			)
+		}
+		fee := lnwire.MilliSatoshi(rpcHop.FeeMsat)
+		if fee == 0 {
+			fee = lnwire.NewMSatFromSatoshis(
+				btcutil.Amount(rpcHop.Fee),
+			)
 		}
 
-		txDetails.Transactions[i] = &lnrpc.Transaction{
-			TxHash:           tx.Hash.String(),
-			Amount:           int64(tx.Value),
-			NumConfirmations: tx.NumConfirmations,
-			BlockHash:        tx.BlockHash.String(),
-			BlockHeight:      tx.BlockHeight,
-			TimeStamp:        tx.Timestamp,
-			TotalFees:        tx.TotalFees,
-			DestAddresses:    destAddresses,
+		hops[i] = &routing.Hop{
+			Channel: &routing.ChannelHop{
+				Capacity:          edgeInfo.Capacity,
+				Chain:             edgeInfo.ChainHash,
+				ChannelEdgePolicy: policy,
+			},
+			OutgoingTimeLock: rpcHop.Expiry,
+			AmtToForward:     amtToForward,
+			Fee:              fee,
 		}
+
+		prevNode = routing.Vertex(hopNode)
 	}
 
-	return txDetails, nil
+	lastHop := hops[len(hops)-1]
+	return routing.NewRouteFromHops(
+		lastHop.AmtToForward, rpcRoute.TotalTimeLock, sourceVertex,
+		hops,
+	)
 }
 
-// DescribeGraph returns a description of the latest graph state from the PoV
-// of the node. The graph information is partitioned into two components: all
-// the nodes/vertexes, and all the edges that connect the vertexes themselves.
-// As this is a directed graph, the edges also contain the node directional
-// specific routing policy which includes: the time lock delta, fee
-// information, etc.
-func (r *rpcServer) DescribeGraph(ctx context.Context,
-	_ *lnrpc.ChannelGraphRequest) (*lnrpc.ChannelGraph, error) {
+// AddInvoice attempts to add a new invoice to the invoice database. Any
+// duplicated invoices are rejected, therefore all invoices *must* have a
+// unique payment preimage.
+func (r *rpcServer) AddInvoice(ctx context.Context,
+	invoice *lnrpc.Invoice) (*lnrpc.AddInvoiceResponse, error) {
 
-	resp := &lnrpc.ChannelGraph{}
+	return r.addInvoice(invoice, nil)
+}
+
+// addInvoice validates the passed invoice, encodes its payment request, and
+// adds it to the invoice registry. If a hold hash is passed, then a hold
+// invoice paid to that hash is added instead, whose preimage is only supplied
+// once the invoice is settled.
+func (r *rpcServer) addInvoice(invoice *lnrpc.Invoice,
+	holdHash *[32]byte) (*lnrpc.AddInvoiceResponse, error) {
+
+	var paymentPreimage [32]byte
+
+	switch {
+	// As the preimage of a hold invoice is only supplied when it's
+	// settled, it must not be specified upfront. Hold invoices also can't
+	// be paid using AMP, as then the payer chooses the preimage.
+	case holdHash != nil && len(invoice.RPreimage) > 0:
+		return nil, fmt.Errorf("preimage of hold invoice must not " +
+			"be specified")
+	case holdHash != nil && invoice.Amp:
+		return nil, fmt.Errorf("hold invoices can't be paid using " +
+			"AMP")
+	case holdHash != nil:
+
+	// If a preimage wasn't specified, then we'll generate a new preimage
+	// from fresh cryptographic randomness.
+	case len(invoice.RPreimage) == 0:
+		if _, err := rand.Read(paymentPreimage[:]); err != nil {
+			return nil, err
+		}
+
+	// Otherwise, if a preimage was specified, then it MUST be exactly
+	// 32-bytes.
+	case len(invoice.RPreimage) > 0 && len(invoice.RPreimage) != 32:
+		return nil, fmt.Errorf("payment preimage must be exactly "+
+			"32 bytes, is instead %v", len(invoice.RPreimage))
+
+	// If the preimage meets the size specifications, then it can be used
+	// as is.
+	default:
+		copy(paymentPreimage[:], invoice.RPreimage[:])
+	}
+
+	// The size of the memo, receipt and description hash attached must not
+	// exceed the maximum values for either of the fields.
+	if len(invoice.Memo) > channeldb.MaxMemoSize {
+		return nil, fmt.Errorf("memo too large: %v bytes "+
+			"(maxsize=%v)", len(invoice.Memo), channeldb.MaxMemoSize)
+	}
+	if len(invoice.Receipt) > channeldb.MaxReceiptSize {
+		return nil, fmt.Errorf("receipt too large: %v bytes "+
+			"(maxsize=%v)", len(invoice.Receipt), channeldb.MaxReceiptSize)
+	}
+	if len(invoice.DescriptionHash) > 0 && len(invoice.DescriptionHash) != 32 {
+		return nil, fmt.Errorf("description hash is %v bytes, must be %v",
+			len(invoice.DescriptionHash), channeldb.MaxPaymentRequestSize)
+	}
+
+	amt := btcutil.Amount(invoice.Value)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+
+	// The value of the invoice must also not exceed the current soft-limit
+	// on the largest payment within the network.
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max "+
+			"payment allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+	}
+
+	// Next, generate the payment hash itself from the preimage, unless
+	// this is a hold invoice. This will be used by clients to query for
+	// the state of a particular invoice.
+	rHash := sha256.Sum256(paymentPreimage[:])
+	if holdHash != nil {
+		rHash = *holdHash
+	}
+
+	// We also create an encoded payment request which allows the
+	// caller to compactly send the invoice to the payer. We'll create a
+	// list of options to be added to the encoded payment request. For now
+	// we only support the required fields description/description_hash,
+	// expiry, fallback address, and the amount field.
+	var options []func(*zpay32.Invoice)
+
+	// We only include the amount in the invoice if it is greater than 0.
+	// By not including the amount, we enable the creation of invoices that
+	// allow the payee to specify the amount of satoshis they wish to send.
+	if amtMSat > 0 {
+		options = append(options, zpay32.Amount(amtMSat))
+	}
+
+	// If specified, add a fallback address to the payment request.
+	if len(invoice.FallbackAddr) > 0 {
+		addr, err := btcutil.DecodeAddress(invoice.FallbackAddr,
+			activeNetParams.Params)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback address: %v",
+				err)
+		}
+		options = append(options, zpay32.FallbackAddr(addr))
+	}
+
+	// If expiry is set, specify it. If it is not provided, no expiry time
+	// will be explicitly added to this payment request, which will imply
+	// the default 3600 seconds.
+	if invoice.Expiry > 0 {
+
+		// We'll ensure that the specified expiry is restricted to sane
+		// number of seconds. As a result, we'll reject an invoice with
+		// an expiry greater than 1 year.
+		maxExpiry := time.Hour * 24 * 365
+		expSeconds := invoice.Expiry
+
+		if float64(expSeconds) > maxExpiry.Seconds() {
+			return nil, fmt.Errorf("expiry of %v seconds "+
+				"greater than max expiry of %v seconds",
+				float64(expSeconds), maxExpiry.Seconds())
+		}
+
+		expiry := time.Duration(invoice.Expiry) * time.Second
+		options = append(options, zpay32.Expiry(expiry))
+	}
+
+	// If the description hash is set, then we add it do the list of options.
+	// If not, use the memo field as the payment request description.
+	if len(invoice.DescriptionHash) > 0 {
+		var descHash [32]byte
+		copy(descHash[:], invoice.DescriptionHash[:])
+		options = append(options, zpay32.DescriptionHash(descHash))
+	} else {
+		// Use the memo field as the description. If this is not set
+		// this will just be an empty string.
+		options = append(options, zpay32.Description(invoice.Memo))
+	}
+
+	// We'll use our current default CLTV value unless one was specified as
+	// an option on the command line when creating an invoice.
+	switch {
+	case invoice.CltvExpiry > math.MaxUint16:
+		return nil, fmt.Errorf("CLTV delta of %v is too large, max "+
+			"accepted is: %v", invoice.CltvExpiry, math.MaxUint16)
+	case invoice.CltvExpiry != 0:
+		options = append(options,
+			zpay32.CLTVExpiry(invoice.CltvExpiry))
+	default:
+		// TODO(roasbeef): assumes set delta between versions
+		defaultDelta := cfg.Bitcoin.TimeLockDelta
+		if registeredChains.PrimaryChain() == litecoinChain {
+			defaultDelta = cfg.Litecoin.TimeLockDelta
+		}
+		options = append(options, zpay32.CLTVExpiry(uint64(defaultDelta)))
+	}
+
+	// Create and encode the payment request as a bech32 (zpay32) string.
+	creationDate := time.Now()
+	payReq, err := zpay32.NewInvoice(
+		activeNetParams.Params,
+		rHash,
+		creationDate,
+		options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payReqString, err := payReq.Encode(
+		zpay32.MessageSigner{
+			SignCompact: r.server.nodeSigner.SignDigestCompact,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &channeldb.Invoice{
+		CreationDate:   creationDate,
+		Expiry:         payReq.Expiry(),
+		Memo:           []byte(invoice.Memo),
+		Receipt:        invoice.Receipt,
+		PaymentRequest: []byte(payReqString),
+		Terms: channeldb.ContractTerm{
+			Value: amtMSat,
+			AMP:   invoice.Amp,
+		},
+	}
+	if holdHash != nil {
+		i.Terms.Hold = true
+		i.Terms.PaymentHash = rHash
+	} else {
+		copy(i.Terms.PaymentPreimage[:], paymentPreimage[:])
+	}
+
+	rpcsLog.Tracef("[addinvoice] adding new invoice %v",
+		newLogClosure(func() string {
+			return spew.Sdump(i)
+		}),
+	)
+
+	// With all sanity checks passed, write the invoice to the database.
+	if err := r.server.invoices.AddInvoice(i); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.AddInvoiceResponse{
+		RHash:          rHash[:],
+		PaymentRequest: payReqString,
+	}, nil
+}
+
+// createRPCInvoice creates an *lnrpc.Invoice from the *channeldb.Invoice.
+func createRPCInvoice(invoice *channeldb.Invoice) (*lnrpc.Invoice, error) {
+	preimage := invoice.Terms.PaymentPreimage
+	rHash := invoice.PaymentHash()
+
+	var (
+		paymentRequest = string(invoice.PaymentRequest)
+		descHash       = []byte("")
+		fallbackAddr   = ""
+		expiry         int64
+		cltvExpiry     uint64
+	)
+
+	// Invoices synthesized for keysend payments have no payment request,
+	// so there's nothing to decode.
+	if !invoice.KeySend {
+		decoded, err := zpay32.Decode(
+			paymentRequest, activeNetParams.Params,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode payment "+
+				"request: %v", err)
+		}
+
+		if decoded.DescriptionHash != nil {
+			descHash = decoded.DescriptionHash[:]
+		}
+
+		if decoded.FallbackAddr != nil {
+			fallbackAddr = decoded.FallbackAddr.String()
+		}
+
+		// Expiry time will default to 3600 seconds if not specified
+		// explicitly.
+		expiry = int64(decoded.Expiry().Seconds())
+
+		// The expiry will default to 9 blocks if not specified
+		// explicitly.
+		cltvExpiry = decoded.MinFinalCLTVExpiry()
+	}
+
+	settleDate := int64(0)
+	if !invoice.SettleDate.IsZero() {
+		settleDate = invoice.SettleDate.Unix()
+	}
+
+	satAmt := invoice.Terms.Value.ToSatoshis()
+
+	state := lnrpc.Invoice_OPEN
+	switch {
+	case invoice.Terms.Settled:
+		state = lnrpc.Invoice_SETTLED
+	case invoice.Terms.Canceled:
+		state = lnrpc.Invoice_CANCELED
+	case invoice.Terms.Accepted:
+		state = lnrpc.Invoice_ACCEPTED
+	}
+
+	// The preimage of a hold invoice is only known once it's settled.
+	var rPreimage []byte
+	if !invoice.Terms.Hold || invoice.Terms.Settled {
+		rPreimage = preimage[:]
+	}
+
+	// Each HTLC set which paid an AMP invoice is reported as a separate
+	// settlement.
+	var ampSettlements []*lnrpc.AMPSettlement
+	for _, settlement := range invoice.AMPSettlements {
+		childPreimages := make([][]byte, len(settlement.ChildPreimages))
+		for i := range settlement.ChildPreimages {
+			childPreimages[i] = settlement.ChildPreimages[i][:]
+		}
+
+		ampSettlements = append(ampSettlements, &lnrpc.AMPSettlement{
+			SetId:          settlement.SetID[:],
+			ChildPreimages: childPreimages,
+			AmtPaidMsat:    int64(settlement.Amount),
+			SettleDate:     settlement.SettleDate.Unix(),
+		})
+	}
+
+	return &lnrpc.Invoice{
+		Memo:            string(invoice.Memo[:]),
+		Receipt:         invoice.Receipt[:],
+		RHash:           rHash[:],
+		RPreimage:       rPreimage,
+		Value:           int64(satAmt),
+		CreationDate:    invoice.CreationDate.Unix(),
+		SettleDate:      settleDate,
+		Settled:         invoice.Terms.Settled,
+		PaymentRequest:  paymentRequest,
+		DescriptionHash: descHash,
+		Expiry:          expiry,
+		CltvExpiry:      cltvExpiry,
+		FallbackAddr:    fallbackAddr,
+		State:           state,
+		CustomRecords:   invoice.CustomRecords,
+		Amp:             invoice.Terms.AMP,
+		AmpSettlements:  ampSettlements,
+		IsKeysend:       invoice.KeySend,
+		AddIndex:        invoice.AddIndex,
+		SettleIndex:     invoice.SettleIndex,
+	}, nil
+}
+
+// LookupInvoice attempts to look up an invoice according to its payment hash.
+// The passed payment hash *must* be exactly 32 bytes, if not an error is
+// returned.
+func (r *rpcServer) LookupInvoice(ctx context.Context,
+	req *lnrpc.PaymentHash) (*lnrpc.Invoice, error) {
+
+	var (
+		payHash [32]byte
+		rHash   []byte
+		err     error
+	)
+
+	// If the RHash as a raw string was provided, then decode that and use
+	// that directly. Otherwise, we use the raw bytes provided.
+	if req.RHashStr != "" {
+		rHash, err = hex.DecodeString(req.RHashStr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rHash = req.RHash
+	}
+
+	// Ensure that the payment hash is *exactly* 32-bytes.
+	if len(rHash) != 0 && len(rHash) != 32 {
+		return nil, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(rHash))
+	}
+	copy(payHash[:], rHash)
+
+	rpcsLog.Tracef("[lookupinvoice] searching for invoice %x", payHash[:])
+
+	invoice, err := r.server.invoices.LookupInvoice(payHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Tracef("[lookupinvoice] located invoice %v",
+		newLogClosure(func() string {
+			return spew.Sdump(invoice)
+		}))
+
+	rpcInvoice, err := createRPCInvoice(&invoice)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcInvoice, nil
+}
+
+// ListInvoices returns a list of all the invoices currently stored within the
+// database. Any active debug invoices are ignored.
+func (r *rpcServer) ListInvoices(ctx context.Context,
+	req *lnrpc.ListInvoiceRequest) (*lnrpc.ListInvoiceResponse, error) {
+
+	dbInvoices, err := r.server.chanDB.FetchAllInvoices(req.PendingOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices := make([]*lnrpc.Invoice, len(dbInvoices))
+	for i, dbInvoice := range dbInvoices {
+
+		rpcInvoice, err := createRPCInvoice(dbInvoice)
+		if err != nil {
+			return nil, err
+		}
+
+		invoices[i] = rpcInvoice
+	}
+
+	return &lnrpc.ListInvoiceResponse{
+		Invoices: invoices,
+	}, nil
+}
+
+// SubscribeInvoices returns a uni-directional stream (server -> client) for
+// notifying the client of newly added/settled/canceled invoices. If the
+// request carries an add or settle index, then the invoices added or settled
+// after it are sent first.
+func (r *rpcServer) SubscribeInvoices(req *lnrpc.InvoiceSubscription,
+	updateStream lnrpc.Lightning_SubscribeInvoicesServer) error {
+
+	invoiceClient, err := r.server.invoices.SubscribeNotifications(
+		req.AddIndex, req.SettleIndex,
+	)
+	if err != nil {
+		return err
+	}
+	defer invoiceClient.Cancel()
+
+	for {
+		select {
+		case newInvoice := <-invoiceClient.NewInvoices:
+			rpcInvoice, err := createRPCInvoice(newInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
+		case settledInvoice := <-invoiceClient.SettledInvoices:
+			rpcInvoice, err := createRPCInvoice(settledInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
+		case canceledInvoice := <-invoiceClient.CanceledInvoices:
+			rpcInvoice, err := createRPCInvoice(canceledInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+
+		case acceptedInvoice := <-invoiceClient.AcceptedInvoices:
+			rpcInvoice, err := createRPCInvoice(acceptedInvoice)
+			if err != nil {
+				return err
+			}
+
+			if err := updateStream.Send(rpcInvoice); err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// SubscribeTransactions creates a uni-directional stream (server -> client) in
+// which any newly discovered transactions relevant to the wallet are sent
+// over.
+func (r *rpcServer) SubscribeTransactions(req *lnrpc.GetTransactionsRequest,
+	updateStream lnrpc.Lightning_SubscribeTransactionsServer) error {
+
+	txClient, err := r.server.cc.wallet.SubscribeTransactions()
+	if err != nil {
+		return err
+	}
+	defer txClient.Cancel()
+
+	for {
+		select {
+		case tx := <-txClient.ConfirmedTransactions():
+			detail := &lnrpc.Transaction{
+				TxHash:           tx.Hash.String(),
+				Amount:           int64(tx.Value),
+				NumConfirmations: tx.NumConfirmations,
+				BlockHash:        tx.BlockHash.String(),
+				TimeStamp:        tx.Timestamp,
+				TotalFees:        tx.TotalFees,
+			}
+			if err := updateStream.Send(detail); err != nil {
+				return err
+			}
+		case tx := <-txClient.UnconfirmedTransactions():
+			detail := &lnrpc.Transaction{
+				TxHash:    tx.Hash.String(),
+				Amount:    int64(tx.Value),
+				TimeStamp: tx.Timestamp,
+				TotalFees: tx.TotalFees,
+			}
+			if err := updateStream.Send(detail); err != nil {
+				return err
+			}
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// GetTransactions returns a list of describing all the known transactions
+// relevant to the wallet.
+func (r *rpcServer) GetTransactions(ctx context.Context,
+	_ *lnrpc.GetTransactionsRequest) (*lnrpc.TransactionDetails, error) {
+
+	// TODO(roasbeef): add pagination support
+	transactions, err := r.server.cc.wallet.ListTransactionDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	txDetails := &lnrpc.TransactionDetails{
+		Transactions: make([]*lnrpc.Transaction, len(transactions)),
+	}
+	for i, tx := range transactions {
+		var destAddresses []string
+		for _, destAddress := range tx.DestAddresses {
+			destAddresses = append(destAddresses, destAddress.EncodeAddress())
+		}
+
+		txDetails.Transactions[i] = &lnrpc.Transaction{
+			TxHash:           tx.Hash.String(),
+			Amount:           int64(tx.Value),
+			NumConfirmations: tx.NumConfirmations,
+			BlockHash:        tx.BlockHash.String(),
+			BlockHeight:      tx.BlockHeight,
+			TimeStamp:        tx.Timestamp,
+			TotalFees:        tx.TotalFees,
+			DestAddresses:    destAddresses,
+		}
+	}
+
+	return txDetails, nil
+}
+
+// DescribeGraph returns a description of the latest graph state from the PoV
+// of the node. The graph information is partitioned into two components: all
+// the nodes/vertexes, and all the edges that connect the vertexes themselves.
+// As this is a directed graph, the edges also contain the node directional
+// specific routing policy which includes: the time lock delta, fee
+// information, etc. As the full graph may exceed the maximum message size,
+// the nodes and edges can be fetched in pages, and filtered.
+func (r *rpcServer) DescribeGraph(ctx context.Context,
+	req *lnrpc.ChannelGraphRequest) (*lnrpc.ChannelGraph, error) {
+
+	// If a page of nodes or edges turns out empty, then the offsets of the
+	// request are returned as is, so the next page resumes from there.
+	resp := &lnrpc.ChannelGraph{
+		LastNodeOffset:   req.NodeOffset,
+		LastChanIdOffset: req.ChanIdOffset,
+	}
+
+	var nodeOffset []byte
+	if req.NodeOffset != "" {
+		var err error
+		nodeOffset, err = hex.DecodeString(req.NodeOffset)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode node offset: %v",
+				err)
+		}
+	}
 
 	// Obtain the pointer to the global singleton channel graph, this will
 	// provide a consistent view of the graph due to bolt db's
 	// transactional model.
 	graph := r.server.chanDB.ChannelGraph()
 
-	// First iterate through all the known nodes (connected or unconnected
-	// within the graph), collating their current state into the RPC
-	// response.
-	err := graph.ForEachNode(nil, func(_ *bolt.Tx, node *channeldb.LightningNode) error {
-		nodeAddrs := make([]*lnrpc.NodeAddress, 0)
-		for _, addr := range node.Addresses {
-			nodeAddr := &lnrpc.NodeAddress{
-				Network: addr.Network(),
-				Addr:    addr.String(),
+	// First iterate through the known nodes (connected or unconnected
+	// within the graph) following the offset, collating their current
+	// state into the RPC response until the page is full.
+	err := graph.ForEachNodeAfter(nodeOffset, func(node *channeldb.LightningNode) error {
+		if node.LastUpdate.Unix() < req.NodesUpdatedSince {
+			return nil
+		}
+
+		nodeAddrs := make([]*lnrpc.NodeAddress, 0)
+		for _, addr := range node.Addresses {
+			nodeAddr := &lnrpc.NodeAddress{
+				Network: addr.Network(),
+				Addr:    addr.String(),
+			}
+			nodeAddrs = append(nodeAddrs, nodeAddr)
+		}
+
+		pubKey := hex.EncodeToString(node.PubKeyBytes[:])
+		nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
+		resp.Nodes = append(resp.Nodes, &lnrpc.LightningNode{
+			LastUpdate: uint32(node.LastUpdate.Unix()),
+			PubKey:     pubKey,
+			Addresses:  nodeAddrs,
+			Alias:      node.Alias,
+			Color:      nodeColor,
+		})
+		resp.LastNodeOffset = pubKey
+
+		if req.NumMaxNodes != 0 &&
+			uint32(len(resp.Nodes)) >= req.NumMaxNodes {
+
+			return channeldb.ErrStopGraphIteration
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Zombie channels are only known by their channel ID, so we fetch
+	// them upfront if they're to be omitted.
+	var zombies map[uint64]struct{}
+	if req.OmitDisabledEdges {
+		zombies, err = graph.FetchZombieEdges()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Next, for each active channel we know of within the graph following
+	// the offset, create a similar response which details both the edge
+	// information as well as the routing policies of th nodes connecting
+	// the two edges.
+	err = graph.ForEachChannelAfter(req.ChanIdOffset,
+		func(edgeInfo *channeldb.ChannelEdgeInfo,
+			c1, c2 *channeldb.ChannelEdgePolicy) error {
+
+			if int64(edgeInfo.Capacity) < req.MinCapacity {
+				return nil
+			}
+			if req.OmitDisabledEdges {
+				if _, ok := zombies[edgeInfo.ChannelID]; ok {
+					return nil
+				}
+				if edgeDisabled(c1, c2) {
+					return nil
+				}
+			}
+
+			edge := marshalDbEdge(edgeInfo, c1, c2)
+			resp.Edges = append(resp.Edges, edge)
+			resp.LastChanIdOffset = edgeInfo.ChannelID
+
+			if req.NumMaxEdges != 0 &&
+				uint32(len(resp.Edges)) >= req.NumMaxEdges {
+
+				return channeldb.ErrStopGraphIteration
+			}
+
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// edgeDisabled returns true if neither of the passed routing policies of a
+// channel is both advertised and enabled, in which case the channel can't be
+// used to route payments in either direction.
+func edgeDisabled(c1, c2 *channeldb.ChannelEdgePolicy) bool {
+	enabled := func(policy *channeldb.ChannelEdgePolicy) bool {
+		return policy != nil &&
+			policy.Flags&lnwire.ChanUpdateDisabled == 0
+	}
+
+	return !enabled(c1) && !enabled(c2)
+}
+
+func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
+	c1, c2 *channeldb.ChannelEdgePolicy) *lnrpc.ChannelEdge {
+
+	var (
+		lastUpdate int64
+	)
+
+	if c2 != nil {
+		lastUpdate = c2.LastUpdate.Unix()
+	}
+	if c1 != nil {
+		lastUpdate = c1.LastUpdate.Unix()
+	}
+
+	edge := &lnrpc.ChannelEdge{
+		ChannelId: edgeInfo.ChannelID,
+		ChanPoint: edgeInfo.ChannelPoint.String(),
+		// TODO(roasbeef): update should be on edge info itself
+		LastUpdate: uint32(lastUpdate),
+		Node1Pub:   hex.EncodeToString(edgeInfo.NodeKey1Bytes[:]),
+		Node2Pub:   hex.EncodeToString(edgeInfo.NodeKey2Bytes[:]),
+		Capacity:   int64(edgeInfo.Capacity),
+	}
+
+	if c1 != nil {
+		edge.Node1Policy = &lnrpc.RoutingPolicy{
+			TimeLockDelta:    uint32(c1.TimeLockDelta),
+			MinHtlc:          int64(c1.MinHTLC),
+			FeeBaseMsat:      int64(c1.FeeBaseMSat),
+			FeeRateMilliMsat: int64(c1.FeeProportionalMillionths),
+			MaxHtlcMsat:      uint64(c1.MaxHTLC),
+		}
+	}
+
+	if c2 != nil {
+		edge.Node2Policy = &lnrpc.RoutingPolicy{
+			TimeLockDelta:    uint32(c2.TimeLockDelta),
+			MinHtlc:          int64(c2.MinHTLC),
+			FeeBaseMsat:      int64(c2.FeeBaseMSat),
+			FeeRateMilliMsat: int64(c2.FeeProportionalMillionths),
+			MaxHtlcMsat:      uint64(c2.MaxHTLC),
+		}
+	}
+
+	return edge
+}
+
+// GetChanInfo returns the latest authenticated network announcement for the
+// given channel identified by its channel ID: an 8-byte integer which uniquely
+// identifies the location of transaction's funding output within the block
+// chain.
+func (r *rpcServer) GetChanInfo(ctx context.Context,
+	in *lnrpc.ChanInfoRequest) (*lnrpc.ChannelEdge, error) {
+
+	graph := r.server.chanDB.ChannelGraph()
+
+	edgeInfo, edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the database's edge format into the network/RPC edge format
+	// which couples the edge itself along with the directional node
+	// routing policies of each node involved within the channel.
+	channelEdge := marshalDbEdge(edgeInfo, edge1, edge2)
+
+	return channelEdge, nil
+}
+
+// GetNodeInfo returns the latest advertised and aggregate authenticated
+// channel information for the specified node identified by its public key.
+func (r *rpcServer) GetNodeInfo(ctx context.Context,
+	in *lnrpc.NodeInfoRequest) (*lnrpc.NodeInfo, error) {
+
+	graph := r.server.chanDB.ChannelGraph()
+
+	// First, parse the hex-encoded public key into a full in-memory public
+	// key object we can work with for querying.
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	// With the public key decoded, attempt to fetch the node corresponding
+	// to this public key. If the node cannot be found, then an error will
+	// be returned.
+	node, err := graph.FetchLightningNode(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// With the node obtained, we'll now iterate through all its out going
+	// edges to gather some basic statistics about its out going channels.
+	var (
+		numChannels   uint32
+		totalCapacity btcutil.Amount
+	)
+	if err := node.ForEachChannel(nil, func(_ channeldb.ReadTx, edge *channeldb.ChannelEdgeInfo,
+		_, _ *channeldb.ChannelEdgePolicy) error {
+
+		numChannels++
+		totalCapacity += edge.Capacity
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	nodeAddrs := make([]*lnrpc.NodeAddress, 0)
+	for _, addr := range node.Addresses {
+		nodeAddr := &lnrpc.NodeAddress{
+			Network: addr.Network(),
+			Addr:    addr.String(),
+		}
+		nodeAddrs = append(nodeAddrs, nodeAddr)
+	}
+	// TODO(roasbeef): list channels as well?
+
+	// We'll also include the node's prior announcements, so the caller
+	// can see when it changed its advertised attributes.
+	history, err := graph.FetchNodeHistory(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	nodeHistory := make([]*lnrpc.LightningNode, 0, len(history))
+	for _, record := range history {
+		addrs := make([]*lnrpc.NodeAddress, 0, len(record.Addresses))
+		for _, addr := range record.Addresses {
+			addrs = append(addrs, &lnrpc.NodeAddress{
+				Network: addr.Network(),
+				Addr:    addr.String(),
+			})
+		}
+
+		nodeHistory = append(nodeHistory, &lnrpc.LightningNode{
+			LastUpdate: uint32(record.Timestamp.Unix()),
+			PubKey:     in.PubKey,
+			Addresses:  addrs,
+			Alias:      record.Alias,
+			Color: fmt.Sprintf("#%02x%02x%02x", record.Color.R,
+				record.Color.G, record.Color.B),
+		})
+	}
+
+	nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
+	return &lnrpc.NodeInfo{
+		Node: &lnrpc.LightningNode{
+			LastUpdate: uint32(node.LastUpdate.Unix()),
+			PubKey:     in.PubKey,
+			Addresses:  nodeAddrs,
+			Alias:      node.Alias,
+			Color:      nodeColor,
+		},
+		NumChannels:   numChannels,
+		TotalCapacity: int64(totalCapacity),
+		History:       nodeHistory,
+	}, nil
+}
+
+// QueryRoutes attempts to query the daemons' Channel Router for a possible
+// route to a target destination capable of carrying a specific amount of
+// satoshis within the route's flow. The retuned route contains the full
+// details required to craft and send an HTLC, also including the necessary
+// information that should be present within the Sphinx packet encapsulated
+// within the HTLC.
+//
+// TODO(roasbeef): should return a slice of routes in reality
+//   - create separate PR to send based on well formatted route
+func (r *rpcServer) QueryRoutes(ctx context.Context,
+	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
+
+	// First parse the hex-encoded public key into a full public key object
+	// we can properly manipulate.
+	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+	if err != nil {
+		return nil, err
+	}
+
+	// Currently, within the bootstrap phase of the network, we limit the
+	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
+	// satoshis.
+	amt := btcutil.Amount(in.Amt)
+	amtMSat := lnwire.NewMSatFromSatoshis(amt)
+	if amtMSat > maxPaymentMSat {
+		return nil, fmt.Errorf("payment of %v is too large, max payment "+
+			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
+	}
+
+	// Next, we'll gather the restrictions the caller imposed on the
+	// routes, so it can build its own logic to avoid certain nodes and
+	// channels on top of our path finding.
+	restrictions := &routing.RouteRestrictions{
+		IgnoredNodes: make(map[routing.Vertex]struct{}),
+		IgnoredEdges: make(map[uint64]struct{}),
+	}
+	restrictions.FeeLimit, err = calculateFeeLimit(in.FeeLimit, amtMSat)
+	if err != nil {
+		return nil, err
+	}
+	for _, ignoredNode := range in.IgnoredNodes {
+		if len(ignoredNode) != 33 {
+			return nil, fmt.Errorf("invalid ignored node pubkey "+
+				"length: %v", len(ignoredNode))
+		}
+
+		var vertex routing.Vertex
+		copy(vertex[:], ignoredNode)
+		restrictions.IgnoredNodes[vertex] = struct{}{}
+	}
+	for _, ignoredEdge := range in.IgnoredEdges {
+		restrictions.IgnoredEdges[ignoredEdge] = struct{}{}
+	}
+	if in.SourcePubKey != "" {
+		sourceBytes, err := hex.DecodeString(in.SourcePubKey)
+		if err != nil {
+			return nil, err
+		}
+		restrictions.Source, err = btcec.ParsePubKey(
+			sourceBytes, btcec.S256(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Query the channel router for a possible path to the destination that
+	// can carry `in.Amt` satoshis _including_ the total fee required on
+	// the route.
+	routes, err := r.server.chanRouter.FindRestrictedRoutes(
+		pubKey, amtMSat, restrictions, uint32(in.NumRoutes),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// As the number of returned routes can be less than the number of
+	// requested routes, we'll clamp down the length of the response to the
+	// minimum of the two.
+	numRoutes := int32(len(routes))
+	if in.NumRoutes < numRoutes {
+		numRoutes = in.NumRoutes
+	}
+
+	// For each valid route, we'll convert the result into the format
+	// required by the RPC system.
+	routeResp := &lnrpc.QueryRoutesResponse{
+		Routes: make([]*lnrpc.Route, 0, in.NumRoutes),
+	}
+	for i := int32(0); i < numRoutes; i++ {
+		routeResp.Routes = append(
+			routeResp.Routes, marshallRoute(routes[i]),
+		)
+	}
+
+	return routeResp, nil
+}
+
+// calculateFeeLimit returns the fee limit in milli-satoshis described by the
+// passed RPC fee limit for a payment of the passed amount. If no fee limit is
+// set, then nil is returned, as the fee is unbounded.
+func calculateFeeLimit(feeLimit *lnrpc.FeeLimit,
+	amount lnwire.MilliSatoshi) (*lnwire.MilliSatoshi, error) {
+
+	switch feeLimit.GetLimit().(type) {
+	case *lnrpc.FeeLimit_Fixed:
+		if feeLimit.GetFixed() < 0 {
+			return nil, fmt.Errorf("fee limit must not be negative")
+		}
+
+		limit := lnwire.NewMSatFromSatoshis(
+			btcutil.Amount(feeLimit.GetFixed()),
+		)
+		return &limit, nil
+
+	case *lnrpc.FeeLimit_Percent:
+		if feeLimit.GetPercent() < 0 {
+			return nil, fmt.Errorf("fee limit must not be negative")
+		}
+
+		limit := amount * lnwire.MilliSatoshi(feeLimit.GetPercent()) / 100
+		return &limit, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+func marshallRoute(route *routing.Route) *lnrpc.Route {
+	resp := &lnrpc.Route{
+		TotalTimeLock: route.TotalTimeLock,
+		TotalFees:     int64(route.TotalFees.ToSatoshis()),
+		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
+		TotalFeesMsat: int64(route.TotalFees),
+		TotalAmtMsat:  int64(route.TotalAmount),
+		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+	}
+	for i, hop := range route.Hops {
+		pubKey := hop.Channel.Node.PubKeyBytes
+		resp.Hops[i] = &lnrpc.Hop{
+			ChanId:           hop.Channel.ChannelID,
+			ChanCapacity:     int64(hop.Channel.Capacity),
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(hop.Fee.ToSatoshis()),
+			Expiry:           uint32(hop.OutgoingTimeLock),
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(hop.Fee),
+			PubKey:           hex.EncodeToString(pubKey[:]),
+		}
+	}
+
+	return resp
+}
+
+// GetNetworkInfo returns some basic stats about the known channel graph from
+// the PoV of the node.
+func (r *rpcServer) GetNetworkInfo(ctx context.Context,
+	_ *lnrpc.NetworkInfoRequest) (*lnrpc.NetworkInfo, error) {
+
+	graph := r.server.chanDB.ChannelGraph()
+
+	var (
+		numNodes             uint32
+		numChannels          uint32
+		maxChanOut           uint32
+		totalNetworkCapacity btcutil.Amount
+		minChannelSize       btcutil.Amount = math.MaxInt64
+		maxChannelSize       btcutil.Amount
+	)
+
+	// We'll use this map to de-duplicate channels during our traversal.
+	// This is needed since channels are directional, so there will be two
+	// edges for each channel within the graph.
+	seenChans := make(map[uint64]struct{})
+
+	// We'll run through all the known nodes in the within our view of the
+	// network, tallying up the total number of nodes, and also gathering
+	// each node so we can measure the graph diameter and degree stats
+	// below.
+	if err := graph.ForEachNode(nil, func(tx channeldb.ReadTx, node *channeldb.LightningNode) error {
+		// Increment the total number of nodes with each iteration.
+		numNodes++
+
+		// For each channel we'll compute the out degree of each node,
+		// and also update our running tallies of the min/max channel
+		// capacity, as well as the total channel capacity. We pass
+		// through the db transaction from the outer view so we can
+		// re-use it within this inner view.
+		var outDegree uint32
+		if err := node.ForEachChannel(tx, func(_ channeldb.ReadTx,
+			edge *channeldb.ChannelEdgeInfo, _, _ *channeldb.ChannelEdgePolicy) error {
+
+			// Bump up the out degree for this node for each
+			// channel encountered.
+			outDegree++
+
+			// If we've already seen this channel, then we'll
+			// return early to ensure that we don't double-count
+			// stats.
+			if _, ok := seenChans[edge.ChannelID]; ok {
+				return nil
+			}
+
+			// Compare the capacity of this channel against the
+			// running min/max to see if we should update the
+			// extrema.
+			chanCapacity := edge.Capacity
+			if chanCapacity < minChannelSize {
+				minChannelSize = chanCapacity
+			}
+			if chanCapacity > maxChannelSize {
+				maxChannelSize = chanCapacity
+			}
+
+			// Accumulate the total capacity of this channel to the
+			// network wide-capacity.
+			totalNetworkCapacity += chanCapacity
+
+			numChannels++
+
+			seenChans[edge.ChannelID] = struct{}{}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Finally, if the out degree of this node is greater than what
+		// we've seen so far, update the maxChanOut variable.
+		if outDegree > maxChanOut {
+			maxChanOut = outDegree
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// If we don't have any channels, then reset the minChannelSize to zero
+	// to avoid outputting NaN in encoded JSON.
+	if numChannels == 0 {
+		minChannelSize = 0
+	}
+
+	// TODO(roasbeef): graph diameter
+
+	// TODO(roasbeef): also add oldest channel?
+	//  * also add median channel size
+	netInfo := &lnrpc.NetworkInfo{
+		MaxOutDegree:         maxChanOut,
+		AvgOutDegree:         float64(numChannels) / float64(numNodes),
+		NumNodes:             numNodes,
+		NumChannels:          numChannels,
+		TotalNetworkCapacity: int64(totalNetworkCapacity),
+		AvgChannelSize:       float64(totalNetworkCapacity) / float64(numChannels),
+
+		MinChannelSize: int64(minChannelSize),
+		MaxChannelSize: int64(maxChannelSize),
+	}
+
+	// Similarly, if we don't have any channels, then we'll also set the
+	// average channel size to zero in order to avoid weird JSON encoding
+	// outputs.
+	if numChannels == 0 {
+		netInfo.AvgChannelSize = 0
+	}
+
+	return netInfo, nil
+}
+
+// GetNodeMetrics computes the requested metrics of every node within the
+// known channel graph. As computing the betweenness centrality requires a
+// search of the graph from each of its nodes, it's spread across all CPUs.
+func (r *rpcServer) GetNodeMetrics(ctx context.Context,
+	req *lnrpc.NodeMetricsRequest) (*lnrpc.NodeMetricsResponse, error) {
+
+	if !req.BetweennessCentrality && !req.DegreeStats {
+		return nil, fmt.Errorf("no node metrics requested")
+	}
+
+	graph := r.server.chanDB.ChannelGraph()
+	metrics := make(map[autopilot.NodeID]*lnrpc.NodeMetrics)
+	nodeMetrics := func(id autopilot.NodeID) *lnrpc.NodeMetrics {
+		m, ok := metrics[id]
+		if !ok {
+			m = &lnrpc.NodeMetrics{
+				PubKey: hex.EncodeToString(id[:]),
+			}
+			metrics[id] = m
+		}
+
+		return m
+	}
+
+	if req.BetweennessCentrality {
+		centrality, err := autopilot.NewBetweennessCentralityMetric(
+			runtime.NumCPU(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		err = centrality.Refresh(autopilot.ChannelGraphFromDatabase(graph))
+		if err != nil {
+			return nil, err
+		}
+
+		for id, c := range centrality.GetMetric(false) {
+			nodeMetrics(id).BetweennessCentrality = c
+		}
+		for id, c := range centrality.GetMetric(true) {
+			nodeMetrics(id).BetweennessCentralityNormalized = c
+		}
+	}
+
+	if req.DegreeStats {
+		err := graph.ForEachNode(nil, func(tx channeldb.ReadTx,
+			node *channeldb.LightningNode) error {
+
+			m := nodeMetrics(autopilot.NodeID(node.PubKeyBytes))
+			return node.ForEachChannel(tx, func(_ channeldb.ReadTx,
+				edge *channeldb.ChannelEdgeInfo, _,
+				_ *channeldb.ChannelEdgePolicy) error {
+
+				m.NumChannels++
+				m.TotalCapacity += int64(edge.Capacity)
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &lnrpc.NodeMetricsResponse{
+		Nodes: make([]*lnrpc.NodeMetrics, 0, len(metrics)),
+	}
+	for _, m := range metrics {
+		resp.Nodes = append(resp.Nodes, m)
+	}
+	sort.Slice(resp.Nodes, func(i, j int) bool {
+		a, b := resp.Nodes[i], resp.Nodes[j]
+		if a.BetweennessCentrality != b.BetweennessCentrality {
+			return a.BetweennessCentrality > b.BetweennessCentrality
+		}
+
+		return a.PubKey < b.PubKey
+	})
+
+	return resp, nil
+}
+
+// StopDaemon will send a shutdown request to the interrupt handler, triggering
+// a graceful shutdown of the daemon.
+func (r *rpcServer) StopDaemon(ctx context.Context,
+	_ *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+
+	shutdownRequestChannel <- struct{}{}
+	return &lnrpc.StopResponse{}, nil
+}
+
+// SubscribeChannelGraph launches a streaming RPC that allows the caller to
+// receive notifications upon any changes the channel graph topology from the
+// review of the responding node. Events notified include: new nodes coming
+// online, nodes updating their authenticated attributes, new channels being
+// advertised, updates in the routing policy for a directional channel edge,
+// and finally when prior channels are closed on-chain.
+func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelGraphServer) error {
+
+	// First, we start by subscribing to a new intent to receive
+	// notifications from the channel router.
+	client, err := r.server.chanRouter.SubscribeTopology()
+	if err != nil {
+		return err
+	}
+
+	// Ensure that the resources for the topology update client is cleaned
+	// up once either the server, or client exists.
+	defer client.Cancel()
+
+	for {
+		select {
+
+		// A new update has been sent by the channel router, we'll
+		// marshal it into the form expected by the gRPC client, then
+		// send it off.
+		case topChange, ok := <-client.TopologyChanges:
+			// If the second value from the channel read is nil,
+			// then this means that the channel router is exiting
+			// or the notification client was cancelled. So we'll
+			// exit early.
+			if !ok {
+				return errors.New("server shutting down")
+			}
+
+			// Convert the struct from the channel router into the
+			// form expected by the gRPC service then send it off
+			// to the client.
+			graphUpdate := marshallTopologyChange(topChange)
+			if err := updateStream.Send(graphUpdate); err != nil {
+				return err
+			}
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// marshallTopologyChange performs a mapping from the topology change struct
+// returned by the router to the form of notifications expected by the current
+// gRPC service.
+func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopologyUpdate {
+
+	// encodeKey is a simple helper function that converts a live public
+	// key into a hex-encoded version of the compressed serialization for
+	// the public key.
+	encodeKey := func(k *btcec.PublicKey) string {
+		return hex.EncodeToString(k.SerializeCompressed())
+	}
+
+	nodeUpdates := make([]*lnrpc.NodeUpdate, len(topChange.NodeUpdates))
+	for i, nodeUpdate := range topChange.NodeUpdates {
+		addrs := make([]string, len(nodeUpdate.Addresses))
+		for i, addr := range nodeUpdate.Addresses {
+			addrs[i] = addr.String()
+		}
+
+		nodeUpdates[i] = &lnrpc.NodeUpdate{
+			Addresses:      addrs,
+			IdentityKey:    encodeKey(nodeUpdate.IdentityKey),
+			GlobalFeatures: nodeUpdate.GlobalFeatures,
+			Alias:          nodeUpdate.Alias,
+		}
+	}
+
+	channelUpdates := make([]*lnrpc.ChannelEdgeUpdate, len(topChange.ChannelEdgeUpdates))
+	for i, channelUpdate := range topChange.ChannelEdgeUpdates {
+		channelUpdates[i] = &lnrpc.ChannelEdgeUpdate{
+			ChanId: channelUpdate.ChanID,
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+					FundingTxidBytes: channelUpdate.ChanPoint.Hash[:],
+				},
+				OutputIndex: channelUpdate.ChanPoint.Index,
+			},
+			Capacity: int64(channelUpdate.Capacity),
+			RoutingPolicy: &lnrpc.RoutingPolicy{
+				TimeLockDelta:    uint32(channelUpdate.TimeLockDelta),
+				MinHtlc:          int64(channelUpdate.MinHTLC),
+				FeeBaseMsat:      int64(channelUpdate.BaseFee),
+				FeeRateMilliMsat: int64(channelUpdate.FeeRate),
+				MaxHtlcMsat:      uint64(channelUpdate.MaxHTLC),
+			},
+			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
+			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
+		}
+	}
+
+	closedChans := make([]*lnrpc.ClosedChannelUpdate, len(topChange.ClosedChannels))
+	for i, closedChan := range topChange.ClosedChannels {
+		closedChans[i] = &lnrpc.ClosedChannelUpdate{
+			ChanId:       closedChan.ChanID,
+			Capacity:     int64(closedChan.Capacity),
+			ClosedHeight: closedChan.ClosedHeight,
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+					FundingTxidBytes: closedChan.ChanPoint.Hash[:],
+				},
+				OutputIndex: closedChan.ChanPoint.Index,
+			},
+		}
+	}
+
+	return &lnrpc.GraphTopologyUpdate{
+		NodeUpdates:    nodeUpdates,
+		ChannelUpdates: channelUpdates,
+		ClosedChans:    closedChans,
+	}
+}
+
+// ListPayments returns a list of outgoing payments. If no index offset or
+// maximum number of payments is specified, all payments are returned.
+func (r *rpcServer) ListPayments(ctx context.Context,
+	req *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+
+	rpcsLog.Debugf("[ListPayments] index_offset=%v, max_payments=%v, "+
+		"reversed=%v, creation_date_start=%v, creation_date_end=%v",
+		req.IndexOffset, req.MaxPayments, req.Reversed,
+		req.CreationDateStart, req.CreationDateEnd)
+
+	query := channeldb.PaymentsQuery{
+		IndexOffset: req.IndexOffset,
+		MaxPayments: req.MaxPayments,
+		Reversed:    req.Reversed,
+	}
+	if req.CreationDateStart != 0 {
+		query.CreationDateStart = time.Unix(req.CreationDateStart, 0)
+	}
+	if req.CreationDateEnd != 0 {
+		query.CreationDateEnd = time.Unix(req.CreationDateEnd, 0)
+	}
+	queryResp, err := r.server.chanDB.QueryPayments(query)
+	if err != nil {
+		return nil, err
+	}
+
+	payments := queryResp.Payments
+	paymentsResp := &lnrpc.ListPaymentsResponse{
+		Payments:         make([]*lnrpc.Payment, len(payments)),
+		FirstIndexOffset: queryResp.FirstIndexOffset,
+		LastIndexOffset:  queryResp.LastIndexOffset,
+	}
+	for i, payment := range payments {
+		path := make([]string, len(payment.Path))
+		for i, hop := range payment.Path {
+			path[i] = hex.EncodeToString(hop[:])
+		}
+
+		// Payments stored before their route was recorded lack it.
+		var route *lnrpc.Route
+		if len(payment.Route) > 0 {
+			route = marshallPaymentRoute(
+				payment.Route, payment.TimeLockLength,
+				payment.Terms.Value+payment.Fee, payment.Fee,
+			)
+		}
+
+		paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
+		htlcs := marshallPaymentAttempts(payment.Attempts)
+		paymentsResp.Payments[i] = &lnrpc.Payment{
+			PaymentHash:     hex.EncodeToString(paymentHash[:]),
+			Value:           int64(payment.Terms.Value.ToSatoshis()),
+			CreationDate:    payment.CreationDate.Unix(),
+			Path:            path,
+			Fee:             int64(payment.Fee.ToSatoshis()),
+			PaymentPreimage: hex.EncodeToString(payment.PaymentPreimage[:]),
+			PaymentIndex:    payment.SequenceNum,
+			Htlcs:           htlcs,
+			Route:           route,
+		}
+	}
+
+	return paymentsResp, nil
+}
+
+// marshallPaymentAttempts converts the stored HTLC attempts of a payment into
+// their RPC representation.
+func marshallPaymentAttempts(
+	attempts []channeldb.HTLCAttempt) []*lnrpc.HTLCAttempt {
+
+	rpcAttempts := make([]*lnrpc.HTLCAttempt, len(attempts))
+	for i := range attempts {
+		rpcAttempts[i] = marshallPaymentAttempt(&attempts[i])
+	}
+
+	return rpcAttempts
+}
+
+// marshallPaymentAttempt converts a stored HTLC attempt into its RPC
+// representation. The resolve time of an attempt which is still in flight is
+// reported as zero.
+func marshallPaymentAttempt(
+	attempt *channeldb.HTLCAttempt) *lnrpc.HTLCAttempt {
+
+	route := marshallPaymentRoute(
+		attempt.Hops, attempt.TotalTimeLock, attempt.TotalAmount,
+		attempt.TotalFees,
+	)
+
+	rpcAttempt := &lnrpc.HTLCAttempt{
+		Route:         route,
+		AttemptTimeNs: attempt.AttemptTime.UnixNano(),
+		Failure:       attempt.Failure,
+	}
+	if !attempt.ResolveTime.IsZero() {
+		rpcAttempt.ResolveTimeNs = attempt.ResolveTime.UnixNano()
+	}
+
+	return rpcAttempt
+}
+
+// marshallPaymentRoute converts the stored hops of a route a payment was sent
+// along into their RPC representation.
+func marshallPaymentRoute(hops []channeldb.AttemptHop, totalTimeLock uint32,
+	totalAmt, totalFees lnwire.MilliSatoshi) *lnrpc.Route {
+
+	route := &lnrpc.Route{
+		TotalTimeLock: totalTimeLock,
+		TotalFees:     int64(totalFees.ToSatoshis()),
+		TotalAmt:      int64(totalAmt.ToSatoshis()),
+		TotalFeesMsat: int64(totalFees),
+		TotalAmtMsat:  int64(totalAmt),
+		Hops:          make([]*lnrpc.Hop, len(hops)),
+	}
+	for i, hop := range hops {
+		route.Hops[i] = &lnrpc.Hop{
+			ChanId:           hop.ChannelID,
+			AmtToForward:     int64(hop.AmtToForward.ToSatoshis()),
+			Fee:              int64(hop.Fee.ToSatoshis()),
+			Expiry:           hop.OutgoingTimeLock,
+			AmtToForwardMsat: int64(hop.AmtToForward),
+			FeeMsat:          int64(hop.Fee),
+			PubKey:           hex.EncodeToString(hop.PubKey[:]),
+		}
+	}
+
+	return route
+}
+
+// DeleteAllPayments deletes all outgoing payments from DB.
+func (r *rpcServer) DeleteAllPayments(ctx context.Context,
+	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
+
+	rpcsLog.Debugf("[DeleteAllPayments]")
+
+	if err := r.server.chanDB.DeleteAllPayments(); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeleteAllPaymentsResponse{}, nil
+}
+
+// DeletePayments deletes the outgoing payments created before the requested
+// time, or only their failed HTLC attempts if failed_only is set.
+func (r *rpcServer) DeletePayments(ctx context.Context,
+	req *lnrpc.DeletePaymentsRequest) (*lnrpc.DeletePaymentsResponse,
+	error) {
+
+	rpcsLog.Debugf("[DeletePayments] failed_only=%v, before_time=%v",
+		req.FailedOnly, req.BeforeTime)
+
+	if req.BeforeTime < 0 {
+		return nil, fmt.Errorf("before_time must not be negative")
+	}
+
+	var beforeTime time.Time
+	if req.BeforeTime != 0 {
+		beforeTime = time.Unix(req.BeforeTime, 0)
+	}
+
+	numDeleted, err := r.server.chanDB.DeletePayments(
+		req.FailedOnly, beforeTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DeletePaymentsResponse{
+		NumDeleted: numDeleted,
+	}, nil
+}
+
+// DebugLevel allows a caller to programmatically set the logging verbosity of
+// lnd. The logging can be targeted according to a coarse daemon-wide logging
+// level, or in a granular fashion to specify the logging for a target
+// sub-system.
+func (r *rpcServer) DebugLevel(ctx context.Context,
+	req *lnrpc.DebugLevelRequest) (*lnrpc.DebugLevelResponse, error) {
+
+	// If show is set, then we simply print out the list of available
+	// sub-systems.
+	if req.Show {
+		return &lnrpc.DebugLevelResponse{
+			SubSystems: strings.Join(supportedSubsystems(), " "),
+		}, nil
+	}
+
+	rpcsLog.Infof("[debuglevel] changing debug level to: %v", req.LevelSpec)
+
+	// Otherwise, we'll attempt to set the logging level using the
+	// specified level spec.
+	if err := parseAndSetDebugLevels(req.LevelSpec); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.DebugLevelResponse{}, nil
+}
+
+// DecodePayReq takes an encoded payment request string and attempts to decode
+// it, returning a full description of the conditions encoded within the
+// payment request.
+func (r *rpcServer) DecodePayReq(ctx context.Context,
+	req *lnrpc.PayReqString) (*lnrpc.PayReq, error) {
+
+	rpcsLog.Tracef("[decodepayreq] decoding: %v", req.PayReq)
+
+	// Fist we'll attempt to decode the payment request string, if the
+	// request is invalid or the checksum doesn't match, then we'll exit
+	// here with an error.
+	payReq, err := zpay32.Decode(req.PayReq, activeNetParams.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Let the fields default to empty strings.
+	desc := ""
+	if payReq.Description != nil {
+		desc = *payReq.Description
+	}
+
+	descHash := []byte("")
+	if payReq.DescriptionHash != nil {
+		descHash = payReq.DescriptionHash[:]
+	}
+
+	fallbackAddr := ""
+	if payReq.FallbackAddr != nil {
+		fallbackAddr = payReq.FallbackAddr.String()
+	}
+
+	// Expiry time will default to 3600 seconds if not specified
+	// explicitly.
+	expiry := int64(payReq.Expiry().Seconds())
+
+	amt := int64(0)
+	if payReq.MilliSat != nil {
+		amt = int64(payReq.MilliSat.ToSatoshis())
+	}
+
+	dest := payReq.Destination.SerializeCompressed()
+	return &lnrpc.PayReq{
+		Destination:     hex.EncodeToString(dest),
+		PaymentHash:     hex.EncodeToString(payReq.PaymentHash[:]),
+		NumSatoshis:     amt,
+		Timestamp:       payReq.Timestamp.Unix(),
+		Description:     desc,
+		DescriptionHash: hex.EncodeToString(descHash[:]),
+		FallbackAddr:    fallbackAddr,
+		Expiry:          expiry,
+		CltvExpiry:      int64(payReq.MinFinalCLTVExpiry()),
+	}, nil
+}
+
+// feeBase is the fixed point that fee rate computation are performed over.
+// Nodes on the network advertise their fee rate using this point as a base.
+// This means that the minimal possible fee rate if 1e-6, or 0.000001, or
+// 0.0001%.
+const feeBase = 1000000
+
+// FeeReport allows the caller to obtain a report detailing the current fee
+// schedule enforced by the node globally for each channel.
+func (r *rpcServer) FeeReport(ctx context.Context,
+	_ *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
+
+	// TODO(roasbeef): use UnaryInterceptor to add automated logging
+
+	rpcsLog.Debugf("[feereport]")
+
+	channelGraph := r.server.chanDB.ChannelGraph()
+	selfNode, err := channelGraph.SourceNode()
+	if err != nil {
+		return nil, err
+	}
+
+	var feeReports []*lnrpc.ChannelFeeReport
+	err = selfNode.ForEachChannel(nil, func(_ channeldb.ReadTx, chanInfo *channeldb.ChannelEdgeInfo,
+		edgePolicy, _ *channeldb.ChannelEdgePolicy) error {
+
+		// We'll compute the effective fee rate by converting from a
+		// fixed point fee rate to a floating point fee rate. The fee
+		// rate field in the database the amount of mSAT charged per
+		// 1mil mSAT sent, so will divide by this to get the proper fee
+		// rate.
+		feeRateFixedPoint := edgePolicy.FeeProportionalMillionths
+		feeRate := float64(feeRateFixedPoint) / float64(feeBase)
+
+		// TODO(roasbeef): also add stats for revenue for each channel
+		feeReports = append(feeReports, &lnrpc.ChannelFeeReport{
+			ChanPoint:   chanInfo.ChannelPoint.String(),
+			BaseFeeMsat: int64(edgePolicy.FeeBaseMSat),
+			FeePerMil:   int64(feeRateFixedPoint),
+			FeeRate:     feeRate,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fwdEventLog := r.server.chanDB.ForwardingLog()
+
+	// computeFeeSum is a helper function that computes the total fees for
+	// a particular time slice described by a forwarding event query.
+	computeFeeSum := func(query channeldb.ForwardingEventQuery) (lnwire.MilliSatoshi, error) {
+
+		var totalFees lnwire.MilliSatoshi
+
+		// We'll continue to fetch the next query and accumulate the
+		// fees until the next query returns no events.
+		for {
+			timeSlice, err := fwdEventLog.Query(query)
+			if err != nil {
+				return 0, nil
 			}
-			nodeAddrs = append(nodeAddrs, nodeAddr)
+
+			// If the timeslice is empty, then we'll return as
+			// we've retrieved all the entries in this range.
+			if len(timeSlice.ForwardingEvents) == 0 {
+				break
+			}
+
+			// Otherwise, we'll tally up an accumulate the total
+			// fees for this time slice.
+			for _, event := range timeSlice.ForwardingEvents {
+				fee := event.AmtIn - event.AmtOut
+				totalFees += fee
+			}
+
+			// We'll now take the last offset index returned as
+			// part of this response, and modify our query to start
+			// at this index. This has a pagination effect in the
+			// case that our query bounds has more than 100k
+			// entries.
+			query.IndexOffset = timeSlice.LastIndexOffset
 		}
 
-		nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
-		resp.Nodes = append(resp.Nodes, &lnrpc.LightningNode{
-			LastUpdate: uint32(node.LastUpdate.Unix()),
-			PubKey:     hex.EncodeToString(node.PubKeyBytes[:]),
-			Addresses:  nodeAddrs,
-			Alias:      node.Alias,
-			Color:      nodeColor,
+		return totalFees, nil
+	}
+
+	now := time.Now()
+
+	// Before we perform the queries below, we'll instruct the switch to
+	// flush any pending events to disk. This ensure we get a complete
+	// snapshot at this particular time.
+	if r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
+		return nil, fmt.Errorf("unable to flush forwarding "+
+			"events: %v", err)
+	}
+
+	// In addition to returning the current fee schedule for each channel.
+	// We'll also perform a series of queries to obtain the total fees
+	// earned over the past day, week, and month.
+	dayQuery := channeldb.ForwardingEventQuery{
+		StartTime:    now.Add(-time.Hour * 24),
+		EndTime:      now,
+		NumMaxEvents: 1000,
+	}
+	dayFees, err := computeFeeSum(dayQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+	}
+
+	weekQuery := channeldb.ForwardingEventQuery{
+		StartTime:    now.Add(-time.Hour * 24 * 7),
+		EndTime:      now,
+		NumMaxEvents: 1000,
+	}
+	weekFees, err := computeFeeSum(weekQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+	}
+
+	monthQuery := channeldb.ForwardingEventQuery{
+		StartTime:    now.Add(-time.Hour * 24 * 30),
+		EndTime:      now,
+		NumMaxEvents: 1000,
+	}
+	monthFees, err := computeFeeSum(monthQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+	}
+
+	return &lnrpc.FeeReportResponse{
+		ChannelFees: feeReports,
+		DayFeeSum:   uint64(dayFees.ToSatoshis()),
+		WeekFeeSum:  uint64(weekFees.ToSatoshis()),
+		MonthFeeSum: uint64(monthFees.ToSatoshis()),
+	}, nil
+}
+
+// minFeeRate is the smallest permitted fee rate within the network. This is
+// derived by the fact that fee rates are computed using a fixed point of
+// 1,000,000. As a result, the smallest representable fee rate is 1e-6, or
+// 0.000001, or 0.0001%.
+const minFeeRate = 1e-6
+
+// UpdateChannelPolicy allows the caller to update the channel forwarding policy
+// for all channels globally, or a particular channel.
+func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
+	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
+
+	var targetChans []wire.OutPoint
+	switch scope := req.Scope.(type) {
+	// If the request is targeting all active channels, then we don't need
+	// target any channels by their channel point.
+	case *lnrpc.PolicyUpdateRequest_Global:
+
+	// Otherwise, we're targeting an individual channel by its channel
+	// point.
+	case *lnrpc.PolicyUpdateRequest_ChanPoint:
+		txidHash, err := getChanPointFundingTxid(scope.ChanPoint)
+		if err != nil {
+			return nil, err
+		}
+		txid, err := chainhash.NewHash(txidHash)
+		if err != nil {
+			return nil, err
+		}
+		targetChans = append(targetChans, wire.OutPoint{
+			Hash:  *txid,
+			Index: scope.ChanPoint.OutputIndex,
 		})
+	default:
+		return nil, fmt.Errorf("unknown scope: %v", scope)
+	}
 
-		return nil
-	})
+	// As a sanity check, we'll ensure that the passed fee rate is below
+	// 1e-6, or the lowest allowed fee rate, and that the passed timelock
+	// is large enough.
+	if req.FeeRate < minFeeRate {
+		return nil, fmt.Errorf("fee rate of %v is too small, min fee "+
+			"rate is %v", req.FeeRate, minFeeRate)
+	}
+
+	if req.TimeLockDelta < minTimeLockDelta {
+		return nil, fmt.Errorf("time lock delta of %v is too small, "+
+			"minimum supported is %v", req.TimeLockDelta,
+			minTimeLockDelta)
+	}
+
+	// We'll also need to convert the floating point fee rate we accept
+	// over RPC to the fixed point rate that we use within the protocol. We
+	// do this by multiplying the passed fee rate by the fee base. This
+	// gives us the fixed point, scaled by 1 million that's used within the
+	// protocol.
+	feeRateFixed := uint32(req.FeeRate * feeBase)
+	baseFeeMsat := lnwire.MilliSatoshi(req.BaseFeeMsat)
+	feeSchema := routing.FeeSchema{
+		BaseFee: baseFeeMsat,
+		FeeRate: feeRateFixed,
+	}
+
+	maxHTLC := lnwire.MilliSatoshi(req.MaxHtlcMsat)
+	chanPolicy := routing.ChannelPolicy{
+		FeeSchema:     feeSchema,
+		TimeLockDelta: req.TimeLockDelta,
+		MaxHTLC:       maxHTLC,
+	}
+
+	rpcsLog.Debugf("[updatechanpolicy] updating channel policy base_fee=%v, "+
+		"rate_float=%v, rate_fixed=%v, time_lock_delta: %v, "+
+		"max_htlc=%v, targets=%v", req.BaseFeeMsat, req.FeeRate,
+		feeRateFixed, req.TimeLockDelta, maxHTLC,
+		spew.Sdump(targetChans))
+
+	// With the scope resolved, we'll now send this to the
+	// AuthenticatedGossiper so it can propagate the new policy for our
+	// target channel(s).
+	err := r.server.authGossiper.PropagateChanPolicyUpdate(
+		chanPolicy, targetChans...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally, we'll apply the set of active links amongst the target
+	// channels.
+	//
+	// We create a partially policy as the logic won't overwrite a valid
+	// sub-policy with a "nil" one.
+	p := htlcswitch.ForwardingPolicy{
+		BaseFee:       baseFeeMsat,
+		FeeRate:       lnwire.MilliSatoshi(feeRateFixed),
+		TimeLockDelta: req.TimeLockDelta,
+		MaxHTLC:       maxHTLC,
+	}
+	err = r.server.htlcSwitch.UpdateForwardingPolicies(p, targetChans...)
+	if err != nil {
+		// If we're unable update the fees due to the links not being
+		// online, then we don't need to fail the call. We'll simply
+		// log the failure.
+		rpcsLog.Warnf("Unable to update link fees: %v", err)
+	}
+
+	return &lnrpc.PolicyUpdateResponse{}, nil
+}
+
+// LabelChannel attaches the requested label to an open or pending channel,
+// replacing any label attached to it before. An empty label removes the
+// channel's label.
+func (r *rpcServer) LabelChannel(ctx context.Context,
+	req *lnrpc.LabelChannelRequest) (*lnrpc.LabelChannelResponse, error) {
+
+	if req.ChannelPoint == nil {
+		return nil, fmt.Errorf("channel point must be set")
+	}
+	txidHash, err := getChanPointFundingTxid(req.ChannelPoint)
+	if err != nil {
+		return nil, err
+	}
+	txid, err := chainhash.NewHash(txidHash)
+	if err != nil {
+		return nil, err
+	}
+	chanPoint := wire.OutPoint{
+		Hash:  *txid,
+		Index: req.ChannelPoint.OutputIndex,
+	}
+
+	rpcsLog.Debugf("[labelchannel] chan_point=%v, label=%q", chanPoint,
+		req.Label)
+
+	// We'll only attach labels to channels which are still listed, which
+	// are those that are open, pending open, or pending close.
+	found := false
+	dbChannels, err := r.server.chanDB.FetchAllChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, dbChannel := range dbChannels {
+		if dbChannel.FundingOutpoint == chanPoint {
+			found = true
+			break
+		}
+	}
+	if !found {
+		pendingCloseChannels, err := r.server.chanDB.FetchClosedChannels(
+			true,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, pendingClose := range pendingCloseChannels {
+			if pendingClose.ChanPoint == chanPoint {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unable to find channel %v", chanPoint)
+	}
+
+	err = r.server.chanDB.SetChannelLabel(&chanPoint, req.Label)
 	if err != nil {
 		return nil, err
 	}
 
-	// Next, for each active channel we know of within the graph, create a
-	// similar response which details both the edge information as well as
-	// the routing policies of th nodes connecting the two edges.
-	err = graph.ForEachChannel(func(edgeInfo *channeldb.ChannelEdgeInfo,
-		c1, c2 *channeldb.ChannelEdgePolicy) error {
+	return &lnrpc.LabelChannelResponse{}, nil
+}
+
+// ForwardingHistory allows the caller to query the htlcswitch for a record of
+// all HTLC's forwarded within the target time range, and integer offset within
+// that time range. If no time-range is specified, then the first chunk of the
+// past 24 hrs of forwarding history are returned.
+
+// A list of forwarding events are returned. The size of each forwarding event
+// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
+// In order to safely stay under this max limit, we'll return 50k events per
+// response.  Each response has the index offset of the last entry. The index
+// offset can be provided to the request to allow the caller to skip a series
+// of records.
+func (r *rpcServer) ForwardingHistory(ctx context.Context,
+	req *lnrpc.ForwardingHistoryRequest) (*lnrpc.ForwardingHistoryResponse, error) {
+
+	rpcsLog.Debugf("[forwardinghistory]")
 
-		edge := marshalDbEdge(edgeInfo, c1, c2)
-		resp.Edges = append(resp.Edges, edge)
-		return nil
-	})
-	if err != nil && err != channeldb.ErrGraphNoEdgesFound {
-		return nil, err
+	// Before we perform the queries below, we'll instruct the switch to
+	// flush any pending events to disk. This ensure we get a complete
+	// snapshot at this particular time.
+	if err := r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
+		return nil, fmt.Errorf("unable to flush forwarding "+
+			"events: %v", err)
 	}
 
-	return resp, nil
-}
-
-func marshalDbEdge(edgeInfo *channeldb.ChannelEdgeInfo,
-	c1, c2 *channeldb.ChannelEdgePolicy) *lnrpc.ChannelEdge {
-
 	var (
-		lastUpdate int64
+		startTime, endTime time.Time
+
+		numEvents uint32
 	)
 
-	if c2 != nil {
-		lastUpdate = c2.LastUpdate.Unix()
+	// If the start and end time were not set, then we'll just return the
+	// records over the past 24 hours. If only the end time wasn't set,
+	// then we'll return the records up until now.
+	now := time.Now()
+	switch {
+	case req.StartTime == 0 && req.EndTime == 0:
+		startTime = now.Add(-time.Hour * 24)
+		endTime = now
+
+	case req.EndTime == 0:
+		startTime = time.Unix(int64(req.StartTime), 0)
+		endTime = now
+
+	default:
+		startTime = time.Unix(int64(req.StartTime), 0)
+		endTime = time.Unix(int64(req.EndTime), 0)
 	}
-	if c1 != nil {
-		lastUpdate = c1.LastUpdate.Unix()
+
+	// If the number of events wasn't specified, then we'll default to
+	// returning the last 100 events.
+	numEvents = req.NumMaxEvents
+	if numEvents == 0 {
+		numEvents = 100
 	}
 
-	edge := &lnrpc.ChannelEdge{
-		ChannelId: edgeInfo.ChannelID,
-		ChanPoint: edgeInfo.ChannelPoint.String(),
-		// TODO(roasbeef): update should be on edge info itself
-		LastUpdate: uint32(lastUpdate),
-		Node1Pub:   hex.EncodeToString(edgeInfo.NodeKey1Bytes[:]),
-		Node2Pub:   hex.EncodeToString(edgeInfo.NodeKey2Bytes[:]),
-		Capacity:   int64(edgeInfo.Capacity),
+	// Next, we'll map the proto request into a format the is understood by
+	// the forwarding log.
+	eventQuery := channeldb.ForwardingEventQuery{
+		StartTime:    startTime,
+		EndTime:      endTime,
+		IndexOffset:  req.IndexOffset,
+		NumMaxEvents: numEvents,
+	}
+	timeSlice, err := r.server.chanDB.ForwardingLog().Query(eventQuery)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query forwarding log: %v", err)
 	}
 
-	if c1 != nil {
-		edge.Node1Policy = &lnrpc.RoutingPolicy{
-			TimeLockDelta:    uint32(c1.TimeLockDelta),
-			MinHtlc:          int64(c1.MinHTLC),
-			FeeBaseMsat:      int64(c1.FeeBaseMSat),
-			FeeRateMilliMsat: int64(c1.FeeProportionalMillionths),
-		}
+	// TODO(roasbeef): add settlement latency?
+	//  * use FPE on all records?
+
+	// With the events retrieved, we'll now map them into the proper proto
+	// response.
+	//
+	// TODO(roasbeef): show in ns for the outside?
+	resp := &lnrpc.ForwardingHistoryResponse{
+		ForwardingEvents: make([]*lnrpc.ForwardingEvent, len(timeSlice.ForwardingEvents)),
+		LastOffsetIndex:  timeSlice.LastIndexOffset,
 	}
+	for i, event := range timeSlice.ForwardingEvents {
+		amtInSat := event.AmtIn.ToSatoshis()
+		amtOutSat := event.AmtOut.ToSatoshis()
 
-	if c2 != nil {
-		edge.Node2Policy = &lnrpc.RoutingPolicy{
-			TimeLockDelta:    uint32(c2.TimeLockDelta),
-			MinHtlc:          int64(c2.MinHTLC),
-			FeeBaseMsat:      int64(c2.FeeBaseMSat),
-			FeeRateMilliMsat: int64(c2.FeeProportionalMillionths),
+		// The fee is computed in milli-satoshis, as rounding both
+		// amounts down to satoshis first could misstate it by up to a
+		// satoshi.
+		feeMsat := event.AmtIn - event.AmtOut
+
+		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
+			Timestamp:  uint64(event.Timestamp.Unix()),
+			ChanIdIn:   event.IncomingChanID.ToUint64(),
+			ChanIdOut:  event.OutgoingChanID.ToUint64(),
+			AmtIn:      uint64(amtInSat),
+			AmtOut:     uint64(amtOutSat),
+			Fee:        uint64(feeMsat.ToSatoshis()),
+			FeeMsat:    uint64(feeMsat),
+			AmtInMsat:  uint64(event.AmtIn),
+			AmtOutMsat: uint64(event.AmtOut),
 		}
 	}
 
-	return edge
+	return resp, nil
 }
 
-// GetChanInfo returns the latest authenticated network announcement for the
-// given channel identified by its channel ID: an 8-byte integer which uniquely
-// identifies the location of transaction's funding output within the block
-// chain.
-func (r *rpcServer) GetChanInfo(ctx context.Context,
-	in *lnrpc.ChanInfoRequest) (*lnrpc.ChannelEdge, error) {
+// parsePolicyPaymentHash extracts the payment hash from the passed request,
+// preferring the hex-encoded string if it's set.
+func parsePolicyPaymentHash(req *lnrpc.PolicyPaymentHash) ([32]byte, error) {
+	return parsePaymentHash(req.PaymentHashStr, req.PaymentHash)
+}
 
-	graph := r.server.chanDB.ChannelGraph()
+// parsePaymentHash parses a payment hash specified either as a hex-encoded
+// string, or as raw bytes, preferring the string if it's set.
+func parsePaymentHash(hashStr string, hash []byte) ([32]byte, error) {
+	var (
+		payHash [32]byte
+		rHash   []byte
+		err     error
+	)
 
-	edgeInfo, edge1, edge2, err := graph.FetchChannelEdgesByID(in.ChanId)
-	if err != nil {
-		return nil, err
+	if hashStr != "" {
+		rHash, err = hex.DecodeString(hashStr)
+		if err != nil {
+			return payHash, err
+		}
+	} else {
+		rHash = hash
 	}
 
-	// Convert the database's edge format into the network/RPC edge format
-	// which couples the edge itself along with the directional node
-	// routing policies of each node involved within the channel.
-	channelEdge := marshalDbEdge(edgeInfo, edge1, edge2)
+	// Ensure that the payment hash is *exactly* 32-bytes.
+	if len(rHash) != 32 {
+		return payHash, fmt.Errorf("payment hash must be exactly "+
+			"32 bytes, is instead %v", len(rHash))
+	}
+	copy(payHash[:], rHash)
 
-	return channelEdge, nil
+	return payHash, nil
 }
 
-// GetNodeInfo returns the latest advertised and aggregate authenticated
-// channel information for the specified node identified by its public key.
-func (r *rpcServer) GetNodeInfo(ctx context.Context,
-	in *lnrpc.NodeInfoRequest) (*lnrpc.NodeInfo, error) {
-
-	graph := r.server.chanDB.ChannelGraph()
+// createRPCPolicy converts a policy stored within the database into its RPC
+// counterpart.
+func createRPCPolicy(policy *channeldb.Policy) *lnrpc.PaymentPolicy {
+	var expiryTime int64
+	if !policy.ExpiryTime.IsZero() {
+		expiryTime = policy.ExpiryTime.Unix()
+	}
+
+	paymentHash := hex.EncodeToString(policy.PaymentHash[:])
+
+	return &lnrpc.PaymentPolicy{
+		PaymentHash:             paymentHash,
+		FeeMsat:                 int64(policy.Fee),
+		BaseFeeMsat:             int64(policy.BaseFee),
+		FeeRatePpm:              int64(policy.FeeRate),
+		ExpiryHeight:            policy.ExpiryHeight,
+		ExpiryTime:              expiryTime,
+		BudgetMsat:              int64(policy.Budget),
+		SpentToDateMsat:         int64(policy.SpentToDate),
+		MinAmtMsat:              int64(policy.MinAmt),
+		MaxAmtMsat:              int64(policy.MaxAmt),
+		MaxCltvDelta:            policy.MaxCLTVDelta,
+		Label:                   policy.Label,
+		Deny:                    policy.Deny,
+		OutgoingChanId:          policy.OutgoingChanID,
+		FeeRejections:           policy.FeeRejections,
+		CheapestRejectedFeeMsat: int64(policy.CheapestRejectedFee),
+	}
+}
 
-	// First, parse the hex-encoded public key into a full in-memory public
-	// key object we can work with for querying.
-	pubKeyBytes, err := hex.DecodeString(in.PubKey)
-	if err != nil {
-		return nil, err
+// unmarshallPolicy validates the limits of an RPC fee policy and converts it
+// into its database counterpart. The payment hash of the policy is left blank.
+func unmarshallPolicy(req *lnrpc.PaymentPolicy) (*channeldb.Policy, error) {
+	if req.FeeMsat < 0 || req.BaseFeeMsat < 0 || req.FeeRatePpm < 0 {
+		return nil, fmt.Errorf("policy fees must be non-negative")
 	}
-	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
-	if err != nil {
-		return nil, err
+	if req.BudgetMsat < 0 {
+		return nil, fmt.Errorf("policy budget must be non-negative")
 	}
-
-	// With the public key decoded, attempt to fetch the node corresponding
-	// to this public key. If the node cannot be found, then an error will
-	// be returned.
-	node, err := graph.FetchLightningNode(pubKey)
-	if err != nil {
-		return nil, err
+	if req.MinAmtMsat < 0 || req.MaxAmtMsat < 0 {
+		return nil, fmt.Errorf("policy amounts must be non-negative")
 	}
-
-	// With the node obtained, we'll now iterate through all its out going
-	// edges to gather some basic statistics about its out going channels.
-	var (
-		numChannels   uint32
-		totalCapacity btcutil.Amount
-	)
-	if err := node.ForEachChannel(nil, func(_ *bolt.Tx, edge *channeldb.ChannelEdgeInfo,
-		_, _ *channeldb.ChannelEdgePolicy) error {
-
-		numChannels++
-		totalCapacity += edge.Capacity
-		return nil
-	}); err != nil {
-		return nil, err
+	if len(req.Label) > channeldb.MaxPolicyLabelLen {
+		return nil, channeldb.ErrPolicyLabelTooLong
 	}
 
-	nodeAddrs := make([]*lnrpc.NodeAddress, 0)
-	for _, addr := range node.Addresses {
-		nodeAddr := &lnrpc.NodeAddress{
-			Network: addr.Network(),
-			Addr:    addr.String(),
-		}
-		nodeAddrs = append(nodeAddrs, nodeAddr)
+	policy := &channeldb.Policy{
+		Fee:            lnwire.MilliSatoshi(req.FeeMsat),
+		BaseFee:        lnwire.MilliSatoshi(req.BaseFeeMsat),
+		FeeRate:        lnwire.MilliSatoshi(req.FeeRatePpm),
+		ExpiryHeight:   req.ExpiryHeight,
+		Budget:         lnwire.MilliSatoshi(req.BudgetMsat),
+		MinAmt:         lnwire.MilliSatoshi(req.MinAmtMsat),
+		MaxAmt:         lnwire.MilliSatoshi(req.MaxAmtMsat),
+		MaxCLTVDelta:   req.MaxCltvDelta,
+		Label:          req.Label,
+		Deny:           req.Deny,
+		OutgoingChanID: req.OutgoingChanId,
+	}
+	if req.ExpiryTime != 0 {
+		policy.ExpiryTime = time.Unix(req.ExpiryTime, 0)
 	}
-	// TODO(roasbeef): list channels as well?
 
-	nodeColor := fmt.Sprintf("#%02x%02x%02x", node.Color.R, node.Color.G, node.Color.B)
-	return &lnrpc.NodeInfo{
-		Node: &lnrpc.LightningNode{
-			LastUpdate: uint32(node.LastUpdate.Unix()),
-			PubKey:     in.PubKey,
-			Addresses:  nodeAddrs,
-			Alias:      node.Alias,
-			Color:      nodeColor,
-		},
-		NumChannels:   numChannels,
-		TotalCapacity: int64(totalCapacity),
-	}, nil
+	return policy, nil
 }
 
-// QueryRoutes attempts to query the daemons' Channel Router for a possible
-// route to a target destination capable of carrying a specific amount of
-// satoshis within the route's flow. The retuned route contains the full
-// details required to craft and send an HTLC, also including the necessary
-// information that should be present within the Sphinx packet encapsulated
-// within the HTLC.
-//
-// TODO(roasbeef): should return a slice of routes in reality
-//  * create separate PR to send based on well formatted route
-func (r *rpcServer) QueryRoutes(ctx context.Context,
-	in *lnrpc.QueryRoutesRequest) (*lnrpc.QueryRoutesResponse, error) {
+// AddPolicy adds a fee policy which bounds the total fee that may be paid to
+// route a payment to the target payment hash. Any existing policy for the same
+// payment hash and amount band is overwritten.
+func (r *rpcServer) AddPolicy(ctx context.Context,
+	req *lnrpc.PaymentPolicy) (*lnrpc.AddPolicyResponse, error) {
 
-	// First parse the hex-encoded public key into a full public key object
-	// we can properly manipulate.
-	pubKeyBytes, err := hex.DecodeString(in.PubKey)
+	payHash, err := parsePolicyPaymentHash(&lnrpc.PolicyPaymentHash{
+		PaymentHashStr: req.PaymentHash,
+	})
 	if err != nil {
 		return nil, err
 	}
-	pubKey, err := btcec.ParsePubKey(pubKeyBytes, btcec.S256())
+
+	policy, err := unmarshallPolicy(req)
 	if err != nil {
 		return nil, err
 	}
+	policy.PaymentHash = payHash
 
-	// Currently, within the bootstrap phase of the network, we limit the
-	// largest payment size allotted to (2^32) - 1 mSAT or 4.29 million
-	// satoshis.
-	amt := btcutil.Amount(in.Amt)
-	amtMSat := lnwire.NewMSatFromSatoshis(amt)
-	if amtMSat > maxPaymentMSat {
-		return nil, fmt.Errorf("payment of %v is too large, max payment "+
-			"allowed is %v", amt, maxPaymentMSat.ToSatoshis())
-	}
+	rpcsLog.Debugf("[addpolicy] adding policy %v",
+		newLogClosure(func() string {
+			return spew.Sdump(policy)
+		}))
 
-	// Query the channel router for a possible path to the destination that
-	// can carry `in.Amt` satoshis _including_ the total fee required on
-	// the route.
-	routes, err := r.server.chanRouter.FindRoutes(
-		pubKey, amtMSat, uint32(in.NumRoutes),
-	)
-	if err != nil {
+	if err := r.server.policyStore.AddPolicy(policy); err != nil {
 		return nil, err
 	}
 
-	// As the number of returned routes can be less than the number of
-	// requested routes, we'll clamp down the length of the response to the
-	// minimum of the two.
-	numRoutes := int32(len(routes))
-	if in.NumRoutes < numRoutes {
-		numRoutes = in.NumRoutes
-	}
+	return &lnrpc.AddPolicyResponse{}, nil
+}
 
-	// For each valid route, we'll convert the result into the format
-	// required by the RPC system.
-	routeResp := &lnrpc.QueryRoutesResponse{
-		Routes: make([]*lnrpc.Route, 0, in.NumRoutes),
-	}
-	for i := int32(0); i < numRoutes; i++ {
-		routeResp.Routes = append(
-			routeResp.Routes, marshallRoute(routes[i]),
-		)
+// ListPolicies returns a list of all the fee policies currently stored within
+// the database.
+func (r *rpcServer) ListPolicies(ctx context.Context,
+	_ *lnrpc.ListPoliciesRequest) (*lnrpc.ListPoliciesResponse, error) {
+
+	rpcsLog.Debugf("[ListPolicies]")
+
+	resp := &lnrpc.ListPoliciesResponse{}
+	err := r.server.policyStore.ForEachPolicies(
+		func(policy *channeldb.Policy) error {
+			resp.Policies = append(
+				resp.Policies, createRPCPolicy(policy),
+			)
+			return nil
+		},
+	)
+	if err != nil && err != channeldb.ErrNoPoliciesCreated {
+		return nil, err
 	}
 
-	return routeResp, nil
+	return resp, nil
 }
 
-func marshallRoute(route *routing.Route) *lnrpc.Route {
-	resp := &lnrpc.Route{
-		TotalTimeLock: route.TotalTimeLock,
-		TotalFees:     int64(route.TotalFees.ToSatoshis()),
-		TotalAmt:      int64(route.TotalAmount.ToSatoshis()),
-		Hops:          make([]*lnrpc.Hop, len(route.Hops)),
+// LookupPolicy attempts to look up the fee policy without an amount band for a
+// payment hash.
+func (r *rpcServer) LookupPolicy(ctx context.Context,
+	req *lnrpc.PolicyPaymentHash) (*lnrpc.PaymentPolicy, error) {
+
+	payHash, err := parsePolicyPaymentHash(req)
+	if err != nil {
+		return nil, err
 	}
-	for i, hop := range route.Hops {
-		resp.Hops[i] = &lnrpc.Hop{
-			ChanId:       hop.Channel.ChannelID,
-			ChanCapacity: int64(hop.Channel.Capacity),
-			AmtToForward: int64(hop.AmtToForward.ToSatoshis()),
-			Fee:          int64(hop.Fee.ToSatoshis()),
-			Expiry:       uint32(hop.OutgoingTimeLock),
-		}
+
+	rpcsLog.Tracef("[lookuppolicy] searching for policy %x", payHash[:])
+
+	policy, err := r.server.policyStore.LookupPolicy(payHash)
+	if err != nil {
+		return nil, err
 	}
 
-	return resp
+	return createRPCPolicy(policy), nil
 }
 
-// GetNetworkInfo returns some basic stats about the known channel graph from
-// the PoV of the node.
-func (r *rpcServer) GetNetworkInfo(ctx context.Context,
-	_ *lnrpc.NetworkInfoRequest) (*lnrpc.NetworkInfo, error) {
-
-	graph := r.server.chanDB.ChannelGraph()
+// DeletePolicy removes all fee policies for a payment hash from the database,
+// including those limited to an amount band.
+func (r *rpcServer) DeletePolicy(ctx context.Context,
+	req *lnrpc.PolicyPaymentHash) (*lnrpc.DeletePolicyResponse, error) {
 
-	var (
-		numNodes             uint32
-		numChannels          uint32
-		maxChanOut           uint32
-		totalNetworkCapacity btcutil.Amount
-		minChannelSize       btcutil.Amount = math.MaxInt64
-		maxChannelSize       btcutil.Amount
-	)
+	payHash, err := parsePolicyPaymentHash(req)
+	if err != nil {
+		return nil, err
+	}
 
-	// We'll use this map to de-duplicate channels during our traversal.
-	// This is needed since channels are directional, so there will be two
-	// edges for each channel within the graph.
-	seenChans := make(map[uint64]struct{})
+	rpcsLog.Debugf("[deletepolicy] deleting policy %x", payHash[:])
 
-	// We'll run through all the known nodes in the within our view of the
-	// network, tallying up the total number of nodes, and also gathering
-	// each node so we can measure the graph diameter and degree stats
-	// below.
-	if err := graph.ForEachNode(nil, func(tx *bolt.Tx, node *channeldb.LightningNode) error {
-		// Increment the total number of nodes with each iteration.
-		numNodes++
+	if err := r.server.policyStore.DeletePolicy(payHash); err != nil {
+		return nil, err
+	}
 
-		// For each channel we'll compute the out degree of each node,
-		// and also update our running tallies of the min/max channel
-		// capacity, as well as the total channel capacity. We pass
-		// through the db transaction from the outer view so we can
-		// re-use it within this inner view.
-		var outDegree uint32
-		if err := node.ForEachChannel(tx, func(_ *bolt.Tx,
-			edge *channeldb.ChannelEdgeInfo, _, _ *channeldb.ChannelEdgePolicy) error {
+	return &lnrpc.DeletePolicyResponse{}, nil
+}
 
-			// Bump up the out degree for this node for each
-			// channel encountered.
-			outDegree++
+// SetDefaultPolicy sets the default fee policy, which governs all payments for
+// which neither a payment hash nor a node policy matches. Any existing default
+// policy is overwritten.
+func (r *rpcServer) SetDefaultPolicy(ctx context.Context,
+	req *lnrpc.PaymentPolicy) (*lnrpc.SetDefaultPolicyResponse, error) {
 
-			// If we've already seen this channel, then we'll
-			// return early to ensure that we don't double-count
-			// stats.
-			if _, ok := seenChans[edge.ChannelID]; ok {
-				return nil
-			}
+	policy, err := unmarshallPolicy(req)
+	if err != nil {
+		return nil, err
+	}
 
-			// Compare the capacity of this channel against the
-			// running min/max to see if we should update the
-			// extrema.
-			chanCapacity := edge.Capacity
-			if chanCapacity < minChannelSize {
-				minChannelSize = chanCapacity
-			}
-			if chanCapacity > maxChannelSize {
-				maxChannelSize = chanCapacity
-			}
+	rpcsLog.Debugf("[setdefaultpolicy] setting default policy %v",
+		newLogClosure(func() string {
+			return spew.Sdump(policy)
+		}))
 
-			// Accumulate the total capacity of this channel to the
-			// network wide-capacity.
-			totalNetworkCapacity += chanCapacity
+	if err := r.server.chanDB.SetDefaultPolicy(policy); err != nil {
+		return nil, err
+	}
 
-			numChannels++
+	return &lnrpc.SetDefaultPolicyResponse{}, nil
+}
 
-			seenChans[edge.ChannelID] = struct{}{}
-			return nil
-		}); err != nil {
-			return err
-		}
+// DefaultPolicy returns the default fee policy.
+func (r *rpcServer) DefaultPolicy(ctx context.Context,
+	_ *lnrpc.DefaultPolicyRequest) (*lnrpc.PaymentPolicy, error) {
 
-		// Finally, if the out degree of this node is greater than what
-		// we've seen so far, update the maxChanOut variable.
-		if outDegree > maxChanOut {
-			maxChanOut = outDegree
-		}
+	rpcsLog.Debugf("[defaultpolicy]")
 
-		return nil
-	}); err != nil {
+	policy, err := r.server.chanDB.FetchDefaultPolicy()
+	if err != nil {
 		return nil, err
 	}
 
-	// If we don't have any channels, then reset the minChannelSize to zero
-	// to avoid outputting NaN in encoded JSON.
-	if numChannels == 0 {
-		minChannelSize = 0
-	}
+	// The default policy doesn't govern any particular payment hash.
+	rpcPolicy := createRPCPolicy(policy)
+	rpcPolicy.PaymentHash = ""
 
-	// TODO(roasbeef): graph diameter
+	return rpcPolicy, nil
+}
 
-	// TODO(roasbeef): also add oldest channel?
-	//  * also add median channel size
-	netInfo := &lnrpc.NetworkInfo{
-		MaxOutDegree:         maxChanOut,
-		AvgOutDegree:         float64(numChannels) / float64(numNodes),
-		NumNodes:             numNodes,
-		NumChannels:          numChannels,
-		TotalNetworkCapacity: int64(totalNetworkCapacity),
-		AvgChannelSize:       float64(totalNetworkCapacity) / float64(numChannels),
+// DeleteDefaultPolicy removes the default fee policy from the database.
+func (r *rpcServer) DeleteDefaultPolicy(ctx context.Context,
+	_ *lnrpc.DeleteDefaultPolicyRequest) (
+	*lnrpc.DeleteDefaultPolicyResponse, error) {
 
-		MinChannelSize: int64(minChannelSize),
-		MaxChannelSize: int64(maxChannelSize),
-	}
+	rpcsLog.Debugf("[deletedefaultpolicy]")
 
-	// Similarly, if we don't have any channels, then we'll also set the
-	// average channel size to zero in order to avoid weird JSON encoding
-	// outputs.
-	if numChannels == 0 {
-		netInfo.AvgChannelSize = 0
+	if err := r.server.chanDB.DeleteDefaultPolicy(); err != nil {
+		return nil, err
 	}
 
-	return netInfo, nil
+	return &lnrpc.DeleteDefaultPolicyResponse{}, nil
 }
 
-// StopDaemon will send a shutdown request to the interrupt handler, triggering
-// a graceful shutdown of the daemon.
-func (r *rpcServer) StopDaemon(ctx context.Context,
-	_ *lnrpc.StopRequest) (*lnrpc.StopResponse, error) {
+// ExportPolicies returns a backup of all payment hash and node fee policies
+// stored within the database, encoded in the JSON lines format.
+func (r *rpcServer) ExportPolicies(ctx context.Context,
+	_ *lnrpc.ExportPoliciesRequest) (*lnrpc.PolicyBackup, error) {
 
-	shutdownRequestChannel <- struct{}{}
-	return &lnrpc.StopResponse{}, nil
+	rpcsLog.Debugf("[exportpolicies]")
+
+	var b bytes.Buffer
+	if err := r.server.chanDB.ExportPolicies(&b); err != nil {
+		return nil, err
+	}
+
+	return &lnrpc.PolicyBackup{
+		Policies: b.Bytes(),
+	}, nil
 }
 
-// SubscribeChannelGraph launches a streaming RPC that allows the caller to
-// receive notifications upon any changes the channel graph topology from the
-// review of the responding node. Events notified include: new nodes coming
-// online, nodes updating their authenticated attributes, new channels being
-// advertised, updates in the routing policy for a directional channel edge,
-// and finally when prior channels are closed on-chain.
-func (r *rpcServer) SubscribeChannelGraph(req *lnrpc.GraphTopologySubscription,
-	updateStream lnrpc.Lightning_SubscribeChannelGraphServer) error {
+// ImportPolicies adds all fee policies contained within a backup created by
+// ExportPolicies, overwriting any existing policies for the same payment hash
+// or node and amount band.
+func (r *rpcServer) ImportPolicies(ctx context.Context,
+	req *lnrpc.PolicyBackup) (*lnrpc.ImportPoliciesResponse, error) {
 
-	// First, we start by subscribing to a new intent to receive
-	// notifications from the channel router.
-	client, err := r.server.chanRouter.SubscribeTopology()
+	numImported, err := r.server.chanDB.ImportPolicies(
+		bytes.NewReader(req.Policies),
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Ensure that the resources for the topology update client is cleaned
-	// up once either the server, or client exists.
-	defer client.Cancel()
+	rpcsLog.Infof("[importpolicies] imported %v policies", numImported)
 
-	for {
-		select {
+	return &lnrpc.ImportPoliciesResponse{
+		NumImported: uint32(numImported),
+	}, nil
+}
 
-		// A new update has been sent by the channel router, we'll
-		// marshal it into the form expected by the gRPC client, then
-		// send it off.
-		case topChange, ok := <-client.TopologyChanges:
-			// If the second value from the channel read is nil,
-			// then this means that the channel router is exiting
-			// or the notification client was cancelled. So we'll
-			// exit early.
-			if !ok {
-				return errors.New("server shutting down")
-			}
+// PolicyAuditLog returns the decisions made by fee policies for the payments
+// they govern within the requested time range, in chronological order.
+func (r *rpcServer) PolicyAuditLog(ctx context.Context,
+	req *lnrpc.PolicyAuditLogRequest) (*lnrpc.PolicyAuditLogResponse,
+	error) {
 
-			// Convert the struct from the channel router into the
-			// form expected by the gRPC service then send it off
-			// to the client.
-			graphUpdate := marshallTopologyChange(topChange)
-			if err := updateStream.Send(graphUpdate); err != nil {
-				return err
-			}
+	var start, end time.Time
+	if req.StartTime != 0 {
+		start = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime != 0 {
+		end = time.Unix(req.EndTime, 0)
+	}
 
-		// The server is quitting, so we'll exit immediately. Returning
-		// nil will close the clients read end of the stream.
-		case <-r.quit:
-			return nil
+	rpcsLog.Debugf("[policyauditlog] start=%v, end=%v", start, end)
+
+	records, err := r.server.chanDB.FetchPolicyAuditLog(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &lnrpc.PolicyAuditLogResponse{
+		Records: make([]*lnrpc.PolicyAuditRecord, 0, len(records)),
+	}
+	for _, record := range records {
+		// The decisions within the database and the RPC enum share
+		// the same values.
+		decision := lnrpc.PolicyAuditRecord_Decision(record.Decision)
+		payHash := record.PaymentHash
+
+		rpcRecord := &lnrpc.PolicyAuditRecord{
+			Timestamp:        record.Timestamp.Unix(),
+			PaymentHash:      hex.EncodeToString(payHash[:]),
+			FeeRequestedMsat: int64(record.FeeRequested),
+			FeeAllowedMsat:   int64(record.FeeAllowed),
+			Decision:         decision,
 		}
+		resp.Records = append(resp.Records, rpcRecord)
 	}
+
+	return resp, nil
 }
 
-// marshallTopologyChange performs a mapping from the topology change struct
-// returned by the router to the form of notifications expected by the current
-// gRPC service.
-func marshallTopologyChange(topChange *routing.TopologyChange) *lnrpc.GraphTopologyUpdate {
+// CompactDatabase schedules the channel and graph databases to be compacted
+// the next time the daemon starts. As the databases are in use by all
+// subsystems, they can't be swapped out while the daemon is running.
+func (r *rpcServer) CompactDatabase(ctx context.Context,
+	_ *lnrpc.CompactDatabaseRequest) (*lnrpc.CompactDatabaseResponse,
+	error) {
 
-	// encodeKey is a simple helper function that converts a live public
-	// key into a hex-encoded version of the compressed serialization for
-	// the public key.
-	encodeKey := func(k *btcec.PublicKey) string {
-		return hex.EncodeToString(k.SerializeCompressed())
+	rpcsLog.Infof("[compactdatabase] scheduling database compaction on " +
+		"next startup")
+
+	if err := r.server.chanDB.ScheduleCompaction(); err != nil {
+		return nil, err
+	}
+	graphDB := r.server.chanDB.ChannelGraph().Database()
+	if err := graphDB.ScheduleCompaction(); err != nil {
+		return nil, err
 	}
 
-	nodeUpdates := make([]*lnrpc.NodeUpdate, len(topChange.NodeUpdates))
-	for i, nodeUpdate := range topChange.NodeUpdates {
-		addrs := make([]string, len(nodeUpdate.Addresses))
-		for i, addr := range nodeUpdate.Addresses {
-			addrs[i] = addr.String()
-		}
+	return &lnrpc.CompactDatabaseResponse{}, nil
+}
 
-		nodeUpdates[i] = &lnrpc.NodeUpdate{
-			Addresses:      addrs,
-			IdentityKey:    encodeKey(nodeUpdate.IdentityKey),
-			GlobalFeatures: nodeUpdate.GlobalFeatures,
-			Alias:          nodeUpdate.Alias,
+// dbChunkWriter is an io.Writer which sends everything written to it as
+// database chunks over an ExportDatabase stream.
+type dbChunkWriter struct {
+	stream lnrpc.Lightning_ExportDatabaseServer
+}
+
+// Write sends p as one or more database chunks, each no larger than
+// maxDBChunkSize.
+//
+// NOTE: Part of the io.Writer interface.
+func (w *dbChunkWriter) Write(p []byte) (int, error) {
+	var n int
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > maxDBChunkSize {
+			chunk = chunk[:maxDBChunkSize]
 		}
-	}
 
-	channelUpdates := make([]*lnrpc.ChannelEdgeUpdate, len(topChange.ChannelEdgeUpdates))
-	for i, channelUpdate := range topChange.ChannelEdgeUpdates {
-		channelUpdates[i] = &lnrpc.ChannelEdgeUpdate{
-			ChanId: channelUpdate.ChanID,
-			ChanPoint: &lnrpc.ChannelPoint{
-				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
-					FundingTxidBytes: channelUpdate.ChanPoint.Hash[:],
-				},
-				OutputIndex: channelUpdate.ChanPoint.Index,
-			},
-			Capacity: int64(channelUpdate.Capacity),
-			RoutingPolicy: &lnrpc.RoutingPolicy{
-				TimeLockDelta:    uint32(channelUpdate.TimeLockDelta),
-				MinHtlc:          int64(channelUpdate.MinHTLC),
-				FeeBaseMsat:      int64(channelUpdate.BaseFee),
-				FeeRateMilliMsat: int64(channelUpdate.FeeRate),
-			},
-			AdvertisingNode: encodeKey(channelUpdate.AdvertisingNode),
-			ConnectingNode:  encodeKey(channelUpdate.ConnectingNode),
+		err := w.stream.Send(&lnrpc.DatabaseChunk{Data: chunk})
+		if err != nil {
+			return n, err
 		}
+
+		n += len(chunk)
+		p = p[len(chunk):]
 	}
 
-	closedChans := make([]*lnrpc.ClosedChannelUpdate, len(topChange.ClosedChannels))
-	for i, closedChan := range topChange.ClosedChannels {
-		closedChans[i] = &lnrpc.ClosedChannelUpdate{
-			ChanId:       closedChan.ChanID,
-			Capacity:     int64(closedChan.Capacity),
-			ClosedHeight: closedChan.ClosedHeight,
-			ChanPoint: &lnrpc.ChannelPoint{
-				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
-					FundingTxidBytes: closedChan.ChanPoint.Hash[:],
-				},
-				OutputIndex: closedChan.ChanPoint.Index,
-			},
-		}
+	return n, nil
+}
+
+// ExportDatabase streams a consistent, point-in-time snapshot of the channel
+// database, which is safe to take while the daemon is running.
+func (r *rpcServer) ExportDatabase(_ *lnrpc.ExportDatabaseRequest,
+	stream lnrpc.Lightning_ExportDatabaseServer) error {
+
+	rpcsLog.Infof("[exportdatabase] exporting database snapshot")
+
+	n, err := r.server.chanDB.Snapshot(&dbChunkWriter{stream: stream})
+	if err != nil {
+		return err
 	}
 
-	return &lnrpc.GraphTopologyUpdate{
-		NodeUpdates:    nodeUpdates,
-		ChannelUpdates: channelUpdates,
-		ClosedChans:    closedChans,
+	rpcsLog.Infof("[exportdatabase] exported %d bytes", n)
+
+	return nil
+}
+
+// SubscribeChannelEvents returns a uni-directional stream (server -> client)
+// for notifying the client of the lifecycle transitions of all channels.
+func (r *rpcServer) SubscribeChannelEvents(req *lnrpc.ChannelEventSubscription,
+	updateStream lnrpc.Lightning_SubscribeChannelEventsServer) error {
+
+	client := r.server.channelNotifier.SubscribeChannelEvents()
+	defer client.Cancel()
+
+	for {
+		select {
+		case event := <-client.Updates:
+			update, err := marshallChannelEvent(event)
+			if err != nil {
+				return err
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
 	}
 }
 
-// ListPayments returns a list of all outgoing payments.
-func (r *rpcServer) ListPayments(ctx context.Context,
-	_ *lnrpc.ListPaymentsRequest) (*lnrpc.ListPaymentsResponse, error) {
+// SubscribePeerEvents returns a uni-directional stream (server -> client)
+// which sends an event to the client whenever a peer comes online or goes
+// offline.
+func (r *rpcServer) SubscribePeerEvents(req *lnrpc.PeerEventSubscription,
+	updateStream lnrpc.Lightning_SubscribePeerEventsServer) error {
 
-	rpcsLog.Debugf("[ListPayments]")
+	client := r.server.peerNotifier.SubscribePeerEvents()
+	defer client.Cancel()
 
-	payments, err := r.server.chanDB.FetchAllPayments()
-	if err != nil && err != channeldb.ErrNoPaymentsCreated {
-		return nil, err
+	for {
+		select {
+		case event := <-client.Updates:
+			var eventType lnrpc.PeerEvent_EventType
+			switch event.eventType {
+			case peerOnlineEvent:
+				eventType = lnrpc.PeerEvent_PEER_ONLINE
+			case peerOfflineEvent:
+				eventType = lnrpc.PeerEvent_PEER_OFFLINE
+			default:
+				return fmt.Errorf("unknown peer event type: %v",
+					event.eventType)
+			}
+
+			update := &lnrpc.PeerEvent{
+				PubKey: hex.EncodeToString(event.pubKey[:]),
+				Type:   eventType,
+			}
+			if err := updateStream.Send(update); err != nil {
+				return err
+			}
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
 	}
+}
 
-	paymentsResp := &lnrpc.ListPaymentsResponse{
-		Payments: make([]*lnrpc.Payment, len(payments)),
+// HtlcInterceptor dispatches a bi-directional streaming RPC in which every HTLC
+// about to be forwarded is held and sent to the client, which then decides
+// whether the HTLC is forwarded as usual, settled or failed back. Any HTLC
+// which the client didn't resolve once the stream ends is forwarded as usual.
+func (r *rpcServer) HtlcInterceptor(
+	stream lnrpc.Lightning_HtlcInterceptorServer) error {
+
+	if !atomic.CompareAndSwapInt32(&r.interceptorActive, 0, 1) {
+		return errors.New("an htlc interceptor is already active")
 	}
-	for i, payment := range payments {
-		path := make([]string, len(payment.Path))
-		for i, hop := range payment.Path {
-			path[i] = hex.EncodeToString(hop[:])
+	defer atomic.StoreInt32(&r.interceptorActive, 0)
+
+	interceptor := newForwardInterceptor(r.server.htlcSwitch)
+	defer interceptor.Stop()
+
+	rpcsLog.Infof("HTLC interceptor registered")
+
+	// We'll launch a goroutine to read the resolutions of the held
+	// forwards sent by the client, so we're able to deliver the next held
+	// forward without waiting for the client's decision on the prior one.
+	errChan := make(chan error, 1)
+	reqQuit := make(chan struct{})
+	defer close(reqQuit)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				errChan <- nil
+				return
+			} else if err != nil {
+				errChan <- err
+				return
+			}
+
+			if err := r.resolveInterceptedForward(
+				interceptor, resp,
+			); err != nil {
+				select {
+				case errChan <- err:
+				case <-reqQuit:
+				}
+				return
+			}
 		}
+	}()
 
-		paymentHash := sha256.Sum256(payment.PaymentPreimage[:])
-		paymentsResp.Payments[i] = &lnrpc.Payment{
-			PaymentHash:     hex.EncodeToString(paymentHash[:]),
-			Value:           int64(payment.Terms.Value.ToSatoshis()),
-			CreationDate:    payment.CreationDate.Unix(),
-			Path:            path,
-			Fee:             int64(payment.Fee.ToSatoshis()),
-			PaymentPreimage: hex.EncodeToString(payment.PaymentPreimage[:]),
+	for {
+		select {
+		case fwd := <-interceptor.Forwards:
+			inKey := fwd.Packet.IncomingCircuit
+			err := stream.Send(&lnrpc.ForwardHtlcInterceptRequest{
+				IncomingCircuitKey: &lnrpc.CircuitKey{
+					ChanId: inKey.ChanID.ToUint64(),
+					HtlcId: inKey.HtlcID,
+				},
+				IncomingAmountMsat: uint64(fwd.Packet.IncomingAmount),
+				PaymentHash:        fwd.Packet.Hash[:],
+				OutgoingRequestedChanId: fwd.Packet.OutgoingChanID.
+					ToUint64(),
+				OutgoingAmountMsat: uint64(fwd.Packet.OutgoingAmount),
+				OutgoingExpiry:     fwd.Packet.OutgoingExpiry,
+				OnionBlob:          fwd.Packet.OnionBlob[:],
+			})
+			if err != nil {
+				return err
+			}
+
+		case err := <-errChan:
+			return err
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
 		}
 	}
-
-	return paymentsResp, nil
 }
 
-// DeleteAllPayments deletes all outgoing payments from DB.
-func (r *rpcServer) DeleteAllPayments(ctx context.Context,
-	_ *lnrpc.DeleteAllPaymentsRequest) (*lnrpc.DeleteAllPaymentsResponse, error) {
-
-	rpcsLog.Debugf("[DeleteAllPayments]")
+// resolveInterceptedForward resolves the held forward identified by the
+// passed response of an HTLC interceptor client.
+func (r *rpcServer) resolveInterceptedForward(interceptor *forwardInterceptor,
+	resp *lnrpc.ForwardHtlcInterceptResponse) error {
 
-	if err := r.server.chanDB.DeleteAllPayments(); err != nil {
-		return nil, err
+	if resp.IncomingCircuitKey == nil {
+		return errors.New("incoming circuit key must be set")
+	}
+	key := htlcswitch.CircuitKey{
+		ChanID: lnwire.NewShortChanIDFromInt(
+			resp.IncomingCircuitKey.ChanId,
+		),
+		HtlcID: resp.IncomingCircuitKey.HtlcId,
 	}
 
-	return &lnrpc.DeleteAllPaymentsResponse{}, nil
-}
+	var (
+		action   interceptAction
+		preimage [32]byte
+	)
+	switch resp.Action {
+	case lnrpc.ForwardHtlcInterceptResponse_RESUME:
+		action = interceptResume
 
-// DebugLevel allows a caller to programmatically set the logging verbosity of
-// lnd. The logging can be targeted according to a coarse daemon-wide logging
-// level, or in a granular fashion to specify the logging for a target
-// sub-system.
-func (r *rpcServer) DebugLevel(ctx context.Context,
-	req *lnrpc.DebugLevelRequest) (*lnrpc.DebugLevelResponse, error) {
+	case lnrpc.ForwardHtlcInterceptResponse_SETTLE:
+		action = interceptSettle
 
-	// If show is set, then we simply print out the list of available
-	// sub-systems.
-	if req.Show {
-		return &lnrpc.DebugLevelResponse{
-			SubSystems: strings.Join(supportedSubsystems(), " "),
-		}, nil
-	}
+		if len(resp.Preimage) != 32 {
+			return fmt.Errorf("preimage must be exactly 32 bytes, "+
+				"is instead %v", len(resp.Preimage))
+		}
+		copy(preimage[:], resp.Preimage)
 
-	rpcsLog.Infof("[debuglevel] changing debug level to: %v", req.LevelSpec)
+		// We'll add the preimage to the preimage cache before settling
+		// the HTLC, so the incoming HTLC can still be claimed on-chain
+		// if the channel is force closed.
+		err := r.server.witnessBeacon.AddPreimage(preimage[:])
+		if err != nil {
+			return err
+		}
 
-	// Otherwise, we'll attempt to set the logging level using the
-	// specified level spec.
-	if err := parseAndSetDebugLevels(req.LevelSpec); err != nil {
-		return nil, err
+	case lnrpc.ForwardHtlcInterceptResponse_FAIL:
+		action = interceptFail
+
+	default:
+		return fmt.Errorf("unknown intercept action %v", resp.Action)
 	}
 
-	return &lnrpc.DebugLevelResponse{}, nil
-}
+	rpcsLog.Debugf("Resolving intercepted HTLC %v with action %v", key,
+		resp.Action)
 
-// DecodePayReq takes an encoded payment request string and attempts to decode
-// it, returning a full description of the conditions encoded within the
-// payment request.
-func (r *rpcServer) DecodePayReq(ctx context.Context,
-	req *lnrpc.PayReqString) (*lnrpc.PayReq, error) {
+	return interceptor.Resolve(key, action, preimage)
+}
 
-	rpcsLog.Tracef("[decodepayreq] decoding: %v", req.PayReq)
+// ChannelAcceptor dispatches a bi-directional streaming RPC in which every
+// channel extended to us by a remote peer is sent to the client, which then
+// decides whether we accept or reject it. Any channel the client didn't decide
+// upon once the stream ends is rejected.
+func (r *rpcServer) ChannelAcceptor(
+	stream lnrpc.Lightning_ChannelAcceptorServer) error {
 
-	// Fist we'll attempt to decode the payment request string, if the
-	// request is invalid or the checksum doesn't match, then we'll exit
-	// here with an error.
-	payReq, err := zpay32.Decode(req.PayReq, activeNetParams.Params)
+	client, err := r.server.chanAcceptor.RegisterClient()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer r.server.chanAcceptor.UnregisterClient(client)
 
-	// Let the fields default to empty strings.
-	desc := ""
-	if payReq.Description != nil {
-		desc = *payReq.Description
-	}
+	rpcsLog.Infof("Channel acceptor registered")
 
-	descHash := []byte("")
-	if payReq.DescriptionHash != nil {
-		descHash = payReq.DescriptionHash[:]
-	}
+	// We'll launch a goroutine to read the decisions sent by the client,
+	// so we're able to deliver the next channel without waiting for the
+	// client's decision on the prior one.
+	responses := make(chan *lnrpc.ChannelAcceptResponse)
+	errChan := make(chan error, 1)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				errChan <- nil
+				return
+			} else if err != nil {
+				errChan <- err
+				return
+			}
 
-	fallbackAddr := ""
-	if payReq.FallbackAddr != nil {
-		fallbackAddr = payReq.FallbackAddr.String()
-	}
+			select {
+			case responses <- resp:
+			case <-client.quit:
+				return
+			}
+		}
+	}()
 
-	// Expiry time will default to 3600 seconds if not specified
-	// explicitly.
-	expiry := int64(payReq.Expiry().Seconds())
+	// pending tracks the channels delivered to the client which it didn't
+	// decide upon yet, by their pending channel id.
+	pending := make(map[[32]byte]*channelAcceptRequest)
+	for {
+		select {
+		case req := <-client.requests:
+			openChan := req.openChan
+			pending[openChan.PendingChannelID] = req
+
+			err := stream.Send(&lnrpc.ChannelAcceptRequest{
+				NodePubkey:       req.node.SerializeCompressed(),
+				ChainHash:        openChan.ChainHash[:],
+				PendingChanId:    openChan.PendingChannelID[:],
+				FundingAmt:       uint64(openChan.FundingAmount),
+				PushAmt:          uint64(openChan.PushAmount),
+				DustLimit:        uint64(openChan.DustLimit),
+				MaxValueInFlight: uint64(openChan.MaxValueInFlight),
+				ChannelReserve:   uint64(openChan.ChannelReserve),
+				MinHtlc:          uint64(openChan.HtlcMinimum),
+				FeePerKw:         uint64(openChan.FeePerKiloWeight),
+				CsvDelay:         uint32(openChan.CsvDelay),
+				MaxAcceptedHtlcs: uint32(openChan.MaxAcceptedHTLCs),
+				ChannelFlags:     uint32(openChan.ChannelFlags),
+			})
+			if err != nil {
+				return err
+			}
 
-	amt := int64(0)
-	if payReq.MilliSat != nil {
-		amt = int64(payReq.MilliSat.ToSatoshis())
-	}
+		case resp := <-responses:
+			var pendingChanID [32]byte
+			if len(resp.PendingChanId) != len(pendingChanID) {
+				return fmt.Errorf("pending channel id must be "+
+					"exactly %v bytes", len(pendingChanID))
+			}
+			copy(pendingChanID[:], resp.PendingChanId)
 
-	dest := payReq.Destination.SerializeCompressed()
-	return &lnrpc.PayReq{
-		Destination:     hex.EncodeToString(dest),
-		PaymentHash:     hex.EncodeToString(payReq.PaymentHash[:]),
-		NumSatoshis:     amt,
-		Timestamp:       payReq.Timestamp.Unix(),
-		Description:     desc,
-		DescriptionHash: hex.EncodeToString(descHash[:]),
-		FallbackAddr:    fallbackAddr,
-		Expiry:          expiry,
-		CltvExpiry:      int64(payReq.MinFinalCLTVExpiry()),
-	}, nil
-}
+			req, ok := pending[pendingChanID]
+			if !ok {
+				return fmt.Errorf("unknown pending channel id %x",
+					pendingChanID[:])
+			}
+			delete(pending, pendingChanID)
 
-// feeBase is the fixed point that fee rate computation are performed over.
-// Nodes on the network advertise their fee rate using this point as a base.
-// This means that the minimal possible fee rate if 1e-6, or 0.000001, or
-// 0.0001%.
-const feeBase = 1000000
+			if resp.MinAcceptDepth > math.MaxUint16 {
+				return fmt.Errorf("min accept depth %v too large",
+					resp.MinAcceptDepth)
+			}
 
-// FeeReport allows the caller to obtain a report detailing the current fee
-// schedule enforced by the node globally for each channel.
-func (r *rpcServer) FeeReport(ctx context.Context,
-	_ *lnrpc.FeeReportRequest) (*lnrpc.FeeReportResponse, error) {
+			reason := resp.Error
+			if !resp.Accept && reason == "" {
+				reason = "rejected by channel acceptor"
+			}
 
-	// TODO(roasbeef): use UnaryInterceptor to add automated logging
+			req.resp <- &channelAcceptResponse{
+				accept:         resp.Accept,
+				reason:         reason,
+				minAcceptDepth: uint16(resp.MinAcceptDepth),
+			}
 
-	rpcsLog.Debugf("[feereport]")
+		case err := <-errChan:
+			return err
 
-	channelGraph := r.server.chanDB.ChannelGraph()
-	selfNode, err := channelGraph.SourceNode()
-	if err != nil {
-		return nil, err
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
+		}
 	}
+}
 
-	var feeReports []*lnrpc.ChannelFeeReport
-	err = selfNode.ForEachChannel(nil, func(_ *bolt.Tx, chanInfo *channeldb.ChannelEdgeInfo,
-		edgePolicy, _ *channeldb.ChannelEdgePolicy) error {
-
-		// We'll compute the effective fee rate by converting from a
-		// fixed point fee rate to a floating point fee rate. The fee
-		// rate field in the database the amount of mSAT charged per
-		// 1mil mSAT sent, so will divide by this to get the proper fee
-		// rate.
-		feeRateFixedPoint := edgePolicy.FeeProportionalMillionths
-		feeRate := float64(feeRateFixedPoint) / float64(feeBase)
-
-		// TODO(roasbeef): also add stats for revenue for each channel
-		feeReports = append(feeReports, &lnrpc.ChannelFeeReport{
-			ChanPoint:   chanInfo.ChannelPoint.String(),
-			BaseFeeMsat: int64(edgePolicy.FeeBaseMSat),
-			FeePerMil:   int64(feeRateFixedPoint),
-			FeeRate:     feeRate,
-		})
+// RegisterRPCMiddleware registers the RPC middleware on the other end of the
+// stream, and hands it the messages of the calls it intercepts until the
+// stream ends. The first message sent by the middleware must be its
+// registration, and all following ones its feedback upon the intercepted
+// messages.
+func (r *rpcServer) RegisterRPCMiddleware(
+	stream lnrpc.Lightning_RegisterRPCMiddlewareServer) error {
 
-		return nil
-	})
+	msg, err := stream.Recv()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if msg.Register == nil {
+		return fmt.Errorf("first message must be the middleware " +
+			"registration")
 	}
 
-	fwdEventLog := r.server.chanDB.ForwardingLog()
-
-	// computeFeeSum is a helper function that computes the total fees for
-	// a particular time slice described by a forwarding event query.
-	computeFeeSum := func(query channeldb.ForwardingEventQuery) (lnwire.MilliSatoshi, error) {
+	middleware, err := r.server.rpcMiddleware.Register(msg.Register)
+	if err != nil {
+		return err
+	}
+	defer r.server.rpcMiddleware.Unregister(middleware)
 
-		var totalFees lnwire.MilliSatoshi
+	rpcsLog.Infof("RPC middleware %v registered (custom_caveat=%v, "+
+		"read_only=%v)", middleware.name, middleware.customCaveatName,
+		middleware.readOnly)
 
-		// We'll continue to fetch the next query and accumulate the
-		// fees until the next query returns no events.
+	// We'll launch a goroutine to read the feedback sent by the
+	// middleware, so we're able to deliver the messages of concurrent
+	// calls without waiting for the feedback upon prior ones.
+	errChan := make(chan error, 1)
+	go func() {
 		for {
-			timeSlice, err := fwdEventLog.Query(query)
-			if err != nil {
-				return 0, nil
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				errChan <- nil
+				return
+			} else if err != nil {
+				errChan <- err
+				return
+			}
+
+			if msg.Register != nil {
+				errChan <- fmt.Errorf("middleware already " +
+					"registered")
+				return
+			}
+			if msg.Feedback == nil {
+				errChan <- fmt.Errorf("feedback upon message "+
+					"%v missing", msg.RefMsgId)
+				return
 			}
 
-			// If the timeslice is empty, then we'll return as
-			// we've retrieved all the entries in this range.
-			if len(timeSlice.ForwardingEvents) == 0 {
-				break
+			// Feedback arriving after the call gave up waiting
+			// for it is dropped.
+			err = middleware.Resolve(msg.RefMsgId, msg.Feedback)
+			if err != nil {
+				rpcsLog.Debugf("Dropping feedback of RPC "+
+					"middleware %v: %v", middleware.name,
+					err)
 			}
+		}
+	}()
 
-			// Otherwise, we'll tally up an accumulate the total
-			// fees for this time slice.
-			for _, event := range timeSlice.ForwardingEvents {
-				fee := event.AmtIn - event.AmtOut
-				totalFees += fee
+	for {
+		select {
+		case req := <-middleware.Requests:
+			if err := stream.Send(req); err != nil {
+				return err
 			}
 
-			// We'll now take the last offset index returned as
-			// part of this response, and modify our query to start
-			// at this index. This has a pagination effect in the
-			// case that our query bounds has more than 100k
-			// entries.
-			query.IndexOffset = timeSlice.LastIndexOffset
+		case err := <-errChan:
+			return err
+
+		// The server is quitting, so we'll exit immediately. Returning
+		// nil will close the clients read end of the stream.
+		case <-r.quit:
+			return nil
 		}
+	}
+}
 
-		return totalFees, nil
+// marshallChannelEvent converts a channel lifecycle transition reported by
+// the channel notifier into its RPC representation.
+func marshallChannelEvent(event *channelEvent) (*lnrpc.ChannelEventUpdate,
+	error) {
+
+	var updateType lnrpc.ChannelEventUpdate_UpdateType
+	switch event.eventType {
+	case pendingOpenChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_PENDING_OPEN_CHANNEL
+	case openChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_OPEN_CHANNEL
+	case closingChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_CLOSING_CHANNEL
+	case closedChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_CLOSED_CHANNEL
+	case activeChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_ACTIVE_CHANNEL
+	case inactiveChannelEvent:
+		updateType = lnrpc.ChannelEventUpdate_INACTIVE_CHANNEL
+	default:
+		return nil, fmt.Errorf("unknown channel event type: %v",
+			event.eventType)
 	}
 
-	now := time.Now()
+	return &lnrpc.ChannelEventUpdate{
+		Type: updateType,
+		ChannelPoint: &lnrpc.ChannelPoint{
+			FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+				FundingTxidBytes: event.chanPoint.Hash[:],
+			},
+			OutputIndex: event.chanPoint.Index,
+		},
+	}, nil
+}
 
-	// Before we perform the queries below, we'll instruct the switch to
-	// flush any pending events to disk. This ensure we get a complete
-	// snapshot at this particular time.
-	if r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
-		return nil, fmt.Errorf("unable to flush forwarding "+
-			"events: %v", err)
+// BakeMacaroon mints a new macaroon which only grants the passed permissions,
+// optionally restricted to an IP address and a lifetime.
+func (r *rpcServer) BakeMacaroon(ctx context.Context,
+	req *lnrpc.BakeMacaroonRequest) (*lnrpc.BakeMacaroonResponse, error) {
+
+	if r.macService == nil {
+		return nil, fmt.Errorf("macaroons are disabled")
 	}
 
-	// In addition to returning the current fee schedule for each channel.
-	// We'll also perform a series of queries to obtain the total fees
-	// earned over the past day, week, and month.
-	dayQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24),
-		EndTime:      now,
-		NumMaxEvents: 1000,
+	if len(req.Permissions) == 0 {
+		return nil, fmt.Errorf("at least one permission is required")
 	}
-	dayFees, err := computeFeeSum(dayQuery)
-	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+	if req.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must not be negative, got %v",
+			req.Timeout)
 	}
 
-	weekQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24 * 7),
-		EndTime:      now,
-		NumMaxEvents: 1000,
+	// We'll only mint macaroons for permissions known to the server, as
+	// any other permission wouldn't grant access to any call, which most
+	// likely means the caller made a typo.
+	ops := make([]bakery.Op, 0, len(req.Permissions))
+	for _, perm := range req.Permissions {
+		op := bakery.Op{
+			Entity: perm.Entity,
+			Action: perm.Action,
+		}
+		if !isKnownPermission(op) {
+			return nil, fmt.Errorf("unknown permission %v:%v",
+				op.Entity, op.Action)
+		}
+		ops = append(ops, op)
 	}
-	weekFees, err := computeFeeSum(weekQuery)
+
+	// The macaroon is derived from the requested root key, which allows
+	// revoking it later on, along with all other macaroons derived from
+	// the same root key, by deleting the root key.
+	rootKeyID := []byte(strconv.FormatUint(req.RootKeyId, 10))
+	mac, err := r.macService.Oven.NewMacaroon(
+		macaroons.ContextWithRootKeyID(ctx, rootKeyID),
+		bakery.LatestVersion, nil, ops...,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+		return nil, err
 	}
 
-	monthQuery := channeldb.ForwardingEventQuery{
-		StartTime:    now.Add(-time.Hour * 24 * 30),
-		EndTime:      now,
-		NumMaxEvents: 1000,
+	var constraints []macaroons.Constraint
+	if req.IpAddress != "" {
+		constraints = append(
+			constraints, macaroons.IPLockConstraint(req.IpAddress),
+		)
 	}
-	monthFees, err := computeFeeSum(monthQuery)
+	if req.Timeout > 0 {
+		constraints = append(
+			constraints, macaroons.TimeoutConstraint(req.Timeout),
+		)
+	}
+	constrainedMac, err := macaroons.AddConstraints(mac.M(), constraints...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve day fees: %v", err)
+		return nil, err
 	}
 
-	return &lnrpc.FeeReportResponse{
-		ChannelFees: feeReports,
-		DayFeeSum:   uint64(dayFees.ToSatoshis()),
-		WeekFeeSum:  uint64(weekFees.ToSatoshis()),
-		MonthFeeSum: uint64(monthFees.ToSatoshis()),
+	macBytes, err := constrainedMac.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcsLog.Infof("[bakemacaroon] baked macaroon with permissions %v "+
+		"from root key %s", ops, rootKeyID)
+
+	return &lnrpc.BakeMacaroonResponse{
+		Macaroon: hex.EncodeToString(macBytes),
 	}, nil
 }
 
-// minFeeRate is the smallest permitted fee rate within the network. This is
-// derived by the fact that fee rates are computed using a fixed point of
-// 1,000,000. As a result, the smallest representable fee rate is 1e-6, or
-// 0.000001, or 0.0001%.
-const minFeeRate = 1e-6
+// ListMacaroonIDs returns the IDs of all root keys macaroons may be derived
+// from.
+func (r *rpcServer) ListMacaroonIDs(ctx context.Context,
+	req *lnrpc.ListMacaroonIDsRequest) (*lnrpc.ListMacaroonIDsResponse,
+	error) {
 
-// UpdateChannelPolicy allows the caller to update the channel forwarding policy
-// for all channels globally, or a particular channel.
-func (r *rpcServer) UpdateChannelPolicy(ctx context.Context,
-	req *lnrpc.PolicyUpdateRequest) (*lnrpc.PolicyUpdateResponse, error) {
+	if r.macService == nil {
+		return nil, fmt.Errorf("macaroons are disabled")
+	}
 
-	var targetChans []wire.OutPoint
-	switch scope := req.Scope.(type) {
-	// If the request is targeting all active channels, then we don't need
-	// target any channels by their channel point.
-	case *lnrpc.PolicyUpdateRequest_Global:
+	rootKeyIDs, err := r.macService.ListMacaroonIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// Otherwise, we're targeting an individual channel by its channel
-	// point.
-	case *lnrpc.PolicyUpdateRequest_ChanPoint:
-		txidHash, err := getChanPointFundingTxid(scope.ChanPoint)
-		if err != nil {
-			return nil, err
-		}
-		txid, err := chainhash.NewHash(txidHash)
+	resp := &lnrpc.ListMacaroonIDsResponse{
+		RootKeyIds: make([]uint64, 0, len(rootKeyIDs)),
+	}
+	for _, rootKeyID := range rootKeyIDs {
+		id, err := strconv.ParseUint(string(rootKeyID), 10, 64)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid root key ID %q: %v",
+				rootKeyID, err)
 		}
-		targetChans = append(targetChans, wire.OutPoint{
-			Hash:  *txid,
-			Index: scope.ChanPoint.OutputIndex,
-		})
-	default:
-		return nil, fmt.Errorf("unknown scope: %v", scope)
-	}
-
-	// As a sanity check, we'll ensure that the passed fee rate is below
-	// 1e-6, or the lowest allowed fee rate, and that the passed timelock
-	// is large enough.
-	if req.FeeRate < minFeeRate {
-		return nil, fmt.Errorf("fee rate of %v is too small, min fee "+
-			"rate is %v", req.FeeRate, minFeeRate)
+		resp.RootKeyIds = append(resp.RootKeyIds, id)
 	}
+	sort.Slice(resp.RootKeyIds, func(i, j int) bool {
+		return resp.RootKeyIds[i] < resp.RootKeyIds[j]
+	})
 
-	if req.TimeLockDelta < minTimeLockDelta {
-		return nil, fmt.Errorf("time lock delta of %v is too small, "+
-			"minimum supported is %v", req.TimeLockDelta,
-			minTimeLockDelta)
-	}
+	return resp, nil
+}
 
-	// We'll also need to convert the floating point fee rate we accept
-	// over RPC to the fixed point rate that we use within the protocol. We
-	// do this by multiplying the passed fee rate by the fee base. This
-	// gives us the fixed point, scaled by 1 million that's used within the
-	// protocol.
-	feeRateFixed := uint32(req.FeeRate * feeBase)
-	baseFeeMsat := lnwire.MilliSatoshi(req.BaseFeeMsat)
-	feeSchema := routing.FeeSchema{
-		BaseFee: baseFeeMsat,
-		FeeRate: feeRateFixed,
-	}
+// DeleteMacaroonID deletes the root key with the passed ID, which invalidates
+// all macaroons derived from it.
+func (r *rpcServer) DeleteMacaroonID(ctx context.Context,
+	req *lnrpc.DeleteMacaroonIDRequest) (*lnrpc.DeleteMacaroonIDResponse,
+	error) {
 
-	chanPolicy := routing.ChannelPolicy{
-		FeeSchema:     feeSchema,
-		TimeLockDelta: req.TimeLockDelta,
+	if r.macService == nil {
+		return nil, fmt.Errorf("macaroons are disabled")
 	}
 
-	rpcsLog.Debugf("[updatechanpolicy] updating channel policy base_fee=%v, "+
-		"rate_float=%v, rate_fixed=%v, time_lock_delta: %v, targets=%v",
-		req.BaseFeeMsat, req.FeeRate, feeRateFixed, req.TimeLockDelta,
-		spew.Sdump(targetChans))
-
-	// With the scope resolved, we'll now send this to the
-	// AuthenticatedGossiper so it can propagate the new policy for our
-	// target channel(s).
-	err := r.server.authGossiper.PropagateChanPolicyUpdate(
-		chanPolicy, targetChans...,
-	)
+	rootKeyID := []byte(strconv.FormatUint(req.RootKeyId, 10))
+	deleted, err := r.macService.DeleteMacaroonID(ctx, rootKeyID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Finally, we'll apply the set of active links amongst the target
-	// channels.
-	//
-	// We create a partially policy as the logic won't overwrite a valid
-	// sub-policy with a "nil" one.
-	p := htlcswitch.ForwardingPolicy{
-		BaseFee:       baseFeeMsat,
-		FeeRate:       lnwire.MilliSatoshi(feeRateFixed),
-		TimeLockDelta: req.TimeLockDelta,
-	}
-	err = r.server.htlcSwitch.UpdateForwardingPolicies(p, targetChans...)
-	if err != nil {
-		// If we're unable update the fees due to the links not being
-		// online, then we don't need to fail the call. We'll simply
-		// log the failure.
-		rpcsLog.Warnf("Unable to update link fees: %v", err)
+	if deleted {
+		rpcsLog.Infof("[deletemacaroonid] deleted root key %s, "+
+			"revoking all macaroons derived from it", rootKeyID)
 	}
 
-	return &lnrpc.PolicyUpdateResponse{}, nil
+	return &lnrpc.DeleteMacaroonIDResponse{
+		Deleted: deleted,
+	}, nil
 }
 
-// ForwardingHistory allows the caller to query the htlcswitch for a record of
-// all HTLC's forwarded within the target time range, and integer offset within
-// that time range. If no time-range is specified, then the first chunk of the
-// past 24 hrs of forwarding history are returned.
-
-// A list of forwarding events are returned. The size of each forwarding event
-// is 40 bytes, and the max message size able to be returned in gRPC is 4 MiB.
-// In order to safely stay under this max limit, we'll return 50k events per
-// response.  Each response has the index offset of the last entry. The index
-// offset can be provided to the request to allow the caller to skip a series
-// of records.
-func (r *rpcServer) ForwardingHistory(ctx context.Context,
-	req *lnrpc.ForwardingHistoryRequest) (*lnrpc.ForwardingHistoryResponse, error) {
-
-	rpcsLog.Debugf("[forwardinghistory]")
+// CheckMacaroonPermissions checks whether the passed macaroon is valid, and
+// grants the passed permissions, and returns the constraints its caveats
+// impose on its use. A macaroon which fails the check isn't treated as an
+// error, instead the reason it failed is reported within the response.
+func (r *rpcServer) CheckMacaroonPermissions(ctx context.Context,
+	req *lnrpc.CheckMacaroonPermissionsRequest) (
+	*lnrpc.CheckMacaroonPermissionsResponse, error) {
 
-	// Before we perform the queries below, we'll instruct the switch to
-	// flush any pending events to disk. This ensure we get a complete
-	// snapshot at this particular time.
-	if err := r.server.htlcSwitch.FlushForwardingEvents(); err != nil {
-		return nil, fmt.Errorf("unable to flush forwarding "+
-			"events: %v", err)
+	if r.macService == nil {
+		return nil, fmt.Errorf("macaroons are disabled")
 	}
 
-	var (
-		startTime, endTime time.Time
+	if len(req.Permissions) == 0 {
+		return nil, fmt.Errorf("at least one permission is required")
+	}
+	ops := make([]bakery.Op, 0, len(req.Permissions))
+	for _, perm := range req.Permissions {
+		ops = append(ops, bakery.Op{
+			Entity: perm.Entity,
+			Action: perm.Action,
+		})
+	}
 
-		numEvents uint32
-	)
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(req.Macaroon); err != nil {
+		return nil, fmt.Errorf("unable to decode macaroon: %v", err)
+	}
 
-	// If the start and end time were not set, then we'll just return the
-	// records over the past 24 hours.
-	if req.StartTime == 0 && req.EndTime == 0 {
-		now := time.Now()
-		startTime = now.Add(-time.Hour * 24)
-		endTime = now
-	} else {
-		startTime = time.Unix(int64(req.StartTime), 0)
-		endTime = time.Unix(int64(req.EndTime), 0)
+	constraints, err := macaroons.ParseConstraints(mac)
+	if err != nil {
+		return nil, err
 	}
 
-	// If the number of events wasn't specified, then we'll default to
-	// returning the last 100 events.
-	numEvents = req.NumMaxEvents
-	if numEvents == 0 {
-		numEvents = 100
+	// IP locked macaroons are checked against the address of the peer
+	// within the context, so we'll substitute the address of the client
+	// the macaroon was presented by, if known.
+	checkCtx := ctx
+	if req.ClientIp != "" {
+		clientIP := net.ParseIP(req.ClientIp)
+		if clientIP == nil {
+			return nil, fmt.Errorf("invalid client IP %q",
+				req.ClientIp)
+		}
+		checkCtx = peer.NewContext(ctx, &peer.Peer{
+			Addr: &net.TCPAddr{IP: clientIP},
+		})
 	}
 
-	// Next, we'll map the proto request into a format the is understood by
-	// the forwarding log.
-	eventQuery := channeldb.ForwardingEventQuery{
-		StartTime:    startTime,
-		EndTime:      endTime,
-		IndexOffset:  req.IndexOffset,
-		NumMaxEvents: numEvents,
+	resp := &lnrpc.CheckMacaroonPermissionsResponse{
+		Caveats: constraints.Caveats,
 	}
-	timeSlice, err := r.server.chanDB.ForwardingLog().Query(eventQuery)
+	if !constraints.Expiry.IsZero() {
+		resp.Expiry = constraints.Expiry.Unix()
+	}
+	if constraints.IPAddr != nil {
+		resp.IpAddress = constraints.IPAddr.String()
+	}
+	for _, caveat := range constraints.CustomCaveats {
+		resp.CustomCaveats = append(
+			resp.CustomCaveats, &lnrpc.MacaroonCustomCaveat{
+				Name:      caveat.Name,
+				Condition: caveat.Condition,
+			},
+		)
+	}
+
+	err = r.macService.CheckMacAuth(checkCtx, mac, ops)
 	if err != nil {
-		return nil, fmt.Errorf("unable to query forwarding log: %v", err)
+		resp.Error = err.Error()
+	} else {
+		resp.Valid = true
 	}
 
-	// TODO(roasbeef): add settlement latency?
-	//  * use FPE on all records?
+	return resp, nil
+}
 
-	// With the events retrieved, we'll now map them into the proper proto
-	// response.
-	//
-	// TODO(roasbeef): show in ns for the outside?
-	resp := &lnrpc.ForwardingHistoryResponse{
-		ForwardingEvents: make([]*lnrpc.ForwardingEvent, len(timeSlice.ForwardingEvents)),
-		LastOffsetIndex:  timeSlice.LastIndexOffset,
+// isKnownPermission returns whether the passed permission is one of those
+// granted by the admin macaroon.
+func isKnownPermission(op bakery.Op) bool {
+	for _, known := range readPermissions {
+		if op == known {
+			return true
+		}
 	}
-	for i, event := range timeSlice.ForwardingEvents {
-		amtInSat := event.AmtIn.ToSatoshis()
-		amtOutSat := event.AmtOut.ToSatoshis()
-
-		resp.ForwardingEvents[i] = &lnrpc.ForwardingEvent{
-			Timestamp: uint64(event.Timestamp.Unix()),
-			ChanIdIn:  event.IncomingChanID.ToUint64(),
-			ChanIdOut: event.OutgoingChanID.ToUint64(),
-			AmtIn:     uint64(amtInSat),
-			AmtOut:    uint64(amtOutSat),
-			Fee:       uint64(amtInSat - amtOutSat),
+	for _, known := range writePermissions {
+		if op == known {
+			return true
 		}
 	}
 
-	return resp, nil
+	return false
 }
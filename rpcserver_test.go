@@ -0,0 +1,782 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/channeldb"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+	"github.com/lightningnetwork/lnd/macaroons"
+	"github.com/lightningnetwork/lnd/routing"
+	"github.com/roasbeef/btcd/btcec"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"gopkg.in/macaroon-bakery.v2/bakery"
+	macaroon "gopkg.in/macaroon.v2"
+)
+
+// TestNewPaymentLimits tests that the limits imposed on a payment by a policy
+// only bound its fee if the policy sets a fee or a budget, so that policies
+// which only restrict the time lock or outgoing channel of a payment don't
+// block payments routed over several hops.
+func TestNewPaymentLimits(t *testing.T) {
+	t.Parallel()
+
+	const amt = lnwire.MilliSatoshi(100000)
+
+	testCases := []struct {
+		name     string
+		policy   *channeldb.Policy
+		feeLimit *lnwire.MilliSatoshi
+	}{
+		{
+			name: "cltv only",
+			policy: &channeldb.Policy{
+				MaxCLTVDelta: 144,
+			},
+		},
+		{
+			name: "outgoing channel only",
+			policy: &channeldb.Policy{
+				OutgoingChanID: 1,
+			},
+		},
+		{
+			name: "absolute fee",
+			policy: &channeldb.Policy{
+				Fee: 1000,
+			},
+			feeLimit: newMSat(1000),
+		},
+		{
+			name: "fee rate",
+			policy: &channeldb.Policy{
+				BaseFee: 100,
+				FeeRate: 1000,
+			},
+			feeLimit: newMSat(200),
+		},
+		{
+			name: "budget only",
+			policy: &channeldb.Policy{
+				Budget:      amt + 500,
+				SpentToDate: 100,
+			},
+			feeLimit: newMSat(400),
+		},
+		{
+			name: "exhausted budget",
+			policy: &channeldb.Policy{
+				Budget: amt - 1,
+			},
+			feeLimit: newMSat(0),
+		},
+	}
+
+	for _, test := range testCases {
+		limits := newPaymentLimits(test.policy, amt)
+
+		payment := &routing.LightningPayment{
+			Amount: amt,
+		}
+		limits.apply(payment)
+
+		switch {
+		case test.feeLimit == nil && payment.FeeLimit != nil:
+			t.Fatalf("%v: expected unbounded fee, got limit of %v",
+				test.name, *payment.FeeLimit)
+
+		case test.feeLimit != nil && payment.FeeLimit == nil:
+			t.Fatalf("%v: expected fee limit of %v, got unbounded "+
+				"fee", test.name, *test.feeLimit)
+
+		case test.feeLimit != nil && *payment.FeeLimit != *test.feeLimit:
+			t.Fatalf("%v: expected fee limit of %v, got %v",
+				test.name, *test.feeLimit, *payment.FeeLimit)
+		}
+
+		if test.policy.MaxCLTVDelta != 0 && (payment.CltvLimit == nil ||
+			*payment.CltvLimit != test.policy.MaxCLTVDelta) {
+
+			t.Fatalf("%v: CLTV limit not applied", test.name)
+		}
+		if test.policy.OutgoingChanID != 0 &&
+			(payment.OutgoingChannelID == nil ||
+				*payment.OutgoingChannelID !=
+					test.policy.OutgoingChanID) {
+
+			t.Fatalf("%v: outgoing channel not applied", test.name)
+		}
+	}
+}
+
+// newMSat returns a pointer to the passed amount.
+func newMSat(amt lnwire.MilliSatoshi) *lnwire.MilliSatoshi {
+	return &amt
+}
+
+// TestPolicyRPCs tests that fee policies can be added, looked up by either
+// their hex-encoded or raw payment hash, listed and deleted through the RPC
+// server, and that invalid policies are rejected.
+func TestPolicyRPCs(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	r := &rpcServer{
+		server: &server{
+			policyStore: db,
+		},
+	}
+	ctx := context.Background()
+
+	payHash := bytes.Repeat([]byte{1}, 32)
+	policy := &lnrpc.PaymentPolicy{
+		PaymentHash:  hex.EncodeToString(payHash),
+		FeeMsat:      1000,
+		ExpiryHeight: 500,
+		ExpiryTime:   1000000,
+		Label:        "rpc policy",
+	}
+	if _, err := r.AddPolicy(ctx, policy); err != nil {
+		t.Fatalf("unable to add policy: %v", err)
+	}
+
+	// The policy should be found by its raw payment hash as well.
+	found, err := r.LookupPolicy(ctx, &lnrpc.PolicyPaymentHash{
+		PaymentHash: payHash,
+	})
+	if err != nil {
+		t.Fatalf("unable to look up policy: %v", err)
+	}
+	if found.PaymentHash != policy.PaymentHash ||
+		found.FeeMsat != policy.FeeMsat ||
+		found.ExpiryHeight != policy.ExpiryHeight ||
+		found.ExpiryTime != policy.ExpiryTime ||
+		found.Label != policy.Label {
+
+		t.Fatalf("expected policy %v, got %v", policy, found)
+	}
+
+	list, err := r.ListPolicies(ctx, &lnrpc.ListPoliciesRequest{})
+	if err != nil {
+		t.Fatalf("unable to list policies: %v", err)
+	}
+	if len(list.Policies) != 1 ||
+		list.Policies[0].PaymentHash != policy.PaymentHash {
+
+		t.Fatalf("expected the added policy to be listed, got %v",
+			list.Policies)
+	}
+
+	// Policies with a malformed payment hash or negative fees should be
+	// rejected.
+	invalid := []*lnrpc.PaymentPolicy{
+		{PaymentHash: "zz", FeeMsat: 1000},
+		{PaymentHash: hex.EncodeToString(payHash[:31]), FeeMsat: 1000},
+		{PaymentHash: policy.PaymentHash, FeeMsat: -1},
+		{PaymentHash: policy.PaymentHash, BaseFeeMsat: -1},
+		{PaymentHash: policy.PaymentHash, FeeRatePpm: -1},
+	}
+	for _, p := range invalid {
+		if _, err := r.AddPolicy(ctx, p); err == nil {
+			t.Fatalf("invalid policy %v accepted", p)
+		}
+	}
+
+	_, err = r.DeletePolicy(ctx, &lnrpc.PolicyPaymentHash{
+		PaymentHashStr: policy.PaymentHash,
+	})
+	if err != nil {
+		t.Fatalf("unable to delete policy: %v", err)
+	}
+	_, err = r.LookupPolicy(ctx, &lnrpc.PolicyPaymentHash{
+		PaymentHash: payHash,
+	})
+	if err == nil {
+		t.Fatalf("deleted policy still found")
+	}
+}
+
+// TestBakeMacaroon tests that a baked macaroon only grants the requested
+// permissions, and that unknown permissions are refused.
+func TestBakeMacaroon(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanUp := newTestMacaroonService(t)
+	defer cleanUp()
+
+	ctx := context.Background()
+
+	// Without macaroons, no macaroon can be baked.
+	r := &rpcServer{}
+	_, err := r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+		Permissions: []*lnrpc.MacaroonPermission{{
+			Entity: "invoices",
+			Action: "read",
+		}},
+	})
+	if err == nil {
+		t.Fatalf("expected baking without macaroons to fail")
+	}
+
+	r = &rpcServer{macService: svc}
+	for _, req := range []*lnrpc.BakeMacaroonRequest{
+		{},
+		{
+			Permissions: []*lnrpc.MacaroonPermission{{
+				Entity: "invoice",
+				Action: "read",
+			}},
+		},
+		{
+			Permissions: []*lnrpc.MacaroonPermission{{
+				Entity: "invoices",
+				Action: "read",
+			}},
+			Timeout: -1,
+		},
+	} {
+		if _, err := r.BakeMacaroon(ctx, req); err == nil {
+			t.Fatalf("expected request %v to be refused", req)
+		}
+	}
+
+	resp, err := r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+		Permissions: []*lnrpc.MacaroonPermission{{
+			Entity: "invoices",
+			Action: "read",
+		}},
+		Timeout: 60,
+	})
+	if err != nil {
+		t.Fatalf("unable to bake macaroon: %v", err)
+	}
+	macBytes, err := hex.DecodeString(resp.Macaroon)
+	if err != nil {
+		t.Fatalf("unable to decode macaroon: %v", err)
+	}
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		t.Fatalf("unable to unmarshal macaroon: %v", err)
+	}
+
+	// The macaroon should only grant the permission it was baked with.
+	authChecker := svc.Checker.Auth(macaroon.Slice{mac})
+	_, err = authChecker.Allow(ctx, bakery.Op{
+		Entity: "invoices",
+		Action: "read",
+	})
+	if err != nil {
+		t.Fatalf("expected macaroon to grant permission: %v", err)
+	}
+	_, err = authChecker.Allow(ctx, bakery.Op{
+		Entity: "invoices",
+		Action: "write",
+	})
+	if err == nil {
+		t.Fatalf("expected macaroon not to grant permission")
+	}
+}
+
+// TestMacaroonRootKeyIDs tests that deleting the root key a macaroon was baked
+// from revokes the macaroon, while leaving other macaroons intact.
+func TestMacaroonRootKeyIDs(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanUp := newTestMacaroonService(t)
+	defer cleanUp()
+
+	r := &rpcServer{macService: svc}
+	ctx := context.Background()
+
+	op := bakery.Op{
+		Entity: "info",
+		Action: "read",
+	}
+	bake := func(rootKeyID uint64) *macaroon.Macaroon {
+		resp, err := r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+			Permissions: []*lnrpc.MacaroonPermission{{
+				Entity: op.Entity,
+				Action: op.Action,
+			}},
+			RootKeyId: rootKeyID,
+		})
+		if err != nil {
+			t.Fatalf("unable to bake macaroon: %v", err)
+		}
+		macBytes, err := hex.DecodeString(resp.Macaroon)
+		if err != nil {
+			t.Fatalf("unable to decode macaroon: %v", err)
+		}
+		mac := &macaroon.Macaroon{}
+		if err := mac.UnmarshalBinary(macBytes); err != nil {
+			t.Fatalf("unable to unmarshal macaroon: %v", err)
+		}
+		return mac
+	}
+	isValid := func(mac *macaroon.Macaroon) bool {
+		authChecker := svc.Checker.Auth(macaroon.Slice{mac})
+		_, err := authChecker.Allow(ctx, op)
+		return err == nil
+	}
+
+	defaultMac := bake(0)
+	otherMac := bake(5)
+	if !isValid(defaultMac) || !isValid(otherMac) {
+		t.Fatalf("expected macaroons to be valid")
+	}
+
+	ids, err := r.ListMacaroonIDs(ctx, &lnrpc.ListMacaroonIDsRequest{})
+	if err != nil {
+		t.Fatalf("unable to list root key IDs: %v", err)
+	}
+	if !reflect.DeepEqual(ids.RootKeyIds, []uint64{0, 5}) {
+		t.Fatalf("unexpected root key IDs: %v", ids.RootKeyIds)
+	}
+
+	// The default root key can't be deleted.
+	_, err = r.DeleteMacaroonID(ctx, &lnrpc.DeleteMacaroonIDRequest{})
+	if err == nil {
+		t.Fatalf("expected deletion of default root key to fail")
+	}
+
+	resp, err := r.DeleteMacaroonID(ctx, &lnrpc.DeleteMacaroonIDRequest{
+		RootKeyId: 5,
+	})
+	if err != nil {
+		t.Fatalf("unable to delete root key: %v", err)
+	}
+	if !resp.Deleted {
+		t.Fatalf("expected root key to be deleted")
+	}
+
+	// Only the macaroon derived from the deleted root key should be
+	// revoked.
+	if !isValid(defaultMac) {
+		t.Fatalf("expected default macaroon to be valid")
+	}
+	if isValid(otherMac) {
+		t.Fatalf("expected macaroon of deleted root key to be revoked")
+	}
+}
+
+// TestCheckMacaroonPermissions tests that the validity of a macaroon is checked
+// against the requested permissions and client IP, and that the constraints of
+// its caveats are reported.
+func TestCheckMacaroonPermissions(t *testing.T) {
+	t.Parallel()
+
+	svc, cleanUp := newTestMacaroonService(t)
+	defer cleanUp()
+
+	r := &rpcServer{macService: svc}
+	ctx := context.Background()
+
+	bakeResp, err := r.BakeMacaroon(ctx, &lnrpc.BakeMacaroonRequest{
+		Permissions: []*lnrpc.MacaroonPermission{{
+			Entity: "invoices",
+			Action: "read",
+		}},
+		IpAddress: "1.2.3.4",
+		Timeout:   60,
+	})
+	if err != nil {
+		t.Fatalf("unable to bake macaroon: %v", err)
+	}
+	macBytes, err := hex.DecodeString(bakeResp.Macaroon)
+	if err != nil {
+		t.Fatalf("unable to decode macaroon: %v", err)
+	}
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(macBytes); err != nil {
+		t.Fatalf("unable to unmarshal macaroon: %v", err)
+	}
+	mac, err = macaroons.AddConstraints(
+		mac, macaroons.CustomConstraint("limits", "max 10"),
+	)
+	if err != nil {
+		t.Fatalf("unable to add custom caveat: %v", err)
+	}
+	macBytes, err = mac.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unable to marshal macaroon: %v", err)
+	}
+
+	check := func(action,
+		clientIP string) *lnrpc.CheckMacaroonPermissionsResponse {
+
+		resp, err := r.CheckMacaroonPermissions(
+			ctx, &lnrpc.CheckMacaroonPermissionsRequest{
+				Macaroon: macBytes,
+				Permissions: []*lnrpc.MacaroonPermission{{
+					Entity: "invoices",
+					Action: action,
+				}},
+				ClientIp: clientIP,
+			},
+		)
+		if err != nil {
+			t.Fatalf("unable to check macaroon: %v", err)
+		}
+		return resp
+	}
+
+	resp := check("read", "1.2.3.4")
+	if !resp.Valid || resp.Error != "" {
+		t.Fatalf("expected macaroon to be valid, got error: %v",
+			resp.Error)
+	}
+	if resp.IpAddress != "1.2.3.4" {
+		t.Fatalf("expected IP address 1.2.3.4, got %v", resp.IpAddress)
+	}
+	expiry := time.Unix(resp.Expiry, 0)
+	if expiry.Before(time.Now()) ||
+		expiry.After(time.Now().Add(time.Minute)) {
+
+		t.Fatalf("unexpected expiry %v", expiry)
+	}
+	expectedCustom := []*lnrpc.MacaroonCustomCaveat{{
+		Name:      "limits",
+		Condition: "max 10",
+	}}
+	if !reflect.DeepEqual(resp.CustomCaveats, expectedCustom) {
+		t.Fatalf("expected custom caveats %v, got %v", expectedCustom,
+			resp.CustomCaveats)
+	}
+	if len(resp.Caveats) != 3 {
+		t.Fatalf("expected 3 caveats, got %v", resp.Caveats)
+	}
+
+	// The macaroon must be refused if presented from another IP address,
+	// or for a permission it doesn't grant.
+	if resp := check("read", "5.6.7.8"); resp.Valid || resp.Error == "" {
+		t.Fatalf("expected macaroon to be refused for other IP")
+	}
+	if resp := check("write", "1.2.3.4"); resp.Valid || resp.Error == "" {
+		t.Fatalf("expected macaroon to be refused for permission")
+	}
+}
+
+// newTestMacaroonService creates an unlocked macaroon service backed by a
+// temporary directory, which is removed by the returned clean up function.
+func newTestMacaroonService(t *testing.T) (*macaroons.Service, func()) {
+	tempDir, err := ioutil.TempDir("", "macaroons")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+
+	svc, err := macaroons.NewService(
+		tempDir, macaroons.IPLockChecker, macaroons.CustomChecker,
+	)
+	if err != nil {
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to create macaroon service: %v", err)
+	}
+
+	pw := []byte("hello")
+	if err := svc.CreateUnlock(&pw); err != nil {
+		svc.Close()
+		os.RemoveAll(tempDir)
+		t.Fatalf("unable to unlock macaroon service: %v", err)
+	}
+
+	return svc, func() {
+		svc.Close()
+		os.RemoveAll(tempDir)
+	}
+}
+
+// TestSignVerifyMessage tests that messages are signed over the Lightning
+// Signed Message prefix, such that signatures match those of other
+// implementations, and that the request isn't modified while doing so.
+func TestSignVerifyMessage(t *testing.T) {
+	t.Parallel()
+
+	// The expected signature was produced independently of btcec, by
+	// signing the double-sha256 of the prefixed message with an RFC6979
+	// nonce and low-S normalization, as libsecp256k1 does for the
+	// signmessage command of c-lightning.
+	const (
+		msg       = "Lightning rocks!"
+		pubKeyHex = "031b84c5567b126440995d3ed5aaba0565d71e1834604819" +
+			"ff9c17f5e9d5dd078f"
+		signature = "ryhmy4fnkiqe9fh5awfy6x78rym4nbuzzietjzaqijuma8" +
+			"twxateghwthck4enauxp1xma3pckbbhu7heyesmm1nosatz57ko1" +
+			"ktrsqr"
+	)
+	privKey, pubKey := btcec.PrivKeyFromBytes(
+		btcec.S256(), bytes.Repeat([]byte{1}, 32),
+	)
+	if hex.EncodeToString(pubKey.SerializeCompressed()) != pubKeyHex {
+		t.Fatalf("unexpected public key %x",
+			pubKey.SerializeCompressed())
+	}
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	r := &rpcServer{
+		server: &server{
+			nodeSigner: newNodeSigner(privKey),
+			chanDB:     db,
+		},
+	}
+	ctx := context.Background()
+
+	signReq := &lnrpc.SignMessageRequest{Msg: []byte(msg)}
+	signResp, err := r.SignMessage(ctx, signReq)
+	if err != nil {
+		t.Fatalf("unable to sign message: %v", err)
+	}
+	if signResp.Signature != signature {
+		t.Fatalf("expected signature %v, got %v", signature,
+			signResp.Signature)
+	}
+	if string(signReq.Msg) != msg {
+		t.Fatalf("request message was modified to %q", signReq.Msg)
+	}
+
+	// The signer isn't a node within the graph yet, so the signature
+	// shouldn't be considered valid, even though the key is recovered.
+	verifyReq := &lnrpc.VerifyMessageRequest{
+		Msg:       []byte(msg),
+		Signature: signature,
+	}
+	verifyResp, err := r.VerifyMessage(ctx, verifyReq)
+	if err != nil {
+		t.Fatalf("unable to verify message: %v", err)
+	}
+	if verifyResp.Valid || verifyResp.Pubkey != pubKeyHex {
+		t.Fatalf("expected unknown signer %v, got %v", pubKeyHex,
+			verifyResp)
+	}
+	if string(verifyReq.Msg) != msg {
+		t.Fatalf("request message was modified to %q", verifyReq.Msg)
+	}
+
+	node := &channeldb.LightningNode{}
+	copy(node.PubKeyBytes[:], pubKey.SerializeCompressed())
+	if err := db.ChannelGraph().AddLightningNode(node); err != nil {
+		t.Fatalf("unable to add node: %v", err)
+	}
+
+	verifyResp, err = r.VerifyMessage(ctx, verifyReq)
+	if err != nil {
+		t.Fatalf("unable to verify message: %v", err)
+	}
+	if !verifyResp.Valid || verifyResp.Pubkey != pubKeyHex {
+		t.Fatalf("expected valid signature by %v, got %v", pubKeyHex,
+			verifyResp)
+	}
+
+	// The signature shouldn't verify for a different message, which
+	// recovers a different key.
+	verifyReq.Msg = []byte("Lightning rocks?")
+	verifyResp, err = r.VerifyMessage(ctx, verifyReq)
+	if err != nil {
+		t.Fatalf("unable to verify message: %v", err)
+	}
+	if verifyResp.Valid || verifyResp.Pubkey == pubKeyHex {
+		t.Fatalf("expected invalid signature, got %v", verifyResp)
+	}
+}
+
+// mockTrackPaymentStream is a TrackPayment update stream which hands each
+// update sent over it to the test.
+type mockTrackPaymentStream struct {
+	grpc.ServerStream
+
+	updates chan *lnrpc.PaymentUpdate
+}
+
+func (m *mockTrackPaymentStream) Send(update *lnrpc.PaymentUpdate) error {
+	m.updates <- update
+	return nil
+}
+
+// TestTrackPayment tests that TrackPayment first replays the persisted
+// attempts of a payment, including the source of their failures, then
+// reports the attempts made afterwards, skipping those already replayed, and
+// finally the outcome of the payment.
+func TestTrackPayment(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	r := &rpcServer{
+		server: &server{
+			chanDB:          db,
+			paymentNotifier: newPaymentNotifier(),
+			witnessBeacon: &mockPreimageCache{
+				preimageMap: make(map[[32]byte][]byte),
+			},
+		},
+		quit: make(chan struct{}),
+	}
+	defer r.server.paymentNotifier.Stop()
+
+	preimage := bytes.Repeat([]byte{1}, 32)
+	paymentHash := sha256.Sum256(preimage)
+
+	// A payment which was never initiated can't be tracked.
+	stream := &mockTrackPaymentStream{
+		updates: make(chan *lnrpc.PaymentUpdate, 10),
+	}
+	req := &lnrpc.TrackPaymentRequest{PaymentHash: paymentHash[:]}
+	err = r.TrackPayment(req, stream)
+	if err != channeldb.ErrPaymentNotInitiated {
+		t.Fatalf("expected ErrPaymentNotInitiated, got %v", err)
+	}
+
+	if err := db.InitPayment(paymentHash); err != nil {
+		t.Fatalf("unable to init payment: %v", err)
+	}
+
+	newAttempt := func(sec int64) channeldb.HTLCAttempt {
+		return channeldb.HTLCAttempt{
+			AttemptTime:   time.Unix(sec, 0),
+			TotalAmount:   10000,
+			TotalFees:     lnwire.MilliSatoshi(sec),
+			TotalTimeLock: 1000,
+		}
+	}
+	record := func(index uint32, attempt channeldb.HTLCAttempt) {
+		err := db.RecordHTLCAttempt(paymentHash, index, &attempt)
+		if err != nil {
+			t.Fatalf("unable to record attempt: %v", err)
+		}
+		r.server.paymentNotifier.NotifyAttempt(
+			paymentHash, index, &attempt,
+		)
+	}
+
+	var source [33]byte
+	source[0] = 2
+	sourceHex := hex.EncodeToString(source[:])
+
+	// The first attempt failed, and the second is in flight, before the
+	// payment is tracked.
+	failed := newAttempt(1)
+	failed.ResolveTime = time.Unix(2, 0)
+	failed.Failure = "TemporaryChannelFailure"
+	failed.FailureSource = source
+	record(0, failed)
+	inFlight := newAttempt(3)
+	record(1, inFlight)
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- r.TrackPayment(req, stream)
+	}()
+
+	assertUpdate := func(updateType lnrpc.PaymentUpdate_UpdateType,
+		numAttempts uint32, failureSource string) *lnrpc.PaymentUpdate {
+
+		var update *lnrpc.PaymentUpdate
+		select {
+		case update = <-stream.updates:
+		case err := <-errChan:
+			t.Fatalf("tracking stopped: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no %v update received", updateType)
+		}
+
+		if update.Type != updateType ||
+			update.NumAttempts != numAttempts ||
+			update.FailureSourcePubKey != failureSource {
+
+			t.Fatalf("expected %v update with %v attempts and "+
+				"failure source %q, got %v", updateType,
+				numAttempts, failureSource, update)
+		}
+		if !bytes.Equal(update.PaymentHash, paymentHash[:]) {
+			t.Fatalf("unexpected payment hash %x",
+				update.PaymentHash)
+		}
+
+		return update
+	}
+
+	// The persisted attempts are replayed, with the failure source of the
+	// failed one.
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_FAILED, 1, sourceHex)
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_DISPATCHED, 2, "")
+
+	// As the replay started, the subscription is in place. Events of the
+	// attempts already replayed shouldn't be reported again.
+	r.server.paymentNotifier.NotifyAttempt(paymentHash, 0, &failed)
+	r.server.paymentNotifier.NotifyAttempt(paymentHash, 1, &inFlight)
+
+	// The second attempt fails, and a third one succeeds.
+	inFlight.ResolveTime = time.Unix(4, 0)
+	inFlight.Failure = "UnknownNextPeer"
+	record(1, inFlight)
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_FAILED, 2, "")
+
+	succeeded := newAttempt(5)
+	record(2, succeeded)
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_DISPATCHED, 3, "")
+
+	succeeded.ResolveTime = time.Unix(6, 0)
+	record(2, succeeded)
+
+	if err := r.server.witnessBeacon.AddPreimage(preimage); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+	if err := db.SucceedPayment(paymentHash); err != nil {
+		t.Fatalf("unable to succeed payment: %v", err)
+	}
+	r.server.paymentNotifier.NotifyResolved(
+		paymentHash, &channeldb.PaymentState{
+			Status: channeldb.StatusSucceeded,
+		},
+	)
+
+	final := assertUpdate(lnrpc.PaymentUpdate_SUCCEEDED, 3, "")
+	if !bytes.Equal(final.PaymentPreimage, preimage) {
+		t.Fatalf("expected preimage %x, got %x", preimage,
+			final.PaymentPreimage)
+	}
+	if final.FeePaidMsat != int64(succeeded.TotalFees) {
+		t.Fatalf("expected fee %v, got %v", succeeded.TotalFees,
+			final.FeePaidMsat)
+	}
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			t.Fatalf("unable to track payment: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("tracking didn't stop after the final update")
+	}
+
+	// Once the payment succeeded, tracking it only replays its attempts
+	// and reports its outcome.
+	err = r.TrackPayment(req, stream)
+	if err != nil {
+		t.Fatalf("unable to track payment: %v", err)
+	}
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_FAILED, 1, sourceHex)
+	assertUpdate(lnrpc.PaymentUpdate_ATTEMPT_FAILED, 2, "")
+	assertUpdate(lnrpc.PaymentUpdate_SUCCEEDED, 3, "")
+}
@@ -15,7 +15,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/bbolt"
 	"github.com/lightningnetwork/lightning-onion"
 	"github.com/lightningnetwork/lnd/autopilot"
 	"github.com/lightningnetwork/lnd/brontide"
@@ -52,6 +51,21 @@ var (
 	// maximumBackoff is the largest backoff we will permit when
 	// reattempting connections to persistent peers.
 	maximumBackoff = time.Hour
+
+	// knownPeerReconnectWindow is the window within which we must have
+	// last successfully connected to a peer without a channel for us to
+	// attempt to reconnect to it on startup.
+	knownPeerReconnectWindow = time.Hour * 24 * 14
+
+	// gossipSyncHorizon is the age up to which the latest gossip we've
+	// received from a returning peer is considered recent enough for us
+	// not to request its entire channel graph again.
+	gossipSyncHorizon = time.Hour
+
+	// gossipTimestampFlushInterval is the interval at which the timestamps
+	// of the latest gossip received from all connected peers are
+	// persisted, so they survive an unclean shutdown.
+	gossipTimestampFlushInterval = 10 * time.Minute
 )
 
 // server is the main server of the Lightning Network Daemon. The server houses
@@ -93,16 +107,36 @@ type server struct {
 	// disconnected.
 	ignorePeerTermination map[*peer]struct{}
 
+	// peerFlaps tracks how often each peer went online or offline since
+	// the server started, keyed by the serialized public key of the peer.
+	// Unlike the peer itself, it outlives the connection, so unstable
+	// peers can be identified across reconnections.
+	peerFlaps map[string]*peerFlapInfo
+
 	cc *chainControl
 
 	fundingMgr *fundingManager
 
 	chanDB *channeldb.DB
 
+	// policyStore is the store which persists the fee policies of
+	// outgoing payments.
+	policyStore channeldb.PolicyStore
+
 	htlcSwitch *htlcswitch.Switch
 
 	invoices *invoiceRegistry
 
+	channelNotifier *channelNotifier
+
+	peerNotifier *peerNotifier
+
+	paymentNotifier *paymentNotifier
+
+	chanAcceptor *channelAcceptor
+
+	rpcMiddleware *rpcMiddlewareRegistry
+
 	witnessBeacon contractcourt.WitnessBeacon
 
 	breachArbiter *breachArbiter
@@ -113,6 +147,10 @@ type server struct {
 
 	utxoNursery *utxoNursery
 
+	policyGC *policyGarbageCollector
+
+	controlTower *controlTower
+
 	chainArb *contractcourt.ChainArbitrator
 
 	sphinx *htlcswitch.OnionProcessor
@@ -164,10 +202,16 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 	)
 
 	s := &server{
-		chanDB: chanDB,
-		cc:     cc,
+		chanDB:      chanDB,
+		policyStore: chanDB,
+		cc:          cc,
 
-		invoices: newInvoiceRegistry(chanDB),
+		invoices:        newInvoiceRegistry(chanDB),
+		channelNotifier: newChannelNotifier(),
+		peerNotifier:    newPeerNotifier(),
+		paymentNotifier: newPaymentNotifier(),
+		chanAcceptor:    newChannelAcceptor(),
+		rpcMiddleware:   newRPCMiddlewareRegistry(),
 
 		identityPriv: privKey,
 		nodeSigner:   newNodeSigner(privKey),
@@ -184,6 +228,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		ignorePeerTermination:  make(map[*peer]struct{}),
 
 		peersByPub:             make(map[string]*peer),
+		peerFlaps:              make(map[string]*peerFlapInfo),
 		inboundPeers:           make(map[string]*peer),
 		outboundPeers:          make(map[string]*peer),
 		peerConnectedListeners: make(map[string][]chan<- struct{}),
@@ -237,6 +282,8 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		FwdingLog:             chanDB.ForwardingLog(),
 		SwitchPackager:        channeldb.NewSwitchPackager(),
 		ExtractErrorEncrypter: s.sphinx.ExtractErrorEncrypter,
+		NotifyActiveChannel:   s.channelNotifier.NotifyActiveChannelEvent,
+		NotifyInactiveChannel: s.channelNotifier.NotifyInactiveChannelEvent,
 	})
 	if err != nil {
 		return nil, err
@@ -360,6 +407,7 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		TrickleDelay:     time.Millisecond * time.Duration(cfg.TrickleDelay),
 		RetransmitDelay:  time.Minute * 30,
 		DB:               chanDB,
+		RejectCacheSize:  cfg.RejectCacheSize,
 		AnnSigner:        s.nodeSigner,
 	},
 		s.identityPriv.PubKey(),
@@ -388,6 +436,22 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 		Store:              utxnStore,
 	})
 
+	s.policyGC = newPolicyGarbageCollector(&policyGCConfig{
+		DB:       chanDB,
+		ChainIO:  cc.chainIO,
+		Notifier: cc.chainNotifier,
+	})
+
+	s.controlTower, err = newControlTower(&controlTowerConfig{
+		DB:              chanDB,
+		PreimageCache:   s.witnessBeacon,
+		Notifier:        cc.chainNotifier,
+		PaymentResolved: s.paymentNotifier.NotifyResolved,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Construct a closure that wraps the htlcswitch's CloseLink method.
 	closeLink := func(chanPoint *wire.OutPoint,
 		closureType htlcswitch.ChannelCloseType) {
@@ -447,6 +511,8 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			_, err := cc.wallet.GetPrivKey(addr)
 			return err == nil
 		},
+		NotifyClosingChannel: s.channelNotifier.NotifyClosingChannelEvent,
+		NotifyClosedChannel:  s.channelNotifier.NotifyClosedChannelEvent,
 	}, chanDB)
 
 	s.breachArbiter = newBreachArbiter(&BreachConfig{
@@ -464,8 +530,9 @@ func newServer(listenAddrs []string, chanDB *channeldb.DB, cc *chainControl,
 			// state.
 			return s.chainArb.SubscribeChannelEvents(chanPoint, true)
 		},
-		Signer: cc.wallet.Cfg.Signer,
-		Store:  newRetributionStore(chanDB),
+		Signer:              cc.wallet.Cfg.Signer,
+		Store:               newRetributionStore(chanDB),
+		NotifyClosedChannel: s.channelNotifier.NotifyClosedChannelEvent,
 	})
 
 	// Create the connection manager which will be responsible for
@@ -513,12 +580,21 @@ func (s *server) Start() error {
 	if err := s.sphinx.Start(); err != nil {
 		return err
 	}
+	if err := s.invoices.Start(); err != nil {
+		return err
+	}
 	if err := s.htlcSwitch.Start(); err != nil {
 		return err
 	}
 	if err := s.utxoNursery.Start(); err != nil {
 		return err
 	}
+	if err := s.policyGC.Start(); err != nil {
+		return err
+	}
+	if err := s.controlTower.Start(); err != nil {
+		return err
+	}
 	if err := s.chainArb.Start(); err != nil {
 		return err
 	}
@@ -541,6 +617,9 @@ func (s *server) Start() error {
 
 	go s.connMgr.Start()
 
+	s.wg.Add(1)
+	go s.gossipTimestampFlusher()
+
 	// If network bootstrapping hasn't been disabled, then we'll configure
 	// the set of active bootstrappers, and launch a dedicated goroutine to
 	// maintain a set of persistent connections.
@@ -577,8 +656,14 @@ func (s *server) Stop() error {
 	s.cc.chainNotifier.Stop()
 	s.chanRouter.Stop()
 	s.htlcSwitch.Stop()
+	s.invoices.Stop()
+	s.channelNotifier.Stop()
+	s.peerNotifier.Stop()
+	s.paymentNotifier.Stop()
 	s.sphinx.Stop()
 	s.utxoNursery.Stop()
+	s.policyGC.Stop()
+	s.controlTower.Stop()
 	s.breachArbiter.Stop()
 	s.authGossiper.Stop()
 	s.chainArb.Stop()
@@ -884,6 +969,28 @@ func (s *server) establishPersistentConnections() error {
 		nodeAddrsMap[pubStr] = nodeAddrs
 	}
 
+	// We'll also attempt to reconnect to the peers we've recently
+	// connected to, even if we don't have a channel with them, so we keep
+	// receiving gossip from the same set of peers across restarts.
+	knownPeers, err := s.chanDB.FetchAllPeers()
+	if err != nil {
+		return err
+	}
+	for _, knownPeer := range knownPeers {
+		pubStr := string(knownPeer.IdentityPub.SerializeCompressed())
+		if _, ok := nodeAddrsMap[pubStr]; ok {
+			continue
+		}
+		if time.Since(knownPeer.LastConnected) > knownPeerReconnectWindow {
+			continue
+		}
+
+		nodeAddrsMap[pubStr] = &nodeAddresses{
+			pubKey:    knownPeer.IdentityPub,
+			addresses: knownPeer.Addresses,
+		}
+	}
+
 	// After checking our previous connections for addresses to connect to,
 	// iterate through the nodes in our channel graph to find addresses
 	// that have been added via NodeAnnouncement messages.
@@ -895,7 +1002,7 @@ func (s *server) establishPersistentConnections() error {
 	// TODO(roasbeef): instead iterate over link nodes and query graph for
 	// each of the nodes.
 	err = sourceNode.ForEachChannel(nil, func(
-		_ *bolt.Tx,
+		_ channeldb.ReadTx,
 		_ *channeldb.ChannelEdgeInfo,
 		policy, _ *channeldb.ChannelEdgePolicy) error {
 
@@ -1216,6 +1323,45 @@ func (s *server) findPeerByPubStr(pubStr string) (*peer, error) {
 	return peer, nil
 }
 
+// persistGossipTimestamp persists the timestamp of the latest gossip we've
+// received from the passed peer, if any.
+func (s *server) persistGossipTimestamp(p *peer) {
+	lastGossip := p.LastGossip()
+	if lastGossip.IsZero() {
+		return
+	}
+
+	err := s.chanDB.UpdatePeerGossipTimestamp(p.addr.IdentityKey, lastGossip)
+	if err != nil {
+		srvrLog.Errorf("unable to update gossip timestamp of peer %v: %v",
+			p, err)
+	}
+}
+
+// gossipTimestampFlusher periodically persists the timestamps of the latest
+// gossip received from all connected peers, which would otherwise only be
+// persisted once each peer disconnects.
+//
+// NOTE: This MUST be run as a goroutine.
+func (s *server) gossipTimestampFlusher() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(gossipTimestampFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, p := range s.Peers() {
+				s.persistGossipTimestamp(p)
+			}
+
+		case <-s.quit:
+			return
+		}
+	}
+}
+
 // peerTerminationWatcher waits until a peer has been disconnected unexpectedly,
 // and then cleans up all resources allocated to the peer, notifies relevant
 // sub-systems of its demise, and finally handles re-connecting to the peer if
@@ -1231,6 +1377,11 @@ func (s *server) peerTerminationWatcher(p *peer) {
 
 	srvrLog.Debugf("Peer %v has been disconnected", p)
 
+	// Persist the timestamp of the latest gossip we received from the
+	// peer, so we know how much gossip we already have from it once we
+	// reconnect.
+	s.persistGossipTimestamp(p)
+
 	// If the server is exiting then we can bail out early ourselves as all
 	// the other sub-systems will already be shutting down.
 	if s.Stopped() {
@@ -1348,12 +1499,30 @@ func (s *server) nextPeerBackoff(pubStr string) time.Duration {
 // shouldRequestGraphSync returns true if the servers deems it necessary that
 // we sync channel graph state with the remote peer. This method is used to
 // avoid _always_ syncing channel graph state with each peer that connects.
+// The passed timestamp is that of the latest gossip we've received from the
+// peer during prior connections, if any.
 //
 // NOTE: This MUST be called with the server's mutex held.
-func (s *server) shouldRequestGraphSync() bool {
+func (s *server) shouldRequestGraphSync(lastGossip time.Time) bool {
+	return needGraphSync(len(s.peersByPub), lastGossip, time.Now())
+}
+
+// needGraphSync returns true if we should request the entire channel graph
+// from a newly connected peer, given the number of peers we're connected to
+// and the timestamp of the latest gossip we've received from the peer during
+// prior connections, if any.
+func needGraphSync(numPeers int, lastGossip, now time.Time) bool {
 	// Initially, we'll only request a graph sync iff we have less than two
 	// peers.
-	return len(s.peersByPub) <= 2
+	if numPeers > 2 {
+		return false
+	}
+
+	// As the wire protocol doesn't allow us to only request gossip newer
+	// than what we already have, the graph is requested in its entirety,
+	// unless we've received gossip from a returning peer recently enough
+	// for the gossip we missed in between to be negligible.
+	return lastGossip.IsZero() || now.Sub(lastGossip) > gossipSyncHorizon
 }
 
 // peerConnected is a function that handles initialization a newly connected
@@ -1390,9 +1559,21 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 	// feature vector to advertise to the remote node.
 	localFeatures := lnwire.NewRawFeatureVector()
 
+	// If we've connected to this peer before, then we'll look up the
+	// timestamp of the latest gossip it sent us.
+	var lastGossip time.Time
+	knownPeer, err := s.chanDB.FetchPeer(pubKey)
+	switch {
+	case err == nil:
+		lastGossip = knownPeer.LastGossip
+	case err != channeldb.ErrPeerNotFound:
+		srvrLog.Errorf("unable to fetch peer %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+
 	// We'll only request a full channel graph sync if we detect that that
 	// we aren't fully synced yet.
-	if s.shouldRequestGraphSync() {
+	if s.shouldRequestGraphSync(lastGossip) {
 		localFeatures.Set(lnwire.InitialRoutingSync)
 	}
 
@@ -1414,6 +1595,14 @@ func (s *server) peerConnected(conn net.Conn, connReq *connmgr.ConnReq,
 		return
 	}
 
+	// Record the successful connection within the peer store, so we can
+	// reconnect to this peer after a restart.
+	err = s.chanDB.RecordPeerConnection(pubKey, addr, time.Now())
+	if err != nil {
+		srvrLog.Errorf("unable to record connection to peer %x: %v",
+			pubKey.SerializeCompressed(), err)
+	}
+
 	s.addPeer(p)
 }
 
@@ -1664,6 +1853,7 @@ func (s *server) addPeer(p *peer) {
 	pubStr := string(p.addr.IdentityKey.SerializeCompressed())
 
 	s.peersByPub[pubStr] = p
+	s.recordPeerFlap(pubStr)
 
 	if p.inbound {
 		s.inboundPeers[pubStr] = p
@@ -1671,6 +1861,10 @@ func (s *server) addPeer(p *peer) {
 		s.outboundPeers[pubStr] = p
 	}
 
+	var pubKey [33]byte
+	copy(pubKey[:], pubStr)
+	s.peerNotifier.NotifyPeerOnline(pubKey)
+
 	// Launch a goroutine to watch for the unexpected termination of this
 	// peer, which will ensure all resources are properly cleaned up, and
 	// re-establish persistent connections when necessary. The peer
@@ -1720,12 +1914,59 @@ func (s *server) removePeer(p *peer) {
 	pubStr := string(p.addr.IdentityKey.SerializeCompressed())
 
 	delete(s.peersByPub, pubStr)
+	s.recordPeerFlap(pubStr)
 
 	if p.inbound {
 		delete(s.inboundPeers, pubStr)
 	} else {
 		delete(s.outboundPeers, pubStr)
 	}
+
+	var pubKey [33]byte
+	copy(pubKey[:], pubStr)
+	s.peerNotifier.NotifyPeerOffline(pubKey)
+}
+
+// peerFlapInfo describes how stable the connection to a peer has been.
+type peerFlapInfo struct {
+	// count is the number of times the peer went online or offline.
+	count uint32
+
+	// lastFlap is the time at which the peer last went online or offline.
+	lastFlap time.Time
+}
+
+// recordPeerFlap records that the peer with the passed serialized public key
+// just went online or offline.
+//
+// NOTE: This MUST be called with the server's mutex held.
+func (s *server) recordPeerFlap(pubStr string) {
+	flaps, ok := s.peerFlaps[pubStr]
+	if !ok {
+		flaps = &peerFlapInfo{}
+		s.peerFlaps[pubStr] = flaps
+	}
+
+	flaps.count++
+	flaps.lastFlap = time.Now()
+}
+
+// PeerFlaps returns the number of times the peer with the passed public key
+// went online or offline since the server started, along with the time at
+// which it last did. The returned time is the zero time if the peer never
+// connected.
+//
+// NOTE: This function is safe for concurrent access.
+func (s *server) PeerFlaps(pub *btcec.PublicKey) (uint32, time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flaps, ok := s.peerFlaps[string(pub.SerializeCompressed())]
+	if !ok {
+		return 0, time.Time{}
+	}
+
+	return flaps.count, flaps.lastFlap
 }
 
 // openChanReq is a message sent to the server in order to request the
@@ -1749,6 +1990,11 @@ type openChanReq struct {
 
 	remoteCsvDelay uint16
 
+	// thawHeight is the block height until which we refuse to initiate a
+	// cooperative close of the channel. Zero indicates the channel isn't
+	// frozen.
+	thawHeight uint32
+
 	// TODO(roasbeef): add ability to specify channel constraints as well
 
 	updates chan *lnrpc.OpenStatusUpdate
@@ -1873,7 +2119,8 @@ func (s *server) DisconnectPeer(pubKey *btcec.PublicKey) error {
 func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 	localAmt btcutil.Amount, pushAmt, minHtlc lnwire.MilliSatoshi,
 	fundingFeePerVSize lnwallet.SatPerVByte, private bool,
-	remoteCsvDelay uint16) (chan *lnrpc.OpenStatusUpdate, chan error) {
+	remoteCsvDelay uint16,
+	thawHeight uint32) (chan *lnrpc.OpenStatusUpdate, chan error) {
 
 	updateChan := make(chan *lnrpc.OpenStatusUpdate, 1)
 	errChan := make(chan error, 1)
@@ -1928,6 +2175,7 @@ func (s *server) OpenChannel(nodeKey *btcec.PublicKey,
 		private:            private,
 		minHtlc:            minHtlc,
 		remoteCsvDelay:     remoteCsvDelay,
+		thawHeight:         thawHeight,
 		updates:            updateChan,
 		err:                errChan,
 	}
@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestParseHexColor(t *testing.T) {
 	empty := ""
@@ -32,3 +35,53 @@ func TestParseHexColor(t *testing.T) {
 		t.Fatalf("Color %s incorrectly parsed as %v", valid, color)
 	}
 }
+
+// TestNeedGraphSync tests that the entire channel graph is only requested
+// from a newly connected peer if we have few peers, and the peer isn't a
+// returning one which has sent us gossip recently.
+func TestNeedGraphSync(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000000, 0)
+
+	testCases := []struct {
+		name       string
+		numPeers   int
+		lastGossip time.Time
+		needSync   bool
+	}{
+		{
+			name:     "new peer",
+			numPeers: 1,
+			needSync: true,
+		},
+		{
+			name:     "new peer with many peers",
+			numPeers: 3,
+		},
+		{
+			name:       "returning peer with recent gossip",
+			numPeers:   1,
+			lastGossip: now.Add(-gossipSyncHorizon / 2),
+		},
+		{
+			name:       "returning peer with stale gossip",
+			numPeers:   1,
+			lastGossip: now.Add(-2 * gossipSyncHorizon),
+			needSync:   true,
+		},
+		{
+			name:       "returning peer with stale gossip and many peers",
+			numPeers:   3,
+			lastGossip: now.Add(-2 * gossipSyncHorizon),
+		},
+	}
+
+	for _, test := range testCases {
+		needSync := needGraphSync(test.numPeers, test.lastGossip, now)
+		if needSync != test.needSync {
+			t.Fatalf("%v: expected graph sync needed to be %v, "+
+				"got %v", test.name, test.needSync, needSync)
+		}
+	}
+}
@@ -329,8 +329,10 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 
 	chainArb := contractcourt.NewChainArbitrator(
 		contractcourt.ChainArbitratorConfig{
-			Notifier: notifier,
-			ChainIO:  chainIO,
+			Notifier:             notifier,
+			ChainIO:              chainIO,
+			NotifyClosingChannel: func(wire.OutPoint) {},
+			NotifyClosedChannel:  func(wire.OutPoint) {},
 		}, dbAlice,
 	)
 	chainArb.WatchNewChannel(aliceChannelState)
@@ -342,8 +344,10 @@ func createTestPeer(notifier chainntnfs.ChainNotifier,
 		chainArb:      chainArb,
 	}
 	htlcSwitch, err := htlcswitch.New(htlcswitch.Config{
-		DB:             dbAlice,
-		SwitchPackager: channeldb.NewSwitchPackager(),
+		DB:                    dbAlice,
+		SwitchPackager:        channeldb.NewSwitchPackager(),
+		NotifyActiveChannel:   func(wire.OutPoint) {},
+		NotifyInactiveChannel: func(wire.OutPoint) {},
 	})
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/btcsuite/websocket"
+)
+
+const (
+	// methodOverrideParam is the query parameter with which a websocket
+	// client specifies the HTTP method of the REST endpoint it connects
+	// to, as the websocket handshake itself is always a GET request.
+	methodOverrideParam = "method"
+
+	// wsProtocolMacaroonPrefix is the prefix of the websocket protocol
+	// through which browser clients, which are unable to set custom
+	// headers on the websocket handshake, pass their macaroon. The hex
+	// encoded macaroon follows the prefix.
+	wsProtocolMacaroonPrefix = "Grpc-Metadata-Macaroon+"
+
+	// wsMaxMessageSize is the maximum size of a single line of the REST
+	// response stream, and therefore of a single websocket message.
+	wsMaxMessageSize = 4 << 20
+)
+
+// websocketProxy wraps the REST proxy in order to make its streaming
+// endpoints available over websockets. Each message received from the
+// websocket client is sent as one line of the request body to the REST proxy,
+// and each line of the response stream of the REST proxy is sent as one
+// message to the client. Any request which isn't a websocket handshake is
+// handed to the REST proxy as is.
+type websocketProxy struct {
+	backend http.Handler
+}
+
+// newWebsocketProxy creates a new websocket proxy in front of the passed REST
+// proxy.
+func newWebsocketProxy(backend http.Handler) *websocketProxy {
+	return &websocketProxy{backend: backend}
+}
+
+// ServeHTTP handles an incoming REST request, upgrading it to a websocket if
+// it's a websocket handshake.
+//
+// NOTE: This is part of the http.Handler interface.
+func (p *websocketProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		p.backend.ServeHTTP(w, r)
+		return
+	}
+
+	p.upgradeToWebsocket(w, r)
+}
+
+// upgradeToWebsocket upgrades the passed request to a websocket, and proxies
+// the messages sent over it to and from the REST proxy until either side
+// closes the stream.
+func (p *websocketProxy) upgradeToWebsocket(w http.ResponseWriter,
+	r *http.Request) {
+
+	// Browsers are unable to set custom headers on the handshake, so we'll
+	// accept the macaroon within the requested protocols as well, and
+	// echo back the protocol to complete the handshake.
+	var (
+		macaroon       string
+		responseHeader http.Header
+	)
+	protocols := strings.Split(r.Header.Get("Sec-Websocket-Protocol"), ",")
+	for _, protocol := range protocols {
+		protocol = strings.TrimSpace(protocol)
+		if !strings.HasPrefix(protocol, wsProtocolMacaroonPrefix) {
+			continue
+		}
+
+		macaroon = strings.TrimPrefix(protocol, wsProtocolMacaroonPrefix)
+		responseHeader = http.Header{
+			"Sec-Websocket-Protocol": []string{protocol},
+		}
+		break
+	}
+
+	upgrader := websocket.Upgrader{
+		CheckOrigin: checkWebsocketOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, responseHeader)
+	if err != nil {
+		if _, ok := err.(websocket.HandshakeError); !ok {
+			rpcsLog.Errorf("Unable to upgrade REST request to "+
+				"websocket: %v", err)
+		}
+		return
+	}
+	defer conn.Close()
+
+	// The request handed to the REST proxy reads its body from the
+	// messages sent by the client, and carries over the headers of the
+	// handshake, except for those specific to the websocket.
+	requestBodyR, requestBodyW := io.Pipe()
+	request, err := http.NewRequest(r.Method, r.URL.String(), requestBodyR)
+	if err != nil {
+		rpcsLog.Errorf("Unable to create REST request: %v", err)
+		return
+	}
+	for header, values := range r.Header {
+		if strings.HasPrefix(header, "Sec-Websocket-") ||
+			header == "Upgrade" || header == "Connection" {
+
+			continue
+		}
+		request.Header[header] = values
+	}
+	if macaroon != "" {
+		request.Header.Set("Grpc-Metadata-Macaroon", macaroon)
+	}
+	if method := r.URL.Query().Get(methodOverrideParam); method != "" {
+		request.Method = strings.ToUpper(method)
+	}
+
+	// The response stream of the REST proxy is written to a pipe, from
+	// which we'll read it line by line.
+	responseR, responseW := io.Pipe()
+	response := newPipeResponseWriter(responseW)
+	go func() {
+		defer responseW.Close()
+
+		p.backend.ServeHTTP(response, request)
+	}()
+
+	// Each message received from the client is written to the request
+	// body as a separate line. Once the client closes the websocket, we
+	// close the request body and notify the REST proxy, which cancels the
+	// request.
+	go func() {
+		defer close(response.closed)
+		defer requestBodyW.Close()
+
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			if _, err := requestBodyW.Write(msg); err != nil {
+				return
+			}
+			if _, err := requestBodyW.Write([]byte("\n")); err != nil {
+				return
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(responseR)
+	scanner.Buffer(make([]byte, 0, 64*1024), wsMaxMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		err := conn.WriteMessage(websocket.TextMessage, line)
+		if err != nil {
+			rpcsLog.Debugf("Unable to write websocket message: %v",
+				err)
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		rpcsLog.Errorf("Unable to read REST response stream: %v", err)
+	}
+
+	// Closing the read end of the response pipe unblocks the REST proxy,
+	// in case we stopped reading before it finished writing.
+	responseR.Close()
+}
+
+// checkWebsocketOrigin returns true if the passed websocket handshake either
+// carries no Origin header, as is the case for clients other than browsers,
+// or originates from a page served by the same host. Browsers don't restrict
+// websocket connections to other origins, so without this check any web page
+// the user visits could connect to the REST proxy on their behalf.
+func checkWebsocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(originURL.Host, r.Host)
+}
+
+// pipeResponseWriter is an http.ResponseWriter which writes the response body
+// to a pipe, so the streamed response of the REST proxy can be read
+// incrementally. The status code and headers are discarded, as any error is
+// also written to the body.
+type pipeResponseWriter struct {
+	header http.Header
+	w      *io.PipeWriter
+
+	// closed is closed once the websocket client went away.
+	closed chan bool
+}
+
+// A compile time check to ensure pipeResponseWriter implements the
+// http.Flusher and http.CloseNotifier interfaces, which the REST proxy uses
+// to flush each message of a response stream, and to cancel the request once
+// the client went away.
+var _ http.Flusher = (*pipeResponseWriter)(nil)
+var _ http.CloseNotifier = (*pipeResponseWriter)(nil)
+
+// newPipeResponseWriter creates a new response writer writing to the passed
+// pipe.
+func newPipeResponseWriter(w *io.PipeWriter) *pipeResponseWriter {
+	return &pipeResponseWriter{
+		header: make(http.Header),
+		w:      w,
+		closed: make(chan bool),
+	}
+}
+
+// Header returns the headers of the response.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (p *pipeResponseWriter) Header() http.Header {
+	return p.header
+}
+
+// Write writes the passed part of the response body to the pipe.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (p *pipeResponseWriter) Write(b []byte) (int, error) {
+	return p.w.Write(b)
+}
+
+// WriteHeader discards the status code of the response.
+//
+// NOTE: This is part of the http.ResponseWriter interface.
+func (p *pipeResponseWriter) WriteHeader(int) {}
+
+// Flush is a no-op, as every write is handed to the reader of the pipe
+// immediately.
+//
+// NOTE: This is part of the http.Flusher interface.
+func (p *pipeResponseWriter) Flush() {}
+
+// CloseNotify returns a channel which is closed once the websocket client went
+// away.
+//
+// NOTE: This is part of the http.CloseNotifier interface.
+func (p *pipeResponseWriter) CloseNotify() <-chan bool {
+	return p.closed
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/websocket"
+)
+
+// TestWebsocketProxy tests that a websocket handshake is upgraded and proxied
+// to the REST backend with the overridden method and the macaroon passed
+// within the protocol, and that handshakes from other origins are refused.
+func TestWebsocketProxy(t *testing.T) {
+	t.Parallel()
+
+	// The backend first reports the method and macaroon of the request,
+	// and then echoes each line of the request body.
+	backend := http.HandlerFunc(func(w http.ResponseWriter,
+		r *http.Request) {
+
+		fmt.Fprintf(w, "%v %v\n", r.Method,
+			r.Header.Get("Grpc-Metadata-Macaroon"))
+		w.(http.Flusher).Flush()
+
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			fmt.Fprintf(w, "%s\n", scanner.Bytes())
+			w.(http.Flusher).Flush()
+		}
+	})
+	server := httptest.NewServer(newWebsocketProxy(backend))
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	wsURL := "ws://" + host + "/v1/channels/stream?method=post"
+
+	// A handshake from a page served by another host should be refused.
+	header := http.Header{}
+	header.Set("Origin", "http://evil.example.com")
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err == nil {
+		t.Fatalf("expected handshake from other origin to be refused")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected forbidden status, got %v", resp)
+	}
+
+	// A handshake from the same host, passing the macaroon within the
+	// protocol, should be upgraded and echo back the protocol.
+	protocol := wsProtocolMacaroonPrefix + "0201"
+	header = http.Header{}
+	header.Set("Origin", "http://"+host)
+	header.Set("Sec-Websocket-Protocol", protocol)
+	conn, resp, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("unable to connect websocket: %v", err)
+	}
+	defer conn.Close()
+
+	if resp.Header.Get("Sec-Websocket-Protocol") != protocol {
+		t.Fatalf("expected protocol %v to be echoed, got %v", protocol,
+			resp.Header.Get("Sec-Websocket-Protocol"))
+	}
+
+	readMessage := func() string {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("unable to read message: %v", err)
+		}
+		return string(msg)
+	}
+
+	if msg := readMessage(); msg != "POST 0201" {
+		t.Fatalf("expected overridden method and macaroon, got %q",
+			msg)
+	}
+
+	// Each message sent by the client should reach the backend as a line
+	// of the request body.
+	for _, sent := range []string{`{"a":1}`, `{"b":2}`} {
+		err := conn.WriteMessage(websocket.TextMessage, []byte(sent))
+		if err != nil {
+			t.Fatalf("unable to write message: %v", err)
+		}
+		if msg := readMessage(); msg != sent {
+			t.Fatalf("expected echo %q, got %q", sent, msg)
+		}
+	}
+}
+
+// TestCheckWebsocketOrigin tests that only handshakes without an Origin header
+// or from the same host are accepted.
+func TestCheckWebsocketOrigin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		origin   string
+		accepted bool
+	}{
+		{origin: "", accepted: true},
+		{origin: "https://localhost:8080", accepted: true},
+		{origin: "https://LOCALHOST:8080", accepted: true},
+		{origin: "https://localhost:8081", accepted: false},
+		{origin: "https://localhost.evil.com:8080", accepted: false},
+		{origin: "null", accepted: false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "https://localhost:8080/", nil)
+		if test.origin != "" {
+			r.Header.Set("Origin", test.origin)
+		}
+
+		if checkWebsocketOrigin(r) != test.accepted {
+			t.Fatalf("expected origin %q accepted: %v",
+				test.origin, test.accepted)
+		}
+	}
+}
@@ -80,6 +80,10 @@ func (p *preimageBeacon) LookupPreimage(payHash []byte) ([]byte, bool) {
 		// wasn't found, so we don't treat it as a critical error.
 	case err != nil:
 		return nil, false
+
+	// The preimage of a hold invoice isn't known until it's settled.
+	case invoice.Terms.Hold && !invoice.Terms.Settled:
+		return nil, false
 	}
 
 	// If we've found the invoice, then we can return the preimage
@@ -88,11 +92,15 @@ func (p *preimageBeacon) LookupPreimage(payHash []byte) ([]byte, bool) {
 		return invoice.Terms.PaymentPreimage[:], true
 	}
 
-	// Otherwise, we'll perform a final check using the witness cache.
+	// Otherwise, we'll perform a final check using the witness cache. Not
+	// finding the preimage there simply means we haven't learned it yet.
 	preimage, err := p.wCache.LookupWitness(
 		channeldb.Sha256HashWitness, payHash,
 	)
-	if err != nil {
+	switch {
+	case err == channeldb.ErrNoWitnesses:
+		return nil, false
+	case err != nil:
 		ltndLog.Errorf("unable to lookup witness: %v", err)
 		return nil, false
 	}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+	"time"
+)
+
+// TestPreimageBeaconSubscribeBeforeLookup tests that a preimage added after a
+// client subscribed to new preimages, but before it looked up the preimage,
+// isn't missed by the client, as it's both delivered to the subscription and
+// returned by the lookup.
+func TestPreimageBeaconSubscribeBeforeLookup(t *testing.T) {
+	t.Parallel()
+
+	db, cleanUp, err := makeTestDB()
+	defer cleanUp()
+	if err != nil {
+		t.Fatalf("unable to create db: %v", err)
+	}
+
+	beacon := &preimageBeacon{
+		invoices:    newInvoiceRegistry(db),
+		wCache:      db.NewWitnessCache(),
+		subscribers: make(map[uint64]*preimageSubscriber),
+	}
+
+	preimage := bytes.Repeat([]byte{1}, 32)
+	payHash := sha256.Sum256(preimage)
+
+	// The preimage is learned after the subscription was created...
+	sub := beacon.SubscribeUpdates()
+	defer sub.CancelSubscription()
+
+	if err := beacon.AddPreimage(preimage); err != nil {
+		t.Fatalf("unable to add preimage: %v", err)
+	}
+
+	// ...but before the lookup, which should therefore find it.
+	lookedUp, ok := beacon.LookupPreimage(payHash[:])
+	if !ok {
+		t.Fatalf("preimage added before lookup not found")
+	}
+	if !bytes.Equal(lookedUp, preimage) {
+		t.Fatalf("expected preimage %x, got %x", preimage, lookedUp)
+	}
+
+	// The subscription should have been notified of it as well.
+	select {
+	case update := <-sub.WitnessUpdates:
+		if !bytes.Equal(update, preimage) {
+			t.Fatalf("expected preimage %x, got %x", preimage,
+				update)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("preimage not delivered to subscription")
+	}
+
+	// A preimage added concurrently with the lookup must be found by
+	// either the lookup or the subscription.
+	for i := byte(2); i < 50; i++ {
+		preimage := bytes.Repeat([]byte{i}, 32)
+		payHash := sha256.Sum256(preimage)
+
+		sub := beacon.SubscribeUpdates()
+
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- beacon.AddPreimage(preimage)
+		}()
+
+		if _, ok := beacon.LookupPreimage(payHash[:]); !ok {
+			found := false
+			for !found {
+				select {
+				case update := <-sub.WitnessUpdates:
+					found = bytes.Equal(update, preimage)
+				case <-time.After(5 * time.Second):
+					t.Fatalf("preimage %x missed", preimage)
+				}
+			}
+		}
+
+		if err := <-errChan; err != nil {
+			t.Fatalf("unable to add preimage: %v", err)
+		}
+		sub.CancelSubscription()
+	}
+}